@@ -2788,10 +2788,10 @@ def go_deps():
         name = "com_github_eclipse_paho_mqtt_golang",
         build_file_proto_mode = "disable_global",
         importpath = "github.com/eclipse/paho.mqtt.golang",
-        sha256 = "d36337c4b5a2752b91bcd437bd74e0907bf6c9e6c611dab88407bcca8462e918",
-        strip_prefix = "github.com/eclipse/paho.mqtt.golang@v1.2.0",
+        sha256 = "899db1e613fa4c915bc968c42ddd23c2991b16172a53fa83bbdf5b33806726ad",
+        strip_prefix = "github.com/eclipse/paho.mqtt.golang@v1.4.2",
         urls = [
-            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/eclipse/paho.mqtt.golang/com_github_eclipse_paho_mqtt_golang-v1.2.0.zip",
+            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/eclipse/paho.mqtt.golang/com_github_eclipse_paho_mqtt_golang-v1.4.2.zip",
         ],
     )
     go_repository(
@@ -6932,10 +6932,10 @@ def go_deps():
         name = "com_github_nats_io_nats_go",
         build_file_proto_mode = "disable_global",
         importpath = "github.com/nats-io/nats.go",
-        sha256 = "34a735d158d70685faad1fc3153f08da0ddc21c0ae42f6a0cb09430d638364b2",
-        strip_prefix = "github.com/nats-io/nats.go@v1.9.1",
+        sha256 = "3cb91adc6c85c2eb2cd55775bc9a857b74ec203cf52c78ff2a60f50a4593a907",
+        strip_prefix = "github.com/nats-io/nats.go@v1.37.0",
         urls = [
-            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nats.go/com_github_nats_io_nats_go-v1.9.1.zip",
+            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nats.go/com_github_nats_io_nats_go-v1.37.0.zip",
         ],
     )
     go_repository(
@@ -6952,10 +6952,10 @@ def go_deps():
         name = "com_github_nats_io_nkeys",
         build_file_proto_mode = "disable_global",
         importpath = "github.com/nats-io/nkeys",
-        sha256 = "291930a7abcd84edcaffc2cadc75aeb830ebf561144313ead6b8e1fcc03b124f",
-        strip_prefix = "github.com/nats-io/nkeys@v0.1.3",
+        sha256 = "b5ea0fc3e87853935f2903cd8222f6ad92944625b795ba3bf8c99c2cfc499b5b",
+        strip_prefix = "github.com/nats-io/nkeys@v0.4.7",
         urls = [
-            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nkeys/com_github_nats_io_nkeys-v0.1.3.zip",
+            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nkeys/com_github_nats_io_nkeys-v0.4.7.zip",
         ],
     )
     go_repository(
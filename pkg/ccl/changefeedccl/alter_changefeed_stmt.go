@@ -135,6 +135,7 @@ func alterChangefeedPlanHook(
 		exprEval := p.ExprEvaluator("ALTER CHANGEFEED")
 		newOptions, newSinkURI, err := generateNewOpts(
 			ctx, exprEval, alterChangefeedStmt.Cmds, prevOpts, prevDetails.SinkURI,
+			prevDetails.Opts[changefeedbase.OptDLQTable],
 		)
 		if err != nil {
 			return err
@@ -294,10 +295,12 @@ func generateNewOpts(
 	alterCmds tree.AlterChangefeedCmds,
 	prevOpts map[string]string,
 	prevSinkURI string,
+	prevDLQTable string,
 ) (changefeedbase.StatementOptions, string, error) {
 	sinkURI := prevSinkURI
 	newOptions := prevOpts
 	null := changefeedbase.StatementOptions{}
+	var alteredDLQTable bool
 
 	for _, cmd := range alterCmds {
 		switch v := cmd.(type) {
@@ -338,6 +341,9 @@ func generateNewOpts(
 				} else {
 					newOptions[key] = value
 				}
+				if key == changefeedbase.OptDLQTable {
+					alteredDLQTable = true
+				}
 			}
 			telemetry.CountBucketed(telemetryPath+`.set_options`, int64(len(opts)))
 		case *tree.AlterChangefeedUnsetOptions:
@@ -353,11 +359,31 @@ func generateNewOpts(
 					return null, ``, pgerror.Newf(pgcode.InvalidParameterValue, `cannot alter option %q`, key)
 				}
 				delete(newOptions, key)
+				if key == changefeedbase.OptDLQTable {
+					alteredDLQTable = true
+				}
 			}
 			telemetry.CountBucketed(telemetryPath+`.unset_options`, int64(len(optKeys)))
 		}
 	}
 
+	// The dead letter queue table is resolved and stored in the details when
+	// on_error is set to dlq, but it is only part of the job description, from
+	// which prevOpts are read, if it was specified. Keep using the resolved
+	// table while on_error remains dlq, rather than resolving it again against
+	// the current database of this session, and clear it once on_error
+	// changes, unless this statement alters it, in which case the options fail
+	// validation.
+	if !alteredDLQTable {
+		if newOptions[changefeedbase.OptOnError] == string(changefeedbase.OptOnErrorDLQ) {
+			if prevDLQTable != "" {
+				newOptions[changefeedbase.OptDLQTable] = prevDLQTable
+			}
+		} else {
+			delete(newOptions, changefeedbase.OptDLQTable)
+		}
+	}
+
 	return changefeedbase.MakeStatementOptions(newOptions), sinkURI, nil
 }
 
@@ -447,6 +447,52 @@ func TestAlterChangefeedSetDiffOption(t *testing.T) {
 	cdcTest(t, testFn, feedTestEnterpriseSinks, feedTestNoExternalConnection)
 }
 
+// TestAlterChangefeedDebeziumForcesDiff verifies that ALTER CHANGEFEED
+// normalizes the options implied by the debezium envelope like CREATE
+// CHANGEFEED does.
+func TestAlterChangefeedDebeziumForcesDiff(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+
+		testFeed := feed(t, f, `CREATE CHANGEFEED FOR foo WITH format='json'`)
+		defer closeFeed(t, testFeed)
+
+		feed, ok := testFeed.(cdctest.EnterpriseTestFeed)
+		require.True(t, ok)
+
+		sqlDB.Exec(t, `PAUSE JOB $1`, feed.JobID())
+		waitForJobStatus(sqlDB, t, feed.JobID(), `paused`)
+
+		registry := s.Server.JobRegistry().(*jobs.Registry)
+		requireDiff := func(expected bool) {
+			t.Helper()
+			job, err := registry.LoadJob(context.Background(), feed.JobID())
+			require.NoError(t, err)
+			details, ok := job.Details().(jobspb.ChangefeedDetails)
+			require.True(t, ok)
+			_, diff := details.Opts[changefeedbase.OptDiff]
+			require.Equal(t, expected, diff)
+		}
+
+		sqlDB.Exec(t, fmt.Sprintf(`ALTER CHANGEFEED %d SET envelope='debezium'`, feed.JobID()))
+		requireDiff(true)
+
+		// The diff option can't be turned off while the envelope is debezium.
+		sqlDB.Exec(t, fmt.Sprintf(`ALTER CHANGEFEED %d UNSET diff`, feed.JobID()))
+		requireDiff(true)
+
+		// Since the diff option was implied by the envelope, it goes away with it.
+		sqlDB.Exec(t, fmt.Sprintf(`ALTER CHANGEFEED %d SET envelope='wrapped'`, feed.JobID()))
+		requireDiff(false)
+	}
+
+	cdcTest(t, testFn, feedTestForceSink("kafka"), feedTestNoExternalConnection)
+}
+
 func TestAlterChangefeedRespectsCDCQuery(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -521,6 +567,79 @@ func TestAlterChangefeedUnsetDiffOption(t *testing.T) {
 	cdcTest(t, testFn, feedTestForceSink("kafka"), feedTestNoExternalConnection)
 }
 
+// TestAlterChangefeedOnErrorDLQ verifies that altering on_error keeps the
+// dead letter queue table, which was resolved when the changefeed was
+// created, in sync with it.
+func TestAlterChangefeedOnErrorDLQ(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+
+		testFeed := feed(t, f, `CREATE CHANGEFEED FOR foo WITH on_error='dlq'`)
+		defer closeFeed(t, testFeed)
+
+		feed, ok := testFeed.(cdctest.EnterpriseTestFeed)
+		require.True(t, ok)
+		jobID := feed.JobID()
+		defaultDLQTable := fmt.Sprintf(`d.crdb_changefeed.dlq_%d`, jobID)
+
+		sqlDB.Exec(t, `PAUSE JOB $1`, jobID)
+		waitForJobStatus(sqlDB, t, jobID, `paused`)
+
+		expectDLQTable := func(t *testing.T, expected string) {
+			t.Helper()
+			var dlqTable gosql.NullString
+			sqlDB.QueryRow(t,
+				`SELECT dlq_table FROM [SHOW CHANGEFEED JOB $1]`, jobID,
+			).Scan(&dlqTable)
+			require.Equal(t, expected, dlqTable.String)
+		}
+		expectDLQTable(t, defaultDLQTable)
+
+		// Altering the changefeed from another database does not move its dead
+		// letter queue table.
+		otherDB := sqlutils.MakeSQLRunner(s.Server.SQLConn(t, serverutils.DBName("defaultdb")))
+		otherDB.Exec(t, fmt.Sprintf(`ALTER CHANGEFEED %d SET diff`, jobID))
+		expectDLQTable(t, defaultDLQTable)
+
+		// The table which was set automatically is cleared along with on_error.
+		sqlDB.Exec(t, fmt.Sprintf(`ALTER CHANGEFEED %d UNSET on_error`, jobID))
+		expectDLQTable(t, ``)
+
+		sqlDB.Exec(t, fmt.Sprintf(`ALTER CHANGEFEED %d SET on_error='dlq'`, jobID))
+		expectDLQTable(t, defaultDLQTable)
+
+		sqlDB.Exec(t, fmt.Sprintf(`ALTER CHANGEFEED %d SET on_error='pause'`, jobID))
+		expectDLQTable(t, ``)
+
+		sqlDB.Exec(t, fmt.Sprintf(
+			`ALTER CHANGEFEED %d SET on_error='dlq', dlq_table='foo_dlq'`, jobID))
+		expectDLQTable(t, `d.public.foo_dlq`)
+		otherDB.Exec(t, fmt.Sprintf(`ALTER CHANGEFEED %d UNSET diff`, jobID))
+		expectDLQTable(t, `d.public.foo_dlq`)
+
+		// A table which is set along with another on_error is still rejected.
+		sqlDB.ExpectErr(t, `dlq_table requires on_error='dlq'`, fmt.Sprintf(
+			`ALTER CHANGEFEED %d SET on_error='fail', dlq_table='foo_dlq'`, jobID))
+
+		sqlDB.Exec(t, fmt.Sprintf(`ALTER CHANGEFEED %d UNSET on_error`, jobID))
+		expectDLQTable(t, ``)
+
+		sqlDB.Exec(t, `RESUME JOB $1`, jobID)
+		waitForJobStatus(sqlDB, t, jobID, `running`)
+
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (0, 'initial')`)
+		assertPayloads(t, testFeed, []string{
+			`foo: [0]->{"after": {"a": 0, "b": "initial"}}`,
+		})
+	}
+
+	cdcTest(t, testFn, feedTestForceSink("kafka"), feedTestNoExternalConnection)
+}
+
 func TestAlterChangefeedErrors(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 	"github.com/linkedin/goavro/v2"
 )
 
@@ -135,6 +136,26 @@ type avroSchemaField struct {
 	// "union key" value. nativeEncodedSecondaryType supports unions of two types (plus null).
 	nativeEncoded              map[string]interface{}
 	nativeEncodedSecondaryType map[string]interface{}
+
+	// required is set for the fields of metadata records which are never null,
+	// such as the source of the debezium envelope. Their SchemaType is not a
+	// union with null, and they have no default.
+	required bool
+}
+
+// MarshalJSON implements the json.Marshaler interface. It omits the default of
+// required fields, since null is not a valid default for them.
+func (f *avroSchemaField) MarshalJSON() ([]byte, error) {
+	// field has the same JSON representation as avroSchemaField, without its
+	// MarshalJSON method.
+	type field avroSchemaField
+	if !f.required {
+		return json.Marshal((*field)(f))
+	}
+	return json.Marshal(struct {
+		*field
+		Default *string `json:"default,omitempty"`
+	}{field: (*field)(f)})
 }
 
 // avroRecord is our representation of the schema of an avro record. Serializing
@@ -167,6 +188,9 @@ type avroEnvelopeOpts struct {
 	beforeField, afterField, recordField bool
 	updatedField, resolvedField          bool
 	mvccTimestampField                   bool
+	// debeziumFields adds the source, op and ts_ms fields of the debezium
+	// envelope.
+	debeziumFields bool
 }
 
 // avroEnvelopeRecord is an `avroRecord` that wraps a changed SQL row and some
@@ -585,9 +609,12 @@ func typeToAvroSchema(typ *types.T) (*avroSchemaField, error) {
 		setNullable(
 			avroSchemaString,
 			func(d tree.Datum, _ interface{}) (interface{}, error) {
-				return d.(*tree.DIPAddr).IPAddr.String(), nil
+				return tree.MustBeDIPAddr(d).IPAddr.String(), nil
 			},
 			func(x interface{}) (tree.Datum, error) {
+				if typ.Oid() == oid.T_cidr {
+					return tree.ParseDCIDR(x.(string))
+				}
 				return tree.ParseDIPAddrFromINetString(x.(string))
 			},
 		)
@@ -829,7 +856,8 @@ func (r *avroDataRecord) BinaryFromRow(buf []byte, it cdcevent.Iterator) ([]byte
 	if err != nil {
 		return nil, err
 	}
-	return r.codec.BinaryFromNative(buf, native)
+	b, err := r.codec.BinaryFromNative(buf, native)
+	return b, changefeedbase.MarkPermanentEventError(err)
 }
 
 // rowFromTextual decodes the given row data from avro's defined JSON format.
@@ -878,8 +906,10 @@ func (r *avroDataRecord) nativeFromRow(it cdcevent.Iterator) (interface{}, error
 			return changefeedbase.WithTerminalError(
 				errors.AssertionFailedf("could not find avro field for column %s", col.Name))
 		}
+		// A datum which can't be encoded, such as a decimal which exceeds the
+		// precision of the schema, will never be encodable.
 		r.native[col.Name], err = r.Fields[fieldIdx].encodeFn(d)
-		return err
+		return changefeedbase.MarkPermanentEventError(err)
 	}); err != nil {
 		return nil, err
 	}
@@ -944,6 +974,26 @@ func envelopeToAvroSchema(
 		}
 		schema.Fields = append(schema.Fields, afterField)
 	}
+	if opts.debeziumFields {
+		// As in the schemas of the Debezium connectors, the source and op
+		// fields are required, while ts_ms is optional.
+		sourceField := &avroSchemaField{
+			Name:       `source`,
+			SchemaType: debeziumSourceToAvroSchema(namespace),
+			required:   true,
+		}
+		opField := &avroSchemaField{
+			Name:       `op`,
+			SchemaType: avroSchemaString,
+			required:   true,
+		}
+		tsField := &avroSchemaField{
+			Name:       `ts_ms`,
+			SchemaType: []avroSchemaType{avroSchemaNull, avroSchemaLong},
+			Default:    nil,
+		}
+		schema.Fields = append(schema.Fields, sourceField, opField, tsField)
+	}
 	if opts.updatedField {
 		updatedField := &avroSchemaField{
 			SchemaType: []avroSchemaType{avroSchemaNull, avroSchemaString},
@@ -1031,6 +1081,30 @@ func (r *avroEnvelopeRecord) BinaryFromRow(
 		}
 	}
 
+	if r.opts.debeziumFields {
+		source, ok := meta[`source`].(map[string]interface{})
+		if !ok {
+			return nil, changefeedbase.WithTerminalError(
+				errors.Errorf(`unknown metadata source type: %T`, meta[`source`]))
+		}
+		op, ok := meta[`op`].(string)
+		if !ok {
+			return nil, changefeedbase.WithTerminalError(
+				errors.Errorf(`unknown metadata op type: %T`, meta[`op`]))
+		}
+		tsMillis, ok := meta[`ts_ms`].(int64)
+		if !ok {
+			return nil, changefeedbase.WithTerminalError(
+				errors.Errorf(`unknown metadata ts_ms type: %T`, meta[`ts_ms`]))
+		}
+		delete(meta, `source`)
+		delete(meta, `op`)
+		delete(meta, `ts_ms`)
+		native[`source`] = source
+		native[`op`] = op
+		native[`ts_ms`] = goavro.Union(avroUnionKey(avroSchemaLong), tsMillis)
+	}
+
 	if r.opts.updatedField {
 		native[`updated`] = nil
 		if u, ok := meta[`updated`]; ok {
@@ -1072,7 +1146,8 @@ func (r *avroEnvelopeRecord) BinaryFromRow(
 	for k := range meta {
 		return nil, changefeedbase.WithTerminalError(errors.AssertionFailedf(`unhandled meta key: %s`, k))
 	}
-	return r.codec.BinaryFromNative(buf, native)
+	b, err := r.codec.BinaryFromNative(buf, native)
+	return b, changefeedbase.MarkPermanentEventError(err)
 }
 
 // Refresh the metadata for user-defined types on a cached schema
@@ -320,6 +320,19 @@ func TestAvroSchema(t *testing.T) {
 		case types.PGVectorFamily:
 			// We don't support PGVector in Avro yet.
 			return true
+		case types.PointFamily, types.BoxFamily, types.LSegFamily, types.LineFamily,
+			types.PathFamily, types.PolygonFamily, types.CircleFamily:
+			// We don't support the geometric types in Avro yet.
+			return true
+		case types.MACAddrFamily, types.MACAddr8Family, types.MoneyFamily:
+			// We don't support the MAC address and money types in Avro yet.
+			return true
+		case types.RangeFamily, types.MultirangeFamily:
+			// We don't support the range and multirange types in Avro yet.
+			return true
+		case types.JsonpathFamily:
+			// We don't support jsonpath in Avro yet.
+			return true
 		case types.ArrayFamily:
 			if !randgen.IsAllowedForArray(typ.ArrayContents()) {
 				return true
@@ -12,7 +12,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
@@ -83,6 +85,10 @@ type batchingSink struct {
 	pacer        *admission.Pacer
 	pacerFactory func() *admission.Pacer
 
+	// dlq, if set, receives messages which fail permanently. See
+	// deadLetterQueueSink.
+	dlq sinkDLQFunc
+
 	termErr error
 	wg      ctxgroup.Group
 	hasher  hash.Hash32
@@ -101,6 +107,8 @@ type flushReq struct {
 // but separate from the encoded keys and values.
 type attributes struct {
 	tableName string
+	// mvcc is the MVCC timestamp of the row.
+	mvcc hlc.Timestamp
 }
 
 type rowEvent struct {
@@ -156,6 +164,59 @@ func (s *batchingSink) Topics() []string {
 
 var _ SinkWithTopics = (*batchingSink)(nil)
 
+var _ deadLetterQueueSink = (*batchingSink)(nil)
+
+// setDeadLetterQueue implements the deadLetterQueueSink interface. It must be
+// called before the first EmitRow.
+func (s *batchingSink) setDeadLetterQueue(dlq sinkDLQFunc) {
+	s.dlq = dlq
+}
+
+var _ transactionalSink = (*batchingSink)(nil)
+
+// initTransactions implements the transactionalSink interface.
+func (s *batchingSink) initTransactions(
+	ctx context.Context, jobID jobspb.JobID, aggregator int32,
+) error {
+	ts, ok := s.client.(transactionalSink)
+	if !ok {
+		return errSinkNotTransactional
+	}
+	return ts.initTransactions(ctx, jobID, aggregator)
+}
+
+// prepareTransaction implements the transactionalSink interface. The sink
+// must have been flushed.
+func (s *batchingSink) prepareTransaction(
+	ctx context.Context,
+) (jobspb.PreparedSinkTransaction, bool, error) {
+	ts, ok := s.client.(transactionalSink)
+	if !ok {
+		return jobspb.PreparedSinkTransaction{}, false, errSinkNotTransactional
+	}
+	return ts.prepareTransaction(ctx)
+}
+
+// commitTransaction implements the transactionalSink interface.
+func (s *batchingSink) commitTransaction(ctx context.Context) error {
+	ts, ok := s.client.(transactionalSink)
+	if !ok {
+		return errSinkNotTransactional
+	}
+	return ts.commitTransaction(ctx)
+}
+
+// recoverTransactions implements the transactionalSink interface.
+func (s *batchingSink) recoverTransactions(
+	ctx context.Context, jobID jobspb.JobID, progress jobspb.ChangefeedProgress,
+) error {
+	ts, ok := s.client.(transactionalSink)
+	if !ok {
+		return errSinkNotTransactional
+	}
+	return ts.recoverTransactions(ctx, jobID, progress)
+}
+
 // Event structs and batch structs which are transferred across routines (and
 // therefore escape to the heap) can both be incredibly frequent (every event
 // may be its own batch) and temporary, so to avoid GC thrashing they are both
@@ -255,6 +316,12 @@ func (s *batchingSink) getConcreteType() sinkType {
 type sinkBatch struct {
 	buffer  BatchBuffer
 	payload SinkPayload // payload is nil until FinalizePayload has been called
+	topic   string
+
+	// events holds the messages of the batch if the sink has a dead letter
+	// queue, so that they may be emitted one at a time should the batch fail
+	// permanently.
+	events []batchedEvent
 
 	numMessages int
 	numKVBytes  int          // the total amount of uncompressed kv data in the batch
@@ -266,6 +333,13 @@ type sinkBatch struct {
 	hasher hash.Hash32
 }
 
+// batchedEvent is a message which was appended to a sinkBatch.
+type batchedEvent struct {
+	key, val        []byte
+	topicDescriptor TopicDescriptor
+	mvcc            hlc.Timestamp
+}
+
 // FinalizePayload closes the writer to produce a payload that is ready to be
 // Flushed by the SinkClient.
 func (sb *sinkBatch) FinalizePayload() error {
@@ -305,6 +379,7 @@ func (sb *sinkBatch) Append(e *rowEvent) {
 
 	sb.buffer.Append(e.key, e.val, attributes{
 		tableName: e.topicDescriptor.GetTableName(),
+		mvcc:      e.mvcc,
 	})
 
 	sb.keys.Add(hashToInt(sb.hasher, e.key))
@@ -318,6 +393,32 @@ func (sb *sinkBatch) Append(e *rowEvent) {
 	sb.alloc.Merge(&e.alloc)
 }
 
+// flushToDeadLetterQueue emits the messages of a batch which failed
+// permanently one at a time, writing those which fail permanently on their own
+// to the dead letter queue.
+func (s *batchingSink) flushToDeadLetterQueue(ctx context.Context, batch *sinkBatch) error {
+	for _, e := range batch.events {
+		buf := s.client.MakeBatchBuffer(batch.topic)
+		buf.Append(e.key, e.val, attributes{tableName: e.topicDescriptor.GetTableName(), mvcc: e.mvcc})
+		payload, err := buf.Close()
+		if err == nil {
+			err = s.client.Flush(ctx, payload)
+		}
+		if err == nil {
+			continue
+		}
+		// Any other error is returned so that the batch is retried as a whole,
+		// which may emit some of its messages more than once.
+		if !changefeedbase.IsPermanentEventError(err) {
+			return err
+		}
+		if err := s.dlq(ctx, e.topicDescriptor, e.mvcc, e.key, e.val, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *batchingSink) handleError(err error) {
 	if s.termErr == nil {
 		s.termErr = err
@@ -327,6 +428,7 @@ func (s *batchingSink) handleError(err error) {
 func (s *batchingSink) newBatchBuffer(topic string) *sinkBatch {
 	batch := newSinkBatch()
 	batch.buffer = s.client.MakeBatchBuffer(topic)
+	batch.topic = topic
 	batch.hasher = s.hasher
 	return batch
 }
@@ -349,7 +451,15 @@ func (s *batchingSink) runBatchingWorker(ctx context.Context) {
 		s.metrics.recordSinkIOInflightChange(int64(batch.numMessages))
 		defer s.metrics.timers().DownstreamClientSend.Start()()
 
-		return s.client.Flush(ctx, batch.payload)
+		err := s.client.Flush(ctx, batch.payload)
+		if err != nil && s.dlq != nil && changefeedbase.IsPermanentEventError(err) {
+			// The error may be caused by only some of the messages, so find
+			// them by emitting the messages one at a time. This is done while
+			// the batch's keys are still held by ParallelIO so that the
+			// messages are not reordered with later ones.
+			return s.flushToDeadLetterQueue(ctx, batch)
+		}
+		return err
 	}
 	ioEmitter := NewParallelIO(ctx, s.retryOpts, s.ioWorkers, ioHandler, s.metrics, s.settings)
 	defer ioEmitter.Close()
@@ -491,6 +601,14 @@ func (s *batchingSink) runBatchingWorker(ctx context.Context) {
 				}
 
 				batchBuffer.Append(r)
+				if s.dlq != nil {
+					batchBuffer.events = append(batchBuffer.events, batchedEvent{
+						key:             r.key,
+						val:             r.val,
+						topicDescriptor: r.topicDescriptor,
+						mvcc:            r.mvcc,
+					})
+				}
 				if s.knobs.OnAppend != nil {
 					s.knobs.OnAppend(r)
 				}
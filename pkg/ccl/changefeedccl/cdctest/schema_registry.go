@@ -9,9 +9,11 @@ import (
 	"crypto/tls"
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
@@ -26,9 +28,13 @@ type SchemaRegistry struct {
 	statusCode int
 	mu         struct {
 		syncutil.Mutex
-		idAlloc  int32
-		schemas  map[int32]string
-		subjects map[string]int32
+		idAlloc     int32
+		schemas     map[int32]string
+		schemaTypes map[int32]string
+		subjects    map[string]int32
+		// versions holds the IDs of the schemas registered for each subject,
+		// oldest first.
+		versions map[string][]int32
 	}
 }
 
@@ -70,7 +76,9 @@ func StartTestSchemaRegistryWithTLS(
 func makeTestSchemaRegistry() *SchemaRegistry {
 	r := &SchemaRegistry{}
 	r.mu.schemas = make(map[int32]string)
+	r.mu.schemaTypes = make(map[int32]string)
 	r.mu.subjects = make(map[string]int32)
+	r.mu.versions = make(map[string][]int32)
 	r.server = httptest.NewUnstartedServer(http.HandlerFunc(r.requestHandler))
 	return r
 }
@@ -102,15 +110,108 @@ func (r *SchemaRegistry) SchemaForSubject(subject string) string {
 	return r.mu.schemas[r.mu.subjects[subject]]
 }
 
-func (r *SchemaRegistry) registerSchema(subject string, schema string) int32 {
+// SchemaTypeForSubject returns the type of the schema registered for the
+// specified subject. Schemas registered without a type are Avro schemas.
+func (r *SchemaRegistry) SchemaTypeForSubject(subject string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if schemaType, ok := r.mu.schemaTypes[r.mu.subjects[subject]]; ok && schemaType != "" {
+		return schemaType
+	}
+	return "AVRO"
+}
+
+// SchemasForSubject returns the schemas registered for the specified subject,
+// oldest first.
+func (r *SchemaRegistry) SchemasForSubject(subject string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var schemas []string
+	for _, id := range r.mu.versions[subject] {
+		schemas = append(schemas, r.mu.schemas[id])
+	}
+	return schemas
+}
+
+// registerSchema registers a new version of the schema of the subject. An
+// error is returned if the schema is incompatible with the previous version.
+func (r *SchemaRegistry) registerSchema(
+	subject string, schema string, schemaType string,
+) (int32, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if prevID, ok := r.mu.subjects[subject]; ok &&
+		schemaType == "PROTOBUF" && r.mu.schemaTypes[prevID] == "PROTOBUF" {
+		if err := checkProtobufCompatibility(r.mu.schemas[prevID], schema); err != nil {
+			return 0, err
+		}
+	}
+
 	id := r.mu.idAlloc
 	r.mu.idAlloc++
 	r.mu.schemas[id] = schema
+	r.mu.schemaTypes[id] = schemaType
 	r.mu.subjects[subject] = id
-	return id
+	r.mu.versions[subject] = append(r.mu.versions[subject], id)
+	return id, nil
+}
+
+var (
+	protobufMessageRegexp = regexp.MustCompile(`^message (\w+) \{$`)
+	protobufFieldRegexp   = regexp.MustCompile(`^\s+(?:optional )?(\w+) (\w+) = (\d+);$`)
+)
+
+// protobufFieldTypes returns the types of the fields of each message of a
+// .proto file, indexed by message name and field number. Only the subset of
+// the .proto syntax generated by changefeeds is understood.
+func protobufFieldTypes(schema string) (map[string]map[int]string, error) {
+	messages := make(map[string]map[int]string)
+	var fields map[int]string
+	for _, line := range strings.Split(schema, "\n") {
+		if m := protobufMessageRegexp.FindStringSubmatch(line); m != nil {
+			fields = make(map[int]string)
+			messages[m[1]] = fields
+			continue
+		}
+		m := protobufFieldRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if fields == nil {
+			return nil, errors.Newf("field %s outside of a message", m[2])
+		}
+		num, err := strconv.Atoi(m[3])
+		if err != nil {
+			return nil, err
+		}
+		fields[num] = m[1]
+	}
+	return messages, nil
+}
+
+// checkProtobufCompatibility returns an error if data written with the
+// previous protobuf schema can't be read with the new one, which is the case
+// if a field number of some message now has a different type. Fields may be
+// added and removed freely.
+func checkProtobufCompatibility(prev, schema string) error {
+	prevMessages, err := protobufFieldTypes(prev)
+	if err != nil {
+		return err
+	}
+	messages, err := protobufFieldTypes(schema)
+	if err != nil {
+		return err
+	}
+	for name, fields := range messages {
+		for num, typ := range fields {
+			if prevTyp, ok := prevMessages[name][num]; ok && prevTyp != typ {
+				return errors.Newf("field %d of message %s changed type from %s to %s",
+					num, name, prevTyp, typ)
+			}
+		}
+	}
+	return nil
 }
 
 // RegistrationCount returns the number of Registration requests received.
@@ -154,7 +255,8 @@ func (r *SchemaRegistry) requestHandler(hw http.ResponseWriter, hr *http.Request
 // register is an http handler for the underlying server which registers schemas.
 func (r *SchemaRegistry) register(hw http.ResponseWriter, hr *http.Request) (err error) {
 	type confluentSchemaVersionRequest struct {
-		Schema string `json:"schema"`
+		Schema     string `json:"schema"`
+		SchemaType string `json:"schemaType"`
 	}
 	type confluentSchemaVersionResponse struct {
 		ID int32 `json:"id"`
@@ -170,7 +272,11 @@ func (r *SchemaRegistry) register(hw http.ResponseWriter, hr *http.Request) (err
 	}
 
 	subject := strings.Split(hr.URL.Path, "/")[2]
-	id := r.registerSchema(subject, req.Schema)
+	id, err := r.registerSchema(subject, req.Schema, req.SchemaType)
+	if err != nil {
+		http.Error(hw, fmt.Sprintf("incompatible schema: %v", err), http.StatusConflict)
+		return nil
+	}
 	res, err := json.Marshal(confluentSchemaVersionResponse{ID: id})
 	if err != nil {
 		return err
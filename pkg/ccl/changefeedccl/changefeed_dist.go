@@ -476,12 +476,13 @@ func makePlan(
 			}
 
 			aggregatorSpecs[i] = &execinfrapb.ChangeAggregatorSpec{
-				Watches:    watches,
-				Checkpoint: aggregatorCheckpoint,
-				Feed:       details,
-				UserProto:  execCtx.User().EncodeProto(),
-				JobID:      jobID,
-				Select:     execinfrapb.Expression{Expr: details.Select},
+				Watches:         watches,
+				Checkpoint:      aggregatorCheckpoint,
+				Feed:            details,
+				UserProto:       execCtx.User().EncodeProto(),
+				JobID:           jobID,
+				Select:          execinfrapb.Expression{Expr: details.Select},
+				AggregatorIndex: int32(i),
 			}
 		}
 
@@ -490,10 +491,11 @@ func makePlan(
 		// is created, even if it is paused and unpaused, but #28982 describes some
 		// ways that this might happen in the future.
 		changeFrontierSpec := execinfrapb.ChangeFrontierSpec{
-			TrackedSpans: trackedSpans,
-			Feed:         details,
-			JobID:        jobID,
-			UserProto:    execCtx.User().EncodeProto(),
+			TrackedSpans:   trackedSpans,
+			Feed:           details,
+			JobID:          jobID,
+			UserProto:      execCtx.User().EncodeProto(),
+			NumAggregators: int32(len(aggregatorSpecs)),
 		}
 
 		if haveKnobs && maybeCfKnobs.OnDistflowSpec != nil {
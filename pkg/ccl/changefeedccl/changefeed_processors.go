@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -39,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log/logcrash"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/cockroach/pkg/util/span"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -74,6 +76,12 @@ type changeAggregator struct {
 	// sink is the Sink to write rows to. Resolved timestamps are never written
 	// by changeAggregator.
 	sink EventSink
+	// txnSink is set if the sink emits rows in transactions. See
+	// prepareSinkTransaction.
+	txnSink transactionalSink
+	// preparedTxn is the sink transaction prepared by the last flush of the
+	// frontier, if it has not been committed yet.
+	preparedTxn *jobspb.PreparedSinkTransaction
 	// changedRowBuf, if non-nil, contains changed rows to be emitted. Anything
 	// queued in `resolvedSpanBuf` is dependent on these having been emitted, so
 	// this one must be empty before moving on to that one.
@@ -402,6 +410,26 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 		return
 	}
 
+	// If the sink emits rows in transactions, start the transactions of this
+	// aggregator, which fences off the producers of its previous incarnations.
+	transactional, err := sinkUsesTransactions(opts)
+	if err == nil && transactional {
+		if ts, ok := ca.sink.(transactionalSink); ok {
+			ca.txnSink = ts
+			err = ts.initTransactions(ctx, ca.spec.JobID, ca.spec.AggregatorIndex)
+		} else {
+			err = errSinkNotTransactional
+		}
+	}
+	if err != nil {
+		if log.V(2) {
+			log.Infof(ca.Ctx(), "change aggregator moving to draining due to error initializing sink transactions: %v", err)
+		}
+		ca.MoveToDraining(err)
+		ca.cancel()
+		return
+	}
+
 	// Init heartbeat timer.
 	ca.lastPush = timeutil.Now()
 
@@ -759,6 +787,12 @@ func (ca *changeAggregator) computeTrailingMetadata(meta *execinfrapb.Changefeed
 		return
 	}
 
+	// The flushed rows are in a sink transaction which no checkpoint records,
+	// so the restart of the changefeed aborts them and must emit them again.
+	if ca.txnSink != nil {
+		return
+	}
+
 	// Build out the list of frontier spans.
 	ca.frontier.Entries(func(r roachpb.Span, ts hlc.Timestamp) (done span.OpResult) {
 		meta.Checkpoint = append(meta.Checkpoint,
@@ -894,6 +928,15 @@ func (ca *changeAggregator) flushFrontier() error {
 
 	// Iterate frontier spans and build a list of spans to emit.
 	var batch jobspb.ResolvedSpans
+	if ca.txnSink != nil {
+		// Likewise, the flushed rows must be in a prepared transaction which the
+		// change frontier records along with these spans.
+		txn, err := ca.prepareSinkTransaction()
+		if err != nil {
+			return err
+		}
+		batch.PreparedSinkTransaction = txn
+	}
 	ca.frontier.Entries(func(s roachpb.Span, ts hlc.Timestamp) span.OpResult {
 		boundaryType := jobspb.ResolvedSpan_NONE
 		if ca.frontier.boundaryTime.Equal(ts) {
@@ -917,6 +960,7 @@ func (ca *changeAggregator) emitResolved(batch jobspb.ResolvedSpans) error {
 		Stats: jobspb.ResolvedSpans_Stats{
 			RecentKvCount: ca.recentKVCount,
 		},
+		PreparedSinkTransaction: batch.PreparedSinkTransaction,
 	}
 	updateBytes, err := protoutil.Marshal(&progressUpdate)
 	if err != nil {
@@ -934,6 +978,71 @@ func (ca *changeAggregator) emitResolved(batch jobspb.ResolvedSpans) error {
 	return nil
 }
 
+// prepareSinkTransaction prepares the sink transaction holding the rows
+// flushed since the previous call, and returns it so that the change frontier
+// records it in the next checkpoint of the job. It returns nil if no rows were
+// flushed.
+//
+// The transaction prepared by the previous call is committed first, once a
+// checkpoint has recorded it. Until then, a restart of the changefeed aborts
+// it and emits its rows again. Once recorded, a restart commits it instead,
+// since the checkpoint may cover its rows.
+func (ca *changeAggregator) prepareSinkTransaction() (*jobspb.PreparedSinkTransaction, error) {
+	ctx := ca.Ctx()
+	if ca.preparedTxn != nil {
+		if err := ca.waitForRecordedSinkTransaction(ctx, *ca.preparedTxn); err != nil {
+			return nil, err
+		}
+		if err := ca.txnSink.commitTransaction(ctx); err != nil {
+			return nil, err
+		}
+		ca.preparedTxn = nil
+	}
+
+	txn, prepared, err := ca.txnSink.prepareTransaction(ctx)
+	if err != nil || !prepared {
+		return nil, err
+	}
+	ca.preparedTxn = &txn
+	return &txn, nil
+}
+
+// sinkTransactionRecordTimeout bounds how long a change aggregator waits for
+// a checkpoint of the job to record its prepared sink transaction.
+const sinkTransactionRecordTimeout = 5 * time.Minute
+
+// waitForRecordedSinkTransaction waits until the progress of the job records
+// the prepared sink transaction.
+func (ca *changeAggregator) waitForRecordedSinkTransaction(
+	ctx context.Context, txn jobspb.PreparedSinkTransaction,
+) error {
+	opts := retry.Options{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+	start := timeutil.Now()
+	for r := retry.StartWithCtx(ctx, opts); r.Next(); {
+		job, err := ca.FlowCtx.Cfg.JobRegistry.LoadJob(ctx, ca.spec.JobID)
+		if err != nil {
+			return err
+		}
+		if cfProgress := job.Progress().GetChangefeed(); cfProgress != nil {
+			for _, recorded := range cfProgress.PreparedSinkTransactions {
+				if recorded == txn {
+					return nil
+				}
+			}
+		}
+		if timeutil.Since(start) > sinkTransactionRecordTimeout {
+			return changefeedbase.MarkRetryableError(errors.Newf(
+				"no checkpoint recorded the transaction of %s after %s",
+				txn.TransactionalID, sinkTransactionRecordTimeout))
+		}
+	}
+	return ctx.Err()
+}
+
 // ConsumerClosed is part of the RowSource interface.
 func (ca *changeAggregator) ConsumerClosed() {
 	// The consumer is done, Next() will not be called again.
@@ -988,6 +1097,9 @@ type changeFrontier struct {
 	// js, if non-nil, is called to checkpoint the changefeed's
 	// progress in the corresponding system job entry.
 	js *jobState
+	// sinkTxns is set if the change aggregators emit rows in sink
+	// transactions, which the checkpoints of the job must record.
+	sinkTxns *sinkTransactionRecorder
 	// highWaterAtStart is the greater of the job high-water and the timestamp the
 	// CHANGEFEED statement was run at. It's used in an assertion that we never
 	// regress the job high-water.
@@ -1042,6 +1154,54 @@ type jobState struct {
 	progressUpdatesSkipped bool
 }
 
+// sinkTransactionRecorder tracks the sink transactions prepared by the change
+// aggregators, which the next checkpoint of the job must record.
+type sinkTransactionRecorder struct {
+	// prepared is the last transaction prepared by each aggregator. An
+	// aggregator commits its previous transaction before preparing the next
+	// one, so earlier transactions no longer need to be recorded.
+	prepared map[int32]jobspb.PreparedSinkTransaction
+	// numAggregators is the number of aggregators in the flow.
+	numAggregators int32
+	// unrecorded is set if the last checkpoint did not record some of the
+	// prepared transactions.
+	unrecorded bool
+}
+
+func makeSinkTransactionRecorder(numAggregators int32) *sinkTransactionRecorder {
+	return &sinkTransactionRecorder{
+		prepared:       make(map[int32]jobspb.PreparedSinkTransaction),
+		numAggregators: numAggregators,
+	}
+}
+
+// add tracks a transaction prepared by an aggregator. It must be called before
+// the frontier is forwarded to the resolved spans which the aggregator sent
+// along with the transaction, so that no checkpoint covers the rows of the
+// transaction without recording it.
+func (r *sinkTransactionRecorder) add(txn jobspb.PreparedSinkTransaction) {
+	r.prepared[txn.Aggregator] = txn
+	r.unrecorded = true
+}
+
+// record sets the prepared transactions in the progress of the job.
+//
+// NOTE: this method may be retried by the job update transaction, so it does
+// not mutate the recorder.
+func (r *sinkTransactionRecorder) record(progress *jobspb.ChangefeedProgress) {
+	txns := make([]jobspb.PreparedSinkTransaction, 0, len(r.prepared))
+	for _, txn := range r.prepared {
+		txns = append(txns, txn)
+	}
+	sort.Slice(txns, func(i, j int) bool {
+		return txns[i].Aggregator < txns[j].Aggregator
+	})
+	progress.PreparedSinkTransactions = txns
+	if progress.SinkTransactionProducers < r.numAggregators {
+		progress.SinkTransactionProducers = r.numAggregators
+	}
+}
+
 // cachedState is a changefeed progress stored in memory.
 // It is used to reduce the number of duplicate events emitted during retries.
 type cachedState struct {
@@ -1304,6 +1464,19 @@ func (cf *changeFrontier) Start(ctx context.Context) {
 			return
 		}
 		cf.js.job = job
+
+		transactional, err := sinkUsesTransactions(changefeedbase.MakeStatementOptions(cf.spec.Feed.Opts))
+		if err != nil {
+			if log.V(2) {
+				log.Infof(cf.Ctx(), "change frontier moving to draining due to error parsing sink config: %v", err)
+			}
+			cf.MoveToDraining(err)
+			return
+		}
+		if transactional {
+			cf.sinkTxns = makeSinkTransactionRecorder(cf.spec.NumAggregators)
+		}
+
 		if changefeedbase.FrontierCheckpointFrequency.Get(&cf.FlowCtx.Cfg.Settings.SV) == 0 {
 			log.Warning(ctx,
 				"Frontier checkpointing disabled; set changefeed.frontier_checkpoint_frequency to non-zero value to re-enable")
@@ -1569,6 +1742,14 @@ func (cf *changeFrontier) noteAggregatorProgress(d rowenc.EncDatum) error {
 
 	cf.maybeMarkJobIdle(resolvedSpans.Stats.RecentKvCount)
 
+	if txn := resolvedSpans.PreparedSinkTransaction; txn != nil {
+		if cf.sinkTxns == nil {
+			return errors.AssertionFailedf(
+				"unexpected sink transaction of %s from aggregator %d", txn.TransactionalID, txn.Aggregator)
+		}
+		cf.sinkTxns.add(*txn)
+	}
+
 	for _, resolved := range resolvedSpans.ResolvedSpans {
 		// Inserting a timestamp less than the one the changefeed flow started at
 		// could potentially regress the job progress. This is not expected, but it
@@ -1657,11 +1838,17 @@ func (cf *changeFrontier) maybeCheckpointJob(
 	// as we receive spans from the scan request at the Backfill Timestamp
 	inBackfill := !frontierChanged && resolvedSpan.Timestamp.Equal(cf.frontier.BackfillTS())
 
+	// Change aggregators wait for their prepared sink transactions to be
+	// recorded before they commit them, so these checkpoints are not
+	// throttled.
+	recordSinkTxns := cf.sinkTxns != nil && cf.sinkTxns.unrecorded
+
 	// If we're not in a backfill, highwater progress and an empty checkpoint will
 	// be saved. This is throttled however we always persist progress to a schema
 	// boundary.
 	updateHighWater :=
-		!inBackfill && (cf.frontier.schemaChangeBoundaryReached() || cf.js.canCheckpointHighWatermark(frontierChanged))
+		!inBackfill && (cf.frontier.schemaChangeBoundaryReached() || recordSinkTxns ||
+			cf.js.canCheckpointHighWatermark(frontierChanged))
 
 	// During backfills or when some problematic spans stop advancing, the
 	// highwater mark remains fixed while other spans may significantly outpace
@@ -1669,7 +1856,7 @@ func (cf *changeFrontier) maybeCheckpointJob(
 	// also store as many of those leading spans as we can in the job progress
 	updateCheckpoint :=
 		(inBackfill || cf.frontier.hasLaggingSpans(cf.spec.Feed.StatementTime, &cf.js.settings.SV)) &&
-			cf.js.canCheckpointSpans()
+			(recordSinkTxns || cf.js.canCheckpointSpans())
 
 	// If the highwater has moved an empty checkpoint will be saved
 	var checkpoint jobspb.ChangefeedProgress_Checkpoint
@@ -1731,6 +1918,9 @@ func (cf *changeFrontier) checkpointJobProgress(
 
 			changefeedProgress := progress.Details.(*jobspb.Progress_Changefeed).Changefeed
 			changefeedProgress.Checkpoint = &checkpoint
+			if cf.sinkTxns != nil {
+				cf.sinkTxns.record(changefeedProgress)
+			}
 
 			if ptsUpdated, err = cf.manageProtectedTimestamps(cf.Ctx(), txn, changefeedProgress); err != nil {
 				log.Warningf(cf.Ctx(), "error managing protected timestamp record: %v", err)
@@ -1758,6 +1948,9 @@ func (cf *changeFrontier) checkpointJobProgress(
 		if ptsUpdated {
 			cf.lastProtectedTimestampUpdate = timeutil.Now()
 		}
+		if cf.sinkTxns != nil {
+			cf.sinkTxns.unrecorded = false
+		}
 		if log.V(2) {
 			log.Infof(cf.Ctx(), "change frontier persisted highwater=%s and checkpoint=%s", frontier, checkpoint)
 		}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rangefeed"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
@@ -229,3 +230,43 @@ func TestGetCheckpointSpans(t *testing.T) {
 		100*(1-float64(catchupFromCheckpoint.Nanoseconds())/float64(catchupFromHWM.Nanoseconds())))
 	require.Less(t, catchupFromCheckpoint, catchupFromHWM)
 }
+
+// TestSinkTransactionRecorder tests that checkpoints record the last sink
+// transaction prepared by each change aggregator.
+func TestSinkTransactionRecorder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	txn := func(aggregator int32, producer int, producerID int64) jobspb.PreparedSinkTransaction {
+		return jobspb.PreparedSinkTransaction{
+			Aggregator:      aggregator,
+			TransactionalID: kafkaTransactionalID(1, aggregator, producer),
+			ProducerID:      producerID,
+		}
+	}
+
+	r := makeSinkTransactionRecorder(3)
+	require.False(t, r.unrecorded)
+
+	r.add(txn(2, 0, 20))
+	r.add(txn(0, 0, 10))
+	r.add(txn(0, 1, 11))
+	require.True(t, r.unrecorded)
+
+	// The progress of a previous flow, which had more aggregators, recorded
+	// transactions which have been committed since.
+	progress := jobspb.ChangefeedProgress{
+		PreparedSinkTransactions: []jobspb.PreparedSinkTransaction{txn(4, 0, 40)},
+		SinkTransactionProducers: 5,
+	}
+	r.record(&progress)
+	require.Equal(t, jobspb.ChangefeedProgress{
+		PreparedSinkTransactions: []jobspb.PreparedSinkTransaction{txn(0, 1, 11), txn(2, 0, 20)},
+		SinkTransactionProducers: 5,
+	}, progress)
+
+	progress = jobspb.ChangefeedProgress{}
+	r.record(&progress)
+	require.Equal(t, int32(3), progress.SinkTransactionProducers)
+	require.Len(t, progress.PreparedSinkTransactions, 2)
+}
@@ -589,6 +589,8 @@ func createChangefeedJobRecord(
 		)
 	}
 
+	normalizeEnvelopeOptions(opts)
+
 	if err = validateDetailsAndOptions(details, opts); err != nil {
 		return nil, err
 	}
@@ -655,6 +657,13 @@ func createChangefeedJobRecord(
 			}
 		}
 
+		if onError, err := opts.GetOnError(); err != nil {
+			return nil, err
+		} else if onError == changefeedbase.OptOnErrorDLQ {
+			return nil, errors.Errorf("%s=%s is not supported for sinkless changefeeds",
+				changefeedbase.OptOnError, changefeedbase.OptOnErrorDLQ)
+		}
+
 		details.Opts = opts.AsMap()
 		// Jobs should not be created for sinkless changefeeds. However, note that
 		// we create and return a job record for sinkless changefeeds below. This is
@@ -678,6 +687,18 @@ func createChangefeedJobRecord(
 		telemetry.Count(telemetryPath + `.enterprise`)
 	}
 
+	if onError, err := opts.GetOnError(); err != nil {
+		return nil, err
+	} else if onError == changefeedbase.OptOnErrorDLQ {
+		// Store the fully qualified name so that the table does not depend on
+		// the session which created the changefeed.
+		dlqTable, err := resolveDLQTableName(opts, p.CurrentDatabase(), jobID)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetDLQTable(dlqTable)
+	}
+
 	// TODO (zinger): validateSink shouldn't need details, remove that so we only
 	// need to have this line once.
 	details.Opts = opts.AsMap()
@@ -935,6 +956,12 @@ func validateSink(
 	if err := canarySink.Close(); err != nil {
 		return err
 	}
+	if opts.Debezium() && !emitsDeleteTombstones(canarySink) {
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			`%s=%s only emits delete tombstones to Kafka sinks, whose log compacted topics use them`,
+			changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeDebezium,
+		))
+	}
 	// If there's no projection we may need to force some options to ensure messages
 	// have enough information.
 	if details.Select == `` {
@@ -966,9 +993,23 @@ func validateSink(
 	return nil
 }
 
+// normalizeEnvelopeOptions turns on the options which the envelope requires.
+// It is applied by createChangefeedJobRecord for both CREATE CHANGEFEED and
+// ALTER CHANGEFEED, since ALTER CHANGEFEED may change the envelope or unset the
+// required options. It runs after the job description is generated, so that
+// the description only has the options which the user specified; otherwise an
+// implied option would outlive the envelope which implied it.
+func normalizeEnvelopeOptions(opts changefeedbase.StatementOptions) {
+	// The debezium envelope relies on the previous version of each row to tell
+	// inserts from updates and to fill in its before field.
+	if opts.Debezium() {
+		opts.ForceDiff()
+	}
+}
+
 func requiresKeyInValue(s Sink) bool {
 	switch s.getConcreteType() {
-	case sinkTypeCloudstorage, sinkTypeWebhook:
+	case sinkTypeCloudstorage, sinkTypeWebhook, sinkTypeMQTT:
 		return true
 	default:
 		return false
@@ -1116,6 +1157,14 @@ func (b *changefeedResumer) Resume(ctx context.Context, execCtx interface{}) err
 		return err
 	}
 
+	if dlqTable := details.Opts[changefeedbase.OptDLQTable]; dlqTable != "" {
+		if err := createDLQTable(
+			ctx, execCfg.InternalDB.Executor(), b.job.Payload().UsernameProto.Decode(), dlqTable,
+		); err != nil {
+			return err
+		}
+	}
+
 	err := b.resumeWithRetries(ctx, jobExec, jobID, details, progress, execCfg)
 	if err != nil {
 		return b.handleChangefeedError(ctx, err, details, jobExec)
@@ -1205,8 +1254,10 @@ func (b *changefeedResumer) handleChangefeedError(
 		return errors.CombineErrors(changefeedErr, errErr)
 	}
 	switch onError {
-	// default behavior
-	case changefeedbase.OptOnErrorFail:
+	// default behavior; with on_error=dlq, events which cannot be emitted are
+	// written to the dead letter queue by the aggregators, so any error which
+	// reaches here fails the job.
+	case changefeedbase.OptOnErrorFail, changefeedbase.OptOnErrorDLQ:
 		log.Warningf(ctx, "job failed (%v)", changefeedErr)
 		return changefeedErr
 	// pause instead of failing
@@ -1272,9 +1323,28 @@ func (b *changefeedResumer) resumeWithRetries(
 	jobExec.ExtendedEvalContext().ChangefeedState = localState
 	knobs, _ := execCfg.DistSQLSrv.TestingKnobs.Changefeed.(*TestingKnobs)
 
+	// When the changefeed stops, commit the sink transactions which the last
+	// checkpoint recorded, rather than leaving them open until it resumes:
+	// Kafka aborts them if the changefeed stays paused for too long.
+	defer func() {
+		recoverCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		job, err := execCfg.JobRegistry.LoadJob(recoverCtx, jobID)
+		if err == nil {
+			err = recoverSinkTransactions(recoverCtx, jobExec, jobID, details, job.Progress())
+		}
+		if err != nil {
+			log.Warningf(ctx, "CHANGEFEED %d could not commit its recorded sink transactions: %v", jobID, err)
+		}
+	}()
+
 	for r := getRetry(ctx); r.Next(); {
 		flowErr := maybeUpgradePreProductionReadyExpression(ctx, jobID, details, jobExec)
 
+		if flowErr == nil {
+			flowErr = recoverSinkTransactions(ctx, jobExec, jobID, details, localState.progress)
+		}
+
 		if flowErr == nil {
 			// startedCh is normally used to signal back to the creator of the job that
 			// the job has started; however, in this case nothing will ever receive
@@ -1350,6 +1420,50 @@ func (b *changefeedResumer) resumeWithRetries(
 	return errors.Wrap(ctx.Err(), `ran out of retries`)
 }
 
+// recoverSinkTransactions commits the sink transactions recorded in the
+// progress of the changefeed's job, which its change aggregators may have left
+// prepared, and fences off the transactional producers of those aggregators.
+// It must run before the changefeed's flow starts, since the new aggregators
+// fence off the same producers, which aborts their prepared transactions.
+func recoverSinkTransactions(
+	ctx context.Context,
+	jobExec sql.JobExecContext,
+	jobID jobspb.JobID,
+	details jobspb.ChangefeedDetails,
+	progress jobspb.Progress,
+) error {
+	transactional, err := sinkUsesTransactions(changefeedbase.MakeStatementOptions(details.Opts))
+	if err != nil || !transactional {
+		return err
+	}
+	cfProgress := progress.GetChangefeed()
+	if cfProgress == nil ||
+		(len(cfProgress.PreparedSinkTransactions) == 0 && cfProgress.SinkTransactionProducers == 0) {
+		return nil
+	}
+
+	execCfg := jobExec.ExecCfg()
+	metrics := execCfg.JobRegistry.MetricsStruct().Changefeed.(*Metrics)
+	sli, err := metrics.getSLIMetrics(details.Opts[changefeedbase.OptMetricsScope])
+	if err != nil {
+		return err
+	}
+	var nilOracle timestampLowerBoundOracle
+	sink, err := getAndDialSink(ctx, &execCfg.DistSQLSrv.ServerConfig, details,
+		nilOracle, jobExec.User(), jobID, sli)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = sink.Close()
+	}()
+	ts, ok := sink.(transactionalSink)
+	if !ok {
+		return errSinkNotTransactional
+	}
+	return ts.recoverTransactions(ctx, jobID, *cfProgress)
+}
+
 // reconcileJobStateWithLocalState ensures that the job progress information
 // is consistent with the state present in the local state.
 func reconcileJobStateWithLocalState(
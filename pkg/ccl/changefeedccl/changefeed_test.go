@@ -7441,6 +7441,73 @@ func TestChangefeedOnErrorOption(t *testing.T) {
 	cdcTest(t, testFn, feedTestEnterpriseSinks)
 }
 
+// TestChangefeedOnErrorDLQ verifies that a row which the sink rejects
+// permanently is written to the dead letter queue table of the changefeed,
+// where SHOW CHANGEFEED DLQ finds it, and that the changefeed moves on.
+func TestChangefeedOnErrorDLQ(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		// Only the batching webhook sink reports the messages which the endpoint
+		// rejects as permanent errors.
+		WebhookV2Enabled.Override(context.Background(), &s.Server.ClusterSettings().SV, true)
+
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		var tableID int
+		sqlDB.QueryRow(t, `SELECT 'foo'::REGCLASS::OID::INT`).Scan(&tableID)
+
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo WITH on_error='dlq'`)
+		defer closeFeed(t, foo)
+		jobID := foo.(cdctest.EnterpriseTestFeed).JobID()
+
+		// The endpoint rejects the poisoned row both in its batch and on its own.
+		webhookFoo := foo.(*webhookFeed)
+		webhookFoo.mockSink.SetStatusCodes([]int{http.StatusRequestEntityTooLarge})
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'poisoned')`)
+
+		countDLQ := fmt.Sprintf(
+			`SELECT count(*) FROM d.crdb_changefeed.dlq_%d WHERE job_id = $1`, jobID)
+		testutils.SucceedsSoon(t, func() error {
+			// The table is created when the changefeed starts, so it may not
+			// exist yet.
+			var count int
+			if err := s.DB.QueryRow(countDLQ, jobID).Scan(&count); err != nil {
+				return err
+			}
+			if count == 0 {
+				return errors.New("waiting for the poisoned row to be written to the dead letter queue")
+			}
+			return nil
+		})
+
+		webhookFoo.mockSink.SetStatusCodes([]int{http.StatusOK})
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 'healthy')`)
+		assertPayloads(t, foo, []string{
+			`foo: [2]->{"after": {"a": 2, "b": "healthy"}}`,
+		})
+
+		sqlDB.CheckQueryResults(t, countDLQ, [][]string{{"1"}}, jobID)
+		sqlDB.CheckQueryResults(t, fmt.Sprintf(`
+			SELECT
+				table_id,
+				dlq_reason LIKE '%%413 Request Entity Too Large%%',
+				convert_from(value, 'UTF8') LIKE '%%"b": "poisoned"%%'
+			FROM [SHOW CHANGEFEED DLQ FOR JOB %d]`, jobID),
+			[][]string{{strconv.Itoa(tableID), "true", "true"}},
+		)
+
+		bar := feed(t, f, `CREATE CHANGEFEED FOR foo`)
+		defer closeFeed(t, bar)
+		sqlDB.ExpectErr(t, `does not have a dead letter queue`,
+			`SHOW CHANGEFEED DLQ FOR JOB $1`, bar.(cdctest.EnterpriseTestFeed).JobID())
+	}
+
+	cdcTest(t, testFn, feedTestForceSink("webhook"), feedTestNoExternalConnection,
+		feedTestUseRootUserConnection)
+}
+
 func TestDistSenderRangeFeedPopulatesVirtualTable(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
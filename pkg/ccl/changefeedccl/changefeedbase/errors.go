@@ -98,6 +98,35 @@ func MarkRetryableError(cause error) error {
 	return errors.Mark(cause, &retryableError{})
 }
 
+// IsRetryableError returns true if the error was marked as retryable with
+// MarkRetryableError.
+func IsRetryableError(err error) bool {
+	return errors.Is(err, &retryableError{})
+}
+
+type permanentEventError struct{}
+
+func (e *permanentEventError) Error() string {
+	return "permanent changefeed event error"
+}
+
+// MarkPermanentEventError marks the given error as one which will recur every
+// time the event being emitted is retried, such as a message which exceeds the
+// sink's size limit. With on_error=dlq such events are written to the dead
+// letter queue instead of failing the changefeed.
+func MarkPermanentEventError(cause error) error {
+	if cause == nil {
+		return nil
+	}
+	return errors.Mark(cause, &permanentEventError{})
+}
+
+// IsPermanentEventError returns true if the error was marked with
+// MarkPermanentEventError.
+func IsPermanentEventError(err error) bool {
+	return errors.Is(err, &permanentEventError{})
+}
+
 type drainHelper interface {
 	IsDraining() bool
 }
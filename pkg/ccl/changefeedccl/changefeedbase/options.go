@@ -95,6 +95,7 @@ const (
 	OptWebhookAuthHeader                  = `webhook_auth_header`
 	OptWebhookClientTimeout               = `webhook_client_timeout`
 	OptOnError                            = `on_error`
+	OptDLQTable                           = `dlq_table`
 	OptMetricsScope                       = `metrics_label`
 	OptUnordered                          = `unordered`
 	OptVirtualColumns                     = `virtual_columns`
@@ -149,14 +150,20 @@ const (
 	OptEnvelopeDeprecatedRow EnvelopeType = `deprecated_row`
 	OptEnvelopeWrapped       EnvelopeType = `wrapped`
 	OptEnvelopeBare          EnvelopeType = `bare`
+	OptEnvelopeDebezium      EnvelopeType = `debezium`
 
-	OptFormatJSON    FormatType = `json`
-	OptFormatAvro    FormatType = `avro`
-	OptFormatCSV     FormatType = `csv`
-	OptFormatParquet FormatType = `parquet`
+	OptFormatJSON     FormatType = `json`
+	OptFormatAvro     FormatType = `avro`
+	OptFormatCSV      FormatType = `csv`
+	OptFormatParquet  FormatType = `parquet`
+	OptFormatProtobuf FormatType = `protobuf`
 
 	OptOnErrorFail  OnErrorType = `fail`
 	OptOnErrorPause OnErrorType = `pause`
+	// OptOnErrorDLQ writes events which can never be emitted, such as rows
+	// which fail to encode or messages too large for the sink, to a dead
+	// letter queue table and continues. Other errors fail the changefeed.
+	OptOnErrorDLQ OnErrorType = `dlq`
 
 	DeprecatedOptFormatAvro                   = `experimental_avro`
 	DeprecatedSinkSchemeCloudStorageAzure     = `experimental-azure`
@@ -175,6 +182,8 @@ const (
 	OptKafkaSinkConfig   = `kafka_sink_config`
 	OptPubsubSinkConfig  = `pubsub_sink_config`
 	OptWebhookSinkConfig = `webhook_sink_config`
+	OptNATSSinkConfig    = `nats_sink_config`
+	OptMQTTSinkConfig    = `mqtt_sink_config`
 
 	// OptSink allows users to alter the Sink URI of an existing changefeed.
 	// Note that this option is only allowed for alter changefeed statements.
@@ -205,6 +214,8 @@ const (
 	SinkSchemeWebhookHTTP           = `webhook-http`
 	SinkSchemeWebhookHTTPS          = `webhook-https`
 	SinkSchemePulsar                = `pulsar`
+	SinkSchemeNATS                  = `nats`
+	SinkSchemeMQTT                  = `mqtt`
 	SinkSchemeExternalConnection    = `external`
 	SinkParamSASLEnabled            = `sasl_enabled`
 	SinkParamSASLHandshake          = `sasl_handshake`
@@ -220,6 +231,7 @@ const (
 	SinkParamSASLAwsRegion          = `sasl_aws_region`
 	SinkParamSASLAwsIAMSessionName  = `sasl_aws_iam_session_name`
 	SinkParamTableNameAttribute     = `with_table_name_attribute`
+	SinkParamMQTTQoS                = `qos`
 
 	SinkSchemeConfluentKafka    = `confluent-cloud`
 	SinkParamConfluentAPIKey    = `api_key`
@@ -339,8 +351,8 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptCursor:                             timestampOption,
 	OptCustomKeyColumn:                    stringOption,
 	OptEndTime:                            timestampOption,
-	OptEnvelope:                           enum("row", "key_only", "wrapped", "deprecated_row", "bare"),
-	OptFormat:                             enum("json", "avro", "csv", "experimental_avro", "parquet"),
+	OptEnvelope:                           enum("row", "key_only", "wrapped", "deprecated_row", "bare", "debezium"),
+	OptFormat:                             enum("json", "avro", "csv", "experimental_avro", "parquet", "protobuf"),
 	OptFullTableName:                      flagOption,
 	OptKeyInValue:                         flagOption,
 	OptTopicInValue:                       flagOption,
@@ -361,9 +373,12 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptKafkaSinkConfig:                    jsonOption,
 	OptPubsubSinkConfig:                   jsonOption,
 	OptWebhookSinkConfig:                  jsonOption,
+	OptNATSSinkConfig:                     jsonOption,
+	OptMQTTSinkConfig:                     jsonOption,
 	OptWebhookAuthHeader:                  stringOption,
 	OptWebhookClientTimeout:               durationOption,
-	OptOnError:                            enum("pause", "fail"),
+	OptOnError:                            enum("pause", "fail", "dlq"),
+	OptDLQTable:                           stringOption,
 	OptMetricsScope:                       stringOption,
 	OptUnordered:                          flagOption,
 	OptVirtualColumns:                     enum("omitted", "null"),
@@ -381,7 +396,7 @@ var CommonOptions = makeStringSet(OptCursor, OptEndTime, OptEnvelope,
 	OptResolvedTimestamps, OptUpdatedTimestamps,
 	OptMVCCTimestamps, OptDiff, OptSplitColumnFamilies,
 	OptSchemaChangeEvents, OptSchemaChangePolicy,
-	OptOnError,
+	OptOnError, OptDLQTable,
 	OptInitialScan, OptNoInitialScan, OptInitialScanOnly, OptUnordered, OptCustomKeyColumn,
 	OptMinCheckpointFrequency, OptMetricsScope, OptVirtualColumns, Topics, OptExpirePTSAfter,
 	OptExecutionLocality, OptLaggingRangesThreshold, OptLaggingRangesPollingInterval,
@@ -403,13 +418,20 @@ var WebhookValidOptions = makeStringSet(OptWebhookAuthHeader, OptWebhookClientTi
 // PubsubValidOptions is options exclusive to pubsub sink
 var PubsubValidOptions = makeStringSet(OptPubsubSinkConfig)
 
+// NATSValidOptions is options exclusive to NATS JetStream sink
+var NATSValidOptions = makeStringSet(OptNATSSinkConfig)
+
+// MQTTValidOptions is options exclusive to MQTT sink
+var MQTTValidOptions = makeStringSet(OptMQTTSinkConfig)
+
 // ExternalConnectionValidOptions is options exclusive to the external
 // connection sink.
 //
 // TODO(adityamaru): Some of these options should be supported when creating the
 // external connection rather than when setting up the changefeed. Move them once
 // we support `CREATE EXTERNAL CONNECTION ... WITH <options>`.
-var ExternalConnectionValidOptions = unionStringSets(SQLValidOptions, KafkaValidOptions, CloudStorageValidOptions, WebhookValidOptions, PubsubValidOptions)
+var ExternalConnectionValidOptions = unionStringSets(SQLValidOptions, KafkaValidOptions, CloudStorageValidOptions, WebhookValidOptions, PubsubValidOptions,
+	NATSValidOptions, MQTTValidOptions)
 
 // CaseInsensitiveOpts options which supports case Insensitive value
 var CaseInsensitiveOpts = makeStringSet(OptFormat, OptEnvelope, OptCompression, OptSchemaChangeEvents,
@@ -842,10 +864,18 @@ func (e EncodingOptions) Validate() error {
 			OptEnvelope, OptEnvelopeRow, OptFormat, OptFormatAvro,
 		)
 	}
+	if e.Envelope == OptEnvelopeDebezium && e.Format != OptFormatJSON && e.Format != OptFormatAvro {
+		return errors.Errorf(`%s=%s is only usable with %s=%s or %s=%s`,
+			OptEnvelope, OptEnvelopeDebezium, OptFormat, OptFormatJSON, OptFormat, OptFormatAvro,
+		)
+	}
 	if e.Format != OptFormatJSON && e.EncodeJSONValueNullAsObject {
 		return errors.Errorf(`%s is only usable with %s=%s`, OptEncodeJSONValueNullAsObject, OptFormat, OptFormatJSON)
 	}
-	if e.Envelope != OptEnvelopeWrapped && e.Format != OptFormatJSON && e.Format != OptFormatParquet {
+	// Like the wrapped envelope, the debezium envelope nests the row data, so it
+	// can hold metadata at the top level.
+	wrapped := e.Envelope == OptEnvelopeWrapped || e.Envelope == OptEnvelopeDebezium
+	if !wrapped && e.Format != OptFormatJSON && e.Format != OptFormatParquet {
 		requiresWrap := []struct {
 			k string
 			b bool
@@ -951,6 +981,18 @@ func (s StatementOptions) GetPubsubConfigJSON() SinkSpecificJSONConfig {
 	return s.getJSONValue(OptPubsubSinkConfig)
 }
 
+// GetNATSConfigJSON returns arbitrary json to be interpreted
+// by the NATS JetStream sink.
+func (s StatementOptions) GetNATSConfigJSON() SinkSpecificJSONConfig {
+	return s.getJSONValue(OptNATSSinkConfig)
+}
+
+// GetMQTTConfigJSON returns arbitrary json to be interpreted
+// by the MQTT sink.
+func (s StatementOptions) GetMQTTConfigJSON() SinkSpecificJSONConfig {
+	return s.getJSONValue(OptMQTTSinkConfig)
+}
+
 // GetResolvedTimestampInterval gets the best-effort interval at which resolved timestamps
 // should be emitted. Nil or 0 means emit as often as possible. False means do not emit at all.
 // Returns an error for negative or invalid duration value.
@@ -1006,6 +1048,11 @@ func (s StatementOptions) KeyOnly() bool {
 	return s.m[OptEnvelope] == string(OptEnvelopeKeyOnly)
 }
 
+// Debezium returns true if we are using the 'debezium' envelope.
+func (s StatementOptions) Debezium() bool {
+	return s.m[OptEnvelope] == string(OptEnvelopeDebezium)
+}
+
 // GetMinCheckpointFrequency returns the minimum frequency with which checkpoints should be
 // recorded. Returns nil if not set, and an error if invalid.
 func (s StatementOptions) GetMinCheckpointFrequency() (*time.Duration, error) {
@@ -1075,6 +1122,17 @@ func (s StatementOptions) SetDefaultEnvelope(t EnvelopeType) {
 	}
 }
 
+// SetDLQTable sets the fully qualified name of the dead letter queue table.
+func (s StatementOptions) SetDLQTable(table string) {
+	s.m[OptDLQTable] = table
+}
+
+// GetDLQTable returns the name of the table to which events are written when
+// on_error is dlq.
+func (s StatementOptions) GetDLQTable() string {
+	return s.m[OptDLQTable]
+}
+
 // GetOnError validates and returns the desired behavior when a non-retriable error is encountered.
 func (s StatementOptions) GetOnError() (OnErrorType, error) {
 	v, err := s.getEnumValue(OptOnError)
@@ -1148,6 +1206,17 @@ func (s StatementOptions) ValidateForCreateChangefeed(isPredicateChangefeed bool
 			return err
 		}
 	}
+	if isPredicateChangefeed && s.Debezium() {
+		return errors.Newf(`%s=%s is not supported with CDC queries`, OptEnvelope, OptEnvelopeDebezium)
+	}
+	// The fields of protobuf messages are numbered by column ID, which the
+	// columns projected by a CDC query don't have.
+	if isPredicateChangefeed && s.m[OptFormat] == string(OptFormatProtobuf) {
+		return errors.Newf(`%s=%s is not supported with CDC queries`, OptFormat, OptFormatProtobuf)
+	}
+	if s.IsSet(OptDLQTable) && s.m[OptOnError] != string(OptOnErrorDLQ) {
+		return errors.Newf(`%s requires %s='%s'`, OptDLQTable, OptOnError, OptOnErrorDLQ)
+	}
 	for o := range s.m {
 		for _, pair := range incompatibleOptionsMap[o] {
 			if s.IsSet(pair.opt1) && s.IsSet(pair.opt2) {
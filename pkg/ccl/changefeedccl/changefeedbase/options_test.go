@@ -37,6 +37,12 @@ func TestOptionsValidations(t *testing.T) {
 		{map[string]string{"initial_scan_only": "", "resolved": ""}, true, "cannot specify both initial_scan='only'"},
 		{map[string]string{"initial_scan_only": "", "resolved": ""}, true, "cannot specify both initial_scan='only'"},
 		{map[string]string{"key_column": "b"}, false, "requires the unordered option"},
+		{map[string]string{"on_error": "dlq"}, false, ""},
+		{map[string]string{"on_error": "dlq", "dlq_table": "d.s.t"}, false, ""},
+		{map[string]string{"dlq_table": "d.s.t"}, false, "dlq_table requires on_error='dlq'"},
+		{map[string]string{"on_error": "pause", "dlq_table": "d.s.t"}, false, "dlq_table requires on_error='dlq'"},
+		{map[string]string{"format": "protobuf"}, false, ""},
+		{map[string]string{"format": "protobuf"}, true, "format=protobuf is not supported with CDC queries"},
 	}
 
 	for _, test := range tests {
@@ -66,6 +72,10 @@ func TestEncodingOptionsValidations(t *testing.T) {
 		{EncodingOptions{Format: OptFormatAvro, Envelope: OptEnvelopeBare, UpdatedTimestamps: true}, "is only usable with envelope=wrapped"},
 		{EncodingOptions{Format: OptFormatAvro, Envelope: OptEnvelopeBare, MVCCTimestamps: true}, "is only usable with envelope=wrapped"},
 		{EncodingOptions{Format: OptFormatAvro, Envelope: OptEnvelopeBare, Diff: true}, "is only usable with envelope=wrapped"},
+		{EncodingOptions{Format: OptFormatJSON, Envelope: OptEnvelopeDebezium, Diff: true}, ""},
+		{EncodingOptions{Format: OptFormatAvro, Envelope: OptEnvelopeDebezium, Diff: true, UpdatedTimestamps: true}, ""},
+		{EncodingOptions{Format: OptFormatCSV, Envelope: OptEnvelopeDebezium}, "envelope=debezium is only usable with format=json or format=avro"},
+		{EncodingOptions{Format: OptFormatProtobuf, Envelope: OptEnvelopeDebezium}, "envelope=debezium is only usable with format=json or format=avro"},
 	}
 
 	for _, c := range cases {
@@ -0,0 +1,235 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// dlqDefaultSchemaName is the schema in the changefeed's current database
+	// in which the dead letter queue table is created if the dlq_table option
+	// is not specified.
+	dlqDefaultSchemaName = "crdb_changefeed"
+
+	createDLQSchemaBaseStmt = `CREATE SCHEMA IF NOT EXISTS %s.%s`
+	createDLQTableBaseStmt  = `CREATE TABLE IF NOT EXISTS %s (
+			id             INT8 DEFAULT unique_rowid(),
+			job_id         INT8 NOT NULL,
+			table_id       INT8 NOT NULL,
+			dlq_timestamp  TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+			dlq_reason     STRING NOT NULL,
+			mvcc_timestamp DECIMAL NOT NULL,
+			deleted        BOOL,
+			key            BYTES,
+			value          BYTES,
+			row            JSONB,
+			PRIMARY KEY (job_id, dlq_timestamp, id) USING HASH
+		)`
+	insertDLQBaseStmt = `INSERT INTO %s (
+			job_id,
+			table_id,
+			dlq_reason,
+			mvcc_timestamp,
+			deleted,
+			key,
+			value,
+			row
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+)
+
+// resolveDLQTableName returns the fully qualified name of the dead letter
+// queue table of the changefeed with the given ID. If the table was not
+// specified, it defaults to crdb_changefeed.dlq_<job id> in the current
+// database. Names which are not fully qualified are resolved against the
+// current database; a two part name is interpreted as schema.table.
+func resolveDLQTableName(
+	opts changefeedbase.StatementOptions, currentDatabase string, jobID jobspb.JobID,
+) (string, error) {
+	var tn tree.TableName
+	if name := opts.GetDLQTable(); name != "" {
+		parsed, err := parser.ParseQualifiedTableName(name)
+		if err != nil {
+			return "", pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+				"invalid %s", changefeedbase.OptDLQTable)
+		}
+		tn = *parsed
+		if !tn.ExplicitSchema {
+			tn.SchemaName = catconstants.PublicSchemaName
+			tn.ExplicitSchema = true
+		}
+		if !tn.ExplicitCatalog {
+			tn.CatalogName = tree.Name(currentDatabase)
+			tn.ExplicitCatalog = true
+		}
+	} else {
+		tn = tree.MakeTableNameWithSchema(
+			tree.Name(currentDatabase), dlqDefaultSchemaName, tree.Name(fmt.Sprintf("dlq_%d", jobID)))
+	}
+	if tn.CatalogName == "" {
+		return "", pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s=%s requires a current database or a fully qualified %s",
+			changefeedbase.OptOnError, changefeedbase.OptOnErrorDLQ, changefeedbase.OptDLQTable)
+	}
+	return tree.AsString(&tn), nil
+}
+
+// createDLQTable creates the given dead letter queue table, along with its
+// schema, if they do not already exist. The statements are run as the owner of
+// the changefeed so that it may only create tables where it is allowed to.
+func createDLQTable(
+	ctx context.Context, ie isql.Executor, user username.SQLUsername, table string,
+) error {
+	tn, err := parser.ParseQualifiedTableName(table)
+	if err != nil {
+		return err
+	}
+	override := sessiondata.InternalExecutorOverride{User: user}
+	createSchemaStmt := fmt.Sprintf(createDLQSchemaBaseStmt, &tn.CatalogName, &tn.SchemaName)
+	if _, err := ie.ExecEx(
+		ctx, "create-changefeed-dlq-schema", nil /* txn */, override, createSchemaStmt,
+	); err != nil {
+		return errors.Wrapf(err, "failed to create schema %s.%s", &tn.CatalogName, &tn.SchemaName)
+	}
+	createTableStmt := fmt.Sprintf(createDLQTableBaseStmt, table)
+	if _, err := ie.ExecEx(
+		ctx, "create-changefeed-dlq-table", nil /* txn */, override, createTableStmt,
+	); err != nil {
+		return errors.Wrapf(err, "failed to create dead letter queue table %s", table)
+	}
+	return nil
+}
+
+// deadLetterQueue records events which the changefeed could not emit.
+type deadLetterQueue interface {
+	// Log records that the given row could not be emitted because of reason.
+	// The key and value are the encoded message, if encoding succeeded.
+	Log(ctx context.Context, row cdcevent.Row, key, value []byte, reason error) error
+	// LogMessage records that the given message, which was encoded from a row
+	// of the given table, could not be emitted because of reason. It is used
+	// by sinks which emit messages asynchronously, and so no longer have the
+	// row by the time the message fails.
+	LogMessage(
+		ctx context.Context, tableID descpb.ID, mvcc hlc.Timestamp, key, value []byte, reason error,
+	) error
+}
+
+// sqlDeadLetterQueue writes events to a SQL table created by createDLQTable.
+type sqlDeadLetterQueue struct {
+	ie    isql.Executor
+	user  username.SQLUsername
+	jobID jobspb.JobID
+	table string
+}
+
+var _ deadLetterQueue = (*sqlDeadLetterQueue)(nil)
+
+func newSQLDeadLetterQueue(
+	ie isql.Executor, user username.SQLUsername, jobID jobspb.JobID, table string,
+) *sqlDeadLetterQueue {
+	return &sqlDeadLetterQueue{ie: ie, user: user, jobID: jobID, table: table}
+}
+
+// Log implements the deadLetterQueue interface.
+func (dlq *sqlDeadLetterQueue) Log(
+	ctx context.Context, row cdcevent.Row, key, value []byte, reason error,
+) error {
+	if !row.IsInitialized() {
+		return errors.AssertionFailedf("cdc event row not initialized")
+	}
+
+	var jsonRow tree.Datum = tree.DNull
+	if row.HasValues() {
+		j, err := row.ToJSON()
+		if err != nil {
+			log.Warningf(ctx, "failed to convert row to json for dead letter queue: %v", err)
+		} else {
+			jsonRow = j
+		}
+	}
+	return dlq.insert(
+		ctx, row.TableID, row.MvccTimestamp, tree.MakeDBool(tree.DBool(row.IsDeleted())),
+		key, value, jsonRow, reason,
+	)
+}
+
+// LogMessage implements the deadLetterQueue interface. Whether the message is
+// for a deleted row, and the row itself, are unknown and recorded as NULL.
+func (dlq *sqlDeadLetterQueue) LogMessage(
+	ctx context.Context, tableID descpb.ID, mvcc hlc.Timestamp, key, value []byte, reason error,
+) error {
+	return dlq.insert(ctx, tableID, mvcc, tree.DNull, key, value, tree.DNull, reason)
+}
+
+func (dlq *sqlDeadLetterQueue) insert(
+	ctx context.Context,
+	tableID descpb.ID,
+	mvcc hlc.Timestamp,
+	deleted tree.Datum,
+	key, value []byte,
+	row tree.Datum,
+	reason error,
+) error {
+	if _, err := dlq.ie.ExecEx(
+		ctx,
+		"insert-changefeed-dlq-row",
+		nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: dlq.user},
+		fmt.Sprintf(insertDLQBaseStmt, dlq.table),
+		int64(dlq.jobID),
+		int64(tableID),
+		reason.Error(),
+		eval.TimestampToDecimalDatum(mvcc),
+		deleted,
+		bytesOrNull(key),
+		bytesOrNull(value),
+		row,
+	); err != nil {
+		return errors.Wrapf(err, "failed to insert row into dead letter queue table %s", dlq.table)
+	}
+	return nil
+}
+
+func bytesOrNull(b []byte) tree.Datum {
+	if b == nil {
+		return tree.DNull
+	}
+	return tree.NewDBytes(tree.DBytes(b))
+}
+
+// isPermanentEncodingError returns true if the error returned by an Encoder
+// will recur every time the event is encoded, so that the event should be
+// written to the dead letter queue rather than retried. Only the errors which
+// the encoders mark with MarkPermanentEventError qualify: the failures to
+// derive a schema from the table or to encode a datum, and schemas which the
+// schema registry rejects. Any other error, such as a cancellation or a
+// failure to reach the schema registry, fails the changefeed as it would
+// without a dead letter queue. Assertion failures indicate a bug rather than a
+// bad event, so they never qualify.
+func isPermanentEncodingError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	return changefeedbase.IsPermanentEventError(err) && !errors.HasAssertionFailure(err)
+}
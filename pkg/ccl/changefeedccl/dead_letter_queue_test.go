@@ -0,0 +1,290 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/cidr"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type dlqEntry struct {
+	key, value []byte
+	reason     string
+}
+
+type recordingDLQ struct {
+	entries []dlqEntry
+}
+
+func (d *recordingDLQ) Log(
+	_ context.Context, _ cdcevent.Row, key, value []byte, reason error,
+) error {
+	d.entries = append(d.entries, dlqEntry{key: key, value: value, reason: reason.Error()})
+	return nil
+}
+
+func (d *recordingDLQ) LogMessage(
+	_ context.Context, _ descpb.ID, _ hlc.Timestamp, key, value []byte, reason error,
+) error {
+	d.entries = append(d.entries, dlqEntry{key: key, value: value, reason: reason.Error()})
+	return nil
+}
+
+type erroringEncoder struct {
+	keyErr, valueErr error
+}
+
+func (e *erroringEncoder) EncodeKey(context.Context, cdcevent.Row) ([]byte, error) {
+	if e.keyErr != nil {
+		return nil, e.keyErr
+	}
+	return []byte("key"), nil
+}
+
+func (e *erroringEncoder) EncodeValue(
+	context.Context, eventContext, cdcevent.Row, cdcevent.Row,
+) ([]byte, error) {
+	if e.valueErr != nil {
+		return nil, e.valueErr
+	}
+	return []byte("value"), nil
+}
+
+func (e *erroringEncoder) EncodeResolvedTimestamp(
+	context.Context, string, hlc.Timestamp,
+) ([]byte, error) {
+	return nil, nil
+}
+
+type erroringSink struct {
+	emitErr error
+	emitted int
+	// kafka makes the sink report that it is a kafka sink.
+	kafka bool
+}
+
+func (s *erroringSink) Dial() error  { return nil }
+func (s *erroringSink) Close() error { return nil }
+func (s *erroringSink) Flush(context.Context) error {
+	return nil
+}
+func (s *erroringSink) getConcreteType() sinkType {
+	if s.kafka {
+		return sinkTypeKafka
+	}
+	return sinkTypeNull
+}
+func (s *erroringSink) EmitRow(
+	ctx context.Context, _ TopicDescriptor, _, _ []byte, _, _ hlc.Timestamp, alloc kvevent.Alloc,
+) error {
+	if s.emitErr != nil {
+		return s.emitErr
+	}
+	alloc.Release(ctx)
+	s.emitted++
+	return nil
+}
+
+// asyncErroringSink is an erroringSink which, like the batching sink, may
+// only find out that a message can never be emitted after EmitRow returned.
+type asyncErroringSink struct {
+	erroringSink
+	dlq sinkDLQFunc
+}
+
+func (s *asyncErroringSink) setDeadLetterQueue(dlq sinkDLQFunc) {
+	s.dlq = dlq
+}
+
+type zeroFrontier struct{}
+
+func (zeroFrontier) Frontier() hlc.Timestamp { return hlc.Timestamp{} }
+
+func TestEncodeAndEmitDeadLetterQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	row := cdcevent.TestingMakeEventRowFromEncDatums(
+		rowenc.EncDatumRow{rowenc.DatumToEncDatum(types.Int, tree.NewDInt(1))},
+		[]*types.T{types.Int}, 1 /* numKeyCols */, false /* deleted */)
+	details := makeChangefeedConfigFromJobDetails(jobspb.ChangefeedDetails{
+		TargetSpecifications: []jobspb.ChangefeedTargetSpecification{
+			{TableID: row.TableID, StatementTimeName: row.TableName},
+		},
+	})
+
+	for _, tc := range []struct {
+		name      string
+		noDLQ     bool
+		keyErr    error
+		valueErr  error
+		emitErr   error
+		expectErr string
+		expectDLQ *dlqEntry
+	}{
+		{
+			name: "no error",
+		},
+		{
+			name:      "key encoding error",
+			keyErr:    changefeedbase.MarkPermanentEventError(errors.New("bad key")),
+			expectDLQ: &dlqEntry{reason: "bad key"},
+		},
+		{
+			name:      "value encoding error",
+			valueErr:  changefeedbase.MarkPermanentEventError(errors.New("bad value")),
+			expectDLQ: &dlqEntry{key: []byte("key"), reason: "bad value"},
+		},
+		{
+			name:      "encoding error without dlq",
+			noDLQ:     true,
+			valueErr:  changefeedbase.MarkPermanentEventError(errors.New("bad value")),
+			expectErr: "bad value",
+		},
+		{
+			name:      "unclassified encoding error",
+			valueErr:  errors.New("connection reset"),
+			expectErr: "connection reset",
+		},
+		{
+			name:      "retryable encoding error",
+			valueErr:  changefeedbase.MarkRetryableError(errors.New("registry unavailable")),
+			expectErr: "registry unavailable",
+		},
+		{
+			name:      "canceled encoding",
+			valueErr:  changefeedbase.MarkPermanentEventError(context.Canceled),
+			expectErr: "context canceled",
+		},
+		{
+			name:      "encoding assertion failure",
+			keyErr:    changefeedbase.MarkPermanentEventError(errors.AssertionFailedf("bug")),
+			expectErr: "bug",
+		},
+		{
+			name:      "permanent sink error",
+			emitErr:   changefeedbase.MarkPermanentEventError(errors.New("too large")),
+			expectDLQ: &dlqEntry{key: []byte("key"), value: []byte("value"), reason: "too large"},
+		},
+		{
+			name:      "sink error",
+			emitErr:   errors.New("broker unavailable"),
+			expectErr: "broker unavailable",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sliMetrics, err := MakeMetrics(base.DefaultHistogramWindowInterval(), cidr.NewTestLookup()).(*Metrics).AggMetrics.getOrCreateScope("")
+			require.NoError(t, err)
+			sink := &erroringSink{emitErr: tc.emitErr}
+			dlq := &recordingDLQ{}
+			c := &kvEventToRowConsumer{
+				frontier:             zeroFrontier{},
+				encoder:              &erroringEncoder{keyErr: tc.keyErr, valueErr: tc.valueErr},
+				sink:                 sink,
+				details:              details,
+				topicDescriptorCache: make(map[TopicIdentifier]TopicDescriptor),
+				metrics:              sliMetrics,
+			}
+			if !tc.noDLQ {
+				c.dlq = dlq
+			}
+
+			err = c.encodeAndEmit(
+				ctx, row, cdcevent.Row{}, hlc.Timestamp{WallTime: 1}, false /* backfill */, kvevent.Alloc{},
+			)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				require.Empty(t, dlq.entries)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectDLQ == nil {
+				require.Equal(t, 1, sink.emitted)
+				require.Empty(t, dlq.entries)
+				require.Zero(t, sliMetrics.DLQMessages.Value())
+				return
+			}
+			require.Equal(t, []dlqEntry{*tc.expectDLQ}, dlq.entries)
+			require.Equal(t, int64(1), sliMetrics.DLQMessages.Value())
+		})
+	}
+}
+
+func TestSinkDeadLetterQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	sliMetrics, err := MakeMetrics(base.DefaultHistogramWindowInterval(), cidr.NewTestLookup()).(*Metrics).AggMetrics.getOrCreateScope("")
+	require.NoError(t, err)
+	dlq := &recordingDLQ{}
+	c := &kvEventToRowConsumer{dlq: dlq, metrics: sliMetrics}
+
+	// The dead letter queue is set through the sink's wrappers.
+	sink := &asyncErroringSink{}
+	setSinkDeadLetterQueue(&safeSink{wrapped: &errorWrapperSink{wrapped: sink}}, c.writeMessageToDLQ)
+	require.NotNil(t, sink.dlq)
+
+	reason := changefeedbase.MarkPermanentEventError(errors.New("too large"))
+	require.NoError(t, sink.dlq(
+		ctx, topic("t"), hlc.Timestamp{WallTime: 1}, []byte("key"), []byte("value"), reason,
+	))
+	require.Equal(t, []dlqEntry{{key: []byte("key"), value: []byte("value"), reason: "too large"}}, dlq.entries)
+	require.Equal(t, int64(1), sliMetrics.DLQMessages.Value())
+}
+
+func TestResolveDLQTableName(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, tc := range []struct {
+		dlqTable  string
+		currentDB string
+		expected  string
+		expectErr string
+	}{
+		{currentDB: "d", expected: "d.crdb_changefeed.dlq_42"},
+		{currentDB: "Weird DB", expected: `"Weird DB".crdb_changefeed.dlq_42`},
+		{currentDB: "", expectErr: "requires a current database"},
+		{dlqTable: "t", currentDB: "d", expected: "d.public.t"},
+		{dlqTable: "s.t", currentDB: "d", expected: "d.s.t"},
+		{dlqTable: "o.s.t", currentDB: "d", expected: "o.s.t"},
+		{dlqTable: "o.s.t", currentDB: "", expected: "o.s.t"},
+		{dlqTable: "s.t", currentDB: "", expectErr: "requires a current database"},
+		{dlqTable: "a.b.c.d", currentDB: "d", expectErr: "invalid dlq_table"},
+	} {
+		t.Run(tc.dlqTable, func(t *testing.T) {
+			opts := map[string]string{changefeedbase.OptOnError: string(changefeedbase.OptOnErrorDLQ)}
+			if tc.dlqTable != "" {
+				opts[changefeedbase.OptDLQTable] = tc.dlqTable
+			}
+			name, err := resolveDLQTableName(changefeedbase.MakeStatementOptions(opts), tc.currentDB, 42)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, name)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+)
+
+// The debezium envelope mirrors the change event value layout of the
+// Debezium connectors so that existing Debezium consumers can read
+// changefeed output:
+//
+//	{
+//	  "before": {...} | null,
+//	  "after": {...} | null,
+//	  "source": {"connector": "cockroachdb", "db": ..., "table": ..., ...},
+//	  "op": "c" | "u" | "d" | "r",
+//	  "ts_ms": ...
+//	}
+//
+// Rangefeeds do not expose transaction IDs, so the txId field of the source is
+// derived from the commit timestamp of the change instead, since all rows
+// written by a transaction share its commit timestamp. Transactions which
+// commit at the same timestamp share a txId, which is rare but possible.
+//
+// Debezium follows every delete with a tombstone, a message with the key of
+// the deleted row and no value, so that Kafka's log compacted topics may
+// eventually drop all the messages of the row. Tombstones are only emitted to
+// Kafka sinks: other sinks have no log compaction, and would deliver them as
+// messages with an empty payload which consumers can't tell apart from
+// malformed messages. See emitsDeleteTombstones.
+
+const debeziumConnectorName = `cockroachdb`
+
+// Values of the op field of the debezium envelope.
+const (
+	debeziumOpCreate = `c`
+	debeziumOpUpdate = `u`
+	debeziumOpDelete = `d`
+	// debeziumOpRead is used for rows emitted by initial scans and schema change
+	// backfills, which Debezium calls snapshots.
+	debeziumOpRead = `r`
+)
+
+// debeziumSource is the metadata of a target table which is reported in the
+// source field of the debezium envelope.
+type debeziumSource struct {
+	clusterName string
+	clusterID   string
+	jobID       jobspb.JobID
+	database    string
+	schema      string
+}
+
+// makeDebeziumSources returns the debezium source metadata of each of the
+// target tables. The database and schema names are resolved once, when the
+// changefeed starts.
+func makeDebeziumSources(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	targets changefeedbase.Targets,
+	jobID jobspb.JobID,
+) (map[descpb.ID]*debeziumSource, error) {
+	var clusterName, clusterID string
+	if execCfg.RPCContext != nil {
+		clusterName = execCfg.RPCContext.ClusterName()
+	}
+	if execCfg.NodeInfo.LogicalClusterID != nil {
+		clusterID = execCfg.NodeInfo.LogicalClusterID().String()
+	}
+
+	sources := make(map[descpb.ID]*debeziumSource, targets.NumUniqueTables())
+	if err := execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		return targets.EachTableID(func(id descpb.ID) error {
+			desc, err := txn.Descriptors().ByIDWithoutLeased(txn.KV()).Get().Table(ctx, id)
+			if err != nil {
+				return err
+			}
+			tbName, err := getQualifiedTableNameObj(ctx, execCfg, txn.KV(), desc)
+			if err != nil {
+				return err
+			}
+			sources[id] = &debeziumSource{
+				clusterName: clusterName,
+				clusterID:   clusterID,
+				jobID:       jobID,
+				database:    tbName.Catalog(),
+				schema:      tbName.Schema(),
+			}
+			return nil
+		})
+	}, isql.WithPriority(admissionpb.LowPri)); err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+// debeziumOp returns the value of the op field of the debezium envelope for
+// the given event. It relies on the previous row, which is always requested
+// for the debezium envelope, to distinguish inserts from updates.
+func debeziumOp(evCtx eventContext, updated, prev cdcevent.Row) string {
+	switch {
+	case evCtx.backfill:
+		return debeziumOpRead
+	case updated.IsDeleted():
+		return debeziumOpDelete
+	case prev.HasValues() && !prev.IsDeleted():
+		return debeziumOpUpdate
+	default:
+		return debeziumOpCreate
+	}
+}
+
+// debeziumSourceField describes a field of the source field of the debezium
+// envelope.
+type debeziumSourceField struct {
+	name     string
+	avroType avroSchemaType
+}
+
+// debeziumSourceFields are the fields of the source field of the debezium
+// envelope, in the order in which they appear in its avro schema.
+var debeziumSourceFields = []debeziumSourceField{
+	{name: `connector`, avroType: avroSchemaString},
+	{name: `name`, avroType: avroSchemaString},
+	{name: `cluster_id`, avroType: avroSchemaString},
+	{name: `job_id`, avroType: avroSchemaString},
+	{name: `ts_ms`, avroType: avroSchemaLong},
+	{name: `ts_ns`, avroType: avroSchemaLong},
+	{name: `ts_hlc`, avroType: avroSchemaString},
+	{name: `snapshot`, avroType: avroSchemaString},
+	{name: `db`, avroType: avroSchemaString},
+	{name: `schema`, avroType: avroSchemaString},
+	{name: `table`, avroType: avroSchemaString},
+	{name: `txId`, avroType: avroSchemaString},
+}
+
+// debeziumTxID returns the txId of the source field of the debezium envelope
+// for a change committed at the given timestamp.
+func debeziumTxID(mvcc hlc.Timestamp) string {
+	return mvcc.AsOfSystemTime()
+}
+
+// debeziumSourceNative returns the source field of the debezium envelope for
+// the given event as a map of field names to strings and int64s, which is also
+// its go native avro representation.
+func debeziumSourceNative(evCtx eventContext, row cdcevent.Row) map[string]interface{} {
+	src := evCtx.source
+	if src == nil {
+		// Encoders used outside of a changefeed, such as by
+		// crdb_internal.to_json_as_changefeed_with_flags, have no source
+		// metadata.
+		src = &debeziumSource{}
+	}
+	var jobID string
+	if src.jobID != 0 {
+		jobID = strconv.FormatInt(int64(src.jobID), 10)
+	}
+	return map[string]interface{}{
+		`connector`:  debeziumConnectorName,
+		`name`:       src.clusterName,
+		`cluster_id`: src.clusterID,
+		`job_id`:     jobID,
+		`ts_ms`:      evCtx.mvcc.WallTime / 1e6,
+		`ts_ns`:      evCtx.mvcc.WallTime,
+		`ts_hlc`:     eval.TimestampToDecimalDatum(evCtx.mvcc).Decimal.String(),
+		`snapshot`:   strconv.FormatBool(evCtx.backfill),
+		`db`:         src.database,
+		`schema`:     src.schema,
+		`table`:      row.TableName,
+		`txId`:       debeziumTxID(evCtx.mvcc),
+	}
+}
+
+// debeziumSourceToAvroSchema returns the avro schema of the source field of
+// the debezium envelope. As in the schemas of the Debezium connectors, its
+// fields are not optional, since they are always set.
+func debeziumSourceToAvroSchema(namespace string) *avroRecord {
+	schema := &avroRecord{
+		Name:       `source`,
+		SchemaType: `record`,
+		Namespace:  namespace,
+	}
+	for _, f := range debeziumSourceFields {
+		schema.Fields = append(schema.Fields, &avroSchemaField{
+			Name:       f.name,
+			SchemaType: f.avroType,
+			required:   true,
+		})
+	}
+	return schema
+}
@@ -0,0 +1,210 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	gojson "encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/cidr"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebeziumEnvelope(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+	require.NoError(t, err)
+	targets := mkTargets(tableDesc)
+
+	row := rowenc.EncDatumRow{
+		rowenc.EncDatum{Datum: tree.NewDInt(1)},
+		rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+	}
+	updatedRow := rowenc.EncDatumRow{
+		rowenc.EncDatum{Datum: tree.NewDInt(1)},
+		rowenc.EncDatum{Datum: tree.NewDString(`baz`)},
+	}
+	ts := hlc.Timestamp{WallTime: 2e9, Logical: 1}
+	source := &debeziumSource{
+		clusterName: `cockroach`,
+		clusterID:   `b0a8b1d2-6b39-4b6c-8a7e-7e2b4a8d7e8c`,
+		jobID:       42,
+		database:    `d`,
+		schema:      `public`,
+	}
+
+	const expectedSource = `{
+		"cluster_id": "b0a8b1d2-6b39-4b6c-8a7e-7e2b4a8d7e8c", "connector": "cockroachdb",
+		"db": "d", "job_id": "42", "name": "cockroach", "schema": "public",
+		"snapshot": %s, "table": "foo", "ts_hlc": "2000000000.0000000001",
+		"ts_ms": 2000, "ts_ns": 2000000000, "txId": "2000000000.0000000001"
+	}`
+
+	for _, tc := range []struct {
+		name           string
+		updated, prev  rowenc.EncDatumRow
+		deleted        bool
+		backfill       bool
+		expectedOp     string
+		expectedBefore string
+		expectedAfter  string
+	}{
+		{
+			name:           "insert",
+			updated:        row,
+			expectedOp:     `c`,
+			expectedBefore: `null`,
+			expectedAfter:  `{"a": 1, "b": "bar"}`,
+		},
+		{
+			name:           "update",
+			updated:        updatedRow,
+			prev:           row,
+			expectedOp:     `u`,
+			expectedBefore: `{"a": 1, "b": "bar"}`,
+			expectedAfter:  `{"a": 1, "b": "baz"}`,
+		},
+		{
+			name:           "delete",
+			updated:        row,
+			prev:           row,
+			deleted:        true,
+			expectedOp:     `d`,
+			expectedBefore: `{"a": 1, "b": "bar"}`,
+			expectedAfter:  `null`,
+		},
+		{
+			name:           "backfill",
+			updated:        row,
+			prev:           row,
+			backfill:       true,
+			expectedOp:     `r`,
+			expectedBefore: `null`,
+			expectedAfter:  `{"a": 1, "b": "bar"}`,
+		},
+	} {
+		evCtx := eventContext{updated: ts, mvcc: ts, backfill: tc.backfill, source: source}
+		snapshot := `"false"`
+		if tc.backfill {
+			snapshot = `"true"`
+		}
+		updated := cdcevent.TestingMakeEventRow(tableDesc, 0, tc.updated, tc.deleted)
+		prev := cdcevent.TestingMakeEventRow(tableDesc, 0, tc.prev, false)
+
+		t.Run(`json/`+tc.name, func(t *testing.T) {
+			opts := changefeedbase.EncodingOptions{
+				Format: changefeedbase.OptFormatJSON, Envelope: changefeedbase.OptEnvelopeDebezium, Diff: true,
+			}
+			require.NoError(t, opts.Validate())
+			e, err := getEncoder(ctx, opts, targets, false, nil, nil)
+			require.NoError(t, err)
+
+			value, err := e.EncodeValue(ctx, evCtx, updated, prev)
+			require.NoError(t, err)
+
+			var envelope map[string]interface{}
+			require.NoError(t, gojson.Unmarshal(value, &envelope))
+			require.Contains(t, envelope, `ts_ms`)
+			delete(envelope, `ts_ms`)
+			actual, err := gojson.Marshal(envelope)
+			require.NoError(t, err)
+
+			expected := `{"after": ` + tc.expectedAfter + `, "before": ` + tc.expectedBefore +
+				`, "op": "` + tc.expectedOp + `", "source": ` +
+				fmt.Sprintf(expectedSource, snapshot) + `}`
+			require.Equal(t, string(normalizeJson(t, []byte(expected))), string(actual))
+		})
+
+		t.Run(`avro/`+tc.name, func(t *testing.T) {
+			reg := cdctest.StartTestSchemaRegistry()
+			defer reg.Close()
+			opts := changefeedbase.EncodingOptions{
+				Format: changefeedbase.OptFormatAvro, Envelope: changefeedbase.OptEnvelopeDebezium, Diff: true,
+				SchemaRegistryURI: reg.URL(),
+			}
+			require.NoError(t, opts.Validate())
+			e, err := getEncoder(ctx, opts, targets, false, nil, nil)
+			require.NoError(t, err)
+
+			value, err := e.EncodeValue(ctx, evCtx, updated, prev)
+			require.NoError(t, err)
+
+			var envelope map[string]interface{}
+			require.NoError(t, gojson.Unmarshal(avroToJSON(t, reg, value), &envelope))
+			// The op and source fields are required, so they aren't unions.
+			require.Equal(t, tc.expectedOp, envelope[`op`])
+			var expectedSourceNative interface{}
+			require.NoError(t, gojson.Unmarshal([]byte(fmt.Sprintf(expectedSource, snapshot)), &expectedSourceNative))
+			require.Equal(t, expectedSourceNative, envelope[`source`])
+			require.Contains(t, envelope, `ts_ms`)
+			require.Equal(t, tc.expectedBefore == `null`, envelope[`before`] == nil)
+			require.Equal(t, tc.expectedAfter == `null`, envelope[`after`] == nil)
+		})
+	}
+}
+
+func TestDebeziumEnvelopeTombstones(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		envelope changefeedbase.EnvelopeType
+		deleted  bool
+		kafka    bool
+		expected int
+	}{
+		{envelope: changefeedbase.OptEnvelopeDebezium, deleted: false, kafka: true, expected: 1},
+		{envelope: changefeedbase.OptEnvelopeDebezium, deleted: true, kafka: true, expected: 2},
+		{envelope: changefeedbase.OptEnvelopeWrapped, deleted: true, kafka: true, expected: 1},
+		// Only kafka sinks get tombstones.
+		{envelope: changefeedbase.OptEnvelopeDebezium, deleted: true, kafka: false, expected: 1},
+	} {
+		row := cdcevent.TestingMakeEventRowFromEncDatums(
+			rowenc.EncDatumRow{rowenc.DatumToEncDatum(types.Int, tree.NewDInt(1))},
+			[]*types.T{types.Int}, 1 /* numKeyCols */, tc.deleted)
+		details := makeChangefeedConfigFromJobDetails(jobspb.ChangefeedDetails{
+			TargetSpecifications: []jobspb.ChangefeedTargetSpecification{
+				{TableID: row.TableID, StatementTimeName: row.TableName},
+			},
+		})
+
+		sliMetrics, err := MakeMetrics(base.DefaultHistogramWindowInterval(), cidr.NewTestLookup()).(*Metrics).AggMetrics.getOrCreateScope("")
+		require.NoError(t, err)
+		sink := &erroringSink{kafka: tc.kafka}
+		c := &kvEventToRowConsumer{
+			frontier:             zeroFrontier{},
+			encoder:              &erroringEncoder{},
+			sink:                 sink,
+			details:              details,
+			encodingOpts:         changefeedbase.EncodingOptions{Envelope: tc.envelope},
+			topicDescriptorCache: make(map[TopicIdentifier]TopicDescriptor),
+			metrics:              sliMetrics,
+		}
+		require.NoError(t, c.encodeAndEmit(
+			ctx, row, cdcevent.Row{}, hlc.Timestamp{WallTime: 1}, false /* backfill */, kvevent.Alloc{},
+		))
+		require.Equal(t, tc.expected, sink.emitted)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/util/cache"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
 
@@ -121,17 +122,26 @@ func newConfluentAvroEncoder(
 // Get the raw SQL-formatted string for a table name
 // and apply full_table_name and avro_schema_prefix options
 func (e *confluentAvroEncoder) rawTableName(eventMeta cdcevent.Metadata) (string, error) {
-	target, found := e.targets.FindByTableIDAndFamilyName(eventMeta.TableID, eventMeta.FamilyName)
+	return rawTableName(e.targets, e.schemaPrefix, eventMeta)
+}
+
+// rawTableName returns the raw SQL-formatted string for the name of the table
+// of the event, with the given schema prefix. It is shared by the encoders
+// that register schemas with a Confluent schema registry.
+func rawTableName(
+	targets changefeedbase.Targets, schemaPrefix string, eventMeta cdcevent.Metadata,
+) (string, error) {
+	target, found := targets.FindByTableIDAndFamilyName(eventMeta.TableID, eventMeta.FamilyName)
 	if !found {
 		return eventMeta.TableName, errors.Newf("Could not find Target for %s", eventMeta)
 	}
 	switch target.Type {
 	case jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY:
-		return e.schemaPrefix + string(target.StatementTimeName), nil
+		return schemaPrefix + string(target.StatementTimeName), nil
 	case jobspb.ChangefeedTargetSpecification_EACH_FAMILY:
-		return fmt.Sprintf("%s%s.%s", e.schemaPrefix, target.StatementTimeName, eventMeta.FamilyName), nil
+		return fmt.Sprintf("%s%s.%s", schemaPrefix, target.StatementTimeName, eventMeta.FamilyName), nil
 	case jobspb.ChangefeedTargetSpecification_COLUMN_FAMILY:
-		return fmt.Sprintf("%s%s.%s", e.schemaPrefix, target.StatementTimeName, target.FamilyName), nil
+		return fmt.Sprintf("%s%s.%s", schemaPrefix, target.StatementTimeName, target.FamilyName), nil
 	default:
 		return "", errors.AssertionFailedf("Found a matching target with unimplemented type %s", target.Type)
 	}
@@ -158,7 +168,7 @@ func (e *confluentAvroEncoder) EncodeKey(ctx context.Context, row cdcevent.Row)
 		if e.customKeyColumn == "" {
 			registered.schema, err = primaryIndexToAvroSchema(row, tableName, e.schemaPrefix)
 			if err != nil {
-				return nil, err
+				return nil, changefeedbase.MarkPermanentEventError(err)
 			}
 		} else {
 			it, err := row.DatumNamed(e.customKeyColumn)
@@ -167,7 +177,7 @@ func (e *confluentAvroEncoder) EncodeKey(ctx context.Context, row cdcevent.Row)
 			}
 			registered.schema, err = newSchemaForRow(it, SQLNameToAvroName(tableName), e.schemaPrefix)
 			if err != nil {
-				return nil, err
+				return nil, changefeedbase.MarkPermanentEventError(err)
 			}
 		}
 
@@ -240,13 +250,21 @@ func (e *confluentAvroEncoder) EncodeValue(
 			var err error
 			beforeDataSchema, err = tableToAvroSchema(prevRow, `before`, e.schemaPrefix)
 			if err != nil {
-				return nil, err
+				return nil, changefeedbase.MarkPermanentEventError(err)
+			}
+		} else if e.envelopeType == changefeedbase.OptEnvelopeDebezium {
+			// The debezium envelope always has a before field, so we fall back to
+			// the schema of the current row when there is no previous row.
+			var err error
+			beforeDataSchema, err = tableToAvroSchema(updatedRow, `before`, e.schemaPrefix)
+			if err != nil {
+				return nil, changefeedbase.MarkPermanentEventError(err)
 			}
 		}
 
 		currentSchema, err := tableToAvroSchema(updatedRow, avroSchemaNoSuffix, e.schemaPrefix)
 		if err != nil {
-			return nil, err
+			return nil, changefeedbase.MarkPermanentEventError(err)
 		}
 
 		var opts avroEnvelopeOpts
@@ -255,10 +273,14 @@ func (e *confluentAvroEncoder) EncodeValue(
 		// it goes in the "record" field. In the "key_only" envelope it's omitted.
 		// This means metadata can safely go at the top level as there are never arbitrary column names
 		// for it to conflict with.
-		if e.envelopeType == changefeedbase.OptEnvelopeWrapped {
+		switch e.envelopeType {
+		case changefeedbase.OptEnvelopeWrapped:
 			opts = avroEnvelopeOpts{afterField: true, beforeField: e.beforeField, updatedField: e.updatedField, mvccTimestampField: e.mvccTimestampField}
 			afterDataSchema = currentSchema
-		} else {
+		case changefeedbase.OptEnvelopeDebezium:
+			opts = avroEnvelopeOpts{afterField: true, beforeField: true, debeziumFields: true, updatedField: e.updatedField, mvccTimestampField: e.mvccTimestampField}
+			afterDataSchema = currentSchema
+		default:
 			opts = avroEnvelopeOpts{recordField: true, updatedField: e.updatedField, mvccTimestampField: e.mvccTimestampField}
 			recordDataSchema = currentSchema
 		}
@@ -270,7 +292,7 @@ func (e *confluentAvroEncoder) EncodeValue(
 		registered.schema, err = envelopeToAvroSchema(name, opts, beforeDataSchema, afterDataSchema, recordDataSchema, e.schemaPrefix)
 
 		if err != nil {
-			return nil, err
+			return nil, changefeedbase.MarkPermanentEventError(err)
 		}
 
 		// NB: This uses the kafka name escaper because it has to match the name
@@ -290,6 +312,17 @@ func (e *confluentAvroEncoder) EncodeValue(
 	if registered.schema.opts.mvccTimestampField {
 		meta[`mvcc_timestamp`] = evCtx.mvcc
 	}
+	if registered.schema.opts.debeziumFields {
+		op := debeziumOp(evCtx, updatedRow, prevRow)
+		meta[`source`] = debeziumSourceNative(evCtx, updatedRow)
+		meta[`op`] = op
+		meta[`ts_ms`] = timeutil.Now().UnixMilli()
+		if op == debeziumOpRead {
+			// Rows read by a backfill have no before value, even though the
+			// previous row of a schema change backfill is the same row.
+			prevRow = cdcevent.Row{}
+		}
+	}
 
 	// https://docs.confluent.io/current/schema-registry/docs/serializer-formatter.html#wire-format
 	header := []byte{
@@ -342,5 +375,7 @@ func (e *confluentAvroEncoder) EncodeResolvedTimestamp(
 func (e *confluentAvroEncoder) register(
 	ctx context.Context, schema *avroRecord, subject string,
 ) (int32, error) {
-	return e.schemaRegistry.RegisterSchemaForSubject(ctx, subject, schema.codec.Schema())
+	return e.schemaRegistry.RegisterSchemaForSubject(
+		ctx, subject, schema.codec.Schema(), confluentSchemaTypeAvro,
+	)
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
 
@@ -64,9 +65,10 @@ var _ Encoder = &jsonEncoder{}
 
 func canJSONEncodeMetadata(e changefeedbase.EnvelopeType) bool {
 	// bare envelopes use the _crdb_ key to avoid collisions with column names.
-	// wrapped envelopes can put metadata at the top level because the columns
-	// are nested under the "after:" key.
-	return e == changefeedbase.OptEnvelopeBare || e == changefeedbase.OptEnvelopeWrapped
+	// wrapped and debezium envelopes can put metadata at the top level because
+	// the columns are nested under the "after:" key.
+	return e == changefeedbase.OptEnvelopeBare || e == changefeedbase.OptEnvelopeWrapped ||
+		e == changefeedbase.OptEnvelopeDebezium
 }
 
 // getCachedOrCreate returns cached object, or creates and caches new one.
@@ -131,11 +133,16 @@ func makeJSONEncoder(ctx context.Context, opts jsonEncoderOptions) (*jsonEncoder
 		}
 	}
 
-	if e.envelopeType == changefeedbase.OptEnvelopeWrapped {
+	switch e.envelopeType {
+	case changefeedbase.OptEnvelopeWrapped:
 		if err := e.initWrappedEnvelope(ctx); err != nil {
 			return nil, err
 		}
-	} else {
+	case changefeedbase.OptEnvelopeDebezium:
+		if err := e.initDebeziumEnvelope(ctx); err != nil {
+			return nil, err
+		}
+	default:
 		if err := e.initRawEnvelope(ctx); err != nil {
 			return nil, err
 		}
@@ -250,7 +257,7 @@ var jsonNullObjectCollisionLogLim = log.Every(10 * time.Second)
 func (e *versionEncoder) datumToJSON(ctx context.Context, d tree.Datum) (json.JSON, error) {
 	j, err := tree.AsJSON(d, sessiondatapb.DataConversionConfig{}, time.UTC)
 	if err != nil {
-		return nil, err
+		return nil, changefeedbase.MarkPermanentEventError(err)
 	}
 
 	if e.encodeJSONValueNullAsObject {
@@ -434,6 +441,113 @@ func (e *jsonEncoder) initWrappedEnvelope(ctx context.Context) error {
 	return nil
 }
 
+func (e *jsonEncoder) initDebeziumEnvelope(ctx context.Context) error {
+	keys := []string{"before", "after", "source", "op", "ts_ms"}
+	if e.keyInValue {
+		keys = append(keys, "key")
+	}
+	if e.topicInValue {
+		keys = append(keys, "topic")
+	}
+	if e.updatedField {
+		keys = append(keys, "updated")
+	}
+	if e.mvccTimestampField {
+		keys = append(keys, "mvcc_timestamp")
+	}
+	b, err := json.NewFixedKeysObjectBuilder(keys)
+	if err != nil {
+		return err
+	}
+	sourceKeys := make([]string, len(debeziumSourceFields))
+	for i, f := range debeziumSourceFields {
+		sourceKeys[i] = f.name
+	}
+	sb, err := json.NewFixedKeysObjectBuilder(sourceKeys)
+	if err != nil {
+		return err
+	}
+
+	const emitDeletedRowAsNull = true
+	e.envelopeEncoder = func(evCtx eventContext, updated, prev cdcevent.Row) (json.JSON, error) {
+		op := debeziumOp(evCtx, updated, prev)
+
+		ve := e.versionEncoder(updated.EventDescriptor, false)
+		after, err := ve.rowAsGoNative(ctx, updated, emitDeletedRowAsNull, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.Set("after", after); err != nil {
+			return nil, err
+		}
+
+		// Rows read by a backfill have no before value, even though the
+		// previous row of a schema change backfill is the same row.
+		var before json.JSON = json.NullJSONValue
+		if op != debeziumOpRead && prev.IsInitialized() && !prev.IsDeleted() {
+			before, err = e.versionEncoder(prev.EventDescriptor, true).rowAsGoNative(ctx, prev, emitDeletedRowAsNull, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := b.Set("before", before); err != nil {
+			return nil, err
+		}
+
+		native := debeziumSourceNative(evCtx, updated)
+		for _, f := range debeziumSourceFields {
+			j, err := json.MakeJSON(native[f.name])
+			if err != nil {
+				return nil, err
+			}
+			if err := sb.Set(f.name, j); err != nil {
+				return nil, err
+			}
+		}
+		source, err := sb.Build()
+		if err != nil {
+			return nil, err
+		}
+		if err := b.Set("source", source); err != nil {
+			return nil, err
+		}
+
+		if err := b.Set("op", json.FromString(op)); err != nil {
+			return nil, err
+		}
+		if err := b.Set("ts_ms", json.FromInt64(timeutil.Now().UnixMilli())); err != nil {
+			return nil, err
+		}
+
+		if e.keyInValue {
+			if err := ve.encodeKeyInValue(ctx, updated, b); err != nil {
+				return nil, err
+			}
+		}
+
+		if e.topicInValue {
+			if err := b.Set("topic", json.FromString(evCtx.topic)); err != nil {
+				return nil, err
+			}
+		}
+
+		if e.updatedField {
+			if err := b.Set("updated", json.FromString(evCtx.updated.AsOfSystemTime())); err != nil {
+				return nil, err
+			}
+		}
+
+		if e.mvccTimestampField {
+			if err := b.Set("mvcc_timestamp", json.FromString(evCtx.mvcc.AsOfSystemTime())); err != nil {
+				return nil, err
+			}
+		}
+
+		return b.Build()
+	}
+	return nil
+}
+
 // EncodeValue implements the Encoder interface.
 func (e *jsonEncoder) EncodeValue(
 	ctx context.Context, evCtx eventContext, updatedRow cdcevent.Row, prevRow cdcevent.Row,
@@ -463,7 +577,8 @@ func (e *jsonEncoder) EncodeResolvedTimestamp(
 		`resolved`: eval.TimestampToDecimalDatum(resolved).Decimal.String(),
 	}
 	var jsonEntries interface{}
-	if e.envelopeType == changefeedbase.OptEnvelopeWrapped {
+	if e.envelopeType == changefeedbase.OptEnvelopeWrapped ||
+		e.envelopeType == changefeedbase.OptEnvelopeDebezium {
 		jsonEntries = meta
 	} else {
 		jsonEntries = map[string]interface{}{
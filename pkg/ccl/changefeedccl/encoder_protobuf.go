@@ -0,0 +1,276 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// confluentProtobufEncoder encodes changefeed entries in the Confluent
+// Protobuf wire format, registering the generated schemas with a Confluent
+// schema registry. Keys are the primary key columns in a message. Values are
+// all columns in a message, which is wrapped in an envelope message for the
+// wrapped envelope.
+type confluentProtobufEncoder struct {
+	schemaRegistry            schemaRegistry
+	schemaPrefix              string
+	updatedField, beforeField bool
+	mvccTimestampField        bool
+	targets                   changefeedbase.Targets
+	envelopeType              changefeedbase.EnvelopeType
+	customKeyColumn           string
+
+	keyCache   *cache.UnorderedCache // [tableIDAndVersion]confluentRegisteredProtobufKeySchema
+	valueCache *cache.UnorderedCache // [tableIDAndVersionPair]confluentRegisteredProtobufEnvelopeSchema
+
+	// resolvedCache doesn't need to be bounded like the other caches because the number of topics
+	// is fixed per changefeed.
+	resolvedCache map[string]confluentRegisteredProtobufEnvelopeSchema
+}
+
+type confluentRegisteredProtobufKeySchema struct {
+	record     *protobufRecord
+	registryID int32
+}
+
+type confluentRegisteredProtobufEnvelopeSchema struct {
+	// Exactly one of envelope and record is set, depending on whether
+	// the row data is wrapped.
+	envelope   *protobufEnvelope
+	record     *protobufRecord
+	registryID int32
+}
+
+var _ Encoder = &confluentProtobufEncoder{}
+
+func newConfluentProtobufEncoder(
+	opts changefeedbase.EncodingOptions,
+	targets changefeedbase.Targets,
+	p externalConnectionProvider,
+	sliMetrics *sliMetrics,
+) (*confluentProtobufEncoder, error) {
+	e := &confluentProtobufEncoder{
+		schemaPrefix:       opts.AvroSchemaPrefix,
+		targets:            targets,
+		envelopeType:       opts.Envelope,
+		updatedField:       opts.UpdatedTimestamps,
+		beforeField:        opts.Diff,
+		customKeyColumn:    opts.CustomKeyColumn,
+		mvccTimestampField: opts.MVCCTimestamps,
+	}
+
+	if opts.KeyInValue {
+		return nil, errors.Errorf(`%s is not supported with %s=%s`,
+			changefeedbase.OptKeyInValue, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+	if opts.TopicInValue {
+		return nil, errors.Errorf(`%s is not supported with %s=%s`,
+			changefeedbase.OptTopicInValue, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+	if len(opts.SchemaRegistryURI) == 0 {
+		return nil, errors.Errorf(`WITH option %s is required for %s=%s`,
+			changefeedbase.OptConfluentSchemaRegistry, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+
+	reg, err := newConfluentSchemaRegistry(opts.SchemaRegistryURI, p, sliMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	e.schemaRegistry = reg
+	e.keyCache = cache.NewUnorderedCache(encoderCacheConfig)
+	e.valueCache = cache.NewUnorderedCache(encoderCacheConfig)
+	e.resolvedCache = make(map[string]confluentRegisteredProtobufEnvelopeSchema)
+	return e, nil
+}
+
+// EncodeKey implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeKey(
+	ctx context.Context, row cdcevent.Row,
+) ([]byte, error) {
+	// No familyID in the cache key for keys because it's the same schema for all families
+	cacheKey := tableIDAndVersion{tableID: row.TableID, version: row.Version}
+
+	it := row.ForEachKeyColumn()
+	if e.customKeyColumn != "" {
+		var err error
+		it, err = row.DatumNamed(e.customKeyColumn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var registered confluentRegisteredProtobufKeySchema
+	if v, ok := e.keyCache.Get(cacheKey); ok {
+		registered = v.(confluentRegisteredProtobufKeySchema)
+	} else {
+		tableName, err := rawTableName(e.targets, e.schemaPrefix, row.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		registered.record, err = newProtobufRecord(it, SQLNameToAvroName(tableName))
+		if err != nil {
+			return nil, err
+		}
+		schema, err := newProtobufSchema(registered.record.desc)
+		if err != nil {
+			return nil, err
+		}
+
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := SQLNameToKafkaName(tableName) + confluentSubjectSuffixKey
+		registered.registryID, err = e.registerProtobuf(ctx, schema, subject)
+		if err != nil {
+			return nil, err
+		}
+		e.keyCache.Add(cacheKey, registered)
+	}
+
+	return registered.record.appendRow(confluentProtobufHeader(registered.registryID), it)
+}
+
+// EncodeValue implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeValue(
+	ctx context.Context, evCtx eventContext, updatedRow cdcevent.Row, prevRow cdcevent.Row,
+) ([]byte, error) {
+	if e.envelopeType == changefeedbase.OptEnvelopeKeyOnly {
+		return nil, nil
+	}
+	wrapped := e.envelopeType == changefeedbase.OptEnvelopeWrapped
+	if !wrapped && updatedRow.IsDeleted() {
+		// Without an envelope, there is nothing to encode for a deletion.
+		return nil, nil
+	}
+
+	var cacheKey tableIDAndVersionPair
+	if e.beforeField && prevRow.IsInitialized() {
+		cacheKey[0] = tableIDAndVersion{
+			tableID: prevRow.TableID, version: prevRow.Version, familyID: prevRow.FamilyID,
+		}
+	}
+	cacheKey[1] = tableIDAndVersion{
+		tableID: updatedRow.TableID, version: updatedRow.Version, familyID: updatedRow.FamilyID,
+	}
+
+	var registered confluentRegisteredProtobufEnvelopeSchema
+	if v, ok := e.valueCache.Get(cacheKey); ok {
+		registered = v.(confluentRegisteredProtobufEnvelopeSchema)
+	} else {
+		name, err := rawTableName(e.targets, e.schemaPrefix, updatedRow.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		recordName := SQLNameToAvroName(name)
+		current, err := newProtobufRecord(updatedRow.ForEachColumn(), recordName)
+		if err != nil {
+			return nil, err
+		}
+
+		var schema *protobufSchema
+		if wrapped {
+			var before *protobufRecord
+			if e.beforeField && prevRow.IsInitialized() {
+				before, err = newProtobufRecord(prevRow.ForEachColumn(), recordName+`_before`)
+				if err != nil {
+					return nil, err
+				}
+			}
+			opts := protobufEnvelopeOpts{
+				afterField:         true,
+				beforeField:        e.beforeField,
+				updatedField:       e.updatedField,
+				mvccTimestampField: e.mvccTimestampField,
+			}
+			registered.envelope = newProtobufEnvelope(name, opts, before, current)
+			schema, err = newProtobufSchema(registered.envelope.messages()...)
+		} else {
+			registered.record = current
+			schema, err = newProtobufSchema(current.desc)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := SQLNameToKafkaName(name) + confluentSubjectSuffixValue
+		registered.registryID, err = e.registerProtobuf(ctx, schema, subject)
+		if err != nil {
+			return nil, err
+		}
+		e.valueCache.Add(cacheKey, registered)
+	}
+
+	header := confluentProtobufHeader(registered.registryID)
+	if registered.record != nil {
+		return registered.record.appendRow(header, updatedRow.ForEachColumn())
+	}
+	meta := protobufMetadata{updated: evCtx.updated, mvcc: evCtx.mvcc}
+	return registered.envelope.appendEnvelope(header, meta, prevRow, updatedRow)
+}
+
+// EncodeResolvedTimestamp implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeResolvedTimestamp(
+	ctx context.Context, topic string, resolved hlc.Timestamp,
+) ([]byte, error) {
+	registered, ok := e.resolvedCache[topic]
+	if !ok {
+		opts := protobufEnvelopeOpts{resolvedField: true}
+		registered.envelope = newProtobufEnvelope(topic, opts, nil /* before */, nil /* after */)
+		schema, err := newProtobufSchema(registered.envelope.messages()...)
+		if err != nil {
+			return nil, err
+		}
+
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := SQLNameToKafkaName(topic) + confluentSubjectSuffixValue
+		registered.registryID, err = e.registerProtobuf(ctx, schema, subject)
+		if err != nil {
+			return nil, err
+		}
+
+		e.resolvedCache[topic] = registered
+	}
+	var nilRow cdcevent.Row
+	meta := protobufMetadata{resolved: resolved}
+	return registered.envelope.appendEnvelope(
+		confluentProtobufHeader(registered.registryID), meta, nilRow, nilRow,
+	)
+}
+
+func (e *confluentProtobufEncoder) registerProtobuf(
+	ctx context.Context, schema *protobufSchema, subject string,
+) (int32, error) {
+	return e.schemaRegistry.RegisterSchemaForSubject(
+		ctx, subject, schema.text, confluentSchemaTypeProtobuf,
+	)
+}
+
+// confluentProtobufHeader returns the header of a message encoded with the
+// schema with the given ID.
+//
+// https://docs.confluent.io/platform/current/schema-registry/fundamentals/serdes-develop/index.html#wire-format
+func confluentProtobufHeader(registryID int32) []byte {
+	header := []byte{
+		changefeedbase.ConfluentAvroWireFormatMagic,
+		0, 0, 0, 0, // Placeholder for the ID.
+		// The message indexes locate the encoded message in the schema. The
+		// encoded message is always the first one, whose indexes, [0], are
+		// abbreviated as a single zero.
+		0,
+	}
+	binary.BigEndian.PutUint32(header[1:5], uint32(registryID))
+	return header
+}
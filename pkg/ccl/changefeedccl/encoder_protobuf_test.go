@@ -0,0 +1,426 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	gojson "encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// protobufToJSON decodes a message in the Confluent Protobuf wire format and
+// returns its JSON representation, with the keys sorted.
+func protobufToJSON(t *testing.T, desc protoreflect.MessageDescriptor, b []byte) string {
+	t.Helper()
+	require.GreaterOrEqual(t, len(b), 6)
+	require.Equal(t, changefeedbase.ConfluentAvroWireFormatMagic, b[0])
+	// The message indexes of the first message of the schema.
+	require.Equal(t, byte(0), b[5])
+
+	msg := dynamicpb.NewMessage(desc)
+	require.NoError(t, proto.Unmarshal(b[6:], msg))
+	require.Empty(t, msg.GetUnknown())
+	j, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	require.NoError(t, err)
+
+	// protojson output is deliberately unstable, so normalize it.
+	var v interface{}
+	require.NoError(t, gojson.Unmarshal(j, &v))
+	j, err = gojson.Marshal(v)
+	require.NoError(t, err)
+	return string(j)
+}
+
+func TestProtobufEncoder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tableDesc, err := parseTableDesc(
+		`CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c FLOAT, d BOOL, e DECIMAL, f BYTES)`)
+	require.NoError(t, err)
+	dec, err := tree.ParseDDecimal(`2.50`)
+	require.NoError(t, err)
+	row := rowenc.EncDatumRow{
+		rowenc.EncDatum{Datum: tree.NewDInt(1)},
+		rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+		rowenc.EncDatum{Datum: tree.NewDFloat(1.5)},
+		rowenc.EncDatum{Datum: tree.DNull},
+		rowenc.EncDatum{Datum: dec},
+		rowenc.EncDatum{Datum: tree.NewDBytes("\x01")},
+	}
+	ts := hlc.Timestamp{WallTime: 1, Logical: 2}
+
+	targets := changefeedbase.Targets{}
+	targets.Add(changefeedbase.Target{
+		Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+		TableID:           tableDesc.GetID(),
+		StatementTimeName: changefeedbase.StatementTimeName(tableDesc.GetName()),
+	})
+
+	const keySchema = `syntax = "proto3";
+
+message foo {
+  optional int64 a = 1;
+}
+`
+	const recordFields = `  optional int64 a = 1;
+  optional string b = 2;
+  optional double c = 3;
+  optional bool d = 4;
+  optional string e = 5;
+  optional bytes f = 6;
+}
+`
+	const afterJSON = `{"a":"1","b":"bar","c":1.5,"e":"2.50","f":"AQ=="}`
+
+	tests := []struct {
+		name        string
+		opts        changefeedbase.EncodingOptions
+		valueSchema string
+		insert      string
+		delete      string
+	}{
+		{
+			name: `wrapped`,
+			opts: changefeedbase.EncodingOptions{Envelope: changefeedbase.OptEnvelopeWrapped},
+			valueSchema: `syntax = "proto3";
+
+message foo_envelope {
+  foo after = 1;
+}
+
+message foo {
+` + recordFields,
+			insert: `{"after":` + afterJSON + `}`,
+			delete: `{}`,
+		},
+		{
+			name: `wrapped,diff,updated,mvcc_timestamp`,
+			opts: changefeedbase.EncodingOptions{
+				Envelope:          changefeedbase.OptEnvelopeWrapped,
+				Diff:              true,
+				UpdatedTimestamps: true,
+				MVCCTimestamps:    true,
+			},
+			valueSchema: `syntax = "proto3";
+
+message foo_envelope {
+  foo after = 1;
+  foo_before before = 2;
+  optional string updated = 3;
+  optional string mvcc_timestamp = 4;
+}
+
+message foo {
+` + recordFields + `
+message foo_before {
+` + recordFields,
+			insert: `{"after":` + afterJSON +
+				`,"mvcc_timestamp":"1.0000000002","updated":"1.0000000002"}`,
+			delete: `{"before":` + afterJSON +
+				`,"mvcc_timestamp":"1.0000000002","updated":"1.0000000002"}`,
+		},
+		{
+			name: `row`,
+			opts: changefeedbase.EncodingOptions{Envelope: changefeedbase.OptEnvelopeRow},
+			valueSchema: `syntax = "proto3";
+
+message foo {
+` + recordFields,
+			insert: afterJSON,
+		},
+		{
+			name: `key_only`,
+			opts: changefeedbase.EncodingOptions{Envelope: changefeedbase.OptEnvelopeKeyOnly},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			reg := cdctest.StartTestSchemaRegistry()
+			defer reg.Close()
+
+			opts := test.opts
+			opts.Format = changefeedbase.OptFormatProtobuf
+			opts.SchemaRegistryURI = reg.URL()
+			require.NoError(t, opts.Validate())
+			e, err := getEncoder(ctx, opts, targets, false, nil, nil)
+			require.NoError(t, err)
+
+			rowInsert := cdcevent.TestingMakeEventRow(tableDesc, 0, row, false)
+			rowDelete := cdcevent.TestingMakeEventRow(tableDesc, 0, row, true)
+			var prevInsert cdcevent.Row
+			if opts.Diff {
+				prevInsert = cdcevent.TestingMakeEventRow(tableDesc, 0, nil, false)
+			}
+			evCtx := eventContext{updated: ts, mvcc: ts}
+
+			keyDesc, err := newProtobufRecord(rowInsert.ForEachKeyColumn(), `foo`)
+			require.NoError(t, err)
+			keySchemaDesc, err := newProtobufSchema(keyDesc.desc)
+			require.NoError(t, err)
+
+			key, err := e.EncodeKey(ctx, rowInsert)
+			require.NoError(t, err)
+			require.Equal(t, `{"a":"1"}`, protobufToJSON(t, keySchemaDesc.message, key))
+			require.Equal(t, keySchema, reg.SchemaForSubject(`foo-key`))
+			require.Equal(t, confluentSchemaTypeProtobuf, reg.SchemaTypeForSubject(`foo-key`))
+
+			valueInsert, err := e.EncodeValue(ctx, evCtx, rowInsert, prevInsert)
+			require.NoError(t, err)
+			valueDelete, err := e.EncodeValue(ctx, evCtx, rowDelete, rowInsert)
+			require.NoError(t, err)
+			if test.valueSchema == `` {
+				require.Nil(t, valueInsert)
+				require.Nil(t, valueDelete)
+				return
+			}
+			require.Equal(t, test.valueSchema, reg.SchemaForSubject(`foo-value`))
+			require.Equal(t, confluentSchemaTypeProtobuf, reg.SchemaTypeForSubject(`foo-value`))
+
+			var valueDesc protoreflect.MessageDescriptor
+			if opts.Envelope == changefeedbase.OptEnvelopeWrapped {
+				after, err := newProtobufRecord(rowInsert.ForEachColumn(), `foo`)
+				require.NoError(t, err)
+				before, err := newProtobufRecord(rowInsert.ForEachColumn(), `foo_before`)
+				require.NoError(t, err)
+				envelope := newProtobufEnvelope(`foo`, protobufEnvelopeOpts{
+					afterField:         true,
+					beforeField:        opts.Diff,
+					updatedField:       opts.UpdatedTimestamps,
+					mvccTimestampField: opts.MVCCTimestamps,
+				}, before, after)
+				schema, err := newProtobufSchema(envelope.messages()...)
+				require.NoError(t, err)
+				require.Equal(t, test.valueSchema, schema.text)
+				valueDesc = schema.message
+			} else {
+				record, err := newProtobufRecord(rowInsert.ForEachColumn(), `foo`)
+				require.NoError(t, err)
+				schema, err := newProtobufSchema(record.desc)
+				require.NoError(t, err)
+				valueDesc = schema.message
+			}
+			require.Equal(t, test.insert, protobufToJSON(t, valueDesc, valueInsert))
+			if test.delete == `` {
+				require.Nil(t, valueDelete)
+			} else {
+				require.Equal(t, test.delete, protobufToJSON(t, valueDesc, valueDelete))
+			}
+
+			resolved, err := e.EncodeResolvedTimestamp(ctx, `foo`, ts)
+			require.NoError(t, err)
+			resolvedEnvelope := newProtobufEnvelope(
+				`foo`, protobufEnvelopeOpts{resolvedField: true}, nil /* before */, nil, /* after */
+			)
+			resolvedSchema, err := newProtobufSchema(resolvedEnvelope.messages()...)
+			require.NoError(t, err)
+			require.Equal(t, `{"resolved":"1.0000000002"}`,
+				protobufToJSON(t, resolvedSchema.message, resolved))
+		})
+	}
+}
+
+// protobufAfterFieldNumbers returns the numbers of the fields set in the after
+// record of a wrapped protobuf message.
+func protobufAfterFieldNumbers(t *testing.T, b []byte) []protowire.Number {
+	t.Helper()
+	require.GreaterOrEqual(t, len(b), 6)
+	consumeFields := func(b []byte, fn func(num protowire.Number, v []byte)) {
+		for len(b) > 0 {
+			num, typ, n := protowire.ConsumeTag(b)
+			require.NoError(t, protowire.ParseError(n))
+			b = b[n:]
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			require.NoError(t, protowire.ParseError(n))
+			fn(num, b[:n])
+			b = b[n:]
+		}
+	}
+	var nums []protowire.Number
+	consumeFields(b[6:], func(num protowire.Number, v []byte) {
+		if num != protobufEnvelopeAfterField {
+			return
+		}
+		after, n := protowire.ConsumeBytes(v)
+		require.NoError(t, protowire.ParseError(n))
+		consumeFields(after, func(num protowire.Number, _ []byte) {
+			nums = append(nums, num)
+		})
+	})
+	return nums
+}
+
+// TestProtobufSchemaEvolution verifies that the protobuf schemas registered as
+// columns are dropped and added are compatible with each other, which the test
+// schema registry enforces by rejecting a schema which changes the type of a
+// field number.
+func TestProtobufSchemaEvolution(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		reg := cdctest.StartTestSchemaRegistry()
+		defer reg.Close()
+
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c INT)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'b1', 1)`)
+
+		foo := feed(t, f, fmt.Sprintf(`CREATE CHANGEFEED FOR foo `+
+			`WITH format=%s, %s='%s', schema_change_policy='nobackfill'`,
+			changefeedbase.OptFormatProtobuf, changefeedbase.OptConfluentSchemaRegistry, reg.URL()))
+		defer closeFeed(t, foo)
+
+		// The field numbers of the after record are the IDs of the columns which
+		// are set in the row.
+		assertNextFieldNumbers := func(expected ...protowire.Number) {
+			t.Helper()
+			for {
+				m, err := foo.Next()
+				require.NoError(t, err)
+				if m.Key == nil {
+					continue
+				}
+				require.Equal(t, expected, protobufAfterFieldNumbers(t, m.Value))
+				return
+			}
+		}
+		assertNextFieldNumbers(1, 2, 3)
+
+		sqlDB.Exec(t, `ALTER TABLE foo DROP COLUMN b`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 2)`)
+		assertNextFieldNumbers(1, 3)
+
+		sqlDB.Exec(t, `ALTER TABLE foo ADD COLUMN d STRING`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (3, 3, 'd3')`)
+		assertNextFieldNumbers(1, 3, 4)
+
+		schemas := reg.SchemasForSubject(`foo-value`)
+		require.GreaterOrEqual(t, len(schemas), 3)
+		require.Equal(t, `syntax = "proto3";
+
+message foo_envelope {
+  foo after = 1;
+}
+
+message foo {
+  optional int64 a = 1;
+  optional string b = 2;
+  optional int64 c = 3;
+}
+`, schemas[0])
+		require.Equal(t, `syntax = "proto3";
+
+message foo_envelope {
+  foo after = 1;
+}
+
+message foo {
+  optional int64 a = 1;
+  optional int64 c = 3;
+  optional string d = 4;
+}
+`, schemas[len(schemas)-1])
+	}
+
+	cdcTest(t, testFn, feedTestForceSink("kafka"))
+}
+
+func TestProtobufEncoderOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	reg := cdctest.StartTestSchemaRegistry()
+	defer reg.Close()
+
+	for _, tc := range []struct {
+		opts changefeedbase.EncodingOptions
+		err  string
+	}{
+		{
+			opts: changefeedbase.EncodingOptions{
+				Envelope: changefeedbase.OptEnvelopeWrapped, SchemaRegistryURI: reg.URL(), KeyInValue: true,
+			},
+			err: `key_in_value is not supported with format=protobuf`,
+		},
+		{
+			opts: changefeedbase.EncodingOptions{
+				Envelope: changefeedbase.OptEnvelopeWrapped, SchemaRegistryURI: reg.URL(), TopicInValue: true,
+			},
+			err: `topic_in_value is not supported with format=protobuf`,
+		},
+		{
+			opts: changefeedbase.EncodingOptions{Envelope: changefeedbase.OptEnvelopeWrapped},
+			err:  `WITH option confluent_schema_registry is required for format=protobuf`,
+		},
+	} {
+		tc.opts.Format = changefeedbase.OptFormatProtobuf
+		_, err := getEncoder(context.Background(), tc.opts, changefeedbase.Targets{}, false, nil, nil)
+		require.EqualError(t, err, tc.err)
+	}
+}
+
+func TestProtobufFieldNumbers(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	cols := func(ids ...uint32) []cdcevent.ResultColumn {
+		var cols []cdcevent.ResultColumn
+		for i, id := range ids {
+			cols = append(cols, cdcevent.ResultColumn{
+				ResultColumn: colinfo.ResultColumn{
+					Name: fmt.Sprintf("c%d", i), Typ: types.Int, PGAttributeNum: id,
+				},
+			})
+		}
+		return cols
+	}
+	for _, tc := range []struct {
+		ids      []uint32
+		expected []protowire.Number
+		err      string
+	}{
+		// Field numbers are column IDs, which leave gaps as columns are dropped.
+		{ids: []uint32{1, 2, 3}, expected: []protowire.Number{1, 2, 3}},
+		{ids: []uint32{1, 4, 7}, expected: []protowire.Number{1, 4, 7}},
+		// Columns without a usable ID are rejected rather than numbered by
+		// position, which would break compatibility as the table changes.
+		{ids: []uint32{1, 0}, err: `column "c1" has no ID to use as its protobuf field number`},
+		{ids: []uint32{1, 19000}, err: `ID 19000 of column "c1" is not a valid protobuf field number`},
+		{ids: []uint32{1, 1 << 29}, err: `ID 536870912 of column "c1" is not a valid protobuf field number`},
+		{ids: []uint32{2, 2}, err: `column "c1" has the same ID 2 as another column`},
+	} {
+		nums, err := protobufFieldNumbers(cols(tc.ids...))
+		if tc.err != "" {
+			require.EqualError(t, err, tc.err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, nums)
+	}
+}
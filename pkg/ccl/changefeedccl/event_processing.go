@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -38,6 +39,12 @@ type eventContext struct {
 	updated, mvcc hlc.Timestamp
 	// topic is set to the string to be included if TopicInValue is true
 	topic string
+	// backfill is true if the event was emitted by an initial scan or a schema
+	// change backfill rather than by a rangefeed.
+	backfill bool
+	// source is the metadata of the table of the event which is included in
+	// the debezium envelope.
+	source *debeziumSource
 }
 
 type eventConsumer interface {
@@ -66,6 +73,14 @@ type kvEventToRowConsumer struct {
 	metrics *sliMetrics
 	sv      *settings.Values
 
+	// dlq, if set, receives events which can never be emitted instead of
+	// failing the changefeed.
+	dlq deadLetterQueue
+
+	// debeziumSources holds the metadata of each target table if the
+	// changefeed uses the debezium envelope.
+	debeziumSources map[descpb.ID]*debeziumSource
+
 	// This pacer is used to incorporate event consumption to elastic CPU
 	// control. This helps ensure that event encoding/decoding does not throttle
 	// foreground SQL traffic.
@@ -238,7 +253,23 @@ func newKVEventToRowConsumer(
 		return nil, err
 	}
 
-	return &kvEventToRowConsumer{
+	var dlq deadLetterQueue
+	if onError, err := details.Opts.GetOnError(); err != nil {
+		return nil, err
+	} else if onError == changefeedbase.OptOnErrorDLQ && spec.JobID != 0 {
+		dlq = newSQLDeadLetterQueue(
+			cfg.InternalDB.Executor(), spec.User(), spec.JobID, details.Opts.GetDLQTable())
+	}
+
+	var debeziumSources map[descpb.ID]*debeziumSource
+	if encodingOpts.Envelope == changefeedbase.OptEnvelopeDebezium {
+		debeziumSources, err = makeDebeziumSources(ctx, cfg, details.Targets, spec.JobID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c := &kvEventToRowConsumer{
 		frontier:             frontier,
 		encoder:              encoder,
 		decoder:              decoder,
@@ -253,7 +284,13 @@ func newKVEventToRowConsumer(
 		metrics:              metrics,
 		pacer:                pacer,
 		sv:                   cfg.SV(),
-	}, nil
+		dlq:                  dlq,
+		debeziumSources:      debeziumSources,
+	}
+	if dlq != nil {
+		setSinkDeadLetterQueue(sink, c.writeMessageToDLQ)
+	}
+	return c, nil
 }
 
 func newEvaluator(
@@ -373,7 +410,8 @@ func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Even
 		}
 	}
 
-	return c.encodeAndEmit(ctx, updatedRow, prevRow, schemaTimestamp, ev.DetachAlloc())
+	backfill := !ev.BackfillTimestamp().IsEmpty()
+	return c.encodeAndEmit(ctx, updatedRow, prevRow, schemaTimestamp, backfill, ev.DetachAlloc())
 }
 
 func (c *kvEventToRowConsumer) encodeAndEmit(
@@ -381,6 +419,7 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 	updatedRow cdcevent.Row,
 	prevRow cdcevent.Row,
 	schemaTS hlc.Timestamp,
+	backfill bool,
 	alloc kvevent.Alloc,
 ) error {
 	topic, err := c.topicForEvent(updatedRow.Metadata)
@@ -400,8 +439,12 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 	}
 
 	evCtx := eventContext{
-		updated: schemaTS,
-		mvcc:    updatedRow.MvccTimestamp,
+		updated:  schemaTS,
+		mvcc:     updatedRow.MvccTimestamp,
+		backfill: backfill,
+	}
+	if c.debeziumSources != nil {
+		evCtx.source = c.debeziumSources[updatedRow.TableID]
 	}
 
 	if c.topicNamer != nil {
@@ -428,6 +471,9 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 	var keyCopy, valueCopy []byte
 	encodedKey, err := c.encoder.EncodeKey(ctx, updatedRow)
 	if err != nil {
+		if c.dlq != nil && isPermanentEncodingError(ctx, err) {
+			return c.writeToDLQ(ctx, updatedRow, nil /* key */, nil /* value */, err, alloc)
+		}
 		return err
 	}
 	c.scratch, keyCopy = c.scratch.Copy(encodedKey, 0 /* extraCap */)
@@ -435,6 +481,9 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 	// might not be available at all when working with changefeed expressions.
 	encodedValue, err := c.encoder.EncodeValue(ctx, evCtx, updatedRow, prevRow)
 	if err != nil {
+		if c.dlq != nil && isPermanentEncodingError(ctx, err) {
+			return c.writeToDLQ(ctx, updatedRow, keyCopy, nil /* value */, err, alloc)
+		}
 		return err
 	}
 	c.scratch, valueCopy = c.scratch.Copy(encodedValue, 0 /* extraCap */)
@@ -450,6 +499,9 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 		)
 	})
 	if err != nil {
+		if c.dlq != nil && changefeedbase.IsPermanentEventError(err) {
+			return c.writeToDLQ(ctx, updatedRow, keyCopy, valueCopy, err, alloc)
+		}
 		if !errors.Is(err, context.Canceled) {
 			log.Warningf(ctx, `sink failed to emit row: %v`, err)
 			c.metrics.SinkErrors.Inc(1)
@@ -459,6 +511,73 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 	if log.V(3) {
 		log.Infof(ctx, `r %s: %s -> %s`, updatedRow.TableName, keyCopy, valueCopy)
 	}
+	if c.encodingOpts.Envelope == changefeedbase.OptEnvelopeDebezium && updatedRow.IsDeleted() &&
+		emitsDeleteTombstones(c.sink) {
+		return c.emitTombstone(ctx, topic, keyCopy, schemaTS, updatedRow.MvccTimestamp)
+	}
+	return nil
+}
+
+// emitsDeleteTombstones returns whether delete events are followed by a
+// tombstone with the debezium envelope. Tombstones are only meaningful for
+// Kafka's log compacted topics; other sinks would deliver them as messages
+// with an empty payload.
+func emitsDeleteTombstones(s EventSink) bool {
+	return s.getConcreteType() == sinkTypeKafka
+}
+
+// emitTombstone emits a message with the given key and no value. Debezium
+// follows every delete event with a tombstone so that log compacted topics,
+// such as those of Kafka, may eventually drop all messages for the deleted
+// row.
+func (c *kvEventToRowConsumer) emitTombstone(
+	ctx context.Context, topic TopicDescriptor, key []byte, updated, mvcc hlc.Timestamp,
+) (err error) {
+	c.metrics.Timers.EmitRow.Time(func() {
+		// The tombstone shares its key with the delete event, whose allocation
+		// already accounts for it.
+		err = c.sink.EmitRow(ctx, topic, key, nil /* value */, updated, mvcc, kvevent.Alloc{})
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		log.Warningf(ctx, `sink failed to emit tombstone: %v`, err)
+		c.metrics.SinkErrors.Inc(1)
+	}
+	return err
+}
+
+// writeToDLQ records the row, which could not be emitted because of reason, in
+// the dead letter queue and releases its allocation.
+func (c *kvEventToRowConsumer) writeToDLQ(
+	ctx context.Context,
+	updatedRow cdcevent.Row,
+	key, value []byte,
+	reason error,
+	alloc kvevent.Alloc,
+) error {
+	if err := c.dlq.Log(ctx, updatedRow, key, value, reason); err != nil {
+		return errors.WithSecondaryError(
+			errors.Wrap(err, "writing event to dead letter queue"), reason)
+	}
+	log.Warningf(ctx, "wrote row from table %s to dead letter queue: %v", updatedRow.TableName, reason)
+	alloc.Release(ctx)
+	c.metrics.DLQMessages.Inc(1)
+	return nil
+}
+
+// writeMessageToDLQ records the message, which the sink accepted but could
+// not emit because of reason, in the dead letter queue. It is called by sinks
+// which emit messages asynchronously; see deadLetterQueueSink.
+func (c *kvEventToRowConsumer) writeMessageToDLQ(
+	ctx context.Context, topic TopicDescriptor, mvcc hlc.Timestamp, key, value []byte, reason error,
+) error {
+	if err := c.dlq.LogMessage(
+		ctx, topic.GetTopicIdentifier().TableID, mvcc, key, value, reason,
+	); err != nil {
+		return errors.WithSecondaryError(
+			errors.Wrap(err, "writing message to dead letter queue"), reason)
+	}
+	log.Warningf(ctx, "wrote message from table %s to dead letter queue: %v", topic.GetTableName(), reason)
+	c.metrics.DLQMessages.Inc(1)
 	return nil
 }
 
@@ -54,6 +54,7 @@ type AggMetrics struct {
 	EmittedMessages             *aggmetric.AggCounter
 	EmittedBatchSizes           *aggmetric.AggHistogram
 	FilteredMessages            *aggmetric.AggCounter
+	DLQMessages                 *aggmetric.AggCounter
 	MessageSize                 *aggmetric.AggHistogram
 	EmittedBytes                *aggmetric.AggCounter
 	FlushedBytes                *aggmetric.AggCounter
@@ -136,6 +137,7 @@ type sliMetrics struct {
 	EmittedResolvedMessages     *aggmetric.Counter
 	EmittedBatchSizes           *aggmetric.Histogram
 	FilteredMessages            *aggmetric.Counter
+	DLQMessages                 *aggmetric.Counter
 	MessageSize                 *aggmetric.Histogram
 	EmittedBytes                *aggmetric.Counter
 	FlushedBytes                *aggmetric.Counter
@@ -809,6 +811,12 @@ func newAggregateMetrics(histogramWindow time.Duration, lookup *cidr.Lookup) *Ag
 		Measurement: "Messages",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaChangefeedDLQMessages := metric.Metadata{
+		Name:        "changefeed.dlq_messages",
+		Help:        "Messages written to the dead letter queue by all feeds",
+		Measurement: "Messages",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaChangefeedEmittedBytes := metric.Metadata{
 		Name:        "changefeed.emitted_bytes",
 		Help:        "Bytes emitted by all feeds",
@@ -1012,6 +1020,7 @@ func newAggregateMetrics(histogramWindow time.Duration, lookup *cidr.Lookup) *Ag
 			BucketConfig: metric.DataCount16MBuckets,
 		}),
 		FilteredMessages: b.Counter(metaChangefeedFilteredMessages),
+		DLQMessages:      b.Counter(metaChangefeedDLQMessages),
 		MessageSize: b.Histogram(metric.HistogramOptions{
 			Metadata:     metaMessageSize,
 			Duration:     histogramWindow,
@@ -1132,6 +1141,7 @@ func (a *AggMetrics) getOrCreateScope(scope string) (*sliMetrics, error) {
 		EmittedResolvedMessages:     a.EmittedMessages.AddChild(scope, "resolved"),
 		EmittedBatchSizes:           a.EmittedBatchSizes.AddChild(scope),
 		FilteredMessages:            a.FilteredMessages.AddChild(scope),
+		DLQMessages:                 a.DLQMessages.AddChild(scope),
 		MessageSize:                 a.MessageSize.AddChild(scope),
 		EmittedBytes:                a.EmittedBytes.AddChild(scope),
 		FlushedBytes:                a.FlushedBytes.AddChild(scope),
@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	kgo "github.com/twmb/franz-go/pkg/kgo"
+	kmsg "github.com/twmb/franz-go/pkg/kmsg"
 )
 
 // MockKafkaClientV2 is a mock of KafkaClientV2 interface.
@@ -35,6 +36,20 @@ func (m *MockKafkaClientV2) EXPECT() *MockKafkaClientV2MockRecorder {
 	return m.recorder
 }
 
+// BeginTransaction mocks base method.
+func (m *MockKafkaClientV2) BeginTransaction() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockKafkaClientV2MockRecorder) BeginTransaction() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockKafkaClientV2)(nil).BeginTransaction))
+}
+
 // Close mocks base method.
 func (m *MockKafkaClientV2) Close() {
 	m.ctrl.T.Helper()
@@ -47,6 +62,20 @@ func (mr *MockKafkaClientV2MockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockKafkaClientV2)(nil).Close))
 }
 
+// EndTransaction mocks base method.
+func (m *MockKafkaClientV2) EndTransaction(arg0 context.Context, arg1 kgo.TransactionEndTry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndTransaction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndTransaction indicates an expected call of EndTransaction.
+func (mr *MockKafkaClientV2MockRecorder) EndTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndTransaction", reflect.TypeOf((*MockKafkaClientV2)(nil).EndTransaction), arg0, arg1)
+}
+
 // ProduceSync mocks base method.
 func (m *MockKafkaClientV2) ProduceSync(arg0 context.Context, arg1 ...*kgo.Record) kgo.ProduceResults {
 	m.ctrl.T.Helper()
@@ -65,3 +94,34 @@ func (mr *MockKafkaClientV2MockRecorder) ProduceSync(arg0 interface{}, arg1 ...i
 	varargs := append([]interface{}{arg0}, arg1...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceSync", reflect.TypeOf((*MockKafkaClientV2)(nil).ProduceSync), varargs...)
 }
+
+// ProducerID mocks base method.
+func (m *MockKafkaClientV2) ProducerID(arg0 context.Context) (int64, int16, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProducerID", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int16)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ProducerID indicates an expected call of ProducerID.
+func (mr *MockKafkaClientV2MockRecorder) ProducerID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProducerID", reflect.TypeOf((*MockKafkaClientV2)(nil).ProducerID), arg0)
+}
+
+// Request mocks base method.
+func (m *MockKafkaClientV2) Request(arg0 context.Context, arg1 kmsg.Request) (kmsg.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Request", arg0, arg1)
+	ret0, _ := ret[0].(kmsg.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Request indicates an expected call of Request.
+func (mr *MockKafkaClientV2MockRecorder) Request(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockKafkaClientV2)(nil).Request), arg0, arg1)
+}
@@ -16,6 +16,8 @@ import (
 var escapeRE = regexp.MustCompile(`_u[0-9a-fA-F]{2,8}_`)
 var kafkaDisallowedRE = regexp.MustCompile(`[^a-zA-Z0-9\._\-]`)
 var avroDisallowedRE = regexp.MustCompile(`[^A-Za-z0-9_]`)
+var natsDisallowedRE = regexp.MustCompile(`[\s*>]`)
+var mqttDisallowedRE = regexp.MustCompile(`[+#\x00]`)
 
 func escapeRune(r rune) string {
 	if r <= 1<<16 {
@@ -52,6 +54,34 @@ func KafkaNameToSQLName(s string) string {
 	return unescapeSQLName(s)
 }
 
+// SQLNameToNATSSubject escapes a sql table name into a valid NATS subject.
+// This is reversible by NATSSubjectToSQLName.
+//
+// NATS subjects may contain anything but whitespace and the `*` and `>`
+// wildcards. Dots are left alone, since they separate the tokens of a subject.
+func SQLNameToNATSSubject(s string) string {
+	return escapeSQLName(s, natsDisallowedRE)
+}
+
+// NATSSubjectToSQLName is the inverse of SQLNameToNATSSubject.
+func NATSSubjectToSQLName(s string) string {
+	return unescapeSQLName(s)
+}
+
+// SQLNameToMQTTTopic escapes a sql table name into a valid MQTT topic name.
+// This is reversible by MQTTTopicToSQLName.
+//
+// MQTT topic names may contain anything but NUL and the `+` and `#` wildcards.
+// Slashes are left alone, since they separate the levels of a topic.
+func SQLNameToMQTTTopic(s string) string {
+	return escapeSQLName(s, mqttDisallowedRE)
+}
+
+// MQTTTopicToSQLName is the inverse of SQLNameToMQTTTopic.
+func MQTTTopicToSQLName(s string) string {
+	return unescapeSQLName(s)
+}
+
 // SQLNameToAvroName escapes a sql table name into a valid avro record or field
 // name. This is reversible by AvroNameToSQLName.
 //
@@ -88,3 +88,39 @@ func TestSQLNameToAvroName(t *testing.T) {
 	// We don't produce capital letters in escapes but check them anyway.
 	require.Equal(t, `/`, KafkaNameToSQLName(`_u2F_`))
 }
+
+func TestSQLNameToNATSSubjectAndMQTTTopic(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tests := []struct {
+		sql, nats, mqtt string
+	}{
+		{`foo`, `foo`, `foo`},
+		{`d.public.foo`, `d.public.foo`, `d.public.foo`},
+		{`cdc/foo`, `cdc/foo`, `cdc/foo`},
+		{`foo bar`, `foo_u0020_bar`, `foo bar`},
+		{`foo*`, `foo_u002a_`, `foo*`},
+		{`foo>`, `foo_u003e_`, `foo>`},
+		{`foo+`, `foo+`, `foo_u002b_`},
+		{`foo#`, `foo#`, `foo_u0023_`},
+		{`foo_u0021_bar`, `foo_u005f__u0075__u0030__u0030__u0032__u0031__u005f_bar`,
+			`foo_u005f__u0075__u0030__u0030__u0032__u0031__u005f_bar`},
+		{`☃`, `☃`, `☃`},
+		{"\x00", "\x00", `_u0000_`},
+	}
+	for i, test := range tests {
+		if n := SQLNameToNATSSubject(test.sql); n != test.nats {
+			t.Errorf(`%d: %s did not escape to %s got %s`, i, test.sql, test.nats, n)
+		}
+		if s := NATSSubjectToSQLName(test.nats); s != test.sql {
+			t.Errorf(`%d: %s did not unescape to %s got %s`, i, test.nats, test.sql, s)
+		}
+		if m := SQLNameToMQTTTopic(test.sql); m != test.mqtt {
+			t.Errorf(`%d: %s did not escape to %s got %s`, i, test.sql, test.mqtt, m)
+		}
+		if s := MQTTTopicToSQLName(test.mqtt); s != test.sql {
+			t.Errorf(`%d: %s did not unescape to %s got %s`, i, test.mqtt, test.sql, s)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
@@ -254,13 +255,24 @@ func (p *ParallelIO) processIO(ctx context.Context, numEmitWorkers int) error {
 		}
 
 		initialSend := true
-		return retry.WithMaxAttempts(ctx, p.retryOpts, p.retryOpts.MaxRetries+1, func() error {
+		var permanentErr error
+		err := retry.WithMaxAttempts(ctx, p.retryOpts, p.retryOpts.MaxRetries+1, func() error {
 			if !initialSend {
 				p.metrics.recordInternalRetry(int64(r.Keys().Len()), false)
 			}
 			initialSend = false
-			return p.ioHandler(ctx, r)
+			err := p.ioHandler(ctx, r)
+			if changefeedbase.IsPermanentEventError(err) {
+				// Retrying would fail the same way.
+				permanentErr = err
+				return nil
+			}
+			return err
 		})
+		if permanentErr != nil {
+			return permanentErr
+		}
+		return err
 	}
 
 	// Multiple worker routines handle the IO operations, retrying when necessary.
@@ -0,0 +1,408 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// The field numbers of the envelope message. They are fixed so that the
+// envelopes of row changes and resolved timestamps, which are registered
+// under the same subject, never assign different meanings to a number.
+const (
+	protobufEnvelopeAfterField         protowire.Number = 1
+	protobufEnvelopeBeforeField        protowire.Number = 2
+	protobufEnvelopeUpdatedField       protowire.Number = 3
+	protobufEnvelopeMVCCTimestampField protowire.Number = 4
+	protobufEnvelopeResolvedField      protowire.Number = 5
+)
+
+// protobufEncodeFn appends the encoding of a non-NULL datum as the field with
+// the given number to buf.
+type protobufEncodeFn func(buf []byte, num protowire.Number, d tree.Datum) []byte
+
+// protobufField is a field of a generated protobuf message, which holds the
+// value of a column.
+type protobufField struct {
+	desc     *descriptorpb.FieldDescriptorProto
+	encodeFn protobufEncodeFn
+}
+
+// protobufRecord is a generated protobuf message holding the columns of a row.
+type protobufRecord struct {
+	desc   *descriptorpb.DescriptorProto
+	fields []protobufField
+}
+
+// protobufEnvelopeOpts controls which fields are included in an envelope.
+type protobufEnvelopeOpts struct {
+	beforeField, afterField          bool
+	updatedField, mvccTimestampField bool
+	resolvedField                    bool
+}
+
+// protobufEnvelope is a generated protobuf message that wraps the before and
+// after versions of a row change, along with metadata about the change.
+type protobufEnvelope struct {
+	desc          *descriptorpb.DescriptorProto
+	opts          protobufEnvelopeOpts
+	before, after *protobufRecord
+}
+
+// protobufSchema is a generated .proto file. Its first message is the one that
+// is encoded in changefeed messages; the others are the records referenced by
+// an envelope.
+type protobufSchema struct {
+	// message is the descriptor of the first message of the file.
+	message protoreflect.MessageDescriptor
+	// text is the .proto source of the file, which is what gets registered with
+	// the schema registry.
+	text string
+}
+
+// protobufFieldNumbers returns the field numbers of the given columns. Fields
+// are numbered by column ID, so that a field keeps its number as other columns
+// are added and dropped and the schemas of successive table versions remain
+// compatible with each other. An error is returned if some column has no ID
+// which can be used as a field number, since numbering such fields any other
+// way would break compatibility once the table changes.
+func protobufFieldNumbers(cols []cdcevent.ResultColumn) ([]protowire.Number, error) {
+	nums := make([]protowire.Number, len(cols))
+	seen := make(map[protowire.Number]struct{}, len(cols))
+	for i, col := range cols {
+		num := protowire.Number(col.PGAttributeNum)
+		if num == 0 {
+			return nil, errors.Newf(
+				"column %q has no ID to use as its protobuf field number", col.Name)
+		}
+		if !num.IsValid() ||
+			(num >= protowire.FirstReservedNumber && num <= protowire.LastReservedNumber) {
+			return nil, errors.Newf(
+				"ID %d of column %q is not a valid protobuf field number", num, col.Name)
+		}
+		if _, dup := seen[num]; dup {
+			return nil, errors.AssertionFailedf(
+				"column %q has the same ID %d as another column", col.Name, num)
+		}
+		seen[num] = struct{}{}
+		nums[i] = num
+	}
+	return nums, nil
+}
+
+// typeToProtobufField returns the protobuf type of a column of the given type
+// and the function that encodes its values. Types without a natural protobuf
+// counterpart, such as DECIMAL, TIMESTAMP, arrays and JSON, are encoded as
+// strings holding their SQL text representation.
+func typeToProtobufField(
+	typ *types.T,
+) (descriptorpb.FieldDescriptorProto_Type, protobufEncodeFn) {
+	switch typ.Family() {
+	case types.BoolFamily:
+		return descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+			func(buf []byte, num protowire.Number, d tree.Datum) []byte {
+				buf = protowire.AppendTag(buf, num, protowire.VarintType)
+				return protowire.AppendVarint(buf, protowire.EncodeBool(bool(tree.MustBeDBool(d))))
+			}
+	case types.IntFamily:
+		return descriptorpb.FieldDescriptorProto_TYPE_INT64,
+			func(buf []byte, num protowire.Number, d tree.Datum) []byte {
+				buf = protowire.AppendTag(buf, num, protowire.VarintType)
+				return protowire.AppendVarint(buf, uint64(tree.MustBeDInt(d)))
+			}
+	case types.FloatFamily:
+		return descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+			func(buf []byte, num protowire.Number, d tree.Datum) []byte {
+				buf = protowire.AppendTag(buf, num, protowire.Fixed64Type)
+				return protowire.AppendFixed64(buf, math.Float64bits(float64(tree.MustBeDFloat(d))))
+			}
+	case types.BytesFamily:
+		return descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+			func(buf []byte, num protowire.Number, d tree.Datum) []byte {
+				buf = protowire.AppendTag(buf, num, protowire.BytesType)
+				return protowire.AppendString(buf, string(tree.MustBeDBytes(d)))
+			}
+	default:
+		return descriptorpb.FieldDescriptorProto_TYPE_STRING,
+			func(buf []byte, num protowire.Number, d tree.Datum) []byte {
+				buf = protowire.AppendTag(buf, num, protowire.BytesType)
+				switch t := d.(type) {
+				case *tree.DString:
+					return protowire.AppendString(buf, string(*t))
+				case *tree.DCollatedString:
+					return protowire.AppendString(buf, t.Contents)
+				default:
+					return protowire.AppendString(buf, tree.AsStringWithFlags(d, tree.FmtExport))
+				}
+			}
+	}
+}
+
+// addProto3OptionalField adds a scalar field with explicit presence to the
+// message, so that NULL values can be told apart from zero values. In proto3,
+// such a field is the only member of a synthetic oneof, whose name must not
+// collide with any of the names in taken.
+func addProto3OptionalField(
+	msg *descriptorpb.DescriptorProto,
+	name string,
+	num protowire.Number,
+	typ descriptorpb.FieldDescriptorProto_Type,
+	taken map[string]struct{},
+) *descriptorpb.FieldDescriptorProto {
+	oneofName := "_" + name
+	for {
+		if _, ok := taken[oneofName]; !ok {
+			break
+		}
+		oneofName = "X" + oneofName
+	}
+	if taken != nil {
+		taken[oneofName] = struct{}{}
+	}
+	msg.OneofDecl = append(msg.OneofDecl, &descriptorpb.OneofDescriptorProto{
+		Name: proto.String(oneofName),
+	})
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:           proto.String(name),
+		Number:         proto.Int32(int32(num)),
+		Label:          descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:           typ.Enum(),
+		OneofIndex:     proto.Int32(int32(len(msg.OneofDecl) - 1)),
+		Proto3Optional: proto.Bool(true),
+	}
+	msg.Field = append(msg.Field, field)
+	return field
+}
+
+// newProtobufRecord creates a protobuf message with the given name and a field
+// for each column of the iterator.
+func newProtobufRecord(it cdcevent.Iterator, name string) (*protobufRecord, error) {
+	var cols []cdcevent.ResultColumn
+	if err := it.Col(func(col cdcevent.ResultColumn) error {
+		cols = append(cols, col)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(cols))
+	taken := make(map[string]struct{}, 2*len(cols))
+	for i, col := range cols {
+		names[i] = SQLNameToAvroName(col.Name)
+		taken[names[i]] = struct{}{}
+	}
+
+	r := &protobufRecord{
+		desc: &descriptorpb.DescriptorProto{Name: proto.String(name)},
+	}
+	nums, err := protobufFieldNumbers(cols)
+	if err != nil {
+		return nil, changefeedbase.MarkPermanentEventError(
+			errors.Wrapf(err, "generating protobuf message %s", name))
+	}
+	for i, col := range cols {
+		typ, encodeFn := typeToProtobufField(col.Typ)
+		field := addProto3OptionalField(r.desc, names[i], nums[i], typ, taken)
+		r.fields = append(r.fields, protobufField{desc: field, encodeFn: encodeFn})
+	}
+	return r, nil
+}
+
+// appendRow appends the encoding of the datums of the iterator, which must
+// match the fields of the record, to buf.
+func (r *protobufRecord) appendRow(buf []byte, it cdcevent.Iterator) ([]byte, error) {
+	i := 0
+	if err := it.Datum(func(d tree.Datum, col cdcevent.ResultColumn) error {
+		if i >= len(r.fields) {
+			return errors.AssertionFailedf("row has more columns than protobuf message %s",
+				r.desc.GetName())
+		}
+		f := &r.fields[i]
+		i++
+		if d == tree.DNull {
+			return nil
+		}
+		buf = f.encodeFn(buf, protowire.Number(f.desc.GetNumber()), d)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// newProtobufEnvelope creates an envelope message for the given topic. The
+// before and after records are only used if the respective options are set,
+// and the before field is omitted if there is no before record.
+func newProtobufEnvelope(
+	topic string, opts protobufEnvelopeOpts, before, after *protobufRecord,
+) *protobufEnvelope {
+	e := &protobufEnvelope{
+		desc: &descriptorpb.DescriptorProto{Name: proto.String(SQLNameToAvroName(topic) + `_envelope`)},
+		opts: opts,
+	}
+	addRecordField := func(name string, num protowire.Number, r *protobufRecord) {
+		e.desc.Field = append(e.desc.Field, &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			Number:   proto.Int32(int32(num)),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+			TypeName: proto.String("." + r.desc.GetName()),
+		})
+	}
+	if opts.afterField {
+		e.after = after
+		addRecordField(`after`, protobufEnvelopeAfterField, after)
+	}
+	if opts.beforeField && before != nil {
+		e.before = before
+		addRecordField(`before`, protobufEnvelopeBeforeField, before)
+	}
+	stringType := descriptorpb.FieldDescriptorProto_TYPE_STRING
+	if opts.updatedField {
+		addProto3OptionalField(e.desc, `updated`, protobufEnvelopeUpdatedField, stringType, nil /* taken */)
+	}
+	if opts.mvccTimestampField {
+		addProto3OptionalField(e.desc, `mvcc_timestamp`, protobufEnvelopeMVCCTimestampField, stringType, nil /* taken */)
+	}
+	if opts.resolvedField {
+		addProto3OptionalField(e.desc, `resolved`, protobufEnvelopeResolvedField, stringType, nil /* taken */)
+	}
+	return e
+}
+
+// messages returns the descriptors of the envelope, followed by those of the
+// records it references.
+func (e *protobufEnvelope) messages() []*descriptorpb.DescriptorProto {
+	descs := []*descriptorpb.DescriptorProto{e.desc}
+	if e.after != nil {
+		descs = append(descs, e.after.desc)
+	}
+	if e.before != nil {
+		descs = append(descs, e.before.desc)
+	}
+	return descs
+}
+
+// protobufMetadata holds the timestamps encoded in an envelope alongside the
+// row data.
+type protobufMetadata struct {
+	updated, mvcc, resolved hlc.Timestamp
+}
+
+// appendEnvelope appends the encoding of the envelope to buf. The metadata
+// timestamps are only encoded if they are set and the envelope has a field
+// for them.
+func (e *protobufEnvelope) appendEnvelope(
+	buf []byte, meta protobufMetadata, beforeRow, afterRow cdcevent.Row,
+) ([]byte, error) {
+	appendRecord := func(num protowire.Number, r *protobufRecord, row cdcevent.Row) error {
+		if !row.HasValues() || row.IsDeleted() {
+			return nil
+		}
+		// The record has to be encoded separately, as its length precedes it.
+		msg, err := r.appendRow(nil, row.ForEachColumn())
+		if err != nil {
+			return err
+		}
+		buf = protowire.AppendTag(buf, num, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, msg)
+		return nil
+	}
+	appendTimestamp := func(num protowire.Number, ts hlc.Timestamp) {
+		if ts.IsEmpty() {
+			return
+		}
+		buf = protowire.AppendTag(buf, num, protowire.BytesType)
+		buf = protowire.AppendString(buf, ts.AsOfSystemTime())
+	}
+
+	if e.opts.afterField {
+		if err := appendRecord(protobufEnvelopeAfterField, e.after, afterRow); err != nil {
+			return nil, err
+		}
+	}
+	if e.before != nil {
+		if err := appendRecord(protobufEnvelopeBeforeField, e.before, beforeRow); err != nil {
+			return nil, err
+		}
+	}
+	if e.opts.updatedField {
+		appendTimestamp(protobufEnvelopeUpdatedField, meta.updated)
+	}
+	if e.opts.mvccTimestampField {
+		appendTimestamp(protobufEnvelopeMVCCTimestampField, meta.mvcc)
+	}
+	if e.opts.resolvedField {
+		appendTimestamp(protobufEnvelopeResolvedField, meta.resolved)
+	}
+	return buf, nil
+}
+
+// newProtobufSchema creates a .proto file holding the given messages, the
+// first of which is the one encoded in changefeed messages.
+func newProtobufSchema(messages ...*descriptorpb.DescriptorProto) (*protobufSchema, error) {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:        proto.String(messages[0].GetName() + `.proto`),
+		Syntax:      proto.String(`proto3`),
+		MessageType: messages,
+	}
+	fd, err := protodesc.NewFile(file, nil /* resolver */)
+	if err != nil {
+		// This happens if column names collide once they have been converted
+		// to protobuf field names.
+		return nil, changefeedbase.MarkPermanentEventError(
+			errors.Wrapf(err, "generating protobuf schema for %s", messages[0].GetName()))
+	}
+	return &protobufSchema{
+		message: fd.Messages().Get(0),
+		text:    protobufSchemaText(file),
+	}, nil
+}
+
+var protobufTypeNames = map[descriptorpb.FieldDescriptorProto_Type]string{
+	descriptorpb.FieldDescriptorProto_TYPE_BOOL:   `bool`,
+	descriptorpb.FieldDescriptorProto_TYPE_INT64:  `int64`,
+	descriptorpb.FieldDescriptorProto_TYPE_DOUBLE: `double`,
+	descriptorpb.FieldDescriptorProto_TYPE_STRING: `string`,
+	descriptorpb.FieldDescriptorProto_TYPE_BYTES:  `bytes`,
+}
+
+// protobufSchemaText returns the .proto source of the file.
+func protobufSchemaText(file *descriptorpb.FileDescriptorProto) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "syntax = %q;\n", file.GetSyntax())
+	for _, msg := range file.MessageType {
+		fmt.Fprintf(&sb, "\nmessage %s {\n", msg.GetName())
+		for _, f := range msg.Field {
+			sb.WriteString("  ")
+			if f.GetProto3Optional() {
+				sb.WriteString("optional ")
+			}
+			if f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+				sb.WriteString(strings.TrimPrefix(f.GetTypeName(), "."))
+			} else {
+				sb.WriteString(protobufTypeNames[f.GetType()])
+			}
+			fmt.Fprintf(&sb, " %s = %d;\n", f.GetName(), f.GetNumber())
+		}
+		sb.WriteString("}\n")
+	}
+	return sb.String()
+}
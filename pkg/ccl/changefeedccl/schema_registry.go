@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"path"
 	"time"
@@ -27,21 +28,32 @@ import (
 
 const confluentSchemaContentType = `application/vnd.schemaregistry.v1+json`
 
+// The types of the schemas registered with the schema registry.
+const (
+	confluentSchemaTypeAvro     = `AVRO`
+	confluentSchemaTypeProtobuf = `PROTOBUF`
+)
+
 type schemaRegistry interface {
 	// Ping tests the connectivity to the schema registry. A nil
 	// error is returned if the schema registry appears to be
 	// available.
 	Ping(ctx context.Context) error
 
-	// RegisterSchemaForSubject registers the given schema for the
-	// given subject. The returned int32 is a schema ID that can
-	// be used in Avro wire messages or in other calls to the
-	// schema registry.
-	RegisterSchemaForSubject(ctx context.Context, subject string, schema string) (int32, error)
+	// RegisterSchemaForSubject registers the given schema, which is
+	// of the given schema type, for the given subject. The returned
+	// int32 is a schema ID that can be used in Avro or Protobuf wire
+	// messages or in other calls to the schema registry.
+	RegisterSchemaForSubject(
+		ctx context.Context, subject string, schema string, schemaType string,
+	) (int32, error)
 }
 
 type confluentSchemaVersionRequest struct {
 	Schema string `json:"schema"`
+	// SchemaType is omitted for Avro schemas, which is the registry's
+	// default, for compatibility with older registries.
+	SchemaType string `json:"schemaType,omitempty"`
 }
 
 type confluentSchemaVersionResponse struct {
@@ -220,18 +232,21 @@ func (r *confluentSchemaRegistry) Ping(ctx context.Context) error {
 }
 
 // RegisterSchemaForSubject registers the given schema for the given
-// subject. The schema type is assumed to be AVRO.
+// subject.
 //
 //	https://docs.confluent.io/platform/current/schema-registry/develop/api.html#post--subjects-(string-%20subject)-versions
 func (r *confluentSchemaRegistry) RegisterSchemaForSubject(
-	ctx context.Context, subject string, schema string,
+	ctx context.Context, subject string, schema string, schemaType string,
 ) (int32, error) {
 	u := r.urlForPath(fmt.Sprintf("subjects/%s/versions", subject))
 	if log.V(1) {
-		log.Infof(ctx, "registering avro schema %s %s", u, schema)
+		log.Infof(ctx, "registering %s schema %s %s", schemaType, u, schema)
 	}
 
 	req := confluentSchemaVersionRequest{Schema: schema}
+	if schemaType != confluentSchemaTypeAvro {
+		req.SchemaType = schemaType
+	}
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(req); err != nil {
 		return 0, err
@@ -246,7 +261,13 @@ func (r *confluentSchemaRegistry) RegisterSchemaForSubject(
 		defer gracefulClose(ctx, resp.Body)
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 			body, _ := io.ReadAll(resp.Body)
-			return errors.Errorf("registering schema to %s %s: %s", u, resp.Status, body)
+			err := errors.Errorf("registering schema to %s %s: %s", u, resp.Status, body)
+			// The registry rejects schemas which are invalid or incompatible with
+			// the previous versions of the subject, and will keep rejecting them.
+			if resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusUnprocessableEntity {
+				return changefeedbase.MarkPermanentEventError(err)
+			}
+			return err
 		}
 		var res confluentSchemaVersionResponse
 		if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
@@ -313,8 +334,9 @@ func (r *confluentSchemaRegistry) urlForPath(relPath string) string {
 }
 
 type schemaRegistryCacheKey struct {
-	subject string
-	schema  string
+	subject    string
+	schema     string
+	schemaType string
 }
 
 type schemaRegistryCache struct {
@@ -350,10 +372,10 @@ func (csr *schemaRegistryWithCache) Ping(ctx context.Context) error {
 
 // RegisterSchemaForSubject implements the schemaRegistry interface.
 func (csr *schemaRegistryWithCache) RegisterSchemaForSubject(
-	ctx context.Context, subject string, schema string,
+	ctx context.Context, subject string, schema string, schemaType string,
 ) (int32, error) {
 	cacheKey := schemaRegistryCacheKey{
-		subject: subject, schema: schema,
+		subject: subject, schema: schema, schemaType: schemaType,
 	}
 	csr.cache.mu.Lock()
 	defer csr.cache.mu.Unlock()
@@ -361,7 +383,7 @@ func (csr *schemaRegistryWithCache) RegisterSchemaForSubject(
 	if ok {
 		return id, nil
 	}
-	id, err := csr.base.RegisterSchemaForSubject(ctx, subject, schema)
+	id, err := csr.base.RegisterSchemaForSubject(ctx, subject, schema, schemaType)
 	if err == nil {
 		csr.cache.Add(cacheKey, id)
 	}
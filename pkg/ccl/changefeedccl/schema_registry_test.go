@@ -16,6 +16,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/cidr"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
@@ -111,7 +112,7 @@ func TestConfluentSchemaRegistrySharedCache(t *testing.T) {
 		go func() {
 			r, err := newConfluentSchemaRegistry(regServer.URL(), nil, nil)
 			require.NoError(t, err)
-			_, err = r.RegisterSchemaForSubject(context.Background(), "subject1", "schema")
+			_, err = r.RegisterSchemaForSubject(context.Background(), "subject1", "schema", confluentSchemaTypeAvro)
 			require.NoError(t, err)
 			wg.Done()
 
@@ -126,7 +127,7 @@ func TestConfluentSchemaRegistrySharedCache(t *testing.T) {
 		go func(i int) {
 			r, err := newConfluentSchemaRegistry(regServer.URL(), nil, nil)
 			require.NoError(t, err)
-			_, err = r.RegisterSchemaForSubject(context.Background(), "subject1", fmt.Sprintf("schema1%d", i))
+			_, err = r.RegisterSchemaForSubject(context.Background(), "subject1", fmt.Sprintf("schema1%d", i), confluentSchemaTypeAvro)
 			require.NoError(t, err)
 			wg.Done()
 
@@ -178,7 +179,7 @@ func TestConfluentSchemaRegistryRetryMetrics(t *testing.T) {
 		require.NoError(t, err)
 		ctx, cancel := context.WithCancel(context.Background())
 		go func() {
-			_, err = reg.RegisterSchemaForSubject(ctx, "subject1", "schema1")
+			_, err = reg.RegisterSchemaForSubject(ctx, "subject1", "schema1", confluentSchemaTypeAvro)
 		}()
 		require.NoError(t, err)
 		testutils.SucceedsSoon(t, func() error {
@@ -192,3 +193,37 @@ func TestConfluentSchemaRegistryRetryMetrics(t *testing.T) {
 	})
 
 }
+
+// TestConfluentSchemaRegistryPermanentErrors verifies that only the responses
+// which reject the schema itself are marked as permanent event errors, so that
+// the event is written to the dead letter queue with on_error=dlq.
+func TestConfluentSchemaRegistryPermanentErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, tc := range []struct {
+		statusCode int
+		permanent  bool
+	}{
+		{statusCode: 409, permanent: true},
+		{statusCode: 422, permanent: true},
+		{statusCode: 401, permanent: false},
+		{statusCode: 503, permanent: false},
+	} {
+		t.Run(fmt.Sprint(tc.statusCode), func(t *testing.T) {
+			regServer := cdctest.StartErrorTestSchemaRegistry(tc.statusCode)
+			defer regServer.Close()
+
+			reg, err := newConfluentSchemaRegistry(regServer.URL(), nil, nil)
+			require.NoError(t, err)
+			base := reg.(*schemaRegistryWithCache).base.(*confluentSchemaRegistry)
+			base.retryOpts.MaxRetries = 1
+			base.retryOpts.InitialBackoff = time.Millisecond
+			_, err = reg.RegisterSchemaForSubject(
+				context.Background(), "subject1", "schema1", confluentSchemaTypeAvro,
+			)
+			require.Error(t, err)
+			require.Equal(t, tc.permanent, changefeedbase.IsPermanentEventError(err))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobsauth"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	sql.AddPlanHook("show changefeed dlq", showChangefeedDLQPlanHook, showChangefeedDLQTypeCheck)
+}
+
+// showChangefeedDLQBaseStmt reads the events of a changefeed from its dead
+// letter queue table, which may be shared with other changefeeds.
+const showChangefeedDLQBaseStmt = `SELECT
+		id, table_id, dlq_timestamp, dlq_reason, mvcc_timestamp, deleted, key, value, row
+	FROM %s
+	WHERE job_id = $1
+	ORDER BY dlq_timestamp, id`
+
+var showChangefeedDLQHeader = colinfo.ResultColumns{
+	{Name: "id", Typ: types.Int},
+	{Name: "table_id", Typ: types.Int},
+	{Name: "dlq_timestamp", Typ: types.TimestampTZ},
+	{Name: "dlq_reason", Typ: types.String},
+	{Name: "mvcc_timestamp", Typ: types.Decimal},
+	{Name: "deleted", Typ: types.Bool},
+	{Name: "key", Typ: types.Bytes},
+	{Name: "value", Typ: types.Bytes},
+	{Name: "row", Typ: types.Jsonb},
+}
+
+func showChangefeedDLQTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	showStmt, ok := stmt.(*tree.ShowChangefeedDLQ)
+	if !ok {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(
+		ctx, "SHOW CHANGEFEED DLQ", p.SemaCtx(), exprutil.Ints{showStmt.Job},
+	); err != nil {
+		return false, nil, err
+	}
+	return true, showChangefeedDLQHeader, nil
+}
+
+// showChangefeedDLQPlanHook implements sql.PlanHookFn. The events are read as
+// the current user, who must be able to view the changefeed job as well as
+// read its dead letter queue table.
+func showChangefeedDLQPlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, []sql.PlanNode, bool, error) {
+	showStmt, ok := stmt.(*tree.ShowChangefeedDLQ)
+	if !ok {
+		return nil, nil, nil, false, nil
+	}
+
+	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
+		id, err := p.ExprEvaluator("SHOW CHANGEFEED DLQ").Int(ctx, showStmt.Job)
+		if err != nil {
+			return pgerror.Wrap(err, pgcode.DatatypeMismatch, "changefeed ID must be an INT value")
+		}
+		jobID := jobspb.JobID(id)
+
+		job, err := p.ExecCfg().JobRegistry.LoadJobWithTxn(ctx, jobID, p.InternalSQLTxn())
+		if err != nil {
+			return errors.Wrapf(err, `could not load job with job id %d`, jobID)
+		}
+		jobPayload := job.Payload()
+		globalPrivileges, err := jobsauth.GetGlobalJobPrivileges(ctx, p)
+		if err != nil {
+			return err
+		}
+		if err := jobsauth.Authorize(
+			ctx, p, jobID, &jobPayload, jobsauth.ViewAccess, globalPrivileges,
+		); err != nil {
+			return err
+		}
+
+		details, ok := job.Details().(jobspb.ChangefeedDetails)
+		if !ok {
+			return errors.Errorf(`job %d is not changefeed job`, jobID)
+		}
+		dlqTable := details.Opts[changefeedbase.OptDLQTable]
+		if dlqTable == "" {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				`changefeed %d does not have a dead letter queue; it requires %s='%s'`,
+				jobID, changefeedbase.OptOnError, changefeedbase.OptOnErrorDLQ)
+		}
+
+		it, err := p.InternalSQLTxn().QueryIteratorEx(
+			ctx, "show-changefeed-dlq", p.Txn(),
+			sessiondata.InternalExecutorOverride{User: p.User()},
+			fmt.Sprintf(showChangefeedDLQBaseStmt, dlqTable), int64(jobID),
+		)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read dead letter queue table %s", dlqTable)
+		}
+		defer func() { _ = it.Close() }()
+
+		var more bool
+		for more, err = it.Next(ctx); more; more, err = it.Next(ctx) {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case resultsCh <- it.Cur():
+			}
+		}
+		return err
+	}
+	return fn, showChangefeedDLQHeader, nil, false, nil
+}
@@ -57,6 +57,8 @@ const (
 	sinkTypeCloudstorage
 	sinkTypeSQL
 	sinkTypePulsar
+	sinkTypeNATS
+	sinkTypeMQTT
 )
 
 // externalResource is the interface common to both EventSink and
@@ -108,6 +110,54 @@ type ResolvedTimestampSink interface {
 	EmitResolvedTimestamp(ctx context.Context, encoder Encoder, resolved hlc.Timestamp) error
 }
 
+// sinkDLQFunc writes a message which a sink accepted, but could never emit, to
+// the changefeed's dead letter queue.
+type sinkDLQFunc func(
+	ctx context.Context, topic TopicDescriptor, mvcc hlc.Timestamp, key, value []byte, reason error,
+) error
+
+// deadLetterQueueSink is implemented by sinks which emit messages
+// asynchronously, and so may only find out that a message can never be emitted
+// after EmitRow has returned. If a dead letter queue is set, such messages are
+// written to it rather than failing the changefeed.
+type deadLetterQueueSink interface {
+	setDeadLetterQueue(dlq sinkDLQFunc)
+}
+
+// setSinkDeadLetterQueue sets the dead letter queue of the sink if it is a
+// deadLetterQueueSink.
+func setSinkDeadLetterQueue(sink EventSink, dlq sinkDLQFunc) {
+	if s, ok := sink.(deadLetterQueueSink); ok {
+		s.setDeadLetterQueue(dlq)
+	}
+}
+
+// transactionalSink is implemented by sinks which can emit the messages of a
+// change aggregator in transactions. A transaction is prepared each time the
+// aggregator flushes its frontier, and committed once a checkpoint of the
+// job has recorded it, so that a restart of the changefeed aborts exactly
+// the messages which it is going to emit again.
+type transactionalSink interface {
+	// initTransactions starts producing messages in transactions whose
+	// transactional IDs are derived from the job ID and the index of the
+	// aggregator, fencing off any producer which previously used them.
+	initTransactions(ctx context.Context, jobID jobspb.JobID, aggregator int32) error
+	// prepareTransaction stops producing to the current transaction and
+	// starts a new one. It returns false if the prepared transaction holds no
+	// messages, in which case it is left open. The previously prepared
+	// transaction must have been committed.
+	prepareTransaction(ctx context.Context) (jobspb.PreparedSinkTransaction, bool, error)
+	// commitTransaction commits the prepared transaction.
+	commitTransaction(ctx context.Context) error
+	// recoverTransactions commits the transactions recorded in the progress
+	// of the changefeed's job, and fences off all its transactional IDs.
+	recoverTransactions(ctx context.Context, jobID jobspb.JobID, progress jobspb.ChangefeedProgress) error
+}
+
+// errSinkNotTransactional is returned by the transactionalSink methods of
+// sink wrappers whose wrapped sink does not support transactions.
+var errSinkNotTransactional = errors.New("sink does not support transactions")
+
 // SinkWithTopics extends the Sink interface to include a method that returns
 // the topics that a changefeed will emit to.
 type SinkWithTopics interface {
@@ -294,6 +344,18 @@ func getSink(
 			} else {
 				return makeDeprecatedPubsubSink(ctx, u, encodingOpts, AllTargets(feedCfg), opts.IsSet(changefeedbase.OptUnordered), metricsBuilder, testingKnobs)
 			}
+		case isNATSSink(u):
+			return validateOptionsAndMakeSink(changefeedbase.NATSValidOptions, func() (Sink, error) {
+				return makeNATSSink(ctx, sinkURL{URL: u}, encodingOpts, opts.GetNATSConfigJSON(), AllTargets(feedCfg),
+					numSinkIOWorkers(serverCfg), newCPUPacerFactory(ctx, serverCfg), timeutil.DefaultTimeSource{},
+					metricsBuilder, serverCfg.Settings)
+			})
+		case isMQTTSink(u):
+			return validateOptionsAndMakeSink(changefeedbase.MQTTValidOptions, func() (Sink, error) {
+				return makeMQTTSink(ctx, sinkURL{URL: u}, encodingOpts, opts.GetMQTTConfigJSON(), AllTargets(feedCfg),
+					numSinkIOWorkers(serverCfg), newCPUPacerFactory(ctx, serverCfg), timeutil.DefaultTimeSource{},
+					metricsBuilder, serverCfg.Settings)
+			})
 		case isCloudStorageSink(u):
 			return validateOptionsAndMakeSink(changefeedbase.CloudStorageValidOptions, func() (Sink, error) {
 				var testingKnobs *TestingKnobs
@@ -494,6 +556,65 @@ func (s errorWrapperSink) EncodeAndEmitRow(
 	return errors.AssertionFailedf("Expected a sink with encoder for, found %T", s.wrapped)
 }
 
+// setDeadLetterQueue implements the deadLetterQueueSink interface.
+func (s errorWrapperSink) setDeadLetterQueue(dlq sinkDLQFunc) {
+	if ds, ok := s.wrapped.(deadLetterQueueSink); ok {
+		ds.setDeadLetterQueue(dlq)
+	}
+}
+
+// initTransactions implements the transactionalSink interface.
+func (s errorWrapperSink) initTransactions(
+	ctx context.Context, jobID jobspb.JobID, aggregator int32,
+) error {
+	ts, ok := s.wrapped.(transactionalSink)
+	if !ok {
+		return errSinkNotTransactional
+	}
+	if err := ts.initTransactions(ctx, jobID, aggregator); err != nil {
+		return changefeedbase.MarkRetryableError(err)
+	}
+	return nil
+}
+
+// prepareTransaction implements the transactionalSink interface.
+func (s errorWrapperSink) prepareTransaction(
+	ctx context.Context,
+) (jobspb.PreparedSinkTransaction, bool, error) {
+	ts, ok := s.wrapped.(transactionalSink)
+	if !ok {
+		return jobspb.PreparedSinkTransaction{}, false, errSinkNotTransactional
+	}
+	txn, prepared, err := ts.prepareTransaction(ctx)
+	if err != nil {
+		return jobspb.PreparedSinkTransaction{}, false, changefeedbase.MarkRetryableError(err)
+	}
+	return txn, prepared, nil
+}
+
+// commitTransaction implements the transactionalSink interface.
+func (s errorWrapperSink) commitTransaction(ctx context.Context) error {
+	ts, ok := s.wrapped.(transactionalSink)
+	if !ok {
+		return errSinkNotTransactional
+	}
+	if err := ts.commitTransaction(ctx); err != nil {
+		return changefeedbase.MarkRetryableError(err)
+	}
+	return nil
+}
+
+// recoverTransactions implements the transactionalSink interface.
+func (s errorWrapperSink) recoverTransactions(
+	ctx context.Context, jobID jobspb.JobID, progress jobspb.ChangefeedProgress,
+) error {
+	ts, ok := s.wrapped.(transactionalSink)
+	if !ok {
+		return errSinkNotTransactional
+	}
+	return ts.recoverTransactions(ctx, jobID, progress)
+}
+
 // Dial implements Sink interface.
 func (s errorWrapperSink) Dial() error {
 	return s.wrapped.Dial()
@@ -737,6 +858,63 @@ func (s *safeSink) Flush(ctx context.Context) error {
 	return s.wrapped.Flush(ctx)
 }
 
+// setDeadLetterQueue implements the deadLetterQueueSink interface.
+func (s *safeSink) setDeadLetterQueue(dlq sinkDLQFunc) {
+	s.Lock()
+	defer s.Unlock()
+	setSinkDeadLetterQueue(s.wrapped, dlq)
+}
+
+// initTransactions implements the transactionalSink interface.
+func (s *safeSink) initTransactions(
+	ctx context.Context, jobID jobspb.JobID, aggregator int32,
+) error {
+	s.Lock()
+	defer s.Unlock()
+	ts, ok := s.wrapped.(transactionalSink)
+	if !ok {
+		return errSinkNotTransactional
+	}
+	return ts.initTransactions(ctx, jobID, aggregator)
+}
+
+// prepareTransaction implements the transactionalSink interface.
+func (s *safeSink) prepareTransaction(
+	ctx context.Context,
+) (jobspb.PreparedSinkTransaction, bool, error) {
+	s.Lock()
+	defer s.Unlock()
+	ts, ok := s.wrapped.(transactionalSink)
+	if !ok {
+		return jobspb.PreparedSinkTransaction{}, false, errSinkNotTransactional
+	}
+	return ts.prepareTransaction(ctx)
+}
+
+// commitTransaction implements the transactionalSink interface.
+func (s *safeSink) commitTransaction(ctx context.Context) error {
+	s.Lock()
+	defer s.Unlock()
+	ts, ok := s.wrapped.(transactionalSink)
+	if !ok {
+		return errSinkNotTransactional
+	}
+	return ts.commitTransaction(ctx)
+}
+
+// recoverTransactions implements the transactionalSink interface.
+func (s *safeSink) recoverTransactions(
+	ctx context.Context, jobID jobspb.JobID, progress jobspb.ChangefeedProgress,
+) error {
+	s.Lock()
+	defer s.Unlock()
+	ts, ok := s.wrapped.(transactionalSink)
+	if !ok {
+		return errSinkNotTransactional
+	}
+	return ts.recoverTransactions(ctx, jobID, progress)
+}
+
 // SinkWithEncoder A sink which both encodes and emits row events. Ideally, this
 // should not be embedding the Sink interface because then all the types that
 // implement this interface will also have to implement EmitRow (instead, they
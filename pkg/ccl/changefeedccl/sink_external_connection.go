@@ -103,6 +103,8 @@ var supportedExternalConnectionTypes = map[string]connectionpb.ConnectionProvide
 	changefeedbase.SinkSchemeWebhookHTTPS:          connectionpb.ConnectionProvider_webhookhttps,
 	changefeedbase.SinkSchemeConfluentKafka:        connectionpb.ConnectionProvider_kafka,
 	changefeedbase.SinkSchemeAzureKafka:            connectionpb.ConnectionProvider_kafka,
+	changefeedbase.SinkSchemeNATS:                  connectionpb.ConnectionProvider_nats,
+	changefeedbase.SinkSchemeMQTT:                  connectionpb.ConnectionProvider_mqtt,
 	// TODO (zinger): Not including SinkSchemeExperimentalSQL for now because A: it's undocumented
 	// and B, in tests it leaks a *gosql.DB and I can't figure out why.
 }
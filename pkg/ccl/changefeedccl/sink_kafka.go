@@ -206,6 +206,14 @@ type saramaConfig struct {
 	RequiredAcks string `json:",omitempty"`
 
 	Version string `json:",omitempty"`
+
+	// Transactional makes each change aggregator produce its messages in Kafka
+	// transactions, which are only committed once a checkpoint of the
+	// changefeed's job has recorded them. Consumers reading with
+	// isolation.level=read_committed then never see messages which a restart
+	// of the changefeed emits again because no checkpoint covered them. It is
+	// only supported by the kgo based sink.
+	Transactional bool `json:",omitempty"`
 }
 
 func (c saramaConfig) Validate() error {
@@ -370,6 +378,15 @@ func (s *kafkaSink) EmitRow(
 		return err
 	}
 
+	// A message which is too large will be rejected every time it is retried,
+	// so report it synchronously so that it may be sent to the dead letter
+	// queue rather than failing the flush.
+	if maxBytes := s.kafkaCfg.Producer.MaxMessageBytes; maxBytes > 0 && len(key)+len(value) > maxBytes {
+		return changefeedbase.MarkPermanentEventError(errors.Newf(
+			"kafka message of %d bytes exceeds the maximum message size of %d bytes",
+			len(key)+len(value), maxBytes))
+	}
+
 	// Since we cannot distinguish between time spent buffering vs emitting
 	// inside sarama, set the DownstreamClientSend timer to the same value as
 	// BatchHistNanos.
@@ -917,6 +934,17 @@ func getSaramaConfig(
 	return
 }
 
+// sinkUsesTransactions returns whether the changefeed's kafka_sink_config
+// makes its sink produce messages in transactions.
+func sinkUsesTransactions(opts changefeedbase.StatementOptions) (bool, error) {
+	cfg, err := getSaramaConfig(opts.GetKafkaConfigJSON())
+	if err != nil {
+		return false, errors.Wrapf(err,
+			"failed to parse sink config; check %s option", changefeedbase.OptKafkaSinkConfig)
+	}
+	return cfg.Transactional, nil
+}
+
 type kafkaDialConfig struct {
 	tlsEnabled            bool
 	tlsSkipVerify         bool
@@ -1322,6 +1350,9 @@ func buildKafkaConfig(
 		return nil, errors.Wrapf(err,
 			"failed to parse sarama config; check %s option", changefeedbase.OptKafkaSinkConfig)
 	}
+	if saramaCfg.Transactional {
+		return nil, errors.Errorf(`Transactional requires %s to be enabled`, KafkaV2Enabled.Name())
+	}
 
 	// Leverage sarama's proxy support to slip in our net metrics
 	config.Net.Proxy.Enable = true
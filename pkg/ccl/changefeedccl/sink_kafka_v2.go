@@ -9,6 +9,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"hash/fnv"
 	"io"
 	"net"
@@ -19,6 +20,8 @@ import (
 	"github.com/IBM/sarama"
 	"github.com/aws/aws-msk-iam-sasl-signer-go/signer"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/cidr"
@@ -34,6 +37,7 @@ import (
 	"github.com/twmb/franz-go/pkg/kadm"
 	"github.com/twmb/franz-go/pkg/kerr"
 	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
 	"github.com/twmb/franz-go/pkg/kversion"
 	"github.com/twmb/franz-go/pkg/sasl"
 	sasloauth "github.com/twmb/franz-go/pkg/sasl/oauth"
@@ -42,10 +46,55 @@ import (
 	"golang.org/x/oauth2/clientcredentials"
 )
 
+// kafkaTransactionTimeout is the transaction timeout of the transactional
+// producers. It is the default transaction.max.timeout.ms of Kafka brokers,
+// the greatest timeout they accept. Kafka aborts a transaction which is still
+// open after this long, so a changefeed which fails with a prepared
+// transaction must be resumed within this duration for the messages of that
+// transaction to be delivered.
+const kafkaTransactionTimeout = 15 * time.Minute
+
+// kafkaTransactionalProducers is the number of transactional producers of a
+// change aggregator. One of them holds the transaction which is waiting to be
+// recorded by a checkpoint while the other one produces new messages.
+const kafkaTransactionalProducers = 2
+
+// kafkaTransactionalID returns the transactional ID of a transactional
+// producer of a change aggregator. It only depends on the position of the
+// aggregator in the changefeed's flow, so that a restarted changefeed fences
+// off the producers of its previous flow.
+func kafkaTransactionalID(jobID jobspb.JobID, aggregator int32, producer int) string {
+	return fmt.Sprintf("crdb-changefeed-%d-%d-%d", jobID, aggregator, producer)
+}
+
 type kafkaSinkClientV2 struct {
 	batchCfg    sinkBatchConfig
 	client      KafkaClientV2
 	adminClient KafkaAdminClientV2
+	clientOpts  []kgo.Opt
+
+	// transactional is set if the sink was configured to produce messages in
+	// transactions. This only applies once initTransactions has been called;
+	// the resolved timestamps of the change frontier, for instance, are
+	// produced by client without transactions.
+	transactional bool
+	txnMu         struct {
+		syncutil.RWMutex
+		aggregator int32
+		// producers are the transactional producers of the change aggregator.
+		// The active one receives all messages. The other one either holds a
+		// prepared transaction or is idle.
+		producers [kafkaTransactionalProducers]*kafkaTransactionalProducer
+		active    int
+		prepared  bool
+	}
+	// activeTxnMu tracks the transaction of the active producer. It is locked
+	// by concurrent calls to Flush, which only hold txnMu for reading.
+	activeTxnMu struct {
+		syncutil.Mutex
+		produced bool
+		err      error
+	}
 
 	knobs          kafkaSinkV2Knobs
 	canTryResizing bool
@@ -73,12 +122,10 @@ func newKafkaSinkClientV2(
 	knobs kafkaSinkV2Knobs,
 	mb metricsRecorderBuilder,
 	topicsForConnectionCheck []string,
+	transactional bool,
 ) (*kafkaSinkClientV2, error) {
 
 	baseOpts := []kgo.Opt{
-		// Disable idempotency to maintain parity with the v1 sink and not add surface area for unknowns.
-		kgo.DisableIdempotentWrite(),
-
 		kgo.SeedBrokers(bootstrapAddrs),
 		kgo.WithLogger(kgoLogAdapter{ctx: ctx}),
 		kgo.RecordPartitioner(newKgoChangefeedPartitioner()),
@@ -99,6 +146,12 @@ func newKafkaSinkClientV2(
 		}),
 	}
 
+	if !transactional {
+		// Disable idempotency to maintain parity with the v1 sink and not add
+		// surface area for unknowns. Transactions require idempotent writes.
+		baseOpts = append(baseOpts, kgo.DisableIdempotentWrite())
+	}
+
 	recordResize := func(numRecords int64) {}
 	if m := mb(requiresResourceAccounting); m != nil { // `m` can be nil in tests.
 		baseOpts = append(baseOpts, kgo.WithHooks(&kgoMetricsAdapter{throttling: m.getKafkaThrottlingMetrics(settings)}))
@@ -124,11 +177,15 @@ func newKafkaSinkClientV2(
 	}
 
 	c := &kafkaSinkClientV2{
-		client:                   client,
-		adminClient:              adminClient,
-		knobs:                    knobs,
-		batchCfg:                 batchCfg,
-		canTryResizing:           changefeedbase.BatchReductionRetryEnabled.Get(&settings.SV),
+		client:        client,
+		adminClient:   adminClient,
+		clientOpts:    clientOpts,
+		transactional: transactional,
+		knobs:         knobs,
+		batchCfg:      batchCfg,
+		// Producing half of the messages again would add the other half to the
+		// transaction twice.
+		canTryResizing:           !transactional && changefeedbase.BatchReductionRetryEnabled.Get(&settings.SV),
 		recordResize:             recordResize,
 		topicsForConnectionCheck: topicsForConnectionCheck,
 	}
@@ -139,6 +196,13 @@ func newKafkaSinkClientV2(
 
 // Close implements SinkClient.
 func (k *kafkaSinkClientV2) Close() error {
+	k.txnMu.Lock()
+	defer k.txnMu.Unlock()
+	for _, p := range k.txnMu.producers {
+		if p != nil && p.client != nil {
+			p.client.Close()
+		}
+	}
 	k.client.Close()
 	return nil
 }
@@ -147,6 +211,14 @@ func (k *kafkaSinkClientV2) Close() error {
 func (k *kafkaSinkClientV2) Flush(ctx context.Context, payload SinkPayload) (retErr error) {
 	msgs := payload.([]*kgo.Record)
 
+	if k.transactional {
+		k.txnMu.RLock()
+		defer k.txnMu.RUnlock()
+		if p := k.txnMu.producers[k.txnMu.active]; p != nil {
+			return k.produceInTransaction(ctx, p.client, msgs)
+		}
+	}
+
 	var flushMsgs func(msgs []*kgo.Record) error
 	flushMsgs = func(msgs []*kgo.Record) error {
 		if err := k.client.ProduceSync(ctx, msgs...).FirstErr(); err != nil {
@@ -175,7 +247,12 @@ func (k *kafkaSinkClientV2) Flush(ctx context.Context, payload SinkPayload) (ret
 		}
 		return nil
 	}
-	return flushMsgs(msgs)
+	err := flushMsgs(msgs)
+	if err != nil && isPermanentKafkaError(err) {
+		// Producing the records again would fail the same way.
+		return changefeedbase.MarkPermanentEventError(err)
+	}
+	return err
 }
 
 // FlushResolvedPayload implements SinkClient.
@@ -279,9 +356,283 @@ func (k *kafkaSinkClientV2) shouldTryResizing(err error, msgs []*kgo.Record) boo
 	return errors.Is(err, kerr.MessageTooLarge)
 }
 
+// isPermanentKafkaError returns true if producing records failed because of
+// the records themselves, such as a record larger than the broker's or the
+// client's maximum message size, rather than because of the state of the
+// cluster.
+func isPermanentKafkaError(err error) bool {
+	return errors.Is(err, kerr.MessageTooLarge) ||
+		errors.Is(err, kerr.RecordListTooLarge) ||
+		errors.Is(err, kerr.InvalidRecord)
+}
+
+// kafkaTransactionalProducer is a transactional producer of a change
+// aggregator.
+type kafkaTransactionalProducer struct {
+	id     string
+	client KafkaClientV2
+}
+
+// newTransactionalProducer returns a client producing with the given
+// transactional ID. Initializing its producer ID bumps the epoch of the
+// transactional ID, which fences off any other producer using it and aborts
+// its open transaction.
+func (k *kafkaSinkClientV2) newTransactionalProducer(
+	ctx context.Context, id string,
+) (KafkaClientV2, error) {
+	var client KafkaClientV2
+	if k.knobs.OverrideTransactionalClient != nil {
+		client = k.knobs.OverrideTransactionalClient(id)
+	} else {
+		opts := append(k.clientOpts[:len(k.clientOpts):len(k.clientOpts)],
+			kgo.TransactionalID(id), kgo.TransactionTimeout(kafkaTransactionTimeout))
+		c, err := kgo.NewClient(opts...)
+		if err != nil {
+			return nil, err
+		}
+		client = c
+	}
+	if _, _, err := client.ProducerID(ctx); err != nil {
+		client.Close()
+		return nil, errors.Wrapf(err, "initializing transactional producer %s", id)
+	}
+	return client, nil
+}
+
+// initTransactions implements the transactionalSink interface.
+func (k *kafkaSinkClientV2) initTransactions(
+	ctx context.Context, jobID jobspb.JobID, aggregator int32,
+) error {
+	if !k.transactional {
+		return errSinkNotTransactional
+	}
+	k.txnMu.Lock()
+	defer k.txnMu.Unlock()
+	k.txnMu.aggregator = aggregator
+	for i := range k.txnMu.producers {
+		id := kafkaTransactionalID(jobID, aggregator, i)
+		client, err := k.newTransactionalProducer(ctx, id)
+		if err != nil {
+			return err
+		}
+		k.txnMu.producers[i] = &kafkaTransactionalProducer{id: id, client: client}
+	}
+	return k.txnMu.producers[k.txnMu.active].client.BeginTransaction()
+}
+
+// produceInTransaction produces messages to the active transaction. A failed
+// produce may still have added some of the messages to the transaction, and
+// producing them again would add them twice. The transaction fails instead:
+// so does every later produce to it, and preparing it, which makes the
+// changefeed restart from its last checkpoint and abort the transaction.
+func (k *kafkaSinkClientV2) produceInTransaction(
+	ctx context.Context, client KafkaClientV2, msgs []*kgo.Record,
+) error {
+	k.activeTxnMu.Lock()
+	err := k.activeTxnMu.err
+	k.activeTxnMu.produced = true
+	k.activeTxnMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := client.ProduceSync(ctx, msgs...).FirstErr(); err != nil {
+		k.activeTxnMu.Lock()
+		defer k.activeTxnMu.Unlock()
+		if k.activeTxnMu.err == nil {
+			k.activeTxnMu.err = errors.Wrap(err, "transaction failed")
+		}
+		return k.activeTxnMu.err
+	}
+	return nil
+}
+
+// prepareTransaction implements the transactionalSink interface.
+func (k *kafkaSinkClientV2) prepareTransaction(
+	ctx context.Context,
+) (jobspb.PreparedSinkTransaction, bool, error) {
+	k.txnMu.Lock()
+	defer k.txnMu.Unlock()
+	active := k.txnMu.producers[k.txnMu.active]
+	if active == nil {
+		return jobspb.PreparedSinkTransaction{}, false, errors.AssertionFailedf(
+			"transactions were not initialized")
+	}
+	if k.txnMu.prepared {
+		return jobspb.PreparedSinkTransaction{}, false, errors.AssertionFailedf(
+			"transaction of %s is still prepared", k.txnMu.producers[1-k.txnMu.active].id)
+	}
+
+	k.activeTxnMu.Lock()
+	defer k.activeTxnMu.Unlock()
+	if err := k.activeTxnMu.err; err != nil {
+		return jobspb.PreparedSinkTransaction{}, false, err
+	}
+	if !k.activeTxnMu.produced {
+		return jobspb.PreparedSinkTransaction{}, false, nil
+	}
+
+	// The producer ID and epoch identify the transaction, so that it can be
+	// committed by another client if this one does not get to it.
+	producerID, epoch, err := active.client.ProducerID(ctx)
+	if err != nil {
+		return jobspb.PreparedSinkTransaction{}, false, err
+	}
+	next := 1 - k.txnMu.active
+	if err := k.txnMu.producers[next].client.BeginTransaction(); err != nil {
+		return jobspb.PreparedSinkTransaction{}, false, err
+	}
+	k.txnMu.active = next
+	k.txnMu.prepared = true
+	k.activeTxnMu.produced = false
+
+	return jobspb.PreparedSinkTransaction{
+		Aggregator:      k.txnMu.aggregator,
+		TransactionalID: active.id,
+		ProducerID:      producerID,
+		ProducerEpoch:   int32(epoch),
+	}, true, nil
+}
+
+// commitTransaction implements the transactionalSink interface.
+func (k *kafkaSinkClientV2) commitTransaction(ctx context.Context) error {
+	k.txnMu.Lock()
+	defer k.txnMu.Unlock()
+	if !k.txnMu.prepared {
+		return nil
+	}
+	p := k.txnMu.producers[1-k.txnMu.active]
+	if err := p.client.EndTransaction(ctx, kgo.TryCommit); err != nil {
+		return errors.Wrapf(err, "committing transaction of %s", p.id)
+	}
+	k.txnMu.prepared = false
+
+	// The transaction stays recorded in the job's progress until the next
+	// one replaces it, and a restart of the changefeed commits it again.
+	// Initializing the producer again bumps its epoch, so that such a commit
+	// fails instead of committing the next transaction of the producer.
+	p.client.Close()
+	client, err := k.newTransactionalProducer(ctx, p.id)
+	if err != nil {
+		p.client = nil
+		k.txnMu.producers[1-k.txnMu.active] = nil
+		return err
+	}
+	p.client = client
+	return nil
+}
+
+// recoverTransactions implements the transactionalSink interface.
+func (k *kafkaSinkClientV2) recoverTransactions(
+	ctx context.Context, jobID jobspb.JobID, progress jobspb.ChangefeedProgress,
+) error {
+	if !k.transactional {
+		return errSinkNotTransactional
+	}
+	for _, txn := range progress.PreparedSinkTransactions {
+		if err := k.doTxnRequest(ctx, func() error {
+			return k.commitRecordedTransaction(ctx, txn)
+		}); err != nil {
+			return err
+		}
+	}
+	for aggregator := int32(0); aggregator < progress.SinkTransactionProducers; aggregator++ {
+		for i := 0; i < kafkaTransactionalProducers; i++ {
+			id := kafkaTransactionalID(jobID, aggregator, i)
+			if err := k.doTxnRequest(ctx, func() error {
+				return k.fenceTransactionalID(ctx, id)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// doTxnRequest runs fn, retrying it while it fails because the transaction
+// coordinator is busy or moving.
+func (k *kafkaSinkClientV2) doTxnRequest(ctx context.Context, fn func() error) error {
+	opts := retry.Options{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		MaxRetries:     10,
+	}
+	var err error
+	for r := retry.StartWithCtx(ctx, opts); r.Next(); {
+		err = fn()
+		if err == nil || !(kerr.IsRetriable(err) || errors.Is(err, kerr.ConcurrentTransactions)) {
+			return err
+		}
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// commitRecordedTransaction commits a transaction recorded in the progress of
+// the changefeed's job.
+func (k *kafkaSinkClientV2) commitRecordedTransaction(
+	ctx context.Context, txn jobspb.PreparedSinkTransaction,
+) error {
+	req := kmsg.NewPtrEndTxnRequest()
+	req.TransactionalID = txn.TransactionalID
+	req.ProducerID = txn.ProducerID
+	req.ProducerEpoch = int16(txn.ProducerEpoch)
+	req.Commit = true
+	resp, err := k.client.Request(ctx, req)
+	if err != nil {
+		return err
+	}
+	err = kerr.ErrorForCode(resp.(*kmsg.EndTxnResponse).ErrorCode)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, kerr.InvalidProducerEpoch), errors.Is(err, kerr.ProducerFenced),
+		errors.Is(err, kerr.InvalidProducerIDMapping):
+		// The producer was initialized again after it prepared the transaction.
+		// Change aggregators only do so once they have committed it.
+		log.Infof(ctx, "transaction of %s (producer %d, epoch %d) was already committed",
+			redact.SafeString(txn.TransactionalID), txn.ProducerID, txn.ProducerEpoch)
+		return nil
+	case errors.Is(err, kerr.InvalidTxnState):
+		// The transaction was aborted, so some of the messages which the
+		// checkpoint covers were never delivered.
+		return changefeedbase.WithTerminalError(errors.Wrapf(err,
+			"transaction of %s recorded by the changefeed's checkpoint was aborted",
+			txn.TransactionalID))
+	default:
+		return errors.Wrapf(err, "committing transaction of %s", txn.TransactionalID)
+	}
+}
+
+// fenceTransactionalID initializes the producer ID of a transactional ID,
+// which fences off its current producer and aborts its open transaction.
+func (k *kafkaSinkClientV2) fenceTransactionalID(ctx context.Context, id string) error {
+	req := kmsg.NewPtrInitProducerIDRequest()
+	req.TransactionalID = &id
+	req.TransactionTimeoutMillis = int32(kafkaTransactionTimeout.Milliseconds())
+	req.ProducerID = -1
+	req.ProducerEpoch = -1
+	resp, err := k.client.Request(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := kerr.ErrorForCode(resp.(*kmsg.InitProducerIDResponse).ErrorCode); err != nil {
+		return errors.Wrapf(err, "fencing off transactional producer %s", id)
+	}
+	return nil
+}
+
+var _ transactionalSink = (*kafkaSinkClientV2)(nil)
+
 // KafkaClientV2 is a small interface restricting the functionality in *kgo.Client
 type KafkaClientV2 interface {
 	ProduceSync(ctx context.Context, msgs ...*kgo.Record) kgo.ProduceResults
+	BeginTransaction() error
+	EndTransaction(ctx context.Context, commit kgo.TransactionEndTry) error
+	ProducerID(ctx context.Context) (int64, int16, error)
+	Request(ctx context.Context, req kmsg.Request) (kmsg.Response, error)
 	Close()
 }
 
@@ -294,6 +645,9 @@ type KafkaAdminClientV2 interface {
 
 type kafkaSinkV2Knobs struct {
 	OverrideClient func(opts []kgo.Opt) (KafkaClientV2, KafkaAdminClientV2)
+	// OverrideTransactionalClient, if set, returns the clients of the
+	// transactional producers.
+	OverrideTransactionalClient func(transactionalID string) KafkaClientV2
 }
 
 var _ SinkClient = (*kafkaSinkClientV2)(nil)
@@ -377,8 +731,17 @@ func makeKafkaSinkV2(
 			`unknown kafka sink query parameters: %s`, strings.Join(unknownParams, ", "))
 	}
 
+	sinkCfg, err := getSaramaConfig(jsonConfig)
+	if err != nil {
+		return nil, err
+	}
+	if sinkCfg.Transactional && !settings.Version.IsActive(ctx, clusterversion.V24_3_KafkaSinkTransactions) {
+		return nil, errors.Errorf(`Transactional requires the cluster to be upgraded`)
+	}
+
 	topicsForConnectionCheck := topicNamer.DisplayNamesSlice()
-	client, err := newKafkaSinkClientV2(ctx, clientOpts, batchCfg, u.Host, settings, knobs, mb, topicsForConnectionCheck)
+	client, err := newKafkaSinkClientV2(ctx, clientOpts, batchCfg, u.Host, settings, knobs, mb,
+		topicsForConnectionCheck, sinkCfg.Transactional)
 	if err != nil {
 		return nil, err
 	}
@@ -508,7 +871,18 @@ func buildKgoConfig(
 		opts = append(opts, kgo.ClientID(sinkCfg.ClientID))
 	}
 
-	switch strings.ToUpper(sinkCfg.RequiredAcks) {
+	requiredAcks := strings.ToUpper(sinkCfg.RequiredAcks)
+	if sinkCfg.Transactional {
+		// Transactions require idempotent writes, which require every in-sync
+		// replica to acknowledge them.
+		switch requiredAcks {
+		case ``, `ALL`, `-1`:
+			requiredAcks = `ALL`
+		default:
+			return nil, errors.Errorf(`Transactional requires RequiredAcks to be ALL`)
+		}
+	}
+	switch requiredAcks {
 	case ``, `ONE`, `1`: // This is our default.
 		opts = append(opts, kgo.RequiredAcks(kgo.LeaderAck()))
 	case `ALL`, `-1`:
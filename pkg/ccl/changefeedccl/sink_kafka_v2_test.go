@@ -18,19 +18,23 @@ import (
 	"github.com/IBM/sarama"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/mocks"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/twmb/franz-go/pkg/kadm"
 	"github.com/twmb/franz-go/pkg/kerr"
 	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
 	"github.com/twmb/franz-go/pkg/kversion"
 	"github.com/twmb/franz-go/pkg/sasl"
 )
@@ -140,7 +144,9 @@ func TestKafkaSinkClientV2_Resize(t *testing.T) {
 		fx, payload, payloadAnys := setup(t, false)
 		pr := kgo.ProduceResults{kgo.ProduceResult{Err: fmt.Errorf("..: %w", kerr.MessageTooLarge)}}
 		fx.kc.EXPECT().ProduceSync(fx.ctx, payloadAnys...).Times(1).Return(pr)
-		require.Error(t, fx.sink.Flush(fx.ctx, payload))
+		err := fx.sink.Flush(fx.ctx, payload)
+		require.ErrorIs(t, err, kerr.MessageTooLarge)
+		require.True(t, changefeedbase.IsPermanentEventError(err))
 	})
 
 	t.Run("resize enabled and it keeps failing", func(t *testing.T) {
@@ -157,7 +163,9 @@ func TestKafkaSinkClientV2_Resize(t *testing.T) {
 			fx.kc.EXPECT().ProduceSync(fx.ctx, payloadAnys[:3]...).Times(1).Return(pr),
 			fx.kc.EXPECT().ProduceSync(fx.ctx, payloadAnys[:1]...).Times(1).Return(pr),
 		)
-		require.Error(t, fx.sink.Flush(fx.ctx, payload))
+		err := fx.sink.Flush(fx.ctx, payload)
+		require.ErrorIs(t, err, kerr.MessageTooLarge)
+		require.True(t, changefeedbase.IsPermanentEventError(err))
 	})
 
 	t.Run("resize enabled and it gets everything", func(t *testing.T) {
@@ -183,6 +191,107 @@ func TestKafkaSinkClientV2_Resize(t *testing.T) {
 	})
 }
 
+func TestKafkaSinkClientV2_PermanentErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, tc := range []struct {
+		err       error
+		permanent bool
+	}{
+		{err: kerr.MessageTooLarge, permanent: true},
+		{err: kerr.RecordListTooLarge, permanent: true},
+		{err: kerr.InvalidRecord, permanent: true},
+		{err: kerr.NotEnoughReplicas, permanent: false},
+		{err: kerr.UnknownTopicOrPartition, permanent: false},
+	} {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			fx := newKafkaSinkV2Fx(t)
+			defer fx.close()
+
+			buf := fx.sink.MakeBatchBuffer("t")
+			buf.Append([]byte("k1"), []byte("v1"), attributes{})
+			payload, err := buf.Close()
+			require.NoError(t, err)
+
+			pr := kgo.ProduceResults{kgo.ProduceResult{Err: fmt.Errorf("..: %w", tc.err)}}
+			fx.kc.EXPECT().ProduceSync(fx.ctx, gomock.Any()).Times(1).Return(pr)
+			err = fx.sink.Flush(fx.ctx, payload)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.permanent, changefeedbase.IsPermanentEventError(err))
+		})
+	}
+}
+
+func TestKafkaSinkClientV2_DeadLetterQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// The batch of three messages fails because one of them is too large,
+	// after which they are produced one at a time.
+	type produceCalls struct {
+		num      int
+		produced []string
+	}
+	setup := func(t *testing.T) (*kafkaSinkV2Fx, *produceCalls) {
+		fx := newKafkaSinkV2Fx(t, withJSONConfig(`{"Flush": {"Messages": 3, "Frequency": "1h"}}`))
+		var calls produceCalls
+		fx.kc.EXPECT().ProduceSync(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+			func(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults {
+				calls.num++
+				for _, r := range records {
+					if string(r.Value) == "too large" {
+						return kgo.ProduceResults{kgo.ProduceResult{Err: fmt.Errorf("..: %w", kerr.MessageTooLarge)}}
+					}
+				}
+				for _, r := range records {
+					calls.produced = append(calls.produced, string(r.Value))
+				}
+				return nil
+			})
+		return fx, &calls
+	}
+	emit := func(t *testing.T, fx *kafkaSinkV2Fx) {
+		for _, value := range []string{"v1", "too large", "v2"} {
+			require.NoError(t, fx.bs.EmitRow(fx.ctx, topic("t"), []byte("k"), []byte(value), zeroTS, zeroTS, zeroAlloc))
+		}
+	}
+
+	t.Run("with dlq", func(t *testing.T) {
+		fx, calls := setup(t)
+		defer fx.close()
+
+		var dlq []string
+		var reasons []error
+		fx.bs.setDeadLetterQueue(func(
+			_ context.Context, _ TopicDescriptor, _ hlc.Timestamp, key, value []byte, reason error,
+		) error {
+			dlq = append(dlq, string(value))
+			reasons = append(reasons, reason)
+			return nil
+		})
+		emit(t, fx)
+		require.NoError(t, fx.bs.Flush(fx.ctx))
+		require.Equal(t, 4, calls.num)
+		require.Equal(t, []string{"v1", "v2"}, calls.produced)
+		require.Equal(t, []string{"too large"}, dlq)
+		require.ErrorIs(t, reasons[0], kerr.MessageTooLarge)
+	})
+
+	t.Run("without dlq", func(t *testing.T) {
+		fx, calls := setup(t)
+		defer fx.close()
+
+		emit(t, fx)
+		err := fx.bs.Flush(fx.ctx)
+		require.ErrorIs(t, err, kerr.MessageTooLarge)
+		require.True(t, changefeedbase.IsPermanentEventError(err))
+		// The error is permanent, so the batch is not retried.
+		require.Equal(t, 1, calls.num)
+		require.Empty(t, calls.produced)
+	})
+}
+
 // These are really tests of the TopicNamer and our configuration of it.
 func TestKafkaSinkClientV2_Naming(t *testing.T) {
 	defer leaktest.AfterTest(t)()
@@ -353,6 +462,16 @@ func TestKafkaSinkClientV2_Opts(t *testing.T) {
 			},
 			expectedBatchingSinkMinFreq: 2 * time.Second,
 		},
+		{
+			name: "transactional",
+			jsonConfig: map[string]any{
+				"Transactional": true,
+			},
+			expectedOpts: map[string]any{
+				"RequiredAcks":           kgo.AllISRAcks(),
+				"DisableIdempotentWrite": false,
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -532,6 +651,203 @@ func TestKafkaSinkClientV2_ErrorsEventually(t *testing.T) {
 	require.Error(t, fx.sink.Flush(fx.ctx, payload))
 }
 
+func TestKafkaSinkClientV2_Transactions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	fx := newKafkaSinkV2Fx(t, withTransactional())
+	defer fx.close()
+
+	const jobID = jobspb.JobID(42)
+	p0 := fx.txnClient(kafkaTransactionalID(jobID, 3, 0))
+	p1 := fx.txnClient(kafkaTransactionalID(jobID, 3, 1))
+
+	// Initializing the transactions fences off both transactional IDs of the
+	// aggregator and begins a transaction on the first one.
+	gomock.InOrder(
+		p0.EXPECT().ProducerID(fx.ctx).Times(1).Return(int64(7), int16(0), nil),
+		p1.EXPECT().ProducerID(fx.ctx).Times(1).Return(int64(8), int16(0), nil),
+		p0.EXPECT().BeginTransaction().Times(1).Return(nil),
+	)
+	require.NoError(t, fx.sink.initTransactions(fx.ctx, jobID, 3))
+
+	// There is nothing to prepare until messages are produced.
+	_, ok, err := fx.sink.prepareTransaction(fx.ctx)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	buf := fx.sink.MakeBatchBuffer("t")
+	buf.Append([]byte("k1"), []byte("v1"), attributes{})
+	payload, err := buf.Close()
+	require.NoError(t, err)
+
+	// Messages go to the active transactional producer, not to the client
+	// of the sink.
+	p0.EXPECT().ProduceSync(fx.ctx, payload.([]*kgo.Record)).Times(1).Return(nil)
+	require.NoError(t, fx.sink.Flush(fx.ctx, payload))
+
+	gomock.InOrder(
+		p0.EXPECT().ProducerID(fx.ctx).Times(1).Return(int64(7), int16(0), nil),
+		p1.EXPECT().BeginTransaction().Times(1).Return(nil),
+	)
+	txn, ok, err := fx.sink.prepareTransaction(fx.ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, jobspb.PreparedSinkTransaction{
+		Aggregator:      3,
+		TransactionalID: "crdb-changefeed-42-3-0",
+		ProducerID:      7,
+		ProducerEpoch:   0,
+	}, txn)
+
+	// The next messages go to the other producer while the prepared
+	// transaction waits to be recorded.
+	p1.EXPECT().ProduceSync(fx.ctx, payload.([]*kgo.Record)).Times(1).Return(nil)
+	require.NoError(t, fx.sink.Flush(fx.ctx, payload))
+
+	// Another transaction cannot be prepared until the first one is committed.
+	_, _, err = fx.sink.prepareTransaction(fx.ctx)
+	require.True(t, errors.HasAssertionFailure(err))
+
+	// Committing the transaction initializes its producer again, which bumps
+	// its epoch.
+	gomock.InOrder(
+		p0.EXPECT().EndTransaction(fx.ctx, kgo.TryCommit).Times(1).Return(nil),
+		p0.EXPECT().Close().Times(1),
+		p0.EXPECT().ProducerID(fx.ctx).Times(1).Return(int64(7), int16(1), nil),
+	)
+	require.NoError(t, fx.sink.commitTransaction(fx.ctx))
+	// There is nothing left to commit.
+	require.NoError(t, fx.sink.commitTransaction(fx.ctx))
+
+	gomock.InOrder(
+		p1.EXPECT().ProducerID(fx.ctx).Times(1).Return(int64(8), int16(0), nil),
+		p0.EXPECT().BeginTransaction().Times(1).Return(nil),
+	)
+	txn, ok, err = fx.sink.prepareTransaction(fx.ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "crdb-changefeed-42-3-1", txn.TransactionalID)
+}
+
+func TestKafkaSinkClientV2_TransactionFails(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	fx := newKafkaSinkV2Fx(t, withTransactional())
+	defer fx.close()
+
+	p0 := fx.txnClient(kafkaTransactionalID(1, 0, 0))
+	p1 := fx.txnClient(kafkaTransactionalID(1, 0, 1))
+	p0.EXPECT().ProducerID(fx.ctx).Times(1).Return(int64(7), int16(0), nil)
+	p1.EXPECT().ProducerID(fx.ctx).Times(1).Return(int64(8), int16(0), nil)
+	p0.EXPECT().BeginTransaction().Times(1).Return(nil)
+	require.NoError(t, fx.sink.initTransactions(fx.ctx, 1, 0))
+
+	buf := fx.sink.MakeBatchBuffer("t")
+	buf.Append([]byte("k1"), []byte("v1"), attributes{})
+	payload, err := buf.Close()
+	require.NoError(t, err)
+
+	// A failed produce is not retried: some of its messages may already be
+	// in the transaction. Every later produce fails without reaching Kafka,
+	// and so does preparing the transaction.
+	pr := kgo.ProduceResults{kgo.ProduceResult{Err: kerr.NotEnoughReplicas}}
+	p0.EXPECT().ProduceSync(fx.ctx, gomock.Any()).Times(1).Return(pr)
+	require.ErrorIs(t, fx.sink.Flush(fx.ctx, payload), kerr.NotEnoughReplicas)
+	require.ErrorIs(t, fx.sink.Flush(fx.ctx, payload), kerr.NotEnoughReplicas)
+	_, _, err = fx.sink.prepareTransaction(fx.ctx)
+	require.ErrorIs(t, err, kerr.NotEnoughReplicas)
+}
+
+func TestKafkaSinkClientV2_NotTransactional(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	fx := newKafkaSinkV2Fx(t)
+	defer fx.close()
+
+	require.ErrorIs(t, fx.sink.initTransactions(fx.ctx, 1, 0), errSinkNotTransactional)
+	require.ErrorIs(t, fx.sink.recoverTransactions(fx.ctx, 1, jobspb.ChangefeedProgress{}),
+		errSinkNotTransactional)
+}
+
+func TestKafkaSinkClientV2_RecoverTransactions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const jobID = jobspb.JobID(42)
+	progress := jobspb.ChangefeedProgress{
+		PreparedSinkTransactions: []jobspb.PreparedSinkTransaction{
+			{Aggregator: 0, TransactionalID: kafkaTransactionalID(jobID, 0, 1), ProducerID: 7, ProducerEpoch: 2},
+			{Aggregator: 1, TransactionalID: kafkaTransactionalID(jobID, 1, 0), ProducerID: 8, ProducerEpoch: 5},
+		},
+		SinkTransactionProducers: 2,
+	}
+
+	isEndTxn := func(txn jobspb.PreparedSinkTransaction) gomock.Matcher {
+		return fnMatcher(func(arg any) bool {
+			req, ok := arg.(*kmsg.EndTxnRequest)
+			return ok && req.Commit && req.TransactionalID == txn.TransactionalID &&
+				req.ProducerID == txn.ProducerID && req.ProducerEpoch == int16(txn.ProducerEpoch)
+		})
+	}
+	endTxnResponse := func(err *kerr.Error) kmsg.Response {
+		resp := kmsg.NewPtrEndTxnResponse()
+		if err != nil {
+			resp.ErrorCode = err.Code
+		}
+		return resp
+	}
+	isInitProducerID := func(id string) gomock.Matcher {
+		return fnMatcher(func(arg any) bool {
+			req, ok := arg.(*kmsg.InitProducerIDRequest)
+			return ok && req.TransactionalID != nil && *req.TransactionalID == id &&
+				req.ProducerID == -1 && req.ProducerEpoch == -1
+		})
+	}
+	expectFencing := func(fx *kafkaSinkV2Fx) {
+		var calls []any
+		for aggregator := int32(0); aggregator < progress.SinkTransactionProducers; aggregator++ {
+			for i := 0; i < kafkaTransactionalProducers; i++ {
+				calls = append(calls, fx.kc.EXPECT().
+					Request(fx.ctx, isInitProducerID(kafkaTransactionalID(jobID, aggregator, i))).
+					Times(1).Return(kmsg.NewPtrInitProducerIDResponse(), nil))
+			}
+		}
+		gomock.InOrder(calls...)
+	}
+
+	t.Run("commits", func(t *testing.T) {
+		fx := newKafkaSinkV2Fx(t, withTransactional())
+		defer fx.close()
+
+		gomock.InOrder(
+			// The transaction coordinator is busy, so the commit is retried.
+			fx.kc.EXPECT().Request(fx.ctx, isEndTxn(progress.PreparedSinkTransactions[0])).
+				Times(1).Return(endTxnResponse(kerr.ConcurrentTransactions), nil),
+			fx.kc.EXPECT().Request(fx.ctx, isEndTxn(progress.PreparedSinkTransactions[0])).
+				Times(1).Return(endTxnResponse(nil), nil),
+			// The producer was fenced off after it committed the transaction.
+			fx.kc.EXPECT().Request(fx.ctx, isEndTxn(progress.PreparedSinkTransactions[1])).
+				Times(1).Return(endTxnResponse(kerr.ProducerFenced), nil),
+		)
+		expectFencing(fx)
+		require.NoError(t, fx.sink.recoverTransactions(fx.ctx, jobID, progress))
+	})
+
+	t.Run("aborted", func(t *testing.T) {
+		fx := newKafkaSinkV2Fx(t, withTransactional())
+		defer fx.close()
+
+		fx.kc.EXPECT().Request(fx.ctx, isEndTxn(progress.PreparedSinkTransactions[0])).
+			Times(1).Return(endTxnResponse(kerr.InvalidTxnState), nil)
+		err := fx.sink.recoverTransactions(fx.ctx, jobID, progress)
+		require.ErrorIs(t, err, kerr.InvalidTxnState)
+		require.Regexp(t, "recorded by the changefeed's checkpoint was aborted", err)
+	})
+}
+
 func TestKafkaSinkClientV2_PartitionsSameAsV1(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -582,6 +898,11 @@ type kafkaSinkV2Fx struct {
 	realClient          bool
 	additionalKOpts     []kgo.Opt
 	createClientErrorCb func(error)
+	transactional       bool
+
+	// txnClients are the clients of the transactional producers of sink,
+	// by transactional ID.
+	txnClients map[string]*mocks.MockKafkaClientV2
 
 	sink *kafkaSinkClientV2
 	bs   *batchingSink
@@ -631,6 +952,12 @@ func withKOptsClient(kOpts []kgo.Opt) fxOpt {
 	}
 }
 
+func withTransactional() fxOpt {
+	return func(fx *kafkaSinkV2Fx) {
+		fx.transactional = true
+	}
+}
+
 func withCreateClientErrorCb(cb func(error)) fxOpt {
 	return func(fx *kafkaSinkV2Fx) {
 		fx.createClientErrorCb = cb
@@ -653,6 +980,7 @@ func newKafkaSinkV2Fx(t *testing.T, opts ...fxOpt) *kafkaSinkV2Fx {
 		ac:          ac,
 		mockCtrl:    ctrl,
 		targetNames: []string{"t"},
+		txnClients:  make(map[string]*mocks.MockKafkaClientV2),
 	}
 
 	for _, opt := range opts {
@@ -665,10 +993,13 @@ func newKafkaSinkV2Fx(t *testing.T, opts ...fxOpt) *kafkaSinkV2Fx {
 		knobs.OverrideClient = func(opts []kgo.Opt) (KafkaClientV2, KafkaAdminClientV2) {
 			return kc, ac
 		}
+		knobs.OverrideTransactionalClient = func(id string) KafkaClientV2 {
+			return fx.txnClient(id)
+		}
 	}
 
 	var err error
-	fx.sink, err = newKafkaSinkClientV2(ctx, fx.additionalKOpts, fx.batchConfig, "no addrs", settings, knobs, nilMetricsRecorderBuilder, nil)
+	fx.sink, err = newKafkaSinkClientV2(ctx, fx.additionalKOpts, fx.batchConfig, "no addrs", settings, knobs, nilMetricsRecorderBuilder, nil, fx.transactional)
 	if err != nil && fx.createClientErrorCb != nil {
 		fx.createClientErrorCb(err)
 		return fx
@@ -700,11 +1031,25 @@ func newKafkaSinkV2Fx(t *testing.T, opts ...fxOpt) *kafkaSinkV2Fx {
 	return fx
 }
 
+// txnClient returns the client of the transactional producer with the given
+// ID. The sink gets the same client whenever it creates that producer again.
+func (fx *kafkaSinkV2Fx) txnClient(id string) *mocks.MockKafkaClientV2 {
+	c, ok := fx.txnClients[id]
+	if !ok {
+		c = mocks.NewMockKafkaClientV2(fx.mockCtrl)
+		fx.txnClients[id] = c
+	}
+	return c
+}
+
 func (fx *kafkaSinkV2Fx) close() {
 	if fx.sink != nil {
 		if _, ok := fx.sink.client.(*mocks.MockKafkaClientV2); ok {
 			fx.kc.EXPECT().Close().AnyTimes()
 		}
+		for _, c := range fx.txnClients {
+			c.EXPECT().Close().AnyTimes()
+		}
 		require.NoError(fx.t, fx.sink.Close())
 	}
 	if fx.bs != nil {
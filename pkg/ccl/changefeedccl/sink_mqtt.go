@@ -0,0 +1,343 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/cidr"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	mqttDefaultPort    = `1883`
+	mqttDefaultTLSPort = `8883`
+	// mqttDefaultQoS is the default quality of service of published messages.
+	// QoS 0 is not supported since the broker does not acknowledge messages
+	// published with it.
+	mqttDefaultQoS = 1
+	// mqttConnectTimeout bounds the time spent connecting to the broker.
+	mqttConnectTimeout = 10 * time.Second
+	// mqttPublishAckTimeout bounds the time spent waiting for the broker to
+	// acknowledge a batch of messages.
+	mqttPublishAckTimeout = 30 * time.Second
+	// mqttDisconnectQuiesce is the time in milliseconds given to in-flight
+	// work to complete when disconnecting from the broker.
+	mqttDisconnectQuiesce = 250
+)
+
+// isMQTTSink returns true if url contains scheme with valid MQTT sink
+func isMQTTSink(u *url.URL) bool {
+	return u.Scheme == changefeedbase.SinkSchemeMQTT
+}
+
+// mqttClient is the subset of mqtt.Client used by the MQTT sink.
+type mqttClient interface {
+	Publish(topic string, qos byte, retained bool, payload interface{}) mqtt.Token
+	IsConnectionOpen() bool
+	Disconnect(quiesce uint)
+}
+
+var _ mqttClient = (mqtt.Client)(nil)
+
+// mqttSinkClient publishes messages to an MQTT broker.
+type mqttSinkClient struct {
+	client   mqttClient
+	qos      byte
+	batchCfg sinkBatchConfig
+}
+
+var _ SinkClient = (*mqttSinkClient)(nil)
+
+func makeMQTTSinkClient(
+	ctx context.Context,
+	u *url.URL,
+	tlsConfig *tls.Config,
+	qos byte,
+	batchCfg sinkBatchConfig,
+	nm *cidr.NetMetrics,
+) (*mqttSinkClient, error) {
+	host := u.Host
+	if u.Port() == `` {
+		port := mqttDefaultPort
+		if tlsConfig != nil {
+			port = mqttDefaultTLSPort
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+	dial := nm.Wrap((&net.Dialer{Timeout: mqttConnectTimeout}).DialContext, "mqtt")
+
+	opts := mqtt.NewClientOptions().
+		AddBroker((&url.URL{Scheme: `tcp`, Host: host}).String()).
+		// Client IDs must be unique among the clients connected to a broker, and
+		// brokers may reject those longer than 23 bytes.
+		SetClientID(fmt.Sprintf("crdb-%016x", rand.Uint64())).
+		SetCleanSession(true).
+		SetConnectTimeout(mqttConnectTimeout).
+		SetCustomOpenConnectionFn(func(broker *url.URL, _ mqtt.ClientOptions) (net.Conn, error) {
+			return dialMQTTBroker(ctx, dial, broker, tlsConfig)
+		})
+	if u.User != nil {
+		opts.SetUsername(u.User.Username())
+		if password, ok := u.User.Password(); ok {
+			opts.SetPassword(password)
+		}
+	}
+
+	client := mqtt.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(mqttConnectTimeout) {
+		return nil, errors.Errorf("timed out connecting to MQTT broker %s", host)
+	}
+	if err := token.Error(); err != nil {
+		return nil, errors.Wrapf(err, "connecting to MQTT broker %s", host)
+	}
+
+	return &mqttSinkClient{client: client, qos: qos, batchCfg: batchCfg}, nil
+}
+
+// dialMQTTBroker opens a connection to the broker, which records network
+// metrics, and establishes TLS over it if tlsConfig is set.
+func dialMQTTBroker(
+	ctx context.Context, dial cidr.DialContext, broker *url.URL, tlsConfig *tls.Config,
+) (net.Conn, error) {
+	conn, err := dial(ctx, "tcp", broker.Host)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		return conn, nil
+	}
+
+	cfg := tlsConfig.Clone()
+	if cfg.ServerName == `` {
+		cfg.ServerName = broker.Hostname()
+	}
+	tlsConn := tls.Client(conn, cfg)
+	handshakeCtx, cancel := context.WithTimeout(ctx, mqttConnectTimeout)
+	defer cancel()
+	if err := tlsConn.HandshakeContext(handshakeCtx); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// CheckConnection implements the SinkClient interface.
+func (sc *mqttSinkClient) CheckConnection(ctx context.Context) error {
+	if !sc.client.IsConnectionOpen() {
+		return errors.New("not connected to MQTT broker")
+	}
+	return nil
+}
+
+// FlushResolvedPayload implements the SinkClient interface.
+func (sc *mqttSinkClient) FlushResolvedPayload(
+	ctx context.Context,
+	body []byte,
+	forEachTopic func(func(topic string) error) error,
+	retryOpts retry.Options,
+) error {
+	return forEachTopic(func(topic string) error {
+		payload := &mqttPayload{topic: topic, messages: [][]byte{body}}
+		return retry.WithMaxAttempts(ctx, retryOpts, retryOpts.MaxRetries+1, func() error {
+			return sc.Flush(ctx, payload)
+		})
+	})
+}
+
+// Flush implements the SinkClient interface.
+//
+// The messages of the batch are published in order over a single connection,
+// and the broker acknowledges each of them once it has taken ownership of it.
+// As with the NATS sink, the batching sink only flushes a batch containing a
+// key once the previous batch containing it was acknowledged.
+func (sc *mqttSinkClient) Flush(ctx context.Context, payload SinkPayload) error {
+	p := payload.(*mqttPayload)
+	tokens := make([]mqtt.Token, 0, len(p.messages))
+	for _, msg := range p.messages {
+		tokens = append(tokens, sc.client.Publish(p.topic, sc.qos, false /* retained */, msg))
+	}
+
+	timer := time.NewTimer(mqttPublishAckTimeout)
+	defer timer.Stop()
+	for _, token := range tokens {
+		select {
+		case <-token.Done():
+			if err := token.Error(); err != nil {
+				return errors.Wrapf(err, "publishing to %s", p.topic)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return errors.Errorf("timed out waiting for MQTT broker to acknowledge messages published to %s",
+				p.topic)
+		}
+	}
+	return nil
+}
+
+// Close implements the SinkClient interface.
+func (sc *mqttSinkClient) Close() error {
+	sc.client.Disconnect(mqttDisconnectQuiesce)
+	return nil
+}
+
+// MakeBatchBuffer implements the SinkClient interface.
+func (sc *mqttSinkClient) MakeBatchBuffer(topic string) BatchBuffer {
+	return &mqttBuffer{
+		sc: sc,
+		payload: &mqttPayload{
+			topic:    topic,
+			messages: make([][]byte, 0, sc.batchCfg.Messages),
+		},
+	}
+}
+
+// mqttPayload is a batch of messages published to the same topic.
+type mqttPayload struct {
+	topic    string
+	messages [][]byte
+}
+
+type mqttBuffer struct {
+	sc       *mqttSinkClient
+	payload  *mqttPayload
+	numBytes int
+}
+
+var _ BatchBuffer = (*mqttBuffer)(nil)
+
+// Append implements the BatchBuffer interface. MQTT messages have no key, so
+// the key_in_value option is forced for this sink.
+func (b *mqttBuffer) Append(key []byte, value []byte, _ attributes) {
+	b.payload.messages = append(b.payload.messages, value)
+	b.numBytes += len(value)
+}
+
+// ShouldFlush implements the BatchBuffer interface.
+func (b *mqttBuffer) ShouldFlush() bool {
+	return shouldFlushBatch(b.numBytes, len(b.payload.messages), b.sc.batchCfg)
+}
+
+// Close implements the BatchBuffer interface.
+func (b *mqttBuffer) Close() (SinkPayload, error) {
+	return b.payload, nil
+}
+
+// validateMQTTFormat checks that the format can be used with the MQTT sink.
+// MQTT messages have no key or headers, so the key_in_value option is forced,
+// which only the JSON and CSV encoders support.
+func validateMQTTFormat(encodingOpts changefeedbase.EncodingOptions) error {
+	switch encodingOpts.Format {
+	case changefeedbase.OptFormatJSON, changefeedbase.OptFormatCSV:
+	case changefeedbase.OptFormatAvro, changefeedbase.OptFormatProtobuf:
+		return errors.WithHintf(
+			errors.Errorf(`this sink is incompatible with %s=%s, since MQTT messages cannot carry a key`,
+				changefeedbase.OptFormat, encodingOpts.Format),
+			`Use %s=%s or %s=%s.`, changefeedbase.OptFormat, changefeedbase.OptFormatJSON,
+			changefeedbase.OptFormat, changefeedbase.OptFormatCSV)
+	default:
+		return errors.Errorf(`this sink is incompatible with %s=%s`,
+			changefeedbase.OptFormat, encodingOpts.Format)
+	}
+	return nil
+}
+
+func makeMQTTSink(
+	ctx context.Context,
+	u sinkURL,
+	encodingOpts changefeedbase.EncodingOptions,
+	jsonConfig changefeedbase.SinkSpecificJSONConfig,
+	targets changefeedbase.Targets,
+	parallelism int,
+	pacerFactory func() *admission.Pacer,
+	source timeutil.TimeSource,
+	mb metricsRecorderBuilder,
+	settings *cluster.Settings,
+) (Sink, error) {
+	m := mb(requiresResourceAccounting)
+
+	if err := validateMQTTFormat(encodingOpts); err != nil {
+		return nil, err
+	}
+
+	batchCfg, retryOpts, err := getSinkConfigFromJson(jsonConfig, sinkJSONConfig{
+		Flush: sinkBatchConfig{
+			Frequency: jsonDuration(10 * time.Millisecond),
+			Messages:  100,
+			Bytes:     1e6,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Host == `` {
+		return nil, errors.Errorf(`missing MQTT broker address in %s sink URI`, changefeedbase.SinkSchemeMQTT)
+	}
+	tlsConfig, err := consumeSinkTLSConfig(&u, false /* tlsByDefault */)
+	if err != nil {
+		return nil, err
+	}
+
+	qos := byte(mqttDefaultQoS)
+	if qosParam := u.consumeParam(changefeedbase.SinkParamMQTTQoS); qosParam != `` {
+		switch qosParam {
+		case `1`, `2`:
+			qos = qosParam[0] - '0'
+		default:
+			return nil, errors.Errorf(`param %s must be 1 or 2`, changefeedbase.SinkParamMQTTQoS)
+		}
+	}
+
+	topicPrefix := u.consumeParam(changefeedbase.SinkParamTopicPrefix)
+	topicName := u.consumeParam(changefeedbase.SinkParamTopicName)
+	topicNamer, err := MakeTopicNamer(
+		targets,
+		WithPrefix(topicPrefix), WithSingleName(topicName), WithJoinByte('/'),
+		WithSanitizeFn(SQLNameToMQTTTopic))
+	if err != nil {
+		return nil, err
+	}
+
+	if unknownParams := u.remainingQueryParams(); len(unknownParams) > 0 {
+		return nil, errors.Errorf(
+			`unknown MQTT sink query parameters: %s`, strings.Join(unknownParams, ", "))
+	}
+
+	sinkClient, err := makeMQTTSinkClient(ctx, u.URL, tlsConfig, qos, batchCfg, m.netMetrics())
+	if err != nil {
+		return nil, err
+	}
+
+	return makeBatchingSink(
+		ctx,
+		sinkTypeMQTT,
+		sinkClient,
+		time.Duration(batchCfg.Frequency),
+		retryOpts,
+		parallelism,
+		topicNamer,
+		pacerFactory,
+		source,
+		m,
+		settings,
+	), nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMQTTToken is an mqtt.Token which has already completed.
+type fakeMQTTToken struct {
+	err error
+}
+
+var _ mqtt.Token = (*fakeMQTTToken)(nil)
+
+func (t *fakeMQTTToken) Wait() bool                     { return true }
+func (t *fakeMQTTToken) WaitTimeout(time.Duration) bool { return true }
+func (t *fakeMQTTToken) Error() error                   { return t.err }
+func (t *fakeMQTTToken) Done() <-chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
+
+type mqttPublished struct {
+	topic   string
+	qos     byte
+	payload string
+}
+
+// fakeMQTTClient records the messages published to it, failing those whose
+// payload is in errs with the corresponding error.
+type fakeMQTTClient struct {
+	published    []mqttPublished
+	errs         map[string]error
+	disconnected bool
+}
+
+var _ mqttClient = (*fakeMQTTClient)(nil)
+
+func (c *fakeMQTTClient) Publish(
+	topic string, qos byte, _ bool, payload interface{},
+) mqtt.Token {
+	p := string(payload.([]byte))
+	c.published = append(c.published, mqttPublished{topic: topic, qos: qos, payload: p})
+	return &fakeMQTTToken{err: c.errs[p]}
+}
+
+func (c *fakeMQTTClient) IsConnectionOpen() bool { return !c.disconnected }
+func (c *fakeMQTTClient) Disconnect(uint)        { c.disconnected = true }
+
+func TestMQTTSinkClient(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	client := &fakeMQTTClient{errs: map[string]error{"bad": errors.New("boom")}}
+	sc := &mqttSinkClient{client: client, qos: 2, batchCfg: sinkBatchConfig{Messages: 2}}
+	require.NoError(t, sc.CheckConnection(ctx))
+
+	buf := sc.MakeBatchBuffer("cdc/foo")
+	buf.Append([]byte(`[1]`), []byte(`a`), attributes{})
+	require.False(t, buf.ShouldFlush())
+	buf.Append([]byte(`[2]`), []byte(`b`), attributes{})
+	require.True(t, buf.ShouldFlush())
+	payload, err := buf.Close()
+	require.NoError(t, err)
+	require.NoError(t, sc.Flush(ctx, payload))
+	require.Equal(t, []mqttPublished{
+		{topic: "cdc/foo", qos: 2, payload: "a"},
+		{topic: "cdc/foo", qos: 2, payload: "b"},
+	}, client.published)
+
+	// A failure to publish any message of the batch fails the flush.
+	buf = sc.MakeBatchBuffer("cdc/foo")
+	buf.Append([]byte(`[1]`), []byte(`c`), attributes{})
+	buf.Append([]byte(`[2]`), []byte(`bad`), attributes{})
+	payload, err = buf.Close()
+	require.NoError(t, err)
+	require.ErrorContains(t, sc.Flush(ctx, payload), "publishing to cdc/foo: boom")
+
+	// Resolved timestamps are published to every topic.
+	client.published = nil
+	require.NoError(t, sc.FlushResolvedPayload(ctx, []byte(`resolved`),
+		func(fn func(topic string) error) error {
+			for _, topic := range []string{"cdc/foo", "cdc/bar"} {
+				if err := fn(topic); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, retry.Options{MaxRetries: 1}))
+	require.Equal(t, []mqttPublished{
+		{topic: "cdc/foo", qos: 2, payload: "resolved"},
+		{topic: "cdc/bar", qos: 2, payload: "resolved"},
+	}, client.published)
+
+	require.NoError(t, sc.Close())
+	require.ErrorContains(t, sc.CheckConnection(ctx), "not connected")
+}
+
+func TestMakeMQTTSinkValidation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		uri       string
+		format    changefeedbase.FormatType
+		expectErr string
+	}{
+		{uri: `mqtt://`, expectErr: `missing MQTT broker address`},
+		{uri: `mqtt://localhost:1883?foo=bar`, expectErr: `unknown MQTT sink query parameters: foo`},
+		{uri: `mqtt://localhost:1883?qos=0`, expectErr: `param qos must be 1 or 2`},
+		{uri: `mqtt://localhost:1883?qos=3`, expectErr: `param qos must be 1 or 2`},
+		{uri: `mqtt://localhost:1883?client_cert=Zm9v`, expectErr: `tls_enabled must be enabled to configure TLS`},
+		{uri: `mqtt://localhost:1883?insecure_tls_skip_verify=true`,
+			expectErr: `tls_enabled must be enabled to configure TLS`},
+		{uri: `mqtt://localhost:1883?tls_enabled=true&client_cert=Zm9v`,
+			expectErr: `client_cert and client_key must be provided together`},
+		{uri: `mqtt://localhost:1883`, format: changefeedbase.OptFormatParquet,
+			expectErr: `this sink is incompatible with format=parquet`},
+		{uri: `mqtt://localhost:1883`, format: changefeedbase.OptFormatAvro,
+			expectErr: `this sink is incompatible with format=avro, since MQTT messages cannot carry a key`},
+		{uri: `mqtt://localhost:1883`, format: changefeedbase.OptFormatProtobuf,
+			expectErr: `this sink is incompatible with format=protobuf, since MQTT messages cannot carry a key`},
+	} {
+		t.Run(tc.uri, func(t *testing.T) {
+			u, err := url.Parse(tc.uri)
+			require.NoError(t, err)
+			encodingOpts := changefeedbase.EncodingOptions{
+				Format: changefeedbase.OptFormatJSON, Envelope: changefeedbase.OptEnvelopeWrapped,
+			}
+			if tc.format != `` {
+				encodingOpts.Format = tc.format
+			}
+			_, err = makeMQTTSink(ctx, sinkURL{URL: u}, encodingOpts, ``, changefeedbase.Targets{},
+				1, nilPacerFactory, timeutil.DefaultTimeSource{}, nilMetricsRecorderBuilder,
+				cluster.MakeTestingClusterSettings())
+			require.ErrorContains(t, err, tc.expectErr)
+		})
+	}
+}
+
+// TestMQTTSinkFormats checks that the formats which support key_in_value can
+// be published to MQTT, and that the others are rejected.
+func TestMQTTSinkFormats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	reg := cdctest.StartTestSchemaRegistry()
+	defer reg.Close()
+
+	ctx := context.Background()
+	for _, format := range []changefeedbase.FormatType{
+		changefeedbase.OptFormatJSON,
+		changefeedbase.OptFormatCSV,
+	} {
+		t.Run(string(format), func(t *testing.T) {
+			opts := changefeedbase.EncodingOptions{
+				Format: format, Envelope: changefeedbase.OptEnvelopeWrapped, KeyInValue: true,
+			}
+			require.NoError(t, validateMQTTFormat(opts))
+			key, value := encodeTestRow(t, opts, reg)
+
+			client := &fakeMQTTClient{}
+			sc := &mqttSinkClient{client: client, qos: 1, batchCfg: sinkBatchConfig{Messages: 1}}
+			buf := sc.MakeBatchBuffer("cdc/foo")
+			buf.Append(key, value, attributes{tableName: "foo"})
+			payload, err := buf.Close()
+			require.NoError(t, err)
+			require.NoError(t, sc.Flush(ctx, payload))
+			require.Equal(t, []mqttPublished{{topic: "cdc/foo", qos: 1, payload: string(value)}},
+				client.published)
+			if format == changefeedbase.OptFormatJSON {
+				require.Contains(t, string(value), `"key": [1]`)
+			}
+		})
+	}
+
+	for _, format := range []changefeedbase.FormatType{
+		changefeedbase.OptFormatAvro,
+		changefeedbase.OptFormatProtobuf,
+	} {
+		t.Run(string(format), func(t *testing.T) {
+			opts := changefeedbase.EncodingOptions{
+				Format: format, Envelope: changefeedbase.OptEnvelopeWrapped, KeyInValue: true,
+			}
+			err := validateMQTTFormat(opts)
+			require.ErrorContains(t, err, `since MQTT messages cannot carry a key`)
+			require.Contains(t, errors.FlattenHints(err), `Use format=json or format=csv.`)
+		})
+	}
+}
@@ -0,0 +1,328 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/cidr"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// natsClientName is the connection name reported to the NATS server.
+	natsClientName = `cockroachdb-changefeed`
+	// natsConnectTimeout bounds the time spent dialing the NATS server.
+	natsConnectTimeout = 10 * time.Second
+	// natsPublishAckTimeout bounds the time spent waiting for JetStream to
+	// acknowledge a batch of messages, as well as the time a publish may stall
+	// when too many acks are outstanding.
+	natsPublishAckTimeout = 30 * time.Second
+	// natsKeyHeader is the header which carries the base64 encoded key of a
+	// row, since NATS messages have no key.
+	natsKeyHeader = `Crdb-Changefeed-Key`
+)
+
+// isNATSSink returns true if url contains scheme with valid NATS sink
+func isNATSSink(u *url.URL) bool {
+	return u.Scheme == changefeedbase.SinkSchemeNATS
+}
+
+// natsJetStream is the subset of jetstream.JetStream used by the NATS sink.
+type natsJetStream interface {
+	PublishMsgAsync(m *nats.Msg, opts ...jetstream.PublishOpt) (jetstream.PubAckFuture, error)
+	AccountInfo(ctx context.Context) (*jetstream.AccountInfo, error)
+}
+
+var _ natsJetStream = (jetstream.JetStream)(nil)
+
+// natsSinkClient publishes messages to NATS JetStream. Each topic is used as
+// the subject of its messages, which must be captured by a JetStream stream.
+type natsSinkClient struct {
+	conn     *nats.Conn
+	js       natsJetStream
+	batchCfg sinkBatchConfig
+}
+
+var _ SinkClient = (*natsSinkClient)(nil)
+
+func makeNATSSinkClient(
+	ctx context.Context,
+	u *url.URL,
+	tlsConfig *tls.Config,
+	batchCfg sinkBatchConfig,
+	nm *cidr.NetMetrics,
+) (*natsSinkClient, error) {
+	// The credentials, if any, are passed along as the user info of the server
+	// URL, which the nats client knows how to interpret.
+	serverURL := url.URL{Scheme: changefeedbase.SinkSchemeNATS, User: u.User, Host: u.Host}
+	dialer := &natsDialer{
+		ctx:  ctx,
+		dial: nm.Wrap((&net.Dialer{Timeout: natsConnectTimeout}).DialContext, "nats"),
+	}
+	opts := []nats.Option{
+		nats.Name(natsClientName),
+		nats.Timeout(natsConnectTimeout),
+		nats.SetCustomDialer(dialer),
+	}
+	if tlsConfig != nil {
+		opts = append(opts, nats.Secure(tlsConfig))
+	}
+
+	conn, err := nats.Connect(serverURL.String(), opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "connecting to NATS server %s", u.Host)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "creating JetStream context")
+	}
+
+	return &natsSinkClient{conn: conn, js: js, batchCfg: batchCfg}, nil
+}
+
+// natsDialer adapts a cidr.DialContext, which records network metrics, to the
+// dialer interface of the nats client.
+type natsDialer struct {
+	ctx  context.Context
+	dial cidr.DialContext
+}
+
+var _ nats.CustomDialer = (*natsDialer)(nil)
+
+// Dial implements the nats.CustomDialer interface.
+func (d *natsDialer) Dial(network, address string) (net.Conn, error) {
+	return d.dial(d.ctx, network, address)
+}
+
+// CheckConnection implements the SinkClient interface.
+func (sc *natsSinkClient) CheckConnection(ctx context.Context) error {
+	if _, err := sc.js.AccountInfo(ctx); err != nil {
+		return errors.Wrap(err, "JetStream is not available")
+	}
+	return nil
+}
+
+// FlushResolvedPayload implements the SinkClient interface.
+func (sc *natsSinkClient) FlushResolvedPayload(
+	ctx context.Context,
+	body []byte,
+	forEachTopic func(func(topic string) error) error,
+	retryOpts retry.Options,
+) error {
+	return forEachTopic(func(topic string) error {
+		payload := []*nats.Msg{{Subject: topic, Data: body}}
+		return retry.WithMaxAttempts(ctx, retryOpts, retryOpts.MaxRetries+1, func() error {
+			return sc.Flush(ctx, payload)
+		})
+	})
+}
+
+// Flush implements the SinkClient interface.
+//
+// The messages of the batch are published asynchronously, in order, over a
+// single connection, so JetStream stores them in that order. The batching sink
+// does not flush a batch containing a key until the previous batch containing
+// that key was acknowledged, so the messages of a key are stored in the order
+// in which they were emitted. The messages published again when a batch is
+// retried are discarded by JetStream, based on their ID, as long as they are
+// published within the duplicate window of the stream.
+func (sc *natsSinkClient) Flush(ctx context.Context, payload SinkPayload) error {
+	msgs := payload.([]*nats.Msg)
+	acks := make([]jetstream.PubAckFuture, 0, len(msgs))
+	for _, msg := range msgs {
+		ack, err := sc.js.PublishMsgAsync(msg, jetstream.WithStallWait(natsPublishAckTimeout))
+		if err != nil {
+			return sc.wrapPublishError(err, msg.Subject)
+		}
+		acks = append(acks, ack)
+	}
+
+	timer := time.NewTimer(natsPublishAckTimeout)
+	defer timer.Stop()
+	for _, ack := range acks {
+		select {
+		case <-ack.Ok():
+		case err := <-ack.Err():
+			return sc.wrapPublishError(err, ack.Msg().Subject)
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return errors.Errorf("timed out waiting for JetStream to acknowledge messages published to %s",
+				ack.Msg().Subject)
+		}
+	}
+	return nil
+}
+
+func (sc *natsSinkClient) wrapPublishError(err error, subject string) error {
+	err = errors.Wrapf(err, "publishing to %s", subject)
+	if errors.Is(err, jetstream.ErrNoStreamResponse) {
+		return errors.WithHintf(err,
+			"Create a JetStream stream whose subjects include %s before starting the changefeed.", subject)
+	}
+	return err
+}
+
+// Close implements the SinkClient interface.
+func (sc *natsSinkClient) Close() error {
+	if sc.conn != nil {
+		sc.conn.Close()
+	}
+	return nil
+}
+
+// MakeBatchBuffer implements the SinkClient interface.
+func (sc *natsSinkClient) MakeBatchBuffer(topic string) BatchBuffer {
+	return &natsBuffer{
+		sc:       sc,
+		subject:  topic,
+		messages: make([]*nats.Msg, 0, sc.batchCfg.Messages),
+	}
+}
+
+type natsBuffer struct {
+	sc       *natsSinkClient
+	subject  string
+	messages []*nats.Msg
+	numBytes int
+}
+
+var _ BatchBuffer = (*natsBuffer)(nil)
+
+// Append implements the BatchBuffer interface. The key is sent in a header,
+// and the message is given an ID derived from the row and its MVCC timestamp,
+// so that JetStream discards the duplicates published when a batch is retried
+// within the duplicate window of the stream.
+func (b *natsBuffer) Append(key []byte, value []byte, attrs attributes) {
+	msg := nats.NewMsg(b.subject)
+	msg.Data = value
+	if len(key) > 0 {
+		msg.Header.Set(natsKeyHeader, base64.StdEncoding.EncodeToString(key))
+	}
+	msg.Header.Set(jetstream.MsgIDHeader, natsMsgID(key, value, attrs))
+	b.messages = append(b.messages, msg)
+	b.numBytes += len(key) + len(value)
+}
+
+// natsMsgID returns the JetStream message ID of a row. The key and the MVCC
+// timestamp identify a row within a table, except when the table is split
+// into column families, so the value is hashed in as well.
+func natsMsgID(key []byte, value []byte, attrs attributes) string {
+	h := sha256.New()
+	_, _ = h.Write(key)
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write(value)
+	return fmt.Sprintf("%s/%s/%x", attrs.tableName, attrs.mvcc.AsOfSystemTime(), h.Sum(nil)[:16])
+}
+
+// ShouldFlush implements the BatchBuffer interface.
+func (b *natsBuffer) ShouldFlush() bool {
+	return shouldFlushBatch(b.numBytes, len(b.messages), b.sc.batchCfg)
+}
+
+// Close implements the BatchBuffer interface.
+func (b *natsBuffer) Close() (SinkPayload, error) {
+	return b.messages, nil
+}
+
+func validateNATSFormat(encodingOpts changefeedbase.EncodingOptions) error {
+	switch encodingOpts.Format {
+	case changefeedbase.OptFormatJSON, changefeedbase.OptFormatAvro,
+		changefeedbase.OptFormatCSV, changefeedbase.OptFormatProtobuf:
+	default:
+		return errors.Errorf(`this sink is incompatible with %s=%s`,
+			changefeedbase.OptFormat, encodingOpts.Format)
+	}
+	return nil
+}
+
+func makeNATSSink(
+	ctx context.Context,
+	u sinkURL,
+	encodingOpts changefeedbase.EncodingOptions,
+	jsonConfig changefeedbase.SinkSpecificJSONConfig,
+	targets changefeedbase.Targets,
+	parallelism int,
+	pacerFactory func() *admission.Pacer,
+	source timeutil.TimeSource,
+	mb metricsRecorderBuilder,
+	settings *cluster.Settings,
+) (Sink, error) {
+	m := mb(requiresResourceAccounting)
+
+	if err := validateNATSFormat(encodingOpts); err != nil {
+		return nil, err
+	}
+
+	batchCfg, retryOpts, err := getSinkConfigFromJson(jsonConfig, sinkJSONConfig{
+		Flush: sinkBatchConfig{
+			Frequency: jsonDuration(10 * time.Millisecond),
+			Messages:  100,
+			Bytes:     1e6,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Host == `` {
+		return nil, errors.Errorf(`missing NATS server address in %s sink URI`, changefeedbase.SinkSchemeNATS)
+	}
+	tlsConfig, err := consumeSinkTLSConfig(&u, false /* tlsByDefault */)
+	if err != nil {
+		return nil, err
+	}
+
+	topicPrefix := u.consumeParam(changefeedbase.SinkParamTopicPrefix)
+	topicName := u.consumeParam(changefeedbase.SinkParamTopicName)
+	topicNamer, err := MakeTopicNamer(
+		targets,
+		WithPrefix(topicPrefix), WithSingleName(topicName), WithSanitizeFn(SQLNameToNATSSubject))
+	if err != nil {
+		return nil, err
+	}
+
+	if unknownParams := u.remainingQueryParams(); len(unknownParams) > 0 {
+		return nil, errors.Errorf(
+			`unknown NATS sink query parameters: %s`, strings.Join(unknownParams, ", "))
+	}
+
+	sinkClient, err := makeNATSSinkClient(ctx, u.URL, tlsConfig, batchCfg, m.netMetrics())
+	if err != nil {
+		return nil, err
+	}
+
+	return makeBatchingSink(
+		ctx,
+		sinkTypeNATS,
+		sinkClient,
+		time.Duration(batchCfg.Frequency),
+		retryOpts,
+		parallelism,
+		topicNamer,
+		pacerFactory,
+		source,
+		m,
+		settings,
+	), nil
+}
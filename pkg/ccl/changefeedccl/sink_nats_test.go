@@ -0,0 +1,268 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePubAck is a jetstream.PubAckFuture which has already been resolved.
+type fakePubAck struct {
+	msg   *nats.Msg
+	okCh  chan *jetstream.PubAck
+	errCh chan error
+}
+
+var _ jetstream.PubAckFuture = (*fakePubAck)(nil)
+
+func (f *fakePubAck) Ok() <-chan *jetstream.PubAck { return f.okCh }
+func (f *fakePubAck) Err() <-chan error            { return f.errCh }
+func (f *fakePubAck) Msg() *nats.Msg               { return f.msg }
+
+// fakeJetStream records the messages published to it, failing those whose
+// data is in errs with the corresponding error.
+type fakeJetStream struct {
+	published []*nats.Msg
+	errs      map[string]error
+}
+
+var _ natsJetStream = (*fakeJetStream)(nil)
+
+func (js *fakeJetStream) PublishMsgAsync(
+	m *nats.Msg, _ ...jetstream.PublishOpt,
+) (jetstream.PubAckFuture, error) {
+	js.published = append(js.published, m)
+	ack := &fakePubAck{msg: m, okCh: make(chan *jetstream.PubAck, 1), errCh: make(chan error, 1)}
+	if err, ok := js.errs[string(m.Data)]; ok {
+		ack.errCh <- err
+	} else {
+		ack.okCh <- &jetstream.PubAck{Stream: "cdc", Sequence: uint64(len(js.published))}
+	}
+	return ack, nil
+}
+
+func (js *fakeJetStream) AccountInfo(context.Context) (*jetstream.AccountInfo, error) {
+	return &jetstream.AccountInfo{}, nil
+}
+
+func TestNATSSinkClient(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	js := &fakeJetStream{errs: map[string]error{
+		"bad":       errors.New("boom"),
+		"no-stream": jetstream.ErrNoStreamResponse,
+	}}
+	sc := &natsSinkClient{js: js, batchCfg: sinkBatchConfig{Messages: 2}}
+	require.NoError(t, sc.CheckConnection(ctx))
+
+	buf := sc.MakeBatchBuffer("cdc.foo")
+	buf.Append([]byte(`[1]`), []byte(`a`), attributes{})
+	require.False(t, buf.ShouldFlush())
+	buf.Append([]byte(`[2]`), []byte(`b`), attributes{})
+	require.True(t, buf.ShouldFlush())
+	payload, err := buf.Close()
+	require.NoError(t, err)
+	require.NoError(t, sc.Flush(ctx, payload))
+
+	var published []string
+	for _, m := range js.published {
+		require.Equal(t, "cdc.foo", m.Subject)
+		published = append(published, string(m.Data))
+	}
+	require.Equal(t, []string{"a", "b"}, published)
+	// The key is carried in a header, and each message has a distinct ID.
+	key, err := base64.StdEncoding.DecodeString(js.published[1].Header.Get(natsKeyHeader))
+	require.NoError(t, err)
+	require.Equal(t, `[2]`, string(key))
+	require.NotEqual(t, js.published[0].Header.Get(jetstream.MsgIDHeader),
+		js.published[1].Header.Get(jetstream.MsgIDHeader))
+
+	// A failure to publish any message of the batch fails the flush.
+	buf = sc.MakeBatchBuffer("cdc.foo")
+	buf.Append([]byte(`[1]`), []byte(`c`), attributes{})
+	buf.Append([]byte(`[2]`), []byte(`bad`), attributes{})
+	payload, err = buf.Close()
+	require.NoError(t, err)
+	require.ErrorContains(t, sc.Flush(ctx, payload), "publishing to cdc.foo: boom")
+
+	buf = sc.MakeBatchBuffer("cdc.bar")
+	buf.Append([]byte(`[1]`), []byte(`no-stream`), attributes{})
+	payload, err = buf.Close()
+	require.NoError(t, err)
+	err = sc.Flush(ctx, payload)
+	require.True(t, errors.Is(err, jetstream.ErrNoStreamResponse))
+	require.Contains(t, errors.FlattenHints(err), "Create a JetStream stream")
+
+	// Resolved timestamps are published to every topic.
+	js.published = nil
+	require.NoError(t, sc.FlushResolvedPayload(ctx, []byte(`resolved`),
+		func(fn func(topic string) error) error {
+			for _, topic := range []string{"cdc.foo", "cdc.bar"} {
+				if err := fn(topic); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, retry.Options{MaxRetries: 1}))
+	require.Len(t, js.published, 2)
+	require.Equal(t, "cdc.foo", js.published[0].Subject)
+	require.Equal(t, "cdc.bar", js.published[1].Subject)
+	require.Equal(t, []byte(`resolved`), js.published[1].Data)
+}
+
+func TestMakeNATSSinkValidation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		uri        string
+		format     changefeedbase.FormatType
+		jsonConfig changefeedbase.SinkSpecificJSONConfig
+		expectErr  string
+	}{
+		{uri: `nats://`, expectErr: `missing NATS server address`},
+		{uri: `nats://localhost:4222?foo=bar`, expectErr: `unknown NATS sink query parameters: foo`},
+		{uri: `nats://localhost:4222?ca_cert=Zm9v`, expectErr: `tls_enabled must be enabled to configure TLS`},
+		{uri: `nats://localhost:4222?tls_enabled=maybe`, expectErr: `param tls_enabled must be a bool`},
+		{uri: `nats://localhost:4222?tls_enabled=true&ca_cert=!!`, expectErr: `param ca_cert must be base 64 encoded`},
+		{uri: `nats://localhost:4222`, format: changefeedbase.OptFormatParquet,
+			expectErr: `this sink is incompatible with format=parquet`},
+		{uri: `nats://localhost:4222`, jsonConfig: `{"Flush": {"Messages": 10}}`,
+			expectErr: `Flush.Frequency is not set`},
+	} {
+		t.Run(tc.uri, func(t *testing.T) {
+			u, err := url.Parse(tc.uri)
+			require.NoError(t, err)
+			encodingOpts := changefeedbase.EncodingOptions{
+				Format: changefeedbase.OptFormatJSON, Envelope: changefeedbase.OptEnvelopeWrapped,
+			}
+			if tc.format != `` {
+				encodingOpts.Format = tc.format
+			}
+			_, err = makeNATSSink(ctx, sinkURL{URL: u}, encodingOpts, tc.jsonConfig, changefeedbase.Targets{},
+				1, nilPacerFactory, timeutil.DefaultTimeSource{}, nilMetricsRecorderBuilder,
+				cluster.MakeTestingClusterSettings())
+			require.ErrorContains(t, err, tc.expectErr)
+		})
+	}
+}
+
+func TestNATSMsgID(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	attrs := attributes{tableName: "foo", mvcc: hlc.Timestamp{WallTime: 1, Logical: 2}}
+	id := natsMsgID([]byte(`[1]`), []byte(`a`), attrs)
+	// A message emitted again when a batch is retried has the same ID, so that
+	// JetStream discards it.
+	require.Equal(t, id, natsMsgID([]byte(`[1]`), []byte(`a`), attrs))
+	// Any other message has a different ID.
+	require.NotEqual(t, id, natsMsgID([]byte(`[2]`), []byte(`a`), attrs))
+	require.NotEqual(t, id, natsMsgID([]byte(`[1]`), []byte(`b`), attrs))
+	require.NotEqual(t, id, natsMsgID([]byte(`[1]`), []byte(`a`),
+		attributes{tableName: "bar", mvcc: attrs.mvcc}))
+	require.NotEqual(t, id, natsMsgID([]byte(`[1]`), []byte(`a`),
+		attributes{tableName: "foo", mvcc: attrs.mvcc.Next()}))
+}
+
+// encodeTestRow encodes a row of a table with the given format, and returns its
+// key and value.
+func encodeTestRow(
+	t *testing.T, opts changefeedbase.EncodingOptions, reg *cdctest.SchemaRegistry,
+) (key, value []byte) {
+	ctx := context.Background()
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+	require.NoError(t, err)
+	targets := changefeedbase.Targets{}
+	targets.Add(changefeedbase.Target{
+		Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+		TableID:           tableDesc.GetID(),
+		StatementTimeName: changefeedbase.StatementTimeName(tableDesc.GetName()),
+	})
+	opts.SchemaRegistryURI = reg.URL()
+	e, err := getEncoder(ctx, opts, targets, false, nil, nil)
+	require.NoError(t, err)
+
+	row := cdcevent.TestingMakeEventRow(tableDesc, 0, rowenc.EncDatumRow{
+		rowenc.EncDatum{Datum: tree.NewDInt(1)},
+		rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+	}, false /* deleted */)
+	key, err = e.EncodeKey(ctx, row)
+	require.NoError(t, err)
+	key = append([]byte(nil), key...)
+	value, err = e.EncodeValue(ctx, eventContext{updated: hlc.Timestamp{WallTime: 1}}, row, cdcevent.Row{})
+	require.NoError(t, err)
+	return key, append([]byte(nil), value...)
+}
+
+// TestNATSSinkFormats checks that every format supported by the NATS sink can
+// be published without key_in_value, with the key sent in a header.
+func TestNATSSinkFormats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	reg := cdctest.StartTestSchemaRegistry()
+	defer reg.Close()
+
+	ctx := context.Background()
+	for _, format := range []changefeedbase.FormatType{
+		changefeedbase.OptFormatJSON,
+		changefeedbase.OptFormatCSV,
+		changefeedbase.OptFormatAvro,
+		changefeedbase.OptFormatProtobuf,
+	} {
+		t.Run(string(format), func(t *testing.T) {
+			opts := changefeedbase.EncodingOptions{Format: format, Envelope: changefeedbase.OptEnvelopeWrapped}
+			require.NoError(t, validateNATSFormat(opts))
+			key, value := encodeTestRow(t, opts, reg)
+
+			js := &fakeJetStream{}
+			sc := &natsSinkClient{js: js, batchCfg: sinkBatchConfig{Messages: 1}}
+			buf := sc.MakeBatchBuffer("cdc.foo")
+			buf.Append(key, value, attributes{tableName: "foo", mvcc: hlc.Timestamp{WallTime: 1}})
+			payload, err := buf.Close()
+			require.NoError(t, err)
+			require.NoError(t, sc.Flush(ctx, payload))
+
+			require.Len(t, js.published, 1)
+			msg := js.published[0]
+			require.Equal(t, value, msg.Data)
+			require.NotEmpty(t, msg.Header.Get(jetstream.MsgIDHeader))
+			if format == changefeedbase.OptFormatCSV {
+				// CSV rows have no key.
+				require.Empty(t, msg.Header.Values(natsKeyHeader))
+				return
+			}
+			require.NotEmpty(t, key)
+			publishedKey, err := base64.StdEncoding.DecodeString(msg.Header.Get(natsKeyHeader))
+			require.NoError(t, err)
+			require.Equal(t, key, publishedKey)
+		})
+	}
+}
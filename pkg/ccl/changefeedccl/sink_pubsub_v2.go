@@ -212,6 +212,12 @@ func (sc *pubsubSinkClient) Flush(ctx context.Context, payload SinkPayload) erro
 				"Create topics in advance or grant this service account the pubsub.editor role on your project.")
 		}
 	}
+	if status.Code(err) == codes.InvalidArgument {
+		// Pub/Sub rejects messages which exceed its size limits, or which are
+		// otherwise malformed, as invalid arguments. Publishing them again
+		// would fail the same way.
+		return changefeedbase.MarkPermanentEventError(err)
+	}
 	return err
 }
 
@@ -221,10 +227,10 @@ type pubsubBuffer struct {
 	topicEncoded []byte
 	messages     []*pb.PubsubMessage
 	numBytes     int
-	// Cache for attributes which are sent along with each message.
-	// This lets us re-use expensive map allocs for messages in the batch
+	// Cache for attributes which are sent along with each message, by table
+	// name. This lets us re-use expensive map allocs for messages in the batch
 	// with the same attributes.
-	attributesCache map[attributes]map[string]string
+	attributesCache map[string]map[string]string
 }
 
 var _ BatchBuffer = (*pubsubBuffer)(nil)
@@ -251,10 +257,10 @@ func (psb *pubsubBuffer) Append(key []byte, value []byte, attributes attributes)
 
 	msg := &pb.PubsubMessage{Data: content}
 	if psb.sc.withTableNameAttribute {
-		if _, ok := psb.attributesCache[attributes]; !ok {
-			psb.attributesCache[attributes] = map[string]string{"TABLE_NAME": attributes.tableName}
+		if _, ok := psb.attributesCache[attributes.tableName]; !ok {
+			psb.attributesCache[attributes.tableName] = map[string]string{"TABLE_NAME": attributes.tableName}
 		}
-		msg.Attributes = psb.attributesCache[attributes]
+		msg.Attributes = psb.attributesCache[attributes.tableName]
 	}
 
 	psb.messages = append(psb.messages, msg)
@@ -285,7 +291,7 @@ func (sc *pubsubSinkClient) MakeBatchBuffer(topic string) BatchBuffer {
 		messages:     make([]*pb.PubsubMessage, 0, sc.batchCfg.Messages),
 	}
 	if sc.withTableNameAttribute {
-		psb.attributesCache = make(map[attributes]map[string]string)
+		psb.attributesCache = make(map[string]map[string]string)
 	}
 	return psb
 }
@@ -693,6 +693,66 @@ func TestWebhookSinkShutsDownOnError(t *testing.T) {
 	}
 }
 
+func TestWebhookSinkDeadLetterQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testFn := func(t *testing.T, withDLQ bool) {
+		ctx := context.Background()
+		cert, certEncoded, err := cdctest.NewCACertBase64Encoded()
+		require.NoError(t, err)
+		sinkDest, err := cdctest.StartMockWebhookSink(cert)
+		require.NoError(t, err)
+		defer sinkDest.Close()
+
+		// The endpoint rejects every message as too large, which must not be
+		// retried.
+		sinkDest.SetStatusCodes([]int{http.StatusRequestEntityTooLarge})
+		sinkDestHost, err := url.Parse(sinkDest.URL())
+		require.NoError(t, err)
+
+		params := sinkDestHost.Query()
+		params.Set(changefeedbase.SinkParamCACert, certEncoded)
+		sinkDestHost.RawQuery = params.Encode()
+
+		details := jobspb.ChangefeedDetails{
+			SinkURI: fmt.Sprintf("webhook-%s", sinkDestHost.String()),
+			Opts:    getGenericWebhookSinkOptions().AsMap(),
+		}
+
+		sinkSrc, err := setupWebhookSinkWithDetails(ctx, details, 1 /* parallelism */, timeutil.DefaultTimeSource{})
+		require.NoError(t, err)
+		defer func() { require.NoError(t, sinkSrc.Close()) }()
+
+		var dlq []dlqEntry
+		if withDLQ {
+			setSinkDeadLetterQueue(sinkSrc, func(
+				_ context.Context, _ TopicDescriptor, _ hlc.Timestamp, key, value []byte, reason error,
+			) error {
+				dlq = append(dlq, dlqEntry{key: key, value: value, reason: reason.Error()})
+				return nil
+			})
+		}
+
+		require.NoError(t, sinkSrc.EmitRow(ctx, noTopic{}, []byte("[1001]"), []byte("{\"after\":{\"col1\":\"val1\",\"rowid\":1000},\"key\":[1001],\"topic:\":\"foo\"}"), zeroTS, zeroTS, zeroAlloc))
+		err = sinkSrc.Flush(ctx)
+		if !withDLQ {
+			require.EqualError(t, err, "413 Request Entity Too Large: ")
+			require.True(t, changefeedbase.IsPermanentEventError(err))
+			require.Equal(t, 1, sinkDest.GetNumCalls())
+			return
+		}
+		require.NoError(t, err)
+		// The batch is sent once, and then each of its messages on its own.
+		require.Equal(t, 2, sinkDest.GetNumCalls())
+		require.Len(t, dlq, 1)
+		require.Equal(t, "[1001]", string(dlq[0].key))
+		require.Equal(t, "413 Request Entity Too Large: ", dlq[0].reason)
+	}
+
+	t.Run("without dlq", func(t *testing.T) { testFn(t, false) })
+	t.Run("with dlq", func(t *testing.T) { testFn(t, true) })
+}
+
 // Regression test for https://github.com/cockroachdb/cockroach/issues/102467.
 // Ensure that we do not use the default retry config which is capped at
 // 4000ms.
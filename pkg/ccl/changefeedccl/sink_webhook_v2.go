@@ -228,7 +228,14 @@ func (sc *webhookSinkClient) Flush(ctx context.Context, batch SinkPayload) error
 		if err != nil {
 			return errors.Wrapf(err, "failed to read body for HTTP response with status: %d", res.StatusCode)
 		}
-		return fmt.Errorf("%s: %s", res.Status, string(resBody))
+		err = fmt.Errorf("%s: %s", res.Status, string(resBody))
+		switch res.StatusCode {
+		case http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+			// The endpoint rejected the messages themselves, so sending them
+			// again would fail the same way.
+			return changefeedbase.MarkPermanentEventError(err)
+		}
+		return err
 	}
 	return nil
 }
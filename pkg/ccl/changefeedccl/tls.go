@@ -39,6 +39,22 @@ func decodeBase64FromString(src string, dest *[]byte) error {
 }
 
 func newClientFromTLSKeyPair(caCert, clientCert, clientKey []byte) (*httputil.Client, error) {
+	tlsConfig, err := newTLSConfigFromKeyPair(caCert, clientCert, clientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	client := httputil.NewClientWithTimeout(httputil.StandardHTTPTimeout)
+	transport := client.Transport.(*http.Transport)
+	transport.TLSClientConfig = tlsConfig
+	client.Client.Transport = transport
+
+	return client, nil
+}
+
+// newTLSConfigFromKeyPair returns a TLS config which trusts the system root
+// CAs as well as caCert, and which presents the client certificate, if any.
+func newTLSConfigFromKeyPair(caCert, clientCert, clientKey []byte) (*tls.Config, error) {
 	rootCAs, err := x509.SystemCertPool()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not load system root CA pool")
@@ -62,9 +78,6 @@ func newClientFromTLSKeyPair(caCert, clientCert, clientKey []byte) (*httputil.Cl
 			changefeedbase.RegistryParamClientCert, changefeedbase.RegistryParamClientKey)
 	}
 
-	client := httputil.NewClientWithTimeout(httputil.StandardHTTPTimeout)
-	transport := client.Transport.(*http.Transport)
-
 	if clientCertProvided {
 		cert, err := tls.X509KeyPair(clientCert, clientKey)
 		if err != nil {
@@ -73,8 +86,44 @@ func newClientFromTLSKeyPair(caCert, clientCert, clientKey []byte) (*httputil.Cl
 		tlsConfig.Certificates = []tls.Certificate{cert}
 	}
 
-	transport.TLSClientConfig = tlsConfig
-	client.Client.Transport = transport
+	return tlsConfig, nil
+}
 
-	return client, nil
+// consumeSinkTLSConfig consumes the TLS parameters of a sink URL and returns
+// the TLS config to connect to the sink with, or nil if TLS is not enabled. TLS
+// is enabled by the tls_enabled parameter, or by tlsByDefault if the parameter
+// is not set.
+func consumeSinkTLSConfig(u *sinkURL, tlsByDefault bool) (*tls.Config, error) {
+	tlsEnabled := tlsByDefault
+	if _, err := u.consumeBool(changefeedbase.SinkParamTLSEnabled, &tlsEnabled); err != nil {
+		return nil, err
+	}
+	var skipVerify bool
+	if _, err := u.consumeBool(changefeedbase.SinkParamSkipTLSVerify, &skipVerify); err != nil {
+		return nil, err
+	}
+	var caCert, clientCert, clientKey []byte
+	if err := u.decodeBase64(changefeedbase.SinkParamCACert, &caCert); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamClientCert, &clientCert); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamClientKey, &clientKey); err != nil {
+		return nil, err
+	}
+
+	if !tlsEnabled {
+		if skipVerify || caCert != nil || clientCert != nil || clientKey != nil {
+			return nil, errors.Errorf(`%s must be enabled to configure TLS`, changefeedbase.SinkParamTLSEnabled)
+		}
+		return nil, nil
+	}
+
+	tlsConfig, err := newTLSConfigFromKeyPair(caCert, clientCert, clientKey)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.InsecureSkipVerify = skipVerify
+	return tlsConfig, nil
 }
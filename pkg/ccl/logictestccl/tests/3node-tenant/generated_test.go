@@ -2717,6 +2717,20 @@ func TestTenantLogicCCL_plpgsql_cursor(
 	runCCLLogicTest(t, "plpgsql_cursor")
 }
 
+func TestTenantLogicCCL_plpgsql_do(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_do")
+}
+
+func TestTenantLogicCCL_plpgsql_for_loop(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_for_loop")
+}
+
 func TestTenantLogicCCL_plpgsql_into(
 	t *testing.T,
 ) {
@@ -2731,6 +2745,13 @@ func TestTenantLogicCCL_plpgsql_record(
 	runCCLLogicTest(t, "plpgsql_record")
 }
 
+func TestTenantLogicCCL_plpgsql_setof(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_setof")
+}
+
 func TestTenantLogicCCL_plpgsql_txn(
 	t *testing.T,
 ) {
@@ -2843,6 +2864,13 @@ func TestTenantLogicCCL_triggers(
 	runCCLLogicTest(t, "triggers")
 }
 
+func TestTenantLogicCCL_udf_aggregate(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "udf_aggregate")
+}
+
 func TestTenantLogicCCL_udf_params(
 	t *testing.T,
 ) {
@@ -2885,6 +2913,13 @@ func TestTenantLogicCCL_vector(
 	runCCLLogicTest(t, "vector")
 }
 
+func TestTenantLogicCCL_vector_index(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "vector_index")
+}
+
 func TestTenantLogicCCL_zone_config_secondary_tenants(
 	t *testing.T,
 ) {
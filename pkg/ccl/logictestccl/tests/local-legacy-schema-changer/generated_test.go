@@ -152,6 +152,20 @@ func TestCCLLogic_plpgsql_cursor(
 	runCCLLogicTest(t, "plpgsql_cursor")
 }
 
+func TestCCLLogic_plpgsql_do(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_do")
+}
+
+func TestCCLLogic_plpgsql_for_loop(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_for_loop")
+}
+
 func TestCCLLogic_plpgsql_into(
 	t *testing.T,
 ) {
@@ -166,6 +180,13 @@ func TestCCLLogic_plpgsql_record(
 	runCCLLogicTest(t, "plpgsql_record")
 }
 
+func TestCCLLogic_plpgsql_setof(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_setof")
+}
+
 func TestCCLLogic_plpgsql_txn(
 	t *testing.T,
 ) {
@@ -243,6 +264,13 @@ func TestCCLLogic_subject(
 	runCCLLogicTest(t, "subject")
 }
 
+func TestCCLLogic_udf_aggregate(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "udf_aggregate")
+}
+
 func TestCCLLogic_udf_params(
 	t *testing.T,
 ) {
@@ -284,3 +312,10 @@ func TestCCLLogic_vector(
 	defer leaktest.AfterTest(t)()
 	runCCLLogicTest(t, "vector")
 }
+
+func TestCCLLogic_vector_index(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "vector_index")
+}
@@ -2666,6 +2666,20 @@ func TestReadCommittedLogicCCL_plpgsql_cursor(
 	runCCLLogicTest(t, "plpgsql_cursor")
 }
 
+func TestReadCommittedLogicCCL_plpgsql_do(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_do")
+}
+
+func TestReadCommittedLogicCCL_plpgsql_for_loop(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_for_loop")
+}
+
 func TestReadCommittedLogicCCL_plpgsql_into(
 	t *testing.T,
 ) {
@@ -2680,6 +2694,13 @@ func TestReadCommittedLogicCCL_plpgsql_record(
 	runCCLLogicTest(t, "plpgsql_record")
 }
 
+func TestReadCommittedLogicCCL_plpgsql_setof(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_setof")
+}
+
 func TestReadCommittedLogicCCL_plpgsql_txn(
 	t *testing.T,
 ) {
@@ -2764,6 +2785,13 @@ func TestReadCommittedLogicCCL_triggers(
 	runCCLLogicTest(t, "triggers")
 }
 
+func TestReadCommittedLogicCCL_udf_aggregate(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "udf_aggregate")
+}
+
 func TestReadCommittedLogicCCL_udf_params(
 	t *testing.T,
 ) {
@@ -2806,6 +2834,13 @@ func TestReadCommittedLogicCCL_vector(
 	runCCLLogicTest(t, "vector")
 }
 
+func TestReadCommittedLogicCCL_vector_index(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "vector_index")
+}
+
 func TestReadCommittedExecBuild_explain_analyze_read_committed(
 	t *testing.T,
 ) {
@@ -2659,6 +2659,20 @@ func TestRepeatableReadLogicCCL_plpgsql_cursor(
 	runCCLLogicTest(t, "plpgsql_cursor")
 }
 
+func TestRepeatableReadLogicCCL_plpgsql_do(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_do")
+}
+
+func TestRepeatableReadLogicCCL_plpgsql_for_loop(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_for_loop")
+}
+
 func TestRepeatableReadLogicCCL_plpgsql_into(
 	t *testing.T,
 ) {
@@ -2673,6 +2687,13 @@ func TestRepeatableReadLogicCCL_plpgsql_record(
 	runCCLLogicTest(t, "plpgsql_record")
 }
 
+func TestRepeatableReadLogicCCL_plpgsql_setof(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_setof")
+}
+
 func TestRepeatableReadLogicCCL_plpgsql_txn(
 	t *testing.T,
 ) {
@@ -2757,6 +2778,13 @@ func TestRepeatableReadLogicCCL_triggers(
 	runCCLLogicTest(t, "triggers")
 }
 
+func TestRepeatableReadLogicCCL_udf_aggregate(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "udf_aggregate")
+}
+
 func TestRepeatableReadLogicCCL_udf_params(
 	t *testing.T,
 ) {
@@ -2799,6 +2827,13 @@ func TestRepeatableReadLogicCCL_vector(
 	runCCLLogicTest(t, "vector")
 }
 
+func TestRepeatableReadLogicCCL_vector_index(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "vector_index")
+}
+
 func TestRepeatableReadExecBuild_geospatial(
 	t *testing.T,
 ) {
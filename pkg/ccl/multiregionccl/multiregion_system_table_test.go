@@ -293,11 +293,14 @@ func TestMrSystemDatabase(t *testing.T) {
 				{"TABLE system.public.locations"},
 				{"TABLE system.public.migrations"},
 				{"TABLE system.public.mvcc_statistics"},
+				{"TABLE system.public.notifications"},
 				{"TABLE system.public.protected_ts_meta"},
 				{"TABLE system.public.protected_ts_records"},
+				{"TABLE system.public.publications"},
 				{"TABLE system.public.rangelog"},
 				{"TABLE system.public.replication_constraint_stats"},
 				{"TABLE system.public.replication_critical_localities"},
+				{"TABLE system.public.replication_slots"},
 				{"TABLE system.public.replication_stats"},
 				{"TABLE system.public.reports_meta"},
 				{"TABLE system.public.scheduled_jobs"},
@@ -335,11 +338,14 @@ func TestMrSystemDatabase(t *testing.T) {
 				{"TABLE system.public.locations"},
 				{"TABLE system.public.migrations"},
 				{"TABLE system.public.mvcc_statistics"},
+				{"TABLE system.public.notifications"},
 				{"TABLE system.public.protected_ts_meta"},
 				{"TABLE system.public.protected_ts_records"},
+				{"TABLE system.public.publications"},
 				{"TABLE system.public.rangelog"},
 				{"TABLE system.public.replication_constraint_stats"},
 				{"TABLE system.public.replication_critical_localities"},
+				{"TABLE system.public.replication_slots"},
 				{"TABLE system.public.replication_stats"},
 				{"TABLE system.public.reports_meta"},
 				{"TABLE system.public.scheduled_jobs"},
@@ -255,6 +255,74 @@ const (
 	// to the system.table_metadata table
 	V24_3_AddTableMetadataCols
 
+	// V24_3_AddNotificationsTable is the migration to add the
+	// system.notifications table used by LISTEN and NOTIFY.
+	V24_3_AddNotificationsTable
+
+	// V24_3_DeferrableConstraints is the version from which FOREIGN KEY, CHECK
+	// and UNIQUE WITHOUT INDEX constraints can be declared DEFERRABLE.
+	V24_3_DeferrableConstraints
+
+	// V24_3_AddReplicationSlotsTable is the migration to add the
+	// system.replication_slots table used by logical replication.
+	V24_3_AddReplicationSlotsTable
+
+	// V24_3_RangeTypes is the version from which the range and multirange types
+	// can be used.
+	V24_3_RangeTypes
+
+	// V24_3_ExclusionConstraints is the version from which EXCLUDE constraints
+	// can be added to tables.
+	V24_3_ExclusionConstraints
+
+	// V24_3_Jsonpath is the version from which the jsonpath type can be used.
+	V24_3_Jsonpath
+
+	// V24_3_TextSearchConfigurations is the version from which text search
+	// configurations and dictionaries can be created.
+	V24_3_TextSearchConfigurations
+
+	// V24_3_GeometricTypes is the version from which the point, box, lseg,
+	// line, path, polygon and circle types can be used.
+	V24_3_GeometricTypes
+
+	// V24_3_NetworkAndMoneyTypes is the version from which the cidr, macaddr,
+	// macaddr8 and money types can be used.
+	V24_3_NetworkAndMoneyTypes
+
+	// V24_3_CreateDomain is the version from which domains can be created with
+	// CREATE DOMAIN.
+	V24_3_CreateDomain
+
+	// V24_3_CreateAggregate is the version from which user-defined aggregates
+	// can be created with CREATE AGGREGATE.
+	V24_3_CreateAggregate
+
+	// V24_3_AddPublicationsTable is the migration to add the
+	// system.publications table used by logical replication.
+	V24_3_AddPublicationsTable
+
+	// V24_3_GroupingSets is the version from which aggregations over grouping
+	// sets can be planned on nodes other than the gateway.
+	V24_3_GroupingSets
+
+	// V24_3_VectorIndexes is the version from which vector indexes can be
+	// created.
+	V24_3_VectorIndexes
+
+	// V24_3_TermStatistics is the version from which inverted indexes can store
+	// term statistics.
+	V24_3_TermStatistics
+
+	// V24_3_SpatialClusterFunctions is the version from which the spatial
+	// clustering and polygonizing aggregates and window functions can be
+	// planned on nodes other than the gateway.
+	V24_3_SpatialClusterFunctions
+
+	// V24_3_KafkaSinkTransactions is the version from which changefeeds can
+	// emit messages to Kafka in transactions committed at job checkpoints.
+	V24_3_KafkaSinkTransactions
+
 	// *************************************************
 	// Step (1) Add new versions above this comment.
 	// Do not add new versions to a patch release.
@@ -313,6 +381,23 @@ var versionTable = [numKeys]roachpb.Version{
 	V24_3_UseRACV2WithV1EntryEncoding:                  {Major: 24, Minor: 2, Internal: 18},
 	V24_3_UseRACV2Full:                                 {Major: 24, Minor: 2, Internal: 20},
 	V24_3_AddTableMetadataCols:                         {Major: 24, Minor: 2, Internal: 22},
+	V24_3_AddNotificationsTable:                        {Major: 24, Minor: 2, Internal: 24},
+	V24_3_DeferrableConstraints:                        {Major: 24, Minor: 2, Internal: 26},
+	V24_3_AddReplicationSlotsTable:                     {Major: 24, Minor: 2, Internal: 28},
+	V24_3_RangeTypes:                                   {Major: 24, Minor: 2, Internal: 30},
+	V24_3_ExclusionConstraints:                         {Major: 24, Minor: 2, Internal: 32},
+	V24_3_Jsonpath:                                     {Major: 24, Minor: 2, Internal: 34},
+	V24_3_TextSearchConfigurations:                     {Major: 24, Minor: 2, Internal: 36},
+	V24_3_GeometricTypes:                               {Major: 24, Minor: 2, Internal: 38},
+	V24_3_NetworkAndMoneyTypes:                         {Major: 24, Minor: 2, Internal: 40},
+	V24_3_CreateDomain:                                 {Major: 24, Minor: 2, Internal: 42},
+	V24_3_CreateAggregate:                              {Major: 24, Minor: 2, Internal: 44},
+	V24_3_AddPublicationsTable:                         {Major: 24, Minor: 2, Internal: 46},
+	V24_3_GroupingSets:                                 {Major: 24, Minor: 2, Internal: 48},
+	V24_3_VectorIndexes:                                {Major: 24, Minor: 2, Internal: 50},
+	V24_3_TermStatistics:                               {Major: 24, Minor: 2, Internal: 52},
+	V24_3_SpatialClusterFunctions:                      {Major: 24, Minor: 2, Internal: 54},
+	V24_3_KafkaSinkTransactions:                        {Major: 24, Minor: 2, Internal: 56},
 
 	// *************************************************
 	// Step (2): Add new versions above this comment.
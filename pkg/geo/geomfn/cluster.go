@@ -0,0 +1,299 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geomfn
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+	geom "github.com/twpayne/go-geom"
+)
+
+// NoCluster is the cluster number of a geometry which isn't assigned to any
+// cluster by ClusterDBSCAN or ClusterKMeans.
+const NoCluster = -1
+
+// maxKMeansIterations is the maximum number of iterations of the k-means
+// algorithm which ClusterKMeans performs.
+const maxKMeansIterations = 1000
+
+// ClusterDBSCAN returns the 0-based cluster number of each of the given
+// geometries as determined by the DBSCAN algorithm. A geometry which has at
+// least minPoints geometries (including itself) within eps of it is a core
+// geometry, and all geometries within eps of a core geometry belong to its
+// cluster. Geometries which aren't within eps of any core geometry, as well as
+// nil and empty geometries, are assigned NoCluster.
+func ClusterDBSCAN(gs []*geo.Geometry, eps float64, minPoints int) ([]int, error) {
+	if eps < 0 || math.IsNaN(eps) {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "eps must be non-negative")
+	}
+	if minPoints < 0 {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "minpoints must be non-negative")
+	}
+	neighbors := make([][]int, len(gs))
+	for i := range gs {
+		if gs[i] == nil || gs[i].Empty() {
+			continue
+		}
+		for j := range gs {
+			if gs[j] == nil || gs[j].Empty() {
+				continue
+			}
+			within := i == j
+			if !within {
+				var err error
+				if within, err = DWithin(*gs[i], *gs[j], eps, geo.FnInclusive); err != nil {
+					return nil, err
+				}
+			}
+			if within {
+				neighbors[i] = append(neighbors[i], j)
+			}
+		}
+	}
+	isCore := func(i int) bool {
+		return len(neighbors[i]) > 0 && len(neighbors[i]) >= minPoints
+	}
+
+	res := make([]int, len(gs))
+	for i := range res {
+		res[i] = NoCluster
+	}
+	cluster := 0
+	for i := range gs {
+		if res[i] != NoCluster || !isCore(i) {
+			continue
+		}
+		res[i] = cluster
+		queue := []int{i}
+		for len(queue) > 0 {
+			p := queue[0]
+			queue = queue[1:]
+			for _, q := range neighbors[p] {
+				if res[q] != NoCluster {
+					continue
+				}
+				res[q] = cluster
+				if isCore(q) {
+					queue = append(queue, q)
+				}
+			}
+		}
+		cluster++
+	}
+	return res, nil
+}
+
+// ClusterKMeans returns the 0-based cluster number of each of the given
+// geometries, as determined by the k-means algorithm applied to their
+// centroids. Nil and empty geometries are assigned NoCluster. If there are
+// fewer than k geometries, each is assigned its own cluster.
+func ClusterKMeans(gs []*geo.Geometry, k int) ([]int, error) {
+	if k <= 0 {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "number of clusters must be greater than zero")
+	}
+	res := make([]int, len(gs))
+	var idxs []int
+	var pts [][2]float64
+	for i, g := range gs {
+		res[i] = NoCluster
+		if g == nil || g.Empty() {
+			continue
+		}
+		c := *g
+		if g.ShapeType2D() != geopb.ShapeType_Point {
+			var err error
+			if c, err = Centroid(*g); err != nil {
+				return nil, err
+			}
+		}
+		t, err := c.AsGeomT()
+		if err != nil {
+			return nil, errors.Wrap(err, "error transforming geometry")
+		}
+		p, ok := t.(*geom.Point)
+		if !ok {
+			return nil, errors.AssertionFailedf("expected centroid to be a Point, got %T", t)
+		}
+		idxs = append(idxs, i)
+		pts = append(pts, [2]float64{p.X(), p.Y()})
+	}
+	if len(pts) == 0 {
+		return res, nil
+	}
+	if k > len(pts) {
+		k = len(pts)
+	}
+
+	// Choose the initial centers deterministically, starting with the first
+	// point and repeatedly adding the point farthest from any center so far.
+	centers := make([][2]float64, 1, k)
+	centers[0] = pts[0]
+	minDist := make([]float64, len(pts))
+	for i := range pts {
+		minDist[i] = squaredDistance(pts[i], centers[0])
+	}
+	for len(centers) < k {
+		farthest := 0
+		for i := range pts {
+			if minDist[i] > minDist[farthest] {
+				farthest = i
+			}
+		}
+		centers = append(centers, pts[farthest])
+		for i := range pts {
+			minDist[i] = math.Min(minDist[i], squaredDistance(pts[i], pts[farthest]))
+		}
+	}
+
+	assignment := make([]int, len(pts))
+	for i := range assignment {
+		assignment[i] = -1
+	}
+	sums := make([][2]float64, k)
+	counts := make([]int, k)
+	for iter := 0; iter < maxKMeansIterations; iter++ {
+		changed := false
+		for i, p := range pts {
+			best := 0
+			bestDist := squaredDistance(p, centers[0])
+			for c := 1; c < k; c++ {
+				if d := squaredDistance(p, centers[c]); d < bestDist {
+					best, bestDist = c, d
+				}
+			}
+			if assignment[i] != best {
+				assignment[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		for c := range sums {
+			sums[c] = [2]float64{}
+			counts[c] = 0
+		}
+		for i, p := range pts {
+			sums[assignment[i]][0] += p[0]
+			sums[assignment[i]][1] += p[1]
+			counts[assignment[i]]++
+		}
+		for c := range centers {
+			// Empty clusters keep their previous center.
+			if counts[c] > 0 {
+				centers[c] = [2]float64{sums[c][0] / float64(counts[c]), sums[c][1] / float64(counts[c])}
+			}
+		}
+	}
+
+	// Number the clusters in the order in which they first appear.
+	renumbered := make([]int, k)
+	for c := range renumbered {
+		renumbered[c] = -1
+	}
+	next := 0
+	for i, c := range assignment {
+		if renumbered[c] == -1 {
+			renumbered[c] = next
+			next++
+		}
+		res[idxs[i]] = renumbered[c]
+	}
+	return res, nil
+}
+
+func squaredDistance(a, b [2]float64) float64 {
+	dx, dy := a[0]-b[0], a[1]-b[1]
+	return dx*dx + dy*dy
+}
+
+// ClusterIntersecting groups the given geometries into clusters of geometries
+// which are connected by intersections, returning a GeometryCollection for each
+// cluster.
+func ClusterIntersecting(gs []geo.Geometry) ([]geo.Geometry, error) {
+	return clusterConnected(gs, Intersects)
+}
+
+// ClusterWithin groups the given geometries into clusters of geometries which
+// are connected by being within the given distance of each other, returning a
+// GeometryCollection for each cluster.
+func ClusterWithin(gs []geo.Geometry, distance float64) ([]geo.Geometry, error) {
+	if distance < 0 || math.IsNaN(distance) {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "tolerance must be non-negative")
+	}
+	return clusterConnected(gs, func(a, b geo.Geometry) (bool, error) {
+		return DWithin(a, b, distance, geo.FnInclusive)
+	})
+}
+
+// clusterConnected returns a GeometryCollection for each connected component
+// of the graph of the given geometries in which two geometries are adjacent if
+// connected returns true for them. The clusters are ordered by their first
+// geometry, and the geometries in each cluster retain their input order.
+func clusterConnected(
+	gs []geo.Geometry, connected func(a, b geo.Geometry) (bool, error),
+) ([]geo.Geometry, error) {
+	for i := 1; i < len(gs); i++ {
+		if gs[i].SRID() != gs[0].SRID() {
+			return nil, geo.NewMismatchingSRIDsError(gs[0].SpatialObject(), gs[i].SpatialObject())
+		}
+	}
+	parent := make([]int, len(gs))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range gs {
+		for j := i + 1; j < len(gs); j++ {
+			if find(i) == find(j) {
+				continue
+			}
+			ok, err := connected(gs[i], gs[j])
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				// Keep the lowest index as the root, so that clusters are ordered by
+				// their first geometry.
+				ri, rj := find(i), find(j)
+				if ri < rj {
+					parent[rj] = ri
+				} else {
+					parent[ri] = rj
+				}
+			}
+		}
+	}
+
+	var roots []int
+	members := make(map[int][]geo.Geometry)
+	for i := range gs {
+		r := find(i)
+		if _, ok := members[r]; !ok {
+			roots = append(roots, r)
+		}
+		members[r] = append(members[r], gs[i])
+	}
+	res := make([]geo.Geometry, len(roots))
+	for i, r := range roots {
+		var err error
+		if res[i], err = makeGeometryCollection(members[r]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
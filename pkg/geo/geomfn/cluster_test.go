@@ -0,0 +1,157 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geomfn
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+func parseGeometryPtrs(wkts []string) []*geo.Geometry {
+	gs := make([]*geo.Geometry, len(wkts))
+	for i, wkt := range wkts {
+		if wkt != "" {
+			g := geo.MustParseGeometry(wkt)
+			gs[i] = &g
+		}
+	}
+	return gs
+}
+
+func TestClusterDBSCAN(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		wkts      []string
+		eps       float64
+		minPoints int
+		expected  []int
+	}{
+		{
+			"two clusters and noise",
+			[]string{"POINT(0 0)", "POINT(10 10)", "POINT(0 1)", "POINT(100 100)", "POINT(10 11)", "POINT(1 1)"},
+			1.5,
+			2,
+			[]int{0, 1, 0, NoCluster, 1, 0},
+		},
+		{
+			"minpoints of one clusters everything",
+			[]string{"POINT(0 0)", "POINT(5 5)"},
+			1,
+			1,
+			[]int{0, 1},
+		},
+		{
+			"null and empty geometries",
+			[]string{"POINT(0 0)", "", "POINT EMPTY", "POINT(0 1)"},
+			1,
+			2,
+			[]int{0, NoCluster, NoCluster, 0},
+		},
+		{
+			"border geometry",
+			// The last point is only within eps of the second point, which is a core
+			// point.
+			[]string{"POINT(0 0)", "POINT(1 0)", "POINT(-1 0)", "POINT(2 0)"},
+			1,
+			3,
+			[]int{0, 0, 0, 0},
+		},
+		{
+			"distance between non-point geometries",
+			[]string{"LINESTRING(0 0, 10 0)", "POINT(10 1)", "POINT(20 20)"},
+			1,
+			2,
+			[]int{0, 0, NoCluster},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ret, err := ClusterDBSCAN(parseGeometryPtrs(tc.wkts), tc.eps, tc.minPoints)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, ret)
+		})
+	}
+
+	_, err := ClusterDBSCAN(parseGeometryPtrs([]string{"POINT(0 0)"}), -1, 1)
+	require.EqualError(t, err, "eps must be non-negative")
+	_, err = ClusterDBSCAN(parseGeometryPtrs([]string{"POINT(0 0)"}), 1, -1)
+	require.EqualError(t, err, "minpoints must be non-negative")
+}
+
+func TestClusterKMeans(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		wkts     []string
+		k        int
+		expected []int
+	}{
+		{
+			"two clusters",
+			[]string{"POINT(0 0)", "POINT(10 10)", "POINT(0 1)", "POINT(11 10)", "POINT(1 0)"},
+			2,
+			[]int{0, 1, 0, 1, 0},
+		},
+		{
+			"fewer geometries than clusters",
+			[]string{"POINT(0 0)", "POINT(10 10)"},
+			5,
+			[]int{0, 1},
+		},
+		{
+			"null and empty geometries",
+			[]string{"", "POINT(0 0)", "POINT EMPTY", "POINT(10 10)"},
+			2,
+			[]int{NoCluster, 0, NoCluster, 1},
+		},
+		{
+			"one cluster",
+			[]string{"POINT(0 0)", "POINT(10 10)", "POINT(5 5)"},
+			1,
+			[]int{0, 0, 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ret, err := ClusterKMeans(parseGeometryPtrs(tc.wkts), tc.k)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, ret)
+		})
+	}
+
+	_, err := ClusterKMeans(parseGeometryPtrs([]string{"POINT(0 0)"}), 0)
+	require.EqualError(t, err, "number of clusters must be greater than zero")
+}
+
+func TestClusterIntersectingAndWithin(t *testing.T) {
+	gs := []geo.Geometry{
+		geo.MustParseGeometry("LINESTRING(0 0, 1 1)"),
+		geo.MustParseGeometry("POINT(10 10)"),
+		geo.MustParseGeometry("LINESTRING(1 1, 2 2)"),
+		geo.MustParseGeometry("POINT(10 11)"),
+	}
+
+	ret, err := ClusterIntersecting(gs)
+	require.NoError(t, err)
+	require.Equal(t, []geo.Geometry{
+		geo.MustParseGeometry("GEOMETRYCOLLECTION(LINESTRING(0 0, 1 1), LINESTRING(1 1, 2 2))"),
+		geo.MustParseGeometry("GEOMETRYCOLLECTION(POINT(10 10))"),
+		geo.MustParseGeometry("GEOMETRYCOLLECTION(POINT(10 11))"),
+	}, ret)
+
+	ret, err = ClusterWithin(gs, 1)
+	require.NoError(t, err)
+	require.Equal(t, []geo.Geometry{
+		geo.MustParseGeometry("GEOMETRYCOLLECTION(LINESTRING(0 0, 1 1), LINESTRING(1 1, 2 2))"),
+		geo.MustParseGeometry("GEOMETRYCOLLECTION(POINT(10 10), POINT(10 11))"),
+	}, ret)
+
+	_, err = ClusterWithin(gs, -1)
+	require.EqualError(t, err, "tolerance must be non-negative")
+}
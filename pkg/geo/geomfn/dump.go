@@ -0,0 +1,193 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geomfn
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+	geom "github.com/twpayne/go-geom"
+)
+
+// DumpedGeometry is a geometry extracted from another geometry, along with
+// its path within that geometry. Each element of the path is the 1-based index
+// of a component of a collection, ring of a polygon or vertex of a line.
+type DumpedGeometry struct {
+	Path     []int
+	Geometry geo.Geometry
+}
+
+// Dump returns the non-collection geometries which make up the given geometry.
+// The path of a geometry is the index of each collection it is nested in. Empty
+// geometries are omitted.
+func Dump(g geo.Geometry) ([]DumpedGeometry, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return nil, errors.Wrap(err, "error transforming geometry")
+	}
+	var res []DumpedGeometry
+	if err := dumpGeomT(t, nil /* path */, g.SRID(), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func dumpGeomT(t geom.T, path []int, srid geopb.SRID, res *[]DumpedGeometry) error {
+	if t.Empty() {
+		return nil
+	}
+	switch t := t.(type) {
+	case *geom.MultiPoint:
+		for i := 0; i < t.NumPoints(); i++ {
+			if err := dumpGeomT(t.Point(i), appendPath(path, i+1), srid, res); err != nil {
+				return err
+			}
+		}
+	case *geom.MultiLineString:
+		for i := 0; i < t.NumLineStrings(); i++ {
+			if err := dumpGeomT(t.LineString(i), appendPath(path, i+1), srid, res); err != nil {
+				return err
+			}
+		}
+	case *geom.MultiPolygon:
+		for i := 0; i < t.NumPolygons(); i++ {
+			if err := dumpGeomT(t.Polygon(i), appendPath(path, i+1), srid, res); err != nil {
+				return err
+			}
+		}
+	case *geom.GeometryCollection:
+		for i, c := range t.Geoms() {
+			if err := dumpGeomT(c, appendPath(path, i+1), srid, res); err != nil {
+				return err
+			}
+		}
+	default:
+		return appendDumped(t, path, srid, res)
+	}
+	return nil
+}
+
+// DumpPoints returns the vertices of the given geometry as points. The path of
+// a point is the index of each collection it is nested in, followed by the
+// index of its ring if it belongs to a polygon, followed by its index.
+func DumpPoints(g geo.Geometry) ([]DumpedGeometry, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return nil, errors.Wrap(err, "error transforming geometry")
+	}
+	var res []DumpedGeometry
+	if err := dumpPointsGeomT(t, nil /* path */, g.SRID(), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func dumpPointsGeomT(t geom.T, path []int, srid geopb.SRID, res *[]DumpedGeometry) error {
+	if t.Empty() {
+		return nil
+	}
+	switch t := t.(type) {
+	case *geom.Point:
+		return appendDumped(t, appendPath(path, 1), srid, res)
+	case *geom.LineString:
+		return dumpCoords(t.Layout(), t.FlatCoords(), path, srid, res)
+	case *geom.Polygon:
+		for i := 0; i < t.NumLinearRings(); i++ {
+			ring := t.LinearRing(i)
+			if err := dumpCoords(ring.Layout(), ring.FlatCoords(), appendPath(path, i+1), srid, res); err != nil {
+				return err
+			}
+		}
+	case *geom.MultiPoint:
+		for i := 0; i < t.NumPoints(); i++ {
+			if err := dumpPointsGeomT(t.Point(i), appendPath(path, i+1), srid, res); err != nil {
+				return err
+			}
+		}
+	case *geom.MultiLineString:
+		for i := 0; i < t.NumLineStrings(); i++ {
+			if err := dumpPointsGeomT(t.LineString(i), appendPath(path, i+1), srid, res); err != nil {
+				return err
+			}
+		}
+	case *geom.MultiPolygon:
+		for i := 0; i < t.NumPolygons(); i++ {
+			if err := dumpPointsGeomT(t.Polygon(i), appendPath(path, i+1), srid, res); err != nil {
+				return err
+			}
+		}
+	case *geom.GeometryCollection:
+		for i, c := range t.Geoms() {
+			if err := dumpPointsGeomT(c, appendPath(path, i+1), srid, res); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.AssertionFailedf("unknown geometry type: %T", t)
+	}
+	return nil
+}
+
+// dumpCoords appends a point for each of the given coordinates.
+func dumpCoords(
+	layout geom.Layout, flatCoords []float64, path []int, srid geopb.SRID, res *[]DumpedGeometry,
+) error {
+	stride := layout.Stride()
+	for i := 0; i < len(flatCoords); i += stride {
+		p := geom.NewPointFlat(layout, flatCoords[i:i+stride])
+		if err := appendDumped(p, appendPath(path, i/stride+1), srid, res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DumpRings returns the rings of the given polygon as polygons. The path of
+// the exterior ring is 0, and the paths of the interior rings are their
+// 1-based indexes.
+func DumpRings(g geo.Geometry) ([]DumpedGeometry, error) {
+	if g.ShapeType2D() != geopb.ShapeType_Polygon {
+		return nil, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"geometry type is unsupported. Please pass a Polygon",
+		)
+	}
+	t, err := g.AsGeomT()
+	if err != nil {
+		return nil, errors.Wrap(err, "error transforming geometry")
+	}
+	poly := t.(*geom.Polygon)
+	res := make([]DumpedGeometry, 0, poly.NumLinearRings())
+	for i := 0; i < poly.NumLinearRings(); i++ {
+		ring := poly.LinearRing(i)
+		p := geom.NewPolygonFlat(ring.Layout(), ring.FlatCoords(), []int{len(ring.FlatCoords())})
+		if err := appendDumped(p, []int{i}, g.SRID(), &res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// appendDumped appends the given geometry with the given path to res.
+func appendDumped(t geom.T, path []int, srid geopb.SRID, res *[]DumpedGeometry) error {
+	geo.AdjustGeomTSRID(t, srid)
+	g, err := geo.MakeGeometryFromGeomT(t)
+	if err != nil {
+		return errors.Wrap(err, "error transforming geometry")
+	}
+	*res = append(*res, DumpedGeometry{Path: path, Geometry: g})
+	return nil
+}
+
+// appendPath returns a copy of path with idx appended to it.
+func appendPath(path []int, idx int) []int {
+	res := make([]int, len(path)+1)
+	copy(res, path)
+	res[len(path)] = idx
+	return res
+}
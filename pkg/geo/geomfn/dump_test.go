@@ -0,0 +1,117 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geomfn
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+type dumpedWKT struct {
+	path []int
+	wkt  string
+}
+
+func requireDumped(t *testing.T, expected []dumpedWKT, actual []DumpedGeometry) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.Equal(t, expected[i].path, actual[i].Path)
+		require.Equal(t, geo.MustParseGeometry(expected[i].wkt), actual[i].Geometry)
+	}
+}
+
+func TestDump(t *testing.T) {
+	testCases := []struct {
+		wkt      string
+		expected []dumpedWKT
+	}{
+		{"POINT EMPTY", nil},
+		{"POINT(1 2)", []dumpedWKT{{nil, "POINT(1 2)"}}},
+		{
+			"SRID=4326;MULTIPOINT((1 2), EMPTY, (3 4))",
+			[]dumpedWKT{
+				{[]int{1}, "SRID=4326;POINT(1 2)"},
+				{[]int{3}, "SRID=4326;POINT(3 4)"},
+			},
+		},
+		{
+			"GEOMETRYCOLLECTION(POINT(1 2), GEOMETRYCOLLECTION(LINESTRING(0 0, 1 1), MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0)))))",
+			[]dumpedWKT{
+				{[]int{1}, "POINT(1 2)"},
+				{[]int{2, 1}, "LINESTRING(0 0, 1 1)"},
+				{[]int{2, 2, 1}, "POLYGON((0 0, 1 0, 1 1, 0 0))"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			ret, err := Dump(geo.MustParseGeometry(tc.wkt))
+			require.NoError(t, err)
+			requireDumped(t, tc.expected, ret)
+		})
+	}
+}
+
+func TestDumpPoints(t *testing.T) {
+	testCases := []struct {
+		wkt      string
+		expected []dumpedWKT
+	}{
+		{"LINESTRING EMPTY", nil},
+		{"POINT(1 2)", []dumpedWKT{{[]int{1}, "POINT(1 2)"}}},
+		{
+			"SRID=4326;LINESTRING(0 0, 1 1)",
+			[]dumpedWKT{
+				{[]int{1}, "SRID=4326;POINT(0 0)"},
+				{[]int{2}, "SRID=4326;POINT(1 1)"},
+			},
+		},
+		{
+			"POLYGON((0 0, 1 0, 1 1, 0 0))",
+			[]dumpedWKT{
+				{[]int{1, 1}, "POINT(0 0)"},
+				{[]int{1, 2}, "POINT(1 0)"},
+				{[]int{1, 3}, "POINT(1 1)"},
+				{[]int{1, 4}, "POINT(0 0)"},
+			},
+		},
+		{
+			"GEOMETRYCOLLECTION(MULTIPOINT Z((1 2 3)), MULTILINESTRING((0 0, 1 1), (2 2, 3 3)))",
+			[]dumpedWKT{
+				{[]int{1, 1, 1}, "POINT Z(1 2 3)"},
+				{[]int{2, 1, 1}, "POINT(0 0)"},
+				{[]int{2, 1, 2}, "POINT(1 1)"},
+				{[]int{2, 2, 1}, "POINT(2 2)"},
+				{[]int{2, 2, 2}, "POINT(3 3)"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			ret, err := DumpPoints(geo.MustParseGeometry(tc.wkt))
+			require.NoError(t, err)
+			requireDumped(t, tc.expected, ret)
+		})
+	}
+}
+
+func TestDumpRings(t *testing.T) {
+	ret, err := DumpRings(geo.MustParseGeometry(
+		"SRID=4326;POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 2 1, 2 2, 1 1))",
+	))
+	require.NoError(t, err)
+	requireDumped(t, []dumpedWKT{
+		{[]int{0}, "SRID=4326;POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))"},
+		{[]int{1}, "SRID=4326;POLYGON((1 1, 2 1, 2 2, 1 1))"},
+	}, ret)
+
+	_, err = DumpRings(geo.MustParseGeometry("MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0)))"))
+	require.EqualError(t, err, "geometry type is unsupported. Please pass a Polygon")
+}
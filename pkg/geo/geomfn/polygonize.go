@@ -0,0 +1,51 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geomfn
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/geo/geos"
+	"github.com/cockroachdb/errors"
+	geom "github.com/twpayne/go-geom"
+)
+
+// Polygonize returns a GeometryCollection of the polygons formed by the
+// linework of the given geometries.
+func Polygonize(gs []geo.Geometry) (geo.Geometry, error) {
+	coll, err := makeGeometryCollection(gs)
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	res, err := geos.Polygonize(coll.EWKB())
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.ParseGeometryFromEWKB(res)
+}
+
+// makeGeometryCollection returns a GeometryCollection of the given geometries,
+// which must all have the same SRID.
+func makeGeometryCollection(gs []geo.Geometry) (geo.Geometry, error) {
+	var srid geopb.SRID
+	gc := geom.NewGeometryCollection()
+	for i := range gs {
+		if i == 0 {
+			srid = gs[i].SRID()
+		} else if gs[i].SRID() != srid {
+			return geo.Geometry{}, geo.NewMismatchingSRIDsError(gs[0].SpatialObject(), gs[i].SpatialObject())
+		}
+		t, err := gs[i].AsGeomT()
+		if err != nil {
+			return geo.Geometry{}, errors.Wrap(err, "error transforming geometry")
+		}
+		if err := gc.Push(t); err != nil {
+			return geo.Geometry{}, err
+		}
+	}
+	gc.SetSRID(int(srid))
+	return geo.MakeGeometryFromGeomT(gc)
+}
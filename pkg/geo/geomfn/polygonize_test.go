@@ -0,0 +1,65 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geomfn
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolygonize(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		wkts     []string
+		expected string
+	}{
+		{
+			"no polygons",
+			[]string{"LINESTRING(0 0, 1 1)"},
+			"GEOMETRYCOLLECTION EMPTY",
+		},
+		{
+			"closed ring",
+			[]string{"LINESTRING(0 0, 1 0, 1 1, 0 0)"},
+			"GEOMETRYCOLLECTION(POLYGON((0 0, 1 1, 1 0, 0 0)))",
+		},
+		{
+			"ring formed by several lines",
+			[]string{
+				"SRID=4326;LINESTRING(0 0, 1 0)",
+				"SRID=4326;LINESTRING(1 0, 1 1)",
+				"SRID=4326;LINESTRING(1 1, 0 0)",
+			},
+			"SRID=4326;GEOMETRYCOLLECTION(POLYGON((0 0, 1 1, 1 0, 0 0)))",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var gs []geo.Geometry
+			for _, wkt := range tc.wkts {
+				gs = append(gs, geo.MustParseGeometry(wkt))
+			}
+			ret, err := Polygonize(gs)
+			require.NoError(t, err)
+			expected := geo.MustParseGeometry(tc.expected)
+			require.Equal(t, expected.SRID(), ret.SRID())
+			eq, err := Equals(expected, ret)
+			require.NoError(t, err)
+			require.True(t, eq, "expected %s, got %s", tc.expected, ret.EWKBHex())
+		})
+	}
+
+	t.Run("mismatching SRIDs", func(t *testing.T) {
+		_, err := Polygonize([]geo.Geometry{
+			geo.MustParseGeometry("LINESTRING(0 0, 1 0)"),
+			geo.MustParseGeometry("SRID=4326;LINESTRING(1 0, 1 1)"),
+		})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,312 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geomfn
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+	geom "github.com/twpayne/go-geom"
+)
+
+// splitPointTolerance is the distance, relative to the magnitude of the
+// coordinates of a segment, within which a point is considered to lie on the
+// segment when splitting a LineString. It absorbs the rounding error of
+// intersection points computed by GEOS.
+const splitPointTolerance = 1e-9
+
+// Split returns a GeometryCollection of the parts of the given geometry after
+// it is split by the blade. LineStrings can be split by Points, LineStrings and
+// Polygons, and Polygons can be split by LineStrings.
+func Split(g geo.Geometry, blade geo.Geometry) (geo.Geometry, error) {
+	if g.SRID() != blade.SRID() {
+		return geo.Geometry{}, geo.NewMismatchingSRIDsError(g.SpatialObject(), blade.SpatialObject())
+	}
+	t, err := g.AsGeomT()
+	if err != nil {
+		return geo.Geometry{}, errors.Wrap(err, "error transforming geometry")
+	}
+	gc := geom.NewGeometryCollection().SetSRID(int(g.SRID()))
+	if g.Empty() {
+		return geo.MakeGeometryFromGeomT(gc)
+	}
+
+	switch t := t.(type) {
+	case *geom.LineString, *geom.MultiLineString:
+		cuts, err := splitLinePoints(g, blade)
+		if err != nil {
+			return geo.Geometry{}, err
+		}
+		var lines []*geom.LineString
+		switch t := t.(type) {
+		case *geom.LineString:
+			lines = append(lines, t)
+		case *geom.MultiLineString:
+			for i := 0; i < t.NumLineStrings(); i++ {
+				lines = append(lines, t.LineString(i))
+			}
+		}
+		for _, line := range lines {
+			if line.Empty() {
+				continue
+			}
+			for _, part := range splitLineString(line, cuts) {
+				if err := gc.Push(part); err != nil {
+					return geo.Geometry{}, err
+				}
+			}
+		}
+	case *geom.Polygon, *geom.MultiPolygon:
+		switch blade.ShapeType2D() {
+		case geopb.ShapeType_LineString, geopb.ShapeType_MultiLineString:
+		default:
+			return geo.Geometry{}, pgerror.Newf(
+				pgcode.InvalidParameterValue,
+				"splitting a Polygon by a %s is unsupported",
+				blade.ShapeType2D(),
+			)
+		}
+		var polys []*geom.Polygon
+		switch t := t.(type) {
+		case *geom.Polygon:
+			polys = append(polys, t)
+		case *geom.MultiPolygon:
+			for i := 0; i < t.NumPolygons(); i++ {
+				polys = append(polys, t.Polygon(i))
+			}
+		}
+		for _, poly := range polys {
+			if poly.Empty() {
+				continue
+			}
+			geo.AdjustGeomTSRID(poly, g.SRID())
+			polyGeom, err := geo.MakeGeometryFromGeomT(poly)
+			if err != nil {
+				return geo.Geometry{}, errors.Wrap(err, "error transforming geometry")
+			}
+			parts, err := splitPolygon(polyGeom, blade)
+			if err != nil {
+				return geo.Geometry{}, err
+			}
+			if err := gc.Push(parts...); err != nil {
+				return geo.Geometry{}, err
+			}
+		}
+	default:
+		return geo.Geometry{}, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"splitting a %s is unsupported",
+			g.ShapeType2D(),
+		)
+	}
+	return geo.MakeGeometryFromGeomT(gc)
+}
+
+// splitLinePoints returns the coordinates at which the given linear geometry
+// is split by the blade.
+func splitLinePoints(g geo.Geometry, blade geo.Geometry) ([]geom.Coord, error) {
+	points := blade
+	switch blade.ShapeType2D() {
+	case geopb.ShapeType_Point, geopb.ShapeType_MultiPoint:
+	case geopb.ShapeType_LineString, geopb.ShapeType_MultiLineString,
+		geopb.ShapeType_Polygon, geopb.ShapeType_MultiPolygon:
+		bladeLines := blade
+		if blade.Dimension() == 2 {
+			var err error
+			if bladeLines, err = Boundary(blade); err != nil {
+				return nil, err
+			}
+		}
+		var err error
+		if points, err = Intersection(g, bladeLines); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"splitting a LineString by a %s is unsupported",
+			blade.ShapeType2D(),
+		)
+	}
+	t, err := points.AsGeomT()
+	if err != nil {
+		return nil, errors.Wrap(err, "error transforming geometry")
+	}
+	var coords []geom.Coord
+	if err := appendPointCoords(t, &coords); err != nil {
+		return nil, err
+	}
+	return coords, nil
+}
+
+// appendPointCoords appends the coordinates of the points in t to coords. It
+// returns an error if t has any non-empty components which aren't points.
+func appendPointCoords(t geom.T, coords *[]geom.Coord) error {
+	if t.Empty() {
+		return nil
+	}
+	switch t := t.(type) {
+	case *geom.Point:
+		*coords = append(*coords, t.Coords())
+	case *geom.MultiPoint:
+		for i := 0; i < t.NumPoints(); i++ {
+			if p := t.Point(i); !p.Empty() {
+				*coords = append(*coords, p.Coords())
+			}
+		}
+	case *geom.GeometryCollection:
+		for _, c := range t.Geoms() {
+			if err := appendPointCoords(c, coords); err != nil {
+				return err
+			}
+		}
+	default:
+		return pgerror.Newf(pgcode.InvalidParameterValue, "splitter line has linear intersection with input")
+	}
+	return nil
+}
+
+// lineCut is the location at which a LineString is cut, as the index of a
+// segment and the fraction of the length of the segment preceding the cut.
+type lineCut struct {
+	seg  int
+	frac float64
+}
+
+// splitLineString splits the given LineString at the given coordinates which
+// lie on it.
+func splitLineString(line *geom.LineString, coords []geom.Coord) []geom.T {
+	n := line.NumCoords()
+	var cuts []lineCut
+	for _, c := range coords {
+		for seg := 0; seg < n-1; seg++ {
+			frac, ok := locateOnSegment(line.Coord(seg), line.Coord(seg+1), c)
+			if !ok {
+				continue
+			}
+			if frac == 1 {
+				seg, frac = seg+1, 0
+			}
+			// Cutting at the endpoints of the line doesn't split it.
+			if (seg != 0 || frac != 0) && seg != n-1 {
+				cuts = append(cuts, lineCut{seg: seg, frac: frac})
+			}
+			break
+		}
+	}
+	sort.Slice(cuts, func(i, j int) bool {
+		if cuts[i].seg != cuts[j].seg {
+			return cuts[i].seg < cuts[j].seg
+		}
+		return cuts[i].frac < cuts[j].frac
+	})
+
+	layout := line.Layout()
+	stride := layout.Stride()
+	var parts []geom.T
+	flatCoords := append([]float64(nil), line.Coord(0)...)
+	emit := func() {
+		ls := geom.NewLineStringFlat(layout, flatCoords)
+		ls.SetSRID(line.SRID())
+		parts = append(parts, ls)
+	}
+	for seg, i := 0, 0; seg < n-1; seg++ {
+		a, b := line.Coord(seg), line.Coord(seg+1)
+		for ; i < len(cuts) && cuts[i].seg == seg; i++ {
+			if i > 0 && cuts[i] == cuts[i-1] {
+				continue
+			}
+			if cuts[i].frac != 0 {
+				for j := 0; j < stride; j++ {
+					flatCoords = append(flatCoords, a[j]+(b[j]-a[j])*cuts[i].frac)
+				}
+			}
+			emit()
+			flatCoords = append([]float64(nil), flatCoords[len(flatCoords)-stride:]...)
+		}
+		flatCoords = append(flatCoords, b...)
+	}
+	emit()
+	return parts
+}
+
+// locateOnSegment returns the fraction of the length of the segment from a to b
+// at which c lies, if it lies on the segment.
+func locateOnSegment(a, b, c geom.Coord) (frac float64, ok bool) {
+	dx, dy := b.X()-a.X(), b.Y()-a.Y()
+	lenSq := dx*dx + dy*dy
+	if lenSq == 0 {
+		return 0, false
+	}
+	frac = ((c.X()-a.X())*dx + (c.Y()-a.Y())*dy) / lenSq
+	frac = math.Max(0, math.Min(1, frac))
+	px, py := a.X()+frac*dx, a.Y()+frac*dy
+	scale := 1 + math.Max(math.Max(math.Abs(a.X()), math.Abs(a.Y())), math.Max(math.Abs(b.X()), math.Abs(b.Y())))
+	if math.Hypot(c.X()-px, c.Y()-py) > splitPointTolerance*scale {
+		return 0, false
+	}
+	// Snap cuts at the vertices of the segment, so that they are recognized as
+	// such.
+	if math.Hypot(c.X()-a.X(), c.Y()-a.Y()) <= splitPointTolerance*scale {
+		return 0, true
+	}
+	if math.Hypot(c.X()-b.X(), c.Y()-b.Y()) <= splitPointTolerance*scale {
+		return 1, true
+	}
+	return frac, true
+}
+
+// splitPolygon splits the given Polygon by the given linear blade, returning
+// the resulting polygons.
+func splitPolygon(poly geo.Geometry, blade geo.Geometry) ([]geom.T, error) {
+	// Polygonize the union of the boundary of the polygon and the blade, and
+	// keep the faces which lie inside the polygon.
+	boundary, err := Boundary(poly)
+	if err != nil {
+		return nil, err
+	}
+	linework, err := Union(boundary, blade)
+	if err != nil {
+		return nil, err
+	}
+	faces, err := Polygonize([]geo.Geometry{linework})
+	if err != nil {
+		return nil, err
+	}
+	t, err := faces.AsGeomT()
+	if err != nil {
+		return nil, errors.Wrap(err, "error transforming geometry")
+	}
+	gc, ok := t.(*geom.GeometryCollection)
+	if !ok {
+		return nil, errors.AssertionFailedf("expected polygonize to return a GeometryCollection, got %T", t)
+	}
+	var parts []geom.T
+	for _, face := range gc.Geoms() {
+		geo.AdjustGeomTSRID(face, poly.SRID())
+		faceGeom, err := geo.MakeGeometryFromGeomT(face)
+		if err != nil {
+			return nil, errors.Wrap(err, "error transforming geometry")
+		}
+		pt, err := PointOnSurface(faceGeom)
+		if err != nil {
+			return nil, err
+		}
+		inside, err := Within(pt, poly)
+		if err != nil {
+			return nil, err
+		}
+		if inside {
+			parts = append(parts, face)
+		}
+	}
+	return parts, nil
+}
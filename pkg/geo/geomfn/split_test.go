@@ -0,0 +1,121 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geomfn
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		g        string
+		blade    string
+		expected string
+	}{
+		{
+			"empty input",
+			"LINESTRING EMPTY",
+			"POINT(1 1)",
+			"GEOMETRYCOLLECTION EMPTY",
+		},
+		{
+			"line split by point",
+			"LINESTRING(0 0, 10 0)",
+			"POINT(4 0)",
+			"GEOMETRYCOLLECTION(LINESTRING(0 0, 4 0), LINESTRING(4 0, 10 0))",
+		},
+		{
+			"line split by point not on it",
+			"LINESTRING(0 0, 10 0)",
+			"POINT(4 1)",
+			"GEOMETRYCOLLECTION(LINESTRING(0 0, 10 0))",
+		},
+		{
+			"line split at a vertex and its endpoint",
+			"SRID=4326;LINESTRING(0 0, 5 0, 5 5)",
+			"SRID=4326;MULTIPOINT((5 0), (5 5))",
+			"SRID=4326;GEOMETRYCOLLECTION(LINESTRING(0 0, 5 0), LINESTRING(5 0, 5 5))",
+		},
+		{
+			"line split by points out of order, interpolating Z",
+			"LINESTRING Z(0 0 0, 10 0 10)",
+			"MULTIPOINT Z((8 0 0), (2 0 0))",
+			"GEOMETRYCOLLECTION Z(LINESTRING Z(0 0 0, 2 0 2), LINESTRING Z(2 0 2, 8 0 8), LINESTRING Z(8 0 8, 10 0 10))",
+		},
+		{
+			"line split by line",
+			"LINESTRING(0 0, 10 0)",
+			"LINESTRING(5 -5, 5 5)",
+			"GEOMETRYCOLLECTION(LINESTRING(0 0, 5 0), LINESTRING(5 0, 10 0))",
+		},
+		{
+			"multiline split by polygon",
+			"MULTILINESTRING((0 0, 10 0), (0 5, 10 5))",
+			"POLYGON((2 -1, 4 -1, 4 1, 2 1, 2 -1))",
+			"GEOMETRYCOLLECTION(LINESTRING(0 0, 2 0), LINESTRING(2 0, 4 0), LINESTRING(4 0, 10 0), LINESTRING(0 5, 10 5))",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ret, err := Split(geo.MustParseGeometry(tc.g), geo.MustParseGeometry(tc.blade))
+			require.NoError(t, err)
+			require.Equal(t, geo.MustParseGeometry(tc.expected), ret)
+		})
+	}
+
+	t.Run("polygon split by line", func(t *testing.T) {
+		poly := geo.MustParseGeometry("SRID=4326;POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))")
+		ret, err := Split(poly, geo.MustParseGeometry("SRID=4326;LINESTRING(5 -1, 5 11)"))
+		require.NoError(t, err)
+		require.Equal(t, poly.SRID(), ret.SRID())
+		parts, err := Dump(ret)
+		require.NoError(t, err)
+		require.Len(t, parts, 2)
+		for _, part := range parts {
+			area, err := Area(part.Geometry)
+			require.NoError(t, err)
+			require.Equal(t, 50.0, area)
+		}
+	})
+
+	errorTestCases := []struct {
+		desc        string
+		g           string
+		blade       string
+		expectedErr string
+	}{
+		{
+			"line overlapping blade",
+			"LINESTRING(0 0, 10 0)",
+			"LINESTRING(5 0, 15 0)",
+			"splitter line has linear intersection with input",
+		},
+		{
+			"polygon split by point",
+			"POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))",
+			"POINT(5 5)",
+			"splitting a Polygon by a Point is unsupported",
+		},
+		{
+			"point input",
+			"POINT(0 0)",
+			"POINT(0 0)",
+			"splitting a Point is unsupported",
+		},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := Split(geo.MustParseGeometry(tc.g), geo.MustParseGeometry(tc.blade))
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -82,6 +82,20 @@ func ConvexHull(g geo.Geometry) (geo.Geometry, error) {
 	return geo.ParseGeometryFromEWKB(convexHullEWKB)
 }
 
+// ConcaveHull returns the concave hull of a given Geometry. The ratio controls
+// the concaveness of the hull, from 0 for the most concave hull to 1 for the
+// convex hull. Holes are only allowed in the hull if allowHoles is set.
+func ConcaveHull(g geo.Geometry, ratio float64, allowHoles bool) (geo.Geometry, error) {
+	if ratio < 0 || ratio > 1 || math.IsNaN(ratio) {
+		return geo.Geometry{}, pgerror.Newf(pgcode.InvalidParameterValue, "target percent must be between 0 and 1")
+	}
+	concaveHullEWKB, err := geos.ConcaveHull(g.EWKB(), ratio, allowHoles)
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.ParseGeometryFromEWKB(concaveHullEWKB)
+}
+
 // Difference returns the difference between two given Geometries.
 func Difference(a, b geo.Geometry) (geo.Geometry, error) {
 	// follow PostGIS behavior
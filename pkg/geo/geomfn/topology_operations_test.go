@@ -163,6 +163,50 @@ func TestConvexHull(t *testing.T) {
 	}
 }
 
+func TestConcaveHull(t *testing.T) {
+	testCases := []struct {
+		wkt string
+	}{
+		{"MULTIPOINT((0 0), (0 10), (10 10), (10 9), (1 9), (1 0))"},
+		{"SRID=4326;MULTIPOINT((0 0), (0 10), (10 10), (10 9), (1 9), (1 0))"},
+		{"MULTILINESTRING((100 190,10 8),(150 10, 20 30))"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			g, err := geo.ParseGeometry(tc.wkt)
+			require.NoError(t, err)
+			convexHull, err := ConvexHull(g)
+			require.NoError(t, err)
+			convexHullArea, err := Area(convexHull)
+			require.NoError(t, err)
+
+			// A ratio of 1 produces the convex hull.
+			ret, err := ConcaveHull(g, 1, false /* allowHoles */)
+			require.NoError(t, err)
+			require.Equal(t, g.SRID(), ret.SRID())
+			eq, err := Equals(convexHull, ret)
+			require.NoError(t, err)
+			require.True(t, eq)
+
+			// Lower ratios never produce a larger hull.
+			ret, err = ConcaveHull(g, 0, false /* allowHoles */)
+			require.NoError(t, err)
+			area, err := Area(ret)
+			require.NoError(t, err)
+			require.LessOrEqual(t, area, convexHullArea)
+		})
+	}
+
+	t.Run("invalid ratio", func(t *testing.T) {
+		g := geo.MustParseGeometry("MULTIPOINT((0 0), (0 10), (10 10))")
+		for _, ratio := range []float64{-0.1, 1.1, math.NaN()} {
+			_, err := ConcaveHull(g, ratio, false /* allowHoles */)
+			require.EqualError(t, err, "target percent must be between 0 and 1")
+		}
+	})
+}
+
 func TestDifference(t *testing.T) {
 	testCases := []struct {
 		wkt1     string
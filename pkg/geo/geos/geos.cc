@@ -97,6 +97,8 @@ typedef CR_GEOS_Geometry (*CR_GEOS_LineMerge_r)(CR_GEOS_Handle, CR_GEOS_Geometry
 typedef CR_GEOS_Geometry (*CR_GEOS_Boundary_r)(CR_GEOS_Handle, CR_GEOS_Geometry);
 typedef CR_GEOS_Geometry (*CR_GEOS_Centroid_r)(CR_GEOS_Handle, CR_GEOS_Geometry);
 typedef CR_GEOS_Geometry (*CR_GEOS_ConvexHull_r)(CR_GEOS_Handle, CR_GEOS_Geometry);
+typedef CR_GEOS_Geometry (*CR_GEOS_ConcaveHull_r)(CR_GEOS_Handle, CR_GEOS_Geometry, double,
+                                                  unsigned int);
 typedef CR_GEOS_Geometry (*CR_GEOS_Difference_r)(CR_GEOS_Handle, CR_GEOS_Geometry,
                                                  CR_GEOS_Geometry);
 typedef CR_GEOS_Geometry (*CR_GEOS_Simplify_r)(CR_GEOS_Handle, CR_GEOS_Geometry, double);
@@ -161,6 +163,11 @@ typedef CR_GEOS_Geometry (*CR_GEOS_SharedPaths_r)(CR_GEOS_Handle, CR_GEOS_Geomet
 
 typedef CR_GEOS_Geometry (*CR_GEOS_Node_r)(CR_GEOS_Handle, CR_GEOS_Geometry);
 
+typedef int (*CR_GEOS_GetNumGeometries_r)(CR_GEOS_Handle, CR_GEOS_Geometry);
+typedef CR_GEOS_Geometry (*CR_GEOS_GetGeometryN_r)(CR_GEOS_Handle, CR_GEOS_Geometry, int);
+typedef CR_GEOS_Geometry (*CR_GEOS_Polygonize_r)(CR_GEOS_Handle, const CR_GEOS_Geometry*,
+                                                 unsigned int);
+
 typedef CR_GEOS_Geometry (*CR_GEOS_VoronoiDiagram_r)(CR_GEOS_Handle, CR_GEOS_Geometry,
                                                   CR_GEOS_Geometry, double, int);
 
@@ -226,6 +233,7 @@ struct CR_GEOS {
   CR_GEOS_Boundary_r GEOSBoundary_r;
   CR_GEOS_Centroid_r GEOSGetCentroid_r;
   CR_GEOS_ConvexHull_r GEOSConvexHull_r;
+  CR_GEOS_ConcaveHull_r GEOSConcaveHull_r;
   CR_GEOS_Difference_r GEOSDifference_r;
   CR_GEOS_Simplify_r GEOSSimplify_r;
   CR_GEOS_TopologyPreserveSimplify_r GEOSTopologyPreserveSimplify_r;
@@ -281,6 +289,10 @@ struct CR_GEOS {
 
   CR_GEOS_Node_r GEOSNode_r;
 
+  CR_GEOS_GetNumGeometries_r GEOSGetNumGeometries_r;
+  CR_GEOS_GetGeometryN_r GEOSGetGeometryN_r;
+  CR_GEOS_Polygonize_r GEOSPolygonize_r;
+
   CR_GEOS_Snap_r GEOSSnap_r;
 
   CR_GEOS_Version_r GEOSversion;
@@ -340,6 +352,7 @@ struct CR_GEOS {
     INIT(GEOSGetCentroid_r);
     INIT(GEOSMinimumBoundingCircle_r);
     INIT(GEOSConvexHull_r);
+    INIT(GEOSConcaveHull_r);
     INIT(GEOSSimplify_r);
     INIT(GEOSTopologyPreserveSimplify_r);
     INIT(GEOSUnaryUnion_r);
@@ -387,6 +400,9 @@ struct CR_GEOS {
     INIT(GEOSWKBWriter_write_r);
     INIT(GEOSClipByRect_r);
     INIT(GEOSNode_r);
+    INIT(GEOSGetNumGeometries_r);
+    INIT(GEOSGetGeometryN_r);
+    INIT(GEOSPolygonize_r);
     INIT(GEOSSnap_r);
     INIT(GEOSversion);
     return nullptr;
@@ -902,6 +918,30 @@ CR_GEOS_Status CR_GEOS_ConvexHull(CR_GEOS* lib, CR_GEOS_Slice a, CR_GEOS_String*
   return toGEOSString(error.data(), error.length());
 }
 
+CR_GEOS_Status CR_GEOS_ConcaveHull(CR_GEOS* lib, CR_GEOS_Slice a, double ratio, char allowHoles,
+                                   CR_GEOS_String* concaveHullEWKB) {
+  *concaveHullEWKB = {.data = NULL, .len = 0};
+  std::string error;
+  // GEOSConcaveHull_r is only available in GEOS 3.11 and later.
+  if (lib->GEOSConcaveHull_r == nullptr) {
+    error = "concave hull requires GEOS 3.11 or later";
+    return toGEOSString(error.data(), error.length());
+  }
+  auto handle = initHandleWithErrorBuffer(lib, &error);
+  auto geom = CR_GEOS_GeometryFromSlice(lib, handle, a);
+  if (geom != nullptr) {
+    auto concaveHullGeom = lib->GEOSConcaveHull_r(handle, geom, ratio, allowHoles);
+    if (concaveHullGeom != nullptr) {
+      auto srid = lib->GEOSGetSRID_r(handle, geom);
+      CR_GEOS_writeGeomToEWKB(lib, handle, concaveHullGeom, concaveHullEWKB, srid);
+      lib->GEOSGeom_destroy_r(handle, concaveHullGeom);
+    }
+    lib->GEOSGeom_destroy_r(handle, geom);
+  }
+  lib->GEOS_finish_r(handle);
+  return toGEOSString(error.data(), error.length());
+}
+
 CR_GEOS_Status CR_GEOS_Difference(CR_GEOS* lib, CR_GEOS_Slice a, CR_GEOS_Slice b,
                                   CR_GEOS_String* diffEWKB) {
   std::string error;
@@ -1464,6 +1504,34 @@ CR_GEOS_Status CR_GEOS_Node(CR_GEOS* lib, CR_GEOS_Slice a, CR_GEOS_String* nodeE
   return toGEOSString(error.data(), error.length());
 }
 
+CR_GEOS_Status CR_GEOS_Polygonize(CR_GEOS* lib, CR_GEOS_Slice a, CR_GEOS_String* ret) {
+  std::string error;
+  auto handle = initHandleWithErrorBuffer(lib, &error);
+  *ret = {.data = NULL, .len = 0};
+
+  auto geom = CR_GEOS_GeometryFromSlice(lib, handle, a);
+  if (geom != nullptr) {
+    // The components of the given collection are the linework to polygonize.
+    auto n = lib->GEOSGetNumGeometries_r(handle, geom);
+    if (n >= 0) {
+      std::vector<CR_GEOS_Geometry> geoms(n);
+      for (int i = 0; i < n; i++) {
+        geoms[i] = lib->GEOSGetGeometryN_r(handle, geom, i);
+      }
+      auto r = lib->GEOSPolygonize_r(handle, geoms.data(), n);
+      if (r != NULL) {
+        auto srid = lib->GEOSGetSRID_r(handle, geom);
+        CR_GEOS_writeGeomToEWKB(lib, handle, r, ret, srid);
+        lib->GEOSGeom_destroy_r(handle, r);
+      }
+    }
+    lib->GEOSGeom_destroy_r(handle, geom);
+  }
+
+  lib->GEOS_finish_r(handle);
+  return toGEOSString(error.data(), error.length());
+}
+
 CR_GEOS_Status CR_GEOS_VoronoiDiagram(CR_GEOS* lib, CR_GEOS_Slice g, CR_GEOS_Slice env,
                                       double tolerance, int onlyEdges, CR_GEOS_String* ret) {
   std::string error;
@@ -486,6 +486,28 @@ func ConvexHull(ewkb geopb.EWKB) (geopb.EWKB, error) {
 	return cStringToSafeGoBytes(cEWKB), nil
 }
 
+// ConcaveHull returns an EWKB which returns the concave hull of the given
+// EWKB. The ratio is the fraction of the difference between the longest and
+// shortest edge lengths of the Delaunay triangulation of the vertices, with 1
+// producing the convex hull.
+func ConcaveHull(ewkb geopb.EWKB, ratio float64, allowHoles bool) (geopb.EWKB, error) {
+	g, err := ensureInitInternal()
+	if err != nil {
+		return nil, err
+	}
+	var holes C.char
+	if allowHoles {
+		holes = 1
+	}
+	var cEWKB C.CR_GEOS_String
+	if err := statusToError(
+		C.CR_GEOS_ConcaveHull(g, goToCSlice(ewkb), C.double(ratio), holes, &cEWKB),
+	); err != nil {
+		return nil, err
+	}
+	return cStringToSafeGoBytes(cEWKB), nil
+}
+
 // Simplify returns an EWKB which returns the simplified EWKB.
 func Simplify(ewkb geopb.EWKB, tolerance float64) (geopb.EWKB, error) {
 	g, err := ensureInitInternal()
@@ -1073,6 +1095,20 @@ func Node(a geopb.EWKB) (geopb.EWKB, error) {
 	return cStringToSafeGoBytes(cEWKB), nil
 }
 
+// Polygonize returns an EWKB containing a GeometryCollection of the polygons
+// formed by the linework of the components of the given collection EWKB.
+func Polygonize(a geopb.EWKB) (geopb.EWKB, error) {
+	g, err := ensureInitInternal()
+	if err != nil {
+		return nil, err
+	}
+	var cEWKB C.CR_GEOS_String
+	if err := statusToError(C.CR_GEOS_Polygonize(g, goToCSlice(a), &cEWKB)); err != nil {
+		return nil, err
+	}
+	return cStringToSafeGoBytes(cEWKB), nil
+}
+
 // VoronoiDiagram Computes the Voronoi Diagram from the vertices of the supplied EWKBs.
 func VoronoiDiagram(a, env geopb.EWKB, tolerance float64, onlyEdges bool) (geopb.EWKB, error) {
 	g, err := ensureInitInternal()
@@ -0,0 +1,789 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/geo/geoprojbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/ewkb"
+)
+
+// DefaultGMLVersion is the GML version used by ST_AsGML if none is specified.
+const DefaultGMLVersion = 2
+
+// DefaultGMLPrefix is the namespace prefix used by ST_AsGML if none is
+// specified.
+const DefaultGMLPrefix = "gml"
+
+// SpatialObjectToGMLFlag maps to the ST_AsGML options for PostGIS.
+type SpatialObjectToGMLFlag int
+
+// These should be kept with ST_AsGML in PostGIS.
+// 0: means no option
+// 1: use the long CRS form (e.g. urn:ogc:def:crs:EPSG::4326)
+// 2: GML 3 only, omit the srsDimension attribute
+// 4: GML 3 only, use <LineString> rather than <Curve> for lines
+// 16: write the coordinates in lat/lng order
+// 32: write the bounding box (envelope) of the geometry
+const (
+	SpatialObjectToGMLFlagLongCRS        SpatialObjectToGMLFlag = 1
+	SpatialObjectToGMLFlagNoSRSDimension SpatialObjectToGMLFlag = 2
+	SpatialObjectToGMLFlagLineString     SpatialObjectToGMLFlag = 4
+	SpatialObjectToGMLFlagLatLng         SpatialObjectToGMLFlag = 16
+	SpatialObjectToGMLFlagEnvelope       SpatialObjectToGMLFlag = 32
+
+	SpatialObjectToGMLFlagZero = 0
+)
+
+// SpatialObjectToGML transforms a given SpatialObject to GML of the given
+// version (2 or 3). Element names are qualified with the given namespace
+// prefix, unless it is empty.
+func SpatialObjectToGML(
+	so geopb.SpatialObject,
+	version int,
+	maxDecimalDigits int,
+	flag SpatialObjectToGMLFlag,
+	prefix string,
+) (string, error) {
+	if version != 2 && version != 3 {
+		return "", pgerror.Newf(pgcode.InvalidParameterValue, "only GML 2 and GML 3 are supported")
+	}
+	t, err := ewkb.Unmarshal([]byte(so.EWKB))
+	if err != nil {
+		return "", err
+	}
+	e := gmlEncoder{
+		version:          version,
+		maxDecimalDigits: maxDecimalDigits,
+		flag:             flag,
+	}
+	if prefix != "" {
+		e.prefix = prefix + ":"
+	}
+	var srsName string
+	if t.SRID() != 0 {
+		projection, err := geoprojbase.Projection(geopb.SRID(t.SRID()))
+		if err != nil {
+			return "", err
+		}
+		if flag&SpatialObjectToGMLFlagLongCRS != 0 {
+			srsName = fmt.Sprintf("urn:ogc:def:crs:%s::%d", projection.AuthName, projection.AuthSRID)
+		} else {
+			srsName = fmt.Sprintf("%s:%d", projection.AuthName, projection.AuthSRID)
+		}
+	}
+	if flag&SpatialObjectToGMLFlagEnvelope != 0 {
+		e.writeEnvelope(t, srsName)
+	} else if err := e.write(t, srsName); err != nil {
+		return "", err
+	}
+	return e.buf.String(), nil
+}
+
+// gmlEncoder writes the GML representation of geometries.
+type gmlEncoder struct {
+	buf              strings.Builder
+	version          int
+	maxDecimalDigits int
+	flag             SpatialObjectToGMLFlag
+	// prefix is the namespace prefix of the elements, including the colon.
+	prefix string
+}
+
+// startElement writes the start tag of the given element. If empty is true,
+// the element is closed immediately.
+func (e *gmlEncoder) startElement(name string, srsName string, empty bool) {
+	e.buf.WriteString("<")
+	e.buf.WriteString(e.prefix)
+	e.buf.WriteString(name)
+	if srsName != "" {
+		fmt.Fprintf(&e.buf, ` srsName="%s"`, srsName)
+	}
+	if empty {
+		e.buf.WriteString("/")
+	}
+	e.buf.WriteString(">")
+}
+
+func (e *gmlEncoder) endElement(name string) {
+	e.buf.WriteString("</")
+	e.buf.WriteString(e.prefix)
+	e.buf.WriteString(name)
+	e.buf.WriteString(">")
+}
+
+// write writes the GML representation of the given geometry. The srsName is
+// only written on the outermost element.
+func (e *gmlEncoder) write(t geom.T, srsName string) error {
+	v3 := e.version == 3
+	switch t := t.(type) {
+	case *geom.Point:
+		e.startElement("Point", srsName, t.Empty())
+		if !t.Empty() {
+			if v3 {
+				e.writeCoords("pos", t.Layout(), t.FlatCoords())
+			} else {
+				e.writeCoords("coordinates", t.Layout(), t.FlatCoords())
+			}
+			e.endElement("Point")
+		}
+	case *geom.LineString:
+		e.writeLineString(t, srsName)
+	case *geom.Polygon:
+		e.writePolygon(t, srsName)
+	case *geom.MultiPoint:
+		e.startElement("MultiPoint", srsName, t.Empty())
+		if !t.Empty() {
+			for i := 0; i < t.NumPoints(); i++ {
+				e.startElement("pointMember", "", false)
+				if err := e.write(t.Point(i), ""); err != nil {
+					return err
+				}
+				e.endElement("pointMember")
+			}
+			e.endElement("MultiPoint")
+		}
+	case *geom.MultiLineString:
+		name, memberName := "MultiLineString", "lineStringMember"
+		if v3 {
+			name, memberName = "MultiCurve", "curveMember"
+		}
+		e.startElement(name, srsName, t.Empty())
+		if !t.Empty() {
+			for i := 0; i < t.NumLineStrings(); i++ {
+				e.startElement(memberName, "", false)
+				e.writeLineString(t.LineString(i), "")
+				e.endElement(memberName)
+			}
+			e.endElement(name)
+		}
+	case *geom.MultiPolygon:
+		name, memberName := "MultiPolygon", "polygonMember"
+		if v3 {
+			name, memberName = "MultiSurface", "surfaceMember"
+		}
+		e.startElement(name, srsName, t.Empty())
+		if !t.Empty() {
+			for i := 0; i < t.NumPolygons(); i++ {
+				e.startElement(memberName, "", false)
+				e.writePolygon(t.Polygon(i), "")
+				e.endElement(memberName)
+			}
+			e.endElement(name)
+		}
+	case *geom.GeometryCollection:
+		e.startElement("MultiGeometry", srsName, t.Empty())
+		if !t.Empty() {
+			for _, g := range t.Geoms() {
+				e.startElement("geometryMember", "", false)
+				if err := e.write(g, ""); err != nil {
+					return err
+				}
+				e.endElement("geometryMember")
+			}
+			e.endElement("MultiGeometry")
+		}
+	default:
+		return pgerror.Newf(pgcode.InvalidParameterValue, "unknown geometry type: %T", t)
+	}
+	return nil
+}
+
+func (e *gmlEncoder) writeLineString(t *geom.LineString, srsName string) {
+	if e.version == 2 || e.flag&SpatialObjectToGMLFlagLineString != 0 {
+		e.startElement("LineString", srsName, t.Empty())
+		if !t.Empty() {
+			if e.version == 2 {
+				e.writeCoords("coordinates", t.Layout(), t.FlatCoords())
+			} else {
+				e.writeCoords("posList", t.Layout(), t.FlatCoords())
+			}
+			e.endElement("LineString")
+		}
+		return
+	}
+	e.startElement("Curve", srsName, t.Empty())
+	if !t.Empty() {
+		e.startElement("segments", "", false)
+		e.startElement("LineStringSegment", "", false)
+		e.writeCoords("posList", t.Layout(), t.FlatCoords())
+		e.endElement("LineStringSegment")
+		e.endElement("segments")
+		e.endElement("Curve")
+	}
+}
+
+func (e *gmlEncoder) writePolygon(t *geom.Polygon, srsName string) {
+	exteriorName, interiorName, coordsName := "outerBoundaryIs", "innerBoundaryIs", "coordinates"
+	if e.version == 3 {
+		exteriorName, interiorName, coordsName = "exterior", "interior", "posList"
+	}
+	e.startElement("Polygon", srsName, t.Empty())
+	if t.Empty() {
+		return
+	}
+	for i := 0; i < t.NumLinearRings(); i++ {
+		name := interiorName
+		if i == 0 {
+			name = exteriorName
+		}
+		e.startElement(name, "", false)
+		e.startElement("LinearRing", "", false)
+		e.writeCoords(coordsName, t.Layout(), t.LinearRing(i).FlatCoords())
+		e.endElement("LinearRing")
+		e.endElement(name)
+	}
+	e.endElement("Polygon")
+}
+
+// writeEnvelope writes the bounding box of the given geometry as a GML 2 Box
+// or a GML 3 Envelope.
+func (e *gmlEncoder) writeEnvelope(t geom.T, srsName string) {
+	name := "Box"
+	if e.version == 3 {
+		name = "Envelope"
+	}
+	if t.Empty() {
+		e.startElement(name, srsName, true /* empty */)
+		return
+	}
+	layout := geom.XY
+	if t.Layout().ZIndex() != -1 {
+		layout = geom.XYZ
+	}
+	b := geom.NewBounds(layout).Extend(t)
+	lower := make([]float64, layout.Stride())
+	upper := make([]float64, layout.Stride())
+	for i := range lower {
+		lower[i], upper[i] = b.Min(i), b.Max(i)
+	}
+	e.startElement(name, srsName, false)
+	if e.version == 3 {
+		e.writeCoords("lowerCorner", layout, lower)
+		e.writeCoords("upperCorner", layout, upper)
+	} else {
+		e.writeCoords("coordinates", layout, append(lower, upper...))
+	}
+	e.endElement(name)
+}
+
+// writeCoords writes an element with the given name containing the given
+// coordinates. M coordinates are not written.
+func (e *gmlEncoder) writeCoords(name string, layout geom.Layout, flatCoords []float64) {
+	dim := 2
+	if layout.ZIndex() != -1 {
+		dim = 3
+	}
+	e.buf.WriteString("<")
+	e.buf.WriteString(e.prefix)
+	e.buf.WriteString(name)
+	if e.version == 3 && name != "lowerCorner" && name != "upperCorner" &&
+		e.flag&SpatialObjectToGMLFlagNoSRSDimension == 0 {
+		fmt.Fprintf(&e.buf, ` srsDimension="%d"`, dim)
+	}
+	e.buf.WriteString(">")
+	// GML 2 separates the ordinates of a coordinate with commas, and GML 3
+	// with spaces.
+	sep := " "
+	if e.version == 2 {
+		sep = ","
+	}
+	stride := layout.Stride()
+	for i := 0; i < len(flatCoords); i += stride {
+		if i > 0 {
+			e.buf.WriteString(" ")
+		}
+		x, y := flatCoords[i], flatCoords[i+1]
+		if e.flag&SpatialObjectToGMLFlagLatLng != 0 {
+			x, y = y, x
+		}
+		e.buf.WriteString(formatFloat(x, e.maxDecimalDigits))
+		e.buf.WriteString(sep)
+		e.buf.WriteString(formatFloat(y, e.maxDecimalDigits))
+		if dim == 3 {
+			e.buf.WriteString(sep)
+			e.buf.WriteString(formatFloat(flatCoords[i+layout.ZIndex()], e.maxDecimalDigits))
+		}
+	}
+	e.endElement(name)
+}
+
+// formatFloat formats the given float with at most maxDecimalDigits
+// decimal digits, omitting trailing zeros. A negative maxDecimalDigits
+// formats the float with full precision.
+func formatFloat(f float64, maxDecimalDigits int) string {
+	if maxDecimalDigits < 0 {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	ret := strconv.FormatFloat(f, 'f', maxDecimalDigits, 64)
+	if strings.IndexByte(ret, '.') != -1 {
+		ret = strings.TrimRight(strings.TrimRight(ret, "0"), ".")
+	}
+	if ret == "-0" {
+		ret = "0"
+	}
+	return ret
+}
+
+// ParseGeometryFromGML parses the GML into a Geometry. The SRID is taken from
+// the srsName attribute of the outermost element, if any.
+func ParseGeometryFromGML(gml string) (Geometry, error) {
+	return ParseGeometryFromGMLAndSRID(gml, 0)
+}
+
+// ParseGeometryFromGMLAndSRID parses the GML into a Geometry with the given
+// SRID, which overrides the srsName attribute of the GML if non-zero.
+func ParseGeometryFromGMLAndSRID(gml string, srid geopb.SRID) (Geometry, error) {
+	t, err := parseGML(gml, srid)
+	if err != nil {
+		return Geometry{}, err
+	}
+	return MakeGeometryFromGeomT(t)
+}
+
+// gmlNode is an element of a GML document.
+type gmlNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Content  string     `xml:",chardata"`
+	Children []gmlNode  `xml:",any"`
+}
+
+// attr returns the value of the attribute with the given local name.
+func (n *gmlNode) attr(name string) (string, bool) {
+	for _, a := range n.Attrs {
+		if a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+func newInvalidGMLError(format string, args ...interface{}) error {
+	return pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: "+format, args...)
+}
+
+// parseGML parses the given GML into a geom.T.
+func parseGML(gml string, srid geopb.SRID) (geom.T, error) {
+	var root gmlNode
+	if err := xml.Unmarshal([]byte(gml), &root); err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue, "error parsing GML")
+	}
+	var d gmlDecoder
+	if srsName, ok := root.attr("srsName"); ok {
+		var err error
+		if d.srid, d.latLng, err = parseGMLSRSName(srsName); err != nil {
+			return nil, err
+		}
+	}
+	if srid != 0 {
+		d.srid = srid
+	}
+	t, err := d.parseGeometry(&root, 0 /* dim */)
+	if err != nil {
+		return nil, err
+	}
+	AdjustGeomTSRID(t, d.srid)
+	return t, nil
+}
+
+// parseGMLSRSName returns the SRID of the given srsName, and whether the
+// coordinates it refers to are in lat/lng order.
+func parseGMLSRSName(srsName string) (geopb.SRID, bool, error) {
+	idx := strings.LastIndexAny(srsName, ":#")
+	if idx == -1 || !strings.Contains(strings.ToUpper(srsName[:idx]), "EPSG") {
+		return 0, false, newInvalidGMLError("unknown spatial reference system %q", srsName)
+	}
+	srid, err := strconv.Atoi(srsName[idx+1:])
+	if err != nil {
+		return 0, false, newInvalidGMLError("unknown spatial reference system %q", srsName)
+	}
+	// Only the URN form of the srsName uses the axis order of the authority,
+	// which is lat/lng for geographic coordinate systems.
+	if !strings.HasPrefix(strings.ToLower(srsName), "urn:") {
+		return geopb.SRID(srid), false, nil
+	}
+	projection, err := geoprojbase.Projection(geopb.SRID(srid))
+	if err != nil {
+		return 0, false, err
+	}
+	return geopb.SRID(srid), projection.IsLatLng, nil
+}
+
+// gmlDecoder converts GML elements into geometries.
+type gmlDecoder struct {
+	srid geopb.SRID
+	// latLng is whether the coordinates are in lat/lng order.
+	latLng bool
+}
+
+// gmlDim returns the srsDimension of the given element, or dim if it does not
+// have one.
+func gmlDim(n *gmlNode, dim int) (int, error) {
+	s, ok := n.attr("srsDimension")
+	if !ok {
+		return dim, nil
+	}
+	ret, err := strconv.Atoi(s)
+	if err != nil || ret < 2 || ret > 3 {
+		return 0, newInvalidGMLError("invalid srsDimension %q", s)
+	}
+	return ret, nil
+}
+
+// parseGeometry parses the given geometry element. dim is the srsDimension
+// inherited from the enclosing elements, or 0 if unknown.
+func (d *gmlDecoder) parseGeometry(n *gmlNode, dim int) (geom.T, error) {
+	dim, err := gmlDim(n, dim)
+	if err != nil {
+		return nil, err
+	}
+	switch n.XMLName.Local {
+	case "Point":
+		layout, flatCoords, err := d.parseCoords(n, dim)
+		if err != nil {
+			return nil, err
+		}
+		if len(flatCoords) == 0 {
+			return geom.NewPointEmpty(geom.XY), nil
+		}
+		if len(flatCoords) != layout.Stride() {
+			return nil, newInvalidGMLError("Point must have exactly one coordinate")
+		}
+		return geom.NewPointFlat(layout, flatCoords), nil
+	case "LineString", "Curve":
+		return d.parseLineString(n, dim)
+	case "Polygon":
+		return d.parsePolygon(n, dim)
+	case "MultiPoint":
+		mp := geom.NewMultiPoint(geom.XY)
+		members, err := d.parseMembers(n, dim, "pointMember", "pointMembers")
+		if err != nil {
+			return nil, err
+		}
+		for i, m := range members {
+			p, ok := m.(*geom.Point)
+			if !ok {
+				return nil, newInvalidGMLError("MultiPoint members must be Points")
+			}
+			if i == 0 {
+				mp = geom.NewMultiPoint(p.Layout())
+			}
+			if err := mp.Push(p); err != nil {
+				return nil, newInvalidGMLError("%v", err)
+			}
+		}
+		return mp, nil
+	case "MultiLineString", "MultiCurve":
+		mls := geom.NewMultiLineString(geom.XY)
+		members, err := d.parseMembers(n, dim, "lineStringMember", "curveMember", "curveMembers")
+		if err != nil {
+			return nil, err
+		}
+		for i, m := range members {
+			ls, ok := m.(*geom.LineString)
+			if !ok {
+				return nil, newInvalidGMLError("%s members must be LineStrings", n.XMLName.Local)
+			}
+			if i == 0 {
+				mls = geom.NewMultiLineString(ls.Layout())
+			}
+			if err := mls.Push(ls); err != nil {
+				return nil, newInvalidGMLError("%v", err)
+			}
+		}
+		return mls, nil
+	case "MultiPolygon", "MultiSurface":
+		mp := geom.NewMultiPolygon(geom.XY)
+		members, err := d.parseMembers(n, dim, "polygonMember", "surfaceMember", "surfaceMembers")
+		if err != nil {
+			return nil, err
+		}
+		for i, m := range members {
+			p, ok := m.(*geom.Polygon)
+			if !ok {
+				return nil, newInvalidGMLError("%s members must be Polygons", n.XMLName.Local)
+			}
+			if i == 0 {
+				mp = geom.NewMultiPolygon(p.Layout())
+			}
+			if err := mp.Push(p); err != nil {
+				return nil, newInvalidGMLError("%v", err)
+			}
+		}
+		return mp, nil
+	case "MultiGeometry":
+		members, err := d.parseMembers(n, dim, "geometryMember", "geometryMembers")
+		if err != nil {
+			return nil, err
+		}
+		gc := geom.NewGeometryCollection()
+		if err := gc.Push(members...); err != nil {
+			return nil, newInvalidGMLError("%v", err)
+		}
+		return gc, nil
+	default:
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unsupported GML type: %s", n.XMLName.Local)
+	}
+}
+
+// parseMembers parses the geometries contained in the member elements with the
+// given names of a multi-geometry element.
+func (d *gmlDecoder) parseMembers(n *gmlNode, dim int, memberNames ...string) ([]geom.T, error) {
+	var ret []geom.T
+	for i := range n.Children {
+		c := &n.Children[i]
+		isMember := false
+		for _, name := range memberNames {
+			isMember = isMember || c.XMLName.Local == name
+		}
+		if !isMember {
+			continue
+		}
+		for j := range c.Children {
+			t, err := d.parseGeometry(&c.Children[j], dim)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, t)
+		}
+	}
+	return ret, nil
+}
+
+// parseLineString parses a LineString or a Curve made of LineStringSegments.
+func (d *gmlDecoder) parseLineString(n *gmlNode, dim int) (*geom.LineString, error) {
+	var layout geom.Layout
+	var flatCoords []float64
+	if n.XMLName.Local == "LineString" {
+		var err error
+		if layout, flatCoords, err = d.parseCoords(n, dim); err != nil {
+			return nil, err
+		}
+	} else {
+		for i := range n.Children {
+			if n.Children[i].XMLName.Local != "segments" {
+				continue
+			}
+			for j := range n.Children[i].Children {
+				seg := &n.Children[i].Children[j]
+				if seg.XMLName.Local != "LineStringSegment" {
+					return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unsupported GML curve segment: %s", seg.XMLName.Local)
+				}
+				segDim, err := gmlDim(seg, dim)
+				if err != nil {
+					return nil, err
+				}
+				segLayout, segCoords, err := d.parseCoords(seg, segDim)
+				if err != nil {
+					return nil, err
+				}
+				if len(flatCoords) == 0 {
+					layout = segLayout
+				} else if segLayout != layout {
+					return nil, newInvalidGMLError("mixed dimensionality")
+				} else if len(segCoords) >= layout.Stride() &&
+					coordsEqual(segCoords[:layout.Stride()], flatCoords[len(flatCoords)-layout.Stride():]) {
+					// Consecutive segments share their end points.
+					segCoords = segCoords[layout.Stride():]
+				}
+				flatCoords = append(flatCoords, segCoords...)
+			}
+		}
+	}
+	if len(flatCoords) == 0 {
+		return geom.NewLineString(geom.XY), nil
+	}
+	if len(flatCoords) < 2*layout.Stride() {
+		return nil, newInvalidGMLError("LineString must have at least 2 coordinates")
+	}
+	return geom.NewLineStringFlat(layout, flatCoords), nil
+}
+
+func coordsEqual(a, b []float64) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// parsePolygon parses a Polygon with its exterior and interior rings.
+func (d *gmlDecoder) parsePolygon(n *gmlNode, dim int) (*geom.Polygon, error) {
+	var layout geom.Layout
+	var flatCoords []float64
+	var ends []int
+	for i := range n.Children {
+		c := &n.Children[i]
+		switch c.XMLName.Local {
+		case "exterior", "outerBoundaryIs":
+			if len(ends) > 0 {
+				return nil, newInvalidGMLError("Polygon must have exactly one exterior ring")
+			}
+		case "interior", "innerBoundaryIs":
+			if len(ends) == 0 {
+				return nil, newInvalidGMLError("Polygon interior ring must follow its exterior ring")
+			}
+		default:
+			continue
+		}
+		for j := range c.Children {
+			ring := &c.Children[j]
+			if ring.XMLName.Local != "LinearRing" {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unsupported GML ring: %s", ring.XMLName.Local)
+			}
+			ringDim, err := gmlDim(ring, dim)
+			if err != nil {
+				return nil, err
+			}
+			ringLayout, ringCoords, err := d.parseCoords(ring, ringDim)
+			if err != nil {
+				return nil, err
+			}
+			stride := ringLayout.Stride()
+			if len(ringCoords) < 4*stride ||
+				!coordsEqual(ringCoords[:stride], ringCoords[len(ringCoords)-stride:]) {
+				return nil, newInvalidGMLError("LinearRing must be closed and have at least 4 coordinates")
+			}
+			if len(ends) == 0 {
+				layout = ringLayout
+			} else if ringLayout != layout {
+				return nil, newInvalidGMLError("mixed dimensionality")
+			}
+			flatCoords = append(flatCoords, ringCoords...)
+			ends = append(ends, len(flatCoords))
+		}
+	}
+	if len(ends) == 0 {
+		return geom.NewPolygon(geom.XY), nil
+	}
+	return geom.NewPolygonFlat(layout, flatCoords, ends), nil
+}
+
+// parseCoords parses the coordinates of the given element, which may be
+// specified by any number of pos, posList, coordinates and coord elements.
+func (d *gmlDecoder) parseCoords(n *gmlNode, dim int) (geom.Layout, []float64, error) {
+	stride := 0
+	var flatCoords []float64
+	appendCoord := func(coord []float64) error {
+		if len(coord) < 2 || len(coord) > 3 {
+			return newInvalidGMLError("coordinates must have 2 or 3 dimensions")
+		}
+		if stride == 0 {
+			stride = len(coord)
+		} else if stride != len(coord) {
+			return newInvalidGMLError("mixed dimensionality")
+		}
+		if d.latLng {
+			coord[0], coord[1] = coord[1], coord[0]
+		}
+		flatCoords = append(flatCoords, coord...)
+		return nil
+	}
+	for i := range n.Children {
+		c := &n.Children[i]
+		switch c.XMLName.Local {
+		case "pos", "posList":
+			cDim, err := gmlDim(c, dim)
+			if err != nil {
+				return 0, nil, err
+			}
+			fields := strings.Fields(c.Content)
+			if cDim == 0 {
+				cDim = 2
+				if c.XMLName.Local == "pos" {
+					cDim = len(fields)
+				}
+			}
+			if len(fields)%cDim != 0 {
+				return 0, nil, newInvalidGMLError("invalid number of ordinates in %s", c.XMLName.Local)
+			}
+			for j := 0; j < len(fields); j += cDim {
+				coord, err := parseGMLOrdinates(fields[j : j+cDim])
+				if err != nil {
+					return 0, nil, err
+				}
+				if err := appendCoord(coord); err != nil {
+					return 0, nil, err
+				}
+			}
+		case "coordinates":
+			cs, ok := c.attr("cs")
+			if !ok {
+				cs = ","
+			}
+			ts, ok := c.attr("ts")
+			var tuples []string
+			if !ok || strings.TrimSpace(ts) == "" {
+				tuples = strings.Fields(c.Content)
+			} else {
+				tuples = strings.Split(strings.TrimSpace(c.Content), ts)
+			}
+			decimal, ok := c.attr("decimal")
+			for _, tuple := range tuples {
+				fields := strings.Split(strings.TrimSpace(tuple), cs)
+				if ok && decimal != "." {
+					for k := range fields {
+						fields[k] = strings.ReplaceAll(fields[k], decimal, ".")
+					}
+				}
+				coord, err := parseGMLOrdinates(fields)
+				if err != nil {
+					return 0, nil, err
+				}
+				if err := appendCoord(coord); err != nil {
+					return 0, nil, err
+				}
+			}
+		case "coord":
+			var fields []string
+			for _, name := range []string{"X", "Y", "Z"} {
+				for k := range c.Children {
+					if c.Children[k].XMLName.Local == name {
+						fields = append(fields, strings.TrimSpace(c.Children[k].Content))
+					}
+				}
+			}
+			coord, err := parseGMLOrdinates(fields)
+			if err != nil {
+				return 0, nil, err
+			}
+			if err := appendCoord(coord); err != nil {
+				return 0, nil, err
+			}
+		}
+	}
+	layout := geom.XY
+	if stride == 3 {
+		layout = geom.XYZ
+	}
+	return layout, flatCoords, nil
+}
+
+func parseGMLOrdinates(fields []string) ([]float64, error) {
+	ret := make([]float64, len(fields))
+	for i, f := range fields {
+		var err error
+		ret[i], err = strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil {
+			return nil, newInvalidGMLError("invalid coordinate %q", f)
+		}
+		if math.IsNaN(ret[i]) || math.IsInf(ret[i], 0) {
+			return nil, newInvalidGMLError("coordinates must be finite")
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpatialObjectToGML(t *testing.T) {
+	testCases := []struct {
+		ewkt             geopb.EWKT
+		version          int
+		maxDecimalDigits int
+		flag             SpatialObjectToGMLFlag
+		prefix           string
+		expected         string
+	}{
+		{
+			"SRID=4326;POLYGON((0 0,0 1,1 1,1 0,0 0))", 2, 15, SpatialObjectToGMLFlagZero, "gml",
+			`<gml:Polygon srsName="EPSG:4326"><gml:outerBoundaryIs><gml:LinearRing><gml:coordinates>0,0 0,1 1,1 1,0 0,0</gml:coordinates></gml:LinearRing></gml:outerBoundaryIs></gml:Polygon>`,
+		},
+		{
+			"SRID=4326;POINT(5.234234233242 6.34534534534)", 3, 5, SpatialObjectToGMLFlagLongCRS | SpatialObjectToGMLFlagLatLng, "gml",
+			`<gml:Point srsName="urn:ogc:def:crs:EPSG::4326"><gml:pos srsDimension="2">6.34535 5.23423</gml:pos></gml:Point>`,
+		},
+		{
+			"SRID=4326;LINESTRING(1 2, 3 4, 10 20)", 3, 5, SpatialObjectToGMLFlagEnvelope, "gml",
+			`<gml:Envelope srsName="EPSG:4326"><gml:lowerCorner>1 2</gml:lowerCorner><gml:upperCorner>10 20</gml:upperCorner></gml:Envelope>`,
+		},
+		{
+			"LINESTRING(1 2, 3 4, 10 20)", 2, 15, SpatialObjectToGMLFlagEnvelope, "gml",
+			`<gml:Box><gml:coordinates>1,2 10,20</gml:coordinates></gml:Box>`,
+		},
+		{
+			"LINESTRING Z (1 2 3, 4 5 6)", 3, 15, SpatialObjectToGMLFlagZero, "gml",
+			`<gml:Curve><gml:segments><gml:LineStringSegment><gml:posList srsDimension="3">1 2 3 4 5 6</gml:posList></gml:LineStringSegment></gml:segments></gml:Curve>`,
+		},
+		{
+			"LINESTRING(1 2, 3 4)", 3, 15, SpatialObjectToGMLFlagLineString | SpatialObjectToGMLFlagNoSRSDimension, "",
+			`<LineString><posList>1 2 3 4</posList></LineString>`,
+		},
+		{
+			"MULTIPOINT(1 2, 3 4)", 2, 15, SpatialObjectToGMLFlagZero, "gml",
+			`<gml:MultiPoint><gml:pointMember><gml:Point><gml:coordinates>1,2</gml:coordinates></gml:Point></gml:pointMember><gml:pointMember><gml:Point><gml:coordinates>3,4</gml:coordinates></gml:Point></gml:pointMember></gml:MultiPoint>`,
+		},
+		{
+			"MULTIPOLYGON(((0 0,0 1,1 1,0 0)))", 3, 15, SpatialObjectToGMLFlagZero, "gml",
+			`<gml:MultiSurface><gml:surfaceMember><gml:Polygon><gml:exterior><gml:LinearRing><gml:posList srsDimension="2">0 0 0 1 1 1 0 0</gml:posList></gml:LinearRing></gml:exterior></gml:Polygon></gml:surfaceMember></gml:MultiSurface>`,
+		},
+		{
+			"GEOMETRYCOLLECTION(POINT(1 2), LINESTRING EMPTY)", 2, 15, SpatialObjectToGMLFlagZero, "gml",
+			`<gml:MultiGeometry><gml:geometryMember><gml:Point><gml:coordinates>1,2</gml:coordinates></gml:Point></gml:geometryMember><gml:geometryMember><gml:LineString/></gml:geometryMember></gml:MultiGeometry>`,
+		},
+		{
+			"POINT EMPTY", 3, 15, SpatialObjectToGMLFlagZero, "gml",
+			`<gml:Point/>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s/%d/%d", tc.ewkt, tc.version, tc.flag), func(t *testing.T) {
+			so, err := parseEWKT(geopb.SpatialObjectType_GeometryType, tc.ewkt, geopb.DefaultGeometrySRID, DefaultSRIDIsHint)
+			require.NoError(t, err)
+			encoded, err := SpatialObjectToGML(so, tc.version, tc.maxDecimalDigits, tc.flag, tc.prefix)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, encoded)
+		})
+	}
+
+	t.Run("invalid version", func(t *testing.T) {
+		_, err := SpatialObjectToGML(MustParseGeometry("POINT(1 2)").SpatialObject(), 4, 15, 0, "gml")
+		require.EqualError(t, err, "only GML 2 and GML 3 are supported")
+	})
+}
+
+func TestParseGeometryFromGML(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		gml      string
+		srid     geopb.SRID
+		expected string
+	}{
+		{
+			"GML 2 point",
+			`<gml:Point srsName="EPSG:4326"><gml:coordinates>1,2</gml:coordinates></gml:Point>`,
+			0,
+			"SRID=4326;POINT(1 2)",
+		},
+		{
+			"GML 3 point with lat/lng order",
+			`<gml:Point srsName="urn:ogc:def:crs:EPSG::4326"><gml:pos>2 1</gml:pos></gml:Point>`,
+			0,
+			"SRID=4326;POINT(1 2)",
+		},
+		{
+			"SRID overrides srsName",
+			`<gml:Point srsName="EPSG:4326"><gml:pos>1 2</gml:pos></gml:Point>`,
+			3857,
+			"SRID=3857;POINT(1 2)",
+		},
+		{
+			"coord elements",
+			`<gml:LineString><gml:coord><gml:X>1</gml:X><gml:Y>2</gml:Y></gml:coord><gml:coord><gml:X>3</gml:X><gml:Y>4</gml:Y></gml:coord></gml:LineString>`,
+			0,
+			"LINESTRING(1 2, 3 4)",
+		},
+		{
+			"coordinates with custom separators",
+			`<gml:LineString><gml:coordinates cs=";" ts="|" decimal=",">1,5;2|3;4,5</gml:coordinates></gml:LineString>`,
+			0,
+			"LINESTRING(1.5 2, 3 4.5)",
+		},
+		{
+			"3D posList",
+			`<gml:LineString><gml:posList srsDimension="3">1 2 3 4 5 6</gml:posList></gml:LineString>`,
+			0,
+			"LINESTRING Z (1 2 3, 4 5 6)",
+		},
+		{
+			"curve with multiple segments",
+			`<gml:Curve><gml:segments><gml:LineStringSegment><gml:posList>0 0 1 1</gml:posList></gml:LineStringSegment><gml:LineStringSegment><gml:posList>1 1 2 0</gml:posList></gml:LineStringSegment></gml:segments></gml:Curve>`,
+			0,
+			"LINESTRING(0 0, 1 1, 2 0)",
+		},
+		{
+			"polygon with hole",
+			`<Polygon xmlns="http://www.opengis.net/gml"><exterior><LinearRing><posList>0 0 10 0 10 10 0 0</posList></LinearRing></exterior><interior><LinearRing><posList>1 1 2 1 2 2 1 1</posList></LinearRing></interior></Polygon>`,
+			0,
+			"POLYGON((0 0, 10 0, 10 10, 0 0), (1 1, 2 1, 2 2, 1 1))",
+		},
+		{
+			"multi curve",
+			`<gml:MultiCurve><gml:curveMember><gml:LineString><gml:posList>0 0 1 1</gml:posList></gml:LineString></gml:curveMember><gml:curveMember><gml:LineString><gml:posList>2 2 3 3</gml:posList></gml:LineString></gml:curveMember></gml:MultiCurve>`,
+			0,
+			"MULTILINESTRING((0 0, 1 1), (2 2, 3 3))",
+		},
+		{
+			"multi geometry",
+			`<gml:MultiGeometry><gml:geometryMember><gml:Point><gml:pos>1 2</gml:pos></gml:Point></gml:geometryMember><gml:geometryMember><gml:MultiPoint/></gml:geometryMember></gml:MultiGeometry>`,
+			0,
+			"GEOMETRYCOLLECTION(POINT(1 2), MULTIPOINT EMPTY)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := ParseGeometryFromGMLAndSRID(tc.gml, tc.srid)
+			require.NoError(t, err)
+			require.Equal(t, MustParseGeometry(tc.expected).EWKB(), g.EWKB())
+		})
+	}
+
+	errorTestCases := []struct {
+		gml           string
+		expectedError string
+	}{
+		{`<gml:Point><gml:pos>1 2`, "error parsing GML: XML syntax error on line 1: unexpected EOF"},
+		{`<gml:Triangle/>`, "unsupported GML type: Triangle"},
+		{`<gml:Point srsName="foo"/>`, `invalid GML representation: unknown spatial reference system "foo"`},
+		{`<gml:Point><gml:pos>1 2 3 4</gml:pos></gml:Point>`, "invalid GML representation: coordinates must have 2 or 3 dimensions"},
+		{`<gml:Point><gml:pos>1 a</gml:pos></gml:Point>`, `invalid GML representation: invalid coordinate "a"`},
+		{`<gml:LineString><gml:posList>1 2</gml:posList></gml:LineString>`, "invalid GML representation: LineString must have at least 2 coordinates"},
+		{
+			`<gml:Polygon><gml:exterior><gml:LinearRing><gml:posList>0 0 1 1 1 0 0 1</gml:posList></gml:LinearRing></gml:exterior></gml:Polygon>`,
+			"invalid GML representation: LinearRing must be closed and have at least 4 coordinates",
+		},
+	}
+	for _, tc := range errorTestCases {
+		t.Run(tc.gml, func(t *testing.T) {
+			_, err := ParseGeometryFromGML(tc.gml)
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestGMLRoundTrip(t *testing.T) {
+	for _, ewkt := range []string{
+		"SRID=4326;POINT(1.5 -2.25)",
+		"POINT Z (1 2 3)",
+		"SRID=3857;LINESTRING(0 0, 1 1, 2 0)",
+		"SRID=4326;POLYGON((0 0, 10 0, 10 10, 0 0), (1 1, 2 1, 2 2, 1 1))",
+		"MULTIPOINT(1 2, 3 4)",
+		"MULTILINESTRING((0 0, 1 1), (2 2, 3 3))",
+		"SRID=4326;MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0)), ((5 5, 6 5, 6 6, 5 5)))",
+		"GEOMETRYCOLLECTION(POINT(1 2), LINESTRING(3 4, 5 6))",
+		"GEOMETRYCOLLECTION EMPTY",
+	} {
+		g := MustParseGeometry(ewkt)
+		for _, version := range []int{2, 3} {
+			for _, flag := range []SpatialObjectToGMLFlag{
+				SpatialObjectToGMLFlagZero,
+				SpatialObjectToGMLFlagLongCRS | SpatialObjectToGMLFlagLatLng,
+				SpatialObjectToGMLFlagLineString | SpatialObjectToGMLFlagNoSRSDimension,
+			} {
+				if flag&SpatialObjectToGMLFlagLatLng != 0 && g.SRID() != 4326 {
+					// Only the URN form of lat/lng coordinate systems implies the
+					// lat/lng order.
+					continue
+				}
+				t.Run(fmt.Sprintf("%s/%d/%d", ewkt, version, flag), func(t *testing.T) {
+					gml, err := SpatialObjectToGML(g.SpatialObject(), version, FullPrecisionGeoJSON, flag, DefaultGMLPrefix)
+					require.NoError(t, err)
+					ret, err := ParseGeometryFromGML(gml)
+					require.NoError(t, err)
+					require.Equal(t, g.EWKB(), ret.EWKB(), gml)
+
+					// GML must agree with GeoJSON, which is always in SRID 4326.
+					if g.SRID() == 4326 {
+						geoJSON, err := SpatialObjectToGeoJSON(g.SpatialObject(), FullPrecisionGeoJSON, SpatialObjectToGeoJSONFlagZero)
+						require.NoError(t, err)
+						fromGeoJSON, err := ParseGeometryFromGeoJSON(geoJSON)
+						require.NoError(t, err)
+						require.Equal(t, fromGeoJSON.EWKB(), ret.EWKB())
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geo
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/ewkb"
+)
+
+// SpatialObjectToSVG transforms a given SpatialObject to SVG path data, as
+// done by ST_AsSVG in PostGIS. Points are written as the attributes of a
+// circle (cx and cy) or, if relative is true, of a use element (x and y).
+// Since the y axis of SVG points down, y coordinates are negated. If relative
+// is true, paths are written using relative moves.
+func SpatialObjectToSVG(
+	so geopb.SpatialObject, relative bool, maxDecimalDigits int,
+) (string, error) {
+	t, err := ewkb.Unmarshal([]byte(so.EWKB))
+	if err != nil {
+		return "", err
+	}
+	e := svgEncoder{relative: relative, maxDecimalDigits: maxDecimalDigits}
+	if err := e.write(t); err != nil {
+		return "", err
+	}
+	return e.buf.String(), nil
+}
+
+// svgEncoder writes the SVG representation of geometries.
+type svgEncoder struct {
+	buf              strings.Builder
+	relative         bool
+	maxDecimalDigits int
+}
+
+func (e *svgEncoder) write(t geom.T) error {
+	switch t := t.(type) {
+	case *geom.Point:
+		e.writePoint(t)
+	case *geom.LineString:
+		e.writePath(t.Layout(), t.FlatCoords(), false /* closed */)
+	case *geom.Polygon:
+		e.writePolygon(t)
+	case *geom.MultiPoint:
+		for i := 0; i < t.NumPoints(); i++ {
+			if i > 0 {
+				e.buf.WriteString(",")
+			}
+			e.writePoint(t.Point(i))
+		}
+	case *geom.MultiLineString:
+		for i := 0; i < t.NumLineStrings(); i++ {
+			if i > 0 {
+				e.buf.WriteString(" ")
+			}
+			ls := t.LineString(i)
+			e.writePath(ls.Layout(), ls.FlatCoords(), false /* closed */)
+		}
+	case *geom.MultiPolygon:
+		for i := 0; i < t.NumPolygons(); i++ {
+			if i > 0 {
+				e.buf.WriteString(" ")
+			}
+			e.writePolygon(t.Polygon(i))
+		}
+	case *geom.GeometryCollection:
+		for i, g := range t.Geoms() {
+			if i > 0 {
+				e.buf.WriteString(";")
+			}
+			if err := e.write(g); err != nil {
+				return err
+			}
+		}
+	default:
+		return pgerror.Newf(pgcode.InvalidParameterValue, "unknown geometry type: %T", t)
+	}
+	return nil
+}
+
+func (e *svgEncoder) writePoint(t *geom.Point) {
+	if t.Empty() {
+		return
+	}
+	xAttr, yAttr := `cx="`, `" cy="`
+	if e.relative {
+		xAttr, yAttr = `x="`, `" y="`
+	}
+	e.buf.WriteString(xAttr)
+	e.buf.WriteString(formatFloat(t.X(), e.maxDecimalDigits))
+	e.buf.WriteString(yAttr)
+	e.buf.WriteString(formatFloat(-t.Y(), e.maxDecimalDigits))
+	e.buf.WriteString(`"`)
+}
+
+func (e *svgEncoder) writePolygon(t *geom.Polygon) {
+	for i := 0; i < t.NumLinearRings(); i++ {
+		if i > 0 {
+			e.buf.WriteString(" ")
+		}
+		ring := t.LinearRing(i)
+		e.writePath(ring.Layout(), ring.FlatCoords(), true /* closed */)
+	}
+}
+
+// writePath writes the path through the given coordinates. If closed is true,
+// the last coordinate, which is the same as the first one, is replaced by a
+// command closing the path.
+func (e *svgEncoder) writePath(layout geom.Layout, flatCoords []float64, closed bool) {
+	stride := layout.Stride()
+	n := len(flatCoords) / stride
+	if n == 0 {
+		return
+	}
+	if closed {
+		n--
+	}
+	e.buf.WriteString("M ")
+	e.writeCoord(flatCoords[0], flatCoords[1])
+	if n > 1 {
+		if e.relative {
+			e.buf.WriteString(" l")
+		} else {
+			e.buf.WriteString(" L")
+		}
+		for i := 1; i < n; i++ {
+			x, y := flatCoords[i*stride], flatCoords[i*stride+1]
+			if e.relative {
+				x -= flatCoords[(i-1)*stride]
+				y -= flatCoords[(i-1)*stride+1]
+			}
+			e.buf.WriteString(" ")
+			e.writeCoord(x, y)
+		}
+	}
+	if closed {
+		if e.relative {
+			e.buf.WriteString(" z")
+		} else {
+			e.buf.WriteString(" Z")
+		}
+	}
+}
+
+func (e *svgEncoder) writeCoord(x, y float64) {
+	e.buf.WriteString(formatFloat(x, e.maxDecimalDigits))
+	e.buf.WriteString(" ")
+	e.buf.WriteString(formatFloat(-y, e.maxDecimalDigits))
+}
@@ -357,3 +357,305 @@ func zigzagInt8(x int8) byte {
 	}
 	return uint8(x) << 1
 }
+
+// Unmarshal converts a TWKB encoded byte array into a geom.T. The bounding
+// box, size and id list of the TWKB are ignored if present.
+func Unmarshal(b []byte) (geom.T, error) {
+	r := bytes.NewReader(b)
+	t, err := unmarshal(r)
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() > 0 {
+		return nil, newInvalidTWKBError("unexpected trailing bytes")
+	}
+	return t, nil
+}
+
+func newInvalidTWKBError(format string, args ...interface{}) error {
+	return pgerror.Newf(pgcode.InvalidParameterValue, "invalid TWKB: "+format, args...)
+}
+
+type unmarshaller struct {
+	r      *bytes.Reader
+	layout geom.Layout
+	// scales are the factors by which each ordinate is divided to undo the
+	// precision with which it is written.
+	scales []float64
+	// prevCoords keeps track of the previously read coordinates, as the
+	// coordinates are written as deltas from them.
+	prevCoords []int64
+}
+
+func unmarshal(r *bytes.Reader) (geom.T, error) {
+	typeAndPrecision, err := r.ReadByte()
+	if err != nil {
+		return nil, newInvalidTWKBError("missing type and precision header")
+	}
+	metadata, err := r.ReadByte()
+	if err != nil {
+		return nil, newInvalidTWKBError("missing metadata header")
+	}
+	typ := twkbType(typeAndPrecision & 0x0F)
+	precisionXY := unzigzagInt8(typeAndPrecision >> 4)
+	hasBBox := metadata&0b1 != 0
+	hasSize := metadata&0b10 != 0
+	hasIDList := metadata&0b100 != 0
+	hasExtendedDimensions := metadata&0b1000 != 0
+	isEmpty := metadata&0b10000 != 0
+
+	u := unmarshaller{r: r, layout: geom.XY}
+	var precisionZ, precisionM int8
+	if hasExtendedDimensions {
+		extDimByte, err := r.ReadByte()
+		if err != nil {
+			return nil, newInvalidTWKBError("missing extended dimensions header")
+		}
+		hasZ, hasM := extDimByte&0b1 != 0, extDimByte&0b10 != 0
+		precisionZ = int8((extDimByte >> 2) & 0b111)
+		precisionM = int8((extDimByte >> 5) & 0b111)
+		switch {
+		case hasZ && hasM:
+			u.layout = geom.XYZM
+		case hasZ:
+			u.layout = geom.XYZ
+		case hasM:
+			u.layout = geom.XYM
+		}
+	}
+	stride := u.layout.Stride()
+	u.scales = make([]float64, stride)
+	u.prevCoords = make([]int64, stride)
+	for i := range u.scales {
+		precision := precisionXY
+		if i == u.layout.ZIndex() {
+			precision = precisionZ
+		}
+		if i == u.layout.MIndex() {
+			precision = precisionM
+		}
+		u.scales[i] = math.Pow(10, float64(precision))
+	}
+
+	if hasSize {
+		if _, err := u.readUvarint(); err != nil {
+			return nil, err
+		}
+	}
+	if hasBBox && !isEmpty {
+		for i := 0; i < 2*stride; i++ {
+			if _, err := u.readVarint(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	switch typ {
+	case twkbTypePoint:
+		if isEmpty {
+			return geom.NewPointEmpty(u.layout), nil
+		}
+		flatCoords, err := u.readFlatCoords(1)
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewPointFlat(u.layout, flatCoords), nil
+	case twkbTypeLineString:
+		if isEmpty {
+			return geom.NewLineString(u.layout), nil
+		}
+		flatCoords, err := u.readPointArray()
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewLineStringFlat(u.layout, flatCoords), nil
+	case twkbTypePolygon:
+		if isEmpty {
+			return geom.NewPolygon(u.layout), nil
+		}
+		flatCoords, ends, err := u.readGeomWithEnds(nil /* flatCoords */)
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewPolygonFlat(u.layout, flatCoords, ends), nil
+	case twkbTypeMultiPoint:
+		if isEmpty {
+			return geom.NewMultiPoint(u.layout), nil
+		}
+		n, err := u.readCount(hasIDList)
+		if err != nil {
+			return nil, err
+		}
+		flatCoords, err := u.readFlatCoords(n)
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewMultiPointFlat(u.layout, flatCoords), nil
+	case twkbTypeMultiLineString:
+		if isEmpty {
+			return geom.NewMultiLineString(u.layout), nil
+		}
+		n, err := u.readCount(hasIDList)
+		if err != nil {
+			return nil, err
+		}
+		var flatCoords []float64
+		ends := make([]int, 0, n)
+		for i := 0; i < n; i++ {
+			lineCoords, err := u.readPointArray()
+			if err != nil {
+				return nil, err
+			}
+			flatCoords = append(flatCoords, lineCoords...)
+			ends = append(ends, len(flatCoords))
+		}
+		return geom.NewMultiLineStringFlat(u.layout, flatCoords, ends), nil
+	case twkbTypeMultiPolygon:
+		if isEmpty {
+			return geom.NewMultiPolygon(u.layout), nil
+		}
+		n, err := u.readCount(hasIDList)
+		if err != nil {
+			return nil, err
+		}
+		var flatCoords []float64
+		endss := make([][]int, 0, n)
+		for i := 0; i < n; i++ {
+			var ends []int
+			if flatCoords, ends, err = u.readGeomWithEnds(flatCoords); err != nil {
+				return nil, err
+			}
+			endss = append(endss, ends)
+		}
+		return geom.NewMultiPolygonFlat(u.layout, flatCoords, endss), nil
+	case twkbTypeGeometryCollection:
+		gc := geom.NewGeometryCollection()
+		if isEmpty {
+			return gc, nil
+		}
+		n, err := u.readCount(hasIDList)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < n; i++ {
+			t, err := unmarshal(r)
+			if err != nil {
+				return nil, err
+			}
+			if err := gc.Push(t); err != nil {
+				return nil, err
+			}
+		}
+		return gc, nil
+	default:
+		return nil, newInvalidTWKBError("unknown type %d", typ)
+	}
+}
+
+// readCount reads the number of elements of a multi-geometry, followed by the
+// id list if there is one.
+func (u *unmarshaller) readCount(hasIDList bool) (int, error) {
+	n, err := u.readLen()
+	if err != nil {
+		return 0, err
+	}
+	if hasIDList {
+		for i := 0; i < n; i++ {
+			if _, err := u.readVarint(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// readGeomWithEnds reads a number of rings, each prefixed by its length, and
+// appends their coordinates to flatCoords.
+func (u *unmarshaller) readGeomWithEnds(flatCoords []float64) ([]float64, []int, error) {
+	n, err := u.readLen()
+	if err != nil {
+		return nil, nil, err
+	}
+	ends := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		ringCoords, err := u.readPointArray()
+		if err != nil {
+			return nil, nil, err
+		}
+		flatCoords = append(flatCoords, ringCoords...)
+		ends = append(ends, len(flatCoords))
+	}
+	return flatCoords, ends, nil
+}
+
+// readPointArray reads a number of points followed by their coordinates.
+func (u *unmarshaller) readPointArray() ([]float64, error) {
+	n, err := u.readLen()
+	if err != nil {
+		return nil, err
+	}
+	return u.readFlatCoords(n)
+}
+
+// readFlatCoords reads the coordinates of n points.
+func (u *unmarshaller) readFlatCoords(n int) ([]float64, error) {
+	stride := u.layout.Stride()
+	flatCoords := make([]float64, n*stride)
+	for i := range flatCoords {
+		delta, err := u.readVarint()
+		if err != nil {
+			return nil, err
+		}
+		curr := u.prevCoords[i%stride] + delta
+		flatCoords[i] = float64(curr) / u.scales[i%stride]
+		u.prevCoords[i%stride] = curr
+	}
+	return flatCoords, nil
+}
+
+// readLen reads a number of elements, making sure that there are enough bytes
+// left for them so that a corrupt length doesn't cause a large allocation.
+func (u *unmarshaller) readLen() (int, error) {
+	n, err := u.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(u.r.Len()) {
+		return 0, newInvalidTWKBError("length %d exceeds the remaining input", n)
+	}
+	return int(n), nil
+}
+
+func (u *unmarshaller) readVarint() (int64, error) {
+	ux, err := u.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	x := int64(ux >> 1)
+	if ux&1 != 0 {
+		x = ^x
+	}
+	return x, nil
+}
+
+func (u *unmarshaller) readUvarint() (uint64, error) {
+	var x uint64
+	for shift := uint(0); shift < 64; shift += 7 {
+		b, err := u.r.ReadByte()
+		if err != nil {
+			return 0, newInvalidTWKBError("unexpected end of input")
+		}
+		x |= uint64(b&0x7F) << shift
+		if b < 0x80 {
+			return x, nil
+		}
+	}
+	return 0, newInvalidTWKBError("varint overflows a 64-bit integer")
+}
+
+func unzigzagInt8(x byte) int8 {
+	if x&0x01 != 0 {
+		return -1 - int8(x>>1)
+	}
+	return int8(x >> 1)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/wkt"
 )
 
 func TestMarshal(t *testing.T) {
@@ -375,6 +376,121 @@ func TestMarshal(t *testing.T) {
 	}
 }
 
+func TestUnmarshal(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		twkb     []byte
+		expected string
+	}{
+		{desc: "empty point", twkb: mustDecodeHex("0110"), expected: "POINT EMPTY"},
+		{desc: "point", twkb: mustDecodeHex("01000406"), expected: "POINT (2 3)"},
+		{
+			desc:     "2D linestring, precision 1",
+			twkb:     mustDecodeHex("2200032036b408fe09b9038b07"),
+			expected: "LINESTRING (1.6 2.7, 55.4 66.6, 33.3 21.2)",
+		},
+		{
+			desc:     "2D linestring, precision -1",
+			twkb:     mustDecodeHex("12000300000c0e0509"),
+			expected: "LINESTRING (0 0, 60 70, 30 20)",
+		},
+		{
+			desc:     "linestring with size and bounding box",
+			twkb:     mustDecodeHex("020309020802080202020808"),
+			expected: "LINESTRING (1 1, 5 5)",
+		},
+		{
+			desc:     "3D linestring, precision XY 1",
+			twkb:     mustDecodeHex("22080103203608b408fe0902b9038b0708"),
+			expected: "LINESTRING Z (1.6 2.7 4, 55.4 66.6 5, 33.3 21.2 9)",
+		},
+		{
+			desc:     "M linestring, precision XY 1",
+			twkb:     mustDecodeHex("22080203203608b408fe0902b9038b0708"),
+			expected: "LINESTRING M (1.6 2.7 4, 55.4 66.6 5, 33.3 21.2 9)",
+		},
+		{
+			desc:     "POLYGON with ring",
+			twkb:     mustDecodeHex("030002040101161600131501040404080800070700"),
+			expected: "POLYGON ((-1 -1, 10 10, 10 0, -1 -1), (1 1, 5 5, 5 1, 1 1))",
+		},
+		{
+			desc:     "MULTIPOINT",
+			twkb:     mustDecodeHex("04000214140a09"),
+			expected: "MULTIPOINT (10 10, 15 5)",
+		},
+		{
+			desc:     "MULTIPOINT with id list",
+			twkb:     mustDecodeHex("040402020414140a09"),
+			expected: "MULTIPOINT (10 10, 15 5)",
+		},
+		{
+			desc:     "MULTILINESTRING with EMPTY element",
+			twkb:     mustDecodeHex("0500030214140a090310103780011a5700"),
+			expected: "MULTILINESTRING ((10 10, 15 5), (23 13, -5 77, 8 33), EMPTY)",
+		},
+		{
+			desc:     "MULTIPOLYGON EMPTY",
+			twkb:     mustDecodeHex("0610"),
+			expected: "MULTIPOLYGON EMPTY",
+		},
+		{
+			desc:     "GEOMETRYCOLLECTION",
+			twkb:     mustDecodeHex("07000301001e32010032460700010200033b781e95015a64"),
+			expected: "GEOMETRYCOLLECTION (POINT (15 25), POINT (25 35), GEOMETRYCOLLECTION (LINESTRING (-30 60, -15 -15, 30 35)))",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ret, err := Unmarshal(tc.twkb)
+			require.NoError(t, err)
+			retWKT, err := wkt.Marshal(ret)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, retWKT)
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		for _, s := range []string{
+			"POINT (1 2)",
+			"LINESTRING Z (1 2 3, 4 5 6)",
+			"POLYGON ((0 0, 1 0, 1 1, 0 0), (0.2 0.1, 0.3 0.1, 0.3 0.2, 0.2 0.1))",
+			"MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((5 5, 6 5, 6 6, 5 5)))",
+			"GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (3 4, 5 6))",
+		} {
+			t.Run(s, func(t *testing.T) {
+				g, err := wkt.Unmarshal(s)
+				require.NoError(t, err)
+				b, err := Marshal(g, MarshalOptionPrecisionXY(1), MarshalOptionPrecisionZ(1))
+				require.NoError(t, err)
+				ret, err := Unmarshal(b)
+				require.NoError(t, err)
+				retWKT, err := wkt.Marshal(ret)
+				require.NoError(t, err)
+				require.Equal(t, s, retWKT)
+			})
+		}
+	})
+
+	errorTestCases := []struct {
+		desc                string
+		twkb                []byte
+		expectedErrorString string
+	}{
+		{desc: "no input", twkb: nil, expectedErrorString: "invalid TWKB: missing type and precision header"},
+		{desc: "unknown type", twkb: mustDecodeHex("0810"), expectedErrorString: "invalid TWKB: unknown type 8"},
+		{desc: "truncated", twkb: mustDecodeHex("020003"), expectedErrorString: "invalid TWKB: length 3 exceeds the remaining input"},
+		{desc: "trailing bytes", twkb: mustDecodeHex("011000"), expectedErrorString: "invalid TWKB: unexpected trailing bytes"},
+	}
+	for _, tc := range errorTestCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := Unmarshal(tc.twkb)
+			require.EqualError(t, err, tc.expectedErrorString)
+		})
+	}
+}
+
 func mustDecodeHex(h string) []byte {
 	ret, err := hex.DecodeString(h)
 	if err != nil {
@@ -86,6 +86,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rangeprober"
@@ -1191,6 +1192,15 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 	)
 	*cfg.internalDB = *internalDB
 	execCfg.InternalDB = internalDB
+	execCfg.NotificationRegistry = pgnotify.NewRegistry(
+		codec,
+		cfg.clock,
+		cfg.rangeFeedFactory,
+		cfg.stopper,
+		cfg.Settings,
+		internalDB,
+		execCfg.SystemTableIDResolver,
+	)
 
 	statsRefresher := stats.MakeRefresher(
 		cfg.AmbientCtx,
@@ -1628,6 +1638,7 @@ func (s *SQLServer) preStart(
 
 	s.execCfg.GCJobNotifier.Start(ctx)
 	s.temporaryObjectCleaner.Start(ctx, stopper)
+	s.execCfg.NotificationRegistry.Start(ctx)
 	s.distSQLServer.Start()
 	s.pgServer.Start(ctx, stopper)
 
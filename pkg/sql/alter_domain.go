@@ -0,0 +1,257 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type alterDomainNode struct {
+	n    *tree.AlterDomain
+	desc *typedesc.Mutable
+}
+
+// alterDomainNode implements planNode. We set n here to satisfy the linter.
+var _ planNode = &alterDomainNode{n: nil}
+
+func (p *planner) AlterDomain(ctx context.Context, n *tree.AlterDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	prefix, desc, err := p.ResolveMutableTypeDescriptor(ctx, n.Domain, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if desc.Kind != descpb.TypeDescriptor_DOMAIN {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%q is not a domain", tree.AsStringWithFQNames(n.Domain, &p.semaCtx.Annotations))
+	}
+
+	// The user needs ownership privilege to alter the domain.
+	if err := p.canModifyType(ctx, desc); err != nil {
+		return nil, err
+	}
+
+	// Commands which are shared with ALTER TYPE are planned as such.
+	if cmd, ok := n.Cmd.(tree.AlterTypeCmd); ok {
+		return &alterTypeNode{
+			n:      &tree.AlterType{Type: n.Domain, Cmd: cmd},
+			prefix: prefix,
+			desc:   desc,
+		}, nil
+	}
+	return &alterDomainNode{n: n, desc: desc}, nil
+}
+
+func (n *alterDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounterWithExtra("domain", n.n.Cmd.TelemetryName()))
+	p := params.p
+	domain := n.desc.Domain
+
+	switch t := n.n.Cmd.(type) {
+	case *tree.AlterDomainSetDefault:
+		if t.Default == nil {
+			domain.DefaultExpr = nil
+			break
+		}
+		expr, err := schemaexpr.ValidateDomainDefaultExpr(params.ctx, t.Default, domain.BaseType, &p.semaCtx)
+		if err != nil {
+			return err
+		}
+		domain.DefaultExpr = &expr
+
+	case *tree.AlterDomainSetNotNull:
+		if err := p.validateDomainNotNull(params.ctx, n.desc); err != nil {
+			return err
+		}
+		domain.NotNull = true
+
+	case *tree.AlterDomainDropNotNull:
+		domain.NotNull = false
+
+	case *tree.AlterDomainAddConstraint:
+		switch t.Constraint.Kind {
+		case tree.DomainNotNull:
+			if err := p.validateDomainNotNull(params.ctx, n.desc); err != nil {
+				return err
+			}
+			domain.NotNull = true
+		case tree.DomainCheck:
+			check, err := makeDomainCheckConstraint(
+				params.ctx, p, n.desc.Name, domain.BaseType, domain, t.Constraint,
+			)
+			if err != nil {
+				return err
+			}
+			if err := p.validateDomainCheck(params.ctx, n.desc, check); err != nil {
+				return err
+			}
+			domain.CheckConstraints = append(domain.CheckConstraints, check)
+		default:
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"use ALTER DOMAIN ... [ SET | DROP ] NOT NULL instead")
+		}
+
+	case *tree.AlterDomainDropConstraint:
+		idx := findDomainCheckConstraint(domain, string(t.Constraint))
+		if idx < 0 {
+			if t.IfExists {
+				p.BufferClientNotice(params.ctx, pgnotice.Newf(
+					"constraint %q of domain %q does not exist, skipping", t.Constraint, n.desc.Name,
+				))
+				return nil
+			}
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q of domain %q does not exist", t.Constraint, n.desc.Name)
+		}
+		domain.CheckConstraints = append(domain.CheckConstraints[:idx], domain.CheckConstraints[idx+1:]...)
+
+	case *tree.AlterDomainRenameConstraint:
+		idx := findDomainCheckConstraint(domain, string(t.Constraint))
+		if idx < 0 {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q of domain %q does not exist", t.Constraint, n.desc.Name)
+		}
+		if findDomainCheckConstraint(domain, string(t.NewName)) >= 0 {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"constraint %q for domain %q already exists", t.NewName, n.desc.Name)
+		}
+		domain.CheckConstraints[idx].Name = string(t.NewName)
+
+	default:
+		return errors.AssertionFailedf("unknown alter domain cmd %s", t)
+	}
+
+	if err := p.writeTypeSchemaChange(
+		params.ctx, n.desc, tree.AsStringWithFQNames(n.n, p.Ann()),
+	); err != nil {
+		return err
+	}
+	return p.logEvent(params.ctx, n.desc.ID, &eventpb.AlterType{
+		TypeName: tree.AsStringWithFQNames(n.n.Domain, p.Ann()),
+	})
+}
+
+// findDomainCheckConstraint returns the index of the CHECK constraint with the
+// given name, or -1 if the domain has no such constraint.
+func findDomainCheckConstraint(domain *descpb.TypeDescriptor_Domain, name string) int {
+	for i := range domain.CheckConstraints {
+		if domain.CheckConstraints[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// validateDomainNotNull verifies that no column of the given domain type
+// contains a NULL value.
+func (p *planner) validateDomainNotNull(ctx context.Context, desc *typedesc.Mutable) error {
+	return p.forEachDomainColumn(ctx, desc, func(tbl catalog.TableDescriptor, col catalog.Column) error {
+		colName := tree.Name(col.GetName())
+		query := fmt.Sprintf(`SELECT 1 FROM [%d AS t] WHERE %s IS NULL LIMIT 1`,
+			tbl.GetID(), colName.String())
+		row, err := p.InternalSQLTxn().QueryRowEx(
+			ctx, "validate domain not null", p.Txn(), sessiondata.NodeUserSessionDataOverride, query,
+		)
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			return pgerror.Newf(pgcode.NotNullViolation,
+				"column %q of table %q contains null values", col.GetName(), tbl.GetName())
+		}
+		return nil
+	})
+}
+
+// validateDomainCheck verifies that all values in the columns of the given
+// domain type satisfy the given CHECK constraint.
+func (p *planner) validateDomainCheck(
+	ctx context.Context, desc *typedesc.Mutable, check descpb.TypeDescriptor_Domain_CheckConstraint,
+) error {
+	expr, err := parser.ParseExpr(check.Expr)
+	if err != nil {
+		return err
+	}
+	return p.forEachDomainColumn(ctx, desc, func(tbl catalog.TableDescriptor, col catalog.Column) error {
+		colExpr, err := schemaexpr.ReplaceDomainValue(expr, tree.NewUnresolvedName(col.GetName()))
+		if err != nil {
+			return err
+		}
+		query := fmt.Sprintf(`SELECT 1 FROM [%d AS t] WHERE NOT (%s) LIMIT 1`,
+			tbl.GetID(), tree.Serialize(colExpr))
+		log.Infof(ctx, "validating domain check constraint %q with query %q", check.Name, query)
+		row, err := p.InternalSQLTxn().QueryRowEx(
+			ctx, "validate domain check constraint", p.Txn(), sessiondata.NodeUserSessionDataOverride, query,
+		)
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			return pgerror.Newf(pgcode.CheckViolation,
+				"column %q of table %q contains values that violate the new constraint",
+				col.GetName(), tbl.GetName())
+		}
+		return nil
+	})
+}
+
+// forEachDomainColumn calls fn for each public column of a table which has the
+// given domain as its type.
+func (p *planner) forEachDomainColumn(
+	ctx context.Context,
+	desc *typedesc.Mutable,
+	fn func(tbl catalog.TableDescriptor, col catalog.Column) error,
+) error {
+	domainOID := catid.TypeIDToOID(desc.GetID())
+	for _, id := range desc.ReferencingDescriptorIDs {
+		d, err := p.Descriptors().ByIDWithoutLeased(p.Txn()).WithoutNonPublic().Get().Desc(ctx, id)
+		if err != nil {
+			return err
+		}
+		tbl, ok := d.(catalog.TableDescriptor)
+		if !ok || !tbl.IsPhysicalTable() {
+			continue
+		}
+		for _, col := range tbl.PublicColumns() {
+			if col.IsVirtual() || col.GetType().Oid() != domainOID {
+				continue
+			}
+			if err := fn(tbl, col); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (n *alterDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *alterDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *alterDomainNode) Close(ctx context.Context)           {}
+func (n *alterDomainNode) ReadingOwnWrites()                   {}
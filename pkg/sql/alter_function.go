@@ -73,6 +73,9 @@ func (n *alterFunctionOptionsNode) startExec(params runParams) error {
 	// referenced by other objects. This is needed when want to allow function
 	// references. Need to think about in what condition a function can be altered
 	// or not.
+	if err := checkRoutineAggregateKind(fnDesc, false /* isAggregateStmt */, "ALTER"); err != nil {
+		return err
+	}
 	if err := tree.ValidateRoutineOptions(n.n.Options, fnDesc.IsProcedure()); err != nil {
 		return err
 	}
@@ -168,6 +171,9 @@ func (n *alterFunctionRenameNode) startExec(params runParams) error {
 			pgcode.UndefinedFunction, "could not find a procedure named %q", &n.n.Function.FuncName,
 		)
 	}
+	if err := checkRoutineAggregateKind(fnDesc, n.n.Aggregate, "ALTER"); err != nil {
+		return err
+	}
 	oldFnName, err := params.p.getQualifiedFunctionName(params.ctx, fnDesc)
 	if err != nil {
 		return err
@@ -290,6 +296,9 @@ func (n *alterFunctionSetOwnerNode) startExec(params runParams) error {
 			pgcode.UndefinedFunction, "could not find a procedure named %q", &n.n.Function.FuncName,
 		)
 	}
+	if err := checkRoutineAggregateKind(fnDesc, n.n.Aggregate, "ALTER"); err != nil {
+		return err
+	}
 	newOwner, err := decodeusername.FromRoleSpec(
 		params.p.SessionData(), username.PurposeValidation, n.n.NewOwner,
 	)
@@ -364,6 +373,9 @@ func (n *alterFunctionSetSchemaNode) startExec(params runParams) error {
 			pgcode.UndefinedFunction, "could not find a procedure named %q", &n.n.Function.FuncName,
 		)
 	}
+	if err := checkRoutineAggregateKind(fnDesc, n.n.Aggregate, "ALTER"); err != nil {
+		return err
+	}
 	oldFnName, err := params.p.getQualifiedFunctionName(params.ctx, fnDesc)
 	if err != nil {
 		return err
@@ -527,6 +539,7 @@ func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor
 		ReturnType:  fnDesc.ReturnType.Type,
 		ReturnSet:   fnDesc.ReturnType.ReturnSet,
 		IsProcedure: fnDesc.IsProcedure(),
+		IsAggregate: fnDesc.IsAggregate(),
 	}
 	for paramIdx, param := range fnDesc.Params {
 		class := funcdesc.ToTreeRoutineParamClass(param.Class)
@@ -191,6 +191,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 						"UNIQUE WITHOUT INDEX constraint on the column",
 				)
 			}
+			if t.ColumnDef.Unique.Deferrability != tree.ConstraintNotDeferrable {
+				return newDeferrableIndexConstraintError()
+			}
 			if t.ColumnDef.PrimaryKey.IsPrimaryKey {
 				return pgerror.Newf(pgcode.InvalidColumnDefinition,
 					"multiple primary keys for table %q are not allowed", tn.Object())
@@ -226,6 +229,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 					}
 					continue
 				}
+				if d.Deferrability != tree.ConstraintNotDeferrable {
+					return newDeferrableIndexConstraintError()
+				}
 
 				if d.PrimaryKey {
 					if t.ValidationBehavior == tree.ValidationSkip {
@@ -361,6 +367,22 @@ func (n *alterTableNode) startExec(params runParams) error {
 						return err
 					}
 				}
+			case *tree.ExcludeConstraintTableDef:
+				if err := addExclusionConstraintTableDef(
+					params.ctx,
+					params.EvalContext(),
+					d,
+					n.tableDesc,
+					*tn,
+					NonEmptyTable,
+					t.ValidationBehavior,
+					params.p.SemaCtx(),
+				); err != nil {
+					return err
+				}
+				if err := params.p.addExclusionConstraintIndexMutation(params, n.tableDesc, *tn, d); err != nil {
+					return err
+				}
 			case *tree.CheckConstraintTableDef:
 				var err error
 				params.p.runWithOptions(resolveFlags{contextDatabaseID: n.tableDesc.ParentID}, func() {
@@ -1687,6 +1709,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExcludeConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
@@ -1602,10 +1602,8 @@ func ValidateConstraint(
 			return txn.WithSyntheticDescriptors(
 				[]catalog.Descriptor{tableDesc},
 				func() error {
-					return validateUniqueConstraint(
-						ctx, tableDesc, uwi.GetName(),
-						uwi.CollectKeyColumnIDs().Ordered(),
-						uwi.GetPredicate(),
+					return validateUniqueWithoutIndexConstraint(
+						ctx, tableDesc, uwi,
 						indexIDForValidation,
 						txn,
 						sessionData.User(),
@@ -1803,7 +1801,15 @@ func countExpectedRowsForInvertedIndex(
 	) error {
 		var stmt string
 		geoConfig := idx.GetGeoConfig()
-		if geoConfig.IsEmpty() {
+		if idx.HasTermStatistics() {
+			// An index which stores term statistics also has a document entry
+			// for every row which has entries for its lexemes.
+			stmt = fmt.Sprintf(
+				`SELECT coalesce(sum_int(n + (n > 0)::INT8), 0) FROM (
+  SELECT crdb_internal.num_inverted_index_entries(%s, %d) AS n FROM [%d AS t]`,
+				colNameOrExpr, idx.GetVersion(), desc.GetID(),
+			)
+		} else if geoConfig.IsEmpty() {
 			stmt = fmt.Sprintf(
 				`SELECT coalesce(sum_int(crdb_internal.num_inverted_index_entries(%s, %d)), 0) FROM [%d AS t]`,
 				colNameOrExpr, idx.GetVersion(), desc.GetID(),
@@ -1819,6 +1825,9 @@ func countExpectedRowsForInvertedIndex(
 		if idx.IsPartial() {
 			stmt = fmt.Sprintf(`%s WHERE %s`, stmt, idx.GetPredicate())
 		}
+		if idx.HasTermStatistics() {
+			stmt += `) AS entries`
+		}
 		return txn.WithSyntheticDescriptors([]catalog.Descriptor{desc}, func() error {
 			row, err := txn.QueryRowEx(ctx, "verify-inverted-idx-count", txn.KV(), execOverride, stmt)
 			if err != nil {
@@ -2795,10 +2804,10 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	if tableDesc.Version > tableDesc.ClusterVersion().Version {
 		syntheticDescs = append(syntheticDescs, tableDesc)
 	}
-	var uc *descpb.UniqueWithoutIndexConstraint
+	var uc catalog.UniqueWithoutIndexConstraint
 	for _, uwi := range tableDesc.UniqueConstraintsWithoutIndex() {
 		if uwi.GetName() == constraintName {
-			uc = uwi.UniqueWithoutIndexDesc()
+			uc = uwi
 			break
 		}
 	}
@@ -2809,12 +2818,10 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	return txn.WithSyntheticDescriptors(
 		syntheticDescs,
 		func() error {
-			return validateUniqueConstraint(
+			return validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.Name,
-				uc.ColumnIDs,
-				uc.Predicate,
+				uc,
 				0, /* indexIDForValidation */
 				txn,
 				user,
@@ -115,9 +115,13 @@ func indexForDisplay(
 		f.WriteString(" USING")
 		if index.Type == descpb.IndexDescriptor_INVERTED {
 			f.WriteString(" gin")
+		} else if index.IsVector() {
+			f.WriteString(" lsh")
 		} else {
 			f.WriteString(" btree")
 		}
+	} else if index.IsVector() {
+		f.WriteString(" USING lsh")
 	}
 
 	f.WriteString(" (")
@@ -135,13 +139,23 @@ func indexForDisplay(
 		}
 	}
 
-	if !isPrimary && len(index.StoreColumnNames) > 0 {
+	storeColumnNames := index.StoreColumnNames
+	if index.IsVector() {
+		// The vector column is implicitly stored in a vector index.
+		storeColumnNames = make([]string, 0, len(index.StoreColumnNames))
+		for i, id := range index.StoreColumnIDs {
+			if id != index.Vector.ColumnID {
+				storeColumnNames = append(storeColumnNames, index.StoreColumnNames[i])
+			}
+		}
+	}
+	if !isPrimary && len(storeColumnNames) > 0 {
 		f.WriteString(" STORING (")
-		for i := range index.StoreColumnNames {
+		for i := range storeColumnNames {
 			if i > 0 {
 				f.WriteString(", ")
 			}
-			f.FormatNameP(&index.StoreColumnNames[i])
+			f.FormatNameP(&storeColumnNames[i])
 		}
 		f.WriteByte(')')
 	}
@@ -219,6 +233,20 @@ func FormatIndexElements(
 		}
 	}
 
+	// The key of a vector index is the partition of the vector column, which is
+	// not displayed.
+	if index.IsVector() {
+		col, err := catalog.MustFindColumnByID(table, index.Vector.ColumnID)
+		if err != nil {
+			return err
+		}
+		name := col.ColName()
+		f.FormatNode(&name)
+		f.WriteByte(' ')
+		f.WriteString(index.Vector.Metric.OpClass())
+		return nil
+	}
+
 	startIdx := index.ExplicitColumnStartIdx()
 	for i, n := startIdx, len(index.KeyColumnIDs); i < n; i++ {
 		col, err := catalog.MustFindColumnByID(table, index.KeyColumnIDs[i])
@@ -345,6 +373,27 @@ func formatStorageConfigs(
 		numCustomSettings++
 	}
 
+	if index.IsVector() {
+		if numCustomSettings > 0 {
+			f.WriteString(", ")
+		} else {
+			f.WriteString(" WITH (")
+		}
+		f.WriteString(`partitions=`)
+		f.WriteString(strconv.FormatInt(int64(index.Vector.Partitions), 10))
+		numCustomSettings++
+	}
+
+	if index.TermStatistics {
+		if numCustomSettings > 0 {
+			f.WriteString(", ")
+		} else {
+			f.WriteString(" WITH (")
+		}
+		f.WriteString(`term_statistics=true`)
+		numCustomSettings++
+	}
+
 	if numCustomSettings > 0 {
 		f.WriteString(")")
 	}
@@ -200,3 +200,16 @@ func (rowLevelTTL *RowLevelTTL) GetTTLExpr() Expression {
 	}
 	return DefaultTTLExpirationExpr
 }
+
+// OpClass returns the name of the operator class of a vector index with the
+// given distance metric.
+func (m VectorIndexDescriptor_Metric) OpClass() string {
+	switch m {
+	case VectorIndexDescriptor_COSINE:
+		return "vector_cosine_ops"
+	case VectorIndexDescriptor_INNER_PRODUCT:
+		return "vector_ip_ops"
+	default:
+		return "vector_l2_ops"
+	}
+}
@@ -74,6 +74,9 @@ var (
 		catconstants.TxnExecInsightsTableName,
 		catconstants.StmtExecInsightsTableName,
 		catconstants.TableMetadata,
+		catconstants.NotificationsTableName,
+		catconstants.ReplicationSlotsTableName,
+		catconstants.PublicationsTableName,
 	}
 
 	readWriteSystemSequences = []catconstants.SystemTableName{
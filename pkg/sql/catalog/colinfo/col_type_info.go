@@ -127,6 +127,39 @@ func ValidateColumnDefType(ctx context.Context, st *cluster.Settings, t *types.T
 			return err
 		}
 
+	case types.RangeFamily, types.MultirangeFamily:
+		if !st.Version.IsActive(ctx, clusterversion.V24_3_RangeTypes) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"%s not supported until version 24.3", t.Name(),
+			)
+		}
+
+	case types.JsonpathFamily:
+		if !st.Version.IsActive(ctx, clusterversion.V24_3_Jsonpath) {
+			return pgerror.New(
+				pgcode.FeatureNotSupported,
+				"jsonpath not supported until version 24.3",
+			)
+		}
+
+	case types.PointFamily, types.BoxFamily, types.LSegFamily, types.LineFamily,
+		types.PathFamily, types.PolygonFamily, types.CircleFamily:
+		if !st.Version.IsActive(ctx, clusterversion.V24_3_GeometricTypes) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"%s not supported until version 24.3", t.Name(),
+			)
+		}
+
+	case types.MACAddrFamily, types.MACAddr8Family, types.MoneyFamily:
+		if !st.Version.IsActive(ctx, clusterversion.V24_3_NetworkAndMoneyTypes) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"%s not supported until version 24.3", t.Name(),
+			)
+		}
+
 	default:
 		return pgerror.Newf(pgcode.InvalidTableDefinition,
 			"value type %s cannot be used for table columns", t.String())
@@ -160,7 +193,7 @@ func ColumnTypeIsInvertedIndexable(t *types.T) bool {
 	switch t.Family() {
 	case types.ArrayFamily:
 		return t.ArrayContents().Family() != types.RefCursorFamily
-	case types.JsonFamily, types.StringFamily:
+	case types.JsonFamily, types.StringFamily, types.RangeFamily, types.MultirangeFamily:
 		return true
 	}
 	return ColumnTypeIsOnlyInvertedIndexable(t)
@@ -200,7 +233,10 @@ func MustBeValueEncoded(semanticType *types.T) bool {
 		return true
 	case types.TSVectorFamily, types.TSQueryFamily:
 		return true
-	case types.PGVectorFamily:
+	case types.PGVectorFamily, types.JsonpathFamily:
+		return true
+	case types.PointFamily, types.BoxFamily, types.LSegFamily, types.LineFamily,
+		types.PathFamily, types.PolygonFamily, types.CircleFamily:
 		return true
 	}
 	return false
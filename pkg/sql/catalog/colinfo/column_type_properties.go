@@ -59,6 +59,10 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 			}
 		}
 		return false
+	case types.RangeFamily:
+		return CanHaveCompositeKeyEncoding(typ.RangeContents())
+	case types.MultirangeFamily:
+		return CanHaveCompositeKeyEncoding(typ.MultirangeContents())
 	case types.BoolFamily,
 		types.IntFamily,
 		types.DateFamily,
@@ -83,7 +87,18 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		types.VoidFamily,
 		types.EncodedKeyFamily,
 		types.TSQueryFamily,
-		types.TSVectorFamily:
+		types.TSVectorFamily,
+		types.JsonpathFamily,
+		types.PointFamily,
+		types.BoxFamily,
+		types.LSegFamily,
+		types.LineFamily,
+		types.PathFamily,
+		types.PolygonFamily,
+		types.CircleFamily,
+		types.MACAddrFamily,
+		types.MACAddr8Family,
+		types.MoneyFamily:
 		return false
 	case types.UnknownFamily,
 		types.AnyFamily:
@@ -19,6 +19,11 @@ func (desc *IndexDescriptor) IsSharded() bool {
 	return desc.Sharded.IsSharded
 }
 
+// IsVector returns whether the index is a vector index or not.
+func (desc *IndexDescriptor) IsVector() bool {
+	return desc.Vector.IsVector
+}
+
 // IsPartial returns true if the index is a partial index.
 func (desc *IndexDescriptor) IsPartial() bool {
 	return desc.Predicate != ""
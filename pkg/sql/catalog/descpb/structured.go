@@ -12,6 +12,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
 )
 
 // ID, ColumnID, FamilyID, and IndexID are all uint32, but are each given a
@@ -330,6 +331,11 @@ func (u *UniqueWithoutIndexConstraint) IsPartial() bool {
 	return u.Predicate != ""
 }
 
+// IsExclusion returns true if the constraint is an exclusion constraint.
+func (u *UniqueWithoutIndexConstraint) IsExclusion() bool {
+	return len(u.ExclusionOperators) > 0
+}
+
 // GetParentID implements the catalog.NameKeyHaver interface.
 func (ni NameInfo) GetParentID() ID {
 	return ni.ParentID
@@ -348,3 +354,33 @@ func (ni NameInfo) GetName() string {
 func init() {
 	protoreflect.RegisterShorthands((*Descriptor)(nil), "descriptor", "desc")
 }
+
+// ToConstraintDeferrability converts a tree.ConstraintDeferrability to its
+// corresponding ConstraintDeferrability.
+func ToConstraintDeferrability(d tree.ConstraintDeferrability) ConstraintDeferrability {
+	switch d {
+	case tree.ConstraintNotDeferrable:
+		return ConstraintDeferrability_NotDeferrable
+	case tree.ConstraintInitiallyImmediate:
+		return ConstraintDeferrability_InitiallyImmediate
+	case tree.ConstraintInitiallyDeferred:
+		return ConstraintDeferrability_InitiallyDeferred
+	default:
+		panic(errors.AssertionFailedf("unknown constraint deferrability %d", d))
+	}
+}
+
+// ToTree converts a ConstraintDeferrability to its corresponding
+// tree.ConstraintDeferrability.
+func (d ConstraintDeferrability) ToTree() tree.ConstraintDeferrability {
+	switch d {
+	case ConstraintDeferrability_NotDeferrable:
+		return tree.ConstraintNotDeferrable
+	case ConstraintDeferrability_InitiallyImmediate:
+		return tree.ConstraintInitiallyImmediate
+	case ConstraintDeferrability_InitiallyDeferred:
+		return tree.ConstraintInitiallyDeferred
+	default:
+		panic(errors.AssertionFailedf("unknown constraint deferrability %d", d))
+	}
+}
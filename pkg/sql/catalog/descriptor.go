@@ -876,6 +876,11 @@ type TypeDescriptor interface {
 	// nil otherwise.
 	AsCompositeTypeDescriptor() CompositeTypeDescriptor
 
+	// AsDomainTypeDescriptor returns this instance cast to
+	// DomainTypeDescriptor if this type is a domain type,
+	// nil otherwise.
+	AsDomainTypeDescriptor() DomainTypeDescriptor
+
 	// AsTableImplicitRecordTypeDescriptor returns this instance cast to
 	// TableImplicitRecordTypeDescriptor if this type is an implicit table record
 	// type, nil otherwise.
@@ -963,6 +968,30 @@ type CompositeTypeDescriptor interface {
 	GetElementType(ordinal int) *types.T
 }
 
+// DomainTypeDescriptor is the TypeDescriptor subtype for domains, which are
+// base types with additional constraints.
+type DomainTypeDescriptor interface {
+	NonAliasTypeDescriptor
+
+	// BaseType returns the underlying type of the domain.
+	BaseType() *types.T
+
+	// GetDefaultExpr returns the serialized default expression of the domain,
+	// and whether the domain has a default.
+	GetDefaultExpr() (string, bool)
+
+	// IsNotNull returns true if the domain does not allow NULL values.
+	IsNotNull() bool
+
+	// NumCheckConstraints returns the number of CHECK constraints on the
+	// domain.
+	NumCheckConstraints() int
+
+	// GetCheckConstraint returns the name and serialized expression of the
+	// CHECK constraint at the given ordinal.
+	GetCheckConstraint(ordinal int) (name string, expr string)
+}
+
 // TableImplicitRecordTypeDescriptor is the TypeDescriptor subtype for the
 // record type implicitly defined by a table.
 type TableImplicitRecordTypeDescriptor interface {
@@ -1048,6 +1077,10 @@ type FunctionDescriptor interface {
 	// returns false if the descriptor represents a user-defined function.
 	IsProcedure() bool
 
+	// IsAggregate returns true if the descriptor represents a user-defined
+	// aggregate function.
+	IsAggregate() bool
+
 	// GetSecurity returns the security specification of this function.
 	GetSecurity() catpb.Function_Security
 }
@@ -211,6 +211,15 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	vp := funcinfo.MakeVolatilityProperties(desc.Volatility, desc.LeakProof)
 	vea.Report(vp.Validate())
 
+	if agg := desc.Aggregate; agg != nil {
+		if agg.TransitionFuncID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("transition function not set for aggregate"))
+		}
+		if agg.StateType == nil {
+			vea.Report(errors.AssertionFailedf("state type not set for aggregate"))
+		}
+	}
+
 	for i, dep := range desc.DependedOnBy {
 		if dep.ID == descpb.InvalidID {
 			vea.Report(errors.AssertionFailedf("invalid relation id %d in depended-on-by references #%d", dep.ID, i))
@@ -910,6 +919,20 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	if desc.ReturnType.ReturnSet {
 		ret.Class = tree.GeneratorClass
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Class = tree.AggregateClass
+		ret.UserDefinedAggregate = &tree.UserDefinedAggregate{
+			TransitionFunc: catid.FuncIDToOID(agg.TransitionFuncID),
+			StateType:      agg.StateType,
+			InitCond:       agg.InitCond,
+		}
+		if agg.FinalFuncID != descpb.InvalidID {
+			ret.UserDefinedAggregate.FinalFunc = catid.FuncIDToOID(agg.FinalFuncID)
+		}
+		if agg.CombineFuncID != descpb.InvalidID {
+			ret.UserDefinedAggregate.CombineFunc = catid.FuncIDToOID(agg.CombineFuncID)
+		}
+	}
 	ret.SecurityMode = desc.getCreateExprSecurity()
 
 	return ret, nil
@@ -991,6 +1014,11 @@ func (desc *immutable) IsProcedure() bool {
 	return desc.FunctionDescriptor.IsProcedure
 }
 
+// IsAggregate implements the FunctionDescriptor interface.
+func (desc *immutable) IsAggregate() bool {
+	return desc.FunctionDescriptor.Aggregate != nil
+}
+
 func (desc *immutable) getCreateExprLang() tree.RoutineLanguage {
 	switch desc.Lang {
 	case catpb.Function_SQL:
@@ -315,8 +315,11 @@ func TableDescs(
 		for idx := range table.Triggers {
 			trigger := &table.Triggers[idx]
 
-			// Rewrite trigger function reference.
-			if triggerFnRewrite, ok := descriptorRewrites[trigger.FuncID]; ok {
+			// Rewrite trigger function reference. Builtin trigger functions are
+			// referenced by OID, which doesn't need to be rewritten.
+			if trigger.BuiltinFuncOID != 0 {
+				// Nothing to do.
+			} else if triggerFnRewrite, ok := descriptorRewrites[trigger.FuncID]; ok {
 				trigger.FuncID = triggerFnRewrite.ID
 			} else {
 				return errors.AssertionFailedf(
@@ -742,7 +745,8 @@ func TypeDescs(types []*typedesc.Mutable, descriptorRewrites jobspb.DescRewriteM
 			}
 		}
 		switch t := typ.Kind; t {
-		case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_COMPOSITE, descpb.TypeDescriptor_MULTIREGION_ENUM:
+		case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_COMPOSITE, descpb.TypeDescriptor_MULTIREGION_ENUM,
+			descpb.TypeDescriptor_DOMAIN:
 			if rw, ok := descriptorRewrites[typ.ArrayTypeID]; ok {
 				typ.ArrayTypeID = rw.ID
 			}
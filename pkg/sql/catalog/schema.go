@@ -42,6 +42,22 @@ type SchemaDescriptor interface {
 	// ForEachFunctionSignature iterates through all function signatures within
 	// the schema and calls fn on each signature.
 	ForEachFunctionSignature(fn func(sig descpb.SchemaDescriptor_FunctionSignature) error) error
+
+	// GetTextSearchConfiguration returns the text search configuration with the
+	// given name.
+	GetTextSearchConfiguration(name string) (descpb.SchemaDescriptor_TextSearchConfiguration, bool)
+
+	// GetTextSearchDictionary returns the text search dictionary with the given
+	// name.
+	GetTextSearchDictionary(name string) (descpb.SchemaDescriptor_TextSearchDictionary, bool)
+
+	// ForEachTextSearchConfiguration iterates through the text search
+	// configurations of the schema in name order.
+	ForEachTextSearchConfiguration(fn func(cfg descpb.SchemaDescriptor_TextSearchConfiguration) error) error
+
+	// ForEachTextSearchDictionary iterates through the text search dictionaries
+	// of the schema in name order.
+	ForEachTextSearchDictionary(fn func(dict descpb.SchemaDescriptor_TextSearchDictionary) error) error
 }
 
 // ResolvedSchemaKind is an enum that represents what kind of schema
@@ -8,6 +8,7 @@ package schemadesc
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -266,6 +267,26 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			}
 		}
 	}
+
+	for name, dict := range desc.TextSearchDictionaries {
+		if dict.Name != name {
+			vea.Report(errors.AssertionFailedf("text search dictionary %q stored under name %q",
+				dict.Name, name))
+		}
+	}
+	for name, cfg := range desc.TextSearchConfigurations {
+		if cfg.Name != name {
+			vea.Report(errors.AssertionFailedf("text search configuration %q stored under name %q",
+				cfg.Name, name))
+		}
+		for i := range cfg.Mappings {
+			if i > 0 && cfg.Mappings[i-1].TokenType >= cfg.Mappings[i].TokenType {
+				vea.Report(errors.AssertionFailedf("text search configuration %q has unsorted mappings",
+					cfg.Name))
+				break
+			}
+		}
+	}
 }
 
 // GetReferencedDescIDs returns the IDs of all descriptors referenced by
@@ -512,6 +533,38 @@ func (desc *Mutable) RemoveFunction(name string, id descpb.ID) {
 	}
 }
 
+// SetTextSearchDictionary adds or replaces a text search dictionary in the
+// schema descriptor.
+func (desc *Mutable) SetTextSearchDictionary(dict descpb.SchemaDescriptor_TextSearchDictionary) {
+	if desc.TextSearchDictionaries == nil {
+		desc.TextSearchDictionaries = make(map[string]descpb.SchemaDescriptor_TextSearchDictionary)
+	}
+	desc.TextSearchDictionaries[dict.Name] = dict
+}
+
+// RemoveTextSearchDictionary removes a text search dictionary from the schema
+// descriptor.
+func (desc *Mutable) RemoveTextSearchDictionary(name string) {
+	delete(desc.TextSearchDictionaries, name)
+}
+
+// SetTextSearchConfiguration adds or replaces a text search configuration in
+// the schema descriptor.
+func (desc *Mutable) SetTextSearchConfiguration(
+	cfg descpb.SchemaDescriptor_TextSearchConfiguration,
+) {
+	if desc.TextSearchConfigurations == nil {
+		desc.TextSearchConfigurations = make(map[string]descpb.SchemaDescriptor_TextSearchConfiguration)
+	}
+	desc.TextSearchConfigurations[cfg.Name] = cfg
+}
+
+// RemoveTextSearchConfiguration removes a text search configuration from the
+// schema descriptor.
+func (desc *Mutable) RemoveTextSearchConfiguration(name string) {
+	delete(desc.TextSearchConfigurations, name)
+}
+
 // ReplaceOverload updates the function signature that matches the existing
 // overload with the new one. An error is returned if the function doesn't exist
 // or a match is not found.
@@ -598,6 +651,8 @@ func (desc *immutable) GetResolvedFuncDefinition(
 		}
 		if funcDescPb.Signatures[i].ReturnSet {
 			overload.Class = tree.GeneratorClass
+		} else if funcDescPb.Signatures[i].IsAggregate {
+			overload.Class = tree.AggregateClass
 		}
 		// There is no need to look at the parameter classes since ArgTypes
 		// already contains only parameters that are included into the
@@ -655,6 +710,56 @@ func (desc *immutable) ForEachFunctionSignature(
 	return nil
 }
 
+// GetTextSearchConfiguration implements the SchemaDescriptor interface.
+func (desc *immutable) GetTextSearchConfiguration(
+	name string,
+) (descpb.SchemaDescriptor_TextSearchConfiguration, bool) {
+	cfg, found := desc.TextSearchConfigurations[name]
+	return cfg, found
+}
+
+// GetTextSearchDictionary implements the SchemaDescriptor interface.
+func (desc *immutable) GetTextSearchDictionary(
+	name string,
+) (descpb.SchemaDescriptor_TextSearchDictionary, bool) {
+	dict, found := desc.TextSearchDictionaries[name]
+	return dict, found
+}
+
+// ForEachTextSearchConfiguration implements the SchemaDescriptor interface.
+func (desc *immutable) ForEachTextSearchConfiguration(
+	fn func(cfg descpb.SchemaDescriptor_TextSearchConfiguration) error,
+) error {
+	names := make([]string, 0, len(desc.TextSearchConfigurations))
+	for name := range desc.TextSearchConfigurations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := fn(desc.TextSearchConfigurations[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEachTextSearchDictionary implements the SchemaDescriptor interface.
+func (desc *immutable) ForEachTextSearchDictionary(
+	fn func(dict descpb.SchemaDescriptor_TextSearchDictionary) error,
+) error {
+	names := make([]string, 0, len(desc.TextSearchDictionaries))
+	for name := range desc.TextSearchDictionaries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := fn(desc.TextSearchDictionaries[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetReplicatedPCRVersion is a part of the catalog.Descriptor
 func (desc *immutable) GetReplicatedPCRVersion() descpb.DescriptorVersion {
 	return desc.ReplicatedPCRVersion
@@ -144,6 +144,34 @@ func (p synthetic) ForEachFunctionSignature(
 	return nil
 }
 
+// GetTextSearchConfiguration implements the SchemaDescriptor interface.
+func (p synthetic) GetTextSearchConfiguration(
+	name string,
+) (descpb.SchemaDescriptor_TextSearchConfiguration, bool) {
+	return descpb.SchemaDescriptor_TextSearchConfiguration{}, false
+}
+
+// GetTextSearchDictionary implements the SchemaDescriptor interface.
+func (p synthetic) GetTextSearchDictionary(
+	name string,
+) (descpb.SchemaDescriptor_TextSearchDictionary, bool) {
+	return descpb.SchemaDescriptor_TextSearchDictionary{}, false
+}
+
+// ForEachTextSearchConfiguration implements the SchemaDescriptor interface.
+func (p synthetic) ForEachTextSearchConfiguration(
+	fn func(cfg descpb.SchemaDescriptor_TextSearchConfiguration) error,
+) error {
+	return nil
+}
+
+// ForEachTextSearchDictionary implements the SchemaDescriptor interface.
+func (p synthetic) ForEachTextSearchDictionary(
+	fn func(dict descpb.SchemaDescriptor_TextSearchDictionary) error,
+) error {
+	return nil
+}
+
 // ForEachUDTDependentForHydration implements the catalog.Descriptor interface.
 func (p synthetic) ForEachUDTDependentForHydration(fn func(t *types.T) error) error {
 	return nil
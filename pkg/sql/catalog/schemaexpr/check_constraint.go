@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -74,6 +75,10 @@ func (b *CheckConstraintBuilder) MarkNameInUse(name string) {
 func (b *CheckConstraintBuilder) Build(
 	c *tree.CheckConstraintTableDef, version clusterversion.ClusterVersion,
 ) (*descpb.TableDescriptor_CheckConstraint, error) {
+	if c.Deferrability != tree.ConstraintNotDeferrable &&
+		!version.IsActive(clusterversion.V24_3_DeferrableConstraints) {
+		return nil, sqlerrors.NewDeferrableConstraintsNotSupportedError()
+	}
 	name := string(c.Name)
 
 	if name == "" {
@@ -107,6 +112,7 @@ func (b *CheckConstraintBuilder) Build(
 		Name:                  name,
 		ColumnIDs:             colIDs.Ordered(),
 		FromHashShardedColumn: c.FromHashShardedColumn,
+		Deferrability:         descpb.ToConstraintDeferrability(c.Deferrability),
 		ConstraintID:          constraintID,
 	}, nil
 }
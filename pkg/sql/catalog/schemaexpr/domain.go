@@ -0,0 +1,66 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// domainValueName is the name by which a domain CHECK constraint refers to
+// the value being checked.
+const domainValueName = "value"
+
+// ReplaceDomainValue returns a copy of the given domain CHECK constraint
+// expression in which every reference to VALUE is replaced with value.
+func ReplaceDomainValue(expr tree.Expr, value tree.Expr) (tree.Expr, error) {
+	return tree.SimpleVisit(expr, func(e tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		if n, ok := e.(*tree.UnresolvedName); ok && n.NumParts == 1 && n.Parts[0] == domainValueName {
+			return false, value, nil
+		}
+		return true, e, nil
+	})
+}
+
+// ValidateDomainCheckExpr validates that a domain CHECK constraint expression
+// is a boolean expression which only references the value being checked and
+// contains no volatile functions. The serialized expression is returned.
+func ValidateDomainCheckExpr(
+	ctx context.Context, expr tree.Expr, baseType *types.T, semaCtx *tree.SemaContext,
+) (string, error) {
+	// Type-check the expression against a NULL of the base type standing in
+	// for VALUE.
+	replaced, err := ReplaceDomainValue(expr, tree.NewTypedCastExpr(tree.DNull, baseType))
+	if err != nil {
+		return "", err
+	}
+	if _, err := SanitizeVarFreeExpr(
+		ctx, replaced, types.Bool, tree.DomainCheckExpr, semaCtx, volatility.Immutable,
+		false, /* allowAssignmentCast */
+	); err != nil {
+		return "", err
+	}
+	return tree.Serialize(expr), nil
+}
+
+// ValidateDomainDefaultExpr validates that a domain DEFAULT expression can be
+// assigned to the base type of the domain. The serialized expression is
+// returned.
+func ValidateDomainDefaultExpr(
+	ctx context.Context, expr tree.Expr, baseType *types.T, semaCtx *tree.SemaContext,
+) (string, error) {
+	typedExpr, err := SanitizeVarFreeExpr(
+		ctx, expr, baseType, tree.DomainDefaultExpr, semaCtx, volatility.Volatile,
+		true, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
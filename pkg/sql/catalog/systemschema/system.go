@@ -1232,6 +1232,45 @@ CREATE TABLE system.mvcc_statistics (
 			details
     )
 	);`
+
+	// NotificationsTableSchema stores the notifications sent with NOTIFY. The
+	// table is watched by a rangefeed on every SQL instance with listening
+	// sessions, and rows are deleted once they are no longer needed.
+	NotificationsTableSchema = `CREATE TABLE system.notifications (
+	id      INT8 NOT NULL DEFAULT unique_rowid(),
+	channel STRING NOT NULL,
+	payload STRING NOT NULL,
+	pid     INT4 NOT NULL,
+	created TIMESTAMPTZ NOT NULL DEFAULT now(),
+	CONSTRAINT "primary" PRIMARY KEY (id),
+	FAMILY "primary" (id, channel, payload, pid, created)
+);`
+
+	// ReplicationSlotsTableSchema stores the logical replication slots created
+	// with CREATE_REPLICATION_SLOT. confirmed_flush_lsn is the position up to
+	// which the client has confirmed the stream, from which it is resumed.
+	ReplicationSlotsTableSchema = `CREATE TABLE system.replication_slots (
+	slot_name           STRING NOT NULL,
+	plugin              STRING NOT NULL,
+	database_id         INT8 NOT NULL,
+	created             TIMESTAMPTZ NOT NULL DEFAULT now(),
+	confirmed_flush_lsn INT8 NOT NULL,
+	CONSTRAINT "primary" PRIMARY KEY (slot_name),
+	FAMILY "primary" (slot_name, plugin, database_id, created, confirmed_flush_lsn)
+);`
+
+	// PublicationsTableSchema stores the publications created with CREATE
+	// PUBLICATION, which determine the tables streamed by logical replication.
+	// table_ids is empty if all_tables is set.
+	PublicationsTableSchema = `CREATE TABLE system.publications (
+	database_id INT8 NOT NULL,
+	name        STRING NOT NULL,
+	owner       STRING NOT NULL,
+	all_tables  BOOL NOT NULL,
+	table_ids   INT8[] NOT NULL,
+	CONSTRAINT "primary" PRIMARY KEY (database_id, name),
+	FAMILY "primary" (database_id, name, owner, all_tables, table_ids)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1284,7 +1323,7 @@ const SystemDatabaseName = catconstants.SystemDatabaseName
 // release version).
 //
 // NB: Don't set this to clusterversion.Latest; use a specific version instead.
-var SystemDatabaseSchemaBootstrapVersion = clusterversion.V24_3_AddTableMetadataCols.Version()
+var SystemDatabaseSchemaBootstrapVersion = clusterversion.V24_3_AddPublicationsTable.Version()
 
 // MakeSystemDatabaseDesc constructs a copy of the system database
 // descriptor.
@@ -1476,6 +1515,9 @@ func MakeSystemTables() []SystemTable {
 		StatementExecInsightsTable,
 		TransactionExecInsightsTable,
 		TableMetadata,
+		NotificationsTable,
+		ReplicationSlotsTable,
+		PublicationsTable,
 	}
 }
 
@@ -5017,6 +5059,88 @@ var (
 			tbl.NextConstraintID++
 		},
 	)
+
+	// NotificationsTable is the descriptor for the notifications table.
+	NotificationsTable = makeSystemTable(
+		NotificationsTableSchema,
+		systemTable(
+			catconstants.NotificationsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "id", ID: 1, Type: types.Int, DefaultExpr: &uniqueRowIDString},
+				{Name: "channel", ID: 2, Type: types.String},
+				{Name: "payload", ID: 3, Type: types.String},
+				{Name: "pid", ID: 4, Type: types.Int4},
+				{Name: "created", ID: 5, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"id", "channel", "payload", "pid", "created"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			pk("id"),
+		),
+	)
+
+	// ReplicationSlotsTable is the descriptor for the replication slots table.
+	ReplicationSlotsTable = makeSystemTable(
+		ReplicationSlotsTableSchema,
+		systemTable(
+			catconstants.ReplicationSlotsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "slot_name", ID: 1, Type: types.String},
+				{Name: "plugin", ID: 2, Type: types.String},
+				{Name: "database_id", ID: 3, Type: types.Int},
+				{Name: "created", ID: 4, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+				{Name: "confirmed_flush_lsn", ID: 5, Type: types.Int},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"slot_name", "plugin", "database_id", "created", "confirmed_flush_lsn"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			pk("slot_name"),
+		),
+	)
+
+	// PublicationsTable is the descriptor for the publications table.
+	PublicationsTable = makeSystemTable(
+		PublicationsTableSchema,
+		systemTable(
+			catconstants.PublicationsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "database_id", ID: 1, Type: types.Int},
+				{Name: "name", ID: 2, Type: types.String},
+				{Name: "owner", ID: 3, Type: types.String},
+				{Name: "all_tables", ID: 4, Type: types.Bool},
+				{Name: "table_ids", ID: 5, Type: types.IntArray},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"database_id", "name", "owner", "all_tables", "table_ids"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                "primary",
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"database_id", "name"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC, catenumpb.IndexColumn_ASC},
+				KeyColumnIDs:        []descpb.ColumnID{1, 2},
+			},
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
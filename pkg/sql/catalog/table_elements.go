@@ -167,6 +167,8 @@ type Index interface {
 	IsUnique() bool
 	IsDisabled() bool
 	IsSharded() bool
+	IsVector() bool
+	HasTermStatistics() bool
 	IsNotVisible() bool
 	IsCreatedExplicitly() bool
 	GetInvisibility() float64
@@ -178,6 +180,7 @@ type Index interface {
 
 	GetSharded() catpb.ShardedDescriptor
 	GetShardColumnName() string
+	GetVector() catpb.VectorIndexDescriptor
 
 	// IsValidOriginIndex returns whether the index can serve as an origin index
 	// for a foreign key constraint.
@@ -447,6 +450,14 @@ type Constraint interface {
 	// the parent table.
 	IsEnforced() bool
 
+	// GetDeferrability returns whether the checking of this constraint can be
+	// deferred until the end of the transaction.
+	GetDeferrability() descpb.ConstraintDeferrability
+
+	// IsDeferrable returns true iff the checking of this constraint can be
+	// deferred until the end of the transaction.
+	IsDeferrable() bool
+
 	// GetName returns the name of this constraint update mutation.
 	GetName() string
 
@@ -594,6 +605,15 @@ type UniqueWithoutIndexConstraint interface {
 
 	// ParentTableID returns the ID of the table this constraint applies to.
 	ParentTableID() descpb.ID
+
+	// IsExclusion returns true iff the constraint is an exclusion constraint,
+	// in which case two rows conflict if all the comparisons of its key
+	// columns with their ExclusionOperators are true.
+	IsExclusion() bool
+
+	// ExclusionOperators returns the operators used to compare the key columns
+	// of an exclusion constraint, or nil if it is not one.
+	ExclusionOperators() []string
 }
 
 // PrimaryKeySwap is an interface around a primary key swap mutation.
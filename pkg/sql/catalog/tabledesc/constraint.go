@@ -117,6 +117,16 @@ func (c checkConstraint) String() string {
 	return fmt.Sprintf("%+v", c.desc)
 }
 
+// GetDeferrability implements the catalog.Constraint interface.
+func (c checkConstraint) GetDeferrability() descpb.ConstraintDeferrability {
+	return c.desc.Deferrability
+}
+
+// IsDeferrable implements the catalog.Constraint interface.
+func (c checkConstraint) IsDeferrable() bool {
+	return c.desc.Deferrability != descpb.ConstraintDeferrability_NotDeferrable
+}
+
 // IsEnforced implements the catalog.Constraint interface.
 func (c checkConstraint) IsEnforced() bool {
 	return !c.IsMutation() || c.WriteAndDeleteOnly()
@@ -146,7 +156,19 @@ func (c uniqueWithoutIndexConstraint) ParentTableID() descpb.ID {
 func (c uniqueWithoutIndexConstraint) IsValidReferencedUniqueConstraint(
 	fk catalog.ForeignKeyConstraint,
 ) bool {
-	return !c.IsPartial() && descpb.ColumnIDs(c.desc.ColumnIDs).PermutationOf(fk.ForeignKeyDesc().ReferencedColumnIDs)
+	return !c.IsPartial() && !c.IsExclusion() &&
+		descpb.ColumnIDs(c.desc.ColumnIDs).PermutationOf(fk.ForeignKeyDesc().ReferencedColumnIDs)
+}
+
+// IsExclusion implements the catalog.UniqueWithoutIndexConstraint interface.
+func (c uniqueWithoutIndexConstraint) IsExclusion() bool {
+	return c.desc.IsExclusion()
+}
+
+// ExclusionOperators implements the catalog.UniqueWithoutIndexConstraint
+// interface.
+func (c uniqueWithoutIndexConstraint) ExclusionOperators() []string {
+	return c.desc.ExclusionOperators
 }
 
 // NumKeyColumns implements the catalog.UniqueConstraint interface.
@@ -210,6 +232,16 @@ func (c uniqueWithoutIndexConstraint) String() string {
 	return fmt.Sprintf("%+v", c.desc)
 }
 
+// GetDeferrability implements the catalog.Constraint interface.
+func (c uniqueWithoutIndexConstraint) GetDeferrability() descpb.ConstraintDeferrability {
+	return c.desc.Deferrability
+}
+
+// IsDeferrable implements the catalog.Constraint interface.
+func (c uniqueWithoutIndexConstraint) IsDeferrable() bool {
+	return c.desc.Deferrability != descpb.ConstraintDeferrability_NotDeferrable
+}
+
 // IsEnforced implements the catalog.Constraint interface.
 func (c uniqueWithoutIndexConstraint) IsEnforced() bool {
 	return !c.IsMutation() || c.WriteAndDeleteOnly()
@@ -322,6 +354,16 @@ func (c foreignKeyConstraint) String() string {
 	return fmt.Sprintf("%+v", c.desc)
 }
 
+// GetDeferrability implements the catalog.Constraint interface.
+func (c foreignKeyConstraint) GetDeferrability() descpb.ConstraintDeferrability {
+	return c.desc.Deferrability
+}
+
+// IsDeferrable implements the catalog.Constraint interface.
+func (c foreignKeyConstraint) IsDeferrable() bool {
+	return c.desc.Deferrability != descpb.ConstraintDeferrability_NotDeferrable
+}
+
 // IsEnforced implements the catalog.Constraint interface.
 func (c foreignKeyConstraint) IsEnforced() bool {
 	return !c.IsMutation() || c.WriteAndDeleteOnly()
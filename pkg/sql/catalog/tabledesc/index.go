@@ -102,6 +102,17 @@ func (w index) IsSharded() bool {
 	return w.desc.IsSharded()
 }
 
+// IsVector returns true iff the index is a vector index.
+func (w index) IsVector() bool {
+	return w.desc.IsVector()
+}
+
+// HasTermStatistics returns true iff the index stores the term statistics of
+// a TSVECTOR column.
+func (w index) HasTermStatistics() bool {
+	return w.desc.TermStatistics
+}
+
 // IsNotVisible returns true iff the index is not visible.
 func (w index) IsNotVisible() bool {
 	return w.desc.NotVisible
@@ -269,6 +280,11 @@ func (w index) GetSharded() catpb.ShardedDescriptor {
 	return w.desc.Sharded
 }
 
+// GetVector returns the VectorIndexDescriptor in the index descriptor.
+func (w index) GetVector() catpb.VectorIndexDescriptor {
+	return w.desc.Vector
+}
+
 // GetShardColumnName returns the name of the shard column if the index is hash
 // sharded, empty string otherwise.
 func (w index) GetShardColumnName() string {
@@ -505,6 +521,17 @@ func (w index) GetConstraintValidity() descpb.ConstraintValidity {
 	return descpb.ConstraintValidity_Validated
 }
 
+// GetDeferrability implements the catalog.Constraint interface. Constraints
+// which are backed by an index are never deferrable.
+func (w index) GetDeferrability() descpb.ConstraintDeferrability {
+	return descpb.ConstraintDeferrability_NotDeferrable
+}
+
+// IsDeferrable implements the catalog.Constraint interface.
+func (w index) IsDeferrable() bool {
+	return false
+}
+
 // IsEnforced implements the catalog.Constraint interface.
 func (w index) IsEnforced() bool {
 	return !w.IsMutation() || w.WriteAndDeleteOnly()
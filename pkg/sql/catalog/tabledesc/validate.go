@@ -1380,14 +1380,21 @@ func (desc *wrapper) validateTriggers() error {
 			return err
 		}
 
-		// Verify that the trigger function ID is valid.
-		if trigger.FuncID == descpb.InvalidID {
+		// Verify that the trigger function ID is valid. Builtin trigger functions
+		// are referenced by OID instead.
+		if trigger.BuiltinFuncOID != 0 {
+			if trigger.FuncID != descpb.InvalidID {
+				return errors.Newf("trigger %q references both function id %d and builtin function oid %d",
+					trigger.Name, trigger.FuncID, trigger.BuiltinFuncOID)
+			}
+		} else if trigger.FuncID == descpb.InvalidID {
 			return errors.Newf("invalid function id %d in trigger %q", trigger.FuncID, trigger.Name)
-		}
-		routineIDs := catalog.MakeDescriptorIDSet(trigger.DependsOnRoutines...)
-		if !routineIDs.Contains(trigger.FuncID) {
-			return errors.Newf("expected function id %d to be in depends-on-routines for trigger %q",
-				trigger.FuncID, trigger.Name)
+		} else {
+			routineIDs := catalog.MakeDescriptorIDSet(trigger.DependsOnRoutines...)
+			if !routineIDs.Contains(trigger.FuncID) {
+				return errors.Newf("expected function id %d to be in depends-on-routines for trigger %q",
+					trigger.FuncID, trigger.Name)
+			}
 		}
 
 		// Verify that the trigger's references are valid. Note that the existence
@@ -1495,6 +1502,23 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 			seen.Add(int(colID))
 		}
 
+		if c.IsExclusion() {
+			ops := c.ExclusionOperators()
+			if len(ops) != c.NumKeyColumns() {
+				return errors.Newf(
+					"exclusion constraint %q has %d operators for %d columns",
+					c.GetName(), len(ops), c.NumKeyColumns(),
+				)
+			}
+			for _, op := range ops {
+				if op != "=" && op != "&&" {
+					return errors.Newf(
+						"exclusion constraint %q has unsupported operator %q", c.GetName(), op,
+					)
+				}
+			}
+		}
+
 		if c.IsPartial() {
 			expr, err := parser.ParseExpr(c.GetPredicate())
 			if err != nil {
@@ -1663,6 +1687,28 @@ func (desc *wrapper) validateTableIndexes(
 					idx.GetName(), idx.GetSharded().Name)
 			}
 		}
+		if idx.IsVector() {
+			vecColID := idx.GetVector().ColumnID
+			if _, exists := columnsByID[vecColID]; !exists {
+				return errors.Newf("vector index %q refers to unknown column ID %d",
+					idx.GetName(), vecColID)
+			}
+			if !idx.CollectSecondaryStoredColumnIDs().Contains(vecColID) {
+				return errors.Newf("vector index %q does not store vector column ID %d",
+					idx.GetName(), vecColID)
+			}
+		}
+		if idx.HasTermStatistics() {
+			if idx.GetType() != descpb.IndexDescriptor_INVERTED || idx.NumKeyColumns() != 1 {
+				return errors.Newf("index %q with term statistics is not a single-column inverted index",
+					idx.GetName())
+			}
+			col, exists := columnsByID[idx.InvertedColumnID()]
+			if !exists || col.GetType().Family() != types.TSVectorFamily {
+				return errors.Newf("index %q with term statistics is not on a TSVECTOR column",
+					idx.GetName())
+			}
+		}
 		if idx.IsPartial() {
 			expr, err := parser.ParseExpr(idx.GetPredicate())
 			if err != nil {
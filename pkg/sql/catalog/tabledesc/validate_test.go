@@ -228,17 +228,20 @@ var validationMap = []struct {
 			"OnUpdate":            {status: thisFieldReferencesNoObjects},
 			"Match":               {status: thisFieldReferencesNoObjects},
 			"ConstraintID":        {status: iSolemnlySwearThisFieldIsValidated},
+			"Deferrability":       {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
 		obj: descpb.UniqueWithoutIndexConstraint{},
 		fieldMap: map[string]validationStatusInfo{
-			"TableID":      {status: iSolemnlySwearThisFieldIsValidated},
-			"ColumnIDs":    {status: iSolemnlySwearThisFieldIsValidated},
-			"Name":         {status: thisFieldReferencesNoObjects},
-			"Validity":     {status: thisFieldReferencesNoObjects},
-			"Predicate":    {status: iSolemnlySwearThisFieldIsValidated},
-			"ConstraintID": {status: iSolemnlySwearThisFieldIsValidated},
+			"TableID":            {status: iSolemnlySwearThisFieldIsValidated},
+			"ColumnIDs":          {status: iSolemnlySwearThisFieldIsValidated},
+			"Name":               {status: thisFieldReferencesNoObjects},
+			"Validity":           {status: thisFieldReferencesNoObjects},
+			"Predicate":          {status: iSolemnlySwearThisFieldIsValidated},
+			"ConstraintID":       {status: iSolemnlySwearThisFieldIsValidated},
+			"Deferrability":      {status: thisFieldReferencesNoObjects},
+			"ExclusionOperators": {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -1788,6 +1791,35 @@ func TestValidateTableDesc(t *testing.T) {
 					},
 				},
 			}},
+		{err: `exclusion constraint "bar_excl" has 1 operators for 2 columns`,
+			desc: descpb.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: descpb.InterleavedFormatVersion,
+				Columns: []descpb.ColumnDescriptor{
+					{ID: 1, Name: "bar"},
+					{ID: 2, Name: "baz"},
+				},
+				Families: []descpb.ColumnFamilyDescriptor{
+					{ID: 0, Name: "primary",
+						ColumnIDs:   []descpb.ColumnID{1, 2},
+						ColumnNames: []string{"bar", "baz"},
+					},
+				},
+				NextColumnID:     3,
+				NextFamilyID:     1,
+				NextConstraintID: 2,
+				UniqueWithoutIndexConstraints: []descpb.UniqueWithoutIndexConstraint{
+					{
+						TableID:            2,
+						ConstraintID:       1,
+						ColumnIDs:          []descpb.ColumnID{1, 2},
+						Name:               "bar_excl",
+						ExclusionOperators: []string{"&&"},
+					},
+				},
+			}},
 		{err: `empty constraint name`,
 			desc: descpb.TableDescriptor{
 				ID:            2,
@@ -154,4 +154,17 @@ func ensureTypeMetadataIsHydrated(
 			}
 		}
 	}
+	if d := maybeDesc.AsDomainTypeDescriptor(); d != nil {
+		tm.DomainData = &types.DomainMetadata{
+			NotNull: d.IsNotNull(),
+			Checks:  make([]types.DomainCheck, d.NumCheckConstraints()),
+		}
+		if def, ok := d.GetDefaultExpr(); ok {
+			tm.DomainData.DefaultExpr = &def
+		}
+		for i := range tm.DomainData.Checks {
+			name, expr := d.GetCheckConstraint(i)
+			tm.DomainData.Checks[i] = types.DomainCheck{Name: name, Expr: expr}
+		}
+	}
 }
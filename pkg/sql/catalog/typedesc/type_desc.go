@@ -38,6 +38,7 @@ var _ catalog.EnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.RegionEnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.AliasTypeDescriptor = (*immutable)(nil)
 var _ catalog.CompositeTypeDescriptor = (*immutable)(nil)
+var _ catalog.DomainTypeDescriptor = (*immutable)(nil)
 var _ catalog.TypeDescriptor = (*Mutable)(nil)
 var _ catalog.MutableDescriptor = (*Mutable)(nil)
 
@@ -498,6 +499,10 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.Composite == nil {
 			vea.Report(errors.AssertionFailedf("COMPOSITE type desc has nil composite type"))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		if desc.Domain == nil || desc.Domain.BaseType == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil base type"))
+		}
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -604,6 +609,14 @@ func (desc *immutable) ValidateForwardReferences(
 		}
 	}
 
+	if d := desc.AsDomainTypeDescriptor(); d != nil && d.BaseType().UserDefined() {
+		// Domains over user-defined types are not supported, but this should be
+		// validated elsewhere.
+		vea.Report(errors.AssertionFailedf("invalid reference to user-defined type %q from domain %q",
+			d.BaseType().String(), desc.GetName(),
+		))
+	}
+
 	if c := desc.AsCompositeTypeDescriptor(); c != nil {
 		for i := 0; i < c.NumElements(); i++ {
 			t := c.GetElementType(i)
@@ -765,6 +778,12 @@ func (desc *immutable) AsTypesT() *types.T {
 			contents,
 			labels,
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		return types.MakeDomain(
+			desc.Domain.BaseType,
+			catid.TypeIDToOID(desc.GetID()),
+			catid.TypeIDToOID(desc.ArrayTypeID),
+		)
 	}
 	panic(errors.AssertionFailedf("unsupported descriptor kind %s", desc.Kind.String()))
 }
@@ -1019,6 +1038,14 @@ func (desc *immutable) AsCompositeTypeDescriptor() catalog.CompositeTypeDescript
 	return nil
 }
 
+// AsDomainTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (desc *immutable) AsDomainTypeDescriptor() catalog.DomainTypeDescriptor {
+	if desc.Kind == descpb.TypeDescriptor_DOMAIN {
+		return desc
+	}
+	return nil
+}
+
 // AsTableImplicitRecordTypeDescriptor implements the catalog.TypeDescriptor
 // interface.
 func (desc *immutable) AsTableImplicitRecordTypeDescriptor() catalog.TableImplicitRecordTypeDescriptor {
@@ -1045,6 +1072,35 @@ func (desc *immutable) GetElementType(ordinal int) *types.T {
 	return desc.Composite.Elements[ordinal].ElementType
 }
 
+// BaseType implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) BaseType() *types.T {
+	return desc.Domain.BaseType
+}
+
+// GetDefaultExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetDefaultExpr() (string, bool) {
+	if desc.Domain.DefaultExpr == nil {
+		return "", false
+	}
+	return *desc.Domain.DefaultExpr, true
+}
+
+// IsNotNull implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) IsNotNull() bool {
+	return desc.Domain.NotNull
+}
+
+// NumCheckConstraints implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) NumCheckConstraints() int {
+	return len(desc.Domain.CheckConstraints)
+}
+
+// GetCheckConstraint implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetCheckConstraint(ordinal int) (name string, expr string) {
+	c := &desc.Domain.CheckConstraints[ordinal]
+	return c.Name, c.Expr
+}
+
 // ForEachRegionInSuperRegion implements the catalog.RegionEnumTypeDescriptor
 // interface.
 func (desc *immutable) ForEachRegionInSuperRegion(
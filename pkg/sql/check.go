@@ -500,12 +500,10 @@ func (p *planner) RevalidateUniqueConstraint(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.GetName() == constraintName {
-			return validateUniqueConstraint(
+			return validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc,
 				0, /* indexIDForValidation */
 				p.InternalSQLTxn(),
 				p.User(),
@@ -580,12 +578,10 @@ func RevalidateUniqueConstraintsInTable(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.IsConstraintValidated() {
-			if err := validateUniqueConstraint(
+			if err := validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc,
 				0, /* indexIDForValidation */
 				txn,
 				user,
@@ -639,6 +635,213 @@ func validateUniqueConstraint(
 		query,
 	)
 
+	values, err := queryValidationRowWithRetry(ctx, txn, "validate unique constraint", user, query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add a unique index due to duplicated keys.
+		errMsg := "could not create unique constraint"
+		if preExisting {
+			errMsg = "failed to validate unique constraint"
+		}
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.UniqueViolation, "%s %q", errMsg, constraintName,
+				),
+				constraintName,
+			),
+			fmt.Sprintf(
+				"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
+			),
+		)
+	}
+	return nil
+}
+
+// validateUniqueWithoutIndexConstraint verifies that the rows in srcTable
+// satisfy the given UNIQUE WITHOUT INDEX or exclusion constraint. The
+// arguments are as in validateUniqueConstraint.
+func validateUniqueWithoutIndexConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uwi catalog.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	if uwi.IsExclusion() {
+		return validateExclusionConstraint(
+			ctx, srcTable, uwi, indexIDForValidation, txn, user, preExisting,
+		)
+	}
+	return validateUniqueConstraint(
+		ctx,
+		srcTable,
+		uwi.GetName(),
+		uwi.CollectKeyColumnIDs().Ordered(),
+		uwi.GetPredicate(),
+		indexIDForValidation,
+		txn,
+		user,
+		preExisting,
+	)
+}
+
+// exclusionViolationQuery generates and returns a query that returns the key
+// columns of a pair of rows which conflict according to the given exclusion
+// constraint. The query has the form:
+//
+//	SELECT t1.c0, t1.c1, t2.c0, t2.c1
+//	FROM (
+//	  SELECT a AS c0, b AS c1, k AS p0 FROM [tbl_id AS tbl]
+//	  WHERE a IS NOT NULL AND b IS NOT NULL AND (pred)
+//	) AS t1
+//	JOIN (
+//	  SELECT a AS c0, b AS c1, k AS p0 FROM [tbl_id AS tbl]
+//	  WHERE a IS NOT NULL AND b IS NOT NULL AND (pred)
+//	) AS t2
+//	ON t1.c0 = t2.c0 AND t1.c1 && t2.c1 AND (t1.p0) != (t2.p0)
+//	LIMIT 1
+//
+// The conflicting rows are distinguished by their primary key columns k.
+// `indexIDForValidation`, if non-zero, will be used to force the sql query to
+// use this particular index by hinting the query.
+func exclusionViolationQuery(
+	srcTbl catalog.TableDescriptor,
+	uwi catalog.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+) (sql string, colNames []string, _ error) {
+	columnIDs := make([]descpb.ColumnID, uwi.NumKeyColumns())
+	for i := range columnIDs {
+		columnIDs[i] = uwi.GetKeyColumnID(i)
+	}
+	colNames, err := catalog.ColumnNamesForIDs(srcTbl, columnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	pkColNames, err := catalog.ColumnNamesForIDs(srcTbl, srcTbl.GetPrimaryIndex().IndexDesc().KeyColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+
+	srcCols := make([]string, 0, len(colNames)+len(pkColNames))
+	srcWhere := make([]string, 0, len(colNames)+1)
+	on := make([]string, 0, len(colNames)+1)
+	res := make([]string, 0, 2*len(colNames))
+	ops := uwi.ExclusionOperators()
+	for i, n := range colNames {
+		srcCols = append(srcCols, fmt.Sprintf("%s AS c%d", tree.NameString(n), i))
+		srcWhere = append(srcWhere, fmt.Sprintf("%s IS NOT NULL", tree.NameString(n)))
+		on = append(on, fmt.Sprintf("t1.c%[1]d %[2]s t2.c%[1]d", i, ops[i]))
+		res = append(res, fmt.Sprintf("t1.c%d", i))
+	}
+	for i := range colNames {
+		res = append(res, fmt.Sprintf("t2.c%d", i))
+	}
+	t1PK := make([]string, len(pkColNames))
+	t2PK := make([]string, len(pkColNames))
+	for i, n := range pkColNames {
+		srcCols = append(srcCols, fmt.Sprintf("%s AS p%d", tree.NameString(n), i))
+		t1PK[i] = fmt.Sprintf("t1.p%d", i)
+		t2PK[i] = fmt.Sprintf("t2.p%d", i)
+	}
+	on = append(on, fmt.Sprintf(
+		"(%s) != (%s)", strings.Join(t1PK, ", "), strings.Join(t2PK, ", "),
+	))
+	if pred := uwi.GetPredicate(); pred != "" {
+		srcWhere = append(srcWhere, fmt.Sprintf("(%s)", pred))
+	}
+
+	hint := ""
+	if indexIDForValidation != 0 {
+		hint = fmt.Sprintf("@[%d]", indexIDForValidation)
+	}
+	src := fmt.Sprintf(
+		`SELECT %[1]s FROM [%[2]d AS tbl]%[3]s WHERE %[4]s`,
+		strings.Join(srcCols, ", "),     // 1
+		srcTbl.GetID(),                  // 2
+		hint,                            // 3
+		strings.Join(srcWhere, " AND "), // 4
+	)
+	query := fmt.Sprintf(
+		`SELECT %[1]s FROM (%[2]s) AS t1 JOIN (%[2]s) AS t2 ON %[3]s LIMIT 1`,
+		strings.Join(res, ", "),   // 1
+		src,                       // 2
+		strings.Join(on, " AND "), // 3
+	)
+	return query, colNames, nil
+}
+
+// validateExclusionConstraint verifies that no two rows in the srcTable
+// conflict according to the given exclusion constraint. The arguments are as
+// in validateUniqueConstraint.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uwi catalog.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	query, colNames, err := exclusionViolationQuery(srcTable, uwi, indexIDForValidation)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		uwi.GetName(),
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	values, err := queryValidationRowWithRetry(ctx, txn, "validate exclusion constraint", user, query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add an exclusion constraint due to conflicting keys.
+		errMsg := "could not create exclusion constraint"
+		if preExisting {
+			errMsg = "failed to validate exclusion constraint"
+		}
+		cols := strings.Join(colNames, ", ")
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.ExclusionViolation, "%s %q", errMsg, uwi.GetName(),
+				),
+				uwi.GetName(),
+			),
+			fmt.Sprintf(
+				"Key (%s)=(%s) conflicts with key (%s)=(%s).",
+				cols, strings.Join(valuesStr[:len(colNames)], ", "),
+				cols, strings.Join(valuesStr[len(colNames):], ", "),
+			),
+		)
+	}
+	return nil
+}
+
+// queryValidationRowWithRetry runs a query which validates a constraint and
+// returns its first row, if any.
+func queryValidationRowWithRetry(
+	ctx context.Context, txn isql.Txn, opName string, user username.SQLUsername, query string,
+) (tree.Datums, error) {
 	sessionDataOverride := sessiondata.NoSessionDataOverride
 	sessionDataOverride.User = user
 	// We are likely to have performed a lot of work before getting here (e.g.
@@ -652,13 +855,14 @@ func validateUniqueConstraint(
 	// retries in order to not waste (a lot of) work that was performed before
 	// we got here.
 	var values tree.Datums
+	var err error
 	retryOptions := retry.Options{
 		InitialBackoff: 20 * time.Millisecond,
 		Multiplier:     1.5,
 		MaxRetries:     5,
 	}
 	for r := retry.StartWithCtx(ctx, retryOptions); r.Next(); {
-		values, err = txn.QueryRowEx(ctx, "validate unique constraint", txn.KV(), sessionDataOverride, query)
+		values, err = txn.QueryRowEx(ctx, opName, txn.KV(), sessionDataOverride, query)
 		if err == nil {
 			break
 		}
@@ -669,32 +873,9 @@ func validateUniqueConstraint(
 			log.Infof(ctx, "retrying the validation query because of %v", err)
 			continue
 		}
-		return err
+		return nil, err
 	}
-	if values.Len() > 0 {
-		valuesStr := make([]string, len(values))
-		for i := range values {
-			valuesStr[i] = values[i].String()
-		}
-		// Note: this error message mirrors the message produced by Postgres
-		// when it fails to add a unique index due to duplicated keys.
-		errMsg := "could not create unique constraint"
-		if preExisting {
-			errMsg = "failed to validate unique constraint"
-		}
-		return errors.WithDetail(
-			pgerror.WithConstraintName(
-				pgerror.Newf(
-					pgcode.UniqueViolation, "%s %q", errMsg, constraintName,
-				),
-				constraintName,
-			),
-			fmt.Sprintf(
-				"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
-			),
-		)
-	}
-	return nil
+	return values, err
 }
 
 // ValidateTTLScheduledJobsInCurrentDB is part of the EvalPlanner interface.
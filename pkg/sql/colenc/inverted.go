@@ -66,12 +66,22 @@ func (b *BatchEncoder) encodeInvertedSecondaryIndex(
 			if keys, err = rowenc.EncodeInvertedIndexTableKeys(val, kys[row], index.GetVersion()); err != nil {
 				return err
 			}
+			keys = rowenc.AppendInvertedIndexDocumentKey(index, keys, kys[row])
 		}
-		for _, key := range keys {
+		var termFreqs []int
+		var docLength int
+		if index.HasTermStatistics() {
+			termFreqs, docLength = rowenc.InvertedIndexTermStatistics(val)
+		}
+		for i, key := range keys {
 			if !index.IsUnique() {
 				key = append(key, extraKeys[row]...)
 			}
-			if err = b.encodeInvertedSecondaryIndexNoFamiliesOneRow(index, key, row); err != nil {
+			var termStats []byte
+			if termFreqs != nil {
+				termStats = rowenc.EncodeInvertedIndexStatistics(termFreqs, docLength, i)
+			}
+			if err = b.encodeInvertedSecondaryIndexNoFamiliesOneRow(index, key, row, termStats); err != nil {
 				return err
 			}
 		}
@@ -81,7 +91,7 @@ func (b *BatchEncoder) encodeInvertedSecondaryIndex(
 }
 
 func (b *BatchEncoder) encodeInvertedSecondaryIndexNoFamiliesOneRow(
-	ind catalog.Index, key roachpb.Key, row int,
+	ind catalog.Index, key roachpb.Key, row int, termStats []byte,
 ) error {
 	var value []byte
 	// If we aren't encoding index keys with families, all index keys use the sentinel family 0.
@@ -92,6 +102,7 @@ func (b *BatchEncoder) encodeInvertedSecondaryIndexNoFamiliesOneRow(
 	if err != nil {
 		return err
 	}
+	value = append(value, termStats...)
 	var kvValue roachpb.Value
 	kvValue.SetBytes(value)
 	b.p.InitPut(&key, &kvValue, false)
@@ -128,7 +128,7 @@ type opResult struct {
 // vectorized engine (neither natively nor by wrapping the corresponding row
 // execution processor).
 func IsSupported(mode sessiondatapb.VectorizeExecMode, spec *execinfrapb.ProcessorSpec) error {
-	err := supportedNatively(&spec.Core)
+	err := supportedNatively(spec)
 	if err != nil {
 		if wrapErr := canWrap(mode, &spec.Core); wrapErr == nil {
 			// We don't support this spec natively, but we can wrap the row
@@ -140,9 +140,10 @@ func IsSupported(mode sessiondatapb.VectorizeExecMode, spec *execinfrapb.Process
 }
 
 // supportedNatively checks whether we have a columnar operator equivalent to a
-// processor described by core. Note that it doesn't perform any other checks
+// processor described by spec. Note that it doesn't perform any other checks
 // (like validity of the number of inputs).
-func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
+func supportedNatively(spec *execinfrapb.ProcessorSpec) error {
+	core := &spec.Core
 	switch {
 	case core.Noop != nil:
 		return nil
@@ -200,7 +201,13 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 				return errWindowFunctionFilterClause
 			}
 			if wf.Func.AggregateFunc != nil {
-				if !colexecagg.IsAggOptimized(*wf.Func.AggregateFunc) {
+				var argTypes []*types.T
+				if len(spec.Input) > 0 {
+					for _, idx := range wf.ArgsIdxs {
+						argTypes = append(argTypes, spec.Input[0].ColumnTypes[idx])
+					}
+				}
+				if !colexecagg.IsAggOptimizedForArgs(*wf.Func.AggregateFunc, argTypes) {
 					return errDefaultAggregateWindowFunction
 				}
 			}
@@ -762,7 +769,7 @@ func NewColOperator(
 	core := &spec.Core
 	post := &spec.Post
 
-	if err = supportedNatively(core); err != nil {
+	if err = supportedNatively(spec); err != nil {
 		inputTypes := make([][]*types.T, len(spec.Input))
 		for inputIdx, input := range spec.Input {
 			inputTypes[inputIdx] = input.ColumnTypes
@@ -972,12 +979,21 @@ func NewColOperator(
 				return r, err
 			}
 			aggSpec := core.Aggregator
-			if aggSpec.IsRowCount() {
+			if aggSpec.IsRowCount() && len(aggSpec.GroupingSets) == 0 {
 				result.Root, err = colexec.NewCountOp(getStreamingAllocator(ctx, args, flowCtx), inputs[0].Root), nil
 				result.ColumnTypes = []*types.T{types.Int}
 				break
 			}
 
+			input, inputTypes := inputs[0].Root, spec.Input[0].ColumnTypes
+			if len(aggSpec.GroupingSets) > 0 {
+				// The aggregation over grouping sets is performed by a hash
+				// aggregation over the expanded input; see
+				// colexec.ExpandGroupingSets.
+				input, inputTypes, aggSpec = colexec.ExpandGroupingSets(
+					getStreamingAllocator(ctx, args, flowCtx), input, inputTypes, aggSpec,
+				)
+			}
 			var needHash bool
 			needHash, err = execagg.NeedHashAggregator(aggSpec)
 			if err != nil {
@@ -986,14 +1002,14 @@ func NewColOperator(
 			// Make a copy of the evalCtx since we're modifying it below.
 			evalCtx := flowCtx.NewEvalCtx()
 			newAggArgs := &colexecagg.NewAggregatorArgs{
-				Input:             inputs[0].Root,
-				InputTypes:        spec.Input[0].ColumnTypes,
+				Input:             input,
+				InputTypes:        inputTypes,
 				Spec:              aggSpec,
 				EvalCtx:           evalCtx,
 				EstimatedRowCount: args.Spec.EstimatedRowCount,
 			}
 			newAggArgs.Constructors, newAggArgs.ConstArguments, newAggArgs.OutputTypes, err = colexecagg.ProcessAggregations(
-				ctx, evalCtx, args.SemaCtx, aggSpec.Aggregations, spec.Input[0].ColumnTypes,
+				ctx, evalCtx, args.SemaCtx, aggSpec.Aggregations, inputTypes,
 			)
 			if err != nil {
 				return r, err
@@ -1036,7 +1052,7 @@ func NewColOperator(
 					newHashAggArgs, sqArgs, hashAggregatorMemMonitorName := makeNewHashAggregatorArgs(
 						ctx, flowCtx, args, opName, newAggArgs, factory,
 					)
-					sqArgs.Types = spec.Input[0].ColumnTypes
+					sqArgs.Types = inputTypes
 					inMemoryHashAggregator := colexec.NewHashAggregator(
 						ctx, newHashAggArgs, sqArgs,
 					)
@@ -1053,7 +1069,7 @@ func NewColOperator(
 					// error even when used by the external hash aggregator).
 					evalCtx.SingleDatumAggMemAccount = ehaMemAccount
 					diskSpiller := colexecdisk.NewOneInputDiskSpiller(
-						inputs[0].Root, inMemoryHashAggregator.(colexecop.BufferingInMemoryOperator),
+						input, inMemoryHashAggregator.(colexecop.BufferingInMemoryOperator),
 						hashAggregatorMemMonitorName,
 						func(input colexecop.Operator) colexecop.Operator {
 							newAggArgs := *newAggArgs
@@ -1093,6 +1109,28 @@ func NewColOperator(
 				result.Root = colexec.NewOrderedAggregator(ctx, newAggArgs)
 				result.ToClose = append(result.ToClose, result.Root.(colexecop.Closer))
 			}
+			if len(core.Aggregator.GroupingSets) > 0 {
+				// The empty grouping sets produce a row even if the input is
+				// empty. The results of the aggregate functions in these rows are
+				// those of a scalar aggregation over the original input.
+				scalarAggArgs := &colexecagg.NewAggregatorArgs{
+					Allocator:  getStreamingAllocator(ctx, args, flowCtx),
+					InputTypes: spec.Input[0].ColumnTypes,
+					Spec:       core.Aggregator,
+					EvalCtx:    evalCtx,
+				}
+				scalarAggArgs.Constructors, scalarAggArgs.ConstArguments, scalarAggArgs.OutputTypes, err = colexecagg.ProcessAggregations(
+					ctx, evalCtx, args.SemaCtx, core.Aggregator.Aggregations, spec.Input[0].ColumnTypes,
+				)
+				if err != nil {
+					return r, err
+				}
+				emptyInputOp := colexec.NewGroupingSetsEmptyInputOp(
+					ctx, result.Root, result.ColumnTypes, scalarAggArgs,
+				)
+				result.Root = emptyInputOp
+				result.ToClose = append(result.ToClose, emptyInputOp)
+			}
 
 		case core.Distinct != nil:
 			if err := checkNumIn(inputs, 1); err != nil {
@@ -1619,6 +1657,20 @@ func NewColOperator(
 						result.Root, err = colexecwindow.NewNthValueOperator(
 							windowArgs, wf.Frame, &wf.Ordering, argIdxs)
 						returnType = result.ColumnTypes[argIdxs[0]]
+					case execinfrapb.WindowerSpec_ST_CLUSTERDBSCAN:
+						opName := opNamePrefix + "st_clusterdbscan"
+						result.finishBufferedWindowerArgs(
+							ctx, flowCtx, args.MonitorRegistry, windowArgs, opName,
+							spec.ProcessorID, factory, true, /* needsBuffer */
+						)
+						result.Root = colexecwindow.NewSTClusterDBSCANOperator(windowArgs, argIdxs)
+					case execinfrapb.WindowerSpec_ST_CLUSTERKMEANS:
+						opName := opNamePrefix + "st_clusterkmeans"
+						result.finishBufferedWindowerArgs(
+							ctx, flowCtx, args.MonitorRegistry, windowArgs, opName,
+							spec.ProcessorID, factory, true, /* needsBuffer */
+						)
+						result.Root = colexecwindow.NewSTClusterKMeansOperator(windowArgs, argIdxs)
 					default:
 						return r, errors.AssertionFailedf("window function %s is not supported", wf.String())
 					}
@@ -44,6 +44,32 @@ func IsAggOptimized(aggFn execinfrapb.AggregatorSpec_Func) bool {
 	}
 }
 
+// IsAggOptimizedForArgs returns whether aggFn has an optimized implementation
+// for arguments of the given types. Some aggregate functions have an optimized
+// implementation for only a subset of the types they are defined on.
+func IsAggOptimizedForArgs(aggFn execinfrapb.AggregatorSpec_Func, argTypes []*types.T) bool {
+	if !IsAggOptimized(aggFn) {
+		return false
+	}
+	if aggFn == execinfrapb.Sum && len(argTypes) > 0 && argTypes[0].Family() == types.MoneyFamily {
+		// There is no optimized implementation of sum for money values.
+		return false
+	}
+	return true
+}
+
+// defaultAggFunc is used in place of an aggregate function that has an
+// optimized implementation, but not for the types of its arguments.
+const defaultAggFunc = execinfrapb.AggregatorSpec_Func(-1)
+
+func aggArgTypes(aggFn *execinfrapb.AggregatorSpec_Aggregation, inputTypes []*types.T) []*types.T {
+	argTypes := make([]*types.T, len(aggFn.ColIdx))
+	for i, colIdx := range aggFn.ColIdx {
+		argTypes[i] = inputTypes[colIdx]
+	}
+	return argTypes
+}
+
 // We will be sharing aggregateFuncAllocs between different columns, and in
 // order to quickly determine whether a particular aggregate overload has
 // already been created, we'll operate on a stack-allocated array of
@@ -260,7 +286,7 @@ func NewAggregateFuncsAlloc(
 	var toClose colexecop.Closers
 	var vecIdxsToConvert []int
 	for _, aggFn := range aggregations {
-		if !IsAggOptimized(aggFn.Func) {
+		if !IsAggOptimizedForArgs(aggFn.Func, aggArgTypes(&aggFn, args.InputTypes)) {
 			for _, vecIdx := range aggFn.ColIdx {
 				found := false
 				for i := range vecIdxsToConvert {
@@ -299,7 +325,11 @@ func NewAggregateFuncsAlloc(
 		// created.
 		var freshAllocator bool
 		var err error
-		switch aggFn.Func {
+		aggFunc := aggFn.Func
+		if !IsAggOptimizedForArgs(aggFunc, aggArgTypes(&aggFn, args.InputTypes)) {
+			aggFunc = defaultAggFunc
+		}
+		switch aggFunc {
 		case execinfrapb.AnyNotNull:
 			firstOverloadIndex = anyNotNullFirstOverload
 			inputType := args.InputTypes[aggFn.ColIdx[0]]
@@ -0,0 +1,167 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package colexecwindow
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geomfn"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// NewSTClusterDBSCANOperator creates a new Operator that computes window
+// function st_clusterdbscan. argIdxs are the indexes of the geometry, eps and
+// minpoints arguments.
+func NewSTClusterDBSCANOperator(args *WindowArgs, argIdxs []int) colexecop.ClosableOperator {
+	return newSTClusterOperator(args, argIdxs, func(gs []*geo.Geometry, params []paramValue) ([]int, error) {
+		return geomfn.ClusterDBSCAN(gs, params[0].float, int(params[1].int))
+	})
+}
+
+// NewSTClusterKMeansOperator creates a new Operator that computes window
+// function st_clusterkmeans. argIdxs are the indexes of the geometry and
+// number_of_clusters arguments.
+func NewSTClusterKMeansOperator(args *WindowArgs, argIdxs []int) colexecop.ClosableOperator {
+	return newSTClusterOperator(args, argIdxs, func(gs []*geo.Geometry, params []paramValue) ([]int, error) {
+		return geomfn.ClusterKMeans(gs, int(params[0].int))
+	})
+}
+
+// paramValue is the value of a parameter of a clustering window function,
+// which is either a float or an int.
+type paramValue struct {
+	float float64
+	int   int64
+}
+
+func newSTClusterOperator(
+	args *WindowArgs,
+	argIdxs []int,
+	cluster func(gs []*geo.Geometry, params []paramValue) ([]int, error),
+) colexecop.ClosableOperator {
+	// Every geometry of the partition is needed to compute the clusters, so the
+	// buffer gets most of the available memory; the queue only needs to hold
+	// the batches of the partition until they are emitted.
+	bufferMemLimit := int64(float64(args.MemoryLimit) * 0.5)
+	mainMemLimit := args.MemoryLimit - bufferMemLimit
+	buffer := colexecutils.NewSpillingBuffer(
+		args.BufferAllocator, bufferMemLimit, args.QueueCfg, args.FdSemaphore,
+		args.InputTypes, args.DiskAcc, args.DiskQueueMemAcc, argIdxs...,
+	)
+	paramTypes := make([]*types.T, len(argIdxs)-1)
+	for i := range paramTypes {
+		paramTypes[i] = args.InputTypes[argIdxs[i+1]]
+	}
+	windower := &stClusterWindow{
+		partitionSeekerBase: partitionSeekerBase{
+			buffer:          buffer,
+			partitionColIdx: args.PartitionColIdx,
+		},
+		outputColIdx: args.OutputColIdx,
+		paramTypes:   paramTypes,
+		cluster:      cluster,
+	}
+	return newBufferedWindowOperator(args, windower, types.Int, mainMemLimit)
+}
+
+// stClusterWindow assigns each geometry of a partition to a cluster. The
+// clusters are computed once the whole partition has been buffered, using the
+// parameters of its first row.
+type stClusterWindow struct {
+	partitionSeekerBase
+	colexecop.CloserHelper
+
+	outputColIdx int
+	// paramTypes are the types of the arguments following the geometry.
+	paramTypes []*types.T
+	cluster    func(gs []*geo.Geometry, params []paramValue) ([]int, error)
+
+	// clusters is the cluster number of each row of the current partition, or
+	// nil if the output is null for the entire partition.
+	clusters []int
+	idx      int
+}
+
+var _ bufferedWindower = &stClusterWindow{}
+
+func (w *stClusterWindow) transitionToProcessing() {
+	w.idx = 0
+	w.clusters = nil
+	if w.partitionSize == 0 {
+		return
+	}
+	params := make([]paramValue, len(w.paramTypes))
+	for i, typ := range w.paramTypes {
+		vec, idx, _ := w.buffer.GetVecWithTuple(w.Ctx, i+1 /* colIdx */, 0 /* idx */)
+		if vec.Nulls().MaybeHasNulls() && vec.Nulls().NullAt(idx) {
+			// If any parameter is null, then the result is null for every row.
+			return
+		}
+		if typ.Family() == types.FloatFamily {
+			params[i].float = vec.Float64()[idx]
+		} else {
+			params[i].int = vec.Int64()[idx]
+		}
+	}
+	gs := make([]*geo.Geometry, w.partitionSize)
+	for i := range gs {
+		vec, idx, _ := w.buffer.GetVecWithTuple(w.Ctx, 0 /* colIdx */, i)
+		if vec.Nulls().MaybeHasNulls() && vec.Nulls().NullAt(idx) {
+			continue
+		}
+		gs[i] = &vec.Datum().Get(idx).(*tree.DGeometry).Geometry
+	}
+	var err error
+	if w.clusters, err = w.cluster(gs, params); err != nil {
+		colexecerror.ExpectedError(err)
+	}
+}
+
+func (w *stClusterWindow) processBatch(batch coldata.Batch, startIdx, endIdx int) {
+	if startIdx >= endIdx {
+		// No processing needs to be done for this portion of the current partition.
+		return
+	}
+	outputVec := batch.ColVec(w.outputColIdx)
+	outputCol := outputVec.Int64()
+	outputNulls := outputVec.Nulls()
+	_ = outputCol[startIdx]
+	_ = outputCol[endIdx-1]
+	for i := startIdx; i < endIdx; i++ {
+		if w.clusters == nil || w.clusters[w.idx] == geomfn.NoCluster {
+			outputNulls.SetNull(i)
+		} else {
+			outputCol[i] = int64(w.clusters[w.idx])
+		}
+		w.idx++
+	}
+}
+
+func (w *stClusterWindow) startNewPartition() {
+	w.idx = 0
+	w.clusters = nil
+	w.partitionSize = 0
+	w.buffer.Reset(w.Ctx)
+}
+
+func (w *stClusterWindow) Init(ctx context.Context) {
+	if !w.InitHelper.Init(ctx) {
+		return
+	}
+}
+
+func (w *stClusterWindow) Close(ctx context.Context) {
+	if !w.CloserHelper.Close() {
+		return
+	}
+	w.buffer.Close(ctx)
+}
@@ -1309,6 +1309,12 @@ func BenchmarkWindowFunctions(b *testing.B) {
 
 	for windowFnIdx := 0; windowFnIdx < len(execinfrapb.WindowerSpec_WindowFunc_name); windowFnIdx++ {
 		windowFn := execinfrapb.WindowerSpec_WindowFunc(windowFnIdx)
+		switch windowFn {
+		case execinfrapb.WindowerSpec_ST_CLUSTERDBSCAN, execinfrapb.WindowerSpec_ST_CLUSTERKMEANS:
+			// Skip the clustering functions in order to avoid handling geometry
+			// arguments.
+			continue
+		}
 		numArgs := windowFnMaxNumArgs[windowFn]
 		runBench(execinfrapb.WindowerSpec_Func{WindowFunc: &windowFn}, windowFn.String(), numArgs)
 	}
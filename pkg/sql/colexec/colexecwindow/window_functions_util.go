@@ -40,17 +40,19 @@ type WindowArgs struct {
 // windowFnMaxNumArgs is a mapping from the window function to the maximum
 // number of arguments it takes.
 var windowFnMaxNumArgs = map[execinfrapb.WindowerSpec_WindowFunc]int{
-	execinfrapb.WindowerSpec_ROW_NUMBER:   0,
-	execinfrapb.WindowerSpec_RANK:         0,
-	execinfrapb.WindowerSpec_DENSE_RANK:   0,
-	execinfrapb.WindowerSpec_PERCENT_RANK: 0,
-	execinfrapb.WindowerSpec_CUME_DIST:    0,
-	execinfrapb.WindowerSpec_NTILE:        1,
-	execinfrapb.WindowerSpec_LAG:          3,
-	execinfrapb.WindowerSpec_LEAD:         3,
-	execinfrapb.WindowerSpec_FIRST_VALUE:  1,
-	execinfrapb.WindowerSpec_LAST_VALUE:   1,
-	execinfrapb.WindowerSpec_NTH_VALUE:    2,
+	execinfrapb.WindowerSpec_ROW_NUMBER:       0,
+	execinfrapb.WindowerSpec_RANK:             0,
+	execinfrapb.WindowerSpec_DENSE_RANK:       0,
+	execinfrapb.WindowerSpec_PERCENT_RANK:     0,
+	execinfrapb.WindowerSpec_CUME_DIST:        0,
+	execinfrapb.WindowerSpec_NTILE:            1,
+	execinfrapb.WindowerSpec_LAG:              3,
+	execinfrapb.WindowerSpec_LEAD:             3,
+	execinfrapb.WindowerSpec_FIRST_VALUE:      1,
+	execinfrapb.WindowerSpec_LAST_VALUE:       1,
+	execinfrapb.WindowerSpec_NTH_VALUE:        2,
+	execinfrapb.WindowerSpec_ST_CLUSTERDBSCAN: 3,
+	execinfrapb.WindowerSpec_ST_CLUSTERKMEANS: 2,
 }
 
 // WindowFnNeedsPeersInfo returns whether a window function pays attention to
@@ -66,7 +68,9 @@ func WindowFnNeedsPeersInfo(windowFn *execinfrapb.WindowerSpec_WindowFn) bool {
 			execinfrapb.WindowerSpec_ROW_NUMBER,
 			execinfrapb.WindowerSpec_NTILE,
 			execinfrapb.WindowerSpec_LAG,
-			execinfrapb.WindowerSpec_LEAD:
+			execinfrapb.WindowerSpec_LEAD,
+			execinfrapb.WindowerSpec_ST_CLUSTERDBSCAN,
+			execinfrapb.WindowerSpec_ST_CLUSTERKMEANS:
 			// Functions that ignore the concept of "peers."
 			return false
 		case
@@ -166,6 +170,26 @@ func WindowFnArgCasts(windowFn execinfrapb.WindowerSpec_Func, argTypes []*types.
 			if !argTypes[1].Identical(types.Int) {
 				castTo[1] = types.Int
 			}
+		case execinfrapb.WindowerSpec_ST_CLUSTERDBSCAN:
+			// The first argument is a geometry, followed by a float distance and an
+			// integer number of points.
+			if len(argTypes) != 3 {
+				colexecerror.InternalError(errors.AssertionFailedf("st_clusterdbscan expects exactly three arguments"))
+			}
+			if !argTypes[1].Identical(types.Float) {
+				castTo[1] = types.Float
+			}
+			if !argTypes[2].Identical(types.Int) {
+				castTo[2] = types.Int
+			}
+		case execinfrapb.WindowerSpec_ST_CLUSTERKMEANS:
+			// The first argument is a geometry, and the second must be an integer.
+			if len(argTypes) != 2 {
+				colexecerror.InternalError(errors.AssertionFailedf("st_clusterkmeans expects exactly two arguments"))
+			}
+			if !argTypes[1].Identical(types.Int) {
+				castTo[1] = types.Int
+			}
 		case
 			execinfrapb.WindowerSpec_ROW_NUMBER,
 			execinfrapb.WindowerSpec_RANK,
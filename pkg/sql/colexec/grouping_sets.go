@@ -0,0 +1,288 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package colexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecagg"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// The vectorized engine aggregates over grouping sets, as in GROUP BY GROUPING
+// SETS, ROLLUP and CUBE, with a regular hash aggregator:
+// - the groupingSetsExpander emits every input batch once for each grouping
+//   set, extended with a copy of each group column, which is NULL if the column
+//   isn't part of the grouping set, and with the ordinal of the grouping set.
+//   The copies and the ordinal identify the group of every tuple, while the
+//   aggregate functions still see the original values of the group columns.
+// - the hash aggregator groups by the copies and the ordinal. Its aggregations
+//   are those of the AggregatorSpec, preceded by an any_not_null of each copy
+//   and followed by an any_not_null of the ordinal, so that its output has the
+//   same columns as the row-by-row aggregator over grouping sets.
+// - the groupingSetsEmptyInputOp outputs the row of each empty grouping set if
+//   the input is empty.
+//
+// See ExpandGroupingSets and NewGroupingSetsEmptyInputOp.
+
+// ExpandGroupingSets plans a groupingSetsExpander on top of input. It returns
+// the expander, its output types and the specification of the hash aggregation
+// over its output, which computes the aggregation over the grouping sets of
+// spec.
+func ExpandGroupingSets(
+	allocator *colmem.Allocator,
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	spec *execinfrapb.AggregatorSpec,
+) (colexecop.Operator, []*types.T, *execinfrapb.AggregatorSpec) {
+	numInputCols := len(inputTypes)
+	outputTypes := make([]*types.T, 0, numInputCols+len(spec.GroupCols)+1)
+	outputTypes = append(outputTypes, inputTypes...)
+	for _, c := range spec.GroupCols {
+		outputTypes = append(outputTypes, inputTypes[c])
+	}
+	outputTypes = append(outputTypes, types.Int)
+
+	inSet := make([][]bool, len(spec.GroupingSets))
+	for i, set := range spec.GroupingSets {
+		inSet[i] = make([]bool, len(spec.GroupCols))
+		for _, c := range set.Cols {
+			for j, groupCol := range spec.GroupCols {
+				if groupCol == c {
+					inSet[i][j] = true
+				}
+			}
+		}
+	}
+
+	numAggs := len(spec.GroupCols) + len(spec.Aggregations) + 1
+	aggSpec := &execinfrapb.AggregatorSpec{
+		Type:           execinfrapb.AggregatorSpec_NON_SCALAR,
+		GroupCols:      make([]uint32, 0, len(spec.GroupCols)+1),
+		Aggregations:   make([]execinfrapb.AggregatorSpec_Aggregation, 0, numAggs),
+		OutputOrdering: spec.OutputOrdering,
+	}
+	for i := numInputCols; i < len(outputTypes); i++ {
+		aggSpec.GroupCols = append(aggSpec.GroupCols, uint32(i))
+	}
+	for _, c := range aggSpec.GroupCols[:len(spec.GroupCols)] {
+		aggSpec.Aggregations = append(aggSpec.Aggregations, execinfrapb.AggregatorSpec_Aggregation{
+			Func:   execinfrapb.AnyNotNull,
+			ColIdx: []uint32{c},
+		})
+	}
+	aggSpec.Aggregations = append(aggSpec.Aggregations, spec.Aggregations...)
+	aggSpec.Aggregations = append(aggSpec.Aggregations, execinfrapb.AggregatorSpec_Aggregation{
+		Func:   execinfrapb.AnyNotNull,
+		ColIdx: []uint32{uint32(len(outputTypes) - 1)},
+	})
+
+	e := &groupingSetsExpander{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		allocator:      allocator,
+		inputTypes:     inputTypes,
+		outputTypes:    outputTypes,
+		groupCols:      spec.GroupCols,
+		inSet:          inSet,
+		set:            len(inSet),
+	}
+	return e, outputTypes, aggSpec
+}
+
+// groupingSetsExpander emits every input batch once for each grouping set,
+// followed by the copies of the group columns and the ordinal of the grouping
+// set. See ExpandGroupingSets.
+type groupingSetsExpander struct {
+	colexecop.OneInputHelper
+
+	allocator   *colmem.Allocator
+	inputTypes  []*types.T
+	outputTypes []*types.T
+	groupCols   []uint32
+	// inSet[i][j] is true if groupCols[j] is part of the i-th grouping set.
+	inSet [][]bool
+
+	output coldata.Batch
+	// set is the ordinal of the grouping set for which output is emitted next.
+	// Once it reaches len(inSet), the next input batch is read.
+	set int
+}
+
+var _ colexecop.Operator = &groupingSetsExpander{}
+
+// Next implements the colexecop.Operator interface.
+func (e *groupingSetsExpander) Next() coldata.Batch {
+	numInputCols := len(e.inputTypes)
+	if e.set == len(e.inSet) {
+		batch := e.Input.Next()
+		n := batch.Length()
+		if n == 0 {
+			return coldata.ZeroBatch
+		}
+		e.output, _ = e.allocator.ResetMaybeReallocateNoMemLimit(e.outputTypes, e.output, n)
+		sel := batch.Selection()
+		e.allocator.PerformOperation(e.output.ColVecs()[:numInputCols], func() {
+			for i := 0; i < numInputCols; i++ {
+				e.output.ColVec(i).Copy(coldata.SliceArgs{
+					Src:       batch.ColVec(i),
+					Sel:       sel,
+					SrcEndIdx: n,
+				})
+			}
+		})
+		e.output.SetLength(n)
+		e.set = 0
+	}
+	n := e.output.Length()
+	e.allocator.PerformOperation(e.output.ColVecs()[numInputCols:], func() {
+		for j, c := range e.groupCols {
+			vec := e.output.ColVec(numInputCols + j)
+			if e.inSet[e.set][j] {
+				vec.Copy(coldata.SliceArgs{
+					Src:       e.output.ColVec(int(c)),
+					SrcEndIdx: n,
+				})
+			} else {
+				vec.Nulls().SetNulls()
+			}
+		}
+		ordinals := e.output.ColVec(numInputCols + len(e.groupCols)).Int64()
+		for i := 0; i < n; i++ {
+			ordinals[i] = int64(e.set)
+		}
+	})
+	e.set++
+	return e.output
+}
+
+// groupingSetsEmptyInputOp passes through the output of the hash aggregation
+// over grouping sets. The aggregation produces no groups at all only if its
+// input is empty. Every empty grouping set still produces a row in this case,
+// like a scalar aggregation, and groupingSetsEmptyInputOp outputs these rows.
+// The results of the aggregate functions over the empty input are computed by
+// a scalar aggregator.
+type groupingSetsEmptyInputOp struct {
+	colexecop.OneInputHelper
+	colexecop.CloserHelper
+
+	allocator   *colmem.Allocator
+	outputTypes []*types.T
+	// numGroupCols is the number of group columns, which precede the results
+	// of the aggregate functions in the output.
+	numGroupCols int
+	// emptySets contains the ordinals of the empty grouping sets.
+	emptySets []int
+	scalarAgg colexecop.ResettableOperator
+
+	emitted bool
+	done    bool
+}
+
+var _ colexecop.ClosableOperator = &groupingSetsEmptyInputOp{}
+
+// NewGroupingSetsEmptyInputOp returns an operator which outputs the rows of
+// the empty grouping sets of spec if the hash aggregation over grouping sets,
+// input, produces no rows. aggArgs are the arguments of the aggregation over
+// the grouping sets of spec, prior to ExpandGroupingSets, and outputTypes are
+// the output types of input.
+func NewGroupingSetsEmptyInputOp(
+	ctx context.Context,
+	input colexecop.Operator,
+	outputTypes []*types.T,
+	aggArgs *colexecagg.NewAggregatorArgs,
+) colexecop.ClosableOperator {
+	spec := aggArgs.Spec
+	op := &groupingSetsEmptyInputOp{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		allocator:      aggArgs.Allocator,
+		outputTypes:    outputTypes,
+		numGroupCols:   len(spec.GroupCols),
+	}
+	for i, set := range spec.GroupingSets {
+		if len(set.Cols) == 0 {
+			op.emptySets = append(op.emptySets, i)
+		}
+	}
+	if len(op.emptySets) > 0 {
+		scalarArgs := *aggArgs
+		scalarArgs.Input = colexecutils.NewFixedNumTuplesNoInputOp(
+			aggArgs.Allocator, 0 /* numTuples */, nil, /* opToInitialize */
+		)
+		scalarArgs.Spec = &execinfrapb.AggregatorSpec{
+			Type:         execinfrapb.AggregatorSpec_SCALAR,
+			Aggregations: spec.Aggregations,
+		}
+		op.scalarAgg = NewOrderedAggregator(ctx, &scalarArgs)
+	}
+	return op
+}
+
+// Init implements the colexecop.Operator interface.
+func (op *groupingSetsEmptyInputOp) Init(ctx context.Context) {
+	if !op.InitHelper.Init(ctx) {
+		return
+	}
+	op.Input.Init(op.Ctx)
+	if op.scalarAgg != nil {
+		op.scalarAgg.Init(op.Ctx)
+	}
+}
+
+// Next implements the colexecop.Operator interface.
+func (op *groupingSetsEmptyInputOp) Next() coldata.Batch {
+	if op.done {
+		return coldata.ZeroBatch
+	}
+	batch := op.Input.Next()
+	if batch.Length() > 0 {
+		op.emitted = true
+		return batch
+	}
+	op.done = true
+	if op.emitted || op.scalarAgg == nil {
+		return coldata.ZeroBatch
+	}
+	// The input was empty, so the results of the aggregate functions are those
+	// of the scalar aggregation over an empty input, which is a single row.
+	scalar := op.scalarAgg.Next()
+	n := len(op.emptySets)
+	output := op.allocator.NewMemBatchWithFixedCapacity(op.outputTypes, n)
+	op.allocator.PerformOperation(output.ColVecs(), func() {
+		for j := 0; j < op.numGroupCols; j++ {
+			output.ColVec(j).Nulls().SetNulls()
+		}
+		for k := 0; k < scalar.Width(); k++ {
+			vec := output.ColVec(op.numGroupCols + k)
+			for i := 0; i < n; i++ {
+				vec.Copy(coldata.SliceArgs{
+					Src:         scalar.ColVec(k),
+					DestIdx:     i,
+					SrcStartIdx: 0,
+					SrcEndIdx:   1,
+				})
+			}
+		}
+		ordinals := output.ColVec(len(op.outputTypes) - 1).Int64()
+		for i, set := range op.emptySets {
+			ordinals[i] = int64(set)
+		}
+	})
+	output.SetLength(n)
+	return output
+}
+
+// Close implements the colexecop.Closer interface.
+func (op *groupingSetsEmptyInputOp) Close(ctx context.Context) error {
+	if !op.CloserHelper.Close() || op.scalarAgg == nil {
+		return nil
+	}
+	return op.scalarAgg.(colexecop.Closer).Close(ctx)
+}
@@ -47,6 +47,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -1316,6 +1317,11 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 	}
 
 	ex.resetExtraTxnState(ctx, txnEvent{eventType: txnEvType}, payloadErr)
+	if rcv := ex.planner.extendedEvalCtx.notificationReceiver; rcv != nil {
+		if r := ex.server.cfg.NotificationRegistry; r != nil {
+			r.UnlistenAll(rcv)
+		}
+	}
 	if ex.hasCreatedTemporarySchema && !ex.server.cfg.TestingKnobs.DisableTempObjectsCleanupOnSessionExit {
 		err := cleanupSessionTempObjects(
 			ctx,
@@ -1484,6 +1490,14 @@ type connExecutor struct {
 		// validateDbZoneConfig should the DB zone config on commit.
 		validateDbZoneConfig bool
 
+		// deferredConstraints tracks the deferrable constraints whose checks
+		// are postponed until the transaction commits.
+		deferredConstraints txnDeferredConstraints
+
+		// listenOps accumulates the LISTEN and UNLISTEN statements which are
+		// applied when the transaction commits.
+		listenOps txnListenOps
+
 		// txnCounter keeps track of how many SQL txns have been open since
 		// the start of the session. This is used for logging, to
 		// distinguish statements that belong to separate SQL transactions.
@@ -2090,6 +2104,8 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent, pay
 	ex.extraTxnState.upgradedToSerializable = false
 	ex.extraTxnState.hasAdminRoleCache = HasAdminRoleCache{}
 	ex.extraTxnState.createdSequences = nil
+	ex.extraTxnState.deferredConstraints.reset()
+	ex.extraTxnState.listenOps.reset()
 
 	if ex.extraTxnState.skipResettingSchemaObjects {
 		if ex.extraTxnState.shouldResetSyntheticDescriptors {
@@ -2544,6 +2560,14 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		//   was created when the statement started executing (via the
 		//   reset() method).
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, timeutil.Now())
+	case StartReplication:
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionQueryReceived, tcmd.TimeReceived)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionStartParse, tcmd.ParseStart)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionEndParse, tcmd.ParseEnd)
+		replRes := ex.clientComm.CreateStartReplicationResult(tcmd, pos)
+		res = replRes
+		stmtCtx := withStatement(ctx, tcmd.Stmt)
+		ev, payload = ex.execStartReplication(stmtCtx, tcmd, replRes)
 	case DrainRequest:
 		// We received a drain request. We terminate immediately if we're not in a
 		// transaction. If we are in a transaction, we'll finish as soon as a Sync
@@ -2822,6 +2846,8 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				// Can't advance.
 			case CopyOut:
 				// Can't advance.
+			case StartReplication:
+				// Can't advance.
 			case DrainRequest:
 				canAdvance = true
 			case Flush:
@@ -3736,6 +3762,15 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 		indexUsageStats:      ex.indexUsageStats,
 		statementPreparer:    ex,
 	}
+	if !ex.extraTxnState.underOuterTxn {
+		// An executor running under an outer transaction does not commit it, so
+		// it cannot defer checks until the commit.
+		evalCtx.deferredConstraints = &ex.extraTxnState.deferredConstraints
+		evalCtx.listenOps = &ex.extraTxnState.listenOps
+	}
+	if rcv, ok := ex.clientComm.(pgnotify.Receiver); ok {
+		evalCtx.notificationReceiver = rcv
+	}
 	evalCtx.copyFromExecCfg(ex.server.cfg)
 }
 
@@ -4030,6 +4065,11 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 		if err := ex.waitOneVersionForNewVersionDescriptorsWithoutJobs(descIDsInJobs); err != nil {
 			return advanceInfo{}, err
 		}
+		if err := ex.extraTxnState.listenOps.apply(
+			ex.Ctx(), ex.server.cfg.NotificationRegistry, ex.planner.extendedEvalCtx.notificationReceiver,
+		); err != nil {
+			res.SetError(errors.Wrap(err, "transaction committed but LISTEN failed"))
+		}
 
 		fallthrough
 	case txnRollback:
@@ -1588,6 +1588,14 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) (retEr
 		ex.state.mu.txn.ConfigureStepping(ctx, prevSteppingMode)
 	}
 
+	// Validate the constraints whose checks were deferred until the end of the
+	// transaction.
+	if pending := ex.extraTxnState.deferredConstraints.takePending(); len(pending) > 0 {
+		if err := ex.planner.validateDeferredConstraints(ctx, pending); err != nil {
+			return err
+		}
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
@@ -100,6 +100,8 @@ func (ex *connExecutor) execSavepointInOpenState(
 		commitOnRelease: commitOnRelease,
 		kvToken:         token,
 		numDDL:          ex.extraTxnState.numDDL,
+		numListenOps:    ex.extraTxnState.listenOps.len(),
+		constraints:     ex.extraTxnState.deferredConstraints.savepoint(),
 	}
 	savepoints.push(sp)
 	ex.sessionDataStack.PushTopClone()
@@ -204,6 +206,8 @@ func (ex *connExecutor) execRollbackToSavepointInOpenState(
 		ev, payload := ex.makeErrEvent(err, s)
 		return ev, payload
 	}
+	ex.extraTxnState.listenOps.truncate(entry.numListenOps)
+	ex.extraTxnState.deferredConstraints.rollbackTo(entry.constraints)
 
 	if err := ex.popSavepointsToIdx(s, idx); err != nil {
 		return ex.makeErrEvent(err, s)
@@ -288,6 +292,8 @@ func (ex *connExecutor) execRollbackToSavepointInAbortedState(
 	if err := ex.state.mu.txn.RollbackToSavepoint(ctx, entry.kvToken); err != nil {
 		return ex.makeErrEvent(err, s)
 	}
+	ex.extraTxnState.listenOps.truncate(entry.numListenOps)
+	ex.extraTxnState.deferredConstraints.rollbackTo(entry.constraints)
 
 	if entry.kvToken.Initial() {
 		return eventTxnRestart{}, nil
@@ -352,6 +358,16 @@ type savepoint struct {
 	// more DDL statements were executed since the savepoint's creation.
 	// TODO(knz): support partial DDL cancellation in pending txns.
 	numDDL int
+
+	// The number of LISTEN and UNLISTEN statements that had been executed in
+	// the transaction when the savepoint was created. Rolling back to the
+	// savepoint discards the later ones.
+	numListenOps int
+
+	// The state of deferrable constraints when the savepoint was created.
+	// Rolling back to the savepoint restores the modes set by SET CONSTRAINTS
+	// and forgets the rows written since.
+	constraints deferredConstraintsSavepoint
 }
 
 type savepointStack []savepoint
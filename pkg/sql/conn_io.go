@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -429,6 +430,45 @@ func (c CopyOut) String() string {
 
 var _ Command = CopyOut{}
 
+// StartReplication is the command for the execution of START_REPLICATION,
+// which streams changes to the client using the Copy-both pgwire subprotocol.
+// Unlike with CopyIn, the network routine keeps reading from the connection
+// while the stream runs and forwards the CopyData messages sent by the client.
+type StartReplication struct {
+	ParsedStmt statements.Statement[tree.Statement]
+	Stmt       *pgrepltree.StartReplication
+	// ClientMessages receives the contents of the CopyData messages sent by
+	// the client. It is closed when the client ends the stream with CopyDone
+	// or CopyFail.
+	ClientMessages <-chan []byte
+	// Done is closed by the connExecutor once the stream has ended, after
+	// which the network routine stops forwarding messages.
+	Done chan<- struct{}
+	// TimeReceived is the time at which the message was received
+	// from the client. Used to compute the service latency.
+	TimeReceived time.Time
+	// ParseStart/ParseEnd are the timing info for parsing of the query. Used for
+	// stats reporting.
+	ParseStart time.Time
+	ParseEnd   time.Time
+}
+
+// command implements the Command interface.
+func (StartReplication) command() string { return "start replication" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (StartReplication) isExtendedProtocolCmd() bool { return false }
+
+func (c StartReplication) String() string {
+	s := "(empty)"
+	if c.Stmt != nil {
+		s = c.Stmt.String()
+	}
+	return fmt.Sprintf("StartReplication: %s", s)
+}
+
+var _ Command = StartReplication{}
+
 // DrainRequest represents a notice that the server is draining and command
 // processing should stop soon.
 //
@@ -755,6 +795,9 @@ type ClientComm interface {
 	CreateCopyInResult(cmd CopyIn, pos CmdPos) CopyInResult
 	// CreateCopyOutResult creates a result for a Copy-out command.
 	CreateCopyOutResult(cmd CopyOut, pos CmdPos) CopyOutResult
+	// CreateStartReplicationResult creates a result for a StartReplication
+	// command.
+	CreateStartReplicationResult(cmd StartReplication, pos CmdPos) StartReplicationResult
 	// CreateDrainResult creates a result for a Drain command.
 	CreateDrainResult(pos CmdPos) DrainResult
 
@@ -982,6 +1025,26 @@ type CopyInResult interface {
 	SetRowsAffected(ctx context.Context, n int)
 }
 
+// StartReplicationResult represents the result of a StartReplication command.
+// Closing this result sends a CommandComplete message to the client.
+type StartReplicationResult interface {
+	ResultBase
+
+	// SendCopyBothResponse sends the CopyBothResponse message which starts
+	// the stream.
+	SendCopyBothResponse(ctx context.Context) error
+
+	// SendCopyData buffers a CopyData message of the stream. isHeader is
+	// always false.
+	SendCopyData(ctx context.Context, copyData []byte, isHeader bool) error
+
+	// SendCopyDone sends the CopyDone message which ends the stream.
+	SendCopyDone(ctx context.Context) error
+
+	// FlushCopyData sends the buffered CopyData messages to the client.
+	FlushCopyData(ctx context.Context) error
+}
+
 // CopyOutResult represents the result of a CopyOut command. Closing this result
 // sends a CommandComplete message to the client.
 type CopyOutResult interface {
@@ -3680,6 +3680,10 @@ func createRoutinePopulate(
 				// otherwise.
 				continue
 			}
+			if fnDesc.IsAggregate() {
+				// Aggregates cannot be expressed as CREATE FUNCTION statements.
+				continue
+			}
 			treeNode, err := fnDesc.ToCreateExpr()
 			treeNode.Name.ObjectNamePrefix = tree.ObjectNamePrefix{
 				ExplicitSchema: true,
@@ -0,0 +1,511 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createAggregateNode struct {
+	n      *tree.CreateAggregate
+	dbDesc catalog.DatabaseDescriptor
+	scDesc catalog.SchemaDescriptor
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createAggregateNode{n: nil}
+
+// CreateAggregate creates a user-defined aggregate function.
+func (p *planner) CreateAggregate(ctx context.Context, n *tree.CreateAggregate) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE AGGREGATE",
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_3_CreateAggregate) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"CREATE AGGREGATE is not supported until version 24.3")
+	}
+
+	un := n.Name.ToUnresolvedObjectName()
+	db, sc, prefix, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	if db.GetID() == keys.SystemDatabaseID {
+		return nil, errors.New("cannot create an aggregate in the system database")
+	}
+	if sc.SchemaKind() == catalog.SchemaTemporary {
+		return nil, unimplemented.NewWithIssue(104687, "cannot create UDFs under a temporary schema")
+	}
+	n.Name.ObjectNamePrefix = prefix
+	return &createAggregateNode{n: n, dbDesc: db, scDesc: sc}, nil
+}
+
+// aggregateDefinition is the resolved definition of a user-defined aggregate.
+type aggregateDefinition struct {
+	params     []descpb.FunctionDescriptor_Parameter
+	stateType  *types.T
+	returnType *types.T
+	initCond   *string
+	volatility catpb.Function_Volatility
+	// transition, final and combine are the component functions of the
+	// aggregate. final and combine are nil if they were not specified.
+	transition catalog.FunctionDescriptor
+	final      catalog.FunctionDescriptor
+	combine    catalog.FunctionDescriptor
+}
+
+// funcIDs returns the IDs of the component functions of the aggregate,
+// without duplicates.
+func (def *aggregateDefinition) funcIDs() []descpb.ID {
+	var ids catalog.DescriptorIDSet
+	for _, fn := range []catalog.FunctionDescriptor{def.transition, def.final, def.combine} {
+		if fn != nil {
+			ids.Add(fn.GetID())
+		}
+	}
+	return ids.Ordered()
+}
+
+// makeAggregateDesc returns the Aggregate field of the aggregate's descriptor.
+func (def *aggregateDefinition) makeAggregateDesc() *descpb.FunctionDescriptor_Aggregate {
+	agg := &descpb.FunctionDescriptor_Aggregate{
+		TransitionFuncID: def.transition.GetID(),
+		StateType:        def.stateType,
+		InitCond:         def.initCond,
+	}
+	if def.final != nil {
+		agg.FinalFuncID = def.final.GetID()
+	}
+	if def.combine != nil {
+		agg.CombineFuncID = def.combine.GetID()
+	}
+	return agg
+}
+
+func (n *createAggregateNode) ReadingOwnWrites() {}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	if err := params.p.canCreateOnSchema(
+		params.ctx, n.scDesc.GetID(), n.dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return err
+	}
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("aggregate"))
+
+	mutScDesc, err := params.p.descCollection.MutableByName(params.p.Txn()).Schema(
+		params.ctx, n.dbDesc, n.scDesc.GetName(),
+	)
+	if err != nil {
+		return err
+	}
+
+	var retErr error
+	params.p.runWithOptions(resolveFlags{contextDatabaseID: n.dbDesc.GetID()}, func() {
+		retErr = func() error {
+			def, err := n.resolveDefinition(params)
+			if err != nil {
+				return err
+			}
+			argTypes := make([]*types.T, len(def.params))
+			for i := range def.params {
+				argTypes[i] = def.params[i].Type
+			}
+			existing, err := n.lookupExisting(params, argTypes)
+			if err != nil {
+				return err
+			}
+			var fnDesc *funcdesc.Mutable
+			if existing == nil {
+				fnDesc, err = n.createNewAggregate(params, mutScDesc, def, argTypes)
+			} else {
+				fnDesc, err = n.replaceAggregate(params, existing, def)
+			}
+			if err != nil {
+				return err
+			}
+			fnName := tree.MakeQualifiedRoutineName(n.dbDesc.GetName(), n.scDesc.GetName(), n.n.Name.Object())
+			event := eventpb.CreateFunction{
+				FunctionName: fnName.FQString(),
+				IsReplace:    existing != nil,
+			}
+			return params.p.logEvent(params.ctx, fnDesc.GetID(), &event)
+		}()
+	})
+	return retErr
+}
+
+func (*createAggregateNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createAggregateNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createAggregateNode) Close(ctx context.Context)           {}
+
+// resolveDefinition resolves the parameters and options of the CREATE
+// AGGREGATE statement, and checks that the component functions are compatible
+// with each other.
+func (n *createAggregateNode) resolveDefinition(params runParams) (*aggregateDefinition, error) {
+	ctx, p := params.ctx, params.p
+	def := &aggregateDefinition{}
+
+	if len(n.n.Params) == 0 {
+		return nil, unimplemented.NewWithIssue(74775, "aggregates without arguments")
+	}
+	argTypes := make([]*types.T, 0, len(n.n.Params))
+	for _, param := range n.n.Params {
+		switch param.Class {
+		case tree.RoutineParamDefault, tree.RoutineParamIn:
+		case tree.RoutineParamOut, tree.RoutineParamInOut:
+			return nil, pgerror.New(pgcode.InvalidFunctionDefinition,
+				"aggregates cannot have output arguments")
+		default:
+			return nil, unimplemented.NewWithIssuef(74775, "%s aggregate arguments", param.Class)
+		}
+		if param.DefaultVal != nil {
+			return nil, pgerror.New(pgcode.InvalidFunctionDefinition,
+				"aggregates cannot have default arguments")
+		}
+		pbParam, err := makeFunctionParam(ctx, p.SemaCtx(), param, p)
+		if err != nil {
+			return nil, err
+		}
+		def.params = append(def.params, pbParam)
+		argTypes = append(argTypes, pbParam.Type)
+	}
+
+	// Collect the options, which may appear in any order.
+	var sfunc, ffunc, cfunc *tree.RoutineName
+	var stype tree.ResolvableTypeReference
+	var seen [tree.AggregateInitCond + 1]bool
+	for i := range n.n.Options {
+		o := &n.n.Options[i]
+		if seen[o.Kind] {
+			return nil, pgerror.New(pgcode.Syntax, "conflicting or redundant options")
+		}
+		seen[o.Kind] = true
+		switch o.Kind {
+		case tree.AggregateSFunc:
+			sfunc = &o.Func
+		case tree.AggregateSType:
+			stype = o.Type
+		case tree.AggregateFinalFunc:
+			ffunc = &o.Func
+		case tree.AggregateCombineFunc:
+			cfunc = &o.Func
+		case tree.AggregateInitCond:
+			initCond := o.Value
+			def.initCond = &initCond
+		}
+	}
+	if stype == nil {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified")
+	}
+	if sfunc == nil {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified")
+	}
+	var err error
+	def.stateType, err = tree.ResolveType(ctx, stype, p)
+	if err != nil {
+		return nil, err
+	}
+	if def.stateType.IsWildcardType() {
+		return nil, unimplemented.NewWithIssuef(74775, "aggregates with state type %s",
+			def.stateType.SQLStringForError())
+	}
+
+	// The transition function takes the state and the aggregate's arguments,
+	// and returns the new state.
+	transitionArgs := append([]*types.T{def.stateType}, argTypes...)
+	def.transition, err = n.resolveComponentFunc(params, *sfunc, transitionArgs)
+	if err != nil {
+		return nil, err
+	}
+	if !def.transition.GetReturnType().Type.Equivalent(def.stateType) {
+		return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+			"return type of transition function %s is not %s",
+			sfunc, def.stateType.SQLStringForError(),
+		)
+	}
+	// Like in Postgres, a strict transition function with a NULL initial state
+	// uses the first non-NULL input as the initial state, so the input must be
+	// usable as the state value.
+	if def.transition.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT &&
+		def.initCond == nil && !argTypes[0].Equivalent(def.stateType) {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"must not omit initial value when transition function is strict and "+
+				"transition type is not compatible with input type")
+	}
+
+	def.returnType = def.stateType
+	if ffunc != nil {
+		def.final, err = n.resolveComponentFunc(params, *ffunc, []*types.T{def.stateType})
+		if err != nil {
+			return nil, err
+		}
+		def.returnType = def.final.GetReturnType().Type
+	}
+	if cfunc != nil {
+		def.combine, err = n.resolveComponentFunc(params, *cfunc, []*types.T{def.stateType, def.stateType})
+		if err != nil {
+			return nil, err
+		}
+		if !def.combine.GetReturnType().Type.Equivalent(def.stateType) {
+			return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+				"return type of combine function %s is not %s",
+				cfunc, def.stateType.SQLStringForError(),
+			)
+		}
+	}
+
+	// Make sure the initial state can be converted to the state type.
+	if def.initCond != nil {
+		if _, err := eval.PerformCast(
+			ctx, params.EvalContext(), tree.NewDString(*def.initCond), def.stateType,
+		); err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.InvalidFunctionDefinition,
+				"invalid initial value for aggregate")
+		}
+	}
+
+	// The aggregate is only as stable as its least stable component function.
+	def.volatility = catpb.Function_IMMUTABLE
+	for _, fn := range []catalog.FunctionDescriptor{def.transition, def.final, def.combine} {
+		if fn == nil {
+			continue
+		}
+		switch fn.GetVolatility() {
+		case catpb.Function_VOLATILE:
+			def.volatility = catpb.Function_VOLATILE
+		case catpb.Function_STABLE:
+			if def.volatility != catpb.Function_VOLATILE {
+				def.volatility = catpb.Function_STABLE
+			}
+		}
+	}
+	return def, nil
+}
+
+// resolveComponentFunc resolves a component function of the aggregate, which
+// must be a user-defined function with exactly the given argument types.
+func (n *createAggregateNode) resolveComponentFunc(
+	params runParams, name tree.RoutineName, argTypes []*types.T,
+) (catalog.FunctionDescriptor, error) {
+	ctx, p := params.ctx, params.p
+	routineObj := tree.RoutineObj{
+		FuncName: name,
+		Params:   make(tree.RoutineParams, len(argTypes)),
+	}
+	for i, typ := range argTypes {
+		routineObj.Params[i] = tree.RoutineParam{Type: typ, Class: tree.RoutineParamIn}
+	}
+	path := p.CurrentSearchPath()
+	fnDef, err := p.ResolveFunction(
+		ctx, tree.MakeUnresolvedFunctionName(name.ToUnresolvedObjectName().ToUnresolvedName()), &path,
+	)
+	if err != nil {
+		return nil, err
+	}
+	ol, err := fnDef.MatchOverload(
+		ctx, p, &routineObj, &path, tree.UDFRoutine, false /* inDropContext */, false, /* tryDefaultExprs */
+	)
+	if err != nil {
+		return nil, err
+	}
+	if ol.Type == tree.BuiltinRoutine {
+		return nil, unimplemented.NewWithIssuef(74775,
+			"using built-in function %s%s in an aggregate", fnDef.Name, ol.Signature(true /* simplify */))
+	}
+	fnDesc, err := p.Descriptors().ByIDWithLeased(p.Txn()).Get().Function(
+		ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if fnDesc.IsAggregate() || fnDesc.GetReturnType().ReturnSet {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"function %s must be a scalar function", fnDesc.GetName())
+	}
+	if dbID := fnDesc.GetParentID(); dbID != n.dbDesc.GetID() && dbID != keys.SystemDatabaseID {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"dependent function %s cannot be from another database", fnDesc.GetName())
+	}
+	if err := p.CheckPrivilege(ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return fnDesc, nil
+}
+
+// lookupExisting returns the existing routine with the same name and argument
+// types as the new aggregate, or nil if there is none.
+func (n *createAggregateNode) lookupExisting(
+	params runParams, argTypes []*types.T,
+) (*funcdesc.Mutable, error) {
+	routineObj := tree.RoutineObj{
+		FuncName: n.n.Name,
+		Params:   make(tree.RoutineParams, len(argTypes)),
+	}
+	for i, typ := range argTypes {
+		routineObj.Params[i] = tree.RoutineParam{Type: typ, Class: tree.RoutineParamIn}
+	}
+	existing, err := params.p.matchRoutine(
+		params.ctx, &routineObj, false, /* required */
+		tree.UDFRoutine|tree.ProcedureRoutine, false, /* inDropContext */
+	)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+	if !n.n.Replace {
+		return nil, pgerror.Newf(
+			pgcode.DuplicateFunction,
+			"function %q already exists with same argument types",
+			n.n.Name.Object(),
+		)
+	}
+	return params.p.checkPrivilegesForDropFunction(
+		params.ctx, funcdesc.UserDefinedFunctionOIDToID(existing.Oid),
+	)
+}
+
+func (n *createAggregateNode) createNewAggregate(
+	params runParams, scDesc *schemadesc.Mutable, def *aggregateDefinition, argTypes []*types.T,
+) (*funcdesc.Mutable, error) {
+	p := params.p
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return nil, err
+	}
+	privileges, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		scDesc.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Routines,
+	)
+	if err != nil {
+		return nil, err
+	}
+	fnDesc := funcdesc.NewMutableFunctionDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		scDesc.GetID(),
+		n.n.Name.Object(),
+		def.params,
+		def.returnType,
+		false, /* returnSet */
+		false, /* isProcedure */
+		privileges,
+	)
+	fnDesc.SetVolatility(def.volatility)
+	fnDesc.Aggregate = def.makeAggregateDesc()
+	if err := n.addComponentFuncReferences(params, &fnDesc, def); err != nil {
+		return nil, err
+	}
+	if err := p.createDescriptor(
+		params.ctx, &fnDesc, tree.AsStringWithFQNames(&n.n.Name, params.Ann()),
+	); err != nil {
+		return nil, err
+	}
+
+	scDesc.AddFunction(
+		fnDesc.GetName(),
+		descpb.SchemaDescriptor_FunctionSignature{
+			ID:          fnDesc.GetID(),
+			ArgTypes:    argTypes,
+			ReturnType:  def.returnType,
+			IsAggregate: true,
+		},
+	)
+	if err := p.writeSchemaDescChange(params.ctx, scDesc, "Create Aggregate"); err != nil {
+		return nil, err
+	}
+	return &fnDesc, nil
+}
+
+func (n *createAggregateNode) replaceAggregate(
+	params runParams, fnDesc *funcdesc.Mutable, def *aggregateDefinition,
+) (*funcdesc.Mutable, error) {
+	p := params.p
+	if !fnDesc.IsAggregate() {
+		formatStr := "%q is a function"
+		if fnDesc.IsProcedure() {
+			formatStr = "%q is a procedure"
+		}
+		return nil, errors.WithDetailf(
+			pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+			formatStr,
+			fnDesc.Name,
+		)
+	}
+	if !def.returnType.Equivalent(fnDesc.ReturnType.Type) {
+		return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"cannot change return type of existing function")
+	}
+
+	// Remove the references to the old component functions before adding the
+	// new ones.
+	for _, id := range fnDesc.DependsOnFunctions {
+		backRefDesc, err := p.Descriptors().MutableByID(p.Txn()).Function(params.ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := backRefDesc.RemoveFunctionReference(fnDesc.ID); err != nil {
+			return nil, err
+		}
+		if err := p.writeFuncSchemaChange(params.ctx, backRefDesc); err != nil {
+			return nil, err
+		}
+	}
+	fnDesc.Params = def.params
+	fnDesc.SetVolatility(def.volatility)
+	fnDesc.Aggregate = def.makeAggregateDesc()
+	if err := n.addComponentFuncReferences(params, fnDesc, def); err != nil {
+		return nil, err
+	}
+	return fnDesc, p.writeFuncSchemaChange(params.ctx, fnDesc)
+}
+
+// addComponentFuncReferences adds references between the aggregate and its
+// component functions. The aggregate does not reference the types in its
+// definition directly; they are all referenced by its component functions.
+func (n *createAggregateNode) addComponentFuncReferences(
+	params runParams, fnDesc *funcdesc.Mutable, def *aggregateDefinition,
+) error {
+	fnDesc.DependsOnFunctions = def.funcIDs()
+	for _, id := range fnDesc.DependsOnFunctions {
+		backRefDesc, err := params.p.Descriptors().MutableByID(params.p.Txn()).Function(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := backRefDesc.AddFunctionReference(fnDesc.ID); err != nil {
+			return err
+		}
+		if err := params.p.writeFuncSchemaChange(params.ctx, backRefDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
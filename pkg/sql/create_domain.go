@@ -0,0 +1,214 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+type createDomainNode struct {
+	n        *tree.CreateDomain
+	typeName *tree.TypeName
+	dbDesc   catalog.DatabaseDescriptor
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createDomainNode{n: nil}
+
+func (p *planner) CreateDomain(ctx context.Context, n *tree.CreateDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_3_CreateDomain) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"CREATE DOMAIN is not supported until version 24.3")
+	}
+
+	typeName, db, err := resolveNewTypeName(ctx, p, n.TypeName)
+	if err != nil {
+		return nil, err
+	}
+	n.TypeName.SetAnnotation(&p.semaCtx.Annotations, typeName)
+	return &createDomainNode{
+		n:        n,
+		typeName: typeName,
+		dbDesc:   db,
+	}, nil
+}
+
+func (n *createDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("domain"))
+	p := params.p
+
+	schema, err := getCreateTypeParams(params.ctx, p, n.typeName, n.dbDesc)
+	if err != nil {
+		return err
+	}
+
+	baseType, err := tree.ResolveType(params.ctx, n.n.Type, p.semaCtx.GetTypeResolver())
+	if err != nil {
+		return err
+	}
+	if err := checkDomainBaseType(params.ctx, p, baseType); err != nil {
+		return err
+	}
+	domain, err := makeDomainDefinition(params.ctx, p, n.typeName.Type(), baseType, n.n.Constraints)
+	if err != nil {
+		return err
+	}
+
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	if err != nil {
+		return err
+	}
+	typeDesc := typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           n.typeName.Type(),
+		ID:             id,
+		ParentID:       n.dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		Domain:         domain,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType()
+
+	return p.finishCreateType(params.ctx, params.EvalContext(), n.typeName, typeDesc, n.dbDesc, schema)
+}
+
+// checkDomainBaseType returns an error if the given type cannot be used as
+// the base type of a domain.
+func checkDomainBaseType(ctx context.Context, p *planner, baseType *types.T) error {
+	if baseType.Identical(types.Trigger) {
+		return tree.CannotAcceptTriggerErr
+	}
+	if err := tree.CheckUnsupportedType(ctx, &p.semaCtx, baseType); err != nil {
+		return err
+	}
+	switch baseType.Family() {
+	case types.AnyFamily, types.UnknownFamily, types.VoidFamily:
+		return pgerror.Newf(pgcode.DatatypeMismatch,
+			"%q is not a valid base type for a domain", baseType.SQLString())
+	}
+	if baseType.UserDefined() || baseType.TypeMeta.ImplicitRecordType {
+		return unimplemented.NewWithIssue(27796,
+			"domains over user-defined types are not yet supported")
+	}
+	return nil
+}
+
+// makeDomainDefinition validates the constraints of a CREATE DOMAIN statement
+// and returns the corresponding domain definition.
+func makeDomainDefinition(
+	ctx context.Context,
+	p *planner,
+	domainName string,
+	baseType *types.T,
+	defs tree.DomainConstraintDefs,
+) (*descpb.TypeDescriptor_Domain, error) {
+	domain := &descpb.TypeDescriptor_Domain{BaseType: baseType}
+	var sawNull, sawNotNull bool
+	for _, def := range defs {
+		switch def.Kind {
+		case tree.DomainDefault:
+			if domain.DefaultExpr != nil {
+				return nil, pgerror.New(pgcode.Syntax, "multiple default expressions")
+			}
+			expr, err := schemaexpr.ValidateDomainDefaultExpr(ctx, def.Expr, baseType, &p.semaCtx)
+			if err != nil {
+				return nil, err
+			}
+			domain.DefaultExpr = &expr
+		case tree.DomainNull:
+			sawNull = true
+		case tree.DomainNotNull:
+			sawNotNull = true
+			domain.NotNull = true
+		case tree.DomainCheck:
+			check, err := makeDomainCheckConstraint(ctx, p, domainName, baseType, domain, def)
+			if err != nil {
+				return nil, err
+			}
+			domain.CheckConstraints = append(domain.CheckConstraints, check)
+		}
+		if sawNull && sawNotNull {
+			return nil, pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+		}
+	}
+	return domain, nil
+}
+
+// makeDomainCheckConstraint validates the CHECK constraint definition for the
+// given domain and returns the constraint, choosing a name for it if none was
+// given.
+func makeDomainCheckConstraint(
+	ctx context.Context,
+	p *planner,
+	domainName string,
+	baseType *types.T,
+	domain *descpb.TypeDescriptor_Domain,
+	def *tree.DomainConstraintDef,
+) (descpb.TypeDescriptor_Domain_CheckConstraint, error) {
+	expr, err := schemaexpr.ValidateDomainCheckExpr(ctx, def.Expr, baseType, &p.semaCtx)
+	if err != nil {
+		return descpb.TypeDescriptor_Domain_CheckConstraint{}, err
+	}
+	nameInUse := func(name string) bool {
+		for i := range domain.CheckConstraints {
+			if domain.CheckConstraints[i].Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	name := string(def.Name)
+	if name == "" {
+		// Follow Postgres in naming unnamed constraints <domain>_check, adding a
+		// numeric suffix if that name is already taken.
+		name = domainName + "_check"
+		for i := 1; nameInUse(name); i++ {
+			name = fmt.Sprintf("%s_check%d", domainName, i)
+		}
+	} else if nameInUse(name) {
+		return descpb.TypeDescriptor_Domain_CheckConstraint{}, pgerror.Newf(pgcode.DuplicateObject,
+			"constraint %q for domain %q already exists", name, domainName)
+	}
+	return descpb.TypeDescriptor_Domain_CheckConstraint{Name: name, Expr: expr}, nil
+}
+
+func (n *createDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createDomainNode) Close(ctx context.Context)           {}
+func (n *createDomainNode) ReadingOwnWrites()                   {}
@@ -195,10 +195,12 @@ func (n *createFunctionNode) replaceFunction(
 	existing *tree.QualifiedOverload,
 ) error {
 
-	if n.cf.IsProcedure != udfDesc.IsProcedure() {
+	if n.cf.IsProcedure != udfDesc.IsProcedure() || udfDesc.IsAggregate() {
 		formatStr := "%q is a function"
 		if udfDesc.IsProcedure() {
 			formatStr = "%q is a procedure"
+		} else if udfDesc.IsAggregate() {
+			formatStr = "%q is an aggregate function"
 		}
 		return errors.WithDetailf(
 			pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
@@ -26,12 +26,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/storageparam"
 	"github.com/cockroachdb/cockroach/pkg/sql/storageparam/indexstorageparam"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -142,6 +144,12 @@ func makeIndexDescriptor(
 			`"bucket_count" storage param should only be set with "USING HASH" for hash sharded index`,
 		)
 	}
+	if !n.Vector && n.StorageParams.GetVal(`partitions`) != nil {
+		return nil, pgerror.New(
+			pgcode.InvalidParameterValue,
+			`"partitions" storage param should only be set with "USING lsh" for vector index`,
+		)
+	}
 	// Since we mutate the columns below, we make copies of them
 	// here so that on retry we do not attempt to validate the
 	// mutated columns.
@@ -155,6 +163,36 @@ func makeIndexDescriptor(
 		return nil, err
 	}
 
+	// A vector index is an index on the partition of the vector column, so the
+	// vector column is replaced with an expression computing the partition
+	// before expression elements are replaced with virtual columns.
+	var vectorConfig catpb.VectorIndexDescriptor
+	if n.Vector {
+		if !params.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.V24_3_VectorIndexes) {
+			return nil, pgerror.New(pgcode.FeatureNotSupported,
+				"vector indexes are not supported until the upgrade to version 24.3 is finalized")
+		}
+		if n.Unique {
+			return nil, pgerror.New(pgcode.InvalidSQLStatementName, "vector indexes can't be unique")
+		}
+		if n.Sharded != nil {
+			return nil, pgerror.New(pgcode.InvalidSQLStatementName, "vector indexes don't support hash sharding")
+		}
+		if n.Predicate != nil {
+			return nil, pgerror.New(pgcode.FeatureNotSupported, "vector indexes don't support partial indexes")
+		}
+		if n.PartitionByIndex.ContainsPartitions() || tableDesc.IsPartitionAllBy() {
+			return nil, pgerror.New(pgcode.FeatureNotSupported, "vector indexes don't support partitioning")
+		}
+		var err error
+		columns, vectorConfig, err = setupVectorIndex(
+			params.ctx, params.EvalContext(), params.p.SemaCtx(), tableDesc, columns, n.StorageParams,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	tn, err := params.p.getQualifiedTableName(params.ctx, tableDesc)
 	if err != nil {
 		return nil, err
@@ -204,6 +242,23 @@ func makeIndexDescriptor(
 		Invisibility:      n.Invisibility.Value,
 	}
 
+	if n.Vector {
+		indexDesc.Vector = vectorConfig
+		vecCol, err := catalog.MustFindColumnByID(tableDesc, vectorConfig.ColumnID)
+		if err != nil {
+			return nil, err
+		}
+		// The vector column is stored in the index so that distances can be
+		// computed without an index join.
+		stored := false
+		for _, name := range indexDesc.StoreColumnNames {
+			stored = stored || name == vecCol.GetName()
+		}
+		if !stored {
+			indexDesc.StoreColumnNames = append(indexDesc.StoreColumnNames, vecCol.GetName())
+		}
+	}
+
 	if n.Inverted {
 		if n.Sharded != nil {
 			return nil, pgerror.New(pgcode.InvalidSQLStatementName, "inverted indexes don't support hash sharding")
@@ -279,6 +334,11 @@ func makeIndexDescriptor(
 	); err != nil {
 		return nil, err
 	}
+	if err := checkTermStatisticsColumn(
+		params.ctx, params.ExecCfg().Settings, tableDesc, &indexDesc,
+	); err != nil {
+		return nil, err
+	}
 
 	// Increment telemetry once a descriptor has been successfully created.
 	if indexDesc.Type == descpb.IndexDescriptor_INVERTED {
@@ -302,6 +362,9 @@ func makeIndexDescriptor(
 	if indexDesc.IsSharded() {
 		telemetry.Inc(sqltelemetry.HashShardedIndexCounter)
 	}
+	if indexDesc.IsVector() {
+		telemetry.Inc(sqltelemetry.VectorIndexCounter)
+	}
 	if indexDesc.IsPartial() {
 		telemetry.Inc(sqltelemetry.PartialIndexCounter)
 	}
@@ -353,6 +416,37 @@ func checkIndexColumns(
 	return nil
 }
 
+// checkTermStatisticsColumn returns an error if the index stores term
+// statistics but isn't on a TSVECTOR column, or if the cluster isn't upgraded
+// yet. The other requirements are checked when the storage parameter is set.
+func checkTermStatisticsColumn(
+	ctx context.Context,
+	st *cluster.Settings,
+	tableDesc catalog.TableDescriptor,
+	indexDesc *descpb.IndexDescriptor,
+) error {
+	if !indexDesc.TermStatistics {
+		return nil
+	}
+	// Nodes running a previous version would write index entries without the
+	// term statistics, which would make the statistics of the index wrong.
+	if !st.Version.IsActive(ctx, clusterversion.V24_3_TermStatistics) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"term_statistics is not supported until the upgrade to version 24.3 is finalized")
+	}
+	col, err := catalog.MustFindColumnByName(tableDesc, indexDesc.KeyColumnNames[0])
+	if err != nil {
+		return err
+	}
+	if col.GetType().Family() != types.TSVectorFamily {
+		return pgerror.New(
+			pgcode.InvalidParameterValue,
+			"term_statistics can only be set on single-column inverted indexes on a TSVECTOR column",
+		)
+	}
+	return nil
+}
+
 // populateInvertedIndexDescriptor adds information to the input index descriptor
 // for the inverted index given by the input column and invCol, which should
 // match (column is the catalog column, and invCol is the grammar node of
@@ -413,6 +507,18 @@ func populateInvertedIndexDescriptor(
 		default:
 			return newUndefinedOpclassError(invCol.OpClass)
 		}
+	case types.RangeFamily:
+		switch invCol.OpClass {
+		case "range_ops", "":
+		default:
+			return newUndefinedOpclassError(invCol.OpClass)
+		}
+	case types.MultirangeFamily:
+		switch invCol.OpClass {
+		case "multirange_ops", "":
+		default:
+			return newUndefinedOpclassError(invCol.OpClass)
+		}
 	default:
 		return tabledesc.NewInvalidInvertedColumnError(column.GetName(), column.GetType().Name())
 	}
@@ -712,6 +818,115 @@ func maybeCreateAndAddShardCol(
 	return catalog.MustFindColumnByName(desc, shardColDesc.Name)
 }
 
+// setupVectorIndex validates the columns of a vector index and returns the new
+// column list for the index along with its vector configuration. The vector
+// column is replaced with an expression element that computes the partition of
+// the vector, which becomes the key of the index.
+func setupVectorIndex(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	tableDesc *tabledesc.Mutable,
+	columns tree.IndexElemList,
+	storageParams tree.StorageParams,
+) (newColumns tree.IndexElemList, cfg catpb.VectorIndexDescriptor, err error) {
+	if len(columns) != 1 || columns[0].Expr != nil {
+		return nil, cfg, pgerror.New(pgcode.FeatureNotSupported,
+			"vector indexes must be created on exactly one column")
+	}
+	elem := columns[0]
+	if elem.Direction != tree.DefaultDirection || elem.NullsOrder != tree.DefaultNullsOrder {
+		return nil, cfg, pgerror.New(pgcode.FeatureNotSupported,
+			"vector indexes don't support ordering")
+	}
+	column, err := catalog.MustFindColumnByTreeName(tableDesc, elem.Column)
+	if err != nil {
+		return nil, cfg, err
+	}
+	if column.GetType().Family() != types.PGVectorFamily {
+		return nil, cfg, pgerror.Newf(pgcode.DatatypeMismatch,
+			"column %s of type %s is not allowed in a vector index",
+			column.GetName(), column.GetType().Name())
+	}
+	if column.GetType().Width() <= 0 {
+		return nil, cfg, pgerror.Newf(pgcode.InvalidParameterValue,
+			"column %s does not have dimensions", column.GetName())
+	}
+	if column.IsVirtual() {
+		return nil, cfg, pgerror.Newf(pgcode.FeatureNotSupported,
+			"vector indexes on virtual column %s are not supported", column.GetName())
+	}
+
+	switch elem.OpClass {
+	case "", "vector_l2_ops":
+		cfg.Metric = catpb.VectorIndexDescriptor_L2
+	case "vector_cosine_ops":
+		cfg.Metric = catpb.VectorIndexDescriptor_COSINE
+	case "vector_ip_ops":
+		cfg.Metric = catpb.VectorIndexDescriptor_INNER_PRODUCT
+	default:
+		return nil, cfg, newUndefinedOpclassError(elem.OpClass)
+	}
+
+	partitions, err := evalVectorIndexPartitions(ctx, semaCtx, evalCtx, storageParams)
+	if err != nil {
+		return nil, cfg, err
+	}
+	cfg.IsVector = true
+	cfg.ColumnID = column.GetID()
+	cfg.Partitions = partitions
+
+	partitionElem := tree.IndexElem{
+		Expr: &tree.FuncExpr{
+			Func: tree.ResolvableFunctionReference{
+				FunctionReference: &tree.UnresolvedName{
+					NumParts: 1,
+					Parts:    tree.NameParts{"crdb_internal.vector_partition"},
+				},
+			},
+			Exprs: tree.Exprs{
+				&tree.ColumnItem{ColumnName: tree.Name(column.GetName())},
+				tree.NewDInt(tree.DInt(partitions)),
+			},
+		},
+		Direction: tree.Ascending,
+	}
+	return tree.IndexElemList{partitionElem}, cfg, nil
+}
+
+// evalVectorIndexPartitions evaluates and checks the `partitions` storage
+// parameter of a vector index, which is the number of partitions of the index.
+func evalVectorIndexPartitions(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	storageParams tree.StorageParams,
+) (int32, error) {
+	const invalidPartitionsMsg = `vector index partitions must be in range [1, %d], got %v`
+	paramVal := storageParams.GetVal(`partitions`)
+	if paramVal == nil {
+		return vector.DefaultPartitions, nil
+	}
+	if paramVal == tree.DNull {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue, invalidPartitionsMsg, vector.MaxPartitions, "NULL")
+	}
+	typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+		ctx, paramVal, types.Int, "partitions", semaCtx, volatility.Volatile, false, /*allowAssignmentCast*/
+	)
+	if err != nil {
+		return 0, err
+	}
+	d, err := eval.Expr(ctx, evalCtx, typedExpr)
+	if err != nil {
+		return 0, pgerror.Wrapf(err, pgcode.InvalidParameterValue, invalidPartitionsMsg, vector.MaxPartitions, typedExpr)
+	}
+	partitions := int64(tree.MustBeDInt(d))
+	if partitions < 1 || partitions > vector.MaxPartitions {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue, invalidPartitionsMsg, vector.MaxPartitions, partitions)
+	}
+	return int32(partitions), nil
+}
+
 func (n *createIndexNode) startExec(params runParams) error {
 	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("index"))
 	foundIndex := catalog.FindIndexByName(n.tableDesc, string(n.n.Name))
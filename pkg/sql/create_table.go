@@ -666,6 +666,9 @@ func addUniqueWithoutIndexColumnTableDef(
 			"unique constraints without an index are not yet supported",
 		)
 	}
+	if err := checkDeferrableConstraintsSupported(ctx, evalCtx, d.Unique.Deferrability); err != nil {
+		return err
+	}
 	// Add a unique constraint.
 	if err := ResolveUniqueWithoutIndexConstraint(
 		ctx,
@@ -673,6 +676,7 @@ func addUniqueWithoutIndexColumnTableDef(
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
 		"", /* predicate */
+		d.Unique.Deferrability,
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -717,6 +721,9 @@ func addUniqueWithoutIndexTableDef(
 			"creating a unique constraint using UNIQUE WITH NOT VISIBLE INDEX is not supported",
 		)
 	}
+	if err := checkDeferrableConstraintsSupported(ctx, evalCtx, d.Deferrability); err != nil {
+		return err
+	}
 
 	// If there is a predicate, validate it.
 	var predicate string
@@ -736,13 +743,37 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrability, ts, validationBehavior,
 	); err != nil {
 		return err
 	}
 	return nil
 }
 
+// checkDeferrableConstraintsSupported returns an error if a constraint with the
+// given deferrability cannot be added at the active cluster version.
+func checkDeferrableConstraintsSupported(
+	ctx context.Context, evalCtx *eval.Context, d tree.ConstraintDeferrability,
+) error {
+	if d != tree.ConstraintNotDeferrable &&
+		!evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_3_DeferrableConstraints) {
+		return sqlerrors.NewDeferrableConstraintsNotSupportedError()
+	}
+	return nil
+}
+
+// newDeferrableIndexConstraintError returns the error used when a UNIQUE
+// constraint backed by an index is declared DEFERRABLE. Uniqueness of an index
+// is enforced by the KV layer on every write, so it cannot be postponed until
+// the transaction commits.
+func newDeferrableIndexConstraintError() error {
+	return errors.WithHint(
+		pgerror.New(pgcode.FeatureNotSupported,
+			"unique constraints backed by an index cannot be deferrable"),
+		"use UNIQUE WITHOUT INDEX to declare a deferrable unique constraint",
+	)
+}
+
 // ResolveUniqueWithoutIndexConstraint looks up the columns mentioned in a
 // UNIQUE WITHOUT INDEX constraint and adds metadata representing that
 // constraint to the descriptor.
@@ -756,6 +787,27 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	deferrability tree.ConstraintDeferrability,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
+) error {
+	return resolveUniqueWithoutIndexConstraint(
+		ctx, tbl, constraintName, colNames, predicate, deferrability, nil, /* exclusionOperators */
+		ts, validationBehavior,
+	)
+}
+
+// resolveUniqueWithoutIndexConstraint is like
+// ResolveUniqueWithoutIndexConstraint, but adds an exclusion constraint if
+// exclusionOperators is not empty.
+func resolveUniqueWithoutIndexConstraint(
+	ctx context.Context,
+	tbl *tabledesc.Mutable,
+	constraintName string,
+	colNames []string,
+	predicate string,
+	deferrability tree.ConstraintDeferrability,
+	exclusionOperators []string,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
@@ -777,8 +829,12 @@ func ResolveUniqueWithoutIndexConstraint(
 
 	// Verify we are not writing a constraint over the same name.
 	if constraintName == "" {
+		prefix := fmt.Sprintf("unique_%s", strings.Join(colNames, "_"))
+		if len(exclusionOperators) > 0 {
+			prefix = fmt.Sprintf("%s_%s_excl", tbl.GetName(), strings.Join(colNames, "_"))
+		}
 		constraintName = tabledesc.GenerateUniqueName(
-			fmt.Sprintf("unique_%s", strings.Join(colNames, "_")),
+			prefix,
 			func(p string) bool {
 				return catalog.FindConstraintByName(tbl, p) != nil
 			},
@@ -804,12 +860,14 @@ func ResolveUniqueWithoutIndexConstraint(
 	}
 
 	uc := descpb.UniqueWithoutIndexConstraint{
-		Name:         constraintName,
-		TableID:      tbl.ID,
-		ColumnIDs:    columnIDs,
-		Predicate:    predicate,
-		Validity:     validity,
-		ConstraintID: tbl.NextConstraintID,
+		Name:               constraintName,
+		TableID:            tbl.ID,
+		ColumnIDs:          columnIDs,
+		Predicate:          predicate,
+		Validity:           validity,
+		Deferrability:      descpb.ToConstraintDeferrability(deferrability),
+		ConstraintID:       tbl.NextConstraintID,
+		ExclusionOperators: exclusionOperators,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -859,6 +917,9 @@ func ResolveFK(
 	validationBehavior tree.ValidationBehavior,
 	evalCtx *eval.Context,
 ) error {
+	if err := checkDeferrableConstraintsSupported(ctx, evalCtx, d.Deferrability); err != nil {
+		return err
+	}
 	var originColSet catalog.TableColSet
 	originCols := make([]catalog.Column, len(d.FromCols))
 	for i, fromCol := range d.FromCols {
@@ -1082,6 +1143,7 @@ func ResolveFK(
 		OnDelete:            tree.ForeignKeyReferenceActionValue[d.Actions.Delete],
 		OnUpdate:            tree.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               tree.CompositeKeyMatchMethodValue[d.Match],
+		Deferrability:       descpb.ToConstraintDeferrability(d.Deferrability),
 		ConstraintID:        tbl.NextConstraintID,
 	}
 	tbl.NextConstraintID++
@@ -1825,6 +1887,24 @@ func NewTableDesc(
 		}
 	}
 
+	// Add the indexes which support exclusion constraints, which are created
+	// like the other indexes below. The definitions are removed from the AST
+	// once this statement completes.
+	numDefs := len(n.Defs)
+	defer func() { n.Defs = n.Defs[:numDefs] }()
+	for _, def := range n.Defs[:numDefs] {
+		if d, ok := def.(*tree.ExcludeConstraintTableDef); ok {
+			idxDef, err := makeExclusionConstraintIndexTableDef(&desc, d)
+			if err != nil {
+				return nil, err
+			}
+			if idxDef != nil && !hasExclusionConstraintIndexTableDef(n.Defs[:numDefs], idxDef) {
+				n.Defs = append(n.Defs, idxDef)
+				cdd = append(cdd, nil)
+			}
+		}
+	}
+
 	for _, def := range n.Defs {
 		switch d := def.(type) {
 		case *tree.ColumnTableDef, *tree.LikeTableDef:
@@ -1942,6 +2022,9 @@ func NewTableDesc(
 			); err != nil {
 				return nil, err
 			}
+			if err := checkTermStatisticsColumn(ctx, st, &desc, &idx); err != nil {
+				return nil, err
+			}
 
 			if err := desc.AddSecondaryIndex(idx); err != nil {
 				return nil, err
@@ -1951,6 +2034,9 @@ func NewTableDesc(
 				// We will add the unique constraint below.
 				break
 			}
+			if d.Deferrability != tree.ConstraintNotDeferrable {
+				return nil, newDeferrableIndexConstraintError()
+			}
 			// If the index is named, ensure that the name is unique. Unnamed
 			// indexes will be given a unique auto-generated name later on when
 			// AllocateIDs is called.
@@ -2070,7 +2156,8 @@ func NewTableDesc(
 					return nil, err
 				}
 			}
-		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
+		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef,
+			*tree.ExcludeConstraintTableDef:
 			// pass, handled below.
 
 		default:
@@ -2224,6 +2311,10 @@ func NewTableDesc(
 	for _, def := range n.Defs {
 		switch d := def.(type) {
 		case *tree.ColumnTableDef:
+			if d.Unique.IsUnique && !d.Unique.WithoutIndex &&
+				d.Unique.Deferrability != tree.ConstraintNotDeferrable {
+				return nil, newDeferrableIndexConstraintError()
+			}
 			if d.Unique.WithoutIndex {
 				if err := addUniqueWithoutIndexColumnTableDef(
 					ctx, evalCtx, sessionData, d, &desc, NewTable, tree.ValidationDefault,
@@ -2259,6 +2350,13 @@ func NewTableDesc(
 				return nil, err
 			}
 
+		case *tree.ExcludeConstraintTableDef:
+			if err := addExclusionConstraintTableDef(
+				ctx, evalCtx, d, &desc, n.Table, NewTable, tree.ValidationDefault, semaCtx,
+			); err != nil {
+				return nil, err
+			}
+
 		default:
 			return nil, errors.Errorf("unsupported table def: %T", def)
 		}
@@ -2669,6 +2767,14 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				defs = append(defs, &def)
 			}
 			for _, c := range td.UniqueWithoutIndexConstraints {
+				if c.IsExclusion() {
+					def, err := makeExclusionConstraintTableDef(td, &c)
+					if err != nil {
+						return nil, err
+					}
+					defs = append(defs, def)
+					continue
+				}
 				def := tree.UniqueConstraintTableDef{
 					IndexTableDef: tree.IndexTableDef{
 						Name:    tree.Name(c.Name),
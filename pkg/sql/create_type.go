@@ -232,6 +232,8 @@ func CreateUserDefinedArrayTypeDesc(
 			labels[i] = e.ElementLabel
 		}
 		elemTyp = types.NewCompositeType(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), contents, labels)
+	case descpb.TypeDescriptor_DOMAIN:
+		elemTyp = types.MakeDomain(typDesc.Domain.BaseType, catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id))
 	default:
 		return nil, errors.AssertionFailedf("cannot make array type for kind %s", t.String())
 	}
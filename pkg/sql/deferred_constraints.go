@@ -0,0 +1,879 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// deferredConstraintKey identifies a constraint on a table.
+type deferredConstraintKey struct {
+	tableID      descpb.ID
+	constraintID descpb.ConstraintID
+}
+
+// constraintsMode is the checking mode set by SET CONSTRAINTS ALL.
+type constraintsMode int8
+
+const (
+	// constraintsModeDefault means that each deferrable constraint is checked
+	// according to its INITIALLY DEFERRED or INITIALLY IMMEDIATE declaration.
+	constraintsModeDefault constraintsMode = iota
+	// constraintsModeImmediate means that all deferrable constraints are
+	// checked at the end of each statement.
+	constraintsModeImmediate
+	// constraintsModeDeferred means that all deferrable constraints are checked
+	// when the transaction commits.
+	constraintsModeDeferred
+)
+
+// deferredConstraintMaxTrackedRows is the maximum number of rows which are
+// tracked for the validation of deferred constraints on a table. Past this
+// limit, the constraints are validated against the whole table.
+const deferredConstraintMaxTrackedRows = 10000
+
+// deferredConstraintValidationBatchSize is the maximum number of tracked rows
+// which are validated by a single query.
+const deferredConstraintValidationBatchSize = 1000
+
+// txnDeferredConstraints tracks the state of deferrable constraints within a
+// transaction: the checking modes set by SET CONSTRAINTS, and the constraints
+// whose checks were skipped by mutations and which therefore have to be
+// validated before the transaction commits.
+//
+// Mutations record the rows they write while a constraint of the table is
+// deferred, and only these rows are validated, unless too many rows were
+// written.
+type txnDeferredConstraints struct {
+	constraintModes
+	// pending contains the constraints which have to be validated before the
+	// transaction commits.
+	pending map[deferredConstraintKey]*pendingConstraint
+	// written contains, for each table, the primary keys of the rows inserted
+	// or updated while some constraints of the table were deferred.
+	written map[descpb.ID]*trackedRows
+}
+
+// constraintModes contains the checking modes set by SET CONSTRAINTS.
+type constraintModes struct {
+	// all is the mode set by SET CONSTRAINTS ALL.
+	all constraintsMode
+	// modes contains the modes set by SET CONSTRAINTS for individual
+	// constraints, which take precedence over all. The value is true if the
+	// constraint is deferred.
+	modes map[deferredConstraintKey]bool
+}
+
+// pendingConstraint is a constraint which has to be validated before the
+// transaction commits.
+type pendingConstraint struct {
+	deferrability descpb.ConstraintDeferrability
+	// referenced contains, for a foreign key, the referenced values of the
+	// rows deleted or updated in the referenced table. The referencing rows
+	// with these values are validated in addition to the rows written in the
+	// referencing table.
+	referenced trackedRows
+}
+
+// trackedRows is a set of rows, identified by the values of some of their
+// columns.
+type trackedRows struct {
+	// rows contains the tracked values, in the order in which they were
+	// added, and keys their encoding.
+	rows []tree.Datums
+	keys []string
+	seen map[string]struct{}
+	// all is set if the set of rows is unknown or too large, in which case all
+	// rows of the table have to be validated.
+	all bool
+}
+
+// add adds the given values to the set if they are not part of it yet.
+func (t *trackedRows) add(vals tree.Datums) {
+	if t.all {
+		return
+	}
+	var key []byte
+	for _, d := range vals {
+		var err error
+		if key, err = keyside.Encode(key, d, encoding.Ascending); err != nil {
+			// The values can't be tracked, so validate the whole table.
+			t.all = true
+			return
+		}
+	}
+	if _, ok := t.seen[string(key)]; ok {
+		return
+	}
+	if len(t.rows) >= deferredConstraintMaxTrackedRows {
+		t.all = true
+		return
+	}
+	if t.seen == nil {
+		t.seen = make(map[string]struct{})
+	}
+	t.seen[string(key)] = struct{}{}
+	t.rows = append(t.rows, append(tree.Datums(nil), vals...))
+	t.keys = append(t.keys, string(key))
+}
+
+// empty returns true if there are no rows to validate.
+func (t *trackedRows) empty() bool {
+	return t == nil || (!t.all && len(t.rows) == 0)
+}
+
+// trackedRowsPos is the state of a trackedRows at a savepoint.
+type trackedRowsPos struct {
+	len int
+	all bool
+}
+
+func (t *trackedRows) pos() trackedRowsPos {
+	return trackedRowsPos{len: len(t.rows), all: t.all}
+}
+
+// rollbackTo discards the rows added after the given position.
+func (t *trackedRows) rollbackTo(pos trackedRowsPos) {
+	for _, key := range t.keys[pos.len:] {
+		delete(t.seen, key)
+	}
+	t.rows = t.rows[:pos.len]
+	t.keys = t.keys[:pos.len]
+	t.all = pos.all
+}
+
+// isDeferred returns whether a constraint with the given key and declared
+// deferrability is currently deferred.
+func (s *constraintModes) isDeferred(
+	key deferredConstraintKey, d descpb.ConstraintDeferrability,
+) bool {
+	if d == descpb.ConstraintDeferrability_NotDeferrable {
+		return false
+	}
+	if deferred, ok := s.modes[key]; ok {
+		return deferred
+	}
+	switch s.all {
+	case constraintsModeImmediate:
+		return false
+	case constraintsModeDeferred:
+		return true
+	}
+	return d == descpb.ConstraintDeferrability_InitiallyDeferred
+}
+
+// setAll implements SET CONSTRAINTS ALL, which overrides any modes previously
+// set for individual constraints.
+func (s *constraintModes) setAll(deferred bool) {
+	s.all = constraintsModeImmediate
+	if deferred {
+		s.all = constraintsModeDeferred
+	}
+	s.modes = nil
+}
+
+// set implements SET CONSTRAINTS for a single constraint.
+func (s *constraintModes) set(key deferredConstraintKey, deferred bool) {
+	if s.modes == nil {
+		s.modes = make(map[deferredConstraintKey]bool)
+	}
+	s.modes[key] = deferred
+}
+
+func (s *constraintModes) clone() constraintModes {
+	c := constraintModes{all: s.all}
+	if s.modes != nil {
+		c.modes = make(map[deferredConstraintKey]bool, len(s.modes))
+		for key, deferred := range s.modes {
+			c.modes[key] = deferred
+		}
+	}
+	return c
+}
+
+// addPending records that the given constraint has to be validated before
+// the transaction commits.
+func (s *txnDeferredConstraints) addPending(
+	key deferredConstraintKey, d descpb.ConstraintDeferrability,
+) *pendingConstraint {
+	pc, ok := s.pending[key]
+	if !ok {
+		if s.pending == nil {
+			s.pending = make(map[deferredConstraintKey]*pendingConstraint)
+		}
+		pc = &pendingConstraint{deferrability: d}
+		s.pending[key] = pc
+	}
+	return pc
+}
+
+// writtenRows returns the rows written in the given table.
+func (s *txnDeferredConstraints) writtenRows(tableID descpb.ID) *trackedRows {
+	t, ok := s.written[tableID]
+	if !ok {
+		if s.written == nil {
+			s.written = make(map[descpb.ID]*trackedRows)
+		}
+		t = &trackedRows{}
+		s.written[tableID] = t
+	}
+	return t
+}
+
+// deferredValidation is a constraint to validate along with the rows to
+// validate it against.
+type deferredValidation struct {
+	key deferredConstraintKey
+	// written contains the primary keys of the rows written in the table of
+	// the constraint. It can be nil.
+	written *trackedRows
+	// referenced is as in pendingConstraint.
+	referenced *trackedRows
+}
+
+func (s *txnDeferredConstraints) makeValidation(
+	key deferredConstraintKey, pc *pendingConstraint,
+) deferredValidation {
+	return deferredValidation{key: key, written: s.written[key.tableID], referenced: &pc.referenced}
+}
+
+// takeImmediate removes and returns the pending constraints which are no
+// longer deferred.
+func (s *txnDeferredConstraints) takeImmediate() []deferredValidation {
+	var vals []deferredValidation
+	for key, pc := range s.pending {
+		if !s.isDeferred(key, pc.deferrability) {
+			vals = append(vals, s.makeValidation(key, pc))
+			delete(s.pending, key)
+		}
+	}
+	sortDeferredValidations(vals)
+	return vals
+}
+
+// takePending removes and returns all pending constraints.
+func (s *txnDeferredConstraints) takePending() []deferredValidation {
+	vals := make([]deferredValidation, 0, len(s.pending))
+	for key, pc := range s.pending {
+		vals = append(vals, s.makeValidation(key, pc))
+	}
+	s.pending = nil
+	s.written = nil
+	sortDeferredValidations(vals)
+	return vals
+}
+
+// sortDeferredValidations sorts the validations so that constraints are
+// validated in a deterministic order.
+func sortDeferredValidations(vals []deferredValidation) {
+	sort.Slice(vals, func(i, j int) bool {
+		if vals[i].key.tableID != vals[j].key.tableID {
+			return vals[i].key.tableID < vals[j].key.tableID
+		}
+		return vals[i].key.constraintID < vals[j].key.constraintID
+	})
+}
+
+// reset clears the state at the end of a transaction.
+func (s *txnDeferredConstraints) reset() {
+	*s = txnDeferredConstraints{}
+}
+
+// deferredConstraintsSavepoint is the state of deferrable constraints when a
+// savepoint was created. Rolling back to the savepoint restores the modes set
+// by SET CONSTRAINTS, and forgets the rows written since.
+type deferredConstraintsSavepoint struct {
+	modes   constraintModes
+	pending map[deferredConstraintKey]pendingConstraintPos
+	written map[descpb.ID]trackedRowsPos
+}
+
+type pendingConstraintPos struct {
+	pc  *pendingConstraint
+	pos trackedRowsPos
+}
+
+// savepoint returns the current state, to be restored by rollbackTo.
+func (s *txnDeferredConstraints) savepoint() deferredConstraintsSavepoint {
+	sp := deferredConstraintsSavepoint{modes: s.clone()}
+	if len(s.pending) > 0 {
+		sp.pending = make(map[deferredConstraintKey]pendingConstraintPos, len(s.pending))
+		for key, pc := range s.pending {
+			sp.pending[key] = pendingConstraintPos{pc: pc, pos: pc.referenced.pos()}
+		}
+	}
+	if len(s.written) > 0 {
+		sp.written = make(map[descpb.ID]trackedRowsPos, len(s.written))
+		for id, t := range s.written {
+			sp.written[id] = t.pos()
+		}
+	}
+	return sp
+}
+
+// rollbackTo restores the state at the given savepoint. Constraints which
+// were validated since the savepoint are pending again, since the validation
+// is rolled back along with the modes which caused it.
+func (s *txnDeferredConstraints) rollbackTo(sp deferredConstraintsSavepoint) {
+	s.constraintModes = sp.modes.clone()
+	for key := range s.pending {
+		if _, ok := sp.pending[key]; !ok {
+			delete(s.pending, key)
+		}
+	}
+	for key, p := range sp.pending {
+		p.pc.referenced.rollbackTo(p.pos)
+		if s.pending == nil {
+			s.pending = make(map[deferredConstraintKey]*pendingConstraint)
+		}
+		s.pending[key] = p.pc
+	}
+	for id, t := range s.written {
+		if pos, ok := sp.written[id]; ok {
+			t.rollbackTo(pos)
+		} else {
+			delete(s.written, id)
+		}
+	}
+}
+
+// deferredConstraintTracker records the rows written by a mutation which
+// have to be validated for the deferred constraints of the table.
+type deferredConstraintTracker struct {
+	state *txnDeferredConstraints
+	desc  catalog.TableDescriptor
+	// constraints contains the deferred CHECK, UNIQUE WITHOUT INDEX and
+	// outbound FK constraints, which are validated against the rows
+	// inserted or updated in the table.
+	constraints []catalog.Constraint
+	// inbound contains the deferred FKs referencing the table with a NO
+	// ACTION reference action, which are validated against the rows of the
+	// referencing table matching the rows deleted or updated in the table.
+	inbound []catalog.ForeignKeyConstraint
+	// recorded is set once the constraints have been added to the pending
+	// constraints of the transaction.
+	recorded bool
+	scratch  tree.Datums
+}
+
+// deferredMutation is the kind of mutation tracked by a
+// deferredConstraintTracker.
+type deferredMutation int8
+
+const (
+	deferredInsert deferredMutation = iota
+	// deferredUpdate is used for both UPDATE and UPSERT.
+	deferredUpdate
+	deferredDelete
+)
+
+// newDeferredConstraintTracker returns a tracker for a mutation of the given
+// kind on the given table, or nil if no constraint of the table is deferred.
+func (p *planner) newDeferredConstraintTracker(
+	desc catalog.TableDescriptor, m deferredMutation,
+) *deferredConstraintTracker {
+	state := p.extendedEvalCtx.deferredConstraints
+	if state == nil {
+		return nil
+	}
+	t := &deferredConstraintTracker{state: state, desc: desc}
+	if m != deferredDelete {
+		for _, c := range desc.EnforcedConstraints() {
+			key := deferredConstraintKey{tableID: desc.GetID(), constraintID: c.GetConstraintID()}
+			if state.isDeferred(key, c.GetDeferrability()) {
+				t.constraints = append(t.constraints, c)
+			}
+		}
+	}
+	if m != deferredInsert {
+		for _, fk := range desc.InboundForeignKeys() {
+			action := fk.OnUpdate()
+			if m == deferredDelete {
+				action = fk.OnDelete()
+			}
+			if action != semenumpb.ForeignKeyAction_NO_ACTION {
+				// Cascading actions and RESTRICT are never deferred.
+				continue
+			}
+			key := deferredConstraintKey{tableID: fk.GetOriginTableID(), constraintID: fk.GetConstraintID()}
+			if state.isDeferred(key, fk.GetDeferrability()) {
+				t.inbound = append(t.inbound, fk)
+			}
+		}
+	}
+	if len(t.constraints) == 0 && len(t.inbound) == 0 {
+		return nil
+	}
+	return t
+}
+
+// newRow records a row inserted in the table, or the new values of an updated
+// row. colMap maps column IDs to ordinals in vals.
+func (t *deferredConstraintTracker) newRow(vals tree.Datums, colMap catalog.TableColMap) {
+	if t == nil || len(t.constraints) == 0 {
+		return
+	}
+	if !t.recorded {
+		for _, c := range t.constraints {
+			t.state.addPending(
+				deferredConstraintKey{tableID: t.desc.GetID(), constraintID: c.GetConstraintID()},
+				c.GetDeferrability(),
+			)
+		}
+		t.recorded = true
+	}
+	written := t.state.writtenRows(t.desc.GetID())
+	pk := t.desc.GetPrimaryIndex()
+	t.scratch = t.scratch[:0]
+	for i := 0; i < pk.NumKeyColumns(); i++ {
+		ord, ok := colMap.Get(pk.GetKeyColumnID(i))
+		if !ok {
+			written.all = true
+			return
+		}
+		t.scratch = append(t.scratch, vals[ord])
+	}
+	written.add(t.scratch)
+}
+
+// oldRow records a row deleted from the table, or the old values of an
+// updated row, in which case newVals contains the new values. colMap maps
+// column IDs to ordinals in both.
+func (t *deferredConstraintTracker) oldRow(oldVals, newVals tree.Datums, colMap catalog.TableColMap) {
+	if t == nil {
+		return
+	}
+	for _, fk := range t.inbound {
+		t.scratch = t.scratch[:0]
+		all, hasNull, changed := false, false, newVals == nil
+		for i, n := 0, fk.NumReferencedColumns(); i < n; i++ {
+			ord, ok := colMap.Get(fk.GetReferencedColumnID(i))
+			if !ok {
+				all = true
+				break
+			}
+			if oldVals[ord] == tree.DNull {
+				// No row references a key with a NULL value.
+				hasNull = true
+				break
+			}
+			if !changed && !datumsEqualForKey(oldVals[ord], newVals[ord]) {
+				changed = true
+			}
+			t.scratch = append(t.scratch, oldVals[ord])
+		}
+		if !all && (hasNull || !changed) {
+			continue
+		}
+		pc := t.state.addPending(
+			deferredConstraintKey{tableID: fk.GetOriginTableID(), constraintID: fk.GetConstraintID()},
+			fk.GetDeferrability(),
+		)
+		if all {
+			pc.referenced.all = true
+		} else {
+			pc.referenced.add(t.scratch)
+		}
+	}
+}
+
+// unknownRowsDeleted records that rows were deleted from the table without
+// their values being known, as is the case for the fast path of DELETE.
+func (t *deferredConstraintTracker) unknownRowsDeleted() {
+	if t == nil {
+		return
+	}
+	for _, fk := range t.inbound {
+		t.state.addPending(
+			deferredConstraintKey{tableID: fk.GetOriginTableID(), constraintID: fk.GetConstraintID()},
+			fk.GetDeferrability(),
+		).referenced.all = true
+	}
+}
+
+// unknownRowsOverwritten records that rows were overwritten without their
+// previous values being known, as is the case for blind UPSERTs. Only the
+// referenced values which are not part of the primary key could have changed.
+func (t *deferredConstraintTracker) unknownRowsOverwritten() {
+	if t == nil {
+		return
+	}
+	pkCols := t.desc.GetPrimaryIndex().CollectKeyColumnIDs()
+	for _, fk := range t.inbound {
+		for i, n := 0, fk.NumReferencedColumns(); i < n; i++ {
+			if !pkCols.Contains(fk.GetReferencedColumnID(i)) {
+				t.state.addPending(
+					deferredConstraintKey{tableID: fk.GetOriginTableID(), constraintID: fk.GetConstraintID()},
+					fk.GetDeferrability(),
+				).referenced.all = true
+				break
+			}
+		}
+	}
+}
+
+// datumsEqualForKey returns true if the given values have the same key
+// encoding.
+func datumsEqualForKey(a, b tree.Datum) bool {
+	ak, err := keyside.Encode(nil, a, encoding.Ascending)
+	if err != nil {
+		return false
+	}
+	bk, err := keyside.Encode(nil, b, encoding.Ascending)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(ak, bk)
+}
+
+// isConstraintDeferred returns true if the check of the deferrable constraint
+// with the given name on the given table is currently deferred until the end
+// of the transaction. The rows which have to be validated are recorded when
+// the mutation runs, see deferredConstraintTracker.
+func (p *planner) isConstraintDeferred(ctx context.Context, tableID descpb.ID, name string) bool {
+	state := p.extendedEvalCtx.deferredConstraints
+	if state == nil {
+		return false
+	}
+	tbl, err := p.LookupTableByID(ctx, tableID)
+	if err != nil {
+		log.VEventf(ctx, 2, "could not look up table %d: %v", tableID, err)
+		return false
+	}
+	c := catalog.FindConstraintByName(tbl, name)
+	if c == nil {
+		return false
+	}
+	key := deferredConstraintKey{tableID: tableID, constraintID: c.GetConstraintID()}
+	return state.isDeferred(key, c.GetDeferrability())
+}
+
+// validateDeferredConstraints validates the given constraints within the
+// current transaction. Constraints which were dropped in the meantime are
+// ignored.
+func (p *planner) validateDeferredConstraints(
+	ctx context.Context, vals []deferredValidation,
+) error {
+	txn := p.InternalSQLTxn()
+	for _, v := range vals {
+		tbl, err := p.Descriptors().ByIDWithLeased(p.Txn()).Get().Table(ctx, v.key.tableID)
+		if err != nil {
+			return err
+		}
+		if tbl.Dropped() {
+			continue
+		}
+		mut := tabledesc.NewBuilder(tbl.TableDesc()).BuildExistingMutableTable()
+		c := catalog.FindConstraintByID(mut, v.key.constraintID)
+		if c == nil || !c.IsEnforced() {
+			continue
+		}
+		if ck := c.AsCheck(); ck != nil {
+			err = p.validateDeferredCheck(ctx, txn, mut, ck, v.written)
+		} else if fk := c.AsForeignKey(); fk != nil {
+			err = validateDeferredForeignKey(ctx, txn, mut, fk, v.written, v.referenced)
+		} else if uwi := c.AsUniqueWithoutIndex(); uwi != nil {
+			err = validateDeferredUniqueWithoutIndex(ctx, txn, mut, uwi, v.written, p.User())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDeferredCheck validates a CHECK constraint against the given rows
+// of the table. A violation results in the same error as when the constraint
+// is checked immediately.
+func (p *planner) validateDeferredCheck(
+	ctx context.Context,
+	txn descs.Txn,
+	tableDesc *tabledesc.Mutable,
+	ck catalog.CheckConstraint,
+	written *trackedRows,
+) error {
+	if written.empty() {
+		return nil
+	}
+	pkCols, err := primaryKeyColumnNames(tableDesc, "t")
+	if err != nil {
+		return err
+	}
+	validate := func(filter string) error {
+		query := fmt.Sprintf(`SELECT 1 FROM [%d AS t] WHERE NOT (%s) AND %s LIMIT 1`,
+			tableDesc.GetID(), ck.GetExpr(), filter)
+		log.VEventf(ctx, 2, "validating deferred check constraint %q with query %q", ck.GetName(), query)
+		violatingRow, err := txn.QueryRowEx(
+			ctx, "validate deferred check constraint", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride, query,
+		)
+		if err != nil {
+			return err
+		}
+		if len(violatingRow) > 0 {
+			return row.CheckFailed(ctx, p.EvalContext(), &p.semaCtx, p.SessionData(), tableDesc, ck)
+		}
+		return nil
+	}
+	return withSyntheticTable(txn, tableDesc, func() error {
+		if written.all {
+			return validate("true")
+		}
+		return forEachRowsBatch(written.rows, func(rows []tree.Datums) error {
+			return validate(inRowsFilter(pkCols, rows))
+		})
+	})
+}
+
+// validateDeferredForeignKey validates a foreign key constraint against the
+// given rows written in the referencing table, and against the rows of the
+// referencing table which match the given referenced values.
+func validateDeferredForeignKey(
+	ctx context.Context,
+	txn descs.Txn,
+	srcTable *tabledesc.Mutable,
+	c catalog.ForeignKeyConstraint,
+	written, referenced *trackedRows,
+) error {
+	if written.empty() && referenced.empty() {
+		return nil
+	}
+	if (written != nil && written.all) || (referenced != nil && referenced.all) {
+		return validateFkInTxn(ctx, txn, srcTable, c.GetName())
+	}
+	_, fk, targetTable, err := getTargetTablesAndFk(ctx, srcTable, txn, c.GetName())
+	if err != nil {
+		return err
+	}
+	pkCols, err := primaryKeyColumnNames(srcTable, "v")
+	if err != nil {
+		return err
+	}
+	originCols := make([]string, len(fk.OriginColumnIDs))
+	for i, id := range fk.OriginColumnIDs {
+		col, err := catalog.MustFindColumnByID(srcTable, id)
+		if err != nil {
+			return err
+		}
+		originCols[i] = "v." + tree.NameString(col.GetName())
+	}
+
+	// validate runs the given query, which returns the violating rows, for each
+	// batch of the given rows of the referencing table.
+	validate := func(
+		query string, colNames []string, filterCols []string, rows []tree.Datums, makeErr func(string) error,
+	) error {
+		return forEachRowsBatch(rows, func(rows []tree.Datums) error {
+			q := fmt.Sprintf(`SELECT * FROM (%s) AS v WHERE %s LIMIT 1`, query, inRowsFilter(filterCols, rows))
+			log.VEventf(ctx, 2, "validating deferred FK %q with query %q", fk.Name, q)
+			values, err := txn.QueryRowEx(ctx, "validate deferred fk constraint", txn.KV(),
+				sessiondata.NodeUserSessionDataOverride, q)
+			if err != nil {
+				return err
+			}
+			if values.Len() > 0 {
+				return pgerror.WithConstraintName(makeErr(formatValues(colNames, values)), fk.Name)
+			}
+			return nil
+		})
+	}
+
+	return withSyntheticTable(txn, srcTable, func() error {
+		if !written.empty() && len(fk.OriginColumnIDs) > 1 && fk.Match == semenumpb.Match_FULL {
+			query, colNames, err := matchFullUnacceptableKeyQuery(srcTable, fk, false /* limitResults */)
+			if err != nil {
+				return err
+			}
+			if err := validate(query, colNames, pkCols, written.rows, func(values string) error {
+				return pgerror.Newf(pgcode.ForeignKeyViolation,
+					"foreign key violation: MATCH FULL does not allow mixing of null and nonnull values %s for %s",
+					values, fk.Name,
+				)
+			}); err != nil {
+				return err
+			}
+		}
+		query, colNames, err := nonMatchingRowQuery(
+			srcTable, fk, targetTable, 0 /* indexIDForValidation */, false, /* limitResults */
+		)
+		if err != nil {
+			return err
+		}
+		makeErr := func(values string) error {
+			return pgerror.Newf(pgcode.ForeignKeyViolation,
+				"foreign key violation: %q row %s has no match in %q",
+				srcTable.Name, values, targetTable.GetName())
+		}
+		if !written.empty() {
+			if err := validate(query, colNames, pkCols, written.rows, makeErr); err != nil {
+				return err
+			}
+		}
+		if !referenced.empty() {
+			if err := validate(query, colNames, originCols, referenced.rows, makeErr); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// validateDeferredUniqueWithoutIndex validates a UNIQUE WITHOUT INDEX
+// constraint against the given rows of the table.
+func validateDeferredUniqueWithoutIndex(
+	ctx context.Context,
+	txn descs.Txn,
+	tableDesc *tabledesc.Mutable,
+	uwi catalog.UniqueWithoutIndexConstraint,
+	written *trackedRows,
+	user username.SQLUsername,
+) error {
+	if written.empty() {
+		return nil
+	}
+	if written.all || uwi.IsExclusion() {
+		return validateUniqueWithoutIndexConstraint(
+			ctx, tableDesc, uwi, 0 /* indexIDForValidation */, txn, user, true, /* preExisting */
+		)
+	}
+	query, colNames, err := duplicateRowQuery(
+		tableDesc, uwi.CollectKeyColumnIDs().Ordered(), uwi.GetPredicate(),
+		0 /* indexIDForValidation */, false, /* limitResults */
+	)
+	if err != nil {
+		return err
+	}
+	cols := make([]string, len(colNames))
+	qualifiedCols := make([]string, len(colNames))
+	for i, n := range colNames {
+		cols[i] = tree.NameString(n)
+		qualifiedCols[i] = "v." + cols[i]
+	}
+	pkCols, err := primaryKeyColumnNames(tableDesc, "w")
+	if err != nil {
+		return err
+	}
+	return withSyntheticTable(txn, tableDesc, func() error {
+		return forEachRowsBatch(written.rows, func(rows []tree.Datums) error {
+			// Only the groups of the written rows can contain duplicates.
+			q := fmt.Sprintf(
+				`SELECT * FROM (%[1]s) AS v WHERE (%[2]s) IN (SELECT %[3]s FROM [%[4]d AS w] WHERE %[5]s) LIMIT 1`,
+				query,
+				strings.Join(qualifiedCols, ", "),
+				strings.Join(cols, ", "),
+				tableDesc.GetID(),
+				inRowsFilter(pkCols, rows),
+			)
+			log.VEventf(ctx, 2, "validating deferred unique constraint %q with query %q", uwi.GetName(), q)
+			values, err := queryValidationRowWithRetry(ctx, txn, "validate deferred unique constraint", user, q)
+			if err != nil {
+				return err
+			}
+			if values.Len() > 0 {
+				valuesStr := make([]string, len(values))
+				for i := range values {
+					valuesStr[i] = values[i].String()
+				}
+				return errors.WithDetail(
+					pgerror.WithConstraintName(
+						pgerror.Newf(
+							pgcode.UniqueViolation, "failed to validate unique constraint %q", uwi.GetName(),
+						),
+						uwi.GetName(),
+					),
+					fmt.Sprintf(
+						"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
+					),
+				)
+			}
+			return nil
+		})
+	})
+}
+
+// withSyntheticTable runs fn with the given table descriptor used by the
+// queries of txn if it was modified by the transaction.
+func withSyntheticTable(txn descs.Txn, tableDesc *tabledesc.Mutable, fn func() error) error {
+	var syntheticDescs []catalog.Descriptor
+	if tableDesc.Version > tableDesc.ClusterVersion().Version {
+		syntheticDescs = append(syntheticDescs, tableDesc)
+	}
+	return txn.WithSyntheticDescriptors(syntheticDescs, fn)
+}
+
+// primaryKeyColumnNames returns the names of the primary key columns of the
+// table, qualified with the given table alias.
+func primaryKeyColumnNames(tableDesc catalog.TableDescriptor, alias string) ([]string, error) {
+	pk := tableDesc.GetPrimaryIndex()
+	names := make([]string, pk.NumKeyColumns())
+	for i := range names {
+		col, err := catalog.MustFindColumnByID(tableDesc, pk.GetKeyColumnID(i))
+		if err != nil {
+			return nil, err
+		}
+		names[i] = alias + "." + tree.NameString(col.GetName())
+	}
+	return names, nil
+}
+
+// forEachRowsBatch calls fn with consecutive batches of the given rows.
+func forEachRowsBatch(rows []tree.Datums, fn func([]tree.Datums) error) error {
+	for len(rows) > 0 {
+		n := len(rows)
+		if n > deferredConstraintValidationBatchSize {
+			n = deferredConstraintValidationBatchSize
+		}
+		if err := fn(rows[:n]); err != nil {
+			return err
+		}
+		rows = rows[n:]
+	}
+	return nil
+}
+
+// inRowsFilter returns an expression which restricts the given columns to the
+// values of the given rows, e.g. (t.a, t.b) IN ((1, 'x'), (2, 'y')).
+func inRowsFilter(cols []string, rows []tree.Datums) string {
+	var buf strings.Builder
+	buf.WriteByte('(')
+	buf.WriteString(strings.Join(cols, ", "))
+	buf.WriteString(") IN (")
+	for i, row := range rows {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteByte('(')
+		for j, d := range row {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(tree.AsStringWithFlags(d, tree.FmtParsable))
+		}
+		buf.WriteByte(')')
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
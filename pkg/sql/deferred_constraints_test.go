@@ -0,0 +1,90 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackedRows(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	row := func(vals ...int) tree.Datums {
+		r := make(tree.Datums, len(vals))
+		for i, v := range vals {
+			r[i] = tree.NewDInt(tree.DInt(v))
+		}
+		return r
+	}
+
+	var rows trackedRows
+	require.True(t, rows.empty())
+	rows.add(row(1, 2))
+	rows.add(row(1, 3))
+	rows.add(row(1, 2))
+	require.Equal(t, []tree.Datums{row(1, 2), row(1, 3)}, rows.rows)
+
+	pos := rows.pos()
+	rows.add(row(4, 5))
+	rows.add(row(1, 3))
+	require.Len(t, rows.rows, 3)
+	rows.rollbackTo(pos)
+	require.Equal(t, []tree.Datums{row(1, 2), row(1, 3)}, rows.rows)
+	rows.add(row(4, 5))
+	require.Len(t, rows.rows, 3)
+
+	// Past the limit, all rows of the table are validated.
+	for i := 0; i < deferredConstraintMaxTrackedRows; i++ {
+		rows.add(row(i, i))
+	}
+	require.True(t, rows.all)
+	require.False(t, rows.empty())
+	rows.rollbackTo(pos)
+	require.False(t, rows.all)
+	require.Len(t, rows.rows, 2)
+}
+
+func TestDeferredConstraintsSavepoint(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	a := deferredConstraintKey{tableID: 100, constraintID: 2}
+	b := deferredConstraintKey{tableID: 101, constraintID: 3}
+	const deferrable = descpb.ConstraintDeferrability_InitiallyImmediate
+
+	var s txnDeferredConstraints
+	s.setAll(true /* deferred */)
+	s.addPending(a, deferrable)
+	s.writtenRows(a.tableID).add(tree.Datums{tree.NewDInt(1)})
+
+	sp := s.savepoint()
+	s.set(a, false /* deferred */)
+	require.False(t, s.isDeferred(a, deferrable))
+	vals := s.takeImmediate()
+	require.Len(t, vals, 1)
+	require.Equal(t, a, vals[0].key)
+	s.addPending(b, deferrable)
+	s.writtenRows(a.tableID).add(tree.Datums{tree.NewDInt(2)})
+	s.writtenRows(b.tableID).add(tree.Datums{tree.NewDInt(3)})
+
+	// Rolling back to the savepoint restores the modes, makes the constraint
+	// validated since the savepoint pending again, and forgets the other
+	// constraints and rows.
+	s.rollbackTo(sp)
+	require.True(t, s.isDeferred(a, deferrable))
+	require.True(t, s.isDeferred(b, deferrable))
+	vals = s.takePending()
+	require.Len(t, vals, 1)
+	require.Equal(t, a, vals[0].key)
+	require.Equal(t, []tree.Datums{{tree.NewDInt(1)}}, vals[0].written.rows)
+}
@@ -58,7 +58,8 @@ SELECT
       table_id = ANY (descriptor_ids)
   ) AS full_table_names,
   changefeed_details->'opts'->>'topics' AS topics,
-  COALESCE(changefeed_details->'opts'->>'format','json') AS format
+  COALESCE(changefeed_details->'opts'->>'format','json') AS format,
+  changefeed_details->'opts'->>'dlq_table' AS dlq_table
 FROM
   crdb_internal.jobs
   INNER JOIN payload ON id = job_id`
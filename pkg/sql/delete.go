@@ -68,6 +68,7 @@ func (d *deleteNode) startExec(params runParams) error {
 			params.p.Mon().MakeBoundAccount(),
 			colinfo.ColTypeInfoFromResCols(d.columns))
 	}
+	d.run.td.deferred = params.p.newDeferredConstraintTracker(d.run.td.tableDesc(), deferredDelete)
 	return d.run.td.init(params.ctx, params.p.txn, params.EvalContext())
 }
 
@@ -103,6 +103,15 @@ func (d *deleteRangeNode) startExec(params runParams) error {
 		return err
 	}
 
+	// The deleted rows are not known, so the deferred foreign keys referencing
+	// the table are validated against the whole referencing table.
+	if t := params.p.newDeferredConstraintTracker(d.desc, deferredDelete); t != nil {
+		t.unknownRowsDeleted()
+		// Deferred constraints have to be validated before the transaction
+		// commits.
+		d.autoCommitEnabled = false
+	}
+
 	ctx := params.ctx
 	log.VEvent(ctx, 2, "fast delete: skipping scan")
 	spans := make([]roachpb.Span, len(d.spans))
@@ -104,6 +104,9 @@ var aggregateFuncToNumArguments = map[execinfrapb.AggregatorSpec_Func]int{
 	execinfrapb.MergeStatementStats:         1,
 	execinfrapb.MergeTransactionStats:       1,
 	execinfrapb.MergeAggregatedStmtMetadata: 1,
+	execinfrapb.StClusterwithin:             2,
+	execinfrapb.StClusterintersecting:       1,
+	execinfrapb.StPolygonize:                1,
 }
 
 // TestAggregateFuncToNumArguments ensures that all aggregate functions are
@@ -129,6 +132,10 @@ func TestAggregateFuncToNumArguments(t *testing.T) {
 	fns := make([]execinfrapb.AggregatorSpec_Func, 0,
 		len(execinfrapb.AggregatorSpec_Func_name))
 	for fn := range execinfrapb.AggregatorSpec_Func_name {
+		if execinfrapb.AggregatorSpec_Func(fn) == execinfrapb.UserDefined {
+			// User-defined aggregates don't have a builtin.
+			continue
+		}
 		fns = append(fns, execinfrapb.AggregatorSpec_Func(fn))
 	}
 	sort.Slice(fns, func(i, j int) bool { return fns[i] < fns[j] })
@@ -388,6 +395,89 @@ func TestAggregatorAgainstProcessor(t *testing.T) {
 	}
 }
 
+func TestGroupingSetsAggregatorAgainstProcessor(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	rng, seed := randutil.NewTestRand()
+	const (
+		numGroupCols    = 3
+		nullProbability = 0.2
+	)
+	// The first numGroupCols columns are grouped by, and the last one is only
+	// aggregated.
+	inputTypes := []*types.T{types.Int, types.Int, types.Int, types.Int}
+	groupCols := []uint32{0, 1, 2}
+	aggregations := []execinfrapb.AggregatorSpec_Aggregation{
+		{Func: execinfrapb.CountRows},
+		{Func: execinfrapb.Count, ColIdx: []uint32{3}},
+		{Func: execinfrapb.SumInt, ColIdx: []uint32{3}},
+		// The aggregate functions see the values of the group columns that
+		// aren't part of the grouping set of the row.
+		{Func: execinfrapb.Max, ColIdx: []uint32{2}},
+	}
+	// The output consists of the group columns, the aggregations and the
+	// grouping set ordinal, all of which are INTs.
+	outputTypes := make([]*types.T, numGroupCols+len(aggregations)+1)
+	for i := range outputTypes {
+		outputTypes[i] = types.Int
+	}
+	for _, tc := range []struct {
+		name string
+		sets [][]uint32
+	}{
+		{name: "rollup", sets: [][]uint32{{0, 1, 2}, {0, 1}, {0}, {}}},
+		{name: "cube", sets: [][]uint32{{0, 1}, {0}, {1}, {}}},
+		{name: "no empty set", sets: [][]uint32{{0}, {2}, {1, 2}}},
+		{name: "duplicate sets", sets: [][]uint32{{1}, {1}, {}, {}}},
+	} {
+		for _, numRows := range []int{0, 1, 100} {
+			for _, spillForced := range []bool{false, true} {
+				rows := make(rowenc.EncDatumRows, numRows)
+				for i := range rows {
+					rows[i] = make(rowenc.EncDatumRow, len(inputTypes))
+					for j := range rows[i] {
+						if rng.Float64() < nullProbability {
+							rows[i][j] = rowenc.EncDatum{Datum: tree.DNull}
+						} else {
+							rows[i][j] = rowenc.EncDatum{Datum: tree.NewDInt(tree.DInt(rng.Intn(4)))}
+						}
+					}
+				}
+				sets := make([]execinfrapb.AggregatorSpec_GroupingSet, len(tc.sets))
+				for i := range tc.sets {
+					sets[i].Cols = tc.sets[i]
+				}
+				pspec := &execinfrapb.ProcessorSpec{
+					Input: []execinfrapb.InputSyncSpec{{ColumnTypes: inputTypes}},
+					Core: execinfrapb.ProcessorCoreUnion{Aggregator: &execinfrapb.AggregatorSpec{
+						Type:         execinfrapb.AggregatorSpec_NON_SCALAR,
+						GroupCols:    groupCols,
+						Aggregations: aggregations,
+						GroupingSets: sets,
+					}},
+					ResultTypes: outputTypes,
+				}
+				args := verifyColOperatorArgs{
+					anyOrder:       true,
+					inputTypes:     [][]*types.T{inputTypes},
+					inputs:         []rowenc.EncDatumRows{rows},
+					pspec:          pspec,
+					forceDiskSpill: spillForced,
+					// An empty input doesn't make the aggregation spill.
+					forcedDiskSpillMightNotOccur: numRows == 0,
+				}
+				if err := verifyColOperator(t, args); err != nil {
+					fmt.Printf("--- seed = %d sets = %s rows = %d spill = %t ---\n",
+						seed, tc.name, numRows, spillForced)
+					prettyPrintInput(rows, inputTypes, "t" /* tableName */)
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+}
+
 func TestDistinctAgainstProcessor(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -1292,6 +1382,11 @@ func TestWindowFunctionsAgainstProcessor(t *testing.T) {
 			argTypes = []*types.T{randArgType}
 		case execinfrapb.WindowerSpec_NTH_VALUE:
 			argTypes = []*types.T{randArgType, types.Int}
+		case execinfrapb.WindowerSpec_ST_CLUSTERDBSCAN, execinfrapb.WindowerSpec_ST_CLUSTERKMEANS:
+			// The clustering functions take their parameters from the first row
+			// of each partition, which isn't deterministic when the ordering
+			// within a partition isn't total, so we skip them.
+			continue
 		}
 		orderNonPartitionCols := windowFn == execinfrapb.WindowerSpec_ROW_NUMBER ||
 			windowFn == execinfrapb.WindowerSpec_NTILE ||
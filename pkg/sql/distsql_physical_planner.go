@@ -14,6 +14,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/gossip"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -542,6 +543,18 @@ func checkSupportForPlanNode(
 			if agg.distsqlBlocklist {
 				return cannotDistribute, newQueryNotSupportedErrorf("aggregate %q cannot be executed with distsql", agg.funcName)
 			}
+			if uda := agg.userDefined; uda != nil {
+				for _, expr := range []tree.TypedExpr{
+					uda.Inlined.Transition, uda.Inlined.Final, uda.Inlined.Combine,
+				} {
+					if expr == nil {
+						continue
+					}
+					if err := checkExprForDistSQL(expr, distSQLVisitor); err != nil {
+						return cannotDistribute, err
+					}
+				}
+			}
 		}
 		// Distribute aggregations if possible.
 		return rec.compose(shouldDistribute), nil
@@ -734,6 +747,13 @@ func checkSupportForPlanNode(
 		if err != nil {
 			return cannotDistribute, err
 		}
+		for _, f := range n.funcs {
+			if f.expr.IsDistSQLBlocklist() {
+				return cannotDistribute, newQueryNotSupportedErrorf(
+					"window function %q cannot be executed with distsql", f.expr.Func.String(),
+				)
+			}
+		}
 		for _, f := range n.funcs {
 			if len(f.partitionIdxs) > 0 {
 				// If at least one function has PARTITION BY clause, then we
@@ -2384,11 +2404,14 @@ func (dsp *DistSQLPlanner) addSorters(
 // needed to perform the physical planning of aggregators once the specs have
 // been created.
 type aggregatorPlanningInfo struct {
-	aggregations             []execinfrapb.AggregatorSpec_Aggregation
-	argumentsColumnTypes     [][]*types.T
-	isScalar                 bool
-	groupCols                []exec.NodeColumnOrdinal
-	groupColOrdering         colinfo.ColumnOrdering
+	aggregations         []execinfrapb.AggregatorSpec_Aggregation
+	argumentsColumnTypes [][]*types.T
+	isScalar             bool
+	groupCols            []exec.NodeColumnOrdinal
+	groupColOrdering     colinfo.ColumnOrdering
+	// groupingSets, if set, contains the columns of each grouping set. See
+	// groupNode.groupingSets.
+	groupingSets             []exec.NodeColumnOrdinalSet
 	inputMergeOrdering       execinfrapb.Ordering
 	reqOrdering              ReqOrdering
 	allowPartialDistribution bool
@@ -2402,12 +2425,29 @@ func (dsp *DistSQLPlanner) addAggregators(
 ) error {
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
+	// With grouping sets, the results of the funcs are preceded by the
+	// grouping columns.
+	resultOffset := 0
+	if n.groupingSets != nil {
+		resultOffset = len(n.groupCols)
+	}
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		if fholder.userDefined != nil {
+			aggregations[i].Func = execinfrapb.UserDefined
+			var err error
+			aggregations[i].UserDefined, err = makeUserDefinedAggregation(
+				ctx, planCtx, fholder.userDefined, n.columns[resultOffset+i].Typ, true, /* useInlined */
+			)
+			if err != nil {
+				return err
+			}
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -2436,12 +2476,89 @@ func (dsp *DistSQLPlanner) addAggregators(
 		isScalar:             n.isScalar,
 		groupCols:            n.groupCols,
 		groupColOrdering:     n.groupColOrdering,
+		groupingSets:         n.groupingSets,
 		inputMergeOrdering:   dsp.convertOrdering(planReqOrdering(n.plan), p.PlanToStreamColMap),
 		reqOrdering:          n.reqOrdering,
 		estimatedRowCount:    n.estimatedRowCount,
 	})
 }
 
+// makeUserDefinedAggregation creates the specification of an aggregate created
+// with CREATE AGGREGATE. If useInlined is true and the component functions
+// were inlined, they are passed as scalar expressions, which can be evaluated
+// on any node. Otherwise they are passed as local routines, since routines
+// cannot be serialized, and the aggregate is computed on the gateway.
+func makeUserDefinedAggregation(
+	ctx context.Context,
+	planCtx *PlanningCtx,
+	uda *exec.UserDefinedAggregate,
+	resultType *types.T,
+	useInlined bool,
+) (*execinfrapb.AggregatorSpec_UserDefinedAggregation, error) {
+	res := &execinfrapb.AggregatorSpec_UserDefinedAggregation{
+		Stage:            execinfrapb.AggregatorSpec_UserDefinedAggregation_FULL,
+		StateType:        uda.StateType,
+		ResultType:       resultType,
+		NumArgs:          uint32(uda.NumArgs),
+		StrictTransition: !uda.Transition.CalledOnNullInput,
+	}
+	if uda.InitCond != nil {
+		res.InitCond, res.HasInitCond = *uda.InitCond, true
+	}
+	transition, final, combine := tree.TypedExpr(uda.Transition), tree.TypedExpr(nil), tree.TypedExpr(nil)
+	if uda.Final != nil {
+		final, res.StrictFinal = uda.Final, !uda.Final.CalledOnNullInput
+	}
+	if uda.Combine != nil {
+		combine, res.StrictCombine = uda.Combine, !uda.Combine.CalledOnNullInput
+	}
+	if useInlined && uda.Inlined != nil {
+		transition, final, combine = uda.Inlined.Transition, uda.Inlined.Final, uda.Inlined.Combine
+	}
+	var ef physicalplan.ExprFactory
+	ef.Init(ctx, planCtx, nil /* indexVarMap */)
+	var err error
+	if res.TransitionFunc, err = ef.Make(transition); err != nil {
+		return nil, err
+	}
+	if res.FinalFunc, err = ef.Make(final); err != nil {
+		return nil, err
+	}
+	if res.CombineFunc, err = ef.Make(combine); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// distAggregationInfo returns the blueprint for planning the given aggregation
+// with a local and a final stage. It returns false if the aggregation does not
+// support a local stage.
+func distAggregationInfo(
+	agg *execinfrapb.AggregatorSpec_Aggregation,
+) (physicalplan.DistAggregationInfo, bool) {
+	if agg.UserDefined != nil {
+		// Partial states of a user-defined aggregate can only be merged with a
+		// combine function.
+		if agg.UserDefined.CombineFunc.Empty() {
+			return physicalplan.DistAggregationInfo{}, false
+		}
+		return physicalplan.UserDefinedDistAggregationInfo, true
+	}
+	info, ok := physicalplan.DistAggregationTable[agg.Func]
+	return info, ok
+}
+
+// aggregationOutputType returns the output type of the given aggregation when
+// applied on the given types.
+func aggregationOutputType(
+	agg *execinfrapb.AggregatorSpec_Aggregation, argTypes []*types.T,
+) (*types.T, error) {
+	if agg.UserDefined != nil {
+		return agg.UserDefined.OutputType(), nil
+	}
+	return execagg.GetAggregateOutputType(agg.Func, argTypes)
+}
+
 // planAggregators plans the aggregator processors. An evaluator stage is added
 // if necessary.
 // Invariants assumed:
@@ -2482,6 +2599,17 @@ func (dsp *DistSQLPlanner) planAggregators(
 		orderedGroupCols[i] = uint32(p.PlanToStreamColMap[c.ColIdx])
 		orderedGroupColSet.Add(c.ColIdx)
 	}
+	var groupingSets []execinfrapb.AggregatorSpec_GroupingSet
+	if info.groupingSets != nil {
+		groupingSets = make([]execinfrapb.AggregatorSpec_GroupingSet, len(info.groupingSets))
+		for i, set := range info.groupingSets {
+			cols := make([]uint32, 0, set.Len())
+			set.ForEach(func(idx int) {
+				cols = append(cols, uint32(p.PlanToStreamColMap[idx]))
+			})
+			groupingSets[i].Cols = cols
+		}
+	}
 
 	// planHashGroupJoin tracks whether we should plan a hash group-join for the
 	// first stage of aggregators (either local if multi-stage or final if
@@ -2500,7 +2628,8 @@ func (dsp *DistSQLPlanner) planAggregators(
 	//      is the same (i.e. both either local or distributed).
 	//      TODO(yuzefovich): we could consider lifting the condition 5. by
 	//      changing the distribution of the hash joiner stager.
-	planHashGroupJoin := planCtx.ExtendedEvalCtx.SessionData().ExperimentalHashGroupJoinEnabled
+	planHashGroupJoin := planCtx.ExtendedEvalCtx.SessionData().ExperimentalHashGroupJoinEnabled &&
+		groupingSets == nil
 	if planHashGroupJoin { // condition 1.
 		planHashGroupJoin = func() bool {
 			prevStageProc := p.Processors[p.ResultRouters[0]].Spec
@@ -2564,8 +2693,10 @@ func (dsp *DistSQLPlanner) planAggregators(
 	}
 
 	// We can have a local stage of distinct processors if all aggregation
-	// functions are distinct.
-	allDistinct := true
+	// functions are distinct. With grouping sets, the group columns aren't
+	// arguments of any aggregation, so the distinct stage would merge the rows
+	// of different groups.
+	allDistinct := groupingSets == nil
 	for _, e := range info.aggregations {
 		if !e.Distinct {
 			allDistinct = false
@@ -2626,6 +2757,18 @@ func (dsp *DistSQLPlanner) planAggregators(
 			break
 		}
 	}
+	// Nodes which don't know about grouping sets would ignore them and compute
+	// a plain GROUP BY, and nodes which don't know about the spatial clustering
+	// aggregates can't compute them, so until the cluster is upgraded such
+	// aggregations are planned as a single stage on the gateway.
+	gatewayOnly := (groupingSets != nil &&
+		!dsp.st.Version.IsActive(ctx, clusterversion.V24_3_GroupingSets)) ||
+		(usesSpatialClusterAggregates(info.aggregations) &&
+			!dsp.st.Version.IsActive(ctx, clusterversion.V24_3_SpatialClusterFunctions))
+	if gatewayOnly {
+		prevStageNode = dsp.gatewaySQLInstanceID
+		planHashGroupJoin = false
+	}
 
 	// We either have a local stage on each stream followed by a final stage, or
 	// just a final stage. We only use a local stage if:
@@ -2642,7 +2785,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 				break
 			}
 			// Check that the function supports a local stage.
-			if _, ok := physicalplan.DistAggregationTable[e.Func]; !ok {
+			if _, ok := distAggregationInfo(&e); !ok {
 				multiStage = false
 				break
 			}
@@ -2668,6 +2811,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 			GroupCols:        groupCols,
 			OrderedGroupCols: orderedGroupCols,
 			OutputOrdering:   finalOutputOrdering,
+			GroupingSets:     groupingSets,
 		}
 	} else {
 		// Some aggregations might need multiple aggregation as part of
@@ -2682,7 +2826,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 		nFinalAgg := 0
 		needRender := false
 		for _, e := range info.aggregations {
-			info := physicalplan.DistAggregationTable[e.Func]
+			info, _ := distAggregationInfo(&e)
 			nLocalAgg += len(info.LocalStage)
 			nFinalAgg += len(info.FinalStage)
 			if info.FinalRendering != nil {
@@ -2717,7 +2861,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 		// to all final aggregations.
 		finalIdx := 0
 		for _, e := range info.aggregations {
-			info := physicalplan.DistAggregationTable[e.Func]
+			info, _ := distAggregationInfo(&e)
 
 			// relToAbsLocalIdx maps each local stage for the given
 			// aggregation e to its final index in localAggs.  This
@@ -2738,6 +2882,9 @@ func (dsp *DistSQLPlanner) planAggregators(
 					Func:         localFunc,
 					ColIdx:       e.ColIdx,
 					FilterColIdx: e.FilterColIdx,
+					UserDefined: e.UserDefined.WithStage(
+						execinfrapb.AggregatorSpec_UserDefinedAggregation_LOCAL,
+					),
 				}
 
 				isNewAgg := true
@@ -2765,7 +2912,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 					for _, c := range e.ColIdx {
 						argTypes = append(argTypes, inputTypes[c])
 					}
-					outputType, err := execagg.GetAggregateOutputType(localFunc, argTypes)
+					outputType, err := aggregationOutputType(&localAgg, argTypes)
 					if err != nil {
 						return err
 					}
@@ -2788,6 +2935,9 @@ func (dsp *DistSQLPlanner) planAggregators(
 				finalAgg := execinfrapb.AggregatorSpec_Aggregation{
 					Func:   finalInfo.Fn,
 					ColIdx: argIdxs,
+					UserDefined: e.UserDefined.WithStage(
+						execinfrapb.AggregatorSpec_UserDefinedAggregation_FINAL,
+					),
 				}
 
 				isNewAgg := true
@@ -2816,7 +2966,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 							// types for the current aggregation e.
 							argTypes = append(argTypes, intermediateTypes[argIdxs[i]])
 						}
-						outputType, err := execagg.GetAggregateOutputType(finalInfo.Fn, argTypes)
+						outputType, err := aggregationOutputType(&finalAgg, argTypes)
 						if err != nil {
 							return err
 						}
@@ -2831,31 +2981,37 @@ func (dsp *DistSQLPlanner) planAggregators(
 		// appear in the rendering. Add IDENT expressions for them, as they need to
 		// be part of the output of the local stage for the final stage to know
 		// about them.
+		//
+		// With grouping sets, the local stage outputs the group columns itself,
+		// followed by the local aggregations and the grouping set ordinal; see
+		// planGroupingSetsFinalStage.
 		finalGroupCols := make([]uint32, len(groupCols))
 		finalOrderedGroupCols := make([]uint32, 0, len(orderedGroupCols))
-		for i, groupColIdx := range groupCols {
-			agg := execinfrapb.AggregatorSpec_Aggregation{
-				Func:   execinfrapb.AnyNotNull,
-				ColIdx: []uint32{groupColIdx},
-			}
-			// See if there already is an aggregation like the one
-			// we want to add.
-			idx := -1
-			for j := range localAggs {
-				if localAggs[j].Equals(agg) {
-					idx = j
-					break
+		if groupingSets == nil {
+			for i, groupColIdx := range groupCols {
+				agg := execinfrapb.AggregatorSpec_Aggregation{
+					Func:   execinfrapb.AnyNotNull,
+					ColIdx: []uint32{groupColIdx},
+				}
+				// See if there already is an aggregation like the one
+				// we want to add.
+				idx := -1
+				for j := range localAggs {
+					if localAggs[j].Equals(agg) {
+						idx = j
+						break
+					}
+				}
+				if idx == -1 {
+					// Not already there, add it.
+					idx = len(localAggs)
+					localAggs = append(localAggs, agg)
+					intermediateTypes = append(intermediateTypes, inputTypes[groupColIdx])
+				}
+				finalGroupCols[i] = uint32(idx)
+				if orderedGroupColSet.Contains(int(info.groupCols[i])) {
+					finalOrderedGroupCols = append(finalOrderedGroupCols, uint32(idx))
 				}
-			}
-			if idx == -1 {
-				// Not already there, add it.
-				idx = len(localAggs)
-				localAggs = append(localAggs, agg)
-				intermediateTypes = append(intermediateTypes, inputTypes[groupColIdx])
-			}
-			finalGroupCols[i] = uint32(idx)
-			if orderedGroupColSet.Contains(int(info.groupCols[i])) {
-				finalOrderedGroupCols = append(finalOrderedGroupCols, uint32(idx))
 			}
 		}
 
@@ -2888,6 +3044,15 @@ func (dsp *DistSQLPlanner) planAggregators(
 			GroupCols:        groupCols,
 			OrderedGroupCols: orderedGroupCols,
 			OutputOrdering:   execinfrapb.Ordering{Columns: ordCols},
+			GroupingSets:     groupingSets,
+		}
+		if groupingSets != nil {
+			localOutTypes := make([]*types.T, 0, len(groupCols)+len(intermediateTypes)+1)
+			for _, c := range groupCols {
+				localOutTypes = append(localOutTypes, inputTypes[c])
+			}
+			localOutTypes = append(localOutTypes, intermediateTypes...)
+			intermediateTypes = append(localOutTypes, types.Int)
 		}
 
 		if planHashGroupJoin {
@@ -2935,7 +3100,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 			var ef physicalplan.ExprFactory
 			ef.Init(ctx, planCtx, nil /* indexVarMap */)
 			for i, e := range info.aggregations {
-				info := physicalplan.DistAggregationTable[e.Func]
+				info, _ := distAggregationInfo(&e)
 				if info.FinalRendering == nil {
 					// mappedIdx corresponds to the index
 					// location of the result for this
@@ -2977,28 +3142,47 @@ func (dsp *DistSQLPlanner) planAggregators(
 			finalAggsPost.Projection = true
 			finalAggsPost.OutputColumns = finalIdxMap
 		}
+
+		if groupingSets != nil {
+			if err := planGroupingSetsFinalStage(
+				ctx, planCtx, &finalAggsSpec, &finalAggsPost, finalIdxMap,
+				finalPreRenderTypes, len(groupCols), intermediateTypes,
+			); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Set up the final stage.
 
-	finalOutTypes := make([]*types.T, len(info.aggregations))
+	finalOutTypes := make([]*types.T, 0, len(groupCols)+len(info.aggregations)+1)
+	if groupingSets != nil {
+		// The aggregations are preceded by the group columns and followed by
+		// the grouping set ordinal.
+		for _, c := range groupCols {
+			finalOutTypes = append(finalOutTypes, inputTypes[c])
+		}
+	}
 	for i, agg := range info.aggregations {
 		argTypes = argTypes[:0]
 		for _, c := range agg.ColIdx {
 			argTypes = append(argTypes, inputTypes[c])
 		}
 		argTypes = append(argTypes, info.argumentsColumnTypes[i]...)
-		returnTyp, err := execagg.GetAggregateOutputType(agg.Func, argTypes)
+		returnTyp, err := aggregationOutputType(&agg, argTypes)
 		if err != nil {
 			return err
 		}
-		finalOutTypes[i] = returnTyp
+		finalOutTypes = append(finalOutTypes, returnTyp)
+	}
+	if groupingSets != nil {
+		finalOutTypes = append(finalOutTypes, types.Int)
 	}
 
 	// Update p.PlanToStreamColMap; we will have a simple 1-to-1 mapping of
 	// planNode columns to stream columns because the aggregator
 	// has been programmed to produce the same columns as the groupNode.
-	p.PlanToStreamColMap = identityMap(p.PlanToStreamColMap, len(info.aggregations))
+	p.PlanToStreamColMap = identityMap(p.PlanToStreamColMap, len(finalOutTypes))
 
 	if planHashGroupJoin {
 		prevStageProc := p.Processors[p.ResultRouters[0]].Spec
@@ -3015,8 +3199,12 @@ func (dsp *DistSQLPlanner) planAggregators(
 			finalOutTypes,
 			dsp.convertOrdering(info.reqOrdering, p.PlanToStreamColMap),
 		)
-	} else if len(finalAggsSpec.GroupCols) == 0 || len(p.ResultRouters) == 1 {
+	} else if len(finalAggsSpec.GroupCols) == 0 || len(p.ResultRouters) == 1 ||
+		finalAggsSpec.GroupingSets != nil || gatewayOnly {
 		// No GROUP BY, or we have a single stream. Use a single final aggregator.
+		// This is also the case with a single stage of grouping sets, since each
+		// input row belongs to a group of every grouping set, and with an
+		// aggregation which must be planned on the gateway.
 		// If the previous stage was all on a single node, put the final
 		// aggregator there. Otherwise, bring the results back on this node.
 		node := dsp.gatewaySQLInstanceID
@@ -3094,6 +3282,106 @@ func (dsp *DistSQLPlanner) planAggregators(
 	return nil
 }
 
+// usesSpatialClusterAggregates returns whether any of the aggregations
+// computes an aggregate function which nodes older than
+// V24_3_SpatialClusterFunctions don't know about.
+func usesSpatialClusterAggregates(aggs []execinfrapb.AggregatorSpec_Aggregation) bool {
+	for _, agg := range aggs {
+		if isSpatialClusterAggregate(agg.Func) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSpatialClusterAggregate returns whether fn is one of the aggregate
+// functions introduced in DistSQL version 73.
+func isSpatialClusterAggregate(fn execinfrapb.AggregatorSpec_Func) bool {
+	switch fn {
+	case execinfrapb.StClusterwithin, execinfrapb.StClusterintersecting, execinfrapb.StPolygonize:
+		return true
+	}
+	return false
+}
+
+// planGroupingSetsFinalStage adjusts the final stage of a multi-stage
+// aggregation over grouping sets. The local stage outputs the group columns,
+// where the columns outside of the grouping set of a row are NULL, followed by
+// the local aggregations and the ordinal of the grouping set of the row. These
+// NULLs and the ordinal identify the group of every row, so the final stage is
+// a regular aggregation grouped by them, which can be distributed by hash. The
+// group columns and the ordinal are passed through the final stage to produce
+// the same output as a single stage.
+//
+// finalIdxMap and finalPreRenderTypes are as in planAggregators, and
+// localOutTypes are the output types of the local stage.
+func planGroupingSetsFinalStage(
+	ctx context.Context,
+	planCtx *PlanningCtx,
+	spec *execinfrapb.AggregatorSpec,
+	post *execinfrapb.PostProcessSpec,
+	finalIdxMap []uint32,
+	finalPreRenderTypes []*types.T,
+	numGroupCols int,
+	localOutTypes []*types.T,
+) error {
+	// The arguments of the final aggregations are the local aggregations,
+	// which follow the group columns.
+	numAggs := len(spec.Aggregations)
+	for i := range spec.Aggregations {
+		colIdx := make([]uint32, len(spec.Aggregations[i].ColIdx))
+		for j, c := range spec.Aggregations[i].ColIdx {
+			colIdx[j] = c + uint32(numGroupCols)
+		}
+		spec.Aggregations[i].ColIdx = colIdx
+	}
+	spec.GroupCols = make([]uint32, 0, numGroupCols+1)
+	for i := 0; i < numGroupCols; i++ {
+		spec.GroupCols = append(spec.GroupCols, uint32(i))
+	}
+	spec.GroupCols = append(spec.GroupCols, uint32(len(localOutTypes)-1))
+	for _, c := range spec.GroupCols {
+		spec.Aggregations = append(spec.Aggregations, execinfrapb.AggregatorSpec_Aggregation{
+			Func:   execinfrapb.AnyNotNull,
+			ColIdx: []uint32{c},
+		})
+	}
+
+	// Move the group columns before the aggregations.
+	if post.RenderExprs == nil {
+		outputCols := make([]uint32, 0, len(finalIdxMap)+numGroupCols+1)
+		for i := 0; i < numGroupCols; i++ {
+			outputCols = append(outputCols, uint32(numAggs+i))
+		}
+		outputCols = append(outputCols, finalIdxMap...)
+		outputCols = append(outputCols, uint32(numAggs+numGroupCols))
+		post.Projection = true
+		post.OutputColumns = outputCols
+		return nil
+	}
+	typs := make([]*types.T, 0, numAggs+numGroupCols+1)
+	typs = append(typs, finalPreRenderTypes...)
+	for _, c := range spec.GroupCols {
+		typs = append(typs, localOutTypes[c])
+	}
+	h := tree.MakeIndexedVarHelperWithTypes(typs)
+	var ef physicalplan.ExprFactory
+	ef.Init(ctx, planCtx, nil /* indexVarMap */)
+	renderExprs := make([]execinfrapb.Expression, 0, len(post.RenderExprs)+numGroupCols+1)
+	for i := 0; i <= numGroupCols; i++ {
+		expr, err := ef.Make(h.IndexedVar(numAggs + i))
+		if err != nil {
+			return err
+		}
+		if i == numGroupCols {
+			renderExprs = append(renderExprs, post.RenderExprs...)
+		}
+		renderExprs = append(renderExprs, expr)
+	}
+	post.RenderExprs = renderExprs
+	return nil
+}
+
 func (dsp *DistSQLPlanner) createPlanForIndexJoin(
 	ctx context.Context, planCtx *PlanningCtx, n *indexJoinNode,
 ) (*PhysicalPlan, error) {
@@ -4736,14 +5024,20 @@ func (dsp *DistSQLPlanner) createPlanForWindow(
 		windowerSpec.WindowFns[windowFnSpecIdx] = windowFnSpec
 	}
 
+	// Nodes which don't know about the spatial clustering functions can't
+	// compute them, so until the cluster is upgraded the windower is planned
+	// on the gateway.
+	gatewayOnly := usesSpatialClusterWindowFns(windowerSpec.WindowFns) &&
+		!dsp.st.Version.IsActive(ctx, clusterversion.V24_3_SpatialClusterFunctions)
+
 	// Get all sqlInstanceIDs from the previous stage.
 	sqlInstanceIDs := getSQLInstanceIDsOfRouters(plan.ResultRouters, plan.Processors)
-	if len(partitionIdxs) == 0 || len(sqlInstanceIDs) == 1 {
+	if len(partitionIdxs) == 0 || len(sqlInstanceIDs) == 1 || gatewayOnly {
 		// No PARTITION BY or we have a single node. Use a single windower. If
 		// the previous stage was all on a single node, put the windower there.
 		// Otherwise, bring the results back on this node.
 		sqlInstanceID := dsp.gatewaySQLInstanceID
-		if len(sqlInstanceIDs) == 1 {
+		if len(sqlInstanceIDs) == 1 && !gatewayOnly {
 			sqlInstanceID = sqlInstanceIDs[0]
 		}
 		plan.AddSingleGroupStage(
@@ -4921,6 +5215,12 @@ func (c *localScanParallelizationChecker) enterNode(
 	case *groupNode:
 		for _, f := range n.funcs {
 			c.prohibitParallelization = f.hasFilter()
+			if f.userDefined != nil && f.userDefined.Inlined == nil {
+				// User-defined aggregates that weren't inlined evaluate
+				// routines, which require the root txn.
+				c.prohibitParallelization = true
+				break
+			}
 		}
 		return true, nil
 	case *indexJoinNode:
@@ -23,18 +23,37 @@ func createWindowFnSpec(
 			return execinfrapb.WindowerSpec_WindowFn{}, nil, errors.Errorf("ColIdx out of range (%d)", argIdx)
 		}
 	}
-	// Figure out which built-in to compute.
-	funcSpec, err := rowexec.CreateWindowerSpecFunc(funcInProgress.expr.Func.String())
-	if err != nil {
-		return execinfrapb.WindowerSpec_WindowFn{}, nil, err
-	}
-	argTypes := make([]*types.T, len(funcInProgress.argsIdxs))
-	for i, argIdx := range funcInProgress.argsIdxs {
-		argTypes[i] = plan.GetResultTypes()[argIdx]
-	}
-	_, outputType, err := execagg.GetWindowFunctionInfo(funcSpec, argTypes...)
-	if err != nil {
-		return execinfrapb.WindowerSpec_WindowFn{}, outputType, err
+	var funcSpec execinfrapb.WindowerSpec_Func
+	var userDefined *execinfrapb.AggregatorSpec_UserDefinedAggregation
+	var outputType *types.T
+	var err error
+	if funcInProgress.userDefined != nil {
+		// The function is an aggregate created with CREATE AGGREGATE.
+		aggSpec := execinfrapb.UserDefined
+		funcSpec.AggregateFunc = &aggSpec
+		outputType = funcInProgress.expr.ResolvedType()
+		// Window functions with user-defined aggregates are not distributed,
+		// so they always invoke the routines.
+		userDefined, err = makeUserDefinedAggregation(
+			ctx, planCtx, funcInProgress.userDefined, outputType, false, /* useInlined */
+		)
+		if err != nil {
+			return execinfrapb.WindowerSpec_WindowFn{}, nil, err
+		}
+	} else {
+		// Figure out which built-in to compute.
+		funcSpec, err = rowexec.CreateWindowerSpecFunc(funcInProgress.expr.Func.String())
+		if err != nil {
+			return execinfrapb.WindowerSpec_WindowFn{}, nil, err
+		}
+		argTypes := make([]*types.T, len(funcInProgress.argsIdxs))
+		for i, argIdx := range funcInProgress.argsIdxs {
+			argTypes[i] = plan.GetResultTypes()[argIdx]
+		}
+		_, outputType, err = execagg.GetWindowFunctionInfo(funcSpec, argTypes...)
+		if err != nil {
+			return execinfrapb.WindowerSpec_WindowFn{}, outputType, err
+		}
 	}
 	// Populating column ordering from ORDER BY clause of funcInProgress.
 	ordCols := make([]execinfrapb.Ordering_Column, 0, len(funcInProgress.columnOrdering))
@@ -52,6 +71,7 @@ func createWindowFnSpec(
 		Ordering:     execinfrapb.Ordering{Columns: ordCols},
 		FilterColIdx: int32(funcInProgress.filterColIdx),
 		OutputColIdx: uint32(funcInProgress.outputColIdx),
+		UserDefined:  userDefined,
 	}
 	if funcInProgress.frame != nil {
 		// funcInProgress has a custom window frame.
@@ -64,3 +84,21 @@ func createWindowFnSpec(
 
 	return funcInProgressSpec, outputType, nil
 }
+
+// usesSpatialClusterWindowFns returns whether any of the window functions
+// computes a function which nodes older than V24_3_SpatialClusterFunctions
+// don't know about.
+func usesSpatialClusterWindowFns(fns []execinfrapb.WindowerSpec_WindowFn) bool {
+	for _, fn := range fns {
+		if f := fn.Func.WindowFunc; f != nil {
+			switch *f {
+			case execinfrapb.WindowerSpec_ST_CLUSTERDBSCAN, execinfrapb.WindowerSpec_ST_CLUSTERKMEANS:
+				return true
+			}
+		}
+		if f := fn.Func.AggregateFunc; f != nil && isSpatialClusterAggregate(*f) {
+			return true
+		}
+	}
+	return false
+}
@@ -491,10 +491,15 @@ func populateAggFuncSpec(
 	return argumentsColumnTypes, nil
 }
 
+// constructAggregators plans the aggregators of a GroupBy, ScalarGroupBy or
+// GroupingSets operator. groupingSets is only set for the latter, in which
+// case the values of the grouping columns are produced by the aggregators
+// rather than by any_not_null aggregations.
 func (e *distSQLSpecExecFactory) constructAggregators(
 	input exec.Node,
 	groupCols []exec.NodeColumnOrdinal,
 	groupColOrdering colinfo.ColumnOrdering,
+	groupingSets []exec.NodeColumnOrdinalSet,
 	aggregations []exec.AggInfo,
 	reqOrdering exec.OutputOrdering,
 	isScalar bool,
@@ -503,10 +508,14 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 	physPlan, plan := getPhysPlan(input)
 	// planAggregators() itself decides whether to distribute the aggregation.
 	planCtx := e.getPlanCtx(shouldDistribute)
-	aggregationSpecs := make([]execinfrapb.AggregatorSpec_Aggregation, len(groupCols)+len(aggregations))
-	argumentsColumnTypes := make([][]*types.T, len(groupCols)+len(aggregations))
+	numGroupColAggs := len(groupCols)
+	if groupingSets != nil {
+		numGroupColAggs = 0
+	}
+	aggregationSpecs := make([]execinfrapb.AggregatorSpec_Aggregation, numGroupColAggs+len(aggregations))
+	argumentsColumnTypes := make([][]*types.T, numGroupColAggs+len(aggregations))
 	var err error
-	if len(groupCols) > 0 {
+	if numGroupColAggs > 0 {
 		argColsScratch := []exec.NodeColumnOrdinal{0}
 		noFilter := exec.NodeColumnOrdinal(tree.NoColumnIdx)
 		for i, col := range groupCols {
@@ -522,7 +531,7 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 		}
 	}
 	for j := range aggregations {
-		i := len(groupCols) + j
+		i := numGroupColAggs + j
 		spec := &aggregationSpecs[i]
 		agg := &aggregations[j]
 		argumentsColumnTypes[i], err = populateAggFuncSpec(
@@ -543,6 +552,7 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 			isScalar:             isScalar,
 			groupCols:            groupCols,
 			groupColOrdering:     groupColOrdering,
+			groupingSets:         groupingSets,
 			inputMergeOrdering:   physPlan.MergeOrdering,
 			reqOrdering:          ReqOrdering(reqOrdering),
 			estimatedRowCount:    estimatedRowCount,
@@ -550,7 +560,11 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 	); err != nil {
 		return nil, err
 	}
-	physPlan.ResultColumns = getResultColumnsForGroupBy(physPlan.ResultColumns, groupCols, aggregations)
+	if groupingSets != nil {
+		physPlan.ResultColumns = getResultColumnsForGroupingSets(physPlan.ResultColumns, groupCols, aggregations)
+	} else {
+		physPlan.ResultColumns = getResultColumnsForGroupBy(physPlan.ResultColumns, groupCols, aggregations)
+	}
 	return plan, nil
 }
 
@@ -567,6 +581,7 @@ func (e *distSQLSpecExecFactory) ConstructGroupBy(
 		input,
 		groupCols,
 		groupColOrdering,
+		nil, /* groupingSets */
 		aggregations,
 		reqOrdering,
 		false, /* isScalar */
@@ -581,6 +596,7 @@ func (e *distSQLSpecExecFactory) ConstructScalarGroupBy(
 		input,
 		nil, /* groupCols */
 		nil, /* groupColOrdering */
+		nil, /* groupingSets */
 		aggregations,
 		exec.OutputOrdering{}, /* reqOrdering */
 		true,                  /* isScalar */
@@ -588,6 +604,25 @@ func (e *distSQLSpecExecFactory) ConstructScalarGroupBy(
 	)
 }
 
+func (e *distSQLSpecExecFactory) ConstructGroupingSets(
+	input exec.Node,
+	groupCols []exec.NodeColumnOrdinal,
+	sets []exec.NodeColumnOrdinalSet,
+	aggregations []exec.AggInfo,
+	estimatedRowCount uint64,
+) (exec.Node, error) {
+	return e.constructAggregators(
+		input,
+		groupCols,
+		nil, /* groupColOrdering */
+		sets,
+		aggregations,
+		exec.OutputOrdering{}, /* reqOrdering */
+		false,                 /* isScalar */
+		estimatedRowCount,
+	)
+}
+
 func (e *distSQLSpecExecFactory) ConstructDistinct(
 	input exec.Node,
 	distinctCols, orderedCols exec.NodeColumnOrdinalSet,
@@ -827,6 +862,19 @@ func (e *distSQLSpecExecFactory) ConstructTopK(
 	return plan, nil
 }
 
+func (e *distSQLSpecExecFactory) ConstructTextSearchTopK(
+	table cat.Table,
+	index cat.Index,
+	keyCols exec.TableColumnOrdinalSet,
+	query *tree.DTSQuery,
+	k int64,
+	bm25 bool,
+	avgDocLength, k1, b float64,
+) (exec.Node, error) {
+	return nil, unimplemented.NewWithIssue(
+		47473, "experimental opt-driven distsql planning: text search top-k")
+}
+
 func (e *distSQLSpecExecFactory) ConstructMax1Row(
 	input exec.Node, errorText string,
 ) (exec.Node, error) {
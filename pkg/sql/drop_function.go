@@ -72,6 +72,9 @@ func (p *planner) DropFunction(ctx context.Context, n *tree.DropRoutine) (ret pl
 		if err != nil {
 			return nil, err
 		}
+		if err := checkRoutineAggregateKind(mut, n.Aggregate, "DROP"); err != nil {
+			return nil, err
+		}
 		if n.DropBehavior != tree.DropCascade && len(mut.DependedOnBy) > 0 {
 			dependedOnByIDs := make([]descpb.ID, 0, len(mut.DependedOnBy))
 			for _, ref := range mut.DependedOnBy {
@@ -160,6 +163,25 @@ func (p *planner) matchRoutine(
 	return &ol, nil
 }
 
+// checkRoutineAggregateKind returns an error if the function is an aggregate
+// and the statement is not an AGGREGATE variant, or vice versa. stmt is the
+// verb of the statement, e.g. "DROP".
+func checkRoutineAggregateKind(
+	fnDesc catalog.FunctionDescriptor, isAggregateStmt bool, stmt string,
+) error {
+	if fnDesc.IsAggregate() == isAggregateStmt {
+		return nil
+	}
+	if isAggregateStmt {
+		return pgerror.Newf(pgcode.WrongObjectType,
+			"function %s is not an aggregate", fnDesc.GetName())
+	}
+	return errors.WithHintf(
+		pgerror.Newf(pgcode.WrongObjectType, "%q is an aggregate function", fnDesc.GetName()),
+		"Use %s AGGREGATE to %s aggregate functions.", stmt, strings.ToLower(stmt),
+	)
+}
+
 func (p *planner) checkPrivilegesForDropFunction(
 	ctx context.Context, fnID descpb.ID,
 ) (*funcdesc.Mutable, error) {
@@ -40,7 +40,29 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 	); err != nil {
 		return nil, err
 	}
+	return p.planDropType(ctx, n, false /* domainsOnly */)
+}
+
+// DropDomain drops the given domains. Domains are user-defined types, so this
+// is planned as a DROP TYPE which only accepts domains.
+func (p *planner) DropDomain(ctx context.Context, n *tree.DropDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+	return p.planDropType(ctx, &tree.DropType{
+		Names:        n.Names,
+		IfExists:     n.IfExists,
+		DropBehavior: n.DropBehavior,
+	}, true /* domainsOnly */)
+}
 
+func (p *planner) planDropType(
+	ctx context.Context, n *tree.DropType, domainsOnly bool,
+) (planNode, error) {
 	node := &dropTypeNode{
 		n:      n,
 		toDrop: make(map[descpb.ID]*typedesc.Mutable),
@@ -61,6 +83,9 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 		if _, ok := node.toDrop[typeDesc.ID]; ok {
 			continue
 		}
+		if domainsOnly && typeDesc.Kind != descpb.TypeDescriptor_DOMAIN {
+			return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", name)
+		}
 		switch typeDesc.Kind {
 		case descpb.TypeDescriptor_ALIAS:
 			// The implicit array types are not directly droppable.
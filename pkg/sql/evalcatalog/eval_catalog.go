@@ -27,6 +27,17 @@ type Builtins struct {
 	codec keys.SQLCodec
 	dc    *descs.Collection
 	txn   *kv.Txn
+
+	// textSearchCache caches the text search configurations and dictionaries
+	// that were built from their descriptors, so that they are not rebuilt for
+	// every row. It is reset for every statement.
+	textSearchCache *textSearchCache
+
+	// collectionStatisticsCache caches the collection statistics that were
+	// computed from inverted indexes, so that every row of a statement is
+	// ranked with the same statistics, which are only computed once. It is
+	// reset for every statement.
+	collectionStatisticsCache *collectionStatisticsCache
 }
 
 // Init initializes the fields of a Builtins. The object should not be used
@@ -35,4 +46,6 @@ func (ec *Builtins) Init(codec keys.SQLCodec, txn *kv.Txn, descriptors *descs.Co
 	ec.codec = codec
 	ec.txn = txn
 	ec.dc = descriptors
+	ec.textSearchCache = &textSearchCache{}
+	ec.collectionStatisticsCache = &collectionStatisticsCache{}
 }
@@ -0,0 +1,242 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package evalcatalog
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/errors"
+)
+
+// textSearchCacheKey identifies a text search object in the cache of compiled
+// objects.
+type textSearchCacheKey struct {
+	kind     tree.TextSearchObjectKind
+	schemaID descpb.ID
+	name     string
+}
+
+// textSearchCache holds the text search objects that were built by a
+// Builtins, indexed by textSearchCacheKey.
+type textSearchCache struct {
+	syncutil.Mutex
+	objects map[textSearchCacheKey]interface{}
+}
+
+// ResolveTextSearchConfig implements the eval.CatalogBuiltins interface.
+func (b *Builtins) ResolveTextSearchConfig(
+	ctx context.Context, name *tree.UnresolvedObjectName, sd *sessiondata.SessionData,
+) (*tsearch.Config, error) {
+	schemaID, found, err := b.LookupTextSearchObject(ctx, tree.TextSearchConfiguration, name, sd)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search configuration %q does not exist", tree.ErrString(name))
+	}
+	return b.compileTextSearchConfig(ctx, schemaID, name.Object())
+}
+
+// ResolveTextSearchDictionary implements the eval.CatalogBuiltins interface.
+func (b *Builtins) ResolveTextSearchDictionary(
+	ctx context.Context, name *tree.UnresolvedObjectName, sd *sessiondata.SessionData,
+) (*tsearch.Dictionary, error) {
+	schemaID, found, err := b.LookupTextSearchObject(ctx, tree.TextSearchDictionary, name, sd)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", tree.ErrString(name))
+	}
+	return b.compileTextSearchDictionary(ctx, descpb.SchemaDescriptor_TextSearchDictionaryReference{
+		SchemaID: schemaID,
+		Name:     name.Object(),
+	}, 0 /* depth */)
+}
+
+// LookupTextSearchObject returns the ID of the schema that contains the text
+// search configuration or dictionary with the given name, and whether it was
+// found. The built-in objects belong to the pg_catalog schema. Unqualified
+// names are looked up in the schemas of the search path of the session, in
+// order.
+func (b *Builtins) LookupTextSearchObject(
+	ctx context.Context,
+	kind tree.TextSearchObjectKind,
+	name *tree.UnresolvedObjectName,
+	sd *sessiondata.SessionData,
+) (schemaID descpb.ID, found bool, _ error) {
+	dbName := sd.Database
+	if name.HasExplicitCatalog() {
+		dbName = name.Catalog()
+	}
+	var db catalog.DatabaseDescriptor
+	lookupSchema := func(scName string) (descpb.ID, bool, error) {
+		if scName == catconstants.PgCatalogName {
+			return catconstants.PgCatalogID, builtinTextSearchObjectExists(kind, name.Object()), nil
+		}
+		if db == nil {
+			if dbName == "" {
+				return 0, false, nil
+			}
+			var err error
+			db, err = b.dc.ByNameWithLeased(b.txn).Get().Database(ctx, dbName)
+			if err != nil {
+				return 0, false, err
+			}
+		}
+		sc, err := b.dc.ByNameWithLeased(b.txn).MaybeGet().Schema(ctx, db, scName)
+		if err != nil || sc == nil || sc.SchemaKind() != catalog.SchemaUserDefined &&
+			sc.SchemaKind() != catalog.SchemaPublic {
+			return 0, false, err
+		}
+		return sc.GetID(), textSearchObjectExists(kind, sc, name.Object()), nil
+	}
+	if name.HasExplicitSchema() {
+		return lookupSchema(name.Schema())
+	}
+	iter := sd.SearchPath.Iter()
+	for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+		id, found, err := lookupSchema(scName)
+		if err != nil || found {
+			return id, found, err
+		}
+	}
+	return 0, false, nil
+}
+
+func builtinTextSearchObjectExists(kind tree.TextSearchObjectKind, name string) bool {
+	if kind == tree.TextSearchConfiguration {
+		_, ok := tsearch.BuiltinConfig(name)
+		return ok
+	}
+	_, ok := tsearch.BuiltinDictionary(name)
+	return ok
+}
+
+func textSearchObjectExists(
+	kind tree.TextSearchObjectKind, sc catalog.SchemaDescriptor, name string,
+) bool {
+	if kind == tree.TextSearchConfiguration {
+		_, ok := sc.GetTextSearchConfiguration(name)
+		return ok
+	}
+	_, ok := sc.GetTextSearchDictionary(name)
+	return ok
+}
+
+// compileTextSearchConfig builds the text search configuration with the given
+// name from its descriptor representation.
+func (b *Builtins) compileTextSearchConfig(
+	ctx context.Context, schemaID descpb.ID, name string,
+) (*tsearch.Config, error) {
+	if schemaID == catconstants.PgCatalogID {
+		cfg, _ := tsearch.BuiltinConfig(name)
+		return cfg, nil
+	}
+	key := textSearchCacheKey{kind: tree.TextSearchConfiguration, schemaID: schemaID, name: name}
+	if cached, ok := b.getCachedTextSearchObject(key); ok {
+		return cached.(*tsearch.Config), nil
+	}
+	sc, err := b.dc.ByIDWithLeased(b.txn).Get().Schema(ctx, schemaID)
+	if err != nil {
+		return nil, err
+	}
+	desc, ok := sc.GetTextSearchConfiguration(name)
+	if !ok {
+		return nil, errors.AssertionFailedf(
+			"text search configuration %q not found in schema %d", name, schemaID)
+	}
+	cfg := tsearch.NewConfig(name)
+	for _, m := range desc.Mappings {
+		dicts := make([]*tsearch.Dictionary, len(m.Dictionaries))
+		for i, ref := range m.Dictionaries {
+			if dicts[i], err = b.compileTextSearchDictionary(ctx, ref, 0 /* depth */); err != nil {
+				return nil, err
+			}
+		}
+		cfg.SetMapping(tsearch.TokenType(m.TokenType), dicts)
+	}
+	b.setCachedTextSearchObject(key, cfg)
+	return cfg, nil
+}
+
+// compileTextSearchDictionary builds the referenced text search dictionary
+// from its descriptor representation. depth is the number of dictionaries
+// that use it as a subdictionary.
+func (b *Builtins) compileTextSearchDictionary(
+	ctx context.Context, ref descpb.SchemaDescriptor_TextSearchDictionaryReference, depth int,
+) (*tsearch.Dictionary, error) {
+	if ref.SchemaID == catconstants.PgCatalogID {
+		if d, ok := tsearch.BuiltinDictionary(ref.Name); ok {
+			return d, nil
+		}
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", ref.Name)
+	}
+	// Only thesaurus dictionaries have a subdictionary, which cannot be a
+	// thesaurus itself.
+	if depth > 1 {
+		return nil, errors.AssertionFailedf("unexpected nesting of text search dictionary %q", ref.Name)
+	}
+	key := textSearchCacheKey{kind: tree.TextSearchDictionary, schemaID: ref.SchemaID, name: ref.Name}
+	if cached, ok := b.getCachedTextSearchObject(key); ok {
+		return cached.(*tsearch.Dictionary), nil
+	}
+	sc, err := b.dc.ByIDWithLeased(b.txn).Get().Schema(ctx, ref.SchemaID)
+	if err != nil {
+		return nil, err
+	}
+	desc, ok := sc.GetTextSearchDictionary(ref.Name)
+	if !ok {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", ref.Name)
+	}
+	var sub *tsearch.Dictionary
+	if desc.Subdictionary != nil {
+		if sub, err = b.compileTextSearchDictionary(ctx, *desc.Subdictionary, depth+1); err != nil {
+			return nil, err
+		}
+	}
+	options := make([]tsearch.DictionaryOption, len(desc.Options))
+	for i, o := range desc.Options {
+		options[i] = tsearch.DictionaryOption{Name: o.Name, Value: o.Value}
+	}
+	d, err := tsearch.NewDictionary(desc.Name, desc.Template, options, sub)
+	if err != nil {
+		return nil, err
+	}
+	b.setCachedTextSearchObject(key, d)
+	return d, nil
+}
+
+func (b *Builtins) getCachedTextSearchObject(key textSearchCacheKey) (interface{}, bool) {
+	c := b.textSearchCache
+	c.Lock()
+	defer c.Unlock()
+	obj, ok := c.objects[key]
+	return obj, ok
+}
+
+func (b *Builtins) setCachedTextSearchObject(key textSearchCacheKey, obj interface{}) {
+	c := b.textSearchCache
+	c.Lock()
+	defer c.Unlock()
+	if c.objects == nil {
+		c.objects = make(map[textSearchCacheKey]interface{})
+	}
+	c.objects[key] = obj
+}
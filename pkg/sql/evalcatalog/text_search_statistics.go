@@ -0,0 +1,164 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package evalcatalog
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/errors"
+)
+
+// collectionStatisticsKey identifies the collection statistics of an inverted
+// index for the ranked lexemes of a query.
+type collectionStatisticsKey struct {
+	tableID descpb.ID
+	indexID descpb.IndexID
+	// lexemes is the encoding of the ranked lexemes.
+	lexemes string
+}
+
+// collectionStatisticsCache holds the collection statistics that were
+// computed by a Builtins, indexed by collectionStatisticsKey.
+type collectionStatisticsCache struct {
+	syncutil.Mutex
+	stats map[collectionStatisticsKey]*tsearch.CollectionStatistics
+}
+
+// collectionStatisticsPageSize is the number of index entries which are read
+// from KV at once to compute collection statistics.
+const collectionStatisticsPageSize = 10000
+
+// TextSearchCollectionStatistics implements the eval.CatalogBuiltins
+// interface.
+func (b *Builtins) TextSearchCollectionStatistics(
+	ctx context.Context, tableID catid.DescID, indexName string, lexemes []tsearch.QueryLexeme,
+) (*tsearch.CollectionStatistics, error) {
+	tableDesc, err := b.dc.ByIDWithLeased(b.txn).WithoutNonPublic().Get().Table(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	index := catalog.FindPublicNonPrimaryIndex(tableDesc, func(idx catalog.Index) bool {
+		return idx.GetName() == indexName
+	})
+	if index == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"index %q does not exist on table %q", indexName, tableDesc.GetName())
+	}
+	if !index.HasTermStatistics() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"index %q does not store term statistics", indexName)
+	}
+	return b.IndexCollectionStatistics(ctx, tableDesc, index, lexemes)
+}
+
+// IndexCollectionStatistics returns the statistics of the rows of a table for
+// the given ranked lexemes of a text search query, which are computed from an
+// inverted index of the table which stores term statistics. The statistics
+// are only computed once per statement for the same index and lexemes.
+func (b *Builtins) IndexCollectionStatistics(
+	ctx context.Context,
+	tableDesc catalog.TableDescriptor,
+	index catalog.Index,
+	lexemes []tsearch.QueryLexeme,
+) (*tsearch.CollectionStatistics, error) {
+	var encoded []byte
+	for _, l := range lexemes {
+		encoded = encoding.EncodeStringAscending(encoded, l.Lexeme)
+		encoded = encoding.EncodeBoolValue(encoded, encoding.NoColumnID, l.Prefix)
+	}
+	key := collectionStatisticsKey{
+		tableID: tableDesc.GetID(),
+		indexID: index.GetID(),
+		lexemes: string(encoded),
+	}
+
+	c := b.collectionStatisticsCache
+	c.Lock()
+	defer c.Unlock()
+	if stats, ok := c.stats[key]; ok {
+		return stats, nil
+	}
+	stats, err := computeCollectionStatistics(ctx, b.codec, b.txn, tableDesc, index, lexemes)
+	if err != nil {
+		return nil, err
+	}
+	if c.stats == nil {
+		c.stats = make(map[collectionStatisticsKey]*tsearch.CollectionStatistics)
+	}
+	c.stats[key] = stats
+	return stats, nil
+}
+
+// computeCollectionStatistics reads the collection statistics from the index.
+// The number of documents and their total length are computed from the
+// document entries of the index, and the document frequency of a lexeme is
+// the number of its postings. For a prefix lexeme, the postings of all the
+// lexemes it matches are counted, so a document which contains several of
+// them is counted several times, up to the number of documents.
+func computeCollectionStatistics(
+	ctx context.Context,
+	codec keys.SQLCodec,
+	txn *kv.Txn,
+	tableDesc catalog.TableDescriptor,
+	index catalog.Index,
+	lexemes []tsearch.QueryLexeme,
+) (*tsearch.CollectionStatistics, error) {
+	prefix := rowenc.MakeIndexKeyPrefix(codec, tableDesc.GetID(), index.GetID())
+	numValueCols := len(rowenc.GetValueColumns(index))
+	stats := &tsearch.CollectionStatistics{DocFreqs: make([]int, len(lexemes))}
+
+	docKey := roachpb.Key(tsearch.EncodeInvertedIndexDocumentKey(prefix))
+	if err := txn.Iterate(ctx, docKey, docKey.PrefixEnd(), collectionStatisticsPageSize,
+		func(kvs []kv.KeyValue) error {
+			for i := range kvs {
+				b, err := kvs[i].Value.GetBytes()
+				if err != nil {
+					return err
+				}
+				if b, err = rowenc.InvertedIndexStatisticsBytes(b, numValueCols); err != nil {
+					return err
+				}
+				dl, err := tsearch.DecodeDocumentStatistics(b)
+				if err != nil {
+					return errors.Wrapf(err, "decoding document statistics of key %s", kvs[i].Key)
+				}
+				stats.NumDocs++
+				stats.TotalDocLength += dl
+			}
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	for i, l := range lexemes {
+		start, end := l.IndexSpan(prefix)
+		if err := txn.Iterate(ctx, roachpb.Key(start), roachpb.Key(end), collectionStatisticsPageSize,
+			func(kvs []kv.KeyValue) error {
+				stats.DocFreqs[i] += len(kvs)
+				return nil
+			},
+		); err != nil {
+			return nil, err
+		}
+		if stats.DocFreqs[i] > stats.NumDocs {
+			stats.DocFreqs[i] = stats.NumDocs
+		}
+	}
+	return stats, nil
+}
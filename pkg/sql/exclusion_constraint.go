@@ -0,0 +1,311 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// Exclusion constraints are stored in the table descriptor as UNIQUE WITHOUT
+// INDEX constraints with an operator for each of their columns. Two rows
+// conflict if the values of the columns compared with "=" are equal and the
+// values of the column compared with "&&" overlap. The constraint is enforced
+// by the uniqueness checks planned by the optimizer, which are accelerated by
+// a supporting index on the columns of the constraint. The supporting index
+// is created along with the constraint if the table does not have one.
+const (
+	exclusionOperatorEq       = "="
+	exclusionOperatorOverlaps = "&&"
+)
+
+// exclusionConstraintElems holds the columns of an EXCLUDE constraint and the
+// operators used to compare them.
+type exclusionConstraintElems struct {
+	colNames  []string
+	operators []string
+	// overlapsOrdinal is the ordinal of the column compared with "&&", or -1
+	// if all the columns are compared with "=".
+	overlapsOrdinal int
+}
+
+// resolveExclusionConstraintElems checks that the elements of the given
+// EXCLUDE constraint are supported, and returns their columns and operators.
+func resolveExclusionConstraintElems(
+	desc *tabledesc.Mutable, d *tree.ExcludeConstraintTableDef,
+) (exclusionConstraintElems, error) {
+	res := exclusionConstraintElems{
+		colNames:        make([]string, len(d.Elems)),
+		operators:       make([]string, len(d.Elems)),
+		overlapsOrdinal: -1,
+	}
+	if d.IndexType == tree.IndexTypeVector {
+		return res, pgerror.New(pgcode.FeatureNotSupported,
+			"exclusion constraints do not support access method lsh")
+	}
+	for i := range d.Elems {
+		elem := &d.Elems[i]
+		if elem.Expr != nil {
+			return res, unimplemented.NewWithIssue(46657,
+				"exclusion constraints on expressions are not supported")
+		}
+		if elem.OpClass != "" {
+			return res, pgerror.New(pgcode.FeatureNotSupported,
+				"operator classes are not supported in exclusion constraints")
+		}
+		if elem.Direction != tree.DefaultDirection || elem.NullsOrder != tree.DefaultNullsOrder {
+			return res, pgerror.New(pgcode.FeatureNotSupported,
+				"exclusion constraints cannot specify an ordering")
+		}
+		col, err := desc.FindActiveOrNewColumnByName(elem.Column)
+		if err != nil {
+			return res, err
+		}
+		for j := 0; j < i; j++ {
+			if res.colNames[j] == col.GetName() {
+				return res, pgerror.Newf(pgcode.DuplicateColumn,
+					"column %q appears twice in exclusion constraint", col.GetName())
+			}
+		}
+		typ := col.GetType()
+		switch elem.Operator.Symbol {
+		case treecmp.EQ:
+			if !colinfo.ColumnTypeIsIndexable(typ) {
+				return res, pgerror.Newf(pgcode.FeatureNotSupported,
+					"column %q of type %s cannot be compared with = in an exclusion constraint",
+					col.GetName(), typ.SQLString())
+			}
+			res.operators[i] = exclusionOperatorEq
+		case treecmp.Overlaps:
+			switch typ.Family() {
+			case types.RangeFamily, types.MultirangeFamily, types.GeometryFamily, types.INetFamily:
+			default:
+				return res, pgerror.Newf(pgcode.UndefinedFunction,
+					"operator does not exist: %s && %s", typ.SQLString(), typ.SQLString())
+			}
+			if res.overlapsOrdinal != -1 {
+				return res, pgerror.New(pgcode.FeatureNotSupported,
+					"exclusion constraints support at most one && operator")
+			}
+			if d.IndexType != tree.IndexTypeInverted {
+				return res, errors.WithHint(
+					pgerror.New(pgcode.WrongObjectType,
+						"operator && is not supported by access method btree"),
+					"use EXCLUDE USING GIST to declare an exclusion constraint with &&",
+				)
+			}
+			res.overlapsOrdinal = i
+			res.operators[i] = exclusionOperatorOverlaps
+		default:
+			return res, errors.WithHint(
+				pgerror.Newf(pgcode.FeatureNotSupported,
+					"operator %s is not supported in exclusion constraints", elem.Operator),
+				"exclusion constraints support the = and && operators",
+			)
+		}
+		res.colNames[i] = col.GetName()
+	}
+	return res, nil
+}
+
+// makeExclusionConstraintIndexTableDef returns the definition of the index
+// which supports the given EXCLUDE constraint, or nil if the table already has
+// a suitable index. The columns compared with "=" form the prefix of the
+// index, and the column compared with "&&", if any, is its last column. The
+// index is inverted if that column can be indexed by an inverted index.
+func makeExclusionConstraintIndexTableDef(
+	desc *tabledesc.Mutable, d *tree.ExcludeConstraintTableDef,
+) (*tree.IndexTableDef, error) {
+	elems, err := resolveExclusionConstraintElems(desc, d)
+	if err != nil {
+		return nil, err
+	}
+	def := &tree.IndexTableDef{Predicate: d.Predicate}
+	for i, name := range elems.colNames {
+		if i != elems.overlapsOrdinal {
+			def.Columns = append(def.Columns, tree.IndexElem{Column: tree.Name(name)})
+		}
+	}
+	if elems.overlapsOrdinal != -1 {
+		name := elems.colNames[elems.overlapsOrdinal]
+		def.Columns = append(def.Columns, tree.IndexElem{Column: tree.Name(name)})
+		col, err := desc.FindActiveOrNewColumnByName(tree.Name(name))
+		if err != nil {
+			return nil, err
+		}
+		def.Inverted = colinfo.ColumnTypeIsInvertedIndexable(col.GetType())
+	}
+
+	// Look for an existing index with the same key columns. An index without a
+	// predicate supports the constraint whether or not it is partial.
+	for _, idx := range desc.NonDropIndexes() {
+		if idx.IsPartial() || (idx.GetType() == descpb.IndexDescriptor_INVERTED) != def.Inverted ||
+			idx.NumKeyColumns() != len(def.Columns) {
+			continue
+		}
+		matches := true
+		for i := range def.Columns {
+			if idx.GetKeyColumnName(i) != string(def.Columns[i].Column) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return nil, nil
+		}
+	}
+	return def, nil
+}
+
+// addExclusionConstraintTableDef runs various checks on the given
+// ExcludeConstraintTableDef before adding it as an exclusion constraint to the
+// given table descriptor. The supporting index is added separately.
+func addExclusionConstraintTableDef(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	d *tree.ExcludeConstraintTableDef,
+	desc *tabledesc.Mutable,
+	tn tree.TableName,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
+	semaCtx *tree.SemaContext,
+) error {
+	if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_3_ExclusionConstraints) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"exclusion constraints are not supported until the upgrade to version 24.3 is finalized")
+	}
+	if validationBehavior == tree.ValidationSkip {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"exclusion constraints cannot be marked NOT VALID")
+	}
+	if err := checkDeferrableConstraintsSupported(ctx, evalCtx, d.Deferrability); err != nil {
+		return err
+	}
+	elems, err := resolveExclusionConstraintElems(desc, d)
+	if err != nil {
+		return err
+	}
+
+	// If there is a predicate, validate it.
+	var predicate string
+	if d.Predicate != nil {
+		predicate, err = schemaexpr.ValidateUniqueWithoutIndexPredicate(
+			ctx, tn, desc, d.Predicate, semaCtx, evalCtx.Settings.Version.ActiveVersionOrEmpty(ctx),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resolveUniqueWithoutIndexConstraint(
+		ctx, desc, string(d.Name), elems.colNames, predicate, d.Deferrability, elems.operators,
+		ts, validationBehavior,
+	)
+}
+
+// addExclusionConstraintIndexMutation adds a mutation creating the index
+// which supports the given EXCLUDE constraint to an existing table, unless the
+// table already has a suitable index.
+func (p *planner) addExclusionConstraintIndexMutation(
+	params runParams, tableDesc *tabledesc.Mutable, tn tree.TableName, d *tree.ExcludeConstraintTableDef,
+) error {
+	def, err := makeExclusionConstraintIndexTableDef(tableDesc, d)
+	if err != nil || def == nil {
+		return err
+	}
+	idx, err := makeIndexDescriptor(params, tree.CreateIndex{
+		Table:     tn,
+		Inverted:  def.Inverted,
+		Columns:   def.Columns,
+		Predicate: def.Predicate,
+	}, tableDesc)
+	if err != nil {
+		return err
+	}
+	idx.CreatedExplicitly = false
+	if err := tableDesc.AddIndexMutationMaybeWithTempIndex(idx, descpb.DescriptorMutation_ADD); err != nil {
+		return err
+	}
+	version := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
+	return tableDesc.AllocateIDs(params.ctx, version)
+}
+
+// hasExclusionConstraintIndexTableDef returns true if defs contain an index
+// with the same key columns and predicate as the given supporting index of an
+// exclusion constraint.
+func hasExclusionConstraintIndexTableDef(defs tree.TableDefs, idxDef *tree.IndexTableDef) bool {
+	for _, def := range defs {
+		d, ok := def.(*tree.IndexTableDef)
+		if !ok || d.Sharded != nil || d.Inverted != idxDef.Inverted ||
+			len(d.Columns) != len(idxDef.Columns) {
+			continue
+		}
+		if (d.Predicate == nil) != (idxDef.Predicate == nil) ||
+			(d.Predicate != nil && tree.Serialize(d.Predicate) != tree.Serialize(idxDef.Predicate)) {
+			continue
+		}
+		matches := true
+		for i := range d.Columns {
+			if d.Columns[i].Expr != nil || d.Columns[i].Column != idxDef.Columns[i].Column {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// makeExclusionConstraintTableDef returns the definition of the given
+// exclusion constraint of a table.
+func makeExclusionConstraintTableDef(
+	td catalog.TableDescriptor, c *descpb.UniqueWithoutIndexConstraint,
+) (*tree.ExcludeConstraintTableDef, error) {
+	colNames, err := catalog.ColumnNamesForIDs(td, c.ColumnIDs)
+	if err != nil {
+		return nil, err
+	}
+	def := &tree.ExcludeConstraintTableDef{
+		Name:          tree.Name(c.Name),
+		IndexType:     tree.IndexTypeInverted,
+		Elems:         make(tree.ExcludeElemList, len(colNames)),
+		Deferrability: c.Deferrability.ToTree(),
+	}
+	for i := range colNames {
+		op := treecmp.MakeComparisonOperator(treecmp.EQ)
+		if c.ExclusionOperators[i] == exclusionOperatorOverlaps {
+			op = treecmp.MakeComparisonOperator(treecmp.Overlaps)
+		}
+		def.Elems[i] = tree.ExcludeElem{
+			IndexElem: tree.IndexElem{Column: tree.Name(colNames[i])},
+			Operator:  op,
+		}
+	}
+	if c.IsPartial() {
+		def.Predicate, err = parser.ParseExpr(c.Predicate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return def, nil
+}
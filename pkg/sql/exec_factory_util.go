@@ -234,6 +234,16 @@ func getResultColumnsForGroupBy(
 	return columns
 }
 
+// getResultColumnsForGroupingSets returns the result columns of a
+// GroupingSets operator: the grouping columns, the aggregations and the
+// ordinal of the grouping set.
+func getResultColumnsForGroupingSets(
+	inputCols colinfo.ResultColumns, groupCols []exec.NodeColumnOrdinal, aggregations []exec.AggInfo,
+) colinfo.ResultColumns {
+	columns := getResultColumnsForGroupBy(inputCols, groupCols, aggregations)
+	return append(columns, colinfo.ResultColumn{Name: "grouping_set", Typ: types.Int})
+}
+
 func constructVirtualScan(
 	ef exec.Factory,
 	p *planner,
@@ -75,6 +75,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -1465,6 +1466,10 @@ type ExecutorConfig struct {
 	// SyntheticPrivilegeCache stores synthetic privileges in an in-memory cache.
 	SyntheticPrivilegeCache *syntheticprivilegecache.Cache
 
+	// NotificationRegistry delivers the notifications sent with NOTIFY to the
+	// sessions on this node which LISTEN on a channel.
+	NotificationRegistry *pgnotify.Registry
+
 	// RangeStatsFetcher is used to fetch RangeStats.
 	RangeStatsFetcher eval.RangeStatsFetcher
 
@@ -2085,6 +2090,19 @@ func checkResultType(typ *types.T, fmtCode pgwirebase.FormatCode) error {
 	case types.PGLSNFamily:
 	case types.PGVectorFamily:
 	case types.RefCursorFamily:
+	case types.RangeFamily:
+	case types.MultirangeFamily:
+	case types.JsonpathFamily:
+	case types.PointFamily:
+	case types.BoxFamily:
+	case types.LSegFamily:
+	case types.LineFamily:
+	case types.PathFamily:
+	case types.PolygonFamily:
+	case types.CircleFamily:
+	case types.MACAddrFamily:
+	case types.MACAddr8Family:
+	case types.MoneyFamily:
 	case types.TupleFamily:
 	case types.EnumFamily:
 	case types.VoidFamily:
@@ -3565,6 +3583,12 @@ func (m *sessionDataMutator) SetDateStyle(style pgdate.DateStyle) {
 	}
 }
 
+// SetLCMonetary sets the locale used to format money values for the given
+// session.
+func (m *sessionDataMutator) SetLCMonetary(locale string) {
+	m.data.DataConversionConfig.LcMonetary = locale
+}
+
 // SetStubCatalogTablesEnabled sets default value for stub_catalog_tables.
 func (m *sessionDataMutator) SetStubCatalogTablesEnabled(enabled bool) {
 	m.data.StubCatalogTablesEnabled = enabled
@@ -3888,6 +3912,10 @@ func (m *sessionDataMutator) SetOptimizerPushLimitIntoProjectFilteredScan(val bo
 	m.data.OptimizerPushLimitIntoProjectFilteredScan = val
 }
 
+func (m *sessionDataMutator) SetVectorSearchProbes(val int64) {
+	m.data.VectorSearchProbes = val
+}
+
 // Utility functions related to scrubbing sensitive information on SQL Stats.
 
 // quantizeCounts ensures that the Count field in the
@@ -80,6 +80,16 @@ func GetAggregateConstructor(
 	inputTypes []*types.T,
 	pAlloc *ParamTypesAllocator,
 ) (constructor AggregateConstructor, arguments tree.Datums, outputType *types.T, err error) {
+	if aggInfo.UserDefined != nil {
+		if len(aggInfo.ColIdx) != 1 || aggInfo.ColIdx[0] >= uint32(len(inputTypes)) {
+			err = errors.Errorf("ColIdx out of range (%d)", aggInfo.ColIdx)
+			return
+		}
+		constructor, err = getUserDefinedAggregateConstructor(
+			ctx, evalCtx, semaCtx, aggInfo.UserDefined, inputTypes[aggInfo.ColIdx[0]],
+		)
+		return constructor, nil, aggInfo.UserDefined.OutputType(), err
+	}
 	paramTypes, err := pAlloc.alloc(len(aggInfo.ColIdx) + len(aggInfo.Arguments))
 	if err != nil {
 		return nil, nil, nil, err
@@ -213,6 +223,12 @@ func GetWindowFunctionInfo(
 // NeedHashAggregator returns whether the given aggregator spec requires hash
 // aggregation.
 func NeedHashAggregator(aggSpec *execinfrapb.AggregatorSpec) (bool, error) {
+	if len(aggSpec.GroupingSets) > 0 {
+		if len(aggSpec.OrderedGroupCols) > 0 {
+			return false, errors.AssertionFailedf("grouping sets require hash aggregation")
+		}
+		return true, nil
+	}
 	var groupCols, orderedCols intsets.Fast
 	for _, col := range aggSpec.OrderedGroupCols {
 		orderedCols.Add(int(col))
@@ -0,0 +1,313 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package execagg
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// getUserDefinedAggregateConstructor returns the constructor for an aggregate
+// created with CREATE AGGREGATE. argType is the type of the input column of the
+// aggregate, which packs the arguments into a tuple if there are several.
+func getUserDefinedAggregateConstructor(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregation,
+	argType *types.T,
+) (AggregateConstructor, error) {
+	stateType := spec.StateType
+	var transition, final, combine *userDefinedAggregateFunc
+	var err error
+	if spec.Stage != execinfrapb.AggregatorSpec_UserDefinedAggregation_FINAL {
+		// The transition function is invoked with the state and the arguments.
+		paramTypes := []*types.T{stateType}
+		if spec.NumArgs > 1 {
+			paramTypes = append(paramTypes, argType.TupleContents()...)
+		} else {
+			paramTypes = append(paramTypes, argType)
+		}
+		transition, err = makeUserDefinedAggregateFunc(
+			ctx, evalCtx, semaCtx, spec.TransitionFunc, paramTypes, spec.StrictTransition,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if transition == nil {
+			return nil, errors.AssertionFailedf("user-defined aggregate without a transition function")
+		}
+	} else {
+		combine, err = makeUserDefinedAggregateFunc(
+			ctx, evalCtx, semaCtx, spec.CombineFunc, []*types.T{stateType, stateType}, spec.StrictCombine,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if combine == nil {
+			return nil, errors.AssertionFailedf("final stage of user-defined aggregate without a combine function")
+		}
+	}
+	if spec.Stage != execinfrapb.AggregatorSpec_UserDefinedAggregation_LOCAL {
+		final, err = makeUserDefinedAggregateFunc(
+			ctx, evalCtx, semaCtx, spec.FinalFunc, []*types.T{stateType}, spec.StrictFinal,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// The initial state is already accounted for in the partial states merged
+	// by the final stage.
+	initState := tree.Datum(tree.DNull)
+	if spec.HasInitCond && spec.Stage != execinfrapb.AggregatorSpec_UserDefinedAggregation_FINAL {
+		initState, err = eval.PerformCast(ctx, evalCtx, tree.NewDString(spec.InitCond), stateType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return func(evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		return &userDefinedAggregate{
+			evalCtx:    evalCtx,
+			numArgs:    int(spec.NumArgs),
+			transition: transition,
+			final:      final,
+			combine:    combine,
+			initState:  initState,
+			state:      initState,
+			noState:    initState == tree.DNull,
+		}
+	}, nil
+}
+
+// GetUserDefinedWindowFunctionInfo returns the windowFunc constructor and the
+// return type of an aggregate created with CREATE AGGREGATE that is computed
+// as a window function.
+func GetUserDefinedWindowFunctionInfo(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregation,
+	argTypes []*types.T,
+) (windowConstructor func(*eval.Context) eval.WindowFunc, returnType *types.T, err error) {
+	if len(argTypes) != 1 {
+		return nil, nil, errors.AssertionFailedf(
+			"expected packed arguments for user-defined aggregate, found %d columns", len(argTypes),
+		)
+	}
+	constructor, err := getUserDefinedAggregateConstructor(ctx, evalCtx, semaCtx, spec, argTypes[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	return builtins.NewFramableAggregateWindowFunc(constructor), spec.ResultType, nil
+}
+
+// userDefinedAggregateFunc is a component function of a user-defined
+// aggregate. It is either a routine, which can only be evaluated on the
+// gateway, or a scalar expression that refers to the i-th argument of the
+// function with an IndexedVar with index i.
+type userDefinedAggregateFunc struct {
+	routine *tree.RoutineExpr
+	expr    tree.TypedExpr
+	// strict is true if the function returns NULL when any argument is NULL.
+	strict bool
+	// args is the IndexedVarContainer used to evaluate expr.
+	args userDefinedAggregateArgs
+}
+
+// makeUserDefinedAggregateFunc returns the component function of a
+// user-defined aggregate with the given parameter types, or nil if the
+// expression is empty. strict is only used if the function is a scalar
+// expression, since routines know whether they are strict.
+func makeUserDefinedAggregateFunc(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	expr execinfrapb.Expression,
+	paramTypes []*types.T,
+	strict bool,
+) (*userDefinedAggregateFunc, error) {
+	if expr.Empty() {
+		return nil, nil
+	}
+	if routine, ok := expr.LocalExpr.(*tree.RoutineExpr); ok {
+		return &userDefinedAggregateFunc{routine: routine, strict: !routine.CalledOnNullInput}, nil
+	}
+	var h execinfrapb.ExprHelper
+	if err := h.Init(ctx, expr, paramTypes, semaCtx, evalCtx); err != nil {
+		return nil, errors.Wrapf(err, "%s", expr)
+	}
+	return &userDefinedAggregateFunc{
+		expr:   h.Expr(),
+		strict: strict,
+		args:   userDefinedAggregateArgs{types: paramTypes},
+	}, nil
+}
+
+// eval invokes the function with the given arguments.
+func (f *userDefinedAggregateFunc) eval(
+	ctx context.Context, evalCtx *eval.Context, args tree.Datums,
+) (tree.Datum, error) {
+	if f.routine != nil {
+		return evalCtx.Planner.EvalRoutineExpr(ctx, f.routine, args)
+	}
+	f.args.datums = args
+	evalCtx.PushIVarContainer(&f.args)
+	defer evalCtx.PopIVarContainer()
+	return eval.Expr(ctx, evalCtx, f.expr)
+}
+
+// userDefinedAggregateArgs binds the IndexedVars of a component function of a
+// user-defined aggregate to its arguments.
+type userDefinedAggregateArgs struct {
+	types  []*types.T
+	datums tree.Datums
+}
+
+var _ eval.IndexedVarContainer = &userDefinedAggregateArgs{}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (a *userDefinedAggregateArgs) IndexedVarResolvedType(idx int) *types.T {
+	return a.types[idx]
+}
+
+// IndexedVarEval implements the eval.IndexedVarContainer interface.
+func (a *userDefinedAggregateArgs) IndexedVarEval(idx int) (tree.Datum, error) {
+	return a.datums[idx], nil
+}
+
+// userDefinedAggregate implements eval.AggregateFunc for aggregates created
+// with CREATE AGGREGATE by invoking their component functions. Like in
+// Postgres, if the transition function is strict:
+//   - rows with a NULL argument are skipped,
+//   - if the initial state is NULL, the first non-NULL argument becomes the
+//     state, and
+//   - once the state is NULL, it remains NULL.
+//
+// The same rules apply to the partial states merged with a strict combine
+// function by the final stage of the aggregate.
+type userDefinedAggregate struct {
+	evalCtx *eval.Context
+	// ctx is the context of the most recent call to Add or Reset. It is used
+	// to invoke the final function in Result, which doesn't take a context.
+	ctx context.Context
+	// numArgs is the number of arguments of the aggregate. If it is greater
+	// than one, the arguments are packed into a tuple.
+	numArgs int
+	// transition is nil in the final stage, and final is nil in the local
+	// stage. combine is only set in the final stage.
+	transition, final, combine *userDefinedAggregateFunc
+	initState                  tree.Datum
+	state                      tree.Datum
+	// noState is true if the state has not been set by an input row yet and
+	// the initial state is NULL.
+	noState bool
+	// args is reused for the arguments of the component functions.
+	args tree.Datums
+}
+
+var _ eval.AggregateFunc = &userDefinedAggregate{}
+
+// Add implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	a.ctx = ctx
+	if a.combine != nil {
+		return a.addPartialState(ctx, firstArg)
+	}
+	a.args = append(a.args[:0], a.state)
+	if a.numArgs > 1 {
+		tuple, ok := tree.AsDTuple(firstArg)
+		if !ok {
+			return errors.AssertionFailedf("expected packed arguments, found %s", firstArg.ResolvedType())
+		}
+		a.args = append(a.args, tuple.D...)
+	} else {
+		a.args = append(a.args, firstArg)
+	}
+	if a.transition.strict {
+		for _, arg := range a.args[1:] {
+			if arg == tree.DNull {
+				return nil
+			}
+		}
+		if a.noState {
+			a.state, a.noState = a.args[1], false
+			return nil
+		}
+		if a.state == tree.DNull {
+			return nil
+		}
+	}
+	state, err := a.transition.eval(ctx, a.evalCtx, a.args)
+	if err != nil {
+		return err
+	}
+	a.state, a.noState = state, false
+	return nil
+}
+
+// addPartialState merges a state computed by the local stage of the aggregate
+// into the state.
+func (a *userDefinedAggregate) addPartialState(ctx context.Context, partial tree.Datum) error {
+	if a.combine.strict {
+		if partial == tree.DNull {
+			return nil
+		}
+		if a.noState {
+			a.state, a.noState = partial, false
+			return nil
+		}
+		if a.state == tree.DNull {
+			return nil
+		}
+	}
+	a.args = append(a.args[:0], a.state, partial)
+	state, err := a.combine.eval(ctx, a.evalCtx, a.args)
+	if err != nil {
+		return err
+	}
+	a.state, a.noState = state, false
+	return nil
+}
+
+// Result implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	if a.final == nil {
+		return a.state, nil
+	}
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if a.final.strict && a.state == tree.DNull {
+		return tree.DNull, nil
+	}
+	a.args = append(a.args[:0], a.state)
+	return a.final.eval(ctx, a.evalCtx, a.args)
+}
+
+// Reset implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(ctx context.Context) {
+	a.ctx = ctx
+	a.state = a.initState
+	a.noState = a.initState == tree.DNull
+}
+
+// Close implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(context.Context) {}
+
+// Size implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return int64(unsafe.Sizeof(*a))
+}
@@ -59,7 +59,7 @@ import "github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 //
 // ATTENTION: When updating these fields, add a brief description of what
 // changed to the version history below.
-const Version execinfrapb.DistSQLVersion = 71
+const Version execinfrapb.DistSQLVersion = 73
 
 // MinAcceptedVersion is the oldest version that the server is compatible with.
 // A server will not accept flows with older versions.
@@ -71,6 +71,22 @@ const MinAcceptedVersion execinfrapb.DistSQLVersion = 71
 
 Please add new entries at the top.
 
+- Version: 73 (MinAcceptedVersion: 71)
+  - The ST_CLUSTERWITHIN, ST_CLUSTERINTERSECTING and ST_POLYGONIZE aggregate
+    functions and the ST_CLUSTERDBSCAN and ST_CLUSTERKMEANS window functions
+    have been introduced. They would be unrecognized by a server running older
+    versions, so aggregators and windowers computing them are only planned on
+    other nodes once the cluster version V24_3_SpatialClusterFunctions is
+    active. A server running v73 can still process all plans from servers
+    running v71, thus the MinAcceptedVersion is kept at 71.
+
+- Version: 72 (MinAcceptedVersion: 71)
+  - AggregatorSpec.GroupingSets has been introduced. A server running v71
+    would ignore it and compute a plain GROUP BY, so aggregators with grouping
+    sets are only planned on other nodes once the cluster version
+    V24_3_GroupingSets is active. A server running v72 can still process all
+    plans from servers running v71, thus the MinAcceptedVersion is kept at 71.
+
 - Version: 71 (MinAcceptedVersion: 71)
   - On-wire representation of booleans and bytes-like values in the Arrow format
     has changed.
@@ -73,4 +73,8 @@ const (
 	MergeStatementStats         = AggregatorSpec_MERGE_STATEMENT_STATS
 	MergeTransactionStats       = AggregatorSpec_MERGE_TRANSACTION_STATS
 	MergeAggregatedStmtMetadata = AggregatorSpec_MERGE_AGGREGATED_STMT_METADATA
+	UserDefined                 = AggregatorSpec_USER_DEFINED
+	StClusterwithin             = AggregatorSpec_ST_CLUSTERWITHIN
+	StClusterintersecting       = AggregatorSpec_ST_CLUSTERINTERSECTING
+	StPolygonize                = AggregatorSpec_ST_POLYGONIZE
 )
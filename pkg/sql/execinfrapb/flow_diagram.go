@@ -104,6 +104,14 @@ func (a *AggregatorSpec) summary() (string, []string) {
 	if len(a.OrderedGroupCols) > 0 {
 		details = append(details, fmt.Sprintf("Ordered: %s", colListStr(a.OrderedGroupCols)))
 	}
+	if len(a.GroupingSets) > 0 {
+		var buf bytes.Buffer
+		buf.WriteString("Grouping sets:")
+		for _, set := range a.GroupingSets {
+			fmt.Fprintf(&buf, " (%s)", colListStr(set.Cols))
+		}
+		details = append(details, buf.String())
+	}
 	for _, agg := range a.Aggregations {
 		var buf bytes.Buffer
 		buf.WriteString(agg.Func.String())
@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/errors"
 )
@@ -59,7 +60,36 @@ func (a AggregatorSpec_Aggregation) Equals(b AggregatorSpec_Aggregation) bool {
 			return false
 		}
 	}
-	return true
+	if a.UserDefined == nil || b.UserDefined == nil {
+		return a.UserDefined == b.UserDefined
+	}
+	// User-defined aggregations are only equal if they invoke the same
+	// routines, or have the same serialized expressions.
+	return a.UserDefined.Stage == b.UserDefined.Stage &&
+		a.UserDefined.TransitionFunc.LocalExpr == b.UserDefined.TransitionFunc.LocalExpr &&
+		a.UserDefined.TransitionFunc.Expr == b.UserDefined.TransitionFunc.Expr
+}
+
+// WithStage returns a copy of the user-defined aggregation that computes the
+// given stage of the aggregation. It returns nil if the receiver is nil.
+func (a *AggregatorSpec_UserDefinedAggregation) WithStage(
+	stage AggregatorSpec_UserDefinedAggregation_Stage,
+) *AggregatorSpec_UserDefinedAggregation {
+	if a == nil {
+		return nil
+	}
+	res := *a
+	res.Stage = stage
+	return &res
+}
+
+// OutputType returns the type of the values produced by the user-defined
+// aggregation. The local stage produces partial states.
+func (a *AggregatorSpec_UserDefinedAggregation) OutputType() *types.T {
+	if a.Stage == AggregatorSpec_UserDefinedAggregation_LOCAL {
+		return a.StateType
+	}
+	return a.ResultType
 }
 
 // IsScalar returns whether the aggregate function is in scalar context.
@@ -33,6 +33,12 @@ type groupNode struct {
 	// even if there are no input rows, e.g. SELECT MIN(x) FROM t.
 	isScalar bool
 
+	// groupingSets, if set, contains the columns of each grouping set, which
+	// are a subset of groupCols. In that case the results consist of the
+	// groupCols, the aggregations and the ordinal of the grouping set, and
+	// funcs only contains the aggregations.
+	groupingSets []exec.NodeColumnOrdinalSet
+
 	// funcs contains the information about all aggregate functions.
 	funcs []*aggregateFuncHolder
 
@@ -77,6 +83,9 @@ type aggregateFuncHolder struct {
 	// distsqlBlocklist is set when this function cannot be evaluated in
 	// distributed fashion.
 	distsqlBlocklist bool
+	// userDefined is set if the function is an aggregate created with CREATE
+	// AGGREGATE.
+	userDefined *exec.UserDefinedAggregate
 }
 
 // newAggregateFuncHolder creates an aggregateFuncHolder.
@@ -6079,8 +6079,8 @@ grant privileges on sequence: could not be parsed
 comment on extension: could not be parsed
 comment on function: could not be parsed
 create extension if not exists with: could not be parsed
-alter aggregate: could not be parsed
-alter domain: could not be parsed
+ALTER AGGREGATE myavg(INT8) RENAME TO my_average: unsupported by IMPORT
+ALTER DOMAIN zipcode SET NOT NULL: unsupported by IMPORT
 create trigger: unsupported by IMPORT
 `,
 			`create function: could not be parsed
@@ -6097,7 +6097,9 @@ unsupported function call: set_config in stmt: SELECT set_config('search_path',
 		checkFiles(schemaFileContents, pgDumpUnsupportedSchemaStmtLog)
 
 		ingestionFileContents := []string{
-			`unsupported 3 fn args in select: ['search_path' '' false]: unsupported by IMPORT
+			`unsupported *tree.AlterRoutineRename statement: ALTER AGGREGATE myavg(INT8) RENAME TO my_average: unsupported by IMPORT
+unsupported *tree.AlterDomain statement: ALTER DOMAIN zipcode SET NOT NULL: unsupported by IMPORT
+unsupported 3 fn args in select: ['search_path' '' false]: unsupported by IMPORT
 unsupported *tree.Delete statement: DELETE FROM geometry_columns WHERE (f_table_name = 'nyc_census_blocks') AND (f_table_schema = 'public'): unsupported by IMPORT
 `,
 		}
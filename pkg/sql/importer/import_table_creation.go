@@ -109,6 +109,7 @@ func MakeSimpleTableDescriptor(
 	for i := range create.Defs {
 		switch def := create.Defs[i].(type) {
 		case *tree.CheckConstraintTableDef,
+			*tree.ExcludeConstraintTableDef,
 			*tree.FamilyTableDef,
 			*tree.UniqueConstraintTableDef:
 			// ignore
@@ -523,11 +523,23 @@ https://www.postgresql.org/docs/9.5/infoschema-columns.html`,
 				// type is a user defined type, then we should fill this value based on
 				// the schema it is under.
 				udtSchema := pgCatalogNameDString
+				udtName := tree.NewDString(column.GetType().PGName())
 				typeMetaName := column.GetType().TypeMeta.Name
 				if typeMetaName != nil {
 					udtSchema = tree.NewDString(typeMetaName.Schema)
 				}
 
+				// For a column whose type is a domain, the domain is reported in the
+				// domain_* columns and the underlying type in the udt_* columns.
+				domainCatalog, domainSchema, domainName := tree.DNull, tree.DNull, tree.DNull
+				if column.GetType().IsDomain() && typeMetaName != nil {
+					domainCatalog = tree.NewDString(typeMetaName.Catalog)
+					domainSchema = udtSchema
+					domainName = udtName
+					udtSchema = pgCatalogNameDString
+					udtName = tree.NewDString(column.GetType().DomainBaseType().PGName())
+				}
+
 				// Get the sequence option if it's an identity column.
 				identityStart := tree.DNull
 				identityIncrement := tree.DNull
@@ -568,18 +580,18 @@ https://www.postgresql.org/docs/9.5/infoschema-columns.html`,
 					collationCatalog,                                          // collation_catalog
 					collationSchema,                                           // collation_schema
 					collationName,                                             // collation_name
-					tree.DNull,                                                // domain_catalog
-					tree.DNull,                                                // domain_schema
-					tree.DNull,                                                // domain_name
+					domainCatalog,                                             // domain_catalog
+					domainSchema,                                              // domain_schema
+					domainName,                                                // domain_name
 					dbNameStr,                                                 // udt_catalog
 					udtSchema,                                                 // udt_schema
-					tree.NewDString(column.GetType().PGName()), // udt_name
-					tree.DNull, // scope_catalog
-					tree.DNull, // scope_schema
-					tree.DNull, // scope_name
-					tree.DNull, // maximum_cardinality
-					tree.DNull, // dtd_identifier
-					tree.DNull, // is_self_referencing
+					udtName,                                                   // udt_name
+					tree.DNull,                                                // scope_catalog
+					tree.DNull,                                                // scope_schema
+					tree.DNull,                                                // scope_name
+					tree.DNull,                                                // maximum_cardinality
+					tree.DNull,                                                // dtd_identifier
+					tree.DNull,                                                // is_self_referencing
 					yesOrNoDatum(column.IsGeneratedAsIdentity()), // is_identity
 					colGeneratedAsIdentity,                       // identity_generation
 					identityStart,                                // identity_start
@@ -819,6 +831,10 @@ https://www.postgresql.org/docs/9.5/infoschema-constraint-column-usage.html`,
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
 				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+					if uwoi.IsExclusion() {
+						// Postgres does not include exclusion constraints here.
+						continue
+					}
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for _, col := range cols {
@@ -862,7 +878,7 @@ https://www.postgresql.org/docs/9.5/infoschema-key-column-usage.html`,
 					cols = table.ForeignKeyOriginColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.IsExclusion() {
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for pos, col := range cols {
@@ -1458,6 +1474,10 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 				tbNameStr := tree.NewDString(table.GetName())
 
 				for _, c := range table.AllConstraints() {
+					if u := c.AsUniqueWithoutIndex(); u != nil && u.IsExclusion() {
+						// Postgres does not include exclusion constraints here.
+						continue
+					}
 					kind := catconstants.ConstraintTypeUnique
 					if c.AsCheck() != nil {
 						kind = catconstants.ConstraintTypeCheck
@@ -1466,16 +1486,17 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 					} else if u := c.AsUniqueWithIndex(); u != nil && u.Primary() {
 						kind = catconstants.ConstraintTypePK
 					}
+					initiallyDeferred := c.GetDeferrability() == descpb.ConstraintDeferrability_InitiallyDeferred
 					if err := addRow(
-						dbNameStr,                     // constraint_catalog
-						scNameStr,                     // constraint_schema
-						tree.NewDString(c.GetName()),  // constraint_name
-						dbNameStr,                     // table_catalog
-						scNameStr,                     // table_schema
-						tbNameStr,                     // table_name
-						tree.NewDString(string(kind)), // constraint_type
-						yesOrNoDatum(false),           // is_deferrable
-						yesOrNoDatum(false),           // initially_deferred
+						dbNameStr,                       // constraint_catalog
+						scNameStr,                       // constraint_schema
+						tree.NewDString(c.GetName()),    // constraint_name
+						dbNameStr,                       // table_catalog
+						scNameStr,                       // table_schema
+						tbNameStr,                       // table_name
+						tree.NewDString(string(kind)),   // constraint_type
+						yesOrNoDatum(c.IsDeferrable()),  // is_deferrable
+						yesOrNoDatum(initiallyDeferred), // initially_deferred
 					); err != nil {
 						return err
 					}
@@ -2114,13 +2135,60 @@ var informationSchemaUsagePrivilegesTable = virtualSchemaTable{
 	unimplemented: true,
 }
 
+// Postgres: https://www.postgresql.org/docs/current/infoschema-domains.html
 var informationSchemaDomainsTable = virtualSchemaTable{
-	comment: "domains was created for compatibility and is currently unimplemented",
-	schema:  vtable.InformationSchemaDomains,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `domains
+https://www.postgresql.org/docs/current/infoschema-domains.html`,
+	schema: vtable.InformationSchemaDomains,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTypeDesc(ctx, p, dbContext, func(ctx context.Context, db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, typeDesc catalog.TypeDescriptor) error {
+			domain := typeDesc.AsDomainTypeDescriptor()
+			if domain == nil {
+				return nil
+			}
+			baseType := domain.BaseType()
+			dbNameStr := tree.NewDString(db.GetName())
+			domainDefault := tree.DNull
+			if expr, ok := domain.GetDefaultExpr(); ok {
+				domainDefault = tree.NewDString(expr)
+			}
+			collationCatalog, collationSchema, collationName := tree.DNull, tree.DNull, tree.DNull
+			if locale := baseType.Locale(); locale != "" {
+				collationCatalog = dbNameStr
+				collationSchema = pgCatalogNameDString
+				collationName = tree.NewDString(locale)
+			}
+			return addRow(
+				dbNameStr,                                         // domain_catalog
+				tree.NewDString(sc.GetName()),                     // domain_schema
+				tree.NewDString(typeDesc.GetName()),               // domain_name
+				tree.NewDString(baseType.InformationSchemaName()), // data_type
+				characterMaximumLength(baseType),                  // character_maximum_length
+				characterOctetLength(baseType),                    // character_octet_length
+				tree.DNull,                                        // character_set_catalog
+				tree.DNull,                                        // character_set_schema
+				tree.DNull,                                        // character_set_name
+				collationCatalog,                                  // collation_catalog
+				collationSchema,                                   // collation_schema
+				collationName,                                     // collation_name
+				numericPrecision(baseType),                        // numeric_precision
+				numericPrecisionRadix(baseType),                   // numeric_precision_radix
+				numericScale(baseType),                            // numeric_scale
+				datetimePrecision(baseType),                       // datetime_precision
+				tree.DNull,                                        // interval_type
+				tree.DNull,                                        // interval_precision
+				domainDefault,                                     // domain_default
+				dbNameStr,                                         // udt_catalog
+				pgCatalogNameDString,                              // udt_schema
+				tree.NewDString(baseType.PGName()),                // udt_name
+				tree.DNull,                                        // scope_catalog
+				tree.DNull,                                        // scope_schema
+				tree.DNull,                                        // scope_name
+				tree.DNull,                                        // maximum_cardinality
+				tree.DNull,                                        // dtd_identifier
+			)
+		})
 	},
-	unimplemented: true,
 }
 
 var informationSchemaSQLImplementationInfoTable = virtualSchemaTable{
@@ -261,6 +261,7 @@ func (n *insertNode) startExec(params runParams) error {
 	n.run.traceKV = params.p.ExtendedEvalContext().Tracing.KVTracingEnabled()
 
 	n.run.initRowContainer(params, n.columns)
+	n.run.ti.deferred = params.p.newDeferredConstraintTracker(n.run.ti.tableDesc(), deferredInsert)
 
 	return n.run.ti.init(params.ctx, params.p.txn, params.EvalContext())
 }
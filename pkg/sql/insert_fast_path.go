@@ -429,6 +429,7 @@ func (n *insertFastPathNode) startExec(params runParams) error {
 		n.run.uniqBatch.Requests = make([]kvpb.RequestUnion, 0, maxSpans)
 		n.run.uniqSpanInfo = make([]insertFastPathFKUniqSpanInfo, 0, maxSpans)
 	}
+	n.run.ti.deferred = params.p.newDeferredConstraintTracker(n.run.ti.tableDesc(), deferredInsert)
 
 	return n.run.ti.init(params.ctx, params.p.txn, params.EvalContext())
 }
@@ -1653,6 +1653,13 @@ func (icc *internalClientComm) CreateCopyOutResult(cmd CopyOut, pos CmdPos) Copy
 	panic("unimplemented")
 }
 
+// CreateStartReplicationResult is part of the ClientComm interface.
+func (icc *internalClientComm) CreateStartReplicationResult(
+	cmd StartReplication, pos CmdPos,
+) StartReplicationResult {
+	panic("unimplemented")
+}
+
 // CreateDrainResult is part of the ClientComm interface.
 func (icc *internalClientComm) CreateDrainResult(pos CmdPos) DrainResult {
 	panic("unimplemented")
@@ -0,0 +1,115 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Listen registers the session as a listener on a channel. Like in Postgres,
+// the session only starts listening when the transaction commits.
+// See https://www.postgresql.org/docs/current/sql-listen.html.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	if p.extendedEvalCtx.notificationReceiver == nil ||
+		p.extendedEvalCtx.listenOps == nil ||
+		p.ExecCfg().NotificationRegistry == nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"LISTEN is not supported in this session")
+	}
+	if err := pgnotify.CheckSupported(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	channel := string(n.ChannelName)
+	if err := pgnotify.ValidateChannel(channel); err != nil {
+		return nil, err
+	}
+	return &listenNode{op: listenOp{channel: channel}}, nil
+}
+
+// listenNode records a LISTEN or UNLISTEN statement in the transaction, to be
+// applied when the transaction commits.
+type listenNode struct {
+	op listenOp
+}
+
+func (n *listenNode) startExec(params runParams) error {
+	if ops := params.p.extendedEvalCtx.listenOps; ops != nil {
+		ops.add(n.op)
+	}
+	return nil
+}
+
+func (n *listenNode) Next(runParams) (bool, error) { return false, nil }
+func (n *listenNode) Values() tree.Datums          { return nil }
+func (n *listenNode) Close(context.Context)        {}
+
+// listenOp is a LISTEN or UNLISTEN statement executed in a transaction.
+type listenOp struct {
+	channel string
+	// unlisten is set for UNLISTEN.
+	unlisten bool
+	// all is set for UNLISTEN *, in which case channel is empty.
+	all bool
+}
+
+// txnListenOps accumulates the LISTEN and UNLISTEN statements executed in the
+// current transaction. They are applied to the notification registry when the
+// transaction commits and discarded when it, or the savepoint they were
+// executed after, is rolled back.
+type txnListenOps struct {
+	ops []listenOp
+}
+
+func (t *txnListenOps) add(op listenOp) {
+	t.ops = append(t.ops, op)
+}
+
+// len returns the number of statements recorded so far. It is saved by
+// savepoints so that rolling back to them discards the later statements.
+func (t *txnListenOps) len() int {
+	return len(t.ops)
+}
+
+// truncate discards the statements recorded after the first n ones.
+func (t *txnListenOps) truncate(n int) {
+	if n < len(t.ops) {
+		t.ops = t.ops[:n]
+	}
+}
+
+func (t *txnListenOps) reset() {
+	t.ops = nil
+}
+
+// apply applies the recorded statements, in order, to the registry on behalf
+// of rcv, and resets the state.
+func (t *txnListenOps) apply(
+	ctx context.Context, r *pgnotify.Registry, rcv pgnotify.Receiver,
+) error {
+	ops := t.ops
+	t.reset()
+	if r == nil || rcv == nil {
+		return nil
+	}
+	for _, op := range ops {
+		switch {
+		case op.all:
+			r.UnlistenAll(rcv)
+		case op.unlisten:
+			r.Unlisten(op.channel, rcv)
+		default:
+			if err := r.Listen(ctx, op.channel, rcv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -549,6 +549,13 @@ func TestLogic_default(
 	runLogicTest(t, "default")
 }
 
+func TestLogic_deferrable_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "deferrable_constraints")
+}
+
 func TestLogic_delete(
 	t *testing.T,
 ) {
@@ -640,6 +647,13 @@ func TestLogic_distsql_srfs(
 	runLogicTest(t, "distsql_srfs")
 }
 
+func TestLogic_domain(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "domain")
+}
+
 func TestLogic_drop_database(
 	t *testing.T,
 ) {
@@ -773,6 +787,13 @@ func TestLogic_exclude_data_from_backup(
 	runLogicTest(t, "exclude_data_from_backup")
 }
 
+func TestLogic_exclusion_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "exclusion_constraints")
+}
+
 func TestLogic_experimental_distsql_planning(
 	t *testing.T,
 ) {
@@ -850,6 +871,13 @@ func TestLogic_fuzzystrmatch(
 	runLogicTest(t, "fuzzystrmatch")
 }
 
+func TestLogic_geometric_types(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "geometric_types")
+}
+
 func TestLogic_geospatial(
 	t *testing.T,
 ) {
@@ -857,6 +885,13 @@ func TestLogic_geospatial(
 	runLogicTest(t, "geospatial")
 }
 
+func TestLogic_geospatial_cluster(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "geospatial_cluster")
+}
+
 func TestLogic_geospatial_index(
 	t *testing.T,
 ) {
@@ -1116,6 +1151,13 @@ func TestLogic_json_index(
 	runLogicTest(t, "json_index")
 }
 
+func TestLogic_jsonpath(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "jsonpath")
+}
+
 func TestLogic_kv_builtin_functions(
 	t *testing.T,
 ) {
@@ -1130,6 +1172,13 @@ func TestLogic_limit(
 	runLogicTest(t, "limit")
 }
 
+func TestLogic_listen_notify(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "listen_notify")
+}
+
 func TestLogic_locality(
 	t *testing.T,
 ) {
@@ -1200,6 +1249,13 @@ func TestLogic_namespace(
 	runLogicTest(t, "namespace")
 }
 
+func TestLogic_network_money_types(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "network_money_types")
+}
+
 func TestLogic_new_schema_changer(
 	t *testing.T,
 ) {
@@ -1473,6 +1529,20 @@ func TestLogic_propagate_input_ordering(
 	runLogicTest(t, "propagate_input_ordering")
 }
 
+func TestLogic_publication(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "publication")
+}
+
+func TestLogic_range_types(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "range_types")
+}
+
 func TestLogic_reassign_owned_by(
 	t *testing.T,
 ) {
@@ -2089,6 +2159,20 @@ func TestLogic_tenant_builtins(
 	runLogicTest(t, "tenant_builtins")
 }
 
+func TestLogic_text_search_config(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "text_search_config")
+}
+
+func TestLogic_text_search_rank(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "text_search_rank")
+}
+
 func TestLogic_time(
 	t *testing.T,
 ) {
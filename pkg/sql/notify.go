@@ -0,0 +1,41 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+)
+
+// Notify sends a notification to the sessions listening on a channel. The
+// notification is written to system.notifications in the current transaction,
+// so it is only delivered if and when the transaction commits.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	if err := pgnotify.CheckSupported(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	channel := string(n.ChannelName)
+	if err := pgnotify.ValidateChannel(channel); err != nil {
+		return nil, err
+	}
+	var payload string
+	if n.Payload != nil {
+		payload = n.Payload.RawString()
+	}
+	if err := pgnotify.ValidatePayload(payload); err != nil {
+		return nil, err
+	}
+	if _, err := p.InternalSQLTxn().ExecEx(
+		ctx, "notify", p.Txn(), sessiondata.NodeUserSessionDataOverride,
+		pgnotify.InsertStmt, channel, payload, int32(p.extendedEvalCtx.QueryCancelKey.GetPGBackendPID()),
+	); err != nil {
+		return nil, err
+	}
+	return newZeroNode(nil /* columns */), nil
+}
@@ -33,6 +33,27 @@ const (
 	T__pgvector  = oid.Oid(90007)
 )
 
+// OIDs in this block are defined by Postgres, but are missing from
+// `github.com/lib/pq/oid` since they were added in Postgres 10 or later.
+const (
+	T_macaddr8        = oid.Oid(774)
+	T__macaddr8       = oid.Oid(775)
+	T_jsonpath        = oid.Oid(4072)
+	T__jsonpath       = oid.Oid(4073)
+	T_int4multirange  = oid.Oid(4451)
+	T_nummultirange   = oid.Oid(4532)
+	T_tsmultirange    = oid.Oid(4533)
+	T_tstzmultirange  = oid.Oid(4534)
+	T_datemultirange  = oid.Oid(4535)
+	T_int8multirange  = oid.Oid(4536)
+	T__int4multirange = oid.Oid(6150)
+	T__nummultirange  = oid.Oid(6151)
+	T__tsmultirange   = oid.Oid(6152)
+	T__tstzmultirange = oid.Oid(6153)
+	T__datemultirange = oid.Oid(6155)
+	T__int8multirange = oid.Oid(6157)
+)
+
 // ExtensionTypeName returns a mapping from extension oids
 // to their type name.
 var ExtensionTypeName = map[oid.Oid]string{
@@ -44,6 +65,23 @@ var ExtensionTypeName = map[oid.Oid]string{
 	T__box2d:     "_BOX2D",
 	T_pgvector:   "VECTOR",
 	T__pgvector:  "_VECTOR",
+
+	T_macaddr8:        "MACADDR8",
+	T__macaddr8:       "_MACADDR8",
+	T_jsonpath:        "JSONPATH",
+	T__jsonpath:       "_JSONPATH",
+	T_int4multirange:  "INT4MULTIRANGE",
+	T_nummultirange:   "NUMMULTIRANGE",
+	T_tsmultirange:    "TSMULTIRANGE",
+	T_tstzmultirange:  "TSTZMULTIRANGE",
+	T_datemultirange:  "DATEMULTIRANGE",
+	T_int8multirange:  "INT8MULTIRANGE",
+	T__int4multirange: "_INT4MULTIRANGE",
+	T__nummultirange:  "_NUMMULTIRANGE",
+	T__tsmultirange:   "_TSMULTIRANGE",
+	T__tstzmultirange: "_TSTZMULTIRANGE",
+	T__datemultirange: "_DATEMULTIRANGE",
+	T__int8multirange: "_INT8MULTIRANGE",
 }
 
 // TypeName checks the name for a given type by first looking up oid.TypeName
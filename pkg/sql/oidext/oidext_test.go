@@ -25,6 +25,8 @@ func TestTypeName(t *testing.T) {
 	}{
 		{oid.T_int4, "INT4", true},
 		{T_geometry, "GEOMETRY", true},
+		{T_int4multirange, "INT4MULTIRANGE", true},
+		{T_jsonpath, "JSONPATH", true},
 		{oid.Oid(99988199), "", false},
 	}
 
@@ -100,6 +100,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.AlterDatabaseSetZoneConfigExtension(ctx, n)
 	case *tree.AlterDefaultPrivileges:
 		return p.alterDefaultPrivileges(ctx, n)
+	case *tree.AlterDomain:
+		return p.AlterDomain(ctx, n)
 	case *tree.AlterFunctionOptions:
 		return p.AlterFunctionOptions(ctx, n)
 	case *tree.AlterRoutineRename:
@@ -112,6 +114,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.AlterFunctionDepExtension(ctx, n)
 	case *tree.AlterIndex:
 		return p.AlterIndex(ctx, n)
+	case *tree.AlterPublication:
+		return p.AlterPublication(ctx, n)
 	case *tree.AlterIndexVisible:
 		return p.AlterIndexVisible(ctx, n)
 	case *tree.AlterSchema:
@@ -122,6 +126,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.AlterTableLocality(ctx, n)
 	case *tree.AlterTableOwner:
 		return p.AlterTableOwner(ctx, n)
+	case *tree.AlterTextSearch:
+		return p.AlterTextSearch(ctx, n)
 	case *tree.AlterTableSetSchema:
 		return p.AlterTableSetSchema(ctx, n)
 	case *tree.AlterTenantCapability:
@@ -161,12 +167,20 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		// it can't have placeholder arguments, and the execution can use the same
 		// logic as if it were a simple query. This matches the Postgres behavior.
 		return &zeroNode{}, nil
+	case *tree.CreateAggregate:
+		return p.CreateAggregate(ctx, n)
 	case *tree.CreateDatabase:
 		return p.CreateDatabase(ctx, n)
+	case *tree.CreateDomain:
+		return p.CreateDomain(ctx, n)
 	case *tree.CreateIndex:
 		return p.CreateIndex(ctx, n)
+	case *tree.CreatePublication:
+		return p.CreatePublication(ctx, n)
 	case *tree.CreateSchema:
 		return p.CreateSchema(ctx, n)
+	case *tree.CreateTextSearch:
+		return p.CreateTextSearch(ctx, n)
 	case *tree.CreateTrigger:
 		return p.CreateTrigger(ctx, n)
 	case *tree.CreateType:
@@ -191,12 +205,16 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.Discard(ctx, n)
 	case *tree.DropDatabase:
 		return p.DropDatabase(ctx, n)
+	case *tree.DropDomain:
+		return p.DropDomain(ctx, n)
 	case *tree.DropRoutine:
 		return p.DropFunction(ctx, n)
 	case *tree.DropIndex:
 		return p.DropIndex(ctx, n)
 	case *tree.DropOwnedBy:
 		return p.DropOwnedBy(ctx)
+	case *tree.DropPublication:
+		return p.DropPublication(ctx, n)
 	case *tree.DropRole:
 		return p.DropRole(ctx, n)
 	case *tree.DropSchema:
@@ -207,6 +225,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.DropTable(ctx, n)
 	case *tree.DropTenant:
 		return p.DropTenant(ctx, n)
+	case *tree.DropTextSearch:
+		return p.DropTextSearch(ctx, n)
 	case *tree.DropTrigger:
 		return p.DropTrigger(ctx, n)
 	case *tree.DropType:
@@ -219,8 +239,12 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.Grant(ctx, n)
 	case *tree.GrantRole:
 		return p.GrantRole(ctx, n)
+	case *tree.Listen:
+		return p.Listen(ctx, n)
 	case *tree.MoveCursor:
 		return p.FetchCursor(ctx, &n.CursorStmt)
+	case *tree.Notify:
+		return p.Notify(ctx, n)
 	case *tree.ReassignOwnedBy:
 		return p.ReassignOwnedBy(ctx, n)
 	case *tree.RefreshMaterializedView:
@@ -249,6 +273,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.SetZoneConfig(ctx, n)
 	case *tree.SetVar:
 		return p.SetVar(ctx, n)
+	case *tree.SetConstraints:
+		return p.SetConstraints(ctx, n)
 	case *tree.SetTransaction:
 		return p.SetTransaction(ctx, n)
 	case *tree.SetSessionAuthorizationDefault:
@@ -287,6 +313,10 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.Unlisten(ctx, n)
 	case *pgrepltree.IdentifySystem:
 		return p.IdentifySystem(ctx, n)
+	case *pgrepltree.CreateReplicationSlot:
+		return p.CreateReplicationSlot(ctx, n)
+	case *pgrepltree.DropReplicationSlot:
+		return p.DropReplicationSlot(ctx, n)
 	case tree.CCLOnlyStatement:
 		plan, err := p.maybePlanHook(ctx, stmt)
 		if plan == nil && err == nil {
@@ -317,16 +347,19 @@ func init() {
 		&tree.AlterDefaultPrivileges{},
 		&tree.AlterFunctionOptions{},
 		&tree.AlterRoutineRename{},
+		&tree.AlterDomain{},
 		&tree.AlterRoutineSetOwner{},
 		&tree.AlterRoutineSetSchema{},
 		&tree.AlterFunctionDepExtension{},
 		&tree.AlterIndex{},
 		&tree.AlterIndexVisible{},
+		&tree.AlterPublication{},
 		&tree.AlterSchema{},
 		&tree.AlterTable{},
 		&tree.AlterTableLocality{},
 		&tree.AlterTableOwner{},
 		&tree.AlterTableSetSchema{},
+		&tree.AlterTextSearch{},
 		&tree.AlterTenantCapability{},
 		&tree.AlterTenantRename{},
 		&tree.AlterTenantSetClusterSetting{},
@@ -344,13 +377,17 @@ func init() {
 		&tree.CommentOnConstraint{},
 		&tree.CommentOnTable{},
 		&tree.CopyTo{},
+		&tree.CreateAggregate{},
 		&tree.CreateDatabase{},
+		&tree.CreateDomain{},
 		&tree.CreateExtension{},
 		&tree.CreateExternalConnection{},
 		&tree.CreateTenant{},
 		&tree.CreateIndex{},
+		&tree.CreatePublication{},
 		&tree.CreateSchema{},
 		&tree.CreateSequence{},
+		&tree.CreateTextSearch{},
 		&tree.CreateTrigger{},
 		&tree.CreateType{},
 		&tree.CreateRole{},
@@ -358,22 +395,27 @@ func init() {
 		&tree.DeclareCursor{},
 		&tree.Discard{},
 		&tree.DropDatabase{},
+		&tree.DropDomain{},
 		&tree.DropExternalConnection{},
 		&tree.DropRoutine{},
 		&tree.DropTrigger{},
 		&tree.DropIndex{},
 		&tree.DropOwnedBy{},
+		&tree.DropPublication{},
 		&tree.DropRole{},
 		&tree.DropSchema{},
 		&tree.DropSequence{},
 		&tree.DropTable{},
 		&tree.DropTenant{},
+		&tree.DropTextSearch{},
 		&tree.DropType{},
 		&tree.DropView{},
 		&tree.FetchCursor{},
 		&tree.Grant{},
 		&tree.GrantRole{},
+		&tree.Listen{},
 		&tree.MoveCursor{},
+		&tree.Notify{},
 		&tree.ReassignOwnedBy{},
 		&tree.RefreshMaterializedView{},
 		&tree.RenameColumn{},
@@ -388,6 +430,7 @@ func init() {
 		&tree.SetClusterSetting{},
 		&tree.SetZoneConfig{},
 		&tree.SetVar{},
+		&tree.SetConstraints{},
 		&tree.SetTransaction{},
 		&tree.SetSessionAuthorizationDefault{},
 		&tree.SetSessionCharacteristics{},
@@ -408,6 +451,8 @@ func init() {
 		&tree.Unlisten{},
 
 		&pgrepltree.IdentifySystem{},
+		&pgrepltree.CreateReplicationSlot{},
+		&pgrepltree.DropReplicationSlot{},
 
 		// CCL statements (without Export which has an optimizer operator).
 		&tree.AlterBackup{},
@@ -419,6 +464,7 @@ func init() {
 		&tree.Restore{},
 		&tree.CreateChangefeed{},
 		&tree.ScheduledChangefeed{},
+		&tree.ShowChangefeedDLQ{},
 		&tree.Import{},
 		&tree.ScheduledBackup{},
 		&tree.CreateTenantFromReplication{},
@@ -213,4 +213,11 @@ type Catalog interface {
 	// GetRoutineOwner returns the username.SQLUsername of the routine's
 	// (specified by routineOid) owner.
 	GetRoutineOwner(ctx context.Context, routineOid oid.Oid) (username.SQLUsername, error)
+
+	// IsConstraintDeferred returns true if checking of the deferrable
+	// constraint with the given name on the given table is currently deferred
+	// until the end of the transaction. If it returns true, the catalog takes
+	// responsibility for validating the constraint before the transaction
+	// commits, and no check should be planned for it.
+	IsConstraintDeferred(ctx context.Context, tabID StableID, name string) bool
 }
@@ -8,6 +8,7 @@ package cat
 import (
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 )
@@ -189,6 +190,17 @@ type Index interface {
 	// describes the configuration for this geospatial inverted index.
 	GeoConfig() geopb.Config
 
+	// VectorConfig returns the configuration of a vector index. If IsVector is
+	// set, the first key column of the index is the partition of the vector
+	// column computed by crdb_internal.vector_partition, and the vector column
+	// is stored in the index.
+	VectorConfig() catpb.VectorIndexDescriptor
+
+	// HasTermStatistics returns true if this is an inverted index on a TSVECTOR
+	// column which stores the frequency of each lexeme and the length of the
+	// document in the value of every index entry.
+	HasTermStatistics() bool
+
 	// Version returns the IndexDescriptorVersion of the index.
 	Version() descpb.IndexDescriptorVersion
 
@@ -10,6 +10,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -190,12 +191,21 @@ type Table interface {
 //
 //	CREATE TABLE a (a INT CHECK (a > 0))
 type CheckConstraint interface {
+	// Name of the check constraint. It is empty for check constraints which
+	// are synthesized by the catalog.
+	Name() string
+
 	// Constraint contains the SQL text of this check constraint.
 	Constraint() string
 
 	// Validated returns true if this check constraint has been validated.
 	Validated() bool
 
+	// Deferrable is true if checking of this constraint can be deferred until
+	// the end of the transaction. Deferrable constraints are never reported as
+	// validated, since they may be violated in the middle of a transaction.
+	Deferrable() bool
+
 	// ColumnCount returns the number of columns in this constraint.
 	ColumnCount() int
 
@@ -319,6 +329,11 @@ type ForeignKeyConstraint interface {
 	// needs to be enforced on new mutations.
 	Validated() bool
 
+	// Deferrable is true if checking of this constraint can be deferred until
+	// the end of the transaction. Deferrable constraints are never reported as
+	// validated, since they may be violated in the middle of a transaction.
+	Deferrable() bool
+
 	// MatchMethod returns the method used for comparing composite foreign keys.
 	MatchMethod() tree.CompositeKeyMatchMethod
 
@@ -374,12 +389,27 @@ type UniqueConstraint interface {
 	// needs to be enforced on new mutations.
 	Validated() bool
 
+	// Deferrable is true if checking of this constraint can be deferred until
+	// the end of the transaction. Deferrable constraints are never reported as
+	// validated, since they may be violated in the middle of a transaction.
+	Deferrable() bool
+
 	// UniquenessGuaranteedByAnotherIndex returns true when WithoutIndex() returns
 	// true and the uniqueness of the constraint is guaranteed by another index.
 	// When true, the optimizer will always consider the constraint to be
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// Exclusion is true if this is an exclusion constraint, in which case two
+	// rows conflict if the comparisons of each of its columns with
+	// ExclusionOperator are all true. An exclusion constraint is always
+	// WithoutIndex, and does not make its columns a key of the table.
+	Exclusion() bool
+
+	// ExclusionOperator returns the operator used to compare the ith column of
+	// an exclusion constraint, which is either EQ or Overlaps.
+	ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
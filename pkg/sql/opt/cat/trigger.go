@@ -10,6 +10,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // Trigger is an interface to a trigger on a table or view, which executes a
@@ -49,9 +50,14 @@ type Trigger interface {
 	WhenExpr() string
 
 	// FuncID is the ID of the function that will be called when the trigger
-	// fires.
+	// fires. It is zero if the function is a builtin.
 	FuncID() StableID
 
+	// BuiltinFuncOID is the OID of the function that will be called when the
+	// trigger fires if it is a builtin function, such as
+	// tsvector_update_trigger. Otherwise, it is zero.
+	BuiltinFuncOID() oid.Oid
+
 	// FuncArgs is a list of constant string arguments for the trigger function.
 	FuncArgs() tree.Datums
 
@@ -101,11 +101,16 @@ func FormatTable(
 		if uniq.WithoutIndex() {
 			withoutIndexStr = "WITHOUT INDEX "
 		}
-		c := child.Childf(
-			"UNIQUE %s%s",
-			withoutIndexStr,
-			formatCols(tab, tab.Unique(i).ColumnCount(), tab.Unique(i).ColumnOrdinal),
-		)
+		var c treeprinter.Node
+		if uniq.Exclusion() {
+			c = child.Childf("EXCLUDE %s", formatExclusionElems(tab, uniq))
+		} else {
+			c = child.Childf(
+				"UNIQUE %s%s",
+				withoutIndexStr,
+				formatCols(tab, tab.Unique(i).ColumnCount(), tab.Unique(i).ColumnOrdinal),
+			)
+		}
 		if pred, isPartial := uniq.Predicate(); isPartial {
 			c.Childf("WHERE %s", MaybeMarkRedactable(pred, redactableValues))
 		}
@@ -205,6 +210,22 @@ func formatCols(tab Table, numCols int, colOrdinal func(tab Table, i int) int) s
 	return buf.String()
 }
 
+// formatExclusionElems formats the columns of an exclusion constraint along
+// with the operators used to compare them.
+func formatExclusionElems(tab Table, uniq UniqueConstraint) string {
+	var buf bytes.Buffer
+	buf.WriteByte('(')
+	for i := 0; i < uniq.ColumnCount(); i++ {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		colName := tab.Column(uniq.ColumnOrdinal(tab, i)).ColName()
+		fmt.Fprintf(&buf, "%s WITH %s", colName.String(), uniq.ExclusionOperator(i))
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
 // formatCatalogFKRef nicely formats a catalog foreign key reference using a
 // treeprinter for debugging and testing.
 func formatCatalogFKRef(
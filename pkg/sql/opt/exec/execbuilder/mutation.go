@@ -887,6 +887,9 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums) error {
 	tabMeta := md.TableMeta(c.Table)
 	uc := tabMeta.Table.Unique(c.CheckOrdinal)
+	if uc.Exclusion() {
+		return mkExclusionCheckErr(md, c, keyVals)
+	}
 	constraintName := uc.Name()
 	var msg, details bytes.Buffer
 
@@ -923,6 +926,51 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	)
 }
 
+// mkExclusionCheckErr generates a user-friendly error describing a violation
+// of an exclusion constraint. The keyVals are the values that correspond to the
+// cat.UniqueConstraint columns, ordered by their ordinals in the table.
+func mkExclusionCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums) error {
+	tabMeta := md.TableMeta(c.Table)
+	uc := tabMeta.Table.Unique(c.CheckOrdinal)
+	constraintName := uc.Name()
+	var msg, details bytes.Buffer
+
+	// Generate an error of the form:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (k, r)=(2, [1,5)) conflicts with existing key.
+	msg.WriteString("conflicting key value violates exclusion constraint ")
+	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
+
+	var ords intsets.Fast
+	for i := 0; i < uc.ColumnCount(); i++ {
+		ords.Add(uc.ColumnOrdinal(tabMeta.Table, i))
+	}
+	details.WriteString("Key (")
+	for i, ord := range ords.Ordered() {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		details.WriteString(string(tabMeta.Table.Column(ord).ColName()))
+	}
+	details.WriteString(")=(")
+	for i, d := range keyVals {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		details.WriteString(d.String())
+	}
+
+	details.WriteString(") conflicts with existing key.")
+
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(pgcode.ExclusionViolation, "%s", msg.String()),
+			constraintName,
+		),
+		details.String(),
+	)
+}
+
 // mkUniqueCheckErrWithoutColNames is a simpler version of mkUniqueCheckErr that
 // omits column names from the error details.
 func mkUniqueCheckErrWithoutColNames(
@@ -35,6 +35,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -203,6 +204,9 @@ func (b *Builder) buildRelational(e memo.RelExpr) (_ execPlan, outputCols colOrd
 	case *memo.GroupByExpr, *memo.ScalarGroupByExpr:
 		ep, outputCols, err = b.buildGroupBy(e)
 
+	case *memo.GroupingSetsExpr:
+		ep, outputCols, err = b.buildGroupingSets(e)
+
 	case *memo.DistinctOnExpr, *memo.EnsureDistinctOnExpr, *memo.UpsertDistinctOnExpr,
 		*memo.EnsureUpsertDistinctOnExpr:
 		ep, outputCols, err = b.buildDistinct(t)
@@ -210,6 +214,9 @@ func (b *Builder) buildRelational(e memo.RelExpr) (_ execPlan, outputCols colOrd
 	case *memo.TopKExpr:
 		ep, outputCols, err = b.buildTopK(t)
 
+	case *memo.TextSearchTopKExpr:
+		ep, outputCols, err = b.buildTextSearchTopK(t)
+
 	case *memo.LimitExpr, *memo.OffsetExpr:
 		ep, outputCols, err = b.buildLimitOffset(e)
 
@@ -1630,6 +1637,107 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 	}
 
 	aggregations := *groupBy.Child(1).(*memo.AggregationsExpr)
+	aggInfos, err := b.buildAggInfos(aggregations, inputCols)
+	if err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+	for i := range aggregations {
+		outputCols.Set(aggregations[i].Col, len(groupingColIdx)+i)
+	}
+
+	var ep execPlan
+	if groupBy.Op() == opt.ScalarGroupByOp {
+		ep.root, err = b.factory.ConstructScalarGroupBy(input.root, aggInfos)
+	} else {
+		groupBy := groupBy.(*memo.GroupByExpr)
+		var groupingColOrder colinfo.ColumnOrdering
+		groupingColOrder, err = sqlOrdering(ordering.StreamingGroupingColOrdering(
+			&groupBy.GroupingPrivate, &groupBy.RequiredPhysical().Ordering,
+		), inputCols)
+		if err != nil {
+			return execPlan{}, colOrdMap{}, err
+		}
+		var reqOrd exec.OutputOrdering
+		reqOrd, err = reqOrdering(groupBy, outputCols)
+		if err != nil {
+			return execPlan{}, colOrdMap{}, err
+		}
+		orderType := exec.GroupingOrderType(groupBy.GroupingOrderType(&groupBy.RequiredPhysical().Ordering))
+		var rowCount uint64
+		if relProps := groupBy.Relational(); relProps.Statistics().Available {
+			rowCount = uint64(math.Ceil(relProps.Statistics().RowCount))
+		}
+		ep.root, err = b.factory.ConstructGroupBy(
+			input.root, groupingColIdx, groupingColOrder, aggInfos, reqOrd, orderType, rowCount,
+		)
+	}
+	if err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+	return ep, outputCols, nil
+}
+
+func (b *Builder) buildGroupingSets(
+	groupingSets *memo.GroupingSetsExpr,
+) (_ execPlan, outputCols colOrdMap, err error) {
+	input, inputCols, err := b.buildGroupByInput(groupingSets)
+	// The input column map is only used for the lifetime of this function, so
+	// free the map afterward.
+	defer b.colOrdsAlloc.Free(inputCols)
+	if err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
+	// The output contains the grouping columns, followed by the aggregations
+	// and the grouping set ID.
+	groupingColIdx := make([]exec.NodeColumnOrdinal, len(groupingSets.InputCols))
+	outputCols = b.colOrdsAlloc.Alloc()
+	for i, col := range groupingSets.InputCols {
+		groupingColIdx[i], err = getNodeColumnOrdinal(inputCols, col)
+		if err != nil {
+			return execPlan{}, colOrdMap{}, err
+		}
+		outputCols.Set(groupingSets.OutputCols[i], i)
+	}
+
+	sets := make([]exec.NodeColumnOrdinalSet, len(groupingSets.Sets))
+	for i, set := range groupingSets.Sets {
+		for col, ok := set.Next(0); ok; col, ok = set.Next(col + 1) {
+			ord, err := getNodeColumnOrdinal(inputCols, col)
+			if err != nil {
+				return execPlan{}, colOrdMap{}, err
+			}
+			sets[i].Add(int(ord))
+		}
+	}
+
+	aggregations := groupingSets.Aggregations
+	aggInfos, err := b.buildAggInfos(aggregations, inputCols)
+	if err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+	for i := range aggregations {
+		outputCols.Set(aggregations[i].Col, len(groupingColIdx)+i)
+	}
+	outputCols.Set(groupingSets.GroupingIDCol, len(groupingColIdx)+len(aggregations))
+
+	var rowCount uint64
+	if relProps := groupingSets.Relational(); relProps.Statistics().Available {
+		rowCount = uint64(math.Ceil(relProps.Statistics().RowCount))
+	}
+	var ep execPlan
+	ep.root, err = b.factory.ConstructGroupingSets(input.root, groupingColIdx, sets, aggInfos, rowCount)
+	if err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+	return ep, outputCols, nil
+}
+
+// buildAggInfos builds the aggregations of a grouping operator, whose input
+// columns are mapped by inputCols.
+func (b *Builder) buildAggInfos(
+	aggregations memo.AggregationsExpr, inputCols colOrdMap,
+) (_ []exec.AggInfo, err error) {
 	aggInfos := make([]exec.AggInfo, len(aggregations))
 	// There will be roughly one column per aggregation.
 	argCols := make([]exec.NodeColumnOrdinal, 0, len(aggregations))
@@ -1642,11 +1750,11 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 		if aggFilter, ok := agg.(*memo.AggFilterExpr); ok {
 			filter, ok := aggFilter.Filter.(*memo.VariableExpr)
 			if !ok {
-				return execPlan{}, colOrdMap{}, errors.AssertionFailedf("only VariableOp args supported")
+				return nil, errors.AssertionFailedf("only VariableOp args supported")
 			}
 			filterOrd, err = getNodeColumnOrdinal(inputCols, filter.Col)
 			if err != nil {
-				return execPlan{}, colOrdMap{}, err
+				return nil, err
 			}
 			agg = aggFilter.Input
 		}
@@ -1657,7 +1765,23 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 			agg = aggDistinct.Input
 		}
 
-		name, overload := memo.FindAggregateOverload(agg)
+		var name string
+		var distsqlBlocklist bool
+		var userDefined *exec.UserDefinedAggregate
+		if uda, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			// User-defined aggregates invoke routines, which can only be
+			// evaluated on the gateway, unless their component functions can
+			// be inlined.
+			userDefined, err = b.buildUserDefinedAggregate(uda.Def)
+			if err != nil {
+				return nil, err
+			}
+			name, distsqlBlocklist = uda.Def.Name, userDefined.Inlined == nil
+		} else {
+			var overload *tree.Overload
+			name, overload = memo.FindAggregateOverload(agg)
+			distsqlBlocklist = overload.DistsqlBlocklist
+		}
 
 		// Accumulate variable arguments in argCols and constant arguments in
 		// constArgs. Constant arguments must follow variable arguments.
@@ -1665,16 +1789,16 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 			child := agg.Child(j)
 			if variable, ok := child.(*memo.VariableExpr); ok {
 				if len(constArgs) != 0 {
-					return execPlan{}, colOrdMap{}, errors.Errorf("constant args must come after variable args")
+					return nil, errors.Errorf("constant args must come after variable args")
 				}
 				ord, err := getNodeColumnOrdinal(inputCols, variable.Col)
 				if err != nil {
-					return execPlan{}, colOrdMap{}, err
+					return nil, err
 				}
 				argCols = append(argCols, ord)
 			} else {
 				if len(argCols) == 0 {
-					return execPlan{}, colOrdMap{}, errors.Errorf("a constant arg requires at least one variable arg")
+					return nil, errors.Errorf("a constant arg requires at least one variable arg")
 				}
 				if constArgs == nil {
 					// Lazily allocate constArgs.
@@ -1691,45 +1815,117 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 			ArgCols:          argCols[:len(argCols):len(argCols)],
 			ConstArgs:        constArgs[:len(constArgs):len(constArgs)],
 			Filter:           filterOrd,
-			DistsqlBlocklist: overload.DistsqlBlocklist,
+			DistsqlBlocklist: distsqlBlocklist,
+			UserDefined:      userDefined,
 		}
-		outputCols.Set(item.Col, len(groupingColIdx)+i)
 		// Slice argCols and constArgs so the rest of their capacity can be
 		// reused.
 		argCols = argCols[len(argCols):]
 		constArgs = constArgs[len(constArgs):]
 	}
+	return aggInfos, nil
+}
 
-	var ep execPlan
-	if groupBy.Op() == opt.ScalarGroupByOp {
-		ep.root, err = b.factory.ConstructScalarGroupBy(input.root, aggInfos)
-	} else {
-		groupBy := groupBy.(*memo.GroupByExpr)
-		var groupingColOrder colinfo.ColumnOrdering
-		groupingColOrder, err = sqlOrdering(ordering.StreamingGroupingColOrdering(
-			&groupBy.GroupingPrivate, &groupBy.RequiredPhysical().Ordering,
-		), inputCols)
-		if err != nil {
-			return execPlan{}, colOrdMap{}, err
+// buildUserDefinedAggregate builds the component functions of a user-defined
+// aggregate into routines that are invoked by the aggregator, and into scalar
+// expressions if they were inlined.
+func (b *Builder) buildUserDefinedAggregate(
+	def *memo.UDADefinition,
+) (*exec.UserDefinedAggregate, error) {
+	res := &exec.UserDefinedAggregate{
+		StateType:  def.StateType,
+		InitCond:   def.InitCond,
+		NumArgs:    len(def.Transition.Params) - 1,
+		Transition: b.buildAggregateRoutine(def.Transition),
+	}
+	if def.Final != nil {
+		res.Final = b.buildAggregateRoutine(def.Final)
+	}
+	if def.Combine != nil {
+		res.Combine = b.buildAggregateRoutine(def.Combine)
+	}
+	if def.Inlined != nil {
+		var inlined exec.InlinedUserDefinedAggregate
+		var err error
+		if inlined.Transition, err = b.buildInlinedAggregateFunc(
+			def.Transition.Params, def.Inlined.Transition,
+		); err != nil {
+			return nil, err
 		}
-		var reqOrd exec.OutputOrdering
-		reqOrd, err = reqOrdering(groupBy, outputCols)
-		if err != nil {
-			return execPlan{}, colOrdMap{}, err
+		if def.Final != nil {
+			if inlined.Final, err = b.buildInlinedAggregateFunc(
+				def.Final.Params, def.Inlined.Final,
+			); err != nil {
+				return nil, err
+			}
 		}
-		orderType := exec.GroupingOrderType(groupBy.GroupingOrderType(&groupBy.RequiredPhysical().Ordering))
-		var rowCount uint64
-		if relProps := groupBy.Relational(); relProps.Statistics().Available {
-			rowCount = uint64(math.Ceil(relProps.Statistics().RowCount))
+		if def.Combine != nil {
+			if inlined.Combine, err = b.buildInlinedAggregateFunc(
+				def.Combine.Params, def.Inlined.Combine,
+			); err != nil {
+				return nil, err
+			}
 		}
-		ep.root, err = b.factory.ConstructGroupBy(
-			input.root, groupingColIdx, groupingColOrder, aggInfos, reqOrd, orderType, rowCount,
-		)
+		res.Inlined = &inlined
 	}
-	if err != nil {
-		return execPlan{}, colOrdMap{}, err
+	return res, nil
+}
+
+// buildInlinedAggregateFunc builds the inlined body of a component function of
+// a user-defined aggregate into a scalar expression that refers to the i-th
+// parameter of the function with an IndexedVar with index i.
+func (b *Builder) buildInlinedAggregateFunc(
+	params opt.ColList, body opt.ScalarExpr,
+) (tree.TypedExpr, error) {
+	paramCols := b.colOrdsAlloc.Alloc()
+	defer b.colOrdsAlloc.Free(paramCols)
+	for i, col := range params {
+		paramCols.Set(col, i)
+	}
+	ctx := makeBuildScalarCtx(paramCols)
+	return b.buildScalar(&ctx, body)
+}
+
+// buildAggregateRoutine builds a component function of a user-defined
+// aggregate into a routine with no arguments. The arguments are supplied by the
+// aggregator each time the routine is invoked.
+func (b *Builder) buildAggregateRoutine(def *memo.UDFDefinition) *tree.RoutineExpr {
+	for _, s := range def.Body {
+		if s.Relational().CanMutate {
+			b.flags.Set(exec.PlanFlagContainsMutation)
+			break
+		}
 	}
-	return ep, outputCols, nil
+	planGen := b.buildRoutinePlanGenerator(
+		def.Params,
+		def.Body,
+		def.BodyProps,
+		def.BodyStmts,
+		false, /* allowOuterWithRefs */
+		nil,   /* wrapRootExpr */
+	)
+	blockState := def.BlockState
+	if blockState != nil {
+		blockState.VariableCount = len(def.Params)
+		b.initRoutineExceptionHandler(blockState, def.ExceptionBlock)
+	}
+	return tree.NewTypedRoutineExpr(
+		def.Name,
+		nil, /* args */
+		planGen,
+		def.Typ,
+		def.Volatility == volatility.Volatile, /* enableStepping */
+		def.CalledOnNullInput,
+		def.MultiColDataSource,
+		def.SetReturning,
+		false, /* tailCall */
+		false, /* procedure */
+		def.BlockStart,
+		blockState,
+		def.CursorDeclaration,
+		def.ResultBuffer,
+		def.ReturnNext,
+	)
 }
 
 func (b *Builder) buildDistinct(
@@ -1810,8 +2006,12 @@ func (b *Builder) buildGroupByInput(
 	// We address just the GroupBy case for now because there is a particularly
 	// important case with COUNT(*) where we can remove all input columns, which
 	// leads to significant speedup.
-	private := groupBy.Private().(*memo.GroupingPrivate)
-	neededCols := private.GroupingCols.Copy()
+	var neededCols opt.ColSet
+	if groupingSets, ok := groupBy.(*memo.GroupingSetsExpr); ok {
+		neededCols = groupingSets.InputCols.ToSet()
+	} else {
+		neededCols = groupBy.Private().(*memo.GroupingPrivate).GroupingCols.Copy()
+	}
 	aggs := *groupBy.Child(1).(*memo.AggregationsExpr)
 	for i := range aggs {
 		neededCols = memo.AddAggInputColumns(neededCols, aggs[i].Agg)
@@ -2030,6 +2230,33 @@ func (b *Builder) buildTopK(e *memo.TopKExpr) (_ execPlan, outputCols colOrdMap,
 	return ep, inputCols, nil
 }
 
+func (b *Builder) buildTextSearchTopK(
+	e *memo.TextSearchTopKExpr,
+) (_ execPlan, outputCols colOrdMap, err error) {
+	md := b.mem.Metadata()
+	tab := md.Table(e.Table)
+	idx := tab.Index(e.Index)
+	b.IndexesUsed.add(tab.ID(), idx.ID())
+
+	keyCols, outputCols := b.getColumns(e.Cols, e.Table)
+	var ep execPlan
+	ep.root, err = b.factory.ConstructTextSearchTopK(
+		tab,
+		idx,
+		keyCols,
+		e.Query.(*tree.DTSQuery),
+		e.K,
+		e.BM25,
+		e.AvgDocLength,
+		e.K1,
+		e.B,
+	)
+	if err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+	return ep, outputCols, nil
+}
+
 // buildLimitOffset builds a plan for a LimitOp or OffsetOp
 func (b *Builder) buildLimitOffset(e memo.RelExpr) (_ execPlan, outputCols colOrdMap, err error) {
 	input, inputCols, err := b.buildRelational(e.Child(0).(memo.RelExpr))
@@ -3408,6 +3635,8 @@ func (b *Builder) buildCall(c *memo.CallExpr) (_ execPlan, outputCols colOrdMap,
 		false, /* blockStart */
 		nil,   /* blockState */
 		nil,   /* cursorDeclaration */
+		false, /* resultBuffer */
+		nil,   /* returnNext */
 	)
 
 	var ep execPlan
@@ -3592,15 +3821,36 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 	filterIdxs := make([]int, len(w.Windows))
 	exprs := make([]*tree.FuncExpr, len(w.Windows))
 	windowVals := make([]tree.WindowDef, len(w.Windows))
+	var userDefined []*exec.UserDefinedAggregate
 
 	for i := range w.Windows {
 		item := &w.Windows[i]
 		fn := b.extractWindowFunction(item.Function)
-		name, overload := memo.FindWindowOverload(fn)
-		if !b.disableTelemetry {
-			telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+		var name string
+		var overload *tree.Overload
+		var props *tree.FunctionProperties
+		var typ *types.T
+		if uda, ok := fn.(*memo.UserDefinedAggExpr); ok {
+			// User-defined aggregates are not builtins, so they are referenced
+			// by name and computed with the routines of their definition. The
+			// routines can only be evaluated on the gateway.
+			name, typ = uda.Def.Name, uda.Def.Typ
+			props = &tree.FunctionProperties{DistsqlBlocklist: true}
+			if userDefined == nil {
+				userDefined = make([]*exec.UserDefinedAggregate, len(w.Windows))
+			}
+			var err error
+			if userDefined[i], err = b.buildUserDefinedAggregate(uda.Def); err != nil {
+				return execPlan{}, colOrdMap{}, err
+			}
+		} else {
+			name, overload = memo.FindWindowOverload(fn)
+			if !b.disableTelemetry {
+				telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+			}
+			props, _ = builtinsregistry.GetBuiltinProperties(name)
+			typ = overload.FixedReturnType()
 		}
-		props, _ := builtinsregistry.GetBuiltinProperties(name)
 
 		args := make([]tree.TypedExpr, fn.ChildCount())
 		argIdxs[i] = make([]exec.NodeColumnOrdinal, fn.ChildCount())
@@ -3643,8 +3893,10 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 			OrderBy:    orderingExprs,
 			Frame:      frame,
 		}
-		wrappedFn, err := b.wrapBuiltinFunction(name)
-		if err != nil {
+		var wrappedFn tree.ResolvableFunctionReference
+		if userDefined != nil && userDefined[i] != nil {
+			wrappedFn.FunctionReference = tree.NewUnresolvedName(name)
+		} else if wrappedFn, err = b.wrapBuiltinFunction(name); err != nil {
 			return execPlan{}, colOrdMap{}, err
 		}
 		exprs[i] = tree.NewTypedFuncExpr(
@@ -3653,7 +3905,7 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 			args,
 			builtFilter,
 			&windowVals[i],
-			overload.FixedReturnType(),
+			typ,
 			props,
 			overload,
 		)
@@ -3692,13 +3944,14 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 	}
 	var ep execPlan
 	ep.root, err = b.factory.ConstructWindow(input.root, exec.WindowInfo{
-		Cols:       resultCols,
-		Exprs:      exprs,
-		OutputIdxs: outputIdxs,
-		ArgIdxs:    argIdxs,
-		FilterIdxs: filterIdxs,
-		Partition:  partitionIdxs,
-		Ordering:   sqlOrdering,
+		Cols:        resultCols,
+		Exprs:       exprs,
+		OutputIdxs:  outputIdxs,
+		ArgIdxs:     argIdxs,
+		FilterIdxs:  filterIdxs,
+		Partition:   partitionIdxs,
+		Ordering:    sqlOrdering,
+		UserDefined: userDefined,
 	})
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
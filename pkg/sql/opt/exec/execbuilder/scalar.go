@@ -710,6 +710,8 @@ func (b *Builder) buildExistsSubquery(
 				false, /* blockStart */
 				nil,   /* blockState */
 				nil,   /* cursorDeclaration */
+				false, /* resultBuffer */
+				nil,   /* returnNext */
 			),
 			tree.DBoolFalse,
 		}, types.Bool), nil
@@ -831,6 +833,8 @@ func (b *Builder) buildSubquery(
 			false, /* blockStart */
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			false, /* resultBuffer */
+			nil,   /* returnNext */
 		), nil
 	}
 
@@ -891,6 +895,8 @@ func (b *Builder) buildSubquery(
 			false, /* blockStart */
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			false, /* resultBuffer */
+			nil,   /* returnNext */
 		), nil
 	}
 
@@ -968,6 +974,13 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 			"expected more than one body statement for a routine that opens a cursor",
 		))
 	}
+	// The same is true when the first body statement adds to the result of a
+	// set-returning routine.
+	if udf.Def.ReturnNext != nil && len(udf.Def.Body) <= 1 {
+		panic(errors.AssertionFailedf(
+			"expected more than one body statement for a RETURN NEXT or RETURN QUERY routine",
+		))
+	}
 
 	// Create a tree.RoutinePlanFn that can plan the statements in the UDF body.
 	// TODO(mgartner): Add support for WITH expressions inside UDF bodies.
@@ -1003,6 +1016,8 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		udf.Def.BlockStart,
 		blockState,
 		udf.Def.CursorDeclaration,
+		udf.Def.ResultBuffer,
+		udf.Def.ReturnNext,
 	), nil
 }
 
@@ -1059,6 +1074,8 @@ func (b *Builder) initRoutineExceptionHandler(
 			false, /* blockStart */
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			false, /* resultBuffer */
+			nil,   /* returnNext */
 		)
 	}
 	blockState.ExceptionHandler = exceptionHandler
@@ -378,6 +378,7 @@ var nodeNames = [...]string{
 	renderOp:               "render",
 	saveTableOp:            "save table",
 	scalarGroupByOp:        "group (scalar)",
+	groupingSetsOp:         "group (grouping sets)",
 	scanBufferOp:           "scan buffer",
 	scanOp:                 "", // This node does not have a fixed name.
 	sequenceSelectOp:       "sequence select",
@@ -389,6 +390,7 @@ var nodeNames = [...]string{
 	simpleProjectOp:        "project",
 	serializingProjectOp:   "project",
 	sortOp:                 "sort",
+	textSearchTopKOp:       "text search top-k",
 	topKOp:                 "top-k",
 	updateOp:               "update",
 	upsertOp:               "upsert",
@@ -698,6 +700,15 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			ob.Attr("k", a.K)
 		}
 
+	case textSearchTopKOp:
+		a := n.args.(*textSearchTopKArgs)
+		e.emitTableAndIndex("table", a.Table, a.Index, "" /* suffix */)
+		ob.Attr("query", a.Query.String())
+		if a.BM25 {
+			ob.Attr("ranking", "bm25")
+		}
+		ob.Attr("k", a.K)
+
 	case unionAllOp:
 		a := n.args.(*unionAllArgs)
 		if a.HardLimit > 0 {
@@ -733,6 +744,18 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			a.Aggregations, nil /* groupCols */, nil /* groupColOrdering */, true, /* isScalar */
 		)
 
+	case groupingSetsOp:
+		a := n.args.(*groupingSetsArgs)
+		inputCols := a.Input.Columns()
+		e.emitGroupByAttributes(
+			inputCols, a.Aggregations, a.GroupCols, nil /* groupColOrdering */, false, /* isScalar */
+		)
+		sets := make([]string, len(a.Sets))
+		for i, set := range a.Sets {
+			sets[i] = "(" + printColumnSet(inputCols, set) + ")"
+		}
+		ob.Attr("grouping sets", strings.Join(sets, " "))
+
 	case distinctOp:
 		a := n.args.(*distinctArgs)
 		inputCols := a.Input.Columns()
@@ -13,6 +13,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
@@ -738,6 +739,14 @@ func (u *unknownIndex) GeoConfig() geopb.Config {
 	return geopb.Config{}
 }
 
+func (u *unknownIndex) VectorConfig() catpb.VectorIndexDescriptor {
+	return catpb.VectorIndexDescriptor{}
+}
+
+func (u *unknownIndex) HasTermStatistics() bool {
+	return false
+}
+
 func (u *unknownIndex) Version() descpb.IndexDescriptorVersion {
 	return descpb.LatestIndexDescriptorVersion
 }
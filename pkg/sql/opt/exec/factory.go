@@ -214,6 +214,44 @@ type AggInfo struct {
 	// DistsqlBlocklist is set to true when this aggregate function cannot be
 	// evaluated in distributed fashion.
 	DistsqlBlocklist bool
+
+	// UserDefined is set if the aggregate was created with CREATE AGGREGATE. In
+	// that case FuncName is the name of the aggregate, and ArgCols contains a
+	// single column, which is a tuple if the aggregate has multiple arguments.
+	UserDefined *UserDefinedAggregate
+}
+
+// UserDefinedAggregate contains the information needed to evaluate an
+// aggregate created with CREATE AGGREGATE.
+type UserDefinedAggregate struct {
+	// StateType is the type of the aggregate's state value.
+	StateType *types.T
+
+	// InitCond is the initial value of the state, or nil if the initial state is
+	// NULL.
+	InitCond *string
+
+	// NumArgs is the number of arguments of the aggregate.
+	NumArgs int
+
+	// Transition is the state transition function, invoked with the current
+	// state and the arguments of each input row. Final is the optional final
+	// function, and Combine the optional combine function, which merges two
+	// states. The routines are built without arguments; the arguments are
+	// supplied during execution.
+	Transition, Final, Combine *tree.RoutineExpr
+
+	// Inlined is set if the component functions can also be evaluated as
+	// scalar expressions, in which case the aggregate can be distributed.
+	Inlined *InlinedUserDefinedAggregate
+}
+
+// InlinedUserDefinedAggregate contains the component functions of a
+// user-defined aggregate as scalar expressions. An expression refers to the
+// i-th argument of its function with an IndexedVar with index i. Final and
+// Combine are nil if the aggregate has no such function.
+type InlinedUserDefinedAggregate struct {
+	Transition, Final, Combine tree.TypedExpr
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -243,6 +281,12 @@ type WindowInfo struct {
 
 	// Ordering is the set of input columns to order on.
 	Ordering colinfo.ColumnOrdering
+
+	// UserDefined contains, in the same order as Exprs, the definition of each
+	// window function that is an aggregate created with CREATE AGGREGATE, and
+	// nil for all other window functions. It is nil if there are no such
+	// window functions.
+	UserDefined []*UserDefinedAggregate
 }
 
 // ExplainEnvData represents the data that's going to be displayed in EXPLAIN (env).
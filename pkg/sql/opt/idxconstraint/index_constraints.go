@@ -19,7 +19,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // Convenience aliases to avoid the constraint prefix everywhere.
@@ -127,6 +129,67 @@ func (c *indexConstraintCtx) makeStringPrefixSpan(
 	)
 }
 
+// makeINetContainmentSpans returns spans that constrain the inet or cidr
+// column <offset> to addresses contained within the network of the given
+// value. If strict is false, the network itself (i.e. an address with the same
+// mask) also matches.
+//
+// Since inet values are ordered by family, then mask and then address, there
+// is one span for each mask that a contained address can have:
+//
+//	x << '10.0.0.0/30' -> [/10.0.0.0/31 - /10.0.0.3/31] [/10.0.0.0/32 - /10.0.0.3/32]
+func (c *indexConstraintCtx) makeINetContainmentSpans(
+	offset int, datum tree.Datum, strict bool, out *constraint.Constraint,
+) {
+	ipAddr := tree.MustBeDIPAddr(datum).IPAddr
+	network := ipAddr.Network()
+	maxMask := byte(128)
+	if network.Family == ipaddr.IPv4family {
+		maxMask = 32
+	}
+	minMask := network.Mask
+	if strict {
+		minMask++
+	}
+	if minMask > maxMask {
+		c.contradiction(offset, out)
+		return
+	}
+	makeDatum := func(ipAddr ipaddr.IPAddr) tree.Datum {
+		d := tree.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr})
+		if c.colType(offset).Oid() == oid.T_cidr {
+			return tree.NewDCIDR(d)
+		}
+		return d
+	}
+	keyCtx := &c.keyCtx[offset]
+	descending := c.columns[offset].Descending()
+	var spans constraint.Spans
+	spans.Alloc(int(maxMask-minMask) + 1)
+	for m := minMask; m <= maxMask; m++ {
+		start := network
+		start.Mask = m
+		end := network.Broadcast()
+		end.Mask = m
+		startKey, endKey := constraint.MakeKey(makeDatum(start)), constraint.MakeKey(makeDatum(end))
+		var sp constraint.Span
+		if !descending {
+			sp.Init(startKey, includeBoundary, endKey, includeBoundary)
+		} else {
+			sp.Init(endKey, includeBoundary, startKey, includeBoundary)
+		}
+		spans.Append(&sp)
+	}
+	if descending {
+		// Reverse the order of the spans.
+		for i, j := 0, spans.Count()-1; i < j; i, j = i+1, j-1 {
+			si, sj := spans.Get(i), spans.Get(j)
+			*si, *sj = *sj, *si
+		}
+	}
+	out.Init(keyCtx, &spans)
+}
+
 // verifyType checks that the type of the index column <offset> matches the
 // given type. We disallow mixed-type comparisons because it would result in
 // incorrect encodings (#4313).
@@ -337,6 +400,14 @@ func (c *indexConstraintCtx) makeSpansForSingleColumnDatum(
 				return complete
 			}
 		}
+
+	case opt.LShiftOp:
+		// For inet and cidr, x << y is true if x is strictly contained within the
+		// network of y.
+		if datum.ResolvedType().Family() == types.INetFamily {
+			c.makeINetContainmentSpans(offset, datum, true /* strict */, out)
+			return true
+		}
 	}
 	c.unconstrained(offset, out)
 	return false
@@ -699,6 +770,25 @@ func (c *indexConstraintCtx) makeSpansForExpr(
 
 	case *memo.RangeExpr:
 		return c.makeSpansForExpr(offset, t.And, out)
+
+	case *memo.FunctionExpr:
+		// Support x <<= y and y >>= x, which are parsed as function calls.
+		if len(t.Args) == 2 {
+			var col, val opt.Expr
+			switch t.Name {
+			case "inet_contained_by_or_equals":
+				col, val = t.Args[0], t.Args[1]
+			case "inet_contains_or_equals":
+				col, val = t.Args[1], t.Args[0]
+			}
+			if col != nil && c.isIndexColumn(col, offset) && opt.IsConstValueOp(val) {
+				datum := memo.ExtractConstDatum(val)
+				if datum != tree.DNull && c.verifyType(offset, datum.ResolvedType()) {
+					c.makeINetContainmentSpans(offset, datum, false /* strict */, out)
+					return true
+				}
+			}
+		}
 	}
 
 	// Support e as (c = TRUE) if c is an indexed, boolean, computed expression
@@ -739,6 +829,13 @@ func (c *indexConstraintCtx) makeSpansForExpr(
 		}
 	}
 
+	// Support y >> x as x << y for inet and cidr.
+	if e.Op() == opt.RShiftOp && c.isIndexColumn(child1, offset) && opt.IsConstValueOp(child0) {
+		if datum := memo.ExtractConstDatum(child0); datum.ResolvedType().Family() == types.INetFamily {
+			return c.makeSpansForSingleColumnDatum(offset, opt.LShiftOp, datum, out)
+		}
+	}
+
 	// Last resort: for conditions like a > b, our column can appear on the right
 	// side. We can deduce a not-null constraint from such conditions.
 	if c.isNullable(offset) && c.isIndexColumn(child1, offset) &&
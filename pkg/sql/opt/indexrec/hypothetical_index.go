@@ -9,6 +9,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/geo/geoindex"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -223,6 +224,16 @@ func (hi *hypotheticalIndex) GeoConfig() geopb.Config {
 	return geopb.Config{}
 }
 
+// VectorConfig is part of the cat.Index interface.
+func (hi *hypotheticalIndex) VectorConfig() catpb.VectorIndexDescriptor {
+	return catpb.VectorIndexDescriptor{}
+}
+
+// HasTermStatistics is part of the cat.Index interface.
+func (hi *hypotheticalIndex) HasTermStatistics() bool {
+	return false
+}
+
 // Version is part of the cat.Index interface.
 func (hi *hypotheticalIndex) Version() descpb.IndexDescriptorVersion {
 	return descpb.LatestIndexDescriptorVersion
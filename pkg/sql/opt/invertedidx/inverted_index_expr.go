@@ -141,6 +141,13 @@ func TryFilterInvertedIndex(
 				index:           index,
 				computedColumns: computedColumns,
 			}
+		case types.RangeFamily, types.MultirangeFamily:
+			filterPlanner = &rangeFilterPlanner{
+				tabID:           tabID,
+				index:           index,
+				computedColumns: computedColumns,
+				typ:             typ,
+			}
 		default:
 			return nil, nil, nil, nil, false
 		}
@@ -472,11 +479,22 @@ func TryJoinInvertedIndex(
 			getSpanExpr: getSpanExprForGeometryIndex,
 		}
 	} else {
-		joinPlanner = &jsonOrArrayJoinPlanner{
-			factory:   factory,
-			tabID:     tabID,
-			index:     index,
-			inputCols: inputCols,
+		col := index.InvertedColumn().InvertedSourceColumnOrdinal()
+		switch factory.Metadata().Table(tabID).Column(col).DatumType().Family() {
+		case types.RangeFamily, types.MultirangeFamily:
+			joinPlanner = &rangeJoinPlanner{
+				factory:   factory,
+				tabID:     tabID,
+				index:     index,
+				inputCols: inputCols,
+			}
+		default:
+			joinPlanner = &jsonOrArrayJoinPlanner{
+				factory:   factory,
+				tabID:     tabID,
+				index:     index,
+				inputCols: inputCols,
+			}
 		}
 	}
 
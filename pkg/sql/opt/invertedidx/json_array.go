@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/errors"
 )
 
@@ -311,6 +312,14 @@ func (g *jsonOrArrayDatumsToInvertedExpr) Convert(
 			case treecmp.ContainedBy:
 				return getInvertedExprForJSONOrArrayIndexForContainedBy(ctx, g.evalCtx, d), nil
 
+			case treecmp.Overlaps:
+				invertedExpr := getInvertedExprForArrayIndexForOverlaps(ctx, g.evalCtx, d)
+				if _, ok := invertedExpr.(inverted.NonInvertedColExpression); ok {
+					// No values overlap an empty range, so there are no matches.
+					return nil, nil
+				}
+				return invertedExpr, nil
+
 			default:
 				return nil, fmt.Errorf("unsupported expression %v", t)
 			}
@@ -387,6 +396,10 @@ func (j *jsonOrArrayFilterPlanner) extractInvertedFilterConditionFromLeaf(
 		}
 	case *memo.OverlapsExpr:
 		invertedExpr = j.extractArrayOverlapsCondition(ctx, evalCtx, t.Left, t.Right)
+	case *memo.FunctionExpr:
+		if t.Name == "jsonb_path_exists_opr" {
+			invertedExpr = j.extractJSONPathExistsCondition(ctx, evalCtx, t.Args[0], t.Args[1])
+		}
 	}
 
 	if invertedExpr == nil {
@@ -546,6 +559,50 @@ func (j *jsonOrArrayFilterPlanner) extractJSONExistsCondition(
 	return inverted.NonInvertedColExpression{}
 }
 
+// extractJSONPathExistsCondition extracts an InvertedExpression representing
+// an inverted filter with the @? operator over the planner's inverted index,
+// based on the given left and right expression arguments. Returns an empty
+// InvertedExpression if no inverted filter could be extracted.
+//
+// Only paths made of member accessors and [*] followed by a filter with
+// equality conditions are supported, such as $.a[*] ? (@.b == 1 && @.c == 2).
+// Each equality condition is converted into the JSON objects that a matching
+// document must contain, for example {"a": [{"b": 1}]}. The expressions of the
+// conditions are intersected, so the resulting expression is never tight.
+func (j *jsonOrArrayFilterPlanner) extractJSONPathExistsCondition(
+	ctx context.Context, evalCtx *eval.Context, left, right opt.ScalarExpr,
+) inverted.Expression {
+	if !isIndexColumn(j.tabID, j.index, left, j.computedColumns) || !memo.CanExtractConstDatum(right) {
+		return inverted.NonInvertedColExpression{}
+	}
+	p, ok := memo.ExtractConstDatum(right).(*tree.DJsonpath)
+	if !ok {
+		return inverted.NonInvertedColExpression{}
+	}
+	var invertedExpr inverted.Expression
+	for _, objs := range buildJSONPathContainmentObjects(p.Jsonpath) {
+		var expr inverted.Expression
+		for i := range objs {
+			e := getInvertedExprForJSONOrArrayIndexForContaining(ctx, evalCtx, tree.NewDJSON(objs[i]))
+			if expr == nil {
+				expr = e
+			} else {
+				expr = inverted.Or(expr, e)
+			}
+		}
+		if invertedExpr == nil {
+			invertedExpr = expr
+		} else {
+			invertedExpr = inverted.And(invertedExpr, expr)
+		}
+	}
+	if invertedExpr == nil {
+		return inverted.NonInvertedColExpression{}
+	}
+	invertedExpr.SetNotTight()
+	return invertedExpr
+}
+
 // extractJSONEqCondition extracts an InvertedExpression representing an
 // inverted filter over the planner's inverted index, based on equality between
 // two scalar expressions. If an InvertedExpression cannot be generated from the
@@ -873,6 +930,182 @@ func buildFetchContainmentObjects(
 	return objs, nil
 }
 
+// maxJSONPathContainmentObjects is the maximum number of JSON objects that
+// are built for a single condition of a jsonpath filter. In lax mode, the
+// number of objects doubles with each accessor, since each of them may be
+// applied to the elements of an array.
+const maxJSONPathContainmentObjects = 64
+
+// jsonPathStep is a level of the JSON objects built by
+// buildJSONPathContainmentObjects. It is either an object key or an array.
+type jsonPathStep struct {
+	key   string
+	array bool
+	// optional is true for arrays that are unwrapped in lax mode, which may or
+	// may not be present in the document.
+	optional bool
+}
+
+// buildJSONPathContainmentObjects returns, for each equality condition of the
+// filter at the end of the given path, the JSON objects that a document must
+// contain, one of which must be contained by the document for the path to
+// return any item. For example, the path $.a ? (@.b == 1) results in:
+//
+//	{"a": {"b": 1}}, {"a": {"b": [1]}}, {"a": [{"b": 1}]}, {"a": [{"b": [1]}]}, ...
+//
+// since in lax mode arrays are automatically unwrapped. It returns nil if the
+// path is not supported.
+func buildJSONPathContainmentObjects(p jsonpath.Jsonpath) [][]json.JSON {
+	path, ok := p.Expr.(jsonpath.Path)
+	if !ok {
+		return nil
+	}
+	if _, ok := path[0].(jsonpath.Root); !ok {
+		return nil
+	}
+	lax := !p.Strict
+	var prefix []jsonPathStep
+	var filter jsonpath.Filter
+	for i, acc := range path[1:] {
+		switch t := acc.(type) {
+		case jsonpath.Key:
+			prefix = append(prefix,
+				jsonPathStep{array: true, optional: true}, jsonPathStep{key: string(t)},
+			)
+		case jsonpath.AnyArray:
+			prefix = append(prefix, jsonPathStep{array: true, optional: lax})
+		case jsonpath.Filter:
+			if i != len(path)-2 {
+				// Only a filter at the end of the path is supported.
+				return nil
+			}
+			prefix = append(prefix, jsonPathStep{array: true, optional: true})
+			filter = t
+		default:
+			return nil
+		}
+	}
+	if filter.Cond == nil {
+		return nil
+	}
+	var res [][]json.JSON
+	for _, cond := range collectJSONPathConjuncts(nil /* conds */, filter.Cond) {
+		steps, val, ok := extractJSONPathEquality(cond, prefix)
+		if !ok {
+			// The other conditions may still constrain the index.
+			continue
+		}
+		if !lax {
+			// In strict mode, arrays are never unwrapped automatically.
+			required := steps[:0]
+			for _, s := range steps {
+				if !s.optional {
+					required = append(required, s)
+				}
+			}
+			steps = required
+		}
+		if objs := buildJSONPathObjects(steps, val); objs != nil {
+			res = append(res, objs)
+		}
+	}
+	return res
+}
+
+// collectJSONPathConjuncts appends the conditions of a conjunction in a
+// jsonpath filter to conds.
+func collectJSONPathConjuncts(conds []jsonpath.Expr, e jsonpath.Expr) []jsonpath.Expr {
+	if b, ok := e.(*jsonpath.Binary); ok && b.Op == jsonpath.OpAnd {
+		conds = collectJSONPathConjuncts(conds, b.Left)
+		return collectJSONPathConjuncts(conds, b.Right)
+	}
+	return append(conds, e)
+}
+
+// extractJSONPathEquality returns the steps to the value compared by a filter
+// condition of the form @.a.b == <scalar>, which are appended to the steps of
+// the filter itself, and the scalar value.
+func extractJSONPathEquality(
+	cond jsonpath.Expr, prefix []jsonPathStep,
+) (steps []jsonPathStep, val json.JSON, ok bool) {
+	b, ok := cond.(*jsonpath.Binary)
+	if !ok || b.Op != jsonpath.OpEqual {
+		return nil, nil, false
+	}
+	operand, other := b.Left, b.Right
+	if _, ok := operand.(jsonpath.Scalar); ok {
+		operand, other = other, operand
+	}
+	scalar, ok := other.(jsonpath.Scalar)
+	if !ok {
+		return nil, nil, false
+	}
+	steps = append([]jsonPathStep(nil), prefix...)
+	switch t := operand.(type) {
+	case jsonpath.Current:
+	case jsonpath.Path:
+		if _, ok := t[0].(jsonpath.Current); !ok {
+			return nil, nil, false
+		}
+		for _, acc := range t[1:] {
+			key, ok := acc.(jsonpath.Key)
+			if !ok {
+				return nil, nil, false
+			}
+			steps = append(steps,
+				jsonPathStep{array: true, optional: true}, jsonPathStep{key: string(key)},
+			)
+		}
+	default:
+		return nil, nil, false
+	}
+	// The operands of comparisons are unwrapped in lax mode.
+	steps = append(steps, jsonPathStep{array: true, optional: true})
+	return steps, scalar.Value, true
+}
+
+// buildJSONPathObjects builds the JSON objects for all the combinations of
+// optional arrays in the given steps, with val as the inner-most value. It
+// returns nil if there are too many combinations.
+func buildJSONPathObjects(steps []jsonPathStep, val json.JSON) []json.JSON {
+	var optional int
+	for _, s := range steps {
+		if s.optional {
+			optional++
+		}
+	}
+	n := 1 << optional
+	if optional >= 31 || n > maxJSONPathContainmentObjects {
+		return nil
+	}
+	objs := make([]json.JSON, n)
+	for mask := range objs {
+		v := val
+		bit := 0
+		for i := len(steps) - 1; i >= 0; i-- {
+			s := steps[i]
+			if s.optional {
+				include := mask&(1<<bit) != 0
+				bit++
+				if !include {
+					continue
+				}
+			}
+			if s.array {
+				b := json.NewArrayBuilder(1)
+				b.Add(v)
+				v = b.Build()
+			} else {
+				b := json.NewObjectBuilder(1)
+				b.Add(s.key, v)
+				v = b.Build()
+			}
+		}
+		objs[mask] = v
+	}
+	return objs
+}
+
 // buildObject constructs a new JSON object of the form:
 //
 //	{<keyN>: ... {<key1>: {key0: <val>}}}
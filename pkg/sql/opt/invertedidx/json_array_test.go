@@ -861,6 +861,37 @@ func TestTryFilterJsonOrArrayIndex(t *testing.T) {
 			indexOrd: jsonOrd,
 			ok:       false,
 		},
+		{
+			// Jsonpath filters with equality conditions are supported. The
+			// original filter must be reapplied after the scan.
+			filters:          `j @? 'strict $ ? (@.a == 1)'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           true,
+			remainingFilters: `j @? 'strict $ ? (@.a == 1)'`,
+		},
+		{
+			// In lax mode, each accessor may be applied to the elements of an
+			// array, so the spans of all the combinations are unioned.
+			filters:          `j @? '$.a[*] ? (@.b == "x" && @.c > 1)'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           false,
+			remainingFilters: `j @? '$.a[*] ? (@.b == "x" && @.c > 1)'`,
+		},
+		{
+			// Jsonpath filters without equality conditions are not supported.
+			filters:  `j @? '$.a ? (@ > 1)'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
+		{
+			filters:  `j @? '$.a'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
 		{
 			// Overlaps is supported for arrays.
 			// Overlaps with a single element array produces
@@ -0,0 +1,182 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package invertedidx
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+type rangeJoinPlanner struct {
+	factory   *norm.Factory
+	tabID     opt.TableID
+	index     cat.Index
+	inputCols opt.ColSet
+}
+
+var _ invertedJoinPlanner = &rangeJoinPlanner{}
+
+// extractInvertedJoinConditionFromLeaf is part of the invertedJoinPlanner
+// interface. Only the && operator is supported, which allows inverted joins to
+// find the ranges which overlap the ranges of the input.
+func (r *rangeJoinPlanner) extractInvertedJoinConditionFromLeaf(
+	ctx context.Context, expr opt.ScalarExpr,
+) opt.ScalarExpr {
+	t, ok := expr.(*memo.OverlapsExpr)
+	if !ok {
+		return nil
+	}
+	var val opt.ScalarExpr
+	commuteArgs := false
+	if isIndexColumn(r.tabID, r.index, t.Left, nil /* computedColumns */) {
+		val = t.Right
+	} else if isIndexColumn(r.tabID, r.index, t.Right, nil /* computedColumns */) {
+		val, commuteArgs = t.Left, true
+	} else {
+		return nil
+	}
+	switch val.DataType().Family() {
+	case types.RangeFamily, types.MultirangeFamily:
+	default:
+		return nil
+	}
+	// The non-indexed argument should either come from the input or be a
+	// constant.
+	var p props.Shared
+	memo.BuildSharedProps(val, &p, r.factory.EvalContext())
+	if !p.OuterCols.Empty() {
+		if !p.OuterCols.SubsetOf(r.inputCols) {
+			return nil
+		}
+	} else if !memo.CanExtractConstDatum(val) {
+		return nil
+	}
+
+	// The && operator is commutative, so the arguments can be swapped to put
+	// the indexed column on the left.
+	if commuteArgs {
+		return r.factory.ConstructOverlaps(t.Right, t.Left)
+	}
+	return expr
+}
+
+type rangeFilterPlanner struct {
+	tabID           opt.TableID
+	index           cat.Index
+	computedColumns map[opt.ColumnID]opt.ScalarExpr
+	// typ is the range or multirange type of the indexed column.
+	typ *types.T
+}
+
+var _ invertedFilterPlanner = &rangeFilterPlanner{}
+
+// rangeOp is a predicate which can be accelerated by a range index.
+type rangeOp int
+
+const (
+	rangeOverlaps rangeOp = iota
+	rangeContains
+	rangeContainedBy
+)
+
+// extractInvertedFilterConditionFromLeaf implements the invertedFilterPlanner
+// interface.
+func (r *rangeFilterPlanner) extractInvertedFilterConditionFromLeaf(
+	ctx context.Context, evalCtx *eval.Context, expr opt.ScalarExpr,
+) (
+	invertedExpr inverted.Expression,
+	remainingFilters opt.ScalarExpr,
+	_ *invertedexpr.PreFiltererStateForInvertedFilterer,
+) {
+	switch t := expr.(type) {
+	case *memo.OverlapsExpr:
+		invertedExpr = r.extractRangeCondition(ctx, evalCtx, t.Left, t.Right, rangeOverlaps)
+	case *memo.ContainsExpr:
+		invertedExpr = r.extractRangeCondition(ctx, evalCtx, t.Left, t.Right, rangeContains)
+	case *memo.ContainedByExpr:
+		invertedExpr = r.extractRangeCondition(ctx, evalCtx, t.Left, t.Right, rangeContainedBy)
+	}
+	if invertedExpr == nil {
+		// An inverted expression could not be extracted.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+
+	// If the extracted inverted expression is not tight then remaining filters
+	// must be applied after the inverted index scan. This is always the case
+	// for range indexes.
+	if !invertedExpr.IsTight() {
+		remainingFilters = expr
+	}
+
+	// We do not currently support pre-filtering for range indexes, so the
+	// returned pre-filter state is nil.
+	return invertedExpr, remainingFilters, nil
+}
+
+// extractRangeCondition extracts an InvertedExpression representing an
+// inverted filter over the planner's inverted index, based on the given left
+// and right expression arguments. Returns nil if no inverted filter could be
+// extracted.
+func (r *rangeFilterPlanner) extractRangeCondition(
+	ctx context.Context, evalCtx *eval.Context, left, right opt.ScalarExpr, op rangeOp,
+) inverted.Expression {
+	var constantVal opt.ScalarExpr
+	if isIndexColumn(r.tabID, r.index, left, r.computedColumns) && memo.CanExtractConstDatum(right) {
+		constantVal = right
+	} else if isIndexColumn(r.tabID, r.index, right, r.computedColumns) && memo.CanExtractConstDatum(left) {
+		// Commute the predicate so that the index column is on the left, which
+		// swaps contains and contained by.
+		constantVal = left
+		switch op {
+		case rangeContains:
+			op = rangeContainedBy
+		case rangeContainedBy:
+			op = rangeContains
+		}
+	} else {
+		return nil
+	}
+	d := memo.ExtractConstDatum(constantVal)
+	if d == tree.DNull {
+		return nil
+	}
+	switch d.ResolvedType().Family() {
+	case types.RangeFamily, types.MultirangeFamily:
+	default:
+		// The constant is an element of the ranges, which is treated as a range
+		// containing only that element.
+		rangeTyp := r.typ
+		if rangeTyp.Family() == types.MultirangeFamily {
+			rangeTyp = rangeTyp.MultirangeContents()
+		}
+		d = tree.NewDRange(rangeTyp, d, d, true /* lowerInc */, true /* upperInc */)
+	}
+	var invertedExpr inverted.Expression
+	var err error
+	switch op {
+	case rangeOverlaps:
+		invertedExpr, err = rowenc.EncodeOverlapsInvertedIndexSpans(ctx, evalCtx, d)
+	case rangeContains:
+		invertedExpr, err = rowenc.EncodeContainingInvertedIndexSpans(ctx, evalCtx, d)
+	case rangeContainedBy:
+		invertedExpr, err = rowenc.EncodeContainedInvertedIndexSpans(ctx, evalCtx, d)
+	}
+	if err != nil {
+		panic(err)
+	}
+	return invertedExpr
+}
@@ -16,7 +16,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 type tsqueryFilterPlanner struct {
@@ -80,3 +82,133 @@ func (t *tsqueryFilterPlanner) extractInvertedFilterConditionFromLeaf(
 	// the returned pre-filter state is nil.
 	return invertedExpr, remainingFilters, nil
 }
+
+// MatchTSMatchesFilter returns the constant query of the given filters if they
+// consist of a single @@ condition between the source column of the inverted
+// index and a constant TSQUERY.
+func MatchTSMatchesFilter(
+	tabID opt.TableID, index cat.Index, filters memo.FiltersExpr,
+) (q tsearch.TSQuery, ok bool) {
+	if len(filters) != 1 {
+		return tsearch.TSQuery{}, false
+	}
+	e, ok := filters[0].Condition.(*memo.TSMatchesExpr)
+	if !ok {
+		return tsearch.TSQuery{}, false
+	}
+	var constantVal opt.ScalarExpr
+	if isIndexColumn(tabID, index, e.Left, nil /* computedColumns */) && memo.CanExtractConstDatum(e.Right) {
+		constantVal = e.Right
+	} else if isIndexColumn(tabID, index, e.Right, nil /* computedColumns */) && memo.CanExtractConstDatum(e.Left) {
+		constantVal = e.Left
+	} else {
+		return tsearch.TSQuery{}, false
+	}
+	d, ok := memo.ExtractConstDatum(constantVal).(*tree.DTSQuery)
+	if !ok {
+		return tsearch.TSQuery{}, false
+	}
+	return d.TSQuery, true
+}
+
+// MatchTFRank returns the query and the parameters of the given function if
+// it is a call to ts_rank_tf which ranks the source column of the inverted
+// index against a constant query, with constant parameters. The query must
+// only combine lexemes with the & and | operators, so that the top ranked rows
+// can be found with tsearch.SearchTopK.
+func MatchTFRank(
+	tabID opt.TableID, index cat.Index, fn *memo.FunctionExpr,
+) (q tsearch.TSQuery, params tsearch.TFRankParams, ok bool) {
+	if fn.Name != "ts_rank_tf" || len(fn.Args) < 2 || !isIndexColumn(tabID, index, fn.Args[0], nil /* computedColumns */) {
+		return tsearch.TSQuery{}, tsearch.TFRankParams{}, false
+	}
+	var args [5]float64
+	for i := 2; i < len(fn.Args); i++ {
+		if !memo.CanExtractConstDatum(fn.Args[i]) {
+			return tsearch.TSQuery{}, tsearch.TFRankParams{}, false
+		}
+		d, ok := memo.ExtractConstDatum(fn.Args[i]).(*tree.DFloat)
+		if !ok {
+			return tsearch.TSQuery{}, tsearch.TFRankParams{}, false
+		}
+		args[i] = float64(*d)
+	}
+	// The parameters must match the overloads of ts_rank_tf.
+	switch len(fn.Args) {
+	case 2:
+		params = tsearch.TFRankParams{K1: tsearch.DefaultTFRankParams.K1}
+	case 3:
+		params = tsearch.DefaultTFRankParams
+		params.AvgDocLength = args[2]
+	case 5:
+		params = tsearch.TFRankParams{AvgDocLength: args[2], K1: args[3], B: args[4]}
+	default:
+		return tsearch.TSQuery{}, tsearch.TFRankParams{}, false
+	}
+	if params.Validate() != nil || !memo.CanExtractConstDatum(fn.Args[1]) {
+		return tsearch.TSQuery{}, tsearch.TFRankParams{}, false
+	}
+	d, ok := memo.ExtractConstDatum(fn.Args[1]).(*tree.DTSQuery)
+	if !ok {
+		return tsearch.TSQuery{}, tsearch.TFRankParams{}, false
+	}
+	if _, ok := tsearch.TopKSearchTerms(d.TSQuery); !ok {
+		return tsearch.TSQuery{}, tsearch.TFRankParams{}, false
+	}
+	return d.TSQuery, params, true
+}
+
+// MatchBM25Rank returns the query and the parameters of the given function if
+// it is a call to ts_rank_bm25 which ranks the source column of the inverted
+// index against a constant query, with the collection statistics of the same
+// index, and with constant parameters. As with MatchTFRank, the query must only
+// combine lexemes with the & and | operators.
+func MatchBM25Rank(
+	tabID opt.TableID, table cat.Table, index cat.Index, fn *memo.FunctionExpr,
+) (q tsearch.TSQuery, params tsearch.BM25Params, ok bool) {
+	if fn.Name != "ts_rank_bm25" || len(fn.Args) < 4 || !isIndexColumn(tabID, index, fn.Args[0], nil /* computedColumns */) {
+		return tsearch.TSQuery{}, tsearch.BM25Params{}, false
+	}
+	for i := 1; i < len(fn.Args); i++ {
+		if !memo.CanExtractConstDatum(fn.Args[i]) {
+			return tsearch.TSQuery{}, tsearch.BM25Params{}, false
+		}
+	}
+	// The statistics must be read from the searched index.
+	tableOID, ok := tree.AsDOid(memo.ExtractConstDatum(fn.Args[2]))
+	if !ok || tableOID.Oid != oid.Oid(table.PostgresDescriptorID()) {
+		return tsearch.TSQuery{}, tsearch.BM25Params{}, false
+	}
+	indexName, ok := tree.AsDString(memo.ExtractConstDatum(fn.Args[3]))
+	if !ok || string(indexName) != string(index.Name()) {
+		return tsearch.TSQuery{}, tsearch.BM25Params{}, false
+	}
+	// The parameters must match the overloads of ts_rank_bm25.
+	switch len(fn.Args) {
+	case 4:
+		params = tsearch.DefaultBM25Params
+	case 6:
+		k1, ok := memo.ExtractConstDatum(fn.Args[4]).(*tree.DFloat)
+		if !ok {
+			return tsearch.TSQuery{}, tsearch.BM25Params{}, false
+		}
+		b, ok := memo.ExtractConstDatum(fn.Args[5]).(*tree.DFloat)
+		if !ok {
+			return tsearch.TSQuery{}, tsearch.BM25Params{}, false
+		}
+		params = tsearch.BM25Params{K1: float64(*k1), B: float64(*b)}
+	default:
+		return tsearch.TSQuery{}, tsearch.BM25Params{}, false
+	}
+	if params.Validate() != nil {
+		return tsearch.TSQuery{}, tsearch.BM25Params{}, false
+	}
+	d, ok := memo.ExtractConstDatum(fn.Args[1]).(*tree.DTSQuery)
+	if !ok {
+		return tsearch.TSQuery{}, tsearch.BM25Params{}, false
+	}
+	if _, ok := tsearch.TopKSearchTerms(d.TSQuery); !ok {
+		return tsearch.TSQuery{}, tsearch.BM25Params{}, false
+	}
+	return d.TSQuery, params, true
+}
@@ -201,6 +201,17 @@ func (m *Memo) CheckExpr(e opt.Expr) {
 			}
 		}
 
+	case *GroupingSetsExpr:
+		if len(t.InputCols) != len(t.OutputCols) {
+			panic(errors.AssertionFailedf("grouping sets with mismatched input and output columns"))
+		}
+		inputCols := t.InputCols.ToSet()
+		for _, set := range t.Sets {
+			if !set.SubsetOf(inputCols) {
+				panic(errors.AssertionFailedf("grouping set %s is not a subset of the grouping columns", set))
+			}
+		}
+
 	case *IndexJoinExpr:
 		if t.Cols.Empty() {
 			panic(errors.AssertionFailedf("index join with no columns"))
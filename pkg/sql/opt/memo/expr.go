@@ -755,6 +755,72 @@ type UDFDefinition struct {
 	// result of the routine. This invariant is enforced when the PLpgSQL routine
 	// is built. CursorDeclaration may be unset.
 	CursorDeclaration *tree.RoutineOpenCursor
+
+	// ResultBuffer is true for the root routine of a set-returning PL/pgSQL
+	// function. Its result is the set of rows added by RETURN NEXT and RETURN
+	// QUERY statements, rather than the result of the last body statement.
+	ResultBuffer bool
+
+	// ReturnNext contains the information needed to add the result of the
+	// *first* body statement to the result of the enclosing set-returning
+	// PL/pgSQL routine. Similar to CursorDeclaration, if it is set there will be
+	// at least two body statements. ReturnNext may be unset.
+	ReturnNext *tree.RoutineReturnNext
+}
+
+// UDADefinition stores details about a user-defined aggregate function and
+// its component functions, which are built as routines that are invoked with
+// the aggregate's state and arguments during execution. If the component
+// functions can be inlined, they are also built as scalar expressions, which
+// can be evaluated on any node.
+type UDADefinition struct {
+	// Name is the name of the aggregate.
+	Name string
+
+	// Typ is the return type of the aggregate.
+	Typ *types.T
+
+	// StateType is the type of the aggregate's state value.
+	StateType *types.T
+
+	// InitCond is the string representation of the initial state value. If it
+	// is nil, the initial state is NULL.
+	InitCond *string
+
+	// Transition is the state transition function. It is invoked with the
+	// current state and the aggregate's arguments, and returns the new state.
+	Transition *UDFDefinition
+
+	// Final computes the result of the aggregate from the final state. It is
+	// nil if the aggregate has no final function, in which case the result is
+	// the final state.
+	Final *UDFDefinition
+
+	// Combine merges two states into one. It is nil if the aggregate has no
+	// combine function.
+	Combine *UDFDefinition
+
+	// Inlined is set if the bodies of all the component functions are single
+	// scalar expressions of their parameters. See UDAInlinedFuncs.
+	Inlined *UDAInlinedFuncs
+}
+
+// UDAInlinedFuncs contains the component functions of a user-defined aggregate
+// inlined as scalar expressions. Each expression refers to the arguments of
+// its function through the function's Params columns, and contains no
+// subqueries or routine calls, so it can be serialized and evaluated on any
+// node. This allows the aggregate to be computed in a distributed fashion.
+type UDAInlinedFuncs struct {
+	// Transition is the inlined body of the transition function.
+	Transition opt.ScalarExpr
+
+	// Final is the inlined body of the final function, or nil if the aggregate
+	// has no final function.
+	Final opt.ScalarExpr
+
+	// Combine is the inlined body of the combine function, or nil if the
+	// aggregate has no combine function.
+	Combine opt.ScalarExpr
 }
 
 // ExceptionBlock contains the information needed to match and handle errors in
@@ -1437,6 +1503,36 @@ func (g *GroupingPrivate) GroupingOrderType(required *props.OrderingChoice) Grou
 	return PartialStreaming
 }
 
+// GroupingSets contains the grouping columns of each grouping set of a
+// GroupingSets operator.
+type GroupingSets []opt.ColSet
+
+// Equals returns true if the two lists of grouping sets are identical.
+func (s GroupingSets) Equals(other GroupingSets) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for i := range s {
+		if !s[i].Equals(other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// NumEmptySets returns the number of grouping sets which have no columns. The
+// GroupingSets operator produces exactly one row for each of them, even if its
+// input is empty.
+func (s GroupingSets) NumEmptySets() int {
+	n := 0
+	for i := range s {
+		if s[i].Empty() {
+			n++
+		}
+	}
+	return n
+}
+
 // IsConstantsAndPlaceholders returns true if all values in the list are
 // constant, placeholders or tuples containing constants, placeholders or other
 // such nested tuples.
@@ -277,7 +277,8 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 		*WindowExpr, *OpaqueRelExpr, *OpaqueMutationExpr, *OpaqueDDLExpr,
 		*AlterTableSplitExpr, *AlterTableUnsplitExpr, *AlterTableUnsplitAllExpr,
 		*AlterTableRelocateExpr, *AlterRangeRelocateExpr, *ControlJobsExpr, *CancelQueriesExpr,
-		*CancelSessionsExpr, *CreateViewExpr, *ExportExpr, *ShowCompletionsExpr:
+		*CancelSessionsExpr, *CreateViewExpr, *ExportExpr, *ShowCompletionsExpr,
+		*TextSearchTopKExpr:
 		fmt.Fprintf(f.Buffer, "%v", e.Op())
 		FormatPrivate(f, e.Private(), required)
 
@@ -387,12 +388,40 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			tp.Childf("error: \"%s\"", private.ErrorOnDup)
 		}
 
+	case *GroupingSetsExpr:
+		if !f.HasFlags(ExprFmtHideColumns) {
+			f.formatColList(tp, "grouping columns:", t.InputCols, t.Input.Relational().NotNullCols)
+			f.Buffer.Reset()
+			f.Buffer.WriteString("grouping sets:")
+			for _, set := range t.Sets {
+				f.Buffer.WriteString(" (")
+				for i, col := range set.ToList() {
+					if i > 0 {
+						f.Buffer.WriteString(", ")
+					}
+					f.formatColSimple("" /* label */, col)
+				}
+				f.Buffer.WriteByte(')')
+			}
+			tp.Child(f.Buffer.String())
+		}
+		if !f.HasFlags(ExprFmtHidePhysProps) && !t.Ordering.Any() {
+			tp.Childf("internal-ordering: %s", t.Ordering)
+		}
+
 	case *TopKExpr:
 		if !f.HasFlags(ExprFmtHidePhysProps) && !t.Ordering.Any() {
 			tp.Childf("internal-ordering: %s", t.Ordering)
 		}
 		tp.Childf("k: %d", t.K)
 
+	case *TextSearchTopKExpr:
+		tp.Childf("query: %s", t.Query)
+		if t.BM25 {
+			tp.Childf("ranking: bm25")
+		}
+		tp.Childf("k: %d", t.K)
+
 	case *LimitExpr:
 		if !f.HasFlags(ExprFmtHidePhysProps) && !t.Ordering.Any() {
 			tp.Childf("internal-ordering: %s", t.Ordering)
@@ -979,6 +1008,14 @@ func (f *ExprFmtCtx) formatScalarWithLabel(
 				if i == 0 && def.CursorDeclaration != nil {
 					// The first statement is opening a cursor.
 					stmtNode = n.Child("open-cursor")
+				} else if i == 0 && def.ReturnNext != nil {
+					// The first statement adds rows to the result of a set-returning
+					// routine.
+					if def.ReturnNext.Dynamic {
+						stmtNode = n.Child("return-query-execute")
+					} else {
+						stmtNode = n.Child("return-next")
+					}
 				}
 				prevTailCalls := f.tailCalls
 				if i == len(def.Body)-1 {
@@ -1799,6 +1836,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *InvertedJoinPrivate:
 		f.formatIndex(t.Table, t.Index, false /* reverse */)
 
+	case *TextSearchTopKPrivate:
+		f.formatIndex(t.Table, t.Index, false /* reverse */)
+
 	case *ValuesPrivate:
 		fmt.Fprintf(f.Buffer, " id=v%d", t.ID)
 
@@ -1812,6 +1852,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *FunctionPrivate:
 		fmt.Fprintf(f.Buffer, " %s", t.Name)
 
+	case *UserDefinedAggPrivate:
+		fmt.Fprintf(f.Buffer, " %s", t.Def.Name)
+
 	case *WindowsItemPrivate:
 		fmt.Fprintf(f.Buffer, " frame=%q", &t.Frame)
 
@@ -458,6 +458,15 @@ func (h *hasher) HashColList(val opt.ColList) {
 	h.hash = hash
 }
 
+func (h *hasher) HashGroupingSets(val GroupingSets) {
+	for i := range val {
+		// Hash the length of each set, so that the boundaries between the sets
+		// contribute to the hash.
+		h.HashInt(val[i].Len())
+		h.HashColSet(val[i])
+	}
+}
+
 func (h *hasher) HashOptionalColList(val opt.OptionalColList) {
 	hash := h.hash
 	for _, id := range val {
@@ -792,6 +801,10 @@ func (h *hasher) HashUDFDefinition(val *UDFDefinition) {
 	h.HashUint64(uint64(reflect.ValueOf(val).Pointer()))
 }
 
+func (h *hasher) HashUDADefinition(val *UDADefinition) {
+	h.HashUint64(uint64(reflect.ValueOf(val).Pointer()))
+}
+
 func (h *hasher) HashStoredProcTxnOp(val tree.StoredProcTxnOp) {
 	h.HashUint64(uint64(val))
 }
@@ -966,6 +979,10 @@ func (h *hasher) IsColListEqual(l, r opt.ColList) bool {
 	return l.Equals(r)
 }
 
+func (h *hasher) IsGroupingSetsEqual(l, r GroupingSets) bool {
+	return l.Equals(r)
+}
+
 func (h *hasher) IsOptionalColListEqual(l, r opt.OptionalColList) bool {
 	return l.Equals(r)
 }
@@ -1356,7 +1373,19 @@ func (h *hasher) IsUDFDefinitionEqual(l, r *UDFDefinition) bool {
 	} else if r.CursorDeclaration != nil {
 		return false
 	}
-	return h.IsColListEqual(l.Params, r.Params) && l.IsRecursive == r.IsRecursive
+	if l.ReturnNext != nil {
+		if r.ReturnNext == nil || l.ReturnNext.Dynamic != r.ReturnNext.Dynamic {
+			return false
+		}
+	} else if r.ReturnNext != nil {
+		return false
+	}
+	return h.IsColListEqual(l.Params, r.Params) && l.IsRecursive == r.IsRecursive &&
+		l.ResultBuffer == r.ResultBuffer
+}
+
+func (h *hasher) IsUDADefinitionEqual(l, r *UDADefinition) bool {
+	return l == r
 }
 
 func (h *hasher) IsStoredProcTxnOpEqual(l, r tree.StoredProcTxnOp) bool {
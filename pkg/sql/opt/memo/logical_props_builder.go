@@ -698,6 +698,86 @@ func (b *logicalPropsBuilder) buildGroupingExprProps(groupExpr RelExpr, rel *pro
 	}
 }
 
+func (b *logicalPropsBuilder) buildGroupingSetsProps(
+	groupingSets *GroupingSetsExpr, rel *props.Relational,
+) {
+	BuildSharedProps(groupingSets, &rel.Shared, b.evalCtx)
+
+	inputProps := groupingSets.Input.Relational()
+	aggs := groupingSets.Aggregations
+	private := &groupingSets.GroupingSetsPrivate
+
+	// Output Columns
+	// --------------
+	// Output columns are the union of the output grouping columns, the grouping
+	// set ID column and the columns from the aggregate projection list.
+	rel.OutputCols = private.OutputCols.ToSet()
+	rel.OutputCols.Add(private.GroupingIDCol)
+	for i := range aggs {
+		rel.OutputCols.Add(aggs[i].Col)
+	}
+
+	// Not Null Columns
+	// ----------------
+	// The grouping set ID is never NULL. A grouping column is only not null if
+	// it is part of every grouping set, in which case the input column is
+	// passed through.
+	rel.NotNullCols.Add(private.GroupingIDCol)
+	for i, outCol := range private.OutputCols {
+		if outCol == private.InputCols[i] && inputProps.NotNullCols.Contains(outCol) {
+			rel.NotNullCols.Add(outCol)
+		}
+	}
+	for i := range aggs {
+		// Unlike GroupBy, an empty grouping set can aggregate zero input rows,
+		// so only the aggregates that never return NULL are not null.
+		if opt.AggregateIsNeverNull(ExtractAggFunc(aggs[i].Agg).Op()) {
+			rel.NotNullCols.Add(aggs[i].Col)
+		}
+	}
+
+	// Outer Columns
+	// -------------
+	// Outer columns were derived by BuildSharedProps; remove any that are bound
+	// by input columns.
+	rel.OuterCols.DifferenceWith(inputProps.OutputCols)
+
+	// Functional Dependencies
+	// -----------------------
+	// The output grouping columns and the grouping set ID form a key, since
+	// every output row is a distinct group of one of the grouping sets. It is
+	// a lax key because a NULL grouping column can belong to a group of the
+	// input as well as to a grouping set which does not contain the column.
+	// Input dependencies are not propagated, since the grouping columns are
+	// nulled independently of each other.
+	groupingCols := private.OutputCols.ToSet()
+	groupingCols.Add(private.GroupingIDCol)
+	rel.FuncDeps.AddLaxKey(groupingCols, rel.OutputCols)
+
+	// Cardinality
+	// -----------
+	// Each empty grouping set returns exactly one row, even if the input is
+	// empty. Every other grouping set returns at most one row per input row,
+	// and at least one row if the input is not empty.
+	numEmptySets := uint32(private.Sets.NumEmptySets())
+	numNonEmptySets := uint32(len(private.Sets)) - numEmptySets
+	nonEmptySetsCard := props.Cardinality{
+		Max: inputProps.Cardinality.Product(
+			props.Cardinality{Min: numNonEmptySets, Max: numNonEmptySets},
+		).Max,
+	}
+	if !inputProps.Cardinality.CanBeZero() {
+		nonEmptySetsCard.Min = numNonEmptySets
+	}
+	rel.Cardinality = nonEmptySetsCard.Add(props.Cardinality{Min: numEmptySets, Max: numEmptySets})
+
+	// Statistics
+	// ----------
+	if !b.disableStats {
+		b.sb.buildGroupingSets(groupingSets, rel)
+	}
+}
+
 func (b *logicalPropsBuilder) buildUnionProps(union *UnionExpr, rel *props.Relational) {
 	b.buildSetProps(union, rel)
 }
@@ -1172,6 +1252,50 @@ func (b *logicalPropsBuilder) buildTopKProps(topK *TopKExpr, rel *props.Relation
 	}
 }
 
+func (b *logicalPropsBuilder) buildTextSearchTopKProps(
+	topK *TextSearchTopKExpr, rel *props.Relational,
+) {
+	md := topK.Memo().Metadata()
+	BuildSharedProps(topK, &rel.Shared, b.evalCtx)
+
+	// Output Columns
+	// --------------
+	// Output columns are stored in the definition.
+	rel.OutputCols = topK.Cols
+
+	// Not Null Columns
+	// ----------------
+	// Initialize not-NULL columns from the table schema.
+	rel.NotNullCols = makeTableNotNullCols(md, topK.Table).Copy()
+	rel.NotNullCols.IntersectionWith(rel.OutputCols)
+
+	// Outer Columns
+	// -------------
+	// TextSearchTopK operator never has outer columns.
+
+	// Functional Dependencies
+	// -----------------------
+	// The search returns each row of the table at most once, so the table FDs
+	// are upheld.
+	rel.FuncDeps.CopyFrom(MakeTableFuncDep(md, topK.Table))
+	rel.FuncDeps.MakeNotNull(rel.NotNullCols)
+	rel.FuncDeps.ProjectCols(rel.OutputCols)
+
+	// Cardinality
+	// -----------
+	// At most K rows are returned.
+	rel.Cardinality = props.AnyCardinality
+	if topK.K < math.MaxUint32 {
+		rel.Cardinality = rel.Cardinality.Limit(uint32(topK.K))
+	}
+
+	// Statistics
+	// ----------
+	if !b.disableStats {
+		b.sb.buildTextSearchTopK(topK, rel)
+	}
+}
+
 func (b *logicalPropsBuilder) buildLimitProps(limit *LimitExpr, rel *props.Relational) {
 	haveConstLimit := false
 	constLimit := int64(math.MaxUint32)
@@ -1757,6 +1881,14 @@ func BuildSharedProps(e opt.Expr, shared *props.Shared, evalCtx *eval.Context) {
 		shared.HasUDF = true
 		shared.VolatilitySet.Add(t.Def.Volatility)
 
+	case *UserDefinedAggExpr:
+		shared.HasUDF = true
+		for _, fn := range []*UDFDefinition{t.Def.Transition, t.Def.Final, t.Def.Combine} {
+			if fn != nil {
+				shared.VolatilitySet.Add(fn.Volatility)
+			}
+		}
+
 	default:
 		if opt.IsUnaryOp(e) {
 			inputType := e.Child(0).(opt.ScalarExpr).DataType()
@@ -1955,6 +2087,12 @@ func MakeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 			continue
 		}
 
+		if unique.Exclusion() {
+			// Exclusion constraints do not make their columns a key, since rows
+			// with equal values do not conflict unless the values overlap.
+			continue
+		}
+
 		// If any of the columns are nullable, add a lax key FD. Otherwise, add a
 		// strict key.
 		var keyCols opt.ColSet
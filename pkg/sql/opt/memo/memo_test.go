@@ -521,6 +521,12 @@ func TestMemoIsStale(t *testing.T) {
 	evalCtx.SessionData().OptimizerPushLimitIntoProjectFilteredScan = false
 	notStale()
 
+	// Stale vector_search_probes.
+	evalCtx.SessionData().VectorSearchProbes = 10
+	stale()
+	evalCtx.SessionData().VectorSearchProbes = 0
+	notStale()
+
 	// User no longer has access to view.
 	catalog.View(tree.NewTableNameWithSchema("t", catconstants.PublicSchemaName, "abcview")).Revoked = true
 	_, err = o.Memo().IsStale(ctx, &evalCtx, catalog)
@@ -292,6 +292,9 @@ func (sb *statisticsBuilder) availabilityFromInput(e RelExpr) bool {
 	case *ScanExpr:
 		return sb.makeTableStatistics(t.Table).Available
 
+	case *TextSearchTopKExpr:
+		return sb.makeTableStatistics(t.Table).Available
+
 	case *LookupJoinExpr:
 		ensureLookupJoinInputProps(t, sb)
 		return t.lookupProps.Statistics().Available && t.Input.Relational().Statistics().Available
@@ -465,12 +468,18 @@ func (sb *statisticsBuilder) colStat(colSet opt.ColSet, e RelExpr) *props.Column
 		opt.UpsertDistinctOnOp, opt.EnsureUpsertDistinctOnOp:
 		return sb.colStatGroupBy(colSet, e)
 
+	case opt.GroupingSetsOp:
+		return sb.colStatGroupingSets(colSet, e.(*GroupingSetsExpr))
+
 	case opt.LimitOp:
 		return sb.colStatLimit(colSet, e.(*LimitExpr))
 
 	case opt.TopKOp:
 		return sb.colStatTopK(colSet, e.(*TopKExpr))
 
+	case opt.TextSearchTopKOp:
+		return sb.colStatTextSearchTopK(colSet, e.(*TextSearchTopKExpr))
+
 	case opt.OffsetOp:
 		return sb.colStatOffset(colSet, e.(*OffsetExpr))
 
@@ -2102,6 +2111,95 @@ func (sb *statisticsBuilder) colStatGroupBy(
 	return colStat
 }
 
+// +---------------+
+// | Grouping Sets |
+// +---------------+
+
+func (sb *statisticsBuilder) buildGroupingSets(
+	groupingSets *GroupingSetsExpr, relProps *props.Relational,
+) {
+	s := relProps.Statistics()
+	if zeroCardinality := s.Init(relProps); zeroCardinality {
+		// Short cut if cardinality is 0.
+		return
+	}
+	s.Available = sb.availabilityFromInput(groupingSets)
+
+	inputStats := sb.statsFromChild(groupingSets, 0 /* childIdx */)
+	s.VirtualCols.UnionWith(inputStats.VirtualCols)
+
+	// The row count is the sum of the row counts of the grouping sets.
+	s.RowCount = 0
+	for i := range groupingSets.Sets {
+		s.RowCount += sb.groupingSetRowCount(groupingSets, i)
+	}
+
+	sb.finalizeFromCardinality(relProps)
+}
+
+// groupingSetRowCount estimates the number of rows produced by the i-th
+// grouping set of a GroupingSets operator.
+func (sb *statisticsBuilder) groupingSetRowCount(groupingSets *GroupingSetsExpr, i int) float64 {
+	set := groupingSets.Sets[i]
+	if set.Empty() {
+		// An empty grouping set always returns exactly one row.
+		return 1
+	}
+	inputStats := sb.statsFromChild(groupingSets, 0 /* childIdx */)
+	colStat := sb.colStatFromChild(set, groupingSets, 0 /* childIdx */)
+	return min(colStat.DistinctCount, inputStats.RowCount)
+}
+
+func (sb *statisticsBuilder) colStatGroupingSets(
+	colSet opt.ColSet, groupingSets *GroupingSetsExpr,
+) *props.ColumnStatistic {
+	relProps := groupingSets.Relational()
+	s := relProps.Statistics()
+	colStat, _ := s.ColStats.Add(colSet)
+
+	// Map the requested output grouping columns to the input columns.
+	var inputCols opt.ColSet
+	onlyGroupingCols := true
+	for col, ok := colSet.Next(0); ok; col, ok = colSet.Next(col + 1) {
+		idx, found := groupingSets.OutputCols.Find(col)
+		if !found {
+			onlyGroupingCols = false
+			break
+		}
+		inputCols.Add(groupingSets.InputCols[idx])
+	}
+
+	if !onlyGroupingCols {
+		// Some of the requested columns are aggregates or the grouping set ID.
+		// Estimate every output row to be distinct.
+		colStat.DistinctCount = s.RowCount
+		colStat.NullCount = 0
+	} else {
+		// The grouping sets which contain all of the columns produce the distinct
+		// values of the input columns, and the others produce NULLs.
+		inputColStat := sb.colStatFromChild(inputCols, groupingSets, 0 /* childIdx */)
+		colStat.DistinctCount = inputColStat.DistinctCount
+		colStat.NullCount = min(1, inputColStat.NullCount)
+		nulled := false
+		for i, set := range groupingSets.Sets {
+			if !inputCols.SubsetOf(set) {
+				colStat.NullCount += sb.groupingSetRowCount(groupingSets, i)
+				nulled = true
+			}
+		}
+		if nulled && inputColStat.NullCount == 0 {
+			// NULL is an additional distinct value.
+			colStat.DistinctCount++
+		}
+	}
+
+	if colSet.Intersects(relProps.NotNullCols) {
+		colStat.NullCount = 0
+	}
+	sb.finalizeFromRowCountAndDistinctCounts(colStat, s)
+	return colStat
+}
+
 // +--------+
 // | Set Op |
 // +--------+
@@ -2397,6 +2495,51 @@ func (sb *statisticsBuilder) colStatTopK(colSet opt.ColSet, topK *TopKExpr) *pro
 	return colStat
 }
 
+// +-------------------+
+// | Text Search Top K |
+// +-------------------+
+
+func (sb *statisticsBuilder) buildTextSearchTopK(
+	topK *TextSearchTopKExpr, relProps *props.Relational,
+) {
+	s := relProps.Statistics()
+	if zeroCardinality := s.Init(relProps); zeroCardinality {
+		// Short cut if cardinality is 0.
+		return
+	}
+	s.Available = sb.availabilityFromInput(topK)
+
+	// The search returns the K highest ranked rows matching the query. Assume
+	// that at least K rows match it.
+	tableStats := sb.makeTableStatistics(topK.Table)
+	s.RowCount = tableStats.RowCount
+	if tableStats.RowCount > 0 && topK.K > 0 {
+		s.RowCount = min(float64(topK.K), tableStats.RowCount)
+		s.Selectivity = props.MakeSelectivity(s.RowCount / tableStats.RowCount)
+	}
+
+	sb.finalizeFromCardinality(relProps)
+}
+
+func (sb *statisticsBuilder) colStatTextSearchTopK(
+	colSet opt.ColSet, topK *TextSearchTopKExpr,
+) *props.ColumnStatistic {
+	relProps := topK.Relational()
+	s := relProps.Statistics()
+
+	inputColStat := sb.colStatTable(topK.Table, colSet)
+	colStat := sb.copyColStat(colSet, s, inputColStat)
+
+	// Scale distinct count based on the selectivity of the search.
+	tableStats := sb.makeTableStatistics(topK.Table)
+	colStat.ApplySelectivity(s.Selectivity, tableStats.RowCount)
+	if colSet.Intersects(relProps.NotNullCols) {
+		colStat.NullCount = 0
+	}
+	sb.finalizeFromRowCountAndDistinctCounts(colStat, s)
+	return colStat
+}
+
 // +--------+
 // | Offset |
 // +--------+
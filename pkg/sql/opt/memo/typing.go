@@ -188,6 +188,7 @@ func init() {
 	typingFuncMap[opt.IfErrOp] = typeIfErr
 	typingFuncMap[opt.UDFCallOp] = typeUDFCall
 	typingFuncMap[opt.TxnControlOp] = typeTxnControl
+	typingFuncMap[opt.UserDefinedAggOp] = typeUserDefinedAgg
 
 	// Override default typeAsAggregate behavior for aggregate functions with
 	// a large number of possible overloads or where ReturnType depends on
@@ -387,6 +388,11 @@ func typeUDFCall(e opt.ScalarExpr) *types.T {
 	return e.(*UDFCallExpr).Def.Typ
 }
 
+// typeUserDefinedAgg returns the type of a UserDefinedAggExpr operator.
+func typeUserDefinedAgg(e opt.ScalarExpr) *types.T {
+	return e.(*UserDefinedAggExpr).Def.Typ
+}
+
 // typeTxnControl returns the type of a TxnControlExpr operator
 func typeTxnControl(e opt.ScalarExpr) *types.T {
 	return e.(*TxnControlExpr).Def.Typ
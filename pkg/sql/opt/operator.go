@@ -227,6 +227,9 @@ var AggregateOpReverseMap = map[Operator]string{
 	STUnionOp:                     "st_union",
 	STCollectOp:                   "st_collect",
 	STExtentOp:                    "st_extent",
+	STClusterWithinOp:             "st_clusterwithin",
+	STClusterIntersectingOp:       "st_clusterintersecting",
+	STPolygonizeOp:                "st_polygonize",
 	MergeAggregatedStmtMetadataOp: "merge_aggregated_stmt_metadata",
 	MergeStatsMetadataOp:          "merge_stats_metadata",
 	MergeStatementStatsOp:         "merge_statement_stats",
@@ -236,17 +239,19 @@ var AggregateOpReverseMap = map[Operator]string{
 // WindowOpReverseMap maps from an optimizer operator type to the name of a
 // window function.
 var WindowOpReverseMap = map[Operator]string{
-	RankOp:        "rank",
-	RowNumberOp:   "row_number",
-	DenseRankOp:   "dense_rank",
-	PercentRankOp: "percent_rank",
-	CumeDistOp:    "cume_dist",
-	NtileOp:       "ntile",
-	LagOp:         "lag",
-	LeadOp:        "lead",
-	FirstValueOp:  "first_value",
-	LastValueOp:   "last_value",
-	NthValueOp:    "nth_value",
+	RankOp:            "rank",
+	RowNumberOp:       "row_number",
+	DenseRankOp:       "dense_rank",
+	PercentRankOp:     "percent_rank",
+	CumeDistOp:        "cume_dist",
+	NtileOp:           "ntile",
+	LagOp:             "lag",
+	LeadOp:            "lead",
+	FirstValueOp:      "first_value",
+	LastValueOp:       "last_value",
+	NthValueOp:        "nth_value",
+	STClusterDBSCANOp: "st_clusterdbscan",
+	STClusterKMeansOp: "st_clusterkmeans",
 }
 
 // NegateOpMap maps from a comparison operator type to its negated operator
@@ -330,11 +335,13 @@ func AggregateIgnoresNulls(op Operator) bool {
 		VarPopOp, CovarPopOp, CovarSampOp, RegressionAvgXOp, RegressionAvgYOp,
 		RegressionInterceptOp, RegressionR2Op, RegressionSlopeOp, RegressionSXXOp,
 		RegressionSXYOp, RegressionSYYOp, RegressionCountOp, MergeStatsMetadataOp,
-		MergeStatementStatsOp, MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp:
+		MergeStatementStatsOp, MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp,
+		STClusterWithinOp, STClusterIntersectingOp, STPolygonizeOp:
 		return true
 
 	case ArrayAggOp, ArrayCatAggOp, ConcatAggOp, ConstAggOp, CountRowsOp,
-		FirstAggOp, JsonAggOp, JsonbAggOp, JsonObjectAggOp, JsonbObjectAggOp:
+		FirstAggOp, JsonAggOp, JsonbAggOp, JsonObjectAggOp, JsonbObjectAggOp,
+		UserDefinedAggOp:
 		return false
 
 	default:
@@ -357,10 +364,11 @@ func AggregateIsNullOnEmpty(op Operator) bool {
 		VarPopOp, CovarPopOp, CovarSampOp, RegressionAvgXOp, RegressionAvgYOp,
 		RegressionInterceptOp, RegressionR2Op, RegressionSlopeOp, RegressionSXXOp,
 		RegressionSXYOp, RegressionSYYOp, MergeStatsMetadataOp, MergeStatementStatsOp,
-		MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp:
+		MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp, STClusterWithinOp,
+		STClusterIntersectingOp, STPolygonizeOp:
 		return true
 
-	case CountOp, CountRowsOp, RegressionCountOp:
+	case CountOp, CountRowsOp, RegressionCountOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -386,11 +394,13 @@ func AggregateIsNeverNullOnNonNullInput(op Operator) bool {
 		JsonObjectAggOp, JsonbObjectAggOp, StdDevPopOp, STCollectOp, STUnionOp,
 		VarPopOp, CovarPopOp, RegressionAvgXOp, RegressionAvgYOp, RegressionSXXOp,
 		RegressionSXYOp, RegressionSYYOp, RegressionCountOp, MergeStatsMetadataOp,
-		MergeStatementStatsOp, MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp:
+		MergeStatementStatsOp, MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp,
+		STClusterIntersectingOp, STPolygonizeOp:
 		return true
 
 	case VarianceOp, StdDevOp, CorrOp, CovarSampOp, RegressionInterceptOp,
-		RegressionR2Op, RegressionSlopeOp, STExtentOp, STMakeLineOp:
+		RegressionR2Op, RegressionSlopeOp, STExtentOp, STMakeLineOp, UserDefinedAggOp,
+		STClusterWithinOp:
 		// These aggregations can return NULL even with non-null input values.
 		return false
 
@@ -442,7 +452,8 @@ func AggregatesCanMerge(inner, outer Operator) bool {
 		VarPopOp, CovarPopOp, CovarSampOp, RegressionAvgXOp, RegressionAvgYOp,
 		RegressionInterceptOp, RegressionR2Op, RegressionSlopeOp, RegressionSXXOp,
 		RegressionSXYOp, RegressionSYYOp, RegressionCountOp, MergeStatsMetadataOp,
-		MergeStatementStatsOp, MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp:
+		MergeStatementStatsOp, MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp,
+		UserDefinedAggOp, STClusterWithinOp, STClusterIntersectingOp, STPolygonizeOp:
 		return false
 
 	default:
@@ -468,7 +479,8 @@ func AggregateIgnoresDuplicates(op Operator) bool {
 		CovarSampOp, RegressionAvgXOp, RegressionAvgYOp, RegressionInterceptOp,
 		RegressionR2Op, RegressionSlopeOp, RegressionSXXOp, RegressionSXYOp,
 		RegressionSYYOp, RegressionCountOp, MergeStatsMetadataOp, MergeStatementStatsOp,
-		MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp:
+		MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp, UserDefinedAggOp,
+		STClusterWithinOp, STClusterIntersectingOp, STPolygonizeOp:
 		return false
 
 	default:
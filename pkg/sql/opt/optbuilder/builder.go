@@ -348,6 +348,7 @@ func (b *Builder) buildStmt(
 		switch stmt := stmt.(type) {
 		case *tree.Select, tree.SelectStatement:
 		case *tree.Insert, *tree.Update, *tree.Delete:
+		case *tree.DoBlock:
 		case *tree.Call:
 			activeVersion := b.evalCtx.Settings.Version.ActiveVersion(b.ctx)
 			if !activeVersion.IsActive(clusterversion.V24_1) {
@@ -395,6 +396,9 @@ func (b *Builder) buildStmt(
 	case *tree.Call:
 		return b.buildProcedure(stmt, inScope)
 
+	case *tree.DoBlock:
+		return b.buildDo(stmt, inScope)
+
 	case *tree.Explain:
 		return b.buildExplain(stmt, inScope)
 
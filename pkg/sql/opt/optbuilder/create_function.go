@@ -305,7 +305,8 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		// CREATE correctly.
 		funcReturnType = outParamType
 		cf.ReturnType = &tree.RoutineReturnType{
-			Type: outParamType,
+			Type:  outParamType,
+			SetOf: cf.ReturnType != nil && cf.ReturnType.SetOf,
 		}
 	} else if funcReturnType == nil {
 		if cf.IsProcedure {
@@ -406,13 +407,6 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			afterBuildStmt()
 		}
 	case tree.RoutineLangPLpgSQL:
-		if cf.ReturnType != nil && cf.ReturnType.SetOf {
-			panic(unimplemented.NewWithIssueDetail(105240,
-				"set-returning PL/pgSQL functions",
-				"set-returning PL/pgSQL functions are not yet supported",
-			))
-		}
-
 		// Parse the function body.
 		stmt, err := plpgsqlparser.Parse(funcBodyStr)
 		if err != nil {
@@ -462,7 +456,7 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
 			plBuilder := newPLpgSQLBuilder(
 				b, cf.Name.Object(), stmt.AST.Label, nil /* colRefs */, routineParams,
-				funcReturnType, cf.IsProcedure, buildSQL, nil, /* outScope */
+				funcReturnType, cf.IsProcedure, cf.ReturnType.SetOf, buildSQL, nil, /* outScope */
 			)
 			stmtScope = plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
 		})
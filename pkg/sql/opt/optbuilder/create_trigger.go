@@ -60,7 +60,11 @@ func (b *Builder) buildCreateTrigger(ct *tree.CreateTrigger, inScope *scope) (ou
 		panic(errors.AssertionFailedf("%s is not a function", funcExpr.Func.String()))
 	}
 	o := f.ResolvedOverload()
-	if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid, b.checkPrivilegeUser); err != nil {
+	if o.Type == tree.BuiltinRoutine {
+		// Builtin trigger functions don't have descriptors, so they are
+		// referenced by OID and don't need an EXECUTE privilege check.
+		ct.BuiltinFuncOID = o.Oid
+	} else if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid, b.checkPrivilegeUser); err != nil {
 		panic(err)
 	}
 
@@ -171,8 +175,10 @@ func (b *Builder) buildFunctionForTrigger(
 		// NOTE: Trigger functions never use SQL.
 		panic(errors.AssertionFailedf("SQL language not supported for triggers"))
 	}
-	// The trigger always references the trigger function.
-	b.schemaFunctionDeps.Add(int(o.Oid))
+	// The trigger always references a user-defined trigger function.
+	if o.Type != tree.BuiltinRoutine {
+		b.schemaFunctionDeps.Add(int(o.Oid))
+	}
 
 	// The trigger function can reference the NEW and OLD transition relations,
 	// aliased in the trigger definition.
@@ -234,7 +240,7 @@ func (b *Builder) buildFunctionForTrigger(
 	b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
 		plBuilder := newPLpgSQLBuilder(
 			b, ct.FuncName.String(), stmt.AST.Label, nil /* colRefs */, triggerFuncParams, tableTyp,
-			false /* isProcedure */, true /* buildSQL */, nil, /* outScope */
+			false /* isProcedure */, false /* setReturning */, true /* buildSQL */, nil, /* outScope */
 		)
 		funcScope = plBuilder.buildRootBlock(stmt.AST, funcScope, triggerFuncParams)
 	})
@@ -0,0 +1,124 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/plpgsql"
+	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// doBlockName is the name of the anonymous procedure that executes the code
+// block of a DO statement. It matches the name used by Postgres in error
+// contexts.
+const doBlockName = "inline_code_block"
+
+// buildDo builds a set of memo groups that represents a DO statement. The code
+// block is compiled in the same way as the body of a PL/pgSQL procedure, and is
+// then invoked as a one-off procedure with no parameters. No descriptor is
+// created for the procedure.
+func (b *Builder) buildDo(do *tree.DoBlock, inScope *scope) *scope {
+	// Disable memo reuse, since the code block is not tracked in the metadata
+	// and its dependencies cannot be checked for staleness.
+	b.DisableMemoReuse = true
+	outScope := inScope.push()
+
+	switch do.Language {
+	case "", tree.RoutineLangPLpgSQL:
+	case tree.RoutineLangSQL, tree.RoutineLangC:
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"language \"%s\" does not support inline code execution", do.Language,
+		))
+	default:
+		if _, err := funcinfo.FunctionLangToProto(do.Language); err != nil {
+			panic(err)
+		}
+	}
+	if err := plpgsql.CheckClusterSupportsPLpgSQL(b.evalCtx.Settings); err != nil {
+		panic(err)
+	}
+
+	// Parse the code block.
+	stmt, err := plpgsqlparser.Parse(do.Code)
+	if err != nil {
+		panic(err)
+	}
+
+	// Statements in the code block are built as the body of a routine. See
+	// buildRoutine.
+	defer func(trackSchemaDeps, insideUDF, insideDataSource, insideSQLRoutine bool) {
+		b.trackSchemaDeps = trackSchemaDeps
+		b.insideUDF = insideUDF
+		b.insideDataSource = insideDataSource
+		b.insideSQLRoutine = insideSQLRoutine
+	}(b.trackSchemaDeps, b.insideUDF, b.insideDataSource, b.insideSQLRoutine)
+	nested := b.insideUDF || b.insideFuncDef
+	b.trackSchemaDeps = false
+	b.insideUDF = true
+	b.insideDataSource = false
+	b.insideSQLRoutine = false
+
+	var expr memo.RelExpr
+	var physProps *physical.Required
+	build := func() {
+		bodyScope := b.allocScope()
+		plBuilder := newPLpgSQLBuilder(
+			b, doBlockName, stmt.AST.Label, nil /* colRefs */, nil, /* routineParams */
+			types.Void, true /* isProcedure */, false /* isSetReturning */, true, /* buildSQL */
+			outScope,
+		)
+		stmtScope := plBuilder.buildRootBlock(stmt.AST, bodyScope, nil /* routineParams */)
+
+		// The code block does not return a result, so only the first row of the
+		// last statement is needed. See finishBuildLastStmt.
+		physProps = stmtScope.makePhysicalProps()
+		b.buildLimit(&tree.Limit{Count: tree.NewDInt(1)}, b.allocScope(), stmtScope)
+		expr = stmtScope.expr
+		physProps.Ordering = props.OrderingChoice{}
+	}
+	if nested {
+		// Transaction control statements are not yet supported in nested
+		// routines.
+		b.withinNestedPLpgSQLCall(build)
+	} else {
+		build()
+	}
+
+	var bodyStmts []string
+	if b.verboseTracing {
+		bodyStmts = []string{stmt.String()}
+	}
+	routine := b.factory.ConstructUDFCall(
+		nil, /* args */
+		&memo.UDFCallPrivate{
+			Def: &memo.UDFDefinition{
+				Name:              doBlockName,
+				Typ:               types.Void,
+				Volatility:        volatility.Volatile,
+				CalledOnNullInput: true,
+				RoutineType:       tree.ProcedureRoutine,
+				RoutineLang:       tree.RoutineLangPLpgSQL,
+				Body:              []memo.RelExpr{expr},
+				BodyProps:         []*physical.Required{physProps},
+				BodyStmts:         bodyStmts,
+			},
+		},
+	)
+	routine = b.finishBuildScalar(nil /* texpr */, routine, inScope,
+		nil /* outScope */, nil /* outCol */)
+
+	// Build a call expression with no output columns.
+	outScope.expr = b.factory.ConstructCall(routine, &memo.CallPrivate{})
+	return outScope
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	checkDomainNotNullFnName    = "crdb_internal.check_domain_not_null"
+	checkDomainConstraintFnName = "crdb_internal.check_domain_constraint"
+)
+
+// buildDomainChecks wraps value, a scalar expression with the given domain
+// type, in calls to internal builtins which raise an error if the value
+// violates the NOT NULL or CHECK constraints of the domain. If typ is not a
+// domain, value is returned unchanged.
+//
+// Each CHECK constraint is built in a scope with a single column named
+// "value", references to which are then replaced with the value expression.
+func (b *Builder) buildDomainChecks(value opt.ScalarExpr, typ *types.T) opt.ScalarExpr {
+	if !typ.IsDomain() {
+		return value
+	}
+	data := typ.TypeMeta.DomainData
+	if data == nil {
+		panic(errors.AssertionFailedf("domain type %s is not hydrated", typ.SQLString()))
+	}
+	input := value
+	domainName := b.factory.ConstructConstVal(tree.NewDString(typ.Name()), types.String)
+	if data.NotNull {
+		value = b.constructDomainCheckFn(
+			checkDomainNotNullFnName, typ, memo.ScalarListExpr{value, domainName},
+		)
+	}
+	if len(data.Checks) == 0 {
+		return value
+	}
+
+	valueScope := b.allocScope()
+	valueCol := b.synthesizeColumn(
+		valueScope, scopeColName("value"), typ.DomainBaseType(), nil /* expr */, nil, /* scalar */
+	)
+	var replace norm.ReplaceFunc
+	replace = func(e opt.Expr) opt.Expr {
+		if v, ok := e.(*memo.VariableExpr); ok && v.Col == valueCol.id {
+			return input
+		}
+		return b.factory.Replace(e, replace)
+	}
+	for i := range data.Checks {
+		check := &data.Checks[i]
+		expr, err := parser.ParseExpr(check.Expr)
+		if err != nil {
+			panic(err)
+		}
+		texpr := valueScope.resolveAndRequireType(expr, types.Bool)
+		ok := replace(b.buildScalar(texpr, valueScope, nil, nil, nil)).(opt.ScalarExpr)
+		value = b.constructDomainCheckFn(
+			checkDomainConstraintFnName, typ, memo.ScalarListExpr{
+				value,
+				ok,
+				domainName,
+				b.factory.ConstructConstVal(tree.NewDString(check.Name), types.String),
+			},
+		)
+	}
+	return value
+}
+
+// constructDomainCheckFn constructs a call to one of the builtins which
+// enforce domain constraints.
+func (b *Builder) constructDomainCheckFn(
+	name string, typ *types.T, args memo.ScalarListExpr,
+) opt.ScalarExpr {
+	props, overloads := builtinsregistry.GetBuiltinProperties(name)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", name))
+	}
+	return b.factory.ConstructFunction(args, &memo.FunctionPrivate{
+		Name:       name,
+		Typ:        typ,
+		Properties: props,
+		Overload:   &overloads[0],
+	})
+}
@@ -95,8 +95,66 @@ type groupby struct {
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
 	buildingGroupingCols bool
+
+	// groupingSets is non-nil if the GROUP BY clause contains ROLLUP, CUBE or
+	// GROUPING SETS.
+	groupingSets *groupingSets
+}
+
+// groupingSets contains the information needed to build an aggregation over
+// multiple grouping sets, as specified by ROLLUP, CUBE or GROUPING SETS.
+//
+// Rather than building a separate aggregation for each grouping set, we build
+// a single GroupingSets operator, which aggregates each input row into a group
+// of every grouping set. For example:
+//
+//	SELECT a, b, sum(c) FROM t GROUP BY ROLLUP (a, b)
+//
+// is built as:
+//
+//	grouping-sets
+//	 ├── columns: a':5 b':6 grouping_id:7 sum:8
+//	 ├── grouping columns: a:1 b:2
+//	 ├── grouping sets: (a, b) (a) ()
+//	 ├── <pre-projection>
+//	 └── aggregations
+//	      └── sum [as=sum:8]
+//	           └── c:3
+//
+// The output grouping columns a' and b' are NULL for the rows of the grouping
+// sets that do not contain them. The grouping_id column identifies the
+// grouping set that each row belongs to, and is used to compute GROUPING(...)
+// expressions. This way the input is only read once, and is not replicated
+// once per grouping set.
+type groupingSets struct {
+	// inSets contains the input grouping columns of each grouping set. The
+	// columns refer to groupby.groupingCols().
+	inSets []opt.ColSet
+
+	// sets contains the output grouping columns of each grouping set, in the
+	// same order as inSets. The columns refer to outCols.
+	sets []opt.ColSet
+
+	// outCols contains the grouping columns produced by the aggregation, in the
+	// same order as groupby.groupingCols(). A grouping column that is not part
+	// of every grouping set must be NULL for the rows of the grouping sets that
+	// do not contain it, so it has a different column ID than the corresponding
+	// input grouping column.
+	outCols []scopeColumn
+
+	// idCol contains the ordinal of the grouping set (in sets) that each row
+	// belongs to.
+	idCol opt.ColumnID
 }
 
+// maxGroupingSets is the maximum number of grouping sets that a GROUP BY clause
+// can expand to. This matches the limit in Postgres.
+const maxGroupingSets = 4096
+
+// maxCubeElements is the maximum number of elements in a CUBE. This matches
+// the limit in Postgres.
+const maxCubeElements = 12
+
 // groupByStrSet is a set of stringified GROUP BY expressions that map to the
 // grouping column in an aggOutScope scope that projects that expression. It
 // is used to enforce scoping rules, since any non-aggregate, variable
@@ -245,6 +303,11 @@ func (a aggregateInfo) isOrderingSensitive() bool {
 	if a.isOrderedSetAggregate() {
 		return true
 	}
+	if isUserDefinedAggregate(a.FuncExpr) {
+		// The result of a user-defined aggregate can depend on the order in
+		// which its transition function sees the rows.
+		return true
+	}
 	switch a.def.Name {
 	case "array_agg", "array_cat_agg", "concat_agg", "string_agg", "json_agg",
 		"jsonb_agg", "json_object_agg", "jsonb_object_agg", "st_makeline",
@@ -287,6 +350,24 @@ func (b *Builder) needsAggregation(sel *tree.SelectClause, scope *scope) bool {
 func (b *Builder) constructGroupBy(
 	input memo.RelExpr, groupingColSet opt.ColSet, aggCols []scopeColumn, ordering opt.Ordering,
 ) memo.RelExpr {
+	aggs := b.constructAggregations(aggCols)
+
+	private := memo.GroupingPrivate{GroupingCols: groupingColSet}
+
+	// The ordering of the GROUP BY is inherited from the input. This ordering is
+	// only useful for intra-group ordering (for order-sensitive aggregations like
+	// ARRAY_AGG). So we add the grouping columns as optional columns.
+	private.Ordering.FromOrderingWithOptCols(ordering, groupingColSet)
+
+	if groupingColSet.Empty() {
+		return b.factory.ConstructScalarGroupBy(input, aggs, &private)
+	}
+	return b.factory.ConstructGroupBy(input, aggs, &private)
+}
+
+// constructAggregations constructs the aggregations of a grouping operator
+// from the aggregate columns of the aggOutScope.
+func (b *Builder) constructAggregations(aggCols []scopeColumn) memo.AggregationsExpr {
 	aggs := make(memo.AggregationsExpr, 0, len(aggCols))
 
 	// Deduplicate the columns; we don't need to produce the same aggregation
@@ -303,18 +384,7 @@ func (b *Builder) constructGroupBy(
 			colSet.Add(id)
 		}
 	}
-
-	private := memo.GroupingPrivate{GroupingCols: groupingColSet}
-
-	// The ordering of the GROUP BY is inherited from the input. This ordering is
-	// only useful for intra-group ordering (for order-sensitive aggregations like
-	// ARRAY_AGG). So we add the grouping columns as optional columns.
-	private.Ordering.FromOrderingWithOptCols(ordering, groupingColSet)
-
-	if groupingColSet.Empty() {
-		return b.factory.ConstructScalarGroupBy(input, aggs, &private)
-	}
-	return b.factory.ConstructGroupBy(input, aggs, &private)
+	return aggs
 }
 
 // buildGroupingColumns builds the grouping columns and adds them to the
@@ -330,7 +400,17 @@ func (b *Builder) buildGroupingColumns(sel *tree.SelectClause, projectionsScope,
 	b.buildGroupingList(sel.GroupBy, sel.Exprs, projectionsScope, fromScope)
 
 	// Copy the grouping columns to the aggOutScope.
-	g.aggOutScope.appendColumns(g.groupingCols())
+	if gs := g.groupingSets; gs != nil {
+		g.aggOutScope.appendColumns(gs.outCols)
+		g.aggOutScope.cols = append(g.aggOutScope.cols, scopeColumn{
+			name:       scopeColName("grouping_id"),
+			typ:        types.Int,
+			id:         gs.idCol,
+			visibility: inaccessible,
+		})
+	} else {
+		g.aggOutScope.appendColumns(g.groupingCols())
+	}
 }
 
 // buildAggregation builds the aggregation operators and constructs the
@@ -347,8 +427,11 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	}
 
 	// If there are any aggregates that are ordering sensitive, build the
-	// aggregations as window functions over each group.
-	if g.hasNonCommutativeAggregates() {
+	// aggregations as window functions over each group. This is not possible
+	// with grouping sets, since each input row belongs to a group of every
+	// grouping set. Instead, the GroupingSets operator requires the ordering of
+	// the aggregates of its input; see buildGroupingSetsOrdering.
+	if g.hasNonCommutativeAggregates() && g.groupingSets == nil {
 		return b.buildAggregationAsWindow(groupingColSet, having, fromScope)
 	}
 
@@ -380,7 +463,11 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		if agg.def.Overload.UserDefinedAggregate != nil {
+			aggCols[i].scalar = b.constructUserDefinedAggregate(agg.FuncExpr, &agg.def, args[0])
+		} else {
+			aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		}
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -417,7 +504,9 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 		}
 	}
 
-	if haveOrderingSensitiveAgg {
+	if g.groupingSets != nil && g.hasNonCommutativeAggregates() {
+		b.buildGroupingSetsOrdering(fromScope)
+	} else if haveOrderingSensitiveAgg {
 		g.aggInScope.copyOrdering(fromScope)
 	}
 
@@ -425,12 +514,16 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// aggregate arguments, as well as any additional order by columns.
 	b.constructProjectForScope(fromScope, g.aggInScope)
 
-	g.aggOutScope.expr = b.constructGroupBy(
-		g.aggInScope.expr,
-		groupingColSet,
-		aggCols,
-		g.aggInScope.ordering,
-	)
+	if g.groupingSets != nil {
+		g.aggOutScope.expr = b.constructGroupingSets(g, aggCols)
+	} else {
+		g.aggOutScope.expr = b.constructGroupBy(
+			g.aggInScope.expr,
+			groupingColSet,
+			aggCols,
+			g.aggInScope.ordering,
+		)
+	}
 
 	// Wrap with having filter if it exists.
 	if having != nil {
@@ -505,8 +598,12 @@ func (b *Builder) buildGroupingList(
 	// used in an aggregate function`. The builder cannot know whether there is
 	// a grouping error until the grouping columns are fully built.
 	g.buildingGroupingCols = true
-	for _, e := range groupBy {
-		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+	if hasGroupingSets(groupBy) {
+		b.buildGroupingSets(groupBy, selects, projectionsScope, fromScope)
+	} else {
+		for _, e := range groupBy {
+			b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		}
 	}
 	g.buildingGroupingCols = false
 }
@@ -531,9 +628,13 @@ func (b *Builder) buildGroupingList(
 // aggInScope       The scope that will contain the grouping expressions as well
 //
 //	as the aggregate function arguments.
+//
+// buildGrouping returns the set of grouping columns that the expression
+// refers to, including any that were already added by a previous GROUP BY
+// expression.
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
-) {
+) (cols opt.ColSet) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
 	alias := ""
@@ -631,7 +732,8 @@ func (b *Builder) buildGrouping(
 		// If a grouping column has already been added, don't add it again.
 		// GROUP BY a, a is semantically equivalent to GROUP BY a.
 		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+		if col, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			cols.Add(col.id)
 			continue
 		}
 
@@ -641,9 +743,322 @@ func (b *Builder) buildGrouping(
 		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
 		b.buildScalar(e, fromScope, aggInScope, col, nil)
 		fromScope.groupby.groupStrs[exprStr] = col
+		cols.Add(col.id)
+	}
+	return cols
+}
+
+// hasGroupingSets returns true if the GROUP BY clause contains ROLLUP, CUBE or
+// GROUPING SETS.
+func hasGroupingSets(groupBy tree.GroupBy) bool {
+	for _, e := range groupBy {
+		if _, ok := e.(*tree.GroupingSet); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// buildGroupingSets builds the grouping columns for a GROUP BY clause that
+// contains ROLLUP, CUBE or GROUPING SETS, and initializes groupby.groupingSets.
+// See the groupingSets comment for details on how the aggregation is built.
+//
+// The GROUP BY items are first expanded into a list of grouping sets, each of
+// which is a list of "elements" (expressions or tuples of expressions). The
+// grouping sets of the whole clause are the cross product of the grouping
+// sets of each item, following the SQL standard. For example:
+//
+//	GROUP BY a, ROLLUP (b, (c, d))
+//	=> GROUPING SETS ((a, b, (c, d)), (a, b), (a))
+func (b *Builder) buildGroupingSets(
+	groupBy tree.GroupBy, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) {
+	g := fromScope.groupby
+
+	// Compute the cross product of the grouping sets of each item.
+	sets := [][]tree.Expr{nil}
+	for _, e := range groupBy {
+		itemSets := expandGroupingSet(e)
+		if len(sets)*len(itemSets) > maxGroupingSets {
+			panic(errTooManyGroupingSets)
+		}
+		product := make([][]tree.Expr, 0, len(sets)*len(itemSets))
+		for _, set := range sets {
+			for _, itemSet := range itemSets {
+				combined := make([]tree.Expr, 0, len(set)+len(itemSet))
+				combined = append(combined, set...)
+				product = append(product, append(combined, itemSet...))
+			}
+		}
+		sets = product
+	}
+
+	// Build the grouping columns for each element of each grouping set.
+	inSets := make([]opt.ColSet, len(sets))
+	for i, set := range sets {
+		for _, e := range set {
+			inSets[i].UnionWith(b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope))
+		}
+	}
+
+	gs := &groupingSets{}
+	g.groupingSets = gs
+	md := b.factory.Metadata()
+	gs.idCol = md.AddColumn("grouping_id", types.Int)
+
+	// Determine the output grouping columns. Any grouping column which is not
+	// part of every grouping set needs a new column ID, since it will be NULL
+	// for some of the output rows.
+	inCols := g.groupingCols()
+	gs.outCols = make([]scopeColumn, len(inCols))
+	inToOut := make(map[opt.ColumnID]*scopeColumn, len(inCols))
+	for i := range inCols {
+		gs.outCols[i] = inCols[i]
+		gs.outCols[i].scalar = nil
+		for j := range inSets {
+			if !inSets[j].Contains(inCols[i].id) {
+				gs.outCols[i].id = md.AddColumn(inCols[i].name.MetadataName(), inCols[i].typ)
+				break
+			}
+		}
+		inToOut[inCols[i].id] = &gs.outCols[i]
+	}
+
+	// Remap the grouping sets and the GROUP BY expressions to the output
+	// grouping columns, so that SELECT, HAVING and ORDER BY expressions which
+	// refer to grouping expressions see the NULLs of the grouping sets that
+	// don't include them.
+	gs.inSets = inSets
+	gs.sets = make([]opt.ColSet, len(inSets))
+	for i := range inSets {
+		for col, ok := inSets[i].Next(0); ok; col, ok = inSets[i].Next(col + 1) {
+			gs.sets[i].Add(inToOut[col].id)
+		}
+	}
+	for exprStr, col := range g.groupStrs {
+		g.groupStrs[exprStr] = inToOut[col.id]
 	}
 }
 
+var errTooManyGroupingSets = pgerror.Newf(pgcode.StatementTooComplex,
+	"too many grouping sets present (maximum %d)", maxGroupingSets)
+
+// expandGroupingSet returns the grouping sets of a single GROUP BY item. Each
+// grouping set is returned as a list of elements, where each element is an
+// expression or a tuple of expressions.
+func expandGroupingSet(e tree.Expr) [][]tree.Expr {
+	gs, ok := e.(*tree.GroupingSet)
+	if !ok {
+		// A plain GROUP BY expression is a single grouping set.
+		return [][]tree.Expr{{e}}
+	}
+	switch gs.Kind {
+	case tree.RollupGroupingSet:
+		// ROLLUP (e1, e2, ..., en) is equivalent to
+		// GROUPING SETS ((e1, e2, ..., en), ..., (e1, e2), (e1), ()).
+		sets := make([][]tree.Expr, 0, len(gs.Exprs)+1)
+		for i := len(gs.Exprs); i >= 0; i-- {
+			sets = append(sets, gs.Exprs[:i:i])
+		}
+		return sets
+
+	case tree.CubeGroupingSet:
+		// CUBE (e1, e2, ..., en) is equivalent to the GROUPING SETS of every
+		// subset of {e1, e2, ..., en}.
+		if len(gs.Exprs) > maxCubeElements {
+			panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"CUBE is limited to %d elements", maxCubeElements))
+		}
+		n := len(gs.Exprs)
+		sets := make([][]tree.Expr, 0, 1<<n)
+		for mask := (1 << n) - 1; mask >= 0; mask-- {
+			var set []tree.Expr
+			for i := range gs.Exprs {
+				if mask&(1<<(n-1-i)) != 0 {
+					set = append(set, gs.Exprs[i])
+				}
+			}
+			sets = append(sets, set)
+		}
+		return sets
+
+	case tree.ExplicitGroupingSets:
+		// GROUPING SETS (...) is the concatenation of the grouping sets of each of
+		// its items. Nested ROLLUP, CUBE and GROUPING SETS are allowed.
+		var sets [][]tree.Expr
+		for _, item := range gs.Exprs {
+			sets = append(sets, expandGroupingSet(item)...)
+			if len(sets) > maxGroupingSets {
+				panic(errTooManyGroupingSets)
+			}
+		}
+		return sets
+
+	default:
+		panic(errors.AssertionFailedf("unknown grouping set kind %d", gs.Kind))
+	}
+}
+
+// buildGroupingSetsOrdering builds the intra-group ordering of an aggregation
+// over grouping sets with ordered aggregates, such as
+// array_agg(x ORDER BY y). The columns of the ordering are added to the
+// aggInScope, and the ordering is stored as the ordering of the aggInScope.
+//
+// Since the GroupingSets operator requires a single ordering of its input, the
+// ORDER BY of every ordered aggregate must be a prefix of the longest one, so
+// that sorting the input by the longest ORDER BY satisfies all of them.
+func (b *Builder) buildGroupingSetsOrdering(fromScope *scope) {
+	g := fromScope.groupby
+
+	// Find the longest ORDER BY.
+	longest := -1
+	for i := range g.aggs {
+		agg := &g.aggs[i]
+		if agg.isCommutative() {
+			continue
+		}
+		if longest == -1 || len(agg.OrderBy) > len(g.aggs[longest].OrderBy) {
+			longest = i
+		}
+	}
+	orderBy := g.aggs[longest].OrderBy
+	for i := range g.aggs {
+		agg := &g.aggs[i]
+		if !agg.isCommutative() && !orderByIsPrefix(agg.OrderBy, orderBy) {
+			panic(errors.WithHint(
+				pgerror.New(pgcode.FeatureNotSupported,
+					"ordered aggregates with different orderings are not supported with "+
+						"ROLLUP, CUBE or GROUPING SETS"),
+				"The ORDER BY of every ordered aggregate must be a prefix of the longest "+
+					"ORDER BY. Use a UNION ALL of GROUP BY queries instead.",
+			))
+		}
+	}
+
+	// The ordering columns are built in a separate scope, since the grouping
+	// columns must stay at the end of the aggInScope.
+	orderingScope := fromScope.push()
+	ordering := b.buildWindowOrdering(
+		orderBy, longest, g.aggs[longest].def.Name, fromScope, orderingScope,
+		false, /* isRangeModeWithOffsets */
+	)
+
+	n := len(g.aggInScope.cols) - len(g.groupStrs)
+	cols := make([]scopeColumn, 0, len(g.aggInScope.cols)+len(orderingScope.cols))
+	cols = append(cols, g.aggInScope.cols[:n]...)
+	cols = append(cols, orderingScope.cols...)
+	g.aggInScope.cols = append(cols, g.aggInScope.cols[n:]...)
+	g.aggInScope.ordering = ordering
+}
+
+// orderByIsPrefix returns true if the first ORDER BY clause is identical to a
+// prefix of the second one.
+func orderByIsPrefix(prefix, orderBy tree.OrderBy) bool {
+	if len(prefix) > len(orderBy) {
+		return false
+	}
+	for i := range prefix {
+		if !prefix[i].Equal(orderBy[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// constructGroupingSets constructs the GroupingSets operator that computes the
+// aggregations over every grouping set. See the groupingSets comment for
+// details.
+func (b *Builder) constructGroupingSets(g *groupby, aggCols []scopeColumn) memo.RelExpr {
+	gs := g.groupingSets
+	inCols := g.groupingCols()
+	private := memo.GroupingSetsPrivate{
+		InputCols:     make(opt.ColList, len(inCols)),
+		OutputCols:    make(opt.ColList, len(gs.outCols)),
+		Sets:          gs.inSets,
+		GroupingIDCol: gs.idCol,
+	}
+	for i := range inCols {
+		private.InputCols[i] = inCols[i].id
+		private.OutputCols[i] = gs.outCols[i].id
+	}
+
+	// The ordering is only used for intra-group ordering, for order-sensitive
+	// aggregations like ARRAY_AGG.
+	private.Ordering.FromOrdering(g.aggInScope.ordering)
+
+	return b.factory.ConstructGroupingSets(g.aggInScope.expr, b.constructAggregations(aggCols), &private)
+}
+
+// buildGroupingOperation builds a GROUPING(...) expression. Each of its
+// arguments must match a GROUP BY expression. Since the result only depends on
+// the grouping set that produced the row, it is built as a CASE expression on
+// the grouping set ID column.
+func (b *Builder) buildGroupingOperation(t *tree.GroupingOperation, inScope *scope) opt.ScalarExpr {
+	switch inScope.context {
+	case exprKindWhere, exprKindOn, exprKindLateralJoin:
+		panic(pgerror.Newf(pgcode.Grouping,
+			"grouping operations are not allowed in %s", inScope.context,
+		))
+	}
+	if len(t.Exprs) > maxGroupingArgs {
+		panic(errTooManyGroupingArgs)
+	}
+	g := inScope.groupby
+	if g == nil || inScope.inAgg || g.buildingGroupingCols {
+		panic(errInvalidGroupingArgs)
+	}
+	args := make([]opt.ColumnID, len(t.Exprs))
+	for i := range t.Exprs {
+		col, ok := g.groupStrs[symbolicExprStr(t.TypedExprAt(i))]
+		if !ok {
+			panic(errInvalidGroupingArgs)
+		}
+		args[i] = col.id
+	}
+
+	gs := g.groupingSets
+	if gs == nil {
+		// Without grouping sets, every argument is part of the only grouping set.
+		return b.factory.ConstructConstVal(tree.NewDInt(0), types.Int)
+	}
+
+	// Bit i of the result (counting from the last argument) is set if the
+	// corresponding argument is not part of the grouping set.
+	masks := make([]int, len(gs.sets))
+	for i := range gs.sets {
+		for j, col := range args {
+			if !gs.sets[i].Contains(col) {
+				masks[i] |= 1 << (len(args) - 1 - j)
+			}
+		}
+	}
+	last := len(masks) - 1
+	var whens memo.ScalarListExpr
+	for i := 0; i < last; i++ {
+		if masks[i] != masks[last] {
+			whens = append(whens, b.factory.ConstructWhen(
+				b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i)), types.Int),
+				b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(masks[i])), types.Int),
+			))
+		}
+	}
+	orElse := b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(masks[last])), types.Int)
+	if len(whens) == 0 {
+		return orElse
+	}
+	return b.factory.ConstructCase(b.factory.ConstructVariable(gs.idCol), whens, orElse)
+}
+
+var errInvalidGroupingArgs = pgerror.New(pgcode.Grouping,
+	"arguments to GROUPING must be grouping expressions of the associated query level")
+
+// maxGroupingArgs is the maximum number of arguments to GROUPING, each of which
+// is a bit of its 32-bit result. This matches the limit in Postgres.
+const maxGroupingArgs = 31
+
+var errTooManyGroupingArgs = pgerror.New(pgcode.TooManyArguments,
+	"GROUPING must have fewer than 32 arguments")
+
 // buildAggArg builds a scalar expression which is used as an input in some form
 // to an aggregate expression. The scopeColumn for the built expression will
 // be added to tempScope.
@@ -694,11 +1109,16 @@ func (b *Builder) buildAggregateFunction(
 ) *aggregateInfo {
 	tempScopeColsBefore := len(tempScope.cols)
 
+	exprs := f.Exprs
+	if isUserDefinedAggregate(f) {
+		exprs = userDefinedAggregateArgs(f)
+	}
+
 	info := aggregateInfo{
 		FuncExpr: f,
 		def:      *def,
 		distinct: (f.Type == tree.DistinctFuncType),
-		args:     make(memo.ScalarListExpr, len(f.Exprs)),
+		args:     make(memo.ScalarListExpr, len(exprs)),
 	}
 
 	// Temporarily set b.subquery to nil so we don't add outer columns to the
@@ -707,7 +1127,7 @@ func (b *Builder) buildAggregateFunction(
 	b.subquery = nil
 	defer func() { b.subquery = subq }()
 
-	for i, pexpr := range f.Exprs {
+	for i, pexpr := range exprs {
 		info.args[i] = b.buildAggArg(pexpr.(tree.TypedExpr), &info, tempScope, fromScope)
 	}
 
@@ -794,6 +1214,10 @@ func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.Scal
 		return b.factory.ConstructLastValue(args[0])
 	case "nth_value":
 		return b.factory.ConstructNthValue(args[0], args[1])
+	case "st_clusterdbscan":
+		return b.factory.ConstructSTClusterDBSCAN(args[0], args[1], args[2])
+	case "st_clusterkmeans":
+		return b.factory.ConstructSTClusterKMeans(args[0], args[1])
 	default:
 		return b.constructAggregate(name, args)
 	}
@@ -871,6 +1295,12 @@ func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.Sca
 		return b.factory.ConstructSTExtent(args[0])
 	case "st_union", "st_memunion":
 		return b.factory.ConstructSTUnion(args[0])
+	case "st_clusterwithin":
+		return b.factory.ConstructSTClusterWithin(args[0], args[1])
+	case "st_clusterintersecting":
+		return b.factory.ConstructSTClusterIntersecting(args[0])
+	case "st_polygonize":
+		return b.factory.ConstructSTPolygonize(args[0])
 	case "xor_agg":
 		return b.factory.ConstructXorAgg(args[0])
 	case "json_agg":
@@ -935,6 +1365,11 @@ func newGroupingError(name tree.Name) error {
 // In the unique index or unique without index cases, all key columns must be
 // marked as NOT NULL to allow the implicit grouping.
 func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bool {
+	if g.groupingSets != nil {
+		// A key column is not a grouping column of every grouping set, so it does
+		// not determine the other columns of the group.
+		return false
+	}
 	md := b.factory.Metadata()
 	colMeta := md.ColumnMeta(colID)
 	if colMeta.Table == 0 {
@@ -963,6 +1398,10 @@ func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bo
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for i := 0; i < tab.UniqueCount(); i++ {
 		uniqueConstraint := tab.Unique(i)
+		if uniqueConstraint.Exclusion() {
+			// Exclusion constraints do not guarantee uniqueness.
+			continue
+		}
 		var uniqueCols opt.ColSet
 		nullable := false
 		for j := 0; j < uniqueConstraint.ColumnCount(); j++ {
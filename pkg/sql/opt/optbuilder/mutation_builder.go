@@ -825,6 +825,9 @@ func (mb *mutationBuilder) addCheckConstraintCols(isUpdate bool) {
 
 		for i, n := 0, mb.tab.CheckCount(); i < n; i++ {
 			check := mb.tab.Check(i)
+			if mb.isConstraintDeferred(mb.tab.ID(), check.Name(), check.Deferrable()) {
+				continue
+			}
 			expr, err := parser.ParseExpr(check.Constraint())
 			if err != nil {
 				panic(err)
@@ -892,6 +895,23 @@ func (mb *mutationBuilder) addCheckConstraintCols(isUpdate bool) {
 	}
 }
 
+// isConstraintDeferred returns true if checking of the given constraint on the
+// table with the given ID is deferred until the end of the transaction, either
+// because it was declared INITIALLY DEFERRED or because of SET CONSTRAINTS. No
+// check is planned for a deferred constraint; instead, the catalog validates
+// it before the transaction commits.
+func (mb *mutationBuilder) isConstraintDeferred(
+	tabID cat.StableID, name string, deferrable bool,
+) bool {
+	if !deferrable {
+		return false
+	}
+	// Whether the constraint is checked depends on the state of the
+	// transaction, so the memo cannot be reused.
+	mb.b.DisableMemoReuse = true
+	return mb.b.catalog.IsConstraintDeferred(mb.b.ctx, tabID, name)
+}
+
 // getColumnFamilySet gets the set of column families represented in colOrdinals.
 func getColumnFamilySet(colOrdinals intsets.Fast, tab cat.Table) intsets.Fast {
 	families := intsets.Fast{}
@@ -1444,6 +1464,9 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 		variable := mb.b.factory.ConstructVariable(colID)
 		cast := mb.b.factory.ConstructAssignmentCast(variable, targetType)
 
+		// Enforce the constraints of the target type if it is a domain.
+		cast = mb.b.buildDomainChecks(cast, targetType)
+
 		// Lazily create the new scope.
 		if projectionScope == nil {
 			projectionScope = mb.outScope.replace()
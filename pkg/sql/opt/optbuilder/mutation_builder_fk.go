@@ -168,6 +168,9 @@ func (mb *mutationBuilder) buildFKChecksAndCascadesForDelete() {
 			})
 			continue
 		}
+		if h.fk.DeleteReferenceAction() == tree.NoAction && h.isDeferred() {
+			continue
+		}
 
 		withScanScope, _ := mb.buildCheckInputScan(checkInputScanFetchedVals, h.tabOrdinals, true /* isFK */)
 		mb.fkChecks = append(mb.fkChecks, h.buildDeletionCheck(withScanScope.expr, withScanScope.colList()))
@@ -311,6 +314,9 @@ func (mb *mutationBuilder) buildFKChecksForUpdate() {
 			})
 			continue
 		}
+		if h.fk.UpdateReferenceAction() == tree.NoAction && h.isDeferred() {
+			continue
+		}
 
 		// Construct an Except expression for the set difference between "old"
 		// FK values and "new" FK values.
@@ -429,6 +435,9 @@ func (mb *mutationBuilder) buildFKChecksForUpsert() {
 			})
 			continue
 		}
+		if h.fk.UpdateReferenceAction() == tree.NoAction && h.isDeferred() {
+			continue
+		}
 
 		// Construct an Except expression for the set difference between "old" FK
 		// values and "new" FK values. See buildFKChecksForUpdate for more details.
@@ -566,6 +575,10 @@ func (h *fkCheckHelper) initWithOutboundFK(mb *mutationBuilder, fkOrdinal int) b
 		// SIMPLE; FK check not needed.
 		return false
 	}
+	if h.isDeferred() {
+		// The FK check is postponed until the transaction commits.
+		return false
+	}
 
 	return true
 }
@@ -602,6 +615,14 @@ func (h *fkCheckHelper) initWithInboundFK(mb *mutationBuilder, fkOrdinal int) (o
 	return true
 }
 
+// isDeferred returns true if checking of the FK constraint is deferred until
+// the end of the transaction. Cascading actions are never deferred, and neither
+// are RESTRICT checks, so callers must only use this for insertion-side checks
+// and NO ACTION deletion-side checks.
+func (h *fkCheckHelper) isDeferred() bool {
+	return h.mb.isConstraintDeferred(h.fk.OriginTableID(), h.fk.Name(), h.fk.Deferrable())
+}
+
 // resolveTable resolves a table StableID. Returns nil if the table is in the
 // process of being added, in which case it is safe to ignore any FK
 // relation with the table.
@@ -13,6 +13,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -67,6 +68,9 @@ func (mb *mutationBuilder) buildUniqueChecksForInsert() {
 		if mb.uniqueConstraintIsArbiter(i) {
 			continue
 		}
+		if mb.isConstraintDeferred(mb.tab.ID(), u.Name(), u.Deferrable()) {
+			continue
+		}
 
 		if h.init(mb, i) {
 			uniqueChecksItem, fastPathUniqueChecksItem := h.buildInsertionCheck(buildFastPathCheck)
@@ -118,6 +122,9 @@ func (mb *mutationBuilder) buildUniqueChecksForUpdate() {
 			mb.uniqueWithTombstoneIndexes.Add(i)
 			continue
 		}
+		if mb.isConstraintDeferred(mb.tab.ID(), u.Name(), u.Deferrable()) {
+			continue
+		}
 		if h.init(mb, i) {
 			// The insertion check works for updates too since it simply checks that
 			// the unique columns in the newly inserted or updated rows do not match
@@ -169,6 +176,9 @@ func (mb *mutationBuilder) buildUniqueChecksForUpsert() {
 		if mb.uniqueConstraintIsArbiter(i) && !mb.uniqueColsUpdated(i) {
 			continue
 		}
+		if mb.isConstraintDeferred(mb.tab.ID(), u.Name(), u.Deferrable()) {
+			continue
+		}
 		if h.init(mb, i) {
 			// The insertion check works for upserts too since it simply checks that
 			// the unique columns in the newly inserted or updated rows do not match
@@ -248,9 +258,15 @@ type uniqueCheckHelper struct {
 	uniqueOrdinals intsets.Fast
 
 	// primaryKeyOrdinals includes the ordinals from any primary key columns
-	// that are not included in uniqueOrdinals.
+	// that are not included in uniqueOrdinals. For exclusion constraints, it
+	// includes the ordinals from any primary key columns that are not compared
+	// with "=".
 	primaryKeyOrdinals intsets.Fast
 
+	// overlapsOrdinal is the table ordinal of the column of an exclusion
+	// constraint which is compared with "&&", or -1 if there is no such column.
+	overlapsOrdinal int
+
 	// The scope and column ordinals of the scan that will serve as the right
 	// side of the semi join for the uniqueness checks.
 	scanScope    *scope
@@ -265,14 +281,24 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// This initialization pattern ensures that fields are not unwittingly
 	// reused. Field reuse must be explicit.
 	*h = uniqueCheckHelper{
-		mb:            mb,
-		unique:        mb.tab.Unique(uniqueOrdinal),
-		uniqueOrdinal: uniqueOrdinal,
+		mb:              mb,
+		unique:          mb.tab.Unique(uniqueOrdinal),
+		uniqueOrdinal:   uniqueOrdinal,
+		overlapsOrdinal: -1,
 	}
 
-	var uniqueOrds intsets.Fast
+	// eqOrds are the ordinals of the columns which must be equal for two rows to
+	// conflict. These are all the unique columns, except for the column of an
+	// exclusion constraint which is compared with "&&".
+	var uniqueOrds, eqOrds intsets.Fast
 	for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
-		uniqueOrds.Add(h.unique.ColumnOrdinal(mb.tab, i))
+		ord := h.unique.ColumnOrdinal(mb.tab, i)
+		uniqueOrds.Add(ord)
+		if h.unique.Exclusion() && h.unique.ExclusionOperator(i) == treecmp.Overlaps {
+			h.overlapsOrdinal = ord
+		} else {
+			eqOrds.Add(ord)
+		}
 	}
 
 	// Find the primary key columns that are not part of the unique constraint.
@@ -283,10 +309,10 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// exists a non-partial unique constraint with columns that are a subset of
 	// the partial unique constraint columns.
 	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
-	primaryOrds.DifferenceWith(uniqueOrds)
+	primaryOrds.DifferenceWith(eqOrds)
 	if primaryOrds.Empty() {
-		// The primary key columns are a subset of the unique columns; unique check
-		// not needed.
+		// The primary key columns are a subset of the columns which must be equal
+		// for two rows to conflict; unique check not needed.
 		return false
 	}
 
@@ -344,7 +370,7 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// presence of the unique index on (region, k) (i.e., the primary index) is
 	// sufficient to guarantee the uniqueness of k.
 	var uniqueCols opt.ColSet
-	h.uniqueOrdinals.ForEach(func(ord int) {
+	eqOrds.ForEach(func(ord int) {
 		colID := h.scanScope.cols[ord].id
 		uniqueCols.Add(colID)
 	})
@@ -482,6 +508,9 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	// Build the join filters:
 	//   (new_a = existing_a) AND (new_b = existing_b) AND ...
 	//
+	// The column of an exclusion constraint which is compared with "&&" is
+	// filtered with (new_c && existing_c) instead.
+	//
 	// Set the capacity to h.uniqueOrdinals.Len()+1 since we'll have an equality
 	// condition for each column in the unique constraint, plus one additional
 	// condition to prevent rows from matching themselves (see below). If the
@@ -494,12 +523,21 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	}
 	semiJoinFilters := make(memo.FiltersExpr, 0, numFilters)
 	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
-			f.ConstructEq(
-				f.ConstructVariable(uniqueCheckScope.cols[i].id),
-				f.ConstructVariable(h.scanScope.cols[i].id),
-			),
-		))
+		newVal := f.ConstructVariable(uniqueCheckScope.cols[i].id)
+		existingVal := f.ConstructVariable(h.scanScope.cols[i].id)
+		var cmp opt.ScalarExpr
+		if i == h.overlapsOrdinal {
+			if h.mb.md.ColumnMeta(h.scanScope.cols[i].id).Type.Family() == types.GeometryFamily {
+				// The && operator means "intersects" when used with geometry
+				// operands.
+				cmp = f.ConstructBBoxIntersects(newVal, existingVal)
+			} else {
+				cmp = f.ConstructOverlaps(newVal, existingVal)
+			}
+		} else {
+			cmp = f.ConstructEq(newVal, existingVal)
+		}
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(cmp))
 	}
 	// Find the ScanExpr which reads from the table this unique check applies to.
 	var uniqueFastPathCheck memo.RelExpr
@@ -518,8 +556,8 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 		scanExpr, foundScan = possibleScan.(*memo.ScanExpr)
 
 		// Fast path is disabled if this check is for a UNIQUE WITHOUT INDEX with a
-		// partial index predicate.
-		if foundScan && !isPartial {
+		// partial index predicate, or for an exclusion constraint.
+		if foundScan && !isPartial && !h.unique.Exclusion() {
 			scanFilters = h.buildFiltersForFastPathCheck(uniqueCheckExpr, uniqueCheckCols, scanExpr)
 		}
 	}
@@ -331,5 +331,8 @@ func ensureColumnOrderable(e tree.TypedExpr) {
 	switch typ.Family() {
 	case types.TSQueryFamily, types.TSVectorFamily, types.PGVectorFamily:
 		panic(unimplementedWithIssueDetailf(92165, "", "can't order by column type %s", typ.SQLString()))
+	case types.PointFamily, types.BoxFamily, types.LSegFamily, types.LineFamily,
+		types.PathFamily, types.PolygonFamily, types.CircleFamily:
+		panic(unimplementedWithIssueDetailf(21286, "", "can't order by column type %s", typ.SQLString()))
 	}
 }
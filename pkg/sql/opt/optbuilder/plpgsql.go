@@ -181,6 +181,11 @@ type plpgsqlBuilder struct {
 	// outParams is the set of OUT parameters for the routine.
 	outParams []ast.Variable
 
+	// loopCursors is a stack containing the cursors opened by the query FOR
+	// loops that enclose the statement currently being built. A loop's cursor
+	// is closed when control leaves the loop; see closeLoopCursors.
+	loopCursors []loopCursor
+
 	// outScope is the output scope for the routine. It is only used for
 	// transaction control statements in procedures, which need the presentation
 	// to construct a new procedure that will resume execution. Note that due to
@@ -190,6 +195,7 @@ type plpgsqlBuilder struct {
 
 	routineName  string
 	isProcedure  bool
+	setReturning bool
 	buildSQL     bool
 	identCounter int
 }
@@ -208,19 +214,21 @@ func newPLpgSQLBuilder(
 	routineParams []routineParam,
 	returnType *types.T,
 	isProcedure bool,
+	setReturning bool,
 	buildSQL bool,
 	outScope *scope,
 ) *plpgsqlBuilder {
 	const initialBlocksCap = 2
 	b := &plpgsqlBuilder{
-		ob:          ob,
-		colRefs:     colRefs,
-		returnType:  returnType,
-		blocks:      make([]plBlock, 0, initialBlocksCap),
-		routineName: routineName,
-		isProcedure: isProcedure,
-		buildSQL:    buildSQL,
-		outScope:    outScope,
+		ob:           ob,
+		colRefs:      colRefs,
+		returnType:   returnType,
+		blocks:       make([]plBlock, 0, initialBlocksCap),
+		routineName:  routineName,
+		isProcedure:  isProcedure,
+		setReturning: setReturning,
+		buildSQL:     buildSQL,
+		outScope:     outScope,
 	}
 	// Build the initial block for the routine parameters, which are considered
 	// PL/pgSQL variables.
@@ -383,7 +391,16 @@ func (b *plpgsqlBuilder) addDeclarations(decls []ast.Statement, block *plBlock,
 				panic(err)
 			}
 			if typ.Identical(types.AnyTuple) {
-				panic(recordVarErr)
+				// A RECORD variable has no concrete type until it is used as the
+				// target of a query FOR loop, so it cannot be initialized.
+				if dec.Expr != nil || dec.Constant {
+					panic(recordVarErr)
+				}
+				b.addVariable(dec.Var, typ)
+				s = b.addPLpgSQLAssignWithType(
+					s, dec.Var, typ, &tree.CastExpr{Expr: tree.DNull, Type: typ}, noIndirection,
+				)
+				continue
 			} else if typ.IsPolymorphicType() {
 				// NOTE: Postgres also returns an "unsupported" error.
 				panic(pgerror.Newf(pgcode.FeatureNotSupported,
@@ -499,11 +516,19 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			return b.buildBlock(t, s)
 
 		case *ast.Return:
-			// If the routine has OUT-parameters or a VOID return type, the RETURN
-			// statement must have no expression. Otherwise, the RETURN statement must
-			// have a non-empty expression.
+			// If the routine is set-returning, has OUT-parameters or has a VOID
+			// return type, the RETURN statement must have no expression. Otherwise,
+			// the RETURN statement must have a non-empty expression.
 			expr := t.Expr
-			if b.hasOutParam() {
+			if b.setReturning {
+				// The result of a set-returning routine is built by RETURN NEXT and
+				// RETURN QUERY statements, so RETURN only ends execution. The value
+				// returned here is discarded.
+				if expr != nil {
+					panic(returnWithSetParameterErr)
+				}
+				expr = tree.DNull
+			} else if b.hasOutParam() {
 				if expr != nil {
 					panic(returnWithOUTParameterErr)
 				}
@@ -521,6 +546,8 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			if expr == nil {
 				panic(emptyReturnErr)
 			}
+			// Control leaves any enclosing query FOR loops.
+			b.closeLoopCursors(s, 0 /* depth */)
 			// RETURN is handled by projecting a single column with the expression
 			// that is being returned.
 			returnScalar := b.buildSQLExpr(expr, b.returnType, s)
@@ -531,6 +558,60 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			b.ob.constructProjectForScope(s, returnScope)
 			return returnScope
 
+		case *ast.ReturnNext:
+			// RETURN NEXT adds a row to the result of a set-returning routine, and
+			// then continues execution with the next statement. It is handled by a
+			// continuation with two body statements: the first produces the row,
+			// which is added to the result during execution, and the second executes
+			// the remaining PL/pgSQL statements. The continuation is volatile to
+			// ensure that it is executed exactly once, in order.
+			if !b.setReturning {
+				panic(returnNextNonSetErr)
+			}
+			expr := t.Expr
+			if b.hasOutParam() {
+				if expr != nil {
+					panic(returnNextWithOUTParameterErr)
+				}
+				expr = b.makeReturnForOutParams()
+			} else if expr == nil {
+				panic(emptyReturnNextErr)
+			}
+			nextCon := b.makeContinuation("_stmt_return_next")
+			nextCon.def.Volatility = volatility.Volatile
+			nextCon.def.ReturnNext = &tree.RoutineReturnNext{}
+			nextScalar := b.buildSQLExpr(expr, b.returnType, nextCon.s)
+			nextColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return_next"))
+			nextScope := nextCon.s.push()
+			b.ob.synthesizeColumn(nextScope, nextColName, b.returnType, nil /* expr */, nextScalar)
+			b.ob.constructProjectForScope(nextCon.s, nextScope)
+			b.appendBodyStmt(&nextCon, nextScope)
+			b.appendPlpgSQLStmts(&nextCon, stmts[i+1:])
+			return b.callContinuation(&nextCon, s)
+
+		case *ast.ReturnQuery:
+			// RETURN QUERY adds the rows of a query to the result of a set-returning
+			// routine. Similar to RETURN NEXT, the query is built into the first body
+			// statement of a volatile continuation. For RETURN QUERY EXECUTE, the
+			// first body statement instead produces the query string and parameters,
+			// and the query is planned and executed when the continuation runs.
+			if !b.setReturning {
+				panic(returnQueryNonSetErr)
+			}
+			queryCon := b.makeContinuation("_stmt_return_query")
+			queryCon.def.Volatility = volatility.Volatile
+			var queryScope *scope
+			if t.DynamicQuery != nil {
+				queryCon.def.ReturnNext = &tree.RoutineReturnNext{Dynamic: true}
+				queryScope = b.buildDynamicQuery(queryCon.s, t.DynamicQuery, t.Params, "stmt_return_query")
+			} else {
+				queryCon.def.ReturnNext = &tree.RoutineReturnNext{}
+				queryScope = b.buildReturnQuery(queryCon.s, t.SqlStmt)
+			}
+			b.appendBodyStmt(&queryCon, queryScope)
+			b.appendPlpgSQLStmts(&queryCon, stmts[i+1:])
+			return b.callContinuation(&queryCon, s)
+
 		case *ast.Assignment:
 			// Assignment (:=) is handled by projecting a new column with the same
 			// name as the variable being assigned.
@@ -669,12 +750,23 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			case *ast.IntForLoopControl:
 				// FOR target IN [ REVERSE ] expr .. expr [ BY expr ] LOOP ...
 				return b.handleIntForLoop(s, t, c)
+			case *ast.QueryForLoopControl, *ast.DynamicQueryForLoopControl, *ast.CursorForLoopControl:
+				// FOR target IN query LOOP ...
+				// FOR target IN EXECUTE expr [ USING expr [, ...] ] LOOP ...
+				// FOR target IN cursor [ ( arg [, ...] ) ] LOOP ...
+				return b.handleQueryForLoop(s, t)
 			default:
-				panic(errors.WithDetail(unsupportedPLStmtErr,
-					"query and cursor FOR loops are not yet supported",
-				))
+				panic(errors.AssertionFailedf("unexpected FOR loop control: %T", c))
 			}
 
+		case *ast.ForEachArray:
+			// Build a continuation that will resume execution after the loop.
+			exitCon := b.makeContinuationWithTyp("loop_exit", t.Label, continuationLoopExit)
+			b.appendPlpgSQLStmts(&exitCon, stmts[i+1:])
+			b.pushContinuation(exitCon)
+			defer b.popContinuation()
+			return b.handleForEachArrayLoop(s, t)
+
 		case *ast.Exit:
 			if t.Condition != nil {
 				// EXIT with a condition is syntactic sugar for EXIT inside an IF stmt.
@@ -839,53 +931,34 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 
 		case *ast.Open:
 			// OPEN statements are used to create a CURSOR for the current session.
-			// This is handled by calling the plpgsql_open_cursor internal builtin
-			// function in a separate body statement that returns no results, similar
-			// to the RAISE implementation.
+			// This is handled by a routine that opens the cursor with the result of
+			// its first body statement; see buildOpenCursor.
 			if t.Scroll == tree.Scroll {
 				panic(scrollableCursorErr)
 			}
-			openCon := b.makeContinuation("_stmt_open")
-			openCon.def.Volatility = volatility.Volatile
-			_, source, _, err := openCon.s.FindSourceProvidingColumn(b.ob.ctx, t.CurVar)
-			if err != nil {
-				if pgerror.GetPGCode(err) == pgcode.UndefinedColumn {
-					panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", t.CurVar))
-				}
-				panic(err)
+			b.resolveCursorVar(s, t.CurVar)
+			query, decl := b.resolveOpenQuery(t)
+			open := &cursorOpen{
+				nameVar:      t.CurVar,
+				scroll:       t.Scroll,
+				query:        query,
+				dynamicQuery: t.DynamicQuery,
+				params:       t.Params,
+				decl:         decl,
+				args:         t.Args,
 			}
-			if !source.(*scopeColumn).typ.Identical(types.RefCursor) {
-				panic(pgerror.Newf(pgcode.DatatypeMismatch,
-					"variable \"%s\" must be of type cursor or refcursor", t.CurVar,
-				))
+			if !open.hasArgs() {
+				return b.buildOpenCursor(s, open, func(s, _ *scope) *scope {
+					return b.buildPLpgSQLStatements(stmts[i+1:], s)
+				})
 			}
-			// Initialize the routine with the information needed to pipe the first
-			// body statement into a cursor.
-			query := b.resolveOpenQuery(t)
-			fmtCtx := b.ob.evalCtx.FmtCtx(tree.FmtSimple)
-			fmtCtx.FormatNode(query)
-			openCon.def.CursorDeclaration = &tree.RoutineOpenCursor{
-				NameArgIdx: source.(*scopeColumn).getParamOrd(),
-				Scroll:     t.Scroll,
-				CursorSQL:  fmtCtx.CloseAndGetString(),
-			}
-			openScope := b.buildSQLStatement(query, openCon.s)
-			if openScope.expr.Relational().CanMutate {
-				// Cursors with mutations are invalid.
-				panic(cursorMutationErr)
-			}
-			b.appendBodyStmt(&openCon, openScope)
-			b.appendPlpgSQLStmts(&openCon, stmts[i+1:])
-
-			// Build a statement to generate a unique name for the cursor if one
-			// was not supplied. Add this to its own volatile routine to ensure that
-			// the name generation isn't reordered with other operations. Use the
-			// resulting projected column as input to the OPEN continuation.
-			nameCon := b.makeContinuation("_gen_cursor_name")
-			nameCon.def.Volatility = volatility.Volatile
-			nameScope := b.buildCursorNameGen(&nameCon, t.CurVar)
-			b.appendBodyStmt(&nameCon, b.callContinuation(&openCon, nameScope))
-			return b.callContinuation(&nameCon, s)
+			// The cursor arguments are only in scope for the cursor's query, so the
+			// statements following the OPEN are built into a separate continuation.
+			retCon := b.makeContinuation("_stmt_open_ret")
+			b.appendPlpgSQLStmts(&retCon, stmts[i+1:])
+			return b.buildOpenCursor(s, open, func(s, _ *scope) *scope {
+				return b.callContinuation(&retCon, s)
+			})
 
 		case *ast.Close:
 			// CLOSE statements close the cursor with the name supplied by a PLpgSQL
@@ -894,32 +967,8 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// that calls the builtin function.
 			closeCon := b.makeContinuation("_stmt_close")
 			closeCon.def.Volatility = volatility.Volatile
-			const closeFnName = "crdb_internal.plpgsql_close"
-			props, overloads := builtinsregistry.GetBuiltinProperties(closeFnName)
-			if len(overloads) != 1 {
-				panic(errors.AssertionFailedf("expected one overload for %s", closeFnName))
-			}
-			_, source, _, err := closeCon.s.FindSourceProvidingColumn(b.ob.ctx, t.CurVar)
-			if err != nil {
-				if pgerror.GetPGCode(err) == pgcode.UndefinedColumn {
-					panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", t.CurVar))
-				}
-				panic(err)
-			}
-			if !source.(*scopeColumn).typ.Identical(types.RefCursor) {
-				panic(pgerror.Newf(pgcode.DatatypeMismatch,
-					"variable \"%s\" must be of type cursor or refcursor", t.CurVar,
-				))
-			}
-			closeCall := b.ob.factory.ConstructFunction(
-				memo.ScalarListExpr{b.ob.factory.ConstructVariable(source.(*scopeColumn).id)},
-				&memo.FunctionPrivate{
-					Name:       closeFnName,
-					Typ:        types.Int,
-					Properties: props,
-					Overload:   &overloads[0],
-				},
-			)
+			curCol := b.resolveCursorVar(closeCon.s, t.CurVar)
+			closeCall := b.makePLpgSQLCloseFn(b.ob.factory.ConstructVariable(curCol.id))
 			closeColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_close"))
 			closeScope := closeCon.s.push()
 			b.ob.synthesizeColumn(closeScope, closeColName, types.Int, nil /* expr */, closeCall)
@@ -997,6 +1046,9 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			if !b.isProcedure {
 				panic(txnInUDFErr)
 			}
+			if len(b.loopCursors) > 0 {
+				panic(txnControlInQueryLoopErr)
+			}
 			name := "_stmt_commit"
 			txnOpType := tree.StoredProcTxnCommit
 			if t.Rollback {
@@ -1247,30 +1299,387 @@ func (b *plpgsqlBuilder) handleIntForLoop(
 	return b.callContinuation(&loopCon, s)
 }
 
-// resolveOpenQuery finds and validates the query that is bound to cursor for
-// the given OPEN statement.
-func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
-	// Search the blocks in reverse order to ensure that more recent declarations
-	// are encountered first.
-	var boundStmt tree.Statement
-	for i := len(b.blocks) - 1; i >= 0; i-- {
-		block := &b.blocks[i]
-		for name := range block.cursors {
-			if open.CurVar == name {
-				boundStmt = block.cursors[name].Query
+// handleQueryForLoop constructs the plan for a FOR loop over the rows of a
+// query, a dynamic query (FOR ... IN EXECUTE), or a bound cursor. The loop
+// opens a cursor for the query, which is eagerly evaluated when it is opened.
+// This means the loop iterates over a snapshot of the rows as of the start of
+// the loop; modifications made by the loop body are not visible to the query.
+//
+// The rows are fetched one at a time by the loop continuation, which assigns
+// them to the target variables and then executes the loop body. The cursor is
+// closed when control leaves the loop, whether through an EXIT, a RETURN, or
+// by running out of rows. If an error is caught by an exception handler, the
+// cursor is closed along with any other cursors opened within the block.
+func (b *plpgsqlBuilder) handleQueryForLoop(s *scope, forLoop *ast.ForLoop) *scope {
+	// Build an implicit block for the loop, which holds the hidden variable with
+	// the name of the cursor, as well as the parameters of a bound cursor.
+	b.pushNewBlock(&ast.Block{Label: forLoop.Label})
+	defer b.popBlock()
+	open := &cursorOpen{scroll: tree.UnspecifiedScroll}
+	isRecord := false
+	switch c := forLoop.Control.(type) {
+	case *ast.QueryForLoopControl:
+		b.checkCursorQuery(c.Query)
+		open.query = c.Query
+	case *ast.DynamicQueryForLoopControl:
+		open.dynamicQuery, open.params = c.Query, c.Params
+	case *ast.CursorForLoopControl:
+		open.decl = b.findBoundCursor(c.CursorVar)
+		if open.decl == nil {
+			panic(unboundCursorForLoopErr)
+		}
+		if len(forLoop.Target) != 1 {
+			panic(cursorForLoopTargetErr)
+		}
+		open.nameVar, open.args = c.CursorVar, c.Args
+		open.query = open.decl.Query
+		b.checkCursorQuery(open.query)
+		// The target of a cursor FOR loop is implicitly declared as a RECORD
+		// variable, which is local to the loop.
+		isRecord = true
+	}
+	if open.nameVar == "" {
+		open.hiddenNameVar = b.makeIdentifier("_loop_cursor")
+		b.addHiddenVariable(open.hiddenNameVar, types.RefCursor)
+		s = b.assignToHiddenVariable(s, open.hiddenNameVar,
+			&tree.CastExpr{Expr: tree.DNull, Type: types.RefCursor},
+		)
+	}
+
+	// Determine the type of the rows that are assigned to the target variables.
+	// A single RECORD variable takes on the type of the query's rows, which is
+	// only known once the query has been built.
+	var rowType *types.T
+	var recordBlock *plBlock
+	isComposite := false
+	if !isRecord {
+		targetTypes := make([]*types.T, len(forLoop.Target))
+		for i, name := range forLoop.Target {
+			block, typ := b.lookupVariable(name)
+			if block == nil {
+				panic(queryForLoopTargetErr)
+			}
+			if typ.Identical(types.AnyTuple) {
+				if len(forLoop.Target) != 1 {
+					panic(queryForLoopTargetErr)
+				}
+				if open.dynamicQuery != nil {
+					panic(dynamicRecordLoopErr)
+				}
+				isRecord, recordBlock = true, block
 				break
 			}
+			targetTypes[i] = typ
+		}
+		if !isRecord {
+			if len(targetTypes) == 1 && targetTypes[0].Family() == types.TupleFamily {
+				// A single composite-typed variable is assigned the whole row.
+				rowType, isComposite = targetTypes[0], true
+			} else {
+				rowType = types.MakeTuple(targetTypes)
+			}
+		}
+	}
+	b.checkDuplicateTargets(forLoop.Target, "FOR")
+
+	buildLoop := func(s, queryScope *scope) *scope {
+		target := forLoop.Target
+		if isRecord {
+			contents := make([]*types.T, 0, len(queryScope.cols))
+			labels := make([]string, 0, len(queryScope.cols))
+			for i := range queryScope.cols {
+				col := &queryScope.cols[i]
+				if col.visibility != visible {
+					continue
+				}
+				contents = append(contents, col.typ)
+				labels = append(labels, string(col.name.ReferenceName()))
+			}
+			rowType = types.MakeLabeledTuple(contents, labels)
+			if recordBlock == nil {
+				b.addVariable(target[0], rowType)
+			} else {
+				// The RECORD variable takes on the type of the rows for the duration of
+				// the loop.
+				recordBlock.varTypes[target[0]] = rowType
+				defer func() { recordBlock.varTypes[target[0]] = types.AnyTuple }()
+			}
+			s = b.addPLpgSQLAssign(s, target[0],
+				&tree.CastExpr{Expr: tree.DNull, Type: rowType}, noIndirection,
+			)
+		}
+		b.loopCursors = append(b.loopCursors, loopCursor{
+			hiddenVar: open.hiddenNameVar, curVar: open.nameVar,
+		})
+		defer func() { b.loopCursors = b.loopCursors[:len(b.loopCursors)-1] }()
+
+		// The loop is implemented by a single recursive continuation, which
+		// fetches the next row from the cursor. If a row is found, it is assigned
+		// to the targets, and the loop body is executed. Otherwise, the loop
+		// exits.
+		loopCon := b.makeContinuationWithTyp("stmt_loop", forLoop.Label, continuationLoopContinue)
+		loopCon.def.IsRecursive = true
+		loopCon.def.Volatility = volatility.Volatile
+		b.pushContinuation(loopCon)
+		fetchScope := b.buildFetchNext(
+			loopCon.s.push(), b.findLoopCursorCol(loopCon.s, b.loopCursors[len(b.loopCursors)-1]), rowType,
+		)
+		fetchCol := &fetchScope.cols[len(fetchScope.cols)-1]
+		rowExpr := &tree.ColumnAccessExpr{Expr: fetchCol, ByIndex: true, ColIndex: 1}
+		body := make([]ast.Statement, 0, len(target)+len(forLoop.Body))
+		if isRecord || isComposite {
+			// A single RECORD or composite-typed variable is assigned the whole row.
+			body = append(body, &ast.Assignment{Var: target[0], Value: rowExpr})
+		} else {
+			for i := range target {
+				body = append(body, &ast.Assignment{
+					Var:   target[i],
+					Value: &tree.ColumnAccessExpr{Expr: rowExpr, ByIndex: true, ColIndex: i},
+				})
+			}
+		}
+		body = append(body, forLoop.Body...)
+		ifStmt := &ast.If{
+			Condition: &tree.ColumnAccessExpr{Expr: fetchCol, ByIndex: true, ColIndex: 0},
+			ThenBody:  body,
+			ElseBody:  []ast.Statement{&ast.Exit{}},
+		}
+		b.appendBodyStmt(&loopCon, b.buildPLpgSQLStatements([]ast.Statement{ifStmt}, fetchScope))
+		b.popContinuation()
+		return b.callContinuation(&loopCon, s)
+	}
+	return b.buildOpenCursor(s, open, buildLoop)
+}
+
+// loopCursor identifies the cursor opened by a query FOR loop. The name of the
+// cursor is held either by a hidden variable, or by the bound cursor variable
+// of a cursor FOR loop.
+type loopCursor struct {
+	hiddenVar string
+	curVar    ast.Variable
+}
+
+// findLoopCursorCol returns the column that holds the name of the given loop
+// cursor in the given scope.
+func (b *plpgsqlBuilder) findLoopCursorCol(s *scope, lc loopCursor) *scopeColumn {
+	if lc.hiddenVar != "" {
+		return s.findAnonymousColumnWithMetadataName(lc.hiddenVar)
+	}
+	_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, lc.curVar)
+	if err != nil {
+		panic(err)
+	}
+	return source.(*scopeColumn)
+}
+
+// closeLoopCursors closes the cursors of the query FOR loops that are exited
+// when control passes from the current statement to a statement that is
+// enclosed by the given number of loops. The cursors are closed in the reverse
+// order of their creation.
+func (b *plpgsqlBuilder) closeLoopCursors(s *scope, depth int) {
+	if depth >= len(b.loopCursors) {
+		return
+	}
+	originalCols := s.colSet()
+	proj := make(memo.ProjectionsExpr, 0, len(b.loopCursors)-depth)
+	for i := len(b.loopCursors) - 1; i >= depth; i-- {
+		col := b.findLoopCursorCol(s, b.loopCursors[i])
+		closeCall := b.makePLpgSQLCloseFn(b.ob.factory.ConstructVariable(col.id))
+		closeCol := b.ob.factory.Metadata().AddColumn(b.makeIdentifier("_loop_cursor_close"), types.Int)
+		proj = append(proj, b.ob.factory.ConstructProjectionsItem(closeCall, closeCol))
+	}
+	s.expr = b.ob.factory.ConstructProject(s.expr, proj, originalCols)
+
+	// Add an optimization barrier to ensure that the cursors are closed before
+	// control leaves the loops. Then, remove the temporary columns from the
+	// output.
+	b.ob.addBarrier(s)
+	s.expr = b.ob.factory.ConstructProject(s.expr, memo.ProjectionsExpr{}, originalCols)
+}
+
+// buildFetchNext projects a call to the crdb_internal.plpgsql_fetch_next
+// builtin function, which fetches the next row from the cursor of a query FOR
+// loop. The projected column is a tuple with a boolean indicating whether a row
+// was found, followed by the row itself, cast to the given type.
+func (b *plpgsqlBuilder) buildFetchNext(s *scope, cursorCol *scopeColumn, rowType *types.T) *scope {
+	const fetchFnName = "crdb_internal.plpgsql_fetch_next"
+	props, overloads := builtinsregistry.GetBuiltinProperties(fetchFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", fetchFnName))
+	}
+	b.ensureScopeHasExpr(s)
+	nulls := make(memo.ScalarListExpr, len(rowType.TupleContents()))
+	for i, typ := range rowType.TupleContents() {
+		nulls[i] = b.ob.factory.ConstructNull(typ)
+	}
+	retType := types.MakeTuple([]*types.T{types.Bool, rowType})
+	fetchCall := b.ob.factory.ConstructFunction(
+		memo.ScalarListExpr{
+			b.ob.factory.ConstructVariable(cursorCol.id),
+			b.ob.factory.ConstructTuple(nulls, rowType),
+		},
+		&memo.FunctionPrivate{
+			Name:       fetchFnName,
+			Typ:        retType,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+	fetchColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_loop_fetch"))
+	fetchScope := s.push()
+	b.ob.synthesizeColumn(fetchScope, fetchColName, retType, nil /* expr */, fetchCall)
+	b.ob.constructProjectForScope(s, fetchScope)
+	b.ob.addBarrier(fetchScope)
+	return fetchScope
+}
+
+// handleForEachArrayLoop constructs the plan for a FOREACH loop, which iterates
+// over the elements of an array. If a SLICE is specified, the loop iterates
+// over slices of the array instead, which are themselves arrays.
+func (b *plpgsqlBuilder) handleForEachArrayLoop(s *scope, forEach *ast.ForEachArray) *scope {
+	if len(forEach.Target) != 1 {
+		panic(foreachTargetErr)
+	}
+	targetTyp := b.resolveVariableForAssign(forEach.Target[0])
+	switch {
+	case forEach.Slice > 1:
+		panic(pgerror.Newf(pgcode.ArraySubscript,
+			"slice dimension (%d) is out of the valid range 0..1", forEach.Slice,
+		))
+	case forEach.Slice == 0 && targetTyp.Family() == types.ArrayFamily:
+		panic(pgerror.New(pgcode.DatatypeMismatch,
+			"FOREACH loop variable must not be of an array type",
+		))
+	case forEach.Slice == 1 && targetTyp.Family() != types.ArrayFamily:
+		panic(pgerror.New(pgcode.DatatypeMismatch,
+			"FOREACH ... SLICE loop variable must be of an array type",
+		))
+	}
+	arrTyp := targetTyp
+	if forEach.Slice == 0 {
+		arrTyp = types.MakeArray(targetTyp)
+	}
+	if b.buildSQL {
+		expr, _ := tree.WalkExpr(s, forEach.Expr)
+		typedExpr, err := expr.TypeCheck(b.ob.ctx, b.ob.semaCtx, types.Any)
+		if err != nil {
+			panic(err)
+		}
+		if typ := typedExpr.ResolvedType(); typ.Family() != types.ArrayFamily &&
+			typ.Family() != types.UnknownFamily {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"FOREACH expression must yield an array, not type %s", typ.SQLStringForError(),
+			))
+		}
+	}
+
+	// Build an implicit block declaring hidden variables for the array, the
+	// number of iterations, and an internal counter that is incremented on each
+	// iteration. Each iteration assigns the element (or slice) at the position
+	// of the counter to the target variable.
+	b.pushNewBlock(&ast.Block{Label: forEach.Label})
+	defer b.popBlock()
+	arrName := b.makeIdentifier("_loop_array")
+	upperName := b.makeIdentifier("_loop_upper")
+	counterName := b.makeIdentifier("_loop_counter")
+	b.addHiddenVariable(arrName, arrTyp)
+	b.addHiddenVariable(upperName, types.Int)
+	b.addHiddenVariable(counterName, types.Int)
+	refHiddenVar := func(s *scope, name string) *scopeColumn {
+		return s.findAnonymousColumnWithMetadataName(name)
+	}
+	s = b.assignToHiddenVariable(s, arrName, forEach.Expr)
+
+	// Add a runtime check that the array is not NULL.
+	const severity, detail, hint = "ERROR", "", ""
+	b.addRuntimeCheck(s,
+		memo.ScalarListExpr{
+			b.buildSQLExpr(&tree.IsNullExpr{Expr: refHiddenVar(s, arrName)}, types.Bool, s),
+		},
+		[]memo.ScalarListExpr{b.makeConstRaiseArgs(
+			severity, foreachNullErr, detail, hint, pgcode.NullValueNotAllowed.String(),
+		)},
+	)
+
+	// A slice of the array is the whole array, so a loop with SLICE 1 iterates
+	// at most once.
+	var upper tree.Expr = &tree.CoalesceExpr{Name: "COALESCE", Exprs: tree.Exprs{
+		&tree.FuncExpr{
+			Func:  tree.WrapFunction("array_length"),
+			Exprs: tree.Exprs{refHiddenVar(s, arrName), tree.NewDInt(1)},
+		},
+		tree.DZero,
+	}}
+	if forEach.Slice == 1 {
+		upper = &tree.FuncExpr{
+			Func: tree.WrapFunction("least"), Exprs: tree.Exprs{upper, tree.NewDInt(1)},
+		}
+	}
+	s = b.assignToHiddenVariable(s, upperName, upper)
+	s = b.assignToHiddenVariable(s, counterName, tree.NewDInt(1))
+
+	// Similar to the integer FOR loop, the looping is implemented by two
+	// continuations that call each other recursively: one to execute the loop
+	// body, and one to increment the counter variable.
+	loopCon := b.makeContinuation("stmt_loop")
+	loopCon.def.IsRecursive = true
+	incrementCon := b.makeContinuationWithTyp("stmt_loop_inc", forEach.Label, continuationLoopContinue)
+	incrementCon.def.IsRecursive = true
+	b.pushContinuation(incrementCon)
+
+	var elem tree.Expr = refHiddenVar(loopCon.s, arrName)
+	if forEach.Slice == 0 {
+		elem = &tree.IndirectionExpr{
+			Expr: elem,
+			Indirection: tree.ArraySubscripts{
+				&tree.ArraySubscript{Begin: refHiddenVar(loopCon.s, counterName)},
+			},
 		}
 	}
+	body := make([]ast.Statement, 0, len(forEach.Body)+1)
+	body = append(body, &ast.Assignment{Var: forEach.Target[0], Value: elem})
+	body = append(body, forEach.Body...)
+	cond := &tree.ComparisonExpr{
+		Operator: treecmp.MakeComparisonOperator(treecmp.LE),
+		Left:     refHiddenVar(loopCon.s, counterName),
+		Right:    refHiddenVar(loopCon.s, upperName),
+	}
+	ifStmt := &ast.If{Condition: cond, ThenBody: body, ElseBody: []ast.Statement{&ast.Exit{}}}
+	b.appendPlpgSQLStmts(&loopCon, []ast.Statement{ifStmt})
+	b.popContinuation()
+
+	incScope := incrementCon.s.push()
+	b.ensureScopeHasExpr(incScope)
+	inc := &tree.BinaryExpr{
+		Operator: treebin.MakeBinaryOperator(treebin.Plus),
+		Left:     refHiddenVar(incScope, counterName),
+		Right:    tree.NewDInt(1),
+	}
+	incScope = b.assignToHiddenVariable(incScope, counterName, inc)
+	b.appendBodyStmt(&incrementCon, b.callContinuation(&loopCon, incScope))
+	return b.callContinuation(&loopCon, s)
+}
+
+// resolveOpenQuery finds and validates the query that is bound to cursor for
+// the given OPEN statement. It also returns the declaration of the cursor if it
+// is a bound cursor. The returned query is nil for OPEN ... FOR EXECUTE.
+func (b *plpgsqlBuilder) resolveOpenQuery(
+	open *ast.Open,
+) (tree.Statement, *ast.CursorDeclaration) {
+	decl := b.findBoundCursor(open.CurVar)
 	stmt := open.Query
-	if stmt != nil && boundStmt != nil {
+	if (stmt != nil || open.DynamicQuery != nil) && decl != nil {
 		// A bound cursor cannot be opened with "OPEN FOR" syntax.
 		panic(errors.WithHintf(
 			pgerror.New(pgcode.Syntax, "syntax error at or near \"FOR\""),
 			"cannot specify a query during OPEN for bound cursor \"%s\"", open.CurVar,
 		))
 	}
-	if stmt == nil && boundStmt == nil {
+	if open.DynamicQuery != nil {
+		// The query will be planned when the cursor is opened.
+		return nil, nil
+	}
+	if stmt == nil && decl == nil {
 		// The query was not specified either during cursor declaration or in the
 		// open statement.
 		panic(errors.WithHintf(
@@ -1280,29 +1689,191 @@ func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
 	}
 	if stmt == nil {
 		// This is a bound cursor.
-		stmt = boundStmt
+		stmt = decl.Query
+	}
+	b.checkCursorQuery(stmt)
+	return stmt, decl
+}
+
+// findBoundCursor returns the declaration of the bound cursor with the given
+// name, or nil if there is no such cursor.
+func (b *plpgsqlBuilder) findBoundCursor(name ast.Variable) *ast.CursorDeclaration {
+	// Search the blocks in reverse order to ensure that more recent declarations
+	// are encountered first.
+	for i := len(b.blocks) - 1; i >= 0; i-- {
+		if decl, ok := b.blocks[i].cursors[name]; ok {
+			return &decl
+		}
 	}
+	return nil
+}
+
+// checkCursorQuery checks that the given statement can be used to open a
+// cursor.
+func (b *plpgsqlBuilder) checkCursorQuery(stmt tree.Statement) {
 	if _, ok := stmt.(*tree.Select); !ok {
 		panic(pgerror.Newf(
 			pgcode.InvalidCursorDefinition, "cannot open %s query as cursor", stmt.StatementTag(),
 		))
 	}
-	return stmt
+}
+
+// resolveCursorVar returns the column for the given variable, which must be of
+// type refcursor.
+func (b *plpgsqlBuilder) resolveCursorVar(s *scope, name ast.Variable) *scopeColumn {
+	_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, name)
+	if err != nil {
+		if pgerror.GetPGCode(err) == pgcode.UndefinedColumn {
+			panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", name))
+		}
+		panic(err)
+	}
+	col := source.(*scopeColumn)
+	if !col.typ.Identical(types.RefCursor) {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"variable \"%s\" must be of type cursor or refcursor", name,
+		))
+	}
+	return col
+}
+
+// cursorOpen describes a cursor that is opened by an OPEN statement or a query
+// FOR loop.
+type cursorOpen struct {
+	// nameVar is the variable that holds the name of the cursor. If it is unset,
+	// the name is held by the hidden variable hiddenNameVar instead.
+	nameVar       ast.Variable
+	hiddenNameVar string
+
+	// scroll is the scroll option for the cursor.
+	scroll tree.CursorScrollOption
+
+	// query is the query that is used to open the cursor. It is unset for a
+	// dynamic query, in which case dynamicQuery and params are set instead.
+	query        tree.Statement
+	dynamicQuery ast.Expr
+	params       []ast.Expr
+
+	// decl is the declaration of a bound cursor, if any. args are the arguments
+	// supplied for the parameters of the bound cursor.
+	decl *ast.CursorDeclaration
+	args []ast.Expr
+}
+
+// hasArgs returns true if the cursor has parameters, or if arguments were
+// supplied for them.
+func (o *cursorOpen) hasArgs() bool {
+	return len(o.args) > 0 || (o.decl != nil && len(o.decl.Args) > 0)
+}
+
+// nameCol returns the column for the variable that holds the name of the
+// cursor in the given scope.
+func (b *plpgsqlBuilder) nameCol(s *scope, open *cursorOpen) *scopeColumn {
+	if open.hiddenNameVar != "" {
+		return s.findAnonymousColumnWithMetadataName(open.hiddenNameVar)
+	}
+	return b.resolveCursorVar(s, open.nameVar)
+}
+
+// buildOpenCursor builds the statements that open a cursor. This is handled by
+// two volatile continuations: the first generates a unique name for the cursor
+// if one was not supplied, and the second executes the cursor's query in its
+// first body statement, which is used to open the cursor when the routine is
+// executed. The second body statement of the latter continuation is built by
+// the given function, which is supplied the scope for the body statement as
+// well as the scope of the cursor's query.
+//
+// If the cursor has parameters, they are declared as variables in a new block
+// that encloses both continuations.
+func (b *plpgsqlBuilder) buildOpenCursor(
+	s *scope, open *cursorOpen, buildNext func(s, queryScope *scope) *scope,
+) *scope {
+	if open.hasArgs() {
+		s = b.bindCursorArgs(s, open)
+		defer b.popBlock()
+	}
+	// Initialize the routine with the information needed to pipe the first
+	// body statement into a cursor.
+	openCon := b.makeContinuation("_stmt_open")
+	openCon.def.Volatility = volatility.Volatile
+	openCon.def.CursorDeclaration = &tree.RoutineOpenCursor{
+		NameArgIdx: b.nameCol(openCon.s, open).getParamOrd(),
+		Scroll:     open.scroll,
+	}
+	var queryScope *scope
+	if open.dynamicQuery != nil {
+		// The first body statement produces the query string and its parameters.
+		openCon.def.CursorDeclaration.Dynamic = true
+		queryScope = b.buildDynamicQuery(openCon.s, open.dynamicQuery, open.params, "stmt_open")
+	} else {
+		fmtCtx := b.ob.evalCtx.FmtCtx(tree.FmtSimple)
+		fmtCtx.FormatNode(open.query)
+		openCon.def.CursorDeclaration.CursorSQL = fmtCtx.CloseAndGetString()
+		queryScope = b.buildSQLStatement(open.query, openCon.s)
+		if queryScope.expr.Relational().CanMutate {
+			// Cursors with mutations are invalid.
+			panic(cursorMutationErr)
+		}
+	}
+	b.appendBodyStmt(&openCon, queryScope)
+	nextScope := openCon.s.push()
+	b.ensureScopeHasExpr(nextScope)
+	b.appendBodyStmt(&openCon, buildNext(nextScope, queryScope))
+
+	// Build a statement to generate a unique name for the cursor if one
+	// was not supplied. Add this to its own volatile routine to ensure that
+	// the name generation isn't reordered with other operations. Use the
+	// resulting projected column as input to the OPEN continuation.
+	nameCon := b.makeContinuation("_gen_cursor_name")
+	nameCon.def.Volatility = volatility.Volatile
+	nameScope := b.buildCursorNameGen(&nameCon, b.nameCol(nameCon.s, open))
+	b.appendBodyStmt(&nameCon, b.callContinuation(&openCon, nameScope))
+	return b.callContinuation(&nameCon, s)
+}
+
+// bindCursorArgs pushes a new block that declares a variable for each
+// parameter of a bound cursor, and assigns the supplied arguments to them. The
+// caller is responsible for popping the block.
+func (b *plpgsqlBuilder) bindCursorArgs(s *scope, open *cursorOpen) *scope {
+	var params []ast.CursorArg
+	if open.decl != nil {
+		params = open.decl.Args
+	}
+	switch {
+	case len(params) == 0:
+		panic(pgerror.Newf(pgcode.Syntax, "cursor \"%s\" has no arguments", open.nameVar))
+	case len(open.args) == 0:
+		panic(pgerror.Newf(pgcode.Syntax, "cursor \"%s\" has arguments", open.nameVar))
+	case len(open.args) < len(params):
+		panic(pgerror.Newf(pgcode.Syntax, "not enough arguments for cursor \"%s\"", open.nameVar))
+	case len(open.args) > len(params):
+		panic(pgerror.Newf(pgcode.Syntax, "too many arguments for cursor \"%s\"", open.nameVar))
+	}
+	b.ensureScopeHasExpr(s)
+	b.pushNewBlock(&ast.Block{})
+	for i := range params {
+		typ, err := tree.ResolveType(b.ob.ctx, params[i].Typ, b.ob.semaCtx.TypeResolver)
+		if err != nil {
+			panic(err)
+		}
+		b.addVariable(params[i].Name, typ)
+		s = b.addPLpgSQLAssign(s, params[i].Name, open.args[i], noIndirection)
+	}
+	return s
 }
 
 // buildCursorNameGen builds a statement that generates a unique name for the
 // cursor if the variable containing the name is unset. The unique name
 // generation is implemented by the crdb_internal.plpgsql_gen_cursor_name
 // builtin function.
-func (b *plpgsqlBuilder) buildCursorNameGen(nameCon *continuation, nameVar ast.Variable) *scope {
-	_, source, _, _ := nameCon.s.FindSourceProvidingColumn(b.ob.ctx, nameVar)
+func (b *plpgsqlBuilder) buildCursorNameGen(nameCon *continuation, nameCol *scopeColumn) *scope {
 	const nameFnName = "crdb_internal.plpgsql_gen_cursor_name"
 	props, overloads := builtinsregistry.GetBuiltinProperties(nameFnName)
 	if len(overloads) != 1 {
 		panic(errors.AssertionFailedf("expected one overload for %s", nameFnName))
 	}
 	nameCall := b.ob.factory.ConstructFunction(
-		memo.ScalarListExpr{b.ob.factory.ConstructVariable(source.(*scopeColumn).id)},
+		memo.ScalarListExpr{b.ob.factory.ConstructVariable(nameCol.id)},
 		&memo.FunctionPrivate{
 			Name:       nameFnName,
 			Typ:        types.RefCursor,
@@ -1311,11 +1882,30 @@ func (b *plpgsqlBuilder) buildCursorNameGen(nameCon *continuation, nameVar ast.V
 		},
 	)
 	nameScope := nameCon.s.push()
-	b.ob.synthesizeColumn(nameScope, scopeColName(nameVar), types.RefCursor, nil /* expr */, nameCall)
+	b.ob.synthesizeColumn(nameScope, nameCol.name, types.RefCursor, nil /* expr */, nameCall)
 	b.ob.constructProjectForScope(nameCon.s, nameScope)
 	return nameScope
 }
 
+// makePLpgSQLCloseFn builds a call to the crdb_internal.plpgsql_close builtin
+// function, which closes the cursor with the given name.
+func (b *plpgsqlBuilder) makePLpgSQLCloseFn(name opt.ScalarExpr) opt.ScalarExpr {
+	const closeFnName = "crdb_internal.plpgsql_close"
+	props, overloads := builtinsregistry.GetBuiltinProperties(closeFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", closeFnName))
+	}
+	return b.ob.factory.ConstructFunction(
+		memo.ScalarListExpr{name},
+		&memo.FunctionPrivate{
+			Name:       closeFnName,
+			Typ:        types.Int,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+}
+
 // addPLpgSQLAssign adds a PL/pgSQL assignment to the current scope as a
 // new column with the variable name that projects the assigned expression.
 // If there is a column with the same name in the previous scope, it will be
@@ -1328,6 +1918,14 @@ func (b *plpgsqlBuilder) addPLpgSQLAssign(
 	inScope *scope, ident ast.Variable, val ast.Expr, indirection tree.Name,
 ) *scope {
 	typ := b.resolveVariableForAssign(ident)
+	return b.addPLpgSQLAssignWithType(inScope, ident, typ, val, indirection)
+}
+
+// addPLpgSQLAssignWithType is similar to addPLpgSQLAssign, but the type of the
+// variable is supplied by the caller rather than resolved.
+func (b *plpgsqlBuilder) addPLpgSQLAssignWithType(
+	inScope *scope, ident ast.Variable, typ *types.T, val ast.Expr, indirection tree.Name,
+) *scope {
 	assignScope := inScope.push()
 	for i := range inScope.cols {
 		col := &inScope.cols[i]
@@ -1794,11 +2392,14 @@ func (b *plpgsqlBuilder) buildExceptions(block *ast.Block) *memo.ExceptionBlock
 // handleEndOfFunction handles the case when control flow reaches the end of a
 // PL/pgSQL routine without reaching a RETURN statement.
 func (b *plpgsqlBuilder) handleEndOfFunction(inScope *scope) *scope {
-	if b.hasOutParam() || b.returnType.Family() == types.VoidFamily {
+	// Control leaves any enclosing query FOR loops.
+	b.closeLoopCursors(inScope, 0 /* depth */)
+	if b.setReturning || b.hasOutParam() || b.returnType.Family() == types.VoidFamily {
 		// Routines with OUT-parameters and VOID return types need not explicitly
-		// specify a RETURN statement.
+		// specify a RETURN statement. Neither do set-returning routines, which
+		// build their result with RETURN NEXT and RETURN QUERY statements.
 		var returnExpr tree.Expr = tree.DNull
-		if b.hasOutParam() {
+		if b.hasOutParam() && !b.setReturning {
 			returnExpr = b.makeReturnForOutParams()
 		}
 		returnScope := inScope.push()
@@ -1838,6 +2439,79 @@ func (b *plpgsqlBuilder) buildEndOfFunctionRaise(con *continuation) {
 	b.appendBodyStmt(con, eofScope)
 }
 
+// buildReturnQuery builds the query of a RETURN QUERY statement. The resulting
+// expression produces a single column with the return type of the routine for
+// each row of the query. The columns of the query must match the return type.
+func (b *plpgsqlBuilder) buildReturnQuery(inScope *scope, stmt tree.Statement) *scope {
+	expected := []*types.T{b.returnType}
+	isComposite := b.returnType.Family() == types.TupleFamily
+	if isComposite {
+		expected = b.returnType.TupleContents()
+	}
+	stmtScope := b.ob.buildStmtAtRootWithScope(stmt, expected /* desiredTypes */, inScope)
+	var cols []*scopeColumn
+	for i := range stmtScope.cols {
+		if stmtScope.cols[i].visibility == visible {
+			cols = append(cols, &stmtScope.cols[i])
+		}
+	}
+	if len(cols) != len(expected) {
+		panic(errors.WithDetailf(returnQueryStructureErr,
+			"Number of returned columns (%d) does not match expected column count (%d).",
+			len(cols), len(expected),
+		))
+	}
+	elems := make(memo.ScalarListExpr, len(cols))
+	for i, col := range cols {
+		if col.typ.Family() != types.UnknownFamily && !col.typ.Equivalent(expected[i]) {
+			panic(errors.WithDetailf(returnQueryStructureErr,
+				"Returned type %s does not match expected type %s in column %d.",
+				col.typ.SQLStringForError(), expected[i].SQLStringForError(), i+1,
+			))
+		}
+		elems[i] = b.coerceType(b.ob.factory.ConstructVariable(col.id), expected[i])
+	}
+	result := elems[0]
+	if isComposite {
+		result = b.ob.factory.ConstructTuple(elems, b.returnType)
+	}
+	colName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return_query"))
+	returnScope := stmtScope.push()
+	b.ob.synthesizeColumn(returnScope, colName, b.returnType, nil /* expr */, result)
+	// Preserve the ordering of the query, if any.
+	returnScope.copyOrdering(stmtScope)
+	b.ob.constructProjectForScope(stmtScope, returnScope)
+	return returnScope
+}
+
+// buildDynamicQuery builds an expression that produces a single row with the
+// query string of a RETURN QUERY EXECUTE statement, OPEN ... FOR EXECUTE
+// statement or FOR ... IN EXECUTE loop, followed by the values of its
+// parameters. The query itself is executed when the routine runs. The given
+// name is used for the projected columns.
+func (b *plpgsqlBuilder) buildDynamicQuery(
+	inScope *scope, query ast.Expr, params []ast.Expr, name string,
+) *scope {
+	returnScope := inScope.push()
+	queryScalar := b.buildSQLExpr(query, types.String, inScope)
+	queryColName := scopeColName("").WithMetadataName(b.makeIdentifier(name))
+	b.ob.synthesizeColumn(returnScope, queryColName, types.String, nil /* expr */, queryScalar)
+	for i := range params {
+		// The types of the parameters are determined by their values, since the
+		// query is not known until execution.
+		expr, _ := tree.WalkExpr(inScope, params[i])
+		typedExpr, err := expr.TypeCheck(b.ob.ctx, b.ob.semaCtx, types.Any)
+		if err != nil {
+			panic(err)
+		}
+		paramScalar := b.ob.buildScalar(typedExpr, inScope, nil, nil, b.colRefs)
+		paramColName := scopeColName("").WithMetadataName(b.makeIdentifier(name + "_param"))
+		b.ob.synthesizeColumn(returnScope, paramColName, paramScalar.DataType(), nil /* expr */, paramScalar)
+	}
+	b.ob.constructProjectForScope(inScope, returnScope)
+	return returnScope
+}
+
 // addOneRowCheck handles INTO STRICT, where a SQL statement is required to
 // return exactly one row, or an error occurs.
 func (b *plpgsqlBuilder) addOneRowCheck(s *scope) {
@@ -2043,6 +2717,7 @@ func (b *plpgsqlBuilder) makeContinuation(conName string) continuation {
 	}
 	b.ensureScopeHasExpr(s)
 	return continuation{
+		loopCursorDepth: len(b.loopCursors),
 		def: &memo.UDFDefinition{
 			Params:            params,
 			Name:              b.makeIdentifier(conName),
@@ -2102,6 +2777,7 @@ func (b *plpgsqlBuilder) callContinuation(con *continuation, s *scope) *scope {
 	if con == nil {
 		return b.handleEndOfFunction(s)
 	}
+	b.closeLoopCursors(s, con.loopCursorDepth)
 	args := b.makeContinuationArgs(con, s)
 	call := b.ob.factory.ConstructUDFCall(args, &memo.UDFCallPrivate{Def: con.def})
 	b.addBarrierIfVolatile(s, call)
@@ -2267,11 +2943,29 @@ func (b *plpgsqlBuilder) resolveVariableForAssign(name ast.Variable) *types.T {
 				panic(pgerror.Newf(pgcode.ErrorInAssignment, "variable \"%s\" is declared CONSTANT", name))
 			}
 		}
+		if typ.Identical(types.AnyTuple) {
+			// RECORD variables can only be assigned by query FOR loops, which give
+			// them a concrete type.
+			panic(recordVarErr)
+		}
 		return typ
 	}
 	panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", name))
 }
 
+// lookupVariable returns the block that declares the variable with the given
+// name, as well as the type of the variable. It returns nil if there is no such
+// variable.
+func (b *plpgsqlBuilder) lookupVariable(name ast.Variable) (*plBlock, *types.T) {
+	for i := len(b.blocks) - 1; i >= 0; i-- {
+		block := &b.blocks[i]
+		if typ, ok := block.varTypes[name]; ok {
+			return block, typ
+		}
+	}
+	return nil, nil
+}
+
 // resolveHiddenVariableForAssign is similar to resolveVariableForAssign, but
 // applies to hidden variables, which are identified only by their name in the
 // query's metadata. It panics if the hidden variable is not found.
@@ -2413,6 +3107,11 @@ type continuation struct {
 
 	// typ defines the context of the continuation.
 	typ continuationType
+
+	// loopCursorDepth is the number of query FOR loops that enclose the
+	// continuation. When control passes to the continuation from within a
+	// more deeply nested loop, the cursors of the exited loops are closed.
+	loopCursorDepth int
 }
 
 const unspecifiedLabel = ""
@@ -2596,35 +3295,47 @@ func (r *recordTypeVisitor) Visit(stmt ast.Statement) (newStmt ast.Statement, re
 			return t, false
 		}
 	case *ast.Return:
-		desired := types.Any
-		if r.typ != nil && r.typ.Family() != types.UnknownFamily {
-			desired = r.typ
-		}
-		expr, _ := tree.WalkExpr(r.s, t.Expr)
-		typedExpr, err := expr.TypeCheck(r.ctx, r.semaCtx, desired)
-		if err != nil {
-			panic(err)
-		}
-		typ := typedExpr.ResolvedType()
-		switch typ.Family() {
-		case types.UnknownFamily, types.TupleFamily:
-		default:
-			panic(nonCompositeErr)
-		}
-		if r.typ == nil || r.typ.Family() == types.UnknownFamily {
-			r.typ = typ
-			return stmt, false
-		}
-		if typ.Family() == types.UnknownFamily {
-			return stmt, false
-		}
-		if !typ.Identical(r.typ) {
-			panic(recordReturnErr)
-		}
+		r.visitReturnExpr(t.Expr)
+	case *ast.ReturnNext:
+		// The rows of a set-returning routine are returned by RETURN NEXT.
+		r.visitReturnExpr(t.Expr)
 	}
 	return stmt, true
 }
 
+// visitReturnExpr checks the type of an expression returned by the routine,
+// and uses it to infer the concrete return type.
+func (r *recordTypeVisitor) visitReturnExpr(returnExpr ast.Expr) {
+	if returnExpr == nil {
+		return
+	}
+	desired := types.Any
+	if r.typ != nil && r.typ.Family() != types.UnknownFamily {
+		desired = r.typ
+	}
+	expr, _ := tree.WalkExpr(r.s, returnExpr)
+	typedExpr, err := expr.TypeCheck(r.ctx, r.semaCtx, desired)
+	if err != nil {
+		panic(err)
+	}
+	typ := typedExpr.ResolvedType()
+	switch typ.Family() {
+	case types.UnknownFamily, types.TupleFamily:
+	default:
+		panic(nonCompositeErr)
+	}
+	if r.typ == nil || r.typ.Family() == types.UnknownFamily {
+		r.typ = typ
+		return
+	}
+	if typ.Family() == types.UnknownFamily {
+		return
+	}
+	if !typ.Identical(r.typ) {
+		panic(recordReturnErr)
+	}
+}
+
 // transactionControlVisitor is used to check for COMMIT or ROLLBACK statements
 // for a PL/pgSQL stored procedure, so that stable folding can be disabled.
 type transactionControlVisitor struct {
@@ -2702,6 +3413,27 @@ var (
 	emptyReturnErr = pgerror.New(pgcode.Syntax,
 		"missing expression at or near \"RETURN;\"",
 	)
+	returnWithSetParameterErr = errors.WithHint(
+		pgerror.New(pgcode.DatatypeMismatch,
+			"RETURN cannot have a parameter in function returning set",
+		),
+		"Use RETURN NEXT or RETURN QUERY.",
+	)
+	returnNextNonSetErr = pgerror.New(pgcode.DatatypeMismatch,
+		"cannot use RETURN NEXT in a non-SETOF function",
+	)
+	returnQueryNonSetErr = pgerror.New(pgcode.DatatypeMismatch,
+		"cannot use RETURN QUERY in a non-SETOF function",
+	)
+	returnNextWithOUTParameterErr = pgerror.New(pgcode.DatatypeMismatch,
+		"RETURN NEXT cannot have a parameter in function with OUT parameters",
+	)
+	emptyReturnNextErr = pgerror.New(pgcode.Syntax,
+		"RETURN NEXT must have a parameter",
+	)
+	returnQueryStructureErr = pgerror.New(pgcode.DatatypeMismatch,
+		"structure of query does not match function result type",
+	)
 	txnControlWithExceptionErr = errors.WithDetail(
 		pgerror.Newf(pgcode.InvalidTransactionTermination, "invalid transaction termination"),
 		"PL/pgSQL COMMIT/ROLLBACK is not allowed inside a block with exception handlers",
@@ -2719,4 +3451,23 @@ var (
 	intForLoopTargetErr = pgerror.New(pgcode.Syntax,
 		"integer FOR loop must have only one target variable",
 	)
+	queryForLoopTargetErr = pgerror.New(pgcode.Syntax,
+		"loop variable of loop over rows must be a record variable or list of scalar variables",
+	)
+	cursorForLoopTargetErr = pgerror.New(pgcode.Syntax,
+		"cursor FOR loop must have only one target variable",
+	)
+	unboundCursorForLoopErr = pgerror.New(pgcode.Syntax,
+		"cursor FOR loop must use a bound cursor variable",
+	)
+	dynamicRecordLoopErr = unimplemented.NewWithIssueDetail(114874, "RECORD variable",
+		"RECORD loop variables are not yet supported for FOR loops over dynamic queries",
+	)
+	txnControlInQueryLoopErr = unimplemented.New("COMMIT or ROLLBACK in a query FOR loop",
+		"COMMIT and ROLLBACK are not yet supported inside a FOR loop over the rows of a query",
+	)
+	foreachTargetErr = unimplemented.New("FOREACH with multiple targets",
+		"FOREACH loops with more than one target variable are not yet supported",
+	)
+	foreachNullErr = "FOREACH expression must not be null"
 )
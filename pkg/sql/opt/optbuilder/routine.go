@@ -328,6 +328,7 @@ func (b *Builder) buildRoutine(
 					))
 				}
 				args[i] = b.factory.ConstructCast(args[i], desiredTyp)
+				args[i] = b.buildDomainChecks(args[i], desiredTyp)
 			}
 			argColName := funcParamColName(tree.Name(paramTypes[i].Name), i)
 			col := b.synthesizeColumn(bodyScope, argColName, desiredTyp, nil /* expr */, nil /* scalar */)
@@ -444,7 +445,7 @@ func (b *Builder) buildRoutine(
 		var physProps *physical.Required
 		plBuilder := newPLpgSQLBuilder(
 			b, def.Name, stmt.AST.Label, colRefs, routineParams, f.ResolvedType(),
-			isProc, true /* buildSQL */, outScope,
+			isProc, isSetReturning, true /* buildSQL */, outScope,
 		)
 		stmtScope := plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
 		expr, physProps = b.finishBuildLastStmt(
@@ -476,6 +477,7 @@ func (b *Builder) buildRoutine(
 				BodyProps:          bodyProps,
 				BodyStmts:          bodyStmts,
 				Params:             params,
+				ResultBuffer:       isSetReturning && o.Language == tree.RoutineLangPLpgSQL,
 			},
 		},
 	)
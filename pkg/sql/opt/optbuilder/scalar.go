@@ -184,6 +184,9 @@ func (b *Builder) buildScalar(
 			)
 		}
 
+	case *tree.GroupingOperation:
+		out = b.buildGroupingOperation(t, inScope)
+
 	case *tree.IfErrExpr:
 		cond := b.buildScalar(t.Cond.(tree.TypedExpr), inScope, nil, nil, colRefs)
 
@@ -278,6 +281,7 @@ func (b *Builder) buildScalar(
 		texpr := t.Expr.(tree.TypedExpr)
 		arg := b.buildScalar(texpr, inScope, nil, nil, colRefs)
 		out = b.factory.ConstructCast(arg, t.ResolvedType())
+		out = b.buildDomainChecks(out, t.ResolvedType())
 
 	case *tree.CoalesceExpr:
 		args := make(memo.ScalarListExpr, len(t.Exprs))
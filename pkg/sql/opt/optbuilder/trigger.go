@@ -667,7 +667,11 @@ func (b *Builder) buildTriggerFunction(
 ) (opt.ScalarExpr, *tree.ResolvedFunctionDefinition) {
 	f := b.factory
 	triggerFuncScope := b.allocScope()
-	funcRef := &tree.FunctionOID{OID: catid.FuncIDToOID(catid.DescID(trigger.FuncID()))}
+	funcOID := trigger.BuiltinFuncOID()
+	if funcOID == 0 {
+		funcOID = catid.FuncIDToOID(catid.DescID(trigger.FuncID()))
+	}
+	funcRef := &tree.FunctionOID{OID: funcOID}
 	funcExpr := tree.FuncExpr{Func: tree.ResolvableFunctionReference{FunctionReference: funcRef}}
 	triggerFuncScope.resolveType(&funcExpr, types.Any)
 	def := funcExpr.Func.FunctionReference.(*tree.ResolvedFunctionDefinition)
@@ -695,7 +699,7 @@ func (b *Builder) buildTriggerFunction(
 	}
 	plBuilder := newPLpgSQLBuilder(
 		b, def.Name, stmt.AST.Label, nil /* colRefs */, params, tableTyp,
-		false /* isProc */, true /* buildSQL */, nil, /* outScope */
+		false /* isProc */, false /* setReturning */, true /* buildSQL */, nil, /* outScope */
 	)
 	stmtScope := plBuilder.buildRootBlock(stmt.AST, triggerFuncScope, params)
 
@@ -0,0 +1,252 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// isUserDefinedAggregate returns true if the given function expression is an
+// invocation of an aggregate created with CREATE AGGREGATE.
+func isUserDefinedAggregate(f *tree.FuncExpr) bool {
+	o := f.ResolvedOverload()
+	return o != nil && o.UserDefinedAggregate != nil
+}
+
+// userDefinedAggregateArgs returns the arguments of a user-defined aggregate
+// invocation. Aggregate operators take columns as their arguments, so if the
+// aggregate has more than one argument, the arguments are packed into a single
+// tuple which is unpacked during execution.
+func userDefinedAggregateArgs(f *tree.FuncExpr) tree.Exprs {
+	if len(f.Exprs) <= 1 {
+		return f.Exprs
+	}
+	contents := make([]*types.T, len(f.Exprs))
+	for i := range f.Exprs {
+		contents[i] = f.Exprs[i].(tree.TypedExpr).ResolvedType()
+	}
+	return tree.Exprs{tree.NewTypedTuple(types.MakeTuple(contents), f.Exprs)}
+}
+
+// constructUserDefinedAggregate constructs a UserDefinedAgg operator for the
+// given invocation of a user-defined aggregate, either as an aggregate or as a
+// window function. The component functions of the aggregate are built as
+// routines that are invoked with the aggregate's state during execution.
+func (b *Builder) constructUserDefinedAggregate(
+	f *tree.FuncExpr, fn *memo.FunctionPrivate, input opt.ScalarExpr,
+) opt.ScalarExpr {
+	o := fn.Overload
+	uda := o.UserDefinedAggregate
+	if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid, b.checkPrivilegeUser); err != nil {
+		panic(err)
+	}
+	argTypes := make([]*types.T, len(f.Exprs))
+	for i, expr := range f.Exprs {
+		argTypes[i] = expr.(tree.TypedExpr).ResolvedType()
+	}
+	b.factory.Metadata().AddUserDefinedRoutine(o, argTypes, f.Func.ReferenceByName)
+	if b.trackSchemaDeps {
+		b.schemaFunctionDeps.Add(int(o.Oid))
+	}
+
+	def := &memo.UDADefinition{
+		Name:      fn.Name,
+		Typ:       f.ResolvedType(),
+		StateType: uda.StateType,
+		InitCond:  uda.InitCond,
+	}
+	transitionTypes := make([]*types.T, 0, len(argTypes)+1)
+	transitionTypes = append(transitionTypes, uda.StateType)
+	transitionTypes = append(transitionTypes, argTypes...)
+	def.Transition = b.buildAggregateComponentFunc(uda.TransitionFunc, transitionTypes)
+	if uda.FinalFunc != 0 {
+		def.Final = b.buildAggregateComponentFunc(uda.FinalFunc, []*types.T{uda.StateType})
+	}
+	if uda.CombineFunc != 0 {
+		def.Combine = b.buildAggregateComponentFunc(
+			uda.CombineFunc, []*types.T{uda.StateType, uda.StateType},
+		)
+	}
+	def.Inlined = b.inlineAggregateComponentFuncs(def)
+	return b.factory.ConstructUserDefinedAgg(input, &memo.UserDefinedAggPrivate{Def: def})
+}
+
+// inlineAggregateComponentFuncs returns the component functions of a
+// user-defined aggregate inlined as scalar expressions, or nil if any of them
+// cannot be inlined.
+func (b *Builder) inlineAggregateComponentFuncs(def *memo.UDADefinition) *memo.UDAInlinedFuncs {
+	var res memo.UDAInlinedFuncs
+	if res.Transition = b.inlineAggregateComponentFunc(def.Transition); res.Transition == nil {
+		return nil
+	}
+	if def.Final != nil {
+		if res.Final = b.inlineAggregateComponentFunc(def.Final); res.Final == nil {
+			return nil
+		}
+	}
+	if def.Combine != nil {
+		if res.Combine = b.inlineAggregateComponentFunc(def.Combine); res.Combine == nil {
+			return nil
+		}
+	}
+	return &res
+}
+
+// inlineAggregateComponentFunc returns the body of a component function of a
+// user-defined aggregate as a scalar expression of the function's parameters,
+// or nil if the function cannot be inlined. This is the case for SQL functions
+// whose body is a single SELECT of a scalar expression with no FROM clause,
+// such as:
+//
+//	SELECT s + v
+//
+// The expression must not contain subqueries, routine calls or functions that
+// cannot be evaluated with DistSQL.
+func (b *Builder) inlineAggregateComponentFunc(def *memo.UDFDefinition) opt.ScalarExpr {
+	if def.RoutineLang != tree.RoutineLangSQL || def.SetReturning || def.MultiColDataSource ||
+		len(def.Body) != 1 || len(def.BodyProps[0].Presentation) != 1 {
+		return nil
+	}
+	body := b.inlineRoutineBodyCol(def.Body[0], def.BodyProps[0].Presentation[0].ID)
+	if body == nil || !isInlinableAggregateBody(body, def.Params.ToSet()) {
+		return nil
+	}
+	return body
+}
+
+// inlineRoutineBodyCol returns a scalar expression that computes the given
+// output column of a routine body statement, or nil if the statement is not a
+// projection of a single row without a FROM clause.
+func (b *Builder) inlineRoutineBodyCol(e memo.RelExpr, col opt.ColumnID) opt.ScalarExpr {
+	switch t := e.(type) {
+	case *memo.LimitExpr:
+		// The statement produces a single row, so the limit that is added to
+		// the last statement of the routine has no effect.
+		return b.inlineRoutineBodyCol(t.Input, col)
+
+	case *memo.ProjectExpr:
+		for i := range t.Projections {
+			if t.Projections[i].Col == col {
+				return b.inlineRoutineBodyInputCols(t.Input, t.Projections[i].Element)
+			}
+		}
+		if t.Passthrough.Contains(col) {
+			return b.inlineRoutineBodyCol(t.Input, col)
+		}
+
+	case *memo.ValuesExpr:
+		if len(t.Rows) != 1 {
+			return nil
+		}
+		row := t.Rows[0].(*memo.TupleExpr)
+		for i, c := range t.Cols {
+			if c == col {
+				return b.inlineRoutineBodyInputCols(nil /* input */, row.Elems[i])
+			}
+		}
+	}
+	return nil
+}
+
+// inlineRoutineBodyInputCols replaces the references to the columns of the
+// given input in a scalar expression with the expressions that compute them.
+// It returns nil if any of the columns cannot be inlined.
+func (b *Builder) inlineRoutineBodyInputCols(input memo.RelExpr, e opt.ScalarExpr) opt.ScalarExpr {
+	if input == nil {
+		return e
+	}
+	inputCols := input.Relational().OutputCols
+	failed := false
+	var replace norm.ReplaceFunc
+	replace = func(e opt.Expr) opt.Expr {
+		if v, ok := e.(*memo.VariableExpr); ok && inputCols.Contains(v.Col) {
+			inlined := b.inlineRoutineBodyCol(input, v.Col)
+			if inlined == nil {
+				failed = true
+				return e
+			}
+			return inlined
+		}
+		return b.factory.Replace(e, replace)
+	}
+	res := replace(e).(opt.ScalarExpr)
+	if failed {
+		return nil
+	}
+	return res
+}
+
+// isInlinableAggregateBody returns true if the given inlined body of a
+// component function only refers to the function's parameters and can be
+// evaluated with DistSQL.
+func isInlinableAggregateBody(e opt.Expr, params opt.ColSet) bool {
+	switch t := e.(type) {
+	case memo.RelExpr:
+		return false
+	case *memo.VariableExpr:
+		return params.Contains(t.Col)
+	case *memo.SubqueryExpr, *memo.ExistsExpr, *memo.AnyExpr, *memo.ArrayFlattenExpr,
+		*memo.UDFCallExpr, *memo.PlaceholderExpr:
+		return false
+	case *memo.FunctionExpr:
+		if t.Overload.DistsqlBlocklist {
+			return false
+		}
+	}
+	for i, n := 0, e.ChildCount(); i < n; i++ {
+		if !isInlinableAggregateBody(e.Child(i), params) {
+			return false
+		}
+	}
+	return true
+}
+
+// buildAggregateComponentFunc builds the definition of a component function of
+// a user-defined aggregate with the given argument types. The function is
+// built as if it was invoked with NULL arguments; only its definition is used,
+// since the arguments are supplied by the aggregate during execution.
+func (b *Builder) buildAggregateComponentFunc(
+	funcOID oid.Oid, argTypes []*types.T,
+) *memo.UDFDefinition {
+	name, o, err := b.catalog.ResolveFunctionByOID(b.ctx, funcOID)
+	if err != nil {
+		panic(err)
+	}
+	args := make(tree.TypedExprs, len(argTypes))
+	for i, typ := range argTypes {
+		args[i] = tree.NewTypedCastExpr(tree.DNull, typ)
+	}
+	f := tree.NewTypedFuncExpr(
+		tree.ResolvableFunctionReference{FunctionReference: &tree.FunctionOID{OID: funcOID}},
+		0, /* aggQualifier */
+		args,
+		nil, /* filter */
+		nil, /* windowDef */
+		o.ReturnType(args),
+		&o.FunctionProperties,
+		o,
+	)
+	def := &tree.ResolvedFunctionDefinition{Name: name.Object()}
+
+	// Disable normalization rules so that the routine is not inlined into its
+	// NULL arguments.
+	var routine opt.ScalarExpr
+	var colRefs opt.ColSet
+	b.factory.DisableOptimizationsTemporarily(func() {
+		routine = b.buildRoutine(f, def, b.allocScope(), nil /* outScope */, &colRefs)
+	})
+	udf, ok := routine.(*memo.UDFCallExpr)
+	if !ok {
+		panic(errors.AssertionFailedf("expected UDFCall for aggregate component %s", name))
+	}
+	return udf.Def
+}
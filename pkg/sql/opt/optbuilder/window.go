@@ -138,7 +138,12 @@ func (b *Builder) buildWindow(outScope *scope, inScope *scope) {
 
 		frameIdx := b.findMatchingFrameIndex(&frames, partitions[i], orderings[i])
 
-		fn := b.constructWindowFn(w.def.Name, argLists[i])
+		var fn opt.ScalarExpr
+		if isUserDefinedAggregate(w.FuncExpr) {
+			fn = b.constructUserDefinedAggregate(w.FuncExpr, &w.def, argLists[i][0])
+		} else {
+			fn = b.constructWindowFn(w.def.Name, argLists[i])
+		}
 
 		if windowFrames[i].Bounds.StartBound.OffsetExpr != nil {
 			fn = b.factory.ConstructWindowFromOffset(
@@ -256,7 +261,11 @@ func (b *Builder) buildAggregationAsWindow(
 
 	// Build the arguments, partitions and orderings for each aggregate.
 	for i, agg := range g.aggs {
-		argExprs := getTypedExprs(agg.Exprs)
+		exprs := agg.Exprs
+		if isUserDefinedAggregate(agg.FuncExpr) {
+			exprs = userDefinedAggregateArgs(agg.FuncExpr)
+		}
+		argExprs := getTypedExprs(exprs)
 
 		// Build the appropriate arguments.
 		argLists[i] = b.buildWindowArgs(argExprs, i, agg.def.Name, fromScope, g.aggInScope)
@@ -283,7 +292,12 @@ func (b *Builder) buildAggregationAsWindow(
 	// so that we can group functions over the same partition and ordering.
 	frames := make([]memo.WindowExpr, 0, len(g.aggs))
 	for i, agg := range g.aggs {
-		fn := b.constructAggregate(agg.def.Name, argLists[i])
+		var fn opt.ScalarExpr
+		if isUserDefinedAggregate(agg.FuncExpr) {
+			fn = b.constructUserDefinedAggregate(agg.FuncExpr, &agg.def, argLists[i][0])
+		} else {
+			fn = b.constructAggregate(agg.def.Name, argLists[i])
+		}
 		if filterCols[i] != 0 {
 			fn = b.factory.ConstructAggFilter(
 				fn,
@@ -332,6 +346,9 @@ func (b *Builder) buildAggregationAsWindow(
 // projecting the default argument to some window functions when we could just
 // not do that projection.
 func (b *Builder) getTypedWindowArgs(w *windowInfo) []tree.TypedExpr {
+	if isUserDefinedAggregate(w.FuncExpr) {
+		return getTypedExprs(userDefinedAggregateArgs(w.FuncExpr))
+	}
 	argExprs := getTypedExprs(w.Exprs)
 
 	switch w.def.Name {
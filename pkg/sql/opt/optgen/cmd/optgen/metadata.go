@@ -191,11 +191,13 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"UniqueID":             {fullName: "opt.UniqueID", passByVal: true},
 		"WithID":               {fullName: "opt.WithID", passByVal: true},
 		"UDFDefinition":        {fullName: "memo.UDFDefinition", isPointer: true},
+		"UDADefinition":        {fullName: "memo.UDADefinition", isPointer: true},
 		"StoredProcTxnOp":      {fullName: "tree.StoredProcTxnOp", passByVal: true},
 		"TransactionModes":     {fullName: "tree.TransactionModes", passByVal: true},
 		"Ordering":             {fullName: "opt.Ordering", passByVal: true},
 		"OrderingChoice":       {fullName: "props.OrderingChoice", passByVal: true},
 		"GroupingOrder":        {fullName: "memo.GroupingOrder", passByVal: true},
+		"GroupingSets":         {fullName: "memo.GroupingSets", passByVal: true},
 		"TupleOrdinal":         {fullName: "memo.TupleOrdinal", passByVal: true},
 		"ScanLimit":            {fullName: "memo.ScanLimit", passByVal: true},
 		"ScanFlags":            {fullName: "memo.ScanFlags", passByVal: true},
@@ -211,6 +213,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"bool":                 {fullName: "bool", passByVal: true},
 		"int":                  {fullName: "int", passByVal: true},
 		"int64":                {fullName: "int64", passByVal: true},
+		"float64":              {fullName: "float64", passByVal: true},
 		"string":               {fullName: "string", passByVal: true},
 		"Type":                 {fullName: "types.T", isPointer: true},
 		"Datum":                {fullName: "tree.Datum", isInterface: true},
@@ -21,6 +21,17 @@ func scalarGroupByBuildChildReqOrdering(
 	return parent.(*memo.ScalarGroupByExpr).Ordering
 }
 
+func groupingSetsBuildChildReqOrdering(
+	parent memo.RelExpr, required *props.OrderingChoice, childIdx int,
+) props.OrderingChoice {
+	if childIdx != 0 {
+		return props.OrderingChoice{}
+	}
+	// GroupingSets only requires the intra-group ordering in its private; it
+	// never provides an ordering, since it is executed as a hash aggregation.
+	return parent.(*memo.GroupingSetsExpr).Ordering
+}
+
 func groupByCanProvideOrdering(expr memo.RelExpr, required *props.OrderingChoice) bool {
 	// GroupBy may require a certain ordering of its input, but can also pass
 	// through a stronger ordering on the grouping columns.
@@ -216,6 +216,11 @@ func init() {
 		buildChildReqOrdering: groupByBuildChildReqOrdering,
 		buildProvidedOrdering: groupByBuildProvided,
 	}
+	funcMap[opt.GroupingSetsOp] = funcs{
+		canProvideOrdering:    canNeverProvideOrdering,
+		buildChildReqOrdering: groupingSetsBuildChildReqOrdering,
+		buildProvidedOrdering: noProvidedOrdering,
+	}
 	funcMap[opt.DistinctOnOp] = funcs{
 		canProvideOrdering:    distinctOnCanProvideOrdering,
 		buildChildReqOrdering: distinctOnBuildChildReqOrdering,
@@ -361,6 +361,9 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		case *tree.IndexTableDef:
 			tab.addIndex(def, nonUniqueIndex)
 
+		case *tree.ExcludeConstraintTableDef:
+			tab.addExclusionConstraint(def)
+
 		case *tree.FamilyTableDef:
 			tab.addFamily(def)
 
@@ -701,6 +704,7 @@ func (tt *Table) addCheckConstraint(check *tree.CheckConstraintTableDef) {
 	}
 
 	tt.Checks = append(tt.Checks, &CheckConstraint{
+		name:           string(check.Name),
 		constraint:     serializeTableDefExpr(check.Expr),
 		validated:      validatedCheckConstraint(check),
 		columnOrdinals: columnOrdinals,
@@ -747,6 +751,43 @@ func (tt *Table) addUniqueConstraint(
 	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
 }
 
+// addExclusionConstraint adds an exclusion constraint to the table, along with
+// an index on its columns which supports it.
+func (tt *Table) addExclusionConstraint(def *tree.ExcludeConstraintTableDef) {
+	u := UniqueConstraint{
+		name:         string(def.Name),
+		tabID:        tt.TabID,
+		withoutIndex: true,
+		validated:    true,
+	}
+	if u.name == "" {
+		u.name = fmt.Sprintf("%s_excl", tt.TabName.Table())
+	}
+	idx := tree.IndexTableDef{Predicate: def.Predicate}
+	var overlaps *tree.IndexElem
+	for i := range def.Elems {
+		elem := &def.Elems[i]
+		u.columnOrdinals = append(u.columnOrdinals, tt.FindOrdinal(string(elem.Column)))
+		u.exclusionOperators = append(u.exclusionOperators, elem.Operator.Symbol)
+		if elem.Operator.Symbol == treecmp.Overlaps {
+			overlaps = &elem.IndexElem
+		} else {
+			idx.Columns = append(idx.Columns, tree.IndexElem{Column: elem.Column})
+		}
+	}
+	if def.Predicate != nil {
+		u.predicate = tree.Serialize(def.Predicate)
+	}
+	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
+
+	if overlaps != nil {
+		idx.Columns = append(idx.Columns, tree.IndexElem{Column: overlaps.Column})
+		col := tt.Columns[tt.FindOrdinal(string(overlaps.Column))]
+		idx.Inverted = colinfo.ColumnTypeIsInvertedIndexable(col.DatumType())
+	}
+	tt.addIndex(&idx, nonUniqueIndex)
+}
+
 func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 	ordinal := len(tt.Columns)
 	nullable := !def.PrimaryKey.IsPrimaryKey && def.Nullable.Nullability != tree.NotNull
@@ -948,6 +989,11 @@ func (tt *Table) addIndexWithVersion(
 						MaxCells: 3,
 					}},
 				}
+
+			case types.TSVectorFamily:
+				if val, ok := def.StorageParams.GetVal(`term_statistics`).(*tree.DBool); ok {
+					idx.termStatistics = bool(*val)
+				}
 			}
 		}
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
@@ -30,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
@@ -342,6 +344,13 @@ func (tc *Catalog) GetRoutineOwner(
 	return tc.GetCurrentUser(), nil
 }
 
+// IsConstraintDeferred is part of the cat.Catalog interface.
+func (tc *Catalog) IsConstraintDeferred(
+	ctx context.Context, tabID cat.StableID, name string,
+) bool {
+	return false
+}
+
 func (tc *Catalog) resolveSchema(toResolve *cat.SchemaName) (cat.Schema, cat.SchemaName, error) {
 	if string(toResolve.CatalogName) != testDB {
 		return nil, cat.SchemaName{}, pgerror.Newf(pgcode.InvalidSchemaName,
@@ -1120,6 +1129,10 @@ type Index struct {
 	// inverted index.
 	geoConfig geopb.Config
 
+	// termStatistics is true if this is an inverted index created with the
+	// term_statistics storage parameter.
+	termStatistics bool
+
 	// version is the index descriptor version of the index.
 	version descpb.IndexDescriptorVersion
 
@@ -1236,6 +1249,16 @@ func (ti *Index) GeoConfig() geopb.Config {
 	return ti.geoConfig
 }
 
+// VectorConfig is part of the cat.Index interface.
+func (ti *Index) VectorConfig() catpb.VectorIndexDescriptor {
+	return catpb.VectorIndexDescriptor{}
+}
+
+// HasTermStatistics is part of the cat.Index interface.
+func (ti *Index) HasTermStatistics() bool {
+	return ti.termStatistics
+}
+
 // Version is part of the cat.Index interface.
 func (ti *Index) Version() descpb.IndexDescriptorVersion {
 	return ti.version
@@ -1288,6 +1311,7 @@ func (p *Partition) SetDatums(datums []tree.Datums) {
 // CheckConstraint implements cat.CheckConstraint. See that interface
 // for more information on the fields.
 type CheckConstraint struct {
+	name           string
 	constraint     string
 	validated      bool
 	columnOrdinals []int
@@ -1295,6 +1319,11 @@ type CheckConstraint struct {
 
 var _ cat.CheckConstraint = &CheckConstraint{}
 
+// Name is part of the cat.CheckConstraint interface.
+func (c *CheckConstraint) Name() string {
+	return c.name
+}
+
 // Constraint is part of the cat.CheckConstraint interface.
 func (c *CheckConstraint) Constraint() string {
 	return c.constraint
@@ -1305,6 +1334,11 @@ func (c *CheckConstraint) Validated() bool {
 	return c.validated
 }
 
+// Deferrable is part of the cat.CheckConstraint interface.
+func (c *CheckConstraint) Deferrable() bool {
+	return false
+}
+
 // ColumnCount is part of the cat.CheckConstraint interface.
 func (c *CheckConstraint) ColumnCount() int {
 	return len(c.columnOrdinals)
@@ -1539,6 +1573,11 @@ func (fk *ForeignKeyConstraint) Validated() bool {
 	return fk.validated
 }
 
+// Deferrable is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) Deferrable() bool {
+	return false
+}
+
 // MatchMethod is part of the cat.ForeignKeyConstraint interface.
 func (fk *ForeignKeyConstraint) MatchMethod() tree.CompositeKeyMatchMethod {
 	return fk.matchMethod
@@ -1564,6 +1603,8 @@ type UniqueConstraint struct {
 	withoutIndex     bool
 	canUseTombstones bool
 	validated        bool
+
+	exclusionOperators []treecmp.ComparisonOperatorSymbol
 }
 
 var _ cat.UniqueConstraint = &UniqueConstraint{}
@@ -1611,12 +1652,27 @@ func (u *UniqueConstraint) Validated() bool {
 	return u.validated
 }
 
+// Deferrable is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) Deferrable() bool {
+	return false
+}
+
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
 // interface.
 func (u *UniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return false
 }
 
+// Exclusion is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) Exclusion() bool {
+	return len(u.exclusionOperators) > 0
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol {
+	return u.exclusionOperators[i]
+}
+
 // Sequence implements the cat.Sequence interface for testing purposes.
 type Sequence struct {
 	SeqID      cat.StableID
@@ -1722,6 +1778,7 @@ type Trigger struct {
 	TriggerForEachRow         bool
 	TriggerWhenExpr           string
 	TriggerFuncID             cat.StableID
+	TriggerBuiltinFuncOID     oid.Oid
 	TriggerFuncArgs           tree.Datums
 	TriggerFuncBody           string
 	TriggerEnabled            bool
@@ -1769,6 +1826,10 @@ func (t *Trigger) FuncID() cat.StableID {
 	return t.TriggerFuncID
 }
 
+func (t *Trigger) BuiltinFuncOID() oid.Oid {
+	return t.TriggerBuiltinFuncOID
+}
+
 func (t *Trigger) FuncArgs() tree.Datums {
 	return t.TriggerFuncArgs
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // CreateTrigger handles the CREATE TRIGGER statement.
@@ -58,6 +59,13 @@ func (tc *Catalog) CreateTrigger(n *tree.CreateTrigger) {
 	if n.When != nil {
 		whenExpr = tree.AsStringWithFlags(n.When, tree.FmtSerializable)
 	}
+	var funcID cat.StableID
+	var builtinFuncOID oid.Oid
+	if ol.Type == tree.BuiltinRoutine {
+		builtinFuncOID = ol.Oid
+	} else {
+		funcID = cat.StableID(catid.UserDefinedOIDToID(ol.Oid))
+	}
 	trigger := Trigger{
 		TriggerName:               n.Name,
 		TriggerActionTime:         n.ActionTime,
@@ -67,7 +75,8 @@ func (tc *Catalog) CreateTrigger(n *tree.CreateTrigger) {
 		TriggerOldTransitionAlias: oldTransitionAlias,
 		TriggerForEachRow:         n.ForEach == tree.TriggerForEachRow,
 		TriggerWhenExpr:           whenExpr,
-		TriggerFuncID:             cat.StableID(catid.UserDefinedOIDToID(ol.Oid)),
+		TriggerFuncID:             funcID,
+		TriggerBuiltinFuncOID:     builtinFuncOID,
 		TriggerFuncArgs:           funcArgs,
 		TriggerFuncBody:           ol.Body,
 		TriggerEnabled:            true,
@@ -215,6 +215,12 @@ const (
 	// random I/O required to insert rows into a sorted structure, the inherent
 	// batching in the LSM tree should amortize the cost.
 	spillCostFactor = seqIOCostFactor
+
+	// textSearchTopKPostingsPerRow is the estimated number of postings that a
+	// TextSearchTopK operator reads for every row that it returns. The number
+	// of postings that can be skipped depends on the distribution of the rank
+	// scores, which isn't known, so this is a rough guess.
+	textSearchTopKPostingsPerRow = 10
 )
 
 // fnCost maps some functions to an execution cost. Currently this list
@@ -544,6 +550,9 @@ func (c *coster) ComputeCost(candidate memo.RelExpr, required *physical.Required
 	case opt.InvertedFilterOp:
 		cost = c.computeInvertedFilterCost(candidate.(*memo.InvertedFilterExpr))
 
+	case opt.TextSearchTopKOp:
+		cost = c.computeTextSearchTopKCost(candidate.(*memo.TextSearchTopKExpr))
+
 	case opt.ValuesOp:
 		cost = c.computeValuesCost(candidate.(*memo.ValuesExpr))
 
@@ -576,6 +585,9 @@ func (c *coster) ComputeCost(candidate memo.RelExpr, required *physical.Required
 		opt.UpsertDistinctOnOp, opt.EnsureUpsertDistinctOnOp:
 		cost = c.computeGroupingCost(candidate, required)
 
+	case opt.GroupingSetsOp:
+		cost = c.computeGroupingSetsCost(candidate.(*memo.GroupingSetsExpr))
+
 	case opt.LimitOp:
 		cost = c.computeLimitCost(candidate.(*memo.LimitExpr))
 
@@ -679,6 +691,20 @@ func (c *coster) computeTopKCost(topk *memo.TopKExpr, required *physical.Require
 	return cost
 }
 
+func (c *coster) computeTextSearchTopKCost(topK *memo.TextSearchTopKExpr) memo.Cost {
+	rowCount := topK.Relational().Statistics().RowCount
+	postingCount := rowCount * textSearchTopKPostingsPerRow
+
+	// Add the IO cost of seeking into the postings of the query lexemes, and
+	// the cost of reading the postings.
+	perRowCost := c.rowScanCost(topK.Table, topK.Index, topK.Cols)
+	cost := memo.Cost(randIOCostFactor) + memo.Cost(postingCount)*(seqIOCostFactor+perRowCost)
+
+	// Add the CPU cost of maintaining the heap of the top K rows.
+	cost += memo.Cost(postingCount*math.Log2(math.Max(rowCount, 1)+1)) * cpuCostFactor
+	return cost
+}
+
 func (c *coster) computeSortCost(sort *memo.SortExpr, required *physical.Required) memo.Cost {
 	// We calculate the cost of a (potentially) segmented sort.
 	//
@@ -1460,6 +1486,28 @@ func (c *coster) computeGroupingCost(grouping memo.RelExpr, required *physical.R
 	return cost
 }
 
+func (c *coster) computeGroupingSetsCost(groupingSets *memo.GroupingSetsExpr) memo.Cost {
+	// Start with the same fixed overhead as the other grouping operators.
+	cost := memo.Cost(cpuCostFactor)
+
+	// Add the CPU cost of emitting the rows.
+	outputRowCount := groupingSets.Relational().Statistics().RowCount
+	cost += memo.Cost(outputRowCount) * cpuCostFactor
+
+	// Each input row is aggregated once for each grouping set, and is inserted
+	// into the hash table of each grouping set.
+	inputRowCount := groupingSets.Input.Relational().Statistics().RowCount
+	numSets := memo.Cost(len(groupingSets.Sets))
+	perRowCost := memo.Cost(len(groupingSets.Aggregations)+len(groupingSets.InputCols)) + 1
+	cost += memo.Cost(inputRowCount) * numSets * perRowCost * cpuCostFactor
+
+	// Add a cost for buffering rows that takes into account increased memory
+	// pressure and the possibility of spilling to disk.
+	cost += c.rowBufferCost(outputRowCount)
+
+	return cost
+}
+
 func (c *coster) computeLimitCost(limit *memo.LimitExpr) memo.Cost {
 	// Add the CPU cost of emitting the rows.
 	cost := memo.Cost(limit.Relational().Statistics().RowCount) * cpuCostFactor
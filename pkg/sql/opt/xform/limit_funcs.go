@@ -6,13 +6,19 @@
 package xform
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedidx"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/ordering"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -347,6 +353,301 @@ func (c *CustomFuncs) GenerateLimitedTopKScans(
 	})
 }
 
+// GenerateVectorSearchScans generates scans over vector indexes for a TopK
+// that orders the rows of a table by the distance between a vector column and
+// a constant query vector. Only the partitions of the index which are nearest
+// to the query vector are scanned, so the search is approximate. The number of
+// partitions that are scanned is controlled by the vector_search_probes
+// session setting, which trades recall for latency.
+//
+// If the index is covering, the TopK is applied to the projection of the
+// constrained index scan:
+//
+//	(TopK (Project (Scan $vectorIndexScanPrivate) $projections $passthrough))
+//
+// Otherwise, the distances are computed from the vector column stored in the
+// index, and an IndexJoin fetches the remaining columns of the K nearest rows:
+//
+//	(Project
+//	  (IndexJoin (TopK (Project (Scan $vectorIndexScanPrivate) [distance])))
+//	  $projections
+//	  $passthrough
+//	)
+func (c *CustomFuncs) GenerateVectorSearchScans(
+	grp memo.RelExpr,
+	required *physical.Required,
+	sp *memo.ScanPrivate,
+	projections memo.ProjectionsExpr,
+	passthrough opt.ColSet,
+	tp *memo.TopKPrivate,
+) {
+	probes := int(c.e.evalCtx.SessionData().VectorSearchProbes)
+	if probes <= 0 || len(tp.Ordering.Columns) == 0 || tp.Ordering.Columns[0].Descending {
+		return
+	}
+	distItem, vecCol, metric, query, ok := c.findVectorDistance(projections, tp.Ordering.Columns[0].Group)
+	if !ok {
+		return
+	}
+	md := c.e.mem.Metadata()
+	if len(query) != int(md.ColumnMeta(vecCol).Type.Width()) {
+		return
+	}
+	tab := md.Table(sp.Table)
+
+	var pkCols opt.ColSet
+	var iter scanIndexIter
+	iter.Init(c.e.evalCtx, c.e, c.e.mem, &c.im, sp, nil /* filters */, rejectPrimaryIndex|rejectInvertedIndexes|rejectPartialIndexes)
+	iter.ForEach(func(index cat.Index, filters memo.FiltersExpr, indexCols opt.ColSet, isCovering bool, constProj memo.ProjectionsExpr) {
+		cfg := index.VectorConfig()
+		if !cfg.IsVector || cfg.Metric != metric || index.ImplicitPartitioningColumnCount() > 0 {
+			return
+		}
+		if !isCovering && sp.Flags.NoIndexJoin {
+			return
+		}
+		// The vector index must be on the vector column of the distance.
+		if ord, ok := vectorIndexColumnOrdinal(tab, cfg); !ok || sp.Table.ColumnID(ord) != vecCol {
+			return
+		}
+
+		// Constrain the scan to the nearest partitions. Rows with NULL vectors
+		// are also scanned, since NULL distances sort first.
+		partitionCol := sp.Table.ColumnID(index.Column(0).Ordinal())
+		var cols constraint.Columns
+		cols.InitSingle(opt.MakeOrderingColumn(partitionCol, false /* descending */))
+		keyCtx := constraint.MakeKeyContext(c.e.ctx, &cols, c.e.evalCtx)
+		partitions := vector.ProbePartitions(query, int(cfg.Partitions), probes)
+		var spans constraint.Spans
+		spans.Alloc(len(partitions) + 1)
+		var span constraint.Span
+		nullKey := constraint.MakeKey(tree.DNull)
+		span.Init(nullKey, constraint.IncludeBoundary, nullKey, constraint.IncludeBoundary)
+		spans.Append(&span)
+		for _, p := range partitions {
+			key := constraint.MakeKey(tree.NewDInt(tree.DInt(p)))
+			span.Init(key, constraint.IncludeBoundary, key, constraint.IncludeBoundary)
+			spans.Append(&span)
+		}
+		var cons constraint.Constraint
+		cons.Init(&keyCtx, &spans)
+
+		newScanPrivate := *sp
+		newScanPrivate.Distribution.Regions = nil
+		newScanPrivate.Index = index.Ordinal()
+		newScanPrivate.SetConstraint(c.e.ctx, c.e.evalCtx, &cons)
+
+		if isCovering {
+			scan := c.e.f.ConstructScan(&newScanPrivate)
+			project := c.e.f.ConstructProject(scan, projections, passthrough)
+			grp.Memo().AddTopKToGroup(&memo.TopKExpr{Input: project, TopKPrivate: *tp}, grp)
+			return
+		}
+
+		// Scan the needed columns which are available from the index, the
+		// primary key columns and the vector column.
+		if pkCols.Empty() {
+			pkCols = c.PrimaryKeyCols(sp.Table)
+		}
+		newScanPrivate.Cols = indexCols.Intersection(sp.Cols)
+		newScanPrivate.Cols.UnionWith(pkCols)
+		newScanPrivate.Cols.Add(vecCol)
+
+		// Order the rows by a new distance column, followed by the remaining
+		// ordering columns, which must be available from the index.
+		distCol := md.AddColumn("distance", distItem.Typ)
+		var ordering props.OrderingChoice
+		ordering.Optional = tp.Ordering.Optional.Intersection(newScanPrivate.Cols)
+		ordering.Columns = make([]props.OrderingColumnChoice, 0, len(tp.Ordering.Columns))
+		ordering.Columns = append(ordering.Columns, props.OrderingColumnChoice{Group: opt.MakeColSet(distCol)})
+		for _, col := range tp.Ordering.Columns[1:] {
+			group := col.Group.Intersection(newScanPrivate.Cols)
+			if group.Empty() {
+				return
+			}
+			ordering.Columns = append(ordering.Columns, props.OrderingColumnChoice{
+				Group:      group,
+				Descending: col.Descending,
+			})
+		}
+
+		var input memo.RelExpr
+		input = c.e.f.ConstructScan(&newScanPrivate)
+		input = c.e.f.ConstructProject(
+			input,
+			memo.ProjectionsExpr{c.e.f.ConstructProjectionsItem(distItem.Element, distCol)},
+			newScanPrivate.Cols,
+		)
+		input = c.e.f.ConstructTopK(input, &memo.TopKPrivate{K: tp.K, Ordering: ordering})
+		input = c.e.f.ConstructIndexJoin(input, &memo.IndexJoinPrivate{
+			Table:   sp.Table,
+			Cols:    sp.Cols,
+			Locking: sp.Locking,
+		})
+		grp.Memo().AddProjectToGroup(&memo.ProjectExpr{
+			Input:       input,
+			Projections: projections,
+			Passthrough: passthrough,
+		}, grp)
+	})
+}
+
+// GenerateTextSearchTopK generates a TextSearchTopK for a TopK that orders the
+// rows of a table which match a constant text search query by their descending
+// ts_rank_tf or ts_rank_bm25 for the same query. The TextSearchTopK searches
+// an inverted index on the TSVECTOR column which stores term statistics for the
+// primary keys of the K highest ranked rows, and an IndexJoin fetches the
+// remaining columns of the rows. The filters and the projections are then applied to the
+// K rows, so the ranks are recomputed for the TopK:
+//
+//	(TopK
+//	  (Project
+//	    (Select (IndexJoin (TextSearchTopK $textSearchTopKPrivate)) $filters)
+//	    $projections
+//	    $passthrough
+//	  )
+//	)
+//
+// The search can only be used if the filters consist of the query alone, and
+// if the rows are ordered by the rank alone, since rows with the same rank may
+// be returned in any order. A ts_rank_bm25 rank must also read its collection
+// statistics from the searched index.
+func (c *CustomFuncs) GenerateTextSearchTopK(
+	grp memo.RelExpr,
+	required *physical.Required,
+	sp *memo.ScanPrivate,
+	filters memo.FiltersExpr,
+	projections memo.ProjectionsExpr,
+	passthrough opt.ColSet,
+	tp *memo.TopKPrivate,
+) {
+	if len(tp.Ordering.Columns) != 1 || !tp.Ordering.Columns[0].Descending || sp.Flags.NoIndexJoin {
+		return
+	}
+	rankGroup := tp.Ordering.Columns[0].Group
+
+	// The primary keys are decoded from the keys of the postings, so they can't
+	// have composite key encodings.
+	pkCols := c.PrimaryKeyCols(sp.Table)
+	for col, ok := pkCols.Next(0); ok; col, ok = pkCols.Next(col + 1) {
+		if colinfo.CanHaveCompositeKeyEncoding(c.e.mem.Metadata().ColumnMeta(col).Type) {
+			return
+		}
+	}
+
+	table := c.e.mem.Metadata().Table(sp.Table)
+	var iter scanIndexIter
+	iter.Init(c.e.evalCtx, c.e, c.e.mem, &c.im, sp, nil /* filters */, rejectNonInvertedIndexes|rejectPartialIndexes)
+	iter.ForEach(func(index cat.Index, _ memo.FiltersExpr, _ opt.ColSet, _ bool, _ memo.ProjectionsExpr) {
+		if !index.HasTermStatistics() || index.ImplicitPartitioningColumnCount() > 0 {
+			return
+		}
+		filterQuery, ok := invertedidx.MatchTSMatchesFilter(sp.Table, index, filters)
+		if !ok {
+			return
+		}
+		for i := range projections {
+			fn, ok := projections[i].Element.(*memo.FunctionExpr)
+			if !ok || !rankGroup.Contains(projections[i].Col) {
+				continue
+			}
+			private := memo.TextSearchTopKPrivate{
+				Table: sp.Table,
+				Index: index.Ordinal(),
+				Cols:  pkCols,
+				K:     tp.K,
+			}
+			query, tfParams, ok := invertedidx.MatchTFRank(sp.Table, index, fn)
+			if ok {
+				private.AvgDocLength, private.K1, private.B = tfParams.AvgDocLength, tfParams.K1, tfParams.B
+			} else {
+				var bm25Params tsearch.BM25Params
+				query, bm25Params, ok = invertedidx.MatchBM25Rank(sp.Table, table, index, fn)
+				private.BM25 = true
+				private.K1, private.B = bm25Params.K1, bm25Params.B
+			}
+			if !ok || query.String() != filterQuery.String() {
+				continue
+			}
+			private.Query = tree.NewDTSQuery(query)
+
+			var input memo.RelExpr
+			input = c.e.f.ConstructTextSearchTopK(&private)
+			input = c.e.f.ConstructIndexJoin(input, &memo.IndexJoinPrivate{
+				Table:   sp.Table,
+				Cols:    sp.Cols,
+				Locking: sp.Locking,
+			})
+			input = c.e.f.ConstructSelect(input, filters)
+			input = c.e.f.ConstructProject(input, projections, passthrough)
+			grp.Memo().AddTopKToGroup(&memo.TopKExpr{Input: input, TopKPrivate: *tp}, grp)
+			return
+		}
+	})
+}
+
+// findVectorDistance searches the projections for an item whose column is in
+// the given group and which computes the distance between a vector column and
+// a constant vector. It returns the item, the vector column, the distance
+// metric and the constant vector.
+func (c *CustomFuncs) findVectorDistance(
+	projections memo.ProjectionsExpr, group opt.ColSet,
+) (
+	item *memo.ProjectionsItem,
+	vecCol opt.ColumnID,
+	metric catpb.VectorIndexDescriptor_Metric,
+	query vector.T,
+	ok bool,
+) {
+	for i := range projections {
+		item = &projections[i]
+		if !group.Contains(item.Col) {
+			continue
+		}
+		var left, right opt.ScalarExpr
+		switch t := item.Element.(type) {
+		case *memo.VectorDistanceExpr:
+			left, right, metric = t.Left, t.Right, catpb.VectorIndexDescriptor_L2
+		case *memo.VectorCosDistanceExpr:
+			left, right, metric = t.Left, t.Right, catpb.VectorIndexDescriptor_COSINE
+		case *memo.VectorNegInnerProductExpr:
+			left, right, metric = t.Left, t.Right, catpb.VectorIndexDescriptor_INNER_PRODUCT
+		default:
+			return nil, 0, 0, nil, false
+		}
+		// The distance functions are symmetric, so the operands can be in
+		// either order.
+		if _, isVar := left.(*memo.VariableExpr); !isVar {
+			left, right = right, left
+		}
+		v, isVar := left.(*memo.VariableExpr)
+		if !isVar {
+			return nil, 0, 0, nil, false
+		}
+		constant, isConst := right.(*memo.ConstExpr)
+		if !isConst {
+			return nil, 0, 0, nil, false
+		}
+		d, isVector := constant.Value.(*tree.DPGVector)
+		if !isVector {
+			return nil, 0, 0, nil, false
+		}
+		return item, v.Col, metric, d.T, true
+	}
+	return nil, 0, 0, nil, false
+}
+
+// vectorIndexColumnOrdinal returns the ordinal of the vector column of a vector
+// index with the given configuration.
+func vectorIndexColumnOrdinal(tab cat.Table, cfg catpb.VectorIndexDescriptor) (int, bool) {
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		if tab.Column(i).ColID() == cat.StableID(cfg.ColumnID) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
 // getPrefixFromOrdering returns an OrderingChoice that holds the prefix
 // of Ordering o that satisfies part of the required OrderingChoice intraOrd,
 // a bool indicating whether the entire Ordering o was satisfied, and a bool
@@ -519,6 +519,13 @@ func (oc *optCatalog) GetRoutineOwner(
 	return fnDesc.FuncDesc().Privileges.Owner(), nil
 }
 
+// IsConstraintDeferred is part of the cat.Catalog interface.
+func (oc *optCatalog) IsConstraintDeferred(
+	ctx context.Context, tabID cat.StableID, name string,
+) bool {
+	return oc.planner.isConstraintDeferred(ctx, descpb.ID(tabID), name)
+}
+
 // dataSourceForDesc returns a data source wrapper for the given descriptor.
 // The wrapper might come from the cache, or it may be created now.
 func (oc *optCatalog) dataSourceForDesc(
@@ -866,7 +873,7 @@ func newOptTable(
 				col.GetType(),
 				col.IsNullable(),
 				visibility,
-				cd.DefaultExpr,
+				columnDefaultExpr(cd),
 				cd.ComputeExpr,
 				cd.OnUpdateExpr,
 				mapGeneratedAsIdentityType(col.GetGeneratedAsIdentityType()),
@@ -939,6 +946,20 @@ func newOptTable(
 			predicate:    u.GetPredicate(),
 			withoutIndex: true,
 			validity:     u.GetConstraintValidity(),
+			deferrable:   u.IsDeferrable(),
+		}
+		if u.IsExclusion() {
+			// The operators of an exclusion constraint correspond to its columns
+			// in the order in which they were declared.
+			uc := &ot.uniqueConstraints[i]
+			uc.columns = u.UniqueWithoutIndexDesc().ColumnIDs
+			uc.exclusionOperators = make([]treecmp.ComparisonOperatorSymbol, len(uc.columns))
+			for j, op := range u.ExclusionOperators() {
+				uc.exclusionOperators[j] = treecmp.EQ
+				if op == exclusionOperatorOverlaps {
+					uc.exclusionOperators[j] = treecmp.Overlaps
+				}
+			}
 		}
 	}
 
@@ -1051,6 +1072,7 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.IsDeferrable(),
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
@@ -1064,6 +1086,7 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.IsDeferrable(),
 		})
 	}
 
@@ -1108,8 +1131,10 @@ func newOptTable(
 	for i := range activeChecks {
 		check := activeChecks[i]
 		ot.checkConstraints = append(ot.checkConstraints, optCheckConstraint{
+			name:        check.GetName(),
 			constraint:  check.GetExpr(),
 			validated:   check.GetConstraintValidity() == descpb.ConstraintValidity_Validated,
+			deferrable:  check.IsDeferrable(),
 			columnCount: len(check.CheckDesc().ColumnIDs),
 			lookupColumnOrdinal: func(j int) (int, error) {
 				return ot.lookupColumnOrdinal(check.CheckDesc().ColumnIDs[j])
@@ -1752,6 +1777,16 @@ func (oi *optIndex) GeoConfig() geopb.Config {
 	return oi.idx.IndexDesc().GeoConfig
 }
 
+// VectorConfig is part of the cat.Index interface.
+func (oi *optIndex) VectorConfig() catpb.VectorIndexDescriptor {
+	return oi.idx.GetVector()
+}
+
+// HasTermStatistics is part of the cat.Index interface.
+func (oi *optIndex) HasTermStatistics() bool {
+	return oi.idx.HasTermStatistics()
+}
+
 // Version is part of the cat.Index interface.
 func (oi *optIndex) Version() descpb.IndexDescriptorVersion {
 	return oi.idx.GetVersion()
@@ -1795,8 +1830,10 @@ func (op *optPartition) PartitionByListPrefixes() []tree.Datums {
 // optCheckConstraint implements cat.CheckConstraint. See that interface
 // for more information on the fields.
 type optCheckConstraint struct {
+	name        string
 	constraint  string
 	validated   bool
+	deferrable  bool
 	columnCount int
 
 	// lookupColumnOrdinal returns the table column ordinal of the ith column in
@@ -1806,6 +1843,11 @@ type optCheckConstraint struct {
 
 var _ cat.CheckConstraint = &optCheckConstraint{}
 
+// Name is part of the cat.CheckConstraint interface.
+func (oc *optCheckConstraint) Name() string {
+	return oc.name
+}
+
 // Constraint is part of the cat.CheckConstraint interface.
 func (oc *optCheckConstraint) Constraint() string {
 	return oc.constraint
@@ -1813,7 +1855,12 @@ func (oc *optCheckConstraint) Constraint() string {
 
 // Validated is part of the cat.CheckConstraint interface.
 func (oc *optCheckConstraint) Validated() bool {
-	return oc.validated
+	return oc.validated && !oc.deferrable
+}
+
+// Deferrable is part of the cat.CheckConstraint interface.
+func (oc *optCheckConstraint) Deferrable() bool {
+	return oc.deferrable
 }
 
 // ColumnCount is part of the cat.CheckConstraint interface.
@@ -2010,6 +2057,11 @@ type optUniqueConstraint struct {
 	withoutIndex     bool
 	canUseTombstones bool
 	validity         descpb.ConstraintValidity
+	deferrable       bool
+
+	// exclusionOperators is set if this is an exclusion constraint, and holds
+	// the operator used to compare each of its columns.
+	exclusionOperators []treecmp.ComparisonOperatorSymbol
 
 	uniquenessGuaranteedByAnotherIndex bool
 }
@@ -2060,7 +2112,12 @@ func (u *optUniqueConstraint) CanUseTombstones() bool {
 
 // Validated is part of the cat.UniqueConstraint interface.
 func (u *optUniqueConstraint) Validated() bool {
-	return u.validity == descpb.ConstraintValidity_Validated
+	return u.validity == descpb.ConstraintValidity_Validated && !u.deferrable
+}
+
+// Deferrable is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrable() bool {
+	return u.deferrable
 }
 
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
@@ -2071,6 +2128,16 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// Exclusion is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Exclusion() bool {
+	return len(u.exclusionOperators) > 0
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol {
+	return u.exclusionOperators[i]
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -2088,6 +2155,7 @@ type optForeignKeyConstraint struct {
 	match        tree.CompositeKeyMatchMethod
 	deleteAction tree.ReferenceAction
 	updateAction tree.ReferenceAction
+	deferrable   bool
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -2141,7 +2209,12 @@ func (fk *optForeignKeyConstraint) ReferencedColumnOrdinal(referencedTable cat.T
 
 // Validated is part of the cat.ForeignKeyConstraint interface.
 func (fk *optForeignKeyConstraint) Validated() bool {
-	return fk.validity == descpb.ConstraintValidity_Validated
+	return fk.validity == descpb.ConstraintValidity_Validated && !fk.deferrable
+}
+
+// Deferrable is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrable() bool {
+	return fk.deferrable
 }
 
 // MatchMethod is part of the cat.ForeignKeyConstraint interface.
@@ -2412,8 +2485,10 @@ func (ot *optVirtualTable) CheckCount() int {
 func (ot *optVirtualTable) Check(i int) cat.CheckConstraint {
 	check := ot.desc.EnforcedCheckConstraints()[i]
 	return &optCheckConstraint{
+		name:        check.GetName(),
 		constraint:  check.GetExpr(),
 		validated:   check.GetConstraintValidity() == descpb.ConstraintValidity_Validated,
+		deferrable:  check.IsDeferrable(),
 		columnCount: len(check.CheckDesc().ColumnIDs),
 		lookupColumnOrdinal: func(j int) (int, error) {
 			return ot.lookupColumnOrdinal(check.CheckDesc().ColumnIDs[j])
@@ -2695,6 +2770,16 @@ func (oi *optVirtualIndex) GeoConfig() geopb.Config {
 	return geopb.Config{}
 }
 
+// VectorConfig is part of the cat.Index interface.
+func (oi *optVirtualIndex) VectorConfig() catpb.VectorIndexDescriptor {
+	return catpb.VectorIndexDescriptor{}
+}
+
+// HasTermStatistics is part of the cat.Index interface.
+func (oi *optVirtualIndex) HasTermStatistics() bool {
+	return false
+}
+
 // Version is part of the cat.Index interface.
 func (oi *optVirtualIndex) Version() descpb.IndexDescriptorVersion {
 	return 0
@@ -2767,6 +2852,7 @@ type optTrigger struct {
 	forEachRow         bool
 	whenExpr           string
 	funcID             cat.StableID
+	builtinFuncOID     oid.Oid
 	funcArgs           tree.Datums
 	funcBody           string
 	enabled            bool
@@ -2819,6 +2905,11 @@ func (o *optTrigger) FuncID() cat.StableID {
 	return o.funcID
 }
 
+// BuiltinFuncOID is part of the cat.Trigger interface.
+func (o *optTrigger) BuiltinFuncOID() oid.Oid {
+	return o.builtinFuncOID
+}
+
 // FuncArgs is part of the cat.Trigger interface.
 func (o *optTrigger) FuncArgs() tree.Datums {
 	return o.funcArgs
@@ -2860,6 +2951,7 @@ func getOptTriggers(descTriggers []descpb.TriggerDescriptor) []optTrigger {
 			forEachRow:         descTrigger.ForEachRow,
 			whenExpr:           descTrigger.WhenExpr,
 			funcID:             cat.StableID(descTrigger.FuncID),
+			builtinFuncOID:     descTrigger.BuiltinFuncOID,
 			funcArgs:           funcArgs,
 			funcBody:           descTrigger.FuncBody,
 			enabled:            descTrigger.Enabled,
@@ -2918,3 +3010,13 @@ func mapGeneratedAsIdentityType(inType catpb.GeneratedAsIdentityType) cat.Genera
 	}
 	return mapGeneratedAsIdentityType[inType]
 }
+
+// columnDefaultExpr returns the DEFAULT expression of the given column. If the
+// column has no DEFAULT expression of its own and its type is a domain, the
+// DEFAULT expression of the domain is returned.
+func columnDefaultExpr(cd *descpb.ColumnDescriptor) *string {
+	if cd.DefaultExpr != nil || !cd.Type.IsDomain() || cd.Type.TypeMeta.DomainData == nil {
+		return cd.DefaultExpr
+	}
+	return cd.Type.TypeMeta.DomainData.DefaultExpr
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -551,6 +552,33 @@ func (ef *execFactory) ConstructGroupBy(
 	return n, nil
 }
 
+// ConstructGroupingSets is part of the exec.Factory interface.
+func (ef *execFactory) ConstructGroupingSets(
+	input exec.Node,
+	groupCols []exec.NodeColumnOrdinal,
+	sets []exec.NodeColumnOrdinalSet,
+	aggregations []exec.AggInfo,
+	estimatedRowCount uint64,
+) (exec.Node, error) {
+	inputPlan := input.(planNode)
+	n := &groupNode{
+		plan:  inputPlan,
+		funcs: make([]*aggregateFuncHolder, 0, len(aggregations)),
+		columns: getResultColumnsForGroupingSets(
+			planColumns(inputPlan), groupCols, aggregations,
+		),
+		groupCols:         groupCols,
+		groupingSets:      sets,
+		estimatedRowCount: estimatedRowCount,
+	}
+	// Unlike with GroupBy, the values of the grouping columns are produced by
+	// the aggregator, so we don't add any_not_null aggregations for them.
+	if err := ef.addAggregations(n, aggregations); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
 func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo) error {
 	for i := range aggregations {
 		agg := &aggregations[i]
@@ -563,6 +591,7 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.DistsqlBlocklist,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		f.userDefined = agg.UserDefined
 
 		n.funcs = append(n.funcs, f)
 	}
@@ -1078,6 +1107,54 @@ func (ef *execFactory) ConstructTopK(
 	}, nil
 }
 
+// ConstructTextSearchTopK is part of the exec.Factory interface.
+func (ef *execFactory) ConstructTextSearchTopK(
+	table cat.Table,
+	index cat.Index,
+	keyCols exec.TableColumnOrdinalSet,
+	query *tree.DTSQuery,
+	k int64,
+	bm25 bool,
+	avgDocLength, k1, b float64,
+) (exec.Node, error) {
+	tabDesc := table.(*optTable).desc
+	idx := index.(*optIndex).idx
+	terms, ok := tsearch.TopKSearchTerms(query.TSQuery)
+	if !ok {
+		return nil, errors.AssertionFailedf("unsupported text search top-k query %s", query)
+	}
+	cols := makeColList(table, keyCols)
+
+	if !ef.isExplain && !ef.planner.SessionData().Internal {
+		idxUsageKey := roachpb.IndexUsageKey{
+			TableID: roachpb.TableID(tabDesc.GetID()),
+			IndexID: roachpb.IndexID(idx.GetID()),
+		}
+		ef.planner.extendedEvalCtx.indexUsageStats.RecordRead(idxUsageKey)
+	}
+
+	n := &textSearchTopKNode{
+		desc:    tabDesc,
+		index:   idx,
+		query:   query.TSQuery,
+		terms:   terms,
+		k:       int(k),
+		bm25:    bm25,
+		params:  tsearch.TFRankParams{AvgDocLength: avgDocLength, K1: k1, B: b},
+		columns: colinfo.ResultColumnsFromColumns(tabDesc.GetID(), cols),
+	}
+	n.suffixToOutput = make([]int, idx.NumKeySuffixColumns())
+	for i := range n.suffixToOutput {
+		n.suffixToOutput[i] = -1
+		for j := range cols {
+			if cols[j].GetID() == idx.GetKeySuffixColumnID(i) {
+				n.suffixToOutput[i] = j
+			}
+		}
+	}
+	return n, nil
+}
+
 // ConstructMax1Row is part of the exec.Factory interface.
 func (ef *execFactory) ConstructMax1Row(input exec.Node, errorText string) (exec.Node, error) {
 	plan := input.(planNode)
@@ -1166,6 +1243,9 @@ func (ef *execFactory) ConstructWindow(root exec.Node, wi exec.WindowInfo) (exec
 			columnOrdering: wi.Ordering,
 			frame:          wi.Exprs[i].WindowDef.Frame,
 		}
+		if wi.UserDefined != nil {
+			p.funcs[i].userDefined = wi.UserDefined[i]
+		}
 		if len(wi.Ordering) == 0 {
 			frame := p.funcs[i].frame
 			if frame.Mode == treewindow.RANGE && frame.Bounds.HasOffset() {
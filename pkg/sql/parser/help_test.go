@@ -100,6 +100,19 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER TYPE t RENAME ??`, `ALTER TYPE`},
 		{`ALTER TYPE t DROP VALUE ??`, `ALTER TYPE`},
 
+		{`ALTER DOMAIN ??`, `ALTER DOMAIN`},
+		{`ALTER DOMAIN d ??`, `ALTER DOMAIN`},
+		{`ALTER DOMAIN d SET ??`, `ALTER DOMAIN`},
+
+		{`ALTER PUBLICATION ??`, `ALTER PUBLICATION`},
+		{`ALTER PUBLICATION p ADD ??`, `ALTER PUBLICATION`},
+
+		{`ALTER TEXT SEARCH ??`, `ALTER TEXT SEARCH`},
+		{`ALTER TEXT SEARCH CONFIGURATION c ADD ??`, `ALTER TEXT SEARCH`},
+
+		{`ALTER AGGREGATE ??`, `ALTER AGGREGATE`},
+		{`ALTER AGGREGATE a(INT) ??`, `ALTER AGGREGATE`},
+
 		{`ALTER INDEX foo@bar RENAME ??`, `ALTER INDEX`},
 		{`ALTER INDEX foo@bar RENAME TO blih ??`, `ALTER INDEX`},
 		{`ALTER INDEX foo@bar SPLIT ??`, `ALTER INDEX`},
@@ -204,6 +217,18 @@ func TestContextualHelp(t *testing.T) {
 
 		{`CREATE TYPE blah AS ENUM ??`, `CREATE TYPE`},
 		{`DROP TYPE ??`, `DROP TYPE`},
+		{`CREATE DOMAIN ??`, `CREATE DOMAIN`},
+		{`CREATE DOMAIN d AS ??`, `CREATE DOMAIN`},
+		{`DROP DOMAIN ??`, `DROP DOMAIN`},
+		{`CREATE PUBLICATION ??`, `CREATE PUBLICATION`},
+		{`CREATE PUBLICATION p FOR ??`, `CREATE PUBLICATION`},
+		{`DROP PUBLICATION ??`, `DROP PUBLICATION`},
+		{`CREATE TEXT SEARCH ??`, `CREATE TEXT SEARCH`},
+		{`CREATE TEXT SEARCH DICTIONARY d (TEMPLATE = ??`, `CREATE TEXT SEARCH`},
+		{`DROP TEXT SEARCH ??`, `DROP TEXT SEARCH`},
+		{`CREATE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`CREATE AGGREGATE a(INT) (??`, `CREATE AGGREGATE`},
+		{`DROP AGGREGATE ??`, `DROP AGGREGATE`},
 
 		{`CREATE SCHEMA IF ??`, `CREATE SCHEMA`},
 		{`CREATE SCHEMA IF NOT ??`, `CREATE SCHEMA`},
@@ -217,6 +242,9 @@ func TestContextualHelp(t *testing.T) {
 		{`DISCARD ALL ??`, `DISCARD`},
 		{`DISCARD ??`, `DISCARD`},
 
+		{`DO ??`, `DO`},
+		{`DO LANGUAGE plpgsql ??`, `DO`},
+
 		{`DROP ??`, `DROP`},
 
 		{`DROP DATABASE IF ??`, `DROP DATABASE`},
@@ -501,6 +529,11 @@ func TestContextualHelp(t *testing.T) {
 		{`TRUNCATE foo ??`, `TRUNCATE`},
 		{`TRUNCATE foo, ??`, `TRUNCATE`},
 
+		{`LISTEN ??`, `LISTEN`},
+		{`NOTIFY ??`, `NOTIFY`},
+		{`NOTIFY foo, ??`, `NOTIFY`},
+		{`UNLISTEN ??`, `UNLISTEN`},
+
 		{`SELECT 1 ??`, `SELECT`},
 		{`SELECT * FROM ??`, `<SOURCE>`},
 		{`SELECT 1 AS OF ??`, `SELECT`},
@@ -526,6 +559,9 @@ func TestContextualHelp(t *testing.T) {
 		{`SET LOCAL TIME ??`, `SET LOCAL`},
 		{`SET LOCAL TIME ZONE 'UTC' ??`, `SET LOCAL`},
 
+		{`SET CONSTRAINTS ??`, `SET CONSTRAINTS`},
+		{`SET CONSTRAINTS ALL ??`, `SET CONSTRAINTS`},
+
 		{`SET TRANSACTION ??`, `SET TRANSACTION`},
 		{`SET TRANSACTION ISOLATION LEVEL SNAPSHOT ??`, `SET TRANSACTION`},
 		{`SET TIME ??`, `SET SESSION`},
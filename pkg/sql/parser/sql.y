@@ -264,6 +264,9 @@ func (u *sqlSymUnion) auditMode() tree.AuditMode {
 func (u *sqlSymUnion) bool() bool {
     return u.val.(bool)
 }
+func (u *sqlSymUnion) indexType() tree.IndexType {
+    return u.val.(tree.IndexType)
+}
 func (u *sqlSymUnion) strPtr() *string {
     return u.val.(*string)
 }
@@ -634,12 +637,21 @@ func (u *sqlSymUnion) readWriteMode() tree.ReadWriteMode {
 func (u *sqlSymUnion) deferrableMode() tree.DeferrableMode {
     return u.val.(tree.DeferrableMode)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+    return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) idxElem() tree.IndexElem {
     return u.val.(tree.IndexElem)
 }
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
+func (u *sqlSymUnion) excludeElem() tree.ExcludeElem {
+    return u.val.(tree.ExcludeElem)
+}
+func (u *sqlSymUnion) excludeElems() tree.ExcludeElemList {
+    return u.val.(tree.ExcludeElemList)
+}
 func (u *sqlSymUnion) indexInvisibility() tree.IndexInvisibility {
     return u.val.(tree.IndexInvisibility)
 }
@@ -788,6 +800,27 @@ func (u *sqlSymUnion) typeReferences() []tree.ResolvableTypeReference {
 func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacement {
     return u.val.(*tree.AlterTypeAddValuePlacement)
 }
+func (u *sqlSymUnion) alterDomainCmd() tree.AlterDomainCmd {
+    return u.val.(tree.AlterDomainCmd)
+}
+func (u *sqlSymUnion) textSearchObjectKind() tree.TextSearchObjectKind {
+    return u.val.(tree.TextSearchObjectKind)
+}
+func (u *sqlSymUnion) textSearchOption() tree.TextSearchOption {
+    return u.val.(tree.TextSearchOption)
+}
+func (u *sqlSymUnion) textSearchOptions() tree.TextSearchOptions {
+    return u.val.(tree.TextSearchOptions)
+}
+func (u *sqlSymUnion) alterTextSearchCmd() tree.AlterTextSearchCmd {
+    return u.val.(tree.AlterTextSearchCmd)
+}
+func (u *sqlSymUnion) domainConstraintDef() *tree.DomainConstraintDef {
+    return u.val.(*tree.DomainConstraintDef)
+}
+func (u *sqlSymUnion) domainConstraintDefs() tree.DomainConstraintDefs {
+    return u.val.(tree.DomainConstraintDefs)
+}
 func (u *sqlSymUnion) scheduleState() tree.ScheduleState {
   return u.val.(tree.ScheduleState)
 }
@@ -863,6 +896,12 @@ func (u *sqlSymUnion) functionObj() tree.RoutineObj {
 func (u *sqlSymUnion) routineObjs() tree.RoutineObjs {
     return u.val.(tree.RoutineObjs)
 }
+func (u *sqlSymUnion) aggregateOption() tree.AggregateOption {
+    return u.val.(tree.AggregateOption)
+}
+func (u *sqlSymUnion) aggregateOptions() tree.AggregateOptions {
+    return u.val.(tree.AggregateOptions)
+}
 func (u *sqlSymUnion) tenantReplicationOptions() *tree.TenantReplicationOptions {
   return u.val.(*tree.TenantReplicationOptions)
 }
@@ -943,7 +982,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 
 %token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLOSE
-%token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
+%token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMBINEFUNC COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONNECTION CONNECTIONS CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
 %token <str> CONVERSION CONVERT COPY COS_DISTANCE COST COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
@@ -953,7 +992,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_IDS DEC DEBUG_DUMP_METADATA_SST DECIMAL DEFAULT DEFAULTS DEFINER
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
-%token <str> DISCARD DISTANCE DISTINCT DO DOMAIN DOUBLE DROP
+%token <str> DICTIONARY DISCARD DISTANCE DISTINCT DLQ DO DOMAIN DOUBLE DROP
 
 %token <str> EACH ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
@@ -962,12 +1001,12 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %token <str> EXPIRATION EXPLAIN EXPORT EXTENSION EXTERNAL EXTRACT EXTRACT_DURATION EXTREMES
 
 %token <str> FAILURE FALSE FAMILY FETCH FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH
-%token <str> FILES FILTER
+%token <str> FILES FILTER FINALFUNC
 %token <str> FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE FORCE_INDEX FORCE_INVERTED_INDEX
 %token <str> FORCE_NOT_NULL FORCE_NULL FORCE_QUOTE FORCE_ZIGZAG
 %token <str> FOREIGN FORMAT FORWARD FREEZE FROM FULL FUNCTION FUNCTIONS
 
-%token <str> GENERATED GEOGRAPHY GEOMETRY GEOMETRYM GEOMETRYZ GEOMETRYZM
+%token <str> GENERATED GEOGRAPHY GEOMETRIC_INTERSECTS GEOMETRY GEOMETRYM GEOMETRYZ GEOMETRYZM
 %token <str> GEOMETRYCOLLECTION GEOMETRYCOLLECTIONM GEOMETRYCOLLECTIONZ GEOMETRYCOLLECTIONZM
 %token <str> GLOBAL GOAL GRANT GRANTEE GRANTS GREATEST GROUP GROUPING GROUPS
 
@@ -977,21 +1016,21 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMEDIATELY IMMUTABLE IMPORT IN INCLUDE
 %token <str> INCLUDING INCLUDE_ALL_SECONDARY_TENANTS INCLUDE_ALL_VIRTUAL_CLUSTERS INCREMENT INCREMENTAL INCREMENTAL_LOCATION
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
-%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
+%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INITCOND INJECT INITIALLY
 %token <str> INDEX_BEFORE_PAREN INDEX_BEFORE_NAME_THEN_PAREN INDEX_AFTER_ORDER_BY_BEFORE_AT
 %token <str> INNER INOUT INPUT INSENSITIVE INSERT INSTEAD INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED INVOKER IS ISERROR ISNULL ISOLATION
 
-%token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
+%token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS JSON_PATH_EXISTS
 
 %token <str> KEY KEYS KMS KV
 
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGICAL LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LISTEN LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGICAL LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MODE MONTH MOVE
+%token <str> MAPPING MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MODE MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
@@ -999,7 +1038,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %token <str> NAN NAME NAMES NATURAL NEG_INNER_PRODUCT NEVER NEW NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NODE NOLOGIN NOMODIFYCLUSTERSETTING NOREPLICATION
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT
-%token <str> NOTHING NOTHING_AFTER_RETURNING
+%token <str> NOTHING NOTHING_AFTER_RETURNING NOTIFY
 %token <str> NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
@@ -1013,7 +1052,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 
 %token <str> QUERIES QUERY QUOTE
 
-%token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFERENCING REFRESH
+%token <str> RANGE RANGES RANGE_ADJACENT READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFERENCING REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH REMOVE_REGIONS RENAME REPEATABLE REPLACE REPLICATION
 %token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
@@ -1022,10 +1061,10 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
 %token <str> SEARCH SECOND SECONDARY SECURITY SELECT SEQUENCE SEQUENCES
 %token <str> SERIALIZABLE SERVER SERVICE SESSION SESSIONS SESSION_USER SET SETOF SETS SETTING SETTINGS
-%token <str> SHARE SHARED SHOW SIMILAR SIMPLE SIZE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
+%token <str> SFUNC SHARE SHARED SHOW SIMILAR SIMPLE SIZE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SKIP_MISSING_UDFS SMALLINT SMALLSERIAL
 %token <str> SNAPSHOT SOME SPLIT SQL SQLLOGIN
-%token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING SUBJECT SUBSTRING SUPER
+%token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING STYPE SUBJECT SUBSTRING SUPER
 %token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
 
 %token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
@@ -1066,8 +1105,10 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 // references.
 // - TENANT_ALL is used to differentiate `ALTER TENANT <id>` from
 // `ALTER TENANT ALL`. Ditto `CLUSTER_ALL` and `CLUSTER ALL`.
+// - NOT_DEFERRABLE is needed to distinguish NOT DEFERRABLE following a
+// constraint from NOT NULL and NOT VALID.
 %token NOT_LA NULLS_LA WITH_LA AS_LA GENERATED_ALWAYS GENERATED_BY_DEFAULT RESET_ALL ROLE_ALL
-%token USER_ALL ON_LA TENANT_ALL CLUSTER_ALL SET_TRACING
+%token USER_ALL ON_LA TENANT_ALL CLUSTER_ALL SET_TRACING NOT_DEFERRABLE
 
 %union {
   id    int32
@@ -1094,8 +1135,11 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <tree.Statement> alter_role_stmt
 %type <*tree.SetVar> set_or_reset_clause
 %type <tree.Statement> alter_type_stmt
+%type <tree.Statement> alter_domain_stmt
+%type <tree.Statement> alter_text_search_stmt
+%type <tree.Statement> alter_publication_stmt
 %type <tree.Statement> alter_schema_stmt
-%type <tree.Statement> alter_unsupported_stmt
+%type <tree.Statement> alter_aggregate_stmt
 %type <tree.Statement> alter_func_stmt
 %type <tree.Statement> alter_proc_stmt
 
@@ -1194,6 +1238,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <tree.Statement> begin_stmt
 
 %type <tree.Statement> call_stmt
+%type <tree.Statement> do_stmt
 
 %type <tree.Statement> cancel_stmt
 %type <tree.Statement> cancel_jobs_stmt
@@ -1231,6 +1276,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <tree.Statement> create_logical_replication_stream_stmt
 %type <tree.Statement> create_view_stmt
 %type <tree.Statement> create_sequence_stmt
+%type <tree.Statement> create_aggregate_stmt
 %type <tree.Statement> create_func_stmt
 %type <tree.Statement> create_proc_stmt
 %type <tree.Statement> create_trigger_stmt
@@ -1244,6 +1290,9 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <*tree.CreateStatsOptions> create_stats_option
 
 %type <tree.Statement> create_type_stmt
+%type <tree.Statement> create_domain_stmt
+%type <tree.Statement> create_text_search_stmt
+%type <tree.Statement> create_publication_stmt
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
 
@@ -1256,8 +1305,12 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
 %type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_domain_stmt
+%type <tree.Statement> drop_text_search_stmt
+%type <tree.Statement> drop_publication_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
+%type <tree.Statement> drop_aggregate_stmt
 %type <tree.Statement> drop_func_stmt
 %type <tree.Statement> drop_proc_stmt
 %type <tree.Statement> drop_trigger_stmt
@@ -1300,6 +1353,8 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt set_or_reset_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
+%type <bool> constraints_set_mode
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -1366,6 +1421,8 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <tree.Statement> transaction_stmt legacy_transaction_stmt legacy_begin_stmt legacy_end_stmt
 %type <tree.Statement> truncate_stmt
 %type <tree.Statement> unlisten_stmt
+%type <tree.Statement> listen_stmt
+%type <tree.Statement> notify_stmt
 %type <tree.Statement> update_stmt
 %type <tree.Statement> upsert_stmt
 %type <tree.Statement> use_stmt
@@ -1445,6 +1502,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <tree.UserPriority> transaction_user_priority
 %type <tree.ReadWriteMode> transaction_read_mode
 %type <tree.DeferrableMode> transaction_deferrable_mode
+%type <tree.ConstraintDeferrability> opt_deferrable
 
 %type <str> name opt_name opt_name_parens
 %type <str> privilege savepoint_name
@@ -1551,7 +1609,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <*tree.TenantSpec> virtual_cluster_spec virtual_cluster_spec_opt_all
 
 %type <bool> opt_unique opt_concurrently opt_cluster opt_without_index
-%type <bool> opt_index_access_method
+%type <tree.IndexType> opt_index_access_method
 
 %type <*tree.Limit> limit_clause offset_clause opt_limit_clause
 %type <tree.Expr> select_fetch_first_value
@@ -1595,6 +1653,8 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <bool> opt_ordinality opt_compact
 %type <*tree.Order> sortby sortby_index
 %type <tree.IndexElem> index_elem index_elem_options create_as_param
+%type <tree.ExcludeElemList> exclude_elems
+%type <tree.ExcludeElem> exclude_elem
 %type <tree.TableExpr> table_ref numeric_table_ref func_table
 %type <tree.Exprs> rowsfrom_list
 %type <tree.Expr> rowsfrom_item
@@ -1615,6 +1675,16 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <tree.ResolvableTypeReference> typename simple_typename cast_target
 %type <*types.T> const_typename
 %type <*tree.AlterTypeAddValuePlacement> opt_add_val_placement
+%type <tree.AlterDomainCmd> alter_domain_cmd
+%type <tree.TextSearchObjectKind> text_search_kind
+%type <tree.TextSearchOptions> text_search_option_list
+%type <tree.TextSearchOption> text_search_option
+%type <str> text_search_option_value
+%type <tree.AlterTextSearchCmd> alter_text_search_config_cmd alter_text_search_dict_cmd
+%type <*tree.DomainConstraintDef> domain_constraint domain_constraint_elem
+%type <tree.AggregateOptions> aggregate_option_list
+%type <tree.AggregateOption> aggregate_option
+%type <tree.DomainConstraintDefs> domain_constraint_list
 %type <bool> opt_timezone
 %type <*types.T> numeric opt_numeric_modifiers
 %type <*types.T> opt_float
@@ -1722,10 +1792,10 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <privilege.TargetObjectType> target_object_type
 
 // Routine (UDF/SP) relevant components.
-%type <bool> opt_or_replace opt_return_table opt_return_set opt_no
+%type <bool> opt_or_replace opt_return_set opt_no
 %type <str> param_name routine_as
-%type <tree.RoutineParams> opt_routine_param_with_default_list routine_param_with_default_list func_params func_params_list
-%type <tree.RoutineParam> routine_param_with_default routine_param
+%type <tree.RoutineParams> opt_routine_param_with_default_list routine_param_with_default_list func_params func_params_list table_func_column_list
+%type <tree.RoutineParam> routine_param_with_default routine_param table_func_column
 %type <tree.ResolvableTypeReference> routine_return_type routine_param_type
 %type <tree.RoutineOptions> opt_create_routine_opt_list create_routine_opt_list alter_func_opt_list
 %type <tree.RoutineOption> create_routine_opt_item common_routine_opt_item
@@ -1766,7 +1836,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %nonassoc  '<' '>' '=' LESS_EQUALS GREATER_EQUALS NOT_EQUALS
 %nonassoc  '~' BETWEEN IN LIKE ILIKE SIMILAR NOT_REGMATCH REGIMATCH NOT_REGIMATCH NOT_LA
 %nonassoc  ESCAPE              // ESCAPE must be just above LIKE/ILIKE/SIMILAR
-%nonassoc  CONTAINS CONTAINED_BY '?' JSON_SOME_EXISTS JSON_ALL_EXISTS
+%nonassoc  CONTAINS CONTAINED_BY '?' JSON_SOME_EXISTS JSON_ALL_EXISTS JSON_PATH_EXISTS
 %nonassoc  OVERLAPS
 %left      POSTFIXOP           // dummy for postfix OP rules
 // To support target_elem without AS, we must give IDENT an explicit priority
@@ -1798,7 +1868,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %left      '|'
 %left      '#'
 %left      '&'
-%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND SQRT CBRT
+%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND RANGE_ADJACENT GEOMETRIC_INTERSECTS SQRT CBRT
 %left      OPERATOR // if changing the last token before OPERATOR, change all instances of %prec <last token>
 %left      '+' '-'
 %left      '*' '/' FLOORDIV '%'
@@ -1848,6 +1918,7 @@ stmt_without_legacy_transaction:
 | execute_stmt               // EXTEND WITH HELP: EXECUTE
 | deallocate_stmt            // EXTEND WITH HELP: DEALLOCATE
 | discard_stmt               // EXTEND WITH HELP: DISCARD
+| do_stmt                    // EXTEND WITH HELP: DO
 | grant_stmt                 // EXTEND WITH HELP: GRANT
 | prepare_stmt               // EXTEND WITH HELP: PREPARE
 | revoke_stmt                // EXTEND WITH HELP: REVOKE
@@ -1863,7 +1934,9 @@ stmt_without_legacy_transaction:
 | fetch_cursor_stmt          // EXTEND WITH HELP: FETCH
 | move_cursor_stmt           // EXTEND WITH HELP: MOVE
 | reindex_stmt
-| unlisten_stmt
+| listen_stmt                // EXTEND WITH HELP: LISTEN
+| notify_stmt                // EXTEND WITH HELP: NOTIFY
+| unlisten_stmt              // EXTEND WITH HELP: UNLISTEN
 | show_commit_timestamp_stmt // EXTEND WITH HELP: SHOW COMMIT TIMESTAMP
 
 // %Help: ALTER
@@ -1873,7 +1946,6 @@ alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_role_stmt     // EXTEND WITH HELP: ALTER ROLE
 | alter_virtual_cluster_stmt   /* SKIP DOC */
-| alter_unsupported_stmt
 | ALTER error         // SHOW HELP: ALTER
 
 alter_ddl_stmt:
@@ -1886,11 +1958,15 @@ alter_ddl_stmt:
 | alter_partition_stmt          // EXTEND WITH HELP: ALTER PARTITION
 | alter_schema_stmt             // EXTEND WITH HELP: ALTER SCHEMA
 | alter_type_stmt               // EXTEND WITH HELP: ALTER TYPE
+| alter_domain_stmt             // EXTEND WITH HELP: ALTER DOMAIN
+| alter_text_search_stmt        // EXTEND WITH HELP: ALTER TEXT SEARCH
+| alter_publication_stmt        // EXTEND WITH HELP: ALTER PUBLICATION
 | alter_default_privileges_stmt // EXTEND WITH HELP: ALTER DEFAULT PRIVILEGES
 | alter_changefeed_stmt         // EXTEND WITH HELP: ALTER CHANGEFEED
 | alter_backup_stmt             // EXTEND WITH HELP: ALTER BACKUP
 | alter_func_stmt               // EXTEND WITH HELP: ALTER FUNCTION
 | alter_proc_stmt               // EXTEND WITH HELP: ALTER PROCEDURE
+| alter_aggregate_stmt          // EXTEND WITH HELP: ALTER AGGREGATE
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
 
 // %Help: ALTER TABLE - change the definition of a table
@@ -2107,6 +2183,43 @@ alter_proc_stmt:
 | alter_proc_set_schema_stmt
 | ALTER PROCEDURE error // SHOW HELP: ALTER PROCEDURE
 
+// %Help: ALTER AGGREGATE - change the definition of an aggregate function
+// %Category: DDL
+// %Text:
+// ALTER AGGREGATE name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//    RENAME TO new_name
+// ALTER AGGREGATE name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//    OWNER TO { new_owner | CURRENT_USER | SESSION_USER }
+// ALTER AGGREGATE name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//    SET SCHEMA new_schema
+// %SeeAlso: CREATE AGGREGATE, DROP AGGREGATE
+alter_aggregate_stmt:
+  ALTER AGGREGATE function_with_paramtypes RENAME TO name
+  {
+    $$.val = &tree.AlterRoutineRename{
+      Function: $3.functionObj(),
+      NewName: tree.Name($6),
+      Aggregate: true,
+    }
+  }
+| ALTER AGGREGATE function_with_paramtypes OWNER TO role_spec
+  {
+    $$.val = &tree.AlterRoutineSetOwner{
+      Function: $3.functionObj(),
+      NewOwner: $6.roleSpec(),
+      Aggregate: true,
+    }
+  }
+| ALTER AGGREGATE function_with_paramtypes SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterRoutineSetSchema{
+      Function: $3.functionObj(),
+      NewSchemaName: tree.Name($6),
+      Aggregate: true,
+    }
+  }
+| ALTER AGGREGATE error // SHOW HELP: ALTER AGGREGATE
+
 // ALTER DATABASE has its error help token here because the ALTER DATABASE
 // prefix is spread over multiple non-terminals.
 | ALTER DATABASE error // SHOW HELP: ALTER DATABASE
@@ -3217,6 +3330,93 @@ alter_type_stmt:
   }
 | ALTER TYPE error // SHOW HELP: ALTER TYPE
 
+// %Help: ALTER DOMAIN - change the definition of a domain.
+// %Category: DDL
+// %Text: ALTER DOMAIN <typename> <command>
+//
+// Commands:
+//   ALTER DOMAIN ... { SET DEFAULT <expr> | DROP DEFAULT }
+//   ALTER DOMAIN ... { SET | DROP } NOT NULL
+//   ALTER DOMAIN ... ADD [CONSTRAINT <name>] CHECK (<expr>)
+//   ALTER DOMAIN ... DROP CONSTRAINT [IF EXISTS] <name> [ CASCADE | RESTRICT ]
+//   ALTER DOMAIN ... RENAME CONSTRAINT <oldname> TO <newname>
+//   ALTER DOMAIN ... RENAME TO <newname>
+//   ALTER DOMAIN ... SET SCHEMA <newschemaname>
+//   ALTER DOMAIN ... OWNER TO {<newowner> | CURRENT_USER | SESSION_USER }
+//
+// %SeeAlso: CREATE DOMAIN, DROP DOMAIN
+alter_domain_stmt:
+  ALTER DOMAIN type_name alter_domain_cmd
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: $4.alterDomainCmd(),
+    }
+  }
+| ALTER DOMAIN error // SHOW HELP: ALTER DOMAIN
+
+alter_domain_cmd:
+  SET DEFAULT a_expr
+  {
+    $$.val = &tree.AlterDomainSetDefault{Default: $3.expr()}
+  }
+| DROP DEFAULT
+  {
+    $$.val = &tree.AlterDomainSetDefault{}
+  }
+| SET NOT NULL
+  {
+    $$.val = &tree.AlterDomainSetNotNull{}
+  }
+| DROP NOT NULL
+  {
+    $$.val = &tree.AlterDomainDropNotNull{}
+  }
+| ADD CONSTRAINT constraint_name domain_constraint_elem
+  {
+    def := $4.domainConstraintDef()
+    def.Name = tree.Name($3)
+    $$.val = &tree.AlterDomainAddConstraint{Constraint: def}
+  }
+| ADD domain_constraint_elem
+  {
+    $$.val = &tree.AlterDomainAddConstraint{Constraint: $2.domainConstraintDef()}
+  }
+| DROP CONSTRAINT constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomainDropConstraint{
+      Constraint: tree.Name($3),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP CONSTRAINT IF EXISTS constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomainDropConstraint{
+      IfExists: true,
+      Constraint: tree.Name($5),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| RENAME CONSTRAINT constraint_name TO constraint_name
+  {
+    $$.val = &tree.AlterDomainRenameConstraint{
+      Constraint: tree.Name($3),
+      NewName: tree.Name($5),
+    }
+  }
+| RENAME TO name
+  {
+    $$.val = &tree.AlterTypeRename{NewName: tree.Name($3)}
+  }
+| SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterTypeSetSchema{Schema: tree.Name($3)}
+  }
+| OWNER TO role_spec
+  {
+    $$.val = &tree.AlterTypeOwner{Owner: $3.roleSpec()}
+  }
+
 opt_add_val_placement:
   BEFORE SCONST
   {
@@ -3286,6 +3486,129 @@ alter_attribute_action:
 | ALTER ATTRIBUTE column_name TYPE type_name opt_collate opt_drop_behavior
 | ALTER ATTRIBUTE column_name SET DATA TYPE type_name opt_collate opt_drop_behavior
 
+
+// %Help: ALTER PUBLICATION - change the tables of a publication
+// %Category: DDL
+// %Text: ALTER PUBLICATION <name> { ADD | SET | DROP } TABLE <tablename> [, ...]
+// %SeeAlso: CREATE PUBLICATION, DROP PUBLICATION
+alter_publication_stmt:
+  ALTER PUBLICATION name ADD TABLE table_name_list
+  {
+    $$.val = &tree.AlterPublication{
+      Name: tree.Name($3),
+      Kind: tree.AlterPublicationAddTable,
+      Tables: $6.tableNames(),
+    }
+  }
+| ALTER PUBLICATION name SET TABLE table_name_list
+  {
+    $$.val = &tree.AlterPublication{
+      Name: tree.Name($3),
+      Kind: tree.AlterPublicationSetTable,
+      Tables: $6.tableNames(),
+    }
+  }
+| ALTER PUBLICATION name DROP TABLE table_name_list
+  {
+    $$.val = &tree.AlterPublication{
+      Name: tree.Name($3),
+      Kind: tree.AlterPublicationDropTable,
+      Tables: $6.tableNames(),
+    }
+  }
+| ALTER PUBLICATION error // SHOW HELP: ALTER PUBLICATION
+
+// %Help: ALTER TEXT SEARCH - change the definition of a text search configuration or dictionary
+// %Category: DDL
+// %Text:
+// ALTER TEXT SEARCH CONFIGURATION <name> <command>
+// ALTER TEXT SEARCH DICTIONARY <name> <command>
+//
+// Configuration commands:
+//   ALTER TEXT SEARCH CONFIGURATION ... ADD MAPPING FOR <token_type> [, ...] WITH <dictionary> [, ...]
+//   ALTER TEXT SEARCH CONFIGURATION ... ALTER MAPPING FOR <token_type> [, ...] WITH <dictionary> [, ...]
+//   ALTER TEXT SEARCH CONFIGURATION ... ALTER MAPPING [FOR <token_type> [, ...]] REPLACE <old_dictionary> WITH <new_dictionary>
+//   ALTER TEXT SEARCH CONFIGURATION ... DROP MAPPING [IF EXISTS] FOR <token_type> [, ...]
+//   ALTER TEXT SEARCH CONFIGURATION ... RENAME TO <newname>
+//
+// Dictionary commands:
+//   ALTER TEXT SEARCH DICTIONARY ... (<option> = <value> [, ...])
+//   ALTER TEXT SEARCH DICTIONARY ... RENAME TO <newname>
+//
+// %SeeAlso: CREATE TEXT SEARCH, DROP TEXT SEARCH
+alter_text_search_stmt:
+  ALTER TEXT SEARCH CONFIGURATION db_object_name alter_text_search_config_cmd
+  {
+    $$.val = &tree.AlterTextSearch{
+      Kind: tree.TextSearchConfiguration,
+      Name: $5.unresolvedObjectName(),
+      Cmd: $6.alterTextSearchCmd(),
+    }
+  }
+| ALTER TEXT SEARCH DICTIONARY db_object_name alter_text_search_dict_cmd
+  {
+    $$.val = &tree.AlterTextSearch{
+      Kind: tree.TextSearchDictionary,
+      Name: $5.unresolvedObjectName(),
+      Cmd: $6.alterTextSearchCmd(),
+    }
+  }
+| ALTER TEXT SEARCH error // SHOW HELP: ALTER TEXT SEARCH
+
+alter_text_search_config_cmd:
+  ADD MAPPING FOR name_list WITH type_name_list
+  {
+    $$.val = &tree.AlterTextSearchAddMapping{
+      TokenTypes: $4.nameList(),
+      Dictionaries: $6.unresolvedObjectNames(),
+    }
+  }
+| ALTER MAPPING FOR name_list WITH type_name_list
+  {
+    $$.val = &tree.AlterTextSearchAddMapping{
+      Alter: true,
+      TokenTypes: $4.nameList(),
+      Dictionaries: $6.unresolvedObjectNames(),
+    }
+  }
+| ALTER MAPPING FOR name_list REPLACE db_object_name WITH db_object_name
+  {
+    $$.val = &tree.AlterTextSearchReplaceDictionary{
+      TokenTypes: $4.nameList(),
+      Old: $6.unresolvedObjectName(),
+      New: $8.unresolvedObjectName(),
+    }
+  }
+| ALTER MAPPING REPLACE db_object_name WITH db_object_name
+  {
+    $$.val = &tree.AlterTextSearchReplaceDictionary{
+      Old: $4.unresolvedObjectName(),
+      New: $6.unresolvedObjectName(),
+    }
+  }
+| DROP MAPPING FOR name_list
+  {
+    $$.val = &tree.AlterTextSearchDropMapping{TokenTypes: $4.nameList()}
+  }
+| DROP MAPPING IF EXISTS FOR name_list
+  {
+    $$.val = &tree.AlterTextSearchDropMapping{IfExists: true, TokenTypes: $6.nameList()}
+  }
+| RENAME TO name
+  {
+    $$.val = &tree.AlterTextSearchRename{NewName: tree.Name($3)}
+  }
+
+alter_text_search_dict_cmd:
+  '(' text_search_option_list ')'
+  {
+    $$.val = &tree.AlterTextSearchSetOptions{Options: $2.textSearchOptions()}
+  }
+| RENAME TO name
+  {
+    $$.val = &tree.AlterTextSearchRename{NewName: tree.Name($3)}
+  }
+
 // %Help: REFRESH - recalculate a materialized view
 // %Category: Misc
 // %Text:
@@ -4023,16 +4346,6 @@ import_format:
     $$ = strings.ToUpper($1)
   }
 
-alter_unsupported_stmt:
-  ALTER DOMAIN error
-  {
-    return unimplemented(sqllex, "alter domain")
-  }
-| ALTER AGGREGATE error
-  {
-    return unimplementedWithIssueDetail(sqllex, 74775, "alter aggregate")
-  }
-
 // %Help: IMPORT - load data from file in a distributed manner
 // %Category: CCL
 // %Text:
@@ -4193,6 +4506,33 @@ call_stmt:
     $$.val = &tree.Call{Proc: p}
   }
 
+// %Help: DO - execute an anonymous code block
+// %Category: Misc
+// %Text: DO [ LANGUAGE <lang_name> ] <code>
+// %SeeAlso: CREATE PROCEDURE, CALL
+do_stmt:
+  DO SCONST
+  {
+    $$.val = &tree.DoBlock{Code: $2}
+  }
+| DO LANGUAGE non_reserved_word_or_sconst SCONST
+  {
+    lang, err := tree.AsRoutineLanguage($3)
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = &tree.DoBlock{Code: $4, Language: lang}
+  }
+| DO SCONST LANGUAGE non_reserved_word_or_sconst
+  {
+    lang, err := tree.AsRoutineLanguage($4)
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = &tree.DoBlock{Code: $2, Language: lang}
+  }
+| DO error // SHOW HELP: DO
+
 // The COPY grammar in postgres has 3 different versions, all of which are supported by postgres:
 // 1) The "really old" syntax from v7.2 and prior
 // 2) Pre 9.0 using hard-wired, space-separated options
@@ -4886,7 +5226,7 @@ create_extension_stmt:
 // %SeeAlso: WEBDOCS/create-function.html
 create_func_stmt:
   CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
-  RETURNS opt_return_table opt_return_set routine_return_type
+  RETURNS opt_return_set routine_return_type
   opt_create_routine_opt_list opt_routine_body
   {
     name := $4.unresolvedObjectName().ToRoutineName()
@@ -4896,11 +5236,44 @@ create_func_stmt:
       Name: name,
       Params: $6.routineParams(),
       ReturnType: &tree.RoutineReturnType{
-        Type: $11.typeReference(),
-        SetOf: $10.bool(),
+        Type: $10.typeReference(),
+        SetOf: $9.bool(),
       },
-      Options: $12.routineOptions(),
-      RoutineBody: $13.routineBody(),
+      Options: $11.routineOptions(),
+      RoutineBody: $12.routineBody(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
+  RETURNS TABLE '(' table_func_column_list ')'
+  opt_create_routine_opt_list opt_routine_body
+  {
+    // RETURNS TABLE is equivalent to declaring the columns as OUT parameters
+    // and returning SETOF RECORD, or SETOF the column type if there is only
+    // one column.
+    name := $4.unresolvedObjectName().ToRoutineName()
+    params := $6.routineParams()
+    for i := range params {
+      if params[i].IsOutParam() {
+        return setErr(sqllex, pgerror.New(pgcode.InvalidFunctionDefinition,
+          "OUT and INOUT arguments aren't allowed in TABLE functions"))
+      }
+    }
+    cols := $11.routineParams()
+    var retType tree.ResolvableTypeReference = types.AnyTuple
+    if len(cols) == 1 {
+      retType = cols[0].Type
+    }
+    $$.val = &tree.CreateRoutine{
+      IsProcedure: false,
+      Replace: $2.bool(),
+      Name: name,
+      Params: append(params, cols...),
+      ReturnType: &tree.RoutineReturnType{
+        Type: retType,
+        SetOf: true,
+      },
+      Options: $13.routineOptions(),
+      RoutineBody: $14.routineBody(),
     }
   }
 | CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
@@ -4945,14 +5318,75 @@ create_proc_stmt:
   }
 | CREATE opt_or_replace PROCEDURE error // SHOW HELP: CREATE PROCEDURE
 
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] AGGREGATE
+//    name ( [ [ argmode ] [ argname ] argtype [, ...] ] ) (
+//    SFUNC = sfunc,
+//    STYPE = state_data_type
+//    [ , FINALFUNC = ffunc ]
+//    [ , COMBINEFUNC = combinefunc ]
+//    [ , INITCOND = initial_condition ]
+// )
+// %SeeAlso: DROP AGGREGATE, ALTER AGGREGATE
+create_aggregate_stmt:
+  CREATE opt_or_replace AGGREGATE routine_create_name func_params '(' aggregate_option_list ')'
+  {
+    $$.val = &tree.CreateAggregate{
+      Replace: $2.bool(),
+      Name: $4.unresolvedObjectName().ToRoutineName(),
+      Params: $5.routineParams(),
+      Options: $7.aggregateOptions(),
+    }
+  }
+| CREATE opt_or_replace AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+aggregate_option_list:
+  aggregate_option
+  {
+    $$.val = tree.AggregateOptions{$1.aggregateOption()}
+  }
+| aggregate_option_list ',' aggregate_option
+  {
+    $$.val = append($1.aggregateOptions(), $3.aggregateOption())
+  }
+
+aggregate_option:
+  SFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateOption{
+      Kind: tree.AggregateSFunc,
+      Func: $3.unresolvedObjectName().ToRoutineName(),
+    }
+  }
+| STYPE '=' typename
+  {
+    $$.val = tree.AggregateOption{Kind: tree.AggregateSType, Type: $3.typeReference()}
+  }
+| FINALFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateOption{
+      Kind: tree.AggregateFinalFunc,
+      Func: $3.unresolvedObjectName().ToRoutineName(),
+    }
+  }
+| COMBINEFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateOption{
+      Kind: tree.AggregateCombineFunc,
+      Func: $3.unresolvedObjectName().ToRoutineName(),
+    }
+  }
+| INITCOND '=' SCONST
+  {
+    $$.val = tree.AggregateOption{Kind: tree.AggregateInitCond, Value: $3}
+  }
+
 opt_or_replace:
   OR REPLACE { $$.val = true }
 | /* EMPTY */ { $$.val = false }
 
-opt_return_table:
-  TABLE { return unimplementedWithIssueDetail(sqllex, 100226, "UDF returning TABLE") }
-| /* EMPTY */ { $$.val = false }
-
 opt_return_set:
   SETOF { $$.val = true}
 | /* EMPTY */ { $$.val = false }
@@ -5033,6 +5467,23 @@ routine_param_class:
 | IN OUT { $$.val = tree.RoutineParamInOut }
 | VARIADIC { return unimplementedWithIssueDetail(sqllex, 88947, "variadic user-defined functions") }
 
+table_func_column_list:
+  table_func_column { $$.val = tree.RoutineParams{$1.routineParam()} }
+| table_func_column_list ',' table_func_column
+  {
+    $$.val = append($1.routineParams(), $3.routineParam())
+  }
+
+table_func_column:
+  param_name routine_param_type
+  {
+    $$.val = tree.RoutineParam{
+      Name: tree.Name($1),
+      Type: $2.typeReference(),
+      Class: tree.RoutineParamOut,
+    }
+  }
+
 routine_param_type:
   typename
 
@@ -5239,6 +5690,32 @@ drop_proc_stmt:
   }
 | DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
 
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text:
+// DROP AGGREGATE [ IF EXISTS ] name ( [ [ argmode ] [ argname ] argtype [, ...] ] ) [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE AGGREGATE
+drop_aggregate_stmt:
+  DROP AGGREGATE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      Aggregate: true,
+      Routines: $3.routineObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      IfExists: true,
+      Aggregate: true,
+      Routines: $5.routineObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
 function_with_paramtypes_list:
   function_with_paramtypes
   {
@@ -5597,7 +6074,6 @@ drop_trigger_stmt:
 
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
@@ -5606,12 +6082,10 @@ create_unsupported:
 | CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
 | CREATE OPERATOR error { return unimplementedWithIssue(sqllex, 65017) }
-| CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
 | CREATE SERVER error { return unimplemented(sqllex, "create server") }
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TABLESPACE error { return unimplementedWithIssueDetail(sqllex, 54113, "create tablespace") }
-| CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
 
 opt_trusted:
   TRUSTED {}
@@ -5623,22 +6097,18 @@ opt_procedural:
 
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
 | DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
-| DROP DOMAIN error { return unimplementedWithIssueDetail(sqllex, 27796, "drop") }
 | DROP EXTENSION IF EXISTS name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension if exists") }
 | DROP EXTENSION name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension") }
 | DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
 | DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
 | DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
-| DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
 | DROP RULE error { return unimplemented(sqllex, "drop rule") }
 | DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
-| DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
 
 create_ddl_stmt:
   create_database_stmt // EXTEND WITH HELP: CREATE DATABASE
@@ -5649,10 +6119,14 @@ create_ddl_stmt:
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
 | CREATE opt_persistence_temp_table TABLE error   // SHOW HELP: CREATE TABLE
 | create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
+| create_domain_stmt   // EXTEND WITH HELP: CREATE DOMAIN
+| create_text_search_stmt // EXTEND WITH HELP: CREATE TEXT SEARCH
+| create_publication_stmt // EXTEND WITH HELP: CREATE PUBLICATION
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
 | create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
 | create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
 
 // %Help: CREATE STATISTICS - create a new table statistic
@@ -6038,8 +6512,12 @@ drop_ddl_stmt:
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
+| drop_domain_stmt   // EXTEND WITH HELP: DROP DOMAIN
+| drop_text_search_stmt // EXTEND WITH HELP: DROP TEXT SEARCH
+| drop_publication_stmt // EXTEND WITH HELP: DROP PUBLICATION
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
 | drop_proc_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
 | drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
 
 // %Help: DROP VIEW - remove a view
@@ -6175,6 +6653,77 @@ drop_type_stmt:
   }
 | DROP TYPE error // SHOW HELP: DROP TYPE
 
+// %Help: DROP DOMAIN - remove a domain
+// %Category: DDL
+// %Text: DROP DOMAIN [IF EXISTS] <type_name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE DOMAIN, ALTER DOMAIN
+drop_domain_stmt:
+  DROP DOMAIN type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $3.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP DOMAIN IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP DOMAIN error // SHOW HELP: DROP DOMAIN
+
+// %Help: DROP PUBLICATION - remove a publication
+// %Category: DDL
+// %Text: DROP PUBLICATION [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE PUBLICATION, ALTER PUBLICATION
+drop_publication_stmt:
+  DROP PUBLICATION name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropPublication{
+      Names: $3.nameList(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP PUBLICATION IF EXISTS name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropPublication{
+      Names: $5.nameList(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP PUBLICATION error // SHOW HELP: DROP PUBLICATION
+
+// %Help: DROP TEXT SEARCH - remove a text search configuration or dictionary
+// %Category: DDL
+// %Text:
+// DROP TEXT SEARCH CONFIGURATION [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// DROP TEXT SEARCH DICTIONARY [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE TEXT SEARCH, ALTER TEXT SEARCH
+drop_text_search_stmt:
+  DROP TEXT SEARCH text_search_kind type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTextSearch{
+      Kind: $4.textSearchObjectKind(),
+      Names: $5.unresolvedObjectNames(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TEXT SEARCH text_search_kind IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTextSearch{
+      Kind: $4.textSearchObjectKind(),
+      Names: $7.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TEXT SEARCH error // SHOW HELP: DROP TEXT SEARCH
+
 // %Help: DROP VIRTUAL CLUSTER - remove a virtual cluster
 // %Category: Experimental
 // %Text: DROP VIRTUAL CLUSTER [IF EXISTS] <virtual_cluster_spec> [IMMEDIATE]
@@ -7157,7 +7706,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 
 // SET SESSION / SET LOCAL / SET CLUSTER SETTING
 preparable_set_stmt:
@@ -7612,6 +8161,36 @@ set_local_stmt:
   }
 | SET LOCAL error  // SHOW HELP: SET LOCAL
 
+// %Help: SET CONSTRAINTS - set when deferrable constraints are checked
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS { ALL | <name> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// DEFERRED constraints are checked when the transaction commits, and
+// IMMEDIATE constraints at the end of each statement. Only constraints
+// declared DEFERRABLE are affected.
+// %SeeAlso: SET TRANSACTION
+set_constraints_stmt:
+  SET CONSTRAINTS ALL constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS name_list constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Names: $3.nameList(), Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
+constraints_set_mode:
+  DEFERRED
+  {
+    $$.val = true
+  }
+| IMMEDIATE
+  {
+    $$.val = false
+  }
+
 // %Help: SET TRANSACTION - configure the transaction settings
 // %Category: Txn
 // %Text:
@@ -8765,6 +9344,7 @@ statements_or_queries:
 // SHOW [AUTOMATIC | CHANGEFEED] JOBS [select clause] [WITH EXECUTION DETAILS]
 // SHOW JOBS FOR SCHEDULES [select clause]
 // SHOW [CHANGEFEED] JOB <jobid> [WITH EXECUTION DETAILS]
+// SHOW CHANGEFEED DLQ FOR JOB <jobid>
 // %SeeAlso: CANCEL JOBS, PAUSE JOBS, RESUME JOBS
 show_jobs_stmt:
   SHOW AUTOMATIC JOBS
@@ -8851,6 +9431,11 @@ show_jobs_stmt:
   }
 | SHOW JOB error // SHOW HELP: SHOW JOBS
 | SHOW CHANGEFEED JOB error // SHOW HELP: SHOW JOBS
+| SHOW CHANGEFEED DLQ FOR JOB a_expr
+  {
+    $$.val = &tree.ShowChangefeedDLQ{Job: $6.expr()}
+  }
+| SHOW CHANGEFEED DLQ error // SHOW HELP: SHOW JOBS
 
 
 show_job_options_list:
@@ -10660,10 +11245,11 @@ col_qualification_elem:
   {
     $$.val = tree.HiddenConstraint{}
   }
-| UNIQUE opt_without_index
+| UNIQUE opt_without_index opt_deferrable
   {
     $$.val = tree.UniqueConstraint{
       WithoutIndex: $2.bool(),
+      Deferrability: $3.constraintDeferrability(),
     }
   }
 | PRIMARY KEY opt_with_storage_parameter_list
@@ -10680,9 +11266,9 @@ col_qualification_elem:
     StorageParams: $6.storageParams(),
   }
 }
-| CHECK '(' a_expr ')'
+| CHECK '(' a_expr ')' opt_deferrable
   {
-    $$.val = &tree.ColumnCheckConstraint{Expr: $3.expr()}
+    $$.val = &tree.ColumnCheckConstraint{Expr: $3.expr(), Deferrability: $5.constraintDeferrability()}
   }
 | DEFAULT b_expr
   {
@@ -10692,7 +11278,7 @@ col_qualification_elem:
   {
     $$.val = &tree.ColumnOnUpdate{Expr: $3.expr()}
   }
-| REFERENCES table_name opt_name_parens key_match reference_actions
+| REFERENCES table_name opt_name_parens key_match reference_actions opt_deferrable
   {
     name := $2.unresolvedObjectName().ToTableName()
     $$.val = &tree.ColumnFKConstraint{
@@ -10700,6 +11286,7 @@ col_qualification_elem:
       Col: tree.Name($3),
       Actions: $5.referenceActions(),
       Match: $4.compositeKeyMatchMethod(),
+      Deferrability: $6.constraintDeferrability(),
     }
   }
 | generated_as '(' a_expr ')' STORED
@@ -10858,6 +11445,7 @@ constraint_elem:
   {
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
+      Deferrability: $5.constraintDeferrability(),
     }
   }
 | UNIQUE opt_without_index '(' index_params ')'
@@ -10871,6 +11459,7 @@ constraint_elem:
         PartitionByIndex: $7.partitionByIndex(),
         Predicate: $9.expr(),
       },
+      Deferrability: $8.constraintDeferrability(),
     }
   }
 | PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -10894,11 +11483,38 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrability: $11.constraintDeferrability(),
     }
   }
-| EXCLUDE USING error
+| EXCLUDE opt_index_access_method '(' exclude_elems ')' opt_where_clause opt_deferrable
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    $$.val = &tree.ExcludeConstraintTableDef{
+      IndexType: $2.indexType(),
+      Elems: $4.excludeElems(),
+      Predicate: $6.expr(),
+      Deferrability: $7.constraintDeferrability(),
+    }
+  }
+
+exclude_elems:
+  exclude_elem
+  {
+    $$.val = tree.ExcludeElemList{$1.excludeElem()}
+  }
+| exclude_elems ',' exclude_elem
+  {
+    $$.val = append($1.excludeElems(), $3.excludeElem())
+  }
+
+exclude_elem:
+  index_elem WITH all_op
+  {
+    op, ok := $3.op().(treecmp.ComparisonOperator)
+    if !ok {
+      sqllex.Error(fmt.Sprintf("operator %s is not a comparison operator", $3.op()))
+      return 1
+    }
+    $$.val = tree.ExcludeElem{IndexElem: $1.idxElem(), Operator: op}
   }
 
 
@@ -11007,12 +11623,51 @@ create_as_col_qualification_elem:
   }
 
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  /* EMPTY */
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| NOT_DEFERRABLE DEFERRABLE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| NOT_DEFERRABLE DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| NOT_DEFERRABLE DEFERRABLE INITIALLY DEFERRED
+  {
+    sqllex.Error("constraint declared INITIALLY DEFERRED must be DEFERRABLE")
+    return 1
+  }
+| DEFERRABLE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| INITIALLY IMMEDIATE DEFERRABLE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| INITIALLY DEFERRED DEFERRABLE
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
 
 storing:
   COVERING
@@ -11672,8 +12327,68 @@ create_type_stmt:
 | CREATE TYPE type_name '(' error         { return unimplementedWithIssueDetail(sqllex, 27793, "base") }
   // Shell types, gateway to define base types using the previous syntax.
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
-  // Domain types.
-| CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
+
+// %Help: CREATE DOMAIN - create a domain
+// %Category: DDL
+// %Text:
+// CREATE DOMAIN <type_name> [AS] <type> [DEFAULT <expr>] [<constraint> ...]
+//
+// Constraints:
+//   [CONSTRAINT <name>] { NOT NULL | NULL | CHECK (<expr>) }
+//
+// %SeeAlso: ALTER DOMAIN, DROP DOMAIN
+create_domain_stmt:
+  CREATE DOMAIN type_name opt_as typename domain_constraint_list
+  {
+    $$.val = &tree.CreateDomain{
+      TypeName: $3.unresolvedObjectName(),
+      Type: $5.typeReference(),
+      Constraints: $6.domainConstraintDefs(),
+    }
+  }
+| CREATE DOMAIN error // SHOW HELP: CREATE DOMAIN
+
+domain_constraint_list:
+  domain_constraint_list domain_constraint
+  {
+    $$.val = append($1.domainConstraintDefs(), $2.domainConstraintDef())
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.DomainConstraintDefs(nil)
+  }
+
+// DEFAULT expression must be b_expr for the same reasons as in
+// col_qualification_elem.
+domain_constraint:
+  CONSTRAINT constraint_name domain_constraint_elem
+  {
+    def := $3.domainConstraintDef()
+    def.Name = tree.Name($2)
+    $$.val = def
+  }
+| domain_constraint_elem
+  {
+    $$.val = $1.domainConstraintDef()
+  }
+| DEFAULT b_expr
+  {
+    $$.val = &tree.DomainConstraintDef{Kind: tree.DomainDefault, Expr: $2.expr()}
+  }
+
+domain_constraint_elem:
+  NOT NULL
+  {
+    $$.val = &tree.DomainConstraintDef{Kind: tree.DomainNotNull}
+  }
+| NULL
+  {
+    $$.val = &tree.DomainConstraintDef{Kind: tree.DomainNull}
+  }
+| CHECK '(' a_expr ')'
+  {
+    $$.val = &tree.DomainConstraintDef{Kind: tree.DomainCheck, Expr: $3.expr()}
+  }
 
 opt_enum_val_list:
   enum_val_list
@@ -11725,6 +12440,96 @@ composite_type_list:
     )
   }
 
+
+// %Help: CREATE PUBLICATION - create a publication for logical replication
+// %Category: DDL
+// %Text: CREATE PUBLICATION <name> [ FOR ALL TABLES | FOR TABLE <tablename> [, ...] ]
+// %SeeAlso: ALTER PUBLICATION, DROP PUBLICATION
+create_publication_stmt:
+  CREATE PUBLICATION name
+  {
+    $$.val = &tree.CreatePublication{Name: tree.Name($3)}
+  }
+| CREATE PUBLICATION name FOR ALL TABLES
+  {
+    $$.val = &tree.CreatePublication{Name: tree.Name($3), AllTables: true}
+  }
+| CREATE PUBLICATION name FOR TABLE table_name_list
+  {
+    $$.val = &tree.CreatePublication{Name: tree.Name($3), Tables: $6.tableNames()}
+  }
+| CREATE PUBLICATION error // SHOW HELP: CREATE PUBLICATION
+
+// %Help: CREATE TEXT SEARCH - create a text search configuration or dictionary
+// %Category: DDL
+// %Text:
+// CREATE TEXT SEARCH CONFIGURATION <name> (PARSER = default | COPY = <configuration>)
+// CREATE TEXT SEARCH DICTIONARY <name> (TEMPLATE = <template> [, <option> = <value> ...])
+//
+// Templates and their options:
+//   simple: StopWords, Accept
+//   snowball: Language, StopWords
+//   synonym: Synonyms, CaseSensitive
+//   thesaurus: Dictionary, Thesaurus
+//   unaccent: Rules
+//
+// %SeeAlso: ALTER TEXT SEARCH, DROP TEXT SEARCH
+create_text_search_stmt:
+  CREATE TEXT SEARCH text_search_kind db_object_name '(' text_search_option_list ')'
+  {
+    $$.val = &tree.CreateTextSearch{
+      Kind: $4.textSearchObjectKind(),
+      Name: $5.unresolvedObjectName(),
+      Options: $7.textSearchOptions(),
+    }
+  }
+| CREATE TEXT SEARCH error // SHOW HELP: CREATE TEXT SEARCH
+
+text_search_kind:
+  CONFIGURATION
+  {
+    $$.val = tree.TextSearchConfiguration
+  }
+| DICTIONARY
+  {
+    $$.val = tree.TextSearchDictionary
+  }
+
+text_search_option_list:
+  text_search_option
+  {
+    $$.val = tree.TextSearchOptions{$1.textSearchOption()}
+  }
+| text_search_option_list ',' text_search_option
+  {
+    $$.val = append($1.textSearchOptions(), $3.textSearchOption())
+  }
+
+text_search_option:
+  name '=' text_search_option_value
+  {
+    $$.val = tree.TextSearchOption{Name: tree.Name($1), Value: $3}
+  }
+
+text_search_option_value:
+  db_object_name
+  {
+    $$ = $1.unresolvedObjectName().String()
+  }
+| SCONST
+| DEFAULT
+  {
+    $$ = "default"
+  }
+| TRUE
+  {
+    $$ = "true"
+  }
+| FALSE
+  {
+    $$ = "false"
+  }
+
 // %Help: CREATE INDEX - create a new index
 // %Category: DDL
 // %Text:
@@ -11750,7 +12555,8 @@ create_index_stmt:
       PartitionByIndex: $14.partitionByIndex(),
       StorageParams:    $15.storageParams(),
       Predicate:        $16.expr(),
-      Inverted:         $8.bool(),
+      Inverted:         $8.indexType() == tree.IndexTypeInverted,
+      Vector:           $8.indexType() == tree.IndexTypeVector,
       Concurrently:     $4.bool(),
       Invisibility:     $17.indexInvisibility(),
     }
@@ -11767,7 +12573,8 @@ create_index_stmt:
       Sharded:          $15.shardedIndexDef(),
       Storing:          $16.nameList(),
       PartitionByIndex: $17.partitionByIndex(),
-      Inverted:         $11.bool(),
+      Inverted:         $11.indexType() == tree.IndexTypeInverted,
+      Vector:           $11.indexType() == tree.IndexTypeVector,
       StorageParams:    $18.storageParams(),
       Predicate:        $19.expr(),
       Concurrently:     $4.bool(),
@@ -11817,10 +12624,19 @@ opt_index_access_method:
     /* FORCE DOC */
     switch $2 {
       case "gin", "gist":
-        $$.val = true
+        $$.val = tree.IndexTypeInverted
       case "btree":
-        $$.val = false
-      case "hash", "spgist", "brin":
+        $$.val = tree.IndexTypeForward
+      case "lsh":
+        $$.val = tree.IndexTypeVector
+      case "ivfflat":
+        // The partitions of lsh indexes are not trained on the data, so they
+        // are not offered under the name of pgvector's IVF indexes.
+        return setErr(sqllex, errors.WithHint(
+          pgerror.New(pgcode.FeatureNotSupported, "ivfflat indexes are not supported"),
+          "USING lsh creates a vector index whose partitions are not trained on the indexed vectors, " +
+            "and the vector_search_probes session variable sets the number of partitions it searches."))
+      case "hash", "spgist", "brin", "hnsw":
         return unimplemented(sqllex, "index using " + $2)
       default:
         sqllex.Error("unrecognized access method: " + $2)
@@ -11829,7 +12645,7 @@ opt_index_access_method:
   }
 | /* EMPTY */
   {
-    $$.val = false
+    $$.val = tree.IndexTypeForward
   }
 
 opt_concurrently:
@@ -12702,7 +13518,7 @@ transaction_deferrable_mode:
   {
     $$.val = tree.Deferrable
   }
-| NOT DEFERRABLE
+| NOT_DEFERRABLE DEFERRABLE
   {
     $$.val = tree.NotDeferrable
   }
@@ -13831,9 +14647,18 @@ group_by_list:
 // rather than reducing the conflicting unreserved_keyword rule.
 group_by_item:
   a_expr { $$.val = $1.expr() }
-| ROLLUP '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "rollup") }
-| CUBE '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "cube") }
-| GROUPING SETS error { return unimplementedWithIssueDetail(sqllex, 46280, "grouping sets") }
+| ROLLUP '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Kind: tree.RollupGroupingSet, Exprs: $3.exprs()}
+  }
+| CUBE '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Kind: tree.CubeGroupingSet, Exprs: $3.exprs()}
+  }
+| GROUPING SETS '(' group_by_list ')'
+  {
+    $$.val = &tree.GroupingSet{Kind: tree.ExplicitGroupingSets, Exprs: $4.exprs()}
+  }
 
 having_clause:
   HAVING a_expr
@@ -14412,16 +15237,46 @@ relation_expr_list:
     $$.val = append($1.tableNames(), name)
   }
 
-// UNLISTEN
+// %Help: LISTEN - start listening for notifications on a channel
+// %Category: Misc
+// %Text: LISTEN <channel>
+// %SeeAlso: NOTIFY, UNLISTEN
+listen_stmt:
+  LISTEN name
+  {
+    $$.val = &tree.Listen{ChannelName: tree.Name($2)}
+  }
+| LISTEN error // SHOW HELP: LISTEN
+
+// %Help: NOTIFY - send a notification to the listeners of a channel
+// %Category: Misc
+// %Text: NOTIFY <channel> [, <payload>]
+// %SeeAlso: LISTEN, UNLISTEN
+notify_stmt:
+  NOTIFY name
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2)}
+  }
+| NOTIFY name ',' SCONST
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2), Payload: tree.NewStrVal($4)}
+  }
+| NOTIFY error // SHOW HELP: NOTIFY
+
+// %Help: UNLISTEN - stop listening for notifications on a channel
+// %Category: Misc
+// %Text: UNLISTEN { <channel> | * }
+// %SeeAlso: LISTEN, NOTIFY
 unlisten_stmt:
-   UNLISTEN type_name
-    {
-        $$.val = &tree.Unlisten{ ChannelName: $2.unresolvedObjectName(), Star: false}
-    }
-|  UNLISTEN '*'
-      {
-          $$.val = &tree.Unlisten{ ChannelName:nil, Star: true}
-      }
+  UNLISTEN name
+  {
+    $$.val = &tree.Unlisten{ChannelName: tree.Name($2)}
+  }
+| UNLISTEN '*'
+  {
+    $$.val = &tree.Unlisten{Star: true}
+  }
+| UNLISTEN error // SHOW HELP: UNLISTEN
 
 
 // Given "UPDATE foo set set ...", we have to decide without looking any
@@ -14637,8 +15492,6 @@ simple_typename:
   }
 | const_typename
 | interval_type
-| POINT error { return unimplementedWithIssueDetail(sqllex, 21286, "point") } // needed or else it generates a syntax error.
-| POLYGON error { return unimplementedWithIssueDetail(sqllex, 21286, "polygon") } // needed or else it generates a syntax error.
 
 geo_shape_type:
   POINT { $$.val = geopb.ShapeType_Point }
@@ -14678,6 +15531,8 @@ const_geo:
   GEOGRAPHY { $$.val = types.Geography }
 | GEOMETRY  { $$.val = types.Geometry }
 | BOX2D     { $$.val = types.Box2D }
+| POINT     { $$.val = types.Point }
+| POLYGON   { $$.val = types.Polygon }
 | GEOMETRY '(' geo_shape_type ')'
   {
     $$.val = types.MakeGeometry($3.geoShapeType(), 0)
@@ -15265,6 +16120,10 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.JSONAllExists), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr JSON_PATH_EXISTS a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("jsonb_path_exists_opr"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
 | a_expr CONTAINS a_expr
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Contains), Left: $1.expr(), Right: $3.expr()}
@@ -15337,6 +16196,14 @@ a_expr:
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction("inet_contains_or_equals"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
   }
+| a_expr RANGE_ADJACENT a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("range_adjacent"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr GEOMETRIC_INTERSECTS a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("geometric_intersects"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
 | a_expr LESS_EQUALS a_expr
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.LE), Left: $1.expr(), Right: $3.expr()}
@@ -15887,7 +16754,10 @@ d_expr:
   {
     $$.val = $2.expr()
   }
-| GROUPING '(' expr_list ')' { return unimplemented(sqllex, "d_expr grouping") }
+| GROUPING '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingOperation{Exprs: $3.exprs()}
+  }
 
 func_application:
   func_application_name '(' ')'
@@ -16230,6 +17100,16 @@ special_function:
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
   }
 | LEAST '(' error { return helpWithFunctionByName(sqllex, $1) }
+| POINT '(' expr_list ')'
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
+  }
+| POINT '(' error { return helpWithFunctionByName(sqllex, $1) }
+| POLYGON '(' expr_list ')'
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
+  }
+| POLYGON '(' error { return helpWithFunctionByName(sqllex, $1) }
 
 
 // Aggregate decoration clauses
@@ -17524,6 +18404,7 @@ unreserved_keyword:
 | CLUSTER
 | CLUSTERS
 | COLUMNS
+| COMBINEFUNC
 | COMMENT
 | COMMENTS
 | COMMIT
@@ -17570,7 +18451,9 @@ unreserved_keyword:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DICTIONARY
 | DISCARD
+| DLQ
 | DOMAIN
 | DOUBLE
 | DROP
@@ -17600,6 +18483,7 @@ unreserved_keyword:
 | FAILURE
 | FILES
 | FILTER
+| FINALFUNC
 | FIRST
 | FOLLOWING
 | FORMAT
@@ -17648,6 +18532,7 @@ unreserved_keyword:
 | INDEX
 | INDEXES
 | INHERITS
+| INITCOND
 | INJECT
 | INPUT
 | INSERT
@@ -17679,6 +18564,7 @@ unreserved_keyword:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCKED
 | LOGICAL
@@ -17686,6 +18572,7 @@ unreserved_keyword:
 | LOCALITY
 | LOOKUP
 | LOW
+| MAPPING
 | MATCH
 | MATERIALIZED
 | MAXVALUE
@@ -17720,6 +18607,7 @@ unreserved_keyword:
 | NO
 | NORMAL
 | NOTHING
+| NOTIFY
 | NO_INDEX_JOIN
 | NO_ZIGZAG_JOIN
 | NO_FULL_SCAN
@@ -17857,6 +18745,7 @@ unreserved_keyword:
 | SESSIONS
 | SET
 | SETS
+| SFUNC
 | SHARE
 | SHARED
 | SHOW
@@ -17889,6 +18778,7 @@ unreserved_keyword:
 | STRAIGHT
 | STREAM
 | STRICT
+| STYPE
 | SUBSCRIPTION
 | SUBJECT
 | SUPER
@@ -18026,6 +18916,7 @@ bare_label_keywords:
 | COLLATION
 | COLUMN
 | COLUMNS
+| COMBINEFUNC
 | COMMENT
 | COMMENTS
 | COMMIT
@@ -18086,8 +18977,10 @@ bare_label_keywords:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DICTIONARY
 | DISCARD
 | DISTINCT
+| DLQ
 | DO
 | DOMAIN
 | DOUBLE
@@ -18124,6 +19017,7 @@ bare_label_keywords:
 | FALSE
 | FAMILY
 | FILES
+| FINALFUNC
 | FIRST
 | FLOAT
 | FOLLOWING
@@ -18187,6 +19081,7 @@ bare_label_keywords:
 | INDEX_BEFORE_NAME_THEN_PAREN
 | INDEX_BEFORE_PAREN
 | INHERITS
+| INITCOND
 | INITIALLY
 | INJECT
 | INNER
@@ -18233,6 +19128,7 @@ bare_label_keywords:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCALITY
 | LOCALTIME
@@ -18242,6 +19138,7 @@ bare_label_keywords:
 | LOGIN
 | LOOKUP
 | LOW
+| MAPPING
 | MATCH
 | MATERIALIZED
 | MAXVALUE
@@ -18290,6 +19187,7 @@ bare_label_keywords:
 | NOT
 | NOTHING
 | NOTHING_AFTER_RETURNING
+| NOTIFY
 | NOVIEWACTIVITY
 | NOVIEWACTIVITYREDACTED
 | NOVIEWCLUSTERSETTING
@@ -18430,6 +19328,7 @@ bare_label_keywords:
 | SETS
 | SETTING
 | SETTINGS
+| SFUNC
 | SHARE
 | SHARED
 | SHOW
@@ -18467,6 +19366,7 @@ bare_label_keywords:
 | STREAM
 | STRICT
 | STRING
+| STYPE
 | SUBSCRIPTION
 | SUBSTRING
 | SUBJECT
@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -54,6 +55,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -909,7 +911,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = conTypeTrigger
-	_ = conTypeExclusion
 
 	fkActionNone       = tree.NewDString("a")
 	fkActionRestrict   = tree.NewDString("r")
@@ -961,6 +962,10 @@ func populateTableConstraints(
 		consrc := tree.DNull
 		conbin := tree.DNull
 		condef := tree.DNull
+		condeferrable := tree.MakeDBool(tree.DBool(c.IsDeferrable()))
+		condeferred := tree.MakeDBool(tree.DBool(
+			c.GetDeferrability() == descpb.ConstraintDeferrability_InitiallyDeferred,
+		))
 
 		// Determine constraint kind-specific fields.
 		var err error
@@ -1054,15 +1059,28 @@ func populateTableConstraints(
 			conoid = h.UniqueWithoutIndexConstraintOid(
 				db.GetID(), sc.GetID(), table.GetID(), uwoi,
 			)
-			f.WriteString("UNIQUE WITHOUT INDEX (")
 			colNames, err := catalog.ColumnNamesForIDs(table, uwoi.UniqueWithoutIndexDesc().ColumnIDs)
 			if err != nil {
 				return err
 			}
-			f.WriteString(strings.Join(colNames, ", "))
-			f.WriteByte(')')
-			if !uwoi.IsConstraintValidated() {
-				f.WriteString(" NOT VALID")
+			if uwoi.IsExclusion() {
+				contype = conTypeExclusion
+				f.WriteString("EXCLUDE USING gist (")
+				for i, op := range uwoi.ExclusionOperators() {
+					if i > 0 {
+						f.WriteString(", ")
+					}
+					f.WriteString(fmt.Sprintf("%s WITH %s", colNames[i], op))
+				}
+				f.WriteByte(')')
+			} else {
+				f.WriteString("UNIQUE WITHOUT INDEX (")
+				f.WriteString(strings.Join(colNames, ", "))
+				f.WriteByte(')')
+				showConstraintDeferrability(&f.Buffer, uwoi.GetDeferrability())
+				if !uwoi.IsConstraintValidated() {
+					f.WriteString(" NOT VALID")
+				}
 			}
 			if uwoi.GetPredicate() != "" {
 				pred, err := schemaexpr.FormatExprForDisplay(ctx, table, uwoi.GetPredicate(), p.EvalContext(), p.SemaCtx(), p.SessionData(), tree.FmtPGCatalog)
@@ -1071,6 +1089,9 @@ func populateTableConstraints(
 				}
 				f.WriteString(fmt.Sprintf(" WHERE (%s)", pred))
 			}
+			if uwoi.IsExclusion() {
+				showConstraintDeferrability(&f.Buffer, uwoi.GetDeferrability())
+			}
 			condef = tree.NewDString(f.CloseAndGetString())
 		} else if ck := c.AsCheck(); ck != nil {
 			conoid = h.CheckConstraintOid(db.GetID(), sc.GetID(), table.GetID(), ck)
@@ -1084,11 +1105,13 @@ func populateTableConstraints(
 			}
 			consrc = tree.NewDString(fmt.Sprintf("(%s)", displayExpr))
 			conbin = consrc
-			validity := ""
+			var buf bytes.Buffer
+			fmt.Fprintf(&buf, "CHECK ((%s))", displayExpr)
+			showConstraintDeferrability(&buf, ck.GetDeferrability())
 			if !ck.IsConstraintValidated() {
-				validity = " NOT VALID"
+				buf.WriteString(" NOT VALID")
 			}
-			condef = tree.NewDString(fmt.Sprintf("CHECK ((%s))%s", displayExpr, validity))
+			condef = tree.NewDString(buf.String())
 		}
 
 		if err := addRow(
@@ -1096,8 +1119,8 @@ func populateTableConstraints(
 			dNameOrNull(c.GetName()), // conname
 			namespaceOid,             // connamespace
 			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
+			condeferrable,            // condeferrable
+			condeferred,              // condeferred
 			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -2632,12 +2655,18 @@ func addPgProcUDFRow(
 	kind := proKindFunction
 	if fnDesc.IsProcedure() {
 		kind = proKindProcedure
+	} else if fnDesc.IsAggregate() {
+		kind = proKindAggregate
 	}
 
 	lang := languageInternalOid
-	if fnDesc.GetLanguage() == catpb.Function_PLPGSQL {
+	switch {
+	case fnDesc.IsAggregate():
+		// Like in Postgres, aggregates are reported as internal functions, since
+		// they have no body of their own.
+	case fnDesc.GetLanguage() == catpb.Function_PLPGSQL:
 		lang = languagePlpgsqlOid
-	} else if fnDesc.GetLanguage() == catpb.Function_SQL {
+	case fnDesc.GetLanguage() == catpb.Function_SQL:
 		lang = languageSqlOid
 	}
 
@@ -3254,17 +3283,16 @@ https://www.postgresql.org/docs/9.5/catalog-pg-trigger.html`,
 }
 
 var (
-	typTypeBase      = tree.NewDString("b")
-	typTypeComposite = tree.NewDString("c")
-	typTypeDomain    = tree.NewDString("d")
-	typTypeEnum      = tree.NewDString("e")
-	typTypePseudo    = tree.NewDString("p")
-	typTypeRange     = tree.NewDString("r")
+	typTypeBase       = tree.NewDString("b")
+	typTypeComposite  = tree.NewDString("c")
+	typTypeDomain     = tree.NewDString("d")
+	typTypeEnum       = tree.NewDString("e")
+	typTypePseudo     = tree.NewDString("p")
+	typTypeRange      = tree.NewDString("r")
+	typTypeMultirange = tree.NewDString("m")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
-	_ = typTypeRange
 
 	// See https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html#CATALOG-TYPCATEGORY-TABLE.
 	typCategoryArray       = tree.NewDString("A")
@@ -3285,8 +3313,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = typCategoryEnum
-	_ = typCategoryGeometric
-	_ = typCategoryRange
 	_ = typCategoryBitString
 
 	commaTypDelim = tree.NewDString(",")
@@ -3361,8 +3387,21 @@ func addPGTypeRow(
 	typArray := oidZero
 	builtinPrefix := builtins.PGIOBuiltinPrefix(typ)
 	typrelid := oidZero
-	switch typ.Family() {
-	case types.ArrayFamily:
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	typDefault := tree.DNull
+	switch {
+	case typ.IsDomain():
+		typType = typTypeDomain
+		typArray = tree.NewDOid(typ.UserDefinedArrayOID())
+		typBaseType = tree.NewDOid(typ.DomainBaseType().Oid())
+		if data := typ.TypeMeta.DomainData; data != nil {
+			typNotNull = tree.MakeDBool(tree.DBool(data.NotNull))
+			if data.DefaultExpr != nil {
+				typDefault = tree.NewDString(*data.DefaultExpr)
+			}
+		}
+	case typ.Family() == types.ArrayFamily:
 		switch typ.Oid() {
 		case oid.T_int2vector:
 			// IntVector needs a special case because it's a special snowflake
@@ -3380,11 +3419,11 @@ func addPGTypeRow(
 			builtinPrefix = "array_"
 			typElem = tree.NewDOid(typ.ArrayContents().Oid())
 		}
-	case types.EnumFamily:
+	case typ.Family() == types.EnumFamily:
 		builtinPrefix = "enum_"
 		typType = typTypeEnum
 		typArray = tree.NewDOid(types.CalcArrayOid(typ))
-	case types.TupleFamily:
+	case typ.Family() == types.TupleFamily:
 		builtinPrefix = "record_"
 		typType = typTypeComposite
 		typArray = tree.NewDOid(types.CalcArrayOid(typ))
@@ -3392,9 +3431,14 @@ func addPGTypeRow(
 		if isUDT {
 			typrelid = tree.NewDOid(typ.Oid())
 		}
-	case types.VoidFamily:
+	case typ.Family() == types.RangeFamily:
+		// Arrays of ranges are not supported, see #27791.
+		typType = typTypeRange
+	case typ.Family() == types.MultirangeFamily:
+		typType = typTypeMultirange
+	case typ.Family() == types.VoidFamily:
 		// void does not have an array type.
-	case types.TriggerFamily:
+	case typ.Family() == types.TriggerFamily:
 		// trigger does not have an array type.
 	default:
 		typArray = tree.NewDOid(types.CalcArrayOid(typ))
@@ -3431,13 +3475,13 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
 		negOneVal,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
 		tree.DNull,      // typdefaultbin
-		tree.DNull,      // typdefault
+		typDefault,      // typdefault
 		tree.DNull,      // typacl
 	)
 }
@@ -4165,12 +4209,34 @@ var pgCatalogStatioSysTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogTsConfigTable = virtualSchemaTable{
-	comment: "pg_ts_config was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsConfig,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `text search configurations
+https://www.postgresql.org/docs/16/catalog-pg-ts-config.html`,
+	schema: vtable.PgCatalogTsConfig,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		for _, name := range tsearch.BuiltinConfigNames() {
+			if err := addRow(
+				h.TextSearchConfigOid(catconstants.PgCatalogID, name), // oid
+				tree.NewDName(name),                      // cfgname
+				schemaOid(catconstants.PgCatalogID),      // cfgnamespace
+				nodeOID,                                  // cfgowner
+				tree.NewDOid(textSearchDefaultParserOid), // cfgparser
+			); err != nil {
+				return err
+			}
+		}
+		return forEachTextSearchSchema(ctx, p, dbContext, func(sc catalog.SchemaDescriptor, ownerOID tree.Datum) error {
+			return sc.ForEachTextSearchConfiguration(func(cfg descpb.SchemaDescriptor_TextSearchConfiguration) error {
+				return addRow(
+					h.TextSearchConfigOid(sc.GetID(), cfg.Name), // oid
+					tree.NewDName(cfg.Name),                     // cfgname
+					schemaOid(sc.GetID()),                       // cfgnamespace
+					ownerOID,                                    // cfgowner
+					tree.NewDOid(textSearchDefaultParserOid),    // cfgparser
+				)
+			})
+		})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatsTable = virtualSchemaTable{
@@ -4352,12 +4418,46 @@ var pgCatalogStatAllIndexesTable = virtualSchemaTable{
 }
 
 var pgCatalogTsConfigMapTable = virtualSchemaTable{
-	comment: "pg_ts_config_map was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsConfigMap,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `dictionaries used by text search configurations for each token type
+https://www.postgresql.org/docs/16/catalog-pg-ts-config-map.html`,
+	schema: vtable.PgCatalogTsConfigMap,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		for _, name := range tsearch.BuiltinConfigNames() {
+			cfgOid := h.TextSearchConfigOid(catconstants.PgCatalogID, name)
+			cfg, _ := tsearch.BuiltinConfig(name)
+			for t := tsearch.TokenType(1); t <= tsearch.NumTokenTypes; t++ {
+				for i, dict := range cfg.Mapping(t) {
+					if err := addRow(
+						cfgOid,                       // mapcfg
+						tree.NewDInt(tree.DInt(t)),   // maptokentype
+						tree.NewDInt(tree.DInt(i+1)), // mapseqno
+						h.TextSearchDictOid(catconstants.PgCatalogID, dict.Name), // mapdict
+					); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return forEachTextSearchSchema(ctx, p, dbContext, func(sc catalog.SchemaDescriptor, _ tree.Datum) error {
+			return sc.ForEachTextSearchConfiguration(func(cfg descpb.SchemaDescriptor_TextSearchConfiguration) error {
+				cfgOid := h.TextSearchConfigOid(sc.GetID(), cfg.Name)
+				for _, m := range cfg.Mappings {
+					for i, ref := range m.Dictionaries {
+						if err := addRow(
+							cfgOid,                                      // mapcfg
+							tree.NewDInt(tree.DInt(m.TokenType)),        // maptokentype
+							tree.NewDInt(tree.DInt(i+1)),                // mapseqno
+							h.TextSearchDictOid(ref.SchemaID, ref.Name), // mapdict
+						); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
+		})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatBgwriterTable = virtualSchemaTable{
@@ -4442,12 +4542,21 @@ var pgCatalogSubscriptionRelTable = virtualSchemaTable{
 }
 
 var pgCatalogTsParserTable = virtualSchemaTable{
-	comment: "pg_ts_parser was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsParser,
+	comment: `text search parsers
+https://www.postgresql.org/docs/16/catalog-pg-ts-parser.html`,
+	schema: vtable.PgCatalogTsParser,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+		return addRow(
+			tree.NewDOid(textSearchDefaultParserOid), // oid
+			tree.NewDName("default"),                 // prsname
+			schemaOid(catconstants.PgCatalogID),      // prsnamespace
+			tree.DNull,                               // prsstart
+			tree.DNull,                               // prstoken
+			tree.DNull,                               // prsend
+			tree.DNull,                               // prsheadline
+			tree.DNull,                               // prslextype
+		)
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatisticExtDataTable = virtualSchemaTable{
@@ -4538,12 +4647,88 @@ func addRowForTimezoneNames(tz string, t time.Time, addRow func(...tree.Datum) e
 }
 
 var pgCatalogTsDictTable = virtualSchemaTable{
-	comment: "pg_ts_dict was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsDict,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `text search dictionaries
+https://www.postgresql.org/docs/16/catalog-pg-ts-dict.html`,
+	schema: vtable.PgCatalogTsDict,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		for _, name := range tsearch.BuiltinDictionaryNames() {
+			dict, _ := tsearch.BuiltinDictionary(name)
+			var options []descpb.SchemaDescriptor_TextSearchOption
+			for _, o := range tsearch.BuiltinDictionaryOptions(name) {
+				options = append(options, descpb.SchemaDescriptor_TextSearchOption{Name: o.Name, Value: o.Value})
+			}
+			if err := addRow(
+				h.TextSearchDictOid(catconstants.PgCatalogID, name), // oid
+				tree.NewDName(name),                        // dictname
+				schemaOid(catconstants.PgCatalogID),        // dictnamespace
+				nodeOID,                                    // dictowner
+				h.TextSearchTemplateOid(dict.Template),     // dicttemplate
+				formatTextSearchDictionaryOptions(options), // dictinitoption
+			); err != nil {
+				return err
+			}
+		}
+		return forEachTextSearchSchema(ctx, p, dbContext, func(sc catalog.SchemaDescriptor, ownerOID tree.Datum) error {
+			return sc.ForEachTextSearchDictionary(func(dict descpb.SchemaDescriptor_TextSearchDictionary) error {
+				return addRow(
+					h.TextSearchDictOid(sc.GetID(), dict.Name),      // oid
+					tree.NewDName(dict.Name),                        // dictname
+					schemaOid(sc.GetID()),                           // dictnamespace
+					ownerOID,                                        // dictowner
+					h.TextSearchTemplateOid(dict.Template),          // dicttemplate
+					formatTextSearchDictionaryOptions(dict.Options), // dictinitoption
+				)
+			})
+		})
 	},
-	unimplemented: true,
+}
+
+// textSearchDefaultParserOid is the OID of the default text search parser in
+// Postgres, which is the only parser we support.
+const textSearchDefaultParserOid = 3722
+
+// forEachTextSearchSchema calls fn for each schema of the database that can
+// contain text search configurations and dictionaries, along with the OID of
+// its owner. Text search objects don't have owners of their own.
+func forEachTextSearchSchema(
+	ctx context.Context,
+	p *planner,
+	dbContext catalog.DatabaseDescriptor,
+	fn func(sc catalog.SchemaDescriptor, ownerOID tree.Datum) error,
+) error {
+	return forEachSchema(ctx, p, dbContext, true /* requiresPrivileges */, func(ctx context.Context, sc catalog.SchemaDescriptor) error {
+		switch sc.SchemaKind() {
+		case catalog.SchemaUserDefined:
+			ownerOID, err := getOwnerOID(ctx, p, sc)
+			if err != nil {
+				return err
+			}
+			return fn(sc, ownerOID)
+		case catalog.SchemaPublic:
+			return fn(sc, adminOID)
+		default:
+			return nil
+		}
+	})
+}
+
+// formatTextSearchDictionaryOptions formats the options of a text search
+// dictionary like Postgres does in pg_ts_dict.dictinitoption.
+func formatTextSearchDictionaryOptions(options []descpb.SchemaDescriptor_TextSearchOption) tree.Datum {
+	if len(options) == 0 {
+		return tree.DNull
+	}
+	var buf bytes.Buffer
+	for i, o := range options {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(o.Name)
+		buf.WriteString(" = ")
+		lexbase.EncodeSQLString(&buf, o.Value)
+	}
+	return tree.NewDString(buf.String())
 }
 
 var pgCatalogStatUserTablesTable = virtualSchemaTable{
@@ -4682,12 +4867,24 @@ var pgCatalogStatXactSysTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogTsTemplateTable = virtualSchemaTable{
-	comment: "pg_ts_template was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsTemplate,
+	comment: `text search templates
+https://www.postgresql.org/docs/16/catalog-pg-ts-template.html`,
+	schema: vtable.PgCatalogTsTemplate,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		for _, template := range tsearch.Templates {
+			if err := addRow(
+				h.TextSearchTemplateOid(template),   // oid
+				tree.NewDName(template),             // tmplname
+				schemaOid(catconstants.PgCatalogID), // tmplnamespace
+				tree.DNull,                          // tmplinit
+				tree.DNull,                          // tmpllexize
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatReplicationTable = virtualSchemaTable{
@@ -4772,6 +4969,7 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.GeographyFamily:   typCategoryUserDefined,
 	types.GeometryFamily:    typCategoryUserDefined,
 	types.JsonFamily:        typCategoryUserDefined,
+	types.JsonpathFamily:    typCategoryUserDefined,
 	types.DecimalFamily:     typCategoryNumeric,
 	types.StringFamily:      typCategoryString,
 	types.TimestampFamily:   typCategoryDateTime,
@@ -4786,6 +4984,18 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.RefCursorFamily:   typCategoryUserDefined,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
+	types.RangeFamily:       typCategoryRange,
+	types.MultirangeFamily:  typCategoryRange,
+	types.PointFamily:       typCategoryGeometric,
+	types.BoxFamily:         typCategoryGeometric,
+	types.LSegFamily:        typCategoryGeometric,
+	types.LineFamily:        typCategoryGeometric,
+	types.PathFamily:        typCategoryGeometric,
+	types.PolygonFamily:     typCategoryGeometric,
+	types.CircleFamily:      typCategoryGeometric,
+	types.MACAddrFamily:     typCategoryUserDefined,
+	types.MACAddr8Family:    typCategoryUserDefined,
+	types.MoneyFamily:       typCategoryNumeric,
 	types.UnknownFamily:     typCategoryUnknown,
 	types.VoidFamily:        typCategoryPseudo,
 	types.TriggerFamily:     typCategoryPseudo,
@@ -5005,6 +5215,9 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	textSearchConfigTypeTag
+	textSearchDictTypeTag
+	textSearchTemplateTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -5179,6 +5392,29 @@ func (h oidHasher) DBSchemaRoleOid(
 	return h.getOid()
 }
 
+// TextSearchConfigOid creates an OID for a text search configuration.
+func (h oidHasher) TextSearchConfigOid(scID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(textSearchConfigTypeTag)
+	h.writeSchema(scID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+// TextSearchDictOid creates an OID for a text search dictionary.
+func (h oidHasher) TextSearchDictOid(scID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(textSearchDictTypeTag)
+	h.writeSchema(scID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+// TextSearchTemplateOid creates an OID for a text search template.
+func (h oidHasher) TextSearchTemplateOid(name string) *tree.DOid {
+	h.writeTypeTag(textSearchTemplateTypeTag)
+	h.writeStr(name)
+	return h.getOid()
+}
+
 func tableOid(id descpb.ID) *tree.DOid {
 	return tree.NewDOid(oid.Oid(id))
 }
@@ -0,0 +1,448 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package pgnotify implements the delivery of the asynchronous notifications
+// sent with NOTIFY and pg_notify to the sessions which LISTEN on a channel.
+//
+// A notification is sent by inserting a row into system.notifications in the
+// sending transaction, so that it only becomes visible, and is only
+// delivered, once that transaction commits. Every SQL instance which has at
+// least one listening session runs a rangefeed over system.notifications and
+// dispatches the rows it receives to the local sessions listening on the
+// corresponding channel. The rows are buffered until the rangefeed frontier
+// passes their commit timestamp, so that the notifications are delivered in
+// commit order and only once, and the rangefeed is stopped once no session on
+// the instance listens anymore. Old rows are periodically deleted.
+package pgnotify
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed/rangefeedbuffer"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/logtags"
+)
+
+// Retention is the amount of time after which sent notifications are deleted
+// from system.notifications.
+var Retention = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.notifications.retention",
+	"the amount of time for which notifications sent with NOTIFY are retained "+
+		"in system.notifications before being deleted",
+	10*time.Minute,
+	settings.PositiveDuration,
+)
+
+// MaxChannelNameLength is the maximum length of a channel name, which matches
+// the maximum length of an identifier in Postgres.
+const MaxChannelNameLength = 63
+
+// MaxPayloadLength is the maximum length of a notification payload, in bytes.
+const MaxPayloadLength = 8000
+
+// cleanupBatchSize is the maximum number of rows deleted by a single
+// statement when removing expired notifications.
+const cleanupBatchSize = 1000
+
+// Notification is an asynchronous notification sent to a channel.
+type Notification struct {
+	Channel string
+	Payload string
+	// SenderPID is the backend PID of the session which sent the notification.
+	SenderPID int32
+}
+
+// Receiver is implemented by a session which can receive notifications.
+type Receiver interface {
+	// Notify delivers a notification to the session. It is called from the
+	// goroutine which processes the rangefeed, so it must not block.
+	Notify(ctx context.Context, n Notification)
+}
+
+// InsertStmt is the statement which sends a notification by inserting it into
+// system.notifications. Its arguments are the channel, the payload and the PID
+// of the sending session.
+const InsertStmt = `INSERT INTO system.notifications (channel, payload, pid) VALUES ($1, $2, $3)`
+
+// CheckSupported returns an error if the cluster has not been upgraded to a
+// version which supports notifications.
+func CheckSupported(ctx context.Context, st *cluster.Settings) error {
+	if !st.Version.IsActive(ctx, clusterversion.V24_3_AddNotificationsTable) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"LISTEN and NOTIFY are not supported until version 24.3")
+	}
+	return nil
+}
+
+// ValidateChannel returns an error if the given name cannot be used as the
+// name of a channel.
+func ValidateChannel(channel string) error {
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > MaxChannelNameLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	return nil
+}
+
+// ValidatePayload returns an error if the given payload is too long.
+func ValidatePayload(payload string) error {
+	if len(payload) >= MaxPayloadLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	return nil
+}
+
+// Registry keeps track of the sessions listening on each channel on this SQL
+// instance and delivers notifications to them.
+type Registry struct {
+	codec            keys.SQLCodec
+	clock            *hlc.Clock
+	f                *rangefeed.Factory
+	stopper          *stop.Stopper
+	st               *cluster.Settings
+	db               isql.DB
+	sysTableResolver catalog.SystemTableIDResolver
+
+	mu struct {
+		syncutil.Mutex
+		// listeners maps each channel to the set of receivers listening on it.
+		listeners map[string]map[Receiver]struct{}
+		// feed is the rangefeed over system.notifications. It is started when
+		// the first session on this instance starts listening and stopped when
+		// the last one stops.
+		feed *feed
+	}
+}
+
+// feed is a rangefeed over system.notifications, along with the notifications
+// it received which have not been delivered yet.
+type feed struct {
+	rf  *rangefeed.RangeFeed
+	buf *rangefeedbuffer.Buffer[event]
+	// dec is only used by the goroutine of the rangefeed.
+	dec rowDecoder
+}
+
+// event is a row of system.notifications received from the rangefeed.
+type event struct {
+	key   roachpb.Key
+	value roachpb.Value
+}
+
+var _ rangefeedbuffer.Event = event{}
+
+// Timestamp implements the rangefeedbuffer.Event interface.
+func (e event) Timestamp() hlc.Timestamp {
+	return e.value.Timestamp
+}
+
+// NewRegistry constructs a new Registry.
+func NewRegistry(
+	codec keys.SQLCodec,
+	clock *hlc.Clock,
+	f *rangefeed.Factory,
+	stopper *stop.Stopper,
+	st *cluster.Settings,
+	db isql.DB,
+	sysTableResolver catalog.SystemTableIDResolver,
+) *Registry {
+	r := &Registry{
+		codec:            codec,
+		clock:            clock,
+		f:                f,
+		stopper:          stopper,
+		st:               st,
+		db:               db,
+		sysTableResolver: sysTableResolver,
+	}
+	r.mu.listeners = make(map[string]map[Receiver]struct{})
+	return r
+}
+
+// Start starts the background task which deletes expired notifications.
+func (r *Registry) Start(ctx context.Context) {
+	_ = r.stopper.RunAsyncTask(ctx, "notifications-cleanup", func(ctx context.Context) {
+		ctx, cancel := r.stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		for {
+			select {
+			case <-time.After(Retention.Get(&r.st.SV) / 2):
+				if err := r.deleteExpired(ctx); err != nil {
+					log.Warningf(ctx, "failed to delete expired notifications: %v", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// deleteExpired deletes the notifications older than the retention period.
+func (r *Registry) deleteExpired(ctx context.Context) error {
+	if CheckSupported(ctx, r.st) != nil {
+		return nil
+	}
+	cutoff := timeutil.Now().Add(-Retention.Get(&r.st.SV))
+	for {
+		n, err := r.db.Executor().ExecEx(
+			ctx, "delete-expired-notifications", nil, /* txn */
+			sessiondata.NodeUserSessionDataOverride,
+			`DELETE FROM system.notifications WHERE created < $1 LIMIT $2`,
+			cutoff, cleanupBatchSize,
+		)
+		if err != nil || n < cleanupBatchSize {
+			return err
+		}
+	}
+}
+
+// Listen registers the receiver as listening on the given channel. It is
+// called once the transaction which executed LISTEN commits.
+func (r *Registry) Listen(ctx context.Context, channel string, rcv Receiver) error {
+	if err := CheckSupported(ctx, r.st); err != nil {
+		return err
+	}
+	if err := ValidateChannel(channel); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mu.feed == nil {
+		f, err := r.startRangeFeed(ctx)
+		if err != nil {
+			return err
+		}
+		r.mu.feed = f
+	}
+	receivers, ok := r.mu.listeners[channel]
+	if !ok {
+		receivers = make(map[Receiver]struct{})
+		r.mu.listeners[channel] = receivers
+	}
+	receivers[rcv] = struct{}{}
+	return nil
+}
+
+// Unlisten unregisters the receiver from the given channel. It is a no-op if
+// the receiver is not listening on the channel.
+func (r *Registry) Unlisten(channel string, rcv Receiver) {
+	r.maybeStopRangeFeed(func() {
+		r.unlistenLocked(channel, rcv)
+	})
+}
+
+// UnlistenAll unregisters the receiver from all channels.
+func (r *Registry) UnlistenAll(rcv Receiver) {
+	r.maybeStopRangeFeed(func() {
+		for channel := range r.mu.listeners {
+			r.unlistenLocked(channel, rcv)
+		}
+	})
+}
+
+func (r *Registry) unlistenLocked(channel string, rcv Receiver) {
+	receivers, ok := r.mu.listeners[channel]
+	if !ok {
+		return
+	}
+	delete(receivers, rcv)
+	if len(receivers) == 0 {
+		delete(r.mu.listeners, channel)
+	}
+}
+
+// maybeStopRangeFeed runs fn, which unregisters receivers, with r.mu held and
+// stops the rangefeed if no receivers are left afterwards. The rangefeed is
+// closed without holding r.mu, since closing it waits for its handlers, which
+// acquire r.mu, to return.
+func (r *Registry) maybeStopRangeFeed(fn func()) {
+	f := func() *feed {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		fn()
+		if len(r.mu.listeners) > 0 {
+			return nil
+		}
+		f := r.mu.feed
+		r.mu.feed = nil
+		return f
+	}()
+	if f != nil {
+		f.rf.Close()
+	}
+}
+
+// startRangeFeed starts the rangefeed over system.notifications. Only the
+// notifications committed after this point are delivered.
+func (r *Registry) startRangeFeed(ctx context.Context) (*feed, error) {
+	tableID, err := r.sysTableResolver.LookupSystemTableID(
+		ctx, systemschema.NotificationsTable.GetName(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	prefix := r.codec.TablePrefix(uint32(tableID))
+	span := roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()}
+
+	// The rows are only retained for sql.notifications.retention, which bounds
+	// the size of the buffer.
+	f := &feed{
+		buf: rangefeedbuffer.New[event](math.MaxInt),
+		dec: makeRowDecoder(),
+	}
+	// The rangefeed runs until no session listens anymore, so it must not be
+	// tied to the context of the session which started it.
+	ctx = logtags.WithTags(context.Background(), logtags.FromContext(ctx))
+	f.rf, err = r.f.RangeFeed(
+		ctx,
+		"notifications",
+		[]roachpb.Span{span},
+		r.clock.Now(),
+		func(ctx context.Context, kv *kvpb.RangeFeedValue) {
+			r.handleEvent(ctx, f, kv)
+		},
+		rangefeed.WithOnFrontierAdvance(func(ctx context.Context, frontier hlc.Timestamp) {
+			r.handleFrontierAdvance(ctx, f, frontier)
+		}),
+		rangefeed.WithSystemTablePriority(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// handleEvent buffers the given row until the frontier of the rangefeed
+// passes its commit timestamp.
+func (r *Registry) handleEvent(ctx context.Context, f *feed, kv *kvpb.RangeFeedValue) {
+	if !kv.Value.IsPresent() {
+		// Ignore the deletion of expired notifications.
+		return
+	}
+	// The buffer discards the rows at or below the frontier, which were
+	// already delivered if the rangefeed emits them again.
+	if err := f.buf.Add(event{key: kv.Key, value: kv.Value}); err != nil {
+		log.Warningf(ctx, "failed to buffer notifications row %v: %v", kv.Key, err)
+	}
+}
+
+// handleFrontierAdvance delivers the buffered rows which were committed at or
+// below the new frontier of the rangefeed to the receivers listening on their
+// channel. The rows are delivered in commit order, and the rows inserted by a
+// transaction are delivered in the order in which they were inserted.
+//
+// Like in Postgres, identical notifications sent by a transaction are only
+// delivered once. This also discards the rows which the rangefeed emitted more
+// than once before the frontier passed them.
+func (r *Registry) handleFrontierAdvance(ctx context.Context, f *feed, frontier hlc.Timestamp) {
+	events := f.buf.Flush(ctx, frontier)
+	if len(events) == 0 {
+		return
+	}
+	// The keys of the rows are ordered by their unique_rowid primary key,
+	// which increases with every row inserted by a session.
+	sort.Slice(events, func(i, j int) bool {
+		if c := events[i].value.Timestamp.Compare(events[j].value.Timestamp); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(events[i].key, events[j].key) < 0
+	})
+	type txnNotification struct {
+		ts hlc.Timestamp
+		n  Notification
+	}
+	seen := make(map[txnNotification]struct{})
+	notifications := make([]Notification, 0, len(events))
+	for _, ev := range events {
+		n, err := f.dec.decodeRow(ev.value)
+		if err != nil {
+			log.Warningf(ctx, "failed to decode notifications row %v: %v", ev.key, err)
+			continue
+		}
+		k := txnNotification{ts: ev.value.Timestamp, n: n}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		notifications = append(notifications, n)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mu.feed != f {
+		// The rangefeed is being stopped.
+		return
+	}
+	for _, n := range notifications {
+		for rcv := range r.mu.listeners[n.Channel] {
+			rcv.Notify(ctx, n)
+		}
+	}
+}
+
+// rowDecoder decodes rows from the system.notifications table.
+type rowDecoder struct {
+	alloc   tree.DatumAlloc
+	decoder valueside.Decoder
+}
+
+func makeRowDecoder() rowDecoder {
+	return rowDecoder{
+		decoder: valueside.MakeDecoder(systemschema.NotificationsTable.PublicColumns()),
+	}
+}
+
+// decodeRow decodes the notification stored in a row of system.notifications.
+// All the columns other than the primary key are stored in a single family, so
+// the key does not need to be decoded.
+func (d *rowDecoder) decodeRow(value roachpb.Value) (Notification, error) {
+	bytes, err := value.GetTuple()
+	if err != nil {
+		return Notification{}, err
+	}
+	datums, err := d.decoder.Decode(&d.alloc, bytes)
+	if err != nil {
+		return Notification{}, err
+	}
+	channel, ok := datums[1].(*tree.DString)
+	if !ok {
+		return Notification{}, errors.AssertionFailedf("unexpected channel %v", datums[1])
+	}
+	n := Notification{Channel: string(*channel)}
+	if payload, ok := datums[2].(*tree.DString); ok {
+		n.Payload = string(*payload)
+	}
+	if pid, ok := datums[3].(*tree.DInt); ok {
+		n.SenderPID = int32(*pid)
+	}
+	return n, nil
+}
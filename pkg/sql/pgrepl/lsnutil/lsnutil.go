@@ -12,9 +12,33 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 )
 
+// logicalBits is the number of low bits of a LSN which hold the logical
+// component of a HLC timestamp. The remaining bits hold the wall time in
+// microseconds, which leaves enough room for the next few centuries.
+const logicalBits = 10
+
+// maxLogical is the largest logical component which can be represented in a
+// LSN. Larger logical components are clamped, which preserves the ordering of
+// timestamps but not their uniqueness.
+const maxLogical = 1<<logicalBits - 1
+
 // HLCToLSN converts a HLC to a LSN.
 // It is in a separate package to prevent the `lsn` package importing `log`.
+//
+// The conversion is monotonic: if a <= b, then HLCToLSN(a) <= HLCToLSN(b).
+// Timestamps which only differ below a microsecond map to the same LSN.
 func HLCToLSN(h hlc.Timestamp) lsn.LSN {
-	// TODO(#105130): correctly populate this field.
-	return lsn.LSN(h.WallTime/int64(time.Millisecond)) << 32
+	logical := h.Logical
+	if logical > maxLogical {
+		logical = maxLogical
+	}
+	return lsn.LSN(h.WallTime/int64(time.Microsecond))<<logicalBits | lsn.LSN(logical)
+}
+
+// LSNToHLC converts a LSN to the smallest HLC which maps to it with HLCToLSN.
+func LSNToHLC(l lsn.LSN) hlc.Timestamp {
+	return hlc.Timestamp{
+		WallTime: int64(l>>logicalBits) * int64(time.Microsecond),
+		Logical:  int32(l & maxLogical),
+	}
 }
@@ -0,0 +1,50 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package lsnutil
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHLCToLSN(t *testing.T) {
+	for _, tc := range []struct {
+		ts       hlc.Timestamp
+		expected string
+	}{
+		{ts: hlc.Timestamp{}, expected: "0/0"},
+		{ts: hlc.Timestamp{WallTime: 1000}, expected: "0/400"},
+		{ts: hlc.Timestamp{WallTime: 1999, Logical: 3}, expected: "0/403"},
+		{ts: hlc.Timestamp{WallTime: 1000, Logical: 5000}, expected: "0/7FF"},
+		{ts: hlc.Timestamp{WallTime: 1728000000000000000}, expected: "188E6D68/B0000000"},
+	} {
+		t.Run(tc.ts.String(), func(t *testing.T) {
+			l := HLCToLSN(tc.ts)
+			require.Equal(t, tc.expected, l.String())
+			// LSNToHLC returns the smallest timestamp which maps to the LSN.
+			back := LSNToHLC(l)
+			require.True(t, back.LessEq(tc.ts))
+			require.Equal(t, l, HLCToLSN(back))
+		})
+	}
+}
+
+func TestHLCToLSNMonotonic(t *testing.T) {
+	timestamps := []hlc.Timestamp{
+		{WallTime: 1},
+		{WallTime: 1000},
+		{WallTime: 1000, Logical: 1},
+		{WallTime: 1001, Logical: 0},
+		{WallTime: 1001, Logical: 2000},
+		{WallTime: 2000},
+		{WallTime: 1728000000000000000, Logical: 1},
+	}
+	for i := 1; i < len(timestamps); i++ {
+		require.LessOrEqual(t, HLCToLSN(timestamps[i-1]), HLCToLSN(timestamps[i]))
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package pgoutput implements the messages of pgoutput, the standard logical
+// decoding output plugin of Postgres, and the messages of the streaming
+// replication protocol which carry them. See
+// https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+// and https://www.postgresql.org/docs/current/protocol-replication.html.
+package pgoutput
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
+)
+
+// PluginName is the name of the output plugin implemented by this package.
+const PluginName = "pgoutput"
+
+// ProtocolVersion is the version of the logical replication protocol
+// implemented by this package. Later versions add support for streaming and
+// two-phase transactions, which are not supported.
+const ProtocolVersion = 1
+
+// The types of the pgoutput messages.
+const (
+	msgBegin    byte = 'B'
+	msgCommit   byte = 'C'
+	msgRelation byte = 'R'
+	msgInsert   byte = 'I'
+	msgUpdate   byte = 'U'
+	msgDelete   byte = 'D'
+)
+
+// The kinds of the tuples in Update and Delete messages.
+const (
+	tupleNew byte = 'N'
+	tupleKey byte = 'K'
+)
+
+// The kinds of the columns in a tuple.
+const (
+	columnNull byte = 'n'
+	columnText byte = 't'
+)
+
+// replicaIdentityDefault is the replica identity of all relations, which
+// means that the old values of the primary key columns are sent with Update
+// and Delete messages.
+const replicaIdentityDefault byte = 'd'
+
+// pgEpoch is the epoch of the timestamps in the replication protocol.
+var pgEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// pgTime converts t to the number of microseconds since pgEpoch.
+func pgTime(t time.Time) int64 {
+	return t.Sub(pgEpoch).Microseconds()
+}
+
+// Column is a column of a Relation.
+type Column struct {
+	Name string
+	Type *types.T
+	// Key is true if the column is part of the primary key of the relation.
+	Key bool
+}
+
+// Relation describes a table whose changes are streamed. A Relation message
+// is sent before the first change to a table and after every change to its
+// schema.
+type Relation struct {
+	ID        oid.Oid
+	Namespace string
+	Name      string
+	Columns   []Column
+}
+
+// Encoder encodes pgoutput messages. Each method appends a message to the
+// given buffer and returns the extended buffer. The datums of tuples are
+// encoded in the text format.
+type Encoder struct {
+	fmtCtx *tree.FmtCtx
+}
+
+// MakeEncoder returns an Encoder which formats datums with the given context,
+// which should have the tree.FmtPgwireText flags.
+func MakeEncoder(fmtCtx *tree.FmtCtx) Encoder {
+	return Encoder{fmtCtx: fmtCtx}
+}
+
+// AppendBegin appends a Begin message for a transaction which commits at the
+// given LSN and time.
+func (e *Encoder) AppendBegin(b []byte, commitLSN lsn.LSN, commitTime time.Time, xid uint32) []byte {
+	b = append(b, msgBegin)
+	b = binary.BigEndian.AppendUint64(b, uint64(commitLSN))
+	b = binary.BigEndian.AppendUint64(b, uint64(pgTime(commitTime)))
+	return binary.BigEndian.AppendUint32(b, xid)
+}
+
+// AppendCommit appends a Commit message for a transaction. The end LSN is the
+// position from which the stream can be resumed once the transaction has been
+// applied by the client.
+func (e *Encoder) AppendCommit(
+	b []byte, commitLSN lsn.LSN, endLSN lsn.LSN, commitTime time.Time,
+) []byte {
+	b = append(b, msgCommit)
+	b = append(b, 0 /* flags */)
+	b = binary.BigEndian.AppendUint64(b, uint64(commitLSN))
+	b = binary.BigEndian.AppendUint64(b, uint64(endLSN))
+	return binary.BigEndian.AppendUint64(b, uint64(pgTime(commitTime)))
+}
+
+// AppendRelation appends a Relation message.
+func (e *Encoder) AppendRelation(b []byte, r *Relation) []byte {
+	b = append(b, msgRelation)
+	b = binary.BigEndian.AppendUint32(b, uint32(r.ID))
+	b = appendString(b, r.Namespace)
+	b = appendString(b, r.Name)
+	b = append(b, replicaIdentityDefault)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(r.Columns)))
+	for i := range r.Columns {
+		c := &r.Columns[i]
+		var flags byte
+		if c.Key {
+			flags = 1
+		}
+		b = append(b, flags)
+		b = appendString(b, c.Name)
+		b = binary.BigEndian.AppendUint32(b, uint32(c.Type.Oid()))
+		b = binary.BigEndian.AppendUint32(b, uint32(c.Type.TypeModifier()))
+	}
+	return b
+}
+
+// AppendInsert appends an Insert message for a new row of a relation.
+func (e *Encoder) AppendInsert(b []byte, relID oid.Oid, row tree.Datums) []byte {
+	b = append(b, msgInsert)
+	b = binary.BigEndian.AppendUint32(b, uint32(relID))
+	b = append(b, tupleNew)
+	return e.appendTuple(b, row)
+}
+
+// AppendUpdate appends an Update message for a changed row of a relation. If
+// the primary key of the row was changed, oldKey contains its old value, with
+// all the other columns set to NULL. Otherwise, oldKey is nil.
+func (e *Encoder) AppendUpdate(b []byte, relID oid.Oid, oldKey, row tree.Datums) []byte {
+	b = append(b, msgUpdate)
+	b = binary.BigEndian.AppendUint32(b, uint32(relID))
+	if oldKey != nil {
+		b = append(b, tupleKey)
+		b = e.appendTuple(b, oldKey)
+	}
+	b = append(b, tupleNew)
+	return e.appendTuple(b, row)
+}
+
+// AppendDelete appends a Delete message for a deleted row of a relation.
+// oldKey contains the primary key of the row, with all the other columns set
+// to NULL.
+func (e *Encoder) AppendDelete(b []byte, relID oid.Oid, oldKey tree.Datums) []byte {
+	b = append(b, msgDelete)
+	b = binary.BigEndian.AppendUint32(b, uint32(relID))
+	b = append(b, tupleKey)
+	return e.appendTuple(b, oldKey)
+}
+
+// appendTuple appends the TupleData of a row.
+func (e *Encoder) appendTuple(b []byte, row tree.Datums) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(row)))
+	for _, d := range row {
+		if d == tree.DNull {
+			b = append(b, columnNull)
+			continue
+		}
+		e.fmtCtx.Buffer.Reset()
+		e.fmtCtx.FormatNode(d)
+		b = append(b, columnText)
+		b = binary.BigEndian.AppendUint32(b, uint32(e.fmtCtx.Buffer.Len()))
+		b = append(b, e.fmtCtx.Buffer.Bytes()...)
+	}
+	return b
+}
+
+// appendString appends a null-terminated string.
+func appendString(b []byte, s string) []byte {
+	b = append(b, s...)
+	return append(b, 0)
+}
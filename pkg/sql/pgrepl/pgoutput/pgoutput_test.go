@@ -0,0 +1,134 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package pgoutput
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder(t *testing.T) {
+	fmtCtx := tree.NewFmtCtx(tree.FmtPgwireText)
+	defer fmtCtx.Close()
+	e := MakeEncoder(fmtCtx)
+	commitTime := pgEpoch.Add(3 * time.Second)
+
+	t.Run("begin", func(t *testing.T) {
+		b := e.AppendBegin(nil, lsn.LSN(0x1_00000002), commitTime, 7)
+		require.Equal(t, []byte{
+			'B',
+			0, 0, 0, 1, 0, 0, 0, 2,
+			0, 0, 0, 0, 0, 0x2d, 0xc6, 0xc0,
+			0, 0, 0, 7,
+		}, b)
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		b := e.AppendCommit(nil, lsn.LSN(2), lsn.LSN(3), commitTime)
+		require.Equal(t, []byte{
+			'C', 0,
+			0, 0, 0, 0, 0, 0, 0, 2,
+			0, 0, 0, 0, 0, 0, 0, 3,
+			0, 0, 0, 0, 0, 0x2d, 0xc6, 0xc0,
+		}, b)
+	})
+
+	t.Run("relation", func(t *testing.T) {
+		b := e.AppendRelation(nil, &Relation{
+			ID:        104,
+			Namespace: "public",
+			Name:      "t",
+			Columns: []Column{
+				{Name: "k", Type: types.Int, Key: true},
+				{Name: "v", Type: types.MakeVarChar(10)},
+			},
+		})
+		expected := []byte{'R', 0, 0, 0, 104}
+		expected = append(expected, "public\x00t\x00d"...)
+		expected = append(expected, 0, 2)
+		expected = append(expected, 1, 'k', 0, 0, 0, 0, 20, 0xff, 0xff, 0xff, 0xff)
+		expected = append(expected, 0, 'v', 0, 0, 0, 0x04, 0x13, 0, 0, 0, 14)
+		require.Equal(t, expected, b)
+	})
+
+	row := tree.Datums{tree.NewDInt(1), tree.NewDString("a'b"), tree.DNull, tree.DBoolTrue}
+	tuple := []byte{0, 4, 't', 0, 0, 0, 1, '1', 't', 0, 0, 0, 3, 'a', '\'', 'b', 'n', 't', 0, 0, 0, 1, 't'}
+	key := tree.Datums{tree.NewDInt(1), tree.DNull, tree.DNull, tree.DNull}
+	keyTuple := []byte{0, 4, 't', 0, 0, 0, 1, '1', 'n', 'n', 'n'}
+
+	t.Run("insert", func(t *testing.T) {
+		b := e.AppendInsert(nil, 104, row)
+		expected := append([]byte{'I', 0, 0, 0, 104, 'N'}, tuple...)
+		require.Equal(t, expected, b)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		b := e.AppendUpdate(nil, 104, nil /* oldKey */, row)
+		expected := append([]byte{'U', 0, 0, 0, 104, 'N'}, tuple...)
+		require.Equal(t, expected, b)
+
+		b = e.AppendUpdate(nil, 104, key, row)
+		expected = append([]byte{'U', 0, 0, 0, 104, 'K'}, keyTuple...)
+		expected = append(expected, 'N')
+		expected = append(expected, tuple...)
+		require.Equal(t, expected, b)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		b := e.AppendDelete(nil, 104, key)
+		expected := append([]byte{'D', 0, 0, 0, 104, 'K'}, keyTuple...)
+		require.Equal(t, expected, b)
+	})
+}
+
+func TestReplicationMessages(t *testing.T) {
+	now := pgEpoch.Add(time.Microsecond)
+
+	b := AppendXLogData(nil, lsn.LSN(1), lsn.LSN(2), now, []byte("data"))
+	require.Equal(t, append([]byte{
+		'w',
+		0, 0, 0, 0, 0, 0, 0, 1,
+		0, 0, 0, 0, 0, 0, 0, 2,
+		0, 0, 0, 0, 0, 0, 0, 1,
+	}, "data"...), b)
+
+	b = AppendPrimaryKeepalive(nil, lsn.LSN(2), now, true /* replyRequested */)
+	require.Equal(t, []byte{
+		'k',
+		0, 0, 0, 0, 0, 0, 0, 2,
+		0, 0, 0, 0, 0, 0, 0, 1,
+		1,
+	}, b)
+
+	status := []byte{'r'}
+	for _, v := range []uint64{3, 2, 1, 0} {
+		status = binary.BigEndian.AppendUint64(status, v)
+	}
+	status = append(status, 1)
+	update, ok, err := ParseClientMessage(status)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, StandbyStatusUpdate{
+		Written: 3, Flushed: 2, Applied: 1, ReplyRequested: true,
+	}, update)
+
+	_, _, err = ParseClientMessage(status[:10])
+	require.ErrorContains(t, err, "invalid standby status update message")
+
+	feedback := append([]byte{'h'}, make([]byte, hotStandbyFeedbackMinLen-1)...)
+	_, ok, err = ParseClientMessage(feedback)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, _, err = ParseClientMessage([]byte{'x'})
+	require.ErrorContains(t, err, "unexpected replication message type")
+}
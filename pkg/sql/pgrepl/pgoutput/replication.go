@@ -0,0 +1,99 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package pgoutput
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+)
+
+// The types of the messages sent by the server in CopyData messages once the
+// connection has switched to the Copy-both subprotocol.
+const (
+	msgXLogData         byte = 'w'
+	msgPrimaryKeepalive byte = 'k'
+)
+
+// The types of the messages sent by the client in CopyData messages.
+const (
+	msgStandbyStatusUpdate   byte = 'r'
+	msgHotStandbyFeedback    byte = 'h'
+	standbyStatusUpdateLen        = 1 + 8 + 8 + 8 + 8 + 1
+	hotStandbyFeedbackMinLen      = 1 + 8 + 4 + 4
+)
+
+// AppendXLogData appends a XLogData message which carries a message encoded
+// by an Encoder. start is the position of the message in the stream and end is
+// the current end of the stream.
+func AppendXLogData(b []byte, start, end lsn.LSN, now time.Time, data []byte) []byte {
+	b = append(b, msgXLogData)
+	b = binary.BigEndian.AppendUint64(b, uint64(start))
+	b = binary.BigEndian.AppendUint64(b, uint64(end))
+	b = binary.BigEndian.AppendUint64(b, uint64(pgTime(now)))
+	return append(b, data...)
+}
+
+// AppendPrimaryKeepalive appends a Primary keepalive message, which reports the
+// current end of the stream to the client. If replyRequested is true, the
+// client should reply with a Standby status update message.
+func AppendPrimaryKeepalive(b []byte, end lsn.LSN, now time.Time, replyRequested bool) []byte {
+	b = append(b, msgPrimaryKeepalive)
+	b = binary.BigEndian.AppendUint64(b, uint64(end))
+	b = binary.BigEndian.AppendUint64(b, uint64(pgTime(now)))
+	if replyRequested {
+		return append(b, 1)
+	}
+	return append(b, 0)
+}
+
+// StandbyStatusUpdate is the message with which the client reports the
+// position up to which it has received and processed the stream.
+type StandbyStatusUpdate struct {
+	Written lsn.LSN
+	// Flushed is the position up to which the client has durably processed
+	// the stream. The stream can be resumed from it.
+	Flushed lsn.LSN
+	Applied lsn.LSN
+	// ReplyRequested is true if the client requests a Primary keepalive
+	// message in response.
+	ReplyRequested bool
+}
+
+// ParseClientMessage parses the contents of a CopyData message sent by the
+// client during streaming. It returns false if the message is a Hot standby
+// feedback message, which is only meaningful for physical replication and is
+// ignored.
+func ParseClientMessage(data []byte) (_ StandbyStatusUpdate, ok bool, _ error) {
+	if len(data) == 0 {
+		return StandbyStatusUpdate{}, false, pgwirebase.NewProtocolViolationErrorf(
+			"empty replication message")
+	}
+	switch data[0] {
+	case msgStandbyStatusUpdate:
+		if len(data) < standbyStatusUpdateLen {
+			return StandbyStatusUpdate{}, false, pgwirebase.NewProtocolViolationErrorf(
+				"invalid standby status update message")
+		}
+		return StandbyStatusUpdate{
+			Written:        lsn.LSN(binary.BigEndian.Uint64(data[1:])),
+			Flushed:        lsn.LSN(binary.BigEndian.Uint64(data[9:])),
+			Applied:        lsn.LSN(binary.BigEndian.Uint64(data[17:])),
+			ReplyRequested: data[33] != 0,
+		}, true, nil
+	case msgHotStandbyFeedback:
+		if len(data) < hotStandbyFeedbackMinLen {
+			return StandbyStatusUpdate{}, false, pgwirebase.NewProtocolViolationErrorf(
+				"invalid hot standby feedback message")
+		}
+		return StandbyStatusUpdate{}, false, nil
+	default:
+		return StandbyStatusUpdate{}, false, pgwirebase.NewProtocolViolationErrorf(
+			"unexpected replication message type %q", data[0])
+	}
+}
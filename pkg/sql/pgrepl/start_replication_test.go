@@ -0,0 +1,275 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package pgrepl
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStartReplication streams changes with START_REPLICATION and checks the
+// decoded pgoutput messages.
+func TestStartReplication(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v STRING)`)
+	sqlDB.Exec(t, `CREATE TABLE u (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `CREATE PUBLICATION p FOR TABLE t`)
+
+	conn := connectReplication(ctx, t, srv.ApplicationLayer(), sqlDB)
+	defer func() { _ = conn.Close(ctx) }()
+	fe := startReplication(t, conn, "s", "p")
+
+	// The changes to u are not streamed, since it is not published.
+	sqlDB.Exec(t, `INSERT INTO t VALUES (1, 'a')`)
+	sqlDB.Exec(t, `INSERT INTO u VALUES (1)`)
+	sqlDB.Exec(t, `UPDATE t SET v = 'b' WHERE k = 1`)
+	sqlDB.Exec(t, `INSERT INTO u VALUES (2)`)
+	sqlDB.Exec(t, `DELETE FROM t WHERE k = 1`)
+
+	decoded, lastCommit := receiveTxns(t, fe, 3)
+	require.Equal(t, []string{
+		"BEGIN", "RELATION public.t", "I 1 a", "COMMIT",
+		"BEGIN", "U 1 b", "COMMIT",
+		"BEGIN", "D 1 NULL", "COMMIT",
+	}, decoded)
+
+	// Confirm the stream and end it.
+	status := []byte{'r'}
+	for _, v := range []uint64{lastCommit, lastCommit, lastCommit, 0} {
+		status = binary.BigEndian.AppendUint64(status, v)
+	}
+	status = append(status, 0)
+	fe.Send(&pgproto3.CopyData{Data: status})
+	fe.Send(&pgproto3.CopyDone{})
+	require.NoError(t, fe.Flush())
+	for done := false; !done; {
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		switch msg := msg.(type) {
+		case *pgproto3.CopyData:
+			require.Equal(t, byte('k'), msg.Data[0])
+		case *pgproto3.CopyDone, *pgproto3.CommandComplete:
+		case *pgproto3.ReadyForQuery:
+			done = true
+		default:
+			t.Fatalf("unexpected message %T", msg)
+		}
+	}
+	sqlDB.CheckQueryResults(t,
+		`SELECT confirmed_flush_lsn FROM system.replication_slots WHERE slot_name = 's'`,
+		[][]string{{fmt.Sprint(lastCommit)}},
+	)
+}
+
+// TestStartReplicationPublishedLater checks that the tables which become
+// published while a stream runs are streamed from then on.
+func TestStartReplicationPublishedLater(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	t.Run("add table", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE a (k INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `CREATE TABLE b (k INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `CREATE PUBLICATION add_pub FOR TABLE a`)
+
+		conn := connectReplication(ctx, t, srv.ApplicationLayer(), sqlDB)
+		defer func() { _ = conn.Close(ctx) }()
+		fe := startReplication(t, conn, "add_slot", "add_pub")
+
+		sqlDB.Exec(t, `INSERT INTO b VALUES (1)`)
+		sqlDB.Exec(t, `ALTER PUBLICATION add_pub ADD TABLE b`)
+		sqlDB.Exec(t, `INSERT INTO b VALUES (2)`)
+		sqlDB.Exec(t, `ALTER PUBLICATION add_pub DROP TABLE b`)
+		sqlDB.Exec(t, `INSERT INTO b VALUES (3)`)
+		sqlDB.Exec(t, `INSERT INTO a VALUES (4)`)
+
+		decoded, _ := receiveTxns(t, fe, 2)
+		require.Equal(t, []string{
+			"BEGIN", "RELATION public.b", "I 2", "COMMIT",
+			"BEGIN", "RELATION public.a", "I 4", "COMMIT",
+		}, decoded)
+	})
+
+	t.Run("all tables", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE PUBLICATION all_pub FOR ALL TABLES`)
+
+		conn := connectReplication(ctx, t, srv.ApplicationLayer(), sqlDB)
+		defer func() { _ = conn.Close(ctx) }()
+		fe := startReplication(t, conn, "all_slot", "all_pub")
+
+		sqlDB.Exec(t, `CREATE TABLE c (k INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `INSERT INTO c VALUES (5)`)
+
+		decoded, _ := receiveTxns(t, fe, 1)
+		require.Equal(t, []string{
+			"BEGIN", "RELATION public.c", "I 5", "COMMIT",
+		}, decoded)
+	})
+}
+
+// TestStartReplicationErrors checks the errors of the streams which cannot
+// start or continue.
+func TestStartReplicationErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE f (k INT PRIMARY KEY, v INT, FAMILY (k), FAMILY (v))`)
+	sqlDB.ExpectErr(t, `cannot publish table "f" with multiple column families`,
+		`CREATE PUBLICATION p FOR TABLE f`)
+	sqlDB.Exec(t, `CREATE PUBLICATION p FOR ALL TABLES`)
+
+	conn := connectReplication(ctx, t, srv.ApplicationLayer(), sqlDB)
+	defer func() { _ = conn.Close(ctx) }()
+	_, err := conn.Exec(ctx, `CREATE_REPLICATION_SLOT s LOGICAL pgoutput`).ReadAll()
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		options string
+		err     string
+	}{
+		{`proto_version '1'`, `publication_names parameter missing`},
+		{`proto_version '1', publication_names 'q'`, `publication "q" does not exist`},
+	} {
+		_, err := conn.Exec(ctx, `START_REPLICATION SLOT s LOGICAL 0/0 (`+tc.options+`)`).ReadAll()
+		require.ErrorContains(t, err, tc.err)
+	}
+
+	// The tables with multiple column families are rejected once they are
+	// published.
+	fe := conn.Frontend()
+	fe.Send(&pgproto3.Query{
+		String: `START_REPLICATION SLOT s LOGICAL 0/0 (proto_version '1', publication_names 'p')`,
+	})
+	require.NoError(t, fe.Flush())
+	for {
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		if errMsg, ok := msg.(*pgproto3.ErrorResponse); ok {
+			require.Equal(t, pgcode.FeatureNotSupported.String(), errMsg.Code)
+			require.Contains(t, errMsg.Message, `cannot publish table "f" with multiple column families`)
+			break
+		}
+	}
+}
+
+// connectReplication opens a replication connection to the server.
+func connectReplication(
+	ctx context.Context, t *testing.T, s serverutils.ApplicationLayerInterface, sqlDB *sqlutils.SQLRunner,
+) *pgconn.PgConn {
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING sql.pgrepl.keepalive_interval = '100ms'`)
+	pgURL, cleanup := s.PGUrl(
+		t, serverutils.CertsDirPrefix("pgrepl_start_replication_test"), serverutils.User(username.RootUser),
+	)
+	defer cleanup()
+	cfg, err := pgconn.ParseConfig(pgURL.String())
+	require.NoError(t, err)
+	cfg.RuntimeParams["replication"] = "database"
+	conn, err := pgconn.ConnectConfig(ctx, cfg)
+	require.NoError(t, err)
+	return conn
+}
+
+// startReplication creates a replication slot and starts streaming the
+// changes to the tables of the publication from it.
+func startReplication(
+	t *testing.T, conn *pgconn.PgConn, slot string, publication string,
+) *pgproto3.Frontend {
+	_, err := conn.Exec(context.Background(),
+		fmt.Sprintf(`CREATE_REPLICATION_SLOT %s LOGICAL pgoutput`, slot)).ReadAll()
+	require.NoError(t, err)
+
+	fe := conn.Frontend()
+	fe.Send(&pgproto3.Query{
+		String: fmt.Sprintf(`START_REPLICATION SLOT %s LOGICAL 0/0 (proto_version '1', publication_names '%s')`,
+			slot, publication),
+	})
+	require.NoError(t, fe.Flush())
+	msg, err := fe.Receive()
+	require.NoError(t, err)
+	require.IsType(t, &pgproto3.CopyBothResponse{}, msg)
+	return fe
+}
+
+// receiveTxns reads the given number of transactions from the stream,
+// ignoring the keepalive messages. It returns the decoded pgoutput messages
+// and the LSN of the last commit.
+func receiveTxns(t *testing.T, fe *pgproto3.Frontend, n int) (decoded []string, lastCommit uint64) {
+	for commits := 0; commits < n; {
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		copyData, ok := msg.(*pgproto3.CopyData)
+		require.True(t, ok, "unexpected message %T", msg)
+		if copyData.Data[0] != 'w' {
+			continue
+		}
+		start := binary.BigEndian.Uint64(copyData.Data[1:])
+		data := copyData.Data[25:]
+		switch data[0] {
+		case 'B':
+			decoded = append(decoded, "BEGIN")
+		case 'R':
+			decoded = append(decoded, "RELATION "+strings.Join(strings.Split(string(data[5:]), "\x00")[:2], "."))
+		case 'I', 'U', 'D':
+			decoded = append(decoded, fmt.Sprintf("%c %s", data[0], decodeTuple(data[6:])))
+		case 'C':
+			decoded = append(decoded, "COMMIT")
+			lastCommit = start
+			commits++
+		default:
+			t.Fatalf("unexpected pgoutput message %q", data[0])
+		}
+	}
+	return decoded, lastCommit
+}
+
+// decodeTuple decodes a pgoutput TupleData into space-separated values.
+func decodeTuple(data []byte) string {
+	n := int(binary.BigEndian.Uint16(data))
+	data = data[2:]
+	vals := make([]string, n)
+	for i := range vals {
+		kind := data[0]
+		data = data[1:]
+		if kind != 't' {
+			vals[i] = "NULL"
+			continue
+		}
+		l := int(binary.BigEndian.Uint32(data))
+		vals[i] = string(data[4 : 4+l])
+		data = data[4+l:]
+	}
+	return strings.Join(vals, " ")
+}
@@ -0,0 +1,253 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package walsender
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/lease"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// changeKind is the kind of a change to a row.
+type changeKind int
+
+const (
+	insertChange changeKind = iota
+	updateChange
+	deleteChange
+)
+
+// change is a decoded change to a row of a table.
+type change struct {
+	kind changeKind
+	// table is the decoder of the version of the table the change was made to.
+	table *tableDecoder
+	// row is the new row for inserts and updates, and the primary key of the
+	// deleted row, with the other columns set to NULL, for deletes.
+	row tree.Datums
+}
+
+// tableVersion identifies a version of a table descriptor.
+type tableVersion struct {
+	id      descpb.ID
+	version descpb.DescriptorVersion
+}
+
+// tableDecoder decodes the rows of a version of a table.
+type tableDecoder struct {
+	version  tableVersion
+	relation pgoutput.Relation
+	// primaryIndexID is the ID of the index which holds the rows of the table.
+	// The changes to the other indexes are ignored.
+	primaryIndexID descpb.IndexID
+	fetcher        row.Fetcher
+	spec           fetchpb.IndexFetchSpec
+	alloc          tree.DatumAlloc
+}
+
+// decoder decodes the values emitted by the rangefeed into changes.
+type decoder struct {
+	codec        keys.SQLCodec
+	leaseManager *lease.Manager
+	db           descs.DB
+	tables       map[tableVersion]*tableDecoder
+	kvs          row.KVProvider
+}
+
+func makeDecoder(codec keys.SQLCodec, leaseManager *lease.Manager, db descs.DB) decoder {
+	return decoder{
+		codec:        codec,
+		leaseManager: leaseManager,
+		db:           db,
+		tables:       make(map[tableVersion]*tableDecoder),
+	}
+}
+
+// tableID returns the ID of the table of the given key.
+func (d *decoder) tableID(key roachpb.Key) (descpb.ID, error) {
+	_, tableID, err := d.codec.DecodeTablePrefix(key)
+	return descpb.ID(tableID), err
+}
+
+// decode decodes the given rangefeed value. It returns false if the value does
+// not correspond to a change which should be streamed.
+func (d *decoder) decode(ctx context.Context, v *kvpb.RangeFeedValue) (change, bool, error) {
+	key, err := d.codec.StripTenantPrefix(v.Key)
+	if err != nil {
+		return change{}, false, err
+	}
+	_, tableID, indexID, err := rowenc.DecodePartialTableIDIndexID(key)
+	if err != nil {
+		return change{}, false, err
+	}
+	ts := v.Value.Timestamp
+	t, err := d.tableAt(ctx, tableID, ts)
+	if err != nil {
+		return change{}, false, err
+	}
+	if indexID != t.primaryIndexID {
+		return change{}, false, nil
+	}
+	c := change{table: t}
+	switch {
+	case !v.Value.IsPresent():
+		if !v.PrevValue.IsPresent() {
+			// The row did not exist before, so there is nothing to delete.
+			return change{}, false, nil
+		}
+		c.kind = deleteChange
+	case v.PrevValue.IsPresent():
+		c.kind = updateChange
+	default:
+		c.kind = insertChange
+	}
+	c.row, err = t.decodeRow(ctx, &d.kvs, roachpb.KeyValue{Key: v.Key, Value: v.Value})
+	if err != nil {
+		return change{}, false, errors.Wrapf(err, "decoding key %s", v.Key)
+	}
+	return c, true, nil
+}
+
+// tableAt returns the decoder of the version of the table at the given
+// timestamp.
+func (d *decoder) tableAt(
+	ctx context.Context, id descpb.ID, ts hlc.Timestamp,
+) (*tableDecoder, error) {
+	leased, err := d.leaseManager.Acquire(ctx, ts, id)
+	if err != nil {
+		return nil, err
+	}
+	// The lease is only needed to look up the version at the timestamp.
+	defer leased.Release(ctx)
+	version := tableVersion{id: id, version: leased.Underlying().GetVersion()}
+	if t, ok := d.tables[version]; ok {
+		return t, nil
+	}
+	desc, ok := leased.Underlying().(catalog.TableDescriptor)
+	if !ok {
+		return nil, errors.AssertionFailedf("descriptor %d is not a table", id)
+	}
+	if desc.MaybeRequiresTypeHydration() {
+		// Only the descriptor collection hydrates the user-defined types of the
+		// columns.
+		if err := d.db.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) (err error) {
+			if err := txn.KV().SetFixedTimestamp(ctx, ts); err != nil {
+				return err
+			}
+			desc, err = txn.Descriptors().ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, id)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+	}
+	schema, err := d.leaseManager.Acquire(ctx, ts, desc.GetParentSchemaID())
+	if err != nil {
+		return nil, err
+	}
+	defer schema.Release(ctx)
+	t, err := newTableDecoder(ctx, d.codec, desc, schema.Underlying().GetName())
+	if err != nil {
+		return nil, err
+	}
+	d.tables[version] = t
+	return t, nil
+}
+
+func newTableDecoder(
+	ctx context.Context, codec keys.SQLCodec, desc catalog.TableDescriptor, schemaName string,
+) (*tableDecoder, error) {
+	if len(desc.GetFamilies()) > 1 {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"logical replication of table %q with multiple column families is not supported",
+			desc.GetName())
+	}
+	t := &tableDecoder{
+		version:        tableVersion{id: desc.GetID(), version: desc.GetVersion()},
+		primaryIndexID: desc.GetPrimaryIndexID(),
+		relation: pgoutput.Relation{
+			ID:        oid.Oid(desc.GetID()),
+			Namespace: schemaName,
+			Name:      desc.GetName(),
+		},
+	}
+	keyColumns := desc.GetPrimaryIndex().CollectKeyColumnIDs()
+	var columnIDs []descpb.ColumnID
+	for _, col := range desc.PublicColumns() {
+		// Virtual columns are not stored, so they cannot be decoded.
+		if col.IsVirtual() {
+			continue
+		}
+		columnIDs = append(columnIDs, col.GetID())
+		t.relation.Columns = append(t.relation.Columns, pgoutput.Column{
+			Name: col.GetName(),
+			Type: col.GetType(),
+			Key:  keyColumns.Contains(col.GetID()),
+		})
+	}
+	if err := rowenc.InitIndexFetchSpec(
+		&t.spec, codec, desc, desc.GetPrimaryIndex(), columnIDs,
+	); err != nil {
+		return nil, err
+	}
+	if err := t.fetcher.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &t.alloc,
+		Spec:              &t.spec,
+	}); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// decodeRow decodes the row stored in the given KV. If the KV is a deletion,
+// only the primary key columns are decoded.
+func (t *tableDecoder) decodeRow(
+	ctx context.Context, kvs *row.KVProvider, kv roachpb.KeyValue,
+) (tree.Datums, error) {
+	kvs.KVs = append(kvs.KVs[:0], kv)
+	if err := t.fetcher.ConsumeKVProvider(ctx, kvs); err != nil {
+		return nil, err
+	}
+	datums, err := t.fetcher.NextRowDecoded(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if datums == nil {
+		return nil, errors.AssertionFailedf("no row decoded")
+	}
+	// Copy the datums since the fetcher reuses them.
+	return append(tree.Datums(nil), datums...), nil
+}
+
+// keyOnly returns the row with all the columns which are not part of the
+// primary key set to NULL.
+func (t *tableDecoder) keyOnly(row tree.Datums) tree.Datums {
+	key := make(tree.Datums, len(row))
+	for i := range row {
+		if t.relation.Columns[i].Key {
+			key[i] = row[i]
+		} else {
+			key[i] = tree.DNull
+		}
+	}
+	return key
+}
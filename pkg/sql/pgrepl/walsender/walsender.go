@@ -0,0 +1,494 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package walsender streams the changes to the published tables to a logical
+// replication client using the pgoutput plugin, as the walsender process does
+// in Postgres.
+//
+// The changes are read with rangefeeds and are buffered until the frontier
+// of the rangefeeds passes them. All the changes committed at the same LSN are
+// then sent as one transaction, where the LSN of a change is derived from its
+// MVCC timestamp with lsnutil.HLCToLSN. Changes made by a single SQL
+// transaction always share a timestamp, so they are never split across
+// transactions in the stream.
+//
+// The set of published tables changes over time, for instance when a table is
+// created in a database published with FOR ALL TABLES or when a table is added
+// to a publication. A rangefeed watches the system tables which determine the
+// published tables, and the published tables are looked up again at the
+// timestamp of each change to them. Each change to a row is only sent if its
+// table was published at the timestamp of the change. When tables which are
+// not watched yet become published, a new rangefeed is started to watch them
+// from the timestamp at which they became published. Tables are never
+// unwatched, since they could be published again later.
+package walsender
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/lease"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// KeepaliveInterval is the interval at which a Primary keepalive message is
+// sent to the client to report the progress of the stream.
+var KeepaliveInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.pgrepl.keepalive_interval",
+	"the interval at which logical replication streams report their progress "+
+		"to the client when there are no changes to send",
+	10*time.Second,
+	settings.PositiveDuration,
+)
+
+// eventBufferSize is the number of rangefeed events which can be queued
+// before the rangefeed is blocked.
+const eventBufferSize = 128
+
+// Config contains the dependencies of a stream.
+type Config struct {
+	Codec            keys.SQLCodec
+	Clock            *hlc.Clock
+	RangeFeedFactory *rangefeed.Factory
+	LeaseManager     *lease.Manager
+	DB               descs.DB
+	Settings         *cluster.Settings
+}
+
+// Sink receives the CopyData messages of a stream.
+type Sink interface {
+	// Send buffers a CopyData message with the given contents.
+	Send(ctx context.Context, data []byte) error
+	// Flush sends the buffered messages to the client.
+	Flush(ctx context.Context) error
+}
+
+// Options configures a stream.
+type Options struct {
+	// TablesAt returns the tables whose changes are streamed at the given
+	// timestamp.
+	TablesAt func(ctx context.Context, ts hlc.Timestamp) ([]descpb.ID, error)
+	// CatalogSpans are the spans of the system tables which determine the
+	// tables returned by TablesAt. TablesAt is called again at the timestamp
+	// of each change to them.
+	CatalogSpans []roachpb.Span
+	// Start is the position from which the stream starts. Only the
+	// transactions which commit after it are sent.
+	Start lsn.LSN
+	// ClientMessages receives the contents of the CopyData messages sent by
+	// the client. The stream ends when it is closed.
+	ClientMessages <-chan []byte
+	// OnFlush is called when the client reports that it has durably processed
+	// the stream up to a position beyond any position it reported before.
+	OnFlush func(ctx context.Context, flushed lsn.LSN) error
+	// FmtCtx is used to format the values of the columns in the text format.
+	FmtCtx *tree.FmtCtx
+}
+
+// catalogFeed is the index of the rangefeed over the catalog spans.
+const catalogFeed = 0
+
+// event is an event emitted by a rangefeed.
+type event struct {
+	// feed is the index of the rangefeed which emitted the event.
+	feed     int
+	value    *kvpb.RangeFeedValue
+	frontier hlc.Timestamp
+	err      error
+}
+
+// publishedTables is the set of tables published from a timestamp on.
+type publishedTables struct {
+	ts     hlc.Timestamp
+	tables map[descpb.ID]struct{}
+}
+
+// sender holds the state of a stream.
+type sender struct {
+	cfg    Config
+	sink   Sink
+	opts   Options
+	enc    pgoutput.Encoder
+	dec    decoder
+	events chan event
+	// feeds contains the rangefeeds of the stream. The first one watches the
+	// catalog spans, and each of the others watches the tables which became
+	// published when it was started.
+	feeds []*rangefeed.RangeFeed
+	// frontiers contains the frontier of each rangefeed.
+	frontiers []hlc.Timestamp
+	// watched contains the tables watched by the rangefeeds.
+	watched map[descpb.ID]struct{}
+	// published contains the published tables, in increasing order of
+	// timestamp. The first entry is at the start of the stream.
+	published []publishedTables
+	// catalogChanges contains the timestamps of the changes to the catalog
+	// spans which have not been applied to published yet.
+	catalogChanges []hlc.Timestamp
+	// buffered contains the values which have not been sent yet because the
+	// frontier has not passed them.
+	buffered []*kvpb.RangeFeedValue
+	// end is the position up to which all the transactions have been sent.
+	end lsn.LSN
+	// flushed is the last position reported as flushed by the client.
+	flushed lsn.LSN
+	// relations contains the version of each table described by the last
+	// Relation message sent for it.
+	relations map[descpb.ID]descpb.DescriptorVersion
+	// msg and buf are reused to encode messages.
+	msg, buf []byte
+}
+
+// Run streams the changes to the tables until the client ends the stream, an
+// error occurs or the context is canceled.
+func Run(ctx context.Context, cfg Config, sink Sink, opts Options) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s := &sender{
+		cfg:       cfg,
+		sink:      sink,
+		opts:      opts,
+		enc:       pgoutput.MakeEncoder(opts.FmtCtx),
+		dec:       makeDecoder(cfg.Codec, cfg.LeaseManager, cfg.DB),
+		events:    make(chan event, eventBufferSize),
+		watched:   make(map[descpb.ID]struct{}),
+		end:       opts.Start,
+		flushed:   opts.Start,
+		relations: make(map[descpb.ID]descpb.DescriptorVersion),
+	}
+	defer func() {
+		for _, feed := range s.feeds {
+			feed.Close()
+		}
+	}()
+
+	// The rangefeed over the catalog starts with the stream, so that all the
+	// later changes to the published tables are applied.
+	start := lsnutil.LSNToHLC(opts.Start)
+	if err := s.startFeed(ctx, opts.CatalogSpans, start, false /* withDiff */); err != nil {
+		return err
+	}
+	s.published = []publishedTables{{ts: start}}
+	if err := s.updatePublished(ctx, 0 /* idx */); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(KeepaliveInterval.Get(&cfg.Settings.SV))
+	defer ticker.Stop()
+	for {
+		select {
+		case e := <-s.events:
+			switch {
+			case e.err != nil:
+				return e.err
+			case e.value != nil && e.feed == catalogFeed:
+				s.catalogChanges = append(s.catalogChanges, e.value.Value.Timestamp)
+			case e.value != nil:
+				s.buffered = append(s.buffered, e.value)
+			default:
+				s.frontiers[e.feed].Forward(e.frontier)
+				if err := s.advance(ctx); err != nil {
+					return err
+				}
+			}
+		case data, ok := <-opts.ClientMessages:
+			if !ok {
+				return nil
+			}
+			if err := s.handleClientMessage(ctx, data); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := s.sendKeepalive(ctx, false /* replyRequested */); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// startFeed starts a rangefeed over the given spans, which emits the changes
+// after the given timestamp.
+func (s *sender) startFeed(
+	ctx context.Context, spans []roachpb.Span, start hlc.Timestamp, withDiff bool,
+) error {
+	idx := len(s.frontiers)
+	push := func(e event) {
+		e.feed = idx
+		select {
+		case s.events <- e:
+		case <-ctx.Done():
+		}
+	}
+	feed, err := s.cfg.RangeFeedFactory.RangeFeed(
+		ctx,
+		"pgrepl-walsender",
+		spans,
+		start,
+		func(_ context.Context, v *kvpb.RangeFeedValue) {
+			push(event{value: v})
+		},
+		rangefeed.WithDiff(withDiff),
+		rangefeed.WithOnFrontierAdvance(func(_ context.Context, ts hlc.Timestamp) {
+			push(event{frontier: ts})
+		}),
+		rangefeed.WithOnInternalError(func(_ context.Context, err error) {
+			push(event{err: err})
+		}),
+		rangefeed.WithOnDeleteRange(func(context.Context, *kvpb.RangeFeedDeleteRange) {
+			// Range deletions are only used to clear the data of dropped
+			// tables and indexes, which is not replicated.
+		}),
+	)
+	if err != nil {
+		return err
+	}
+	s.feeds = append(s.feeds, feed)
+	s.frontiers = append(s.frontiers, start)
+	return nil
+}
+
+// frontier returns the timestamp up to which all the rangefeeds have emitted
+// their values.
+func (s *sender) frontier() hlc.Timestamp {
+	frontier := s.frontiers[0]
+	for _, ts := range s.frontiers[1:] {
+		frontier.Backward(ts)
+	}
+	return frontier
+}
+
+// updatePublished looks up the tables published at the timestamp of the
+// published entry with the given index, and starts watching the ones which
+// are not watched yet.
+func (s *sender) updatePublished(ctx context.Context, idx int) error {
+	p := &s.published[idx]
+	tableIDs, err := s.opts.TablesAt(ctx, p.ts)
+	if err != nil {
+		return err
+	}
+	p.tables = make(map[descpb.ID]struct{}, len(tableIDs))
+	var spans []roachpb.Span
+	for _, id := range tableIDs {
+		p.tables[id] = struct{}{}
+		if _, ok := s.watched[id]; !ok {
+			s.watched[id] = struct{}{}
+			spans = append(spans, s.cfg.Codec.TableSpan(uint32(id)))
+		}
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+	// The rangefeed emits the changes after its start timestamp, and the
+	// tables have to be watched from p.ts on. The first entry is at the start
+	// of the stream, which was already sent.
+	start := p.ts
+	if idx > 0 {
+		start = start.Prev()
+	}
+	return s.startFeed(ctx, spans, start, true /* withDiff */)
+}
+
+// isPublished returns whether the table was published at the given timestamp.
+func (s *sender) isPublished(id descpb.ID, ts hlc.Timestamp) bool {
+	i := sort.Search(len(s.published), func(i int) bool {
+		return ts.Less(s.published[i].ts)
+	})
+	if i > 0 {
+		i--
+	}
+	_, ok := s.published[i].tables[id]
+	return ok
+}
+
+// applyCatalogChanges applies the changes to the catalog which the frontier
+// has passed. They may start rangefeeds for the newly published tables, which
+// hold the frontier back until these rangefeeds catch up.
+func (s *sender) applyCatalogChanges(ctx context.Context, frontier hlc.Timestamp) error {
+	sort.Slice(s.catalogChanges, func(i, j int) bool {
+		return s.catalogChanges[i].Less(s.catalogChanges[j])
+	})
+	n := 0
+	for ; n < len(s.catalogChanges) && s.catalogChanges[n].LessEq(frontier); n++ {
+		// All the changes made by a transaction share a timestamp.
+		ts := s.catalogChanges[n]
+		if ts.LessEq(s.published[len(s.published)-1].ts) {
+			continue
+		}
+		s.published = append(s.published, publishedTables{ts: ts})
+		if err := s.updatePublished(ctx, len(s.published)-1); err != nil {
+			return err
+		}
+	}
+	s.catalogChanges = append(s.catalogChanges[:0], s.catalogChanges[n:]...)
+	return nil
+}
+
+// advance sends the transactions which are complete once all the rangefeeds
+// have reached the frontier. All the values emitted later by the rangefeeds
+// have a higher timestamp, so they map to a LSN at least as high as that of
+// frontier.Next().
+func (s *sender) advance(ctx context.Context) error {
+	if err := s.applyCatalogChanges(ctx, s.frontier()); err != nil {
+		return err
+	}
+	frontier := s.frontier()
+	end := lsnutil.HLCToLSN(frontier.Next()) - 1
+	if end <= s.end {
+		return nil
+	}
+	sort.Slice(s.buffered, func(i, j int) bool {
+		a, b := s.buffered[i], s.buffered[j]
+		if c := a.Value.Timestamp.Compare(b.Value.Timestamp); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(a.Key, b.Key) < 0
+	})
+	n := 0
+	for n < len(s.buffered) && lsnutil.HLCToLSN(s.buffered[n].Value.Timestamp) <= end {
+		n++
+	}
+	for i := 0; i < n; {
+		txnLSN := lsnutil.HLCToLSN(s.buffered[i].Value.Timestamp)
+		j := i + 1
+		for j < n && lsnutil.HLCToLSN(s.buffered[j].Value.Timestamp) == txnLSN {
+			j++
+		}
+		if txnLSN > s.end {
+			if err := s.sendTxn(ctx, txnLSN, s.buffered[i:j]); err != nil {
+				return err
+			}
+		}
+		i = j
+	}
+	s.buffered = append(s.buffered[:0], s.buffered[n:]...)
+	s.end = end
+	// Only the last set of published tables which the frontier has passed is
+	// needed for the buffered values.
+	i := sort.Search(len(s.published), func(i int) bool {
+		return frontier.Less(s.published[i].ts)
+	})
+	if i > 1 {
+		s.published = append(s.published[:0], s.published[i-1:]...)
+	}
+	if n > 0 {
+		return s.sink.Flush(ctx)
+	}
+	return nil
+}
+
+// sendTxn sends the given values, which all map to the given LSN, as a
+// transaction. Nothing is sent if none of the values is a change to a table
+// published at the time of the change.
+func (s *sender) sendTxn(ctx context.Context, txnLSN lsn.LSN, values []*kvpb.RangeFeedValue) error {
+	commitTime := values[len(values)-1].Value.Timestamp.GoTime()
+	began := false
+	for _, v := range values {
+		tableID, err := s.dec.tableID(v.Key)
+		if err != nil {
+			return err
+		}
+		if !s.isPublished(tableID, v.Value.Timestamp) {
+			continue
+		}
+		c, ok, err := s.dec.decode(ctx, v)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if !began {
+			// The LSN is also used as the transaction ID, which only needs to
+			// identify the transaction within the stream.
+			s.msg = s.enc.AppendBegin(s.msg[:0], txnLSN, commitTime, uint32(txnLSN))
+			if err := s.sendXLogData(ctx, txnLSN); err != nil {
+				return err
+			}
+			began = true
+		}
+		rel := &c.table.relation
+		if v, ok := s.relations[c.table.version.id]; !ok || v != c.table.version.version {
+			s.msg = s.enc.AppendRelation(s.msg[:0], rel)
+			if err := s.sendXLogData(ctx, txnLSN); err != nil {
+				return err
+			}
+			s.relations[c.table.version.id] = c.table.version.version
+		}
+		switch c.kind {
+		case insertChange:
+			s.msg = s.enc.AppendInsert(s.msg[:0], rel.ID, c.row)
+		case updateChange:
+			s.msg = s.enc.AppendUpdate(s.msg[:0], rel.ID, nil /* oldKey */, c.row)
+		case deleteChange:
+			s.msg = s.enc.AppendDelete(s.msg[:0], rel.ID, c.table.keyOnly(c.row))
+		}
+		if err := s.sendXLogData(ctx, txnLSN); err != nil {
+			return err
+		}
+	}
+	if !began {
+		return nil
+	}
+	s.msg = s.enc.AppendCommit(s.msg[:0], txnLSN, txnLSN, commitTime)
+	return s.sendXLogData(ctx, txnLSN)
+}
+
+// sendXLogData sends the encoded message in a XLogData message.
+func (s *sender) sendXLogData(ctx context.Context, at lsn.LSN) error {
+	s.buf = pgoutput.AppendXLogData(s.buf[:0], at, at, timeutil.Now(), s.msg)
+	return s.sink.Send(ctx, s.buf)
+}
+
+// sendKeepalive sends a Primary keepalive message with the current end of the
+// stream.
+func (s *sender) sendKeepalive(ctx context.Context, replyRequested bool) error {
+	s.buf = pgoutput.AppendPrimaryKeepalive(s.buf[:0], s.end, timeutil.Now(), replyRequested)
+	if err := s.sink.Send(ctx, s.buf); err != nil {
+		return err
+	}
+	return s.sink.Flush(ctx)
+}
+
+// handleClientMessage handles a CopyData message sent by the client.
+func (s *sender) handleClientMessage(ctx context.Context, data []byte) error {
+	update, ok, err := pgoutput.ParseClientMessage(data)
+	if err != nil || !ok {
+		return err
+	}
+	// The client cannot have processed more than what was sent.
+	flushed := update.Flushed
+	if flushed > s.end {
+		flushed = s.end
+	}
+	if flushed > s.flushed {
+		if err := s.opts.OnFlush(ctx, flushed); err != nil {
+			return err
+		}
+		s.flushed = flushed
+	}
+	if update.ReplyRequested {
+		return s.sendKeepalive(ctx, false /* replyRequested */)
+	}
+	return nil
+}
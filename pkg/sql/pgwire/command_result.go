@@ -157,10 +157,18 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 	case closeComplete:
 		r.conn.bufferCloseComplete()
 	case readyForQuery:
+		if t == sql.IdleTxnBlock {
+			r.conn.bufferNotifications()
+		}
 		r.conn.bufferReadyForQuery(byte(t))
 		// The error is saved on conn.err.
 		_ /* err */ = r.conn.Flush(r.pos)
 		r.conn.maybeReallocate()
+		if t == sql.IdleTxnBlock {
+			// Only mark the session as idle once ReadyForQuery has been written,
+			// so that the notifications sent from now on are written after it.
+			r.conn.setNotificationsIdle()
+		}
 	case emptyQueryResponse:
 		r.conn.bufferEmptyQueryResponse()
 	case flush:
@@ -408,6 +416,19 @@ func (r *commandResult) SendCopyDone(ctx context.Context) error {
 	return r.conn.bufferCopyDone()
 }
 
+// SendCopyBothResponse is part of the sql.StartReplicationResult interface.
+func (r *commandResult) SendCopyBothResponse(ctx context.Context) error {
+	r.assertNotReleased()
+	r.conn.writerState.fi.registerCmd(r.pos)
+	return r.conn.bufferCopyBothResponse()
+}
+
+// FlushCopyData is part of the sql.StartReplicationResult interface.
+func (r *commandResult) FlushCopyData(ctx context.Context) error {
+	r.assertNotReleased()
+	return r.conn.Flush(r.pos)
+}
+
 // SetRowsAffected is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SetRowsAffected(ctx context.Context, n int) {
 	r.assertNotReleased()
@@ -448,6 +469,9 @@ func (r *commandResult) assertNotReleased() {
 }
 
 func (c *conn) allocCommandResult() *commandResult {
+	// The session is busy until the ReadyForQuery message which follows the
+	// results of this command.
+	c.setNotificationsBusy()
 	r := &c.res
 	if r.released {
 		r.released = false
@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgreplparser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -40,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/ring"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
@@ -110,7 +112,45 @@ type conn struct {
 
 	// alwaysLogAuthActivity is used force-enables logging of authn events.
 	alwaysLogAuthActivity bool
-}
+
+	// notifications groups together the state used to deliver the asynchronous
+	// notifications for the channels the session listens on.
+	notifications struct {
+		syncutil.Mutex
+		// idle is set while the session is outside of a transaction and waiting
+		// for the client to send a command. Notifications received while the
+		// session is idle are sent right away; the others are held until the
+		// session next becomes idle, like in Postgres. It is only modified by
+		// the command processor goroutine: it is set after a ReadyForQuery
+		// message outside of a transaction was flushed and cleared before the
+		// results of the next command are produced.
+		idle bool
+		// pending contains the notifications which have not been sent yet.
+		pending []pgnotify.Notification
+		// buf is used to encode the notifications which are written directly to
+		// the network connection while the session is idle.
+		buf writeBuffer
+		// wakeCh is signaled when a notification is received while the session
+		// is idle.
+		wakeCh chan struct{}
+	}
+
+	// replicationStream is set while a START_REPLICATION command streams
+	// changes to the client. It is only accessed by the network routine.
+	replicationStream struct {
+		// clientMessages receives the CopyData messages sent by the client.
+		clientMessages chan []byte
+		// done is closed by the connExecutor once the stream has ended.
+		done chan struct{}
+	}
+}
+
+// replicationClientMessagesBufferSize is the number of CopyData messages sent
+// by the client during a replication stream which can be buffered before the
+// network routine blocks.
+const replicationClientMessagesBufferSize = 16
+
+var _ pgnotify.Receiver = (*conn)(nil)
 
 func (c *conn) setErr(err error) {
 	c.err.Store(err)
@@ -358,8 +398,12 @@ func (c *conn) handleSimpleQuery(
 			log.SqlExec.Infof(ctx, "could not parse simple query in replication protocol: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{Err: err})
 		}
-		switch stmt.AST.(type) {
-		case *pgrepltree.IdentifySystem:
+		switch ast := stmt.AST.(type) {
+		case *pgrepltree.IdentifySystem,
+			*pgrepltree.CreateReplicationSlot,
+			*pgrepltree.DropReplicationSlot:
+		case *pgrepltree.StartReplication:
+			return c.startReplication(ctx, stmt, ast, timeReceived, startParse)
 		default:
 			log.SqlExec.Infof(ctx, "unhandled replication protocol query: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{
@@ -488,6 +532,64 @@ func (c *conn) handleSimpleQuery(
 	return nil
 }
 
+// startReplication queues a StartReplication command for execution. The
+// CopyData messages sent by the client are forwarded to it by
+// forwardReplicationMessage until the stream ends.
+func (c *conn) startReplication(
+	ctx context.Context,
+	stmt statements.Statement[tree.Statement],
+	ast *pgrepltree.StartReplication,
+	timeReceived, startParse time.Time,
+) error {
+	msgs := make(chan []byte, replicationClientMessagesBufferSize)
+	done := make(chan struct{})
+	c.replicationStream.clientMessages = msgs
+	c.replicationStream.done = done
+	return c.stmtBuf.Push(ctx, sql.StartReplication{
+		ParsedStmt:     stmt,
+		Stmt:           ast,
+		ClientMessages: msgs,
+		Done:           done,
+		TimeReceived:   timeReceived,
+		ParseStart:     startParse,
+		ParseEnd:       timeutil.Now(),
+	})
+}
+
+// forwardReplicationMessage forwards a CopyData, CopyDone or CopyFail message
+// sent by the client to the replication stream. It returns false if there is
+// no active stream, in which case the message should be ignored.
+func (c *conn) forwardReplicationMessage(
+	ctx context.Context, typ pgwirebase.ClientMessageType,
+) (bool, error) {
+	rs := &c.replicationStream
+	if rs.clientMessages == nil {
+		return false, nil
+	}
+	select {
+	case <-rs.done:
+		// The stream ended on the server side.
+		rs.clientMessages, rs.done = nil, nil
+		return false, nil
+	default:
+	}
+	if typ != pgwirebase.ClientMsgCopyData {
+		// The client ended the stream.
+		close(rs.clientMessages)
+		rs.clientMessages, rs.done = nil, nil
+		return true, nil
+	}
+	data := append([]byte(nil), c.readBuf.Msg...)
+	select {
+	case rs.clientMessages <- data:
+	case <-rs.done:
+		rs.clientMessages, rs.done = nil, nil
+	case <-ctx.Done():
+		return true, ctx.Err()
+	}
+	return true, nil
+}
+
 // An error is returned iff the statement buffer has been closed. In that case,
 // the connection should be considered toast.
 func (c *conn) handleParse(ctx context.Context, nakedIntSize *types.T) error {
@@ -866,7 +968,7 @@ func cookTag(
 			tag = strconv.AppendUint(tag, uint64(rowsAffected), 10)
 		}
 
-	case tree.Ack, tree.DDL:
+	case tree.Ack, tree.DDL, tree.Replication:
 		if tagStr == "SELECT" {
 			tag = append(tag, ' ')
 			tag = strconv.AppendInt(tag, int64(rowsAffected), 10)
@@ -1023,6 +1125,102 @@ func (c *tenantEgressCounter) GetBatchNetworkEgress(
 	return egress
 }
 
+// Notify is part of the pgnotify.Receiver interface.
+func (c *conn) Notify(ctx context.Context, n pgnotify.Notification) {
+	c.notifications.Lock()
+	defer c.notifications.Unlock()
+	c.notifications.pending = append(c.notifications.pending, n)
+	if c.notifications.idle {
+		select {
+		case c.notifications.wakeCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// sendNotifications writes the notifications received while the session is
+// idle to the network connection. It returns when ctx is canceled or when
+// writing to the connection fails.
+func (c *conn) sendNotifications(ctx context.Context) {
+	for {
+		select {
+		case <-c.notifications.wakeCh:
+		case <-ctx.Done():
+			return
+		}
+		if err := func() error {
+			c.notifications.Lock()
+			defer c.notifications.Unlock()
+			if !c.notifications.idle {
+				return nil
+			}
+			for _, n := range c.notifications.pending {
+				if err := writeNotification(&c.notifications.buf, n, c.conn); err != nil {
+					return err
+				}
+			}
+			c.notifications.pending = c.notifications.pending[:0]
+			return nil
+		}(); err != nil {
+			c.setErr(err)
+			return
+		}
+	}
+}
+
+// setNotificationsBusy records that the command processor is about to produce
+// the results of a command. It must only be called by the command processor
+// goroutine, or once that goroutine has exited; in particular, the reader
+// goroutine must not mark the session as busy when it receives a message
+// because the command processor might still be finishing the previous
+// command, which would race with the marking of the session as idle.
+//
+// Since sendNotifications holds the lock while writing to the network
+// connection, once this returns there are no concurrent writes to the
+// connection until the session becomes idle again.
+func (c *conn) setNotificationsBusy() {
+	c.notifications.Lock()
+	defer c.notifications.Unlock()
+	c.notifications.idle = false
+}
+
+// bufferNotifications buffers the notifications received so far so that they
+// are sent before the ReadyForQuery message which is about to be buffered.
+func (c *conn) bufferNotifications() {
+	c.notifications.Lock()
+	defer c.notifications.Unlock()
+	for _, n := range c.notifications.pending {
+		if err := writeNotification(&c.msgBuilder, n, &c.writerState.buf); err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err from buffer"))
+		}
+	}
+	c.notifications.pending = c.notifications.pending[:0]
+}
+
+// setNotificationsIdle records that the session is idle, after the
+// ReadyForQuery message has been flushed to the network connection. The
+// notifications received in the meantime are handed to sendNotifications.
+func (c *conn) setNotificationsIdle() {
+	c.notifications.Lock()
+	defer c.notifications.Unlock()
+	c.notifications.idle = true
+	if len(c.notifications.pending) > 0 {
+		select {
+		case c.notifications.wakeCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// writeNotification writes a NotificationResponse message to w.
+func writeNotification(b *writeBuffer, n pgnotify.Notification, w io.Writer) error {
+	b.initMsg(pgwirebase.ServerMsgNotificationResponse)
+	b.putInt32(n.SenderPID)
+	b.writeTerminatedString(n.Channel)
+	b.writeTerminatedString(n.Payload)
+	return b.finishMsg(w)
+}
+
 func (c *conn) bufferReadyForQuery(txnStatus byte) {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgReady)
 	c.msgBuilder.writeByte(txnStatus)
@@ -1188,6 +1386,15 @@ func (c *conn) bufferCopyData(copyData []byte, res *commandResult) error {
 	return nil
 }
 
+// bufferCopyBothResponse buffers the CopyBothResponse message which starts the
+// stream of a START_REPLICATION command. The stream has no columns.
+func (c *conn) bufferCopyBothResponse() error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyBothResponse)
+	c.msgBuilder.writeByte(byte(pgwirebase.FormatText))
+	c.msgBuilder.putInt16(0)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 func (c *conn) bufferCopyDone() error {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDoneCommand)
 	return c.msgBuilder.finishMsg(&c.writerState.buf)
@@ -1418,6 +1625,16 @@ func (c *conn) CreateCopyOutResult(cmd sql.CopyOut, pos sql.CmdPos) sql.CopyOutR
 	return res
 }
 
+// CreateStartReplicationResult is part of the sql.ClientComm interface.
+func (c *conn) CreateStartReplicationResult(
+	cmd sql.StartReplication, pos sql.CmdPos,
+) sql.StartReplicationResult {
+	res := c.newMiscResult(pos, commandComplete)
+	res.stmtType = cmd.Stmt.StatementReturnType()
+	res.cmdCompleteTag = cmd.Stmt.StatementTag()
+	return res
+}
+
 // pgwireReader is an io.Reader that wraps a conn, maintaining its metrics as
 // it is consumed.
 type pgwireReader struct {
@@ -2402,3 +2402,83 @@ func TestPublishConnLatencyMetric(t *testing.T) {
 	require.Equal(t, int64(2), count)
 	require.Equal(t, float64(9), sum)
 }
+
+// TestNotificationsAcrossNodes checks that a session receives the
+// notifications sent from another node as NotificationResponse messages, in
+// commit order, and that LISTEN and NOTIFY only take effect when their
+// transaction commits.
+func TestNotificationsAcrossNodes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := serverutils.StartCluster(t, 2, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+
+	// Speed up the advancement of the rangefeed frontier, which the
+	// notifications are held until.
+	sysDB := sqlutils.MakeSQLRunner(tc.SystemLayer(0).SQLConn(t))
+	sysDB.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.target_duration = '10ms'`)
+
+	connect := func(idx int) *pgx.Conn {
+		pgURL, cleanup := tc.ApplicationLayer(idx).PGUrl(
+			t, serverutils.CertsDirPrefix(t.Name()), serverutils.User(username.RootUser),
+		)
+		defer cleanup()
+		conn, err := pgx.Connect(ctx, pgURL.String())
+		require.NoError(t, err)
+		return conn
+	}
+	listener := connect(0)
+	defer func() { _ = listener.Close(ctx) }()
+	notifier := connect(1)
+	defer func() { _ = notifier.Close(ctx) }()
+
+	waitForNotification := func() *pgconn.Notification {
+		ctx, cancel := context.WithTimeout(ctx, testutils.SucceedsSoonDuration())
+		defer cancel()
+		n, err := listener.WaitForNotification(ctx)
+		require.NoError(t, err)
+		return n
+	}
+	exec := func(conn *pgx.Conn, stmts ...string) {
+		for _, stmt := range stmts {
+			_, err := conn.Exec(ctx, stmt)
+			require.NoError(t, err)
+		}
+	}
+
+	exec(listener, `LISTEN foo`)
+	// A LISTEN which is rolled back has no effect.
+	exec(listener, `BEGIN`, `LISTEN bar`, `ROLLBACK`)
+	// Neither has a LISTEN rolled back to a savepoint.
+	exec(listener, `BEGIN`, `SAVEPOINT s`, `LISTEN bar`, `ROLLBACK TO SAVEPOINT s`, `COMMIT`)
+
+	exec(notifier, `NOTIFY bar, 'not listening'`)
+	exec(notifier, `BEGIN`, `NOTIFY foo, 'rolled back'`, `ROLLBACK`)
+	exec(notifier, `NOTIFY foo, 'hello'`)
+	// Identical notifications sent by a transaction are only delivered once.
+	exec(notifier,
+		`BEGIN`,
+		`NOTIFY foo, 'a'`,
+		`SELECT pg_notify('foo', 'b')`,
+		`NOTIFY foo, 'a'`,
+		`COMMIT`,
+	)
+
+	pid := notifier.PgConn().PID()
+	for _, payload := range []string{"hello", "a", "b"} {
+		n := waitForNotification()
+		require.Equal(t, "foo", n.Channel)
+		require.Equal(t, payload, n.Payload)
+		require.Equal(t, pid, n.PID)
+	}
+
+	// The notifications stop once the session stops listening, which is
+	// checked by listening again on another channel.
+	exec(listener, `UNLISTEN foo`, `LISTEN baz`)
+	exec(notifier, `NOTIFY foo, 'unlistened'`, `NOTIFY baz, 'done'`)
+	n := waitForNotification()
+	require.Equal(t, "baz", n.Channel)
+	require.Equal(t, "done", n.Payload)
+}
@@ -104,6 +104,23 @@ var PLpgSQLConditionNameToCode = map[string][]string{
 	"invalid_xml_content":                        {"2200N"},
 	"invalid_xml_comment":                        {"2200S"},
 	"invalid_xml_processing_instruction":         {"2200T"},
+
+	"duplicate_json_object_key_value":                 {"22030"},
+	"invalid_argument_for_sql_json_datetime_function": {"22031"},
+	"invalid_json_text":                               {"22032"},
+	"invalid_sql_json_subscript":                      {"22033"},
+	"more_than_one_sql_json_item":                     {"22034"},
+	"no_sql_json_item":                                {"22035"},
+	"non_numeric_sql_json_item":                       {"22036"},
+	"non_unique_keys_in_a_json_object":                {"22037"},
+	"singleton_sql_json_item_required":                {"22038"},
+	"sql_json_array_not_found":                        {"22039"},
+	"sql_json_member_not_found":                       {"2203A"},
+	"sql_json_number_not_found":                       {"2203B"},
+	"sql_json_object_not_found":                       {"2203C"},
+	"too_many_json_array_elements":                    {"2203D"},
+	"too_many_json_object_members":                    {"2203E"},
+	"sql_json_scalar_required":                        {"2203F"},
 	// Section: Class 23 - Integrity Constraint Violation
 	"integrity_constraint_violation": {"23000"},
 	"restrict_violation":             {"23001"},
@@ -31,8 +31,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/geometric"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/macaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
@@ -353,6 +355,12 @@ func DecodeDatum(
 			return da.NewDInt(tree.DInt(i)), nil
 		case oid.T_pg_lsn:
 			return tree.ParseDPGLSN(bs)
+		case oid.T_macaddr:
+			return tree.ParseDMACAddr(bs)
+		case oidext.T_macaddr8:
+			return tree.ParseDMACAddr8(bs)
+		case oid.T_money:
+			return tree.ParseDMoney(evalCtx, bs)
 		case oid.T_oid,
 			oid.T_regoper,
 			oid.T_regproc,
@@ -452,6 +460,12 @@ func DecodeDatum(
 				return nil, tree.MakeParseError(bs, typ, err)
 			}
 			return da.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr}), nil
+		case oid.T_cidr:
+			var ipAddr ipaddr.IPAddr
+			if err := ipaddr.ParseCIDR(bs, &ipAddr); err != nil {
+				return nil, err
+			}
+			return da.NewDCIDR(tree.DIPAddr{IPAddr: ipAddr}), nil
 		case oid.T_jsonb, oid.T_json:
 			if err := validateStringBytes(b); err != nil {
 				return nil, err
@@ -461,6 +475,11 @@ func DecodeDatum(
 				return nil, tree.MakeParseError(bs, typ, err)
 			}
 			return da.NewDJSON(tree.DJSON{JSON: v}), nil
+		case oidext.T_jsonpath:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(bs)
 		case oid.T_tsquery:
 			ret, err := tsearch.ParseTSQuery(bs)
 			if err != nil {
@@ -479,8 +498,34 @@ func DecodeDatum(
 				return nil, err
 			}
 			return &tree.DPGVector{T: ret}, nil
+		case oid.T_point:
+			return tree.ParseDPoint(bs)
+		case oid.T_box:
+			return tree.ParseDBox(bs)
+		case oid.T_lseg:
+			return tree.ParseDLSeg(bs)
+		case oid.T_line:
+			return tree.ParseDLine(bs)
+		case oid.T_path:
+			return tree.ParseDPath(bs)
+		case oid.T_polygon:
+			return tree.ParseDPolygon(bs)
+		case oid.T_circle:
+			return tree.ParseDCircle(bs)
 		}
 		switch typ.Family() {
+		case types.RangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			r, _, err := tree.ParseDRangeFromString(evalCtx, bs, typ)
+			return r, err
+		case types.MultirangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			m, _, err := tree.ParseDMultirangeFromString(evalCtx, bs, typ)
+			return m, err
 		case types.ArrayFamily, types.TupleFamily:
 			// Arrays and tuples come in in their string form, so we parse them
 			// as such and later convert them to their actual datum form.
@@ -527,6 +572,24 @@ func DecodeDatum(
 			}
 			i := int64(binary.BigEndian.Uint64(b))
 			return da.NewDPGLSN(tree.DPGLSN{LSN: lsn.LSN(i)}), nil
+		case oid.T_macaddr:
+			m, err := macaddr.FromBytes(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDMACAddr(m), nil
+		case oidext.T_macaddr8:
+			m, err := macaddr.FromBytes8(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDMACAddr8(m), nil
+		case oid.T_money:
+			if len(b) < 8 {
+				return nil, pgerror.Newf(pgcode.Syntax, "money requires 8 bytes for binary format")
+			}
+			i := int64(binary.BigEndian.Uint64(b))
+			return tree.NewDMoney(tree.DMoney(i)), nil
 		case oid.T_float4:
 			if len(b) < 4 {
 				return nil, pgerror.Newf(pgcode.Syntax, "float4 requires 4 bytes for binary format")
@@ -719,6 +782,16 @@ func DecodeDatum(
 				return nil, err
 			}
 			return da.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr}), nil
+		case oid.T_cidr:
+			ipAddr, err := pgBinaryToIPAddr(b)
+			if err != nil {
+				return nil, err
+			}
+			if !ipAddr.IsNetwork() {
+				return nil, pgerror.New(pgcode.InvalidBinaryRepresentation,
+					"invalid external \"cidr\" value")
+			}
+			return da.NewDCIDR(tree.DIPAddr{IPAddr: ipAddr}), nil
 		case oid.T_json:
 			if err := validateStringBytes(b); err != nil {
 				return nil, err
@@ -745,6 +818,61 @@ func DecodeDatum(
 				return nil, tree.MakeParseError(bs, typ, err)
 			}
 			return da.NewDJSON(tree.DJSON{JSON: v}), nil
+		case oidext.T_jsonpath:
+			if len(b) < 1 {
+				return nil, NewProtocolViolationErrorf("no data to decode")
+			}
+			if b[0] != 1 {
+				return nil, NewProtocolViolationErrorf("expected jsonpath version 1")
+			}
+			// Skip over the version number.
+			b = b[1:]
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(encoding.UnsafeConvertBytesToString(b))
+		case oid.T_point:
+			v, err := geometric.DecodePoint(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDPoint(v), nil
+		case oid.T_box:
+			v, err := geometric.DecodeBox(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDBox(v), nil
+		case oid.T_lseg:
+			v, err := geometric.DecodeLSeg(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDLSeg(v), nil
+		case oid.T_line:
+			v, err := geometric.DecodeLine(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDLine(v), nil
+		case oid.T_path:
+			v, err := geometric.DecodePath(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDPath(v), nil
+		case oid.T_polygon:
+			v, err := geometric.DecodePolygon(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDPolygon(v), nil
+		case oid.T_circle:
+			v, err := geometric.DecodeCircle(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDCircle(v), nil
 		case oid.T_varbit, oid.T_bit:
 			if len(b) < 4 {
 				return nil, NewProtocolViolationErrorf("insufficient data: %d", len(b))
@@ -811,6 +939,13 @@ func DecodeDatum(
 			if typ.Family() == types.TupleFamily {
 				return decodeBinaryTuple(ctx, evalCtx, b, da)
 			}
+			if typ.Family() == types.RangeFamily {
+				r, _, err := decodeBinaryRange(ctx, evalCtx, typ, b, da)
+				return r, err
+			}
+			if typ.Family() == types.MultirangeFamily {
+				return decodeBinaryMultirange(ctx, evalCtx, typ, b, da)
+			}
 			if typ.Family() == types.OidFamily {
 				if len(b) < 4 {
 					return nil, pgerror.Newf(pgcode.ProtocolViolation, "oid requires 4 bytes for binary format")
@@ -1034,6 +1169,89 @@ func decodeBinaryArray(
 	return arr, nil
 }
 
+// The flags of the Postgres binary format of ranges.
+const (
+	rangeEmptyFlag    = 0x01
+	rangeLowerIncFlag = 0x02
+	rangeUpperIncFlag = 0x04
+	rangeLowerInfFlag = 0x08
+	rangeUpperInfFlag = 0x10
+)
+
+// decodeBinaryRange decodes the binary format of a range of type t, which is
+// a flags byte followed by the length-prefixed binary formats of its finite
+// bounds. It returns the remaining bytes.
+func decodeBinaryRange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte, da *tree.DatumAlloc,
+) (*tree.DRange, []byte, error) {
+	if len(b) < 1 {
+		return nil, nil, pgerror.Newf(pgcode.ProtocolViolation, "insufficient data left in message")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&rangeEmptyFlag != 0 {
+		return tree.NewDEmptyRange(t), b, nil
+	}
+	var bounds [2]tree.Datum
+	for i, infFlag := range []byte{rangeLowerInfFlag, rangeUpperInfFlag} {
+		if flags&infFlag != 0 {
+			continue
+		}
+		if len(b) < 4 {
+			return nil, nil, pgerror.Newf(pgcode.ProtocolViolation, "insufficient data left in message")
+		}
+		vlen := int(int32(binary.BigEndian.Uint32(b)))
+		b = b[4:]
+		if vlen < 0 || vlen > len(b) {
+			return nil, nil, pgerror.Newf(pgcode.ProtocolViolation, "insufficient data left in message")
+		}
+		bound, err := DecodeDatum(ctx, evalCtx, t.RangeContents(), FormatBinary, b[:vlen], da)
+		if err != nil {
+			return nil, nil, err
+		}
+		bounds[i] = bound
+		b = b[vlen:]
+	}
+	r, err := tree.MakeDRange(
+		ctx, evalCtx, t, bounds[0], bounds[1], flags&rangeLowerIncFlag != 0, flags&rangeUpperIncFlag != 0,
+	)
+	return r, b, err
+}
+
+// decodeBinaryMultirange decodes the binary format of a multirange of type t,
+// which is the number of its ranges followed by their length-prefixed binary
+// formats.
+func decodeBinaryMultirange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte, da *tree.DatumAlloc,
+) (tree.Datum, error) {
+	if len(b) < 4 {
+		return nil, pgerror.Newf(pgcode.ProtocolViolation, "insufficient data left in message")
+	}
+	n := int(int32(binary.BigEndian.Uint32(b)))
+	b = b[4:]
+	if n < 0 {
+		return nil, pgerror.Newf(pgcode.ProtocolViolation, "invalid multirange length %d", n)
+	}
+	var ranges []*tree.DRange
+	for i := 0; i < n; i++ {
+		if len(b) < 4 {
+			return nil, pgerror.Newf(pgcode.ProtocolViolation, "insufficient data left in message")
+		}
+		vlen := int(int32(binary.BigEndian.Uint32(b)))
+		b = b[4:]
+		if vlen < 0 || vlen > len(b) {
+			return nil, pgerror.Newf(pgcode.ProtocolViolation, "insufficient data left in message")
+		}
+		r, _, err := decodeBinaryRange(ctx, evalCtx, t.MultirangeContents(), b[:vlen], da)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+		b = b[vlen:]
+	}
+	return tree.MakeDMultirange(ctx, evalCtx, t, ranges)
+}
+
 const tupleHeaderSize, oidSize, elementSize = 4, 4, 4
 
 func decodeBinaryTuple(
@@ -40,12 +40,14 @@ const (
 	ServerMsgCloseComplete        ServerMessageType = '3'
 	ServerMsgCopyInResponse       ServerMessageType = 'G'
 	ServerMsgCopyOutResponse      ServerMessageType = 'H'
+	ServerMsgCopyBothResponse     ServerMessageType = 'W'
 	ServerMsgCopyDataCommand      ServerMessageType = 'd'
 	ServerMsgCopyDoneCommand      ServerMessageType = 'c'
 	ServerMsgDataRow              ServerMessageType = 'D'
 	ServerMsgEmptyQuery           ServerMessageType = 'I'
 	ServerMsgErrorResponse        ServerMessageType = 'E'
 	ServerMsgNoticeResponse       ServerMessageType = 'N'
+	ServerMsgNotificationResponse ServerMessageType = 'A'
 	ServerMsgNoData               ServerMessageType = 'n'
 	ServerMsgParameterDescription ServerMessageType = 't'
 	ServerMsgParameterStatus      ServerMessageType = 'S'
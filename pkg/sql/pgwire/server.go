@@ -1039,6 +1039,8 @@ func (s *Server) newConn(
 	c.writerState.fi.buf = &c.writerState.buf
 	c.writerState.fi.lastFlushed = -1
 	c.msgBuilder.init(func(i int64) { c.destMetrics().BytesOutCount.Inc(i) })
+	c.notifications.buf.init(func(i int64) { c.destMetrics().BytesOutCount.Inc(i) })
+	c.notifications.wakeCh = make(chan struct{}, 1)
 	c.errWriter.sv = sv
 	c.errWriter.msgBuilder = &c.msgBuilder
 	return c
@@ -1203,6 +1205,9 @@ func (s *Server) serveImpl(
 				sessionID,
 			)
 		}()
+		// Spawn the goroutine which sends the notifications received while the
+		// session is idle.
+		go c.sendNotifications(ctx)
 	} else {
 		// sqlServer == nil means we are in a local test. In this case
 		// we only need the minimum to make pgx happy.
@@ -1392,7 +1397,12 @@ func (s *Server) serveImpl(
 				return false, isSimpleQuery, c.handleFlush(ctx)
 
 			case pgwirebase.ClientMsgCopyData, pgwirebase.ClientMsgCopyDone, pgwirebase.ClientMsgCopyFail:
-				// We're supposed to ignore these messages, per the protocol spec. This
+				// During a replication stream, the client reports its progress with
+				// CopyData messages and ends the stream with CopyDone.
+				if forwarded, err := c.forwardReplicationMessage(ctx, typ); forwarded || err != nil {
+					return false, isSimpleQuery, err
+				}
+				// Otherwise, we're supposed to ignore these messages, per the protocol spec. This
 				// state will happen when an error occurs on the server-side during a copy
 				// operation: the server will send an error and a ready message back to
 				// the client, and must then ignore further copy messages. See:
@@ -1463,6 +1473,7 @@ func (s *Server) serveImpl(
 		// NOTE: If a query is canceled due to draining, the conn_executor already
 		// will have sent a QueryCanceled error as a response to the query.
 		log.Ops.Info(ctx, "closing existing connection while server is draining")
+		c.setNotificationsBusy()
 		_ /* err */ = c.writeErr(ctx, newAdminShutdownErr(ErrDrainingExistingConn), &c.writerState.buf)
 		_ /* n */, _ /* err */ = c.writerState.buf.WriteTo(c.conn)
 	}
@@ -26,9 +26,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/geometric"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/macaddr"
+	"github.com/cockroachdb/cockroach/pkg/util/money"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
@@ -53,6 +56,8 @@ type pgType struct {
 }
 
 func pgTypeForParserType(t *types.T) pgType {
+	// Like Postgres, describe values of a domain type using the base type.
+	t = t.DomainBaseType()
 	size := tree.PGWireTypeSize(t)
 	tOid := t.Oid()
 	if tOid == oid.T_text && t.Width() > 0 {
@@ -181,7 +186,12 @@ func writeTextDatumNotNull(
 		writeTextUUID(b, v.UUID)
 
 	case *tree.DIPAddr:
-		b.writeLengthPrefixedString(v.IPAddr.String())
+		if w, ok := d.(*tree.DOidWrapper); ok && w.Oid == oid.T_cidr {
+			// cidr values are always displayed with their netmask.
+			b.writeLengthPrefixedString(v.IPAddr.CIDRString())
+		} else {
+			b.writeLengthPrefixedString(v.IPAddr.String())
+		}
 
 	case *tree.DString:
 		writeTextString(b, string(*v), t)
@@ -213,6 +223,15 @@ func writeTextDatumNotNull(
 		b.putInt32(int32(len(s)))
 		b.write([]byte(s))
 
+	case *tree.DMACAddr:
+		b.writeLengthPrefixedString(v.MACAddr.String())
+
+	case *tree.DMACAddr8:
+		b.writeLengthPrefixedString(v.MACAddr8.String())
+
+	case *tree.DMoney:
+		b.writeLengthPrefixedString(money.Format(int64(*v), money.MustLookupLocale(conv.LcMonetary)))
+
 	case *tree.DBox2D:
 		s := v.Repr()
 		b.putInt32(int32(len(s)))
@@ -241,6 +260,30 @@ func writeTextDatumNotNull(
 	case *tree.DJSON:
 		b.writeLengthPrefixedString(v.JSON.String())
 
+	case *tree.DJsonpath:
+		b.writeLengthPrefixedString(v.Jsonpath.String())
+
+	case *tree.DPoint:
+		b.writeLengthPrefixedString(v.Point.String())
+
+	case *tree.DBox:
+		b.writeLengthPrefixedString(v.Box.String())
+
+	case *tree.DLSeg:
+		b.writeLengthPrefixedString(v.LSeg.String())
+
+	case *tree.DLine:
+		b.writeLengthPrefixedString(v.Line.String())
+
+	case *tree.DPath:
+		b.writeLengthPrefixedString(v.Path.String())
+
+	case *tree.DPolygon:
+		b.writeLengthPrefixedString(v.Polygon.String())
+
+	case *tree.DCircle:
+		b.writeLengthPrefixedString(v.Circle.String())
+
 	case *tree.DTSQuery:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
@@ -257,6 +300,14 @@ func writeTextDatumNotNull(
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
 
+	case *tree.DRange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
+	case *tree.DMultirange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DArray:
 		// Arrays have custom formatting depending on their OID.
 		b.textFormatter.FormatNode(d)
@@ -666,15 +717,20 @@ func writeBinaryDatumNotNull(
 		//  The int32 length of the following bytes.
 		//  The family byte.
 		//  The mask size byte.
-		//  A 0 byte for is_cidr. It's ignored on the postgres frontend.
+		//  The is_cidr byte, which is 1 for cidr values and 0 otherwise. It's
+		//  ignored on the postgres frontend.
 		//  The length of our IP bytes.
 		//  The IP bytes.
 		const pgIPAddrBinaryHeaderSize = 4
+		var isCIDR byte
+		if w, ok := d.(*tree.DOidWrapper); ok && w.Oid == oid.T_cidr {
+			isCIDR = 1
+		}
 		if v.Family == ipaddr.IPv4family {
 			b.putInt32(net.IPv4len + pgIPAddrBinaryHeaderSize)
 			b.writeByte(pgwirebase.PGBinaryIPv4family)
 			b.writeByte(v.Mask)
-			b.writeByte(0)
+			b.writeByte(isCIDR)
 			b.writeByte(byte(net.IPv4len))
 			err := v.Addr.WriteIPv4Bytes(b)
 			if err != nil {
@@ -684,7 +740,7 @@ func writeBinaryDatumNotNull(
 			b.putInt32(net.IPv6len + pgIPAddrBinaryHeaderSize)
 			b.writeByte(pgwirebase.PGBinaryIPv6family)
 			b.writeByte(v.Mask)
-			b.writeByte(0)
+			b.writeByte(isCIDR)
 			b.writeByte(byte(net.IPv6len))
 			err := v.Addr.WriteIPv6Bytes(b)
 			if err != nil {
@@ -754,6 +810,18 @@ func writeBinaryDatumNotNull(
 		b.putInt32(8)
 		b.putInt64(int64(v.LSN))
 
+	case *tree.DMACAddr:
+		b.putInt32(macaddr.Size)
+		b.write(v.MACAddr.AppendBytes(b.putbuf[:0]))
+
+	case *tree.DMACAddr8:
+		b.putInt32(macaddr.Size8)
+		b.write(v.MACAddr8.AppendBytes(b.putbuf[:0]))
+
+	case *tree.DMoney:
+		b.putInt32(8)
+		b.putInt64(int64(*v))
+
 	case *tree.DBox2D:
 		b.putInt32(32)
 		b.putInt64(int64(math.Float64bits(v.LoX)))
@@ -801,6 +869,28 @@ func writeBinaryDatumNotNull(
 			b.putInt32(int32(math.Float32bits(f)))
 		}
 
+	case *tree.DRange:
+		initialLen := b.Len()
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		writeBinaryRange(ctx, b, v, sessionLoc)
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
+	case *tree.DMultirange:
+		initialLen := b.Len()
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		b.putInt32(int32(len(v.Ranges)))
+		for _, r := range v.Ranges {
+			rangeLen := b.Len()
+			b.putInt32(int32(0))
+			writeBinaryRange(ctx, b, r, sessionLoc)
+			b.putInt32AtIndex(rangeLen /* index to write at */, int32(b.Len()-(rangeLen+4)))
+		}
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
 	case *tree.DArray:
 		if v.ParamTyp.Family() == types.ArrayFamily {
 			b.setError(unimplemented.NewWithIssueDetail(32552,
@@ -841,6 +931,34 @@ func writeBinaryDatumNotNull(
 	case *tree.DJSON:
 		writeBinaryJSON(b, v.JSON, t)
 
+	case *tree.DJsonpath:
+		s := v.Jsonpath.String()
+		b.putInt32(int32(len(s) + 1))
+		// Postgres version number, as of writing, `1` is the only valid value.
+		b.writeByte(1)
+		b.writeString(s)
+
+	case *tree.DPoint:
+		writeBinaryBytes(b, geometric.EncodePoint(nil, v.Point), t)
+
+	case *tree.DBox:
+		writeBinaryBytes(b, geometric.EncodeBox(nil, v.Box), t)
+
+	case *tree.DLSeg:
+		writeBinaryBytes(b, geometric.EncodeLSeg(nil, v.LSeg), t)
+
+	case *tree.DLine:
+		writeBinaryBytes(b, geometric.EncodeLine(nil, v.Line), t)
+
+	case *tree.DPath:
+		writeBinaryBytes(b, geometric.EncodePath(nil, v.Path), t)
+
+	case *tree.DPolygon:
+		writeBinaryBytes(b, geometric.EncodePolygon(nil, v.Polygon), t)
+
+	case *tree.DCircle:
+		writeBinaryBytes(b, geometric.EncodeCircle(nil, v.Circle), t)
+
 	case *tree.DOid:
 		b.putInt32(4)
 		b.putInt32(int32(v.Oid))
@@ -849,6 +967,46 @@ func writeBinaryDatumNotNull(
 	}
 }
 
+// The flags of the Postgres binary format of ranges.
+const (
+	rangeEmptyFlag    = 0x01
+	rangeLowerIncFlag = 0x02
+	rangeUpperIncFlag = 0x04
+	rangeLowerInfFlag = 0x08
+	rangeUpperInfFlag = 0x10
+)
+
+// writeBinaryRange writes the Postgres binary format of a range without its
+// length prefix, which is a flags byte followed by its finite bounds.
+func writeBinaryRange(
+	ctx context.Context, b *writeBuffer, r *tree.DRange, sessionLoc *time.Location,
+) {
+	var flags byte
+	if r.Empty {
+		flags = rangeEmptyFlag
+	} else {
+		if r.Lower == nil {
+			flags |= rangeLowerInfFlag
+		} else if r.LowerInc {
+			flags |= rangeLowerIncFlag
+		}
+		if r.Upper == nil {
+			flags |= rangeUpperInfFlag
+		} else if r.UpperInc {
+			flags |= rangeUpperIncFlag
+		}
+	}
+	b.writeByte(flags)
+	if r.Empty {
+		return
+	}
+	for _, bound := range []tree.Datum{r.Lower, r.Upper} {
+		if bound != nil {
+			b.writeBinaryDatum(ctx, bound, sessionLoc, r.ResolvedType().RangeContents())
+		}
+	}
+}
+
 // writeBinaryColumnarElement is the same as writeBinaryDatum where the datum is
 // represented in a columnar element (at position rowIdx in the vector at
 // position vecIdx in vecs).
@@ -78,6 +78,20 @@ type DistAggregationInfo struct {
 // index corresponding to the local stage.
 var passThroughLocalIdxs = []uint32{0}
 
+// UserDefinedDistAggregationInfo is the DistAggregationInfo for user-defined
+// aggregates that have a combine function. The local stage computes partial
+// states with the transition function, and the final stage merges them with
+// the combine function before applying the final function.
+var UserDefinedDistAggregationInfo = DistAggregationInfo{
+	LocalStage: []execinfrapb.AggregatorSpec_Func{execinfrapb.UserDefined},
+	FinalStage: []FinalStageInfo{
+		{
+			Fn:        execinfrapb.UserDefined,
+			LocalIdxs: passThroughLocalIdxs,
+		},
+	},
+}
+
 // DistAggregationTable is DistAggregationInfo look-up table. Functions that
 // don't have an entry in the table are not optimized with a local stage.
 var DistAggregationTable = map[execinfrapb.AggregatorSpec_Func]DistAggregationInfo{
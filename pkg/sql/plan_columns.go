@@ -95,6 +95,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 		return n.columns
 	case *showFingerprintsNode:
 		return n.columns
+	case *textSearchTopKNode:
+		return n.columns
 	case *callNode:
 		return n.getResultColumns()
 
@@ -173,6 +175,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 
 	case *identifySystemNode:
 		return n.getColumns(mut, colinfo.IdentifySystemColumns)
+	case *createReplicationSlotNode:
+		return n.getColumns(mut, colinfo.CreateReplicationSlotColumns)
 	}
 
 	// Every other node has no columns in their results.
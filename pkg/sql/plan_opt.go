@@ -78,7 +78,8 @@ func (p *planner) prepareUsingOptimizer(
 		*tree.ReleaseSavepoint, *tree.RenameColumn, *tree.RenameDatabase,
 		*tree.RenameIndex, *tree.RenameTable, *tree.Revoke, *tree.RevokeRole,
 		*tree.RollbackToSavepoint, *tree.RollbackTransaction,
-		*tree.Savepoint, *tree.SetTransaction, *tree.SetTracing, *tree.SetSessionAuthorizationDefault,
+		*tree.Savepoint, *tree.SetConstraints, *tree.SetTransaction, *tree.SetTracing,
+		*tree.SetSessionAuthorizationDefault,
 		*tree.SetSessionCharacteristics:
 		// These statements do not have result columns and do not support placeholders
 		// so there is no need to do anything during prepare.
@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -109,6 +110,28 @@ type extendedEvalContext struct {
 
 	// validateDbZoneConfig should the DB zone config on commit.
 	validateDbZoneConfig *bool
+
+	// notificationReceiver receives the notifications for the channels the
+	// session listens on. It is nil if the session cannot receive
+	// notifications, as is the case for internal executors.
+	notificationReceiver pgnotify.Receiver
+
+	// listenOps refers to the LISTEN and UNLISTEN statements recorded in
+	// extraTxnState. It is nil if the executor does not commit its
+	// transactions, as is the case for executors running under an outer
+	// transaction.
+	listenOps *txnListenOps
+
+	// deferredConstraints refers to the deferred constraint state in
+	// extraTxnState. It is nil if constraints must always be checked
+	// immediately, as is the case for internal planners and for executors
+	// running under an outer transaction.
+	deferredConstraints *txnDeferredConstraints
+
+	// routineResultBuffer accumulates the result rows of the innermost
+	// set-returning PL/pgSQL routine that is currently executing, if any. It is
+	// used by RETURN NEXT and RETURN QUERY statements.
+	routineResultBuffer *routineResultBuffer
 }
 
 // copyFromExecCfg copies relevant fields from an ExecutorConfig.
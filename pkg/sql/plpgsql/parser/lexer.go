@@ -8,6 +8,7 @@ package parser
 import (
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/build"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -188,6 +189,16 @@ func (l *lexer) MakeExecSqlStmt() (*plpgsqltree.Execute, error) {
 	}, nil
 }
 
+// MakeDoStmt parses the options of a DO statement nested in a PL/pgSQL block.
+// The DO statement is executed as a SQL statement.
+func (l *lexer) MakeDoStmt(sqlStr string) (*plpgsqltree.Execute, error) {
+	sqlStmt, err := parser.ParseOne("DO " + sqlStr)
+	if err != nil {
+		return nil, err
+	}
+	return &plpgsqltree.Execute{SqlStmt: sqlStmt.AST}, nil
+}
+
 func (l *lexer) MakeDynamicExecuteStmt() (*plpgsqltree.DynamicExecute, error) {
 	cmdStr, _, err := l.ReadSqlStatement(INTO, USING, ';')
 	if err != nil {
@@ -360,6 +371,140 @@ func (l *lexer) ReadIntegerForLoopControl() (plpgsqltree.ForLoopControl, error)
 	}, err
 }
 
+// ReadQueryForLoopControl reads the control structure of a FOR loop that
+// iterates over the rows of a query, a dynamic query (FOR ... IN EXECUTE), or a
+// bound cursor. The LOOP keyword is consumed.
+func (l *lexer) ReadQueryForLoopControl() (plpgsqltree.ForLoopControl, error) {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it can be included.
+		l.PushBack(1)
+	}
+	if l.Peek().id == EXECUTE {
+		l.lastPos++
+		queryStr, terminator, err := l.ReadSqlExpr(USING, LOOP)
+		if err != nil {
+			return nil, err
+		}
+		l.lastPos++
+		query, err := l.ParseExpr(queryStr)
+		if err != nil {
+			return nil, err
+		}
+		var params []plpgsqltree.Expr
+		for terminator == USING {
+			var paramStr string
+			paramStr, terminator, err = l.ReadSqlExpr(',', LOOP)
+			if err != nil {
+				return nil, err
+			}
+			l.lastPos++
+			param, err := l.ParseExpr(paramStr)
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, param)
+		}
+		if terminator == 0 {
+			return nil, errors.New("missing LOOP keyword")
+		}
+		return &plpgsqltree.DynamicQueryForLoopControl{Query: query, Params: params}, nil
+	}
+	startPos := l.lastPos + 1
+	sqlStr, terminator, err := l.ReadSqlStatement(LOOP)
+	if err != nil {
+		return nil, err
+	}
+	if terminator == 0 {
+		return nil, errors.New("missing LOOP keyword")
+	}
+	endPos := l.lastPos
+	l.lastPos++
+	stmts, err := parser.Parse(sqlStr)
+	if err == nil {
+		if len(stmts) != 1 {
+			return nil, errors.New("expected exactly one SQL statement for FOR loop")
+		}
+		return &plpgsqltree.QueryForLoopControl{Query: stmts[0].AST}, nil
+	}
+	// The tokens do not form a SQL statement, so this could be a loop over a
+	// bound cursor, optionally with arguments: "FOR rec IN cur(1, 2) LOOP". If
+	// not, return the error from parsing the SQL statement.
+	if l.tokens[startPos].id != IDENT {
+		return nil, err
+	}
+	cursorVar := plpgsqltree.Variable(strings.TrimSpace(l.getStr(startPos, startPos+1)))
+	if startPos == endPos {
+		return &plpgsqltree.CursorForLoopControl{CursorVar: cursorVar}, nil
+	}
+	if l.tokens[startPos+1].id != '(' || l.tokens[endPos].id != ')' {
+		return nil, err
+	}
+	// Read the cursor arguments, and then restore the position after the LOOP
+	// keyword.
+	loopPos := l.lastPos
+	l.lastPos = startPos + 1
+	args, argsErr := l.ReadCursorArgs()
+	if argsErr != nil || l.lastPos != endPos {
+		return nil, err
+	}
+	l.lastPos = loopPos
+	return &plpgsqltree.CursorForLoopControl{CursorVar: cursorVar, Args: args}, nil
+}
+
+// ReadCursorArgs reads a comma-separated list of cursor argument expressions,
+// as well as the closing parenthesis that terminates the list. The opening
+// parenthesis must already have been consumed.
+func (l *lexer) ReadCursorArgs() ([]plpgsqltree.Expr, error) {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it can be included.
+		l.PushBack(1)
+	}
+	var args []plpgsqltree.Expr
+	for {
+		argStr, terminator, err := l.ReadSqlExpr(',', ')')
+		if err != nil {
+			return nil, err
+		}
+		if terminator == 0 {
+			return nil, errors.New("missing \")\" for cursor arguments")
+		}
+		l.lastPos++
+		arg, err := l.ParseExpr(argStr)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if terminator == ')' {
+			return args, nil
+		}
+	}
+}
+
+// ParseType parses the given string as the type of a variable declaration or
+// cursor argument.
+func (l *lexer) ParseType(sqlStr string) (tree.ResolvableTypeReference, error) {
+	// This is an inlined version of GetTypeFromValidSQLSyntax which doesn't
+	// return an assertion failure.
+	castExpr, err := l.ParseExpr("1::" + sqlStr)
+	if err != nil {
+		return nil, errors.New("unable to parse type of variable declaration")
+	}
+	switch t := castExpr.(type) {
+	case *tree.CollateExpr:
+		return types.MakeCollatedString(types.String, t.Locale), nil
+	case *tree.CastExpr:
+		return t.Type, nil
+	default:
+		err := errors.New("unable to parse type of variable declaration")
+		if strings.Contains(sqlStr, "%") {
+			err = errors.WithIssueLink(errors.WithHint(err,
+				"you may have attempted to use %TYPE or %ROWTYPE syntax, which is unsupported.",
+			), errors.IssueLink{IssueURL: build.MakeIssueURL(114676)})
+		}
+		return nil, err
+	}
+}
+
 func (l *lexer) ReadSqlExpr(
 	terminator1 int, terminators ...int,
 ) (sqlStr string, terminatorMet int, err error) {
@@ -2,14 +2,10 @@
 package parser
 
 import (
-  "strings"
-
-  "github.com/cockroachdb/cockroach/pkg/build"
   "github.com/cockroachdb/cockroach/pkg/sql/parser"
   "github.com/cockroachdb/cockroach/pkg/sql/scanner"
   "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
   "github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
-  "github.com/cockroachdb/cockroach/pkg/sql/types"
   "github.com/cockroachdb/errors"
   "github.com/cockroachdb/redact"
 )
@@ -175,6 +171,10 @@ func (u *plpgsqlSymUnion) variables() []plpgsqltree.Variable {
 		return u.val.([]plpgsqltree.Variable)
 }
 
+func (u *plpgsqlSymUnion) cursorArgs() []plpgsqltree.CursorArg {
+    return u.val.([]plpgsqltree.CursorArg)
+}
+
 func (u *plpgsqlSymUnion) forLoopControl() plpgsqltree.ForLoopControl {
 		return u.val.(plpgsqltree.ForLoopControl)
 }
@@ -326,7 +326,9 @@ func (u *plpgsqlSymUnion) forLoopControl() plpgsqltree.ForLoopControl {
 %type <str> decl_varname decl_defkey
 %type <bool>	decl_const decl_notnull
 %type <plpgsqltree.Expr>	decl_defval decl_cursor_query
-%type <tree.ResolvableTypeReference>	decl_datatype
+%type <tree.ResolvableTypeReference>	decl_datatype decl_cursor_argtype
+%type <[]plpgsqltree.CursorArg>	decl_cursor_args decl_cursor_arglist
+%type <plpgsqltree.CursorArg>	decl_cursor_arg
 %type <str>		decl_collate
 
 %type <str>	expr_until_semi expr_until_paren stmt_until_semi return_expr
@@ -337,7 +339,7 @@ func (u *plpgsqlSymUnion) forLoopControl() plpgsqltree.ForLoopControl {
 %type <*tree.NumVal> foreach_slice
 %type <plpgsqltree.ForLoopControl> for_control
 
-%type <str> any_identifier opt_block_label opt_loop_label opt_label query_options
+%type <str> any_identifier opt_block_label opt_loop_label opt_label
 %type <str> opt_error_level option_type
 
 %type <[]plpgsqltree.Statement> proc_sect
@@ -371,8 +373,9 @@ func (u *plpgsqlSymUnion) forLoopControl() plpgsqltree.ForLoopControl {
 
 %type <*plpgsqltree.RaiseOption> option_expr
 %type <[]plpgsqltree.RaiseOption> option_exprs opt_option_exprs
-%type <plpgsqltree.Expr> format_expr
-%type <[]plpgsqltree.Expr> opt_format_exprs format_exprs
+%type <plpgsqltree.Expr> format_expr query_expr query_param
+%type <[]plpgsqltree.Expr> opt_format_exprs format_exprs opt_query_params query_params
+%type <[]plpgsqltree.Expr> open_cursor_args
 
 %type <tree.CursorScrollOption>	opt_scrollable
 
@@ -465,6 +468,7 @@ decl_statement: decl_varname decl_const decl_datatype decl_collate decl_notnull
       Name: plpgsqltree.Variable($1),
       Scroll: $2.cursorScrollOption(),
       Query: $6.sqlStatement(),
+      Args: $4.cursorArgs(),
     }
   }
 ;
@@ -496,25 +500,46 @@ decl_cursor_query: stmt_until_semi ';'
   }
 ;
 
-decl_cursor_args: '('
+decl_cursor_args: '(' decl_cursor_arglist ')'
   {
-    return unimplemented(plpgsqllex, "cursor arguments")
+    $$.val = $2.cursorArgs()
   }
 | /* EMPTY */
   {
+    $$.val = []plpgsqltree.CursorArg(nil)
   }
 ;
 
 decl_cursor_arglist: decl_cursor_arg
   {
+    $$.val = []plpgsqltree.CursorArg{$1.val.(plpgsqltree.CursorArg)}
   }
 | decl_cursor_arglist ',' decl_cursor_arg
   {
+    $$.val = append($1.cursorArgs(), $3.val.(plpgsqltree.CursorArg))
   }
 ;
 
-decl_cursor_arg: decl_varname decl_datatype
+decl_cursor_arg: decl_varname decl_cursor_argtype
   {
+    $$.val = plpgsqltree.CursorArg{
+      Name: plpgsqltree.Variable($1),
+      Typ: $2.typ(),
+    }
+  }
+;
+
+decl_cursor_argtype:
+  {
+    sqlStr, _, err := plpgsqllex.(*lexer).ReadSqlExpr(',', ')')
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    typ, err := plpgsqllex.(*lexer).ParseType(sqlStr)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = typ
   }
 ;
 
@@ -554,26 +579,11 @@ decl_datatype:
     if err != nil {
       return setErr(plpgsqllex, err)
     }
-    // This is an inlined version of GetTypeFromValidSQLSyntax which doesn't
-    // return an assertion failure.
-    castExpr, err := plpgsqllex.(*lexer).ParseExpr("1::" + sqlStr)
+    typ, err := plpgsqllex.(*lexer).ParseType(sqlStr)
     if err != nil {
-      return setErr(plpgsqllex, errors.New("unable to parse type of variable declaration"))
-    }
-    switch t := castExpr.(type) {
-    case *tree.CollateExpr:
-      $$.val = types.MakeCollatedString(types.String, t.Locale)
-    case *tree.CastExpr:
-      $$.val = t.Type
-    default:
-      err := errors.New("unable to parse type of variable declaration")
-      if strings.Contains(sqlStr, "%") {
-        err = errors.WithIssueLink(errors.WithHint(err,
-          "you may have attempted to use %TYPE or %ROWTYPE syntax, which is unsupported.",
-        ), errors.IssueLink{IssueURL: build.MakeIssueURL(114676)})
-      }
       return setErr(plpgsqllex, err)
     }
+    $$.val = typ
   }
 ;
 
@@ -776,9 +786,13 @@ stmt_call: CALL expr_until_semi ';'
   }
 ;
 
-stmt_do: DO expr_until_semi ';'
+stmt_do: DO stmt_until_semi ';'
   {
-    return unimplemented(plpgsqllex, "do")
+    stmt, err := plpgsqllex.(*lexer).MakeDoStmt($2)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = stmt
   }
 ;
 
@@ -1058,7 +1072,12 @@ for_control:
 	    }
 	    $$.val = forLoopControl
 	  case LOOP:
-	    return unimplemented(plpgsqllex, "for loop over query or cursor")
+	    // This is an iteration over the rows of a query or cursor.
+	    forLoopControl, err := plpgsqllex.(*lexer).ReadQueryForLoopControl()
+	    if err != nil {
+	      return setErr(plpgsqllex, err)
+	    }
+	    $$.val = forLoopControl
 	  default:
 	    return setErr(plpgsqllex, errors.New("unterminated FOR loop definition"))
 	  }
@@ -1075,17 +1094,41 @@ for_target:
   }
 ;
 
-stmt_foreach_a: opt_loop_label FOREACH
+stmt_foreach_a: opt_loop_label FOREACH for_target foreach_slice IN ARRAY expr_until_loop LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for each loop")
+    loopLabel, loopEndLabel := $1, $10
+    if err := checkLoopLabels(loopLabel, loopEndLabel); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    var slice int
+    if $4.numVal() != nil {
+      val, err := $4.numVal().AsInt64()
+      if err != nil {
+        return setErr(plpgsqllex, err)
+      }
+      slice = int(val)
+    }
+    expr, err := plpgsqllex.(*lexer).ParseExpr($7)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.ForEachArray{
+      Label: loopLabel,
+      Target: $3.variables(),
+      Slice: slice,
+      Expr: expr,
+      Body: $9.statements(),
+    }
   }
 ;
 
 foreach_slice:
   {
+    $$.val = (*tree.NumVal)(nil)
   }
 | SLICE ICONST
   {
+    $$.val = $2.numVal()
   }
 ;
 
@@ -1119,14 +1162,36 @@ stmt_return: RETURN return_expr ';'
     }
     $$.val = &plpgsqltree.Return{Expr: expr}
   }
-| RETURN_NEXT NEXT
+| RETURN_NEXT NEXT return_expr ';'
+  {
+    var expr plpgsqltree.Expr
+    if $3 != "" {
+      var err error
+      expr, err = plpgsqllex.(*lexer).ParseExpr($3)
+      if err != nil {
+        return setErr(plpgsqllex, err)
+      }
+    }
+    $$.val = &plpgsqltree.ReturnNext{Expr: expr}
+  }
+| RETURN_QUERY QUERY EXECUTE query_expr opt_query_params ';'
   {
-    return unimplemented(plpgsqllex, "return next")
+    $$.val = &plpgsqltree.ReturnQuery{
+      DynamicQuery: $4.expr(),
+      Params: $5.exprs(),
+    }
+  }
+| RETURN_QUERY QUERY stmt_until_semi ';'
+  {
+    stmts, err := parser.Parse($3)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    if len(stmts) != 1 {
+      return setErr(plpgsqllex, errors.New("expected exactly one SQL statement for RETURN QUERY"))
+    }
+    $$.val = &plpgsqltree.ReturnQuery{SqlStmt: stmts[0].AST}
   }
-| RETURN_QUERY QUERY
- {
-   return unimplemented (plpgsqllex, "return query")
- }
 ;
 
 return_expr:
@@ -1139,19 +1204,53 @@ return_expr:
   }
 ;
 
-query_options:
+query_expr:
   {
-    _, terminator, err := plpgsqllex.(*lexer).ReadSqlExpr(EXECUTE, ';')
+    sqlStr, _, err := plpgsqllex.(*lexer).ReadSqlExpr(USING, ';')
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    expr, err := plpgsqllex.(*lexer).ParseExpr(sqlStr)
     if err != nil {
       return setErr(plpgsqllex, err)
     }
-    if terminator == EXECUTE {
-      return unimplemented (plpgsqllex, "return dynamic sql query")
+    $$.val = expr
+  }
+;
+
+opt_query_params:
+  USING query_params
+  {
+    $$.val = $2.exprs()
+  }
+| /* EMPTY */
+  {
+    $$.val = []plpgsqltree.Expr(nil)
+  }
+;
+
+query_params:
+  query_param
+  {
+    $$.val = []plpgsqltree.Expr{$1.expr()}
+  }
+| query_params ',' query_param
+  {
+    $$.val = append($1.exprs(), $3.expr())
+  }
+;
+
+query_param:
+  {
+    sqlStr, _, err := plpgsqllex.(*lexer).ReadSqlExpr(',', ';')
+    if err != nil {
+      return setErr(plpgsqllex, err)
     }
-    _, _, err = plpgsqllex.(*lexer).ReadSqlExpr(';')
+    param, err := plpgsqllex.(*lexer).ParseExpr(sqlStr)
     if err != nil {
       return setErr(plpgsqllex, err)
     }
+    $$.val = param
   }
 ;
 
@@ -1358,9 +1457,21 @@ stmt_open: OPEN IDENT ';'
   {
     $$.val = &plpgsqltree.Open{CurVar: plpgsqltree.Variable($2)}
   }
-| OPEN IDENT opt_scrollable FOR EXECUTE 
+| OPEN IDENT '(' open_cursor_args ';'
+  {
+    $$.val = &plpgsqltree.Open{
+      CurVar: plpgsqltree.Variable($2),
+      Args: $4.exprs(),
+    }
+  }
+| OPEN IDENT opt_scrollable FOR EXECUTE query_expr opt_query_params ';'
   {
-    return unimplemented(plpgsqllex, "cursor for execute")
+    $$.val = &plpgsqltree.Open{
+      CurVar: plpgsqltree.Variable($2),
+      Scroll: $3.cursorScrollOption(),
+      DynamicQuery: $6.expr(),
+      Params: $7.exprs(),
+    }
   }
 | OPEN IDENT opt_scrollable FOR stmt_until_semi ';'
   {
@@ -1379,6 +1490,16 @@ stmt_open: OPEN IDENT ';'
   }
 ;
 
+open_cursor_args:
+  {
+    args, err := plpgsqllex.(*lexer).ReadCursorArgs()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = args
+  }
+;
+
 stmt_fetch: FETCH
   {
     fetch, err := plpgsqllex.(*lexer).MakeFetchOrMoveStmt(false)
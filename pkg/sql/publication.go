@@ -0,0 +1,467 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// Publications are stored in system.publications. A publication belongs to a
+// database and lists the tables of the database whose changes are streamed to
+// the logical replication clients which subscribe to it, unless it publishes
+// all the tables of the database.
+//
+// The pgoutput plugin streams whole rows, so only tables with a single column
+// family can be published.
+
+// publication is a row of system.publications.
+type publication struct {
+	name      string
+	owner     username.SQLUsername
+	allTables bool
+	// tableIDs is empty if allTables is set. It may contain the IDs of tables
+	// which have been dropped since they were added.
+	tableIDs []descpb.ID
+}
+
+type createPublicationNode struct {
+	n *tree.CreatePublication
+}
+
+type alterPublicationNode struct {
+	n *tree.AlterPublication
+}
+
+type dropPublicationNode struct {
+	n *tree.DropPublication
+}
+
+// CreatePublication creates a publication in the current database.
+func (p *planner) CreatePublication(
+	ctx context.Context, n *tree.CreatePublication,
+) (planNode, error) {
+	if err := checkPublicationsSupported(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	return &createPublicationNode{n: n}, nil
+}
+
+// AlterPublication changes the tables of a publication.
+func (p *planner) AlterPublication(ctx context.Context, n *tree.AlterPublication) (planNode, error) {
+	if err := checkPublicationsSupported(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	return &alterPublicationNode{n: n}, nil
+}
+
+// DropPublication drops publications.
+func (p *planner) DropPublication(ctx context.Context, n *tree.DropPublication) (planNode, error) {
+	if err := checkPublicationsSupported(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	return &dropPublicationNode{n: n}, nil
+}
+
+// checkPublicationsSupported returns an error if the cluster has not been
+// upgraded to a version with the system.publications table.
+func checkPublicationsSupported(ctx context.Context, st *cluster.Settings) error {
+	if !st.Version.IsActive(ctx, clusterversion.V24_3_AddPublicationsTable) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"publications are not supported until version 24.3")
+	}
+	return nil
+}
+
+func (n *createPublicationNode) startExec(params runParams) error {
+	ctx, p := params.ctx, params.p
+	db, err := p.publicationDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	if err := p.CheckPrivilege(ctx, db, privilege.CREATE); err != nil {
+		return err
+	}
+	if n.n.AllTables {
+		// Like in Postgres, only superusers can publish the tables they don't
+		// own.
+		if hasAdmin, err := p.HasAdminRole(ctx); err != nil {
+			return err
+		} else if !hasAdmin {
+			return pgerror.New(pgcode.InsufficientPrivilege,
+				"must be admin to create FOR ALL TABLES publication")
+		}
+	}
+	tables, err := p.resolvePublicationTables(ctx, db, n.n.Tables, true /* adding */)
+	if err != nil {
+		return err
+	}
+	tableIDs := make([]descpb.ID, len(tables))
+	for i, tbl := range tables {
+		tableIDs[i] = tbl.GetID()
+	}
+	sort.Slice(tableIDs, func(i, j int) bool { return tableIDs[i] < tableIDs[j] })
+	ids, err := tableIDsDatum(tableIDs)
+	if err != nil {
+		return err
+	}
+	rows, err := p.InternalSQLTxn().ExecEx(
+		ctx, "create-publication", p.Txn(), sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.publications (database_id, name, owner, all_tables, table_ids)
+VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING`,
+		int64(db.GetID()), string(n.n.Name), p.User().Normalized(), n.n.AllTables, ids,
+	)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"publication %q already exists", string(n.n.Name))
+	}
+	return nil
+}
+
+func (n *createPublicationNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createPublicationNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createPublicationNode) Close(ctx context.Context)           {}
+
+func (n *alterPublicationNode) startExec(params runParams) error {
+	ctx, p := params.ctx, params.p
+	db, err := p.publicationDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	name := string(n.n.Name)
+	pub, err := getPublication(ctx, p.InternalSQLTxn(), db.GetID(), name)
+	if err != nil {
+		return err
+	}
+	if pub == nil {
+		return newUndefinedPublicationError(name)
+	}
+	if err := p.checkPublicationOwnership(ctx, pub); err != nil {
+		return err
+	}
+	if pub.allTables {
+		return errors.WithDetail(
+			pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"publication %q is defined as FOR ALL TABLES", name),
+			"Tables cannot be added to or dropped from FOR ALL TABLES publications.",
+		)
+	}
+	adding := n.n.Kind != tree.AlterPublicationDropTable
+	tables, err := p.resolvePublicationTables(ctx, db, n.n.Tables, adding)
+	if err != nil {
+		return err
+	}
+	published := make(map[descpb.ID]struct{}, len(pub.tableIDs))
+	for _, id := range pub.tableIDs {
+		published[id] = struct{}{}
+	}
+	switch n.n.Kind {
+	case tree.AlterPublicationAddTable:
+		for _, tbl := range tables {
+			if _, ok := published[tbl.GetID()]; ok {
+				return pgerror.Newf(pgcode.DuplicateObject,
+					"table %q is already member of publication %q", tbl.GetName(), name)
+			}
+			published[tbl.GetID()] = struct{}{}
+		}
+	case tree.AlterPublicationSetTable:
+		published = make(map[descpb.ID]struct{}, len(tables))
+		for _, tbl := range tables {
+			published[tbl.GetID()] = struct{}{}
+		}
+	case tree.AlterPublicationDropTable:
+		for _, tbl := range tables {
+			if _, ok := published[tbl.GetID()]; !ok {
+				return pgerror.Newf(pgcode.UndefinedObject,
+					"table %q is not part of the publication", tbl.GetName())
+			}
+			delete(published, tbl.GetID())
+		}
+	}
+	tableIDs := make([]descpb.ID, 0, len(published))
+	for id := range published {
+		tableIDs = append(tableIDs, id)
+	}
+	sort.Slice(tableIDs, func(i, j int) bool { return tableIDs[i] < tableIDs[j] })
+	ids, err := tableIDsDatum(tableIDs)
+	if err != nil {
+		return err
+	}
+	_, err = p.InternalSQLTxn().ExecEx(
+		ctx, "alter-publication", p.Txn(), sessiondata.NodeUserSessionDataOverride,
+		`UPDATE system.publications SET table_ids = $3 WHERE database_id = $1 AND name = $2`,
+		int64(db.GetID()), name, ids,
+	)
+	return err
+}
+
+func (n *alterPublicationNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *alterPublicationNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *alterPublicationNode) Close(ctx context.Context)           {}
+
+func (n *dropPublicationNode) startExec(params runParams) error {
+	ctx, p := params.ctx, params.p
+	db, err := p.publicationDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	// Nothing depends on publications, so CASCADE and RESTRICT are no-ops.
+	for _, name := range n.n.Names {
+		pub, err := getPublication(ctx, p.InternalSQLTxn(), db.GetID(), string(name))
+		if err != nil {
+			return err
+		}
+		if pub == nil {
+			if n.n.IfExists {
+				continue
+			}
+			return newUndefinedPublicationError(string(name))
+		}
+		if err := p.checkPublicationOwnership(ctx, pub); err != nil {
+			return err
+		}
+		if _, err := p.InternalSQLTxn().ExecEx(
+			ctx, "drop-publication", p.Txn(), sessiondata.NodeUserSessionDataOverride,
+			`DELETE FROM system.publications WHERE database_id = $1 AND name = $2`,
+			int64(db.GetID()), string(name),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *dropPublicationNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *dropPublicationNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *dropPublicationNode) Close(ctx context.Context)           {}
+
+func newUndefinedPublicationError(name string) error {
+	return pgerror.Newf(pgcode.UndefinedObject, "publication %q does not exist", name)
+}
+
+// publicationDatabase returns the current database, which the publications
+// of the statement belong to.
+func (p *planner) publicationDatabase(ctx context.Context) (catalog.DatabaseDescriptor, error) {
+	if p.CurrentDatabase() == "" {
+		return nil, sqlerrors.ErrNoDatabase
+	}
+	return p.Descriptors().ByNameWithLeased(p.Txn()).Get().Database(ctx, p.CurrentDatabase())
+}
+
+// checkPublicationOwnership returns an error if the user is not the owner of
+// the publication, a member of the owner role or an admin.
+func (p *planner) checkPublicationOwnership(ctx context.Context, pub *publication) error {
+	if hasAdmin, err := p.HasAdminRole(ctx); err != nil || hasAdmin {
+		return err
+	}
+	isOwner, err := p.checkRolePredicate(ctx, p.User(), func(role username.SQLUsername) (bool, error) {
+		return role == pub.owner, nil
+	})
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of publication %s", pub.name)
+	}
+	return nil
+}
+
+// resolvePublicationTables resolves the tables of a CREATE or ALTER
+// PUBLICATION statement, which must belong to the database of the
+// publication. If the tables are added to the publication, the user must own
+// them and they must be publishable. Like in Postgres, the tables which are
+// listed more than once are only returned once.
+func (p *planner) resolvePublicationTables(
+	ctx context.Context, db catalog.DatabaseDescriptor, names tree.TableNames, adding bool,
+) ([]catalog.TableDescriptor, error) {
+	flags := tree.ObjectLookupFlags{
+		Required:             true,
+		DesiredObjectKind:    tree.TableObject,
+		DesiredTableDescKind: tree.ResolveRequireTableDesc,
+	}
+	tables := make([]catalog.TableDescriptor, 0, len(names))
+	seen := make(map[descpb.ID]struct{}, len(names))
+	for i := range names {
+		tn := &names[i]
+		_, tbl, err := resolver.ResolveExistingTableObject(ctx, p, tn, flags)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[tbl.GetID()]; ok {
+			continue
+		}
+		seen[tbl.GetID()] = struct{}{}
+		if tbl.GetParentID() != db.GetID() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"table %q does not belong to database %q", tree.ErrString(tn), db.GetName())
+		}
+		if adding {
+			if err := checkTablePublishable(tbl); err != nil {
+				return nil, err
+			}
+			if isOwner, err := p.HasOwnership(ctx, tbl); err != nil {
+				return nil, err
+			} else if !isOwner {
+				return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+					"must be owner of table %s", tbl.GetName())
+			}
+		}
+		tables = append(tables, tbl)
+	}
+	return tables, nil
+}
+
+// checkTablePublishable returns an error if the changes to the table cannot be
+// streamed by the pgoutput plugin.
+func checkTablePublishable(tbl catalog.TableDescriptor) error {
+	if tbl.IsVirtualTable() || tbl.IsTemporary() {
+		return errors.WithDetail(
+			pgerror.Newf(pgcode.InvalidParameterValue,
+				"cannot add table %q to publication", tbl.GetName()),
+			"Only regular tables can be published.",
+		)
+	}
+	if len(tbl.GetFamilies()) > 1 {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot publish table %q with multiple column families", tbl.GetName()),
+			"Logical replication streams whole rows, which requires a single column family.",
+		)
+	}
+	return nil
+}
+
+// tableIDsDatum returns the value of the table_ids column of
+// system.publications.
+func tableIDsDatum(tableIDs []descpb.ID) (*tree.DArray, error) {
+	a := tree.NewDArray(types.Int)
+	for _, id := range tableIDs {
+		if err := a.Append(tree.NewDInt(tree.DInt(id))); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// getPublication reads the publication with the given name. It returns nil if
+// the publication does not exist.
+func getPublication(
+	ctx context.Context, txn isql.Txn, dbID descpb.ID, name string,
+) (*publication, error) {
+	row, err := txn.QueryRowEx(
+		ctx, "get-publication", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`SELECT owner, all_tables, table_ids FROM system.publications
+WHERE database_id = $1 AND name = $2`,
+		int64(dbID), name,
+	)
+	if err != nil || row == nil {
+		return nil, err
+	}
+	pub := &publication{
+		name:      name,
+		owner:     username.MakeSQLUsernameFromPreNormalizedString(string(tree.MustBeDString(row[0]))),
+		allTables: bool(tree.MustBeDBool(row[1])),
+	}
+	for _, id := range tree.MustBeDArray(row[2]).Array {
+		pub.tableIDs = append(pub.tableIDs, descpb.ID(tree.MustBeDInt(id)))
+	}
+	return pub, nil
+}
+
+// publishedTables returns the IDs of the tables of the database which are
+// published by at least one of the given publications, as seen by the
+// transaction. The publications which don't exist are ignored. An error is
+// returned if one of the tables cannot be published.
+func publishedTables(
+	ctx context.Context, txn descs.Txn, dbID descpb.ID, publications []string,
+) ([]descpb.ID, error) {
+	allTables := false
+	published := make(map[descpb.ID]struct{})
+	for _, name := range publications {
+		pub, err := getPublication(ctx, txn, dbID, name)
+		if err != nil {
+			return nil, err
+		}
+		if pub == nil {
+			continue
+		}
+		allTables = allTables || pub.allTables
+		for _, id := range pub.tableIDs {
+			published[id] = struct{}{}
+		}
+	}
+	if !allTables && len(published) == 0 {
+		return nil, nil
+	}
+	db, err := txn.Descriptors().ByIDWithoutLeased(txn.KV()).WithoutNonPublic().Get().Database(ctx, dbID)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := txn.Descriptors().GetAllTablesInDatabase(ctx, txn.KV(), db)
+	if err != nil {
+		return nil, err
+	}
+	var tableIDs []descpb.ID
+	if err := tables.ForEachDescriptor(func(desc catalog.Descriptor) error {
+		tbl, ok := desc.(catalog.TableDescriptor)
+		if !ok || !tbl.IsTable() || !tbl.Public() {
+			return nil
+		}
+		if _, ok := published[tbl.GetID()]; !ok {
+			// FOR ALL TABLES publications only publish the regular tables, like
+			// in Postgres.
+			if !allTables || tbl.IsVirtualTable() || tbl.IsTemporary() {
+				return nil
+			}
+		}
+		if err := checkTablePublishable(tbl); err != nil {
+			return err
+		}
+		tableIDs = append(tableIDs, tbl.GetID())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(tableIDs, func(i, j int) bool { return tableIDs[i] < tableIDs[j] })
+	return tableIDs, nil
+}
+
+// publishedTablesAt is like publishedTables, but returns the tables published
+// at the given timestamp.
+func publishedTablesAt(
+	ctx context.Context, db descs.DB, dbID descpb.ID, publications []string, ts hlc.Timestamp,
+) (tableIDs []descpb.ID, err error) {
+	err = db.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) (err error) {
+		if err := txn.KV().SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		tableIDs, err = publishedTables(ctx, txn, dbID, publications)
+		return err
+	})
+	return tableIDs, err
+}
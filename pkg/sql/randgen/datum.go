@@ -22,8 +22,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/cockroach/pkg/util/geometric"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
+	"github.com/cockroachdb/cockroach/pkg/util/macaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
@@ -219,7 +222,16 @@ func RandDatumWithNullChance(
 		return tree.NewDUuid(tree.DUuid{UUID: gen.NewV4()})
 	case types.INetFamily:
 		ipAddr := ipaddr.RandIPAddr(rng)
+		if typ.Oid() == oid.T_cidr {
+			return tree.NewDCIDR(&tree.DIPAddr{IPAddr: ipAddr.Network()})
+		}
 		return tree.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr})
+	case types.MACAddrFamily:
+		return tree.NewDMACAddr(macaddr.RandMACAddr(rng))
+	case types.MACAddr8Family:
+		return tree.NewDMACAddr8(macaddr.RandMACAddr8(rng))
+	case types.MoneyFamily:
+		return tree.NewDMoney(tree.DMoney(int64(rng.Uint64())))
 	case types.JsonFamily:
 		j, err := json.Random(20, rng)
 		if err != nil {
@@ -324,13 +336,88 @@ func RandDatumWithNullChance(
 		return tree.NewDTSVector(tsearch.RandomTSVector(rng))
 	case types.TSQueryFamily:
 		return tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
+	case types.JsonpathFamily:
+		return tree.NewDJsonpath(jsonpath.RandomJsonpath(rng))
+	case types.PointFamily:
+		return tree.NewDPoint(geometric.RandomPoint(rng))
+	case types.BoxFamily:
+		return tree.NewDBox(geometric.RandomBox(rng))
+	case types.LSegFamily:
+		return tree.NewDLSeg(geometric.RandomLSeg(rng))
+	case types.LineFamily:
+		return tree.NewDLine(geometric.RandomLine(rng))
+	case types.PathFamily:
+		return tree.NewDPath(geometric.RandomPath(rng))
+	case types.PolygonFamily:
+		return tree.NewDPolygon(geometric.RandomPolygon(rng))
+	case types.CircleFamily:
+		return tree.NewDCircle(geometric.RandomCircle(rng))
 	case types.PGVectorFamily:
 		return tree.NewDPGVector(vector.Random(rng))
+	case types.RangeFamily:
+		if rng.Intn(10) == 0 {
+			return tree.NewDEmptyRange(typ)
+		}
+		lower := rng.Int63n(2000) - 1000
+		return randRange(rng, typ, lower, lower+1+rng.Int63n(1000), true /* infiniteOk */)
+	case types.MultirangeFamily:
+		ranges := make([]*tree.DRange, rng.Intn(4))
+		next := rng.Int63n(2000) - 1000
+		for i := range ranges {
+			// Leave a gap between the ranges so that they are not adjacent.
+			upper := next + 1 + rng.Int63n(100)
+			ranges[i] = randRange(rng, typ.MultirangeContents(), next, upper, false /* infiniteOk */)
+			next = upper + 1 + rng.Int63n(100)
+		}
+		return tree.NewDMultirange(typ, ranges)
 	default:
 		panic(errors.AssertionFailedf("invalid type %v", typ.DebugString()))
 	}
 }
 
+// randRange returns a random non-empty range of type typ between lower and
+// upper, which must be less than upper. If infiniteOk is true, either bound
+// may be infinite. Ranges of discrete types are generated in their canonical
+// form.
+func randRange(rng *rand.Rand, typ *types.T, lower, upper int64, infiniteOk bool) *tree.DRange {
+	sub := typ.RangeContents()
+	boundDatum := func(v int64) tree.Datum {
+		switch sub.Family() {
+		case types.IntFamily:
+			return tree.NewDInt(tree.DInt(v))
+		case types.DecimalFamily:
+			d := &tree.DDecimal{}
+			d.SetInt64(v)
+			return d
+		case types.TimestampFamily:
+			return tree.MustMakeDTimestamp(timeutil.Unix(v*3600, 0), time.Microsecond)
+		case types.TimestampTZFamily:
+			return tree.MustMakeDTimestampTZ(timeutil.Unix(v*3600, 0), time.Microsecond)
+		case types.DateFamily:
+			d, err := pgdate.MakeDateFromUnixEpoch(v)
+			if err != nil {
+				panic(err)
+			}
+			return tree.NewDDate(d)
+		default:
+			panic(errors.AssertionFailedf("invalid range subtype %v", sub.DebugString()))
+		}
+	}
+	var lowerDatum, upperDatum tree.Datum
+	if !infiniteOk || rng.Intn(5) != 0 {
+		lowerDatum = boundDatum(lower)
+	}
+	if !infiniteOk || rng.Intn(5) != 0 {
+		upperDatum = boundDatum(upper)
+	}
+	lowerInc, upperInc := lowerDatum != nil, false
+	if sub.Family() != types.IntFamily && sub.Family() != types.DateFamily {
+		lowerInc = lowerDatum != nil && rng.Intn(2) == 0
+		upperInc = upperDatum != nil && rng.Intn(2) == 0
+	}
+	return tree.NewDRange(typ, lowerDatum, upperDatum, lowerInc, upperInc)
+}
+
 // RandArray generates a random DArray where the contents have nullChance
 // of being null.
 func RandArray(rng *rand.Rand, typ *types.T, nullChance int) tree.Datum {
@@ -0,0 +1,268 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// replicationSlot is a logical replication slot stored in
+// system.replication_slots.
+type replicationSlot struct {
+	name       string
+	plugin     string
+	databaseID descpb.ID
+	// confirmedFlushLSN is the position up to which the client has confirmed
+	// that it has durably processed the stream. Streaming resumes from it.
+	confirmedFlushLSN lsn.LSN
+}
+
+// checkReplicationSlotsSupported returns an error if the cluster has not been
+// upgraded to a version with the system.replication_slots table.
+func checkReplicationSlotsSupported(ctx context.Context, st *cluster.Settings) error {
+	if !st.Version.IsActive(ctx, clusterversion.V24_3_AddReplicationSlotsTable) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"replication slots are not supported until version 24.3")
+	}
+	return nil
+}
+
+// getReplicationSlot reads the replication slot with the given name. It
+// returns an error if the slot does not exist.
+func getReplicationSlot(ctx context.Context, txn isql.Txn, name string) (replicationSlot, error) {
+	row, err := txn.QueryRowEx(
+		ctx, "get-replication-slot", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`SELECT plugin, database_id, confirmed_flush_lsn FROM system.replication_slots WHERE slot_name = $1`,
+		name,
+	)
+	if err != nil {
+		return replicationSlot{}, err
+	}
+	if row == nil {
+		return replicationSlot{}, pgerror.Newf(pgcode.UndefinedObject,
+			"replication slot %q does not exist", name)
+	}
+	return replicationSlot{
+		name:              name,
+		plugin:            string(tree.MustBeDString(row[0])),
+		databaseID:        descpb.ID(tree.MustBeDInt(row[1])),
+		confirmedFlushLSN: lsn.LSN(tree.MustBeDInt(row[2])),
+	}, nil
+}
+
+// advanceReplicationSlot records that the client of the replication slot has
+// durably processed the stream up to the given position.
+func advanceReplicationSlot(ctx context.Context, db isql.DB, name string, flushed lsn.LSN) error {
+	return db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		_, err := txn.ExecEx(
+			ctx, "advance-replication-slot", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+			`UPDATE system.replication_slots SET confirmed_flush_lsn = $2
+WHERE slot_name = $1 AND confirmed_flush_lsn < $2`,
+			name, int64(flushed),
+		)
+		return err
+	})
+}
+
+type createReplicationSlotNode struct {
+	optColumnsSlot
+	n *pgrepltree.CreateReplicationSlot
+	// consistentPoint is the position from which the slot streams changes.
+	consistentPoint lsn.LSN
+	shown           bool
+}
+
+// CreateReplicationSlot creates a logical replication slot which streams the
+// changes to the tables of the current database with the pgoutput plugin.
+func (p *planner) CreateReplicationSlot(
+	ctx context.Context, n *pgrepltree.CreateReplicationSlot,
+) (planNode, error) {
+	if err := checkReplicationSlotsSupported(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	if n.Kind != pgrepltree.LogicalReplication {
+		return nil, unimplemented.New("physical replication",
+			"physical replication slots are not supported")
+	}
+	if n.Temporary {
+		return nil, unimplemented.New("temporary replication slots",
+			"temporary replication slots are not supported")
+	}
+	if n.Plugin != pgoutput.PluginName {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"output plugin %q is not supported", string(n.Plugin))
+	}
+	for _, opt := range n.Options {
+		switch opt.Key {
+		case "snapshot":
+			// No snapshot is exported, which clients treat as "nothing".
+		case "two_phase":
+			if replicationOptionValue(opt) != "false" {
+				return nil, unimplemented.New("two-phase decoding",
+					"two-phase decoding is not supported")
+			}
+		case "reserve_wal":
+			// Only meaningful for physical replication.
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"unrecognized option %q for CREATE_REPLICATION_SLOT", string(opt.Key))
+		}
+	}
+	if p.CurrentDatabase() == "" {
+		return nil, pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical decoding requires a database connection")
+	}
+	return &createReplicationSlotNode{n: n}, nil
+}
+
+func (n *createReplicationSlotNode) startExec(params runParams) error {
+	ctx, p := params.ctx, params.p
+	db, err := p.Descriptors().ByNameWithLeased(p.Txn()).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return err
+	}
+	// The changes visible to this transaction are part of the initial state of
+	// the slot, so the stream starts right after its read timestamp.
+	n.consistentPoint = lsnutil.HLCToLSN(p.Txn().ReadTimestamp())
+	rows, err := p.InternalSQLTxn().ExecEx(
+		ctx, "create-replication-slot", p.Txn(), sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.replication_slots (slot_name, plugin, database_id, confirmed_flush_lsn)
+VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`,
+		string(n.n.Slot), string(n.n.Plugin), int64(db.GetID()), int64(n.consistentPoint),
+	)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"replication slot %q already exists", string(n.n.Slot))
+	}
+	return nil
+}
+
+func (n *createReplicationSlotNode) Next(params runParams) (bool, error) {
+	if n.shown {
+		return false, nil
+	}
+	n.shown = true
+	return true, nil
+}
+
+func (n *createReplicationSlotNode) Values() tree.Datums {
+	return tree.Datums{
+		tree.NewDString(string(n.n.Slot)),
+		tree.NewDString(n.consistentPoint.String()),
+		tree.DNull, // snapshot_name
+		tree.NewDString(string(n.n.Plugin)),
+	}
+}
+
+func (n *createReplicationSlotNode) Close(ctx context.Context) {}
+
+// DropReplicationSlot drops a replication slot.
+func (p *planner) DropReplicationSlot(
+	ctx context.Context, n *pgrepltree.DropReplicationSlot,
+) (planNode, error) {
+	if err := checkReplicationSlotsSupported(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	rows, err := p.InternalSQLTxn().ExecEx(
+		ctx, "drop-replication-slot", p.Txn(), sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.replication_slots WHERE slot_name = $1`,
+		string(n.Slot),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"replication slot %q does not exist", string(n.Slot))
+	}
+	return newZeroNode(nil /* columns */), nil
+}
+
+// replicationOptionValue returns the value of an option of a replication
+// command, or the empty string if it has no value.
+func replicationOptionValue(opt pgrepltree.Option) string {
+	switch v := opt.Value.(type) {
+	case nil:
+		return ""
+	case *tree.StrVal:
+		return v.RawString()
+	default:
+		return tree.AsStringWithFlags(v, tree.FmtBareStrings)
+	}
+}
+
+// validateStartReplicationOptions returns an error if the options of a
+// START_REPLICATION command, which are passed to the pgoutput plugin, are not
+// supported. Otherwise, it returns the names of the publications whose tables
+// are streamed.
+func validateStartReplicationOptions(opts pgrepltree.Options) (publications []string, _ error) {
+	for _, opt := range opts {
+		val := replicationOptionValue(opt)
+		switch opt.Key {
+		case "proto_version":
+			if val != "1" {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"client sent proto_version=%s but only version %d is supported",
+					val, pgoutput.ProtocolVersion)
+			}
+		case "publication_names":
+			var err error
+			if publications, err = parsePublicationNames(val); err != nil {
+				return nil, err
+			}
+		case "binary", "messages", "streaming", "two_phase", "origin":
+			if val != "" && val != "false" && val != "off" && val != "none" && val != "any" {
+				return nil, unimplemented.Newf("pgoutput "+string(opt.Key),
+					"pgoutput option %q is not supported", string(opt.Key))
+			}
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized pgoutput option: %s", string(opt.Key))
+		}
+	}
+	if len(publications) == 0 {
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "publication_names parameter missing")
+	}
+	return publications, nil
+}
+
+// parsePublicationNames parses the value of the publication_names option,
+// which is a comma-separated list of identifiers. Like in Postgres, the
+// identifiers which are not double-quoted are folded to lower case.
+func parsePublicationNames(val string) ([]string, error) {
+	var names []string
+	for _, part := range strings.Split(val, ",") {
+		part = strings.TrimSpace(part)
+		name := lexbase.NormalizeName(part)
+		if len(part) >= 2 && part[0] == '"' && part[len(part)-1] == '"' {
+			name = strings.ReplaceAll(part[1:len(part)-1], `""`, `"`)
+		}
+		if name == "" {
+			return nil, pgerror.New(pgcode.InvalidName, "invalid publication_names syntax")
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
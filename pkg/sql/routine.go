@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/metamorphic"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
@@ -193,6 +194,10 @@ type routineGenerator struct {
 		expr *tree.RoutineExpr
 		args tree.Datums
 	}
+	// resultBuffer accumulates the result rows of a set-returning PL/pgSQL
+	// routine. It is only set for the root routine of such a function, and is
+	// preserved when the generator is reset for tail-call optimization.
+	resultBuffer *routineResultBuffer
 }
 
 var _ eval.ValueGenerator = &routineGenerator{}
@@ -212,8 +217,11 @@ func (g *routineGenerator) init(p *planner, expr *tree.RoutineExpr, args tree.Da
 func (g *routineGenerator) reset(
 	ctx context.Context, p *planner, expr *tree.RoutineExpr, args tree.Datums,
 ) {
+	resultBuffer := g.resultBuffer
+	g.resultBuffer = nil
 	g.Close(ctx)
 	g.init(p, expr, args)
+	g.resultBuffer = resultBuffer
 }
 
 // ResolvedType is part of the eval.ValueGenerator interface.
@@ -223,17 +231,35 @@ func (g *routineGenerator) ResolvedType() *types.T {
 
 // Start is part of the eval.ValueGenerator interface.
 func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
+	if g.expr.ResultBuffer {
+		// The result rows of a set-returning PL/pgSQL routine are added by the
+		// nested routines for RETURN NEXT and RETURN QUERY statements, which find
+		// the buffer through the planner.
+		p := g.p
+		g.resultBuffer = newRoutineResultBuffer(ctx, p, g.expr)
+		prevResultBuffer := p.extendedEvalCtx.routineResultBuffer
+		p.extendedEvalCtx.routineResultBuffer = g.resultBuffer
+		defer func() { p.extendedEvalCtx.routineResultBuffer = prevResultBuffer }()
+	}
 	for {
 		err = g.startInternal(ctx, txn)
 		if err != nil || g.deferredRoutine.expr == nil {
 			// No tail-call optimization.
-			return err
+			break
 		}
 		// A nested routine in tail-call position deferred its execution until now.
 		// Since it's in tail-call position, evaluating it will give the result of
 		// this routine as well.
 		g.reset(ctx, g.p, g.deferredRoutine.expr, g.deferredRoutine.args)
 	}
+	if err != nil || g.resultBuffer == nil {
+		return err
+	}
+	// The result of the last body statement of a set-returning PL/pgSQL routine
+	// is always NULL, and is discarded in favor of the buffered rows.
+	g.rci.Close()
+	g.rci = newRowContainerIterator(ctx, g.resultBuffer.rch)
+	return nil
 }
 
 // startInternal implements logic for a single execution of a routine.
@@ -283,6 +309,7 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 	ef := newExecFactory(ctx, g.p)
 	rrw := NewRowResultWriter(&g.rch)
 	var cursorHelper *plpgsqlCursorHelper
+	var dynamicQuery tree.Datums
 	err = g.expr.ForEachPlan(ctx, ef, g.args, func(plan tree.RoutinePlan, stmtForDistSQLDiagram string, isFinalPlan bool) error {
 		stmtIdx++
 		opName := "udf-stmt-" + g.expr.Name + "-" + strconv.Itoa(stmtIdx)
@@ -291,16 +318,43 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 
 		var w rowResultWriter
 		openCursor := stmtIdx == 1 && g.expr.CursorDeclaration != nil
+		returnNext := stmtIdx == 1 && g.expr.ReturnNext != nil
 		if isFinalPlan {
 			// The result of this statement is the routine's output.
 			w = rrw
+		} else if openCursor && g.expr.CursorDeclaration.Dynamic {
+			// The first statement returns the query string and its parameters.
+			w = NewCallbackResultWriter(func(ctx context.Context, row tree.Datums) error {
+				dynamicQuery = append(tree.Datums(nil), row...)
+				return nil
+			})
 		} else if openCursor {
 			// The result of the first statement will be used to open a SQL cursor.
-			cursorHelper, err = g.newCursorHelper(plan.(*planComponents))
+			cursorHelper, err = g.newCursorHelper(
+				plan.(*planComponents).main.planColumns(), g.expr.CursorDeclaration.CursorSQL,
+			)
 			if err != nil {
 				return err
 			}
 			w = NewRowResultWriter(&cursorHelper.container)
+		} else if returnNext {
+			// The result of the first statement is added to the result of the
+			// enclosing set-returning routine.
+			resultBuffer := g.p.extendedEvalCtx.routineResultBuffer
+			if resultBuffer == nil {
+				return errors.AssertionFailedf("RETURN NEXT outside of a set-returning routine")
+			}
+			if g.expr.ReturnNext.Dynamic {
+				// The first statement returns the query string and its parameters.
+				w = NewCallbackResultWriter(func(ctx context.Context, row tree.Datums) error {
+					dynamicQuery = append(tree.Datums(nil), row...)
+					return nil
+				})
+			} else {
+				w = NewCallbackResultWriter(func(ctx context.Context, row tree.Datums) error {
+					return resultBuffer.addValue(ctx, row[0])
+				})
+			}
 		} else {
 			// The result of this statement is not needed. Use a rowResultWriter that
 			// drops all rows added to it.
@@ -323,9 +377,16 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 		if err != nil {
 			return err
 		}
+		if openCursor && g.expr.CursorDeclaration.Dynamic {
+			cursorHelper, err = g.openDynamicCursor(ctx, dynamicQuery)
+			return err
+		}
 		if openCursor {
 			return cursorHelper.createCursor(g.p)
 		}
+		if returnNext && g.expr.ReturnNext.Dynamic {
+			return g.returnQueryExecute(ctx, dynamicQuery)
+		}
 		return nil
 	})
 	if err != nil {
@@ -487,9 +548,159 @@ func (g *routineGenerator) Close(ctx context.Context) {
 		g.rci.Close()
 	}
 	g.rch.Close(ctx)
+	if g.resultBuffer != nil {
+		g.resultBuffer.rch.Close(ctx)
+	}
 	*g = routineGenerator{}
 }
 
+// returnQueryExecute implements RETURN QUERY EXECUTE by executing the query
+// produced by the first body statement of the routine, and adding its rows to
+// the result of the enclosing set-returning routine. The query string is the
+// first element of the given row, and the remaining elements are the values of
+// the query parameters.
+func (g *routineGenerator) returnQueryExecute(ctx context.Context, query tree.Datums) error {
+	if len(query) == 0 {
+		return errors.AssertionFailedf("expected a query string for RETURN QUERY EXECUTE")
+	}
+	if query[0] == tree.DNull {
+		return pgerror.New(pgcode.NullValueNotAllowed, "query string argument of EXECUTE is null")
+	}
+	qargs := make([]interface{}, len(query)-1)
+	for i := range qargs {
+		qargs[i] = query[i+1]
+	}
+	rows, cols, err := g.p.QueryBufferedExWithCols(
+		ctx, "plpgsql-return-query-execute", sessiondata.NoSessionDataOverride,
+		string(tree.MustBeDString(query[0])), qargs...,
+	)
+	if err != nil {
+		return err
+	}
+	return g.p.extendedEvalCtx.routineResultBuffer.addQueryRows(ctx, rows, cols)
+}
+
+// openDynamicCursor implements OPEN ... FOR EXECUTE by executing the query
+// produced by the first body statement of the routine, and opening a cursor
+// with its rows. The query string is the first element of the given row, and
+// the remaining elements are the values of the query parameters.
+func (g *routineGenerator) openDynamicCursor(
+	ctx context.Context, query tree.Datums,
+) (*plpgsqlCursorHelper, error) {
+	if len(query) == 0 {
+		return nil, errors.AssertionFailedf("expected a query string for OPEN FOR EXECUTE")
+	}
+	if query[0] == tree.DNull {
+		return nil, pgerror.New(pgcode.NullValueNotAllowed, "query string argument of EXECUTE is null")
+	}
+	queryStr := string(tree.MustBeDString(query[0]))
+	qargs := make([]interface{}, len(query)-1)
+	for i := range qargs {
+		qargs[i] = query[i+1]
+	}
+	rows, cols, err := g.p.QueryBufferedExWithCols(
+		ctx, "plpgsql-open-cursor-execute", sessiondata.NoSessionDataOverride, queryStr, qargs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	cursorHelper, err := g.newCursorHelper(cols, queryStr)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if err = cursorHelper.container.AddRow(ctx, row); err != nil {
+			return cursorHelper, err
+		}
+	}
+	return cursorHelper, cursorHelper.createCursor(g.p)
+}
+
+// routineResultBuffer accumulates the result rows of a set-returning PL/pgSQL
+// routine. Rows are added by RETURN NEXT and RETURN QUERY statements, which are
+// executed by routines nested within the set-returning routine.
+type routineResultBuffer struct {
+	rch rowContainerHelper
+
+	// typ is the return type of the set-returning routine.
+	typ *types.T
+
+	// multiColOutput is true if the routine returns multiple columns. In this
+	// case, each composite value added to the buffer is expanded into one
+	// column per element.
+	multiColOutput bool
+}
+
+func newRoutineResultBuffer(
+	ctx context.Context, p *planner, expr *tree.RoutineExpr,
+) *routineResultBuffer {
+	b := &routineResultBuffer{typ: expr.ResolvedType(), multiColOutput: expr.MultiColOutput}
+	typs := []*types.T{b.typ}
+	if b.multiColOutput {
+		typs = b.typ.TupleContents()
+	}
+	b.rch.Init(ctx, typs, p.ExtendedEvalContext(), "routine-result" /* opName */)
+	return b
+}
+
+// addValue adds a single value of the routine's return type to the buffer.
+func (b *routineResultBuffer) addValue(ctx context.Context, val tree.Datum) error {
+	if !b.multiColOutput {
+		return b.rch.AddRow(ctx, tree.Datums{val})
+	}
+	row := make(tree.Datums, len(b.typ.TupleContents()))
+	if val == tree.DNull {
+		// A NULL composite value is returned as a row of NULLs.
+		for i := range row {
+			row[i] = tree.DNull
+		}
+	} else {
+		tuple, ok := tree.AsDTuple(val)
+		if !ok {
+			return errors.AssertionFailedf("expected a tuple, got %T", val)
+		}
+		copy(row, tuple.D)
+	}
+	return b.rch.AddRow(ctx, row)
+}
+
+// addQueryRows adds the rows produced by a dynamic query to the buffer. The
+// result columns of the query must match the return type of the routine.
+func (b *routineResultBuffer) addQueryRows(
+	ctx context.Context, rows []tree.Datums, cols colinfo.ResultColumns,
+) error {
+	expected := []*types.T{b.typ}
+	isComposite := b.typ.Family() == types.TupleFamily
+	if isComposite {
+		expected = b.typ.TupleContents()
+	}
+	if len(cols) != len(expected) {
+		return errors.WithDetailf(
+			pgerror.New(pgcode.DatatypeMismatch, "structure of query does not match function result type"),
+			"Number of returned columns (%d) does not match expected column count (%d).",
+			len(cols), len(expected),
+		)
+	}
+	for i := range cols {
+		if !cols[i].Typ.Equivalent(expected[i]) {
+			return errors.WithDetailf(
+				pgerror.New(pgcode.DatatypeMismatch, "structure of query does not match function result type"),
+				"Returned type %s does not match expected type %s in column %d.",
+				cols[i].Typ.SQLStringForError(), expected[i].SQLStringForError(), i+1,
+			)
+		}
+	}
+	for _, row := range rows {
+		if isComposite && !b.multiColOutput {
+			row = tree.Datums{tree.NewDTuple(b.typ, row...)}
+		}
+		if err := b.rch.AddRow(ctx, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 var tailCallOptimizationEnabled = metamorphic.ConstantWithTestBool(
 	"tail-call-optimization-enabled",
 	true,
@@ -522,7 +733,9 @@ func (g *routineGenerator) SendDeferredRoutine(nestedRoutine *tree.RoutineExpr,
 	g.deferredRoutine.args = args
 }
 
-func (g *routineGenerator) newCursorHelper(plan *planComponents) (*plpgsqlCursorHelper, error) {
+func (g *routineGenerator) newCursorHelper(
+	planCols colinfo.ResultColumns, cursorSQL string,
+) (*plpgsqlCursorHelper, error) {
 	open := g.expr.CursorDeclaration
 	if open.NameArgIdx < 0 || open.NameArgIdx >= len(g.args) {
 		panic(errors.AssertionFailedf("unexpected name argument index: %d", open.NameArgIdx))
@@ -538,12 +751,11 @@ func (g *routineGenerator) newCursorHelper(plan *planComponents) (*plpgsqlCursor
 	}
 	// Use context.Background(), since the cursor can outlive the context in which
 	// it was created.
-	planCols := plan.main.planColumns()
 	cursorHelper := &plpgsqlCursorHelper{
 		ctx:        context.Background(),
 		cursorName: cursorName,
 		resultCols: make(colinfo.ResultColumns, len(planCols)),
-		cursorSql:  open.CursorSQL,
+		cursorSql:  cursorSQL,
 	}
 	copy(cursorHelper.resultCols, planCols)
 	mon := g.p.Mon()
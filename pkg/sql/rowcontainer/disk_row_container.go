@@ -149,6 +149,11 @@ func MakeDiskRowContainer(
 			return DiskRowContainer{}, unimplemented.NewWithIssueDetailf(
 				92165, "", "can't order by column type %s", t.SQLStringForError(),
 			)
+		case types.PointFamily, types.BoxFamily, types.LSegFamily, types.LineFamily,
+			types.PathFamily, types.PolygonFamily, types.CircleFamily:
+			return DiskRowContainer{}, unimplemented.NewWithIssueDetailf(
+				21286, "", "can't order by column type %s", t.SQLStringForError(),
+			)
 		case types.TupleFamily:
 			return DiskRowContainer{}, unimplemented.NewWithIssueDetailf(
 				49975, "", "can't spill column type %s to disk", t.SQLStringForError(),
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/rangeindex"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/rowencpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -623,7 +624,65 @@ func EncodeInvertedIndexKeys(
 	if !indexGeoConfig.IsEmpty() {
 		return EncodeGeoInvertedIndexTableKeys(ctx, val, keyPrefix, indexGeoConfig)
 	}
-	return EncodeInvertedIndexTableKeys(val, keyPrefix, index.GetVersion())
+	keys, err := EncodeInvertedIndexTableKeys(val, keyPrefix, index.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	return AppendInvertedIndexDocumentKey(index, keys, keyPrefix), nil
+}
+
+// AppendInvertedIndexDocumentKey appends the key of the document entry of a
+// row to the keys of the entries of its lexemes, if the inverted index stores
+// term statistics and the row has a non-empty TSVECTOR value. The document
+// entries are used to compute the number of documents and their average
+// length. See tsearch.EncodeInvertedIndexDocumentKey.
+func AppendInvertedIndexDocumentKey(
+	index catalog.Index, keys [][]byte, keyPrefix []byte,
+) [][]byte {
+	if !index.HasTermStatistics() || len(keys) == 0 {
+		return keys
+	}
+	return append(keys, tsearch.EncodeInvertedIndexDocumentKey(keyPrefix))
+}
+
+// InvertedIndexTermStatistics returns the term statistics which an inverted
+// index created with the term_statistics storage parameter stores for the
+// given TSVECTOR value: the frequency of each lexeme, in the same order as the
+// index keys of the value, and the length of the document.
+func InvertedIndexTermStatistics(val tree.Datum) (termFreqs []int, docLength int) {
+	if val == tree.DNull {
+		return nil, 0
+	}
+	return tsearch.TermStatistics(val.(*tree.DTSVector).TSVector)
+}
+
+// EncodeInvertedIndexStatistics returns the statistics which an inverted index
+// created with the term_statistics storage parameter stores in the value of
+// the i-th entry of a row, given the statistics returned by
+// InvertedIndexTermStatistics for the row. The entries of the lexemes store
+// the frequency of their lexeme and the length of the document, and the
+// document entry, which follows them, only stores the length.
+func EncodeInvertedIndexStatistics(termFreqs []int, docLength int, i int) []byte {
+	if i < len(termFreqs) {
+		return tsearch.EncodeTermStatistics(nil /* appendTo */, termFreqs[i], docLength)
+	}
+	return tsearch.EncodeDocumentStatistics(nil /* appendTo */, docLength)
+}
+
+// InvertedIndexStatisticsBytes returns the suffix of the value of an entry of
+// an inverted index created with the term_statistics storage parameter which
+// holds the statistics encoded by EncodeInvertedIndexStatistics.
+// numValueCols is the number of columns returned by GetValueColumns for the
+// index, which are encoded before the statistics.
+func InvertedIndexStatisticsBytes(value []byte, numValueCols int) ([]byte, error) {
+	for i := 0; i < numValueCols; i++ {
+		_, n, err := encoding.PeekValueLength(value)
+		if err != nil {
+			return nil, err
+		}
+		value = value[n:]
+	}
+	return value, nil
 }
 
 // EncodeInvertedIndexPrefixKeys encodes the non-inverted prefix columns if
@@ -693,6 +752,8 @@ func EncodeInvertedIndexTableKeys(
 		return encodeTrigramInvertedIndexTableKeys(string(*datum.(*tree.DString)), inKey, version, true /* pad */)
 	case types.TSVectorFamily:
 		return tsearch.EncodeInvertedIndexKeys(inKey, val.(*tree.DTSVector).TSVector)
+	case types.RangeFamily, types.MultirangeFamily:
+		return rangeindex.EncodeInvertedIndexKeys(inKey, datum)
 	}
 	return nil, errors.AssertionFailedf("trying to apply inverted index to unsupported type %s", datum.ResolvedType().SQLStringForError())
 }
@@ -720,6 +781,8 @@ func EncodeContainingInvertedIndexSpans(
 		return json.EncodeContainingInvertedIndexSpans(nil /* inKey */, val.(*tree.DJSON).JSON)
 	case types.ArrayFamily:
 		return encodeContainingArrayInvertedIndexSpans(val.(*tree.DArray), nil /* inKey */)
+	case types.RangeFamily, types.MultirangeFamily:
+		return rangeindex.ContainingSpans(datum)
 	default:
 		return nil, errors.AssertionFailedf(
 			"trying to apply inverted index to unsupported type %s", datum.ResolvedType().SQLStringForError(),
@@ -751,6 +814,8 @@ func EncodeContainedInvertedIndexSpans(
 		return encodeContainedArrayInvertedIndexSpans(val.(*tree.DArray), nil /* inKey */)
 	case types.JsonFamily:
 		return json.EncodeContainedInvertedIndexSpans(nil /* inKey */, val.(*tree.DJSON).JSON)
+	case types.RangeFamily, types.MultirangeFamily:
+		return rangeindex.ContainedSpans(datum)
 	default:
 		return nil, errors.AssertionFailedf(
 			"trying to apply inverted index to unsupported type %s", datum.ResolvedType().SQLStringForError(),
@@ -815,14 +880,14 @@ func EncodeExistsInvertedIndexSpans(
 
 // EncodeOverlapsInvertedIndexSpans returns the spans that must be scanned in
 // the inverted index to evaluate an overlaps (&&) predicate with the given
-// datum, which should be an Array. These spans should be used to find the
-// objects in the index that could overlap with the given array. In other
-// words, if we have a predicate x && y, this function should use the value of
-// y to find the spans to scan in an inverted index on x.
+// datum, which should be an Array, a range or a multirange. These spans should
+// be used to find the objects in the index that could overlap with the given
+// datum. In other words, if we have a predicate x && y, this function should
+// use the value of y to find the spans to scan in an inverted index on x.
 //
 // The spans are returned in an inverted.SpanExpression, which represents the
 // set operations that must be applied on the spans read during execution. The
-// span expression returned will be tight. See comments in the
+// span expression returned will be tight for arrays. See comments in the
 // SpanExpression definition for details.
 func EncodeOverlapsInvertedIndexSpans(
 	ctx context.Context, evalCtx *eval.Context, val tree.Datum,
@@ -834,6 +899,8 @@ func EncodeOverlapsInvertedIndexSpans(
 	switch val.ResolvedType().Family() {
 	case types.ArrayFamily:
 		return encodeOverlapsArrayInvertedIndexSpans(val.(*tree.DArray), nil /* inKey */)
+	case types.RangeFamily, types.MultirangeFamily:
+		return rangeindex.OverlapsSpans(datum)
 	default:
 		return nil, errors.AssertionFailedf(
 			"trying to apply inverted index to unsupported type %s", datum.ResolvedType().SQLStringForError(),
@@ -1349,10 +1416,20 @@ func EncodeSecondaryIndex(
 		return []IndexEntry{}, err
 	}
 
+	// An inverted index which stores term statistics appends the statistics of
+	// the lexeme of each entry to its value.
+	var termFreqs []int
+	var docLength int
+	if secondaryIndex.HasTermStatistics() {
+		termFreqs, docLength = InvertedIndexTermStatistics(
+			findColumnValue(secondaryIndex.InvertedColumnID(), colMap, values),
+		)
+	}
+
 	// entries is the resulting array that we will return. We allocate upfront at least
 	// len(secondaryKeys) positions to avoid allocations from appending.
 	entries := make([]IndexEntry, 0, len(secondaryKeys))
-	for _, key := range secondaryKeys {
+	for i, key := range secondaryKeys {
 		if !secondaryIndex.IsUnique() || containsNull {
 			// If the index is not unique or it contains a NULL value, append
 			// extraKey to the key in order to make it unique.
@@ -1364,7 +1441,13 @@ func EncodeSecondaryIndex(
 			secondaryIndex.GetVersion() == descpb.BaseIndexFormatVersion {
 			// We do all computation that affects indexes with families in a separate code path to avoid performance
 			// regression for tables without column families.
-			entry, err := encodeSecondaryIndexNoFamilies(secondaryIndex, colMap, key, values, extraKey)
+			var termStats []byte
+			if termFreqs != nil {
+				termStats = EncodeInvertedIndexStatistics(termFreqs, docLength, i)
+			}
+			entry, err := encodeSecondaryIndexNoFamilies(
+				secondaryIndex, colMap, key, values, extraKey, termStats,
+			)
 			if err != nil {
 				return []IndexEntry{}, err
 			}
@@ -1518,6 +1601,7 @@ func encodeSecondaryIndexNoFamilies(
 	key []byte,
 	row []tree.Datum,
 	extraKeyCols []byte,
+	termStats []byte,
 ) (IndexEntry, error) {
 	var (
 		value []byte
@@ -1542,6 +1626,7 @@ func encodeSecondaryIndexNoFamilies(
 	if err != nil {
 		return IndexEntry{}, err
 	}
+	value = append(value, termStats...)
 	entry := IndexEntry{Key: key, Family: 0}
 	entry.Value.SetBytes(value)
 	return entry, nil
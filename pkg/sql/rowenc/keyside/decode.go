@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/macaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -44,6 +45,10 @@ func Decode(
 	switch valType.Family() {
 	case types.ArrayFamily:
 		return decodeArrayKey(a, valType, key, dir)
+	case types.RangeFamily:
+		return decodeRangeKey(a, valType, key, dir)
+	case types.MultirangeFamily:
+		return decodeMultirangeKey(a, valType, key, dir)
 	case types.BitFamily:
 		var r bitarray.BitArray
 		if dir == encoding.Ascending {
@@ -78,6 +83,30 @@ func Decode(
 			rkey, i, err = encoding.DecodeUvarintDescending(key)
 		}
 		return a.NewDPGLSN(tree.DPGLSN{LSN: lsn.LSN(i)}), rkey, err
+	case types.MACAddrFamily:
+		var i uint64
+		if dir == encoding.Ascending {
+			rkey, i, err = encoding.DecodeUvarintAscending(key)
+		} else {
+			rkey, i, err = encoding.DecodeUvarintDescending(key)
+		}
+		return tree.NewDMACAddr(macaddr.MACAddr(i)), rkey, err
+	case types.MACAddr8Family:
+		var i uint64
+		if dir == encoding.Ascending {
+			rkey, i, err = encoding.DecodeUvarintAscending(key)
+		} else {
+			rkey, i, err = encoding.DecodeUvarintDescending(key)
+		}
+		return tree.NewDMACAddr8(macaddr.MACAddr8(i)), rkey, err
+	case types.MoneyFamily:
+		var i int64
+		if dir == encoding.Ascending {
+			rkey, i, err = encoding.DecodeVarintAscending(key)
+		} else {
+			rkey, i, err = encoding.DecodeVarintDescending(key)
+		}
+		return tree.NewDMoney(tree.DMoney(i)), rkey, err
 	case types.RefCursorFamily:
 		var r string
 		if dir == encoding.Ascending {
@@ -259,6 +288,9 @@ func Decode(
 		}
 		var ipAddr ipaddr.IPAddr
 		_, err := ipAddr.FromBuffer(r)
+		if valType.Oid() == oid.T_cidr {
+			return a.NewDCIDR(tree.DIPAddr{IPAddr: ipAddr}), rkey, err
+		}
 		return a.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr}), rkey, err
 	case types.OidFamily:
 		// TODO: This possibly should use DecodeUint32 (with corresponding changes
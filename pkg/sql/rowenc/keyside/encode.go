@@ -77,6 +77,21 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 			return encoding.EncodeUvarintAscending(b, uint64(t.LSN)), nil
 		}
 		return encoding.EncodeUvarintDescending(b, uint64(t.LSN)), nil
+	case *tree.DMACAddr:
+		if dir == encoding.Ascending {
+			return encoding.EncodeUvarintAscending(b, uint64(t.MACAddr)), nil
+		}
+		return encoding.EncodeUvarintDescending(b, uint64(t.MACAddr)), nil
+	case *tree.DMACAddr8:
+		if dir == encoding.Ascending {
+			return encoding.EncodeUvarintAscending(b, uint64(t.MACAddr8)), nil
+		}
+		return encoding.EncodeUvarintDescending(b, uint64(t.MACAddr8)), nil
+	case *tree.DMoney:
+		if dir == encoding.Ascending {
+			return encoding.EncodeVarintAscending(b, int64(*t)), nil
+		}
+		return encoding.EncodeVarintDescending(b, int64(*t)), nil
 	case *tree.DBox2D:
 		if dir == encoding.Ascending {
 			return encoding.EncodeBox2DAscending(b, t.CartesianBoundingBox.BoundingBox)
@@ -156,6 +171,10 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 		return b, nil
 	case *tree.DArray:
 		return encodeArrayKey(b, t, dir)
+	case *tree.DRange:
+		return encodeRangeKey(b, t, dir)
+	case *tree.DMultirange:
+		return encodeMultirangeKey(b, t, dir)
 	case *tree.DCollatedString:
 		if dir == encoding.Ascending {
 			return encoding.EncodeBytesAscending(b, t.Key), nil
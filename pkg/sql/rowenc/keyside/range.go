@@ -0,0 +1,179 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The markers of the key encoding of the bounds of a range. An infinite lower
+// bound sorts before all the finite ones, and an infinite upper bound sorts
+// after them.
+const (
+	rangeEmptyMarker         = 0
+	rangeNonEmptyMarker      = 1
+	rangeLowerInfiniteMarker = 0
+	rangeFiniteMarker        = 1
+	rangeUpperInfiniteMarker = 2
+)
+
+// encodeRangeKey generates an ordered key encoding of a range. The bounds of
+// the range are encoded in ascending order and wrapped in a bytes encoding,
+// which preserves their order in both directions and allows the key to be
+// skipped without knowing its type:
+//
+//	empty:     [0]
+//	non-empty: [1, lower, upper]
+//
+// where an infinite lower bound is [0], an infinite upper bound is [2], and a
+// finite bound is [1, enc(val), inc]. Inclusive lower bounds sort before
+// exclusive ones, and exclusive upper bounds sort before inclusive ones, which
+// matches the ordering of ranges.
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	inner, err := appendRangeKeyContents(nil, r)
+	if err != nil {
+		return nil, err
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, inner), nil
+	}
+	return encoding.EncodeBytesDescending(b, inner), nil
+}
+
+// encodeMultirangeKey generates an ordered key encoding of a multirange,
+// which is the concatenation of the encodings of its ranges wrapped in a bytes
+// encoding. A multirange which is a prefix of another sorts first.
+func encodeMultirangeKey(b []byte, m *tree.DMultirange, dir encoding.Direction) ([]byte, error) {
+	var inner []byte
+	for _, r := range m.Ranges {
+		var err error
+		if inner, err = appendRangeKeyContents(inner, r); err != nil {
+			return nil, err
+		}
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, inner), nil
+	}
+	return encoding.EncodeBytesDescending(b, inner), nil
+}
+
+func appendRangeKeyContents(b []byte, r *tree.DRange) ([]byte, error) {
+	if r.Empty {
+		return encoding.EncodeVarintAscending(b, rangeEmptyMarker), nil
+	}
+	b = encoding.EncodeVarintAscending(b, rangeNonEmptyMarker)
+	var err error
+	if r.Lower == nil {
+		b = encoding.EncodeVarintAscending(b, rangeLowerInfiniteMarker)
+	} else {
+		b = encoding.EncodeVarintAscending(b, rangeFiniteMarker)
+		if b, err = Encode(b, r.Lower, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		b = encoding.EncodeVarintAscending(b, boolToInt64(!r.LowerInc))
+	}
+	if r.Upper == nil {
+		b = encoding.EncodeVarintAscending(b, rangeUpperInfiniteMarker)
+	} else {
+		b = encoding.EncodeVarintAscending(b, rangeFiniteMarker)
+		if b, err = Encode(b, r.Upper, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		b = encoding.EncodeVarintAscending(b, boolToInt64(r.UpperInc))
+	}
+	return b, nil
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	rkey, inner, err := decodeRangeKeyBytes(key, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, inner, err := decodeRangeKeyContents(a, t, inner)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(inner) != 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (trailing bytes)")
+	}
+	return r, rkey, nil
+}
+
+// decodeMultirangeKey decodes a multirange key generated by
+// encodeMultirangeKey.
+func decodeMultirangeKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	rkey, inner, err := decodeRangeKeyBytes(key, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	var ranges []*tree.DRange
+	for len(inner) > 0 {
+		var r *tree.DRange
+		if r, inner, err = decodeRangeKeyContents(a, t.MultirangeContents(), inner); err != nil {
+			return nil, nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	return tree.NewDMultirange(t, ranges), rkey, nil
+}
+
+func decodeRangeKeyBytes(key []byte, dir encoding.Direction) (rkey, inner []byte, err error) {
+	if dir == encoding.Ascending {
+		return encoding.DecodeBytesAscending(key, nil)
+	}
+	return encoding.DecodeBytesDescending(key, nil)
+}
+
+func decodeRangeKeyContents(
+	a *tree.DatumAlloc, t *types.T, b []byte,
+) (*tree.DRange, []byte, error) {
+	b, marker, err := encoding.DecodeVarintAscending(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	if marker == rangeEmptyMarker {
+		return tree.NewDEmptyRange(t), b, nil
+	}
+	var lower, upper tree.Datum
+	var lowerInc, upperInc bool
+	for _, isLower := range []bool{true, false} {
+		if b, marker, err = encoding.DecodeVarintAscending(b); err != nil {
+			return nil, nil, err
+		}
+		if marker != rangeFiniteMarker {
+			continue
+		}
+		var val tree.Datum
+		if val, b, err = Decode(a, t.RangeContents(), b, encoding.Ascending); err != nil {
+			return nil, nil, err
+		}
+		var flag int64
+		if b, flag, err = encoding.DecodeVarintAscending(b); err != nil {
+			return nil, nil, err
+		}
+		if isLower {
+			lower, lowerInc = val, flag == 0
+		} else {
+			upper, upperInc = val, flag == 1
+		}
+	}
+	return tree.NewDRange(t, lower, upper, lowerInc, upperInc), b, nil
+}
@@ -0,0 +1,311 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package rangeindex implements inverted indexes on range and multirange
+// columns.
+//
+// The bounds of a range are mapped in an order-preserving way onto the
+// unsigned 64-bit integers, and the range is covered by a small number of
+// dyadic cells in that domain, which are the inverted index keys of the
+// range. Like S2 cells, a cell at level L contains all the values which share
+// its top L bits, and its ID has the bit after these set, so the IDs of all
+// the descendants of a cell form a contiguous span around the ID of the cell.
+// Two ranges can only overlap if one of the cells covering one of them is an
+// ancestor or a descendant of one of the cells covering the other, so the
+// index can be used to find the ranges which may overlap, contain or be
+// contained by a given range. The spans it produces are never tight, and the
+// original predicate must be applied to the results.
+package rangeindex
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// maxCells is the maximum number of cells used to cover a range.
+const maxCells = 4
+
+// maxLevel is the finest level of the cells. Cells at this level contain two
+// values, which keeps the ID of every cell within 64 bits.
+const maxLevel = 63
+
+// emptyCellID is the ID of the key of empty ranges and multiranges, which
+// contain no values. It is not the ID of any cell.
+const emptyCellID = 0
+
+// cellID is the ID of a cell at some level, which is its smallest value with
+// the bit after its level set.
+type cellID uint64
+
+// lsb returns the lowest set bit of the cell ID, which determines its level.
+func (c cellID) lsb() uint64 {
+	return uint64(c) & -uint64(c)
+}
+
+// rangeMin returns the smallest ID of the descendants of the cell.
+func (c cellID) rangeMin() cellID {
+	return c - cellID(c.lsb()-1)
+}
+
+// rangeMax returns the largest ID of the descendants of the cell.
+func (c cellID) rangeMax() cellID {
+	return c + cellID(c.lsb()-1)
+}
+
+// parent returns the cell at the previous level which contains the cell.
+func (c cellID) parent() cellID {
+	lsb := c.lsb() << 1
+	return cellID((uint64(c) & -lsb) | lsb)
+}
+
+// isRoot returns true if the cell is at level 0, and so contains every value.
+func (c cellID) isRoot() bool {
+	return uint64(c) == 1<<63
+}
+
+// makeCellID returns the ID of the cell at the given level which contains u.
+func makeCellID(u uint64, level int) cellID {
+	lsb := uint64(1) << (63 - level)
+	return cellID((u & -(lsb << 1)) | lsb)
+}
+
+// interval is a non-empty interval [lo, hi] of the domain of the cells.
+type interval struct {
+	lo, hi uint64
+}
+
+// boundValue maps a finite bound of a range onto the domain of the cells.
+// The mapping preserves the order of the bounds, but may map distinct bounds
+// to the same value.
+func boundValue(d tree.Datum) (uint64, error) {
+	var v int64
+	switch t := tree.UnwrapDOidWrapper(d).(type) {
+	case *tree.DInt:
+		v = int64(*t)
+	case *tree.DDate:
+		v = t.UnixEpochDays()
+	case *tree.DTimestamp:
+		v = t.UnixMicro()
+	case *tree.DTimestampTZ:
+		v = t.UnixMicro()
+	case *tree.DDecimal:
+		f, err := t.Float64()
+		if err != nil && !math.IsInf(f, 0) {
+			return 0, err
+		}
+		if math.IsNaN(f) {
+			// NaN sorts after all other numbers.
+			return math.MaxUint64, nil
+		}
+		// Flip the sign bit of positive floats and all the bits of negative
+		// ones, which makes the order of their bits match their numeric order.
+		bits := math.Float64bits(f)
+		if bits&(1<<63) != 0 {
+			return ^bits, nil
+		}
+		return bits | 1<<63, nil
+	default:
+		return 0, errors.AssertionFailedf("unsupported range bound type %s", d.ResolvedType())
+	}
+	return uint64(v) ^ 1<<63, nil
+}
+
+// rangeInterval returns the interval of the domain which contains all the
+// values of the given non-empty range. Infinite bounds extend the interval to
+// the ends of the domain, and the inclusivity of the bounds is ignored.
+func rangeInterval(r *tree.DRange) (interval, error) {
+	i := interval{lo: 0, hi: math.MaxUint64}
+	var err error
+	if r.Lower != nil {
+		if i.lo, err = boundValue(r.Lower); err != nil {
+			return interval{}, err
+		}
+	}
+	if r.Upper != nil {
+		if i.hi, err = boundValue(r.Upper); err != nil {
+			return interval{}, err
+		}
+	}
+	return i, nil
+}
+
+// covering returns the cells which cover the interval. All the cells are at
+// the finest level at which the interval is covered by at most maxCells cells.
+func covering(i interval) []cellID {
+	level := maxLevel
+	for ; level > 0; level-- {
+		shift := uint(64 - level)
+		if (i.hi>>shift)-(i.lo>>shift) < maxCells {
+			break
+		}
+	}
+	var cells []cellID
+	last := makeCellID(i.hi, level)
+	for c := makeCellID(i.lo, level); ; c += cellID(c.lsb() << 1) {
+		cells = append(cells, c)
+		if c == last {
+			return cells
+		}
+	}
+}
+
+// intervals returns the intervals of the domain which contain the values of
+// the given range or multirange datum. It returns no intervals if the datum is
+// empty.
+func intervals(d tree.Datum) ([]interval, error) {
+	var ranges []*tree.DRange
+	switch t := tree.UnwrapDOidWrapper(d).(type) {
+	case *tree.DRange:
+		ranges = []*tree.DRange{t}
+	case *tree.DMultirange:
+		ranges = t.Ranges
+	default:
+		return nil, errors.AssertionFailedf("unsupported range index type %s", d.ResolvedType())
+	}
+	res := make([]interval, 0, len(ranges))
+	for _, r := range ranges {
+		if r.Empty {
+			continue
+		}
+		i, err := rangeInterval(r)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, i)
+	}
+	return res, nil
+}
+
+func encodeCell(inKey []byte, c cellID) []byte {
+	return encoding.EncodeUvarintAscending(inKey, uint64(c))
+}
+
+// EncodeInvertedIndexKeys returns the inverted index keys of the given range
+// or multirange datum, each prefixed by inKey. Empty ranges and multiranges
+// have a single key which is not shared with any other values.
+func EncodeInvertedIndexKeys(inKey []byte, d tree.Datum) ([][]byte, error) {
+	is, err := intervals(d)
+	if err != nil {
+		return nil, err
+	}
+	if len(is) == 0 {
+		return [][]byte{encodeCell(inKey, emptyCellID)}, nil
+	}
+	var cells []cellID
+	for _, i := range is {
+		cells = append(cells, covering(i)...)
+	}
+	// The cells of adjacent ranges of a multirange may be shared.
+	sort.Slice(cells, func(i, j int) bool { return cells[i] < cells[j] })
+	keys := make([][]byte, 0, len(cells))
+	for i, c := range cells {
+		if i > 0 && cells[i-1] == c {
+			continue
+		}
+		// Make sure that each key has its own copy of the prefix.
+		keys = append(keys, encodeCell(inKey[:len(inKey):len(inKey)], c))
+	}
+	return keys, nil
+}
+
+// overlappingSpans returns the spans of the keys of the values which may
+// overlap the interval.
+func overlappingSpans(i interval) inverted.Spans {
+	var spans inverted.Spans
+	for _, c := range covering(i) {
+		// The descendants of the cell, including the cell itself.
+		end := c.rangeMax()
+		span := inverted.Span{Start: encodeCell(nil, c.rangeMin())}
+		if end < math.MaxUint64 {
+			span.End = encodeCell(nil, end+1)
+		} else {
+			span.End = inverted.MakeSingleValSpan(encodeCell(nil, end)).End
+		}
+		spans = append(spans, span)
+		// The ancestors of the cell.
+		for a := c; !a.isRoot(); {
+			a = a.parent()
+			spans = append(spans, inverted.MakeSingleValSpan(encodeCell(nil, a)))
+		}
+	}
+	return spans
+}
+
+// spansExpr returns a non-tight expression which is the union of the spans.
+func spansExpr(spans inverted.Spans) inverted.Expression {
+	var expr inverted.Expression
+	for _, span := range spans {
+		spanExpr := inverted.ExprForSpan(span, false /* tight */)
+		if expr == nil {
+			expr = spanExpr
+		} else {
+			expr = inverted.Or(expr, spanExpr)
+		}
+	}
+	return expr
+}
+
+// OverlapsSpans returns the inverted expression which finds the values in the
+// index which may overlap the given range or multirange datum, as in
+// x && val. The datum must not be empty, since no values overlap it.
+func OverlapsSpans(d tree.Datum) (inverted.Expression, error) {
+	is, err := intervals(d)
+	if err != nil {
+		return nil, err
+	}
+	if len(is) == 0 {
+		return inverted.NonInvertedColExpression{}, nil
+	}
+	var spans inverted.Spans
+	for _, i := range is {
+		spans = append(spans, overlappingSpans(i)...)
+	}
+	return spansExpr(spans), nil
+}
+
+// ContainingSpans returns the inverted expression which finds the values in
+// the index which may contain the given range or multirange datum, as in
+// x @> val. Since every value contains an empty one, the index cannot be used
+// if the datum is empty.
+func ContainingSpans(d tree.Datum) (inverted.Expression, error) {
+	is, err := intervals(d)
+	if err != nil {
+		return nil, err
+	}
+	if len(is) == 0 {
+		return inverted.NonInvertedColExpression{}, nil
+	}
+	// A value which contains the datum overlaps each of its ranges.
+	var expr inverted.Expression
+	for _, i := range is {
+		spanExpr := spansExpr(overlappingSpans(i))
+		if expr == nil {
+			expr = spanExpr
+		} else {
+			expr = inverted.And(expr, spanExpr)
+		}
+	}
+	return expr, nil
+}
+
+// ContainedSpans returns the inverted expression which finds the values in
+// the index which may be contained by the given range or multirange datum, as
+// in x <@ val. These are the empty values and the values which overlap it.
+func ContainedSpans(d tree.Datum) (inverted.Expression, error) {
+	is, err := intervals(d)
+	if err != nil {
+		return nil, err
+	}
+	spans := inverted.Spans{inverted.MakeSingleValSpan(encodeCell(nil, emptyCellID))}
+	for _, i := range is {
+		spans = append(spans, overlappingSpans(i)...)
+	}
+	return spansExpr(spans), nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package rangeindex
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCellID(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	root := makeCellID(12345, 0)
+	require.True(t, root.isRoot())
+	require.Equal(t, cellID(1), root.rangeMin())
+	require.Equal(t, cellID(1<<64-1), root.rangeMax())
+
+	for _, u := range []uint64{0, 1, 12345, 1 << 63, 1<<64 - 1} {
+		c := makeCellID(u, maxLevel)
+		for level := maxLevel - 1; level >= 0; level-- {
+			p := c.parent()
+			require.Equal(t, makeCellID(u, level), p)
+			require.True(t, p.rangeMin() <= c.rangeMin() && c.rangeMax() <= p.rangeMax())
+			c = p
+		}
+		require.True(t, c.isRoot())
+	}
+}
+
+// TestSpans checks that the spans of each predicate find every range which
+// satisfies it.
+func TestSpans(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	rng, _ := randutil.NewTestRand()
+	makeRange := func() (*tree.DRange, int64, int64) {
+		if rng.Intn(10) == 0 {
+			return tree.NewDEmptyRange(types.Int8Range), 1, 0
+		}
+		// Use a mix of small and large ranges.
+		lo := rng.Int63n(1000) - 500
+		hi := lo + rng.Int63n(1+rng.Int63n(1000))
+		return tree.NewDRange(
+			types.Int8Range, tree.NewDInt(tree.DInt(lo)), tree.NewDInt(tree.DInt(hi)), true, true,
+		), lo, hi
+	}
+	containsKeys := func(expr inverted.Expression, keys [][]byte) bool {
+		spanExpr, ok := expr.(*inverted.SpanExpression)
+		require.True(t, ok)
+		require.False(t, spanExpr.IsTight())
+		res, err := spanExpr.ContainsKeys(keys)
+		require.NoError(t, err)
+		return res
+	}
+
+	for i := 0; i < 1000; i++ {
+		r, rlo, rhi := makeRange()
+		q, qlo, qhi := makeRange()
+		keys, err := EncodeInvertedIndexKeys(nil /* inKey */, r)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(keys), maxCells)
+		rEmpty, qEmpty := rlo > rhi, qlo > qhi
+
+		if !qEmpty {
+			overlaps, err := OverlapsSpans(q)
+			require.NoError(t, err)
+			if !rEmpty && rlo <= qhi && qlo <= rhi {
+				require.True(t, containsKeys(overlaps, keys), "%s && %s", r, q)
+			}
+
+			containing, err := ContainingSpans(q)
+			require.NoError(t, err)
+			if !rEmpty && rlo <= qlo && qhi <= rhi {
+				require.True(t, containsKeys(containing, keys), "%s @> %s", r, q)
+			}
+		}
+
+		contained, err := ContainedSpans(q)
+		require.NoError(t, err)
+		if rEmpty || (!qEmpty && qlo <= rlo && rhi <= qhi) {
+			require.True(t, containsKeys(contained, keys), "%s <@ %s", r, q)
+		}
+	}
+}
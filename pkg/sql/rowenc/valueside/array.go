@@ -224,7 +224,7 @@ func DatumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 		return encoding.True, nil
 	case types.BitFamily:
 		return encoding.BitArray, nil
-	case types.PGLSNFamily:
+	case types.PGLSNFamily, types.MACAddrFamily, types.MACAddr8Family, types.MoneyFamily:
 		return encoding.Int, nil
 	case types.UuidFamily:
 		return encoding.UUID, nil
@@ -280,6 +280,12 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 		return encoding.EncodeUntaggedIntValue(b, t.UnixEpochDaysWithOrig()), nil
 	case *tree.DPGLSN:
 		return encoding.EncodeUntaggedIntValue(b, int64(t.LSN)), nil
+	case *tree.DMACAddr:
+		return encoding.EncodeUntaggedIntValue(b, int64(t.MACAddr)), nil
+	case *tree.DMACAddr8:
+		return encoding.EncodeUntaggedIntValue(b, int64(t.MACAddr8)), nil
+	case *tree.DMoney:
+		return encoding.EncodeUntaggedIntValue(b, int64(*t)), nil
 	case *tree.DBox2D:
 		return encoding.EncodeUntaggedBox2DValue(b, t.CartesianBoundingBox.BoundingBox)
 	case *tree.DGeography:
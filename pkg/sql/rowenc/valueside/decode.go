@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/macaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/vector"
@@ -119,6 +120,24 @@ func DecodeUntaggedDatum(
 			return nil, b, err
 		}
 		return a.NewDPGLSN(tree.DPGLSN{LSN: lsn.LSN(data)}), b, nil
+	case types.MACAddrFamily:
+		b, data, err := encoding.DecodeUntaggedIntValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		return tree.NewDMACAddr(macaddr.MACAddr(data)), b, nil
+	case types.MACAddr8Family:
+		b, data, err := encoding.DecodeUntaggedIntValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		return tree.NewDMACAddr8(macaddr.MACAddr8(data)), b, nil
+	case types.MoneyFamily:
+		b, data, err := encoding.DecodeUntaggedIntValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		return tree.NewDMoney(tree.DMoney(data)), b, nil
 	case types.RefCursorFamily:
 		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
 		if err != nil {
@@ -183,6 +202,9 @@ func DecodeUntaggedDatum(
 		return a.NewDUuid(tree.DUuid{UUID: data}), b, err
 	case types.INetFamily:
 		b, data, err := encoding.DecodeUntaggedIPAddrValue(buf)
+		if t.Oid() == oid.T_cidr {
+			return a.NewDCIDR(tree.DIPAddr{IPAddr: data}), b, err
+		}
 		return a.NewDIPAddr(tree.DIPAddr{IPAddr: data}), b, err
 	case types.JsonFamily:
 		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
@@ -199,6 +221,27 @@ func DecodeUntaggedDatum(
 			return nil, b, err
 		}
 		return a.NewDJSON(tree.DJSON{JSON: j}), b, nil
+	case types.JsonpathFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		p, err := tree.ParseDJsonpath(string(data))
+		if err != nil {
+			return nil, b, err
+		}
+		return p, b, nil
+	case types.PointFamily, types.BoxFamily, types.LSegFamily, types.LineFamily,
+		types.PathFamily, types.PolygonFamily, types.CircleFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		d, err := decodeGeometric(t, data)
+		if err != nil {
+			return nil, b, err
+		}
+		return d, b, nil
 	case types.TSQueryFamily:
 		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
 		if err != nil {
@@ -246,6 +289,26 @@ func DecodeUntaggedDatum(
 		return decodeArray(a, t, b)
 	case types.TupleFamily:
 		return decodeTuple(a, t, buf)
+	case types.RangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		r, _, err := decodeRange(a, t, data)
+		if err != nil {
+			return nil, b, err
+		}
+		return r, b, nil
+	case types.MultirangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		m, _, err := decodeMultirange(a, t, data)
+		if err != nil {
+			return nil, b, err
+		}
+		return m, b, nil
 	case types.EnumFamily:
 		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
 		if err != nil {
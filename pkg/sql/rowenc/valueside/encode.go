@@ -54,6 +54,12 @@ func Encode(appendTo []byte, colID ColumnIDDelta, val tree.Datum, scratch []byte
 		return encoding.EncodeIntValue(appendTo, uint32(colID), t.UnixEpochDaysWithOrig()), nil
 	case *tree.DPGLSN:
 		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(t.LSN)), nil
+	case *tree.DMACAddr:
+		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(t.MACAddr)), nil
+	case *tree.DMACAddr8:
+		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(t.MACAddr8)), nil
+	case *tree.DMoney:
+		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(*t)), nil
 	case *tree.DBox2D:
 		return encoding.EncodeBox2DValue(appendTo, uint32(colID), t.CartesianBoundingBox.BoundingBox)
 	case *tree.DGeography:
@@ -80,6 +86,15 @@ func Encode(appendTo []byte, colID ColumnIDDelta, val tree.Datum, scratch []byte
 			return nil, err
 		}
 		return encoding.EncodeJSONValue(appendTo, uint32(colID), encoded), nil
+	case *tree.DJsonpath:
+		// Jsonpath values are stored in their text representation.
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), []byte(t.Jsonpath.String())), nil
+	case *tree.DPoint, *tree.DBox, *tree.DLSeg, *tree.DLine, *tree.DPath, *tree.DPolygon, *tree.DCircle:
+		encoded, err := encodeGeometric(scratch, t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), encoded), nil
 	case *tree.DTSQuery:
 		encoded, err := tsearch.EncodeTSQuery(scratch, t.TSQuery)
 		if err != nil {
@@ -104,6 +119,18 @@ func Encode(appendTo []byte, colID ColumnIDDelta, val tree.Datum, scratch []byte
 			return nil, err
 		}
 		return encoding.EncodeArrayValue(appendTo, uint32(colID), a), nil
+	case *tree.DRange:
+		r, err := encodeRange(scratch, t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), r), nil
+	case *tree.DMultirange:
+		m, err := encodeMultirange(scratch, t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), m), nil
 	case *tree.DTuple:
 		return encodeTuple(t, appendTo, uint32(colID), scratch)
 	case *tree.DCollatedString:
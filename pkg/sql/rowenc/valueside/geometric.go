@@ -0,0 +1,85 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/geometric"
+	"github.com/cockroachdb/errors"
+)
+
+// encodeGeometric appends the encoding of a geometric datum to b. Geometric
+// values are stored in the Postgres binary format of their type.
+func encodeGeometric(b []byte, d tree.Datum) ([]byte, error) {
+	switch t := d.(type) {
+	case *tree.DPoint:
+		return geometric.EncodePoint(b, t.Point), nil
+	case *tree.DBox:
+		return geometric.EncodeBox(b, t.Box), nil
+	case *tree.DLSeg:
+		return geometric.EncodeLSeg(b, t.LSeg), nil
+	case *tree.DLine:
+		return geometric.EncodeLine(b, t.Line), nil
+	case *tree.DPath:
+		return geometric.EncodePath(b, t.Path), nil
+	case *tree.DPolygon:
+		return geometric.EncodePolygon(b, t.Polygon), nil
+	case *tree.DCircle:
+		return geometric.EncodeCircle(b, t.Circle), nil
+	}
+	return nil, errors.AssertionFailedf("unexpected geometric datum %T", d)
+}
+
+// decodeGeometric decodes a geometric datum of the given type produced by
+// encodeGeometric.
+func decodeGeometric(typ *types.T, b []byte) (tree.Datum, error) {
+	switch typ.Family() {
+	case types.PointFamily:
+		v, err := geometric.DecodePoint(b)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDPoint(v), nil
+	case types.BoxFamily:
+		v, err := geometric.DecodeBox(b)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDBox(v), nil
+	case types.LSegFamily:
+		v, err := geometric.DecodeLSeg(b)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDLSeg(v), nil
+	case types.LineFamily:
+		v, err := geometric.DecodeLine(b)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDLine(v), nil
+	case types.PathFamily:
+		v, err := geometric.DecodePath(b)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDPath(v), nil
+	case types.PolygonFamily:
+		v, err := geometric.DecodePolygon(b)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDPolygon(v), nil
+	case types.CircleFamily:
+		v, err := geometric.DecodeCircle(b)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDCircle(v), nil
+	}
+	return nil, errors.AssertionFailedf("unexpected geometric type %s", typ.SQLStringForError())
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/macaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -89,6 +90,21 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetInt(int64(v.LSN))
 			return r, nil
 		}
+	case types.MACAddrFamily:
+		if v, ok := val.(*tree.DMACAddr); ok {
+			r.SetInt(int64(v.MACAddr))
+			return r, nil
+		}
+	case types.MACAddr8Family:
+		if v, ok := val.(*tree.DMACAddr8); ok {
+			r.SetInt(int64(v.MACAddr8))
+			return r, nil
+		}
+	case types.MoneyFamily:
+		if v, ok := val.(*tree.DMoney); ok {
+			r.SetInt(int64(*v))
+			return r, nil
+		}
 	case types.RefCursorFamily:
 		if v, ok := tree.AsDString(val); ok {
 			r.SetString(string(v))
@@ -135,7 +151,7 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			return r, nil
 		}
 	case types.INetFamily:
-		if v, ok := val.(*tree.DIPAddr); ok {
+		if v, ok := tree.AsDIPAddr(val); ok {
 			data := v.ToBuffer(nil)
 			r.SetBytes(data)
 			return r, nil
@@ -149,6 +165,21 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(data)
 			return r, nil
 		}
+	case types.JsonpathFamily:
+		if v, ok := val.(*tree.DJsonpath); ok {
+			r.SetBytes([]byte(v.Jsonpath.String()))
+			return r, nil
+		}
+	case types.PointFamily, types.BoxFamily, types.LSegFamily, types.LineFamily,
+		types.PathFamily, types.PolygonFamily, types.CircleFamily:
+		if val.ResolvedType().Family() == colType.Family() {
+			data, err := encodeGeometric(nil, val)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(data)
+			return r, nil
+		}
 	case types.TSQueryFamily:
 		if v, ok := val.(*tree.DTSQuery); ok {
 			data := tsearch.EncodeTSQueryPGBinary(nil, v.TSQuery)
@@ -185,6 +216,24 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(b)
 			return r, nil
 		}
+	case types.RangeFamily:
+		if v, ok := val.(*tree.DRange); ok {
+			b, err := encodeRange(nil, v)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(b)
+			return r, nil
+		}
+	case types.MultirangeFamily:
+		if v, ok := val.(*tree.DMultirange); ok {
+			b, err := encodeMultirange(nil, v)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(b)
+			return r, nil
+		}
 	case types.TupleFamily:
 		if v, ok := val.(*tree.DTuple); ok {
 			b, err := encodeUntaggedTuple(v, nil /* appendTo */, 0 /* colID */, nil /* scratch */)
@@ -259,6 +308,24 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return a.NewDPGLSN(tree.DPGLSN{LSN: lsn.LSN(v)}), nil
+	case types.MACAddrFamily:
+		v, err := value.GetInt()
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDMACAddr(macaddr.MACAddr(v)), nil
+	case types.MACAddr8Family:
+		v, err := value.GetInt()
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDMACAddr8(macaddr.MACAddr8(v)), nil
+	case types.MoneyFamily:
+		v, err := value.GetInt()
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDMoney(tree.DMoney(v)), nil
 	case types.RefCursorFamily:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -375,6 +442,9 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 		if err != nil {
 			return nil, err
 		}
+		if typ.Oid() == oid.T_cidr {
+			return a.NewDCIDR(tree.DIPAddr{IPAddr: ipAddr}), nil
+		}
 		return a.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr}), nil
 	case types.OidFamily:
 		v, err := value.GetInt()
@@ -397,6 +467,20 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 		}
 		datum, _, err := decodeTuple(a, typ, v)
 		return datum, err
+	case types.RangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		datum, _, err := decodeRange(a, typ, v)
+		return datum, err
+	case types.MultirangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		datum, _, err := decodeMultirange(a, typ, v)
+		return datum, err
 	case types.JsonFamily:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -407,6 +491,19 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return tree.NewDJSON(jsonDatum), nil
+	case types.JsonpathFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return tree.ParseDJsonpath(string(v))
+	case types.PointFamily, types.BoxFamily, types.LSegFamily, types.LineFamily,
+		types.PathFamily, types.PolygonFamily, types.CircleFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return decodeGeometric(typ, v)
 	case types.TSQueryFamily:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -0,0 +1,112 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The flags of the value encoding of a range, which match the flags of the
+// Postgres binary format of ranges.
+const (
+	rangeEmptyFlag    = 0x01
+	rangeLowerIncFlag = 0x02
+	rangeUpperIncFlag = 0x04
+	rangeLowerInfFlag = 0x08
+	rangeUpperInfFlag = 0x10
+)
+
+// encodeRange produces the value encoding of a range, which is a flags byte
+// followed by the value encodings of its finite bounds.
+func encodeRange(b []byte, r *tree.DRange) ([]byte, error) {
+	var flags byte
+	switch {
+	case r.Empty:
+		flags = rangeEmptyFlag
+	default:
+		if r.Lower == nil {
+			flags |= rangeLowerInfFlag
+		} else if r.LowerInc {
+			flags |= rangeLowerIncFlag
+		}
+		if r.Upper == nil {
+			flags |= rangeUpperInfFlag
+		} else if r.UpperInc {
+			flags |= rangeUpperIncFlag
+		}
+	}
+	b = append(b, flags)
+	for _, bound := range []tree.Datum{r.Lower, r.Upper} {
+		if r.Empty || bound == nil {
+			continue
+		}
+		var err error
+		if b, err = Encode(b, NoColumnID, bound, nil /* scratch */); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// encodeMultirange produces the value encoding of a multirange, which is the
+// number of its ranges followed by their encodings.
+func encodeMultirange(b []byte, m *tree.DMultirange) ([]byte, error) {
+	b = encoding.EncodeNonsortingUvarint(b, uint64(len(m.Ranges)))
+	for _, r := range m.Ranges {
+		var err error
+		if b, err = encodeRange(b, r); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// decodeRange decodes a range encoded by encodeRange.
+func decodeRange(a *tree.DatumAlloc, t *types.T, b []byte) (*tree.DRange, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (empty)")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&rangeEmptyFlag != 0 {
+		return tree.NewDEmptyRange(t), b, nil
+	}
+	var lower, upper tree.Datum
+	var err error
+	if flags&rangeLowerInfFlag == 0 {
+		if lower, b, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	if flags&rangeUpperInfFlag == 0 {
+		if upper, b, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return tree.NewDRange(
+		t, lower, upper, flags&rangeLowerIncFlag != 0, flags&rangeUpperIncFlag != 0,
+	), b, nil
+}
+
+// decodeMultirange decodes a multirange encoded by encodeMultirange.
+func decodeMultirange(
+	a *tree.DatumAlloc, t *types.T, b []byte,
+) (*tree.DMultirange, []byte, error) {
+	b, _, n, err := encoding.DecodeNonsortingUvarint(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranges := make([]*tree.DRange, n)
+	for i := range ranges {
+		if ranges[i], b, err = decodeRange(a, t.MultirangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return tree.NewDMultirange(t, ranges), b, nil
+}
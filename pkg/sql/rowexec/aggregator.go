@@ -150,6 +150,17 @@ func (ag *aggregatorBase) init(
 		}
 		ag.outputTypes[i] = outputType
 	}
+	if len(spec.GroupingSets) > 0 {
+		// The aggregations are preceded by the group columns and followed by
+		// the grouping set ordinal (see groupingSetsAggregator).
+		outputTypes := make([]*types.T, 0, len(ag.groupCols)+len(ag.outputTypes)+1)
+		for _, col := range ag.groupCols {
+			outputTypes = append(outputTypes, ag.inputTypes[col])
+		}
+		outputTypes = append(outputTypes, ag.outputTypes...)
+		ag.outputTypes = append(outputTypes, types.Int)
+		ag.row = make(rowenc.EncDatumRow, len(ag.outputTypes))
+	}
 
 	return ag.ProcessorBase.InitWithEvalCtx(
 		ctx, self, post, ag.outputTypes, flowCtx, ag.evalCtx, processorID, memMonitor,
@@ -262,6 +273,9 @@ func newAggregator(
 	input execinfra.RowSource,
 	post *execinfrapb.PostProcessSpec,
 ) (execinfra.Processor, error) {
+	if len(spec.GroupingSets) > 0 {
+		return newGroupingSetsAggregator(ctx, flowCtx, processorID, spec, input, post)
+	}
 	if spec.IsRowCount() {
 		return newCountAggregator(ctx, flowCtx, processorID, input, post)
 	}
@@ -0,0 +1,339 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package rowexec
+
+import (
+	"context"
+	"encoding/binary"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/memsize"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// groupingSetsAggregator is a specialization of aggregatorBase that groups
+// its input by several grouping sets at once, as in GROUP BY GROUPING SETS,
+// ROLLUP and CUBE. Every input row is accumulated into one bucket per grouping
+// set, so the input only needs to be read once.
+//
+// The output rows consist of the group columns, where the columns that are not
+// part of the grouping set of the row are NULL, followed by the aggregations,
+// followed by the ordinal of the grouping set of the row.
+type groupingSetsAggregator struct {
+	aggregatorBase
+
+	// sets contains the group columns of each grouping set.
+	sets []execinfrapb.AggregatorSpec_GroupingSet
+
+	// buckets is used during the accumulation phase to track the buckets of
+	// all grouping sets. The keys are prefixed with the ordinal of the set.
+	// After accumulation, the keys are extracted into bucketsIter for
+	// iteration.
+	buckets     map[string]*groupingSetsBucket
+	bucketsIter []string
+}
+
+// groupingSetsBucket is a single group of a groupingSetsAggregator.
+type groupingSetsBucket struct {
+	funcs aggregateFuncs
+	// groupVals contains the values of all group columns of the group; the
+	// columns that are not part of the grouping set are NULL.
+	groupVals rowenc.EncDatumRow
+	// set is the ordinal of the grouping set of the group.
+	set int
+}
+
+const sizeOfGroupingSetsBucket = int64(unsafe.Sizeof(groupingSetsBucket{}))
+
+var _ execinfra.Processor = &groupingSetsAggregator{}
+var _ execinfra.RowSource = &groupingSetsAggregator{}
+var _ execopnode.OpNode = &groupingSetsAggregator{}
+
+const groupingSetsAggregatorProcName = "grouping sets aggregator"
+
+func newGroupingSetsAggregator(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec *execinfrapb.AggregatorSpec,
+	input execinfra.RowSource,
+	post *execinfrapb.PostProcessSpec,
+) (*groupingSetsAggregator, error) {
+	if len(spec.OrderedGroupCols) > 0 {
+		return nil, errors.AssertionFailedf("grouping sets require hash aggregation")
+	}
+	ag := &groupingSetsAggregator{
+		sets:    spec.GroupingSets,
+		buckets: make(map[string]*groupingSetsBucket),
+	}
+	return ag, ag.init(
+		ctx,
+		ag,
+		flowCtx,
+		processorID,
+		spec,
+		input,
+		post,
+		func() []execinfrapb.ProducerMetadata {
+			ag.close()
+			return nil
+		},
+	)
+}
+
+// Start is part of the RowSource interface.
+func (ag *groupingSetsAggregator) Start(ctx context.Context) {
+	ag.start(ctx, groupingSetsAggregatorProcName)
+}
+
+func (ag *groupingSetsAggregator) close() {
+	if ag.InternalClose() {
+		log.VEventf(ag.Ctx(), 2, "exiting aggregator")
+		// If we have started emitting rows, bucketsIter will represent which
+		// buckets are still open, since buckets are closed once their results are
+		// emitted.
+		if ag.bucketsIter == nil {
+			for _, bucket := range ag.buckets {
+				bucket.funcs.close(ag.Ctx())
+			}
+		} else {
+			for _, bucket := range ag.bucketsIter {
+				ag.buckets[bucket].funcs.close(ag.Ctx())
+			}
+		}
+		ag.buckets = nil
+		ag.bucketsAcc.Close(ag.Ctx())
+		ag.aggFuncsAcc.Close(ag.Ctx())
+		ag.MemMonitor.Stop(ag.Ctx())
+	}
+}
+
+// accumulateRows reads all rows from the input and accumulates them into the
+// buckets of every grouping set. If it encounters metadata, the metadata is
+// immediately returned. Subsequent calls of this function will resume row
+// accumulation.
+func (ag *groupingSetsAggregator) accumulateRows() (
+	aggregatorState,
+	rowenc.EncDatumRow,
+	*execinfrapb.ProducerMetadata,
+) {
+	for {
+		row, meta := ag.input.Next()
+		if meta != nil {
+			if meta.Err != nil {
+				ag.MoveToDraining(nil /* err */)
+				return aggStateUnknown, nil, meta
+			}
+			return aggAccumulating, nil, meta
+		}
+		if row == nil {
+			log.VEvent(ag.Ctx(), 1, "accumulation complete")
+			ag.inputDone = true
+			break
+		}
+		if err := ag.accumulateRow(row); err != nil {
+			ag.MoveToDraining(err)
+			return aggStateUnknown, nil, nil
+		}
+	}
+
+	// An empty grouping set produces a row even if nothing was aggregated, like
+	// a scalar aggregation.
+	for i := range ag.sets {
+		if len(ag.sets[i].Cols) > 0 {
+			continue
+		}
+		key := ag.appendSetOrdinal(ag.scratch[:0], i)
+		if _, ok := ag.buckets[string(key)]; !ok {
+			if _, err := ag.addBucket(key, nil /* row */, i); err != nil {
+				ag.MoveToDraining(err)
+				return aggStateUnknown, nil, nil
+			}
+		}
+	}
+
+	// Note that, for simplicity, we're ignoring the overhead of the slice of
+	// strings.
+	if err := ag.bucketsAcc.Grow(ag.Ctx(), int64(len(ag.buckets))*memsize.String); err != nil {
+		ag.MoveToDraining(err)
+		return aggStateUnknown, nil, nil
+	}
+	ag.bucketsIter = make([]string, 0, len(ag.buckets))
+	for bucket := range ag.buckets {
+		ag.bucketsIter = append(ag.bucketsIter, bucket)
+	}
+
+	// Transition to aggEmittingRows, and let it generate the next row/meta.
+	return aggEmittingRows, nil, nil
+}
+
+// appendSetOrdinal appends the prefix of the keys of the buckets of the given
+// grouping set.
+func (ag *groupingSetsAggregator) appendSetOrdinal(appendTo []byte, set int) []byte {
+	return binary.AppendUvarint(appendTo, uint64(set))
+}
+
+// accumulateRow accumulates a single row into the bucket of each grouping
+// set, returning an error if accumulation failed for any reason.
+func (ag *groupingSetsAggregator) accumulateRow(row rowenc.EncDatumRow) error {
+	if err := ag.cancelChecker.Check(); err != nil {
+		return err
+	}
+
+	for i := range ag.sets {
+		encoded := ag.appendSetOrdinal(ag.scratch[:0], i)
+		for _, colIdx := range ag.sets[i].Cols {
+			var err error
+			encoded, err = row[colIdx].Fingerprint(
+				ag.Ctx(), ag.inputTypes[colIdx], &ag.datumAlloc, encoded, &ag.bucketsAcc,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		ag.scratch = encoded[:0]
+
+		bucket, ok := ag.buckets[string(encoded)]
+		if !ok {
+			var err error
+			if bucket, err = ag.addBucket(encoded, row, i); err != nil {
+				return err
+			}
+		}
+		// The aggregations see the original row, including the values of the
+		// group columns which are not part of this grouping set.
+		if err := ag.accumulateRowIntoBucket(row, encoded, bucket.funcs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addBucket creates the bucket with the given key for the given grouping set,
+// taking the values of the group columns from row. row can only be nil for an
+// empty grouping set.
+func (ag *groupingSetsAggregator) addBucket(
+	key []byte, row rowenc.EncDatumRow, set int,
+) (*groupingSetsBucket, error) {
+	s, err := ag.arena.AllocBytes(ag.Ctx(), key)
+	if err != nil {
+		return nil, err
+	}
+	funcs, err := ag.createAggregateFuncs()
+	if err != nil {
+		return nil, err
+	}
+	bucket := &groupingSetsBucket{
+		funcs:     funcs,
+		groupVals: ag.rowAlloc.AllocRow(len(ag.groupCols)),
+		set:       set,
+	}
+	for i := range bucket.groupVals {
+		bucket.groupVals[i] = rowenc.NullEncDatum()
+	}
+	for _, colIdx := range ag.sets[set].Cols {
+		// Decode the datum so that the bucket doesn't reference the memory of
+		// the input row.
+		if err := row[colIdx].EnsureDecoded(ag.inputTypes[colIdx], &ag.datumAlloc); err != nil {
+			return nil, err
+		}
+		for i, groupCol := range ag.groupCols {
+			if groupCol == colIdx {
+				bucket.groupVals[i] = rowenc.DatumToEncDatum(ag.inputTypes[colIdx], row[colIdx].Datum)
+			}
+		}
+	}
+	if err := ag.bucketsAcc.Grow(
+		ag.Ctx(), memsize.MapEntryOverhead+sizeOfGroupingSetsBucket+int64(bucket.groupVals.Size()),
+	); err != nil {
+		return nil, err
+	}
+	ag.buckets[s] = bucket
+	return bucket, nil
+}
+
+// emitRow constructs an output row from an accumulated bucket and returns it.
+//
+// emitRow() might move to stateDraining. It might also not return a row if the
+// ProcOutputHelper filtered the current row out.
+func (ag *groupingSetsAggregator) emitRow() (
+	aggregatorState,
+	rowenc.EncDatumRow,
+	*execinfrapb.ProducerMetadata,
+) {
+	if len(ag.bucketsIter) == 0 {
+		// We've exhausted all of the aggregation buckets. Transition to
+		// draining so that we emit any metadata that we've produced.
+		ag.MoveToDraining(nil /* err */)
+		return aggStateUnknown, nil, nil
+	}
+
+	key := ag.bucketsIter[0]
+	ag.bucketsIter = ag.bucketsIter[1:]
+	bucket := ag.buckets[key]
+	// See the comment in hashAggregator.emitRow about the memory accounting of
+	// the deleted buckets.
+	delete(ag.buckets, key)
+	defer bucket.funcs.close(ag.Ctx())
+
+	n := copy(ag.row, bucket.groupVals)
+	for i, b := range bucket.funcs {
+		result, err := b.Result()
+		if err != nil {
+			ag.MoveToDraining(err)
+			return aggStateUnknown, nil, nil
+		}
+		if result == nil {
+			// We can't encode nil into an EncDatum, so we represent it with DNull.
+			result = tree.DNull
+		}
+		ag.row[n+i] = rowenc.DatumToEncDatum(ag.outputTypes[n+i], result)
+	}
+	ag.row[len(ag.row)-1] = rowenc.DatumToEncDatum(types.Int, tree.NewDInt(tree.DInt(bucket.set)))
+
+	if outRow := ag.ProcessRowHelper(ag.row); outRow != nil {
+		return aggEmittingRows, outRow, nil
+	}
+	// We might have switched to draining, we might not have. In case we
+	// haven't, aggEmittingRows is accurate. If we have, it will be ignored by
+	// the caller.
+	return aggEmittingRows, nil, nil
+}
+
+// Next is part of the RowSource interface.
+func (ag *groupingSetsAggregator) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	for ag.State == execinfra.StateRunning {
+		var row rowenc.EncDatumRow
+		var meta *execinfrapb.ProducerMetadata
+		switch ag.runningState {
+		case aggAccumulating:
+			ag.runningState, row, meta = ag.accumulateRows()
+		case aggEmittingRows:
+			ag.runningState, row, meta = ag.emitRow()
+		default:
+			log.Fatalf(ag.Ctx(), "unsupported state: %d", ag.runningState)
+		}
+
+		if row == nil && meta == nil {
+			continue
+		}
+		return row, meta
+	}
+	return nil, ag.DrainHelper()
+}
+
+// ConsumerClosed is part of the RowSource interface.
+func (ag *groupingSetsAggregator) ConsumerClosed() {
+	// The consumer is done, Next() will not be called again.
+	ag.close()
+}
@@ -386,6 +386,73 @@ func TestAggregator(t *testing.T) {
 				},
 			},
 		},
+		{
+			// SELECT @1, @2, sum_int(@3), count(DISTINCT @2)
+			// GROUP BY ROLLUP (@1, @2).
+			Name: "SumCountDistinctGroupByRollup",
+			Input: ProcessorTestCaseRows{
+				Rows: [][]interface{}{
+					{1, 1, 10},
+					{1, 2, 20},
+					{2, 1, 5},
+					{2, 1, 15},
+				},
+				Types: types.MakeIntCols(3),
+			},
+			Output: ProcessorTestCaseRows{
+				Rows: [][]interface{}{
+					{1, 1, 10, 1, 0},
+					{1, 2, 20, 1, 0},
+					{2, 1, 20, 1, 0},
+					{1, nil, 30, 2, 1},
+					{2, nil, 20, 1, 1},
+					{nil, nil, 50, 2, 2},
+				},
+				Types: types.MakeIntCols(5),
+			},
+			ProcessorCore: execinfrapb.ProcessorCoreUnion{
+				Aggregator: &execinfrapb.AggregatorSpec{
+					Type:      execinfrapb.AggregatorSpec_NON_SCALAR,
+					GroupCols: []uint32{0, 1},
+					GroupingSets: []execinfrapb.AggregatorSpec_GroupingSet{
+						{Cols: []uint32{0, 1}},
+						{Cols: col0},
+						{},
+					},
+					Aggregations: aggregations([]aggTestSpec{
+						{fname: "SUM_INT", colIdx: col2},
+						{fname: "COUNT", distinct: true, colIdx: col1},
+					}),
+				},
+			},
+		},
+		{
+			// SELECT @1, count(*) GROUP BY ROLLUP (@1) (no rows).
+			Name: "CountRowsGroupByRollupNoRows",
+			Input: ProcessorTestCaseRows{
+				Rows:  [][]interface{}{},
+				Types: types.MakeIntCols(1),
+			},
+			Output: ProcessorTestCaseRows{
+				Rows: [][]interface{}{
+					{nil, 0, 1},
+				},
+				Types: types.MakeIntCols(3),
+			},
+			ProcessorCore: execinfrapb.ProcessorCoreUnion{
+				Aggregator: &execinfrapb.AggregatorSpec{
+					Type:      execinfrapb.AggregatorSpec_NON_SCALAR,
+					GroupCols: col0,
+					GroupingSets: []execinfrapb.AggregatorSpec_GroupingSet{
+						{Cols: col0},
+						{},
+					},
+					Aggregations: aggregations([]aggTestSpec{
+						{fname: "COUNT_ROWS"},
+					}),
+				},
+			},
+		},
 	}
 
 	ctx := context.Background()
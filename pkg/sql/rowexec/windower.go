@@ -135,7 +135,16 @@ func newWindower(
 		for i, argIdx := range windowFn.ArgsIdxs {
 			argTypes[i] = w.inputTypes[argIdx]
 		}
-		windowConstructor, outputType, err := execagg.GetWindowFunctionInfo(windowFn.Func, argTypes...)
+		var windowConstructor func(*eval.Context) eval.WindowFunc
+		var outputType *types.T
+		var err error
+		if windowFn.UserDefined != nil {
+			windowConstructor, outputType, err = execagg.GetUserDefinedWindowFunctionInfo(
+				ctx, w.evalCtx, flowCtx.NewSemaContext(flowCtx.Txn), windowFn.UserDefined, argTypes,
+			)
+		} else {
+			windowConstructor, outputType, err = execagg.GetWindowFunctionInfo(windowFn.Func, argTypes...)
+		}
 		if err != nil {
 			return nil, err
 		}
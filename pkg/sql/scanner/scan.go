@@ -302,6 +302,10 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.JSON_ALL_EXISTS)
 			return
+		case '#': // ?#
+			s.pos++
+			lval.SetID(lexbase.GEOMETRIC_INTERSECTS)
+			return
 		}
 		return
 
@@ -433,6 +437,10 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.AT_AT)
 			return
+		case '?': // @?
+			s.pos++
+			lval.SetID(lexbase.JSON_PATH_EXISTS)
+			return
 		}
 		return
 
@@ -447,6 +455,13 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 
 	case '-':
 		switch s.peek() {
+		case '|': // -|
+			if s.peekN(1) == '-' {
+				// -|-
+				s.pos += 2
+				lval.SetID(lexbase.RANGE_ADJACENT)
+				return
+			}
 		case '>': // ->
 			if s.peekN(1) == '>' {
 				// ->>
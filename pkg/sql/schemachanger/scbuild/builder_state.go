@@ -1034,6 +1034,9 @@ func (b *builderState) ResolveUserDefinedTypeType(
 	case descpb.TypeDescriptor_COMPOSITE:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
+	case descpb.TypeDescriptor_DOMAIN:
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"domain type %q", typ.GetName()))
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		// Implicit record types are not directly modifiable.
 		panic(pgerror.Newf(pgcode.DependentObjectsStillExist,
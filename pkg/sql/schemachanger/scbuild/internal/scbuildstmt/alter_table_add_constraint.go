@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -26,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -65,6 +67,28 @@ func alterTableAddConstraint(
 	}
 }
 
+// isSupportedAddConstraint returns true if the constraint added by the given
+// command is supported by the declarative schema changer. Deferrable
+// constraints and exclusion constraints are only supported by the legacy
+// schema changer.
+func isSupportedAddConstraint(
+	t *tree.AlterTableAddConstraint,
+	_ sessiondatapb.NewSchemaChangerMode,
+	_ clusterversion.ClusterVersion,
+) bool {
+	switch d := t.ConstraintDef.(type) {
+	case *tree.UniqueConstraintTableDef:
+		return d.Deferrability == tree.ConstraintNotDeferrable
+	case *tree.CheckConstraintTableDef:
+		return d.Deferrability == tree.ConstraintNotDeferrable
+	case *tree.ForeignKeyConstraintTableDef:
+		return d.Deferrability == tree.ConstraintNotDeferrable
+	case *tree.ExcludeConstraintTableDef:
+		return false
+	}
+	return true
+}
+
 // alterTableAddPrimaryKey contains logics for building
 // `ALTER TABLE ... ADD PRIMARY KEY`.
 // It assumes `t` is such a command.
@@ -41,6 +41,12 @@ import (
 
 // CreateIndex implements CREATE INDEX.
 func CreateIndex(b BuildCtx, n *tree.CreateIndex) {
+	if n.Vector {
+		panic(scerrors.NotImplementedErrorf(n, "vector indexes are not supported"))
+	}
+	if n.StorageParams.GetVal(`term_statistics`) != nil {
+		panic(scerrors.NotImplementedErrorf(n, "term statistics are not supported"))
+	}
 	b.IncrementSchemaChangeCreateCounter("index")
 	// Resolve the table name and start building the new index element.
 	relationElements := b.ResolveRelation(n.Table.ToUnresolvedObjectName(), ResolveParams{
@@ -362,6 +368,18 @@ func processColNodeType(
 			}
 			invertedKind = catpb.InvertedIndexColumnKind_TRIGRAM
 			b.IncrementSchemaChangeIndexCounter("trigram_inverted")
+		case types.RangeFamily:
+			switch columnNode.OpClass {
+			case "range_ops", "":
+			default:
+				panic(newUndefinedOpclassError(columnNode.OpClass))
+			}
+		case types.MultirangeFamily:
+			switch columnNode.OpClass {
+			case "multirange_ops", "":
+			default:
+				panic(newUndefinedOpclassError(columnNode.OpClass))
+			}
 
 		}
 		relationElts := b.QueryByID(indexSpec.secondary.TableID)
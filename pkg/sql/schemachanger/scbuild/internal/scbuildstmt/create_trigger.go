@@ -98,35 +98,39 @@ func CreateTrigger(b BuildCtx, n *tree.CreateTrigger) {
 			WhenExpr:  string(when.Expr),
 		})
 	}
-	routineName, err := n.FuncName.ToRoutineName()
-	if err != nil {
-		panic(err)
-	}
-	fnElements := b.ResolveRoutine(
-		&tree.RoutineObj{FuncName: routineName},
-		ResolveParams{RequiredPrivilege: privilege.EXECUTE},
-		tree.UDFRoutine,
-	)
-	var fn *scpb.Function
-	fnElements.ForEach(func(_ scpb.Status, _ scpb.TargetStatus, elem scpb.Element) {
-		switch e := elem.(type) {
-		case *scpb.Function:
-			fn = e
-		}
-	})
-	if fn == nil {
-		panic(errors.AssertionFailedf("expected function %v to be resolved", routineName))
-	}
 	if n.FuncBody == "" {
 		panic(errors.AssertionFailedf("expected non-empty function body"))
 	}
-	b.Add(&scpb.TriggerFunctionCall{
-		TableID:   tableID,
-		TriggerID: triggerID,
-		FuncID:    fn.FunctionID,
-		FuncBody:  b.ReplaceSeqTypeNamesInStatements(n.FuncBody, catpb.Function_PLPGSQL),
-		FuncArgs:  n.FuncArgs,
-	})
+	fnCall := &scpb.TriggerFunctionCall{
+		TableID:        tableID,
+		TriggerID:      triggerID,
+		FuncBody:       b.ReplaceSeqTypeNamesInStatements(n.FuncBody, catpb.Function_PLPGSQL),
+		FuncArgs:       n.FuncArgs,
+		BuiltinFuncOID: n.BuiltinFuncOID,
+	}
+	if n.BuiltinFuncOID == 0 {
+		// Builtin trigger functions, like tsvector_update_trigger, don't have
+		// descriptors, so only user-defined functions are resolved.
+		routineName, err := n.FuncName.ToRoutineName()
+		if err != nil {
+			panic(err)
+		}
+		fnElements := b.ResolveRoutine(
+			&tree.RoutineObj{FuncName: routineName},
+			ResolveParams{RequiredPrivilege: privilege.EXECUTE},
+			tree.UDFRoutine,
+		)
+		fnElements.ForEach(func(_ scpb.Status, _ scpb.TargetStatus, elem scpb.Element) {
+			switch e := elem.(type) {
+			case *scpb.Function:
+				fnCall.FuncID = e.FunctionID
+			}
+		})
+		if fnCall.FuncID == 0 {
+			panic(errors.AssertionFailedf("expected function %v to be resolved", routineName))
+		}
+	}
+	b.Add(fnCall)
 	// It is possible for the trigger function to reference the table on which the
 	// trigger is defined. In that case, we need to remove the table from the list
 	// of referenced relations to avoid a circular dependency.
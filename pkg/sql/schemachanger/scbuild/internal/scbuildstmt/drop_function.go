@@ -17,6 +17,9 @@ import (
 )
 
 func DropFunction(b BuildCtx, n *tree.DropRoutine) {
+	if n.Aggregate {
+		panic(scerrors.NotImplementedErrorf(n, "dropping aggregate functions"))
+	}
 	if n.DropBehavior == tree.DropCascade {
 		// TODO(chengxiong): remove this when we allow UDF usage.
 		panic(scerrors.NotImplementedErrorf(n, "cascade dropping functions"))
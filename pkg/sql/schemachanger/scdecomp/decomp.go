@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
@@ -214,6 +215,11 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 				Name:            comp.GetElementLabel(i),
 			})
 		}
+	} else if typ.AsDomainTypeDescriptor() != nil {
+		// Domains have no corresponding elements yet, so defer to the legacy
+		// schema changer for any statement which would need to modify them.
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"domain type %q", typ.GetName()))
 	} else {
 		panic(errors.AssertionFailedf("unsupported type kind %q", typ.GetKind()))
 	}
@@ -631,6 +637,7 @@ func (w *walkCtx) walkIndex(tbl catalog.TableDescriptor, idx catalog.Index) {
 			ConstraintID:        idx.GetConstraintID(),
 			IsNotVisible:        idx.GetInvisibility() != 0.0,
 			Invisibility:        idx.GetInvisibility(),
+			TermStatistics:      idx.HasTermStatistics(),
 		}
 		if geoConfig := idx.GetGeoConfig(); !geoConfig.IsEmpty() {
 			index.GeoConfig = protoutil.Clone(&geoConfig).(*geopb.Config)
@@ -672,6 +679,9 @@ func (w *walkCtx) walkIndex(tbl catalog.TableDescriptor, idx catalog.Index) {
 		if idx.IsSharded() {
 			index.Sharding = &cpy.Sharded
 		}
+		if idx.IsVector() {
+			index.Vector = &cpy.Vector
+		}
 		idxStatus := maybeMutationStatus(idx)
 		if idx.GetEncodingType() == catenumpb.PrimaryIndexEncoding {
 			if idx.IsTemporaryIndexForBackfill() {
@@ -853,11 +863,12 @@ func (w *walkCtx) walkTrigger(tbl catalog.TableDescriptor, t *descpb.TriggerDesc
 		})
 	}
 	w.ev(scpb.Status_PUBLIC, &scpb.TriggerFunctionCall{
-		TableID:   tbl.GetID(),
-		TriggerID: t.ID,
-		FuncID:    t.FuncID,
-		FuncBody:  t.FuncBody,
-		FuncArgs:  t.FuncArgs,
+		TableID:        tbl.GetID(),
+		TriggerID:      t.ID,
+		FuncID:         t.FuncID,
+		FuncBody:       t.FuncBody,
+		FuncArgs:       t.FuncArgs,
+		BuiltinFuncOID: t.BuiltinFuncOID,
 	})
 	w.ev(scpb.Status_PUBLIC, &scpb.TriggerDeps{
 		TableID:         tbl.GetID(),
@@ -909,6 +920,12 @@ func (w *walkCtx) walkForeignKeyConstraint(
 }
 
 func (w *walkCtx) walkFunction(fnDesc catalog.FunctionDescriptor) {
+	if fnDesc.IsAggregate() {
+		// Aggregates have no corresponding elements yet, so defer to the legacy
+		// schema changer for any statement which would need to modify them.
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"aggregate function %q", fnDesc.GetName()))
+	}
 	typeT := newTypeT(fnDesc.GetReturnType().Type)
 	fn := &scpb.Function{
 		FunctionID: fnDesc.GetID(),
@@ -94,6 +94,10 @@ func addNewIndexMutation(
 	if opIndex.GeoConfig != nil {
 		idx.GeoConfig = *opIndex.GeoConfig
 	}
+	if opIndex.Vector != nil {
+		idx.Vector = *opIndex.Vector
+	}
+	idx.TermStatistics = opIndex.TermStatistics
 	return enqueueIndexMutation(tbl, idx, state, descpb.DescriptorMutation_ADD)
 }
 
@@ -98,6 +98,7 @@ func (i *immediateVisitor) SetTriggerFunctionCall(
 		return err
 	}
 	trigger.FuncID = op.FunctionCall.FuncID
+	trigger.BuiltinFuncOID = op.FunctionCall.BuiltinFuncOID
 	trigger.FuncArgs = op.FunctionCall.FuncArgs
 	trigger.FuncBody = op.FunctionCall.FuncBody
 	return nil
@@ -54,6 +54,12 @@ func AllTargetDescIDs(e scpb.Element) (ids catalog.DescriptorIDSet) {
 		// Ignore the parent database in the table data element, the parent
 		// database won't have back-references to any tables.
 		ids.Add(te.TableID)
+	case *scpb.TriggerFunctionCall:
+		// The function ID is unset if the trigger function is a builtin.
+		ids.Add(te.TableID)
+		if te.FuncID != catid.InvalidDescID {
+			ids.Add(te.FuncID)
+		}
 	default:
 		_ = WalkDescIDs(e, func(id *catid.DescID) error {
 			ids.Add(*id)
@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geomfn"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/geo/geos"
 	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
@@ -412,6 +413,8 @@ var aggregates = map[string]builtinDefinition{
 			"Calculates the sum of the selected values."),
 		makeImmutableAggOverload([]*types.T{types.Interval}, types.Interval, newIntervalSumAggregate,
 			"Calculates the sum of the selected values."),
+		makeImmutableAggOverload([]*types.T{types.Money}, types.Money, newMoneySumAggregate,
+			"Calculates the sum of the selected values."),
 	),
 
 	"sqrdiff": makeBuiltin(tree.FunctionProperties{},
@@ -588,6 +591,72 @@ var aggregates = map[string]builtinDefinition{
 	"st_memunion":   makeSTUnionBuiltin(),
 	"st_collect":    makeSTCollectBuiltin(),
 	"st_memcollect": makeSTCollectBuiltin(),
+	"st_clusterwithin": makeBuiltin(
+		tree.FunctionProperties{
+			AvailableOnPublicSchema: true,
+		},
+		makeAggOverload(
+			[]*types.T{types.Geometry, types.Float},
+			types.MakeArray(types.Geometry),
+			newSTGeometryListAgg(func(gs []geo.Geometry, args tree.Datums) (tree.Datum, error) {
+				clusters, err := geomfn.ClusterWithin(gs, float64(tree.MustBeDFloat(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return makeGeometryArray(clusters)
+			}),
+			infoBuilder{
+				info: "Groups the geometries provided into GeometryCollections of geometries which are " +
+					"connected by being within the given distance of each other.",
+			}.String(),
+			volatility.Immutable,
+			true, /* calledOnNullInput */
+		),
+	),
+	"st_clusterintersecting": makeBuiltin(
+		tree.FunctionProperties{
+			AvailableOnPublicSchema: true,
+		},
+		makeAggOverload(
+			[]*types.T{types.Geometry},
+			types.MakeArray(types.Geometry),
+			newSTGeometryListAgg(func(gs []geo.Geometry, _ tree.Datums) (tree.Datum, error) {
+				clusters, err := geomfn.ClusterIntersecting(gs)
+				if err != nil {
+					return nil, err
+				}
+				return makeGeometryArray(clusters)
+			}),
+			infoBuilder{
+				info:         "Groups the geometries provided into GeometryCollections of geometries which are connected by intersections.",
+				libraryUsage: usesGEOS,
+			}.String(),
+			volatility.Immutable,
+			true, /* calledOnNullInput */
+		),
+	),
+	"st_polygonize": makeBuiltin(
+		tree.FunctionProperties{
+			AvailableOnPublicSchema: true,
+		},
+		makeAggOverload(
+			[]*types.T{types.Geometry},
+			types.Geometry,
+			newSTGeometryListAgg(func(gs []geo.Geometry, _ tree.Datums) (tree.Datum, error) {
+				polygons, err := geomfn.Polygonize(gs)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(polygons), nil
+			}),
+			infoBuilder{
+				info:         "Returns a GeometryCollection of the polygons formed by the linework of the geometries provided.",
+				libraryUsage: usesGEOS,
+			}.String(),
+			volatility.Immutable,
+			true, /* calledOnNullInput */
+		),
+	),
 
 	AnyNotNull: makePrivate(makeBuiltin(tree.FunctionProperties{},
 		makeImmutableAggOverloadWithReturnType(
@@ -1035,6 +1104,87 @@ func (agg *stUnionAgg) Size() int64 {
 	return sizeOfSTUnionAggregate
 }
 
+// stGeometryListAgg accumulates the geometries provided, and computes its result
+// from all of them at once.
+type stGeometryListAgg struct {
+	acc   mon.BoundAccount
+	geoms []geo.Geometry
+	// args are the non-geometry arguments of the first row which was added.
+	args     tree.Datums
+	finalize func(gs []geo.Geometry, args tree.Datums) (tree.Datum, error)
+}
+
+func newSTGeometryListAgg(
+	finalize func(gs []geo.Geometry, args tree.Datums) (tree.Datum, error),
+) func([]*types.T, *eval.Context, tree.Datums) eval.AggregateFunc {
+	return func(_ []*types.T, evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		return &stGeometryListAgg{
+			acc:      evalCtx.Planner.Mon().MakeBoundAccount(),
+			finalize: finalize,
+		}
+	}
+}
+
+// Add implements the AggregateFunc interface.
+func (agg *stGeometryListAgg) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	if firstArg == tree.DNull {
+		return nil
+	}
+	for _, arg := range otherArgs {
+		if arg == tree.DNull {
+			return nil
+		}
+	}
+	geomArg := tree.MustBeDGeometry(firstArg)
+	if err := agg.acc.Grow(ctx, int64(geomArg.Size())); err != nil {
+		return err
+	}
+	if agg.args == nil {
+		agg.args = append(tree.Datums{}, otherArgs...)
+	}
+	agg.geoms = append(agg.geoms, geomArg.Geometry)
+	return nil
+}
+
+// Result implements the AggregateFunc interface.
+func (agg *stGeometryListAgg) Result() (tree.Datum, error) {
+	if len(agg.geoms) == 0 {
+		return tree.DNull, nil
+	}
+	return agg.finalize(agg.geoms, agg.args)
+}
+
+// Reset implements the AggregateFunc interface.
+func (agg *stGeometryListAgg) Reset(ctx context.Context) {
+	agg.geoms = agg.geoms[:0]
+	agg.args = nil
+	agg.acc.Empty(ctx)
+}
+
+// Close implements the AggregateFunc interface.
+func (agg *stGeometryListAgg) Close(ctx context.Context) {
+	agg.geoms = nil
+	agg.acc.Close(ctx)
+}
+
+// Size implements the AggregateFunc interface.
+func (agg *stGeometryListAgg) Size() int64 {
+	return sizeOfSTGeometryListAggregate
+}
+
+// makeGeometryArray returns an array of the given geometries.
+func makeGeometryArray(gs []geo.Geometry) (tree.Datum, error) {
+	arr := tree.NewDArray(types.Geometry)
+	for _, g := range gs {
+		if err := arr.Append(tree.NewDGeometry(g)); err != nil {
+			return nil, err
+		}
+	}
+	return arr, nil
+}
+
 type stCollectAgg struct {
 	acc  mon.BoundAccount
 	coll geom.T
@@ -1289,6 +1439,7 @@ var _ eval.AggregateFunc = &intSumAggregate{}
 var _ eval.AggregateFunc = &decimalSumAggregate{}
 var _ eval.AggregateFunc = &floatSumAggregate{}
 var _ eval.AggregateFunc = &intervalSumAggregate{}
+var _ eval.AggregateFunc = &moneySumAggregate{}
 var _ eval.AggregateFunc = &intSqrDiffAggregate{}
 var _ eval.AggregateFunc = &floatSqrDiffAggregate{}
 var _ eval.AggregateFunc = &decimalSqrDiffAggregate{}
@@ -1313,6 +1464,7 @@ var _ eval.AggregateFunc = &percentileDiscAggregate{}
 var _ eval.AggregateFunc = &percentileContAggregate{}
 var _ eval.AggregateFunc = &stMakeLineAgg{}
 var _ eval.AggregateFunc = &stUnionAgg{}
+var _ eval.AggregateFunc = &stGeometryListAgg{}
 var _ eval.AggregateFunc = &stExtentAgg{}
 var _ eval.AggregateFunc = &regressionAccumulatorDecimalBase{}
 var _ eval.AggregateFunc = &finalRegressionAccumulatorDecimalBase{}
@@ -1354,6 +1506,7 @@ const sizeOfIntSumAggregate = int64(unsafe.Sizeof(intSumAggregate{}))
 const sizeOfDecimalSumAggregate = int64(unsafe.Sizeof(decimalSumAggregate{}))
 const sizeOfFloatSumAggregate = int64(unsafe.Sizeof(floatSumAggregate{}))
 const sizeOfIntervalSumAggregate = int64(unsafe.Sizeof(intervalSumAggregate{}))
+const sizeOfMoneySumAggregate = int64(unsafe.Sizeof(moneySumAggregate{}))
 const sizeOfIntSqrDiffAggregate = int64(unsafe.Sizeof(intSqrDiffAggregate{}))
 const sizeOfFloatSqrDiffAggregate = int64(unsafe.Sizeof(floatSqrDiffAggregate{}))
 const sizeOfDecimalSqrDiffAggregate = int64(unsafe.Sizeof(decimalSqrDiffAggregate{}))
@@ -1382,6 +1535,7 @@ const sizeOfPercentileContAggregate = int64(unsafe.Sizeof(percentileContAggregat
 const sizeOfSTMakeLineAggregate = int64(unsafe.Sizeof(stMakeLineAgg{}))
 const sizeOfSTUnionAggregate = int64(unsafe.Sizeof(stUnionAgg{}))
 const sizeOfSTCollectAggregate = int64(unsafe.Sizeof(stCollectAgg{}))
+const sizeOfSTGeometryListAggregate = int64(unsafe.Sizeof(stGeometryListAgg{}))
 const sizeOfSTExtentAggregate = int64(unsafe.Sizeof(stExtentAgg{}))
 const sizeOfStatementStatistics = int64(unsafe.Sizeof(aggStatementStatistics{}))
 const sizeOfAggStatementMetadata = int64(unsafe.Sizeof(aggStatementMetadata{}))
@@ -4023,6 +4177,51 @@ func (a *intervalSumAggregate) Size() int64 {
 	return sizeOfIntervalSumAggregate
 }
 
+type moneySumAggregate struct {
+	sum        int64
+	sawNonNull bool
+}
+
+func newMoneySumAggregate(_ []*types.T, _ *eval.Context, _ tree.Datums) eval.AggregateFunc {
+	return &moneySumAggregate{}
+}
+
+// Add adds the value of the passed datum to the sum.
+func (a *moneySumAggregate) Add(_ context.Context, datum tree.Datum, _ ...tree.Datum) error {
+	if datum == tree.DNull {
+		return nil
+	}
+	r, ok := arith.AddWithOverflow(a.sum, int64(tree.MustBeDMoney(datum)))
+	if !ok {
+		return tree.ErrMoneyOutOfRange
+	}
+	a.sum = r
+	a.sawNonNull = true
+	return nil
+}
+
+// Result returns the sum.
+func (a *moneySumAggregate) Result() (tree.Datum, error) {
+	if !a.sawNonNull {
+		return tree.DNull, nil
+	}
+	return tree.NewDMoney(tree.DMoney(a.sum)), nil
+}
+
+// Reset implements eval.AggregateFunc interface.
+func (a *moneySumAggregate) Reset(context.Context) {
+	a.sum = 0
+	a.sawNonNull = false
+}
+
+// Close is part of the eval.AggregateFunc interface.
+func (a *moneySumAggregate) Close(context.Context) {}
+
+// Size is part of the eval.AggregateFunc interface.
+func (a *moneySumAggregate) Size() int64 {
+	return sizeOfMoneySumAggregate
+}
+
 // Read-only constants used for square difference computations.
 var (
 	decimalZero = apd.New(0, 0)
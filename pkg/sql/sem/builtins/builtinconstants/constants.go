@@ -40,6 +40,7 @@ const (
 	CategoryEnum                = "Enum"
 	CategoryFullTextSearch      = "Full Text Search"
 	CategoryGenerator           = "Set-returning"
+	CategoryGeometric           = "Geometric"
 	CategoryTrigram             = "Trigrams"
 	CategoryFuzzyStringMatching = "Fuzzy String Matching"
 	CategoryIDGeneration        = "ID generation"
@@ -47,6 +48,7 @@ const (
 	CategoryMultiRegion         = "Multi-region"
 	CategoryMultiTenancy        = "Multi-tenancy"
 	CategoryPGVector            = "PGVector"
+	CategoryRange               = "Range"
 	CategorySequences           = "Sequence"
 	CategorySpatial             = "Spatial"
 	CategoryString              = "String and byte"
@@ -83,6 +83,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/money"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
@@ -97,9 +98,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/ulid"
 	"github.com/cockroachdb/cockroach/pkg/util/unaccent"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 	"github.com/knz/strtime"
+	"github.com/lib/pq/oid"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -295,14 +298,14 @@ var regularBuiltins = map[string]builtinDefinition{
 	// TODO(pmattis): What string functions should also support types.Bytes?
 
 	"lower": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
+		append([]tree.Overload{stringOverload1(
 			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
 				return tree.NewDString(strings.ToLower(s)), nil
 			},
 			types.String,
 			"Converts all characters in `val` to their lower-case equivalents.",
 			volatility.Immutable,
-		),
+		)}, makeRangeBoundOverloads(true /* lower */)...)...,
 	),
 
 	"unaccent": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -326,14 +329,14 @@ var regularBuiltins = map[string]builtinDefinition{
 	),
 
 	"upper": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
+		append([]tree.Overload{stringOverload1(
 			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
 				return tree.NewDString(strings.ToUpper(s)), nil
 			},
 			types.String,
 			"Converts all characters in `val` to their to their upper-case equivalents.",
 			volatility.Immutable,
-		),
+		)}, makeRangeBoundOverloads(false /* lower */)...)...,
 	),
 
 	"prettify_statement": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -878,9 +881,11 @@ var regularBuiltins = map[string]builtinDefinition{
 	// - hostmask
 	// - masklen
 	// - netmask
+	// - network
 	// - set_masklen
 	// - text(inet)
 	// - inet_same_family
+	// - inet_merge
 
 	"abbrev": makeBuiltin(defProps(),
 		tree.Overload{
@@ -888,11 +893,16 @@ var regularBuiltins = map[string]builtinDefinition{
 			ReturnType: tree.FixedReturnType(types.String),
 			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
 				dIPAddr := tree.MustBeDIPAddr(args[0])
+				if args[0].ResolvedType().Oid() == oid.T_cidr {
+					return tree.NewDString(dIPAddr.IPAddr.AbbrevCIDR()), nil
+				}
 				return tree.NewDString(dIPAddr.IPAddr.String()), nil
 			},
 			Info: "Converts the combined IP address and prefix length to an abbreviated display format as text." +
 				"For INET types, this will omit the prefix length if it's not the default (32 or IPv4, 128 for IPv6)" +
-				"\n\nFor example, `abbrev('192.168.1.2/24')` returns `'192.168.1.2/24'`",
+				"For CIDR types, this will omit the trailing octets of the network that are zero." +
+				"\n\nFor example, `abbrev('192.168.1.2/24')` returns `'192.168.1.2/24'` and " +
+				"`abbrev('10.1.0.0/16'::cidr)` returns `'10.1/16'`",
 			Volatility: volatility.Immutable,
 		},
 	),
@@ -991,13 +1001,28 @@ var regularBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	"network": makeBuiltin(defProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.INet}},
+			ReturnType: tree.FixedReturnType(types.CIDR),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dIPAddr := tree.MustBeDIPAddr(args[0])
+				return tree.NewDCIDR(&tree.DIPAddr{IPAddr: dIPAddr.IPAddr.Network()}), nil
+			},
+			Info: "Extracts the network part of the address, zeroing out the bits to the right of the " +
+				"prefix length." +
+				"\n\nFor example, `network('192.168.1.5/24')` returns `'192.168.1.0/24'`",
+			Volatility: volatility.Immutable,
+		},
+	),
+
 	"set_masklen": makeBuiltin(defProps(),
 		tree.Overload{
 			Types: tree.ParamTypes{
 				{Name: "val", Typ: types.INet},
 				{Name: "prefixlen", Typ: types.Int},
 			},
-			ReturnType: tree.FixedReturnType(types.INet),
+			ReturnType: tree.IdentityReturnType(0),
 			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
 				dIPAddr := tree.MustBeDIPAddr(args[0])
 				mask := int(tree.MustBeDInt(args[1]))
@@ -1006,9 +1031,15 @@ var regularBuiltins = map[string]builtinDefinition{
 					return nil, pgerror.Newf(
 						pgcode.InvalidParameterValue, "invalid mask length: %d", mask)
 				}
-				return &tree.DIPAddr{IPAddr: ipaddr.IPAddr{Family: dIPAddr.Family, Addr: dIPAddr.Addr, Mask: byte(mask)}}, nil
+				ipAddr := ipaddr.IPAddr{Family: dIPAddr.Family, Addr: dIPAddr.Addr, Mask: byte(mask)}
+				if args[0].ResolvedType().Oid() == oid.T_cidr {
+					// A cidr value can't have any bits set to the right of the mask.
+					return tree.NewDCIDR(&tree.DIPAddr{IPAddr: ipAddr.Network()}), nil
+				}
+				return &tree.DIPAddr{IPAddr: ipAddr}, nil
 			},
-			Info: "Sets the prefix length of `val` to `prefixlen`.\n\n" +
+			Info: "Sets the prefix length of `val` to `prefixlen`. For CIDR values, the bits to the " +
+				"right of the new prefix length are set to zero.\n\n" +
 				"For example, `set_masklen('192.168.1.2', 16)` returns `'192.168.1.2/16'`.",
 			Volatility: volatility.Immutable,
 		},
@@ -1031,6 +1062,28 @@ var regularBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	"inet_merge": makeBuiltin(defProps(),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "val", Typ: types.INet},
+				{Name: "val", Typ: types.INet},
+			},
+			ReturnType: tree.FixedReturnType(types.CIDR),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				first := tree.MustBeDIPAddr(args[0])
+				other := tree.MustBeDIPAddr(args[1])
+				merged, err := first.IPAddr.Merge(&other.IPAddr)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDCIDR(&tree.DIPAddr{IPAddr: merged}), nil
+			},
+			Info: "Computes the smallest network which includes both of the given networks." +
+				"\n\nFor example, `inet_merge('192.168.1.5/24', '192.168.2.5/24')` returns `'192.168.0.0/22'`",
+			Volatility: volatility.Immutable,
+		},
+	),
+
 	"inet_contained_by_or_equals": makeBuiltin(defProps(),
 		tree.Overload{
 			Types: tree.ParamTypes{
@@ -1067,6 +1120,35 @@ var regularBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	"macaddr8_set7bit": makeBuiltin(defProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.MACAddr8}},
+			ReturnType: tree.FixedReturnType(types.MACAddr8),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDMACAddr8(tree.MustBeDMACAddr8(args[0]).Set7Bit()), nil
+			},
+			Info: "Sets the 7th bit of the MAC address to one, creating a modified EUI-64 " +
+				"address for inclusion in an IPv6 address." +
+				"\n\nFor example, `macaddr8_set7bit('08:00:2b:01:02:03:04:05')` returns " +
+				"`'0a:00:2b:01:02:03:04:05'`",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"cash_words": makeBuiltin(defProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.Money}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDString(money.Words(int64(tree.MustBeDMoney(args[0])))), nil
+			},
+			Info: "Spells out the amount of money in English words." +
+				"\n\nFor example, `cash_words('$1.50')` returns " +
+				"`'One dollar and fifty cents'`",
+			Volatility: volatility.Immutable,
+		},
+	),
+
 	"from_ip": makeBuiltin(defProps(),
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "val", Typ: types.Bytes}},
@@ -3938,30 +4020,17 @@ value if you rely on the HLC for accuracy.`,
 	}, false /* supportsArrayInput */)),
 
 	// Full text search functions.
-	"ts_match_qv":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_match_vq":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_cmp":                   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_concat":                makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_debug":                       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_headline":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_lexize":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"websearch_to_tsquery":           makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"array_to_tsvector":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"get_current_ts_config":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"numnode":                        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"querytree":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"setweight":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"strip":                          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"json_to_tsvector":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"jsonb_to_tsvector":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_delete":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_filter":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_rank_cd":                     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_rewrite":                     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsquery_phrase":                 makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_to_array":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_update_trigger":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_update_trigger_column": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"ts_match_qv":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"ts_match_vq":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"tsvector_cmp":      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"tsvector_concat":   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"ts_debug":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"numnode":           makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"querytree":         makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"json_to_tsvector":  makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"jsonb_to_tsvector": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"ts_delete":         makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"ts_filter":         makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 
 	// Fuzzy String Matching
 	"soundex": makeBuiltin(
@@ -4069,14 +4138,6 @@ value if you rely on the HLC for accuracy.`,
 	// The behavior of both the JSON and JSONB data types in CockroachDB is
 	// similar to the behavior of the JSONB data type in Postgres.
 
-	"jsonb_path_exists":      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_exists_opr":  makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_match":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_match_opr":   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query_array": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query_first": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-
 	"json_remove_path": makeBuiltin(jsonProps(),
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "val", Typ: types.Jsonb}, {Name: "path", Typ: types.StringArray}},
@@ -6571,6 +6632,87 @@ SELECT
 		},
 	),
 
+	"crdb_internal.check_domain_not_null": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     builtinconstants.CategorySystemInfo,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "val", Typ: types.Any},
+				{Name: "domain", Typ: types.String},
+			},
+			ReturnType: tree.IdentityReturnType(0),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[0] == tree.DNull {
+					return nil, pgerror.Newf(pgcode.NotNullViolation,
+						"domain %s does not allow null values", tree.MustBeDString(args[1]))
+				}
+				return args[0], nil
+			},
+			Info:       "This function is used internally to enforce the NOT NULL constraint of a domain.",
+			Volatility: volatility.Immutable,
+			// The function must raise an error for NULL values.
+			CalledOnNullInput: true,
+		},
+	),
+
+	"crdb_internal.check_domain_constraint": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     builtinconstants.CategorySystemInfo,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "val", Typ: types.Any},
+				{Name: "ok", Typ: types.Bool},
+				{Name: "domain", Typ: types.String},
+				{Name: "constraint", Typ: types.String},
+			},
+			ReturnType: tree.IdentityReturnType(0),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				// Like table CHECK constraints, domain CHECK constraints are
+				// satisfied if they evaluate to NULL.
+				if args[1] != tree.DNull && !tree.MustBeDBool(args[1]) {
+					return nil, pgerror.Newf(pgcode.CheckViolation,
+						"value for domain %s violates check constraint %q",
+						tree.MustBeDString(args[2]), tree.MustBeDString(args[3]))
+				}
+				return args[0], nil
+			},
+			Info:       "This function is used internally to enforce a CHECK constraint of a domain.",
+			Volatility: volatility.Immutable,
+			// The value being checked may be NULL.
+			CalledOnNullInput: true,
+		},
+	),
+
+	"crdb_internal.vector_partition": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     builtinconstants.CategorySystemInfo,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.PGVector},
+				{Name: "partitions", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDPGVector(args[0])
+				partitions := int64(tree.MustBeDInt(args[1]))
+				if partitions < 1 || partitions > vector.MaxPartitions {
+					return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+						"partitions must be between 1 and %d", vector.MaxPartitions)
+				}
+				return tree.NewDInt(tree.DInt(vector.Partition(v.T, int(partitions)))), nil
+			},
+			Info: "This function is used internally to compute the partition of a vector " +
+				"in a vector index.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
 	"crdb_internal.round_decimal_values": makeBuiltin(
 		tree.FunctionProperties{
 			Category: builtinconstants.CategorySystemInfo,
@@ -8806,6 +8948,64 @@ specified store on the node it's run from. One of 'mvccGC', 'merge', 'split',
 			CalledOnNullInput: true,
 		},
 	),
+	"crdb_internal.plpgsql_fetch_next": makeBuiltin(tree.FunctionProperties{
+		Category:     builtinconstants.CategoryString,
+		Undocumented: true,
+	},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "name", Typ: types.RefCursor},
+				{Name: "rowType", Typ: types.Any},
+			},
+			ReturnType: func(args []tree.TypedExpr) *types.T {
+				if len(args) < 2 {
+					return tree.UnknownReturnType
+				}
+				return types.MakeTuple([]*types.T{types.Bool, args[1].ResolvedType()})
+			},
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[0] == tree.DNull {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed, "cursor name cannot be null")
+				}
+				rowType := args[1].ResolvedType()
+				retType := types.MakeTuple([]*types.T{types.Bool, rowType})
+				cursor := &tree.CursorStmt{
+					Name:      tree.Name(tree.MustBeDString(args[0])),
+					FetchType: tree.FetchNormal,
+					Count:     1,
+				}
+				row, err := evalCtx.Planner.PLpgSQLFetchCursor(ctx, cursor)
+				if err != nil {
+					return nil, err
+				}
+				if row == nil {
+					// The cursor is exhausted.
+					tup := tree.MakeDTuple(retType, tree.DBoolFalse, tree.DNull)
+					return &tup, nil
+				}
+				rowTypes := rowType.TupleContents()
+				res := make(tree.Datums, len(rowTypes))
+				for i := range rowTypes {
+					if i < len(row) {
+						res[i], err = eval.PerformCastNoTruncate(ctx, evalCtx, row[i], rowTypes[i])
+						if err != nil {
+							return nil, err
+						}
+					} else {
+						res[i] = tree.DNull
+					}
+				}
+				rowTup := tree.MakeDTuple(rowType, res...)
+				tup := tree.MakeDTuple(retType, tree.DBoolTrue, &rowTup)
+				return &tup, nil
+			},
+			Info: "This function is used internally to fetch the next row of the cursor " +
+				"that implements a PLpgSQL query FOR loop. It returns whether a row was " +
+				"found, along with the row cast to the given row type.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
 	"crdb_internal.protect_mvcc_history": makeBuiltin(
 		tree.FunctionProperties{
 			Category:     builtinconstants.CategoryClusterReplication,
@@ -8969,6 +9169,7 @@ var lengthImpls = func(incBitOverload bool) builtinDefinition {
 				volatility.Immutable,
 			),
 		)
+		overloads = append(overloads, makeGeometricLengthOverloads()...)
 	}
 	return makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString}, overloads...)
 }
@@ -11430,11 +11631,18 @@ func asJSONBuildObjectKey(
 			tree.FmtBareStrings,
 			tree.FmtDataConversionConfig(dcc),
 		), nil
+	case *tree.DMoney:
+		return tree.AsStringWithFlags(
+			t,
+			tree.FmtBareStrings,
+			tree.FmtDataConversionConfig(dcc),
+		), nil
 	case *tree.DBitArray, *tree.DBool, *tree.DBox2D, *tree.DBytes, *tree.DDate,
 		*tree.DDecimal, *tree.DEnum, *tree.DFloat, *tree.DGeography,
 		*tree.DGeometry, *tree.DIPAddr, *tree.DInt, *tree.DInterval, *tree.DOid,
 		*tree.DOidWrapper, *tree.DPGLSN, *tree.DPGVector, *tree.DTime, *tree.DTimeTZ,
-		*tree.DTimestamp, *tree.DTSQuery, *tree.DTSVector, *tree.DUuid, *tree.DVoid:
+		*tree.DTimestamp, *tree.DTSQuery, *tree.DTSVector, *tree.DUuid, *tree.DVoid,
+		*tree.DMACAddr, *tree.DMACAddr8:
 		return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
 	default:
 		return "", errors.AssertionFailedf("unexpected type %T for key value", d)
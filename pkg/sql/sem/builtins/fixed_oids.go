@@ -853,7 +853,7 @@ var builtinOidsArray = []string{
 	877:  `hostmask(val: inet) -> inet`,
 	878:  `masklen(val: inet) -> int`,
 	879:  `netmask(val: inet) -> inet`,
-	880:  `set_masklen(val: inet, prefixlen: int) -> inet`,
+	880:  `set_masklen(val: inet, prefixlen: int) -> anyelement`,
 	881:  `text(inet: inet) -> string`,
 	882:  `inet_same_family(val: inet, val: inet) -> bool`,
 	883:  `inet_contained_by_or_equals(val: inet, container: inet) -> bool`,
@@ -2606,6 +2606,661 @@ var builtinOidsArray = []string{
 	2643: `crdb_internal.type_is_indexable(oid: oid) -> bool`,
 	2644: `crdb_internal.range_stats_with_errors(key: bytes) -> jsonb`,
 	2645: `crdb_internal.lease_holder_with_errors(key: bytes) -> jsonb`,
+	2646: `crdb_internal.check_domain_not_null(val: anyelement, domain: string) -> anyelement`,
+	2647: `crdb_internal.check_domain_constraint(val: anyelement, ok: bool, domain: string, constraint: string) -> anyelement`,
+	2648: `pg_notify(channel: string, payload: string) -> void`,
+	2649: `crdb_internal.vector_partition(vector: vector, partitions: int) -> int`,
+	2650: `crdb_internal.plpgsql_fetch_next(name: refcursor, rowType: anyelement) -> anyelement`,
+	2651: `int4range(lower: int4, upper: int4) -> int4range`,
+	2652: `int4range(lower: int4, upper: int4, bounds: string) -> int4range`,
+	2653: `int4multirange(int4range...) -> int4multirange`,
+	2654: `int8range(lower: int, upper: int) -> int8range`,
+	2655: `int8range(lower: int, upper: int, bounds: string) -> int8range`,
+	2656: `int8multirange(int8range...) -> int8multirange`,
+	2657: `numrange(lower: decimal, upper: decimal) -> numrange`,
+	2658: `numrange(lower: decimal, upper: decimal, bounds: string) -> numrange`,
+	2659: `nummultirange(numrange...) -> nummultirange`,
+	2660: `tsrange(lower: timestamp, upper: timestamp) -> tsrange`,
+	2661: `tsrange(lower: timestamp, upper: timestamp, bounds: string) -> tsrange`,
+	2662: `tsmultirange(tsrange...) -> tsmultirange`,
+	2663: `tstzrange(lower: timestamptz, upper: timestamptz) -> tstzrange`,
+	2664: `tstzrange(lower: timestamptz, upper: timestamptz, bounds: string) -> tstzrange`,
+	2665: `tstzmultirange(tstzrange...) -> tstzmultirange`,
+	2666: `daterange(lower: date, upper: date) -> daterange`,
+	2667: `daterange(lower: date, upper: date, bounds: string) -> daterange`,
+	2668: `datemultirange(daterange...) -> datemultirange`,
+	2669: `lower(val: int4range) -> int4`,
+	2670: `lower(val: int8range) -> int`,
+	2671: `lower(val: numrange) -> decimal`,
+	2672: `lower(val: tsrange) -> timestamp`,
+	2673: `lower(val: tstzrange) -> timestamptz`,
+	2674: `lower(val: daterange) -> date`,
+	2675: `lower(val: int4multirange) -> int4`,
+	2676: `lower(val: int8multirange) -> int`,
+	2677: `lower(val: nummultirange) -> decimal`,
+	2678: `lower(val: tsmultirange) -> timestamp`,
+	2679: `lower(val: tstzmultirange) -> timestamptz`,
+	2680: `lower(val: datemultirange) -> date`,
+	2681: `upper(val: int4range) -> int4`,
+	2682: `upper(val: int8range) -> int`,
+	2683: `upper(val: numrange) -> decimal`,
+	2684: `upper(val: tsrange) -> timestamp`,
+	2685: `upper(val: tstzrange) -> timestamptz`,
+	2686: `upper(val: daterange) -> date`,
+	2687: `upper(val: int4multirange) -> int4`,
+	2688: `upper(val: int8multirange) -> int`,
+	2689: `upper(val: nummultirange) -> decimal`,
+	2690: `upper(val: tsmultirange) -> timestamp`,
+	2691: `upper(val: tstzmultirange) -> timestamptz`,
+	2692: `upper(val: datemultirange) -> date`,
+	2693: `isempty(val: int4range) -> bool`,
+	2694: `isempty(val: int8range) -> bool`,
+	2695: `isempty(val: numrange) -> bool`,
+	2696: `isempty(val: tsrange) -> bool`,
+	2697: `isempty(val: tstzrange) -> bool`,
+	2698: `isempty(val: daterange) -> bool`,
+	2699: `isempty(val: int4multirange) -> bool`,
+	2700: `isempty(val: int8multirange) -> bool`,
+	2701: `isempty(val: nummultirange) -> bool`,
+	2702: `isempty(val: tsmultirange) -> bool`,
+	2703: `isempty(val: tstzmultirange) -> bool`,
+	2704: `isempty(val: datemultirange) -> bool`,
+	2705: `lower_inc(val: int4range) -> bool`,
+	2706: `lower_inc(val: int8range) -> bool`,
+	2707: `lower_inc(val: numrange) -> bool`,
+	2708: `lower_inc(val: tsrange) -> bool`,
+	2709: `lower_inc(val: tstzrange) -> bool`,
+	2710: `lower_inc(val: daterange) -> bool`,
+	2711: `lower_inc(val: int4multirange) -> bool`,
+	2712: `lower_inc(val: int8multirange) -> bool`,
+	2713: `lower_inc(val: nummultirange) -> bool`,
+	2714: `lower_inc(val: tsmultirange) -> bool`,
+	2715: `lower_inc(val: tstzmultirange) -> bool`,
+	2716: `lower_inc(val: datemultirange) -> bool`,
+	2717: `upper_inc(val: int4range) -> bool`,
+	2718: `upper_inc(val: int8range) -> bool`,
+	2719: `upper_inc(val: numrange) -> bool`,
+	2720: `upper_inc(val: tsrange) -> bool`,
+	2721: `upper_inc(val: tstzrange) -> bool`,
+	2722: `upper_inc(val: daterange) -> bool`,
+	2723: `upper_inc(val: int4multirange) -> bool`,
+	2724: `upper_inc(val: int8multirange) -> bool`,
+	2725: `upper_inc(val: nummultirange) -> bool`,
+	2726: `upper_inc(val: tsmultirange) -> bool`,
+	2727: `upper_inc(val: tstzmultirange) -> bool`,
+	2728: `upper_inc(val: datemultirange) -> bool`,
+	2729: `lower_inf(val: int4range) -> bool`,
+	2730: `lower_inf(val: int8range) -> bool`,
+	2731: `lower_inf(val: numrange) -> bool`,
+	2732: `lower_inf(val: tsrange) -> bool`,
+	2733: `lower_inf(val: tstzrange) -> bool`,
+	2734: `lower_inf(val: daterange) -> bool`,
+	2735: `lower_inf(val: int4multirange) -> bool`,
+	2736: `lower_inf(val: int8multirange) -> bool`,
+	2737: `lower_inf(val: nummultirange) -> bool`,
+	2738: `lower_inf(val: tsmultirange) -> bool`,
+	2739: `lower_inf(val: tstzmultirange) -> bool`,
+	2740: `lower_inf(val: datemultirange) -> bool`,
+	2741: `upper_inf(val: int4range) -> bool`,
+	2742: `upper_inf(val: int8range) -> bool`,
+	2743: `upper_inf(val: numrange) -> bool`,
+	2744: `upper_inf(val: tsrange) -> bool`,
+	2745: `upper_inf(val: tstzrange) -> bool`,
+	2746: `upper_inf(val: daterange) -> bool`,
+	2747: `upper_inf(val: int4multirange) -> bool`,
+	2748: `upper_inf(val: int8multirange) -> bool`,
+	2749: `upper_inf(val: nummultirange) -> bool`,
+	2750: `upper_inf(val: tsmultirange) -> bool`,
+	2751: `upper_inf(val: tstzmultirange) -> bool`,
+	2752: `upper_inf(val: datemultirange) -> bool`,
+	2753: `range_adjacent(left: int4range, right: int4range) -> bool`,
+	2754: `range_adjacent(left: int4range, right: int4multirange) -> bool`,
+	2755: `range_adjacent(left: int4multirange, right: int4range) -> bool`,
+	2756: `range_adjacent(left: int4multirange, right: int4multirange) -> bool`,
+	2757: `range_adjacent(left: int8range, right: int8range) -> bool`,
+	2758: `range_adjacent(left: int8range, right: int8multirange) -> bool`,
+	2759: `range_adjacent(left: int8multirange, right: int8range) -> bool`,
+	2760: `range_adjacent(left: int8multirange, right: int8multirange) -> bool`,
+	2761: `range_adjacent(left: numrange, right: numrange) -> bool`,
+	2762: `range_adjacent(left: numrange, right: nummultirange) -> bool`,
+	2763: `range_adjacent(left: nummultirange, right: numrange) -> bool`,
+	2764: `range_adjacent(left: nummultirange, right: nummultirange) -> bool`,
+	2765: `range_adjacent(left: tsrange, right: tsrange) -> bool`,
+	2766: `range_adjacent(left: tsrange, right: tsmultirange) -> bool`,
+	2767: `range_adjacent(left: tsmultirange, right: tsrange) -> bool`,
+	2768: `range_adjacent(left: tsmultirange, right: tsmultirange) -> bool`,
+	2769: `range_adjacent(left: tstzrange, right: tstzrange) -> bool`,
+	2770: `range_adjacent(left: tstzrange, right: tstzmultirange) -> bool`,
+	2771: `range_adjacent(left: tstzmultirange, right: tstzrange) -> bool`,
+	2772: `range_adjacent(left: tstzmultirange, right: tstzmultirange) -> bool`,
+	2773: `range_adjacent(left: daterange, right: daterange) -> bool`,
+	2774: `range_adjacent(left: daterange, right: datemultirange) -> bool`,
+	2775: `range_adjacent(left: datemultirange, right: daterange) -> bool`,
+	2776: `range_adjacent(left: datemultirange, right: datemultirange) -> bool`,
+	2777: `range_merge(left: int4range, right: int4range) -> int4range`,
+	2778: `range_merge(val: int4multirange) -> int4range`,
+	2779: `range_merge(left: int8range, right: int8range) -> int8range`,
+	2780: `range_merge(val: int8multirange) -> int8range`,
+	2781: `range_merge(left: numrange, right: numrange) -> numrange`,
+	2782: `range_merge(val: nummultirange) -> numrange`,
+	2783: `range_merge(left: tsrange, right: tsrange) -> tsrange`,
+	2784: `range_merge(val: tsmultirange) -> tsrange`,
+	2785: `range_merge(left: tstzrange, right: tstzrange) -> tstzrange`,
+	2786: `range_merge(val: tstzmultirange) -> tstzrange`,
+	2787: `range_merge(left: daterange, right: daterange) -> daterange`,
+	2788: `range_merge(val: datemultirange) -> daterange`,
+	2789: `multirange(val: int4range) -> int4multirange`,
+	2790: `multirange(val: int8range) -> int8multirange`,
+	2791: `multirange(val: numrange) -> nummultirange`,
+	2792: `multirange(val: tsrange) -> tsmultirange`,
+	2793: `multirange(val: tstzrange) -> tstzmultirange`,
+	2794: `multirange(val: daterange) -> datemultirange`,
+	2795: `int4rangesend(int4range: int4range) -> bytes`,
+	2796: `int4rangerecv(input: anyelement) -> int4range`,
+	2797: `int4rangeout(int4range: int4range) -> bytes`,
+	2798: `int4rangein(input: anyelement) -> int4range`,
+	2799: `int4range(string: string) -> int4range`,
+	2800: `int4range(int4range: int4range) -> int4range`,
+	2801: `varchar(int4range: int4range) -> varchar`,
+	2802: `text(int4range: int4range) -> string`,
+	2803: `bpchar(int4range: int4range) -> bpchar`,
+	2804: `name(int4range: int4range) -> name`,
+	2805: `char(int4range: int4range) -> "char"`,
+	2806: `int8rangesend(int8range: int8range) -> bytes`,
+	2807: `int8rangerecv(input: anyelement) -> int8range`,
+	2808: `int8rangeout(int8range: int8range) -> bytes`,
+	2809: `int8rangein(input: anyelement) -> int8range`,
+	2810: `int8range(string: string) -> int8range`,
+	2811: `int8range(int8range: int8range) -> int8range`,
+	2812: `varchar(int8range: int8range) -> varchar`,
+	2813: `text(int8range: int8range) -> string`,
+	2814: `bpchar(int8range: int8range) -> bpchar`,
+	2815: `name(int8range: int8range) -> name`,
+	2816: `char(int8range: int8range) -> "char"`,
+	2817: `numrangesend(numrange: numrange) -> bytes`,
+	2818: `numrangerecv(input: anyelement) -> numrange`,
+	2819: `numrangeout(numrange: numrange) -> bytes`,
+	2820: `numrangein(input: anyelement) -> numrange`,
+	2821: `numrange(string: string) -> numrange`,
+	2822: `numrange(numrange: numrange) -> numrange`,
+	2823: `varchar(numrange: numrange) -> varchar`,
+	2824: `text(numrange: numrange) -> string`,
+	2825: `bpchar(numrange: numrange) -> bpchar`,
+	2826: `name(numrange: numrange) -> name`,
+	2827: `char(numrange: numrange) -> "char"`,
+	2828: `tsrangesend(tsrange: tsrange) -> bytes`,
+	2829: `tsrangerecv(input: anyelement) -> tsrange`,
+	2830: `tsrangeout(tsrange: tsrange) -> bytes`,
+	2831: `tsrangein(input: anyelement) -> tsrange`,
+	2832: `tsrange(string: string) -> tsrange`,
+	2833: `tsrange(tsrange: tsrange) -> tsrange`,
+	2834: `varchar(tsrange: tsrange) -> varchar`,
+	2835: `text(tsrange: tsrange) -> string`,
+	2836: `bpchar(tsrange: tsrange) -> bpchar`,
+	2837: `name(tsrange: tsrange) -> name`,
+	2838: `char(tsrange: tsrange) -> "char"`,
+	2839: `tstzrangesend(tstzrange: tstzrange) -> bytes`,
+	2840: `tstzrangerecv(input: anyelement) -> tstzrange`,
+	2841: `tstzrangeout(tstzrange: tstzrange) -> bytes`,
+	2842: `tstzrangein(input: anyelement) -> tstzrange`,
+	2843: `tstzrange(string: string) -> tstzrange`,
+	2844: `tstzrange(tstzrange: tstzrange) -> tstzrange`,
+	2845: `varchar(tstzrange: tstzrange) -> varchar`,
+	2846: `text(tstzrange: tstzrange) -> string`,
+	2847: `bpchar(tstzrange: tstzrange) -> bpchar`,
+	2848: `name(tstzrange: tstzrange) -> name`,
+	2849: `char(tstzrange: tstzrange) -> "char"`,
+	2850: `daterangesend(daterange: daterange) -> bytes`,
+	2851: `daterangerecv(input: anyelement) -> daterange`,
+	2852: `daterangeout(daterange: daterange) -> bytes`,
+	2853: `daterangein(input: anyelement) -> daterange`,
+	2854: `daterange(string: string) -> daterange`,
+	2855: `daterange(daterange: daterange) -> daterange`,
+	2856: `varchar(daterange: daterange) -> varchar`,
+	2857: `text(daterange: daterange) -> string`,
+	2858: `bpchar(daterange: daterange) -> bpchar`,
+	2859: `name(daterange: daterange) -> name`,
+	2860: `char(daterange: daterange) -> "char"`,
+	2861: `int4multirangesend(int4multirange: int4multirange) -> bytes`,
+	2862: `int4multirangerecv(input: anyelement) -> int4multirange`,
+	2863: `int4multirangeout(int4multirange: int4multirange) -> bytes`,
+	2864: `int4multirangein(input: anyelement) -> int4multirange`,
+	2865: `int4multirange(string: string) -> int4multirange`,
+	2866: `int4multirange(int4multirange: int4multirange) -> int4multirange`,
+	2867: `varchar(int4multirange: int4multirange) -> varchar`,
+	2868: `text(int4multirange: int4multirange) -> string`,
+	2869: `bpchar(int4multirange: int4multirange) -> bpchar`,
+	2870: `name(int4multirange: int4multirange) -> name`,
+	2871: `char(int4multirange: int4multirange) -> "char"`,
+	2872: `int8multirangesend(int8multirange: int8multirange) -> bytes`,
+	2873: `int8multirangerecv(input: anyelement) -> int8multirange`,
+	2874: `int8multirangeout(int8multirange: int8multirange) -> bytes`,
+	2875: `int8multirangein(input: anyelement) -> int8multirange`,
+	2876: `int8multirange(string: string) -> int8multirange`,
+	2877: `int8multirange(int8multirange: int8multirange) -> int8multirange`,
+	2878: `varchar(int8multirange: int8multirange) -> varchar`,
+	2879: `text(int8multirange: int8multirange) -> string`,
+	2880: `bpchar(int8multirange: int8multirange) -> bpchar`,
+	2881: `name(int8multirange: int8multirange) -> name`,
+	2882: `char(int8multirange: int8multirange) -> "char"`,
+	2883: `nummultirangesend(nummultirange: nummultirange) -> bytes`,
+	2884: `nummultirangerecv(input: anyelement) -> nummultirange`,
+	2885: `nummultirangeout(nummultirange: nummultirange) -> bytes`,
+	2886: `nummultirangein(input: anyelement) -> nummultirange`,
+	2887: `nummultirange(string: string) -> nummultirange`,
+	2888: `nummultirange(nummultirange: nummultirange) -> nummultirange`,
+	2889: `varchar(nummultirange: nummultirange) -> varchar`,
+	2890: `text(nummultirange: nummultirange) -> string`,
+	2891: `bpchar(nummultirange: nummultirange) -> bpchar`,
+	2892: `name(nummultirange: nummultirange) -> name`,
+	2893: `char(nummultirange: nummultirange) -> "char"`,
+	2894: `tsmultirangesend(tsmultirange: tsmultirange) -> bytes`,
+	2895: `tsmultirangerecv(input: anyelement) -> tsmultirange`,
+	2896: `tsmultirangeout(tsmultirange: tsmultirange) -> bytes`,
+	2897: `tsmultirangein(input: anyelement) -> tsmultirange`,
+	2898: `tsmultirange(string: string) -> tsmultirange`,
+	2899: `tsmultirange(tsmultirange: tsmultirange) -> tsmultirange`,
+	2900: `varchar(tsmultirange: tsmultirange) -> varchar`,
+	2901: `text(tsmultirange: tsmultirange) -> string`,
+	2902: `bpchar(tsmultirange: tsmultirange) -> bpchar`,
+	2903: `name(tsmultirange: tsmultirange) -> name`,
+	2904: `char(tsmultirange: tsmultirange) -> "char"`,
+	2905: `tstzmultirangesend(tstzmultirange: tstzmultirange) -> bytes`,
+	2906: `tstzmultirangerecv(input: anyelement) -> tstzmultirange`,
+	2907: `tstzmultirangeout(tstzmultirange: tstzmultirange) -> bytes`,
+	2908: `tstzmultirangein(input: anyelement) -> tstzmultirange`,
+	2909: `tstzmultirange(string: string) -> tstzmultirange`,
+	2910: `tstzmultirange(tstzmultirange: tstzmultirange) -> tstzmultirange`,
+	2911: `varchar(tstzmultirange: tstzmultirange) -> varchar`,
+	2912: `text(tstzmultirange: tstzmultirange) -> string`,
+	2913: `bpchar(tstzmultirange: tstzmultirange) -> bpchar`,
+	2914: `name(tstzmultirange: tstzmultirange) -> name`,
+	2915: `char(tstzmultirange: tstzmultirange) -> "char"`,
+	2916: `datemultirangesend(datemultirange: datemultirange) -> bytes`,
+	2917: `datemultirangerecv(input: anyelement) -> datemultirange`,
+	2918: `datemultirangeout(datemultirange: datemultirange) -> bytes`,
+	2919: `datemultirangein(input: anyelement) -> datemultirange`,
+	2920: `datemultirange(string: string) -> datemultirange`,
+	2921: `datemultirange(datemultirange: datemultirange) -> datemultirange`,
+	2922: `varchar(datemultirange: datemultirange) -> varchar`,
+	2923: `text(datemultirange: datemultirange) -> string`,
+	2924: `bpchar(datemultirange: datemultirange) -> bpchar`,
+	2925: `name(datemultirange: datemultirange) -> name`,
+	2926: `char(datemultirange: datemultirange) -> "char"`,
+	2927: `jsonb_path_exists(target: jsonb, path: jsonpath) -> bool`,
+	2928: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2929: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2930: `jsonb_path_exists_tz(target: jsonb, path: jsonpath) -> bool`,
+	2931: `jsonb_path_exists_tz(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2932: `jsonb_path_exists_tz(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2933: `jsonb_path_match(target: jsonb, path: jsonpath) -> bool`,
+	2934: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2935: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2936: `jsonb_path_match_tz(target: jsonb, path: jsonpath) -> bool`,
+	2937: `jsonb_path_match_tz(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2938: `jsonb_path_match_tz(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2939: `jsonb_path_query(target: jsonb, path: jsonpath) -> jsonb`,
+	2940: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2941: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2942: `jsonb_path_query_tz(target: jsonb, path: jsonpath) -> jsonb`,
+	2943: `jsonb_path_query_tz(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2944: `jsonb_path_query_tz(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2945: `jsonb_path_query_array(target: jsonb, path: jsonpath) -> jsonb`,
+	2946: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2947: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2948: `jsonb_path_query_array_tz(target: jsonb, path: jsonpath) -> jsonb`,
+	2949: `jsonb_path_query_array_tz(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2950: `jsonb_path_query_array_tz(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2951: `jsonb_path_query_first(target: jsonb, path: jsonpath) -> jsonb`,
+	2952: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2953: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2954: `jsonb_path_query_first_tz(target: jsonb, path: jsonpath) -> jsonb`,
+	2955: `jsonb_path_query_first_tz(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2956: `jsonb_path_query_first_tz(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2957: `jsonb_path_exists_opr(target: jsonb, path: jsonpath) -> bool`,
+	2958: `jsonb_path_match_opr(target: jsonb, path: jsonpath) -> bool`,
+	2959: `jsonpath_send(jsonpath: jsonpath) -> bytes`,
+	2960: `jsonpath_recv(input: anyelement) -> jsonpath`,
+	2961: `jsonpath_out(jsonpath: jsonpath) -> bytes`,
+	2962: `jsonpath_in(input: anyelement) -> jsonpath`,
+	2963: `jsonpath(string: string) -> jsonpath`,
+	2964: `jsonpath(jsonpath: jsonpath) -> jsonpath`,
+	2965: `varchar(jsonpath: jsonpath) -> varchar`,
+	2966: `text(jsonpath: jsonpath) -> string`,
+	2967: `bpchar(jsonpath: jsonpath) -> bpchar`,
+	2968: `name(jsonpath: jsonpath) -> name`,
+	2969: `char(jsonpath: jsonpath) -> "char"`,
+	2970: `ts_lexize(dict: string, token: string) -> string[]`,
+	2971: `get_current_ts_config() -> string`,
+	2972: `ts_rank_cd(weights: float[], vector: tsvector, query: tsquery, normalization: int) -> float4`,
+	2973: `ts_rank_cd(weights: float[], vector: tsvector, query: tsquery) -> float4`,
+	2974: `ts_rank_cd(vector: tsvector, query: tsquery, normalization: int) -> float4`,
+	2975: `ts_rank_cd(vector: tsvector, query: tsquery) -> float4`,
+	2976: `ts_headline(config: string, document: string, query: tsquery, options: string) -> string`,
+	2977: `ts_headline(config: string, document: string, query: tsquery) -> string`,
+	2978: `ts_headline(document: string, query: tsquery, options: string) -> string`,
+	2979: `ts_headline(document: string, query: tsquery) -> string`,
+	2980: `websearch_to_tsquery(config: string, text: string) -> tsquery`,
+	2981: `websearch_to_tsquery(text: string) -> tsquery`,
+	2982: `tsquery_phrase(query1: tsquery, query2: tsquery) -> tsquery`,
+	2983: `tsquery_phrase(query1: tsquery, query2: tsquery, distance: int) -> tsquery`,
+	2984: `ts_rewrite(query: tsquery, target: tsquery, substitute: tsquery) -> tsquery`,
+	2985: `ts_rewrite(query: tsquery, select: string) -> tsquery`,
+	2986: `setweight(vector: tsvector, weight: "char") -> tsvector`,
+	2987: `setweight(vector: tsvector, weight: "char", lexemes: string[]) -> tsvector`,
+	2988: `strip(vector: tsvector) -> tsvector`,
+	2989: `array_to_tsvector(lexemes: string[]) -> tsvector`,
+	2990: `tsvector_to_array(vector: tsvector) -> string[]`,
+	2991: `tsvector_update_trigger() -> trigger`,
+	2992: `tsvector_update_trigger_column() -> trigger`,
+	2993: `crdb_internal.tsvector_update_trigger(new: tuple, tg_when: string, tg_level: string, tg_op: string, tg_argv: string[], config_column: bool) -> anyelement`,
+	2994: `ts_rank_tf(vector: tsvector, query: tsquery) -> float`,
+	2995: `ts_rank_tf(vector: tsvector, query: tsquery, avg_doc_length: float) -> float`,
+	2996: `ts_rank_tf(vector: tsvector, query: tsquery, avg_doc_length: float, k1: float, b: float) -> float`,
+	2997: `st_dump(geometry: geometry) -> tuple{int[] AS path, geometry AS geom}`,
+	2998: `st_dumppoints(geometry: geometry) -> tuple{int[] AS path, geometry AS geom}`,
+	2999: `st_dumprings(geometry: geometry) -> tuple{int[] AS path, geometry AS geom}`,
+	3000: `st_concavehull(geometry: geometry, target_percent: float) -> geometry`,
+	3001: `st_concavehull(geometry: geometry, target_percent: float, allow_holes: bool) -> geometry`,
+	3002: `st_split(input: geometry, blade: geometry) -> geometry`,
+	3003: `st_clusterwithin(arg1: geometry, arg2: float) -> geometry[]`,
+	3004: `st_clusterintersecting(arg1: geometry) -> geometry[]`,
+	3005: `st_polygonize(arg1: geometry) -> geometry`,
+	3006: `st_clusterdbscan(geometry: geometry, eps: float, minpoints: int) -> int`,
+	3007: `st_clusterkmeans(geometry: geometry, number_of_clusters: int) -> int`,
+	3008: `st_geomfromgml(val: string) -> geometry`,
+	3009: `st_geomfromgml(str: string, srid: int) -> geometry`,
+	3010: `st_geomfromtwkb(val: bytes) -> geometry`,
+	3011: `st_asgml(geometry: geometry) -> string`,
+	3012: `st_asgml(geometry: geometry, max_decimal_digits: int) -> string`,
+	3013: `st_asgml(geometry: geometry, max_decimal_digits: int, options: int) -> string`,
+	3014: `st_asgml(version: int, geometry: geometry) -> string`,
+	3015: `st_asgml(version: int, geometry: geometry, max_decimal_digits: int) -> string`,
+	3016: `st_asgml(version: int, geometry: geometry, max_decimal_digits: int, options: int) -> string`,
+	3017: `st_asgml(version: int, geometry: geometry, max_decimal_digits: int, options: int, nprefix: string) -> string`,
+	3018: `st_asgml(geography: geography) -> string`,
+	3019: `st_asgml(geography: geography, max_decimal_digits: int) -> string`,
+	3020: `st_asgml(geography: geography, max_decimal_digits: int, options: int) -> string`,
+	3021: `st_asgml(version: int, geography: geography) -> string`,
+	3022: `st_asgml(version: int, geography: geography, max_decimal_digits: int) -> string`,
+	3023: `st_asgml(version: int, geography: geography, max_decimal_digits: int, options: int) -> string`,
+	3024: `st_asgml(version: int, geography: geography, max_decimal_digits: int, options: int, nprefix: string) -> string`,
+	3025: `st_assvg(geometry: geometry) -> string`,
+	3026: `st_assvg(geometry: geometry, rel: int) -> string`,
+	3027: `st_assvg(geometry: geometry, rel: int, max_decimal_digits: int) -> string`,
+	3028: `st_assvg(geography: geography) -> string`,
+	3029: `st_assvg(geography: geography, rel: int) -> string`,
+	3030: `st_assvg(geography: geography, rel: int, max_decimal_digits: int) -> string`,
+	3031: `st_asgml(geometry_str: string) -> string`,
+	3032: `st_asgml(geometry_str: string, max_decimal_digits: int) -> string`,
+	3033: `st_asgml(geometry_str: string, max_decimal_digits: int, options: int) -> string`,
+	3034: `st_asgml(version: int, geometry_str: string) -> string`,
+	3035: `st_asgml(version: int, geometry_str: string, max_decimal_digits: int) -> string`,
+	3036: `st_asgml(version: int, geometry_str: string, max_decimal_digits: int, options: int) -> string`,
+	3037: `st_asgml(version: int, geometry_str: string, max_decimal_digits: int, options: int, nprefix: string) -> string`,
+	3038: `st_assvg(geometry_str: string) -> string`,
+	3039: `st_assvg(geometry_str: string, rel: int) -> string`,
+	3040: `st_assvg(geometry_str: string, rel: int, max_decimal_digits: int) -> string`,
+	3041: `st_gmltosql(val: string) -> geometry`,
+	3042: `st_gmltosql(str: string, srid: int) -> geometry`,
+	3043: `area(val: box) -> float`,
+	3044: `area(val: path) -> float`,
+	3045: `area(val: circle) -> float`,
+	3046: `center(val: box) -> point`,
+	3047: `center(val: circle) -> point`,
+	3048: `diagonal(val: box) -> lseg`,
+	3049: `diameter(val: circle) -> float`,
+	3050: `radius(val: circle) -> float`,
+	3051: `height(val: box) -> float`,
+	3052: `width(val: box) -> float`,
+	3053: `isclosed(val: path) -> bool`,
+	3054: `isopen(val: path) -> bool`,
+	3055: `npoints(val: path) -> int`,
+	3056: `npoints(val: polygon) -> int`,
+	3057: `pclose(val: path) -> path`,
+	3058: `popen(val: path) -> path`,
+	3059: `slope(left: point, right: point) -> float`,
+	3060: `bound_box(left: box, right: box) -> box`,
+	3061: `geometric_intersects(left: lseg, right: lseg) -> bool`,
+	3062: `geometric_intersects(left: lseg, right: box) -> bool`,
+	3063: `geometric_intersects(left: box, right: lseg) -> bool`,
+	3064: `geometric_intersects(left: lseg, right: line) -> bool`,
+	3065: `geometric_intersects(left: line, right: lseg) -> bool`,
+	3066: `geometric_intersects(left: line, right: line) -> bool`,
+	3067: `geometric_intersects(left: line, right: box) -> bool`,
+	3068: `geometric_intersects(left: box, right: line) -> bool`,
+	3069: `geometric_intersects(left: box, right: box) -> bool`,
+	3070: `geometric_intersects(left: path, right: path) -> bool`,
+	3071: `length(val: lseg) -> float`,
+	3072: `length(val: path) -> float`,
+	3073: `point(x: float, y: float) -> point`,
+	3074: `box(p1: point, p2: point) -> box`,
+	3075: `lseg(p1: point, p2: point) -> lseg`,
+	3076: `line(p1: point, p2: point) -> line`,
+	3077: `polygon(npts: int, circle: circle) -> polygon`,
+	3078: `circle(center: point, radius: float) -> circle`,
+	3079: `point_send(point: point) -> bytes`,
+	3080: `point_recv(input: anyelement) -> point`,
+	3081: `point_out(point: point) -> bytes`,
+	3082: `point_in(input: anyelement) -> point`,
+	3083: `point(string: string) -> point`,
+	3084: `point(point: point) -> point`,
+	3085: `varchar(point: point) -> varchar`,
+	3086: `text(point: point) -> string`,
+	3087: `bpchar(point: point) -> bpchar`,
+	3088: `name(point: point) -> name`,
+	3089: `char(point: point) -> "char"`,
+	3090: `box_send(box: box) -> bytes`,
+	3091: `box_recv(input: anyelement) -> box`,
+	3092: `box_out(box: box) -> bytes`,
+	3093: `box_in(input: anyelement) -> box`,
+	3094: `box(string: string) -> box`,
+	3095: `box(box: box) -> box`,
+	3096: `varchar(box: box) -> varchar`,
+	3097: `text(box: box) -> string`,
+	3098: `bpchar(box: box) -> bpchar`,
+	3099: `name(box: box) -> name`,
+	3100: `char(box: box) -> "char"`,
+	3101: `lseg_send(lseg: lseg) -> bytes`,
+	3102: `lseg_recv(input: anyelement) -> lseg`,
+	3103: `lseg_out(lseg: lseg) -> bytes`,
+	3104: `lseg_in(input: anyelement) -> lseg`,
+	3105: `lseg(string: string) -> lseg`,
+	3106: `lseg(lseg: lseg) -> lseg`,
+	3107: `varchar(lseg: lseg) -> varchar`,
+	3108: `text(lseg: lseg) -> string`,
+	3109: `bpchar(lseg: lseg) -> bpchar`,
+	3110: `name(lseg: lseg) -> name`,
+	3111: `char(lseg: lseg) -> "char"`,
+	3112: `line_send(line: line) -> bytes`,
+	3113: `line_recv(input: anyelement) -> line`,
+	3114: `line_out(line: line) -> bytes`,
+	3115: `line_in(input: anyelement) -> line`,
+	3116: `line(string: string) -> line`,
+	3117: `line(line: line) -> line`,
+	3118: `varchar(line: line) -> varchar`,
+	3119: `text(line: line) -> string`,
+	3120: `bpchar(line: line) -> bpchar`,
+	3121: `name(line: line) -> name`,
+	3122: `char(line: line) -> "char"`,
+	3123: `path_send(path: path) -> bytes`,
+	3124: `path_recv(input: anyelement) -> path`,
+	3125: `path_out(path: path) -> bytes`,
+	3126: `path_in(input: anyelement) -> path`,
+	3127: `path(string: string) -> path`,
+	3128: `path(path: path) -> path`,
+	3129: `varchar(path: path) -> varchar`,
+	3130: `text(path: path) -> string`,
+	3131: `bpchar(path: path) -> bpchar`,
+	3132: `name(path: path) -> name`,
+	3133: `char(path: path) -> "char"`,
+	3134: `polygon_send(polygon: polygon) -> bytes`,
+	3135: `polygon_recv(input: anyelement) -> polygon`,
+	3136: `polygon_out(polygon: polygon) -> bytes`,
+	3137: `polygon_in(input: anyelement) -> polygon`,
+	3138: `polygon(string: string) -> polygon`,
+	3139: `polygon(polygon: polygon) -> polygon`,
+	3140: `varchar(polygon: polygon) -> varchar`,
+	3141: `text(polygon: polygon) -> string`,
+	3142: `bpchar(polygon: polygon) -> bpchar`,
+	3143: `name(polygon: polygon) -> name`,
+	3144: `char(polygon: polygon) -> "char"`,
+	3145: `circle_send(circle: circle) -> bytes`,
+	3146: `circle_recv(input: anyelement) -> circle`,
+	3147: `circle_out(circle: circle) -> bytes`,
+	3148: `circle_in(input: anyelement) -> circle`,
+	3149: `circle(string: string) -> circle`,
+	3150: `circle(circle: circle) -> circle`,
+	3151: `varchar(circle: circle) -> varchar`,
+	3152: `text(circle: circle) -> string`,
+	3153: `bpchar(circle: circle) -> bpchar`,
+	3154: `name(circle: circle) -> name`,
+	3155: `char(circle: circle) -> "char"`,
+	3156: `box(point: point) -> box`,
+	3157: `geometry(point: point) -> geometry`,
+	3158: `point(box: box) -> point`,
+	3159: `lseg(box: box) -> lseg`,
+	3160: `circle(box: box) -> circle`,
+	3161: `polygon(box: box) -> polygon`,
+	3162: `point(lseg: lseg) -> point`,
+	3163: `polygon(path: path) -> polygon`,
+	3164: `geometry(path: path) -> geometry`,
+	3165: `point(polygon: polygon) -> point`,
+	3166: `box(polygon: polygon) -> box`,
+	3167: `circle(polygon: polygon) -> circle`,
+	3168: `geometry(polygon: polygon) -> geometry`,
+	3169: `path(polygon: polygon) -> path`,
+	3170: `point(circle: circle) -> point`,
+	3171: `box(circle: circle) -> box`,
+	3172: `polygon(circle: circle) -> polygon`,
+	3173: `point(geometry: geometry) -> point`,
+	3174: `path(geometry: geometry) -> path`,
+	3175: `polygon(geometry: geometry) -> polygon`,
+	3176: `network(val: inet) -> cidr`,
+	3177: `inet_merge(val: inet, val: inet) -> cidr`,
+	3178: `trunc(val: macaddr) -> macaddr`,
+	3179: `trunc(val: macaddr8) -> macaddr8`,
+	3180: `macaddr8_set7bit(val: macaddr8) -> macaddr8`,
+	3181: `cash_words(val: money) -> string`,
+	3182: `sum(arg1: money) -> money`,
+	3183: `cidr_send(cidr: cidr) -> bytes`,
+	3184: `cidr_recv(input: anyelement) -> cidr`,
+	3185: `cidr_out(cidr: cidr) -> bytes`,
+	3186: `cidr_in(input: anyelement) -> cidr`,
+	3187: `cidr(string: string) -> cidr`,
+	3188: `cidr(inet: inet) -> cidr`,
+	3189: `macaddr_send(macaddr: macaddr) -> bytes`,
+	3190: `macaddr_recv(input: anyelement) -> macaddr`,
+	3191: `macaddr_out(macaddr: macaddr) -> bytes`,
+	3192: `macaddr_in(input: anyelement) -> macaddr`,
+	3193: `macaddr(string: string) -> macaddr`,
+	3194: `macaddr(macaddr8: macaddr8) -> macaddr`,
+	3195: `macaddr(macaddr: macaddr) -> macaddr`,
+	3196: `varchar(macaddr: macaddr) -> varchar`,
+	3197: `text(macaddr: macaddr) -> string`,
+	3198: `bpchar(macaddr: macaddr) -> bpchar`,
+	3199: `name(macaddr: macaddr) -> name`,
+	3200: `char(macaddr: macaddr) -> "char"`,
+	3201: `max(arg1: macaddr) -> anyelement`,
+	3202: `percentile_disc_impl(arg1: float, arg2: macaddr) -> macaddr`,
+	3203: `percentile_disc_impl(arg1: float[], arg2: macaddr) -> macaddr[]`,
+	3204: `min(arg1: macaddr) -> anyelement`,
+	3205: `array_cat_agg(arg1: macaddr[]) -> macaddr[]`,
+	3206: `array_agg(arg1: macaddr) -> macaddr[]`,
+	3207: `array_prepend(elem: macaddr, array: macaddr[]) -> macaddr[]`,
+	3208: `array_remove(array: macaddr[], elem: macaddr) -> anyelement`,
+	3209: `array_positions(array: macaddr[], elem: macaddr) -> int[]`,
+	3210: `array_cat(left: macaddr[], right: macaddr[]) -> macaddr[]`,
+	3211: `array_position(array: macaddr[], elem: macaddr) -> int`,
+	3212: `array_replace(array: macaddr[], toreplace: macaddr, replacewith: macaddr) -> anyelement`,
+	3213: `array_append(array: macaddr[], elem: macaddr) -> macaddr[]`,
+	3214: `first_value(val: macaddr) -> macaddr`,
+	3215: `nth_value(val: macaddr, n: int) -> macaddr`,
+	3216: `lag(val: macaddr) -> macaddr`,
+	3217: `lag(val: macaddr, n: int) -> macaddr`,
+	3218: `lag(val: macaddr, n: int, default: macaddr) -> macaddr`,
+	3219: `lead(val: macaddr) -> macaddr`,
+	3220: `lead(val: macaddr, n: int) -> macaddr`,
+	3221: `lead(val: macaddr, n: int, default: macaddr) -> macaddr`,
+	3222: `last_value(val: macaddr) -> macaddr`,
+	3223: `array_position(array: macaddr[], elem: macaddr, start: int) -> int`,
+	3224: `array_agg(arg1: macaddr[]) -> macaddr[][]`,
+	3225: `macaddr8_send(macaddr8: macaddr8) -> bytes`,
+	3226: `macaddr8_recv(input: anyelement) -> macaddr8`,
+	3227: `macaddr8_out(macaddr8: macaddr8) -> bytes`,
+	3228: `macaddr8_in(input: anyelement) -> macaddr8`,
+	3229: `macaddr8(string: string) -> macaddr8`,
+	3230: `macaddr8(macaddr: macaddr) -> macaddr8`,
+	3231: `macaddr8(macaddr8: macaddr8) -> macaddr8`,
+	3232: `varchar(macaddr8: macaddr8) -> varchar`,
+	3233: `text(macaddr8: macaddr8) -> string`,
+	3234: `bpchar(macaddr8: macaddr8) -> bpchar`,
+	3235: `name(macaddr8: macaddr8) -> name`,
+	3236: `char(macaddr8: macaddr8) -> "char"`,
+	3237: `max(arg1: macaddr8) -> anyelement`,
+	3238: `percentile_disc_impl(arg1: float, arg2: macaddr8) -> macaddr8`,
+	3239: `percentile_disc_impl(arg1: float[], arg2: macaddr8) -> macaddr8[]`,
+	3240: `min(arg1: macaddr8) -> anyelement`,
+	3241: `array_cat_agg(arg1: macaddr8[]) -> macaddr8[]`,
+	3242: `array_agg(arg1: macaddr8) -> macaddr8[]`,
+	3243: `array_prepend(elem: macaddr8, array: macaddr8[]) -> macaddr8[]`,
+	3244: `array_remove(array: macaddr8[], elem: macaddr8) -> anyelement`,
+	3245: `array_positions(array: macaddr8[], elem: macaddr8) -> int[]`,
+	3246: `array_cat(left: macaddr8[], right: macaddr8[]) -> macaddr8[]`,
+	3247: `array_position(array: macaddr8[], elem: macaddr8) -> int`,
+	3248: `array_replace(array: macaddr8[], toreplace: macaddr8, replacewith: macaddr8) -> anyelement`,
+	3249: `array_append(array: macaddr8[], elem: macaddr8) -> macaddr8[]`,
+	3250: `first_value(val: macaddr8) -> macaddr8`,
+	3251: `nth_value(val: macaddr8, n: int) -> macaddr8`,
+	3252: `lag(val: macaddr8) -> macaddr8`,
+	3253: `lag(val: macaddr8, n: int) -> macaddr8`,
+	3254: `lag(val: macaddr8, n: int, default: macaddr8) -> macaddr8`,
+	3255: `lead(val: macaddr8) -> macaddr8`,
+	3256: `lead(val: macaddr8, n: int) -> macaddr8`,
+	3257: `lead(val: macaddr8, n: int, default: macaddr8) -> macaddr8`,
+	3258: `last_value(val: macaddr8) -> macaddr8`,
+	3259: `array_position(array: macaddr8[], elem: macaddr8, start: int) -> int`,
+	3260: `array_agg(arg1: macaddr8[]) -> macaddr8[][]`,
+	3261: `money_send(money: money) -> bytes`,
+	3262: `money_recv(input: anyelement) -> money`,
+	3263: `money_out(money: money) -> bytes`,
+	3264: `money_in(input: anyelement) -> money`,
+	3265: `money(string: string) -> money`,
+	3266: `money(int: int) -> money`,
+	3267: `money(decimal: decimal) -> money`,
+	3268: `money(money: money) -> money`,
+	3269: `numeric(money: money) -> decimal`,
+	3270: `varchar(money: money) -> varchar`,
+	3271: `text(money: money) -> string`,
+	3272: `bpchar(money: money) -> bpchar`,
+	3273: `name(money: money) -> name`,
+	3274: `char(money: money) -> "char"`,
+	3275: `max(arg1: money) -> anyelement`,
+	3276: `percentile_disc_impl(arg1: float, arg2: money) -> money`,
+	3277: `percentile_disc_impl(arg1: float[], arg2: money) -> money[]`,
+	3278: `min(arg1: money) -> anyelement`,
+	3279: `array_cat_agg(arg1: money[]) -> money[]`,
+	3280: `array_agg(arg1: money) -> money[]`,
+	3281: `array_prepend(elem: money, array: money[]) -> money[]`,
+	3282: `array_remove(array: money[], elem: money) -> anyelement`,
+	3283: `array_positions(array: money[], elem: money) -> int[]`,
+	3284: `array_cat(left: money[], right: money[]) -> money[]`,
+	3285: `array_position(array: money[], elem: money) -> int`,
+	3286: `array_replace(array: money[], toreplace: money, replacewith: money) -> anyelement`,
+	3287: `array_append(array: money[], elem: money) -> money[]`,
+	3288: `first_value(val: money) -> money`,
+	3289: `nth_value(val: money, n: int) -> money`,
+	3290: `lag(val: money) -> money`,
+	3291: `lag(val: money, n: int) -> money`,
+	3292: `lag(val: money, n: int, default: money) -> money`,
+	3293: `lead(val: money) -> money`,
+	3294: `lead(val: money, n: int) -> money`,
+	3295: `lead(val: money, n: int, default: money) -> money`,
+	3296: `last_value(val: money) -> money`,
+	3297: `array_position(array: money[], elem: money, start: int) -> int`,
+	3298: `array_agg(arg1: money[]) -> money[][]`,
+	3299: `ts_rank_bm25(vector: tsvector, query: tsquery, table: regclass, index: string) -> float`,
+	3300: `ts_rank_bm25(vector: tsvector, query: tsquery, table: regclass, index: string, k1: float, b: float) -> float`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
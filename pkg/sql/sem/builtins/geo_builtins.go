@@ -469,6 +469,60 @@ func (s *subdividedGeometriesGen) Next(_ context.Context) (bool, error) {
 	return s.curr < len(s.geometries), nil
 }
 
+var dumpedGeometriesReturnType = types.MakeLabeledTuple(
+	[]*types.T{types.IntArray, types.Geometry},
+	[]string{"path", "geom"},
+)
+
+func makeDumpedGeometriesGeneratorFactory(
+	dumpFn func(geo.Geometry) ([]geomfn.DumpedGeometry, error),
+) eval.GeneratorOverload {
+	return func(
+		_ context.Context, _ *eval.Context, args tree.Datums,
+	) (eval.ValueGenerator, error) {
+		geometry := tree.MustBeDGeometry(args[0])
+		results, err := dumpFn(geometry.Geometry)
+		if err != nil {
+			return nil, err
+		}
+		return &dumpedGeometriesGen{
+			geometries: results,
+			curr:       -1,
+		}, nil
+	}
+}
+
+// dumpedGeometriesGen implements the eval.ValueGenerator interface
+type dumpedGeometriesGen struct {
+	geometries []geomfn.DumpedGeometry
+	curr       int
+}
+
+func (d *dumpedGeometriesGen) ResolvedType() *types.T { return dumpedGeometriesReturnType }
+
+func (d *dumpedGeometriesGen) Close(_ context.Context) {}
+
+func (d *dumpedGeometriesGen) Start(_ context.Context, _ *kv.Txn) error {
+	d.curr = -1
+	return nil
+}
+
+func (d *dumpedGeometriesGen) Values() (tree.Datums, error) {
+	dumped := d.geometries[d.curr]
+	path := tree.NewDArray(types.Int)
+	for _, idx := range dumped.Path {
+		if err := path.Append(tree.NewDInt(tree.DInt(idx))); err != nil {
+			return nil, err
+		}
+	}
+	return tree.Datums{path, tree.NewDGeometry(dumped.Geometry)}, nil
+}
+
+func (d *dumpedGeometriesGen) Next(_ context.Context) (bool, error) {
+	d.curr++
+	return d.curr < len(d.geometries), nil
+}
+
 var geoBuiltins = map[string]builtinDefinition{
 	//
 	// Meta builtins.
@@ -796,6 +850,61 @@ var geoBuiltins = map[string]builtinDefinition{
 			volatility.Immutable,
 		),
 	),
+	"st_geomfromgml": makeBuiltin(
+		defProps(),
+		stringOverload1(
+			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+				g, err := geo.ParseGeometryFromGML(s)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(g), nil
+			},
+			types.Geometry,
+			infoBuilder{
+				info: "Returns the Geometry from a GML representation. Both GML 2 and GML 3 are supported. " +
+					"The SRID is taken from the srsName attribute, if any.",
+			}.String(),
+			volatility.Immutable,
+		),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "str", Typ: types.String}, {Name: "srid", Typ: types.Int}},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				s := string(tree.MustBeDString(args[0]))
+				srid := geopb.SRID(tree.MustBeDInt(args[1]))
+				g, err := geo.ParseGeometryFromGMLAndSRID(s, srid)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(g), nil
+			},
+			Info: infoBuilder{
+				info: "Returns the Geometry from a GML representation with the given SRID set, overriding the srsName attribute. " +
+					"Both GML 2 and GML 3 are supported.",
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
+	"st_geomfromtwkb": makeBuiltin(
+		defProps(),
+		bytesOverload1(
+			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+				t, err := twkb.Unmarshal([]byte(s))
+				if err != nil {
+					return nil, err
+				}
+				g, err := geo.MakeGeometryFromGeomT(t)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(g), nil
+			},
+			types.Geometry,
+			infoBuilder{info: "Returns the Geometry from a TWKB representation."}.String(),
+			volatility.Immutable,
+		),
+	),
 	"st_makepoint": makeBuiltin(
 		defProps(),
 		tree.Overload{
@@ -1693,6 +1802,28 @@ var geoBuiltins = map[string]builtinDefinition{
 			volatility.Immutable,
 		),
 	),
+	"st_asgml": makeBuiltin(
+		defProps(),
+		append(
+			stAsGMLOverloads(types.Geometry, "Geometry", func(d tree.Datum) geopb.SpatialObject {
+				return tree.MustBeDGeometry(d).Geometry.SpatialObject()
+			}),
+			stAsGMLOverloads(types.Geography, "Geography", func(d tree.Datum) geopb.SpatialObject {
+				return tree.MustBeDGeography(d).Geography.SpatialObject()
+			})...,
+		)...,
+	),
+	"st_assvg": makeBuiltin(
+		defProps(),
+		append(
+			stAsSVGOverloads(types.Geometry, "Geometry", func(d tree.Datum) geopb.SpatialObject {
+				return tree.MustBeDGeometry(d).Geometry.SpatialObject()
+			}),
+			stAsSVGOverloads(types.Geography, "Geography", func(d tree.Datum) geopb.SpatialObject {
+				return tree.MustBeDGeography(d).Geography.SpatialObject()
+			})...,
+		)...,
+	),
 	"st_geohash": makeBuiltin(
 		defProps(),
 		geometryOverload1(
@@ -4320,6 +4451,57 @@ Note the geometry must be a LineString with M coordinates.`,
 			volatility.Immutable,
 		),
 	),
+	"st_concavehull": makeBuiltin(
+		defProps(),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "target_percent", Typ: types.Float},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				ratio := float64(tree.MustBeDFloat(args[1]))
+				ret, err := geomfn.ConcaveHull(g.Geometry, ratio, false /* allowHoles */)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: "Returns a geometry that represents a possibly concave hull of the given geometry. " +
+					"target_percent is the fraction of the area of the convex hull that the result approaches, " +
+					"with 1 producing the convex hull.",
+				libraryUsage: usesGEOS,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "target_percent", Typ: types.Float},
+				{Name: "allow_holes", Typ: types.Bool},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				ratio := float64(tree.MustBeDFloat(args[1]))
+				allowHoles := bool(tree.MustBeDBool(args[2]))
+				ret, err := geomfn.ConcaveHull(g.Geometry, ratio, allowHoles)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: "Returns a geometry that represents a possibly concave hull of the given geometry. " +
+					"target_percent is the fraction of the area of the convex hull that the result approaches, " +
+					"with 1 producing the convex hull. If allow_holes is true, the result may contain holes.",
+				libraryUsage: usesGEOS,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
 	"st_difference": makeBuiltin(
 		defProps(),
 		geometryOverload2(
@@ -6168,6 +6350,70 @@ See http://developers.google.com/maps/documentation/utilities/polylinealgorithm`
 			volatility.Immutable,
 		),
 	),
+	"st_dump": makeBuiltin(
+		genProps(),
+		makeGeneratorOverload(
+			tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+			},
+			dumpedGeometriesReturnType,
+			makeDumpedGeometriesGeneratorFactory(geomfn.Dump),
+			"Returns a set of records containing the non-collection geometries which make up the given geometry, "+
+				"along with the path of each geometry within the collections it is nested in.",
+			volatility.Immutable,
+		),
+	),
+	"st_dumppoints": makeBuiltin(
+		genProps(),
+		makeGeneratorOverload(
+			tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+			},
+			dumpedGeometriesReturnType,
+			makeDumpedGeometriesGeneratorFactory(geomfn.DumpPoints),
+			"Returns a set of records containing the vertices of the given geometry as points, "+
+				"along with the path of each vertex within the geometry.",
+			volatility.Immutable,
+		),
+	),
+	"st_dumprings": makeBuiltin(
+		genProps(),
+		makeGeneratorOverload(
+			tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+			},
+			dumpedGeometriesReturnType,
+			makeDumpedGeometriesGeneratorFactory(geomfn.DumpRings),
+			"Returns a set of records containing the rings of the given polygon as polygons. "+
+				"The path of the exterior ring is {0}, and the path of each interior ring is its index.",
+			volatility.Immutable,
+		),
+	),
+	"st_split": makeBuiltin(
+		defProps(),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "input", Typ: types.Geometry},
+				{Name: "blade", Typ: types.Geometry},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				blade := tree.MustBeDGeometry(args[1])
+				ret, err := geomfn.Split(g.Geometry, blade.Geometry)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: "Returns a GeometryCollection of the parts of the input geometry after it is split by the blade. " +
+					"LineStrings can be split by Points, LineStrings and Polygons, and Polygons can be split by LineStrings.",
+				libraryUsage: usesGEOS,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
 
 	//
 	// BoundingBox
@@ -7266,36 +7512,21 @@ Can be used to define the tile bounds required by ST_AsMVTGeom to convert geomet
 	// Unimplemented.
 	//
 
-	"st_asgml":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48877}),
 	"st_aslatlontext":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48882}),
-	"st_assvg":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48883}),
 	"st_boundingdiagonal":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48889}),
 	"st_buildarea":           makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48892}),
 	"st_chaikinsmoothing":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48894}),
 	"st_cleangeometry":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48895}),
-	"st_clusterdbscan":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48898}),
-	"st_clusterintersecting": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48899}),
-	"st_clusterkmeans":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48900}),
-	"st_clusterwithin":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48901}),
-	"st_concavehull":         makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48906}),
 	"st_delaunaytriangles":   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48915}),
-	"st_dump":                makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49785}),
-	"st_dumppoints":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49786}),
-	"st_dumprings":           makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49787}),
 	"st_geometricmedian":     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48944}),
 	"st_interpolatepoint":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48950}),
 	"st_isvaliddetail":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48962}),
 	"st_length2dspheroid":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48967}),
 	"st_lengthspheroid":      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48968}),
-	"st_polygonize":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49011}),
 	"st_quantizecoordinates": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49012}),
 	"st_seteffectivearea":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49030}),
 	"st_simplifyvw":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49039}),
-	"st_split":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49045}),
 	"st_wrapx":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49068}),
-	"st_geomfromgml":         makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48807}),
-	"st_geomfromtwkb":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48809}),
-	"st_gmltosql":            makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48810}),
 }
 
 var compatFixedStringInfo = infoBuilder{
@@ -7532,6 +7763,7 @@ func init() {
 		{"st_numinteriorring", "st_numinteriorrings"},
 		{"st_symmetricdifference", "st_symdifference"},
 		{"st_force3d", "st_force3dz"},
+		{"st_gmltosql", "st_geomfromgml"},
 	} {
 		if _, ok := geoBuiltins[alias.builtinName]; !ok {
 			panic("expected builtin definition for alias: " + alias.builtinName)
@@ -7572,11 +7804,9 @@ func init() {
 		"st_area",
 		"st_asewkt",
 		"st_asgeojson",
-		// TODO(#48877): uncomment
-		// "st_asgml",
+		"st_asgml",
 		"st_askml",
-		// TODO(#48883): uncomment
-		// "st_assvg",
+		"st_assvg",
 		// TODO(#48886): uncomment
 		// "st_astwkb",
 		"st_astext",
@@ -7771,6 +8001,138 @@ This variant will cast all geometry_str arguments into Geometry types.
 	return makeBuiltin(def.props, newOverloads...)
 }
 
+// stAsGMLOverloads returns the overloads of st_asgml for the given spatial
+// type, with and without the leading GML version argument.
+func stAsGMLOverloads(
+	typ *types.T, typName string, spatialObject func(tree.Datum) geopb.SpatialObject,
+) []tree.Overload {
+	paramName := strings.ToLower(typName)
+	optionalParams := tree.ParamTypes{
+		{Name: "max_decimal_digits", Typ: types.Int},
+		{Name: "options", Typ: types.Int},
+		{Name: "nprefix", Typ: types.String},
+	}
+	var ret []tree.Overload
+	for _, withVersion := range []bool{false, true} {
+		var params tree.ParamTypes
+		if withVersion {
+			params = append(params, tree.ParamType{Name: "version", Typ: types.Int})
+		}
+		soIdx := len(params)
+		params = append(params, tree.ParamType{Name: paramName, Typ: typ})
+		// The namespace prefix can only be specified along with the version.
+		numOptional := len(optionalParams) - 1
+		if withVersion {
+			numOptional = len(optionalParams)
+		}
+		for n := 0; n <= numOptional; n++ {
+			ovParams := append(append(tree.ParamTypes(nil), params...), optionalParams[:n]...)
+			info := fmt.Sprintf("Returns the GML representation of a given %s.", typName)
+			if withVersion {
+				info += " The version may be 2 (the default) or 3."
+			} else {
+				info += " GML 2 is used."
+			}
+			if n == 0 {
+				info += fmt.Sprintf(" A maximum of %d decimal digits is used.", defaultWKTDecimalDigits)
+			}
+			if n >= 2 {
+				info += `
+
+Options is a flag that can be bitmasked. The options are:
+* 0: no option
+* 1: use the long CRS form (e.g. urn:ogc:def:crs:EPSG::4326) rather than the short one (e.g. EPSG:4326)
+* 2: GML 3 only, omit the srsDimension attribute
+* 4: GML 3 only, use <LineString> rather than <Curve> for lines
+* 16: write the coordinates in lat/lng order
+* 32: write the bounding box (envelope) of the spatial object
+`
+			}
+			if n >= 3 {
+				info += "\nElements are qualified with the namespace prefix nprefix, unless it is empty."
+			}
+			ret = append(ret, tree.Overload{
+				Types:      ovParams,
+				ReturnType: tree.FixedReturnType(types.String),
+				Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+					version := geo.DefaultGMLVersion
+					if withVersion {
+						version = int(tree.MustBeDInt(args[0]))
+					}
+					so := spatialObject(args[soIdx])
+					maxDecimalDigits := defaultWKTDecimalDigits
+					flag := geo.SpatialObjectToGMLFlag(geo.SpatialObjectToGMLFlagZero)
+					prefix := geo.DefaultGMLPrefix
+					optionalArgs := args[soIdx+1:]
+					if len(optionalArgs) > 0 {
+						maxDecimalDigits = fitMaxDecimalDigitsToBounds(int(tree.MustBeDInt(optionalArgs[0])))
+					}
+					if len(optionalArgs) > 1 {
+						flag = geo.SpatialObjectToGMLFlag(tree.MustBeDInt(optionalArgs[1]))
+					}
+					if len(optionalArgs) > 2 {
+						prefix = string(tree.MustBeDString(optionalArgs[2]))
+					}
+					ret, err := geo.SpatialObjectToGML(so, version, maxDecimalDigits, flag, prefix)
+					if err != nil {
+						return nil, err
+					}
+					return tree.NewDString(ret), nil
+				},
+				Info:       infoBuilder{info: info}.String(),
+				Volatility: volatility.Immutable,
+			})
+		}
+	}
+	return ret
+}
+
+// stAsSVGOverloads returns the overloads of st_assvg for the given spatial
+// type.
+func stAsSVGOverloads(
+	typ *types.T, typName string, spatialObject func(tree.Datum) geopb.SpatialObject,
+) []tree.Overload {
+	params := tree.ParamTypes{
+		{Name: strings.ToLower(typName), Typ: typ},
+		{Name: "rel", Typ: types.Int},
+		{Name: "max_decimal_digits", Typ: types.Int},
+	}
+	ret := make([]tree.Overload, len(params))
+	for n := range ret {
+		info := fmt.Sprintf("Returns the SVG path data of a given %s. ", typName) +
+			"Points are written as the cx and cy attributes of a circle, and the y coordinates are negated."
+		if n >= 1 {
+			info += " If rel is non-zero, paths are written using relative moves, " +
+				"and points are written as the x and y attributes of a use element."
+		}
+		if n < 2 {
+			info += fmt.Sprintf(" A maximum of %d decimal digits is used.", defaultWKTDecimalDigits)
+		}
+		ret[n] = tree.Overload{
+			Types:      params[:n+1],
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				relative := false
+				maxDecimalDigits := defaultWKTDecimalDigits
+				if len(args) > 1 {
+					relative = tree.MustBeDInt(args[1]) != 0
+				}
+				if len(args) > 2 {
+					maxDecimalDigits = fitMaxDecimalDigitsToBounds(int(tree.MustBeDInt(args[2])))
+				}
+				ret, err := geo.SpatialObjectToSVG(spatialObject(args[0]), relative, maxDecimalDigits)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDString(ret), nil
+			},
+			Info:       infoBuilder{info: info}.String(),
+			Volatility: volatility.Immutable,
+		}
+	}
+	return ret
+}
+
 // stAsGeoJSONFromTuple returns a *tree.DString representing JSON output
 // for ST_AsGeoJSON.
 func stAsGeoJSONFromTuple(
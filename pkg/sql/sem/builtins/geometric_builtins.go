@@ -0,0 +1,304 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/geometric"
+)
+
+func init() {
+	for k, v := range geometricBuiltins {
+		v.props.Category = builtinconstants.CategoryGeometric
+		v.props.AvailableOnPublicSchema = true
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+// geometricOverload1 returns an overload of a function of a single geometric
+// value.
+func geometricOverload1(
+	typ, ret *types.T, fn func(tree.Datum) (tree.Datum, error), info string,
+) tree.Overload {
+	return tree.Overload{
+		Types:      tree.ParamTypes{{Name: "val", Typ: typ}},
+		ReturnType: tree.FixedReturnType(ret),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			return fn(args[0])
+		},
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}
+}
+
+// geometricOverload2 returns an overload of a function of two geometric
+// values.
+func geometricOverload2(
+	left, right, ret *types.T, fn func(left, right tree.Datum) (tree.Datum, error), info string,
+) tree.Overload {
+	return tree.Overload{
+		Types:      tree.ParamTypes{{Name: "left", Typ: left}, {Name: "right", Typ: right}},
+		ReturnType: tree.FixedReturnType(ret),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			return fn(args[0], args[1])
+		},
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}
+}
+
+// makeGeometricIntersectsOverloads returns the overloads of the function
+// implementing the ?# operator, which returns whether two geometric values
+// intersect. Both orders of the operands are supported for different types.
+func makeGeometricIntersectsOverloads() []tree.Overload {
+	const info = "Returns whether the two values intersect. This is the ?# operator."
+	var overloads []tree.Overload
+	add := func(left, right *types.T, fn func(left, right tree.Datum) bool) {
+		overloads = append(overloads, geometricOverload2(left, right, types.Bool,
+			func(l, r tree.Datum) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(fn(l, r))), nil
+			}, info))
+		if !left.Identical(right) {
+			overloads = append(overloads, geometricOverload2(right, left, types.Bool,
+				func(l, r tree.Datum) (tree.Datum, error) {
+					return tree.MakeDBool(tree.DBool(fn(r, l))), nil
+				}, info))
+		}
+	}
+	add(types.LSeg, types.LSeg, func(left, right tree.Datum) bool {
+		return tree.MustBeDLSeg(left).LSeg.Intersects(tree.MustBeDLSeg(right).LSeg)
+	})
+	add(types.LSeg, types.Box, func(left, right tree.Datum) bool {
+		return tree.MustBeDLSeg(left).LSeg.IntersectsBox(tree.MustBeDBox(right).Box)
+	})
+	add(types.LSeg, types.Line, func(left, right tree.Datum) bool {
+		return tree.MustBeDLSeg(left).LSeg.IntersectsLine(tree.MustBeDLine(right).Line)
+	})
+	add(types.Line, types.Line, func(left, right tree.Datum) bool {
+		return tree.MustBeDLine(left).Line.Intersects(tree.MustBeDLine(right).Line)
+	})
+	add(types.Line, types.Box, func(left, right tree.Datum) bool {
+		return tree.MustBeDLine(left).Line.IntersectsBox(tree.MustBeDBox(right).Box)
+	})
+	add(types.Box, types.Box, func(left, right tree.Datum) bool {
+		return tree.MustBeDBox(left).Box.Overlaps(tree.MustBeDBox(right).Box)
+	})
+	add(types.Path, types.Path, func(left, right tree.Datum) bool {
+		return tree.MustBeDPath(left).Path.Intersects(tree.MustBeDPath(right).Path)
+	})
+	return overloads
+}
+
+// makeGeometricLengthOverloads returns the overloads of the length function
+// for line segments and paths.
+func makeGeometricLengthOverloads() []tree.Overload {
+	return []tree.Overload{
+		geometricOverload1(types.LSeg, types.Float, func(d tree.Datum) (tree.Datum, error) {
+			return tree.NewDFloat(tree.DFloat(tree.MustBeDLSeg(d).LSeg.Length())), nil
+		}, "Returns the length of the line segment."),
+		geometricOverload1(types.Path, types.Float, func(d tree.Datum) (tree.Datum, error) {
+			return tree.NewDFloat(tree.DFloat(tree.MustBeDPath(d).Path.Length())), nil
+		}, "Returns the total length of the segments of the path."),
+	}
+}
+
+// addGeometricConstructorOverloads adds the overloads of the constructor
+// function of a geometric type to def, which is the cast builtin named after
+// the type.
+func addGeometricConstructorOverloads(def *builtinDefinition, t *types.T) {
+	def.props.Category = builtinconstants.CategoryGeometric
+	def.props.Undocumented = false
+	def.overloads = append(def.overloads, makeGeometricConstructorOverloads(t)...)
+}
+
+func makeGeometricConstructorOverloads(t *types.T) []tree.Overload {
+	twoPoints := func(
+		info string, fn func(p1, p2 geometric.Point) (tree.Datum, error),
+	) tree.Overload {
+		return tree.Overload{
+			Types:      tree.ParamTypes{{Name: "p1", Typ: types.Point}, {Name: "p2", Typ: types.Point}},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(tree.MustBeDPoint(args[0]).Point, tree.MustBeDPoint(args[1]).Point)
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		}
+	}
+	switch t.Family() {
+	case types.PointFamily:
+		return []tree.Overload{{
+			Types:      tree.ParamTypes{{Name: "x", Typ: types.Float}, {Name: "y", Typ: types.Float}},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDPoint(geometric.Point{
+					X: float64(tree.MustBeDFloat(args[0])),
+					Y: float64(tree.MustBeDFloat(args[1])),
+				}), nil
+			},
+			Info:       "Constructs a point from its coordinates.",
+			Volatility: volatility.Immutable,
+		}}
+	case types.BoxFamily:
+		return []tree.Overload{twoPoints(
+			"Constructs a box from two opposite corners.",
+			func(p1, p2 geometric.Point) (tree.Datum, error) {
+				return tree.NewDBox(geometric.MakeBox(p1, p2)), nil
+			},
+		)}
+	case types.LSegFamily:
+		return []tree.Overload{twoPoints(
+			"Constructs a line segment from its endpoints.",
+			func(p1, p2 geometric.Point) (tree.Datum, error) {
+				return tree.NewDLSeg(geometric.LSeg{P: [2]geometric.Point{p1, p2}}), nil
+			},
+		)}
+	case types.LineFamily:
+		return []tree.Overload{twoPoints(
+			"Constructs the line going through two distinct points.",
+			func(p1, p2 geometric.Point) (tree.Datum, error) {
+				if p1 == p2 {
+					return nil, pgerror.New(pgcode.InvalidParameterValue,
+						"invalid line specification: must be two distinct points")
+				}
+				return tree.NewDLine(geometric.MakeLine(p1, p2)), nil
+			},
+		)}
+	case types.PolygonFamily:
+		return []tree.Overload{{
+			Types:      tree.ParamTypes{{Name: "npts", Typ: types.Int}, {Name: "circle", Typ: types.Circle}},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				p, err := tree.MustBeDCircle(args[1]).Circle.Polygon(int(tree.MustBeDInt(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDPolygon(p), nil
+			},
+			Info:       "Approximates the circle by a polygon with `npts` vertices.",
+			Volatility: volatility.Immutable,
+		}}
+	case types.CircleFamily:
+		return []tree.Overload{{
+			Types:      tree.ParamTypes{{Name: "center", Typ: types.Point}, {Name: "radius", Typ: types.Float}},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				r := float64(tree.MustBeDFloat(args[1]))
+				if r < 0 {
+					return nil, pgerror.New(pgcode.InvalidParameterValue,
+						"circle radius cannot be negative")
+				}
+				return tree.NewDCircle(geometric.Circle{Center: tree.MustBeDPoint(args[0]).Point, Radius: r}), nil
+			},
+			Info:       "Constructs a circle from its center and radius.",
+			Volatility: volatility.Immutable,
+		}}
+	}
+	return nil
+}
+
+var geometricBuiltins = map[string]builtinDefinition{
+	"area": makeBuiltin(defProps(),
+		geometricOverload1(types.Box, types.Float, func(d tree.Datum) (tree.Datum, error) {
+			return tree.NewDFloat(tree.DFloat(tree.MustBeDBox(d).Box.Area())), nil
+		}, "Returns the area of the box."),
+		geometricOverload1(types.Path, types.Float, func(d tree.Datum) (tree.Datum, error) {
+			a, ok := tree.MustBeDPath(d).Path.Area()
+			if !ok {
+				return tree.DNull, nil
+			}
+			return tree.NewDFloat(tree.DFloat(a)), nil
+		}, "Returns the area enclosed by the path, or NULL if the path is open."),
+		geometricOverload1(types.Circle, types.Float, func(d tree.Datum) (tree.Datum, error) {
+			return tree.NewDFloat(tree.DFloat(tree.MustBeDCircle(d).Circle.Area())), nil
+		}, "Returns the area of the circle."),
+	),
+	"center": makeBuiltin(defProps(),
+		geometricOverload1(types.Box, types.Point, func(d tree.Datum) (tree.Datum, error) {
+			return tree.NewDPoint(tree.MustBeDBox(d).Box.Center()), nil
+		}, "Returns the center of the box."),
+		geometricOverload1(types.Circle, types.Point, func(d tree.Datum) (tree.Datum, error) {
+			return tree.NewDPoint(tree.MustBeDCircle(d).Circle.Center), nil
+		}, "Returns the center of the circle."),
+	),
+	"diagonal": makeBuiltin(defProps(),
+		geometricOverload1(types.Box, types.LSeg, func(d tree.Datum) (tree.Datum, error) {
+			return tree.NewDLSeg(tree.MustBeDBox(d).Box.Diagonal()), nil
+		}, "Returns the diagonal of the box, from its upper right to its lower left corner."),
+	),
+	"diameter": makeBuiltin(defProps(),
+		geometricOverload1(types.Circle, types.Float, func(d tree.Datum) (tree.Datum, error) {
+			return tree.NewDFloat(tree.DFloat(tree.MustBeDCircle(d).Circle.Diameter())), nil
+		}, "Returns the diameter of the circle."),
+	),
+	"radius": makeBuiltin(defProps(),
+		geometricOverload1(types.Circle, types.Float, func(d tree.Datum) (tree.Datum, error) {
+			return tree.NewDFloat(tree.DFloat(tree.MustBeDCircle(d).Circle.Radius)), nil
+		}, "Returns the radius of the circle."),
+	),
+	"height": makeBuiltin(defProps(),
+		geometricOverload1(types.Box, types.Float, func(d tree.Datum) (tree.Datum, error) {
+			return tree.NewDFloat(tree.DFloat(tree.MustBeDBox(d).Box.Height())), nil
+		}, "Returns the vertical size of the box."),
+	),
+	"width": makeBuiltin(defProps(),
+		geometricOverload1(types.Box, types.Float, func(d tree.Datum) (tree.Datum, error) {
+			return tree.NewDFloat(tree.DFloat(tree.MustBeDBox(d).Box.Width())), nil
+		}, "Returns the horizontal size of the box."),
+	),
+	"isclosed": makeBuiltin(defProps(),
+		geometricOverload1(types.Path, types.Bool, func(d tree.Datum) (tree.Datum, error) {
+			return tree.MakeDBool(tree.DBool(tree.MustBeDPath(d).Path.Closed)), nil
+		}, "Returns whether the path is closed."),
+	),
+	"isopen": makeBuiltin(defProps(),
+		geometricOverload1(types.Path, types.Bool, func(d tree.Datum) (tree.Datum, error) {
+			return tree.MakeDBool(tree.DBool(!tree.MustBeDPath(d).Path.Closed)), nil
+		}, "Returns whether the path is open."),
+	),
+	"npoints": makeBuiltin(defProps(),
+		geometricOverload1(types.Path, types.Int, func(d tree.Datum) (tree.Datum, error) {
+			return tree.NewDInt(tree.DInt(len(tree.MustBeDPath(d).Path.Points))), nil
+		}, "Returns the number of points of the path."),
+		geometricOverload1(types.Polygon, types.Int, func(d tree.Datum) (tree.Datum, error) {
+			return tree.NewDInt(tree.DInt(len(tree.MustBeDPolygon(d).Polygon.Points))), nil
+		}, "Returns the number of vertices of the polygon."),
+	),
+	"pclose": makeBuiltin(defProps(),
+		geometricOverload1(types.Path, types.Path, func(d tree.Datum) (tree.Datum, error) {
+			p := tree.MustBeDPath(d).Path
+			return tree.NewDPath(geometric.Path{Points: p.Points, Closed: true}), nil
+		}, "Returns the path converted to a closed path."),
+	),
+	"popen": makeBuiltin(defProps(),
+		geometricOverload1(types.Path, types.Path, func(d tree.Datum) (tree.Datum, error) {
+			p := tree.MustBeDPath(d).Path
+			return tree.NewDPath(geometric.Path{Points: p.Points, Closed: false}), nil
+		}, "Returns the path converted to an open path."),
+	),
+	"slope": makeBuiltin(defProps(),
+		geometricOverload2(types.Point, types.Point, types.Float, func(left, right tree.Datum) (tree.Datum, error) {
+			return tree.NewDFloat(tree.DFloat(geometric.Slope(
+				tree.MustBeDPoint(left).Point, tree.MustBeDPoint(right).Point,
+			))), nil
+		}, "Returns the slope of the line going through the two points."),
+	),
+	"bound_box": makeBuiltin(defProps(),
+		geometricOverload2(types.Box, types.Box, types.Box, func(left, right tree.Datum) (tree.Datum, error) {
+			return tree.NewDBox(tree.MustBeDBox(left).Box.Union(tree.MustBeDBox(right).Box)), nil
+		}, "Returns the smallest box containing both boxes."),
+	),
+	"geometric_intersects": makeBuiltin(defProps(), makeGeometricIntersectsOverloads()...),
+}
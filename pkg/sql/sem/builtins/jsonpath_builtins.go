@@ -0,0 +1,263 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
+)
+
+func init() {
+	for k, v := range jsonpathBuiltins {
+		v.props.Category = builtinconstants.CategoryJSON
+		v.props.AvailableOnPublicSchema = true
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+	for k, v := range jsonpathGenerators {
+		v.props.Category = builtinconstants.CategoryJSON
+		v.props.AvailableOnPublicSchema = true
+		const enforceClass = true
+		registerBuiltin(k, v, tree.GeneratorClass, enforceClass)
+	}
+}
+
+var jsonpathBuiltins = map[string]builtinDefinition{
+	"jsonb_path_exists": makeBuiltin(defProps(), makeJsonpathOverloads(
+		types.Bool, false /* useTZ */, jsonpathExists,
+		"Checks whether the JSON path returns any item for the specified JSON value.",
+	)...),
+	"jsonb_path_exists_tz": makeBuiltin(defProps(), makeJsonpathOverloads(
+		types.Bool, true /* useTZ */, jsonpathExists,
+		"Checks whether the JSON path returns any item for the specified JSON value. "+
+			"Comparisons of date/time values use the session time zone.",
+	)...),
+	"jsonb_path_match": makeBuiltin(defProps(), makeJsonpathOverloads(
+		types.Bool, false /* useTZ */, jsonpathMatch,
+		"Returns the result of a JSON path predicate check for the specified JSON value.",
+	)...),
+	"jsonb_path_match_tz": makeBuiltin(defProps(), makeJsonpathOverloads(
+		types.Bool, true /* useTZ */, jsonpathMatch,
+		"Returns the result of a JSON path predicate check for the specified JSON value. "+
+			"Comparisons of date/time values use the session time zone.",
+	)...),
+	"jsonb_path_query_array": makeBuiltin(defProps(), makeJsonpathOverloads(
+		types.Jsonb, false /* useTZ */, jsonpathQueryArray,
+		"Returns all the items returned by the JSON path for the specified JSON value, "+
+			"as a JSON array.",
+	)...),
+	"jsonb_path_query_array_tz": makeBuiltin(defProps(), makeJsonpathOverloads(
+		types.Jsonb, true /* useTZ */, jsonpathQueryArray,
+		"Returns all the items returned by the JSON path for the specified JSON value, "+
+			"as a JSON array. Comparisons of date/time values use the session time zone.",
+	)...),
+	"jsonb_path_query_first": makeBuiltin(defProps(), makeJsonpathOverloads(
+		types.Jsonb, false /* useTZ */, jsonpathQueryFirst,
+		"Returns the first item returned by the JSON path for the specified JSON value.",
+	)...),
+	"jsonb_path_query_first_tz": makeBuiltin(defProps(), makeJsonpathOverloads(
+		types.Jsonb, true /* useTZ */, jsonpathQueryFirst,
+		"Returns the first item returned by the JSON path for the specified JSON value. "+
+			"Comparisons of date/time values use the session time zone.",
+	)...),
+
+	// The following are the functions that implement the @? and @@ operators,
+	// which suppress errors like the silent argument does.
+	"jsonb_path_exists_opr": makeBuiltin(defProps(), makeJsonpathOprOverload(
+		jsonpathExists, "Implements the @? operator.",
+	)),
+	"jsonb_path_match_opr": makeBuiltin(defProps(), makeJsonpathOprOverload(
+		jsonpathMatch, "Implements the @@ operator for jsonpath.",
+	)),
+}
+
+var jsonpathGenerators = map[string]builtinDefinition{
+	"jsonb_path_query": makeBuiltin(genProps(), makeJsonpathQueryOverloads(
+		false /* useTZ */, "Returns all the items returned by the JSON path for the specified JSON value.",
+	)...),
+	"jsonb_path_query_tz": makeBuiltin(genProps(), makeJsonpathQueryOverloads(
+		true /* useTZ */, "Returns all the items returned by the JSON path for the specified JSON value. "+
+			"Comparisons of date/time values use the session time zone.",
+	)...),
+}
+
+// jsonpathFn evaluates a JSON path on a target for one of the jsonb_path_*
+// functions. vars is nil if it was not specified.
+type jsonpathFn func(
+	p *tree.DJsonpath, target json.JSON, vars json.JSON, opts jsonpath.Options,
+) (tree.Datum, error)
+
+// jsonpathParams returns the parameters of the jsonb_path_* functions, which
+// are target and path, followed by the optional vars and silent parameters.
+func jsonpathParams(n int) tree.ParamTypes {
+	return tree.ParamTypes{
+		{Name: "target", Typ: types.Jsonb},
+		{Name: "path", Typ: types.Jsonpath},
+		{Name: "vars", Typ: types.Jsonb},
+		{Name: "silent", Typ: types.Bool},
+	}[:n]
+}
+
+// jsonpathArgs extracts the arguments of a jsonb_path_* function.
+func jsonpathArgs(
+	evalCtx *eval.Context, args tree.Datums, useTZ bool,
+) (p *tree.DJsonpath, target, vars json.JSON, opts jsonpath.Options) {
+	target = tree.MustBeDJSON(args[0]).JSON
+	p = tree.MustBeDJsonpath(args[1])
+	if len(args) > 2 {
+		vars = tree.MustBeDJSON(args[2]).JSON
+	}
+	if len(args) > 3 {
+		opts.Silent = bool(tree.MustBeDBool(args[3]))
+	}
+	if useTZ {
+		opts.UseTZ = true
+		opts.Location = evalCtx.GetLocation()
+	}
+	return p, target, vars, opts
+}
+
+// jsonpathVolatility returns the volatility of a jsonb_path_* function. The
+// _tz variants depend on the session time zone.
+func jsonpathVolatility(useTZ bool) volatility.V {
+	if useTZ {
+		return volatility.Stable
+	}
+	return volatility.Immutable
+}
+
+func makeJsonpathOverloads(
+	ret *types.T, useTZ bool, fn jsonpathFn, info string,
+) []tree.Overload {
+	overloads := make([]tree.Overload, 0, 3)
+	for n := 2; n <= 4; n++ {
+		overloads = append(overloads, tree.Overload{
+			Types:      jsonpathParams(n),
+			ReturnType: tree.FixedReturnType(ret),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(jsonpathArgs(evalCtx, args, useTZ))
+			},
+			Info:       info,
+			Volatility: jsonpathVolatility(useTZ),
+		})
+	}
+	return overloads
+}
+
+func makeJsonpathOprOverload(fn jsonpathFn, info string) tree.Overload {
+	return tree.Overload{
+		Types:      jsonpathParams(2),
+		ReturnType: tree.FixedReturnType(types.Bool),
+		Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+			p, target, vars, opts := jsonpathArgs(evalCtx, args, false /* useTZ */)
+			opts.Silent = true
+			return fn(p, target, vars, opts)
+		},
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}
+}
+
+func makeJsonpathQueryOverloads(useTZ bool, info string) []tree.Overload {
+	overloads := make([]tree.Overload, 0, 3)
+	for n := 2; n <= 4; n++ {
+		overloads = append(overloads, makeGeneratorOverload(
+			jsonpathParams(n),
+			types.Jsonb,
+			func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (eval.ValueGenerator, error) {
+				res, err := jsonpath.Query(jsonpathArgs(evalCtx, args, useTZ))
+				if err != nil {
+					return nil, err
+				}
+				return &jsonpathQueryGenerator{items: res}, nil
+			},
+			info,
+			jsonpathVolatility(useTZ),
+		))
+	}
+	return overloads
+}
+
+func jsonpathExists(
+	p *tree.DJsonpath, target json.JSON, vars json.JSON, opts jsonpath.Options,
+) (tree.Datum, error) {
+	exists, isNull, err := jsonpath.Exists(p.Jsonpath, target, vars, opts)
+	if err != nil || isNull {
+		return tree.DNull, err
+	}
+	return tree.MakeDBool(tree.DBool(exists)), nil
+}
+
+func jsonpathMatch(
+	p *tree.DJsonpath, target json.JSON, vars json.JSON, opts jsonpath.Options,
+) (tree.Datum, error) {
+	res, isNull, err := jsonpath.Match(p.Jsonpath, target, vars, opts)
+	if err != nil || isNull {
+		return tree.DNull, err
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
+
+func jsonpathQueryArray(
+	p *tree.DJsonpath, target json.JSON, vars json.JSON, opts jsonpath.Options,
+) (tree.Datum, error) {
+	res, err := jsonpath.Query(p.Jsonpath, target, vars, opts)
+	if err != nil {
+		return nil, err
+	}
+	b := json.NewArrayBuilder(len(res))
+	for _, j := range res {
+		b.Add(j)
+	}
+	return tree.NewDJSON(b.Build()), nil
+}
+
+func jsonpathQueryFirst(
+	p *tree.DJsonpath, target json.JSON, vars json.JSON, opts jsonpath.Options,
+) (tree.Datum, error) {
+	res, err := jsonpath.Query(p.Jsonpath, target, vars, opts)
+	if err != nil || len(res) == 0 {
+		return tree.DNull, err
+	}
+	return tree.NewDJSON(res[0]), nil
+}
+
+// jsonpathQueryGenerator supports jsonb_path_query.
+type jsonpathQueryGenerator struct {
+	items []json.JSON
+	curr  int
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (*jsonpathQueryGenerator) ResolvedType() *types.T { return types.Jsonb }
+
+// Close implements the eval.ValueGenerator interface.
+func (*jsonpathQueryGenerator) Close(_ context.Context) {}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *jsonpathQueryGenerator) Start(_ context.Context, _ *kv.Txn) error {
+	g.curr = -1
+	return nil
+}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *jsonpathQueryGenerator) Next(_ context.Context) (bool, error) {
+	g.curr++
+	return g.curr < len(g.items), nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *jsonpathQueryGenerator) Values() (tree.Datums, error) {
+	return tree.Datums{tree.NewDJSON(g.items[g.curr])}, nil
+}
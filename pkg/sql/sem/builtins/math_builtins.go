@@ -555,6 +555,26 @@ var mathBuiltins = map[string]builtinDefinition{
 			Info:       "Truncate `val` to `scale` decimal places",
 			Volatility: volatility.Immutable,
 		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.MACAddr}},
+			ReturnType: tree.FixedReturnType(types.MACAddr),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDMACAddr(tree.MustBeDMACAddr(args[0]).Trunc()), nil
+			},
+			Info: "Sets the last 3 bytes of the MAC address to zero, leaving only the " +
+				"manufacturer prefix.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.MACAddr8}},
+			ReturnType: tree.FixedReturnType(types.MACAddr8),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDMACAddr8(tree.MustBeDMACAddr8(args[0]).Trunc()), nil
+			},
+			Info: "Sets the last 5 bytes of the MAC address to zero, leaving only the " +
+				"manufacturer prefix.",
+			Volatility: volatility.Immutable,
+		},
 	),
 
 	"width_bucket": makeBuiltin(defProps(),
@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -73,11 +74,23 @@ var typeBuiltinsHaveUnderscore = map[oid.Oid]struct{}{
 	types.Interval.Oid():    {},
 	types.Json.Oid():        {},
 	types.Jsonb.Oid():       {},
+	types.Jsonpath.Oid():    {},
 	types.Uuid.Oid():        {},
 	types.VarBit.Oid():      {},
 	types.Geometry.Oid():    {},
 	types.Geography.Oid():   {},
 	types.Box2D.Oid():       {},
+	types.Point.Oid():       {},
+	types.Box.Oid():         {},
+	types.LSeg.Oid():        {},
+	types.Line.Oid():        {},
+	types.Path.Oid():        {},
+	types.Polygon.Oid():     {},
+	types.Circle.Oid():      {},
+	types.CIDR.Oid():        {},
+	types.MACAddr.Oid():     {},
+	types.MACAddr8.Oid():    {},
+	types.Money.Oid():       {},
 	oid.T_bit:               {},
 	types.Timestamp.Oid():   {},
 	types.TimestampTZ.Oid(): {},
@@ -201,6 +214,19 @@ func init() {
 			},
 		)
 	}
+	// The cast builtins of the range and multirange types also construct them
+	// from their bounds and ranges, respectively.
+	for i, r := range types.RangeTypes {
+		addRangeConstructorOverloads(castBuiltins[r.Oid()], r)
+		addMultirangeConstructorOverload(castBuiltins[types.MultirangeTypes[i].Oid()], types.MultirangeTypes[i])
+	}
+	// The cast builtins of most geometric types also construct them from their
+	// components.
+	for _, t := range types.GeometricTypes {
+		if t.Family() != types.PathFamily {
+			addGeometricConstructorOverloads(castBuiltins[t.Oid()], t)
+		}
+	}
 	for toOID, def := range castBuiltins {
 		n := cast.CastTypeName(types.OidToType[toOID])
 		CastBuiltinNames[n] = struct{}{}
@@ -247,6 +273,8 @@ func shouldMakeFromCastBuiltin(in *types.T) bool {
 		return false
 	case in.Family() == types.FloatFamily && in.Oid() != oid.T_float8:
 		return false
+	case in.Family() == types.INetFamily && in.Oid() != oid.T_inet:
+		return false
 	case in.Family() == types.TriggerFamily:
 		// TRIGGER is not a valid cast target.
 		return false
@@ -1375,6 +1403,49 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	// See https://www.postgresql.org/docs/current/functions-info.html#FUNCTIONS-PG-NOTIFY.
+	"pg_notify": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "channel", Typ: types.String},
+				{Name: "payload", Typ: types.String},
+			},
+			ReturnType:        tree.FixedReturnType(types.Void),
+			CalledOnNullInput: true,
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if err := pgnotify.CheckSupported(ctx, evalCtx.Settings); err != nil {
+					return nil, err
+				}
+				var channel, payload string
+				if args[0] != tree.DNull {
+					channel = string(tree.MustBeDString(args[0]))
+				}
+				if args[1] != tree.DNull {
+					payload = string(tree.MustBeDString(args[1]))
+				}
+				if err := pgnotify.ValidateChannel(channel); err != nil {
+					return nil, err
+				}
+				if err := pgnotify.ValidatePayload(payload); err != nil {
+					return nil, err
+				}
+				pid := int32(evalCtx.QueryCancelKey.GetPGBackendPID())
+				if _, err := evalCtx.Planner.QueryRowEx(
+					ctx, "pg_notify", sessiondata.NodeUserSessionDataOverride,
+					pgnotify.InsertStmt, channel, payload, pid,
+				); err != nil {
+					return nil, err
+				}
+				return tree.DVoidDatum, nil
+			},
+			Info: "Sends a notification event with the given payload to the sessions " +
+				"listening on the given channel. The notification is delivered when the " +
+				"current transaction commits.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
 	// pg_is_in_recovery returns true if the Postgres database is currently in
 	// recovery.  This is not applicable so this can always return false.
 	// https://www.postgresql.org/docs/current/static/functions-admin.html#FUNCTIONS-RECOVERY-INFO-TABLE
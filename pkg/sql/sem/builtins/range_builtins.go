@@ -0,0 +1,293 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	for k, v := range makeRangeBuiltins() {
+		v.props.Category = builtinconstants.CategoryRange
+		v.props.AvailableOnPublicSchema = true
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+// rangeAndMultirangeTypes returns all the built-in range and multirange types.
+func rangeAndMultirangeTypes() []*types.T {
+	typs := make([]*types.T, 0, len(types.RangeTypes)+len(types.MultirangeTypes))
+	return append(append(typs, types.RangeTypes...), types.MultirangeTypes...)
+}
+
+// rangeSubtype returns the type of the bounds of a range or multirange type.
+func rangeSubtype(t *types.T) *types.T {
+	if t.Family() == types.MultirangeFamily {
+		t = t.MultirangeContents()
+	}
+	return t.RangeContents()
+}
+
+// rangeSpan returns the smallest range which contains a range or multirange
+// datum.
+func rangeSpan(d tree.Datum) *tree.DRange {
+	if r, ok := tree.AsDRange(d); ok {
+		return r
+	}
+	return tree.MustBeDMultirange(d).Span()
+}
+
+func makeRangeBuiltins() map[string]builtinDefinition {
+	return map[string]builtinDefinition{
+		"isempty": makeBuiltin(defProps(), makeRangePredicateOverloads(
+			func(r *tree.DRange) bool { return r.Empty },
+			"Returns whether the range or multirange is empty.",
+		)...),
+		"lower_inc": makeBuiltin(defProps(), makeRangePredicateOverloads(
+			func(r *tree.DRange) bool { return !r.Empty && r.Lower != nil && r.LowerInc },
+			"Returns whether the lower bound of the range or multirange is inclusive.",
+		)...),
+		"upper_inc": makeBuiltin(defProps(), makeRangePredicateOverloads(
+			func(r *tree.DRange) bool { return !r.Empty && r.Upper != nil && r.UpperInc },
+			"Returns whether the upper bound of the range or multirange is inclusive.",
+		)...),
+		"lower_inf": makeBuiltin(defProps(), makeRangePredicateOverloads(
+			func(r *tree.DRange) bool { return !r.Empty && r.Lower == nil },
+			"Returns whether the lower bound of the range or multirange is infinite.",
+		)...),
+		"upper_inf": makeBuiltin(defProps(), makeRangePredicateOverloads(
+			func(r *tree.DRange) bool { return !r.Empty && r.Upper == nil },
+			"Returns whether the upper bound of the range or multirange is infinite.",
+		)...),
+		"range_adjacent": makeBuiltin(defProps(), makeRangeAdjacentOverloads()...),
+		"range_merge":    makeBuiltin(defProps(), makeRangeMergeOverloads()...),
+		"multirange":     makeBuiltin(defProps(), makeMultirangeOverloads()...),
+	}
+}
+
+// addRangeConstructorOverloads adds the overloads of the constructor function
+// of a range type to def, which is the cast builtin named after the type.
+func addRangeConstructorOverloads(def *builtinDefinition, t *types.T) {
+	def.props.Category = builtinconstants.CategoryRange
+	def.props.Undocumented = false
+	def.overloads = append(def.overloads, makeRangeConstructorOverloads(t)...)
+}
+
+// addMultirangeConstructorOverload adds the overload of the constructor
+// function of a multirange type to def, which is the cast builtin named after
+// the type.
+func addMultirangeConstructorOverload(def *builtinDefinition, t *types.T) {
+	def.props.Category = builtinconstants.CategoryRange
+	def.props.Undocumented = false
+	def.overloads = append(def.overloads, makeMultirangeConstructorOverload(t))
+}
+
+func makeRangeConstructorOverloads(t *types.T) []tree.Overload {
+	sub := t.RangeContents()
+	construct := func(
+		ctx context.Context, evalCtx *eval.Context, lower, upper tree.Datum, bounds string,
+	) (tree.Datum, error) {
+		var lowerInc, upperInc bool
+		switch bounds {
+		case "[)":
+			lowerInc = true
+		case "[]":
+			lowerInc, upperInc = true, true
+		case "(]":
+			upperInc = true
+		case "()":
+		default:
+			return nil, errors.WithHint(
+				pgerror.New(pgcode.Syntax, "invalid range bound flags"),
+				`Valid values are "[]", "[)", "(]", and "()".`,
+			)
+		}
+		return tree.MakeDRange(ctx, evalCtx, t, lower, upper, lowerInc, upperInc)
+	}
+	return []tree.Overload{
+		{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: sub},
+				{Name: "upper", Typ: sub},
+			},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return construct(ctx, evalCtx, args[0], args[1], "[)")
+			},
+			Info: "Constructs a range with an inclusive lower bound and an exclusive upper " +
+				"bound. A NULL bound is infinite.",
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+		{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: sub},
+				{Name: "upper", Typ: sub},
+				{Name: "bounds", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[2] == tree.DNull {
+					return nil, pgerror.New(pgcode.DataException,
+						"range constructor flags argument must not be null")
+				}
+				return construct(ctx, evalCtx, args[0], args[1], string(tree.MustBeDString(args[2])))
+			},
+			Info: "Constructs a range with the given bounds. `bounds` is one of `[)`, `[]`, " +
+				"`(]` or `()`, and specifies whether each bound is inclusive. A NULL bound is " +
+				"infinite.",
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+	}
+}
+
+func makeMultirangeConstructorOverload(t *types.T) tree.Overload {
+	return tree.Overload{
+		Types:      tree.VariadicType{VarType: t.MultirangeContents()},
+		ReturnType: tree.FixedReturnType(t),
+		Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+			ranges := make([]*tree.DRange, len(args))
+			for i, arg := range args {
+				ranges[i] = tree.MustBeDRange(arg)
+			}
+			return tree.MakeDMultirange(ctx, evalCtx, t, ranges)
+		},
+		Info:       "Constructs a multirange which contains the union of the given ranges.",
+		Volatility: volatility.Immutable,
+	}
+}
+
+// makeRangePredicateOverloads returns an overload for each range and
+// multirange type which evaluates fn on the span of its argument.
+func makeRangePredicateOverloads(fn func(*tree.DRange) bool, info string) []tree.Overload {
+	var overloads []tree.Overload
+	for _, t := range rangeAndMultirangeTypes() {
+		overloads = append(overloads, tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: t}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(fn(rangeSpan(args[0])))), nil
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
+// makeRangeBoundOverloads returns the overloads of the lower and upper
+// functions for range and multirange types.
+func makeRangeBoundOverloads(lower bool) []tree.Overload {
+	info := "Returns the upper bound of the range or multirange, or NULL if it is " +
+		"empty or the bound is infinite."
+	if lower {
+		info = "Returns the lower bound of the range or multirange, or NULL if it is " +
+			"empty or the bound is infinite."
+	}
+	var overloads []tree.Overload
+	for _, t := range rangeAndMultirangeTypes() {
+		overloads = append(overloads, tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: t}},
+			ReturnType: tree.FixedReturnType(rangeSubtype(t)),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				r := rangeSpan(args[0])
+				bound := r.Upper
+				if lower {
+					bound = r.Lower
+				}
+				if r.Empty || bound == nil {
+					return tree.DNull, nil
+				}
+				return bound, nil
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
+func makeRangeAdjacentOverloads() []tree.Overload {
+	var overloads []tree.Overload
+	for i, r := range types.RangeTypes {
+		m := types.MultirangeTypes[i]
+		for _, left := range []*types.T{r, m} {
+			for _, right := range []*types.T{r, m} {
+				overloads = append(overloads, tree.Overload{
+					Types:      tree.ParamTypes{{Name: "left", Typ: left}, {Name: "right", Typ: right}},
+					ReturnType: tree.FixedReturnType(types.Bool),
+					Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+						adjacent, err := tree.RangeAdjacent(ctx, evalCtx, args[0], args[1])
+						if err != nil {
+							return nil, err
+						}
+						return tree.MakeDBool(tree.DBool(adjacent)), nil
+					},
+					Info: "Returns whether the ranges or multiranges are adjacent, which is the " +
+						"same as the `-|-` operator.",
+					Volatility: volatility.Immutable,
+				})
+			}
+		}
+	}
+	return overloads
+}
+
+func makeRangeMergeOverloads() []tree.Overload {
+	var overloads []tree.Overload
+	for i, r := range types.RangeTypes {
+		m := types.MultirangeTypes[i]
+		overloads = append(overloads,
+			tree.Overload{
+				Types:      tree.ParamTypes{{Name: "left", Typ: r}, {Name: "right", Typ: r}},
+				ReturnType: tree.FixedReturnType(r),
+				Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+					return tree.MustBeDRange(args[0]).Merge(ctx, evalCtx, tree.MustBeDRange(args[1]))
+				},
+				Info:       "Returns the smallest range which includes both of the given ranges.",
+				Volatility: volatility.Immutable,
+			},
+			tree.Overload{
+				Types:      tree.ParamTypes{{Name: "val", Typ: m}},
+				ReturnType: tree.FixedReturnType(r),
+				Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+					return tree.MustBeDMultirange(args[0]).Span(), nil
+				},
+				Info:       "Returns the smallest range which includes the entire multirange.",
+				Volatility: volatility.Immutable,
+			},
+		)
+	}
+	return overloads
+}
+
+func makeMultirangeOverloads() []tree.Overload {
+	var overloads []tree.Overload
+	for i, r := range types.RangeTypes {
+		m := types.MultirangeTypes[i]
+		overloads = append(overloads, tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: r}},
+			ReturnType: tree.FixedReturnType(m),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.MakeDMultirange(ctx, evalCtx, m, []*tree.DRange{tree.MustBeDRange(args[0])})
+			},
+			Info:       "Returns a multirange containing just the given range.",
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
@@ -7,16 +7,22 @@ package builtins
 
 import (
 	"context"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/errors"
 )
 
 func init() {
@@ -54,7 +60,10 @@ func (t *tsParseGenerator) Next(_ context.Context) (bool, error) {
 }
 
 func (t tsParseGenerator) Values() (tree.Datums, error) {
-	return tree.Datums{tree.NewDInt(1), tree.NewDString(t.nextToken)}, nil
+	return tree.Datums{
+		tree.NewDInt(tree.DInt(tsearch.ClassifyToken(t.nextToken))),
+		tree.NewDString(t.nextToken),
+	}, nil
 }
 
 func (t tsParseGenerator) Close(_ context.Context) {}
@@ -91,9 +100,12 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSVector),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
 				// Parse, stem, and stopword the input.
-				config := string(tree.MustBeDString(args[0]))
+				config, err := getTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				document := string(tree.MustBeDString(args[1]))
 				vector, err := tsearch.DocumentToTSVector(config, document)
 				if err != nil {
@@ -108,8 +120,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSVector),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig)
+				if err != nil {
+					return nil, err
+				}
 				document := string(tree.MustBeDString(args[0]))
 				vector, err := tsearch.DocumentToTSVector(config, document)
 				if err != nil {
@@ -127,8 +142,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.ToTSQuery(config, input)
 				if err != nil {
@@ -144,8 +162,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig)
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.ToTSQuery(config, input)
 				if err != nil {
@@ -164,8 +185,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.PlainToTSQuery(config, input)
 				if err != nil {
@@ -180,8 +204,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig)
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.PlainToTSQuery(config, input)
 				if err != nil {
@@ -199,8 +226,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.PhraseToTSQuery(config, input)
 				if err != nil {
@@ -215,8 +245,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig)
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.PhraseToTSQuery(config, input)
 				if err != nil {
@@ -229,6 +262,49 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			Volatility: volatility.Stable,
 		},
 	),
+	"ts_lexize": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "dict", Typ: types.String}, {Name: "token", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.StringArray),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				name, err := parser.ParseTableName(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				dict, err := evalCtx.CatalogBuiltins.ResolveTextSearchDictionary(ctx, name, evalCtx.SessionData())
+				if err != nil {
+					return nil, err
+				}
+				lexemes, ok := dict.Lexize(string(tree.MustBeDString(args[1])))
+				if !ok {
+					return tree.DNull, nil
+				}
+				arr := tree.NewDArray(types.String)
+				for _, lexeme := range lexemes {
+					if err := arr.Append(tree.NewDString(lexeme)); err != nil {
+						return nil, err
+					}
+				}
+				return arr, nil
+			},
+			Info: "Returns an array of lexemes if the input token is known to the dictionary, " +
+				"an empty array if the token is a stop word, or NULL if it is an unknown word.",
+			Volatility: volatility.Stable,
+		},
+	),
+	"get_current_ts_config": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDString(evalCtx.SessionData().DefaultTextSearchConfig), nil
+			},
+			Info:       "Returns the name of the default text search configuration of the session.",
+			Volatility: volatility.Stable,
+		},
+	),
 	"ts_rank": makeBuiltin(
 		tree.FunctionProperties{},
 		tree.Overload{
@@ -328,6 +404,550 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			Volatility: volatility.Immutable,
 		},
 	),
+	"ts_rank_cd": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "weights", Typ: types.FloatArray},
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "normalization", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				weights, err := getWeights(tree.MustBeDArray(args[0]))
+				if err != nil {
+					return nil, err
+				}
+				rank, err := tsearch.RankCD(
+					weights,
+					tree.MustBeDTSVector(args[1]).TSVector,
+					tree.MustBeDTSQuery(args[2]).TSQuery,
+					int(tree.MustBeDInt(args[3])),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDFloat(tree.DFloat(rank)), nil
+			},
+			Info:       "Ranks vectors based on the cover density of their matching lexemes.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "weights", Typ: types.FloatArray},
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				weights, err := getWeights(tree.MustBeDArray(args[0]))
+				if err != nil {
+					return nil, err
+				}
+				rank, err := tsearch.RankCD(
+					weights,
+					tree.MustBeDTSVector(args[1]).TSVector,
+					tree.MustBeDTSQuery(args[2]).TSQuery,
+					0, /* method */
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDFloat(tree.DFloat(rank)), nil
+			},
+			Info:       "Ranks vectors based on the cover density of their matching lexemes.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "normalization", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				rank, err := tsearch.RankCD(
+					nil, /* weights */
+					tree.MustBeDTSVector(args[0]).TSVector,
+					tree.MustBeDTSQuery(args[1]).TSQuery,
+					int(tree.MustBeDInt(args[2])),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDFloat(tree.DFloat(rank)), nil
+			},
+			Info:       "Ranks vectors based on the cover density of their matching lexemes.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				rank, err := tsearch.RankCD(
+					nil, /* weights */
+					tree.MustBeDTSVector(args[0]).TSVector,
+					tree.MustBeDTSQuery(args[1]).TSQuery,
+					0, /* method */
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDFloat(tree.DFloat(rank)), nil
+			},
+			Info:       "Ranks vectors based on the cover density of their matching lexemes.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_rank_tf": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return rankTF(args, tsearch.TFRankParams{K1: tsearch.DefaultTFRankParams.K1})
+			},
+			Info: "Ranks vectors by the saturated frequency of their matching lexemes, " +
+				"like the term frequency component of BM25, with k1 = 1.2 and without " +
+				"document length normalization. There is no inverse document frequency " +
+				"component, since only the ranked vector is taken into account.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "avg_doc_length", Typ: types.Float},
+			},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				params := tsearch.DefaultTFRankParams
+				params.AvgDocLength = float64(tree.MustBeDFloat(args[2]))
+				return rankTF(args, params)
+			},
+			Info: "Ranks vectors by the saturated frequency of their matching lexemes, " +
+				"like the term frequency component of BM25, with k1 = 1.2 and b = 0.75, " +
+				"normalizing the length of the vector by the given average length. There is " +
+				"no inverse document frequency component, since only the ranked vector is " +
+				"taken into account.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "avg_doc_length", Typ: types.Float},
+				{Name: "k1", Typ: types.Float},
+				{Name: "b", Typ: types.Float},
+			},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return rankTF(args, tsearch.TFRankParams{
+					AvgDocLength: float64(tree.MustBeDFloat(args[2])),
+					K1:           float64(tree.MustBeDFloat(args[3])),
+					B:            float64(tree.MustBeDFloat(args[4])),
+				})
+			},
+			Info: "Ranks vectors by the saturated frequency of their matching lexemes, " +
+				"like the term frequency component of BM25, with the given parameters, " +
+				"normalizing the length of the vector by the given average length. There is " +
+				"no inverse document frequency component, since only the ranked vector is " +
+				"taken into account.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_rank_bm25": makeBuiltin(
+		tree.FunctionProperties{DistsqlBlocklist: true},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "table", Typ: types.RegClass},
+				{Name: "index", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return rankBM25(ctx, evalCtx, args, tsearch.DefaultBM25Params)
+			},
+			Info: "Ranks vectors with BM25, with k1 = 1.2 and b = 0.75. The number of " +
+				"documents, their average length and the document frequencies of the lexemes " +
+				"are read from the given inverted index of the table, which must be created " +
+				"with the term_statistics storage parameter.",
+			Volatility: volatility.Stable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "table", Typ: types.RegClass},
+				{Name: "index", Typ: types.String},
+				{Name: "k1", Typ: types.Float},
+				{Name: "b", Typ: types.Float},
+			},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return rankBM25(ctx, evalCtx, args, tsearch.BM25Params{
+					K1: float64(tree.MustBeDFloat(args[4])),
+					B:  float64(tree.MustBeDFloat(args[5])),
+				})
+			},
+			Info: "Ranks vectors with BM25, with the given parameters. The number of " +
+				"documents, their average length and the document frequencies of the lexemes " +
+				"are read from the given inverted index of the table, which must be created " +
+				"with the term_statistics storage parameter.",
+			Volatility: volatility.Stable,
+		},
+	),
+	"ts_headline": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "config", Typ: types.String},
+				{Name: "document", Typ: types.String},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "options", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tsHeadline(
+					ctx, evalCtx, string(tree.MustBeDString(args[0])), args[1], args[2],
+					string(tree.MustBeDString(args[3])),
+				)
+			},
+			Info: "Returns an excerpt of the document with the terms of the query highlighted, using " +
+				"the specified configuration and options.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "config", Typ: types.String},
+				{Name: "document", Typ: types.String},
+				{Name: "query", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tsHeadline(
+					ctx, evalCtx, string(tree.MustBeDString(args[0])), args[1], args[2], "", /* options */
+				)
+			},
+			Info: "Returns an excerpt of the document with the terms of the query highlighted, using " +
+				"the specified configuration.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "document", Typ: types.String},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "options", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tsHeadline(
+					ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig, args[0], args[1],
+					string(tree.MustBeDString(args[2])),
+				)
+			},
+			Info: "Returns an excerpt of the document with the terms of the query highlighted, using " +
+				"the default configuration and the specified options.",
+			Volatility: volatility.Stable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "document", Typ: types.String},
+				{Name: "query", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tsHeadline(
+					ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig, args[0], args[1], "", /* options */
+				)
+			},
+			Info: "Returns an excerpt of the document with the terms of the query highlighted, using " +
+				"the default configuration.",
+			Volatility: volatility.Stable,
+		},
+	),
+	"websearch_to_tsquery": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				query, err := tsearch.WebSearchToTSQuery(config, string(tree.MustBeDString(args[1])))
+				if err != nil {
+					return nil, err
+				}
+				return &tree.DTSQuery{TSQuery: query}, nil
+			},
+			Info: "Converts text to a tsquery using a syntax similar to the one of web search engines, " +
+				"normalizing words according to the specified configuration. Quoted text is converted " +
+				"to phrases, \"or\" is converted to the | operator and - is converted to the ! operator.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig)
+				if err != nil {
+					return nil, err
+				}
+				query, err := tsearch.WebSearchToTSQuery(config, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return &tree.DTSQuery{TSQuery: query}, nil
+			},
+			Info: "Converts text to a tsquery using a syntax similar to the one of web search engines, " +
+				"normalizing words according to the default configuration. Quoted text is converted " +
+				"to phrases, \"or\" is converted to the | operator and - is converted to the ! operator.",
+			Volatility: volatility.Stable,
+		},
+	),
+	"tsquery_phrase": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "query1", Typ: types.TSQuery}, {Name: "query2", Typ: types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				query, err := tsearch.TSQueryPhrase(
+					tree.MustBeDTSQuery(args[0]).TSQuery, tree.MustBeDTSQuery(args[1]).TSQuery, 1, /* distance */
+				)
+				if err != nil {
+					return nil, err
+				}
+				return &tree.DTSQuery{TSQuery: query}, nil
+			},
+			Info:       "Constructs a phrase query that searches for query1 followed by query2.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "query1", Typ: types.TSQuery},
+				{Name: "query2", Typ: types.TSQuery},
+				{Name: "distance", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				query, err := tsearch.TSQueryPhrase(
+					tree.MustBeDTSQuery(args[0]).TSQuery, tree.MustBeDTSQuery(args[1]).TSQuery,
+					int(tree.MustBeDInt(args[2])),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return &tree.DTSQuery{TSQuery: query}, nil
+			},
+			Info: "Constructs a phrase query that searches for query1 followed by query2 at exactly " +
+				"the given distance.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_rewrite": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "target", Typ: types.TSQuery},
+				{Name: "substitute", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				query := tsearch.Rewrite(
+					tree.MustBeDTSQuery(args[0]).TSQuery,
+					[]tsearch.TSQuery{tree.MustBeDTSQuery(args[1]).TSQuery},
+					[]tsearch.TSQuery{tree.MustBeDTSQuery(args[2]).TSQuery},
+				)
+				return &tree.DTSQuery{TSQuery: query}, nil
+			},
+			Info:       "Replaces occurrences of target with substitute within the query.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "query", Typ: types.TSQuery}, {Name: "select", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				targets, substitutes, err := getTSRewriteRules(ctx, evalCtx, string(tree.MustBeDString(args[1])))
+				if err != nil {
+					return nil, err
+				}
+				query := tsearch.Rewrite(tree.MustBeDTSQuery(args[0]).TSQuery, targets, substitutes)
+				return &tree.DTSQuery{TSQuery: query}, nil
+			},
+			Info: "Replaces parts of the query according to the targets and substitutes obtained by " +
+				"executing the select statement, which must return two tsquery columns.",
+			Volatility: volatility.Volatile,
+		},
+	),
+	"setweight": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}, {Name: "weight", Typ: types.QChar}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				vector, err := tsearch.SetWeight(
+					tree.MustBeDTSVector(args[0]).TSVector, getQCharByte(args[1]), nil, /* lexemes */
+				)
+				if err != nil {
+					return nil, err
+				}
+				return &tree.DTSVector{TSVector: vector}, nil
+			},
+			Info:       "Assigns the given weight to each element of the vector.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "weight", Typ: types.QChar},
+				{Name: "lexemes", Typ: types.StringArray},
+			},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				lexemes, err := getLexemes(tree.MustBeDArray(args[2]))
+				if err != nil {
+					return nil, err
+				}
+				vector, err := tsearch.SetWeight(
+					tree.MustBeDTSVector(args[0]).TSVector, getQCharByte(args[1]), lexemes,
+				)
+				if err != nil {
+					return nil, err
+				}
+				return &tree.DTSVector{TSVector: vector}, nil
+			},
+			Info:       "Assigns the given weight to the elements of the vector that are listed in lexemes.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"strip": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return &tree.DTSVector{TSVector: tsearch.Strip(tree.MustBeDTSVector(args[0]).TSVector)}, nil
+			},
+			Info:       "Removes positions and weights from the vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"array_to_tsvector": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "lexemes", Typ: types.StringArray}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				lexemes, err := getLexemes(tree.MustBeDArray(args[0]))
+				if err != nil {
+					return nil, err
+				}
+				vector, err := tsearch.ArrayToTSVector(lexemes)
+				if err != nil {
+					return nil, err
+				}
+				return &tree.DTSVector{TSVector: vector}, nil
+			},
+			Info:       "Converts an array of lexemes to a tsvector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"tsvector_to_array": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.StringArray),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				arr := tree.NewDArray(types.String)
+				for _, lexeme := range tsearch.TSVectorToArray(tree.MustBeDTSVector(args[0]).TSVector) {
+					if err := arr.Append(tree.NewDString(lexeme)); err != nil {
+						return nil, err
+					}
+				}
+				return arr, nil
+			},
+			Info:       "Converts a tsvector to an array of lexemes.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	// The tsvector_update_trigger functions are trigger functions with a
+	// PL/pgSQL body that calls into crdb_internal.tsvector_update_trigger, which
+	// computes the new row.
+	"tsvector_update_trigger": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{},
+			ReturnType: tree.FixedReturnType(types.Trigger),
+			Body: `BEGIN
+RETURN crdb_internal.tsvector_update_trigger(NEW, TG_WHEN, TG_LEVEL, TG_OP, TG_ARGV, false);
+END`,
+			Info: "Trigger function that automatically updates a tsvector column from text columns. " +
+				"The trigger arguments are the name of the tsvector column, the schema-qualified name " +
+				"of the text search configuration and the names of the text columns.",
+			Volatility: volatility.Volatile,
+			Language:   tree.RoutineLangPLpgSQL,
+		},
+	),
+	"tsvector_update_trigger_column": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{},
+			ReturnType: tree.FixedReturnType(types.Trigger),
+			Body: `BEGIN
+RETURN crdb_internal.tsvector_update_trigger(NEW, TG_WHEN, TG_LEVEL, TG_OP, TG_ARGV, true);
+END`,
+			Info: "Trigger function that automatically updates a tsvector column from text columns. " +
+				"The trigger arguments are the name of the tsvector column, the name of a column that " +
+				"contains the text search configuration and the names of the text columns.",
+			Volatility: volatility.Volatile,
+			Language:   tree.RoutineLangPLpgSQL,
+		},
+	),
+	"crdb_internal.tsvector_update_trigger": makeBuiltin(
+		tree.FunctionProperties{Undocumented: true},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "new", Typ: types.AnyTuple},
+				{Name: "tg_when", Typ: types.String},
+				{Name: "tg_level", Typ: types.String},
+				{Name: "tg_op", Typ: types.String},
+				{Name: "tg_argv", Typ: types.StringArray},
+				{Name: "config_column", Typ: types.Bool},
+			},
+			ReturnType: tree.IdentityReturnType(0),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tsvectorUpdateTrigger(ctx, evalCtx, args)
+			},
+			Info:       "Computes the new row for the tsvector_update_trigger trigger functions.",
+			Volatility: volatility.Stable,
+		},
+	),
+}
+
+// getTextSearchConfig returns the text search configuration with the given
+// name. The built-in configurations are found without a catalog lookup.
+func getTextSearchConfig(
+	ctx context.Context, evalCtx *eval.Context, name string,
+) (*tsearch.Config, error) {
+	if config, ok := tsearch.BuiltinConfig(tsearch.GetConfigKey(name)); ok {
+		return config, nil
+	}
+	un, err := parser.ParseTableName(name)
+	if err != nil {
+		return nil, err
+	}
+	return evalCtx.CatalogBuiltins.ResolveTextSearchConfig(ctx, un, evalCtx.SessionData())
 }
 
 func getWeights(arr *tree.DArray) ([]float32, error) {
@@ -343,3 +963,265 @@ func getWeights(arr *tree.DArray) ([]float32, error) {
 	}
 	return ret, nil
 }
+
+// tsHeadline implements the ts_headline builtin.
+func tsHeadline(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	configName string,
+	document, query tree.Datum,
+	options string,
+) (tree.Datum, error) {
+	config, err := getTextSearchConfig(ctx, evalCtx, configName)
+	if err != nil {
+		return nil, err
+	}
+	headline, err := tsearch.Headline(
+		config, string(tree.MustBeDString(document)), tree.MustBeDTSQuery(query).TSQuery, options,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDString(headline), nil
+}
+
+// rankTF implements ts_rank_tf, whose first two arguments are the vector
+// and the query.
+func rankTF(args tree.Datums, params tsearch.TFRankParams) (tree.Datum, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	rank := tsearch.RankTF(
+		tree.MustBeDTSVector(args[0]).TSVector,
+		tree.MustBeDTSQuery(args[1]).TSQuery,
+		params,
+	)
+	return tree.NewDFloat(tree.DFloat(rank)), nil
+}
+
+// rankBM25 implements the ts_rank_bm25 builtin. The collection statistics are
+// read from the inverted index given by the table and index arguments, which
+// requires the SELECT privilege on the table.
+func rankBM25(
+	ctx context.Context, evalCtx *eval.Context, args tree.Datums, params tsearch.BM25Params,
+) (tree.Datum, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	tableOID := tree.MustBeDOid(args[2]).Oid
+	res, err := evalCtx.Planner.HasAnyPrivilegeForSpecifier(
+		ctx, eval.HasPrivilegeSpecifier{TableOID: &tableOID}, evalCtx.SessionData().User(),
+		[]privilege.Privilege{{Kind: privilege.SELECT}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	switch res {
+	case eval.ObjectNotFound:
+		return nil, pgerror.Newf(pgcode.UndefinedTable, "relation with OID %d does not exist", tableOID)
+	case eval.HasNoPrivilege:
+		return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"user %s does not have SELECT privilege on relation with OID %d",
+			evalCtx.SessionData().User(), tableOID)
+	}
+	q := tree.MustBeDTSQuery(args[1]).TSQuery
+	stats, err := evalCtx.CatalogBuiltins.TextSearchCollectionStatistics(
+		ctx, catid.DescID(tableOID), string(tree.MustBeDString(args[3])), tsearch.RankedLexemes(q),
+	)
+	if err != nil {
+		return nil, err
+	}
+	rank, err := tsearch.RankBM25(tree.MustBeDTSVector(args[0]).TSVector, q, params, stats)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDFloat(tree.DFloat(rank)), nil
+}
+
+// getTSRewriteRules executes the given select statement, which must return
+// pairs of target and substitute tsqueries, and returns the pairs. Rows with a
+// NULL target are skipped, and a NULL substitute removes the target from the
+// query.
+func getTSRewriteRules(
+	ctx context.Context, evalCtx *eval.Context, stmt string,
+) (targets, substitutes []tsearch.TSQuery, retErr error) {
+	var ieo sessiondata.InternalExecutorOverride
+	ieo.User = evalCtx.SessionData().User()
+	it, err := evalCtx.Planner.QueryIteratorEx(ctx, "ts_rewrite", ieo, stmt)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		retErr = errors.CombineErrors(retErr, it.Close())
+	}()
+	var ok bool
+	for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+		row := it.Cur()
+		if len(row) != 2 {
+			return nil, nil, pgerror.New(pgcode.InvalidParameterValue,
+				"ts_rewrite query must return two tsquery columns")
+		}
+		var rule [2]tsearch.TSQuery
+		for i, d := range row {
+			if d == tree.DNull {
+				continue
+			}
+			q, ok := d.(*tree.DTSQuery)
+			if !ok {
+				return nil, nil, pgerror.New(pgcode.InvalidParameterValue,
+					"ts_rewrite query must return two tsquery columns")
+			}
+			rule[i] = q.TSQuery
+		}
+		if row[0] == tree.DNull {
+			continue
+		}
+		targets = append(targets, rule[0])
+		substitutes = append(substitutes, rule[1])
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return targets, substitutes, nil
+}
+
+// getQCharByte returns the value of a "char" argument. The empty string is
+// returned as the zero byte.
+func getQCharByte(d tree.Datum) byte {
+	s := tree.MustBeDString(d)
+	if len(s) == 0 {
+		return 0
+	}
+	return s[0]
+}
+
+func getLexemes(arr *tree.DArray) ([]string, error) {
+	ret := make([]string, arr.Len())
+	for i, d := range arr.Array {
+		if d == tree.DNull {
+			return nil, pgerror.New(pgcode.NullValueNotAllowed, "lexeme array may not contain nulls")
+		}
+		ret[i] = string(tree.MustBeDString(d))
+	}
+	return ret, nil
+}
+
+// tsvectorUpdateTrigger implements crdb_internal.tsvector_update_trigger,
+// which parallels tsvector_update_trigger in Postgres. The trigger arguments
+// are the name of the tsvector column, the text search configuration and the
+// names of the text columns. If configColumn is true, the configuration is
+// read from the column with the given name, which must be of a string type
+// since there is no regconfig type.
+func tsvectorUpdateTrigger(
+	ctx context.Context, evalCtx *eval.Context, args tree.Datums,
+) (tree.Datum, error) {
+	newRow := tree.MustBeDTuple(args[0])
+	when := string(tree.MustBeDString(args[1]))
+	level := string(tree.MustBeDString(args[2]))
+	op := string(tree.MustBeDString(args[3]))
+	argv := tree.MustBeDArray(args[4])
+	configColumn := bool(tree.MustBeDBool(args[5]))
+
+	if level != "ROW" {
+		return nil, pgerror.New(pgcode.TriggeredActionException,
+			"tsvector_update_trigger: must be fired for row")
+	}
+	if when != "BEFORE" {
+		return nil, pgerror.New(pgcode.TriggeredActionException,
+			"tsvector_update_trigger: must be fired BEFORE event")
+	}
+	if op != "INSERT" && op != "UPDATE" {
+		return nil, pgerror.New(pgcode.TriggeredActionException,
+			"tsvector_update_trigger: must be fired for INSERT or UPDATE")
+	}
+	if argv.Len() < 3 {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"tsvector_update_trigger: arguments must be tsvector_field, ts_config, text_field1, ...")
+	}
+	triggerArgs := make([]string, argv.Len())
+	for i, d := range argv.Array {
+		if d == tree.DNull {
+			return nil, pgerror.New(pgcode.NullValueNotAllowed,
+				"tsvector_update_trigger: arguments must not be null")
+		}
+		triggerArgs[i] = string(tree.MustBeDString(d))
+	}
+
+	typ := newRow.ResolvedType()
+	colTypes := typ.TupleContents()
+	findColumn := func(name string) int {
+		for i, label := range typ.TupleLabels() {
+			if label == name {
+				return i
+			}
+		}
+		return -1
+	}
+
+	vectorIdx := findColumn(triggerArgs[0])
+	if vectorIdx < 0 {
+		return nil, pgerror.Newf(pgcode.UndefinedColumn,
+			"tsvector column \"%s\" does not exist", triggerArgs[0])
+	}
+	if colTypes[vectorIdx].Family() != types.TSVectorFamily {
+		return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+			"column \"%s\" is not of tsvector type", triggerArgs[0])
+	}
+
+	var configName string
+	if configColumn {
+		configIdx := findColumn(triggerArgs[1])
+		if configIdx < 0 {
+			return nil, pgerror.Newf(pgcode.UndefinedColumn,
+				"config column \"%s\" does not exist", triggerArgs[1])
+		}
+		if colTypes[configIdx].Family() != types.StringFamily {
+			return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+				"column \"%s\" is not of a character type", triggerArgs[1])
+		}
+		d := newRow.D[configIdx]
+		if d == tree.DNull {
+			return nil, pgerror.Newf(pgcode.NullValueNotAllowed,
+				"config column \"%s\" must not be null", triggerArgs[1])
+		}
+		configName = string(tree.MustBeDString(d))
+	} else {
+		configName = triggerArgs[1]
+		if !strings.Contains(configName, ".") {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"text search configuration name \"%s\" must be schema-qualified", configName)
+		}
+	}
+	config, err := getTextSearchConfig(ctx, evalCtx, configName)
+	if err != nil {
+		return nil, err
+	}
+
+	var document strings.Builder
+	for _, name := range triggerArgs[2:] {
+		idx := findColumn(name)
+		if idx < 0 {
+			return nil, pgerror.Newf(pgcode.UndefinedColumn, "column \"%s\" does not exist", name)
+		}
+		if colTypes[idx].Family() != types.StringFamily {
+			return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+				"column \"%s\" is not of a character type", name)
+		}
+		d := newRow.D[idx]
+		if d == tree.DNull {
+			continue
+		}
+		if document.Len() > 0 {
+			document.WriteByte(' ')
+		}
+		document.WriteString(string(tree.MustBeDString(d)))
+	}
+	vector, err := tsearch.DocumentToTSVector(config, document.String())
+	if err != nil {
+		return nil, err
+	}
+
+	ret := tree.NewDTuple(typ, append(tree.Datums(nil), newRow.D...)...)
+	ret.D[vectorIdx] = &tree.DTSVector{TSVector: vector}
+	return ret, nil
+}
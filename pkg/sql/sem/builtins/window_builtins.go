@@ -8,6 +8,8 @@ package builtins
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geomfn"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -193,6 +195,36 @@ var windows = map[string]builtinDefinition{
 				volatility.Immutable,
 			)
 		}),
+	"st_clusterdbscan": makeBuiltin(tree.FunctionProperties{AvailableOnPublicSchema: true},
+		makeWindowOverload(
+			tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "eps", Typ: types.Float},
+				{Name: "minpoints", Typ: types.Int},
+			},
+			types.Int,
+			newSTClusterDBSCANWindow,
+			"Calculates the 0-based number of the cluster of the current row's geometry, as determined by "+
+				"the DBSCAN algorithm. A geometry is in a cluster if it is within `eps` of a geometry "+
+				"which has at least `minpoints` geometries (including itself) within `eps` of it; "+
+				"null if the geometry isn't in any cluster.",
+			volatility.Immutable,
+		),
+	),
+	"st_clusterkmeans": makeBuiltin(tree.FunctionProperties{AvailableOnPublicSchema: true},
+		makeWindowOverload(
+			tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "number_of_clusters", Typ: types.Int},
+			},
+			types.Int,
+			newSTClusterKMeansWindow,
+			"Calculates the 0-based number of the cluster of the current row's geometry, as determined by "+
+				"the k-means algorithm applied to the centroids of the geometries of the partition; "+
+				"null for empty geometries.",
+			volatility.Immutable,
+		),
+	),
 }
 
 func makeWindowOverload(
@@ -220,6 +252,7 @@ var _ eval.WindowFunc = &leadLagWindow{}
 var _ eval.WindowFunc = &firstValueWindow{}
 var _ eval.WindowFunc = &lastValueWindow{}
 var _ eval.WindowFunc = &nthValueWindow{}
+var _ eval.WindowFunc = &stClusterWindow{}
 
 // aggregateWindowFunc aggregates over the current row's window frame, using
 // the internal eval.AggregateFunc to perform the aggregation.
@@ -312,6 +345,17 @@ type framableAggregateWindowFunc struct {
 	shouldReset    bool
 }
 
+// NewFramableAggregateWindowFunc creates a constructor of
+// framableAggregateWindowFunc with aggregates created by the provided
+// aggConstructor.
+func NewFramableAggregateWindowFunc(
+	aggConstructor func(*eval.Context, tree.Datums) eval.AggregateFunc,
+) func(*eval.Context) eval.WindowFunc {
+	return func(evalCtx *eval.Context) eval.WindowFunc {
+		return newFramableAggregateWindow(aggConstructor(evalCtx, nil /* arguments */), aggConstructor)
+	}
+}
+
 func newFramableAggregateWindow(
 	agg eval.AggregateFunc, aggConstructor func(*eval.Context, tree.Datums) eval.AggregateFunc,
 ) eval.WindowFunc {
@@ -630,6 +674,79 @@ func (w *ntileWindow) Reset(context.Context) {
 
 func (w *ntileWindow) Close(context.Context, *eval.Context) {}
 
+// stClusterWindow assigns each geometry of the partition to a cluster. The
+// clusters are computed once per partition, using the parameters of its first
+// row.
+type stClusterWindow struct {
+	cluster  func(gs []*geo.Geometry, params tree.Datums) ([]int, error)
+	clusters []int
+	computed bool
+}
+
+func newSTClusterDBSCANWindow([]*types.T, *eval.Context) eval.WindowFunc {
+	return &stClusterWindow{
+		cluster: func(gs []*geo.Geometry, params tree.Datums) ([]int, error) {
+			eps := float64(tree.MustBeDFloat(params[0]))
+			minPoints := int(tree.MustBeDInt(params[1]))
+			return geomfn.ClusterDBSCAN(gs, eps, minPoints)
+		},
+	}
+}
+
+func newSTClusterKMeansWindow([]*types.T, *eval.Context) eval.WindowFunc {
+	return &stClusterWindow{
+		cluster: func(gs []*geo.Geometry, params tree.Datums) ([]int, error) {
+			return geomfn.ClusterKMeans(gs, int(tree.MustBeDInt(params[0])))
+		},
+	}
+}
+
+func (w *stClusterWindow) Compute(
+	ctx context.Context, _ *eval.Context, wfr *eval.WindowFrameRun,
+) (tree.Datum, error) {
+	if !w.computed {
+		// If this is the first call to stClusterWindow.Compute for the
+		// partition, cluster all of its geometries.
+		w.computed = true
+		var params tree.Datums
+		gs := make([]*geo.Geometry, wfr.PartitionSize())
+		for i := range gs {
+			args, err := wfr.ArgsByRowIdx(ctx, i)
+			if err != nil {
+				return nil, err
+			}
+			if i == 0 {
+				params = args[1:]
+			}
+			if args[0] != tree.DNull {
+				gs[i] = &tree.MustBeDGeometry(args[0]).Geometry
+			}
+		}
+		for _, param := range params {
+			if param == tree.DNull {
+				// If any parameter is null, then the result is null for every row.
+				return tree.DNull, nil
+			}
+		}
+		var err error
+		if w.clusters, err = w.cluster(gs, params); err != nil {
+			return nil, err
+		}
+	}
+	if w.clusters == nil || w.clusters[wfr.RowIdx] == geomfn.NoCluster {
+		return tree.DNull, nil
+	}
+	return tree.NewDInt(tree.DInt(w.clusters[wfr.RowIdx])), nil
+}
+
+// Reset implements eval.WindowFunc interface.
+func (w *stClusterWindow) Reset(context.Context) {
+	w.clusters = nil
+	w.computed = false
+}
+
+func (w *stClusterWindow) Close(context.Context, *eval.Context) {}
+
 type leadLagWindow struct {
 	forward     bool
 	withOffset  bool
@@ -196,6 +196,19 @@ func OIDInCastMap(src, tgt oid.Oid) bool {
 // LookupCast returns a cast that describes the cast from src to tgt if it
 // exists. If it does not exist, ok=false is returned.
 func LookupCast(src, tgt *types.T) (Cast, bool) {
+	// Domains are cast as their base types, and casts between a domain and its
+	// base type are allowed in implicit contexts.
+	if src.IsDomain() || tgt.IsDomain() {
+		srcBase, tgtBase := src.DomainBaseType(), tgt.DomainBaseType()
+		if srcBase.Oid() == tgtBase.Oid() {
+			return Cast{
+				MaxContext: ContextImplicit,
+				Volatility: volatility.Immutable,
+			}, true
+		}
+		return LookupCast(srcBase, tgtBase)
+	}
+
 	srcFamily := src.Family()
 	tgtFamily := tgt.Family()
 
@@ -102,6 +102,9 @@ const (
 	StmtExecInsightsTableName              SystemTableName = "statement_execution_insights"
 	TxnExecInsightsTableName               SystemTableName = "transaction_execution_insights"
 	TableMetadata                          SystemTableName = "table_metadata"
+	NotificationsTableName                 SystemTableName = "notifications"
+	ReplicationSlotsTableName              SystemTableName = "replication_slots"
+	PublicationsTableName                  SystemTableName = "publications"
 )
 
 // Oid for virtual database and table.
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/trigram"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
@@ -138,6 +139,12 @@ func (e *evaluator) EvalCompareBox2DOp(
 	return tree.MakeDBool(tree.DBool(op.Op(left, right))), nil
 }
 
+func (e *evaluator) EvalCompareGeometricOp(
+	ctx context.Context, op *tree.CompareGeometricOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(op.Op(left, right))), nil
+}
+
 func (e *evaluator) EvalCompareScalarOp(
 	ctx context.Context, op *tree.CompareScalarOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -301,6 +308,16 @@ func (e *evaluator) EvalContainedByJsonbOp(
 	return tree.MakeDBool(tree.DBool(c)), nil
 }
 
+func (e *evaluator) EvalContainedByRangeOp(
+	ctx context.Context, _ *tree.ContainedByRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	c, err := tree.RangeContains(ctx, e.ctx(), b, a)
+	if err != nil {
+		return nil, err
+	}
+	return tree.MakeDBool(tree.DBool(c)), nil
+}
+
 func (e *evaluator) EvalContainsArrayOp(
 	ctx context.Context, _ *tree.ContainsArrayOp, a, b tree.Datum,
 ) (tree.Datum, error) {
@@ -319,6 +336,16 @@ func (e *evaluator) EvalContainsJsonbOp(
 	return tree.MakeDBool(tree.DBool(c)), nil
 }
 
+func (e *evaluator) EvalContainsRangeOp(
+	ctx context.Context, _ *tree.ContainsRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	c, err := tree.RangeContains(ctx, e.ctx(), a, b)
+	if err != nil {
+		return nil, err
+	}
+	return tree.MakeDBool(tree.DBool(c)), nil
+}
+
 func (e *evaluator) EvalDivDecimalIntOp(
 	ctx context.Context, _ *tree.DivDecimalIntOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -686,6 +713,20 @@ func (e *evaluator) EvalJSONFetchValStringOp(
 	return &tree.DJSON{JSON: j}, nil
 }
 
+func (e *evaluator) EvalJSONPathMatchOp(
+	ctx context.Context, _ *tree.JSONPathMatchOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	// Like jsonb_path_match_opr, errors are suppressed as if the silent
+	// argument was true.
+	res, isNull, err := jsonpath.Match(
+		tree.MustBeDJsonpath(b).Jsonpath, tree.MustBeDJSON(a).JSON, nil /* vars */, jsonpath.Options{Silent: true},
+	)
+	if err != nil || isNull {
+		return tree.DNull, err
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
+
 func (e *evaluator) EvalJSONSomeExistsOp(
 	ctx context.Context, _ *tree.JSONSomeExistsOp, a, b tree.Datum,
 ) (tree.Datum, error) {
@@ -1199,6 +1240,16 @@ func (e *evaluator) EvalOverlapsArrayOp(
 	return tree.ArrayOverlaps(ctx, e.ctx(), array, other)
 }
 
+func (e *evaluator) EvalOverlapsRangeOp(
+	ctx context.Context, _ *tree.OverlapsRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	c, err := tree.RangeOverlaps(ctx, e.ctx(), left, right)
+	if err != nil {
+		return nil, err
+	}
+	return tree.MakeDBool(tree.DBool(c)), nil
+}
+
 func (e *evaluator) EvalOverlapsINetOp(
 	ctx context.Context, _ *tree.OverlapsINetOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1225,6 +1276,12 @@ func (e *evaluator) EvalTSMatchesVectorQueryOp(
 	return tree.MakeDBool(tree.DBool(ret)), err
 }
 
+func (e *evaluator) EvalGeometricBinaryOp(
+	ctx context.Context, op *tree.GeometricBinaryOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return op.Fn(left, right)
+}
+
 func (e *evaluator) EvalDistanceVectorOp(
 	ctx context.Context, _ *tree.DistanceVectorOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1707,3 +1764,162 @@ func (e *evaluator) EvalMultPGVectorOp(
 	}
 	return tree.NewDPGVector(ret), nil
 }
+
+func (e *evaluator) EvalPlusRangeOp(
+	ctx context.Context, _ *tree.PlusRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	if r, ok := left.(*tree.DRange); ok {
+		return r.Union(ctx, e.ctx(), tree.MustBeDRange(right))
+	}
+	return tree.MustBeDMultirange(left).Union(ctx, e.ctx(), tree.MustBeDMultirange(right))
+}
+
+func (e *evaluator) EvalMinusRangeOp(
+	ctx context.Context, _ *tree.MinusRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	if r, ok := left.(*tree.DRange); ok {
+		return r.Difference(ctx, e.ctx(), tree.MustBeDRange(right))
+	}
+	return tree.MustBeDMultirange(left).Difference(ctx, e.ctx(), tree.MustBeDMultirange(right))
+}
+
+func (e *evaluator) EvalMultRangeOp(
+	ctx context.Context, _ *tree.MultRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	if r, ok := left.(*tree.DRange); ok {
+		return r.Intersect(ctx, e.ctx(), tree.MustBeDRange(right))
+	}
+	return tree.MustBeDMultirange(left).Intersect(ctx, e.ctx(), tree.MustBeDMultirange(right))
+}
+
+func (e *evaluator) EvalBitAndMACAddrOp(
+	ctx context.Context, _ *tree.BitAndMACAddrOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	l, r := tree.MustBeDMACAddr(left), tree.MustBeDMACAddr(right)
+	return tree.NewDMACAddr(l.And(r.MACAddr)), nil
+}
+
+func (e *evaluator) EvalBitAndMACAddr8Op(
+	ctx context.Context, _ *tree.BitAndMACAddr8Op, left, right tree.Datum,
+) (tree.Datum, error) {
+	l, r := tree.MustBeDMACAddr8(left), tree.MustBeDMACAddr8(right)
+	return tree.NewDMACAddr8(l.And(r.MACAddr8)), nil
+}
+
+func (e *evaluator) EvalBitOrMACAddrOp(
+	ctx context.Context, _ *tree.BitOrMACAddrOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	l, r := tree.MustBeDMACAddr(left), tree.MustBeDMACAddr(right)
+	return tree.NewDMACAddr(l.Or(r.MACAddr)), nil
+}
+
+func (e *evaluator) EvalBitOrMACAddr8Op(
+	ctx context.Context, _ *tree.BitOrMACAddr8Op, left, right tree.Datum,
+) (tree.Datum, error) {
+	l, r := tree.MustBeDMACAddr8(left), tree.MustBeDMACAddr8(right)
+	return tree.NewDMACAddr8(l.Or(r.MACAddr8)), nil
+}
+
+func (e *evaluator) EvalPlusMoneyOp(
+	ctx context.Context, _ *tree.PlusMoneyOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	r, ok := arith.AddWithOverflow(int64(tree.MustBeDMoney(left)), int64(tree.MustBeDMoney(right)))
+	if !ok {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	return tree.NewDMoney(tree.DMoney(r)), nil
+}
+
+func (e *evaluator) EvalMinusMoneyOp(
+	ctx context.Context, _ *tree.MinusMoneyOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	r, ok := arith.SubWithOverflow(int64(tree.MustBeDMoney(left)), int64(tree.MustBeDMoney(right)))
+	if !ok {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	return tree.NewDMoney(tree.DMoney(r)), nil
+}
+
+func (e *evaluator) EvalMultMoneyIntOp(
+	ctx context.Context, _ *tree.MultMoneyIntOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return multMoneyInt(tree.MustBeDMoney(left), tree.MustBeDInt(right))
+}
+
+func (e *evaluator) EvalMultIntMoneyOp(
+	ctx context.Context, _ *tree.MultIntMoneyOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return multMoneyInt(tree.MustBeDMoney(right), tree.MustBeDInt(left))
+}
+
+func (e *evaluator) EvalMultMoneyFloatOp(
+	ctx context.Context, _ *tree.MultMoneyFloatOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return floatToMoney(float64(tree.MustBeDMoney(left)) * float64(*right.(*tree.DFloat)))
+}
+
+func (e *evaluator) EvalMultFloatMoneyOp(
+	ctx context.Context, _ *tree.MultFloatMoneyOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return floatToMoney(float64(*left.(*tree.DFloat)) * float64(tree.MustBeDMoney(right)))
+}
+
+func (e *evaluator) EvalDivMoneyIntOp(
+	ctx context.Context, _ *tree.DivMoneyIntOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	m, i := tree.MustBeDMoney(left), tree.MustBeDInt(right)
+	if i == 0 {
+		return nil, tree.ErrDivByZero
+	}
+	if m == math.MinInt64 && i == -1 {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	// Like postgres, integer division truncates towards zero.
+	return tree.NewDMoney(m / tree.DMoney(i)), nil
+}
+
+func (e *evaluator) EvalDivMoneyFloatOp(
+	ctx context.Context, _ *tree.DivMoneyFloatOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	f := float64(*right.(*tree.DFloat))
+	if f == 0 {
+		return nil, tree.ErrDivByZero
+	}
+	return floatToMoney(float64(tree.MustBeDMoney(left)) / f)
+}
+
+func (e *evaluator) EvalDivMoneyOp(
+	ctx context.Context, _ *tree.DivMoneyOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	r := tree.MustBeDMoney(right)
+	if r == 0 {
+		return nil, tree.ErrDivByZero
+	}
+	return tree.NewDFloat(tree.DFloat(float64(tree.MustBeDMoney(left)) / float64(r))), nil
+}
+
+// multMoneyInt returns m*i, or an error if the result does not fit in a
+// money value.
+func multMoneyInt(m tree.DMoney, i tree.DInt) (tree.Datum, error) {
+	a, b := int64(m), int64(i)
+	c := a * b
+	if a == 0 || b == 0 || a == 1 || b == 1 {
+		// ignore
+	} else if a == math.MinInt64 || b == math.MinInt64 {
+		// This test is required to detect math.MinInt64 * -1.
+		return nil, tree.ErrMoneyOutOfRange
+	} else if c/b != a {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	return tree.NewDMoney(tree.DMoney(c)), nil
+}
+
+// floatToMoney rounds f to the nearest integer, with ties going to the even
+// integer like postgres' rint, and returns it as a money value.
+func floatToMoney(f float64) (tree.Datum, error) {
+	f = math.RoundToEven(f)
+	if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	return tree.NewDMoney(tree.DMoney(f)), nil
+}
@@ -23,9 +23,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/arith"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/geometric"
+	"github.com/cockroachdb/cockroach/pkg/util/money"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
@@ -97,6 +100,9 @@ func PerformAssignmentCast(
 func performCast(
 	ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T, truncateWidth bool,
 ) (tree.Datum, error) {
+	// Casts to a domain are performed as casts to its base type. The
+	// constraints of the domain are enforced by the optimizer.
+	t = t.DomainBaseType()
 	d, err := performCastWithoutPrecisionTruncation(ctx, evalCtx, d, t, truncateWidth)
 	if err != nil {
 		return nil, err
@@ -383,6 +389,8 @@ func performCastWithoutPrecisionTruncation(
 		case *tree.DInterval:
 			v.AsBigInt(&dd.Coeff)
 			dd.Exponent = -9
+		case *tree.DMoney:
+			dd.SetFinite(int64(*v), -int32(evalCtx.GetMoneyLocale().FracDigits))
 		case *tree.DJSON:
 			dec, ok := v.AsDecimal()
 			if !ok {
@@ -456,7 +464,7 @@ func performCastWithoutPrecisionTruncation(
 				tree.FmtDataConversionConfig(evalCtx.SessionData().DataConversionConfig),
 				tree.FmtLocation(evalCtx.GetLocation()),
 			)
-		case *tree.DArray:
+		case *tree.DArray, *tree.DRange, *tree.DMultirange:
 			s = tree.AsStringWithFlags(
 				d,
 				tree.FmtPgwireText,
@@ -497,6 +505,28 @@ func performCastWithoutPrecisionTruncation(
 			s = t.JSON.String()
 		case *tree.DTSQuery:
 			s = t.TSQuery.String()
+		case *tree.DJsonpath:
+			s = t.Jsonpath.String()
+		case *tree.DPoint:
+			s = t.Point.String()
+		case *tree.DBox:
+			s = t.Box.String()
+		case *tree.DLSeg:
+			s = t.LSeg.String()
+		case *tree.DLine:
+			s = t.Line.String()
+		case *tree.DPath:
+			s = t.Path.String()
+		case *tree.DPolygon:
+			s = t.Polygon.String()
+		case *tree.DCircle:
+			s = t.Circle.String()
+		case *tree.DMACAddr:
+			s = t.MACAddr.String()
+		case *tree.DMACAddr8:
+			s = t.MACAddr8.String()
+		case *tree.DMoney:
+			s = money.Format(int64(*t), evalCtx.GetMoneyLocale())
 		case *tree.DTSVector:
 			s = t.TSVector.String()
 		case *tree.DPGVector:
@@ -565,6 +595,19 @@ func performCastWithoutPrecisionTruncation(
 		}
 
 	case types.INetFamily:
+		if t.Oid() == oid.T_cidr {
+			switch d := d.(type) {
+			case *tree.DString:
+				return tree.ParseDCIDR(string(*d))
+			case *tree.DCollatedString:
+				return tree.ParseDCIDR(d.Contents)
+			case *tree.DIPAddr:
+				// Casting inet to cidr zeroes the bits to the right of the
+				// netmask.
+				return tree.NewDCIDR(&tree.DIPAddr{IPAddr: d.Network()}), nil
+			}
+			break
+		}
 		switch t := d.(type) {
 		case *tree.DString:
 			return tree.ParseDIPAddrFromINetString(string(*t))
@@ -574,6 +617,61 @@ func performCastWithoutPrecisionTruncation(
 			return d, nil
 		}
 
+	case types.MACAddrFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			return tree.ParseDMACAddr(string(*d))
+		case *tree.DCollatedString:
+			return tree.ParseDMACAddr(d.Contents)
+		case *tree.DMACAddr:
+			return d, nil
+		case *tree.DMACAddr8:
+			m, err := d.ToMACAddr()
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDMACAddr(m), nil
+		}
+
+	case types.MACAddr8Family:
+		switch d := d.(type) {
+		case *tree.DString:
+			return tree.ParseDMACAddr8(string(*d))
+		case *tree.DCollatedString:
+			return tree.ParseDMACAddr8(d.Contents)
+		case *tree.DMACAddr:
+			return tree.NewDMACAddr8(d.ToMACAddr8()), nil
+		case *tree.DMACAddr8:
+			return d, nil
+		}
+
+	case types.MoneyFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			return tree.ParseDMoney(evalCtx, string(*d))
+		case *tree.DCollatedString:
+			return tree.ParseDMoney(evalCtx, d.Contents)
+		case *tree.DInt:
+			v, ok := arith.MulHalfPositiveWithOverflow(int64(*d), evalCtx.GetMoneyLocale().Scale())
+			if !ok {
+				return nil, tree.ErrMoneyOutOfRange
+			}
+			return tree.NewDMoney(tree.DMoney(v)), nil
+		case *tree.DDecimal:
+			// The amount is rounded to the number of fractional digits of the
+			// currency, like in postgres.
+			var scaled apd.Decimal
+			scaled.Set(&d.Decimal)
+			scaled.Exponent += int32(evalCtx.GetMoneyLocale().FracDigits)
+			v, err := roundDecimalToInt(&scaled)
+			if err != nil {
+				return nil, tree.ErrMoneyOutOfRange
+			}
+			return tree.NewDMoney(tree.DMoney(v)), nil
+		case *tree.DMoney:
+			return d, nil
+		}
+
 	case types.Box2DFamily:
 		switch d := d.(type) {
 		case *tree.DString:
@@ -632,6 +730,30 @@ func performCastWithoutPrecisionTruncation(
 			return d, nil
 		}
 
+	case types.RangeFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, string(*d), t)
+			return res, err
+		case *tree.DCollatedString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, d.Contents, t)
+			return res, err
+		case *tree.DRange:
+			return d, nil
+		}
+
+	case types.MultirangeFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDMultirangeFromString(evalCtx, string(*d), t)
+			return res, err
+		case *tree.DCollatedString:
+			res, _, err := tree.ParseDMultirangeFromString(evalCtx, d.Contents, t)
+			return res, err
+		case *tree.DMultirange:
+			return d, nil
+		}
+
 	case types.RefCursorFamily:
 		switch d := d.(type) {
 		case *tree.DString:
@@ -735,6 +857,24 @@ func performCastWithoutPrecisionTruncation(
 				return nil, err
 			}
 			return &tree.DGeometry{Geometry: g}, nil
+		case *tree.DPoint:
+			g, err := geo.MakeGeometryFromGeomT(d.Point.AsGeomT())
+			if err != nil {
+				return nil, err
+			}
+			return &tree.DGeometry{Geometry: g}, nil
+		case *tree.DPath:
+			g, err := geo.MakeGeometryFromGeomT(d.Path.AsGeomT())
+			if err != nil {
+				return nil, err
+			}
+			return &tree.DGeometry{Geometry: g}, nil
+		case *tree.DPolygon:
+			g, err := geo.MakeGeometryFromGeomT(d.Polygon.AsGeomT())
+			if err != nil {
+				return nil, err
+			}
+			return &tree.DGeometry{Geometry: g}, nil
 		case *tree.DBytes:
 			g, err := geo.ParseGeometryFromEWKB(geopb.EWKB(*d))
 			if err != nil {
@@ -913,6 +1053,22 @@ func performCastWithoutPrecisionTruncation(
 			}
 			return tree.ParseDJSON(string(j))
 		}
+	case types.JsonpathFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			return tree.ParseDJsonpath(string(*v))
+		case *tree.DJsonpath:
+			return d, nil
+		}
+	case types.PointFamily, types.BoxFamily, types.LSegFamily, types.LineFamily,
+		types.PathFamily, types.PolygonFamily, types.CircleFamily:
+		if v, ok := d.(*tree.DString); ok {
+			res, _, err := tree.ParseAndRequireString(t, string(*v), evalCtx)
+			return res, err
+		}
+		if res, ok, err := performGeometricCast(d, t); ok || err != nil {
+			return res, err
+		}
 	case types.TSQueryFamily:
 		switch v := d.(type) {
 		case *tree.DString:
@@ -1016,6 +1172,114 @@ func performCastWithoutPrecisionTruncation(
 		pgcode.CannotCoerce, "invalid cast: %s -> %s", d.ResolvedType(), t)
 }
 
+// performGeometricCast casts the input datum to the geometric type given by
+// the input types.T. It returns ok=false if there is no such cast.
+func performGeometricCast(d tree.Datum, t *types.T) (_ tree.Datum, ok bool, _ error) {
+	switch t.Family() {
+	case types.PointFamily:
+		switch v := d.(type) {
+		case *tree.DPoint:
+			return d, true, nil
+		case *tree.DBox:
+			return tree.NewDPoint(v.Box.Center()), true, nil
+		case *tree.DLSeg:
+			return tree.NewDPoint(v.LSeg.Center()), true, nil
+		case *tree.DPolygon:
+			return tree.NewDPoint(v.Polygon.Center()), true, nil
+		case *tree.DCircle:
+			return tree.NewDPoint(v.Circle.Center), true, nil
+		case *tree.DGeometry:
+			g, err := v.Geometry.AsGeomT()
+			if err != nil {
+				return nil, true, err
+			}
+			p, err := geometric.PointFromGeomT(g)
+			if err != nil {
+				return nil, true, err
+			}
+			return tree.NewDPoint(p), true, nil
+		}
+	case types.BoxFamily:
+		switch v := d.(type) {
+		case *tree.DBox:
+			return d, true, nil
+		case *tree.DPoint:
+			return tree.NewDBox(geometric.MakeBox(v.Point, v.Point)), true, nil
+		case *tree.DPolygon:
+			return tree.NewDBox(v.Polygon.BoundBox), true, nil
+		case *tree.DCircle:
+			return tree.NewDBox(v.Circle.Box()), true, nil
+		}
+	case types.LSegFamily:
+		switch v := d.(type) {
+		case *tree.DLSeg:
+			return d, true, nil
+		case *tree.DBox:
+			return tree.NewDLSeg(v.Box.Diagonal()), true, nil
+		}
+	case types.LineFamily:
+		if _, ok := d.(*tree.DLine); ok {
+			return d, true, nil
+		}
+	case types.PathFamily:
+		switch v := d.(type) {
+		case *tree.DPath:
+			return d, true, nil
+		case *tree.DPolygon:
+			return tree.NewDPath(v.Polygon.Path()), true, nil
+		case *tree.DGeometry:
+			g, err := v.Geometry.AsGeomT()
+			if err != nil {
+				return nil, true, err
+			}
+			p, err := geometric.PathFromGeomT(g)
+			if err != nil {
+				return nil, true, err
+			}
+			return tree.NewDPath(p), true, nil
+		}
+	case types.PolygonFamily:
+		switch v := d.(type) {
+		case *tree.DPolygon:
+			return d, true, nil
+		case *tree.DBox:
+			return tree.NewDPolygon(v.Box.Polygon()), true, nil
+		case *tree.DPath:
+			p, err := v.Path.Polygon()
+			if err != nil {
+				return nil, true, err
+			}
+			return tree.NewDPolygon(p), true, nil
+		case *tree.DCircle:
+			p, err := v.Circle.Polygon(geometric.DefaultPolygonPoints)
+			if err != nil {
+				return nil, true, err
+			}
+			return tree.NewDPolygon(p), true, nil
+		case *tree.DGeometry:
+			g, err := v.Geometry.AsGeomT()
+			if err != nil {
+				return nil, true, err
+			}
+			p, err := geometric.PolygonFromGeomT(g)
+			if err != nil {
+				return nil, true, err
+			}
+			return tree.NewDPolygon(p), true, nil
+		}
+	case types.CircleFamily:
+		switch v := d.(type) {
+		case *tree.DCircle:
+			return d, true, nil
+		case *tree.DBox:
+			return tree.NewDCircle(v.Box.Circle()), true, nil
+		case *tree.DPolygon:
+			return tree.NewDCircle(v.Polygon.Circle()), true, nil
+		}
+	}
+	return nil, false, nil
+}
+
 // performIntToOidCast casts the input integer to the OID type given by the
 // input types.T.
 func performIntToOidCast(
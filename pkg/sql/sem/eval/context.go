@@ -37,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/money"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
@@ -812,6 +813,14 @@ func (ec *Context) GetDateStyle() pgdate.DateStyle {
 	return ec.SessionData().GetDateStyle()
 }
 
+// GetMoneyLocale returns the session lc_monetary locale.
+func (ec *Context) GetMoneyLocale() *money.Locale {
+	if ec.SessionData() == nil {
+		return money.MustLookupLocale(money.DefaultLocale)
+	}
+	return money.MustLookupLocale(ec.SessionData().DataConversionConfig.LcMonetary)
+}
+
 // BoundedStaleness returns true if this query uses bounded staleness.
 func (ec *Context) BoundedStaleness() bool {
 	return ec.AsOfSystemTime != nil &&
@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/rangedesc"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/lib/pq/oid"
 )
 
@@ -131,6 +132,28 @@ type CatalogBuiltins interface {
 		nonTerminalJobIDMightExist func(id jobspb.JobID) bool,
 		roleExists func(username username.SQLUsername) bool,
 	) ([]byte, error)
+
+	// ResolveTextSearchConfig returns the text search configuration with the
+	// given name. Unqualified names are resolved using the search path of the
+	// session.
+	ResolveTextSearchConfig(
+		ctx context.Context, name *tree.UnresolvedObjectName, sd *sessiondata.SessionData,
+	) (*tsearch.Config, error)
+
+	// ResolveTextSearchDictionary returns the text search dictionary with the
+	// given name. Unqualified names are resolved using the search path of the
+	// session.
+	ResolveTextSearchDictionary(
+		ctx context.Context, name *tree.UnresolvedObjectName, sd *sessiondata.SessionData,
+	) (*tsearch.Dictionary, error)
+
+	// TextSearchCollectionStatistics returns the statistics of the rows of a
+	// table for the given ranked lexemes of a text search query, which are kept
+	// by the inverted index of the table with the given name. The index must
+	// store term statistics.
+	TextSearchCollectionStatistics(
+		ctx context.Context, tableID catid.DescID, indexName string, lexemes []tsearch.QueryLexeme,
+	) (*tsearch.CollectionStatistics, error)
 }
 
 // HasPrivilegeSpecifier specifies an object to lookup privilege for.
@@ -510,6 +510,12 @@ func (e *evaluator) evalFuncArgs(
 	return false, args, nil
 }
 
+func (e *evaluator) EvalGroupingOperation(
+	ctx context.Context, expr *tree.GroupingOperation,
+) (tree.Datum, error) {
+	return nil, errors.AssertionFailedf("GROUPING must be replaced by the optimizer before evaluation")
+}
+
 func (e *evaluator) EvalIfErrExpr(ctx context.Context, expr *tree.IfErrExpr) (tree.Datum, error) {
 	cond, evalErr := expr.Cond.(tree.TypedExpr).Eval(ctx, e)
 	if evalErr == nil {
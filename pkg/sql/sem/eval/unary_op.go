@@ -102,3 +102,25 @@ func (e *evaluator) EvalUnaryMinusIntervalOp(
 	i.Months = -i.Months
 	return &tree.DInterval{Duration: i}, nil
 }
+
+func (e *evaluator) EvalUnaryMinusMoneyOp(
+	ctx context.Context, _ *tree.UnaryMinusMoneyOp, d tree.Datum,
+) (tree.Datum, error) {
+	m := tree.MustBeDMoney(d)
+	if m == math.MinInt64 {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	return tree.NewDMoney(-m), nil
+}
+
+func (e *evaluator) EvalComplementMACAddrOp(
+	ctx context.Context, _ *tree.ComplementMACAddrOp, d tree.Datum,
+) (tree.Datum, error) {
+	return tree.NewDMACAddr(tree.MustBeDMACAddr(d).Not()), nil
+}
+
+func (e *evaluator) EvalComplementMACAddr8Op(
+	ctx context.Context, _ *tree.ComplementMACAddr8Op, d tree.Datum,
+) (tree.Datum, error) {
+	return tree.NewDMACAddr8(tree.MustBeDMACAddr8(d).Not()), nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
 )
 
 type unsupportedTypeChecker struct {
@@ -35,13 +36,33 @@ func (tc *unsupportedTypeChecker) CheckType(ctx context.Context, typ *types.T) e
 	// NB: when adding an unsupported type here, change the constructor to not
 	// return nil.
 	var errorTypeString string
+	var minVersion clusterversion.Key
 	switch typ.Family() {
 	case types.PGVectorFamily:
 		errorTypeString = "vector"
+		minVersion = clusterversion.V24_2
+	case types.RangeFamily, types.MultirangeFamily:
+		errorTypeString = typ.Name()
+		minVersion = clusterversion.V24_3_RangeTypes
+	case types.JsonpathFamily:
+		errorTypeString = "jsonpath"
+		minVersion = clusterversion.V24_3_Jsonpath
+	case types.PointFamily, types.BoxFamily, types.LSegFamily, types.LineFamily,
+		types.PathFamily, types.PolygonFamily, types.CircleFamily:
+		errorTypeString = typ.Name()
+		minVersion = clusterversion.V24_3_GeometricTypes
+	case types.MACAddrFamily, types.MACAddr8Family, types.MoneyFamily:
+		errorTypeString = typ.Name()
+		minVersion = clusterversion.V24_3_NetworkAndMoneyTypes
+	case types.INetFamily:
+		if typ.Oid() == oid.T_cidr {
+			errorTypeString = "cidr"
+			minVersion = clusterversion.V24_3_NetworkAndMoneyTypes
+		}
 	}
-	if errorTypeString != "" && !tc.version.IsActive(ctx, clusterversion.V24_2) {
+	if errorTypeString != "" && !tc.version.IsActive(ctx, minVersion) {
 		return pgerror.Newf(pgcode.FeatureNotSupported,
-			"%s not supported until version 24.2", errorTypeString,
+			"%s not supported until version %s", errorTypeString, minVersion.ReleaseSeries(),
 		)
 	}
 
@@ -186,10 +186,22 @@ type CursorDeclaration struct {
 	Name   Variable
 	Scroll tree.CursorScrollOption
 	Query  tree.Statement
+
+	// Args is the list of arguments for a parameterized cursor. The arguments
+	// can be referenced by the query, and their values are supplied when the
+	// cursor is opened.
+	Args []CursorArg
+}
+
+// CursorArg is an argument of a parameterized cursor declaration.
+type CursorArg struct {
+	Name Variable
+	Typ  tree.ResolvableTypeReference
 }
 
 func (s *CursorDeclaration) CopyNode() *CursorDeclaration {
 	copyNode := *s
+	copyNode.Args = append([]CursorArg(nil), s.Args...)
 	return &copyNode
 }
 
@@ -201,7 +213,20 @@ func (s *CursorDeclaration) Format(ctx *tree.FmtCtx) {
 	case tree.NoScroll:
 		ctx.WriteString(" NO SCROLL")
 	}
-	ctx.WriteString(" CURSOR FOR ")
+	ctx.WriteString(" CURSOR")
+	if len(s.Args) > 0 {
+		ctx.WriteString(" (")
+		for i := range s.Args {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(&s.Args[i].Name)
+			ctx.WriteString(" ")
+			ctx.FormatTypeReference(s.Args[i].Typ)
+		}
+		ctx.WriteString(")")
+	}
+	ctx.WriteString(" FOR ")
 	ctx.FormatNode(s.Query)
 	ctx.WriteString(";\n")
 }
@@ -636,6 +661,71 @@ func (c *IntForLoopControl) Format(ctx *tree.FmtCtx) {
 	}
 }
 
+// QueryForLoopControl is the control structure for a FOR loop that iterates
+// over the rows of a query.
+type QueryForLoopControl struct {
+	Query tree.Statement
+}
+
+var _ ForLoopControl = &QueryForLoopControl{}
+
+func (c *QueryForLoopControl) isForLoopControl() {}
+
+func (c *QueryForLoopControl) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(c.Query)
+}
+
+// DynamicQueryForLoopControl is the control structure for a FOR loop that
+// iterates over the rows of a query string, which is planned at execution
+// time.
+type DynamicQueryForLoopControl struct {
+	Query  Expr
+	Params []Expr
+}
+
+var _ ForLoopControl = &DynamicQueryForLoopControl{}
+
+func (c *DynamicQueryForLoopControl) isForLoopControl() {}
+
+func (c *DynamicQueryForLoopControl) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("EXECUTE ")
+	ctx.FormatNode(c.Query)
+	if len(c.Params) > 0 {
+		ctx.WriteString(" USING ")
+		for i, param := range c.Params {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(param)
+		}
+	}
+}
+
+// CursorForLoopControl is the control structure for a FOR loop that opens a
+// bound cursor and iterates over its rows.
+type CursorForLoopControl struct {
+	CursorVar Variable
+	Args      []Expr
+}
+
+var _ ForLoopControl = &CursorForLoopControl{}
+
+func (c *CursorForLoopControl) isForLoopControl() {}
+
+func (c *CursorForLoopControl) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(&c.CursorVar)
+	if len(c.Args) > 0 {
+		ctx.WriteString("(")
+		for i, arg := range c.Args {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(arg)
+		}
+		ctx.WriteString(")")
+	}
+}
+
 // stmt_for
 type ForLoop struct {
 	StatementImpl
@@ -682,6 +772,12 @@ func (s *ForLoop) PlpgSQLStatementTag() string {
 	switch s.Control.(type) {
 	case *IntForLoopControl:
 		return "stmt_for_int_loop"
+	case *QueryForLoopControl:
+		return "stmt_for_query_loop"
+	case *DynamicQueryForLoopControl:
+		return "stmt_for_dyn_query_loop"
+	case *CursorForLoopControl:
+		return "stmt_for_cursor_loop"
 	}
 	return "stmt_for_unknown"
 }
@@ -706,14 +802,51 @@ func (s *ForLoop) WalkStmt(visitor StatementVisitor) Statement {
 // stmt_foreach_a
 type ForEachArray struct {
 	StatementImpl
-	Label string
-	Var   *Variable
-	Slice int // TODO(drewk): not sure what this is
+	Label  string
+	Target []Variable
+	// Slice is the number of dimensions of the array that are assigned to the
+	// target on each iteration. It is zero if the elements are assigned one at a
+	// time.
+	Slice int
 	Expr  Expr
 	Body  []Statement
 }
 
+func (s *ForEachArray) CopyNode() *ForEachArray {
+	copyNode := *s
+	copyNode.Body = append([]Statement(nil), copyNode.Body...)
+	return &copyNode
+}
+
 func (s *ForEachArray) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatNameP(&s.Label)
+		ctx.WriteString(">>\n")
+	}
+	ctx.WriteString("FOREACH ")
+	for i, target := range s.Target {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatName(string(target))
+	}
+	if s.Slice != 0 {
+		ctx.WriteString(" SLICE ")
+		ctx.WriteString(strconv.Itoa(s.Slice))
+	}
+	ctx.WriteString(" IN ARRAY ")
+	ctx.FormatNode(s.Expr)
+	ctx.WriteString(" LOOP\n")
+	for _, stmt := range s.Body {
+		ctx.FormatNode(stmt)
+	}
+	ctx.WriteString("END LOOP")
+	if s.Label != "" {
+		ctx.WriteString(" ")
+		ctx.FormatNameP(&s.Label)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ForEachArray) PlpgSQLStatementTag() string {
@@ -721,7 +854,20 @@ func (s *ForEachArray) PlpgSQLStatementTag() string {
 }
 
 func (s *ForEachArray) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, recurse := visitor.Visit(s)
+
+	if recurse {
+		for i, bodyStmt := range s.Body {
+			newBodyStmt := bodyStmt.WalkStmt(visitor)
+			if newBodyStmt != bodyStmt {
+				if newStmt == s {
+					newStmt = s.CopyNode()
+				}
+				newStmt.(*ForEachArray).Body[i] = newBodyStmt
+			}
+		}
+	}
+	return newStmt
 }
 
 // stmt_exit
@@ -822,13 +968,24 @@ func (s *Return) WalkStmt(visitor StatementVisitor) Statement {
 	return newStmt
 }
 
+// stmt_return_next
 type ReturnNext struct {
 	StatementImpl
-	Expr   Expr
-	RetVar Variable
+	Expr Expr
+}
+
+func (s *ReturnNext) CopyNode() *ReturnNext {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *ReturnNext) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN NEXT")
+	if s.Expr != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(s.Expr)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnNext) PlpgSQLStatementTag() string {
@@ -836,17 +993,46 @@ func (s *ReturnNext) PlpgSQLStatementTag() string {
 }
 
 func (s *ReturnNext) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
+// stmt_return_query
 type ReturnQuery struct {
 	StatementImpl
-	Query        Expr
+	// SqlStmt is the query whose result is returned. It is unset if the query is
+	// dynamic.
+	SqlStmt tree.Statement
+	// DynamicQuery is an expression that evaluates to the text of the query
+	// for RETURN QUERY EXECUTE. Params are the values of the query's
+	// placeholders, supplied by the USING clause.
 	DynamicQuery Expr
 	Params       []Expr
 }
 
+func (s *ReturnQuery) CopyNode() *ReturnQuery {
+	copyNode := *s
+	copyNode.Params = append([]Expr(nil), s.Params...)
+	return &copyNode
+}
+
 func (s *ReturnQuery) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN QUERY ")
+	if s.DynamicQuery != nil {
+		ctx.WriteString("EXECUTE ")
+		ctx.FormatNode(s.DynamicQuery)
+		for i := range s.Params {
+			if i == 0 {
+				ctx.WriteString(" USING ")
+			} else {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(s.Params[i])
+		}
+	} else {
+		ctx.FormatNode(s.SqlStmt)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnQuery) PlpgSQLStatementTag() string {
@@ -854,7 +1040,8 @@ func (s *ReturnQuery) PlpgSQLStatementTag() string {
 }
 
 func (s *ReturnQuery) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
 // stmt_raise
@@ -1127,16 +1314,36 @@ type Open struct {
 	CurVar Variable
 	Scroll tree.CursorScrollOption
 	Query  tree.Statement
+
+	// Args are the argument values for a parameterized bound cursor.
+	Args []Expr
+
+	// DynamicQuery is the query string for OPEN ... FOR EXECUTE, and Params are
+	// the values of its parameters.
+	DynamicQuery Expr
+	Params       []Expr
 }
 
 func (s *Open) CopyNode() *Open {
 	copyNode := *s
+	copyNode.Args = append([]Expr(nil), s.Args...)
+	copyNode.Params = append([]Expr(nil), s.Params...)
 	return &copyNode
 }
 
 func (s *Open) Format(ctx *tree.FmtCtx) {
 	ctx.WriteString("OPEN ")
 	ctx.FormatNode(&s.CurVar)
+	if len(s.Args) > 0 {
+		ctx.WriteString("(")
+		for i, arg := range s.Args {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(arg)
+		}
+		ctx.WriteString(")")
+	}
 	switch s.Scroll {
 	case tree.Scroll:
 		ctx.WriteString(" SCROLL")
@@ -1146,6 +1353,18 @@ func (s *Open) Format(ctx *tree.FmtCtx) {
 	if s.Query != nil {
 		ctx.WriteString(" FOR ")
 		ctx.FormatNode(s.Query)
+	} else if s.DynamicQuery != nil {
+		ctx.WriteString(" FOR EXECUTE ")
+		ctx.FormatNode(s.DynamicQuery)
+		if len(s.Params) > 0 {
+			ctx.WriteString(" USING ")
+			for i, param := range s.Params {
+				if i > 0 {
+					ctx.WriteString(", ")
+				}
+				ctx.FormatNode(param)
+			}
+		}
 	}
 	ctx.WriteString(";\n")
 }
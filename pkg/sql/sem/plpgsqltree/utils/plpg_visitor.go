@@ -118,6 +118,28 @@ func simpleVisit(expr tree.Expr, fn tree.SimpleVisitFn) (tree.Expr, error) {
 	return tree.SimpleVisit(expr, fn)
 }
 
+// simpleVisitExprs calls simpleVisit on each of the given expressions. It
+// returns a new slice if one of the expressions was changed.
+func simpleVisitExprs(
+	exprs []tree.Expr, fn tree.SimpleVisitFn,
+) (newExprs []tree.Expr, changed bool, err error) {
+	newExprs = exprs
+	for i := range exprs {
+		e, err := simpleVisit(exprs[i], fn)
+		if err != nil {
+			return nil, false, err
+		}
+		if e != exprs[i] {
+			if !changed {
+				newExprs = append([]tree.Expr(nil), exprs...)
+				changed = true
+			}
+			newExprs[i] = e
+		}
+	}
+	return newExprs, changed, nil
+}
+
 func (v *SQLStmtVisitor) Visit(
 	stmt plpgsqltree.Statement,
 ) (newStmt plpgsqltree.Statement, recurse bool) {
@@ -153,9 +175,26 @@ func (v *SQLStmtVisitor) Visit(
 		if v.Err != nil {
 			return stmt, false
 		}
-		if t.Query != s {
+		e, v.Err = simpleVisit(t.DynamicQuery, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		var newArgs, newParams []tree.Expr
+		var argsChanged, paramsChanged bool
+		newArgs, argsChanged, v.Err = simpleVisitExprs(t.Args, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		newParams, paramsChanged, v.Err = simpleVisitExprs(t.Params, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Query != s || t.DynamicQuery != e || argsChanged || paramsChanged {
 			cpy := t.CopyNode()
 			cpy.Query = s
+			cpy.DynamicQuery = e
+			cpy.Args = newArgs
+			cpy.Params = newParams
 			newStmt = cpy
 		}
 	case *plpgsqltree.Declaration:
@@ -239,6 +278,43 @@ func (v *SQLStmtVisitor) Visit(
 			cpy.Expr = e
 			newStmt = cpy
 		}
+	case *plpgsqltree.ReturnNext:
+		e, v.Err = simpleVisit(t.Expr, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Expr != e {
+			cpy := t.CopyNode()
+			cpy.Expr = e
+			newStmt = cpy
+		}
+	case *plpgsqltree.ReturnQuery:
+		s, v.Err = simpleStmtVisit(t.SqlStmt, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		e, v.Err = simpleVisit(t.DynamicQuery, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.SqlStmt != s || t.DynamicQuery != e {
+			cpy := t.CopyNode()
+			cpy.SqlStmt = s
+			cpy.DynamicQuery = e
+			newStmt = cpy
+		}
+		for i, p := range t.Params {
+			e, v.Err = simpleVisit(p, v.Fn)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if t.Params[i] != e {
+				if newStmt == stmt {
+					newStmt = t.CopyNode()
+				}
+				newStmt.(*plpgsqltree.ReturnQuery).Params[i] = e
+			}
+		}
 	case *plpgsqltree.Raise:
 		for i, p := range t.Params {
 			e, v.Err = simpleVisit(p, v.Fn)
@@ -330,10 +406,58 @@ func (v *SQLStmtVisitor) Visit(
 				}
 				newStmt = cpy
 			}
+		case *plpgsqltree.QueryForLoopControl:
+			s, v.Err = simpleStmtVisit(c.Query, v.Fn)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if c.Query != s {
+				cpy := t.CopyNode()
+				cpy.Control = &plpgsqltree.QueryForLoopControl{Query: s}
+				newStmt = cpy
+			}
+		case *plpgsqltree.DynamicQueryForLoopControl:
+			e, v.Err = simpleVisit(c.Query, v.Fn)
+			if v.Err != nil {
+				return stmt, false
+			}
+			var newParams []tree.Expr
+			var paramsChanged bool
+			newParams, paramsChanged, v.Err = simpleVisitExprs(c.Params, v.Fn)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if c.Query != e || paramsChanged {
+				cpy := t.CopyNode()
+				cpy.Control = &plpgsqltree.DynamicQueryForLoopControl{Query: e, Params: newParams}
+				newStmt = cpy
+			}
+		case *plpgsqltree.CursorForLoopControl:
+			var newArgs []tree.Expr
+			var argsChanged bool
+			newArgs, argsChanged, v.Err = simpleVisitExprs(c.Args, v.Fn)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if argsChanged {
+				cpy := t.CopyNode()
+				cpy.Control = &plpgsqltree.CursorForLoopControl{CursorVar: c.CursorVar, Args: newArgs}
+				newStmt = cpy
+			}
 		}
 
-	case *plpgsqltree.ForEachArray, *plpgsqltree.ReturnNext,
-		*plpgsqltree.ReturnQuery, *plpgsqltree.Perform:
+	case *plpgsqltree.ForEachArray:
+		e, v.Err = simpleVisit(t.Expr, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Expr != e {
+			cpy := t.CopyNode()
+			cpy.Expr = e
+			newStmt = cpy
+		}
+
+	case *plpgsqltree.Perform:
 		panic(unimp.New("plpgsql visitor", "Unimplemented PLpgSQL visitor"))
 	}
 	if v.Err != nil {
@@ -344,7 +468,8 @@ func (v *SQLStmtVisitor) Visit(
 
 // TypeRefVisitor calls the given replace function on each type reference
 // contained in the visited PLpgSQL statements. Note that this currently only
-// includes `Declaration`. SQL statements and expressions are not visited.
+// includes `Declaration` and the arguments of `CursorDeclaration`. SQL
+// statements and expressions are not visited.
 type TypeRefVisitor struct {
 	Fn  func(typ tree.ResolvableTypeReference) (newTyp tree.ResolvableTypeReference, err error)
 	Err error
@@ -359,7 +484,8 @@ func (v *TypeRefVisitor) Visit(
 		return stmt, false
 	}
 	newStmt = stmt
-	if t, ok := stmt.(*plpgsqltree.Declaration); ok {
+	switch t := stmt.(type) {
+	case *plpgsqltree.Declaration:
 		var newTyp tree.ResolvableTypeReference
 		newTyp, v.Err = v.Fn(t.Typ)
 		if v.Err != nil {
@@ -371,6 +497,20 @@ func (v *TypeRefVisitor) Visit(
 				newStmt.(*plpgsqltree.Declaration).Typ = newTyp
 			}
 		}
+	case *plpgsqltree.CursorDeclaration:
+		for i := range t.Args {
+			var newTyp tree.ResolvableTypeReference
+			newTyp, v.Err = v.Fn(t.Args[i].Typ)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if t.Args[i].Typ != newTyp {
+				if newStmt == stmt {
+					newStmt = t.CopyNode()
+				}
+				newStmt.(*plpgsqltree.CursorDeclaration).Args[i].Typ = newTyp
+			}
+		}
 	}
 	return newStmt, true
 }
@@ -0,0 +1,159 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+// AlterDomain represents an ALTER DOMAIN statement.
+type AlterDomain struct {
+	Domain *UnresolvedObjectName
+	Cmd    AlterDomainCmd
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomain) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER DOMAIN ")
+	ctx.FormatNode(node.Domain)
+	ctx.FormatNode(node.Cmd)
+}
+
+// AlterDomainCmd represents a domain modification operation.
+type AlterDomainCmd interface {
+	NodeFormatter
+	alterDomainCmd()
+	// TelemetryName returns the counter name to use for telemetry purposes.
+	TelemetryName() string
+}
+
+func (*AlterDomainSetDefault) alterDomainCmd()       {}
+func (*AlterDomainSetNotNull) alterDomainCmd()       {}
+func (*AlterDomainDropNotNull) alterDomainCmd()      {}
+func (*AlterDomainAddConstraint) alterDomainCmd()    {}
+func (*AlterDomainDropConstraint) alterDomainCmd()   {}
+func (*AlterDomainRenameConstraint) alterDomainCmd() {}
+
+// The RENAME TO, SET SCHEMA and OWNER TO commands are shared with ALTER TYPE.
+func (*AlterTypeRename) alterDomainCmd()    {}
+func (*AlterTypeSetSchema) alterDomainCmd() {}
+func (*AlterTypeOwner) alterDomainCmd()     {}
+
+var _ AlterDomainCmd = &AlterDomainSetDefault{}
+var _ AlterDomainCmd = &AlterDomainSetNotNull{}
+var _ AlterDomainCmd = &AlterDomainDropNotNull{}
+var _ AlterDomainCmd = &AlterDomainAddConstraint{}
+var _ AlterDomainCmd = &AlterDomainDropConstraint{}
+var _ AlterDomainCmd = &AlterDomainRenameConstraint{}
+var _ AlterDomainCmd = &AlterTypeRename{}
+var _ AlterDomainCmd = &AlterTypeSetSchema{}
+var _ AlterDomainCmd = &AlterTypeOwner{}
+
+// AlterDomainSetDefault represents an ALTER DOMAIN SET DEFAULT or DROP DEFAULT
+// command.
+type AlterDomainSetDefault struct {
+	// Default is nil for DROP DEFAULT.
+	Default Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainSetDefault) Format(ctx *FmtCtx) {
+	if node.Default == nil {
+		ctx.WriteString(" DROP DEFAULT")
+	} else {
+		ctx.WriteString(" SET DEFAULT ")
+		ctx.FormatNode(node.Default)
+	}
+}
+
+// TelemetryName implements the AlterDomainCmd interface.
+func (node *AlterDomainSetDefault) TelemetryName() string {
+	return "set_default"
+}
+
+// AlterDomainSetNotNull represents an ALTER DOMAIN SET NOT NULL command.
+type AlterDomainSetNotNull struct{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainSetNotNull) Format(ctx *FmtCtx) {
+	ctx.WriteString(" SET NOT NULL")
+}
+
+// TelemetryName implements the AlterDomainCmd interface.
+func (node *AlterDomainSetNotNull) TelemetryName() string {
+	return "set_not_null"
+}
+
+// AlterDomainDropNotNull represents an ALTER DOMAIN DROP NOT NULL command.
+type AlterDomainDropNotNull struct{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainDropNotNull) Format(ctx *FmtCtx) {
+	ctx.WriteString(" DROP NOT NULL")
+}
+
+// TelemetryName implements the AlterDomainCmd interface.
+func (node *AlterDomainDropNotNull) TelemetryName() string {
+	return "drop_not_null"
+}
+
+// AlterDomainAddConstraint represents an ALTER DOMAIN ADD CONSTRAINT command.
+type AlterDomainAddConstraint struct {
+	Constraint *DomainConstraintDef
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainAddConstraint) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ADD ")
+	ctx.FormatNode(node.Constraint)
+}
+
+// TelemetryName implements the AlterDomainCmd interface.
+func (node *AlterDomainAddConstraint) TelemetryName() string {
+	return "add_constraint"
+}
+
+// AlterDomainDropConstraint represents an ALTER DOMAIN DROP CONSTRAINT
+// command.
+type AlterDomainDropConstraint struct {
+	IfExists     bool
+	Constraint   Name
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainDropConstraint) Format(ctx *FmtCtx) {
+	ctx.WriteString(" DROP CONSTRAINT ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Constraint)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// TelemetryName implements the AlterDomainCmd interface.
+func (node *AlterDomainDropConstraint) TelemetryName() string {
+	return "drop_constraint"
+}
+
+// AlterDomainRenameConstraint represents an ALTER DOMAIN RENAME CONSTRAINT
+// command.
+type AlterDomainRenameConstraint struct {
+	Constraint Name
+	NewName    Name
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainRenameConstraint) Format(ctx *FmtCtx) {
+	ctx.WriteString(" RENAME CONSTRAINT ")
+	ctx.FormatNode(&node.Constraint)
+	ctx.WriteString(" TO ")
+	ctx.FormatNode(&node.NewName)
+}
+
+// TelemetryName implements the AlterDomainCmd interface.
+func (node *AlterDomainRenameConstraint) TelemetryName() string {
+	return "rename_constraint"
+}
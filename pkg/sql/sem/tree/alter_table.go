@@ -159,8 +159,9 @@ func (node *AlterTable) HoistAddColumnConstraints(onHoistedFKConstraint func())
 				normalizedCmds = append(normalizedCmds,
 					&AlterTableAddConstraint{
 						ConstraintDef: &CheckConstraintTableDef{
-							Expr: checkExpr.Expr,
-							Name: checkExpr.ConstraintName,
+							Expr:          checkExpr.Expr,
+							Name:          checkExpr.ConstraintName,
+							Deferrability: checkExpr.Deferrability,
 						},
 						ValidationBehavior: ValidationDefault,
 					},
@@ -173,12 +174,13 @@ func (node *AlterTable) HoistAddColumnConstraints(onHoistedFKConstraint func())
 					targetCol = append(targetCol, d.References.Col)
 				}
 				fk := &ForeignKeyConstraintTableDef{
-					Table:    *d.References.Table,
-					FromCols: NameList{d.Name},
-					ToCols:   targetCol,
-					Name:     d.References.ConstraintName,
-					Actions:  d.References.Actions,
-					Match:    d.References.Match,
+					Table:         *d.References.Table,
+					FromCols:      NameList{d.Name},
+					ToCols:        targetCol,
+					Name:          d.References.ConstraintName,
+					Actions:       d.References.Actions,
+					Match:         d.References.Match,
+					Deferrability: d.References.Deferrability,
 				}
 				constraint := &AlterTableAddConstraint{
 					ConstraintDef:      fk,
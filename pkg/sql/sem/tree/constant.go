@@ -537,14 +537,37 @@ var (
 		types.UUIDArray,
 		types.INet,
 		types.Jsonb,
+		types.Jsonpath,
 		types.PGLSN,
 		types.PGLSNArray,
 		types.PGVector,
 		types.PGVectorArray,
 		types.RefCursor,
 		types.RefCursorArray,
+		types.Int4Range,
+		types.Int8Range,
+		types.NumRange,
+		types.TimestampRange,
+		types.TimestampTZRange,
+		types.DateRange,
+		types.Int4Multirange,
+		types.Int8Multirange,
+		types.NumMultirange,
+		types.TimestampMultirange,
+		types.TimestampTZMultirange,
+		types.DateMultirange,
 		types.TSQuery,
 		types.TSVector,
+		types.Point,
+		types.Box,
+		types.LSeg,
+		types.Line,
+		types.Path,
+		types.Polygon,
+		types.Circle,
+		types.MACAddr,
+		types.MACAddr8,
+		types.Money,
 		types.VarBit,
 		types.AnyEnum,
 		types.AnyEnumArray,
@@ -138,3 +138,30 @@ func (x CompositeKeyMatchMethod) String() string {
 		return strconv.Itoa(int(x))
 	}
 }
+
+// ConstraintDeferrability describes whether the checking of a constraint can
+// be deferred until the end of the transaction.
+type ConstraintDeferrability int8
+
+// The values for ConstraintDeferrability.
+const (
+	// ConstraintNotDeferrable is used for constraints which are always checked
+	// at the end of each statement.
+	ConstraintNotDeferrable ConstraintDeferrability = iota
+	// ConstraintInitiallyImmediate is used for constraints which are checked at
+	// the end of each statement unless deferred with SET CONSTRAINTS.
+	ConstraintInitiallyImmediate
+	// ConstraintInitiallyDeferred is used for constraints which are checked when
+	// the transaction commits unless made immediate with SET CONSTRAINTS.
+	ConstraintInitiallyDeferred
+)
+
+// Format implements the NodeFormatter interface.
+func (node *ConstraintDeferrability) Format(ctx *FmtCtx) {
+	switch *node {
+	case ConstraintInitiallyImmediate:
+		ctx.WriteString(" DEFERRABLE")
+	case ConstraintInitiallyDeferred:
+		ctx.WriteString(" DEFERRABLE INITIALLY DEFERRED")
+	}
+}
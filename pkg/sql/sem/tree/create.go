@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/collatedstring"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
@@ -223,6 +224,19 @@ func (l *IndexElemList) doc(p *PrettyCfg) pretty.Doc {
 	return p.commaSeparated(d...)
 }
 
+// IndexType is the access method of an index, as specified by the USING
+// clause of CREATE INDEX.
+type IndexType uint8
+
+const (
+	// IndexTypeForward is a regular btree index.
+	IndexTypeForward IndexType = iota
+	// IndexTypeInverted is an inverted index (USING gin or USING gist).
+	IndexTypeInverted
+	// IndexTypeVector is a vector index (USING lsh).
+	IndexTypeVector
+)
+
 type IndexInvisibility struct {
 	Value         float64
 	FloatProvided bool
@@ -234,6 +248,7 @@ type CreateIndex struct {
 	Table       TableName
 	Unique      bool
 	Inverted    bool
+	Vector      bool
 	IfNotExists bool
 	Columns     IndexElemList
 	Sharded     *ShardedIndexDef
@@ -272,6 +287,9 @@ func (node *CreateIndex) Format(ctx *FmtCtx) {
 	}
 	ctx.WriteString("ON ")
 	ctx.FormatNode(&node.Table)
+	if node.Vector {
+		ctx.WriteString(" USING lsh")
+	}
 
 	ctx.WriteString(" (")
 	ctx.FormatNode(&node.Columns)
@@ -407,6 +425,83 @@ func (node *CreateType) String() string {
 	return AsString(node)
 }
 
+// CreateDomain represents a CREATE DOMAIN statement.
+type CreateDomain struct {
+	TypeName *UnresolvedObjectName
+	Type     ResolvableTypeReference
+	// Constraints are the DEFAULT, NULL, NOT NULL and CHECK clauses of the
+	// domain, in the order in which they were specified.
+	Constraints DomainConstraintDefs
+}
+
+var _ Statement = &CreateDomain{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateDomain) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE DOMAIN ")
+	ctx.FormatNode(node.TypeName)
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.Type)
+	for _, c := range node.Constraints {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(c)
+	}
+}
+
+func (node *CreateDomain) String() string {
+	return AsString(node)
+}
+
+// DomainConstraintKind is the kind of a DomainConstraintDef.
+type DomainConstraintKind int
+
+const (
+	// DomainDefault is a DEFAULT clause.
+	DomainDefault DomainConstraintKind = iota
+	// DomainNull is a NULL clause, which has no effect.
+	DomainNull
+	// DomainNotNull is a NOT NULL constraint.
+	DomainNotNull
+	// DomainCheck is a CHECK constraint.
+	DomainCheck
+)
+
+// DomainConstraintDef represents a clause of a CREATE DOMAIN statement or the
+// constraint of an ALTER DOMAIN ... ADD CONSTRAINT statement.
+type DomainConstraintDef struct {
+	// Name is the optional constraint name. It is never set for DEFAULT.
+	Name Name
+	Kind DomainConstraintKind
+	// Expr is the default expression for DEFAULT and the check expression for
+	// CHECK. It is nil otherwise.
+	Expr Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DomainConstraintDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	switch node.Kind {
+	case DomainDefault:
+		ctx.WriteString("DEFAULT ")
+		ctx.FormatNode(node.Expr)
+	case DomainNull:
+		ctx.WriteString("NULL")
+	case DomainNotNull:
+		ctx.WriteString("NOT NULL")
+	case DomainCheck:
+		ctx.WriteString("CHECK (")
+		ctx.FormatNode(node.Expr)
+		ctx.WriteByte(')')
+	}
+}
+
+// DomainConstraintDefs is a list of domain constraint definitions.
+type DomainConstraintDefs []*DomainConstraintDef
+
 // TableDef represents a column, index or constraint definition within a CREATE
 // TABLE statement.
 type TableDef interface {
@@ -421,6 +516,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExcludeConstraintTableDef) tableDef()    {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -497,6 +593,7 @@ type ColumnTableDef struct {
 		IsUnique       bool
 		WithoutIndex   bool
 		ConstraintName Name
+		Deferrability  ConstraintDeferrability
 	}
 	DefaultExpr struct {
 		Expr           Expr
@@ -513,6 +610,7 @@ type ColumnTableDef struct {
 		ConstraintName Name
 		Actions        ReferenceActions
 		Match          CompositeKeyMatchMethod
+		Deferrability  ConstraintDeferrability
 	}
 	Computed struct {
 		Computed bool
@@ -531,6 +629,7 @@ type ColumnTableDef struct {
 type ColumnTableDefCheckExpr struct {
 	Expr           Expr
 	ConstraintName Name
+	Deferrability  ConstraintDeferrability
 }
 
 func processCollationOnType(
@@ -681,10 +780,12 @@ func NewColumnTableDef(
 			d.Unique.IsUnique = true
 			d.Unique.WithoutIndex = t.WithoutIndex
 			d.Unique.ConstraintName = c.Name
+			d.Unique.Deferrability = t.Deferrability
 		case *ColumnCheckConstraint:
 			d.CheckExprs = append(d.CheckExprs, ColumnTableDefCheckExpr{
 				Expr:           t.Expr,
 				ConstraintName: c.Name,
+				Deferrability:  t.Deferrability,
 			})
 		case *ColumnFKConstraint:
 			if d.HasFKConstraint() {
@@ -696,6 +797,7 @@ func NewColumnTableDef(
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
+			d.References.Deferrability = t.Deferrability
 		case *ColumnComputedDef:
 			if d.GeneratedIdentity.IsGeneratedAsIdentity {
 				return nil, pgerror.Newf(pgcode.Syntax,
@@ -807,6 +909,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			if node.Unique.WithoutIndex {
 				ctx.WriteString(" WITHOUT INDEX")
 			}
+			ctx.FormatNode(&node.Unique.Deferrability)
 		}
 	}
 	if node.HasDefaultExpr() {
@@ -848,6 +951,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 		ctx.WriteString(" CHECK (")
 		ctx.FormatNode(checkExpr.Expr)
 		ctx.WriteByte(')')
+		ctx.FormatNode(&checkExpr.Deferrability)
 	}
 	if node.HasFKConstraint() {
 		if node.References.ConstraintName != "" {
@@ -866,6 +970,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(node.References.Match.String())
 		}
 		ctx.FormatNode(&node.References.Actions)
+		ctx.FormatNode(&node.References.Deferrability)
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
@@ -994,20 +1099,23 @@ type ShardedPrimaryKeyConstraint struct {
 
 // UniqueConstraint represents UNIQUE on a column.
 type UniqueConstraint struct {
-	WithoutIndex bool
+	WithoutIndex  bool
+	Deferrability ConstraintDeferrability
 }
 
 // ColumnCheckConstraint represents either a check on a column.
 type ColumnCheckConstraint struct {
-	Expr Expr
+	Expr          Expr
+	Deferrability ConstraintDeferrability
 }
 
 // ColumnFKConstraint represents a FK-constaint on a column.
 type ColumnFKConstraint struct {
-	Table   TableName
-	Col     Name // empty-string means use PK
-	Actions ReferenceActions
-	Match   CompositeKeyMatchMethod
+	Table         TableName
+	Col           Name // empty-string means use PK
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	Deferrability ConstraintDeferrability
 }
 
 // ColumnComputedDef represents the description of a computed column.
@@ -1097,14 +1205,16 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExcludeConstraintTableDef) constraintTableDef()    {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
 type UniqueConstraintTableDef struct {
 	IndexTableDef
-	PrimaryKey   bool
-	WithoutIndex bool
-	IfNotExists  bool
+	PrimaryKey    bool
+	WithoutIndex  bool
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
 }
 
 // SetName implements the TableDef interface.
@@ -1149,6 +1259,7 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionByIndex != nil {
 		ctx.FormatNode(node.PartitionByIndex)
 	}
+	ctx.FormatNode(&node.Deferrability)
 	if node.Predicate != nil {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
@@ -1168,13 +1279,14 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 
 // ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
 type ForeignKeyConstraintTableDef struct {
-	Name        Name
-	Table       TableName
-	FromCols    NameList
-	ToCols      NameList
-	Actions     ReferenceActions
-	Match       CompositeKeyMatchMethod
-	IfNotExists bool
+	Name          Name
+	Table         TableName
+	FromCols      NameList
+	ToCols        NameList
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
 }
 
 // Format implements the NodeFormatter interface.
@@ -1205,6 +1317,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(&node.Deferrability)
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1224,6 +1337,7 @@ type CheckConstraintTableDef struct {
 	Expr                  Expr
 	FromHashShardedColumn bool
 	IfNotExists           bool
+	Deferrability         ConstraintDeferrability
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1249,6 +1363,82 @@ func (node *CheckConstraintTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteString("CHECK (")
 	ctx.FormatNode(node.Expr)
 	ctx.WriteByte(')')
+	ctx.FormatNode(&node.Deferrability)
+}
+
+// ExcludeConstraintTableDef represents an EXCLUDE constraint within a CREATE
+// TABLE statement. Two rows violate the constraint if the comparisons of all
+// of its elements with their operators are true.
+type ExcludeConstraintTableDef struct {
+	Name          Name
+	IndexType     IndexType
+	Elems         ExcludeElemList
+	Predicate     Expr
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
+}
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// SetIfNotExists implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetIfNotExists() {
+	node.IfNotExists = true
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		if node.IfNotExists {
+			ctx.WriteString("IF NOT EXISTS ")
+		}
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("EXCLUDE ")
+	if node.IndexType == IndexTypeInverted {
+		ctx.WriteString("USING GIST ")
+	}
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE (")
+		ctx.FormatNode(node.Predicate)
+		ctx.WriteByte(')')
+	}
+	ctx.FormatNode(&node.Deferrability)
+}
+
+// ExcludeElem is an element of an EXCLUDE constraint, which is a column or
+// expression and the operator used to compare its values in different rows.
+type ExcludeElem struct {
+	IndexElem
+	Operator treecmp.ComparisonOperator
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeElem) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.IndexElem)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator.String())
+}
+
+// ExcludeElemList is a list of ExcludeElem.
+type ExcludeElemList []ExcludeElem
+
+// Format pretty-prints the contained names separated by commas.
+// Format implements the NodeFormatter interface.
+func (l *ExcludeElemList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
 }
 
 // FamilyTableDef represents a family definition within a CREATE TABLE
@@ -1603,8 +1793,9 @@ func (node *CreateTable) HoistConstraints() {
 			for _, checkExpr := range col.CheckExprs {
 				node.Defs = append(node.Defs,
 					&CheckConstraintTableDef{
-						Expr: checkExpr.Expr,
-						Name: checkExpr.ConstraintName,
+						Expr:          checkExpr.Expr,
+						Name:          checkExpr.ConstraintName,
+						Deferrability: checkExpr.Deferrability,
 					},
 				)
 			}
@@ -1615,12 +1806,13 @@ func (node *CreateTable) HoistConstraints() {
 					targetCol = append(targetCol, col.References.Col)
 				}
 				node.Defs = append(node.Defs, &ForeignKeyConstraintTableDef{
-					Table:    *col.References.Table,
-					FromCols: NameList{col.Name},
-					ToCols:   targetCol,
-					Name:     col.References.ConstraintName,
-					Actions:  col.References.Actions,
-					Match:    col.References.Match,
+					Table:         *col.References.Table,
+					FromCols:      NameList{col.Name},
+					ToCols:        targetCol,
+					Name:          col.References.ConstraintName,
+					Actions:       col.References.Actions,
+					Match:         col.References.Match,
+					Deferrability: col.References.Deferrability,
 				})
 				col.References.Table = nil
 			}
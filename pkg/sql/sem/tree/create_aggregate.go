@@ -0,0 +1,120 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// CreateAggregate represents a CREATE AGGREGATE statement.
+type CreateAggregate struct {
+	Replace bool
+	Name    RoutineName
+	Params  RoutineParams
+	Options AggregateOptions
+}
+
+var _ Statement = &CreateAggregate{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("AGGREGATE ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte('(')
+	ctx.FormatNode(node.Params)
+	ctx.WriteString(") (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteByte(')')
+}
+
+// String implements the Statement interface.
+func (node *CreateAggregate) String() string {
+	return AsString(node)
+}
+
+// AggregateOptionKind identifies an option in the definition of a CREATE
+// AGGREGATE statement.
+type AggregateOptionKind int
+
+const (
+	// AggregateSFunc is the SFUNC option, which names the state transition
+	// function.
+	AggregateSFunc AggregateOptionKind = iota
+	// AggregateSType is the STYPE option, which is the data type of the
+	// aggregate's state value.
+	AggregateSType
+	// AggregateFinalFunc is the FINALFUNC option, which names the function that
+	// computes the aggregate's result from the final state value.
+	AggregateFinalFunc
+	// AggregateCombineFunc is the COMBINEFUNC option, which names the function
+	// that combines two state values.
+	AggregateCombineFunc
+	// AggregateInitCond is the INITCOND option, which is the initial setting of
+	// the state value.
+	AggregateInitCond
+)
+
+// String returns the keyword of the option.
+func (k AggregateOptionKind) String() string {
+	switch k {
+	case AggregateSFunc:
+		return "SFUNC"
+	case AggregateSType:
+		return "STYPE"
+	case AggregateFinalFunc:
+		return "FINALFUNC"
+	case AggregateCombineFunc:
+		return "COMBINEFUNC"
+	case AggregateInitCond:
+		return "INITCOND"
+	}
+	return "UNKNOWN"
+}
+
+// AggregateOption is a single "name = value" option in the definition of a
+// CREATE AGGREGATE statement.
+type AggregateOption struct {
+	Kind AggregateOptionKind
+	// Func is set for the SFUNC, FINALFUNC and COMBINEFUNC options.
+	Func RoutineName
+	// Type is set for the STYPE option.
+	Type ResolvableTypeReference
+	// Value is set for the INITCOND option.
+	Value string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateOption) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.Kind.String())
+	ctx.WriteString(" = ")
+	switch node.Kind {
+	case AggregateSFunc, AggregateFinalFunc, AggregateCombineFunc:
+		ctx.FormatNode(&node.Func)
+	case AggregateSType:
+		ctx.FormatTypeReference(node.Type)
+	case AggregateInitCond:
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, node.Value, ctx.flags.EncodeFlags())
+		}
+	}
+}
+
+// AggregateOptions is a list of options of a CREATE AGGREGATE statement.
+type AggregateOptions []AggregateOption
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateOptions) Format(ctx *FmtCtx) {
+	for i := range *node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*node)[i])
+	}
+}
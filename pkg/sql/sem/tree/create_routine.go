@@ -460,10 +460,12 @@ type RoutineReturnType struct {
 	SetOf bool
 }
 
-// DropRoutine represents a DROP FUNCTION or DROP PROCEDURE statement.
+// DropRoutine represents a DROP FUNCTION, DROP PROCEDURE or DROP AGGREGATE
+// statement.
 type DropRoutine struct {
 	IfExists     bool
 	Procedure    bool
+	Aggregate    bool
 	Routines     RoutineObjs
 	DropBehavior DropBehavior
 }
@@ -472,6 +474,8 @@ type DropRoutine struct {
 func (node *DropRoutine) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("DROP PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("DROP AGGREGATE ")
 	} else {
 		ctx.WriteString("DROP FUNCTION ")
 	}
@@ -531,18 +535,21 @@ func (node *AlterFunctionOptions) Format(ctx *FmtCtx) {
 	}
 }
 
-// AlterRoutineRename represents a ALTER FUNCTION...RENAME or
-// ALTER PROCEDURE...RENAME statement.
+// AlterRoutineRename represents a ALTER FUNCTION...RENAME,
+// ALTER PROCEDURE...RENAME or ALTER AGGREGATE...RENAME statement.
 type AlterRoutineRename struct {
 	Function  RoutineObj
 	NewName   Name
 	Procedure bool
+	Aggregate bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterRoutineRename) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("ALTER PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
 	} else {
 		ctx.WriteString("ALTER FUNCTION ")
 	}
@@ -551,18 +558,21 @@ func (node *AlterRoutineRename) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.NewName)
 }
 
-// AlterRoutineSetSchema represents a ALTER FUNCTION...SET SCHEMA or
-// ALTER PROCEDURE...SET SCHEMA statement.
+// AlterRoutineSetSchema represents a ALTER FUNCTION...SET SCHEMA,
+// ALTER PROCEDURE...SET SCHEMA or ALTER AGGREGATE...SET SCHEMA statement.
 type AlterRoutineSetSchema struct {
 	Function      RoutineObj
 	NewSchemaName Name
 	Procedure     bool
+	Aggregate     bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterRoutineSetSchema) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("ALTER PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
 	} else {
 		ctx.WriteString("ALTER FUNCTION ")
 	}
@@ -571,18 +581,21 @@ func (node *AlterRoutineSetSchema) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.NewSchemaName)
 }
 
-// AlterRoutineSetOwner represents the ALTER FUNCTION...OWNER TO or
-// ALTER PROCEDURE...OWNER TO statement.
+// AlterRoutineSetOwner represents the ALTER FUNCTION...OWNER TO,
+// ALTER PROCEDURE...OWNER TO or ALTER AGGREGATE...OWNER TO statement.
 type AlterRoutineSetOwner struct {
 	Function  RoutineObj
 	NewOwner  RoleSpec
 	Procedure bool
+	Aggregate bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterRoutineSetOwner) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("ALTER PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
 	} else {
 		ctx.WriteString("ALTER FUNCTION ")
 	}
@@ -645,6 +658,8 @@ const (
 	ColumnDefaultExprInNewView      SchemaExprContext = "DEFAULT (in CREATE VIEW)"
 	ColumnDefaultExprInSetDefault   SchemaExprContext = "DEFAULT (in SET DEFAULT)"
 	CheckConstraintExpr             SchemaExprContext = "CHECK"
+	DomainCheckExpr                 SchemaExprContext = "CHECK (in DOMAIN)"
+	DomainDefaultExpr               SchemaExprContext = "DEFAULT (in DOMAIN)"
 	UniqueWithoutIndexPredicateExpr SchemaExprContext = "UNIQUE WITHOUT INDEX PREDICATE"
 	IndexPredicateExpr              SchemaExprContext = "INDEX PREDICATE"
 	ExpressionIndexElementExpr      SchemaExprContext = "EXPRESSION INDEX ELEMENT"
@@ -5,7 +5,10 @@
 
 package tree
 
-import "github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/lib/pq/oid"
+)
 
 type CreateTrigger struct {
 	Replace     bool
@@ -23,6 +26,11 @@ type CreateTrigger struct {
 	// TODO(#128536): Pass this information through `memo.CreateTriggerExpr`
 	// instead.
 	FuncBody string
+	// BuiltinFuncOID is the OID of the trigger function if it is a builtin
+	// function, or zero if it is a user-defined function.
+	// TODO(#128536): Pass this information through `memo.CreateTriggerExpr`
+	// instead.
+	BuiltinFuncOID oid.Oid
 }
 
 var _ Statement = &CreateTrigger{}
@@ -36,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/money"
 	"github.com/cockroachdb/cockroach/pkg/util/stringencoding"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
@@ -397,6 +398,17 @@ func ParseDIPAddrFromINetString(s string) (*DIPAddr, error) {
 	return &d, nil
 }
 
+// ParseDCIDR parses and returns the cidr Datum (implemented as a *DOidWrapper
+// around a *DIPAddr) represented by the provided string, or an error if
+// parsing is unsuccessful.
+func ParseDCIDR(s string) (Datum, error) {
+	var d DIPAddr
+	if err := ipaddr.ParseCIDR(s, &d.IPAddr); err != nil {
+		return nil, err
+	}
+	return NewDCIDR(&d), nil
+}
+
 // GetBool gets DBool or an error (also treats NULL as false, not an error).
 func GetBool(d Datum) (DBool, error) {
 	if v, ok := d.(*DBool); ok {
@@ -2029,6 +2041,8 @@ type ParseContext interface {
 	GetDateStyle() pgdate.DateStyle
 	// GetDateHelper returns a helper to optimize parsing of datetime types.
 	GetDateHelper() *pgdate.ParseHelper
+	// GetMoneyLocale returns the lc_monetary locale in the session.
+	GetMoneyLocale() *money.Locale
 }
 
 var _ ParseContext = &simpleParseContext{}
@@ -2043,6 +2057,14 @@ func NewParseContextOptionDateStyle(dateStyle pgdate.DateStyle) NewParseContextO
 	}
 }
 
+// NewParseContextOptionMoneyLocale sets the lc_monetary locale for the
+// context.
+func NewParseContextOptionMoneyLocale(locale string) NewParseContextOption {
+	return func(ret *simpleParseContext) {
+		ret.MoneyLocale = locale
+	}
+}
+
 // NewParseContext constructs a ParseContext that returns
 // the given values.
 func NewParseContext(relativeParseTime time.Time, opts ...NewParseContextOption) ParseContext {
@@ -2060,6 +2082,7 @@ type simpleParseContext struct {
 	CollationEnvironment CollationEnvironment
 	DateStyle            pgdate.DateStyle
 	IntervalStyle        duration.IntervalStyle
+	MoneyLocale          string
 	dateHelper           pgdate.ParseHelper
 }
 
@@ -2088,6 +2111,11 @@ func (ctx *simpleParseContext) GetDateHelper() *pgdate.ParseHelper {
 	return &ctx.dateHelper
 }
 
+// GetMoneyLocale implements ParseContext.
+func (ctx *simpleParseContext) GetMoneyLocale() *money.Locale {
+	return money.MustLookupLocale(ctx.MoneyLocale)
+}
+
 // relativeParseTime chooses a reasonable "now" value for
 // performing date parsing.
 func relativeParseTime(ctx ParseContext) time.Time {
@@ -2111,6 +2139,13 @@ func intervalStyle(ctx ParseContext) duration.IntervalStyle {
 	return ctx.GetIntervalStyle()
 }
 
+func moneyLocale(ctx ParseContext) *money.Locale {
+	if ctx == nil {
+		return money.MustLookupLocale(money.DefaultLocale)
+	}
+	return ctx.GetMoneyLocale()
+}
+
 func dateParseHelper(ctx ParseContext) *pgdate.ParseHelper {
 	if ctx == nil {
 		return nil
@@ -3963,6 +3998,9 @@ func MustBeDJSON(e Expr) DJSON {
 func AsJSON(
 	d Datum, dcc sessiondatapb.DataConversionConfig, loc *time.Location,
 ) (json.JSON, error) {
+	if w, ok := d.(*DOidWrapper); ok && w.Oid == oid.T_cidr {
+		return json.FromString(AsStringWithFlags(w, FmtBareStrings)), nil
+	}
 	d = UnwrapDOidWrapper(d)
 	switch t := d.(type) {
 	case *DBool:
@@ -4022,7 +4060,8 @@ func AsJSON(
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(formatTime(t.UTC(), "2006-01-02T15:04:05.999999999")), nil
 	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D,
-		*DTSVector, *DTSQuery, *DPGLSN, *DPGVector:
+		*DTSVector, *DTSQuery, *DPGLSN, *DPGVector, *DRange, *DMultirange, *DJsonpath,
+		*DPoint, *DBox, *DLSeg, *DLine, *DPath, *DPolygon, *DCircle, *DMACAddr, *DMACAddr8, *DMoney:
 		return json.FromString(
 			AsStringWithFlags(t, FmtBareStrings, FmtDataConversionConfig(dcc), FmtLocation(loc)),
 		), nil
@@ -5696,6 +5735,7 @@ func (d *DOid) Name() string {
 // Types that currently benefit from DOidWrapper are:
 // - DName => DOidWrapper(*DString, oid.T_name)
 // - DRefCursor => DOidWrapper(*DString, oid.T_refcursor)
+// - DCIDR => DOidWrapper(*DIPAddr, oid.T_cidr)
 type DOidWrapper struct {
 	Wrapped Datum
 	Oid     oid.Oid
@@ -5709,10 +5749,11 @@ func wrapWithOid(d Datum, oid oid.Oid) Datum {
 	case *DInt:
 	case *DString:
 	case *DArray:
+	case *DIPAddr:
 	case dNull, *DOidWrapper:
 		panic(errors.AssertionFailedf("cannot wrap %T with an Oid", v))
 	default:
-		// Currently only *DInt, *DString, *DArray are hooked up to work with
+		// Currently only *DInt, *DString, *DArray, *DIPAddr are hooked up to work with
 		// *DOidWrapper. To support another base Datum type, replace all type
 		// assertions to that type with calls to functions like AsDInt and
 		// MustBeDInt.
@@ -5797,6 +5838,11 @@ func (d *DOidWrapper) Format(ctx *FmtCtx) {
 		wrapped.Format(ctx)
 		return
 	}
+	if d.Oid == oid.T_cidr {
+		// Unlike inet, cidr values are always displayed with their netmask.
+		formatQuoted(ctx, MustBeDIPAddr(d.Wrapped).IPAddr.CIDRString())
+		return
+	}
 	ctx.FormatNode(d.Wrapped)
 }
 
@@ -5876,6 +5922,12 @@ func NewDRefCursor(d string) Datum {
 	return NewDRefCursorFromDString(NewDString(d))
 }
 
+// NewDCIDR is a helper routine to create a *DCIDR (implemented as a
+// *DOidWrapper) initialized from an existing *DIPAddr.
+func NewDCIDR(d *DIPAddr) Datum {
+	return wrapWithOid(d, oid.T_cidr)
+}
+
 // NewDIntVectorFromDArray is a helper routine to create a new *DArray,
 // initialized from an existing *DArray, with the special oid for IntVector.
 func NewDIntVectorFromDArray(d *DArray) Datum {
@@ -6074,6 +6126,8 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.GeometryFamily:       {unsafe.Sizeof(DGeometry{}), variableSize},
 	types.PGLSNFamily:          {unsafe.Sizeof(DPGLSN{}), fixedSize},
 	types.PGVectorFamily:       {unsafe.Sizeof(DPGVector{}), variableSize},
+	types.RangeFamily:          {unsafe.Sizeof(DRange{}), variableSize},
+	types.MultirangeFamily:     {unsafe.Sizeof(DMultirange{}), variableSize},
 	types.RefCursorFamily:      {unsafe.Sizeof(DString("")), variableSize},
 	types.TimeFamily:           {unsafe.Sizeof(DTime(0)), fixedSize},
 	types.TimeTZFamily:         {unsafe.Sizeof(DTimeTZ{}), fixedSize},
@@ -6083,8 +6137,19 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.TSVectorFamily:       {unsafe.Sizeof(DTSVector{}), variableSize},
 	types.IntervalFamily:       {unsafe.Sizeof(DInterval{}), fixedSize},
 	types.JsonFamily:           {unsafe.Sizeof(DJSON{}), variableSize},
+	types.JsonpathFamily:       {unsafe.Sizeof(DJsonpath{}), variableSize},
+	types.PointFamily:          {unsafe.Sizeof(DPoint{}), fixedSize},
+	types.BoxFamily:            {unsafe.Sizeof(DBox{}), fixedSize},
+	types.LSegFamily:           {unsafe.Sizeof(DLSeg{}), fixedSize},
+	types.LineFamily:           {unsafe.Sizeof(DLine{}), fixedSize},
+	types.PathFamily:           {unsafe.Sizeof(DPath{}), variableSize},
+	types.PolygonFamily:        {unsafe.Sizeof(DPolygon{}), variableSize},
+	types.CircleFamily:         {unsafe.Sizeof(DCircle{}), fixedSize},
 	types.UuidFamily:           {unsafe.Sizeof(DUuid{}), fixedSize},
 	types.INetFamily:           {unsafe.Sizeof(DIPAddr{}), fixedSize},
+	types.MACAddrFamily:        {unsafe.Sizeof(DMACAddr{}), fixedSize},
+	types.MACAddr8Family:       {unsafe.Sizeof(DMACAddr8{}), fixedSize},
+	types.MoneyFamily:          {unsafe.Sizeof(DMoney(0)), fixedSize},
 	types.OidFamily:            {unsafe.Sizeof(DOid{}.Oid), fixedSize},
 	types.EnumFamily:           {unsafe.Sizeof(DEnum{}), variableSize},
 
@@ -6248,6 +6313,8 @@ func InferTypes(vals []string) []types.Family {
 // the width of the value is wider than a single character. For this exception,
 // AdjustValueToType performs the truncation itself.
 func AdjustValueToType(typ *types.T, inVal Datum) (outVal Datum, err error) {
+	// Values of a domain are adjusted to its base type.
+	typ = typ.DomainBaseType()
 	switch typ.Family() {
 	case types.StringFamily, types.CollatedStringFamily:
 		var sv string
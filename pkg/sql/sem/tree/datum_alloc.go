@@ -222,6 +222,11 @@ func (a *DatumAlloc) NewDName(v DString) Datum {
 	return NewDNameFromDString(a.NewDString(v))
 }
 
+// NewDCIDR allocates a DCIDR.
+func (a *DatumAlloc) NewDCIDR(v DIPAddr) Datum {
+	return NewDCIDR(a.NewDIPAddr(v))
+}
+
 // NewDRefCursor allocates a DRefCursor.
 func (a *DatumAlloc) NewDRefCursor(v DString) Datum {
 	return NewDRefCursorFromDString(a.NewDString(v))
@@ -0,0 +1,775 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/geometric"
+	"github.com/cockroachdb/errors"
+)
+
+// formatGeometric formats the text representation of a geometric value,
+// quoting it unless bare strings are requested. The text representations of
+// geometric values never contain quotes.
+func formatGeometric(ctx *FmtCtx, s string) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.WriteString(s)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// DPoint is the point Datum, which represents a point on a plane.
+type DPoint struct {
+	geometric.Point
+}
+
+// NewDPoint is a helper routine to create a DPoint initialized from its
+// argument.
+func NewDPoint(v geometric.Point) *DPoint {
+	return &DPoint{Point: v}
+}
+
+// ParseDPoint parses the text representation of a point and returns a
+// DPoint value.
+func ParseDPoint(s string) (*DPoint, error) {
+	v, err := geometric.ParsePoint(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDPoint(v), nil
+}
+
+// AsDPoint attempts to retrieve a DPoint from an Expr, returning a DPoint and a
+// flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DPoint wrapped by a
+// *DOidWrapper is possible.
+func AsDPoint(e Expr) (*DPoint, bool) {
+	switch t := e.(type) {
+	case *DPoint:
+		return t, true
+	case *DOidWrapper:
+		return AsDPoint(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDPoint attempts to retrieve a DPoint from an Expr, panicking if the
+// assertion fails.
+func MustBeDPoint(e Expr) *DPoint {
+	v, ok := AsDPoint(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DPoint, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DPoint) Format(ctx *FmtCtx) {
+	formatGeometric(ctx, d.Point.String())
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DPoint) ResolvedType() *types.T {
+	return types.Point
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DPoint) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface. Geometric values are ordered by
+// their coordinates, which gives them a total order that is used for exact
+// equality but does not correspond to any of the ordering operators defined by
+// Postgres.
+func (d *DPoint) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DPoint)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.Point.Compare(v.Point), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DPoint) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DPoint) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DPoint) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DPoint) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DPoint) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DPoint) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DPoint) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
+// DBox is the box Datum, which represents a rectangular box whose sides are parallel to the axes.
+type DBox struct {
+	geometric.Box
+}
+
+// NewDBox is a helper routine to create a DBox initialized from its
+// argument.
+func NewDBox(v geometric.Box) *DBox {
+	return &DBox{Box: v}
+}
+
+// ParseDBox parses the text representation of a box and returns a
+// DBox value.
+func ParseDBox(s string) (*DBox, error) {
+	v, err := geometric.ParseBox(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDBox(v), nil
+}
+
+// AsDBox attempts to retrieve a DBox from an Expr, returning a DBox and a
+// flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DBox wrapped by a
+// *DOidWrapper is possible.
+func AsDBox(e Expr) (*DBox, bool) {
+	switch t := e.(type) {
+	case *DBox:
+		return t, true
+	case *DOidWrapper:
+		return AsDBox(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDBox attempts to retrieve a DBox from an Expr, panicking if the
+// assertion fails.
+func MustBeDBox(e Expr) *DBox {
+	v, ok := AsDBox(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DBox, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DBox) Format(ctx *FmtCtx) {
+	formatGeometric(ctx, d.Box.String())
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DBox) ResolvedType() *types.T {
+	return types.Box
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DBox) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DBox) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DBox)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.Box.Compare(v.Box), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DBox) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DBox) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DBox) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DBox) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DBox) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DBox) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DBox) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
+// DLSeg is the lseg Datum, which represents a finite line segment.
+type DLSeg struct {
+	geometric.LSeg
+}
+
+// NewDLSeg is a helper routine to create a DLSeg initialized from its
+// argument.
+func NewDLSeg(v geometric.LSeg) *DLSeg {
+	return &DLSeg{LSeg: v}
+}
+
+// ParseDLSeg parses the text representation of a lseg and returns a
+// DLSeg value.
+func ParseDLSeg(s string) (*DLSeg, error) {
+	v, err := geometric.ParseLSeg(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDLSeg(v), nil
+}
+
+// AsDLSeg attempts to retrieve a DLSeg from an Expr, returning a DLSeg and a
+// flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DLSeg wrapped by a
+// *DOidWrapper is possible.
+func AsDLSeg(e Expr) (*DLSeg, bool) {
+	switch t := e.(type) {
+	case *DLSeg:
+		return t, true
+	case *DOidWrapper:
+		return AsDLSeg(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDLSeg attempts to retrieve a DLSeg from an Expr, panicking if the
+// assertion fails.
+func MustBeDLSeg(e Expr) *DLSeg {
+	v, ok := AsDLSeg(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DLSeg, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DLSeg) Format(ctx *FmtCtx) {
+	formatGeometric(ctx, d.LSeg.String())
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DLSeg) ResolvedType() *types.T {
+	return types.LSeg
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DLSeg) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DLSeg) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DLSeg)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.LSeg.Compare(v.LSeg), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DLSeg) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DLSeg) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DLSeg) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DLSeg) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DLSeg) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DLSeg) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DLSeg) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
+// DLine is the line Datum, which represents an infinite line.
+type DLine struct {
+	geometric.Line
+}
+
+// NewDLine is a helper routine to create a DLine initialized from its
+// argument.
+func NewDLine(v geometric.Line) *DLine {
+	return &DLine{Line: v}
+}
+
+// ParseDLine parses the text representation of a line and returns a
+// DLine value.
+func ParseDLine(s string) (*DLine, error) {
+	v, err := geometric.ParseLine(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDLine(v), nil
+}
+
+// AsDLine attempts to retrieve a DLine from an Expr, returning a DLine and a
+// flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DLine wrapped by a
+// *DOidWrapper is possible.
+func AsDLine(e Expr) (*DLine, bool) {
+	switch t := e.(type) {
+	case *DLine:
+		return t, true
+	case *DOidWrapper:
+		return AsDLine(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDLine attempts to retrieve a DLine from an Expr, panicking if the
+// assertion fails.
+func MustBeDLine(e Expr) *DLine {
+	v, ok := AsDLine(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DLine, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DLine) Format(ctx *FmtCtx) {
+	formatGeometric(ctx, d.Line.String())
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DLine) ResolvedType() *types.T {
+	return types.Line
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DLine) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DLine) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DLine)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.Line.Compare(v.Line), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DLine) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DLine) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DLine) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DLine) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DLine) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DLine) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DLine) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
+// DPath is the path Datum, which represents an open or closed path made of connected points.
+type DPath struct {
+	geometric.Path
+}
+
+// NewDPath is a helper routine to create a DPath initialized from its
+// argument.
+func NewDPath(v geometric.Path) *DPath {
+	return &DPath{Path: v}
+}
+
+// ParseDPath parses the text representation of a path and returns a
+// DPath value.
+func ParseDPath(s string) (*DPath, error) {
+	v, err := geometric.ParsePath(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDPath(v), nil
+}
+
+// AsDPath attempts to retrieve a DPath from an Expr, returning a DPath and a
+// flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DPath wrapped by a
+// *DOidWrapper is possible.
+func AsDPath(e Expr) (*DPath, bool) {
+	switch t := e.(type) {
+	case *DPath:
+		return t, true
+	case *DOidWrapper:
+		return AsDPath(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDPath attempts to retrieve a DPath from an Expr, panicking if the
+// assertion fails.
+func MustBeDPath(e Expr) *DPath {
+	v, ok := AsDPath(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DPath, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DPath) Format(ctx *FmtCtx) {
+	formatGeometric(ctx, d.Path.String())
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DPath) ResolvedType() *types.T {
+	return types.Path
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DPath) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DPath) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DPath)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.Path.Compare(v.Path), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DPath) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DPath) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DPath) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DPath) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DPath) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DPath) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DPath) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.Points))*unsafe.Sizeof(geometric.Point{})
+}
+
+// DPolygon is the polygon Datum, which represents a polygon.
+type DPolygon struct {
+	geometric.Polygon
+}
+
+// NewDPolygon is a helper routine to create a DPolygon initialized from its
+// argument.
+func NewDPolygon(v geometric.Polygon) *DPolygon {
+	return &DPolygon{Polygon: v}
+}
+
+// ParseDPolygon parses the text representation of a polygon and returns a
+// DPolygon value.
+func ParseDPolygon(s string) (*DPolygon, error) {
+	v, err := geometric.ParsePolygon(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDPolygon(v), nil
+}
+
+// AsDPolygon attempts to retrieve a DPolygon from an Expr, returning a DPolygon and a
+// flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DPolygon wrapped by a
+// *DOidWrapper is possible.
+func AsDPolygon(e Expr) (*DPolygon, bool) {
+	switch t := e.(type) {
+	case *DPolygon:
+		return t, true
+	case *DOidWrapper:
+		return AsDPolygon(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDPolygon attempts to retrieve a DPolygon from an Expr, panicking if the
+// assertion fails.
+func MustBeDPolygon(e Expr) *DPolygon {
+	v, ok := AsDPolygon(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DPolygon, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DPolygon) Format(ctx *FmtCtx) {
+	formatGeometric(ctx, d.Polygon.String())
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DPolygon) ResolvedType() *types.T {
+	return types.Polygon
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DPolygon) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DPolygon) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DPolygon)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.Polygon.Compare(v.Polygon), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DPolygon) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DPolygon) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DPolygon) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DPolygon) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DPolygon) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DPolygon) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DPolygon) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.Points))*unsafe.Sizeof(geometric.Point{})
+}
+
+// DCircle is the circle Datum, which represents a circle.
+type DCircle struct {
+	geometric.Circle
+}
+
+// NewDCircle is a helper routine to create a DCircle initialized from its
+// argument.
+func NewDCircle(v geometric.Circle) *DCircle {
+	return &DCircle{Circle: v}
+}
+
+// ParseDCircle parses the text representation of a circle and returns a
+// DCircle value.
+func ParseDCircle(s string) (*DCircle, error) {
+	v, err := geometric.ParseCircle(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDCircle(v), nil
+}
+
+// AsDCircle attempts to retrieve a DCircle from an Expr, returning a DCircle and a
+// flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DCircle wrapped by a
+// *DOidWrapper is possible.
+func AsDCircle(e Expr) (*DCircle, bool) {
+	switch t := e.(type) {
+	case *DCircle:
+		return t, true
+	case *DOidWrapper:
+		return AsDCircle(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDCircle attempts to retrieve a DCircle from an Expr, panicking if the
+// assertion fails.
+func MustBeDCircle(e Expr) *DCircle {
+	v, ok := AsDCircle(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DCircle, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DCircle) Format(ctx *FmtCtx) {
+	formatGeometric(ctx, d.Circle.String())
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DCircle) ResolvedType() *types.T {
+	return types.Circle
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DCircle) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DCircle) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DCircle)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.Circle.Compare(v.Circle), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DCircle) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DCircle) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DCircle) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DCircle) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DCircle) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DCircle) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DCircle) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
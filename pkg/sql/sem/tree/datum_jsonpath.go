@@ -0,0 +1,135 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"context"
+	"strings"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
+	"github.com/cockroachdb/errors"
+)
+
+// DJsonpath is the jsonpath Datum, which holds a parsed SQL/JSON path
+// expression.
+type DJsonpath struct {
+	jsonpath.Jsonpath
+}
+
+// NewDJsonpath is a helper routine to create a DJsonpath initialized from its
+// argument.
+func NewDJsonpath(p jsonpath.Jsonpath) *DJsonpath {
+	return &DJsonpath{Jsonpath: p}
+}
+
+// ParseDJsonpath takes a string of jsonpath and returns a DJsonpath value.
+func ParseDJsonpath(s string) (Datum, error) {
+	p, err := jsonpath.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDJsonpath(p), nil
+}
+
+// AsDJsonpath attempts to retrieve a DJsonpath from an Expr, returning a
+// DJsonpath and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DJsonpath wrapped by a *DOidWrapper is possible.
+func AsDJsonpath(e Expr) (*DJsonpath, bool) {
+	switch t := e.(type) {
+	case *DJsonpath:
+		return t, true
+	case *DOidWrapper:
+		return AsDJsonpath(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDJsonpath attempts to retrieve a DJsonpath from an Expr, panicking if
+// the assertion fails.
+func MustBeDJsonpath(e Expr) *DJsonpath {
+	v, ok := AsDJsonpath(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DJsonpath, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DJsonpath) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	str := d.Jsonpath.String()
+	if !bareStrings {
+		str = strings.ReplaceAll(str, `'`, `''`)
+	}
+	ctx.WriteString(str)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DJsonpath) ResolvedType() *types.T {
+	return types.Jsonpath
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DJsonpath) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface. Postgres does not define an ordering
+// of jsonpath values, so they are ordered by their text representation.
+func (d *DJsonpath) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DJsonpath)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return strings.Compare(d.Jsonpath.String(), v.Jsonpath.String()), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DJsonpath) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DJsonpath) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DJsonpath) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DJsonpath) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DJsonpath) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DJsonpath) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DJsonpath) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.Jsonpath.String()))
+}
@@ -0,0 +1,270 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"context"
+	"math"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/macaddr"
+	"github.com/cockroachdb/errors"
+)
+
+// formatQuoted writes s to the FmtCtx, quoting it unless bare strings are
+// requested. It is used for the types whose text representation never
+// contains quotes.
+func formatQuoted(ctx *FmtCtx, s string) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.WriteString(s)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// DMACAddr is the macaddr Datum, which represents a 6 byte MAC address.
+type DMACAddr struct {
+	macaddr.MACAddr
+}
+
+// NewDMACAddr is a helper routine to create a DMACAddr initialized from its
+// argument.
+func NewDMACAddr(v macaddr.MACAddr) *DMACAddr {
+	return &DMACAddr{MACAddr: v}
+}
+
+// ParseDMACAddr parses the text representation of a macaddr and returns a
+// DMACAddr value.
+func ParseDMACAddr(s string) (*DMACAddr, error) {
+	v, err := macaddr.ParseMACAddr(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDMACAddr(v), nil
+}
+
+// AsDMACAddr attempts to retrieve a DMACAddr from an Expr, returning a
+// DMACAddr and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DMACAddr wrapped by a *DOidWrapper is possible.
+func AsDMACAddr(e Expr) (*DMACAddr, bool) {
+	switch t := e.(type) {
+	case *DMACAddr:
+		return t, true
+	case *DOidWrapper:
+		return AsDMACAddr(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMACAddr attempts to retrieve a DMACAddr from an Expr, panicking if
+// the assertion fails.
+func MustBeDMACAddr(e Expr) *DMACAddr {
+	v, ok := AsDMACAddr(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMACAddr, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DMACAddr) Format(ctx *FmtCtx) {
+	formatQuoted(ctx, d.MACAddr.String())
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DMACAddr) ResolvedType() *types.T {
+	return types.MACAddr
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DMACAddr) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DMACAddr) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DMACAddr)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return compareUint64(uint64(d.MACAddr), uint64(v.MACAddr)), nil
+}
+
+// dMaxMACAddr is the largest macaddr.
+var dMaxMACAddr = NewDMACAddr(macaddr.MACAddr(1<<(8*macaddr.Size) - 1))
+
+// Prev implements the Datum interface.
+func (d *DMACAddr) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	if d.IsMin(ctx, cmpCtx) {
+		return nil, false
+	}
+	return NewDMACAddr(d.MACAddr - 1), true
+}
+
+// Next implements the Datum interface.
+func (d *DMACAddr) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	if d.IsMax(ctx, cmpCtx) {
+		return nil, false
+	}
+	return NewDMACAddr(d.MACAddr + 1), true
+}
+
+// IsMin implements the Datum interface.
+func (d *DMACAddr) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return d.MACAddr == 0
+}
+
+// IsMax implements the Datum interface.
+func (d *DMACAddr) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return d.MACAddr == dMaxMACAddr.MACAddr
+}
+
+// Max implements the Datum interface.
+func (d *DMACAddr) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return dMaxMACAddr, true
+}
+
+// Min implements the Datum interface.
+func (d *DMACAddr) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return NewDMACAddr(0), true
+}
+
+// Size implements the Datum interface.
+func (d *DMACAddr) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
+// DMACAddr8 is the macaddr8 Datum, which represents an 8 byte MAC address in
+// EUI-64 format.
+type DMACAddr8 struct {
+	macaddr.MACAddr8
+}
+
+// NewDMACAddr8 is a helper routine to create a DMACAddr8 initialized from its
+// argument.
+func NewDMACAddr8(v macaddr.MACAddr8) *DMACAddr8 {
+	return &DMACAddr8{MACAddr8: v}
+}
+
+// ParseDMACAddr8 parses the text representation of a macaddr8 and returns a
+// DMACAddr8 value.
+func ParseDMACAddr8(s string) (*DMACAddr8, error) {
+	v, err := macaddr.ParseMACAddr8(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDMACAddr8(v), nil
+}
+
+// AsDMACAddr8 attempts to retrieve a DMACAddr8 from an Expr, returning a
+// DMACAddr8 and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DMACAddr8 wrapped by a *DOidWrapper is possible.
+func AsDMACAddr8(e Expr) (*DMACAddr8, bool) {
+	switch t := e.(type) {
+	case *DMACAddr8:
+		return t, true
+	case *DOidWrapper:
+		return AsDMACAddr8(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMACAddr8 attempts to retrieve a DMACAddr8 from an Expr, panicking if
+// the assertion fails.
+func MustBeDMACAddr8(e Expr) *DMACAddr8 {
+	v, ok := AsDMACAddr8(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMACAddr8, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DMACAddr8) Format(ctx *FmtCtx) {
+	formatQuoted(ctx, d.MACAddr8.String())
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DMACAddr8) ResolvedType() *types.T {
+	return types.MACAddr8
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DMACAddr8) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DMACAddr8) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DMACAddr8)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return compareUint64(uint64(d.MACAddr8), uint64(v.MACAddr8)), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DMACAddr8) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	if d.IsMin(ctx, cmpCtx) {
+		return nil, false
+	}
+	return NewDMACAddr8(d.MACAddr8 - 1), true
+}
+
+// Next implements the Datum interface.
+func (d *DMACAddr8) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	if d.IsMax(ctx, cmpCtx) {
+		return nil, false
+	}
+	return NewDMACAddr8(d.MACAddr8 + 1), true
+}
+
+// IsMin implements the Datum interface.
+func (d *DMACAddr8) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return d.MACAddr8 == 0
+}
+
+// IsMax implements the Datum interface.
+func (d *DMACAddr8) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return d.MACAddr8 == math.MaxUint64
+}
+
+// Max implements the Datum interface.
+func (d *DMACAddr8) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return NewDMACAddr8(math.MaxUint64), true
+}
+
+// Min implements the Datum interface.
+func (d *DMACAddr8) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return NewDMACAddr8(0), true
+}
+
+// Size implements the Datum interface.
+func (d *DMACAddr8) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
+func compareUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
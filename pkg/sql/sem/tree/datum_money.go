@@ -0,0 +1,141 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"context"
+	"math"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/money"
+	"github.com/cockroachdb/errors"
+)
+
+// DMoney is the money Datum. It stores a currency amount as an integral
+// number of the smallest unit of the currency, e.g. cents. Like in postgres,
+// the amount is interpreted and formatted according to the lc_monetary
+// session setting.
+type DMoney int64
+
+// NewDMoney is a helper routine to create a *DMoney initialized from its
+// argument.
+func NewDMoney(d DMoney) *DMoney {
+	return &d
+}
+
+// ParseDMoney parses the text representation of a money value using the
+// lc_monetary locale of the ParseContext and returns a DMoney.
+func ParseDMoney(ctx ParseContext, s string) (*DMoney, error) {
+	v, err := money.Parse(s, moneyLocale(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return NewDMoney(DMoney(v)), nil
+}
+
+// AsDMoney attempts to retrieve a DMoney from an Expr, returning a DMoney and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DMoney wrapped by a
+// *DOidWrapper is possible.
+func AsDMoney(e Expr) (DMoney, bool) {
+	switch t := e.(type) {
+	case *DMoney:
+		return *t, true
+	case *DOidWrapper:
+		return AsDMoney(t.Wrapped)
+	}
+	return 0, false
+}
+
+// MustBeDMoney attempts to retrieve a DMoney from an Expr, panicking if the
+// assertion fails.
+func MustBeDMoney(e Expr) DMoney {
+	v, ok := AsDMoney(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMoney, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DMoney) Format(ctx *FmtCtx) {
+	formatQuoted(ctx, money.Format(int64(*d), money.MustLookupLocale(ctx.dataConversionConfig.LcMonetary)))
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DMoney) ResolvedType() *types.T {
+	return types.Money
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DMoney) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DMoney) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DMoney)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	switch {
+	case *d < *v:
+		return -1, nil
+	case *d > *v:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+var (
+	dMinMoney = NewDMoney(math.MinInt64)
+	dMaxMoney = NewDMoney(math.MaxInt64)
+)
+
+// Prev implements the Datum interface.
+func (d *DMoney) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	if d.IsMin(ctx, cmpCtx) {
+		return nil, false
+	}
+	return NewDMoney(*d - 1), true
+}
+
+// Next implements the Datum interface.
+func (d *DMoney) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	if d.IsMax(ctx, cmpCtx) {
+		return nil, false
+	}
+	return NewDMoney(*d + 1), true
+}
+
+// IsMax implements the Datum interface.
+func (d *DMoney) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return *d == *dMaxMoney
+}
+
+// IsMin implements the Datum interface.
+func (d *DMoney) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return *d == *dMinMoney
+}
+
+// Max implements the Datum interface.
+func (d *DMoney) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return dMaxMoney, true
+}
+
+// Min implements the Datum interface.
+func (d *DMoney) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return dMinMoney, true
+}
+
+// Size implements the Datum interface.
+func (d *DMoney) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
@@ -0,0 +1,1014 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// DRange is the Datum representation of the range types, such as int4range
+// and tstzrange.
+//
+// Ranges of discrete types (integers and dates) are always stored in their
+// canonical form, with an inclusive lower bound and an exclusive upper bound,
+// so that equal ranges have the same representation.
+type DRange struct {
+	typ *types.T
+	// Lower and Upper are the bounds of the range. A nil bound is infinite.
+	Lower, Upper Datum
+	// LowerInc and UpperInc indicate whether the bounds are inclusive. They are
+	// always false for infinite bounds.
+	LowerInc, UpperInc bool
+	// Empty is true if the range contains no values, in which case the bounds
+	// are unset.
+	Empty bool
+}
+
+// DMultirange is the Datum representation of the multirange types, such as
+// int4multirange and tstzmultirange.
+type DMultirange struct {
+	typ *types.T
+	// Ranges are the non-empty ranges of the multirange, sorted by their lower
+	// bounds. No two ranges overlap or are adjacent.
+	Ranges []*DRange
+}
+
+var (
+	errRangeBoundOrder = pgerror.New(pgcode.DataException,
+		"range lower bound must be less than or equal to range upper bound")
+	errRangeUnionNotContiguous = pgerror.New(pgcode.DataException,
+		"result of range union would not be contiguous")
+	errRangeDifferenceNotContiguous = pgerror.New(pgcode.DataException,
+		"result of range difference would not be contiguous")
+)
+
+// NewDRange returns a range with the given bounds. The bounds must already be
+// in canonical form; use MakeDRange to build a range from arbitrary bounds.
+func NewDRange(typ *types.T, lower, upper Datum, lowerInc, upperInc bool) *DRange {
+	return &DRange{typ: typ, Lower: lower, Upper: upper, LowerInc: lowerInc, UpperInc: upperInc}
+}
+
+// NewDEmptyRange returns the empty range of the given type.
+func NewDEmptyRange(typ *types.T) *DRange {
+	return &DRange{typ: typ, Empty: true}
+}
+
+// MakeDRange returns the range of the given type with the given bounds, where
+// a nil or NULL bound is infinite. It returns an error if the lower bound is
+// greater than the upper bound.
+func MakeDRange(
+	ctx context.Context,
+	cmpCtx CompareContext,
+	typ *types.T,
+	lower, upper Datum,
+	lowerInc, upperInc bool,
+) (*DRange, error) {
+	if lower == DNull {
+		lower = nil
+	}
+	if upper == DNull {
+		upper = nil
+	}
+	if lower != nil && upper != nil {
+		c, err := lower.Compare(ctx, cmpCtx, upper)
+		if err != nil {
+			return nil, err
+		}
+		if c > 0 {
+			return nil, errRangeBoundOrder
+		}
+	}
+	return makeRangeFromBounds(
+		ctx, cmpCtx, typ,
+		rangeBound{val: lower, inc: lowerInc && lower != nil, lower: true},
+		rangeBound{val: upper, inc: upperInc && upper != nil},
+	)
+}
+
+// rangeBound is the lower or upper bound of a range.
+type rangeBound struct {
+	// val is the value of the bound, or nil if the bound is infinite.
+	val   Datum
+	inc   bool
+	lower bool
+}
+
+func (d *DRange) lowerBound() rangeBound {
+	return rangeBound{val: d.Lower, inc: d.LowerInc, lower: true}
+}
+
+func (d *DRange) upperBound() rangeBound {
+	return rangeBound{val: d.Upper, inc: d.UpperInc}
+}
+
+// compareRangeBounds compares two bounds, which may be a lower and an upper
+// bound, by the position at which they start or stop including values.
+func compareRangeBounds(
+	ctx context.Context, cmpCtx CompareContext, a, b rangeBound,
+) (int, error) {
+	switch {
+	case a.val == nil && b.val == nil:
+		if a.lower == b.lower {
+			return 0, nil
+		}
+		return boundSign(a.lower), nil
+	case a.val == nil:
+		return boundSign(a.lower), nil
+	case b.val == nil:
+		return -boundSign(b.lower), nil
+	}
+	c, err := a.val.Compare(ctx, cmpCtx, b.val)
+	if err != nil || c != 0 {
+		return c, err
+	}
+	switch {
+	case a.inc && b.inc:
+		return 0, nil
+	case !a.inc && !b.inc:
+		if a.lower == b.lower {
+			return 0, nil
+		}
+		return -boundSign(a.lower), nil
+	case !a.inc:
+		return -boundSign(a.lower), nil
+	default:
+		return boundSign(b.lower), nil
+	}
+}
+
+// boundSign returns -1 for lower bounds and 1 for upper bounds.
+func boundSign(lower bool) int {
+	if lower {
+		return -1
+	}
+	return 1
+}
+
+// boundsAdjacent returns whether the given upper bound and lower bound touch
+// without overlapping.
+func boundsAdjacent(
+	ctx context.Context, cmpCtx CompareContext, upper, lower rangeBound,
+) (bool, error) {
+	if upper.val == nil || lower.val == nil {
+		return false, nil
+	}
+	c, err := upper.val.Compare(ctx, cmpCtx, lower.val)
+	if err != nil || c != 0 {
+		return false, err
+	}
+	return upper.inc != lower.inc, nil
+}
+
+// isDiscreteRangeSubtype returns whether the ranges of the given subtype are
+// stored in canonical form.
+func isDiscreteRangeSubtype(typ *types.T) bool {
+	switch typ.Family() {
+	case types.IntFamily, types.DateFamily:
+		return true
+	}
+	return false
+}
+
+// rangeSuccessor returns the value following the given value of a discrete
+// range subtype.
+func rangeSuccessor(typ *types.T, d Datum) (Datum, error) {
+	switch t := d.(type) {
+	case *DInt:
+		if (typ.Width() == 32 && *t >= math.MaxInt32) || *t == math.MaxInt64 {
+			return nil, ErrIntOutOfRange
+		}
+		return NewDInt(*t + 1), nil
+	case *DDate:
+		if !t.IsFinite() {
+			return d, nil
+		}
+		next, err := t.AddDays(1)
+		if err != nil {
+			return nil, err
+		}
+		return NewDDate(next), nil
+	}
+	return nil, errors.AssertionFailedf("unexpected range bound %T", d)
+}
+
+// makeRangeFromBounds returns the range with the given bounds in canonical
+// form, or the empty range if the lower bound is past the upper bound.
+func makeRangeFromBounds(
+	ctx context.Context, cmpCtx CompareContext, typ *types.T, lower, upper rangeBound,
+) (*DRange, error) {
+	if sub := typ.RangeContents(); isDiscreteRangeSubtype(sub) {
+		var err error
+		if lower.val != nil && !lower.inc {
+			if lower.val, err = rangeSuccessor(sub, lower.val); err != nil {
+				return nil, err
+			}
+			lower.inc = true
+		}
+		if upper.val != nil && upper.inc {
+			if upper.val, err = rangeSuccessor(sub, upper.val); err != nil {
+				return nil, err
+			}
+			upper.inc = false
+		}
+	}
+	c, err := compareRangeBounds(ctx, cmpCtx, lower, upper)
+	if err != nil {
+		return nil, err
+	}
+	if c > 0 {
+		return NewDEmptyRange(typ), nil
+	}
+	return NewDRange(typ, lower.val, upper.val, lower.inc, upper.inc), nil
+}
+
+// ranges returns the range as a list of zero or one non-empty ranges, which is
+// the representation of the operations shared with multiranges.
+func (d *DRange) ranges() []*DRange {
+	if d.Empty {
+		return nil
+	}
+	return []*DRange{d}
+}
+
+// fromRanges returns a range of the type of d from a list of at most one
+// range.
+func (d *DRange) fromRanges(rs []*DRange) *DRange {
+	if len(rs) == 0 {
+		return NewDEmptyRange(d.typ)
+	}
+	return rs[0]
+}
+
+// ContainsElem returns whether the range contains the given value.
+func (d *DRange) ContainsElem(ctx context.Context, cmpCtx CompareContext, elem Datum) (bool, error) {
+	if d.Empty {
+		return false, nil
+	}
+	if d.Lower != nil {
+		c, err := d.Lower.Compare(ctx, cmpCtx, elem)
+		if err != nil || c > 0 || (c == 0 && !d.LowerInc) {
+			return false, err
+		}
+	}
+	if d.Upper != nil {
+		c, err := d.Upper.Compare(ctx, cmpCtx, elem)
+		if err != nil || c < 0 || (c == 0 && !d.UpperInc) {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// containsRange returns whether the non-empty range d contains the non-empty
+// range other.
+func (d *DRange) containsRange(
+	ctx context.Context, cmpCtx CompareContext, other *DRange,
+) (bool, error) {
+	c, err := compareRangeBounds(ctx, cmpCtx, d.lowerBound(), other.lowerBound())
+	if err != nil || c > 0 {
+		return false, err
+	}
+	c, err = compareRangeBounds(ctx, cmpCtx, d.upperBound(), other.upperBound())
+	return c >= 0, err
+}
+
+// overlapsRange returns whether the non-empty ranges d and other have values
+// in common.
+func (d *DRange) overlapsRange(
+	ctx context.Context, cmpCtx CompareContext, other *DRange,
+) (bool, error) {
+	c, err := compareRangeBounds(ctx, cmpCtx, d.lowerBound(), other.upperBound())
+	if err != nil || c > 0 {
+		return false, err
+	}
+	c, err = compareRangeBounds(ctx, cmpCtx, other.lowerBound(), d.upperBound())
+	return c <= 0, err
+}
+
+// Union returns the union of the ranges. It returns an error if the ranges do
+// not overlap and are not adjacent, since the union would have a gap.
+func (d *DRange) Union(ctx context.Context, cmpCtx CompareContext, other *DRange) (*DRange, error) {
+	rs, err := normalizeRanges(ctx, cmpCtx, append(d.ranges(), other.ranges()...))
+	if err != nil {
+		return nil, err
+	}
+	if len(rs) > 1 {
+		return nil, errRangeUnionNotContiguous
+	}
+	return d.fromRanges(rs), nil
+}
+
+// Merge returns the smallest range which includes both ranges, as
+// range_merge does.
+func (d *DRange) Merge(ctx context.Context, cmpCtx CompareContext, other *DRange) (*DRange, error) {
+	if d.Empty {
+		return other, nil
+	}
+	if other.Empty {
+		return d, nil
+	}
+	lower, upper := d.lowerBound(), d.upperBound()
+	if c, err := compareRangeBounds(ctx, cmpCtx, other.lowerBound(), lower); err != nil {
+		return nil, err
+	} else if c < 0 {
+		lower = other.lowerBound()
+	}
+	if c, err := compareRangeBounds(ctx, cmpCtx, other.upperBound(), upper); err != nil {
+		return nil, err
+	} else if c > 0 {
+		upper = other.upperBound()
+	}
+	return NewDRange(d.typ, lower.val, upper.val, lower.inc, upper.inc), nil
+}
+
+// Intersect returns the intersection of the ranges.
+func (d *DRange) Intersect(
+	ctx context.Context, cmpCtx CompareContext, other *DRange,
+) (*DRange, error) {
+	rs, err := intersectRanges(ctx, cmpCtx, d.typ, d.ranges(), other.ranges())
+	if err != nil {
+		return nil, err
+	}
+	return d.fromRanges(rs), nil
+}
+
+// Difference returns the values of d which are not in other. It returns an
+// error if other splits d in two.
+func (d *DRange) Difference(
+	ctx context.Context, cmpCtx CompareContext, other *DRange,
+) (*DRange, error) {
+	rs, err := subtractRanges(ctx, cmpCtx, d.typ, d.ranges(), other.ranges())
+	if err != nil {
+		return nil, err
+	}
+	if len(rs) > 1 {
+		return nil, errRangeDifferenceNotContiguous
+	}
+	return d.fromRanges(rs), nil
+}
+
+// RangeContains returns whether the range or multirange left contains right,
+// which is a range, a multirange or a value of the subtype of left.
+func RangeContains(ctx context.Context, cmpCtx CompareContext, left, right Datum) (bool, error) {
+	left, right = UnwrapDOidWrapper(left), UnwrapDOidWrapper(right)
+	switch right.(type) {
+	case *DRange, *DMultirange:
+		return rangesContain(ctx, cmpCtx, RangesOf(left), RangesOf(right))
+	}
+	switch t := left.(type) {
+	case *DRange:
+		return t.ContainsElem(ctx, cmpCtx, right)
+	case *DMultirange:
+		return t.ContainsElem(ctx, cmpCtx, right)
+	}
+	return false, errors.AssertionFailedf("expected range or multirange, found %T", left)
+}
+
+// RangeOverlaps returns whether the ranges or multiranges left and right have
+// values in common.
+func RangeOverlaps(ctx context.Context, cmpCtx CompareContext, left, right Datum) (bool, error) {
+	return rangesOverlap(ctx, cmpCtx, RangesOf(left), RangesOf(right))
+}
+
+// RangeAdjacent returns whether the ranges or multiranges left and right
+// touch without overlapping.
+func RangeAdjacent(ctx context.Context, cmpCtx CompareContext, left, right Datum) (bool, error) {
+	return rangesAdjacent(ctx, cmpCtx, RangesOf(left), RangesOf(right))
+}
+
+// rangesContain returns whether the union of the sorted ranges a contains the
+// union of the sorted ranges b.
+func rangesContain(ctx context.Context, cmpCtx CompareContext, a, b []*DRange) (bool, error) {
+	i := 0
+	for _, r := range b {
+		for ; i < len(a); i++ {
+			c, err := compareRangeBounds(ctx, cmpCtx, a[i].upperBound(), r.upperBound())
+			if err != nil {
+				return false, err
+			}
+			if c >= 0 {
+				break
+			}
+		}
+		if i == len(a) {
+			return false, nil
+		}
+		if ok, err := a[i].containsRange(ctx, cmpCtx, r); err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// rangesOverlap returns whether the sorted ranges a and b have values in
+// common.
+func rangesOverlap(ctx context.Context, cmpCtx CompareContext, a, b []*DRange) (bool, error) {
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if ok, err := a[i].overlapsRange(ctx, cmpCtx, b[j]); err != nil || ok {
+			return ok, err
+		}
+		c, err := compareRangeBounds(ctx, cmpCtx, a[i].upperBound(), b[j].upperBound())
+		if err != nil {
+			return false, err
+		}
+		if c < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return false, nil
+}
+
+// rangesAdjacent returns whether the unions of the sorted ranges a and b
+// touch without overlapping.
+func rangesAdjacent(ctx context.Context, cmpCtx CompareContext, a, b []*DRange) (bool, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return false, nil
+	}
+	ok, err := boundsAdjacent(ctx, cmpCtx, a[len(a)-1].upperBound(), b[0].lowerBound())
+	if err != nil || ok {
+		return ok, err
+	}
+	return boundsAdjacent(ctx, cmpCtx, b[len(b)-1].upperBound(), a[0].lowerBound())
+}
+
+// normalizeRanges sorts the given ranges and merges the ones which overlap or
+// are adjacent, dropping the empty ones.
+func normalizeRanges(ctx context.Context, cmpCtx CompareContext, rs []*DRange) ([]*DRange, error) {
+	res := make([]*DRange, 0, len(rs))
+	for _, r := range rs {
+		if !r.Empty {
+			res = append(res, r)
+		}
+	}
+	var sortErr error
+	sort.SliceStable(res, func(i, j int) bool {
+		c, err := compareRangeBounds(ctx, cmpCtx, res[i].lowerBound(), res[j].lowerBound())
+		if err != nil {
+			sortErr = err
+		}
+		return c < 0
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+	n := 0
+	for _, r := range res {
+		if n > 0 {
+			last := res[n-1]
+			overlaps, err := last.overlapsRange(ctx, cmpCtx, r)
+			if err != nil {
+				return nil, err
+			}
+			adjacent, err := boundsAdjacent(ctx, cmpCtx, last.upperBound(), r.lowerBound())
+			if err != nil {
+				return nil, err
+			}
+			if overlaps || adjacent {
+				c, err := compareRangeBounds(ctx, cmpCtx, r.upperBound(), last.upperBound())
+				if err != nil {
+					return nil, err
+				}
+				if c > 0 {
+					res[n-1] = NewDRange(last.typ, last.Lower, r.Upper, last.LowerInc, r.UpperInc)
+				}
+				continue
+			}
+		}
+		res[n] = r
+		n++
+	}
+	return res[:n], nil
+}
+
+// intersectRanges returns the intersection of the sorted ranges a and b.
+func intersectRanges(
+	ctx context.Context, cmpCtx CompareContext, typ *types.T, a, b []*DRange,
+) ([]*DRange, error) {
+	var res []*DRange
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		lower, upper := a[i].lowerBound(), a[i].upperBound()
+		if c, err := compareRangeBounds(ctx, cmpCtx, b[j].lowerBound(), lower); err != nil {
+			return nil, err
+		} else if c > 0 {
+			lower = b[j].lowerBound()
+		}
+		c, err := compareRangeBounds(ctx, cmpCtx, b[j].upperBound(), upper)
+		if err != nil {
+			return nil, err
+		}
+		if c < 0 {
+			upper = b[j].upperBound()
+		}
+		r, err := makeRangeFromBounds(ctx, cmpCtx, typ, lower, upper)
+		if err != nil {
+			return nil, err
+		}
+		if !r.Empty {
+			res = append(res, r)
+		}
+		if c < 0 {
+			j++
+		} else {
+			i++
+		}
+	}
+	return res, nil
+}
+
+// subtractRanges returns the values of the sorted ranges a which are not in
+// the sorted ranges b.
+func subtractRanges(
+	ctx context.Context, cmpCtx CompareContext, typ *types.T, a, b []*DRange,
+) ([]*DRange, error) {
+	var res []*DRange
+	for _, r := range a {
+		for _, s := range b {
+			overlaps, err := r.overlapsRange(ctx, cmpCtx, s)
+			if err != nil {
+				return nil, err
+			}
+			if !overlaps {
+				continue
+			}
+			// Keep the part of r before s, if any.
+			c, err := compareRangeBounds(ctx, cmpCtx, r.lowerBound(), s.lowerBound())
+			if err != nil {
+				return nil, err
+			}
+			if c < 0 {
+				before, err := makeRangeFromBounds(
+					ctx, cmpCtx, typ, r.lowerBound(), rangeBound{val: s.Lower, inc: !s.LowerInc},
+				)
+				if err != nil {
+					return nil, err
+				}
+				if !before.Empty {
+					res = append(res, before)
+				}
+			}
+			// Continue with the part of r after s, if any.
+			c, err = compareRangeBounds(ctx, cmpCtx, r.upperBound(), s.upperBound())
+			if err != nil {
+				return nil, err
+			}
+			if c <= 0 {
+				r = nil
+				break
+			}
+			if r, err = makeRangeFromBounds(
+				ctx, cmpCtx, typ, rangeBound{val: s.Upper, inc: !s.UpperInc, lower: true}, r.upperBound(),
+			); err != nil {
+				return nil, err
+			}
+			if r.Empty {
+				r = nil
+				break
+			}
+		}
+		if r != nil {
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+// AsDRange attempts to retrieve a *DRange from an Expr, returning a *DRange and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DRange wrapped by a
+// *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a *DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	r, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return r
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DRange) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface. As in Postgres, the empty range
+// sorts first, and the other ranges sort by their lower and then their upper
+// bounds.
+func (d *DRange) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DRange)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return compareRanges(ctx, cmpCtx, d, v)
+}
+
+func compareRanges(ctx context.Context, cmpCtx CompareContext, a, b *DRange) (int, error) {
+	switch {
+	case a.Empty && b.Empty:
+		return 0, nil
+	case a.Empty:
+		return -1, nil
+	case b.Empty:
+		return 1, nil
+	}
+	c, err := compareRangeBounds(ctx, cmpCtx, a.lowerBound(), b.lowerBound())
+	if err != nil || c != 0 {
+		return c, err
+	}
+	return compareRangeBounds(ctx, cmpCtx, a.upperBound(), b.upperBound())
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return d.Empty
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return NewDEmptyRange(d.typ), true
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DRange) Format(ctx *FmtCtx) {
+	var sb strings.Builder
+	d.formatText(ctx, &sb)
+	formatRangeText(ctx, sb.String())
+}
+
+// formatText writes the text representation of the range, as used by
+// Postgres, to sb.
+func (d *DRange) formatText(ctx *FmtCtx, sb *strings.Builder) {
+	if d.Empty {
+		sb.WriteString("empty")
+		return
+	}
+	if d.LowerInc {
+		sb.WriteByte('[')
+	} else {
+		sb.WriteByte('(')
+	}
+	formatRangeBound(ctx, sb, d.Lower)
+	sb.WriteByte(',')
+	formatRangeBound(ctx, sb, d.Upper)
+	if d.UpperInc {
+		sb.WriteByte(']')
+	} else {
+		sb.WriteByte(')')
+	}
+}
+
+var rangeQuoteSet asciiSet
+
+func init() {
+	var ok bool
+	rangeQuoteSet, ok = makeASCIISet(" \t\v\f\r\n()[],\"\\")
+	if !ok {
+		panic("range asciiset")
+	}
+}
+
+// formatRangeBound writes the value of a range bound, quoting it if it could
+// not be parsed back otherwise. Nothing is written for infinite bounds.
+func formatRangeBound(ctx *FmtCtx, sb *strings.Builder, bound Datum) {
+	if bound == nil {
+		return
+	}
+	s := AsStringWithFlags(
+		bound, FmtBareStrings, FmtDataConversionConfig(ctx.dataConversionConfig), FmtLocation(ctx.location),
+	)
+	quote := s == "" || rangeQuoteSet.in(s)
+	if quote {
+		sb.WriteByte('"')
+	}
+	for _, r := range s {
+		if r == '"' || r == '\\' {
+			sb.WriteRune(r)
+		}
+		sb.WriteRune(r)
+	}
+	if quote {
+		sb.WriteByte('"')
+	}
+}
+
+// formatRangeText writes the text representation of a range or a multirange,
+// as a string literal unless bare strings are requested.
+func formatRangeText(ctx *FmtCtx, s string) {
+	if ctx.HasFlags(FmtFlags(lexbase.EncBareStrings)) {
+		ctx.WriteString(s)
+		return
+	}
+	ctx.WriteByte('\'')
+	ctx.WriteString(strings.ReplaceAll(s, `'`, `''`))
+	ctx.WriteByte('\'')
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if d.Lower != nil {
+		sz += d.Lower.Size()
+	}
+	if d.Upper != nil {
+		sz += d.Upper.Size()
+	}
+	return sz
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DRange) IsComposite() bool {
+	for _, bound := range []Datum{d.Lower, d.Upper} {
+		if cdatum, ok := bound.(CompositeDatum); ok && cdatum.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// NewDMultirange returns a multirange with the given ranges, which must
+// already be sorted, non-empty, and neither overlapping nor adjacent. Use
+// MakeDMultirange to build a multirange from arbitrary ranges.
+func NewDMultirange(typ *types.T, ranges []*DRange) *DMultirange {
+	return &DMultirange{typ: typ, Ranges: ranges}
+}
+
+// MakeDMultirange returns the multirange of the given type which contains the
+// values of all the given ranges.
+func MakeDMultirange(
+	ctx context.Context, cmpCtx CompareContext, typ *types.T, ranges []*DRange,
+) (*DMultirange, error) {
+	rs, err := normalizeRanges(ctx, cmpCtx, ranges)
+	if err != nil {
+		return nil, err
+	}
+	return NewDMultirange(typ, rs), nil
+}
+
+// ContainsElem returns whether the multirange contains the given value.
+func (d *DMultirange) ContainsElem(
+	ctx context.Context, cmpCtx CompareContext, elem Datum,
+) (bool, error) {
+	for _, r := range d.Ranges {
+		if ok, err := r.ContainsElem(ctx, cmpCtx, elem); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// Union returns the union of the multiranges.
+func (d *DMultirange) Union(
+	ctx context.Context, cmpCtx CompareContext, other *DMultirange,
+) (*DMultirange, error) {
+	ranges := make([]*DRange, 0, len(d.Ranges)+len(other.Ranges))
+	ranges = append(append(ranges, d.Ranges...), other.Ranges...)
+	return MakeDMultirange(ctx, cmpCtx, d.typ, ranges)
+}
+
+// Intersect returns the intersection of the multiranges.
+func (d *DMultirange) Intersect(
+	ctx context.Context, cmpCtx CompareContext, other *DMultirange,
+) (*DMultirange, error) {
+	rs, err := intersectRanges(ctx, cmpCtx, d.typ.MultirangeContents(), d.Ranges, other.Ranges)
+	if err != nil {
+		return nil, err
+	}
+	return NewDMultirange(d.typ, rs), nil
+}
+
+// Difference returns the values of d which are not in other.
+func (d *DMultirange) Difference(
+	ctx context.Context, cmpCtx CompareContext, other *DMultirange,
+) (*DMultirange, error) {
+	rs, err := subtractRanges(ctx, cmpCtx, d.typ.MultirangeContents(), d.Ranges, other.Ranges)
+	if err != nil {
+		return nil, err
+	}
+	return NewDMultirange(d.typ, rs), nil
+}
+
+// Span returns the smallest range which contains the multirange.
+func (d *DMultirange) Span() *DRange {
+	if len(d.Ranges) == 0 {
+		return NewDEmptyRange(d.typ.MultirangeContents())
+	}
+	first, last := d.Ranges[0], d.Ranges[len(d.Ranges)-1]
+	return NewDRange(first.typ, first.Lower, last.Upper, first.LowerInc, last.UpperInc)
+}
+
+// RangesOf returns the non-empty ranges of a range or multirange datum, sorted
+// by their lower bounds.
+func RangesOf(d Datum) []*DRange {
+	switch t := UnwrapDOidWrapper(d).(type) {
+	case *DRange:
+		return t.ranges()
+	case *DMultirange:
+		return t.Ranges
+	}
+	panic(errors.AssertionFailedf("expected range or multirange, found %T", d))
+}
+
+// AsDMultirange attempts to retrieve a *DMultirange from an Expr, returning a
+// *DMultirange and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DMultirange wrapped by a *DOidWrapper is possible.
+func AsDMultirange(e Expr) (*DMultirange, bool) {
+	switch t := e.(type) {
+	case *DMultirange:
+		return t, true
+	case *DOidWrapper:
+		return AsDMultirange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMultirange attempts to retrieve a *DMultirange from an Expr, panicking
+// if the assertion fails.
+func MustBeDMultirange(e Expr) *DMultirange {
+	m, ok := AsDMultirange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMultirange, found %T", e))
+	}
+	return m
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DMultirange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DMultirange) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface. Multiranges are compared range by
+// range, and a multirange sorts after its prefixes.
+func (d *DMultirange) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DMultirange)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	for i := 0; i < len(d.Ranges) && i < len(v.Ranges); i++ {
+		c, err := compareRanges(ctx, cmpCtx, d.Ranges[i], v.Ranges[i])
+		if err != nil || c != 0 {
+			return c, err
+		}
+	}
+	switch {
+	case len(d.Ranges) < len(v.Ranges):
+		return -1, nil
+	case len(d.Ranges) > len(v.Ranges):
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DMultirange) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DMultirange) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DMultirange) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DMultirange) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return len(d.Ranges) == 0
+}
+
+// Max implements the Datum interface.
+func (d *DMultirange) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DMultirange) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return NewDMultirange(d.typ, nil), true
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DMultirange) Format(ctx *FmtCtx) {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, r := range d.Ranges {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		r.formatText(ctx, &sb)
+	}
+	sb.WriteByte('}')
+	formatRangeText(ctx, sb.String())
+}
+
+// Size implements the Datum interface.
+func (d *DMultirange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	for _, r := range d.Ranges {
+		sz += r.Size()
+	}
+	return sz
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DMultirange) IsComposite() bool {
+	for _, r := range d.Ranges {
+		if r.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeParseCompareContext is the CompareContext used to build the ranges
+// parsed from strings. The bounds of a range always have the same type, so
+// comparing them does not depend on the session.
+type rangeParseCompareContext struct {
+	parseCtx ParseContext
+}
+
+var _ CompareContext = rangeParseCompareContext{}
+
+// UnwrapDatum implements the CompareContext interface.
+func (c rangeParseCompareContext) UnwrapDatum(ctx context.Context, d Datum) Datum {
+	return UnwrapDOidWrapper(d)
+}
+
+// GetLocation implements the CompareContext interface.
+func (c rangeParseCompareContext) GetLocation() *time.Location {
+	return c.GetRelativeParseTime().Location()
+}
+
+// GetRelativeParseTime implements the CompareContext interface.
+func (c rangeParseCompareContext) GetRelativeParseTime() time.Time {
+	return relativeParseTime(c.parseCtx)
+}
+
+// MustGetPlaceholderValue implements the CompareContext interface.
+func (c rangeParseCompareContext) MustGetPlaceholderValue(
+	ctx context.Context, p *Placeholder,
+) Datum {
+	panic(errors.AssertionFailedf("unexpected placeholder %s in range bound", p))
+}
@@ -0,0 +1,266 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/randgen"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	testData := []struct {
+		typ      *types.T
+		str      string
+		expected string
+	}{
+		// Ranges of discrete types are stored in their canonical [) form.
+		{types.Int4Range, `[1,5)`, `[1,5)`},
+		{types.Int4Range, `(1,5]`, `[2,6)`},
+		{types.Int8Range, `[1,5]`, `[1,6)`},
+		{types.Int8Range, `(,5]`, `(,6)`},
+		{types.Int8Range, `[5,)`, `[5,)`},
+		{types.Int8Range, `(,)`, `(,)`},
+		{types.Int8Range, `[3,3)`, `empty`},
+		{types.Int8Range, `(3,4)`, `empty`},
+		{types.Int8Range, `EMPTY`, `empty`},
+		{types.DateRange, `(2024-01-01,2024-01-31]`, `[2024-01-02,2024-02-01)`},
+		// Continuous ranges keep their bounds.
+		{types.NumRange, `(1.5,2.5]`, `(1.5,2.5]`},
+		{types.NumRange, `[1.5,1.5]`, `[1.5,1.5]`},
+		{types.NumRange, `(1.5,1.5]`, `empty`},
+		{types.TimestampRange, `["2024-01-01 00:00:00","2024-01-02 00:00:00")`,
+			`["2024-01-01 00:00:00","2024-01-02 00:00:00")`},
+	}
+	for _, td := range testData {
+		t.Run(td.typ.String()+" "+td.str, func(t *testing.T) {
+			r, _, err := tree.ParseDRangeFromString(evalCtx, td.str, td.typ)
+			require.NoError(t, err)
+			require.Equal(t, td.expected, tree.AsStringWithFlags(r, tree.FmtBareStrings))
+		})
+	}
+}
+
+func TestParseDRangeError(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	testData := []struct {
+		typ *types.T
+		str string
+		err string
+	}{
+		{types.Int8Range, ``, `Missing left parenthesis or bracket`},
+		{types.Int8Range, `1,5)`, `Missing left parenthesis or bracket`},
+		{types.Int8Range, `[1 5)`, `could not parse "1 5" as type int`},
+		{types.Int8Range, `[1,5`, `Missing right parenthesis or bracket`},
+		{types.Int8Range, `[1,5,7)`, `Too many commas`},
+		{types.Int8Range, `[1,5) x`, `Junk after right parenthesis or bracket`},
+		{types.Int8Range, `[5,1)`, `range lower bound must be less than or equal to range upper bound`},
+		{types.Int8Range, `[a,5)`, `could not parse "a" as type int`},
+		{types.Int4Range, `[1,2147483647]`, `integer out of range`},
+		{types.Int8Multirange, `[1,5)`, `Missing left brace`},
+		{types.Int8Multirange, `{[1,5) [7,8)}`, `Expected comma or end of multirange`},
+		{types.Int8Multirange, `{[1,5)} x`, `Junk after closing right brace`},
+	}
+	for _, td := range testData {
+		t.Run(td.typ.String()+" "+td.str, func(t *testing.T) {
+			var err error
+			if td.typ.Family() == types.MultirangeFamily {
+				_, _, err = tree.ParseDMultirangeFromString(evalCtx, td.str, td.typ)
+			} else {
+				_, _, err = tree.ParseDRangeFromString(evalCtx, td.str, td.typ)
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error()+" "+errors.FlattenDetails(err), td.err)
+		})
+	}
+}
+
+func TestParseDMultirange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	testData := []struct {
+		typ      *types.T
+		str      string
+		expected string
+	}{
+		{types.Int8Multirange, `{}`, `{}`},
+		{types.Int8Multirange, `{empty}`, `{}`},
+		{types.Int8Multirange, `{[7,8), [1,3)}`, `{[1,3),[7,8)}`},
+		// Overlapping and adjacent ranges are merged.
+		{types.Int8Multirange, `{[1,3), [2,5), [7,8)}`, `{[1,5),[7,8)}`},
+		{types.Int8Multirange, `{[1,2], [3,4]}`, `{[1,5)}`},
+		{types.Int8Multirange, `{(,1), [0,)}`, `{(,)}`},
+		{types.NumMultirange, `{[1,2), [2,3)}`, `{[1,3)}`},
+		{types.NumMultirange, `{[1,2), (2,3)}`, `{[1,2),(2,3)}`},
+	}
+	for _, td := range testData {
+		t.Run(td.typ.String()+" "+td.str, func(t *testing.T) {
+			m, _, err := tree.ParseDMultirangeFromString(evalCtx, td.str, td.typ)
+			require.NoError(t, err)
+			require.Equal(t, td.expected, tree.AsStringWithFlags(m, tree.FmtBareStrings))
+		})
+	}
+}
+
+func TestRangeOperators(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	parse := func(s string) tree.Datum {
+		var d tree.Datum
+		var err error
+		if s[0] == '{' {
+			d, _, err = tree.ParseDMultirangeFromString(evalCtx, s, types.Int8Multirange)
+		} else {
+			d, _, err = tree.ParseDRangeFromString(evalCtx, s, types.Int8Range)
+		}
+		require.NoError(t, err)
+		return d
+	}
+
+	testData := []struct {
+		left, right                  string
+		contains, overlaps, adjacent bool
+		union, intersect, difference string
+	}{
+		{`[1,5)`, `[2,3)`, true, true, false, `[1,5)`, `[2,3)`, `error`},
+		{`[1,5)`, `[3,8)`, false, true, false, `[1,8)`, `[3,5)`, `[1,3)`},
+		{`[1,5)`, `[5,8)`, false, false, true, `[1,8)`, `empty`, `[1,5)`},
+		{`[1,5)`, `[6,8)`, false, false, false, `error`, `empty`, `[1,5)`},
+		{`[1,5)`, `empty`, true, false, false, `[1,5)`, `empty`, `[1,5)`},
+		{`empty`, `[1,5)`, false, false, false, `[1,5)`, `empty`, `empty`},
+		{`(,)`, `[1,5)`, true, true, false, `(,)`, `[1,5)`, `error`},
+		{`[1,5)`, `(,3)`, false, true, false, `(,5)`, `[1,3)`, `[3,5)`},
+		{`{[1,3),[5,8)}`, `{[2,3),[6,7)}`, true, true, false, `{[1,3),[5,8)}`, `{[2,3),[6,7)}`,
+			`{[1,2),[5,6),[7,8)}`},
+		{`{[1,3),[5,8)}`, `{[3,5)}`, false, false, false, `{[1,8)}`, `{}`, `{[1,3),[5,8)}`},
+		{`{[1,3),[5,8)}`, `{[2,6)}`, false, true, false, `{[1,8)}`, `{[2,3),[5,6)}`, `{[1,2),[6,8)}`},
+	}
+	format := func(d tree.Datum, err error) string {
+		if err != nil {
+			return `error`
+		}
+		return tree.AsStringWithFlags(d, tree.FmtBareStrings)
+	}
+	for _, td := range testData {
+		t.Run(td.left+" "+td.right, func(t *testing.T) {
+			left, right := parse(td.left), parse(td.right)
+			contains, err := tree.RangeContains(ctx, evalCtx, left, right)
+			require.NoError(t, err)
+			require.Equal(t, td.contains, contains, "contains")
+			overlaps, err := tree.RangeOverlaps(ctx, evalCtx, left, right)
+			require.NoError(t, err)
+			require.Equal(t, td.overlaps, overlaps, "overlaps")
+			adjacent, err := tree.RangeAdjacent(ctx, evalCtx, left, right)
+			require.NoError(t, err)
+			require.Equal(t, td.adjacent, adjacent, "adjacent")
+
+			var union, intersect, difference string
+			switch l := left.(type) {
+			case *tree.DRange:
+				r := right.(*tree.DRange)
+				union = format(l.Union(ctx, evalCtx, r))
+				intersect = format(l.Intersect(ctx, evalCtx, r))
+				difference = format(l.Difference(ctx, evalCtx, r))
+			case *tree.DMultirange:
+				r := right.(*tree.DMultirange)
+				union = format(l.Union(ctx, evalCtx, r))
+				intersect = format(l.Intersect(ctx, evalCtx, r))
+				difference = format(l.Difference(ctx, evalCtx, r))
+			}
+			require.Equal(t, td.union, union, "union")
+			require.Equal(t, td.intersect, intersect, "intersect")
+			require.Equal(t, td.difference, difference, "difference")
+		})
+	}
+
+	// Ranges also contain the values of their subtype.
+	for _, tc := range []struct {
+		elem     int
+		expected bool
+	}{{0, false}, {1, true}, {4, true}, {5, false}} {
+		contains, err := tree.RangeContains(ctx, evalCtx, parse(`[1,5)`), tree.NewDInt(tree.DInt(tc.elem)))
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, contains, "[1,5) @> %d", tc.elem)
+	}
+}
+
+func TestCompareDRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	// The ranges are sorted by their lower bounds, then by their upper
+	// bounds, and the empty range sorts first.
+	ordered := []string{`empty`, `(,1)`, `(,)`, `[0,1)`, `[0,2)`, `[0,)`, `[1,2)`}
+	for i := range ordered {
+		for j := range ordered {
+			a, _, err := tree.ParseDRangeFromString(evalCtx, ordered[i], types.Int8Range)
+			require.NoError(t, err)
+			b, _, err := tree.ParseDRangeFromString(evalCtx, ordered[j], types.Int8Range)
+			require.NoError(t, err)
+			c, err := a.Compare(ctx, evalCtx, b)
+			require.NoError(t, err)
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			require.Equal(t, expected, c, "%s vs %s", ordered[i], ordered[j])
+		}
+	}
+}
+
+// TestRandDRangeRoundTrip checks that random ranges and multiranges are
+// parsed back from their text representation.
+func TestRandDRangeRoundTrip(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	rng, _ := randutil.NewTestRand()
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	typs := append(append([]*types.T{}, types.RangeTypes...), types.MultirangeTypes...)
+	for i := 0; i < 1000; i++ {
+		typ := typs[rng.Intn(len(typs))]
+		d := randgen.RandDatum(rng, typ, false /* nullOk */)
+		s := tree.AsStringWithFlags(d, tree.FmtBareStrings)
+		var parsed tree.Datum
+		var err error
+		if typ.Family() == types.MultirangeFamily {
+			parsed, _, err = tree.ParseDMultirangeFromString(evalCtx, s, typ)
+		} else {
+			parsed, _, err = tree.ParseDRangeFromString(evalCtx, s, typ)
+		}
+		require.NoError(t, err, "%s: %s", typ, s)
+		c, err := d.Compare(ctx, evalCtx, parsed)
+		require.NoError(t, err)
+		require.Equal(t, 0, c, "%s: %s parsed as %s", typ, s, tree.AsStringWithFlags(parsed, tree.FmtBareStrings))
+	}
+}
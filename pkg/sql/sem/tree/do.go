@@ -0,0 +1,47 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+// DoBlock represents a DO statement, which executes an anonymous code block
+// as a one-off procedure.
+type DoBlock struct {
+	// Code is the body of the anonymous code block.
+	Code string
+	// Language is the language of the code block. It is empty if no language
+	// was specified, in which case the code block is PL/pgSQL.
+	Language RoutineLanguage
+}
+
+var _ Statement = &DoBlock{}
+
+// Format implements the NodeFormatter interface.
+func (node *DoBlock) Format(ctx *FmtCtx) {
+	ctx.WriteString("DO ")
+	if node.Language != "" {
+		ctx.FormatNode(node.Language)
+		ctx.WriteByte(' ')
+	}
+	if ctx.flags.HasFlags(FmtTagDollarQuotes) {
+		ctx.WriteString("$funcbody$")
+	} else {
+		ctx.WriteString("$$")
+	}
+	if ctx.flags.HasFlags(FmtAnonymize) || ctx.flags.HasFlags(FmtHideConstants) {
+		ctx.WriteString("_")
+	} else {
+		ctx.WriteString(node.Code)
+	}
+	if ctx.flags.HasFlags(FmtTagDollarQuotes) {
+		ctx.WriteString("$funcbody$")
+	} else {
+		ctx.WriteString("$$")
+	}
+}
+
+// String implements the Statement interface.
+func (node *DoBlock) String() string {
+	return AsString(node)
+}
@@ -191,6 +191,33 @@ func (node *DropType) Format(ctx *FmtCtx) {
 	}
 }
 
+// DropDomain represents a DROP DOMAIN command.
+type DropDomain struct {
+	Names        []*UnresolvedObjectName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropDomain{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropDomain) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP DOMAIN ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	for i := range node.Names {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(node.Names[i])
+	}
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
 // DropSchema represents a DROP SCHEMA command.
 type DropSchema struct {
 	Names        ObjectNamePrefixList
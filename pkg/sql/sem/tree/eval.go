@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/geometric"
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/errors"
@@ -39,6 +40,8 @@ var (
 	ErrDecOutOfRange = pgerror.New(pgcode.NumericValueOutOfRange, "decimal out of range")
 	// ErrCharOutOfRange is reported when int cast to ASCII byte overflows.
 	ErrCharOutOfRange = pgerror.New(pgcode.NumericValueOutOfRange, "\"char\" out of range")
+	// ErrMoneyOutOfRange is reported when money arithmetic overflows.
+	ErrMoneyOutOfRange = pgerror.New(pgcode.NumericValueOutOfRange, "money out of range")
 
 	// ErrDivByZero is reported on a division by zero.
 	ErrDivByZero = pgerror.New(pgcode.DivisionByZero, "division by zero")
@@ -178,6 +181,12 @@ var UnaryOps = unaryOpFixups(map[UnaryOperatorSymbol]*UnaryOpOverloads{
 			EvalOp:     &UnaryMinusIntervalOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			Typ:        types.Money,
+			ReturnType: types.Money,
+			EvalOp:     &UnaryMinusMoneyOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	UnaryComplement: {overloads: []*UnaryOp{
@@ -199,6 +208,18 @@ var UnaryOps = unaryOpFixups(map[UnaryOperatorSymbol]*UnaryOpOverloads{
 			EvalOp:     &ComplementINetOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			Typ:        types.MACAddr,
+			ReturnType: types.MACAddr,
+			EvalOp:     &ComplementMACAddrOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			Typ:        types.MACAddr8,
+			ReturnType: types.MACAddr8,
+			EvalOp:     &ComplementMACAddr8Op{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	UnarySqrt: {overloads: []*UnaryOp{
@@ -472,10 +493,41 @@ func initNonArrayToNonArrayConcatenation() {
 	}
 }
 
+// initRangeOperators initializes the union (+), intersection (*) and
+// difference (-) operators of the range and multirange types.
+func initRangeOperators() {
+	for _, typs := range [][]*types.T{types.RangeTypes, types.MultirangeTypes} {
+		for _, t := range typs {
+			addBinOp(treebin.Plus, &BinOp{
+				LeftType:   t,
+				RightType:  t,
+				ReturnType: t,
+				EvalOp:     &PlusRangeOp{},
+				Volatility: volatility.Immutable,
+			})
+			addBinOp(treebin.Mult, &BinOp{
+				LeftType:   t,
+				RightType:  t,
+				ReturnType: t,
+				EvalOp:     &MultRangeOp{},
+				Volatility: volatility.Immutable,
+			})
+			addBinOp(treebin.Minus, &BinOp{
+				LeftType:   t,
+				RightType:  t,
+				ReturnType: t,
+				EvalOp:     &MinusRangeOp{},
+				Volatility: volatility.Immutable,
+			})
+		}
+	}
+}
+
 func init() {
 	initArrayElementConcatenation()
 	initArrayToArrayConcatenation()
 	initNonArrayToNonArrayConcatenation()
+	initRangeOperators()
 }
 
 func init() {
@@ -570,6 +622,20 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &BitAndINetOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.MACAddr,
+			RightType:  types.MACAddr,
+			ReturnType: types.MACAddr,
+			EvalOp:     &BitAndMACAddrOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.MACAddr8,
+			RightType:  types.MACAddr8,
+			ReturnType: types.MACAddr8,
+			EvalOp:     &BitAndMACAddr8Op{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	treebin.Bitor: {overloads: []*BinOp{
@@ -594,6 +660,20 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &BitOrINetOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.MACAddr,
+			RightType:  types.MACAddr,
+			ReturnType: types.MACAddr,
+			EvalOp:     &BitOrMACAddrOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.MACAddr8,
+			RightType:  types.MACAddr8,
+			ReturnType: types.MACAddr8,
+			EvalOp:     &BitOrMACAddr8Op{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	treebin.Bitxor: {overloads: []*BinOp{
@@ -613,7 +693,7 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 		},
 	}},
 
-	treebin.Plus: {overloads: []*BinOp{
+	treebin.Plus: {overloads: append([]*BinOp{
 		{
 			LeftType:   types.Int,
 			RightType:  types.Int,
@@ -803,9 +883,25 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &PlusPGVectorOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Money,
+			ReturnType: types.Money,
+			EvalOp:     &PlusMoneyOp{},
+			Volatility: volatility.Immutable,
+		},
+	}, append(
+		makeGeometricPointOperators(treebin.Plus),
+		makeGeometricBinOp(types.Path, types.Path, types.Path, func(left, right Datum) (Datum, error) {
+			res, ok := MustBeDPath(left).Path.Concat(MustBeDPath(right).Path)
+			if !ok {
+				return DNull, nil
+			}
+			return NewDPath(res), nil
+		}),
+	)...)},
 
-	treebin.Minus: {overloads: []*BinOp{
+	treebin.Minus: {overloads: append([]*BinOp{
 		{
 			LeftType:   types.Int,
 			RightType:  types.Int,
@@ -996,9 +1092,16 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &MinusPGVectorOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Money,
+			ReturnType: types.Money,
+			EvalOp:     &MinusMoneyOp{},
+			Volatility: volatility.Immutable,
+		},
+	}, makeGeometricPointOperators(treebin.Minus)...)},
 
-	treebin.Mult: {overloads: []*BinOp{
+	treebin.Mult: {overloads: append([]*BinOp{
 		{
 			LeftType:   types.Int,
 			RightType:  types.Int,
@@ -1086,9 +1189,37 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &MultPGVectorOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Int,
+			ReturnType: types.Money,
+			EvalOp:     &MultMoneyIntOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.Int,
+			RightType:  types.Money,
+			ReturnType: types.Money,
+			EvalOp:     &MultIntMoneyOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Float,
+			ReturnType: types.Money,
+			EvalOp:     &MultMoneyFloatOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.Float,
+			RightType:  types.Money,
+			ReturnType: types.Money,
+			EvalOp:     &MultFloatMoneyOp{},
+			Volatility: volatility.Immutable,
+		},
+	}, makeGeometricPointOperators(treebin.Mult)...)},
 
-	treebin.Div: {overloads: []*BinOp{
+	treebin.Div: {overloads: append([]*BinOp{
 		{
 			LeftType:   types.Int,
 			RightType:  types.Int,
@@ -1138,7 +1269,28 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &DivIntervalFloatOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Int,
+			ReturnType: types.Money,
+			EvalOp:     &DivMoneyIntOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Float,
+			ReturnType: types.Money,
+			EvalOp:     &DivMoneyFloatOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Money,
+			ReturnType: types.Float,
+			EvalOp:     &DivMoneyOp{},
+			Volatility: volatility.Immutable,
+		},
+	}, makeGeometricPointOperators(treebin.Div)...)},
 
 	treebin.FloorDiv: {overloads: []*BinOp{
 		{
@@ -1398,7 +1550,7 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			Volatility: volatility.Immutable,
 		},
 	}},
-	treebin.Distance: {overloads: []*BinOp{
+	treebin.Distance: {overloads: append([]*BinOp{
 		{
 			LeftType:   types.PGVector,
 			RightType:  types.PGVector,
@@ -1406,7 +1558,7 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &DistanceVectorOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeGeometricDistanceOperators()...)},
 	treebin.CosDistance: {overloads: []*BinOp{
 		{
 			LeftType:   types.PGVector,
@@ -1526,6 +1678,43 @@ func cmpOpFixups(
 		})
 	}
 
+	// Range and multirange comparisons. The containment and overlap operators
+	// accept any combination of a range and the multirange of the same type,
+	// and containment also accepts a value of the subtype.
+	for i, r := range types.RangeTypes {
+		appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
+			s, ok := cmpOps[sym]
+			if !ok {
+				s = new(CmpOpOverloads)
+				cmpOps[sym] = s
+			}
+			s.overloads = append(s.overloads, cmpOp)
+		}
+		makeRangeOp := func(left, right *types.T, op BinaryEvalOp) *CmpOp {
+			return &CmpOp{
+				LeftType:   left,
+				RightType:  right,
+				EvalOp:     op,
+				Volatility: volatility.Immutable,
+			}
+		}
+		m, elem := types.MultirangeTypes[i], r.RangeContents()
+		for _, t := range []*types.T{r, m} {
+			appendCmpOp(treecmp.EQ, makeEqFn(t, t, volatility.Immutable))
+			appendCmpOp(treecmp.LT, makeLtFn(t, t, volatility.Immutable))
+			appendCmpOp(treecmp.LE, makeLeFn(t, t, volatility.Immutable))
+			appendCmpOp(treecmp.IsNotDistinctFrom, makeIsFn(t, t, volatility.Immutable))
+			appendCmpOp(treecmp.In, makeEvalTupleIn(t, volatility.Immutable))
+			appendCmpOp(treecmp.Contains, makeRangeOp(t, elem, &ContainsRangeOp{}))
+			appendCmpOp(treecmp.ContainedBy, makeRangeOp(elem, t, &ContainedByRangeOp{}))
+			for _, other := range []*types.T{r, m} {
+				appendCmpOp(treecmp.Contains, makeRangeOp(t, other, &ContainsRangeOp{}))
+				appendCmpOp(treecmp.ContainedBy, makeRangeOp(t, other, &ContainedByRangeOp{}))
+				appendCmpOp(treecmp.Overlaps, makeRangeOp(t, other, &OverlapsRangeOp{}))
+			}
+		}
+	}
+
 	for _, overloads := range cmpOps {
 		_ = overloads.ForEachCmpOp(func(op *CmpOp) error {
 			op.types = ParamTypes{{"left", op.LeftType}, {"right", op.RightType}}
@@ -1616,6 +1805,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEqFn(types.Int, types.Int, volatility.Leakproof),
 		makeEqFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeEqFn(types.Jsonb, types.Jsonb, volatility.Immutable),
+		makeEqFn(types.MACAddr, types.MACAddr, volatility.Leakproof),
+		makeEqFn(types.MACAddr8, types.MACAddr8, volatility.Leakproof),
+		makeEqFn(types.Money, types.Money, volatility.Leakproof),
 		makeEqFn(types.Oid, types.Oid, volatility.Leakproof),
 		makeEqFn(types.PGLSN, types.PGLSN, volatility.Leakproof),
 		makeEqFn(types.PGVector, types.PGVector, volatility.Leakproof),
@@ -1630,6 +1822,15 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEqFn(types.Uuid, types.Uuid, volatility.Leakproof),
 		makeEqFn(types.VarBit, types.VarBit, volatility.Leakproof),
 
+		// Geometric types.
+		makeEqFn(types.Box, types.Box, volatility.Leakproof),
+		makeEqFn(types.Circle, types.Circle, volatility.Leakproof),
+		makeEqFn(types.Line, types.Line, volatility.Leakproof),
+		makeEqFn(types.LSeg, types.LSeg, volatility.Leakproof),
+		makeEqFn(types.Path, types.Path, volatility.Leakproof),
+		makeEqFn(types.Point, types.Point, volatility.Leakproof),
+		makeEqFn(types.Polygon, types.Polygon, volatility.Leakproof),
+
 		// Mixed-type comparisons.
 		makeEqFn(types.Date, types.Timestamp, volatility.Immutable),
 		makeEqFn(types.Date, types.TimestampTZ, volatility.Stable),
@@ -1677,6 +1878,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLtFn(types.INet, types.INet, volatility.Leakproof),
 		makeLtFn(types.Int, types.Int, volatility.Leakproof),
 		makeLtFn(types.Interval, types.Interval, volatility.Leakproof),
+		makeLtFn(types.MACAddr, types.MACAddr, volatility.Leakproof),
+		makeLtFn(types.MACAddr8, types.MACAddr8, volatility.Leakproof),
+		makeLtFn(types.Money, types.Money, volatility.Leakproof),
 		makeLtFn(types.Oid, types.Oid, volatility.Leakproof),
 		makeLtFn(types.PGLSN, types.PGLSN, volatility.Leakproof),
 		makeLtFn(types.PGVector, types.PGVector, volatility.Leakproof),
@@ -1737,6 +1941,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLeFn(types.INet, types.INet, volatility.Leakproof),
 		makeLeFn(types.Int, types.Int, volatility.Leakproof),
 		makeLeFn(types.Interval, types.Interval, volatility.Leakproof),
+		makeLeFn(types.MACAddr, types.MACAddr, volatility.Leakproof),
+		makeLeFn(types.MACAddr8, types.MACAddr8, volatility.Leakproof),
+		makeLeFn(types.Money, types.Money, volatility.Leakproof),
 		makeLeFn(types.Oid, types.Oid, volatility.Leakproof),
 		makeLeFn(types.PGLSN, types.PGLSN, volatility.Leakproof),
 		makeLeFn(types.PGVector, types.PGVector, volatility.Leakproof),
@@ -1818,6 +2025,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeIsFn(types.Int, types.Int, volatility.Leakproof),
 		makeIsFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeIsFn(types.Jsonb, types.Jsonb, volatility.Immutable),
+		makeIsFn(types.MACAddr, types.MACAddr, volatility.Leakproof),
+		makeIsFn(types.MACAddr8, types.MACAddr8, volatility.Leakproof),
+		makeIsFn(types.Money, types.Money, volatility.Leakproof),
 		makeIsFn(types.Oid, types.Oid, volatility.Leakproof),
 		makeIsFn(types.PGLSN, types.PGLSN, volatility.Leakproof),
 		makeIsFn(types.PGVector, types.PGVector, volatility.Leakproof),
@@ -1832,6 +2042,15 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeIsFn(types.Uuid, types.Uuid, volatility.Leakproof),
 		makeIsFn(types.VarBit, types.VarBit, volatility.Leakproof),
 
+		// Geometric types.
+		makeIsFn(types.Box, types.Box, volatility.Leakproof),
+		makeIsFn(types.Circle, types.Circle, volatility.Leakproof),
+		makeIsFn(types.Line, types.Line, volatility.Leakproof),
+		makeIsFn(types.LSeg, types.LSeg, volatility.Leakproof),
+		makeIsFn(types.Path, types.Path, volatility.Leakproof),
+		makeIsFn(types.Point, types.Point, volatility.Leakproof),
+		makeIsFn(types.Polygon, types.Polygon, volatility.Leakproof),
+
 		// Mixed-type comparisons.
 		makeIsFn(types.Date, types.Timestamp, volatility.Immutable),
 		makeIsFn(types.Date, types.TimestampTZ, volatility.Stable),
@@ -1887,6 +2106,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEvalTupleIn(types.Int, volatility.Leakproof),
 		makeEvalTupleIn(types.Interval, volatility.Leakproof),
 		makeEvalTupleIn(types.Jsonb, volatility.Leakproof),
+		makeEvalTupleIn(types.MACAddr, volatility.Leakproof),
+		makeEvalTupleIn(types.MACAddr8, volatility.Leakproof),
+		makeEvalTupleIn(types.Money, volatility.Leakproof),
 		makeEvalTupleIn(types.Oid, volatility.Leakproof),
 		makeEvalTupleIn(types.PGLSN, volatility.Leakproof),
 		makeEvalTupleIn(types.PGVector, volatility.Leakproof),
@@ -1898,6 +2120,15 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEvalTupleIn(types.TimestampTZ, volatility.Leakproof),
 		makeEvalTupleIn(types.Uuid, volatility.Leakproof),
 		makeEvalTupleIn(types.VarBit, volatility.Leakproof),
+
+		// Geometric types.
+		makeEvalTupleIn(types.Box, volatility.Leakproof),
+		makeEvalTupleIn(types.Circle, volatility.Leakproof),
+		makeEvalTupleIn(types.Line, volatility.Leakproof),
+		makeEvalTupleIn(types.LSeg, volatility.Leakproof),
+		makeEvalTupleIn(types.Path, volatility.Leakproof),
+		makeEvalTupleIn(types.Point, volatility.Leakproof),
+		makeEvalTupleIn(types.Polygon, volatility.Leakproof),
 	}},
 
 	treecmp.Like: {overloads: []*CmpOp{
@@ -1978,7 +2209,7 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		},
 	}},
 
-	treecmp.Contains: {overloads: []*CmpOp{
+	treecmp.Contains: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -1991,9 +2222,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainsJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeGeometricContainsOperators(true /* contains */)...)},
 
-	treecmp.ContainedBy: {overloads: []*CmpOp{
+	treecmp.ContainedBy: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -2006,7 +2237,7 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainedByJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeGeometricContainsOperators(false /* contains */)...)},
 	treecmp.Overlaps: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
@@ -2020,10 +2251,13 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &OverlapsINetOp{},
 			Volatility: volatility.Immutable,
 		},
-	}, makeBox2DComparisonOperators(
-		func(lhs, rhs *geo.CartesianBoundingBox) bool {
-			return lhs.Intersects(rhs)
-		},
+	}, append(
+		makeBox2DComparisonOperators(
+			func(lhs, rhs *geo.CartesianBoundingBox) bool {
+				return lhs.Intersects(rhs)
+			},
+		),
+		makeGeometricOverlapsOperators()...,
 	)...),
 	},
 	treecmp.TSMatches: {overloads: []*CmpOp{
@@ -2039,6 +2273,12 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &TSMatchesVectorQueryOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathMatchOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 })
 
@@ -2091,6 +2331,238 @@ func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bo
 	}
 }
 
+// makeGeometricCmpOp returns a comparison operator between two geometric types
+// which evaluates the given predicate.
+func makeGeometricCmpOp(a, b *types.T, op func(left, right Datum) bool) *CmpOp {
+	return &CmpOp{
+		LeftType:   a,
+		RightType:  b,
+		EvalOp:     &CompareGeometricOp{Op: op},
+		Volatility: volatility.Immutable,
+	}
+}
+
+// makeGeometricContainsOperators returns the overloads of the @> operator for
+// the geometric types if contains is true, and the overloads of the <@
+// operator otherwise.
+func makeGeometricContainsOperators(contains bool) []*CmpOp {
+	makeOp := func(container, contained *types.T, op func(container, contained Datum) bool) *CmpOp {
+		if contains {
+			return makeGeometricCmpOp(container, contained, op)
+		}
+		return makeGeometricCmpOp(contained, container, func(left, right Datum) bool {
+			return op(right, left)
+		})
+	}
+	return []*CmpOp{
+		makeOp(types.Box, types.Point, func(left, right Datum) bool {
+			return MustBeDBox(left).Box.ContainsPoint(MustBeDPoint(right).Point)
+		}),
+		makeOp(types.Box, types.Box, func(left, right Datum) bool {
+			return MustBeDBox(left).Box.ContainsBox(MustBeDBox(right).Box)
+		}),
+		makeOp(types.Box, types.LSeg, func(left, right Datum) bool {
+			b, l := MustBeDBox(left).Box, MustBeDLSeg(right).LSeg
+			return b.ContainsPoint(l.P[0]) && b.ContainsPoint(l.P[1])
+		}),
+		makeOp(types.LSeg, types.Point, func(left, right Datum) bool {
+			return MustBeDLSeg(left).LSeg.ContainsPoint(MustBeDPoint(right).Point)
+		}),
+		makeOp(types.Line, types.Point, func(left, right Datum) bool {
+			return MustBeDLine(left).Line.ContainsPoint(MustBeDPoint(right).Point)
+		}),
+		makeOp(types.Line, types.LSeg, func(left, right Datum) bool {
+			return MustBeDLine(left).Line.ContainsLSeg(MustBeDLSeg(right).LSeg)
+		}),
+		makeOp(types.Path, types.Point, func(left, right Datum) bool {
+			return MustBeDPath(left).Path.ContainsPoint(MustBeDPoint(right).Point)
+		}),
+		makeOp(types.Polygon, types.Point, func(left, right Datum) bool {
+			return MustBeDPolygon(left).Polygon.ContainsPoint(MustBeDPoint(right).Point)
+		}),
+		makeOp(types.Polygon, types.Polygon, func(left, right Datum) bool {
+			return MustBeDPolygon(left).Polygon.ContainsPolygon(MustBeDPolygon(right).Polygon)
+		}),
+		makeOp(types.Circle, types.Point, func(left, right Datum) bool {
+			return MustBeDCircle(left).Circle.ContainsPoint(MustBeDPoint(right).Point)
+		}),
+		makeOp(types.Circle, types.Circle, func(left, right Datum) bool {
+			return MustBeDCircle(left).Circle.ContainsCircle(MustBeDCircle(right).Circle)
+		}),
+	}
+}
+
+// makeGeometricOverlapsOperators returns the overloads of the && operator for
+// the geometric types.
+func makeGeometricOverlapsOperators() []*CmpOp {
+	return []*CmpOp{
+		makeGeometricCmpOp(types.Box, types.Box, func(left, right Datum) bool {
+			return MustBeDBox(left).Box.Overlaps(MustBeDBox(right).Box)
+		}),
+		makeGeometricCmpOp(types.Polygon, types.Polygon, func(left, right Datum) bool {
+			return MustBeDPolygon(left).Polygon.Overlaps(MustBeDPolygon(right).Polygon)
+		}),
+		makeGeometricCmpOp(types.Circle, types.Circle, func(left, right Datum) bool {
+			return MustBeDCircle(left).Circle.Overlaps(MustBeDCircle(right).Circle)
+		}),
+	}
+}
+
+// makeGeometricBinOp returns a binary operator between two geometric types
+// which evaluates the given function.
+func makeGeometricBinOp(
+	a, b, ret *types.T, fn func(left, right Datum) (Datum, error),
+) *BinOp {
+	return &BinOp{
+		LeftType:   a,
+		RightType:  b,
+		ReturnType: ret,
+		EvalOp:     &GeometricBinaryOp{Fn: fn},
+		Volatility: volatility.Immutable,
+	}
+}
+
+// makeGeometricDistanceOperators returns the overloads of the <-> operator for
+// the geometric types. The distance between two different types is symmetric,
+// so both orders of the operands are supported. The distance is NULL if it
+// cannot be computed, such as with an empty path.
+func makeGeometricDistanceOperators() []*BinOp {
+	var ops []*BinOp
+	add := func(a, b *types.T, fn func(left, right Datum) (float64, bool)) {
+		ops = append(ops, makeGeometricBinOp(a, b, types.Float, func(left, right Datum) (Datum, error) {
+			if d, ok := fn(left, right); ok {
+				return NewDFloat(DFloat(d)), nil
+			}
+			return DNull, nil
+		}))
+		if !a.Identical(b) {
+			ops = append(ops, makeGeometricBinOp(b, a, types.Float, func(left, right Datum) (Datum, error) {
+				if d, ok := fn(right, left); ok {
+					return NewDFloat(DFloat(d)), nil
+				}
+				return DNull, nil
+			}))
+		}
+	}
+	add(types.Point, types.Point, func(left, right Datum) (float64, bool) {
+		return MustBeDPoint(left).Point.Distance(MustBeDPoint(right).Point), true
+	})
+	add(types.Point, types.LSeg, func(left, right Datum) (float64, bool) {
+		return MustBeDLSeg(right).LSeg.DistanceToPoint(MustBeDPoint(left).Point), true
+	})
+	add(types.Point, types.Box, func(left, right Datum) (float64, bool) {
+		return MustBeDBox(right).Box.DistanceToPoint(MustBeDPoint(left).Point), true
+	})
+	add(types.Point, types.Line, func(left, right Datum) (float64, bool) {
+		return MustBeDLine(right).Line.DistanceToPoint(MustBeDPoint(left).Point), true
+	})
+	add(types.Point, types.Path, func(left, right Datum) (float64, bool) {
+		return MustBeDPath(right).Path.DistanceToPoint(MustBeDPoint(left).Point)
+	})
+	add(types.Point, types.Polygon, func(left, right Datum) (float64, bool) {
+		return MustBeDPolygon(right).Polygon.DistanceToPoint(MustBeDPoint(left).Point), true
+	})
+	add(types.Point, types.Circle, func(left, right Datum) (float64, bool) {
+		return MustBeDCircle(right).Circle.DistanceToPoint(MustBeDPoint(left).Point), true
+	})
+	add(types.LSeg, types.LSeg, func(left, right Datum) (float64, bool) {
+		return MustBeDLSeg(left).LSeg.Distance(MustBeDLSeg(right).LSeg), true
+	})
+	add(types.LSeg, types.Line, func(left, right Datum) (float64, bool) {
+		return MustBeDLSeg(left).LSeg.DistanceToLine(MustBeDLine(right).Line), true
+	})
+	add(types.LSeg, types.Box, func(left, right Datum) (float64, bool) {
+		return MustBeDLSeg(left).LSeg.DistanceToBox(MustBeDBox(right).Box), true
+	})
+	add(types.Line, types.Line, func(left, right Datum) (float64, bool) {
+		return MustBeDLine(left).Line.Distance(MustBeDLine(right).Line), true
+	})
+	add(types.Line, types.Box, func(left, right Datum) (float64, bool) {
+		return MustBeDLine(left).Line.DistanceToBox(MustBeDBox(right).Box), true
+	})
+	add(types.Box, types.Box, func(left, right Datum) (float64, bool) {
+		return MustBeDBox(left).Box.Distance(MustBeDBox(right).Box), true
+	})
+	add(types.Path, types.Path, func(left, right Datum) (float64, bool) {
+		return MustBeDPath(left).Path.Distance(MustBeDPath(right).Path)
+	})
+	add(types.Polygon, types.Polygon, func(left, right Datum) (float64, bool) {
+		return MustBeDPolygon(left).Polygon.Distance(MustBeDPolygon(right).Polygon), true
+	})
+	add(types.Circle, types.Circle, func(left, right Datum) (float64, bool) {
+		return MustBeDCircle(left).Circle.Distance(MustBeDCircle(right).Circle), true
+	})
+	add(types.Circle, types.Polygon, func(left, right Datum) (float64, bool) {
+		return MustBeDCircle(left).Circle.DistanceToPolygon(MustBeDPolygon(right).Polygon), true
+	})
+	return ops
+}
+
+// makeGeometricPointOperators returns the overloads of the given arithmetic
+// operator between a point and a point, box, path or circle. The point on the
+// right translates the value on the left for addition and subtraction, and
+// scales and rotates it for multiplication and division, as if the points were
+// complex numbers.
+func makeGeometricPointOperators(op treebin.BinaryOperatorSymbol) []*BinOp {
+	return []*BinOp{
+		makeGeometricBinOp(types.Point, types.Point, types.Point, func(left, right Datum) (Datum, error) {
+			l, r := MustBeDPoint(left).Point, MustBeDPoint(right).Point
+			switch op {
+			case treebin.Plus:
+				return NewDPoint(l.Add(r)), nil
+			case treebin.Minus:
+				return NewDPoint(l.Sub(r)), nil
+			case treebin.Mult:
+				return NewDPoint(l.Mul(r)), nil
+			}
+			res, err := l.Div(r)
+			if err != nil {
+				return nil, err
+			}
+			return NewDPoint(res), nil
+		}),
+		makeGeometricBinOp(types.Box, types.Point, types.Box, func(left, right Datum) (Datum, error) {
+			res, err := evalGeometricPointOp(op, MustBeDBox(left).Box, MustBeDPoint(right).Point)
+			if err != nil {
+				return nil, err
+			}
+			return NewDBox(res), nil
+		}),
+		makeGeometricBinOp(types.Path, types.Point, types.Path, func(left, right Datum) (Datum, error) {
+			res, err := evalGeometricPointOp(op, MustBeDPath(left).Path, MustBeDPoint(right).Point)
+			if err != nil {
+				return nil, err
+			}
+			return NewDPath(res), nil
+		}),
+		makeGeometricBinOp(types.Circle, types.Point, types.Circle, func(left, right Datum) (Datum, error) {
+			res, err := evalGeometricPointOp(op, MustBeDCircle(left).Circle, MustBeDPoint(right).Point)
+			if err != nil {
+				return nil, err
+			}
+			return NewDCircle(res), nil
+		}),
+	}
+}
+
+// evalGeometricPointOp applies the given arithmetic operator to a geometric
+// value and a point.
+func evalGeometricPointOp[T interface {
+	Translate(geometric.Point) T
+	Mul(geometric.Point) T
+	Div(geometric.Point) (T, error)
+}](op treebin.BinaryOperatorSymbol, v T, p geometric.Point) (T, error) {
+	switch op {
+	case treebin.Plus:
+		return v.Translate(p), nil
+	case treebin.Minus:
+		return v.Translate(geometric.Point{X: -p.X, Y: -p.Y}), nil
+	case treebin.Mult:
+		return v.Mul(p), nil
+	}
+	return v.Div(p)
+}
+
 // This map contains the inverses for operators in the CmpOps map that have
 // inverses.
 var cmpOpsInverse map[treecmp.ComparisonOperatorSymbol]treecmp.ComparisonOperatorSymbol
@@ -2139,7 +2611,8 @@ func (expr *FuncExpr) MaybeWrapError(err error) error {
 	// If we are facing an explicit error, propagate it unchanged.
 	fName := expr.Func.String()
 	if fName == `crdb_internal.force_error` || fName == `crdb_internal.plpgsql_raise` ||
-		fName == `crdb_internal.plpgsql_close` || fName == `crdb_internal.plpgsql_fetch` {
+		fName == `crdb_internal.plpgsql_close` || fName == `crdb_internal.plpgsql_fetch` ||
+		fName == `crdb_internal.plpgsql_fetch_next` {
 		return err
 	}
 	// Otherwise, wrap it with context.
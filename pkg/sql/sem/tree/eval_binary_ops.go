@@ -27,6 +27,16 @@ type CompareBox2DOp struct {
 	Op func(left, right Datum) bool
 }
 
+// CompareGeometricOp is a BinaryEvalOp.
+type CompareGeometricOp struct {
+	Op func(left, right Datum) bool
+}
+
+// GeometricBinaryOp is a BinaryEvalOp.
+type GeometricBinaryOp struct {
+	Fn func(left, right Datum) (Datum, error)
+}
+
 // InTupleOp is a BinaryEvalOp.
 type InTupleOp struct{}
 
@@ -70,6 +80,9 @@ type OverlapsArrayOp struct{}
 // OverlapsINetOp is a BinaryEvalOp.
 type OverlapsINetOp struct{}
 
+// OverlapsRangeOp is a BinaryEvalOp.
+type OverlapsRangeOp struct{}
+
 // TSMatchesVectorQueryOp is a BinaryEvalOp.
 type TSMatchesVectorQueryOp struct{}
 
@@ -113,6 +126,10 @@ type (
 	BitAndVarBitOp struct{}
 	// BitAndINetOp is a BinaryEvalOp.
 	BitAndINetOp struct{}
+	// BitAndMACAddrOp is a BinaryEvalOp.
+	BitAndMACAddrOp struct{}
+	// BitAndMACAddr8Op is a BinaryEvalOp.
+	BitAndMACAddr8Op struct{}
 )
 
 type (
@@ -122,6 +139,10 @@ type (
 	BitOrVarBitOp struct{}
 	// BitOrINetOp is a BinaryEvalOp.
 	BitOrINetOp struct{}
+	// BitOrMACAddrOp is a BinaryEvalOp.
+	BitOrMACAddrOp struct{}
+	// BitOrMACAddr8Op is a BinaryEvalOp.
+	BitOrMACAddr8Op struct{}
 )
 
 type (
@@ -184,8 +205,12 @@ type (
 	PlusDecimalPGLSNOp struct{}
 	// PlusPGLSNDecimalOp is a BinaryEvalOp.
 	PlusPGLSNDecimalOp struct{}
+	// PlusMoneyOp is a BinaryEvalOp.
+	PlusMoneyOp struct{}
 	// PlusPGVectorOp is a BinaryEvalOp.
 	PlusPGVectorOp struct{}
+	// PlusRangeOp is a BinaryEvalOp.
+	PlusRangeOp struct{}
 )
 
 type (
@@ -241,8 +266,12 @@ type (
 	MinusPGLSNDecimalOp struct{}
 	// MinusPGLSNOp is a BinaryEvalOp.
 	MinusPGLSNOp struct{}
+	// MinusMoneyOp is a BinaryEvalOp.
+	MinusMoneyOp struct{}
 	// MinusPGVectorOp is a BinaryEvalOp.
 	MinusPGVectorOp struct{}
+	// MinusRangeOp is a BinaryEvalOp.
+	MinusRangeOp struct{}
 )
 type (
 	// MultDecimalIntOp is a BinaryEvalOp.
@@ -267,8 +296,18 @@ type (
 	MultIntervalFloatOp struct{}
 	// MultIntervalIntOp is a BinaryEvalOp.
 	MultIntervalIntOp struct{}
+	// MultMoneyIntOp is a BinaryEvalOp.
+	MultMoneyIntOp struct{}
+	// MultIntMoneyOp is a BinaryEvalOp.
+	MultIntMoneyOp struct{}
+	// MultMoneyFloatOp is a BinaryEvalOp.
+	MultMoneyFloatOp struct{}
+	// MultFloatMoneyOp is a BinaryEvalOp.
+	MultFloatMoneyOp struct{}
 	// MultPGVectorOp is a BinaryEvalOp.
 	MultPGVectorOp struct{}
+	// MultRangeOp is a BinaryEvalOp.
+	MultRangeOp struct{}
 )
 
 type (
@@ -286,6 +325,12 @@ type (
 	DivIntervalFloatOp struct{}
 	// DivIntervalIntOp is a BinaryEvalOp.
 	DivIntervalIntOp struct{}
+	// DivMoneyOp is a BinaryEvalOp.
+	DivMoneyOp struct{}
+	// DivMoneyIntOp is a BinaryEvalOp.
+	DivMoneyIntOp struct{}
+	// DivMoneyFloatOp is a BinaryEvalOp.
+	DivMoneyFloatOp struct{}
 )
 
 type (
@@ -380,6 +425,9 @@ type JSONSomeExistsOp struct{}
 // JSONAllExistsOp is a BinaryEvalOp.
 type JSONAllExistsOp struct{}
 
+// JSONPathMatchOp is a BinaryEvalOp.
+type JSONPathMatchOp struct{}
+
 // JSONFetchValPathOp is a BinaryEvalOp.
 type JSONFetchValPathOp struct{}
 
@@ -392,8 +440,14 @@ type ContainsArrayOp struct{}
 // ContainsJsonbOp is a BinaryEvalOp.
 type ContainsJsonbOp struct{}
 
+// ContainsRangeOp is a BinaryEvalOp.
+type ContainsRangeOp struct{}
+
 // ContainedByArrayOp is a BinaryEvalOp.
 type ContainedByArrayOp struct{}
 
 // ContainedByJsonbOp is a BinaryEvalOp.
 type ContainedByJsonbOp struct{}
+
+// ContainedByRangeOp is a BinaryEvalOp.
+type ContainedByRangeOp struct{}
@@ -32,6 +32,7 @@ type ExprEvaluator interface {
 	EvalComparisonExpr(context.Context, *ComparisonExpr) (Datum, error)
 	EvalDefaultVal(context.Context, *DefaultVal) (Datum, error)
 	EvalFuncExpr(context.Context, *FuncExpr) (Datum, error)
+	EvalGroupingOperation(context.Context, *GroupingOperation) (Datum, error)
 	EvalIfErrExpr(context.Context, *IfErrExpr) (Datum, error)
 	EvalIfExpr(context.Context, *IfExpr) (Datum, error)
 	EvalIndexedVar(context.Context, *IndexedVar) (Datum, error)
@@ -202,11 +203,36 @@ func (node *DJSON) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DJsonpath) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DOid) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DMultirange) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DMACAddr) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DMACAddr8) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DMoney) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DOidWrapper) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
@@ -222,6 +248,46 @@ func (node *DPGVector) Eval(ctx context.Context, v ExprEvaluator) (Datum, error)
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DPoint) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DBox) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DLSeg) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DLine) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DPath) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DPolygon) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DCircle) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DRange) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DString) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
@@ -282,6 +348,11 @@ func (node *FuncExpr) Eval(ctx context.Context, v ExprEvaluator) (Datum, error)
 	return v.EvalFuncExpr(ctx, node)
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *GroupingOperation) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return v.EvalGroupingOperation(ctx, node)
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *IfErrExpr) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return v.EvalIfErrExpr(ctx, node)
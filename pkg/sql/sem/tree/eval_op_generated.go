@@ -39,6 +39,8 @@ type UnaryOpEvaluator interface {
 	EvalCbrtFloatOp(context.Context, *CbrtFloatOp, Datum) (Datum, error)
 	EvalComplementINetOp(context.Context, *ComplementINetOp, Datum) (Datum, error)
 	EvalComplementIntOp(context.Context, *ComplementIntOp, Datum) (Datum, error)
+	EvalComplementMACAddr8Op(context.Context, *ComplementMACAddr8Op, Datum) (Datum, error)
+	EvalComplementMACAddrOp(context.Context, *ComplementMACAddrOp, Datum) (Datum, error)
 	EvalComplementVarBitOp(context.Context, *ComplementVarBitOp, Datum) (Datum, error)
 	EvalSqrtDecimalOp(context.Context, *SqrtDecimalOp, Datum) (Datum, error)
 	EvalSqrtFloatOp(context.Context, *SqrtFloatOp, Datum) (Datum, error)
@@ -46,6 +48,7 @@ type UnaryOpEvaluator interface {
 	EvalUnaryMinusFloatOp(context.Context, *UnaryMinusFloatOp, Datum) (Datum, error)
 	EvalUnaryMinusIntOp(context.Context, *UnaryMinusIntOp, Datum) (Datum, error)
 	EvalUnaryMinusIntervalOp(context.Context, *UnaryMinusIntervalOp, Datum) (Datum, error)
+	EvalUnaryMinusMoneyOp(context.Context, *UnaryMinusMoneyOp, Datum) (Datum, error)
 }
 
 // UnaryOpEvaluator knows how to evaluate BinaryEvalOps.
@@ -53,13 +56,18 @@ type BinaryOpEvaluator interface {
 	EvalAppendToMaybeNullArrayOp(context.Context, *AppendToMaybeNullArrayOp, Datum, Datum) (Datum, error)
 	EvalBitAndINetOp(context.Context, *BitAndINetOp, Datum, Datum) (Datum, error)
 	EvalBitAndIntOp(context.Context, *BitAndIntOp, Datum, Datum) (Datum, error)
+	EvalBitAndMACAddr8Op(context.Context, *BitAndMACAddr8Op, Datum, Datum) (Datum, error)
+	EvalBitAndMACAddrOp(context.Context, *BitAndMACAddrOp, Datum, Datum) (Datum, error)
 	EvalBitAndVarBitOp(context.Context, *BitAndVarBitOp, Datum, Datum) (Datum, error)
 	EvalBitOrINetOp(context.Context, *BitOrINetOp, Datum, Datum) (Datum, error)
 	EvalBitOrIntOp(context.Context, *BitOrIntOp, Datum, Datum) (Datum, error)
+	EvalBitOrMACAddr8Op(context.Context, *BitOrMACAddr8Op, Datum, Datum) (Datum, error)
+	EvalBitOrMACAddrOp(context.Context, *BitOrMACAddrOp, Datum, Datum) (Datum, error)
 	EvalBitOrVarBitOp(context.Context, *BitOrVarBitOp, Datum, Datum) (Datum, error)
 	EvalBitXorIntOp(context.Context, *BitXorIntOp, Datum, Datum) (Datum, error)
 	EvalBitXorVarBitOp(context.Context, *BitXorVarBitOp, Datum, Datum) (Datum, error)
 	EvalCompareBox2DOp(context.Context, *CompareBox2DOp, Datum, Datum) (Datum, error)
+	EvalCompareGeometricOp(context.Context, *CompareGeometricOp, Datum, Datum) (Datum, error)
 	EvalCompareScalarOp(context.Context, *CompareScalarOp, Datum, Datum) (Datum, error)
 	EvalCompareTupleOp(context.Context, *CompareTupleOp, Datum, Datum) (Datum, error)
 	EvalConcatArraysOp(context.Context, *ConcatArraysOp, Datum, Datum) (Datum, error)
@@ -70,8 +78,10 @@ type BinaryOpEvaluator interface {
 	EvalConcatVarBitOp(context.Context, *ConcatVarBitOp, Datum, Datum) (Datum, error)
 	EvalContainedByArrayOp(context.Context, *ContainedByArrayOp, Datum, Datum) (Datum, error)
 	EvalContainedByJsonbOp(context.Context, *ContainedByJsonbOp, Datum, Datum) (Datum, error)
+	EvalContainedByRangeOp(context.Context, *ContainedByRangeOp, Datum, Datum) (Datum, error)
 	EvalContainsArrayOp(context.Context, *ContainsArrayOp, Datum, Datum) (Datum, error)
 	EvalContainsJsonbOp(context.Context, *ContainsJsonbOp, Datum, Datum) (Datum, error)
+	EvalContainsRangeOp(context.Context, *ContainsRangeOp, Datum, Datum) (Datum, error)
 	EvalCosDistanceVectorOp(context.Context, *CosDistanceVectorOp, Datum, Datum) (Datum, error)
 	EvalDistanceVectorOp(context.Context, *DistanceVectorOp, Datum, Datum) (Datum, error)
 	EvalDivDecimalIntOp(context.Context, *DivDecimalIntOp, Datum, Datum) (Datum, error)
@@ -81,11 +91,15 @@ type BinaryOpEvaluator interface {
 	EvalDivIntOp(context.Context, *DivIntOp, Datum, Datum) (Datum, error)
 	EvalDivIntervalFloatOp(context.Context, *DivIntervalFloatOp, Datum, Datum) (Datum, error)
 	EvalDivIntervalIntOp(context.Context, *DivIntervalIntOp, Datum, Datum) (Datum, error)
+	EvalDivMoneyFloatOp(context.Context, *DivMoneyFloatOp, Datum, Datum) (Datum, error)
+	EvalDivMoneyIntOp(context.Context, *DivMoneyIntOp, Datum, Datum) (Datum, error)
+	EvalDivMoneyOp(context.Context, *DivMoneyOp, Datum, Datum) (Datum, error)
 	EvalFloorDivDecimalIntOp(context.Context, *FloorDivDecimalIntOp, Datum, Datum) (Datum, error)
 	EvalFloorDivDecimalOp(context.Context, *FloorDivDecimalOp, Datum, Datum) (Datum, error)
 	EvalFloorDivFloatOp(context.Context, *FloorDivFloatOp, Datum, Datum) (Datum, error)
 	EvalFloorDivIntDecimalOp(context.Context, *FloorDivIntDecimalOp, Datum, Datum) (Datum, error)
 	EvalFloorDivIntOp(context.Context, *FloorDivIntOp, Datum, Datum) (Datum, error)
+	EvalGeometricBinaryOp(context.Context, *GeometricBinaryOp, Datum, Datum) (Datum, error)
 	EvalInTupleOp(context.Context, *InTupleOp, Datum, Datum) (Datum, error)
 	EvalJSONAllExistsOp(context.Context, *JSONAllExistsOp, Datum, Datum) (Datum, error)
 	EvalJSONExistsOp(context.Context, *JSONExistsOp, Datum, Datum) (Datum, error)
@@ -95,6 +109,7 @@ type BinaryOpEvaluator interface {
 	EvalJSONFetchValIntOp(context.Context, *JSONFetchValIntOp, Datum, Datum) (Datum, error)
 	EvalJSONFetchValPathOp(context.Context, *JSONFetchValPathOp, Datum, Datum) (Datum, error)
 	EvalJSONFetchValStringOp(context.Context, *JSONFetchValStringOp, Datum, Datum) (Datum, error)
+	EvalJSONPathMatchOp(context.Context, *JSONPathMatchOp, Datum, Datum) (Datum, error)
 	EvalJSONSomeExistsOp(context.Context, *JSONSomeExistsOp, Datum, Datum) (Datum, error)
 	EvalLShiftINetOp(context.Context, *LShiftINetOp, Datum, Datum) (Datum, error)
 	EvalLShiftIntOp(context.Context, *LShiftIntOp, Datum, Datum) (Datum, error)
@@ -116,9 +131,11 @@ type BinaryOpEvaluator interface {
 	EvalMinusJsonbIntOp(context.Context, *MinusJsonbIntOp, Datum, Datum) (Datum, error)
 	EvalMinusJsonbStringArrayOp(context.Context, *MinusJsonbStringArrayOp, Datum, Datum) (Datum, error)
 	EvalMinusJsonbStringOp(context.Context, *MinusJsonbStringOp, Datum, Datum) (Datum, error)
+	EvalMinusMoneyOp(context.Context, *MinusMoneyOp, Datum, Datum) (Datum, error)
 	EvalMinusPGLSNDecimalOp(context.Context, *MinusPGLSNDecimalOp, Datum, Datum) (Datum, error)
 	EvalMinusPGLSNOp(context.Context, *MinusPGLSNOp, Datum, Datum) (Datum, error)
 	EvalMinusPGVectorOp(context.Context, *MinusPGVectorOp, Datum, Datum) (Datum, error)
+	EvalMinusRangeOp(context.Context, *MinusRangeOp, Datum, Datum) (Datum, error)
 	EvalMinusTimeIntervalOp(context.Context, *MinusTimeIntervalOp, Datum, Datum) (Datum, error)
 	EvalMinusTimeOp(context.Context, *MinusTimeOp, Datum, Datum) (Datum, error)
 	EvalMinusTimeTZIntervalOp(context.Context, *MinusTimeTZIntervalOp, Datum, Datum) (Datum, error)
@@ -138,17 +155,23 @@ type BinaryOpEvaluator interface {
 	EvalMultDecimalIntervalOp(context.Context, *MultDecimalIntervalOp, Datum, Datum) (Datum, error)
 	EvalMultDecimalOp(context.Context, *MultDecimalOp, Datum, Datum) (Datum, error)
 	EvalMultFloatIntervalOp(context.Context, *MultFloatIntervalOp, Datum, Datum) (Datum, error)
+	EvalMultFloatMoneyOp(context.Context, *MultFloatMoneyOp, Datum, Datum) (Datum, error)
 	EvalMultFloatOp(context.Context, *MultFloatOp, Datum, Datum) (Datum, error)
 	EvalMultIntDecimalOp(context.Context, *MultIntDecimalOp, Datum, Datum) (Datum, error)
 	EvalMultIntIntervalOp(context.Context, *MultIntIntervalOp, Datum, Datum) (Datum, error)
+	EvalMultIntMoneyOp(context.Context, *MultIntMoneyOp, Datum, Datum) (Datum, error)
 	EvalMultIntOp(context.Context, *MultIntOp, Datum, Datum) (Datum, error)
 	EvalMultIntervalDecimalOp(context.Context, *MultIntervalDecimalOp, Datum, Datum) (Datum, error)
 	EvalMultIntervalFloatOp(context.Context, *MultIntervalFloatOp, Datum, Datum) (Datum, error)
 	EvalMultIntervalIntOp(context.Context, *MultIntervalIntOp, Datum, Datum) (Datum, error)
+	EvalMultMoneyFloatOp(context.Context, *MultMoneyFloatOp, Datum, Datum) (Datum, error)
+	EvalMultMoneyIntOp(context.Context, *MultMoneyIntOp, Datum, Datum) (Datum, error)
 	EvalMultPGVectorOp(context.Context, *MultPGVectorOp, Datum, Datum) (Datum, error)
+	EvalMultRangeOp(context.Context, *MultRangeOp, Datum, Datum) (Datum, error)
 	EvalNegInnerProductVectorOp(context.Context, *NegInnerProductVectorOp, Datum, Datum) (Datum, error)
 	EvalOverlapsArrayOp(context.Context, *OverlapsArrayOp, Datum, Datum) (Datum, error)
 	EvalOverlapsINetOp(context.Context, *OverlapsINetOp, Datum, Datum) (Datum, error)
+	EvalOverlapsRangeOp(context.Context, *OverlapsRangeOp, Datum, Datum) (Datum, error)
 	EvalPlusDateIntOp(context.Context, *PlusDateIntOp, Datum, Datum) (Datum, error)
 	EvalPlusDateIntervalOp(context.Context, *PlusDateIntervalOp, Datum, Datum) (Datum, error)
 	EvalPlusDateTimeOp(context.Context, *PlusDateTimeOp, Datum, Datum) (Datum, error)
@@ -168,8 +191,10 @@ type BinaryOpEvaluator interface {
 	EvalPlusIntervalTimeTZOp(context.Context, *PlusIntervalTimeTZOp, Datum, Datum) (Datum, error)
 	EvalPlusIntervalTimestampOp(context.Context, *PlusIntervalTimestampOp, Datum, Datum) (Datum, error)
 	EvalPlusIntervalTimestampTZOp(context.Context, *PlusIntervalTimestampTZOp, Datum, Datum) (Datum, error)
+	EvalPlusMoneyOp(context.Context, *PlusMoneyOp, Datum, Datum) (Datum, error)
 	EvalPlusPGLSNDecimalOp(context.Context, *PlusPGLSNDecimalOp, Datum, Datum) (Datum, error)
 	EvalPlusPGVectorOp(context.Context, *PlusPGVectorOp, Datum, Datum) (Datum, error)
+	EvalPlusRangeOp(context.Context, *PlusRangeOp, Datum, Datum) (Datum, error)
 	EvalPlusTimeDateOp(context.Context, *PlusTimeDateOp, Datum, Datum) (Datum, error)
 	EvalPlusTimeIntervalOp(context.Context, *PlusTimeIntervalOp, Datum, Datum) (Datum, error)
 	EvalPlusTimeTZDateOp(context.Context, *PlusTimeTZDateOp, Datum, Datum) (Datum, error)
@@ -211,6 +236,16 @@ func (op *ComplementIntOp) Eval(ctx context.Context, e OpEvaluator, v Datum) (Da
 	return e.EvalComplementIntOp(ctx, op, v)
 }
 
+// Eval is part of the UnaryEvalOp interface.
+func (op *ComplementMACAddr8Op) Eval(ctx context.Context, e OpEvaluator, v Datum) (Datum, error) {
+	return e.EvalComplementMACAddr8Op(ctx, op, v)
+}
+
+// Eval is part of the UnaryEvalOp interface.
+func (op *ComplementMACAddrOp) Eval(ctx context.Context, e OpEvaluator, v Datum) (Datum, error) {
+	return e.EvalComplementMACAddrOp(ctx, op, v)
+}
+
 // Eval is part of the UnaryEvalOp interface.
 func (op *ComplementVarBitOp) Eval(ctx context.Context, e OpEvaluator, v Datum) (Datum, error) {
 	return e.EvalComplementVarBitOp(ctx, op, v)
@@ -246,6 +281,11 @@ func (op *UnaryMinusIntervalOp) Eval(ctx context.Context, e OpEvaluator, v Datum
 	return e.EvalUnaryMinusIntervalOp(ctx, op, v)
 }
 
+// Eval is part of the UnaryEvalOp interface.
+func (op *UnaryMinusMoneyOp) Eval(ctx context.Context, e OpEvaluator, v Datum) (Datum, error) {
+	return e.EvalUnaryMinusMoneyOp(ctx, op, v)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *AppendToMaybeNullArrayOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalAppendToMaybeNullArrayOp(ctx, op, a, b)
@@ -261,6 +301,16 @@ func (op *BitAndIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Dat
 	return e.EvalBitAndIntOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *BitAndMACAddr8Op) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalBitAndMACAddr8Op(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *BitAndMACAddrOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalBitAndMACAddrOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *BitAndVarBitOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalBitAndVarBitOp(ctx, op, a, b)
@@ -276,6 +326,16 @@ func (op *BitOrIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datu
 	return e.EvalBitOrIntOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *BitOrMACAddr8Op) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalBitOrMACAddr8Op(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *BitOrMACAddrOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalBitOrMACAddrOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *BitOrVarBitOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalBitOrVarBitOp(ctx, op, a, b)
@@ -296,6 +356,11 @@ func (op *CompareBox2DOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (
 	return e.EvalCompareBox2DOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *CompareGeometricOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalCompareGeometricOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *CompareScalarOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalCompareScalarOp(ctx, op, a, b)
@@ -346,6 +411,11 @@ func (op *ContainedByJsonbOp) Eval(ctx context.Context, e OpEvaluator, a, b Datu
 	return e.EvalContainedByJsonbOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *ContainedByRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalContainedByRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *ContainsArrayOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalContainsArrayOp(ctx, op, a, b)
@@ -356,6 +426,11 @@ func (op *ContainsJsonbOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum)
 	return e.EvalContainsJsonbOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *ContainsRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalContainsRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *CosDistanceVectorOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalCosDistanceVectorOp(ctx, op, a, b)
@@ -401,6 +476,21 @@ func (op *DivIntervalIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum)
 	return e.EvalDivIntervalIntOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *DivMoneyFloatOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalDivMoneyFloatOp(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *DivMoneyIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalDivMoneyIntOp(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *DivMoneyOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalDivMoneyOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *FloorDivDecimalIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalFloorDivDecimalIntOp(ctx, op, a, b)
@@ -426,6 +516,11 @@ func (op *FloorDivIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (D
 	return e.EvalFloorDivIntOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *GeometricBinaryOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalGeometricBinaryOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *InTupleOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalInTupleOp(ctx, op, a, b)
@@ -471,6 +566,11 @@ func (op *JSONFetchValStringOp) Eval(ctx context.Context, e OpEvaluator, a, b Da
 	return e.EvalJSONFetchValStringOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *JSONPathMatchOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalJSONPathMatchOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *JSONSomeExistsOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalJSONSomeExistsOp(ctx, op, a, b)
@@ -576,6 +676,11 @@ func (op *MinusJsonbStringOp) Eval(ctx context.Context, e OpEvaluator, a, b Datu
 	return e.EvalMinusJsonbStringOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *MinusMoneyOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalMinusMoneyOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *MinusPGLSNDecimalOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalMinusPGLSNDecimalOp(ctx, op, a, b)
@@ -591,6 +696,11 @@ func (op *MinusPGVectorOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum)
 	return e.EvalMinusPGVectorOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *MinusRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalMinusRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *MinusTimeIntervalOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalMinusTimeIntervalOp(ctx, op, a, b)
@@ -686,6 +796,11 @@ func (op *MultFloatIntervalOp) Eval(ctx context.Context, e OpEvaluator, a, b Dat
 	return e.EvalMultFloatIntervalOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *MultFloatMoneyOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalMultFloatMoneyOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *MultFloatOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalMultFloatOp(ctx, op, a, b)
@@ -701,6 +816,11 @@ func (op *MultIntIntervalOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum
 	return e.EvalMultIntIntervalOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *MultIntMoneyOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalMultIntMoneyOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *MultIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalMultIntOp(ctx, op, a, b)
@@ -721,11 +841,26 @@ func (op *MultIntervalIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum
 	return e.EvalMultIntervalIntOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *MultMoneyFloatOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalMultMoneyFloatOp(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *MultMoneyIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalMultMoneyIntOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *MultPGVectorOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalMultPGVectorOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *MultRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalMultRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *NegInnerProductVectorOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalNegInnerProductVectorOp(ctx, op, a, b)
@@ -741,6 +876,11 @@ func (op *OverlapsINetOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (
 	return e.EvalOverlapsINetOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *OverlapsRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalOverlapsRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *PlusDateIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalPlusDateIntOp(ctx, op, a, b)
@@ -836,6 +976,11 @@ func (op *PlusIntervalTimestampTZOp) Eval(ctx context.Context, e OpEvaluator, a,
 	return e.EvalPlusIntervalTimestampTZOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *PlusMoneyOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalPlusMoneyOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *PlusPGLSNDecimalOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalPlusPGLSNDecimalOp(ctx, op, a, b)
@@ -846,6 +991,11 @@ func (op *PlusPGVectorOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (
 	return e.EvalPlusPGVectorOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *PlusRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalPlusRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *PlusTimeDateOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalPlusTimeDateOp(ctx, op, a, b)
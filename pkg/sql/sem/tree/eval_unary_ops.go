@@ -24,6 +24,8 @@ type (
 	UnaryMinusIntOp struct{}
 	// UnaryMinusDecimalOp is a UnaryEvalOp.
 	UnaryMinusDecimalOp struct{}
+	// UnaryMinusMoneyOp is a UnaryEvalOp.
+	UnaryMinusMoneyOp struct{}
 )
 type (
 	// ComplementIntOp is a UnaryEvalOp.
@@ -32,6 +34,10 @@ type (
 	ComplementVarBitOp struct{}
 	// ComplementINetOp is a UnaryEvalOp.
 	ComplementINetOp struct{}
+	// ComplementMACAddrOp is a UnaryEvalOp.
+	ComplementMACAddrOp struct{}
+	// ComplementMACAddr8Op is a UnaryEvalOp.
+	ComplementMACAddr8Op struct{}
 )
 type (
 	// SqrtFloatOp is a UnaryEvalOp.
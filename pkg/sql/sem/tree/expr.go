@@ -1714,75 +1714,112 @@ func (node *ColumnAccessExpr) Format(ctx *FmtCtx) {
 	}
 }
 
-func (node *AliasedTableExpr) String() string { return AsString(node) }
-func (node *ParenTableExpr) String() string   { return AsString(node) }
-func (node *JoinTableExpr) String() string    { return AsString(node) }
-func (node *AndExpr) String() string          { return AsString(node) }
-func (node *Array) String() string            { return AsString(node) }
-func (node *BinaryExpr) String() string       { return AsString(node) }
-func (node *CaseExpr) String() string         { return AsString(node) }
-func (node *CastExpr) String() string         { return AsString(node) }
-func (node *CoalesceExpr) String() string     { return AsString(node) }
-func (node *ColumnAccessExpr) String() string { return AsString(node) }
-func (node *CollateExpr) String() string      { return AsString(node) }
-func (node *ComparisonExpr) String() string   { return AsString(node) }
-func (node *Datums) String() string           { return AsString(node) }
-func (node *DBitArray) String() string        { return AsString(node) }
-func (node *DBool) String() string            { return AsString(node) }
-func (node *DBytes) String() string           { return AsString(node) }
-func (node *DEncodedKey) String() string      { return AsString(node) }
-func (node *DDate) String() string            { return AsString(node) }
-func (node *DTime) String() string            { return AsString(node) }
-func (node *DTimeTZ) String() string          { return AsString(node) }
-func (node *DDecimal) String() string         { return AsString(node) }
-func (node *DFloat) String() string           { return AsString(node) }
-func (node *DBox2D) String() string           { return AsString(node) }
-func (node *DPGLSN) String() string           { return AsString(node) }
-func (node *DGeography) String() string       { return AsString(node) }
-func (node *DGeometry) String() string        { return AsString(node) }
-func (node *DInt) String() string             { return AsString(node) }
-func (node *DInterval) String() string        { return AsString(node) }
-func (node *DJSON) String() string            { return AsString(node) }
-func (node *DUuid) String() string            { return AsString(node) }
-func (node *DIPAddr) String() string          { return AsString(node) }
-func (node *DString) String() string          { return AsString(node) }
-func (node *DCollatedString) String() string  { return AsString(node) }
-func (node *DTimestamp) String() string       { return AsString(node) }
-func (node *DTimestampTZ) String() string     { return AsString(node) }
-func (node *DTuple) String() string           { return AsString(node) }
-func (node *DArray) String() string           { return AsString(node) }
-func (node *DOid) String() string             { return AsString(node) }
-func (node *DOidWrapper) String() string      { return AsString(node) }
-func (node *DVoid) String() string            { return AsString(node) }
-func (node *Exprs) String() string            { return AsString(node) }
-func (node *ArrayFlatten) String() string     { return AsString(node) }
-func (node *FuncExpr) String() string         { return AsString(node) }
-func (node *IfExpr) String() string           { return AsString(node) }
-func (node *IfErrExpr) String() string        { return AsString(node) }
-func (node *IndexedVar) String() string       { return AsString(node) }
-func (node *IndirectionExpr) String() string  { return AsString(node) }
-func (node *IsOfTypeExpr) String() string     { return AsString(node) }
-func (node *Name) String() string             { return AsString(node) }
-func (node *UnrestrictedName) String() string { return AsString(node) }
-func (node *NotExpr) String() string          { return AsString(node) }
-func (node *IsNullExpr) String() string       { return AsString(node) }
-func (node *IsNotNullExpr) String() string    { return AsString(node) }
-func (node *NullIfExpr) String() string       { return AsString(node) }
-func (node *NumVal) String() string           { return AsString(node) }
-func (node *OrExpr) String() string           { return AsString(node) }
-func (node *ParenExpr) String() string        { return AsString(node) }
-func (node *RangeCond) String() string        { return AsString(node) }
-func (node *TxnControlExpr) String() string   { return AsString(node) }
-func (node *StrVal) String() string           { return AsString(node) }
-func (node *Subquery) String() string         { return AsString(node) }
-func (node *RoutineExpr) String() string      { return AsString(node) }
-func (node *Tuple) String() string            { return AsString(node) }
-func (node *TupleStar) String() string        { return AsString(node) }
-func (node *AnnotateTypeExpr) String() string { return AsString(node) }
-func (node *UnaryExpr) String() string        { return AsString(node) }
-func (node DefaultVal) String() string        { return AsString(node) }
-func (node PartitionMaxVal) String() string   { return AsString(node) }
-func (node PartitionMinVal) String() string   { return AsString(node) }
-func (node *Placeholder) String() string      { return AsString(node) }
-func (node dNull) String() string             { return AsString(node) }
-func (list *NameList) String() string         { return AsString(list) }
+// GroupingOperation represents a GROUPING(E, ...) expression. It evaluates to
+// an integer bitmask in which bit i (counting from the least significant bit
+// of the last argument) is set if the corresponding argument is not part of
+// the grouping set that produced the current row. It is only valid in the
+// SELECT list, HAVING and ORDER BY clauses of a query with GROUP BY.
+type GroupingOperation struct {
+	Exprs Exprs
+
+	typeAnnotation
+}
+
+// TypedExprAt returns the expression at the specified index as a TypedExpr.
+func (node *GroupingOperation) TypedExprAt(idx int) TypedExpr {
+	return node.Exprs[idx].(TypedExpr)
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingOperation) Format(ctx *FmtCtx) {
+	ctx.WriteString("GROUPING(")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
+func (node *AliasedTableExpr) String() string  { return AsString(node) }
+func (node *ParenTableExpr) String() string    { return AsString(node) }
+func (node *JoinTableExpr) String() string     { return AsString(node) }
+func (node *AndExpr) String() string           { return AsString(node) }
+func (node *Array) String() string             { return AsString(node) }
+func (node *BinaryExpr) String() string        { return AsString(node) }
+func (node *CaseExpr) String() string          { return AsString(node) }
+func (node *CastExpr) String() string          { return AsString(node) }
+func (node *CoalesceExpr) String() string      { return AsString(node) }
+func (node *ColumnAccessExpr) String() string  { return AsString(node) }
+func (node *CollateExpr) String() string       { return AsString(node) }
+func (node *ComparisonExpr) String() string    { return AsString(node) }
+func (node *Datums) String() string            { return AsString(node) }
+func (node *DBitArray) String() string         { return AsString(node) }
+func (node *DBool) String() string             { return AsString(node) }
+func (node *DBytes) String() string            { return AsString(node) }
+func (node *DEncodedKey) String() string       { return AsString(node) }
+func (node *DDate) String() string             { return AsString(node) }
+func (node *DTime) String() string             { return AsString(node) }
+func (node *DTimeTZ) String() string           { return AsString(node) }
+func (node *DDecimal) String() string          { return AsString(node) }
+func (node *DFloat) String() string            { return AsString(node) }
+func (node *DBox2D) String() string            { return AsString(node) }
+func (node *DPGLSN) String() string            { return AsString(node) }
+func (node *DMACAddr) String() string          { return AsString(node) }
+func (node *DMACAddr8) String() string         { return AsString(node) }
+func (node *DMoney) String() string            { return AsString(node) }
+func (node *DPoint) String() string            { return AsString(node) }
+func (node *DBox) String() string              { return AsString(node) }
+func (node *DLSeg) String() string             { return AsString(node) }
+func (node *DLine) String() string             { return AsString(node) }
+func (node *DPath) String() string             { return AsString(node) }
+func (node *DPolygon) String() string          { return AsString(node) }
+func (node *DCircle) String() string           { return AsString(node) }
+func (node *DRange) String() string            { return AsString(node) }
+func (node *DMultirange) String() string       { return AsString(node) }
+func (node *DGeography) String() string        { return AsString(node) }
+func (node *DGeometry) String() string         { return AsString(node) }
+func (node *DInt) String() string              { return AsString(node) }
+func (node *DInterval) String() string         { return AsString(node) }
+func (node *DJSON) String() string             { return AsString(node) }
+func (node *DJsonpath) String() string         { return AsString(node) }
+func (node *DUuid) String() string             { return AsString(node) }
+func (node *DIPAddr) String() string           { return AsString(node) }
+func (node *DString) String() string           { return AsString(node) }
+func (node *DCollatedString) String() string   { return AsString(node) }
+func (node *DTimestamp) String() string        { return AsString(node) }
+func (node *DTimestampTZ) String() string      { return AsString(node) }
+func (node *DTuple) String() string            { return AsString(node) }
+func (node *DArray) String() string            { return AsString(node) }
+func (node *DOid) String() string              { return AsString(node) }
+func (node *DOidWrapper) String() string       { return AsString(node) }
+func (node *DVoid) String() string             { return AsString(node) }
+func (node *Exprs) String() string             { return AsString(node) }
+func (node *ArrayFlatten) String() string      { return AsString(node) }
+func (node *FuncExpr) String() string          { return AsString(node) }
+func (node *GroupingOperation) String() string { return AsString(node) }
+func (node *IfExpr) String() string            { return AsString(node) }
+func (node *IfErrExpr) String() string         { return AsString(node) }
+func (node *IndexedVar) String() string        { return AsString(node) }
+func (node *IndirectionExpr) String() string   { return AsString(node) }
+func (node *IsOfTypeExpr) String() string      { return AsString(node) }
+func (node *Name) String() string              { return AsString(node) }
+func (node *UnrestrictedName) String() string  { return AsString(node) }
+func (node *NotExpr) String() string           { return AsString(node) }
+func (node *IsNullExpr) String() string        { return AsString(node) }
+func (node *IsNotNullExpr) String() string     { return AsString(node) }
+func (node *NullIfExpr) String() string        { return AsString(node) }
+func (node *NumVal) String() string            { return AsString(node) }
+func (node *OrExpr) String() string            { return AsString(node) }
+func (node *ParenExpr) String() string         { return AsString(node) }
+func (node *RangeCond) String() string         { return AsString(node) }
+func (node *TxnControlExpr) String() string    { return AsString(node) }
+func (node *StrVal) String() string            { return AsString(node) }
+func (node *Subquery) String() string          { return AsString(node) }
+func (node *RoutineExpr) String() string       { return AsString(node) }
+func (node *Tuple) String() string             { return AsString(node) }
+func (node *TupleStar) String() string         { return AsString(node) }
+func (node *AnnotateTypeExpr) String() string  { return AsString(node) }
+func (node *UnaryExpr) String() string         { return AsString(node) }
+func (node DefaultVal) String() string         { return AsString(node) }
+func (node PartitionMaxVal) String() string    { return AsString(node) }
+func (node PartitionMinVal) String() string    { return AsString(node) }
+func (node *Placeholder) String() string       { return AsString(node) }
+func (node dNull) String() string              { return AsString(node) }
+func (list *NameList) String() string          { return AsString(list) }
@@ -0,0 +1,48 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+// Listen represents a LISTEN statement.
+type Listen struct {
+	ChannelName Name
+}
+
+var _ Statement = &Listen{}
+
+// Format implements the NodeFormatter interface.
+func (node *Listen) Format(ctx *FmtCtx) {
+	ctx.WriteString("LISTEN ")
+	ctx.FormatNode(&node.ChannelName)
+}
+
+// String implements the Statement interface.
+func (node *Listen) String() string {
+	return AsString(node)
+}
+
+// Notify represents a NOTIFY statement.
+type Notify struct {
+	ChannelName Name
+	// Payload is nil if no payload was specified.
+	Payload *StrVal
+}
+
+var _ Statement = &Notify{}
+
+// Format implements the NodeFormatter interface.
+func (node *Notify) Format(ctx *FmtCtx) {
+	ctx.WriteString("NOTIFY ")
+	ctx.FormatNode(&node.ChannelName)
+	if node.Payload != nil {
+		ctx.WriteString(", ")
+		ctx.FormatNode(node.Payload)
+	}
+}
+
+// String implements the Statement interface.
+func (node *Notify) String() string {
+	return AsString(node)
+}
@@ -296,6 +296,31 @@ type Overload struct {
 	// should be performed against the function owner rather than the invoking
 	// user.
 	SecurityMode RoutineSecurity
+
+	// UserDefinedAggregate is set when the overload represents a user-defined
+	// aggregate function, in which case Class is AggregateClass. It is nil if
+	// UDFContainsOnlySignature is true.
+	UserDefinedAggregate *UserDefinedAggregate
+}
+
+// UserDefinedAggregate contains the definition of a user-defined aggregate
+// function. The component functions are user-defined functions identified by
+// their OIDs.
+type UserDefinedAggregate struct {
+	// TransitionFunc computes the next state value from the current state value
+	// and the arguments of the aggregate.
+	TransitionFunc oid.Oid
+	// FinalFunc, if non-zero, computes the result of the aggregate from the
+	// final state value. If zero, the final state value is the result.
+	FinalFunc oid.Oid
+	// CombineFunc, if non-zero, combines two state values. It allows a
+	// distributed aggregate to be computed in a local and a final stage.
+	CombineFunc oid.Oid
+	// StateType is the type of the state value.
+	StateType *types.T
+	// InitCond is the textual representation of the initial state value. It is
+	// nil if the initial state value is NULL.
+	InitCond *string
 }
 
 // params implements the overloadImpl interface.
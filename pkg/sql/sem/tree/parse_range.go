@@ -0,0 +1,205 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+var malformedRangeError = pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed range literal")
+var malformedMultirangeError = pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed multirange literal")
+
+type rangeParseState struct {
+	s                string
+	ctx              ParseContext
+	dependsOnContext bool
+	// t is the range type of the ranges to parse.
+	t *types.T
+}
+
+func (p *rangeParseState) eatWhitespace() {
+	p.s = strings.TrimLeftFunc(p.s, unicode.IsSpace)
+}
+
+func (p *rangeParseState) peek() byte {
+	if len(p.s) == 0 {
+		return 0
+	}
+	return p.s[0]
+}
+
+// parseRange parses a range at the start of the remaining input. As in
+// Postgres, whitespace is allowed around the range but not around its bounds.
+func (p *rangeParseState) parseRange() (*DRange, error) {
+	p.eatWhitespace()
+	if len(p.s) >= len("empty") && strings.EqualFold(p.s[:len("empty")], "empty") {
+		p.s = p.s[len("empty"):]
+		return NewDEmptyRange(p.t), nil
+	}
+	var lowerInc, upperInc bool
+	switch p.peek() {
+	case '[':
+		lowerInc = true
+	case '(':
+	default:
+		return nil, errors.WithDetail(malformedRangeError, "Missing left parenthesis or bracket.")
+	}
+	p.s = p.s[1:]
+	lower, err := p.parseBound()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != ',' {
+		return nil, errors.WithDetail(malformedRangeError, "Missing comma after lower bound.")
+	}
+	p.s = p.s[1:]
+	upper, err := p.parseBound()
+	if err != nil {
+		return nil, err
+	}
+	switch p.peek() {
+	case ']':
+		upperInc = true
+	case ')':
+	case ',':
+		return nil, errors.WithDetail(malformedRangeError, "Too many commas.")
+	default:
+		return nil, errors.WithDetail(malformedRangeError, "Missing right parenthesis or bracket.")
+	}
+	p.s = p.s[1:]
+	return MakeDRange(
+		context.TODO(), rangeParseCompareContext{parseCtx: p.ctx}, p.t, lower, upper, lowerInc, upperInc,
+	)
+}
+
+// parseBound parses a bound of a range, returning nil for an infinite bound.
+// A bound is infinite if it is empty; a quoted empty string is not.
+func (p *rangeParseState) parseBound() (Datum, error) {
+	var sb strings.Builder
+	quoted, inQuote := false, false
+	i := 0
+	for ; i < len(p.s); i++ {
+		ch := p.s[i]
+		switch {
+		case ch == '\\':
+			i++
+			if i == len(p.s) {
+				return nil, errors.WithDetail(malformedRangeError, "Unexpected end of input.")
+			}
+			sb.WriteByte(p.s[i])
+		case ch == '"':
+			if inQuote && i+1 < len(p.s) && p.s[i+1] == '"' {
+				// A doubled quote within quotes is a literal quote.
+				sb.WriteByte('"')
+				i++
+			} else {
+				inQuote = !inQuote
+				quoted = true
+			}
+		case !inQuote && (ch == ',' || ch == ')' || ch == ']'):
+			p.s = p.s[i:]
+			if sb.Len() == 0 && !quoted {
+				return nil, nil
+			}
+			d, dependsOnContext, err := ParseAndRequireString(p.t.RangeContents(), sb.String(), p.ctx)
+			if dependsOnContext {
+				p.dependsOnContext = true
+			}
+			return d, err
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return nil, errors.WithDetail(malformedRangeError, "Unexpected end of input.")
+}
+
+// ParseDRangeFromString parses the string-form of a range, such as '[1,10)',
+// into a range of type t.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	p := rangeParseState{s: s, ctx: ctx, t: t}
+	r, err := p.parseRange()
+	if err == nil {
+		p.eatWhitespace()
+		if p.s != "" {
+			err = errors.WithDetail(malformedRangeError, "Junk after right parenthesis or bracket.")
+		}
+	}
+	if err != nil {
+		return nil, false, MakeParseError(s, t, err)
+	}
+	return r, p.dependsOnContext, nil
+}
+
+// ParseDMultirangeFromString parses the string-form of a multirange, such as
+// '{[1,3), [5,7)}', into a multirange of type t.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDMultirangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DMultirange, dependsOnContext bool, _ error) {
+	m, dependsOnContext, err := doParseDMultirangeFromString(ctx, s, t)
+	if err != nil {
+		return nil, false, MakeParseError(s, t, err)
+	}
+	return m, dependsOnContext, nil
+}
+
+// doParseDMultirangeFromString does most of the work of
+// ParseDMultirangeFromString, except the error it returns isn't prettified as
+// a parsing error.
+func doParseDMultirangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DMultirange, dependsOnContext bool, _ error) {
+	p := rangeParseState{s: s, ctx: ctx, t: t.MultirangeContents()}
+	p.eatWhitespace()
+	if p.peek() != '{' {
+		return nil, false, errors.WithDetail(malformedMultirangeError, "Missing left brace.")
+	}
+	p.s = p.s[1:]
+	p.eatWhitespace()
+	var ranges []*DRange
+	if p.peek() == '}' {
+		p.s = p.s[1:]
+	} else {
+		for {
+			r, err := p.parseRange()
+			if err != nil {
+				return nil, false, err
+			}
+			ranges = append(ranges, r)
+			p.eatWhitespace()
+			if p.peek() == '}' {
+				p.s = p.s[1:]
+				break
+			}
+			if p.peek() != ',' {
+				return nil, false, errors.WithDetail(
+					malformedMultirangeError, "Expected comma or end of multirange.",
+				)
+			}
+			p.s = p.s[1:]
+		}
+	}
+	p.eatWhitespace()
+	if p.s != "" {
+		return nil, false, errors.WithDetail(malformedMultirangeError, "Junk after closing right brace.")
+	}
+	m, err := MakeDMultirange(context.TODO(), rangeParseCompareContext{parseCtx: ctx}, t, ranges)
+	return m, p.dependsOnContext, err
+}
@@ -53,7 +53,11 @@ func ParseAndRequireString(
 	case types.FloatFamily:
 		d, err = ParseDFloat(strings.TrimSpace(s))
 	case types.INetFamily:
-		d, err = ParseDIPAddrFromINetString(s)
+		if t.Oid() == oid.T_cidr {
+			d, err = ParseDCIDR(s)
+		} else {
+			d, err = ParseDIPAddrFromINetString(s)
+		}
 	case types.IntFamily:
 		d, err = ParseDInt(strings.TrimSpace(s))
 	case types.IntervalFamily:
@@ -62,10 +66,21 @@ func ParseAndRequireString(
 			return nil, false, typErr
 		}
 		d, err = ParseDIntervalWithTypeMetadata(intervalStyle(ctx), s, itm)
+	case types.MACAddrFamily:
+		d, err = ParseDMACAddr(s)
+	case types.MACAddr8Family:
+		d, err = ParseDMACAddr8(s)
+	case types.MoneyFamily:
+		d, err = ParseDMoney(ctx, s)
+		dependsOnContext = true
 	case types.PGLSNFamily:
 		d, err = ParseDPGLSN(s)
 	case types.PGVectorFamily:
 		d, err = ParseDPGVector(s)
+	case types.RangeFamily:
+		d, dependsOnContext, err = ParseDRangeFromString(ctx, s, t)
+	case types.MultirangeFamily:
+		d, dependsOnContext, err = ParseDMultirangeFromString(ctx, s, t)
 	case types.RefCursorFamily:
 		d = NewDRefCursor(s)
 	case types.Box2DFamily:
@@ -76,6 +91,22 @@ func ParseAndRequireString(
 		d, err = ParseDGeometry(s)
 	case types.JsonFamily:
 		d, err = ParseDJSON(s)
+	case types.JsonpathFamily:
+		d, err = ParseDJsonpath(s)
+	case types.PointFamily:
+		d, err = ParseDPoint(s)
+	case types.BoxFamily:
+		d, err = ParseDBox(s)
+	case types.LSegFamily:
+		d, err = ParseDLSeg(s)
+	case types.LineFamily:
+		d, err = ParseDLine(s)
+	case types.PathFamily:
+		d, err = ParseDPath(s)
+	case types.PolygonFamily:
+		d, err = ParseDPolygon(s)
+	case types.CircleFamily:
+		d, err = ParseDCircle(s)
 	case types.OidFamily:
 		if t.Oid() != oid.T_oid && s == UnknownOidName {
 			d = NewDOidWithType(UnknownOidValue, t)
@@ -1629,7 +1629,7 @@ func (node *ShardedIndexDef) doc(p *PrettyCfg) pretty.Doc {
 func (node *CreateIndex) doc(p *PrettyCfg) pretty.Doc {
 	// Final layout:
 	// CREATE [UNIQUE] [INVERTED] INDEX [name]
-	//    ON tbl (cols...)
+	//    ON tbl [USING lsh] (cols...)
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
@@ -1657,10 +1657,12 @@ func (node *CreateIndex) doc(p *PrettyCfg) pretty.Doc {
 	}
 
 	clauses := make([]pretty.Doc, 0, 7)
-	clauses = append(clauses, pretty.Fold(pretty.ConcatSpace,
-		pretty.Keyword("ON"),
-		p.Doc(&node.Table),
-		p.bracket("(", p.Doc(&node.Columns), ")")))
+	on := []pretty.Doc{pretty.Keyword("ON"), p.Doc(&node.Table)}
+	if node.Vector {
+		on = append(on, pretty.Keyword("USING lsh"))
+	}
+	on = append(on, p.bracket("(", p.Doc(&node.Columns), ")"))
+	clauses = append(clauses, pretty.Fold(pretty.ConcatSpace, on...))
 
 	if node.Sharded != nil {
 		clauses = append(clauses, p.Doc(node.Sharded))
@@ -1822,6 +1824,9 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionByIndex != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByIndex))
 	}
+	if node.Deferrability != ConstraintNotDeferrable {
+		clauses = append(clauses, p.Doc(&node.Deferrability))
+	}
 	if node.Predicate != nil {
 		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
 	}
@@ -1885,6 +1890,10 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 		clauses = append(clauses, actions)
 	}
 
+	if node.Deferrability != ConstraintNotDeferrable {
+		clauses = append(clauses, p.Doc(&node.Deferrability))
+	}
+
 	return p.nestUnder(title, pretty.Group(pretty.Stack(clauses...)))
 }
 
@@ -2024,6 +2033,9 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if node.Unique.WithoutIndex {
 			pkConstraint = pretty.ConcatSpace(pkConstraint, pretty.Keyword("WITHOUT INDEX"))
 		}
+		if node.Unique.Deferrability != ConstraintNotDeferrable {
+			pkConstraint = pretty.ConcatSpace(pkConstraint, p.Doc(&node.Unique.Deferrability))
+		}
 	}
 	if pkConstraint != pretty.Nil {
 		clauses = append(clauses, p.maybePrependConstraintName(&node.Unique.ConstraintName, pkConstraint))
@@ -2053,8 +2065,11 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 
 	// CHECK expressions/constraints.
 	for _, checkExpr := range node.CheckExprs {
-		clauses = append(clauses, p.maybePrependConstraintName(&checkExpr.ConstraintName,
-			pretty.ConcatSpace(pretty.Keyword("CHECK"), p.bracket("(", p.Doc(checkExpr.Expr), ")"))))
+		check := pretty.ConcatSpace(pretty.Keyword("CHECK"), p.bracket("(", p.Doc(checkExpr.Expr), ")"))
+		if checkExpr.Deferrability != ConstraintNotDeferrable {
+			check = pretty.ConcatSpace(check, p.Doc(&checkExpr.Deferrability))
+		}
+		clauses = append(clauses, p.maybePrependConstraintName(&checkExpr.ConstraintName, check))
 	}
 
 	// FK constraints.
@@ -2071,6 +2086,9 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if ref := p.Doc(&node.References.Actions); ref != pretty.Nil {
 			fkDetails = append(fkDetails, ref)
 		}
+		if node.References.Deferrability != ConstraintNotDeferrable {
+			fkDetails = append(fkDetails, p.Doc(&node.References.Deferrability))
+		}
 		fk := fkHead
 		if len(fkDetails) > 0 {
 			fk = p.nestUnder(fk, pretty.Group(pretty.Stack(fkDetails...)))
@@ -2118,6 +2136,9 @@ func (node *CheckConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//
 	d := pretty.ConcatSpace(pretty.Keyword("CHECK"),
 		p.bracket("(", p.Doc(node.Expr), ")"))
+	if node.Deferrability != ConstraintNotDeferrable {
+		d = pretty.ConcatSpace(d, p.Doc(&node.Deferrability))
+	}
 
 	if node.Name != "" {
 		d = p.nestUnder(
@@ -2131,6 +2152,16 @@ func (node *CheckConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	return d
 }
 
+func (node *ConstraintDeferrability) doc(p *PrettyCfg) pretty.Doc {
+	switch *node {
+	case ConstraintInitiallyImmediate:
+		return pretty.Keyword("DEFERRABLE")
+	case ConstraintInitiallyDeferred:
+		return pretty.Keyword("DEFERRABLE INITIALLY DEFERRED")
+	}
+	return pretty.Nil
+}
+
 func (node *ReferenceActions) doc(p *PrettyCfg) pretty.Doc {
 	var docs []pretty.Doc
 	if node.Delete != NoAction {
@@ -0,0 +1,93 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+// CreatePublication represents a CREATE PUBLICATION statement.
+type CreatePublication struct {
+	Name Name
+	// AllTables is set for FOR ALL TABLES, in which case Tables is empty.
+	AllTables bool
+	Tables    TableNames
+}
+
+var _ Statement = &CreatePublication{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreatePublication) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE PUBLICATION ")
+	ctx.FormatNode(&node.Name)
+	if node.AllTables {
+		ctx.WriteString(" FOR ALL TABLES")
+	} else if len(node.Tables) > 0 {
+		ctx.WriteString(" FOR TABLE ")
+		ctx.FormatNode(&node.Tables)
+	}
+}
+
+// AlterPublicationKind is the kind of change of an ALTER PUBLICATION
+// statement.
+type AlterPublicationKind int
+
+// The kinds of changes to the tables of a publication.
+const (
+	AlterPublicationAddTable AlterPublicationKind = iota
+	AlterPublicationSetTable
+	AlterPublicationDropTable
+)
+
+// String implements the fmt.Stringer interface.
+func (k AlterPublicationKind) String() string {
+	switch k {
+	case AlterPublicationAddTable:
+		return "ADD"
+	case AlterPublicationSetTable:
+		return "SET"
+	default:
+		return "DROP"
+	}
+}
+
+// AlterPublication represents an ALTER PUBLICATION ... { ADD | SET | DROP }
+// TABLE statement.
+type AlterPublication struct {
+	Name   Name
+	Kind   AlterPublicationKind
+	Tables TableNames
+}
+
+var _ Statement = &AlterPublication{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterPublication) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER PUBLICATION ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.Kind.String())
+	ctx.WriteString(" TABLE ")
+	ctx.FormatNode(&node.Tables)
+}
+
+// DropPublication represents a DROP PUBLICATION statement.
+type DropPublication struct {
+	Names        NameList
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropPublication{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropPublication) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP PUBLICATION ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
@@ -136,6 +136,16 @@ type RoutineExpr struct {
 	// CursorDeclaration contains the information needed to open a SQL cursor with
 	// the result of the *first* body statement. It may be unset.
 	CursorDeclaration *RoutineOpenCursor
+
+	// ResultBuffer is true for a set-returning PL/pgSQL routine. The result rows
+	// of such a routine are accumulated by RETURN NEXT and RETURN QUERY
+	// statements, rather than produced by the last body statement.
+	ResultBuffer bool
+
+	// ReturnNext contains the information needed to add the result of the
+	// *first* body statement to the result buffer of the enclosing set-returning
+	// PL/pgSQL routine. It may be unset.
+	ReturnNext *RoutineReturnNext
 }
 
 // NewTypedRoutineExpr returns a new RoutineExpr that is well-typed.
@@ -153,6 +163,8 @@ func NewTypedRoutineExpr(
 	blockStart bool,
 	blockState *BlockState,
 	cursorDeclaration *RoutineOpenCursor,
+	resultBuffer bool,
+	returnNext *RoutineReturnNext,
 ) *RoutineExpr {
 	return &RoutineExpr{
 		Args:              args,
@@ -168,6 +180,8 @@ func NewTypedRoutineExpr(
 		BlockStart:        blockStart,
 		BlockState:        blockState,
 		CursorDeclaration: cursorDeclaration,
+		ResultBuffer:      resultBuffer,
+		ReturnNext:        returnNext,
 	}
 }
 
@@ -222,6 +236,23 @@ type RoutineOpenCursor struct {
 	// CursorSQL is a formatted string used to associate the original SQL
 	// statement with the cursor.
 	CursorSQL string
+
+	// Dynamic is true for OPEN ... FOR EXECUTE and FOR ... IN EXECUTE loops. In
+	// this case, the first body statement produces a single row with the query
+	// string followed by the values for its parameters. The query is executed,
+	// and the cursor is opened with its rows.
+	Dynamic bool
+}
+
+// RoutineReturnNext stores the information needed to add the output of a
+// routine to the result of a set-returning PL/pgSQL routine. It is used to
+// implement the RETURN NEXT and RETURN QUERY statements.
+type RoutineReturnNext struct {
+	// Dynamic is true for RETURN QUERY EXECUTE. In this case, the first body
+	// statement produces a single row with the query string followed by the
+	// values for its parameters. The query is executed, and its rows are added
+	// to the result.
+	Dynamic bool
 }
 
 // BlockState is shared state between all routines that make up a PLpgSQL block.
@@ -775,6 +775,49 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingSetKind indicates the kind of a GroupingSet.
+type GroupingSetKind int
+
+const (
+	// RollupGroupingSet is a ROLLUP (...) grouping item.
+	RollupGroupingSet GroupingSetKind = iota
+	// CubeGroupingSet is a CUBE (...) grouping item.
+	CubeGroupingSet
+	// ExplicitGroupingSets is a GROUPING SETS (...) grouping item.
+	ExplicitGroupingSets
+)
+
+var groupingSetKindName = [...]string{
+	RollupGroupingSet:    "ROLLUP",
+	CubeGroupingSet:      "CUBE",
+	ExplicitGroupingSets: "GROUPING SETS",
+}
+
+func (k GroupingSetKind) String() string {
+	return groupingSetKindName[k]
+}
+
+// GroupingSet represents a ROLLUP, CUBE or GROUPING SETS item in a GROUP BY
+// clause. Each element of Exprs is either a single expression or a Tuple that
+// groups several expressions into one element (e.g. the (a, b) in
+// ROLLUP ((a, b), c)). The elements of a GROUPING SETS item can additionally
+// be nested ROLLUP, CUBE or GROUPING SETS items.
+type GroupingSet struct {
+	Kind  GroupingSetKind
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.Kind.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
+// String implements the fmt.Stringer interface.
+func (node *GroupingSet) String() string { return AsString(node) }
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
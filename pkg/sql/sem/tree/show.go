@@ -574,6 +574,18 @@ func (node *ShowChangefeedJobs) Format(ctx *FmtCtx) {
 	}
 }
 
+// ShowChangefeedDLQ represents a SHOW CHANGEFEED DLQ statement, which lists
+// the events that a changefeed wrote to its dead letter queue.
+type ShowChangefeedDLQ struct {
+	Job Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowChangefeedDLQ) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW CHANGEFEED DLQ FOR JOB ")
+	ctx.FormatNode(node.Job)
+}
+
 // ShowSurvivalGoal represents a SHOW SURVIVAL GOAL statement
 type ShowSurvivalGoal struct {
 	DatabaseName Name
@@ -244,6 +244,7 @@ var _ CCLOnlyStatement = &ShowBackup{}
 var _ CCLOnlyStatement = &Restore{}
 var _ CCLOnlyStatement = &CreateChangefeed{}
 var _ CCLOnlyStatement = &AlterChangefeed{}
+var _ CCLOnlyStatement = &ShowChangefeedDLQ{}
 var _ CCLOnlyStatement = &Import{}
 var _ CCLOnlyStatement = &Export{}
 var _ CCLOnlyStatement = &ScheduledBackup{}
@@ -421,6 +422,17 @@ func (*AlterDefaultPrivileges) StatementType() StatementType { return TypeDCL }
 // StatementTag returns a short string identifying the type of statement.
 func (*AlterDefaultPrivileges) StatementTag() string { return "ALTER DEFAULT PRIVILEGES" }
 
+// StatementReturnType implements the Statement interface.
+func (*AlterDomain) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterDomain) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (*AlterDomain) StatementTag() string { return "ALTER DOMAIN" }
+
+func (*AlterDomain) hiddenFromShowQueries() {}
+
 // StatementReturnType implements the Statement interface.
 func (*AlterIndex) StatementReturnType() StatementReturnType { return DDL }
 
@@ -568,6 +580,17 @@ func (*AlterTenantService) StatementType() StatementType { return TypeDCL }
 // StatementTag returns a short string identifying the type of statement.
 func (*AlterTenantService) StatementTag() string { return "ALTER VIRTUAL CLUSTER SERVICE" }
 
+// StatementReturnType implements the Statement interface.
+func (*AlterTextSearch) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterTextSearch) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (n *AlterTextSearch) StatementTag() string {
+	return "ALTER TEXT SEARCH " + n.Kind.String()
+}
+
 // StatementReturnType implements the Statement interface.
 func (*AlterType) StatementReturnType() StatementReturnType { return DDL }
 
@@ -579,6 +602,15 @@ func (*AlterType) StatementTag() string { return "ALTER TYPE" }
 
 func (*AlterType) hiddenFromShowQueries() {}
 
+// StatementReturnType implements the Statement interface.
+func (*AlterPublication) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterPublication) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (*AlterPublication) StatementTag() string { return "ALTER PUBLICATION" }
+
 // StatementReturnType implements the Statement interface.
 func (*AlterSequence) StatementReturnType() StatementReturnType { return DDL }
 
@@ -884,6 +916,17 @@ func (*CreateDatabase) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*CreateDatabase) StatementTag() string { return "CREATE DATABASE" }
 
+// StatementReturnType implements the Statement interface.
+func (*CreateDomain) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateDomain) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (*CreateDomain) StatementTag() string { return "CREATE DOMAIN" }
+
+func (*CreateDomain) modifiesSchema() bool { return true }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateExtension) StatementReturnType() StatementReturnType { return Ack }
 
@@ -986,6 +1029,20 @@ func (n *CreateTable) StatementTag() string {
 // modifiesSchema implements the canModifySchema interface.
 func (*CreateTable) modifiesSchema() bool { return true }
 
+// StatementReturnType implements the Statement interface.
+func (*CreateTextSearch) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateTextSearch) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (n *CreateTextSearch) StatementTag() string {
+	return "CREATE TEXT SEARCH " + n.Kind.String()
+}
+
+// modifiesSchema implements the canModifySchema interface.
+func (*CreateTextSearch) modifiesSchema() bool { return true }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateType) StatementReturnType() StatementReturnType { return DDL }
 
@@ -997,6 +1054,15 @@ func (*CreateType) StatementTag() string { return "CREATE TYPE" }
 
 func (*CreateType) modifiesSchema() bool { return true }
 
+// StatementReturnType implements the Statement interface.
+func (*CreatePublication) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreatePublication) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (*CreatePublication) StatementTag() string { return "CREATE PUBLICATION" }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateRole) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1068,6 +1134,19 @@ func (d *Discard) StatementTag() string {
 // modifiesSchema implements the canModifySchema interface.
 func (*Discard) modifiesSchema() bool { return true }
 
+// StatementReturnType implements the Statement interface.
+func (*DoBlock) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*DoBlock) StatementType() StatementType { return TypeTCL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DoBlock) StatementTag() string { return "DO" }
+
+// modifiesSchema implements the canModifySchema interface. A DO block may
+// execute arbitrary statements, including schema changes.
+func (*DoBlock) modifiesSchema() bool { return true }
+
 // StatementReturnType implements the Statement interface.
 func (n *DeclareCursor) StatementReturnType() StatementReturnType { return Ack }
 
@@ -1095,6 +1174,15 @@ func (*DropDatabase) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*DropDatabase) StatementTag() string { return DropDatabaseTag }
 
+// StatementReturnType implements the Statement interface.
+func (*DropDomain) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropDomain) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (*DropDomain) StatementTag() string { return "DROP DOMAIN" }
+
 // StatementReturnType implements the Statement interface.
 func (*DropIndex) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1131,6 +1219,15 @@ func (*DropSequence) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*DropSequence) StatementTag() string { return DropSequenceTag }
 
+// StatementReturnType implements the Statement interface.
+func (*DropPublication) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropPublication) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (*DropPublication) StatementTag() string { return "DROP PUBLICATION" }
+
 // StatementReturnType implements the Statement interface.
 func (*DropRole) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1142,6 +1239,17 @@ func (*DropRole) StatementTag() string { return "DROP ROLE" }
 
 func (*DropRole) hiddenFromShowQueries() {}
 
+// StatementReturnType implements the Statement interface.
+func (*DropTextSearch) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropTextSearch) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (n *DropTextSearch) StatementTag() string {
+	return "DROP TEXT SEARCH " + n.Kind.String()
+}
+
 // StatementReturnType implements the Statement interface.
 func (*DropType) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1272,6 +1380,24 @@ func (*LiteralValuesClause) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*LiteralValuesClause) StatementTag() string { return "VALUES" }
 
+// StatementReturnType implements the Statement interface.
+func (*Listen) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*Listen) StatementType() StatementType { return TypeTCL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Listen) StatementTag() string { return "LISTEN" }
+
+// StatementReturnType implements the Statement interface.
+func (*Notify) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*Notify) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Notify) StatementTag() string { return "NOTIFY" }
+
 // StatementReturnType implements the Statement interface.
 func (*ParenSelect) StatementReturnType() StatementReturnType { return Rows }
 
@@ -1533,6 +1659,15 @@ func (*SetTransaction) StatementType() StatementType { return TypeTCL }
 // StatementTag returns a short string identifying the type of statement.
 func (*SetTransaction) StatementTag() string { return "SET TRANSACTION" }
 
+// StatementReturnType implements the Statement interface.
+func (*SetConstraints) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*SetConstraints) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*SetConstraints) StatementTag() string { return "SET CONSTRAINTS" }
+
 // StatementReturnType implements the Statement interface.
 func (*SetTracing) StatementReturnType() StatementReturnType { return Ack }
 
@@ -1781,6 +1916,17 @@ func (*ShowChangefeedJobs) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*ShowChangefeedJobs) StatementTag() string { return "SHOW CHANGEFEED JOBS" }
 
+// StatementReturnType implements the Statement interface.
+func (*ShowChangefeedDLQ) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ShowChangefeedDLQ) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowChangefeedDLQ) StatementTag() string { return "SHOW CHANGEFEED DLQ" }
+
+func (*ShowChangefeedDLQ) cclOnlyStatement() {}
+
 // StatementReturnType implements the Statement interface.
 func (*ShowRoleGrants) StatementReturnType() StatementReturnType { return Rows }
 
@@ -2185,6 +2331,15 @@ func (*ValuesClause) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*ValuesClause) StatementTag() string { return "VALUES" }
 
+// StatementReturnType implements the Statement interface.
+func (*CreateAggregate) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateAggregate) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateAggregate) StatementTag() string { return "CREATE AGGREGATE" }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateRoutine) StatementReturnType() StatementReturnType { return DDL }
 
@@ -2219,6 +2374,9 @@ func (n *DropRoutine) StatementTag() string {
 	if n.Procedure {
 		return DropProcedureTag
 	}
+	if n.Aggregate {
+		return "DROP AGGREGATE"
+	}
 	return DropFunctionTag
 }
 
@@ -2263,6 +2421,8 @@ func (*AlterRoutineRename) StatementType() StatementType { return TypeDDL }
 func (n *AlterRoutineRename) StatementTag() string {
 	if n.Procedure {
 		return "ALTER PROCEDURE"
+	} else if n.Aggregate {
+		return "ALTER AGGREGATE"
 	} else {
 		return "ALTER FUNCTION"
 	}
@@ -2278,6 +2438,8 @@ func (*AlterRoutineSetSchema) StatementType() StatementType { return TypeDDL }
 func (n *AlterRoutineSetSchema) StatementTag() string {
 	if n.Procedure {
 		return "ALTER PROCEDURE"
+	} else if n.Aggregate {
+		return "ALTER AGGREGATE"
 	} else {
 		return "ALTER FUNCTION"
 	}
@@ -2293,6 +2455,8 @@ func (*AlterRoutineSetOwner) StatementType() StatementType { return TypeDDL }
 func (n *AlterRoutineSetOwner) StatementTag() string {
 	if n.Procedure {
 		return "ALTER PROCEDURE"
+	} else if n.Aggregate {
+		return "ALTER AGGREGATE"
 	} else {
 		return "ALTER FUNCTION"
 	}
@@ -2327,6 +2491,7 @@ func (n *AlterDatabaseSecondaryRegion) String() string        { return AsString(
 func (n *AlterDatabaseDropSecondaryRegion) String() string    { return AsString(n) }
 func (n *AlterDatabaseSetZoneConfigExtension) String() string { return AsString(n) }
 func (n *AlterDefaultPrivileges) String() string              { return AsString(n) }
+func (n *AlterDomain) String() string                         { return AsString(n) }
 func (n *AlterFunctionOptions) String() string                { return AsString(n) }
 func (n *AlterRoutineRename) String() string                  { return AsString(n) }
 func (n *AlterRoutineSetSchema) String() string               { return AsString(n) }
@@ -2356,7 +2521,9 @@ func (n *AlterTenantReset) String() string                    { return AsString(
 func (n *AlterTenantRename) String() string                   { return AsString(n) }
 func (n *AlterTenantReplication) String() string              { return AsString(n) }
 func (n *AlterTenantService) String() string                  { return AsString(n) }
+func (n *AlterTextSearch) String() string                     { return AsString(n) }
 func (n *AlterType) String() string                           { return AsString(n) }
+func (n *AlterPublication) String() string                    { return AsString(n) }
 func (n *AlterRole) String() string                           { return AsString(n) }
 func (n *AlterRoleSet) String() string                        { return AsString(n) }
 func (n *AlterSequence) String() string                       { return AsString(n) }
@@ -2389,7 +2556,9 @@ func (n *CreateRoutine) String() string                       { return AsString(
 func (n *CreateTrigger) String() string                       { return AsString(n) }
 func (n *CreateIndex) String() string                         { return AsString(n) }
 func (n *CreateLogicalReplicationStream) String() string      { return AsString(n) }
+func (n *CreatePublication) String() string                   { return AsString(n) }
 func (n *CreateRole) String() string                          { return AsString(n) }
+func (n *CreateTextSearch) String() string                    { return AsString(n) }
 func (n *CreateTable) String() string                         { return AsString(n) }
 func (n *CreateTenant) String() string                        { return AsString(n) }
 func (n *CreateTenantFromReplication) String() string         { return AsString(n) }
@@ -2401,6 +2570,7 @@ func (n *Deallocate) String() string                          { return AsString(
 func (n *Delete) String() string                              { return AsString(n) }
 func (n *DeclareCursor) String() string                       { return AsString(n) }
 func (n *DropDatabase) String() string                        { return AsString(n) }
+func (n *DropDomain) String() string                          { return AsString(n) }
 func (n *DropRoutine) String() string                         { return AsString(n) }
 func (n *DropTrigger) String() string                         { return AsString(n) }
 func (n *DropIndex) String() string                           { return AsString(n) }
@@ -2408,8 +2578,10 @@ func (n *DropOwnedBy) String() string                         { return AsString(
 func (n *DropSchema) String() string                          { return AsString(n) }
 func (n *DropSequence) String() string                        { return AsString(n) }
 func (n *DropTable) String() string                           { return AsString(n) }
+func (n *DropTextSearch) String() string                      { return AsString(n) }
 func (n *DropType) String() string                            { return AsString(n) }
 func (n *DropView) String() string                            { return AsString(n) }
+func (n *DropPublication) String() string                     { return AsString(n) }
 func (n *DropRole) String() string                            { return AsString(n) }
 func (n *DropTenant) String() string                          { return AsString(n) }
 func (n *Execute) String() string                             { return AsString(n) }
@@ -2452,6 +2624,7 @@ func (n *SelectClause) String() string                        { return AsString(
 func (n *SetClusterSetting) String() string                   { return AsString(n) }
 func (n *SetZoneConfig) String() string                       { return AsString(n) }
 func (n *SetSessionAuthorizationDefault) String() string      { return AsString(n) }
+func (n *SetConstraints) String() string                      { return AsString(n) }
 func (n *SetSessionCharacteristics) String() string           { return AsString(n) }
 func (n *SetTransaction) String() string                      { return AsString(n) }
 func (n *SetTracing) String() string                          { return AsString(n) }
@@ -2482,6 +2655,7 @@ func (n *ShowSchedules) String() string                       { return AsString(
 func (n *ShowIndexes) String() string                         { return AsString(n) }
 func (n *ShowJobs) String() string                            { return AsString(n) }
 func (n *ShowChangefeedJobs) String() string                  { return AsString(n) }
+func (n *ShowChangefeedDLQ) String() string                   { return AsString(n) }
 func (n *ShowLastQueryStatistics) String() string             { return AsString(n) }
 func (n *ShowPartitions) String() string                      { return AsString(n) }
 func (n *ShowQueries) String() string                         { return AsString(n) }
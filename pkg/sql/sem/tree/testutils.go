@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // presetTypesForTesting is a mapping of qualified names to types that can be mocked out
@@ -69,17 +70,58 @@ func SampleDatum(t *types.T) Datum {
 		u, _ := ParseDUuidFromString("3189ad07-52f2-4d60-83e8-4a8347fef718")
 		return u
 	case types.INetFamily:
+		if t.Oid() == oid.T_cidr {
+			c, _ := ParseDCIDR("192.168.0.0/16")
+			return c
+		}
 		i, _ := ParseDIPAddrFromINetString("127.0.0.1")
 		return i
 	case types.JsonFamily:
 		j, _ := ParseDJSON(`{"a": "b"}`)
 		return j
+	case types.JsonpathFamily:
+		p, _ := ParseDJsonpath(`$.a ? (@ > 1)`)
+		return p
 	case types.OidFamily:
 		return NewDOidWithType(1009, t)
+	case types.MACAddrFamily:
+		m, _ := ParseDMACAddr("08:00:2b:01:02:03")
+		return m
+	case types.MACAddr8Family:
+		m, _ := ParseDMACAddr8("08:00:2b:01:02:03:04:05")
+		return m
+	case types.MoneyFamily:
+		return NewDMoney(123456)
 	case types.PGLSNFamily:
 		return NewDPGLSN(0x1000000100)
 	case types.RefCursorFamily:
 		return NewDRefCursor("Wheezer")
+	case types.RangeFamily:
+		return NewDRange(t, SampleDatum(t.RangeContents()), nil /* upper */, true /* lowerInc */, false /* upperInc */)
+	case types.MultirangeFamily:
+		r := SampleDatum(t.MultirangeContents()).(*DRange)
+		return NewDMultirange(t, []*DRange{r})
+	case types.PointFamily:
+		p, _ := ParseDPoint("(1,2)")
+		return p
+	case types.BoxFamily:
+		b, _ := ParseDBox("(3,4),(1,2)")
+		return b
+	case types.LSegFamily:
+		l, _ := ParseDLSeg("[(1,2),(3,4)]")
+		return l
+	case types.LineFamily:
+		l, _ := ParseDLine("{1,-1,1}")
+		return l
+	case types.PathFamily:
+		p, _ := ParseDPath("[(1,2),(3,4),(5,6)]")
+		return p
+	case types.PolygonFamily:
+		p, _ := ParseDPolygon("((0,0),(0,1),(1,1))")
+		return p
+	case types.CircleFamily:
+		c, _ := ParseDCircle("<(1,2),3>")
+		return c
 	case types.Box2DFamily:
 		b := geo.NewCartesianBoundingBox().AddPoint(1, 2).AddPoint(3, 4)
 		return NewDBox2D(*b)
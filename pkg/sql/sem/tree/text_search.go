@@ -0,0 +1,258 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// TextSearchObjectKind is the kind of object in a TEXT SEARCH statement.
+type TextSearchObjectKind int
+
+// The kinds of text search objects that can be created. Postgres also has
+// text search parsers and templates, which can only be created in C.
+const (
+	TextSearchConfiguration TextSearchObjectKind = iota
+	TextSearchDictionary
+)
+
+// String implements the fmt.Stringer interface.
+func (k TextSearchObjectKind) String() string {
+	if k == TextSearchConfiguration {
+		return "CONFIGURATION"
+	}
+	return "DICTIONARY"
+}
+
+// TextSearchOption is an option of a text search configuration or dictionary,
+// such as TEMPLATE = snowball or Language = english.
+type TextSearchOption struct {
+	Name  Name
+	Value string
+}
+
+// TextSearchOptions is a list of text search options.
+type TextSearchOptions []TextSearchOption
+
+// Format implements the NodeFormatter interface.
+func (node *TextSearchOptions) Format(ctx *FmtCtx) {
+	ctx.WriteByte('(')
+	for i := range *node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		o := &(*node)[i]
+		// Option names never contain sensitive information, so they are not
+		// anonymized.
+		ctx.WithFlags(ctx.flags&^FmtAnonymize&^FmtMarkRedactionNode, func() {
+			ctx.FormatNode(&o.Name)
+		})
+		ctx.WriteString(" = ")
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, o.Value, ctx.flags.EncodeFlags())
+	}
+	ctx.WriteByte(')')
+}
+
+// CreateTextSearch represents a CREATE TEXT SEARCH CONFIGURATION or
+// CREATE TEXT SEARCH DICTIONARY statement.
+type CreateTextSearch struct {
+	Kind    TextSearchObjectKind
+	Name    *UnresolvedObjectName
+	Options TextSearchOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTextSearch) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TEXT SEARCH ")
+	ctx.WriteString(node.Kind.String())
+	ctx.WriteByte(' ')
+	ctx.FormatNode(node.Name)
+	ctx.WriteByte(' ')
+	ctx.FormatNode(&node.Options)
+}
+
+// AlterTextSearch represents an ALTER TEXT SEARCH CONFIGURATION or
+// ALTER TEXT SEARCH DICTIONARY statement.
+type AlterTextSearch struct {
+	Kind TextSearchObjectKind
+	Name *UnresolvedObjectName
+	Cmd  AlterTextSearchCmd
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearch) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER TEXT SEARCH ")
+	ctx.WriteString(node.Kind.String())
+	ctx.WriteByte(' ')
+	ctx.FormatNode(node.Name)
+	ctx.FormatNode(node.Cmd)
+}
+
+// AlterTextSearchCmd represents a text search object modification operation.
+type AlterTextSearchCmd interface {
+	NodeFormatter
+	alterTextSearchCmd()
+	// TelemetryName returns the counter name to use for telemetry purposes.
+	TelemetryName() string
+}
+
+func (*AlterTextSearchSetOptions) alterTextSearchCmd()        {}
+func (*AlterTextSearchRename) alterTextSearchCmd()            {}
+func (*AlterTextSearchAddMapping) alterTextSearchCmd()        {}
+func (*AlterTextSearchReplaceDictionary) alterTextSearchCmd() {}
+func (*AlterTextSearchDropMapping) alterTextSearchCmd()       {}
+
+var _ AlterTextSearchCmd = &AlterTextSearchSetOptions{}
+var _ AlterTextSearchCmd = &AlterTextSearchRename{}
+var _ AlterTextSearchCmd = &AlterTextSearchAddMapping{}
+var _ AlterTextSearchCmd = &AlterTextSearchReplaceDictionary{}
+var _ AlterTextSearchCmd = &AlterTextSearchDropMapping{}
+
+// AlterTextSearchSetOptions represents an ALTER TEXT SEARCH DICTIONARY (...)
+// command, which changes the options of a dictionary.
+type AlterTextSearchSetOptions struct {
+	Options TextSearchOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearchSetOptions) Format(ctx *FmtCtx) {
+	ctx.WriteByte(' ')
+	ctx.FormatNode(&node.Options)
+}
+
+// TelemetryName implements the AlterTextSearchCmd interface.
+func (node *AlterTextSearchSetOptions) TelemetryName() string {
+	return "set_options"
+}
+
+// AlterTextSearchRename represents an ALTER TEXT SEARCH ... RENAME TO
+// command.
+type AlterTextSearchRename struct {
+	NewName Name
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearchRename) Format(ctx *FmtCtx) {
+	ctx.WriteString(" RENAME TO ")
+	ctx.FormatNode(&node.NewName)
+}
+
+// TelemetryName implements the AlterTextSearchCmd interface.
+func (node *AlterTextSearchRename) TelemetryName() string {
+	return "rename"
+}
+
+// AlterTextSearchAddMapping represents an ALTER TEXT SEARCH CONFIGURATION
+// ADD MAPPING or ALTER MAPPING ... WITH command.
+type AlterTextSearchAddMapping struct {
+	// Alter is true for ALTER MAPPING, which replaces existing mappings. ADD
+	// MAPPING fails if a mapping already exists for one of the token types.
+	Alter        bool
+	TokenTypes   NameList
+	Dictionaries []*UnresolvedObjectName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearchAddMapping) Format(ctx *FmtCtx) {
+	if node.Alter {
+		ctx.WriteString(" ALTER MAPPING FOR ")
+	} else {
+		ctx.WriteString(" ADD MAPPING FOR ")
+	}
+	ctx.FormatNode(&node.TokenTypes)
+	ctx.WriteString(" WITH ")
+	formatTextSearchObjectNames(ctx, node.Dictionaries)
+}
+
+// TelemetryName implements the AlterTextSearchCmd interface.
+func (node *AlterTextSearchAddMapping) TelemetryName() string {
+	if node.Alter {
+		return "alter_mapping"
+	}
+	return "add_mapping"
+}
+
+// AlterTextSearchReplaceDictionary represents an ALTER TEXT SEARCH
+// CONFIGURATION ALTER MAPPING ... REPLACE command.
+type AlterTextSearchReplaceDictionary struct {
+	// TokenTypes is empty if the dictionary is replaced in the mappings of all
+	// token types.
+	TokenTypes NameList
+	Old        *UnresolvedObjectName
+	New        *UnresolvedObjectName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearchReplaceDictionary) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ALTER MAPPING ")
+	if len(node.TokenTypes) > 0 {
+		ctx.WriteString("FOR ")
+		ctx.FormatNode(&node.TokenTypes)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("REPLACE ")
+	ctx.FormatNode(node.Old)
+	ctx.WriteString(" WITH ")
+	ctx.FormatNode(node.New)
+}
+
+// TelemetryName implements the AlterTextSearchCmd interface.
+func (node *AlterTextSearchReplaceDictionary) TelemetryName() string {
+	return "replace_dictionary"
+}
+
+// AlterTextSearchDropMapping represents an ALTER TEXT SEARCH CONFIGURATION
+// DROP MAPPING command.
+type AlterTextSearchDropMapping struct {
+	IfExists   bool
+	TokenTypes NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearchDropMapping) Format(ctx *FmtCtx) {
+	ctx.WriteString(" DROP MAPPING ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.WriteString("FOR ")
+	ctx.FormatNode(&node.TokenTypes)
+}
+
+// TelemetryName implements the AlterTextSearchCmd interface.
+func (node *AlterTextSearchDropMapping) TelemetryName() string {
+	return "drop_mapping"
+}
+
+func formatTextSearchObjectNames(ctx *FmtCtx, names []*UnresolvedObjectName) {
+	for i := range names {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(names[i])
+	}
+}
+
+// DropTextSearch represents a DROP TEXT SEARCH CONFIGURATION or
+// DROP TEXT SEARCH DICTIONARY statement.
+type DropTextSearch struct {
+	Kind         TextSearchObjectKind
+	Names        []*UnresolvedObjectName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTextSearch) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TEXT SEARCH ")
+	ctx.WriteString(node.Kind.String())
+	ctx.WriteByte(' ')
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	formatTextSearchObjectNames(ctx, node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
@@ -181,6 +181,26 @@ func (expr *CoalesceExpr) Walk(v Visitor) Expr {
 	return ret
 }
 
+// Walk implements the Expr interface.
+func (expr *GroupingOperation) Walk(v Visitor) Expr {
+	if exprs, changed := walkExprSlice(v, expr.Exprs); changed {
+		exprCopy := *expr
+		exprCopy.Exprs = exprs
+		return &exprCopy
+	}
+	return expr
+}
+
+// Walk implements the Expr interface.
+func (expr *GroupingSet) Walk(v Visitor) Expr {
+	if exprs, changed := walkExprSlice(v, expr.Exprs); changed {
+		exprCopy := *expr
+		exprCopy.Exprs = exprs
+		return &exprCopy
+	}
+	return expr
+}
+
 // Walk implements the Expr interface.
 func (expr *ComparisonExpr) Walk(v Visitor) Expr {
 	left, changedL := WalkExpr(v, expr.Left)
@@ -757,9 +777,24 @@ func (expr *DBox2D) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr *DPGLSN) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DMACAddr) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DMACAddr8) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DMoney) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DPGVector) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DRange) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DMultirange) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DGeography) Walk(_ Visitor) Expr { return expr }
 
@@ -769,6 +804,30 @@ func (expr *DGeometry) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr *DJSON) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DJsonpath) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DPoint) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DBox) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DLSeg) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DLine) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DPath) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DPolygon) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DCircle) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DTSQuery) Walk(_ Visitor) Expr { return expr }
 
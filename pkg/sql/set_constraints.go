@@ -0,0 +1,112 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// SetConstraints sets whether deferrable constraints are checked at the end
+// of each statement or when the transaction commits. Constraints which become
+// IMMEDIATE are validated right away if their checks were deferred earlier in
+// the transaction.
+// See https://www.postgresql.org/docs/current/sql-set-constraints.html.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	state := p.extendedEvalCtx.deferredConstraints
+	if state == nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"SET CONSTRAINTS is not supported in this session")
+	}
+	if p.extendedEvalCtx.TxnImplicit {
+		// This no-ops in postgres with a warning, so copy accordingly.
+		p.BufferClientNotice(
+			ctx,
+			pgnotice.NewWithSeverityf(
+				"WARNING",
+				"SET CONSTRAINTS can only be used in transaction blocks",
+			),
+		)
+		return newZeroNode(nil /* columns */), nil
+	}
+
+	if len(n.Names) == 0 {
+		state.setAll(n.Deferred)
+	} else {
+		keys, err := p.resolveDeferrableConstraints(ctx, n.Names)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			state.set(key, n.Deferred)
+		}
+	}
+
+	if !n.Deferred {
+		if err := p.validateDeferredConstraints(ctx, state.takeImmediate()); err != nil {
+			return nil, err
+		}
+	}
+	return newZeroNode(nil /* columns */), nil
+}
+
+// resolveDeferrableConstraints finds the constraints with the given names on
+// the tables of the current database. It returns an error if a name does not
+// match any constraint, or if it matches a constraint which is not
+// deferrable.
+func (p *planner) resolveDeferrableConstraints(
+	ctx context.Context, names tree.NameList,
+) ([]deferredConstraintKey, error) {
+	db, err := p.Descriptors().ByNameWithLeased(p.Txn()).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	inDB, err := p.Descriptors().GetAllTablesInDatabase(ctx, p.Txn(), db)
+	if err != nil {
+		return nil, err
+	}
+	found := make([]bool, len(names))
+	var keys []deferredConstraintKey
+	if err := inDB.ForEachDescriptor(func(desc catalog.Descriptor) error {
+		tbl, err := catalog.AsTableDescriptor(desc)
+		if err != nil {
+			return err
+		}
+		if tbl.Dropped() {
+			return nil
+		}
+		for i, name := range names {
+			c := catalog.FindConstraintByName(tbl, string(name))
+			if c == nil {
+				continue
+			}
+			if !c.IsDeferrable() {
+				return pgerror.Newf(pgcode.WrongObjectType,
+					"constraint %q is not deferrable", tree.ErrString(&names[i]))
+			}
+			found[i] = true
+			keys = append(keys, deferredConstraintKey{
+				tableID:      tbl.GetID(),
+				constraintID: c.GetConstraintID(),
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for i := range names {
+		if !found[i] {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q does not exist", tree.ErrString(&names[i]))
+		}
+	}
+	return keys, nil
+}
@@ -142,6 +142,11 @@ func ShowCreateTable(
 	for _, idx := range desc.PublicNonPrimaryIndexes() {
 		// Showing the primary index is handled above.
 
+		// Vector indexes are shown after the CREATE TABLE statement below.
+		if idx.IsVector() {
+			continue
+		}
+
 		// Build the PARTITION BY clause.
 		var partitionBuf bytes.Buffer
 		if err := ShowCreatePartitioning(
@@ -193,6 +198,31 @@ func ShowCreateTable(
 		return "", err
 	}
 
+	// Vector indexes cannot be defined in a CREATE TABLE statement, so they are
+	// shown as separate CREATE INDEX statements.
+	for _, idx := range desc.PublicNonPrimaryIndexes() {
+		if !idx.IsVector() {
+			continue
+		}
+		idxStr, err := catformat.IndexForDisplay(
+			ctx,
+			desc,
+			tn,
+			idx,
+			"", /* partition */
+			fmtFlags,
+			p.EvalContext(),
+			p.SemaCtx(),
+			p.SessionData(),
+			catformat.IndexDisplayShowCreate,
+		)
+		if err != nil {
+			return "", err
+		}
+		f.WriteString(";\n")
+		f.WriteString(idxStr)
+	}
+
 	if !displayOptions.IgnoreComments {
 		if err := showComments(tn, desc, selectComment(ctx, p, desc.GetID()), &f.Buffer); err != nil {
 			return "", err
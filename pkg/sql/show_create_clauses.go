@@ -588,12 +588,25 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(tree.ForeignKeyReferenceActionType[fk.OnUpdate].String())
 	}
+	showConstraintDeferrability(buf, fk.Deferrability)
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
 	return nil
 }
 
+// showConstraintDeferrability writes the DEFERRABLE clause for a constraint
+// with the given deferrability. Nothing is written for constraints which are
+// not deferrable, since that is the default.
+func showConstraintDeferrability(buf *bytes.Buffer, d descpb.ConstraintDeferrability) {
+	switch d {
+	case descpb.ConstraintDeferrability_InitiallyImmediate:
+		buf.WriteString(" DEFERRABLE")
+	case descpb.ConstraintDeferrability_InitiallyDeferred:
+		buf.WriteString(" DEFERRABLE INITIALLY DEFERRED")
+	}
+}
+
 // ShowCreateSequence returns a valid SQL representation of the
 // CREATE SEQUENCE statement used to create the given sequence.
 func ShowCreateSequence(
@@ -839,6 +852,7 @@ func showConstraintClause(
 		}
 		f.WriteString(expr)
 		f.WriteString(")")
+		showConstraintDeferrability(&f.Buffer, e.GetDeferrability())
 		if !e.IsConstraintValidated() {
 			f.WriteString(" NOT VALID")
 		}
@@ -850,6 +864,14 @@ func showConstraintClause(
 			formatQuoteNames(&f.Buffer, c.GetName())
 			f.WriteString(" ")
 		}
+		if c.IsExclusion() {
+			if err := showExclusionConstraint(
+				ctx, f, desc, c, evalCtx, semaCtx, sessionData, exprFmtFlags,
+			); err != nil {
+				return err
+			}
+			continue
+		}
 		f.WriteString("UNIQUE WITHOUT INDEX (")
 		colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
 		if err != nil {
@@ -857,6 +879,7 @@ func showConstraintClause(
 		}
 		f.WriteString(strings.Join(colNames, ", "))
 		f.WriteString(")")
+		showConstraintDeferrability(&f.Buffer, c.GetDeferrability())
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(
@@ -874,3 +897,44 @@ func showConstraintClause(
 	f.WriteString("\n)")
 	return nil
 }
+
+// showExclusionConstraint adds the definition of an exclusion constraint, not
+// including its name, to f.
+func showExclusionConstraint(
+	ctx context.Context,
+	f *tree.FmtCtx,
+	desc catalog.TableDescriptor,
+	c catalog.UniqueWithoutIndexConstraint,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	sessionData *sessiondata.SessionData,
+	exprFmtFlags tree.FmtFlags,
+) error {
+	colNames, err := catalog.ColumnNamesForIDs(desc, c.UniqueWithoutIndexDesc().ColumnIDs)
+	if err != nil {
+		return err
+	}
+	f.WriteString("EXCLUDE USING GIST (")
+	for i, op := range c.ExclusionOperators() {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		formatQuoteNames(&f.Buffer, colNames[i])
+		f.WriteString(" WITH ")
+		f.WriteString(op)
+	}
+	f.WriteString(")")
+	if c.IsPartial() {
+		pred, err := schemaexpr.FormatExprForDisplay(
+			ctx, desc, c.GetPredicate(), evalCtx, semaCtx, sessionData, exprFmtFlags,
+		)
+		if err != nil {
+			return err
+		}
+		f.WriteString(" WHERE (")
+		f.WriteString(pred)
+		f.WriteString(")")
+	}
+	showConstraintDeferrability(&f.Buffer, c.GetDeferrability())
+	return nil
+}
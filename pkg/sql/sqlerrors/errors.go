@@ -233,6 +233,13 @@ func NewUnsupportedUnvalidatedConstraintError(constraintType catconstants.Constr
 		"%v constraints cannot be marked NOT VALID", constraintType)
 }
 
+// NewDeferrableConstraintsNotSupportedError creates an error for a deferrable
+// constraint added before the cluster was upgraded to support them.
+func NewDeferrableConstraintsNotSupportedError() error {
+	return pgerror.New(pgcode.FeatureNotSupported,
+		"deferrable constraints are not supported until the upgrade to version 24.3 is finalized")
+}
+
 // NewInvalidActionOnComputedFKColumnError creates an error when there is an
 // attempt to have an unsupported action on a FK over a computed column.
 func NewInvalidActionOnComputedFKColumnError(onUpdateAction bool) error {
@@ -50,6 +50,10 @@ var (
 	// indexes.
 	InvertedIndexCounter = telemetry.GetCounterOnce("sql.schema.inverted_index")
 
+	// VectorIndexCounter is to be incremented every time a vector index is
+	// created.
+	VectorIndexCounter = telemetry.GetCounterOnce("sql.schema.vector_index")
+
 	// MultiColumnInvertedIndexCounter is to be incremented every time a
 	// multi-column inverted index is created.
 	MultiColumnInvertedIndexCounter = telemetry.GetCounterOnce("sql.schema.multi_column_inverted_index")
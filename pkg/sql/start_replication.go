@@ -0,0 +1,188 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/walsender"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxlog"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/fsm"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+)
+
+// execStartReplication runs a START_REPLICATION command, which streams the
+// changes to the tables published by the publications requested by the client
+// until the client ends the stream or the query is canceled.
+//
+// The stream is not part of a transaction, so the command can only run while
+// there is no open transaction.
+func (ex *connExecutor) execStartReplication(
+	ctx context.Context, cmd StartReplication, res StartReplicationResult,
+) (fsm.Event, fsm.EventPayload) {
+	defer close(cmd.Done)
+
+	if _, isNoTxn := ex.machine.CurState().(stateNoTxn); !isNoTxn {
+		return ex.makeErrEvent(pgerror.New(pgcode.ActiveSQLTransaction,
+			"START_REPLICATION cannot be executed inside a transaction block"), cmd.Stmt)
+	}
+
+	ex.incrementStartedStmtCounter(cmd.Stmt)
+	var cancelQuery context.CancelFunc
+	ctx, cancelQuery = ctxlog.WithCancel(ctx)
+	queryID := ex.server.cfg.GenerateID()
+	ex.addActiveQuery(cmd.ParsedStmt, nil /* placeholders */, queryID, cancelQuery)
+	ex.metrics.EngineMetrics.SQLActiveStatements.Inc(1)
+	defer func() {
+		ex.removeActiveQuery(queryID, cmd.Stmt)
+		cancelQuery()
+		ex.metrics.EngineMetrics.SQLActiveStatements.Dec(1)
+	}()
+
+	if err := ex.runStartReplication(ctx, cmd, res); err != nil {
+		return eventNonRetriableErr{IsCommit: fsm.False}, eventNonRetriableErrPayload{err: err}
+	}
+	ex.incrementExecutedStmtCounter(cmd.Stmt)
+	return nil, nil
+}
+
+// runStartReplication validates a START_REPLICATION command and runs the
+// stream.
+func (ex *connExecutor) runStartReplication(
+	ctx context.Context, cmd StartReplication, res StartReplicationResult,
+) error {
+	cfg := ex.server.cfg
+	if err := checkReplicationSlotsSupported(ctx, cfg.Settings); err != nil {
+		return err
+	}
+	if cmd.Stmt.Kind != pgrepltree.LogicalReplication {
+		return unimplemented.New("physical replication",
+			"physical replication is not supported")
+	}
+	if cmd.Stmt.Slot == "" {
+		return pgerror.New(pgcode.Syntax, "logical replication requires a replication slot")
+	}
+	if err := checkPublicationsSupported(ctx, cfg.Settings); err != nil {
+		return err
+	}
+	publications, err := validateStartReplicationOptions(cmd.Stmt.Options)
+	if err != nil {
+		return err
+	}
+
+	slotName := string(cmd.Stmt.Slot)
+	var slot replicationSlot
+	if err := cfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) (err error) {
+		slot, err = getReplicationSlot(ctx, txn, slotName)
+		if err != nil {
+			return err
+		}
+		db, err := txn.Descriptors().ByIDWithLeased(txn.KV()).Get().Database(ctx, slot.databaseID)
+		if err != nil {
+			return err
+		}
+		if db.GetName() != ex.sessionData().Database {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"replication slot %q was not created in this database", slotName)
+		}
+		// The publications may not exist yet at the start of the stream, but
+		// they must exist when it is requested.
+		for _, name := range publications {
+			pub, err := getPublication(ctx, txn, slot.databaseID, name)
+			if err != nil {
+				return err
+			}
+			if pub == nil {
+				return newUndefinedPublicationError(name)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	publicationsID, err := cfg.SystemTableIDResolver.LookupSystemTableID(
+		ctx, string(catconstants.PublicationsTableName),
+	)
+	if err != nil {
+		return err
+	}
+
+	// The stream resumes from the position confirmed by the client, unless the
+	// client asks for a later one.
+	start := slot.confirmedFlushLSN
+	if cmd.Stmt.LSN > start {
+		start = cmd.Stmt.LSN
+	}
+
+	if err := res.SendCopyBothResponse(ctx); err != nil {
+		return err
+	}
+	if err := res.FlushCopyData(ctx); err != nil {
+		return err
+	}
+
+	sd := ex.sessionData()
+	fmtCtx := tree.NewFmtCtx(
+		tree.FmtPgwireText,
+		tree.FmtDataConversionConfig(sd.DataConversionConfig),
+		tree.FmtLocation(sd.GetLocation()),
+	)
+	defer fmtCtx.Close()
+	if err := walsender.Run(ctx, walsender.Config{
+		Codec:            cfg.Codec,
+		Clock:            cfg.Clock,
+		RangeFeedFactory: cfg.RangeFeedFactory,
+		LeaseManager:     cfg.LeaseManager,
+		DB:               cfg.InternalDB,
+		Settings:         cfg.Settings,
+	}, replicationSink{res: res}, walsender.Options{
+		TablesAt: func(ctx context.Context, ts hlc.Timestamp) ([]descpb.ID, error) {
+			return publishedTablesAt(ctx, cfg.InternalDB, slot.databaseID, publications, ts)
+		},
+		CatalogSpans: []roachpb.Span{
+			cfg.Codec.TableSpan(keys.DescriptorTableID),
+			cfg.Codec.TableSpan(uint32(publicationsID)),
+		},
+		Start:          start,
+		ClientMessages: cmd.ClientMessages,
+		OnFlush: func(ctx context.Context, flushed lsn.LSN) error {
+			return advanceReplicationSlot(ctx, cfg.InternalDB, slotName, flushed)
+		},
+		FmtCtx: fmtCtx,
+	}); err != nil {
+		return err
+	}
+	return res.SendCopyDone(ctx)
+}
+
+// replicationSink sends the messages of a replication stream as CopyData
+// messages.
+type replicationSink struct {
+	res StartReplicationResult
+}
+
+var _ walsender.Sink = replicationSink{}
+
+// Send implements the walsender.Sink interface.
+func (s replicationSink) Send(ctx context.Context, data []byte) error {
+	return s.res.SendCopyData(ctx, data, false /* isHeader */)
+}
+
+// Flush implements the walsender.Sink interface.
+func (s replicationSink) Flush(ctx context.Context) error {
+	return s.res.FlushCopyData(ctx)
+}
@@ -125,6 +125,16 @@ func (po *Setter) Set(
 	// indexes.
 	case `bucket_count`:
 		return nil
+	// `partitions` is handled in schema changer when creating vector indexes.
+	case `partitions`:
+		return nil
+	case `term_statistics`:
+		val, err := paramparse.DatumAsBool(ctx, evalCtx, key, expr)
+		if err != nil {
+			return err
+		}
+		po.IndexDesc.TermStatistics = val
+		return nil
 	case `vacuum_cleanup_index_scale_factor`,
 		`buffering`,
 		`fastupdate`,
@@ -155,6 +165,15 @@ func (po *Setter) RunPostChecks() error {
 		}
 	}
 
+	if po.IndexDesc.TermStatistics {
+		if po.IndexDesc.Type != descpb.IndexDescriptor_INVERTED || len(po.IndexDesc.KeyColumnNames) != 1 {
+			return pgerror.New(
+				pgcode.InvalidParameterValue,
+				"term_statistics can only be set on single-column inverted indexes on a TSVECTOR column",
+			)
+		}
+	}
+
 	if cfg := po.IndexDesc.GeoConfig.S2Geometry; cfg != nil {
 		if cfg.MaxX <= cfg.MinX {
 			return pgerror.Newf(
@@ -74,6 +74,10 @@ type tableWriterBase struct {
 	// rows contains the accumulated result rows if rowsNeeded is set on the
 	// corresponding tableWriter.
 	rows *rowcontainer.RowContainer
+	// deferred records the written rows which have to be validated for the
+	// deferred constraints of the table. It is nil if no constraint is
+	// deferred.
+	deferred *deferredConstraintTracker
 	// If set, mutations.MaxBatchSize and row.getKVBatchSize will be overridden
 	// to use the non-test value.
 	forceProductionBatchSizes bool
@@ -167,7 +171,10 @@ func (tb *tableWriterBase) finalize(ctx context.Context) (err error) {
 		// Also, we don't want to try to commit here if the deadline is expired.
 		// If we bubble back up to SQL then maybe we can get a fresh deadline
 		// before committing.
-		!tb.txn.DeadlineLikelySufficient() {
+		!tb.txn.DeadlineLikelySufficient() &&
+		// Deferred constraints have to be validated before the transaction
+		// commits.
+		tb.deferred == nil {
 		log.Event(ctx, "autocommit enabled")
 		log.VEventf(ctx, 2, "writing batch with %d requests and committing", len(tb.b.Requests()))
 		// An auto-txn can commit the transaction with the batch. This is an
@@ -47,6 +47,7 @@ func (td *tableDeleter) row(
 	ctx context.Context, values tree.Datums, pm row.PartialIndexUpdateHelper, traceKV bool,
 ) error {
 	td.currentBatchSize++
+	td.deferred.oldRow(values, nil /* newVals */, td.rd.FetchColIDtoRowIndex)
 	return td.rd.DeleteRow(ctx, td.b, values, pm, nil, traceKV)
 }
 
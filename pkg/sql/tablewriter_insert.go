@@ -42,6 +42,7 @@ func (ti *tableInserter) row(
 	ctx context.Context, values tree.Datums, pm row.PartialIndexUpdateHelper, traceKV bool,
 ) error {
 	ti.currentBatchSize++
+	ti.deferred.newRow(values, ti.ri.InsertColIDtoRowIndex)
 	return ti.ri.InsertRow(ctx, &ti.putter, values, pm, nil, false /* overwrite */, traceKV)
 }
 
@@ -45,7 +45,13 @@ func (tu *tableUpdater) rowForUpdate(
 	traceKV bool,
 ) (tree.Datums, error) {
 	tu.currentBatchSize++
-	return tu.ru.UpdateRow(ctx, tu.b, oldValues, updateValues, pm, nil, traceKV)
+	newValues, err := tu.ru.UpdateRow(ctx, tu.b, oldValues, updateValues, pm, nil, traceKV)
+	if err != nil {
+		return nil, err
+	}
+	tu.deferred.oldRow(oldValues, newValues, tu.ru.FetchColIDtoRowIndex)
+	tu.deferred.newRow(newValues, tu.ru.FetchColIDtoRowIndex)
+	return newValues, nil
 }
 
 // tableDesc returns the TableDescriptor for the table that the tableUpdater
@@ -211,6 +211,10 @@ func (tu *tableUpserter) insertNonConflictingRow(
 	if err := tu.ri.InsertRow(ctx, &tu.putter, insertRow, pm, nil, overwrite, traceKV); err != nil {
 		return err
 	}
+	if overwrite {
+		tu.deferred.unknownRowsOverwritten()
+	}
+	tu.deferred.newRow(insertRow, tu.ri.InsertColIDtoRowIndex)
 
 	if !tu.rowsNeeded {
 		return nil
@@ -259,10 +263,12 @@ func (tu *tableUpserter) updateConflictingRow(
 	// Queue the update in KV. This also returns an "update row"
 	// containing the updated values for every column in the
 	// table. This is useful for RETURNING, which we collect below.
-	_, err := tu.ru.UpdateRow(ctx, b, fetchRow, updateValues, pm, nil, traceKV)
+	newValues, err := tu.ru.UpdateRow(ctx, b, fetchRow, updateValues, pm, nil, traceKV)
 	if err != nil {
 		return err
 	}
+	tu.deferred.oldRow(fetchRow, newValues, tu.ru.FetchColIDtoRowIndex)
+	tu.deferred.newRow(newValues, tu.ru.FetchColIDtoRowIndex)
 
 	// We only need a result row if we're collecting rows.
 	if !tu.rowsNeeded {
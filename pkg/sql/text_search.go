@@ -0,0 +1,792 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/errors"
+)
+
+// Text search configurations and dictionaries are stored in the descriptor of
+// their schema. They don't have owners or privileges of their own: creating,
+// altering or dropping them requires the CREATE privilege on the schema.
+//
+// Configurations and thesaurus dictionaries refer to other dictionaries by
+// schema ID and name. The built-in objects belong to the pg_catalog schema.
+// References must not cross databases.
+
+type createTextSearchNode struct {
+	n *tree.CreateTextSearch
+}
+
+type alterTextSearchNode struct {
+	n *tree.AlterTextSearch
+}
+
+type dropTextSearchNode struct {
+	n *tree.DropTextSearch
+}
+
+// CreateTextSearch creates a text search configuration or dictionary.
+func (p *planner) CreateTextSearch(ctx context.Context, n *tree.CreateTextSearch) (planNode, error) {
+	if err := checkTextSearchSupported(ctx, p, n.StatementTag()); err != nil {
+		return nil, err
+	}
+	return &createTextSearchNode{n: n}, nil
+}
+
+// AlterTextSearch alters a text search configuration or dictionary.
+func (p *planner) AlterTextSearch(ctx context.Context, n *tree.AlterTextSearch) (planNode, error) {
+	if err := checkTextSearchSupported(ctx, p, n.StatementTag()); err != nil {
+		return nil, err
+	}
+	return &alterTextSearchNode{n: n}, nil
+}
+
+// DropTextSearch drops text search configurations or dictionaries.
+func (p *planner) DropTextSearch(ctx context.Context, n *tree.DropTextSearch) (planNode, error) {
+	if err := checkTextSearchSupported(ctx, p, n.StatementTag()); err != nil {
+		return nil, err
+	}
+	return &dropTextSearchNode{n: n}, nil
+}
+
+func checkTextSearchSupported(ctx context.Context, p *planner, stmtTag string) error {
+	if err := checkSchemaChangeEnabled(ctx, p.ExecCfg(), stmtTag); err != nil {
+		return err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_3_TextSearchConfigurations) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported until the upgrade to version 24.3 is finalized", stmtTag)
+	}
+	return nil
+}
+
+// textSearchObjectType returns the name of a kind of text search object, as
+// used in error messages.
+func textSearchObjectType(kind tree.TextSearchObjectKind) string {
+	if kind == tree.TextSearchConfiguration {
+		return "text search configuration"
+	}
+	return "text search dictionary"
+}
+
+// textSearchTelemetryName returns the name of a kind of text search object, as
+// used in telemetry counters.
+func textSearchTelemetryName(kind tree.TextSearchObjectKind) string {
+	if kind == tree.TextSearchConfiguration {
+		return "text_search_configuration"
+	}
+	return "text_search_dictionary"
+}
+
+func newUndefinedTextSearchObjectError(
+	kind tree.TextSearchObjectKind, name *tree.UnresolvedObjectName,
+) error {
+	return pgerror.Newf(pgcode.UndefinedObject,
+		"%s %q does not exist", textSearchObjectType(kind), tree.ErrString(name))
+}
+
+func (n *createTextSearchNode) startExec(params runParams) error {
+	p := params.p
+	kind := n.n.Kind
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter(textSearchTelemetryName(kind)))
+
+	db, sc, _, err := p.ResolveTargetObject(params.ctx, n.n.Name)
+	if err != nil {
+		return err
+	}
+	if sc.SchemaKind() == catalog.SchemaTemporary {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot create a %s in a temporary schema", textSearchObjectType(kind))
+	}
+	if err := p.canCreateOnSchema(
+		params.ctx, sc.GetID(), db.GetID(), p.User(), checkPublicSchema,
+	); err != nil {
+		return err
+	}
+	mutSc, err := p.Descriptors().MutableByID(p.Txn()).Schema(params.ctx, sc.GetID())
+	if err != nil {
+		return err
+	}
+
+	name := n.n.Name.Object()
+	if textSearchObjectExistsInSchema(kind, mutSc, name) {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"%s %q already exists", textSearchObjectType(kind), name)
+	}
+	if kind == tree.TextSearchConfiguration {
+		cfg, err := p.makeTextSearchConfiguration(params.ctx, db, name, n.n.Options)
+		if err != nil {
+			return err
+		}
+		mutSc.SetTextSearchConfiguration(cfg)
+	} else {
+		dict, err := p.makeTextSearchDictionary(params.ctx, db, name, n.n.Options)
+		if err != nil {
+			return err
+		}
+		mutSc.SetTextSearchDictionary(dict)
+	}
+	return p.writeSchemaDescChange(params.ctx, mutSc, tree.AsStringWithFQNames(n.n, params.Ann()))
+}
+
+func (n *createTextSearchNode) ReadingOwnWrites()                   {}
+func (n *createTextSearchNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createTextSearchNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createTextSearchNode) Close(ctx context.Context)           {}
+
+func (n *alterTextSearchNode) startExec(params runParams) error {
+	p := params.p
+	ctx := params.ctx
+	kind := n.n.Kind
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounterWithExtra(
+		textSearchTelemetryName(kind), n.n.Cmd.TelemetryName(),
+	))
+
+	sc, db, found, err := p.lookupMutableTextSearchObject(ctx, kind, n.n.Name, "alter")
+	if err != nil {
+		return err
+	}
+	if !found {
+		return newUndefinedTextSearchObjectError(kind, n.n.Name)
+	}
+	jobDesc := tree.AsStringWithFQNames(n.n, params.Ann())
+	name := n.n.Name.Object()
+
+	if cmd, ok := n.n.Cmd.(*tree.AlterTextSearchRename); ok {
+		return p.renameTextSearchObject(ctx, kind, db, sc, name, string(cmd.NewName), jobDesc)
+	}
+	if kind == tree.TextSearchDictionary {
+		cmd, ok := n.n.Cmd.(*tree.AlterTextSearchSetOptions)
+		if !ok {
+			return errors.AssertionFailedf("unexpected command %T", n.n.Cmd)
+		}
+		dict, _ := sc.GetTextSearchDictionary(name)
+		if err := p.alterTextSearchDictionaryOptions(ctx, db, &dict, cmd.Options); err != nil {
+			return err
+		}
+		sc.SetTextSearchDictionary(dict)
+		return p.writeSchemaDescChange(ctx, sc, jobDesc)
+	}
+
+	cfg, _ := sc.GetTextSearchConfiguration(name)
+	switch cmd := n.n.Cmd.(type) {
+	case *tree.AlterTextSearchAddMapping:
+		tokenTypes, err := resolveTextSearchTokenTypes(cmd.TokenTypes)
+		if err != nil {
+			return err
+		}
+		refs := make([]descpb.SchemaDescriptor_TextSearchDictionaryReference, len(cmd.Dictionaries))
+		for i, dictName := range cmd.Dictionaries {
+			if refs[i], _, err = p.resolveTextSearchDictionaryRef(ctx, db, dictName); err != nil {
+				return err
+			}
+		}
+		for _, t := range tokenTypes {
+			if _, exists := findTextSearchMapping(&cfg, t); exists && !cmd.Alter {
+				return pgerror.Newf(pgcode.DuplicateObject,
+					"mapping for token type %q already exists", t.Alias())
+			}
+			setTextSearchMapping(&cfg, t, refs)
+		}
+
+	case *tree.AlterTextSearchReplaceDictionary:
+		tokenTypes, err := resolveTextSearchTokenTypes(cmd.TokenTypes)
+		if err != nil {
+			return err
+		}
+		oldRef, _, err := p.resolveTextSearchDictionaryRef(ctx, db, cmd.Old)
+		if err != nil {
+			return err
+		}
+		newRef, _, err := p.resolveTextSearchDictionaryRef(ctx, db, cmd.New)
+		if err != nil {
+			return err
+		}
+		if len(tokenTypes) == 0 {
+			for i := range cfg.Mappings {
+				tokenTypes = append(tokenTypes, tsearch.TokenType(cfg.Mappings[i].TokenType))
+			}
+		}
+		for _, t := range tokenTypes {
+			idx, exists := findTextSearchMapping(&cfg, t)
+			if !exists {
+				continue
+			}
+			dicts := append([]descpb.SchemaDescriptor_TextSearchDictionaryReference(nil),
+				cfg.Mappings[idx].Dictionaries...)
+			for i := range dicts {
+				if dicts[i] == oldRef {
+					dicts[i] = newRef
+				}
+			}
+			setTextSearchMapping(&cfg, t, dicts)
+		}
+
+	case *tree.AlterTextSearchDropMapping:
+		tokenTypes, err := resolveTextSearchTokenTypes(cmd.TokenTypes)
+		if err != nil {
+			return err
+		}
+		for _, t := range tokenTypes {
+			if _, exists := findTextSearchMapping(&cfg, t); !exists {
+				if !cmd.IfExists {
+					return pgerror.Newf(pgcode.UndefinedObject,
+						"mapping for token type %q does not exist", t.Alias())
+				}
+				p.BufferClientNotice(ctx, pgnotice.Newf(
+					"mapping for token type %q does not exist, skipping", t.Alias(),
+				))
+				continue
+			}
+			setTextSearchMapping(&cfg, t, nil /* dicts */)
+		}
+
+	default:
+		return errors.AssertionFailedf("unexpected command %T", n.n.Cmd)
+	}
+	sc.SetTextSearchConfiguration(cfg)
+	return p.writeSchemaDescChange(ctx, sc, jobDesc)
+}
+
+func (n *alterTextSearchNode) ReadingOwnWrites()                   {}
+func (n *alterTextSearchNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *alterTextSearchNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *alterTextSearchNode) Close(ctx context.Context)           {}
+
+func (n *dropTextSearchNode) startExec(params runParams) error {
+	p := params.p
+	ctx := params.ctx
+	kind := n.n.Kind
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter(textSearchTelemetryName(kind)))
+
+	jobDesc := tree.AsStringWithFQNames(n.n, params.Ann())
+	for _, name := range n.n.Names {
+		sc, db, found, err := p.lookupMutableTextSearchObject(ctx, kind, name, "drop")
+		if err != nil {
+			return err
+		}
+		if !found {
+			if n.n.IfExists {
+				p.BufferClientNotice(ctx, pgnotice.Newf(
+					"%s %q does not exist, skipping", textSearchObjectType(kind), tree.ErrString(name),
+				))
+				continue
+			}
+			return newUndefinedTextSearchObjectError(kind, name)
+		}
+		if kind == tree.TextSearchConfiguration {
+			sc.RemoveTextSearchConfiguration(name.Object())
+			if err := p.writeSchemaDescChange(ctx, sc, jobDesc); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := p.dropTextSearchDictionary(
+			ctx, db, sc, name.Object(), n.n.DropBehavior, jobDesc,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *dropTextSearchNode) ReadingOwnWrites()                   {}
+func (n *dropTextSearchNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *dropTextSearchNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *dropTextSearchNode) Close(ctx context.Context)           {}
+
+// lookupMutableTextSearchObject returns the mutable descriptor of the schema
+// that contains the given text search object and the descriptor of its
+// database, after checking that the user may perform op on the object.
+func (p *planner) lookupMutableTextSearchObject(
+	ctx context.Context, kind tree.TextSearchObjectKind, name *tree.UnresolvedObjectName, op string,
+) (*schemadesc.Mutable, catalog.DatabaseDescriptor, bool, error) {
+	schemaID, found, err := p.evalCatalogBuiltins.LookupTextSearchObject(ctx, kind, name, p.SessionData())
+	if err != nil || !found {
+		return nil, nil, false, err
+	}
+	if schemaID == catconstants.PgCatalogID {
+		return nil, nil, false, pgerror.Newf(pgcode.DependentObjectsStillExist,
+			"cannot %s %s %q because it is required by the database system",
+			op, textSearchObjectType(kind), name.Object())
+	}
+	sc, err := p.Descriptors().MutableByID(p.Txn()).Schema(ctx, schemaID)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if err := p.canCreateOnSchema(ctx, sc.GetID(), sc.GetParentID(), p.User(), checkPublicSchema); err != nil {
+		return nil, nil, false, err
+	}
+	db, err := p.Descriptors().ByIDWithLeased(p.Txn()).Get().Database(ctx, sc.GetParentID())
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return sc, db, true, nil
+}
+
+func textSearchObjectExistsInSchema(
+	kind tree.TextSearchObjectKind, sc catalog.SchemaDescriptor, name string,
+) bool {
+	if kind == tree.TextSearchConfiguration {
+		_, ok := sc.GetTextSearchConfiguration(name)
+		return ok
+	}
+	_, ok := sc.GetTextSearchDictionary(name)
+	return ok
+}
+
+// makeTextSearchConfiguration builds the descriptor representation of a text
+// search configuration from the options of CREATE TEXT SEARCH CONFIGURATION.
+func (p *planner) makeTextSearchConfiguration(
+	ctx context.Context, db catalog.DatabaseDescriptor, name string, options tree.TextSearchOptions,
+) (descpb.SchemaDescriptor_TextSearchConfiguration, error) {
+	cfg := descpb.SchemaDescriptor_TextSearchConfiguration{Name: name}
+	var parserName, copyName string
+	for _, o := range options {
+		switch strings.ToLower(string(o.Name)) {
+		case "parser":
+			parserName = o.Value
+		case "copy":
+			copyName = o.Value
+		default:
+			return cfg, pgerror.Newf(pgcode.Syntax,
+				"text search configuration parameter %q not recognized", string(o.Name))
+		}
+	}
+	switch {
+	case parserName != "" && copyName != "":
+		return cfg, pgerror.New(pgcode.Syntax, "cannot specify both PARSER and COPY options")
+
+	case parserName != "":
+		// We only support the default parser, whose configurations start without
+		// any mappings.
+		if strings.TrimPrefix(parserName, catconstants.PgCatalogName+".") != "default" {
+			return cfg, pgerror.Newf(pgcode.UndefinedObject,
+				"text search parser %q does not exist", parserName)
+		}
+		return cfg, nil
+
+	case copyName != "":
+		un, err := parser.ParseTableName(copyName)
+		if err != nil {
+			return cfg, err
+		}
+		schemaID, found, err := p.evalCatalogBuiltins.LookupTextSearchObject(
+			ctx, tree.TextSearchConfiguration, un, p.SessionData(),
+		)
+		if err != nil {
+			return cfg, err
+		}
+		if !found {
+			return cfg, newUndefinedTextSearchObjectError(tree.TextSearchConfiguration, un)
+		}
+		if schemaID == catconstants.PgCatalogID {
+			builtin, _ := tsearch.BuiltinConfig(un.Object())
+			for t := tsearch.TokenType(1); t <= tsearch.NumTokenTypes; t++ {
+				var refs []descpb.SchemaDescriptor_TextSearchDictionaryReference
+				for _, d := range builtin.Mapping(t) {
+					refs = append(refs, descpb.SchemaDescriptor_TextSearchDictionaryReference{
+						SchemaID: catconstants.PgCatalogID,
+						Name:     d.Name,
+					})
+				}
+				setTextSearchMapping(&cfg, t, refs)
+			}
+			return cfg, nil
+		}
+		sc, err := p.Descriptors().ByIDWithLeased(p.Txn()).Get().Schema(ctx, schemaID)
+		if err != nil {
+			return cfg, err
+		}
+		if err := checkTextSearchSameDatabase(db, sc, un); err != nil {
+			return cfg, err
+		}
+		src, _ := sc.GetTextSearchConfiguration(un.Object())
+		for _, m := range src.Mappings {
+			setTextSearchMapping(&cfg, tsearch.TokenType(m.TokenType), m.Dictionaries)
+		}
+		return cfg, nil
+
+	default:
+		return cfg, pgerror.New(pgcode.InvalidObjectDefinition, "text search parser is required")
+	}
+}
+
+// makeTextSearchDictionary builds the descriptor representation of a text
+// search dictionary from the options of CREATE TEXT SEARCH DICTIONARY.
+func (p *planner) makeTextSearchDictionary(
+	ctx context.Context, db catalog.DatabaseDescriptor, name string, options tree.TextSearchOptions,
+) (descpb.SchemaDescriptor_TextSearchDictionary, error) {
+	dict := descpb.SchemaDescriptor_TextSearchDictionary{Name: name}
+	for _, o := range options {
+		optName := strings.ToLower(string(o.Name))
+		if optName == "template" {
+			dict.Template = strings.TrimPrefix(o.Value, catconstants.PgCatalogName+".")
+			continue
+		}
+		dict.Options = append(dict.Options, descpb.SchemaDescriptor_TextSearchOption{
+			Name:  optName,
+			Value: o.Value,
+		})
+	}
+	if dict.Template == "" {
+		return dict, pgerror.New(pgcode.InvalidObjectDefinition, "text search template is required")
+	}
+	if err := p.validateTextSearchDictionary(ctx, db, &dict); err != nil {
+		return dict, err
+	}
+	return dict, nil
+}
+
+// alterTextSearchDictionaryOptions applies the options of ALTER TEXT SEARCH
+// DICTIONARY to a dictionary. Options replace the existing options with the
+// same name.
+func (p *planner) alterTextSearchDictionaryOptions(
+	ctx context.Context,
+	db catalog.DatabaseDescriptor,
+	dict *descpb.SchemaDescriptor_TextSearchDictionary,
+	options tree.TextSearchOptions,
+) error {
+	updated := append([]descpb.SchemaDescriptor_TextSearchOption(nil), dict.Options...)
+	for _, o := range options {
+		optName := strings.ToLower(string(o.Name))
+		if optName == "template" {
+			return pgerror.New(pgcode.Syntax, "cannot change template of text search dictionary")
+		}
+		replaced := false
+		for i := range updated {
+			if updated[i].Name == optName {
+				updated[i].Value = o.Value
+				replaced = true
+			}
+		}
+		if !replaced {
+			updated = append(updated, descpb.SchemaDescriptor_TextSearchOption{
+				Name:  optName,
+				Value: o.Value,
+			})
+		}
+	}
+	dict.Options = updated
+	return p.validateTextSearchDictionary(ctx, db, dict)
+}
+
+// validateTextSearchDictionary checks that a dictionary can be built from the
+// given descriptor representation. It resolves the subdictionary of a
+// thesaurus and qualifies its name in the Dictionary option.
+func (p *planner) validateTextSearchDictionary(
+	ctx context.Context,
+	db catalog.DatabaseDescriptor,
+	dict *descpb.SchemaDescriptor_TextSearchDictionary,
+) error {
+	dict.Subdictionary = nil
+	var sub *tsearch.Dictionary
+	for i := range dict.Options {
+		o := &dict.Options[i]
+		if dict.Template != tsearch.TemplateThesaurus || o.Name != "dictionary" {
+			continue
+		}
+		un, err := parser.ParseTableName(o.Value)
+		if err != nil {
+			return err
+		}
+		ref, qualifiedName, err := p.resolveTextSearchDictionaryRef(ctx, db, un)
+		if err != nil {
+			return err
+		}
+		if sub, err = p.evalCatalogBuiltins.ResolveTextSearchDictionary(ctx, un, p.SessionData()); err != nil {
+			return err
+		}
+		o.Value = qualifiedName
+		dict.Subdictionary = &ref
+	}
+	options := make([]tsearch.DictionaryOption, len(dict.Options))
+	for i, o := range dict.Options {
+		options[i] = tsearch.DictionaryOption{Name: o.Name, Value: o.Value}
+	}
+	_, err := tsearch.NewDictionary(dict.Name, dict.Template, options, sub)
+	return err
+}
+
+// resolveTextSearchDictionaryRef returns a reference to the text search
+// dictionary with the given name, along with its qualified name.
+func (p *planner) resolveTextSearchDictionaryRef(
+	ctx context.Context, db catalog.DatabaseDescriptor, name *tree.UnresolvedObjectName,
+) (descpb.SchemaDescriptor_TextSearchDictionaryReference, string, error) {
+	ref := descpb.SchemaDescriptor_TextSearchDictionaryReference{Name: name.Object()}
+	schemaID, found, err := p.evalCatalogBuiltins.LookupTextSearchObject(
+		ctx, tree.TextSearchDictionary, name, p.SessionData(),
+	)
+	if err != nil {
+		return ref, "", err
+	}
+	if !found {
+		return ref, "", newUndefinedTextSearchObjectError(tree.TextSearchDictionary, name)
+	}
+	ref.SchemaID = schemaID
+	scName := catconstants.PgCatalogName
+	if schemaID != catconstants.PgCatalogID {
+		sc, err := p.Descriptors().ByIDWithLeased(p.Txn()).Get().Schema(ctx, schemaID)
+		if err != nil {
+			return ref, "", err
+		}
+		if err := checkTextSearchSameDatabase(db, sc, name); err != nil {
+			return ref, "", err
+		}
+		scName = sc.GetName()
+	}
+	return ref, qualifiedTextSearchName(scName, ref.Name), nil
+}
+
+func qualifiedTextSearchName(scName, name string) string {
+	return tree.NameString(scName) + "." + tree.NameString(name)
+}
+
+func checkTextSearchSameDatabase(
+	db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, name *tree.UnresolvedObjectName,
+) error {
+	if sc.GetParentID() != db.GetID() {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cross-database references are not supported: %s", tree.ErrString(name))
+	}
+	return nil
+}
+
+// resolveTextSearchTokenTypes resolves the aliases of text search token types.
+func resolveTextSearchTokenTypes(names tree.NameList) ([]tsearch.TokenType, error) {
+	tokenTypes := make([]tsearch.TokenType, len(names))
+	for i, name := range names {
+		t, ok := tsearch.TokenTypeByAlias(strings.ToLower(string(name)))
+		if !ok {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"token type %q does not exist", string(name))
+		}
+		tokenTypes[i] = t
+	}
+	return tokenTypes, nil
+}
+
+// findTextSearchMapping returns the index of the mapping of the given token
+// type in cfg.
+func findTextSearchMapping(
+	cfg *descpb.SchemaDescriptor_TextSearchConfiguration, t tsearch.TokenType,
+) (int, bool) {
+	idx := sort.Search(len(cfg.Mappings), func(i int) bool {
+		return cfg.Mappings[i].TokenType >= int32(t)
+	})
+	return idx, idx < len(cfg.Mappings) && cfg.Mappings[idx].TokenType == int32(t)
+}
+
+// setTextSearchMapping sets the dictionaries of the given token type in cfg,
+// keeping the mappings sorted by token type. The mapping is removed if there
+// are no dictionaries.
+func setTextSearchMapping(
+	cfg *descpb.SchemaDescriptor_TextSearchConfiguration,
+	t tsearch.TokenType,
+	dicts []descpb.SchemaDescriptor_TextSearchDictionaryReference,
+) {
+	idx, exists := findTextSearchMapping(cfg, t)
+	mappings := append([]descpb.SchemaDescriptor_TextSearchMapping(nil), cfg.Mappings[:idx]...)
+	if len(dicts) > 0 {
+		mappings = append(mappings, descpb.SchemaDescriptor_TextSearchMapping{
+			TokenType:    int32(t),
+			Dictionaries: append([]descpb.SchemaDescriptor_TextSearchDictionaryReference(nil), dicts...),
+		})
+	}
+	if exists {
+		idx++
+	}
+	cfg.Mappings = append(mappings, cfg.Mappings[idx:]...)
+}
+
+// textSearchDependent is a text search configuration or thesaurus dictionary
+// that uses another dictionary.
+type textSearchDependent struct {
+	kind     tree.TextSearchObjectKind
+	schemaID descpb.ID
+	name     string
+}
+
+// textSearchDictionaryDependents returns the text search configurations and
+// dictionaries of the database that use the referenced dictionary.
+func (p *planner) textSearchDictionaryDependents(
+	ctx context.Context,
+	db catalog.DatabaseDescriptor,
+	ref descpb.SchemaDescriptor_TextSearchDictionaryReference,
+) ([]textSearchDependent, error) {
+	schemas, err := p.Descriptors().GetAllSchemasInDatabase(ctx, p.Txn(), db)
+	if err != nil {
+		return nil, err
+	}
+	var deps []textSearchDependent
+	if err := schemas.ForEachDescriptor(func(desc catalog.Descriptor) error {
+		sc, err := catalog.AsSchemaDescriptor(desc)
+		if err != nil {
+			return err
+		}
+		if err := sc.ForEachTextSearchConfiguration(func(cfg descpb.SchemaDescriptor_TextSearchConfiguration) error {
+			for _, m := range cfg.Mappings {
+				for _, d := range m.Dictionaries {
+					if d == ref {
+						deps = append(deps, textSearchDependent{
+							kind: tree.TextSearchConfiguration, schemaID: sc.GetID(), name: cfg.Name,
+						})
+						return nil
+					}
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return sc.ForEachTextSearchDictionary(func(dict descpb.SchemaDescriptor_TextSearchDictionary) error {
+			if dict.Subdictionary != nil && *dict.Subdictionary == ref {
+				deps = append(deps, textSearchDependent{
+					kind: tree.TextSearchDictionary, schemaID: sc.GetID(), name: dict.Name,
+				})
+			}
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// dropTextSearchDictionary drops a text search dictionary. The configurations
+// and thesaurus dictionaries that use it are dropped as well with CASCADE.
+func (p *planner) dropTextSearchDictionary(
+	ctx context.Context,
+	db catalog.DatabaseDescriptor,
+	sc *schemadesc.Mutable,
+	name string,
+	behavior tree.DropBehavior,
+	jobDesc string,
+) error {
+	ref := descpb.SchemaDescriptor_TextSearchDictionaryReference{SchemaID: sc.GetID(), Name: name}
+	deps, err := p.textSearchDictionaryDependents(ctx, db, ref)
+	if err != nil {
+		return err
+	}
+	if len(deps) > 0 && behavior != tree.DropCascade {
+		return sqlerrors.NewDependentBlocksOpError(
+			"drop", "text search dictionary", name, textSearchObjectType(deps[0].kind), deps[0].name,
+		)
+	}
+	sc.RemoveTextSearchDictionary(name)
+	if err := p.writeSchemaDescChange(ctx, sc, jobDesc); err != nil {
+		return err
+	}
+	for _, dep := range deps {
+		depSc, err := p.Descriptors().MutableByID(p.Txn()).Schema(ctx, dep.schemaID)
+		if err != nil {
+			return err
+		}
+		if dep.kind == tree.TextSearchDictionary {
+			if err := p.dropTextSearchDictionary(ctx, db, depSc, dep.name, behavior, jobDesc); err != nil {
+				return err
+			}
+			continue
+		}
+		depSc.RemoveTextSearchConfiguration(dep.name)
+		if err := p.writeSchemaDescChange(ctx, depSc, jobDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameTextSearchObject renames a text search configuration or dictionary.
+// The references to a renamed dictionary are updated.
+func (p *planner) renameTextSearchObject(
+	ctx context.Context,
+	kind tree.TextSearchObjectKind,
+	db catalog.DatabaseDescriptor,
+	sc *schemadesc.Mutable,
+	oldName, newName string,
+	jobDesc string,
+) error {
+	if oldName == newName {
+		return nil
+	}
+	if textSearchObjectExistsInSchema(kind, sc, newName) {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"%s %q already exists in schema %q", textSearchObjectType(kind), newName, sc.GetName())
+	}
+	if kind == tree.TextSearchConfiguration {
+		cfg, _ := sc.GetTextSearchConfiguration(oldName)
+		sc.RemoveTextSearchConfiguration(oldName)
+		cfg.Name = newName
+		sc.SetTextSearchConfiguration(cfg)
+		return p.writeSchemaDescChange(ctx, sc, jobDesc)
+	}
+
+	oldRef := descpb.SchemaDescriptor_TextSearchDictionaryReference{SchemaID: sc.GetID(), Name: oldName}
+	newRef := descpb.SchemaDescriptor_TextSearchDictionaryReference{SchemaID: sc.GetID(), Name: newName}
+	deps, err := p.textSearchDictionaryDependents(ctx, db, oldRef)
+	if err != nil {
+		return err
+	}
+	dict, _ := sc.GetTextSearchDictionary(oldName)
+	sc.RemoveTextSearchDictionary(oldName)
+	dict.Name = newName
+	sc.SetTextSearchDictionary(dict)
+	if err := p.writeSchemaDescChange(ctx, sc, jobDesc); err != nil {
+		return err
+	}
+	for _, dep := range deps {
+		depSc, err := p.Descriptors().MutableByID(p.Txn()).Schema(ctx, dep.schemaID)
+		if err != nil {
+			return err
+		}
+		if dep.kind == tree.TextSearchDictionary {
+			thesaurus, _ := depSc.GetTextSearchDictionary(dep.name)
+			thesaurus.Subdictionary = &newRef
+			thesaurus.Options = append([]descpb.SchemaDescriptor_TextSearchOption(nil), thesaurus.Options...)
+			for i := range thesaurus.Options {
+				if thesaurus.Options[i].Name == "dictionary" {
+					thesaurus.Options[i].Value = qualifiedTextSearchName(sc.GetName(), newName)
+				}
+			}
+			depSc.SetTextSearchDictionary(thesaurus)
+		} else {
+			cfg, _ := depSc.GetTextSearchConfiguration(dep.name)
+			for _, m := range cfg.Mappings {
+				dicts := append([]descpb.SchemaDescriptor_TextSearchDictionaryReference(nil), m.Dictionaries...)
+				for i := range dicts {
+					if dicts[i] == oldRef {
+						dicts[i] = newRef
+					}
+				}
+				setTextSearchMapping(&cfg, tsearch.TokenType(m.TokenType), dicts)
+			}
+			depSc.SetTextSearchConfiguration(cfg)
+		}
+		if err := p.writeSchemaDescChange(ctx, depSc, jobDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/errors"
+)
+
+// textSearchTopKNode searches an inverted index on a TSVECTOR column which
+// stores term statistics for the K rows of a table with the highest ts_rank_tf
+// or ts_rank_bm25 ranks for a text search query, and returns their primary
+// keys. The postings of the query lexemes are read from KV, and
+// tsearch.SearchTopK skips the postings of the rows which can't make it into
+// the top K.
+type textSearchTopKNode struct {
+	desc  catalog.TableDescriptor
+	index catalog.Index
+	query tsearch.TSQuery
+	// terms are the distinct lexemes of the query.
+	terms []string
+	k     int
+	// bm25 is set if the rows are ranked with ts_rank_bm25, whose collection
+	// statistics are read from the index. Only the K1 and B parameters are used
+	// then.
+	bm25   bool
+	params tsearch.TFRankParams
+
+	// columns are the primary key columns returned by the node, in table
+	// column order.
+	columns colinfo.ResultColumns
+	// suffixToOutput maps the ordinals of the key suffix columns of the index
+	// to the ordinals of the output columns.
+	suffixToOutput []int
+
+	run struct {
+		rows tree.Datums
+		docs []tsearch.ScoredDoc
+		pos  int
+		// alloc is used to decode the primary keys.
+		alloc tree.DatumAlloc
+	}
+}
+
+func (n *textSearchTopKNode) startExec(params runParams) error {
+	prefix := rowenc.MakeIndexKeyPrefix(params.ExecCfg().Codec, n.desc.GetID(), n.index.GetID())
+	numValueCols := len(rowenc.GetValueColumns(n.index))
+	cursors := make([]tsearch.PostingCursor, len(n.terms))
+	for i, term := range n.terms {
+		c := &postingCursor{
+			txn:          params.p.txn,
+			prefix:       tsearch.EncodeInvertedIndexKey(prefix, term),
+			numValueCols: numValueCols,
+		}
+		if err := c.scan(params.ctx, c.prefix); err != nil {
+			return err
+		}
+		cursors[i] = c
+	}
+	var scorer tsearch.TermScorer = n.params
+	if n.bm25 {
+		// The statistics are shared with the ts_rank_bm25 calls of the statement,
+		// so that the ranks of the returned rows are the same as the ranks the
+		// search is based on.
+		stats, err := params.p.evalCatalogBuiltins.IndexCollectionStatistics(
+			params.ctx, n.desc, n.index, tsearch.RankedLexemes(n.query),
+		)
+		if err != nil {
+			return err
+		}
+		scorer = tsearch.MakeBM25Scorer(tsearch.BM25Params{K1: n.params.K1, B: n.params.B}, stats)
+	}
+	docs, err := tsearch.SearchTopK(params.ctx, n.query, n.terms, cursors, n.k, scorer)
+	if err != nil {
+		return err
+	}
+	n.run.docs = docs
+	n.run.pos = -1
+	n.run.rows = make(tree.Datums, len(n.columns))
+	return nil
+}
+
+func (n *textSearchTopKNode) Next(params runParams) (bool, error) {
+	n.run.pos++
+	if n.run.pos >= len(n.run.docs) {
+		return false, nil
+	}
+	// The document of a posting is the suffix of its key which follows the
+	// lexeme, i.e. the primary key of the row followed by the family sentinel.
+	key := n.run.docs[n.run.pos].Doc
+	for i, ord := range n.suffixToOutput {
+		col, err := catalog.MustFindColumnByID(n.desc, n.index.GetKeySuffixColumnID(i))
+		if err != nil {
+			return false, err
+		}
+		var d tree.Datum
+		d, key, err = keyside.Decode(&n.run.alloc, col.GetType(), key, encoding.Ascending)
+		if err != nil {
+			return false, err
+		}
+		if ord >= 0 {
+			n.run.rows[ord] = d
+		}
+	}
+	return true, nil
+}
+
+func (n *textSearchTopKNode) Values() tree.Datums {
+	return n.run.rows
+}
+
+func (n *textSearchTopKNode) Close(context.Context) {}
+
+// postingCursorBatchSize is the number of postings that a postingCursor reads
+// from KV at once.
+const postingCursorBatchSize = 1000
+
+// postingCursor is a tsearch.PostingCursor over the postings of a lexeme in an
+// inverted index which stores term statistics. The postings are read from KV in
+// batches. Seeking within the current batch doesn't read from KV, and seeking
+// past it starts a new scan at the sought document.
+type postingCursor struct {
+	txn *kv.Txn
+	// prefix is the key prefix of the postings of the lexeme.
+	prefix roachpb.Key
+	// numValueCols is the number of columns which are encoded in the values of
+	// the postings before the term statistics.
+	numValueCols int
+
+	kvs []kv.KeyValue
+	pos int
+	// exhausted is set if there are no postings after the current batch.
+	exhausted bool
+	tf, dl    int
+}
+
+var _ tsearch.PostingCursor = &postingCursor{}
+
+// Doc implements the tsearch.PostingCursor interface.
+func (c *postingCursor) Doc() []byte {
+	if c.pos >= len(c.kvs) {
+		return nil
+	}
+	return c.kvs[c.pos].Key[len(c.prefix):]
+}
+
+// Stats implements the tsearch.PostingCursor interface.
+func (c *postingCursor) Stats() (tf, dl int) {
+	return c.tf, c.dl
+}
+
+// Next implements the tsearch.PostingCursor interface.
+func (c *postingCursor) Next(ctx context.Context) error {
+	c.pos++
+	if c.pos == len(c.kvs) && !c.exhausted {
+		return c.scan(ctx, c.kvs[len(c.kvs)-1].Key.Next())
+	}
+	return c.decodeStats()
+}
+
+// Seek implements the tsearch.PostingCursor interface.
+func (c *postingCursor) Seek(ctx context.Context, doc []byte) error {
+	if d := c.Doc(); d == nil || bytes.Compare(d, doc) >= 0 {
+		return nil
+	}
+	rest := c.kvs[c.pos:]
+	i := sort.Search(len(rest), func(i int) bool {
+		return bytes.Compare(rest[i].Key[len(c.prefix):], doc) >= 0
+	})
+	if i == len(rest) && !c.exhausted {
+		start := make(roachpb.Key, 0, len(c.prefix)+len(doc))
+		start = append(append(start, c.prefix...), doc...)
+		return c.scan(ctx, start)
+	}
+	c.pos += i
+	return c.decodeStats()
+}
+
+// scan reads the next batch of postings, starting at the given key.
+func (c *postingCursor) scan(ctx context.Context, start roachpb.Key) error {
+	kvs, err := c.txn.Scan(ctx, start, c.prefix.PrefixEnd(), postingCursorBatchSize)
+	if err != nil {
+		return err
+	}
+	c.kvs = kvs
+	c.pos = 0
+	c.exhausted = len(kvs) < postingCursorBatchSize
+	return c.decodeStats()
+}
+
+// decodeStats decodes the term statistics of the current posting.
+func (c *postingCursor) decodeStats() error {
+	if c.pos >= len(c.kvs) {
+		return nil
+	}
+	b, err := c.kvs[c.pos].Value.GetBytes()
+	if err != nil {
+		return err
+	}
+	if b, err = rowenc.InvertedIndexStatisticsBytes(b, c.numValueCols); err != nil {
+		return err
+	}
+	c.tf, c.dl, err = tsearch.DecodeTermStatistics(b)
+	if err != nil {
+		return errors.Wrapf(err, "decoding term statistics of key %s", c.kvs[c.pos].Key)
+	}
+	return nil
+}
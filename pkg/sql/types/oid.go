@@ -57,16 +57,22 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_anyelement: Any,
 	oid.T_bit:        typeBit,
 	oid.T_bool:       Bool,
+	oid.T_box:        Box,
 	oid.T_bpchar:     typeBpChar,
 	oid.T_bytea:      Bytes,
 	oid.T_char:       QChar,
+	oid.T_cidr:       CIDR,
+	oid.T_circle:     Circle,
 	oid.T_date:       Date,
+	oid.T_daterange:  DateRange,
 	oid.T_float4:     Float4,
 	oid.T_float8:     Float,
 	oid.T_int2:       Int2,
 	oid.T_int2vector: Int2Vector,
 	oid.T_int4:       Int4,
+	oid.T_int4range:  Int4Range,
 	oid.T_int8:       Int,
+	oid.T_int8range:  Int8Range,
 	oid.T_inet:       INet,
 	oid.T_interval:   Interval,
 	// NOTE(sql-exp): Uncomment the line below if we support the JSON type.
@@ -74,11 +80,19 @@ var OidToType = map[oid.Oid]*T{
 	// existing tables.
 	// oid.T_json:      Json,
 	oid.T_jsonb:        Jsonb,
+	oid.T_line:         Line,
+	oid.T_lseg:         LSeg,
+	oid.T_macaddr:      MACAddr,
+	oid.T_money:        Money,
 	oid.T_name:         Name,
 	oid.T_numeric:      Decimal,
+	oid.T_numrange:     NumRange,
 	oid.T_oid:          Oid,
 	oid.T_oidvector:    OidVector,
+	oid.T_path:         Path,
 	oid.T_pg_lsn:       PGLSN,
+	oid.T_point:        Point,
+	oid.T_polygon:      Polygon,
 	oid.T_record:       AnyTuple,
 	oid.T_refcursor:    RefCursor,
 	oid.T_regclass:     RegClass,
@@ -94,6 +108,8 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_timestamptz:  TimestampTZ,
 	oid.T_trigger:      Trigger,
 	oid.T_tsquery:      TSQuery,
+	oid.T_tsrange:      TimestampRange,
+	oid.T_tstzrange:    TimestampTZRange,
 	oid.T_tsvector:     TSVector,
 	oid.T_unknown:      Unknown,
 	oid.T_uuid:         Uuid,
@@ -105,6 +121,15 @@ var OidToType = map[oid.Oid]*T{
 	oidext.T_geography: Geography,
 	oidext.T_box2d:     Box2D,
 	oidext.T_pgvector:  PGVector,
+	oidext.T_jsonpath:  Jsonpath,
+	oidext.T_macaddr8:  MACAddr8,
+
+	oidext.T_int4multirange: Int4Multirange,
+	oidext.T_int8multirange: Int8Multirange,
+	oidext.T_nummultirange:  NumMultirange,
+	oidext.T_tsmultirange:   TimestampMultirange,
+	oidext.T_tstzmultirange: TimestampTZMultirange,
+	oidext.T_datemultirange: DateMultirange,
 }
 
 // oidToArrayOid maps scalar type Oids to their corresponding array type Oid.
@@ -112,9 +137,12 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_anyelement:   oid.T_anyarray,
 	oid.T_bit:          oid.T__bit,
 	oid.T_bool:         oid.T__bool,
+	oid.T_box:          oid.T__box,
 	oid.T_bpchar:       oid.T__bpchar,
 	oid.T_bytea:        oid.T__bytea,
 	oid.T_char:         oid.T__char,
+	oid.T_cidr:         oid.T__cidr,
+	oid.T_circle:       oid.T__circle,
 	oid.T_date:         oid.T__date,
 	oid.T_float4:       oid.T__float4,
 	oid.T_float8:       oid.T__float8,
@@ -125,11 +153,18 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_int8:         oid.T__int8,
 	oid.T_interval:     oid.T__interval,
 	oid.T_jsonb:        oid.T__jsonb,
+	oid.T_line:         oid.T__line,
+	oid.T_lseg:         oid.T__lseg,
+	oid.T_macaddr:      oid.T__macaddr,
+	oid.T_money:        oid.T__money,
 	oid.T_name:         oid.T__name,
 	oid.T_numeric:      oid.T__numeric,
 	oid.T_oid:          oid.T__oid,
 	oid.T_oidvector:    oid.T__oidvector,
+	oid.T_path:         oid.T__path,
 	oid.T_pg_lsn:       oid.T__pg_lsn,
+	oid.T_point:        oid.T__point,
+	oid.T_polygon:      oid.T__polygon,
 	oid.T_record:       oid.T__record,
 	oid.T_refcursor:    oid.T__refcursor,
 	oid.T_regclass:     oid.T__regclass,
@@ -153,6 +188,8 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oidext.T_geography: oidext.T__geography,
 	oidext.T_box2d:     oidext.T__box2d,
 	oidext.T_pgvector:  oidext.T__pgvector,
+	oidext.T_jsonpath:  oidext.T__jsonpath,
+	oidext.T_macaddr8:  oidext.T__macaddr8,
 }
 
 // familyToOid maps each type family to a default OID value that is used when
@@ -190,6 +227,22 @@ var familyToOid = map[Family]oid.Oid{
 	GeographyFamily: oidext.T_geography,
 	Box2DFamily:     oidext.T_box2d,
 	PGVectorFamily:  oidext.T_pgvector,
+	JsonpathFamily:  oidext.T_jsonpath,
+
+	RangeFamily:      oid.T_int8range,
+	MultirangeFamily: oidext.T_int8multirange,
+
+	PointFamily:   oid.T_point,
+	BoxFamily:     oid.T_box,
+	LSegFamily:    oid.T_lseg,
+	LineFamily:    oid.T_line,
+	PathFamily:    oid.T_path,
+	PolygonFamily: oid.T_polygon,
+	CircleFamily:  oid.T_circle,
+
+	MACAddrFamily:  oid.T_macaddr,
+	MACAddr8Family: oidext.T_macaddr8,
+	MoneyFamily:    oid.T_money,
 }
 
 // ArrayOids is a set of all oids which correspond to an array type.
@@ -220,6 +220,27 @@ type UserDefinedTypeMetadata struct {
 	// for a table. Note: this can be deleted if we migrate implicit record types
 	// to ordinary persisted composite types.
 	ImplicitRecordType bool
+
+	// DomainData is non-nil iff the metadata is for a DOMAIN type.
+	DomainData *DomainMetadata
+}
+
+// DomainMetadata is metadata about a DOMAIN needed to enforce its constraints.
+type DomainMetadata struct {
+	// DefaultExpr is the serialized default expression of the domain, or nil if
+	// the domain has no default.
+	DefaultExpr *string
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// Checks are the CHECK constraints of the domain.
+	Checks []DomainCheck
+}
+
+// DomainCheck is a named CHECK constraint of a domain. The serialized
+// expression refers to the value being checked as VALUE.
+type DomainCheck struct {
+	Name string
+	Expr string
 }
 
 // EnumMetadata is metadata about an ENUM needed for evaluation.
@@ -446,6 +467,40 @@ var (
 	INet = &T{InternalType: InternalType{
 		Family: INetFamily, Oid: oid.T_inet, Locale: &emptyLocale}}
 
+	// CIDR is the type of an IPv4 or IPv6 network specification, which is like
+	// INet except that no bits can be set to the right of the mask. For
+	// example:
+	//
+	//   192.168.100.128/25
+	//   2001:4f8:3:ba::/64
+	//
+	CIDR = &T{InternalType: InternalType{
+		Family: INetFamily, Oid: oid.T_cidr, Locale: &emptyLocale}}
+
+	// MACAddr is the type of a 6 byte MAC address. For example:
+	//
+	//   08:00:2b:01:02:03
+	//
+	MACAddr = &T{InternalType: InternalType{
+		Family: MACAddrFamily, Oid: oid.T_macaddr, Locale: &emptyLocale}}
+
+	// MACAddr8 is the type of an 8 byte MAC address in EUI-64 format. For
+	// example:
+	//
+	//   08:00:2b:01:02:03:04:05
+	//
+	MACAddr8 = &T{InternalType: InternalType{
+		Family: MACAddr8Family, Oid: oidext.T_macaddr8, Locale: &emptyLocale}}
+
+	// Money is the type of a currency amount, which is stored as an integer
+	// number of the smallest fractional unit of the currency of the
+	// lc_monetary locale. For example:
+	//
+	//   $1,234.56
+	//
+	Money = &T{InternalType: InternalType{
+		Family: MoneyFamily, Oid: oid.T_money, Locale: &emptyLocale}}
+
 	// Geometry is the type of a geospatial Geometry object.
 	Geometry = &T{
 		InternalType: InternalType{
@@ -533,6 +588,16 @@ var (
 		},
 	}
 
+	// Jsonpath is the type of a SQL/JSON path expression, which is used to
+	// query JSONB values.
+	Jsonpath = &T{
+		InternalType: InternalType{
+			Family: JsonpathFamily,
+			Oid:    oidext.T_jsonpath,
+			Locale: &emptyLocale,
+		},
+	}
+
 	// RefCursor is the type for a variable representing the name of a cursor in a
 	// PLpgSQL routine. The underlying value is a string.
 	RefCursor = &T{
@@ -543,6 +608,98 @@ var (
 		},
 	}
 
+	// Int4Range is the type of a range of INT4 values.
+	Int4Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int4range, Locale: &emptyLocale}}
+
+	// Int8Range is the type of a range of INT8 values.
+	Int8Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int8range, Locale: &emptyLocale}}
+
+	// NumRange is the type of a range of DECIMAL values.
+	NumRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_numrange, Locale: &emptyLocale}}
+
+	// TimestampRange is the type of a range of TIMESTAMP values. It is called
+	// tsrange in Postgres.
+	TimestampRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tsrange, Locale: &emptyLocale}}
+
+	// TimestampTZRange is the type of a range of TIMESTAMPTZ values. It is
+	// called tstzrange in Postgres.
+	TimestampTZRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tstzrange, Locale: &emptyLocale}}
+
+	// DateRange is the type of a range of DATE values.
+	DateRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_daterange, Locale: &emptyLocale}}
+
+	// Int4Multirange is the type of a multirange of INT4 values.
+	Int4Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_int4multirange, Locale: &emptyLocale}}
+
+	// Int8Multirange is the type of a multirange of INT8 values.
+	Int8Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_int8multirange, Locale: &emptyLocale}}
+
+	// NumMultirange is the type of a multirange of DECIMAL values.
+	NumMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_nummultirange, Locale: &emptyLocale}}
+
+	// TimestampMultirange is the type of a multirange of TIMESTAMP values.
+	TimestampMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_tsmultirange, Locale: &emptyLocale}}
+
+	// TimestampTZMultirange is the type of a multirange of TIMESTAMPTZ values.
+	TimestampTZMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_tstzmultirange, Locale: &emptyLocale}}
+
+	// DateMultirange is the type of a multirange of DATE values.
+	DateMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_datemultirange, Locale: &emptyLocale}}
+
+	// RangeTypes contains all the built-in range types.
+	RangeTypes = []*T{Int4Range, Int8Range, NumRange, TimestampRange, TimestampTZRange, DateRange}
+
+	// MultirangeTypes contains all the built-in multirange types, in the same
+	// order as the corresponding range types in RangeTypes.
+	MultirangeTypes = []*T{
+		Int4Multirange, Int8Multirange, NumMultirange,
+		TimestampMultirange, TimestampTZMultirange, DateMultirange,
+	}
+
+	// Point is the type of a point on a plane.
+	Point = &T{InternalType: InternalType{
+		Family: PointFamily, Oid: oid.T_point, Locale: &emptyLocale}}
+
+	// Box is the type of a rectangular box whose sides are parallel to the
+	// axes.
+	Box = &T{InternalType: InternalType{
+		Family: BoxFamily, Oid: oid.T_box, Locale: &emptyLocale}}
+
+	// LSeg is the type of a finite line segment.
+	LSeg = &T{InternalType: InternalType{
+		Family: LSegFamily, Oid: oid.T_lseg, Locale: &emptyLocale}}
+
+	// Line is the type of an infinite line.
+	Line = &T{InternalType: InternalType{
+		Family: LineFamily, Oid: oid.T_line, Locale: &emptyLocale}}
+
+	// Path is the type of an open or closed path made of connected points.
+	Path = &T{InternalType: InternalType{
+		Family: PathFamily, Oid: oid.T_path, Locale: &emptyLocale}}
+
+	// Polygon is the type of a polygon, which is similar to a closed path.
+	Polygon = &T{InternalType: InternalType{
+		Family: PolygonFamily, Oid: oid.T_polygon, Locale: &emptyLocale}}
+
+	// Circle is the type of a circle.
+	Circle = &T{InternalType: InternalType{
+		Family: CircleFamily, Oid: oid.T_circle, Locale: &emptyLocale}}
+
+	// GeometricTypes contains the Postgres geometric types.
+	GeometricTypes = []*T{Point, Box, LSeg, Line, Path, Polygon, Circle}
+
 	// Scalar contains all types that meet this criteria:
 	//
 	//   1. Scalar type (no ArrayFamily or TupleFamily types).
@@ -572,6 +729,9 @@ var (
 		TimeTZ,
 		Jsonb,
 		VarBit,
+		MACAddr,
+		MACAddr8,
+		Money,
 	}
 
 	// Any is a special type used only during static analysis as a wildcard type
@@ -690,6 +850,23 @@ var (
 	INetArray = &T{InternalType: InternalType{
 		Family: ArrayFamily, ArrayContents: INet, Oid: oid.T__inet, Locale: &emptyLocale}}
 
+	// CIDRArray is the type of an array value having CIDR-typed elements.
+	CIDRArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: CIDR, Oid: oid.T__cidr, Locale: &emptyLocale}}
+
+	// MACAddrArray is the type of an array value having MACAddr-typed elements.
+	MACAddrArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: MACAddr, Oid: oid.T__macaddr, Locale: &emptyLocale}}
+
+	// MACAddr8Array is the type of an array value having MACAddr8-typed
+	// elements.
+	MACAddr8Array = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: MACAddr8, Oid: oidext.T__macaddr8, Locale: &emptyLocale}}
+
+	// MoneyArray is the type of an array value having Money-typed elements.
+	MoneyArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: Money, Oid: oid.T__money, Locale: &emptyLocale}}
+
 	// VarBitArray is the type of an array value having VarBit-typed elements.
 	VarBitArray = &T{InternalType: InternalType{
 		Family: ArrayFamily, ArrayContents: VarBit, Oid: oid.T__varbit, Locale: &emptyLocale}}
@@ -1264,6 +1441,20 @@ func NewCompositeType(typeOID, arrayTypeOID oid.Oid, contents []*T, labels []str
 	}}
 }
 
+// MakeDomain constructs a new instance of a domain type over the given base
+// type, with the given stable type ID. The domain shares the family and all
+// other attributes of the base type. Note that it does not hydrate cached
+// fields on the type.
+func MakeDomain(base *T, typeOID, arrayTypeOID oid.Oid) *T {
+	typ := &T{InternalType: base.InternalType}
+	typ.InternalType.Oid = typeOID
+	typ.InternalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		ArrayTypeOID:  arrayTypeOID,
+		DomainBaseOID: base.Oid(),
+	}
+	return typ
+}
+
 // Family specifies a group of types that are compatible with one another. Types
 // in the same family can be compared, assigned, etc., but may differ from one
 // another in width, precision, locale, and other attributes. For example, it is
@@ -1466,6 +1657,53 @@ func (t *T) ArrayContents() *T {
 	return t.InternalType.ArrayContents
 }
 
+// RangeContents returns the subtype of a range type, which is the type of its
+// bounds. This is nil for types that are not in the RangeFamily.
+func (t *T) RangeContents() *T {
+	if t.Family() != RangeFamily {
+		return nil
+	}
+	switch t.Oid() {
+	case oid.T_int4range:
+		return Int4
+	case oid.T_int8range:
+		return Int
+	case oid.T_numrange:
+		return Decimal
+	case oid.T_tsrange:
+		return Timestamp
+	case oid.T_tstzrange:
+		return TimestampTZ
+	case oid.T_daterange:
+		return Date
+	}
+	panic(errors.AssertionFailedf("unexpected range OID: %d", t.Oid()))
+}
+
+// MultirangeContents returns the range type of the ranges of a multirange
+// type. This is nil for types that are not in the MultirangeFamily.
+func (t *T) MultirangeContents() *T {
+	if t.Family() != MultirangeFamily {
+		return nil
+	}
+	for i, typ := range MultirangeTypes {
+		if typ.Oid() == t.Oid() {
+			return RangeTypes[i]
+		}
+	}
+	panic(errors.AssertionFailedf("unexpected multirange OID: %d", t.Oid()))
+}
+
+// MakeMultirange returns the multirange type of the given range type.
+func MakeMultirange(rangeTyp *T) *T {
+	for i, typ := range RangeTypes {
+		if typ.Oid() == rangeTyp.Oid() {
+			return MultirangeTypes[i]
+		}
+	}
+	panic(errors.AssertionFailedf("unexpected range type: %s", rangeTyp.SQLStringForError()))
+}
+
 // TupleContents returns a slice containing the type of each tuple field. This
 // is nil for non-TupleFamily types.
 func (t *T) TupleContents() []*T {
@@ -1504,6 +1742,23 @@ func RemapUserDefinedTypeOIDs(t *T, newOID, newArrayOID oid.Oid) {
 	}
 }
 
+// IsDomain returns whether or not t is a domain type.
+func (t *T) IsDomain() bool {
+	return t.InternalType.UDTMetadata != nil && t.InternalType.UDTMetadata.DomainBaseOID != 0
+}
+
+// DomainBaseType returns the base type of a domain type. It returns t if t is
+// not a domain.
+func (t *T) DomainBaseType() *T {
+	if !t.IsDomain() {
+		return t
+	}
+	base := &T{InternalType: t.InternalType}
+	base.InternalType.Oid = t.InternalType.UDTMetadata.DomainBaseOID
+	base.InternalType.UDTMetadata = nil
+	return base
+}
+
 // UserDefined returns whether or not t is a user defined type.
 func (t *T) UserDefined() bool {
 	return IsOIDUserDefinedType(t.Oid())
@@ -1520,8 +1775,10 @@ var familyNames = map[Family]redact.SafeString{
 	ArrayFamily:          "array",
 	BitFamily:            "bit",
 	BoolFamily:           "bool",
+	BoxFamily:            "box",
 	Box2DFamily:          "box2d",
 	BytesFamily:          "bytes",
+	CircleFamily:         "circle",
 	CollatedStringFamily: "collatedstring",
 	DateFamily:           "date",
 	DecimalFamily:        "decimal",
@@ -1533,9 +1790,20 @@ var familyNames = map[Family]redact.SafeString{
 	IntFamily:            "int",
 	IntervalFamily:       "interval",
 	JsonFamily:           "jsonb",
+	JsonpathFamily:       "jsonpath",
+	LineFamily:           "line",
+	LSegFamily:           "lseg",
+	MACAddrFamily:        "macaddr",
+	MACAddr8Family:       "macaddr8",
+	MoneyFamily:          "money",
+	MultirangeFamily:     "multirange",
 	OidFamily:            "oid",
+	PathFamily:           "path",
 	PGLSNFamily:          "pg_lsn",
 	PGVectorFamily:       "vector",
+	PointFamily:          "point",
+	PolygonFamily:        "polygon",
+	RangeFamily:          "range",
 	RefCursorFamily:      "refcursor",
 	StringFamily:         "string",
 	TimeFamily:           "time",
@@ -1571,6 +1839,9 @@ func (f Family) Name() redact.SafeString {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.Basename()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
 		return "anyelement"
@@ -1602,6 +1873,12 @@ func (t *T) Name() string {
 			panic(errors.AssertionFailedf("programming error: unknown float width: %d", t.Width()))
 		}
 
+	case INetFamily:
+		if t.Oid() == oid.T_cidr {
+			return "cidr"
+		}
+		return "inet"
+
 	case IntFamily:
 		switch t.Width() {
 		case 64:
@@ -1614,7 +1891,7 @@ func (t *T) Name() string {
 			panic(errors.AssertionFailedf("programming error: unknown int width: %d", t.Width()))
 		}
 
-	case OidFamily:
+	case OidFamily, RangeFamily, MultirangeFamily:
 		return t.SQLStandardName()
 
 	case StringFamily, CollatedStringFamily:
@@ -1713,6 +1990,9 @@ func (t *T) TelemetryName() string {
 // This function is full of special cases. See backend/utils/adt/format_type.c
 // in Postgres.
 func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.Basename()
+	}
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
@@ -1779,7 +2059,7 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 	case GeometryFamily, GeographyFamily:
 		return t.Name() + t.InternalType.GeoMetadata.SQLString()
 	case INetFamily:
-		return "inet"
+		return t.Name()
 	case IntFamily:
 		switch t.Width() {
 		case 16:
@@ -1800,6 +2080,12 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 	case JsonFamily:
 		// Only binary JSON is currently supported.
 		return "jsonb"
+	case JsonpathFamily:
+		return "jsonpath"
+	case PointFamily, BoxFamily, LSegFamily, LineFamily, PathFamily, PolygonFamily, CircleFamily:
+		return t.Name()
+	case MACAddrFamily, MACAddr8Family, MoneyFamily:
+		return t.Name()
 	case OidFamily:
 		switch t.Oid() {
 		case oid.T_oid:
@@ -1823,6 +2109,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		return "pg_lsn"
 	case PGVectorFamily:
 		return "vector"
+	case RangeFamily, MultirangeFamily:
+		return t.PGName()
 	case RefCursorFamily:
 		return "refcursor"
 	case StringFamily, CollatedStringFamily:
@@ -1928,6 +2216,11 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		// Do not include the catalog name, for the same reasons as user-defined
+		// enum types below.
+		return t.TypeMeta.Name.FQName(false /* explicitCatalog */)
+	}
 	switch t.Family() {
 	case BitFamily:
 		switch t.Oid() {
@@ -2067,7 +2360,8 @@ func (t *T) SQLString() string {
 // type name to be a fully-qualified 3-part name.
 func (t *T) SQLStringFullyQualified() string {
 	if t.TypeMeta.Name != nil &&
-		(t.Family() == EnumFamily || (t.Family() == TupleFamily && t.UserDefined())) {
+		(t.Family() == EnumFamily || t.IsDomain() ||
+			(t.Family() == TupleFamily && t.UserDefined())) {
 		// Include the catalog in the type name. This is necessary to properly
 		// resolve the type, as some code paths require the database name to
 		// correctly distinguish cross-database references.
@@ -2106,7 +2400,9 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		IntervalFamily, StringFamily, BytesFamily, TimestampTZFamily, CollatedStringFamily, OidFamily,
 		UnknownFamily, UuidFamily, INetFamily, TimeFamily, JsonFamily, TimeTZFamily, BitFamily,
 		GeometryFamily, GeographyFamily, Box2DFamily, VoidFamily, EncodedKeyFamily, TSQueryFamily,
-		TSVectorFamily, AnyFamily, PGLSNFamily, PGVectorFamily, RefCursorFamily:
+		TSVectorFamily, AnyFamily, PGLSNFamily, PGVectorFamily, RefCursorFamily, RangeFamily,
+		MultirangeFamily, JsonpathFamily, PointFamily, BoxFamily, LSegFamily, LineFamily, PathFamily,
+		PolygonFamily, CircleFamily, MACAddrFamily, MACAddr8Family, MoneyFamily:
 		// These types do not contain other types, and do not require redaction.
 		return redact.Sprint(redact.SafeString(t.SQLString()))
 	}
@@ -2181,6 +2477,12 @@ func (t *T) Equivalent(other *T) bool {
 		if t.Oid() != other.Oid() {
 			return false
 		}
+
+	case RangeFamily, MultirangeFamily:
+		// Ranges of different subtypes cannot be compared.
+		if t.Oid() != other.Oid() {
+			return false
+		}
 	}
 
 	return true
@@ -2359,7 +2661,8 @@ func (t *InternalType) Identical(other *InternalType) bool {
 		}
 	}
 	if t.UDTMetadata != nil && other.UDTMetadata != nil {
-		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID {
+		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID ||
+			t.UDTMetadata.DomainBaseOID != other.UDTMetadata.DomainBaseOID {
 			return false
 		}
 	} else if t.UDTMetadata != nil {
@@ -2394,6 +2697,13 @@ func (t *T) Unmarshal(data []byte) error {
 // setting required values. This is necessary to preserve backwards-
 // compatibility with older formats (e.g. restoring database from old backup).
 func (t *T) upgradeType() error {
+	if t.IsDomain() {
+		// Domains carry the attributes of their base type, so upgrade them as
+		// the base type and then restore the domain OID.
+		domainOID := t.InternalType.Oid
+		t.InternalType.Oid = t.InternalType.UDTMetadata.DomainBaseOID
+		defer func() { t.InternalType.Oid = domainOID }()
+	}
 	switch t.Family() {
 	case IntFamily:
 		// Check VisibleType field that was populated in previous versions.
@@ -2631,6 +2941,12 @@ func (t *T) MarshalTo(data []byte) (int, error) {
 // CRDB. This is necessary to preserve backwards-compatibility in mixed-version
 // scenarios, such as during upgrade.
 func (t *T) downgradeType() error {
+	if t.IsDomain() {
+		// See the comment in upgradeType.
+		domainOID := t.InternalType.Oid
+		t.InternalType.Oid = t.InternalType.UDTMetadata.DomainBaseOID
+		defer func() { t.InternalType.Oid = domainOID }()
+	}
 	// Set Family and VisibleType for 19.1 backwards-compatibility.
 	switch t.Family() {
 	case BitFamily:
@@ -2883,6 +3199,12 @@ func IsValidArrayElementType(t *T) (valid bool, issueNum int) {
 		return false, 90886
 	case PGVectorFamily:
 		return false, 121432
+	case RangeFamily, MultirangeFamily:
+		return false, 27791
+	case JsonpathFamily:
+		return false, 22513
+	case PointFamily, BoxFamily, LSegFamily, LineFamily, PathFamily, PolygonFamily, CircleFamily:
+		return false, 21286
 	default:
 		return true, 0
 	}
@@ -3095,16 +3417,6 @@ var unreservedTypeTokens = map[string]*T{
 // github issues. It is also possible, but not necessary, to include
 // PostgreSQL types that are already implemented in CockroachDB.
 var postgresPredefinedTypeIssues = map[string]int{
-	"box":           21286,
-	"cidr":          18846,
-	"circle":        21286,
-	"jsonpath":      22513,
-	"line":          21286,
-	"lseg":          21286,
-	"macaddr":       45813,
-	"macaddr8":      45813,
-	"money":         41578,
-	"path":          21286,
 	"txid_snapshot": -1,
 	"xml":           43355,
 }
@@ -1,4 +1,4 @@
-// Copyright 2022 The Cockroach Authors.
+// Copyright 2024 The Cockroach Authors.
 //
 // Use of this software is governed by the CockroachDB Software License
 // included in the /LICENSE file.
@@ -8,17 +8,16 @@ package sql
 import (
 	"context"
 
-	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
+// Unlisten unregisters the session as a listener on a channel, or on all
+// channels. Like in Postgres, it only takes effect when the transaction
+// commits.
+// See https://www.postgresql.org/docs/current/sql-unlisten.html.
 func (p *planner) Unlisten(ctx context.Context, n *tree.Unlisten) (planNode, error) {
-	p.BufferClientNotice(ctx,
-		pgerror.WithSeverity(
-			unimplemented.NewWithIssuef(41522, "CRDB does not support LISTEN, making UNLISTEN a no-op"),
-			"NOTICE",
-		),
-	)
-	return newZeroNode(nil /* columns */), nil
+	if n.Star {
+		return &listenNode{op: listenOp{unlisten: true, all: true}}, nil
+	}
+	return &listenNode{op: listenOp{channel: string(n.ChannelName), unlisten: true}}, nil
 }
@@ -78,6 +78,7 @@ func (u *updateNode) startExec(params runParams) error {
 			colinfo.ColTypeInfoFromResCols(u.columns),
 		)
 	}
+	u.run.tu.deferred = params.p.newDeferredConstraintTracker(u.run.tu.tableDesc(), deferredUpdate)
 	return u.run.tu.init(params.ctx, params.p.txn, params.EvalContext())
 }
 
@@ -54,6 +54,7 @@ type upsertRun struct {
 func (n *upsertNode) startExec(params runParams) error {
 	// cache traceKV during execution, to avoid re-evaluating it for every row.
 	n.run.traceKV = params.p.ExtendedEvalContext().Tracing.KVTracingEnabled()
+	n.run.tw.deferred = params.p.newDeferredConstraintTracker(n.run.tw.tableDesc(), deferredUpdate)
 
 	return n.run.tw.init(params.ctx, params.p.txn, params.EvalContext())
 }
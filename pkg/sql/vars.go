@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/gpq"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -45,6 +46,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/metamorphic"
+	"github.com/cockroachdb/cockroach/pkg/util/money"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
@@ -1373,13 +1375,31 @@ var varGen = map[string]sessionVar{
 
 	// See https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-DEFAULT-TEXT-SEARCH-CONFIG
 	`default_text_search_config`: {
+		// Set is used for session defaults, which are not validated since the
+		// configuration may belong to a database other than the current one.
 		Set: func(_ context.Context, m sessionDataMutator, s string) error {
-			if err := tsearch.ValidConfig(s); err != nil {
-				return err
-			}
 			m.SetDefaultTextSearchConfig(s)
 			return nil
 		},
+		SetWithPlanner: func(ctx context.Context, p *planner, local bool, s string) error {
+			if _, ok := tsearch.BuiltinConfig(tsearch.GetConfigKey(s)); !ok {
+				name, err := parser.ParseTableName(s)
+				if err != nil {
+					return err
+				}
+				if _, err := p.evalCatalogBuiltins.ResolveTextSearchConfig(ctx, name, p.SessionData()); err != nil {
+					return err
+				}
+			}
+			return p.applyOnSessionDataMutators(
+				ctx,
+				local,
+				func(m sessionDataMutator) error {
+					m.SetDefaultTextSearchConfig(s)
+					return nil
+				},
+			)
+		},
 		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
 			return evalCtx.SessionData().DefaultTextSearchConfig, nil
 		},
@@ -1404,7 +1424,19 @@ var varGen = map[string]sessionVar{
 
 	// See https://www.postgresql.org/docs/14/sql-show.html and
 	// https://www.postgresql.org/docs/14/locale.html
-	`lc_monetary`: makeCompatStringVar("lc_monetary", PgCompatLocale),
+	`lc_monetary`: {
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			if _, ok := money.LookupLocale(s); !ok {
+				return newVarValueError("lc_monetary", s)
+			}
+			m.SetLCMonetary(s)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return evalCtx.SessionData().DataConversionConfig.LcMonetary, nil
+		},
+		GlobalDefault: func(_ *settings.Values) string { return PgCompatLocale },
+	},
 
 	// See https://www.postgresql.org/docs/14/sql-show.html and
 	// https://www.postgresql.org/docs/14/locale.html
@@ -3491,6 +3523,29 @@ var varGen = map[string]sessionVar{
 		},
 		GlobalDefault: globalTrue,
 	},
+
+	// CockroachDB extension.
+	`vector_search_probes`: {
+		GetStringVal: makeIntGetStringValFn(`vector_search_probes`),
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			b, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return err
+			}
+			if b <= 0 {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"vector_search_probes must be a positive value: %d", b)
+			}
+			m.SetVectorSearchProbes(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return strconv.FormatInt(evalCtx.SessionData().VectorSearchProbes, 10), nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return "10"
+		},
+	},
 }
 
 func ReplicationModeFromString(s string) (sessiondatapb.ReplicationMode, error) {
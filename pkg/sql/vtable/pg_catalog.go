@@ -1121,7 +1121,7 @@ CREATE TABLE pg_catalog.pg_statio_all_tables (
 	tidx_blks_hit INT
 )`
 
-// PgCatalogTsTemplate is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsTemplate describes the schema of the pg_catalog.pg_ts_template table.
 const PgCatalogTsTemplate = `
 CREATE TABLE pg_catalog.pg_ts_template (
 	oid OID,
@@ -1390,7 +1390,7 @@ CREATE TABLE pg_catalog.pg_statio_all_indexes (
 	idx_blks_hit INT
 )`
 
-// PgCatalogTsConfig is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsConfig describes the schema of the pg_catalog.pg_ts_config table.
 const PgCatalogTsConfig = `
 CREATE TABLE pg_catalog.pg_ts_config (
 	oid OID,
@@ -1724,7 +1724,7 @@ CREATE TABLE pg_catalog.pg_stat_all_indexes (
 	idx_tup_fetch INT
 )`
 
-// PgCatalogTsConfigMap is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsConfigMap describes the schema of the pg_catalog.pg_ts_config_map table.
 const PgCatalogTsConfigMap = `
 CREATE TABLE pg_catalog.pg_ts_config_map (
 	mapcfg OID,
@@ -1759,7 +1759,7 @@ CREATE TABLE pg_catalog.pg_transform (
 	trftosql REGPROC
 )`
 
-// PgCatalogTsParser is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsParser describes the schema of the pg_catalog.pg_ts_parser table.
 const PgCatalogTsParser = `
 CREATE TABLE pg_catalog.pg_ts_parser (
 	oid OID,
@@ -1917,7 +1917,7 @@ CREATE TABLE pg_catalog.pg_subscription (
 	subpublications STRING[]
 )`
 
-// PgCatalogTsDict is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsDict describes the schema of the pg_catalog.pg_ts_dict table.
 const PgCatalogTsDict = `
 CREATE TABLE pg_catalog.pg_ts_dict (
 	oid OID,
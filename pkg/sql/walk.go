@@ -349,8 +349,10 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterFunctionSetOwnerNode{}):               "alter function owner",
 	reflect.TypeOf(&alterFunctionSetSchemaNode{}):              "alter function set schema",
 	reflect.TypeOf(&alterFunctionDepExtensionNode{}):           "alter function depends on extension",
+	reflect.TypeOf(&alterDomainNode{}):                         "alter domain",
 	reflect.TypeOf(&alterIndexNode{}):                          "alter index",
 	reflect.TypeOf(&alterIndexVisibleNode{}):                   "alter index visibility",
+	reflect.TypeOf(&alterPublicationNode{}):                    "alter publication",
 	reflect.TypeOf(&alterSequenceNode{}):                       "alter sequence",
 	reflect.TypeOf(&alterSchemaNode{}):                         "alter schema",
 	reflect.TypeOf(&alterTableNode{}):                          "alter table",
@@ -358,6 +360,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterTableSetLocalityNode{}):               "alter table set locality",
 	reflect.TypeOf(&alterTableSetSchemaNode{}):                 "alter table set schema",
 	reflect.TypeOf(&alterTenantCapabilityNode{}):               "alter tenant capability",
+	reflect.TypeOf(&alterTextSearchNode{}):                     "alter text search",
 	reflect.TypeOf(&alterTenantSetClusterSettingNode{}):        "alter tenant set cluster setting",
 	reflect.TypeOf(&alterTenantServiceNode{}):                  "alter tenant service",
 	reflect.TypeOf(&alterTypeNode{}):                           "alter type",
@@ -380,16 +383,20 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&completionsNode{}):                         "show completions",
 	reflect.TypeOf(&controlJobsNode{}):                         "control jobs",
 	reflect.TypeOf(&controlSchedulesNode{}):                    "control schedules",
+	reflect.TypeOf(&createAggregateNode{}):                     "create aggregate",
 	reflect.TypeOf(&createDatabaseNode{}):                      "create database",
+	reflect.TypeOf(&createDomainNode{}):                        "create domain",
 	reflect.TypeOf(&createExtensionNode{}):                     "create extension",
 	reflect.TypeOf(&createExternalConnectionNode{}):            "create external connection",
 	reflect.TypeOf(&createFunctionNode{}):                      "create function",
 	reflect.TypeOf(&createIndexNode{}):                         "create index",
+	reflect.TypeOf(&createPublicationNode{}):                   "create publication",
 	reflect.TypeOf(&createSequenceNode{}):                      "create sequence",
 	reflect.TypeOf(&createSchemaNode{}):                        "create schema",
 	reflect.TypeOf(&createStatsNode{}):                         "create statistics",
 	reflect.TypeOf(&createTableNode{}):                         "create table",
 	reflect.TypeOf(&createTenantNode{}):                        "create tenant",
+	reflect.TypeOf(&createTextSearchNode{}):                    "create text search",
 	reflect.TypeOf(&createTypeNode{}):                          "create type",
 	reflect.TypeOf(&CreateRoleNode{}):                          "create user/role",
 	reflect.TypeOf(&createViewNode{}):                          "create view",
@@ -402,10 +409,12 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&dropExternalConnectionNode{}):              "drop external connection",
 	reflect.TypeOf(&dropFunctionNode{}):                        "drop function",
 	reflect.TypeOf(&dropIndexNode{}):                           "drop index",
+	reflect.TypeOf(&dropPublicationNode{}):                     "drop publication",
 	reflect.TypeOf(&dropSequenceNode{}):                        "drop sequence",
 	reflect.TypeOf(&dropSchemaNode{}):                          "drop schema",
 	reflect.TypeOf(&dropTableNode{}):                           "drop table",
 	reflect.TypeOf(&dropTenantNode{}):                          "drop tenant",
+	reflect.TypeOf(&dropTextSearchNode{}):                      "drop text search",
 	reflect.TypeOf(&dropTypeNode{}):                            "drop type",
 	reflect.TypeOf(&DropRoleNode{}):                            "drop user/role",
 	reflect.TypeOf(&dropViewNode{}):                            "drop view",
@@ -426,6 +435,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&invertedJoinNode{}):                        "inverted join",
 	reflect.TypeOf(&joinNode{}):                                "join",
 	reflect.TypeOf(&limitNode{}):                               "limit",
+	reflect.TypeOf(&listenNode{}):                              "listen",
 	reflect.TypeOf(&lookupJoinNode{}):                          "lookup join",
 	reflect.TypeOf(&max1RowNode{}):                             "max1row",
 	reflect.TypeOf(&ordinalityNode{}):                          "ordinality",
@@ -464,6 +474,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&showVarNode{}):                             "show",
 	reflect.TypeOf(&sortNode{}):                                "sort",
 	reflect.TypeOf(&splitNode{}):                               "split",
+	reflect.TypeOf(&textSearchTopKNode{}):                      "text search top-k",
 	reflect.TypeOf(&topKNode{}):                                "top-k",
 	reflect.TypeOf(&unsplitNode{}):                             "unsplit",
 	reflect.TypeOf(&unsplitAllNode{}):                          "unsplit all",
@@ -481,4 +492,5 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&zigzagJoinNode{}):                          "zigzag join",
 	reflect.TypeOf(&schemaChangePlanNode{}):                    "schema change",
 	reflect.TypeOf(&identifySystemNode{}):                      "identify system",
+	reflect.TypeOf(&createReplicationSlotNode{}):               "create replication slot",
 }
@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
@@ -71,6 +72,10 @@ type windowFuncHolder struct {
 	partitionIdxs  []int
 	columnOrdering colinfo.ColumnOrdering
 	frame          *tree.WindowFrame
+
+	// userDefined is set if the function is an aggregate created with CREATE
+	// AGGREGATE.
+	userDefined *exec.UserDefinedAggregate
 }
 
 // samePartition returns whether w and other have the same PARTITION BY clause.
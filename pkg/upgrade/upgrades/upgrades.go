@@ -142,6 +142,30 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
 	),
 
+	upgrade.NewTenantUpgrade(
+		"add new notifications table used by LISTEN and NOTIFY",
+		clusterversion.V24_3_AddNotificationsTable.Version(),
+		upgrade.NoPrecondition,
+		addNotificationsTable,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
+	),
+
+	upgrade.NewTenantUpgrade(
+		"add new replication_slots table used by logical replication",
+		clusterversion.V24_3_AddReplicationSlotsTable.Version(),
+		upgrade.NoPrecondition,
+		addReplicationSlotsTable,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
+	),
+
+	upgrade.NewTenantUpgrade(
+		"add new publications table used by logical replication",
+		clusterversion.V24_3_AddPublicationsTable.Version(),
+		upgrade.NoPrecondition,
+		addPublicationsTable,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
+	),
+
 	// Note: when starting a new release version, the first upgrade (for
 	// Vxy_zStart) must be a newFirstUpgrade. Keep this comment at the bottom.
 }
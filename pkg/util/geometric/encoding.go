@@ -0,0 +1,208 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geometric
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// The functions in this file implement the binary formats used by PostgreSQL
+// for the geometric types, which are made of big-endian float8 coordinates.
+// They are also used to store geometric values.
+
+// maxPoints is the maximum number of points of a path or polygon which can be
+// decoded, to avoid allocating too much memory for invalid input.
+const maxPoints = math.MaxInt32 / 16
+
+var errInsufficientData = pgerror.New(pgcode.InvalidBinaryRepresentation, "insufficient data left in message")
+
+func appendFloat(appendTo []byte, f float64) []byte {
+	return binary.BigEndian.AppendUint64(appendTo, math.Float64bits(f))
+}
+
+func appendPoint(appendTo []byte, p Point) []byte {
+	return appendFloat(appendFloat(appendTo, p.X), p.Y)
+}
+
+func appendPoints(appendTo []byte, points []Point) []byte {
+	appendTo = binary.BigEndian.AppendUint32(appendTo, uint32(len(points)))
+	for _, p := range points {
+		appendTo = appendPoint(appendTo, p)
+	}
+	return appendTo
+}
+
+// decoder reads coordinates, keeping track of the first error.
+type decoder struct {
+	b   []byte
+	err error
+}
+
+func (d *decoder) float() float64 {
+	if len(d.b) < 8 {
+		d.err = errInsufficientData
+		return 0
+	}
+	f := math.Float64frombits(binary.BigEndian.Uint64(d.b))
+	d.b = d.b[8:]
+	return f
+}
+
+func (d *decoder) point() Point {
+	x := d.float()
+	return Point{X: x, Y: d.float()}
+}
+
+func (d *decoder) points() []Point {
+	if len(d.b) < 4 {
+		d.err = errInsufficientData
+		return nil
+	}
+	n := binary.BigEndian.Uint32(d.b)
+	d.b = d.b[4:]
+	if n == 0 || n > maxPoints || uint64(len(d.b)) < uint64(n)*16 {
+		d.err = pgerror.New(pgcode.InvalidBinaryRepresentation, "invalid number of points in external value")
+		return nil
+	}
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = d.point()
+	}
+	return points
+}
+
+// finish returns the first error encountered, or an error if there is some
+// input left.
+func (d *decoder) finish() error {
+	if d.err == nil && len(d.b) > 0 {
+		d.err = pgerror.New(pgcode.InvalidBinaryRepresentation, "unexpected trailing data")
+	}
+	return d.err
+}
+
+// EncodePoint appends the binary representation of a point.
+func EncodePoint(appendTo []byte, p Point) []byte {
+	return appendPoint(appendTo, p)
+}
+
+// DecodePoint decodes the binary representation of a point.
+func DecodePoint(b []byte) (Point, error) {
+	d := decoder{b: b}
+	p := d.point()
+	return p, d.finish()
+}
+
+// EncodeBox appends the binary representation of a box, which is its upper
+// right corner followed by its lower left corner.
+func EncodeBox(appendTo []byte, b Box) []byte {
+	return appendPoint(appendPoint(appendTo, b.High), b.Low)
+}
+
+// DecodeBox decodes the binary representation of a box.
+func DecodeBox(b []byte) (Box, error) {
+	d := decoder{b: b}
+	p1 := d.point()
+	p2 := d.point()
+	return MakeBox(p1, p2), d.finish()
+}
+
+// EncodeLSeg appends the binary representation of a line segment.
+func EncodeLSeg(appendTo []byte, l LSeg) []byte {
+	return appendPoint(appendPoint(appendTo, l.P[0]), l.P[1])
+}
+
+// DecodeLSeg decodes the binary representation of a line segment.
+func DecodeLSeg(b []byte) (LSeg, error) {
+	d := decoder{b: b}
+	var l LSeg
+	l.P[0] = d.point()
+	l.P[1] = d.point()
+	return l, d.finish()
+}
+
+// EncodeLine appends the binary representation of a line, which is made of
+// its A, B and C coefficients.
+func EncodeLine(appendTo []byte, l Line) []byte {
+	return appendFloat(appendFloat(appendFloat(appendTo, l.A), l.B), l.C)
+}
+
+// DecodeLine decodes the binary representation of a line.
+func DecodeLine(b []byte) (Line, error) {
+	d := decoder{b: b}
+	var l Line
+	l.A = d.float()
+	l.B = d.float()
+	l.C = d.float()
+	if err := d.finish(); err != nil {
+		return Line{}, err
+	}
+	if fpZero(l.A) && fpZero(l.B) {
+		return Line{}, pgerror.New(pgcode.InvalidBinaryRepresentation,
+			"invalid line specification: A and B cannot both be zero")
+	}
+	return l, nil
+}
+
+// EncodePath appends the binary representation of a path, which is a byte
+// indicating whether the path is closed, followed by the number of points and
+// the points.
+func EncodePath(appendTo []byte, p Path) []byte {
+	closed := byte(0)
+	if p.Closed {
+		closed = 1
+	}
+	return appendPoints(append(appendTo, closed), p.Points)
+}
+
+// DecodePath decodes the binary representation of a path.
+func DecodePath(b []byte) (Path, error) {
+	if len(b) < 1 {
+		return Path{}, errInsufficientData
+	}
+	d := decoder{b: b[1:]}
+	p := Path{Closed: b[0] != 0, Points: d.points()}
+	return p, d.finish()
+}
+
+// EncodePolygon appends the binary representation of a polygon, which is its
+// number of points followed by the points.
+func EncodePolygon(appendTo []byte, p Polygon) []byte {
+	return appendPoints(appendTo, p.Points)
+}
+
+// DecodePolygon decodes the binary representation of a polygon.
+func DecodePolygon(b []byte) (Polygon, error) {
+	d := decoder{b: b}
+	points := d.points()
+	if err := d.finish(); err != nil {
+		return Polygon{}, err
+	}
+	return MakePolygon(points), nil
+}
+
+// EncodeCircle appends the binary representation of a circle, which is its
+// center followed by its radius.
+func EncodeCircle(appendTo []byte, c Circle) []byte {
+	return appendFloat(appendPoint(appendTo, c.Center), c.Radius)
+}
+
+// DecodeCircle decodes the binary representation of a circle.
+func DecodeCircle(b []byte) (Circle, error) {
+	d := decoder{b: b}
+	c := Circle{Center: d.point(), Radius: d.float()}
+	if err := d.finish(); err != nil {
+		return Circle{}, err
+	}
+	if c.Radius < 0 {
+		return Circle{}, pgerror.New(pgcode.InvalidBinaryRepresentation,
+			"invalid radius in external \"circle\" value")
+	}
+	return c, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geometric
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+)
+
+// The conversions in this file match the casts between the geometric types
+// and the geometry type defined by PostGIS.
+
+// AsGeomT returns the point as a geometry point.
+func (p Point) AsGeomT() geom.T {
+	return geom.NewPointFlat(geom.XY, []float64{p.X, p.Y})
+}
+
+// AsGeomT returns the path as a geometry line string.
+func (p Path) AsGeomT() geom.T {
+	return geom.NewLineStringFlat(geom.XY, flatCoords(p.Points, false /* close */))
+}
+
+// AsGeomT returns the polygon as a geometry polygon, whose exterior ring is
+// made of the vertices of the polygon.
+func (p Polygon) AsGeomT() geom.T {
+	coords := flatCoords(p.Points, true /* close */)
+	return geom.NewPolygonFlat(geom.XY, coords, []int{len(coords)})
+}
+
+// flatCoords returns the coordinates of the points. If close is true, the
+// first point is repeated at the end unless it already is the last point.
+func flatCoords(points []Point, close bool) []float64 {
+	coords := make([]float64, 0, 2*len(points)+2)
+	for _, p := range points {
+		coords = append(coords, p.X, p.Y)
+	}
+	if close && len(points) > 0 && points[0] != points[len(points)-1] {
+		coords = append(coords, points[0].X, points[0].Y)
+	}
+	return coords
+}
+
+// pointsFromFlatCoords returns the points with the given coordinates, which
+// may have more than two dimensions.
+func pointsFromFlatCoords(coords []float64, stride int) []Point {
+	points := make([]Point, 0, len(coords)/stride)
+	for i := 0; i+1 < len(coords); i += stride {
+		points = append(points, Point{X: coords[i], Y: coords[i+1]})
+	}
+	return points
+}
+
+// PointFromGeomT returns the point of a geometry point.
+func PointFromGeomT(t geom.T) (Point, error) {
+	p, ok := t.(*geom.Point)
+	if !ok || p.Empty() {
+		return Point{}, pgerror.New(pgcode.InvalidParameterValue,
+			"geometry_to_point only accepts Points")
+	}
+	return Point{X: p.X(), Y: p.Y()}, nil
+}
+
+// PathFromGeomT returns the open path going through the points of a geometry
+// line string.
+func PathFromGeomT(t geom.T) (Path, error) {
+	ls, ok := t.(*geom.LineString)
+	if !ok || ls.Empty() {
+		return Path{}, pgerror.New(pgcode.InvalidParameterValue,
+			"geometry_to_path only accepts LineStrings")
+	}
+	return Path{Points: pointsFromFlatCoords(ls.FlatCoords(), ls.Stride())}, nil
+}
+
+// PolygonFromGeomT returns the polygon made of the exterior ring of a geometry
+// polygon. The last point of the ring, which is the same as the first, is
+// omitted.
+func PolygonFromGeomT(t geom.T) (Polygon, error) {
+	poly, ok := t.(*geom.Polygon)
+	if !ok || poly.Empty() {
+		return Polygon{}, pgerror.New(pgcode.InvalidParameterValue,
+			"geometry_to_polygon only accepts Polygons")
+	}
+	ring := poly.LinearRing(0)
+	points := pointsFromFlatCoords(ring.FlatCoords(), ring.Stride())
+	if len(points) > 1 && points[0] == points[len(points)-1] {
+		points = points[:len(points)-1]
+	}
+	return MakePolygon(points), nil
+}
@@ -0,0 +1,334 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package geometric implements the PostgreSQL geometric types (point, box,
+// lseg, line, path, polygon and circle), which describe two-dimensional
+// shapes on a Cartesian plane. Unlike the spatial types of the geo package,
+// these have no spatial reference system and are always two-dimensional.
+//
+// As in PostgreSQL, most comparisons between coordinates are fuzzy: two
+// coordinates which differ by less than Epsilon are considered equal.
+package geometric
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Epsilon is the tolerance used by the fuzzy comparisons of coordinates. It
+// matches the EPSILON constant of PostgreSQL.
+const Epsilon = 1.0e-06
+
+func fpZero(a float64) bool  { return math.Abs(a) <= Epsilon }
+func fpEq(a, b float64) bool { return a == b || math.Abs(a-b) <= Epsilon }
+func fpLt(a, b float64) bool { return a+Epsilon < b }
+func fpLe(a, b float64) bool { return a <= b+Epsilon }
+func fpGt(a, b float64) bool { return a > b+Epsilon }
+func fpGe(a, b float64) bool { return a+Epsilon >= b }
+
+// Point is a point on the plane.
+type Point struct {
+	X, Y float64
+}
+
+// Box is a rectangular box whose sides are parallel to the axes. High is the
+// upper right corner and Low the lower left corner.
+type Box struct {
+	High, Low Point
+}
+
+// LSeg is a finite line segment.
+type LSeg struct {
+	P [2]Point
+}
+
+// Line is an infinite line, represented by the coefficients of the linear
+// equation Ax + By + C = 0. A and B are never both zero.
+type Line struct {
+	A, B, C float64
+}
+
+// Path is a list of connected points. If the path is closed, the last point
+// is connected back to the first.
+type Path struct {
+	Points []Point
+	Closed bool
+}
+
+// Polygon is a closed path which encloses an area. BoundBox is the smallest
+// box containing all of its points.
+type Polygon struct {
+	Points   []Point
+	BoundBox Box
+}
+
+// Circle is a circle, given by its center and radius.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// MakeBox returns the box with the given opposite corners.
+func MakeBox(p1, p2 Point) Box {
+	return Box{
+		High: Point{X: math.Max(p1.X, p2.X), Y: math.Max(p1.Y, p2.Y)},
+		Low:  Point{X: math.Min(p1.X, p2.X), Y: math.Min(p1.Y, p2.Y)},
+	}
+}
+
+// MakeLine returns the line going through the two given points, which must
+// be distinct.
+func MakeLine(p1, p2 Point) Line {
+	var l Line
+	switch m := Slope(p1, p2); {
+	case math.IsInf(m, 0):
+		// Vertical line.
+		l = Line{A: -1, B: 0, C: p1.X}
+	case m == 0:
+		// Horizontal line.
+		l = Line{A: 0, B: -1, C: p1.Y}
+	default:
+		l = Line{A: m, B: -1, C: p1.Y - m*p1.X}
+	}
+	// Avoid creating -0.
+	if l.C == 0 {
+		l.C = 0
+	}
+	return l
+}
+
+// MakePolygon returns the polygon with the given vertices.
+func MakePolygon(points []Point) Polygon {
+	return Polygon{Points: points, BoundBox: boundBox(points)}
+}
+
+// boundBox returns the smallest box containing all of the given points, which
+// must not be empty.
+func boundBox(points []Point) Box {
+	b := Box{High: points[0], Low: points[0]}
+	for _, p := range points[1:] {
+		b.High.X = math.Max(b.High.X, p.X)
+		b.High.Y = math.Max(b.High.Y, p.Y)
+		b.Low.X = math.Min(b.Low.X, p.X)
+		b.Low.Y = math.Min(b.Low.Y, p.Y)
+	}
+	return b
+}
+
+// Slope returns the slope of the line going through the two given points. It
+// is infinite if the line is vertical.
+func Slope(p1, p2 Point) float64 {
+	if fpEq(p1.X, p2.X) {
+		return math.Inf(1)
+	}
+	if fpEq(p1.Y, p2.Y) {
+		return 0
+	}
+	return (p1.Y - p2.Y) / (p1.X - p2.X)
+}
+
+// String implements the fmt.Stringer interface.
+func (p Point) String() string {
+	var b strings.Builder
+	writePoint(&b, p)
+	return b.String()
+}
+
+// String implements the fmt.Stringer interface.
+func (b Box) String() string {
+	var sb strings.Builder
+	writePoint(&sb, b.High)
+	sb.WriteByte(',')
+	writePoint(&sb, b.Low)
+	return sb.String()
+}
+
+// String implements the fmt.Stringer interface.
+func (l LSeg) String() string {
+	var b strings.Builder
+	b.WriteByte('[')
+	writePoints(&b, l.P[:])
+	b.WriteByte(']')
+	return b.String()
+}
+
+// String implements the fmt.Stringer interface.
+func (l Line) String() string {
+	var b strings.Builder
+	b.WriteByte('{')
+	writeFloat(&b, l.A)
+	b.WriteByte(',')
+	writeFloat(&b, l.B)
+	b.WriteByte(',')
+	writeFloat(&b, l.C)
+	b.WriteByte('}')
+	return b.String()
+}
+
+// String implements the fmt.Stringer interface.
+func (p Path) String() string {
+	var b strings.Builder
+	if p.Closed {
+		b.WriteByte('(')
+	} else {
+		b.WriteByte('[')
+	}
+	writePoints(&b, p.Points)
+	if p.Closed {
+		b.WriteByte(')')
+	} else {
+		b.WriteByte(']')
+	}
+	return b.String()
+}
+
+// String implements the fmt.Stringer interface.
+func (p Polygon) String() string {
+	var b strings.Builder
+	b.WriteByte('(')
+	writePoints(&b, p.Points)
+	b.WriteByte(')')
+	return b.String()
+}
+
+// String implements the fmt.Stringer interface.
+func (c Circle) String() string {
+	var b strings.Builder
+	b.WriteByte('<')
+	writePoint(&b, c.Center)
+	b.WriteByte(',')
+	writeFloat(&b, c.Radius)
+	b.WriteByte('>')
+	return b.String()
+}
+
+func writePoints(b *strings.Builder, points []Point) {
+	for i, p := range points {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writePoint(b, p)
+	}
+}
+
+func writePoint(b *strings.Builder, p Point) {
+	b.WriteByte('(')
+	writeFloat(b, p.X)
+	b.WriteByte(',')
+	writeFloat(b, p.Y)
+	b.WriteByte(')')
+}
+
+// writeFloat writes a coordinate the way PostgreSQL formats float8 values.
+func writeFloat(b *strings.Builder, f float64) {
+	switch {
+	case math.IsInf(f, 1):
+		b.WriteString("Infinity")
+	case math.IsInf(f, -1):
+		b.WriteString("-Infinity")
+	default:
+		b.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+	}
+}
+
+// Compare returns -1, 0 or 1 depending on whether p sorts before, equal to or
+// after p2. Points are ordered by their X and then their Y coordinates. The
+// comparison is exact, and only used to give a total order to the values.
+func (p Point) Compare(p2 Point) int {
+	if c := compareFloats(p.X, p2.X); c != 0 {
+		return c
+	}
+	return compareFloats(p.Y, p2.Y)
+}
+
+// Compare returns -1, 0 or 1 depending on whether b sorts before, equal to or
+// after b2.
+func (b Box) Compare(b2 Box) int {
+	if c := b.High.Compare(b2.High); c != 0 {
+		return c
+	}
+	return b.Low.Compare(b2.Low)
+}
+
+// Compare returns -1, 0 or 1 depending on whether l sorts before, equal to or
+// after l2.
+func (l LSeg) Compare(l2 LSeg) int {
+	if c := l.P[0].Compare(l2.P[0]); c != 0 {
+		return c
+	}
+	return l.P[1].Compare(l2.P[1])
+}
+
+// Compare returns -1, 0 or 1 depending on whether l sorts before, equal to or
+// after l2.
+func (l Line) Compare(l2 Line) int {
+	if c := compareFloats(l.A, l2.A); c != 0 {
+		return c
+	}
+	if c := compareFloats(l.B, l2.B); c != 0 {
+		return c
+	}
+	return compareFloats(l.C, l2.C)
+}
+
+// Compare returns -1, 0 or 1 depending on whether p sorts before, equal to or
+// after p2. Open paths sort before closed paths.
+func (p Path) Compare(p2 Path) int {
+	if p.Closed != p2.Closed {
+		if p2.Closed {
+			return -1
+		}
+		return 1
+	}
+	return comparePoints(p.Points, p2.Points)
+}
+
+// Compare returns -1, 0 or 1 depending on whether p sorts before, equal to or
+// after p2.
+func (p Polygon) Compare(p2 Polygon) int {
+	return comparePoints(p.Points, p2.Points)
+}
+
+// Compare returns -1, 0 or 1 depending on whether c sorts before, equal to or
+// after c2.
+func (c Circle) Compare(c2 Circle) int {
+	if cmp := c.Center.Compare(c2.Center); cmp != 0 {
+		return cmp
+	}
+	return compareFloats(c.Radius, c2.Radius)
+}
+
+func comparePoints(a, b []Point) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := a[i].Compare(b[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
+// compareFloats compares two floats, sorting NaN after all other values.
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	case a == b:
+		return 0
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a):
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geometric
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		typ      string
+		input    string
+		expected string
+		err      string
+	}{
+		{"point", "(1,2)", "(1,2)", ""},
+		{"point", " ( 1.5 , -2e3 ) ", "(1.5,-2000)", ""},
+		{"point", "1,2", "(1,2)", ""},
+		{"point", "(1e20,0.000001)", "(1e+20,1e-06)", ""},
+		{"point", "(Infinity,-inf)", "(Infinity,-Infinity)", ""},
+		{"point", "(1,2", "", `invalid input syntax for type point: "(1,2"`},
+		{"point", "(1,2,3)", "", `invalid input syntax for type point: "(1,2,3)"`},
+		{"point", "(a,b)", "", `invalid input syntax for type point: "(a,b)"`},
+		{"point", "", "", `invalid input syntax for type point: ""`},
+
+		{"box", "(1,2),(3,4)", "(3,4),(1,2)", ""},
+		{"box", "((3,2),(1,4))", "(3,4),(1,2)", ""},
+		{"box", "1,2,3,4", "(3,4),(1,2)", ""},
+		{"box", "(1,2,3,4)", "(3,4),(1,2)", ""},
+		{"box", "[(1,2),(3,4)]", "", `invalid input syntax for type box: "[(1,2),(3,4)]"`},
+		{"box", "(1,2)", "", `invalid input syntax for type box: "(1,2)"`},
+
+		{"lseg", "[(1,2),(3,4)]", "[(1,2),(3,4)]", ""},
+		{"lseg", "((3,4),(1,2))", "[(3,4),(1,2)]", ""},
+		{"lseg", "1,2,3,4", "[(1,2),(3,4)]", ""},
+		{"lseg", "[(1,2),(3,4),(5,6)]", "", `invalid input syntax for type lseg: "[(1,2),(3,4),(5,6)]"`},
+
+		{"line", "{1,2,3}", "{1,2,3}", ""},
+		{"line", "{ 1 , -1 , 0 }", "{1,-1,0}", ""},
+		{"line", "[(0,0),(1,1)]", "{1,-1,0}", ""},
+		{"line", "(1,0),(1,5)", "{-1,0,1}", ""},
+		{"line", "(0,3),(5,3)", "{0,-1,3}", ""},
+		{"line", "{0,0,1}", "", "invalid line specification: A and B cannot both be zero"},
+		{"line", "[(1,1),(1,1)]", "", "invalid line specification: must be two distinct points"},
+		{"line", "{1,2}", "", `invalid input syntax for type line: "{1,2}"`},
+
+		{"path", "[(1,2),(3,4),(5,6)]", "[(1,2),(3,4),(5,6)]", ""},
+		{"path", "((1,2),(3,4),(5,6))", "((1,2),(3,4),(5,6))", ""},
+		{"path", "(1,2),(3,4)", "((1,2),(3,4))", ""},
+		{"path", "1,2,3,4,5,6", "((1,2),(3,4),(5,6))", ""},
+		{"path", "(1,2,3,4)", "((1,2),(3,4))", ""},
+		{"path", "[(1,2)]", "[(1,2)]", ""},
+		{"path", "[(1,2),(3,4))", "", `invalid input syntax for type path: "[(1,2),(3,4))"`},
+		{"path", "[]", "", `invalid input syntax for type path: "[]"`},
+
+		{"polygon", "((0,0),(0,1),(1,1))", "((0,0),(0,1),(1,1))", ""},
+		{"polygon", "0,0,0,1,1,1", "((0,0),(0,1),(1,1))", ""},
+		{"polygon", "[(0,0),(0,1)]", "", `invalid input syntax for type polygon: "[(0,0),(0,1)]"`},
+
+		{"circle", "<(1,2),3>", "<(1,2),3>", ""},
+		{"circle", "((1,2),3)", "<(1,2),3>", ""},
+		{"circle", "(1,2),3", "<(1,2),3>", ""},
+		{"circle", "1,2,3", "<(1,2),3>", ""},
+		{"circle", "<(1,2),-3>", "", `invalid input syntax for type circle: "<(1,2),-3>"`},
+		{"circle", "<(1,2),3)", "", `invalid input syntax for type circle: "<(1,2),3)"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s/%s", tc.typ, tc.input), func(t *testing.T) {
+			var s fmt.Stringer
+			var err error
+			switch tc.typ {
+			case "point":
+				s, err = ParsePoint(tc.input)
+			case "box":
+				s, err = ParseBox(tc.input)
+			case "lseg":
+				s, err = ParseLSeg(tc.input)
+			case "line":
+				s, err = ParseLine(tc.input)
+			case "path":
+				s, err = ParsePath(tc.input)
+			case "polygon":
+				s, err = ParsePolygon(tc.input)
+			case "circle":
+				s, err = ParseCircle(tc.input)
+			}
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, s.String())
+		})
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		p := RandomPoint(rng)
+		decodedPoint, err := DecodePoint(EncodePoint(nil, p))
+		require.NoError(t, err)
+		require.Equal(t, p, decodedPoint)
+
+		b := RandomBox(rng)
+		decodedBox, err := DecodeBox(EncodeBox(nil, b))
+		require.NoError(t, err)
+		require.Equal(t, b, decodedBox)
+
+		lseg := RandomLSeg(rng)
+		decodedLSeg, err := DecodeLSeg(EncodeLSeg(nil, lseg))
+		require.NoError(t, err)
+		require.Equal(t, lseg, decodedLSeg)
+
+		line := RandomLine(rng)
+		decodedLine, err := DecodeLine(EncodeLine(nil, line))
+		require.NoError(t, err)
+		require.Equal(t, line, decodedLine)
+
+		path := RandomPath(rng)
+		decodedPath, err := DecodePath(EncodePath(nil, path))
+		require.NoError(t, err)
+		require.Equal(t, path, decodedPath)
+
+		poly := RandomPolygon(rng)
+		decodedPoly, err := DecodePolygon(EncodePolygon(nil, poly))
+		require.NoError(t, err)
+		require.Equal(t, poly, decodedPoly)
+
+		c := RandomCircle(rng)
+		decodedCircle, err := DecodeCircle(EncodeCircle(nil, c))
+		require.NoError(t, err)
+		require.Equal(t, c, decodedCircle)
+	}
+
+	_, err := DecodePoint([]byte{1, 2, 3})
+	require.EqualError(t, err, "insufficient data left in message")
+	_, err = DecodeBox(make([]byte, 40))
+	require.EqualError(t, err, "unexpected trailing data")
+	_, err = DecodePath([]byte{0, 0, 0, 0, 0})
+	require.EqualError(t, err, "invalid number of points in external value")
+	_, err = DecodePolygon([]byte{0, 0, 1, 0})
+	require.EqualError(t, err, "invalid number of points in external value")
+	_, err = DecodeLine(make([]byte, 24))
+	require.EqualError(t, err, "invalid line specification: A and B cannot both be zero")
+}
+
+func TestCompare(t *testing.T) {
+	p1, p2 := Point{X: 1, Y: 2}, Point{X: 1, Y: 3}
+	require.Equal(t, -1, p1.Compare(p2))
+	require.Equal(t, 1, p2.Compare(p1))
+	require.Equal(t, 0, p1.Compare(p1))
+
+	open := Path{Points: []Point{p1, p2}}
+	closed := Path{Points: []Point{p1}, Closed: true}
+	require.Equal(t, -1, open.Compare(closed))
+	require.Equal(t, 1, MakePolygon([]Point{p1, p2}).Compare(MakePolygon([]Point{p1})))
+}
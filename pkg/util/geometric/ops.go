@@ -0,0 +1,845 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geometric
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// The operations in this file follow the definitions of src/backend/utils/adt/
+// geo_ops.c in PostgreSQL.
+
+// Add returns the sum of the two points.
+func (p Point) Add(p2 Point) Point {
+	return Point{X: p.X + p2.X, Y: p.Y + p2.Y}
+}
+
+// Sub returns the difference of the two points.
+func (p Point) Sub(p2 Point) Point {
+	return Point{X: p.X - p2.X, Y: p.Y - p2.Y}
+}
+
+// Mul returns the product of the two points, treated as complex numbers.
+// Multiplying a shape by a point scales and rotates it around the origin.
+func (p Point) Mul(p2 Point) Point {
+	return Point{X: p.X*p2.X - p.Y*p2.Y, Y: p.X*p2.Y + p.Y*p2.X}
+}
+
+// Div returns the quotient of the two points, treated as complex numbers.
+func (p Point) Div(p2 Point) (Point, error) {
+	div := p2.X*p2.X + p2.Y*p2.Y
+	if div == 0 {
+		return Point{}, pgerror.New(pgcode.DivisionByZero, "division by zero")
+	}
+	return Point{
+		X: (p.X*p2.X + p.Y*p2.Y) / div,
+		Y: (p.Y*p2.X - p.X*p2.Y) / div,
+	}, nil
+}
+
+// Distance returns the distance between the two points.
+func (p Point) Distance(p2 Point) float64 {
+	return math.Hypot(p.X-p2.X, p.Y-p2.Y)
+}
+
+// Width returns the width of the box.
+func (b Box) Width() float64 { return b.High.X - b.Low.X }
+
+// Height returns the height of the box.
+func (b Box) Height() float64 { return b.High.Y - b.Low.Y }
+
+// Area returns the area of the box.
+func (b Box) Area() float64 { return b.Width() * b.Height() }
+
+// Center returns the center of the box.
+func (b Box) Center() Point {
+	return Point{X: (b.High.X + b.Low.X) / 2, Y: (b.High.Y + b.Low.Y) / 2}
+}
+
+// Diagonal returns the diagonal of the box, from its upper right to its lower
+// left corner.
+func (b Box) Diagonal() LSeg {
+	return LSeg{P: [2]Point{b.High, b.Low}}
+}
+
+// Corners returns the corners of the box, counterclockwise from the lower left
+// one.
+func (b Box) Corners() []Point {
+	return []Point{
+		b.Low,
+		{X: b.High.X, Y: b.Low.Y},
+		b.High,
+		{X: b.Low.X, Y: b.High.Y},
+	}
+}
+
+// edges returns the four sides of the box.
+func (b Box) edges() [4]LSeg {
+	c := b.Corners()
+	return [4]LSeg{
+		{P: [2]Point{c[0], c[1]}},
+		{P: [2]Point{c[1], c[2]}},
+		{P: [2]Point{c[2], c[3]}},
+		{P: [2]Point{c[3], c[0]}},
+	}
+}
+
+// Translate returns the box moved by the given offset.
+func (b Box) Translate(p Point) Box {
+	return Box{High: b.High.Add(p), Low: b.Low.Add(p)}
+}
+
+// Mul returns the box with its corners multiplied by the given point.
+func (b Box) Mul(p Point) Box {
+	return MakeBox(b.High.Mul(p), b.Low.Mul(p))
+}
+
+// Div returns the box with its corners divided by the given point.
+func (b Box) Div(p Point) (Box, error) {
+	high, err := b.High.Div(p)
+	if err != nil {
+		return Box{}, err
+	}
+	low, err := b.Low.Div(p)
+	if err != nil {
+		return Box{}, err
+	}
+	return MakeBox(high, low), nil
+}
+
+// Union returns the smallest box containing both boxes.
+func (b Box) Union(b2 Box) Box {
+	return Box{
+		High: Point{X: math.Max(b.High.X, b2.High.X), Y: math.Max(b.High.Y, b2.High.Y)},
+		Low:  Point{X: math.Min(b.Low.X, b2.Low.X), Y: math.Min(b.Low.Y, b2.Low.Y)},
+	}
+}
+
+// ContainsPoint returns whether the point is inside the box or on its
+// boundary.
+func (b Box) ContainsPoint(p Point) bool {
+	return b.High.X >= p.X && b.Low.X <= p.X && b.High.Y >= p.Y && b.Low.Y <= p.Y
+}
+
+// ContainsBox returns whether b2 is inside b.
+func (b Box) ContainsBox(b2 Box) bool {
+	return fpGe(b.High.X, b2.High.X) && fpLe(b.Low.X, b2.Low.X) &&
+		fpGe(b.High.Y, b2.High.Y) && fpLe(b.Low.Y, b2.Low.Y)
+}
+
+// Overlaps returns whether the two boxes have a point in common.
+func (b Box) Overlaps(b2 Box) bool {
+	return fpLe(b.Low.X, b2.High.X) && fpLe(b2.Low.X, b.High.X) &&
+		fpLe(b.Low.Y, b2.High.Y) && fpLe(b2.Low.Y, b.High.Y)
+}
+
+// Distance returns the distance between the centers of the two boxes.
+func (b Box) Distance(b2 Box) float64 {
+	return b.Center().Distance(b2.Center())
+}
+
+// DistanceToPoint returns the distance from the point to the closest point of
+// the box, which is zero if the point is inside the box.
+func (b Box) DistanceToPoint(p Point) float64 {
+	if b.ContainsPoint(p) {
+		return 0
+	}
+	d := math.Inf(1)
+	for _, e := range b.edges() {
+		d = math.Min(d, e.DistanceToPoint(p))
+	}
+	return d
+}
+
+// Polygon returns the polygon with the corners of the box as vertices.
+func (b Box) Polygon() Polygon {
+	// Postgres lists the vertices clockwise from the lower left corner.
+	c := b.Corners()
+	return Polygon{Points: []Point{c[0], c[3], c[2], c[1]}, BoundBox: b}
+}
+
+// Circle returns the circle circumscribed about the box.
+func (b Box) Circle() Circle {
+	center := b.Center()
+	return Circle{Center: center, Radius: center.Distance(b.High)}
+}
+
+// Length returns the length of the line segment.
+func (l LSeg) Length() float64 {
+	return l.P[0].Distance(l.P[1])
+}
+
+// Center returns the middle of the line segment.
+func (l LSeg) Center() Point {
+	return Point{X: (l.P[0].X + l.P[1].X) / 2, Y: (l.P[0].Y + l.P[1].Y) / 2}
+}
+
+// Line returns the line going through the line segment.
+func (l LSeg) Line() Line {
+	return MakeLine(l.P[0], l.P[1])
+}
+
+// Box returns the box with the line segment as a diagonal.
+func (l LSeg) Box() Box {
+	return MakeBox(l.P[0], l.P[1])
+}
+
+// ContainsPoint returns whether the point is on the line segment.
+func (l LSeg) ContainsPoint(p Point) bool {
+	return fpEq(p.Distance(l.P[0])+p.Distance(l.P[1]), l.Length())
+}
+
+// cross returns the cross product of b-a and c-a, whose sign tells on which
+// side of the line going through a and b the point c is.
+func cross(a, b, c Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// Intersects returns whether the two line segments have a point in common.
+func (l LSeg) Intersects(l2 LSeg) bool {
+	d1 := cross(l2.P[0], l2.P[1], l.P[0])
+	d2 := cross(l2.P[0], l2.P[1], l.P[1])
+	d3 := cross(l.P[0], l.P[1], l2.P[0])
+	d4 := cross(l.P[0], l.P[1], l2.P[1])
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	// The segments don't cross, but they may touch.
+	return l2.ContainsPoint(l.P[0]) || l2.ContainsPoint(l.P[1]) ||
+		l.ContainsPoint(l2.P[0]) || l.ContainsPoint(l2.P[1])
+}
+
+// IntersectsBox returns whether the line segment has a point in common with
+// the box.
+func (l LSeg) IntersectsBox(b Box) bool {
+	if !l.Box().Overlaps(b) {
+		return false
+	}
+	if b.ContainsPoint(l.P[0]) || b.ContainsPoint(l.P[1]) {
+		return true
+	}
+	for _, e := range b.edges() {
+		if l.Intersects(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// IntersectsLine returns whether the line segment crosses the line. As in
+// PostgreSQL, a line segment parallel to the line never intersects it.
+func (l LSeg) IntersectsLine(line Line) bool {
+	p, ok := l.Line().intersection(line)
+	return ok && l.ContainsPoint(p)
+}
+
+// DistanceToPoint returns the distance from the point to the closest point of
+// the line segment.
+func (l LSeg) DistanceToPoint(p Point) float64 {
+	d := l.P[1].Sub(l.P[0])
+	lenSq := d.X*d.X + d.Y*d.Y
+	if lenSq == 0 {
+		return p.Distance(l.P[0])
+	}
+	t := ((p.X-l.P[0].X)*d.X + (p.Y-l.P[0].Y)*d.Y) / lenSq
+	t = math.Max(0, math.Min(1, t))
+	return p.Distance(Point{X: l.P[0].X + t*d.X, Y: l.P[0].Y + t*d.Y})
+}
+
+// Distance returns the distance between the closest points of the two line
+// segments.
+func (l LSeg) Distance(l2 LSeg) float64 {
+	if l.Intersects(l2) {
+		return 0
+	}
+	return math.Min(
+		math.Min(l.DistanceToPoint(l2.P[0]), l.DistanceToPoint(l2.P[1])),
+		math.Min(l2.DistanceToPoint(l.P[0]), l2.DistanceToPoint(l.P[1])),
+	)
+}
+
+// DistanceToLine returns the distance between the line segment and the line.
+func (l LSeg) DistanceToLine(line Line) float64 {
+	if l.IntersectsLine(line) {
+		return 0
+	}
+	return math.Min(line.DistanceToPoint(l.P[0]), line.DistanceToPoint(l.P[1]))
+}
+
+// DistanceToBox returns the distance between the line segment and the box.
+func (l LSeg) DistanceToBox(b Box) float64 {
+	if l.IntersectsBox(b) {
+		return 0
+	}
+	d := math.Inf(1)
+	for _, e := range b.edges() {
+		d = math.Min(d, l.Distance(e))
+	}
+	return d
+}
+
+// eval returns Ax + By + C for the given point.
+func (l Line) eval(p Point) float64 {
+	return l.A*p.X + l.B*p.Y + l.C
+}
+
+// ContainsPoint returns whether the point is on the line.
+func (l Line) ContainsPoint(p Point) bool {
+	return fpZero(l.eval(p))
+}
+
+// DistanceToPoint returns the distance from the point to the line.
+func (l Line) DistanceToPoint(p Point) float64 {
+	return math.Abs(l.eval(p)) / math.Hypot(l.A, l.B)
+}
+
+// intersection returns the intersection point of the two lines. It returns
+// false if the lines are parallel, including if they are the same line.
+func (l Line) intersection(l2 Line) (Point, bool) {
+	var x, y float64
+	switch {
+	case !fpZero(l.B):
+		if fpEq(l2.A, l.A*(l2.B/l.B)) {
+			return Point{}, false
+		}
+		x = (l.B*l2.C - l2.B*l.C) / (l.A*l2.B - l2.A*l.B)
+		y = -(l.A*x + l.C) / l.B
+	case !fpZero(l2.B):
+		if fpEq(l.A, l2.A*(l.B/l2.B)) {
+			return Point{}, false
+		}
+		x = (l2.B*l.C - l.B*l2.C) / (l2.A*l.B - l.A*l2.B)
+		y = -(l2.A*x + l2.C) / l2.B
+	default:
+		return Point{}, false
+	}
+	// Avoid returning -0.
+	if x == 0 {
+		x = 0
+	}
+	if y == 0 {
+		y = 0
+	}
+	return Point{X: x, Y: y}, true
+}
+
+// Intersects returns whether the two lines intersect. As in PostgreSQL,
+// parallel lines never intersect, even if they are the same line.
+func (l Line) Intersects(l2 Line) bool {
+	_, ok := l.intersection(l2)
+	return ok
+}
+
+// IntersectsBox returns whether the line goes through the box.
+func (l Line) IntersectsBox(b Box) bool {
+	for _, e := range b.edges() {
+		if e.IntersectsLine(l) {
+			return true
+		}
+	}
+	return false
+}
+
+// Distance returns the distance between the two lines, which is zero unless
+// they are parallel.
+func (l Line) Distance(l2 Line) float64 {
+	if l.Intersects(l2) {
+		return 0
+	}
+	var ratio float64
+	switch {
+	case !fpZero(l.A) && !math.IsNaN(l.A) && !fpZero(l2.A) && !math.IsNaN(l2.A):
+		ratio = l.A / l2.A
+	case !fpZero(l.B) && !math.IsNaN(l.B) && !fpZero(l2.B) && !math.IsNaN(l2.B):
+		ratio = l.B / l2.B
+	default:
+		ratio = 1
+	}
+	return math.Abs(l.C-ratio*l2.C) / math.Hypot(l.A, l.B)
+}
+
+// DistanceToBox returns the distance between the line and the box.
+func (l Line) DistanceToBox(b Box) float64 {
+	if l.IntersectsBox(b) {
+		return 0
+	}
+	d := math.Inf(1)
+	for _, c := range b.Corners() {
+		d = math.Min(d, l.DistanceToPoint(c))
+	}
+	return d
+}
+
+// ContainsLSeg returns whether the line segment is on the line.
+func (l Line) ContainsLSeg(s LSeg) bool {
+	return l.ContainsPoint(s.P[0]) && l.ContainsPoint(s.P[1])
+}
+
+// segments calls fn with each of the segments of the path, including the one
+// closing it if the path is closed. It stops if fn returns false.
+func segments(points []Point, closed bool, fn func(LSeg) bool) {
+	for i := range points {
+		var prev int
+		if i > 0 {
+			prev = i - 1
+		} else if closed {
+			prev = len(points) - 1
+		} else {
+			continue
+		}
+		if !fn(LSeg{P: [2]Point{points[prev], points[i]}}) {
+			return
+		}
+	}
+}
+
+// Length returns the total length of the segments of the path.
+func (p Path) Length() float64 {
+	var length float64
+	segments(p.Points, p.Closed, func(s LSeg) bool {
+		length += s.Length()
+		return true
+	})
+	return length
+}
+
+// Area returns the area enclosed by a closed path. It returns false if the path
+// is open.
+func (p Path) Area() (float64, bool) {
+	if !p.Closed {
+		return 0, false
+	}
+	return shoelaceArea(p.Points), true
+}
+
+func shoelaceArea(points []Point) float64 {
+	var area float64
+	for i := range points {
+		j := (i + 1) % len(points)
+		area += points[i].X*points[j].Y - points[i].Y*points[j].X
+	}
+	return math.Abs(area) / 2
+}
+
+// transform returns a copy of the path with fn applied to each point.
+func (p Path) transform(fn func(Point) (Point, error)) (Path, error) {
+	res := Path{Points: make([]Point, len(p.Points)), Closed: p.Closed}
+	for i, pt := range p.Points {
+		var err error
+		if res.Points[i], err = fn(pt); err != nil {
+			return Path{}, err
+		}
+	}
+	return res, nil
+}
+
+// Translate returns the path moved by the given offset.
+func (p Path) Translate(offset Point) Path {
+	res, _ := p.transform(func(pt Point) (Point, error) { return pt.Add(offset), nil })
+	return res
+}
+
+// Mul returns the path with its points multiplied by the given point.
+func (p Path) Mul(factor Point) Path {
+	res, _ := p.transform(func(pt Point) (Point, error) { return pt.Mul(factor), nil })
+	return res
+}
+
+// Div returns the path with its points divided by the given point.
+func (p Path) Div(divisor Point) (Path, error) {
+	return p.transform(func(pt Point) (Point, error) { return pt.Div(divisor) })
+}
+
+// Concat returns the path made of the points of p followed by the points of
+// p2. It returns false if either path is closed.
+func (p Path) Concat(p2 Path) (Path, bool) {
+	if p.Closed || p2.Closed {
+		return Path{}, false
+	}
+	points := make([]Point, 0, len(p.Points)+len(p2.Points))
+	points = append(append(points, p.Points...), p2.Points...)
+	return Path{Points: points}, true
+}
+
+// ContainsPoint returns whether the point is on an open path, or inside or on
+// a closed path.
+func (p Path) ContainsPoint(pt Point) bool {
+	if p.Closed {
+		return pointInside(pt, p.Points) != outside
+	}
+	contains := false
+	segments(p.Points, false /* closed */, func(s LSeg) bool {
+		contains = s.ContainsPoint(pt)
+		return !contains
+	})
+	return contains
+}
+
+// Intersects returns whether a segment of p intersects a segment of p2.
+func (p Path) Intersects(p2 Path) bool {
+	if !boundBox(p.Points).Overlaps(boundBox(p2.Points)) {
+		return false
+	}
+	intersects := false
+	segments(p.Points, p.Closed, func(s LSeg) bool {
+		segments(p2.Points, p2.Closed, func(s2 LSeg) bool {
+			intersects = s.Intersects(s2)
+			return !intersects
+		})
+		return !intersects
+	})
+	return intersects
+}
+
+// DistanceToPoint returns the distance from the point to the closest segment
+// of the path. It returns false if the path has no segments.
+func (p Path) DistanceToPoint(pt Point) (float64, bool) {
+	d, ok := math.Inf(1), false
+	segments(p.Points, p.Closed, func(s LSeg) bool {
+		d, ok = math.Min(d, s.DistanceToPoint(pt)), true
+		return true
+	})
+	return d, ok
+}
+
+// Distance returns the distance between the closest segments of the two
+// paths. It returns false if either path has no segments.
+func (p Path) Distance(p2 Path) (float64, bool) {
+	d, ok := math.Inf(1), false
+	segments(p.Points, p.Closed, func(s LSeg) bool {
+		segments(p2.Points, p2.Closed, func(s2 LSeg) bool {
+			d, ok = math.Min(d, s.Distance(s2)), true
+			return true
+		})
+		return true
+	})
+	return d, ok
+}
+
+// Polygon returns the polygon with the points of the closed path as vertices.
+func (p Path) Polygon() (Polygon, error) {
+	if !p.Closed {
+		return Polygon{}, pgerror.New(pgcode.InvalidParameterValue,
+			"open path cannot be converted to polygon")
+	}
+	return MakePolygon(append([]Point(nil), p.Points...)), nil
+}
+
+// Path returns the closed path with the vertices of the polygon.
+func (p Polygon) Path() Path {
+	return Path{Points: append([]Point(nil), p.Points...), Closed: true}
+}
+
+// Center returns the average of the vertices of the polygon.
+func (p Polygon) Center() Point {
+	var c Point
+	for _, pt := range p.Points {
+		c = c.Add(pt)
+	}
+	n := float64(len(p.Points))
+	return Point{X: c.X / n, Y: c.Y / n}
+}
+
+// Circle returns the circle centered on the average of the vertices of the
+// polygon, whose radius is the average distance from the center to the
+// vertices.
+func (p Polygon) Circle() Circle {
+	c := p.Center()
+	var r float64
+	for _, pt := range p.Points {
+		r += pt.Distance(c)
+	}
+	return Circle{Center: c, Radius: r / float64(len(p.Points))}
+}
+
+// ContainsPoint returns whether the point is inside the polygon or on its
+// boundary.
+func (p Polygon) ContainsPoint(pt Point) bool {
+	return pointInside(pt, p.Points) != outside
+}
+
+// ContainsPolygon returns whether p2 is inside p.
+func (p Polygon) ContainsPolygon(p2 Polygon) bool {
+	if !p.BoundBox.ContainsBox(p2.BoundBox) {
+		return false
+	}
+	contains := true
+	segments(p2.Points, true /* closed */, func(s LSeg) bool {
+		contains = p.containsLSeg(s)
+		return contains
+	})
+	return contains
+}
+
+// containsLSeg returns whether the line segment is inside the polygon or on
+// its boundary.
+func (p Polygon) containsLSeg(s LSeg) bool {
+	if !p.ContainsPoint(s.P[0]) || !p.ContainsPoint(s.P[1]) || !p.ContainsPoint(s.Center()) {
+		return false
+	}
+	// Both ends of the segment are inside the polygon. It leaves the polygon
+	// if it properly crosses an edge.
+	crosses := false
+	segments(p.Points, true /* closed */, func(e LSeg) bool {
+		d1, d2 := cross(e.P[0], e.P[1], s.P[0]), cross(e.P[0], e.P[1], s.P[1])
+		d3, d4 := cross(s.P[0], s.P[1], e.P[0]), cross(s.P[0], s.P[1], e.P[1])
+		crosses = !fpZero(d1) && !fpZero(d2) && !fpZero(d3) && !fpZero(d4) &&
+			(d1 > 0) != (d2 > 0) && (d3 > 0) != (d4 > 0)
+		return !crosses
+	})
+	return !crosses
+}
+
+// Overlaps returns whether the two polygons have a point in common.
+func (p Polygon) Overlaps(p2 Polygon) bool {
+	if !p.BoundBox.Overlaps(p2.BoundBox) {
+		return false
+	}
+	overlaps := false
+	segments(p.Points, true /* closed */, func(s LSeg) bool {
+		segments(p2.Points, true /* closed */, func(s2 LSeg) bool {
+			overlaps = s.Intersects(s2)
+			return !overlaps
+		})
+		return !overlaps
+	})
+	// If no edges intersect, one polygon may still be inside the other.
+	return overlaps || p2.ContainsPoint(p.Points[0]) || p.ContainsPoint(p2.Points[0])
+}
+
+// DistanceToPoint returns the distance from the point to the polygon, which is
+// zero if the point is inside the polygon.
+func (p Polygon) DistanceToPoint(pt Point) float64 {
+	if p.ContainsPoint(pt) {
+		return 0
+	}
+	d, _ := p.Path().DistanceToPoint(pt)
+	return d
+}
+
+// Distance returns the distance between the two polygons, which is zero if
+// they overlap.
+func (p Polygon) Distance(p2 Polygon) float64 {
+	if p.Overlaps(p2) {
+		return 0
+	}
+	d, _ := p.Path().Distance(p2.Path())
+	return d
+}
+
+// Area returns the area of the circle.
+func (c Circle) Area() float64 {
+	return math.Pi * c.Radius * c.Radius
+}
+
+// Diameter returns the diameter of the circle.
+func (c Circle) Diameter() float64 {
+	return 2 * c.Radius
+}
+
+// ContainsPoint returns whether the point is inside the circle or on its
+// boundary.
+func (c Circle) ContainsPoint(p Point) bool {
+	return c.Center.Distance(p) <= c.Radius
+}
+
+// ContainsCircle returns whether c2 is inside c.
+func (c Circle) ContainsCircle(c2 Circle) bool {
+	return fpLe(c.Center.Distance(c2.Center)+c2.Radius, c.Radius)
+}
+
+// Overlaps returns whether the two circles have a point in common.
+func (c Circle) Overlaps(c2 Circle) bool {
+	return fpLe(c.Center.Distance(c2.Center), c.Radius+c2.Radius)
+}
+
+// DistanceToPoint returns the distance from the point to the circle, which is
+// zero if the point is inside the circle.
+func (c Circle) DistanceToPoint(p Point) float64 {
+	return math.Max(c.Center.Distance(p)-c.Radius, 0)
+}
+
+// Distance returns the distance between the two circles, which is zero if
+// they overlap.
+func (c Circle) Distance(c2 Circle) float64 {
+	return math.Max(c.Center.Distance(c2.Center)-(c.Radius+c2.Radius), 0)
+}
+
+// DistanceToPolygon returns the distance between the circle and the polygon,
+// which is zero if they overlap.
+func (c Circle) DistanceToPolygon(p Polygon) float64 {
+	return math.Max(p.DistanceToPoint(c.Center)-c.Radius, 0)
+}
+
+// Translate returns the circle moved by the given offset.
+func (c Circle) Translate(p Point) Circle {
+	return Circle{Center: c.Center.Add(p), Radius: c.Radius}
+}
+
+// Mul returns the circle with its center multiplied by the given point, and
+// its radius scaled accordingly.
+func (c Circle) Mul(p Point) Circle {
+	return Circle{Center: c.Center.Mul(p), Radius: c.Radius * math.Hypot(p.X, p.Y)}
+}
+
+// Div returns the circle with its center divided by the given point, and its
+// radius scaled accordingly.
+func (c Circle) Div(p Point) (Circle, error) {
+	center, err := c.Center.Div(p)
+	if err != nil {
+		return Circle{}, err
+	}
+	return Circle{Center: center, Radius: c.Radius / math.Hypot(p.X, p.Y)}, nil
+}
+
+// Box returns the box inscribed in the circle.
+func (c Circle) Box() Box {
+	delta := c.Radius / math.Sqrt2
+	return Box{
+		High: Point{X: c.Center.X + delta, Y: c.Center.Y + delta},
+		Low:  Point{X: c.Center.X - delta, Y: c.Center.Y - delta},
+	}
+}
+
+// DefaultPolygonPoints is the number of vertices of the polygon approximating
+// a circle when it is not specified.
+const DefaultPolygonPoints = 12
+
+// Polygon returns a polygon with the given number of vertices, equally spaced
+// on the circle.
+func (c Circle) Polygon(npts int) (Polygon, error) {
+	if fpZero(c.Radius) {
+		return Polygon{}, pgerror.New(pgcode.InvalidParameterValue,
+			"cannot convert circle with radius zero to polygon")
+	}
+	if npts < 2 {
+		return Polygon{}, pgerror.New(pgcode.InvalidParameterValue,
+			"must request at least 2 points")
+	}
+	if npts > maxPoints {
+		return Polygon{}, pgerror.New(pgcode.ProgramLimitExceeded,
+			"too many points requested")
+	}
+	points := make([]Point, npts)
+	step := 2 * math.Pi / float64(npts)
+	for i := range points {
+		angle := step * float64(i)
+		points[i] = Point{
+			X: c.Center.X - c.Radius*math.Cos(angle),
+			Y: c.Center.Y + c.Radius*math.Sin(angle),
+		}
+	}
+	return MakePolygon(points), nil
+}
+
+// pointLocation is the result of pointInside.
+type pointLocation int
+
+const (
+	outside pointLocation = iota
+	inside
+	onBoundary
+)
+
+// onPolygon is returned by lsegCrossing when the point is on the segment.
+const onPolygon = math.MaxInt32
+
+// pointInside returns the location of a point relative to the polygon with
+// the given vertices. It counts how many times the polygon crosses the
+// positive X axis, centered on the point.
+func pointInside(p Point, points []Point) pointLocation {
+	if len(points) == 0 {
+		return outside
+	}
+	x0, y0 := points[0].X-p.X, points[0].Y-p.Y
+	prevX, prevY := x0, y0
+	total := 0
+	for _, pt := range points[1:] {
+		x, y := pt.X-p.X, pt.Y-p.Y
+		c := lsegCrossing(x, y, prevX, prevY)
+		if c == onPolygon {
+			return onBoundary
+		}
+		total += c
+		prevX, prevY = x, y
+	}
+	// Close the polygon.
+	c := lsegCrossing(x0, y0, prevX, prevY)
+	if c == onPolygon {
+		return onBoundary
+	}
+	total += c
+	if total != 0 {
+		return inside
+	}
+	return outside
+}
+
+// lsegCrossing returns +/-2 if the segment between (prevX,prevY) and (x,y)
+// crosses the positive X axis, +/-1 if one of its ends is on the axis, 0 if it
+// does not cross it and onPolygon if it goes through the origin.
+func lsegCrossing(x, y, prevX, prevY float64) int {
+	if fpZero(y) {
+		// The point is on the X axis.
+		if fpZero(x) {
+			return onPolygon
+		}
+		if fpGt(x, 0) {
+			if fpZero(prevY) {
+				if fpGt(prevX, 0) {
+					return 0
+				}
+				return onPolygon
+			}
+			if fpLt(prevY, 0) {
+				return 1
+			}
+			return -1
+		}
+		if fpZero(prevY) {
+			if fpLt(prevX, 0) {
+				return 0
+			}
+			return onPolygon
+		}
+		return 0
+	}
+	ySign := -1
+	if fpGt(y, 0) {
+		ySign = 1
+	}
+	if fpZero(prevY) {
+		// The previous point was on the X axis.
+		if fpLt(prevX, 0) {
+			return 0
+		}
+		return ySign
+	}
+	if (ySign < 0 && fpLt(prevY, 0)) || (ySign > 0 && fpGt(prevY, 0)) {
+		// Both points are on the same side of the X axis.
+		return 0
+	}
+	if fpGe(x, 0) && fpGt(prevX, 0) {
+		// Both points are to the right, so the segment crosses the positive X
+		// axis.
+		return 2 * ySign
+	}
+	if fpLt(x, 0) && fpLe(prevX, 0) {
+		// Both points are to the left.
+		return 0
+	}
+	z := (x-prevX)*y - (y-prevY)*x
+	if fpZero(z) {
+		return onPolygon
+	}
+	if (ySign < 0 && fpLt(z, 0)) || (ySign > 0 && fpGt(z, 0)) {
+		return 0
+	}
+	return 2 * ySign
+}
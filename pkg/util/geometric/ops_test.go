@@ -0,0 +1,199 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geometric
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func mustParse[T any](t *testing.T, parse func(string) (T, error), s string) T {
+	v, err := parse(s)
+	require.NoError(t, err)
+	return v
+}
+
+func TestDistance(t *testing.T) {
+	pt := func(s string) Point { return mustParse(t, ParsePoint, s) }
+	box := func(s string) Box { return mustParse(t, ParseBox, s) }
+	lseg := func(s string) LSeg { return mustParse(t, ParseLSeg, s) }
+	line := func(s string) Line { return mustParse(t, ParseLine, s) }
+	path := func(s string) Path { return mustParse(t, ParsePath, s) }
+	poly := func(s string) Polygon { return mustParse(t, ParsePolygon, s) }
+	circle := func(s string) Circle { return mustParse(t, ParseCircle, s) }
+
+	require.Equal(t, 5.0, pt("(0,0)").Distance(pt("(3,4)")))
+	require.Equal(t, 1.0, lseg("[(1,0),(1,1)]").DistanceToPoint(pt("(0,0)")))
+	require.Equal(t, math.Sqrt2, lseg("[(1,1),(2,2)]").DistanceToPoint(pt("(0,0)")))
+	require.Equal(t, 0.0, box("(2,2),(0,0)").DistanceToPoint(pt("(1,1)")))
+	require.Equal(t, 1.0, box("(2,2),(0,0)").DistanceToPoint(pt("(3,1)")))
+	require.InDelta(t, math.Sqrt2/2, line("{1,-1,0}").DistanceToPoint(pt("(1,0)")), 1e-15)
+	require.Equal(t, 3.0, circle("<(0,0),1>").Distance(circle("<(5,0),1>")))
+	require.Equal(t, 0.0, circle("<(0,0),3>").Distance(circle("<(5,0),3>")))
+	require.Equal(t, 1.0, circle("<(0,0),1>").DistanceToPoint(pt("(2,0)")))
+	require.Equal(t, 2.0, poly("((0,0),(0,1),(1,1),(1,0))").Distance(poly("((3,0),(3,1),(4,1),(4,0))")))
+	require.Equal(t, 0.0, poly("((0,0),(0,4),(4,4),(4,0))").Distance(poly("((1,1),(1,2),(2,2))")))
+	require.Equal(t, 0.0, poly("((0,0),(0,2),(2,2),(2,0))").DistanceToPoint(pt("(1,1)")))
+	require.Equal(t, 1.0, poly("((0,0),(0,2),(2,2),(2,0))").DistanceToPoint(pt("(3,1)")))
+	require.Equal(t, 2.0, circle("<(5,1),1>").DistanceToPolygon(poly("((0,0),(0,2),(2,2),(2,0))")))
+	require.Equal(t, 1.0, lseg("[(0,0),(0,2)]").Distance(lseg("[(1,1),(2,1)]")))
+	require.Equal(t, 0.0, lseg("[(0,0),(2,2)]").Distance(lseg("[(0,2),(2,0)]")))
+	require.Equal(t, 2.0, line("{0,-1,3}").Distance(line("{0,-1,1}")))
+	require.Equal(t, 0.0, line("{1,-1,0}").Distance(line("{0,-1,1}")))
+	require.Equal(t, 1.0, lseg("[(3,0),(3,2)]").DistanceToBox(box("(2,2),(0,0)")))
+	require.Equal(t, 1.0, line("{-1,0,3}").DistanceToBox(box("(2,2),(0,0)")))
+	require.Equal(t, math.Sqrt(8), box("(1,1),(0,0)").Distance(box("(3,3),(2,2)")))
+
+	d, ok := path("[(0,0),(2,0),(2,2)]").DistanceToPoint(pt("(1,1)"))
+	require.True(t, ok)
+	require.Equal(t, 1.0, d)
+	_, ok = path("[(0,0)]").DistanceToPoint(pt("(1,1)"))
+	require.False(t, ok)
+	d, ok = path("[(0,0),(2,0)]").Distance(path("[(0,3),(2,3)]"))
+	require.True(t, ok)
+	require.Equal(t, 3.0, d)
+}
+
+func TestPredicates(t *testing.T) {
+	pt := func(s string) Point { return mustParse(t, ParsePoint, s) }
+	box := func(s string) Box { return mustParse(t, ParseBox, s) }
+	lseg := func(s string) LSeg { return mustParse(t, ParseLSeg, s) }
+	line := func(s string) Line { return mustParse(t, ParseLine, s) }
+	path := func(s string) Path { return mustParse(t, ParsePath, s) }
+	poly := func(s string) Polygon { return mustParse(t, ParsePolygon, s) }
+	circle := func(s string) Circle { return mustParse(t, ParseCircle, s) }
+
+	require.True(t, box("(2,2),(0,0)").ContainsPoint(pt("(1,1)")))
+	require.True(t, box("(2,2),(0,0)").ContainsPoint(pt("(2,1)")))
+	require.False(t, box("(2,2),(0,0)").ContainsPoint(pt("(3,1)")))
+	require.True(t, box("(2,2),(0,0)").ContainsBox(box("(1,1),(0,0)")))
+	require.False(t, box("(1,1),(0,0)").ContainsBox(box("(2,2),(0,0)")))
+	require.True(t, box("(1,1),(0,0)").Overlaps(box("(2,2),(1,1)")))
+	require.False(t, box("(1,1),(0,0)").Overlaps(box("(3,3),(2,2)")))
+
+	require.True(t, circle("<(0,0),2>").ContainsPoint(pt("(1,1)")))
+	require.False(t, circle("<(0,0),1>").ContainsPoint(pt("(1,1)")))
+	require.True(t, circle("<(0,0),2>").ContainsCircle(circle("<(1,0),1>")))
+	require.False(t, circle("<(0,0),2>").ContainsCircle(circle("<(2,0),1>")))
+	require.True(t, circle("<(0,0),1>").Overlaps(circle("<(2,0),1>")))
+	require.False(t, circle("<(0,0),1>").Overlaps(circle("<(3,0),1>")))
+
+	require.True(t, lseg("[(0,0),(2,2)]").ContainsPoint(pt("(1,1)")))
+	require.False(t, lseg("[(0,0),(2,2)]").ContainsPoint(pt("(3,3)")))
+	require.True(t, lseg("[(0,0),(0,1)]").Intersects(lseg("[(0,0),(1,0)]")))
+	require.True(t, lseg("[(0,0),(2,2)]").Intersects(lseg("[(0,2),(2,0)]")))
+	require.False(t, lseg("[(0,0),(1,1)]").Intersects(lseg("[(0,2),(1,3)]")))
+	require.True(t, lseg("[(-1,0),(1,0)]").IntersectsBox(box("(2,2),(-2,-2)")))
+	require.True(t, lseg("[(-3,0),(3,0)]").IntersectsBox(box("(2,2),(-2,-2)")))
+	require.False(t, lseg("[(-3,3),(3,3)]").IntersectsBox(box("(2,2),(-2,-2)")))
+	require.True(t, lseg("[(0,0),(2,2)]").IntersectsLine(line("{0,-1,1}")))
+	require.False(t, lseg("[(0,0),(2,0)]").IntersectsLine(line("{0,-1,1}")))
+
+	require.True(t, line("{1,-1,0}").ContainsPoint(pt("(2,2)")))
+	require.True(t, line("{1,-1,0}").Intersects(line("{0,-1,1}")))
+	require.False(t, line("{0,-1,0}").Intersects(line("{0,-1,1}")))
+	require.True(t, line("{1,-1,0}").IntersectsBox(box("(2,2),(1,0)")))
+	require.False(t, line("{1,-1,5}").IntersectsBox(box("(2,2),(1,0)")))
+	require.True(t, line("{1,-1,0}").ContainsLSeg(lseg("[(1,1),(3,3)]")))
+
+	require.True(t, path("[(0,0),(2,0)]").ContainsPoint(pt("(1,0)")))
+	require.False(t, path("[(0,0),(2,0),(2,2)]").ContainsPoint(pt("(1,1)")))
+	require.True(t, path("((0,0),(2,0),(2,2))").ContainsPoint(pt("(1.5,0.5)")))
+	require.True(t, path("[(0,0),(2,2)]").Intersects(path("[(0,2),(2,0)]")))
+	require.False(t, path("[(0,0),(2,0)]").Intersects(path("[(0,1),(2,1)]")))
+	// The closing segment of a closed path is taken into account.
+	require.True(t, path("((0,0),(2,0),(2,2))").Intersects(path("[(0,2),(2,0.5)]")))
+
+	square := poly("((0,0),(0,3),(3,3),(3,0))")
+	require.True(t, square.ContainsPoint(pt("(1,1)")))
+	require.True(t, square.ContainsPoint(pt("(0,1)")))
+	require.False(t, square.ContainsPoint(pt("(4,1)")))
+	require.True(t, square.ContainsPolygon(poly("((1,1),(1,2),(2,2),(2,1))")))
+	require.True(t, square.ContainsPolygon(square))
+	require.False(t, poly("((1,1),(1,2),(2,2),(2,1))").ContainsPolygon(square))
+	lShape := poly("((0,0),(0,3),(1,3),(1,1),(3,1),(3,0))")
+	require.False(t, lShape.ContainsPolygon(poly("((0.5,0.5),(2,2),(2,0.5))")))
+	require.False(t, lShape.ContainsPolygon(poly("((0.5,2.5),(2.5,0.5),(0.5,0.5))")))
+	require.True(t, lShape.ContainsPolygon(poly("((0.2,0.2),(0.2,2.8),(0.8,0.2))")))
+	require.True(t, lShape.ContainsPolygon(poly("((0,0),(0,3),(1,1),(3,0))")))
+	require.True(t, square.Overlaps(poly("((2,2),(2,5),(5,5))")))
+	require.True(t, square.Overlaps(poly("((1,1),(1,2),(2,2))")))
+	require.False(t, square.Overlaps(poly("((4,4),(4,5),(5,5))")))
+}
+
+func TestConversions(t *testing.T) {
+	box := mustParse(t, ParseBox, "(1,1),(0,0)")
+	require.Equal(t, "<(0.5,0.5),0.7071067811865476>", box.Circle().String())
+	require.Equal(t, "((0,0),(0,1),(1,1),(1,0))", box.Polygon().String())
+	require.Equal(t, "[(1,1),(0,0)]", box.Diagonal().String())
+	require.Equal(t, "(2,2),(0,0)", box.Mul(Point{X: 2}).String())
+	divided, err := box.Div(Point{X: 2})
+	require.NoError(t, err)
+	require.Equal(t, "(0.5,0.5),(0,0)", divided.String())
+	_, err = box.Div(Point{})
+	require.EqualError(t, err, "division by zero")
+
+	circle := mustParse(t, ParseCircle, "<(3,0),1>")
+	poly, err := circle.Polygon(4)
+	require.NoError(t, err)
+	require.Len(t, poly.Points, 4)
+	for i, expected := range []Point{{X: 2, Y: 0}, {X: 3, Y: 1}, {X: 4, Y: 0}, {X: 3, Y: -1}} {
+		require.InDelta(t, expected.X, poly.Points[i].X, 1e-15)
+		require.InDelta(t, expected.Y, poly.Points[i].Y, 1e-15)
+	}
+	_, err = circle.Polygon(1)
+	require.EqualError(t, err, "must request at least 2 points")
+	require.Equal(t, "<(0.5,0.5),0.7071067811865476>", box.Polygon().Circle().String())
+	require.InDelta(t, math.Pi, circle.Area(), 1e-15)
+
+	path := mustParse(t, ParsePath, "((0,0),(1,0),(1,1))")
+	require.Equal(t, "((0,0),(3,0),(3,3))", path.Mul(Point{X: 3}).String())
+	require.Equal(t, 2+math.Sqrt2, path.Length())
+	area, ok := path.Area()
+	require.True(t, ok)
+	require.Equal(t, 0.5, area)
+	_, ok = Path{Points: path.Points}.Area()
+	require.False(t, ok)
+	_, err = Path{Points: path.Points}.Polygon()
+	require.EqualError(t, err, "open path cannot be converted to polygon")
+	concat, ok := Path{Points: path.Points[:1]}.Concat(Path{Points: path.Points[1:]})
+	require.True(t, ok)
+	require.Equal(t, "[(0,0),(1,0),(1,1)]", concat.String())
+	_, ok = path.Concat(path)
+	require.False(t, ok)
+
+	require.Equal(t, 0.5, Slope(Point{}, Point{X: 2, Y: 1}))
+	require.True(t, math.IsInf(Slope(Point{}, Point{Y: 1}), 1))
+	require.Equal(t, "{0.5,-1,0}", MakeLine(Point{}, Point{X: 2, Y: 1}).String())
+}
+
+func TestGeomT(t *testing.T) {
+	p, err := PointFromGeomT(Point{X: 1, Y: 2}.AsGeomT())
+	require.NoError(t, err)
+	require.Equal(t, Point{X: 1, Y: 2}, p)
+
+	path := Path{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}, Closed: true}
+	require.Equal(t, []float64{0, 0, 1, 1}, path.AsGeomT().FlatCoords())
+	openPath, err := PathFromGeomT(path.AsGeomT())
+	require.NoError(t, err)
+	require.Equal(t, "[(0,0),(1,1)]", openPath.String())
+
+	poly := MakePolygon([]Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}})
+	require.Equal(t, []float64{0, 0, 0, 1, 1, 1, 0, 0}, poly.AsGeomT().FlatCoords())
+	roundTripped, err := PolygonFromGeomT(poly.AsGeomT())
+	require.NoError(t, err)
+	require.Equal(t, poly, roundTripped)
+
+	_, err = PointFromGeomT(geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1}))
+	require.EqualError(t, err, "geometry_to_point only accepts Points")
+	_, err = PathFromGeomT(geom.NewPointFlat(geom.XY, []float64{0, 0}))
+	require.EqualError(t, err, "geometry_to_path only accepts LineStrings")
+	_, err = PolygonFromGeomT(geom.NewPointFlat(geom.XY, []float64{0, 0}))
+	require.EqualError(t, err, "geometry_to_polygon only accepts Polygons")
+}
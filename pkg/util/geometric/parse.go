@@ -0,0 +1,257 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geometric
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// parser reads the text representation of a geometric value.
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.s) && isSpace(p.s[p.pos]) {
+		p.pos++
+	}
+}
+
+const spaces = " \t\n\r\v\f"
+
+func isSpace(c byte) bool {
+	return strings.IndexByte(spaces, c) >= 0
+}
+
+// peek returns the next non-space character, or 0 at the end of the input.
+func (p *parser) peek() byte {
+	p.skipSpace()
+	if p.pos == len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// consume skips over the next non-space character if it is c.
+func (p *parser) consume(c byte) bool {
+	if p.peek() != c {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+// done returns whether all of the input has been read.
+func (p *parser) done() bool {
+	return p.peek() == 0
+}
+
+// float reads a coordinate, which is delimited by a space or by one of the
+// punctuation characters of the geometric types.
+func (p *parser) float() (float64, bool) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if c == ',' || c == '(' || c == ')' || c == '[' || c == ']' ||
+			c == '<' || c == '>' || c == '{' || c == '}' || isSpace(c) {
+			break
+		}
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// point reads a point, written as x,y or (x,y).
+func (p *parser) point() (Point, bool) {
+	paren := p.consume('(')
+	x, ok := p.float()
+	if !ok || !p.consume(',') {
+		return Point{}, false
+	}
+	y, ok := p.float()
+	if !ok || (paren && !p.consume(')')) {
+		return Point{}, false
+	}
+	return Point{X: x, Y: y}, true
+}
+
+// points reads a comma-separated list of points. The whole list may be
+// enclosed in parentheses or, if allowOpen is true, in brackets, which makes
+// it an open path.
+func (p *parser) points(allowOpen bool) (points []Point, open bool, ok bool) {
+	var closing byte
+	switch p.peek() {
+	case '[':
+		if !allowOpen {
+			return nil, false, false
+		}
+		p.pos++
+		open, closing = true, ']'
+	case '(':
+		// The parenthesis encloses the list if it is followed by another one, or
+		// if it is the only one, as in (x1,y1,x2,y2).
+		rest := p.s[p.pos+1:]
+		if strings.HasPrefix(strings.TrimLeft(rest, spaces), "(") || !strings.Contains(rest, "(") {
+			p.pos++
+			closing = ')'
+		}
+	}
+	for {
+		pt, ok := p.point()
+		if !ok {
+			return nil, false, false
+		}
+		points = append(points, pt)
+		if !p.consume(',') {
+			break
+		}
+	}
+	if closing != 0 && !p.consume(closing) {
+		return nil, false, false
+	}
+	return points, open, true
+}
+
+func makeParseError(typ, s string) error {
+	return pgerror.Newf(pgcode.InvalidTextRepresentation, "invalid input syntax for type %s: %q", typ, s)
+}
+
+// ParsePoint parses a point written as (x,y) or x,y.
+func ParsePoint(s string) (Point, error) {
+	p := parser{s: s}
+	pt, ok := p.point()
+	if !ok || !p.done() {
+		return Point{}, makeParseError("point", s)
+	}
+	return pt, nil
+}
+
+// parseTwoPoints parses the two points of a box or line segment.
+func parseTwoPoints(typ, s string, allowOpen bool) ([2]Point, error) {
+	p := parser{s: s}
+	points, _, ok := p.points(allowOpen)
+	if !ok || len(points) != 2 || !p.done() {
+		return [2]Point{}, makeParseError(typ, s)
+	}
+	return [2]Point{points[0], points[1]}, nil
+}
+
+// ParseBox parses a box given by two of its opposite corners, written as
+// ((x1,y1),(x2,y2)), (x1,y1),(x2,y2) or x1,y1,x2,y2.
+func ParseBox(s string) (Box, error) {
+	points, err := parseTwoPoints("box", s, false /* allowOpen */)
+	if err != nil {
+		return Box{}, err
+	}
+	return MakeBox(points[0], points[1]), nil
+}
+
+// ParseLSeg parses a line segment written as [(x1,y1),(x2,y2)] or in any of
+// the forms accepted for boxes.
+func ParseLSeg(s string) (LSeg, error) {
+	points, err := parseTwoPoints("lseg", s, true /* allowOpen */)
+	if err != nil {
+		return LSeg{}, err
+	}
+	return LSeg{P: points}, nil
+}
+
+// ParseLine parses a line written as {A,B,C}, or as two distinct points on
+// the line in any of the forms accepted for line segments.
+func ParseLine(s string) (Line, error) {
+	p := parser{s: s}
+	if !p.consume('{') {
+		points, err := parseTwoPoints("line", s, true /* allowOpen */)
+		if err != nil {
+			return Line{}, err
+		}
+		if points[0] == points[1] {
+			return Line{}, pgerror.New(pgcode.InvalidParameterValue,
+				"invalid line specification: must be two distinct points")
+		}
+		return MakeLine(points[0], points[1]), nil
+	}
+	var coeffs [3]float64
+	for i := range coeffs {
+		if i > 0 && !p.consume(',') {
+			return Line{}, makeParseError("line", s)
+		}
+		var ok bool
+		if coeffs[i], ok = p.float(); !ok {
+			return Line{}, makeParseError("line", s)
+		}
+	}
+	if !p.consume('}') || !p.done() {
+		return Line{}, makeParseError("line", s)
+	}
+	if fpZero(coeffs[0]) && fpZero(coeffs[1]) {
+		return Line{}, pgerror.New(pgcode.InvalidParameterValue,
+			"invalid line specification: A and B cannot both be zero")
+	}
+	return Line{A: coeffs[0], B: coeffs[1], C: coeffs[2]}, nil
+}
+
+// ParsePath parses a path, written as [(x1,y1),...,(xn,yn)] if it is open, or
+// as ((x1,y1),...,(xn,yn)), (x1,y1),...,(xn,yn) or x1,y1,...,xn,yn if it is
+// closed.
+func ParsePath(s string) (Path, error) {
+	p := parser{s: s}
+	points, open, ok := p.points(true /* allowOpen */)
+	if !ok || !p.done() {
+		return Path{}, makeParseError("path", s)
+	}
+	return Path{Points: points, Closed: !open}, nil
+}
+
+// ParsePolygon parses a polygon, written in any of the forms accepted for
+// closed paths.
+func ParsePolygon(s string) (Polygon, error) {
+	p := parser{s: s}
+	points, _, ok := p.points(false /* allowOpen */)
+	if !ok || !p.done() {
+		return Polygon{}, makeParseError("polygon", s)
+	}
+	return MakePolygon(points), nil
+}
+
+// ParseCircle parses a circle written as <(x,y),r>, ((x,y),r), (x,y),r or
+// x,y,r.
+func ParseCircle(s string) (Circle, error) {
+	p := parser{s: s}
+	var closing byte
+	switch p.peek() {
+	case '<':
+		p.pos++
+		closing = '>'
+	case '(':
+		if strings.HasPrefix(strings.TrimLeft(p.s[p.pos+1:], spaces), "(") {
+			p.pos++
+			closing = ')'
+		}
+	}
+	center, ok := p.point()
+	if !ok || !p.consume(',') {
+		return Circle{}, makeParseError("circle", s)
+	}
+	radius, ok := p.float()
+	if !ok || (closing != 0 && !p.consume(closing)) || !p.done() {
+		return Circle{}, makeParseError("circle", s)
+	}
+	if radius < 0 {
+		return Circle{}, makeParseError("circle", s)
+	}
+	return Circle{Center: center, Radius: radius}, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package geometric
+
+import "math/rand"
+
+func randomFloat(rng *rand.Rand) float64 {
+	// Use small whole and half values, which are more likely to produce
+	// interesting shapes and are printed exactly.
+	return float64(rng.Intn(41)-20) / 2
+}
+
+// RandomPoint returns a random Point for testing.
+func RandomPoint(rng *rand.Rand) Point {
+	return Point{X: randomFloat(rng), Y: randomFloat(rng)}
+}
+
+func randomPoints(rng *rand.Rand) []Point {
+	points := make([]Point, 1+rng.Intn(6))
+	for i := range points {
+		points[i] = RandomPoint(rng)
+	}
+	return points
+}
+
+// RandomBox returns a random Box for testing.
+func RandomBox(rng *rand.Rand) Box {
+	return MakeBox(RandomPoint(rng), RandomPoint(rng))
+}
+
+// RandomLSeg returns a random LSeg for testing.
+func RandomLSeg(rng *rand.Rand) LSeg {
+	return LSeg{P: [2]Point{RandomPoint(rng), RandomPoint(rng)}}
+}
+
+// RandomLine returns a random Line for testing.
+func RandomLine(rng *rand.Rand) Line {
+	for {
+		l := Line{A: randomFloat(rng), B: randomFloat(rng), C: randomFloat(rng)}
+		if l.A != 0 || l.B != 0 {
+			return l
+		}
+	}
+}
+
+// RandomPath returns a random Path for testing.
+func RandomPath(rng *rand.Rand) Path {
+	return Path{Points: randomPoints(rng), Closed: rng.Intn(2) == 0}
+}
+
+// RandomPolygon returns a random Polygon for testing.
+func RandomPolygon(rng *rand.Rand) Polygon {
+	return MakePolygon(randomPoints(rng))
+}
+
+// RandomCircle returns a random Circle for testing.
+func RandomCircle(rng *rand.Rand) Circle {
+	return Circle{Center: RandomPoint(rng), Radius: float64(rng.Intn(20)) / 2}
+}
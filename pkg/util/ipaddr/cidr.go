@@ -0,0 +1,251 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package ipaddr
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/uint128"
+	"github.com/cockroachdb/errors"
+)
+
+// MaxMask returns the largest mask length of the family of the IPAddr, 32 for
+// IPv4 and 128 for IPv6.
+func (ipAddr *IPAddr) MaxMask() byte {
+	if ipAddr.Family == IPv4family {
+		return 32
+	}
+	return 128
+}
+
+// ParseCIDR parses postgres style CIDR values, which specify a network and
+// can't have bits set to the right of the mask. If the mask of an IPv4 network
+// is omitted, it is derived from the class of the network, widened to cover
+// all the octets given. See TestIPAddrParseCIDR for examples.
+func ParseCIDR(s string, dest *IPAddr) error {
+	invalid := func() error {
+		return pgerror.Newf(pgcode.InvalidTextRepresentation,
+			"invalid input syntax for type cidr: %q", s)
+	}
+	addr, maskStr, hasMask := strings.Cut(s, "/")
+	var ipAddr IPAddr
+	if getFamily(addr) == IPv6family {
+		ip := ParseIP(addr)
+		if ip == nil {
+			return invalid()
+		}
+		ipAddr = IPAddr{Family: IPv6family, Addr: Addr(uint128.FromBytes(ip)), Mask: 128}
+	} else {
+		// Unlike inet, postgres allows trailing octets of IPv4 networks to be
+		// omitted.
+		octets := strings.Split(strings.TrimRight(addr, "."), ".")
+		if len(octets) > 4 {
+			return invalid()
+		}
+		var v uint64
+		for i := 0; i < 4; i++ {
+			v <<= 8
+			if i < len(octets) {
+				o, err := strconv.ParseUint(octets[i], 10, 8)
+				if err != nil {
+					return invalid()
+				}
+				v |= o
+			}
+		}
+		ipAddr = IPAddr{
+			Family: IPv4family,
+			Addr:   Addr(uint128.FromInts(0, v|IPv4mappedIPv6prefix)),
+			Mask:   classfulMask(byte(v>>24), len(octets)),
+		}
+	}
+	if hasMask {
+		mask, err := strconv.Atoi(maskStr)
+		if err != nil || mask < 0 || mask > int(ipAddr.MaxMask()) {
+			return invalid()
+		}
+		ipAddr.Mask = byte(mask)
+	}
+	if !ipAddr.IsNetwork() {
+		return errors.WithDetail(
+			pgerror.Newf(pgcode.InvalidTextRepresentation, "invalid cidr value: %q", s),
+			"Value has bits set to right of mask.",
+		)
+	}
+	*dest = ipAddr
+	return nil
+}
+
+// classfulMask returns the mask of an IPv4 network whose mask was omitted,
+// which follows the obsolete classful network addressing, except that it is at
+// least large enough to include all the given octets.
+func classfulMask(firstOctet byte, octets int) byte {
+	var mask int
+	switch {
+	case firstOctet >= 240:
+		// Class E.
+		mask = 32
+	case firstOctet >= 224:
+		// Class D.
+		mask = 8
+	case firstOctet >= 192:
+		// Class C.
+		mask = 24
+	case firstOctet >= 128:
+		// Class B.
+		mask = 16
+	default:
+		// Class A.
+		mask = 8
+	}
+	if mask < octets*8 {
+		mask = octets * 8
+	}
+	// Multicast networks given without additional octets use a 4 bit mask.
+	if mask == 8 && firstOctet == 224 {
+		mask = 4
+	}
+	return byte(mask)
+}
+
+// Network returns the network of the IPAddr, which is the address with all the
+// bits to the right of the mask set to zero.
+func (ipAddr *IPAddr) Network() IPAddr {
+	netmask := ipAddr.Netmask()
+	return IPAddr{Family: ipAddr.Family, Mask: ipAddr.Mask, Addr: ipAddr.Addr.and(netmask.Addr)}
+}
+
+// IsNetwork returns whether no bits are set to the right of the mask, which is
+// required for CIDR values.
+func (ipAddr *IPAddr) IsNetwork() bool {
+	network := ipAddr.Network()
+	return network.Addr.Equal(ipAddr.Addr)
+}
+
+// CIDRString returns the string representation of the IPAddr as a CIDR value.
+// Unlike String, the mask is always included.
+func (ipAddr IPAddr) CIDRString() string {
+	s := ipAddr.String()
+	if strings.IndexByte(s, '/') < 0 {
+		s += "/" + strconv.Itoa(int(ipAddr.Mask))
+	}
+	return s
+}
+
+// AbbrevCIDR returns the abbreviated string representation of the IPAddr as a
+// CIDR value, which omits the trailing parts of the network that are zero.
+// For example, 10.1.0.0/16 is abbreviated to 10.1/16.
+func (ipAddr IPAddr) AbbrevCIDR() string {
+	network := ipAddr.Network()
+	b := uint128.Uint128(network.Addr).GetBytes()
+	var sb strings.Builder
+	if ipAddr.Family == IPv4family {
+		// This follows inet_cidr_ntop_ipv4 in Postgres.
+		b = b[12:]
+		if ipAddr.Mask == 0 {
+			sb.WriteByte('0')
+		}
+		whole := int(ipAddr.Mask / 8)
+		for i := 0; i < whole; i++ {
+			if i > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(strconv.Itoa(int(b[i])))
+		}
+		if ipAddr.Mask%8 != 0 {
+			if whole > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(strconv.Itoa(int(b[whole])))
+		}
+	} else {
+		// This follows inet_cidr_ntop_ipv6 in Postgres.
+		words := (int(ipAddr.Mask) + 15) / 16
+		if words == 1 {
+			words = 2
+		}
+		word := func(i int) int { return int(b[2*i])<<8 | int(b[2*i+1]) }
+		// Find the longest run of zero words.
+		var zeroStart, zeroLen, tmpStart, tmpLen int
+		for i := 0; i < words; i++ {
+			if word(i) == 0 {
+				if tmpLen == 0 {
+					tmpStart = i
+				}
+				tmpLen++
+			} else if tmpLen != 0 && zeroLen < tmpLen {
+				zeroStart, zeroLen, tmpLen = tmpStart, tmpLen, 0
+			}
+		}
+		if tmpLen != 0 && zeroLen < tmpLen {
+			zeroStart, zeroLen = tmpStart, tmpLen
+		}
+		isIPv4 := zeroLen != words && zeroStart == 0 && (zeroLen == 6 ||
+			(zeroLen == 5 && b[10] == 0xff && b[11] == 0xff) ||
+			(zeroLen == 7 && b[14] != 0 && b[15] != 1))
+		for i := 0; i < words; i++ {
+			if zeroLen != 0 && i >= zeroStart && i < zeroStart+zeroLen {
+				if i == zeroStart {
+					sb.WriteByte(':')
+				}
+				if i == words-1 {
+					sb.WriteByte(':')
+				}
+				continue
+			}
+			if isIPv4 && i > 5 {
+				if i == 6 {
+					sb.WriteByte(':')
+				} else {
+					sb.WriteByte('.')
+				}
+				sb.WriteString(strconv.Itoa(int(b[2*i])))
+				// The last octet can be omitted.
+				if i != 7 || ipAddr.Mask > 120 {
+					sb.WriteByte('.')
+					sb.WriteString(strconv.Itoa(int(b[2*i+1])))
+				}
+				continue
+			}
+			if sb.Len() > 0 {
+				sb.WriteByte(':')
+			}
+			sb.WriteString(strconv.FormatInt(int64(word(i)), 16))
+		}
+	}
+	sb.WriteByte('/')
+	sb.WriteString(strconv.Itoa(int(ipAddr.Mask)))
+	return sb.String()
+}
+
+// Merge returns the smallest network which includes both IPAddrs.
+func (ipAddr *IPAddr) Merge(other *IPAddr) (IPAddr, error) {
+	if ipAddr.Family != other.Family {
+		return IPAddr{}, pgerror.New(pgcode.InvalidParameterValue,
+			"cannot merge addresses from different families")
+	}
+	mask := ipAddr.Mask
+	if other.Mask < mask {
+		mask = other.Mask
+	}
+	var common int
+	if ipAddr.Family == IPv4family {
+		common = bits.LeadingZeros32(uint32(ipAddr.Addr.Lo ^ other.Addr.Lo))
+	} else if ipAddr.Addr.Hi != other.Addr.Hi {
+		common = bits.LeadingZeros64(ipAddr.Addr.Hi ^ other.Addr.Hi)
+	} else {
+		common = 64 + bits.LeadingZeros64(ipAddr.Addr.Lo^other.Addr.Lo)
+	}
+	if common < int(mask) {
+		mask = byte(common)
+	}
+	merged := IPAddr{Family: ipAddr.Family, Mask: mask, Addr: ipAddr.Addr}
+	return merged.Network(), nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package ipaddr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIPAddrParseCIDR(t *testing.T) {
+	testCases := []struct {
+		s   string
+		exp string
+		err string
+	}{
+		{"192.168.1.0/24", "192.168.1.0/24", ""},
+		{"192.168.1.2/32", "192.168.1.2/32", ""},
+		{"192.168.1.2", "192.168.1.2/32", ""},
+		{"2001:4f8:3:ba::/64", "2001:4f8:3:ba::/64", ""},
+		{"2001:4f8:3:ba:2e0:81ff:fe22:d1f1", "2001:4f8:3:ba:2e0:81ff:fe22:d1f1/128", ""},
+		{"::ffff:1.2.3.0/120", "::ffff:1.2.3.0/120", ""},
+		{"0.0.0.0/0", "0.0.0.0/0", ""},
+		{"::/0", "::/0", ""},
+
+		// Classful masks are imputed when the mask is omitted.
+		{"10", "10.0.0.0/8", ""},
+		{"10.1", "10.1.0.0/16", ""},
+		{"128", "128.0.0.0/16", ""},
+		{"128.1.2", "128.1.2.0/24", ""},
+		{"192.168", "192.168.0.0/24", ""},
+		{"224", "224.0.0.0/4", ""},
+		{"224.1", "224.1.0.0/16", ""},
+		{"230", "230.0.0.0/8", ""},
+		{"240", "240.0.0.0/32", ""},
+		{"192.168.", "192.168.0.0/24", ""},
+		{"192.168/25", "192.168.0.0/25", ""},
+
+		// Bits set to the right of the mask.
+		{"192.168.1.2/24", "", "invalid cidr value"},
+		{"10.1/8", "", "invalid cidr value"},
+		{"2001:4f8:3:ba::1/64", "", "invalid cidr value"},
+
+		// Bad input.
+		{"", "", "invalid input syntax"},
+		{"abc", "", "invalid input syntax"},
+		{"192.168.0.0.0", "", "invalid input syntax"},
+		{"192.168.0.256", "", "invalid input syntax"},
+		{"192.168.0.0/33", "", "invalid input syntax"},
+		{"192.168.0.0/a", "", "invalid input syntax"},
+		{"::/129", "", "invalid input syntax"},
+		{"::g", "", "invalid input syntax"},
+	}
+	for i, testCase := range testCases {
+		var actual IPAddr
+		if err := ParseCIDR(testCase.s, &actual); err != nil {
+			if len(testCase.err) == 0 {
+				t.Errorf("%d: ParseCIDR(%s) caused an unexpected error:%s", i, testCase.s, err)
+			} else if !strings.Contains(err.Error(), testCase.err) {
+				t.Errorf("%d: ParseCIDR(%s) caused an incorrect error actual:%s, expected:%s", i, testCase.s,
+					err, testCase.err)
+			}
+		} else if len(testCase.err) > 0 {
+			t.Errorf("%d: ParseCIDR(%s) expected error:%s", i, testCase.s, testCase.err)
+		} else if s := actual.CIDRString(); s != testCase.exp {
+			t.Errorf("%d: ParseCIDR(%s) actual:%s does not match expected:%s", i, testCase.s, s,
+				testCase.exp)
+		}
+	}
+}
+
+func TestIPAddrAbbrevCIDR(t *testing.T) {
+	testCases := []struct {
+		s   string
+		exp string
+	}{
+		{"192.168.0.0/24", "192.168.0/24"},
+		{"192.168.0.0/23", "192.168.0/23"},
+		{"192.168.1.0/24", "192.168.1/24"},
+		{"10.0.0.0/8", "10/8"},
+		{"10.1.0.0/16", "10.1/16"},
+		{"10.1.2.3/32", "10.1.2.3/32"},
+		{"0.0.0.0/0", "0/0"},
+		{"2001:4f8:3:ba::/64", "2001:4f8:3:ba/64"},
+		{"2001:4f8:3:ba:2e0:81ff:fe22:d1f1/128", "2001:4f8:3:ba:2e0:81ff:fe22:d1f1/128"},
+		{"::ffff:1.2.3.0/120", "::ffff:1.2.3/120"},
+		{"::ffff:1.2.3.4/128", "::ffff:1.2.3.4/128"},
+	}
+	for i, testCase := range testCases {
+		var ip IPAddr
+		if err := ParseCIDR(testCase.s, &ip); err != nil {
+			t.Fatal(err)
+		}
+		if actual := ip.AbbrevCIDR(); actual != testCase.exp {
+			t.Errorf("%d: AbbrevCIDR(%s) actual:%s does not match expected:%s", i, testCase.s, actual,
+				testCase.exp)
+		}
+	}
+}
+
+func TestIPAddrNetwork(t *testing.T) {
+	testCases := []struct {
+		s   string
+		exp string
+	}{
+		{"192.168.1.5/24", "192.168.1.0/24"},
+		{"192.168.1.5", "192.168.1.5/32"},
+		{"192.168.1.5/0", "0.0.0.0/0"},
+		{"2001:4f8:3:ba:2e0:81ff:fe22:d1f1/64", "2001:4f8:3:ba::/64"},
+		{"::ffff:1.2.3.4/120", "::ffff:1.2.3.0/120"},
+	}
+	for i, testCase := range testCases {
+		var ip IPAddr
+		if err := ParseINet(testCase.s, &ip); err != nil {
+			t.Fatal(err)
+		}
+		if actual := ip.Network(); actual.CIDRString() != testCase.exp {
+			t.Errorf("%d: Network(%s) actual:%s does not match expected:%s", i, testCase.s,
+				actual.CIDRString(), testCase.exp)
+		}
+	}
+}
+
+func TestIPAddrMerge(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		exp  string
+		err  string
+	}{
+		{"192.168.1.5/24", "192.168.2.5/24", "192.168.0.0/22", ""},
+		{"192.168.1.5/24", "192.168.1.6/30", "192.168.1.0/24", ""},
+		{"192.168.1.5", "192.168.1.5", "192.168.1.5/32", ""},
+		{"10.0.0.1", "192.168.0.1", "0.0.0.0/0", ""},
+		{"2001:4f8:3:ba::1", "2001:4f8:3:bb::1", "2001:4f8:3:ba::/63", ""},
+		{"2001:4f8:3:ba::1", "2001:4f8:3:ba::3", "2001:4f8:3:ba::/126", ""},
+		{"192.168.1.5", "::1", "", "cannot merge addresses from different families"},
+	}
+	for i, testCase := range testCases {
+		var a, b IPAddr
+		if err := ParseINet(testCase.a, &a); err != nil {
+			t.Fatal(err)
+		}
+		if err := ParseINet(testCase.b, &b); err != nil {
+			t.Fatal(err)
+		}
+		actual, err := a.Merge(&b)
+		if err != nil {
+			if len(testCase.err) == 0 || !strings.Contains(err.Error(), testCase.err) {
+				t.Errorf("%d: Merge(%s, %s) caused an unexpected error:%s", i, testCase.a, testCase.b, err)
+			}
+		} else if actual.CIDRString() != testCase.exp {
+			t.Errorf("%d: Merge(%s, %s) actual:%s does not match expected:%s", i, testCase.a, testCase.b,
+				actual.CIDRString(), testCase.exp)
+		}
+	}
+}
@@ -0,0 +1,402 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package jsonpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// datetimeKind is the type of a datetime item produced by .datetime().
+type datetimeKind int
+
+const (
+	dtDate datetimeKind = iota
+	dtTime
+	dtTimeTZ
+	dtTimestamp
+	dtTimestampTZ
+)
+
+var datetimeKindNames = [...]string{
+	dtDate:        "date",
+	dtTime:        "time without time zone",
+	dtTimeTZ:      "time with time zone",
+	dtTimestamp:   "timestamp without time zone",
+	dtTimestampTZ: "timestamp with time zone",
+}
+
+func (k datetimeKind) String() string {
+	return datetimeKindNames[k]
+}
+
+func (k datetimeKind) hasDate() bool {
+	return k == dtDate || k == dtTimestamp || k == dtTimestampTZ
+}
+
+func (k datetimeKind) hasZone() bool {
+	return k == dtTimeTZ || k == dtTimestampTZ
+}
+
+// timeBaseDate is the date of the time.Time values of time items.
+var timeBaseDate = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// datetime is a date or time item. The location of t is UTC for kinds
+// without a time zone, and a fixed zone with the item's UTC offset for kinds
+// with a time zone. The date of time items is timeBaseDate.
+type datetime struct {
+	kind datetimeKind
+	t    time.Time
+}
+
+// String formats the datetime in the ISO 8601 format used by Postgres when a
+// datetime item is converted to JSON.
+func (d datetime) String() string {
+	var b strings.Builder
+	if d.kind.hasDate() {
+		b.WriteString(d.t.Format("2006-01-02"))
+		if d.kind == dtDate {
+			return b.String()
+		}
+		b.WriteByte('T')
+	}
+	b.WriteString(d.t.Format("15:04:05.999999"))
+	if d.kind.hasZone() {
+		_, offset := d.t.Zone()
+		sign := byte('+')
+		if offset < 0 {
+			sign, offset = '-', -offset
+		}
+		fmt.Fprintf(&b, "%c%02d:%02d", sign, offset/3600, offset/60%60)
+		if offset%60 != 0 {
+			fmt.Fprintf(&b, ":%02d", offset%60)
+		}
+	}
+	return b.String()
+}
+
+// withZone converts a datetime without a time zone to the corresponding kind
+// with a time zone, interpreting it in loc.
+func (d datetime) withZone(loc *time.Location) datetime {
+	t := d.t
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	_, offset := t.Zone()
+	t = t.In(time.FixedZone("", offset))
+	switch d.kind {
+	case dtTime:
+		return datetime{kind: dtTimeTZ, t: t}
+	default:
+		return datetime{kind: dtTimestampTZ, t: t}
+	}
+}
+
+// compareDatetimes compares two datetime items. ok is false if the kinds of
+// the items cannot be compared. Comparing items with and without a time zone
+// requires useTZ to be set, in which case loc is used as the time zone of the
+// items without one.
+func compareDatetimes(
+	a, b datetime, useTZ bool, loc *time.Location,
+) (cmp int, ok bool, err error) {
+	// Times cannot be compared to dates or timestamps.
+	if a.kind.hasDate() != b.kind.hasDate() {
+		return 0, false, nil
+	}
+	origA, origB := a.kind, b.kind
+	// Dates are compared to timestamps at midnight.
+	if a.kind == dtDate && b.kind != dtDate {
+		a.kind = dtTimestamp
+	}
+	if b.kind == dtDate && a.kind != dtDate {
+		b.kind = dtTimestamp
+	}
+	if a.kind.hasZone() != b.kind.hasZone() {
+		if !useTZ {
+			from, to := origA, origB
+			if from.hasZone() {
+				from, to = to, from
+			}
+			return 0, false, errors.WithHint(
+				pgerror.Newf(pgcode.FeatureNotSupported,
+					"cannot convert value from %s to %s without time zone usage", from, to),
+				"Use *_tz() function for time zone support.",
+			)
+		}
+		if !a.kind.hasZone() {
+			a = a.withZone(loc)
+		} else {
+			b = b.withZone(loc)
+		}
+	}
+	if c := a.t.Compare(b.t); c != 0 || a.kind != dtTimeTZ {
+		return c, true, nil
+	}
+	// Times with time zones that represent the same instant are ordered by
+	// their offsets, as in Postgres.
+	_, offA := a.t.Zone()
+	_, offB := b.t.Zone()
+	switch {
+	case offA > offB:
+		return -1, true, nil
+	case offA < offB:
+		return 1, true, nil
+	}
+	return 0, true, nil
+}
+
+// isoTemplates are the templates that are tried in order by .datetime()
+// without an argument.
+var isoTemplates = []string{
+	`yyyy-mm-dd`,
+	`HH24:MI:SS.USTZ`,
+	`HH24:MI:SSTZ`,
+	`HH24:MI:SS.US`,
+	`HH24:MI:SS`,
+	`yyyy-mm-dd HH24:MI:SS.USTZ`,
+	`yyyy-mm-dd HH24:MI:SSTZ`,
+	`yyyy-mm-dd"T"HH24:MI:SS.USTZ`,
+	`yyyy-mm-dd"T"HH24:MI:SSTZ`,
+	`yyyy-mm-dd HH24:MI:SS.US`,
+	`yyyy-mm-dd HH24:MI:SS`,
+	`yyyy-mm-dd"T"HH24:MI:SS.US`,
+	`yyyy-mm-dd"T"HH24:MI:SS`,
+}
+
+// parseDatetime parses s into a datetime item using the given template, or
+// using the ISO 8601 formats if hasTemplate is false.
+func parseDatetime(s string, template string, hasTemplate bool) (datetime, error) {
+	if hasTemplate {
+		return parseDatetimeWithTemplate(s, template, false /* strict */)
+	}
+	for _, tmpl := range isoTemplates {
+		if d, err := parseDatetimeWithTemplate(s, tmpl, true /* strict */); err == nil {
+			return d, nil
+		}
+	}
+	return datetime{}, errors.WithHint(
+		pgerror.Newf(pgcode.InvalidArgumentForSQLJSONDatetimeFunction,
+			"datetime format is not recognized: %q", s),
+		"Use a datetime template argument to specify the input data format.",
+	)
+}
+
+// templateFields are the fields supported in .datetime() templates. Longer
+// fields are listed before their prefixes.
+var templateFields = []string{
+	"HH24", "HH12", "HH", "YYYY", "YY", "MM", "MI", "MS", "DD", "SS", "US",
+	"TZH", "TZM", "TZ", "A.M.", "P.M.", "AM", "PM",
+}
+
+// parseDatetimeWithTemplate parses s using a datetime template. The template
+// language is a subset of the one used by to_timestamp in Postgres. In strict
+// mode, which is used for the ISO 8601 formats, separators must match exactly
+// and time zone offsets must have a sign.
+func parseDatetimeWithTemplate(s string, template string, strict bool) (datetime, error) {
+	year, month, day := timeBaseDate.Year(), 1, 1
+	var hour, minute, sec, nsec, offset int
+	var hasDate, hasTime, hasZone, pm, hour12 bool
+	pos := 0
+	for i := 0; i < len(template); {
+		if template[i] == '"' {
+			end := strings.IndexByte(template[i+1:], '"')
+			if end < 0 {
+				end = len(template) - i - 1
+			}
+			lit := template[i+1 : i+1+end]
+			if !strings.HasPrefix(s[pos:], lit) {
+				return datetime{}, errUnmatchedTemplate(s, lit)
+			}
+			pos += len(lit)
+			i += end + 2
+			continue
+		}
+		field := ""
+		upper := strings.ToUpper(template[i:])
+		for _, f := range templateFields {
+			if strings.HasPrefix(upper, f) {
+				field = f
+				break
+			}
+		}
+		if field == "" {
+			// Any other template character must be matched by the same
+			// character, or by another separator.
+			c := template[i]
+			switch {
+			case pos < len(s) && s[pos] == c:
+				pos++
+			case !strict && pos < len(s) && isSeparator(c) && isSeparator(s[pos]):
+				pos++
+			default:
+				return datetime{}, errUnmatchedTemplate(s, template[i:i+1])
+			}
+			i++
+			continue
+		}
+		i += len(field)
+		var err error
+		switch field {
+		case "YYYY":
+			hasDate = true
+			year, pos, err = scanDigits(s, pos, 4, field)
+		case "YY":
+			hasDate = true
+			year, pos, err = scanDigits(s, pos, 2, field)
+			if year < 70 {
+				year += 2000
+			} else if year < 100 {
+				year += 1900
+			}
+		case "MM":
+			hasDate = true
+			month, pos, err = scanDigits(s, pos, 2, field)
+		case "DD":
+			hasDate = true
+			day, pos, err = scanDigits(s, pos, 2, field)
+		case "HH24":
+			hasTime = true
+			hour, pos, err = scanDigits(s, pos, 2, field)
+		case "HH12", "HH":
+			hasTime, hour12 = true, true
+			hour, pos, err = scanDigits(s, pos, 2, field)
+		case "MI":
+			hasTime = true
+			minute, pos, err = scanDigits(s, pos, 2, field)
+		case "SS":
+			hasTime = true
+			sec, pos, err = scanDigits(s, pos, 2, field)
+		case "MS", "US":
+			hasTime = true
+			width := 3
+			if field == "US" {
+				width = 6
+			}
+			start := pos
+			var frac int
+			frac, pos, err = scanDigits(s, pos, width, field)
+			// The digits are a fraction of a second, so they are scaled by the
+			// number of digits that were omitted.
+			for n := pos - start; n < 9; n++ {
+				frac *= 10
+			}
+			nsec = frac
+		case "TZH", "TZ":
+			hasZone = true
+			sign := 1
+			if pos < len(s) && (s[pos] == '+' || s[pos] == '-') {
+				if s[pos] == '-' {
+					sign = -1
+				}
+				pos++
+			} else if strict {
+				return datetime{}, errUnmatchedTemplate(s, field)
+			}
+			var h, m int
+			h, pos, err = scanDigits(s, pos, 2, field)
+			if err == nil && field == "TZ" && pos < len(s) && s[pos] == ':' {
+				m, pos, err = scanDigits(s, pos+1, 2, field)
+			}
+			offset = sign * (h*3600 + m*60)
+		case "TZM":
+			hasZone = true
+			var m int
+			m, pos, err = scanDigits(s, pos, 2, field)
+			if offset < 0 {
+				offset -= m * 60
+			} else {
+				offset += m * 60
+			}
+		case "AM", "PM", "A.M.", "P.M.":
+			hasTime = true
+			if pos+len(field) > len(s) {
+				return datetime{}, errUnmatchedTemplate(s, field)
+			}
+			switch strings.ToUpper(s[pos : pos+len(field)]) {
+			case "AM", "A.M.":
+			case "PM", "P.M.":
+				pm = true
+			default:
+				return datetime{}, errUnmatchedTemplate(s, field)
+			}
+			pos += len(field)
+		}
+		if err != nil {
+			return datetime{}, err
+		}
+	}
+	if pos < len(s) {
+		return datetime{}, pgerror.New(pgcode.InvalidDatetimeFormat,
+			"trailing characters remain in input string after datetime format")
+	}
+	if hour12 {
+		if hour < 1 || hour > 12 {
+			return datetime{}, errFieldOutOfRange(s)
+		}
+		hour %= 12
+	}
+	if pm {
+		hour += 12
+	}
+	if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 || sec > 59 {
+		return datetime{}, errFieldOutOfRange(s)
+	}
+	loc := time.UTC
+	if hasZone {
+		loc = time.FixedZone("", offset)
+	}
+	t := time.Date(year, time.Month(month), day, hour, minute, sec, nsec, loc)
+	if t.Day() != day {
+		// The day does not exist in the month, and time.Date normalized it.
+		return datetime{}, errFieldOutOfRange(s)
+	}
+	var kind datetimeKind
+	switch {
+	case !hasTime:
+		kind = dtDate
+	case !hasDate && hasZone:
+		kind = dtTimeTZ
+	case !hasDate:
+		kind = dtTime
+	case hasZone:
+		kind = dtTimestampTZ
+	default:
+		kind = dtTimestamp
+	}
+	return datetime{kind: kind, t: t}, nil
+}
+
+func isSeparator(c byte) bool {
+	return !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z')
+}
+
+// scanDigits scans between 1 and maxDigits decimal digits of s, starting at
+// pos.
+func scanDigits(s string, pos int, maxDigits int, field string) (int, int, error) {
+	start := pos
+	for pos < len(s) && pos-start < maxDigits && s[pos] >= '0' && s[pos] <= '9' {
+		pos++
+	}
+	if pos == start {
+		return 0, pos, errUnmatchedTemplate(s, field)
+	}
+	v, err := strconv.Atoi(s[start:pos])
+	return v, pos, err
+}
+
+func errUnmatchedTemplate(s string, field string) error {
+	return pgerror.Newf(pgcode.InvalidDatetimeFormat,
+		"invalid value %q for %q", s, field)
+}
+
+func errFieldOutOfRange(s string) error {
+	return pgerror.Newf(pgcode.DatetimeFieldOverflow,
+		"date/time field value out of range: %q", s)
+}
@@ -0,0 +1,960 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package jsonpath
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+var (
+	// decimalCtx is the context used for division, which matches the default
+	// decimal context of SQL.
+	decimalCtx = &apd.Context{
+		Precision:   20,
+		Rounding:    apd.RoundHalfUp,
+		MaxExponent: 2000,
+		MinExponent: -2000,
+		Traps:       apd.DefaultTraps,
+	}
+	// exactCtx is the context used for all other arithmetic.
+	exactCtx = decimalCtx.WithPrecision(0)
+	// highPrecisionCtx is used for the modulo operator, which cannot be
+	// computed with unlimited precision.
+	highPrecisionCtx = decimalCtx.WithPrecision(2000)
+	// truncateCtx is used to convert array subscripts to integers. Subscripts
+	// that do not fit in its precision are out of range anyway.
+	truncateCtx = func() *apd.Context {
+		ctx := *decimalCtx
+		ctx.Rounding = apd.RoundDown
+		return &ctx
+	}()
+)
+
+// errSilenceable marks the errors that are suppressed when a path is
+// evaluated with the Silent option, or when they occur in the operands of a
+// predicate. Errors that are not marked, such as references to undefined
+// variables, are always reported.
+var errSilenceable = errors.New("silenceable jsonpath error")
+
+func silenceable(err error) error {
+	return errors.Mark(err, errSilenceable)
+}
+
+// Options controls the evaluation of a jsonpath.
+type Options struct {
+	// Silent suppresses the errors caused by missing object fields or array
+	// elements, unexpected JSON item types, and datetime and numeric errors.
+	Silent bool
+	// UseTZ allows date and time values with and without time zones to be
+	// compared, by interpreting the latter in Location. These comparisons
+	// are errors otherwise.
+	UseTZ    bool
+	Location *time.Location
+}
+
+// Query evaluates the path against the target document, and returns the
+// resulting sequence of items. vars is an object that holds the values of the
+// variables referenced by the path, and may be nil. If an error is suppressed
+// by the Silent option, the result is empty.
+func Query(p Jsonpath, target, vars json.JSON, opts Options) ([]json.JSON, error) {
+	items, err := execute(p, target, vars, opts)
+	if err != nil {
+		if opts.Silent && errors.Is(err, errSilenceable) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	res := make([]json.JSON, len(items))
+	for i := range items {
+		res[i] = items[i].toJSON()
+	}
+	return res, nil
+}
+
+// Exists returns whether the path returns any item for the target document.
+// isNull is true if an error was suppressed by the Silent option.
+func Exists(p Jsonpath, target, vars json.JSON, opts Options) (exists, isNull bool, err error) {
+	items, err := execute(p, target, vars, opts)
+	if err != nil {
+		if opts.Silent && errors.Is(err, errSilenceable) {
+			return false, true, nil
+		}
+		return false, false, err
+	}
+	return len(items) > 0, false, nil
+}
+
+// Match returns the result of a path that is a predicate, which must be a
+// single boolean or null item. isNull is true if the result is null or
+// unknown, or if an error was suppressed by the Silent option.
+func Match(p Jsonpath, target, vars json.JSON, opts Options) (result, isNull bool, err error) {
+	items, err := execute(p, target, vars, opts)
+	if err == nil && len(items) == 1 && !items[0].isDatetime {
+		switch items[0].j.Type() {
+		case json.TrueJSONType:
+			return true, false, nil
+		case json.FalseJSONType:
+			return false, false, nil
+		case json.NullJSONType:
+			return false, true, nil
+		}
+	}
+	if err == nil {
+		err = silenceable(pgerror.New(pgcode.SingletonSQLJSONItemRequired,
+			"single boolean result is expected"))
+	}
+	if opts.Silent && errors.Is(err, errSilenceable) {
+		return false, true, nil
+	}
+	return false, false, err
+}
+
+func execute(p Jsonpath, target, vars json.JSON, opts Options) ([]item, error) {
+	if vars != nil && vars.Type() != json.ObjectJSONType {
+		return nil, errors.WithDetail(
+			pgerror.New(pgcode.InvalidParameterValue, `"vars" argument is not an object`),
+			`Jsonpath parameters should be encoded as key-value pairs of "vars" object.`,
+		)
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	ev := evaluator{
+		root:                   target,
+		vars:                   vars,
+		strict:                 p.Strict,
+		ignoreStructuralErrors: !p.Strict,
+		opts:                   opts,
+		innermostArraySize:     -1,
+	}
+	return ev.eval(p.Expr)
+}
+
+// item is a value produced during the evaluation of a path. It is either a
+// JSON value, or a datetime produced by the .datetime() method.
+type item struct {
+	j          json.JSON
+	dt         datetime
+	isDatetime bool
+}
+
+func jsonItem(j json.JSON) item {
+	return item{j: j}
+}
+
+func (it item) toJSON() json.JSON {
+	if it.isDatetime {
+		return json.FromString(it.dt.String())
+	}
+	return it.j
+}
+
+func (it item) isArray() bool {
+	return !it.isDatetime && it.j.Type() == json.ArrayJSONType
+}
+
+func (it item) isObject() bool {
+	return !it.isDatetime && it.j.Type() == json.ObjectJSONType
+}
+
+// elements returns the elements of an array item.
+func (it item) elements() []item {
+	elems, _ := it.j.AsArray()
+	res := make([]item, len(elems))
+	for i := range elems {
+		res[i] = jsonItem(elems[i])
+	}
+	return res
+}
+
+// objectValues returns the values of an object item.
+func (it item) objectValues() ([]string, []item, error) {
+	iter, err := it.j.ObjectIter()
+	if err != nil || iter == nil {
+		return nil, nil, err
+	}
+	var keys []string
+	var values []item
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+		values = append(values, jsonItem(iter.Value()))
+	}
+	return keys, values, nil
+}
+
+// triBool is the result of a predicate, which may be unknown.
+type triBool int
+
+const (
+	triFalse triBool = iota
+	triTrue
+	triUnknown
+)
+
+func makeTriBool(b bool) triBool {
+	if b {
+		return triTrue
+	}
+	return triFalse
+}
+
+type evaluator struct {
+	root json.JSON
+	vars json.JSON
+	// strict is true if the path is evaluated in strict mode.
+	strict bool
+	// ignoreStructuralErrors is true if errors caused by the structure of the
+	// document, such as missing keys, are ignored. This is the case in lax
+	// mode, and for the accessor that follows .** in strict mode.
+	ignoreStructuralErrors bool
+	opts                   Options
+	// current is the item being tested by the innermost filter, which is
+	// referred to by @.
+	current item
+	// innermostArraySize is the size of the array being subscripted, which is
+	// used to evaluate last. It is -1 outside of array subscripts.
+	innermostArraySize int
+}
+
+// structuralError returns err, or no error if structural errors are ignored.
+func (ev *evaluator) structuralError(err error) ([]item, error) {
+	if ev.ignoreStructuralErrors {
+		return nil, nil
+	}
+	return nil, silenceable(err)
+}
+
+// eval evaluates an expression and returns the resulting sequence of items.
+func (ev *evaluator) eval(e Expr) ([]item, error) {
+	switch t := e.(type) {
+	case Root:
+		return []item{jsonItem(ev.root)}, nil
+
+	case Current:
+		return []item{ev.current}, nil
+
+	case Last:
+		if ev.innermostArraySize < 0 {
+			return nil, errors.AssertionFailedf("evaluating jsonpath LAST outside of array subscript")
+		}
+		return []item{jsonItem(json.FromInt(ev.innermostArraySize - 1))}, nil
+
+	case Variable:
+		var v json.JSON
+		if ev.vars != nil {
+			var err error
+			if v, err = ev.vars.FetchValKey(string(t)); err != nil {
+				return nil, err
+			}
+		}
+		if v == nil {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"could not find jsonpath variable %q", string(t))
+		}
+		return []item{jsonItem(v)}, nil
+
+	case Scalar:
+		return []item{jsonItem(t.Value)}, nil
+
+	case Path:
+		items, err := ev.eval(t[0])
+		if err != nil {
+			return nil, err
+		}
+		for i, acc := range t[1:] {
+			var next []item
+			for _, it := range items {
+				res, err := ev.evalAccessorAfter(acc, it, t[i])
+				if err != nil {
+					return nil, err
+				}
+				next = append(next, res...)
+			}
+			items = next
+		}
+		return items, nil
+
+	case *Binary:
+		if isPredicate(t) {
+			return ev.evalPredicateItem(t)
+		}
+		return ev.evalArithmetic(t)
+
+	case *Unary:
+		if isPredicate(t) {
+			return ev.evalPredicateItem(t)
+		}
+		return ev.evalUnaryArithmetic(t)
+
+	case *LikeRegex:
+		return ev.evalPredicateItem(t)
+	}
+	return nil, errors.AssertionFailedf("unexpected jsonpath expression %T", e)
+}
+
+// evalAccessorAfter applies an accessor to an item produced by prev.
+func (ev *evaluator) evalAccessorAfter(acc Expr, it item, prev Expr) ([]item, error) {
+	if _, ok := prev.(AnyPath); ok && !ev.ignoreStructuralErrors {
+		// Structural errors are ignored for the items found by .**, since they
+		// include all the levels of the document.
+		ev.ignoreStructuralErrors = true
+		defer func() { ev.ignoreStructuralErrors = false }()
+	}
+	return ev.evalAccessor(acc, it, !ev.strict /* unwrap */)
+}
+
+// evalUnwrapped evaluates an expression, and in lax mode replaces the arrays
+// in the result with their elements.
+func (ev *evaluator) evalUnwrapped(e Expr) ([]item, error) {
+	items, err := ev.eval(e)
+	if err != nil || ev.strict {
+		return items, err
+	}
+	var res []item
+	for _, it := range items {
+		if it.isArray() {
+			res = append(res, it.elements()...)
+		} else {
+			res = append(res, it)
+		}
+	}
+	return res, nil
+}
+
+// unwrapsInput returns true if the accessor is applied to the elements of an
+// array in lax mode, rather than to the array itself.
+func unwrapsInput(acc Expr) bool {
+	switch t := acc.(type) {
+	case Key, AnyKey, Filter:
+		return true
+	case Method:
+		return t.Kind != MethodType && t.Kind != MethodSize
+	}
+	return false
+}
+
+// evalAccessor applies an accessor to an item. If unwrap is true and the
+// accessor supports it, arrays are unwrapped and the accessor is applied to
+// each of their elements.
+func (ev *evaluator) evalAccessor(acc Expr, it item, unwrap bool) ([]item, error) {
+	if unwrap && it.isArray() && unwrapsInput(acc) {
+		var res []item
+		for _, elem := range it.elements() {
+			r, err := ev.evalAccessor(acc, elem, false /* unwrap */)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, r...)
+		}
+		return res, nil
+	}
+
+	switch t := acc.(type) {
+	case Key:
+		if !it.isObject() {
+			return ev.structuralError(pgerror.New(pgcode.SQLJSONMemberNotFound,
+				"jsonpath member accessor can only be applied to an object"))
+		}
+		v, err := it.j.FetchValKey(string(t))
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return ev.structuralError(pgerror.Newf(pgcode.SQLJSONMemberNotFound,
+				"JSON object does not contain key %q", string(t)))
+		}
+		return []item{jsonItem(v)}, nil
+
+	case AnyKey:
+		if !it.isObject() {
+			return ev.structuralError(pgerror.New(pgcode.SQLJSONObjectNotFound,
+				"jsonpath wildcard member accessor can only be applied to an object"))
+		}
+		_, values, err := it.objectValues()
+		return values, err
+
+	case AnyArray:
+		if it.isArray() {
+			return it.elements(), nil
+		}
+		if !ev.strict {
+			return []item{it}, nil
+		}
+		return ev.structuralError(pgerror.New(pgcode.SQLJSONArrayNotFound,
+			"jsonpath wildcard array accessor can only be applied to an array"))
+
+	case ArrayList:
+		return ev.evalSubscripts(t, it)
+
+	case AnyPath:
+		var res []item
+		if t.First == 0 {
+			res = append(res, it)
+		}
+		return ev.evalAnyPath(it, 1 /* level */, t, res)
+
+	case Filter:
+		saved := ev.current
+		ev.current = it
+		res, err := ev.evalPredicate(t.Cond)
+		ev.current = saved
+		if err != nil || res != triTrue {
+			return nil, err
+		}
+		return []item{it}, nil
+
+	case Method:
+		return ev.evalMethod(t, it)
+	}
+	return nil, errors.AssertionFailedf("unexpected jsonpath accessor %T", acc)
+}
+
+func (ev *evaluator) evalSubscripts(l ArrayList, it item) ([]item, error) {
+	var elems []item
+	if it.isArray() {
+		elems = it.elements()
+	} else if !ev.strict {
+		// In lax mode, non-array items are treated as single-element arrays.
+		elems = []item{it}
+	} else {
+		return ev.structuralError(pgerror.New(pgcode.SQLJSONArrayNotFound,
+			"jsonpath array accessor can only be applied to an array"))
+	}
+	saved := ev.innermostArraySize
+	ev.innermostArraySize = len(elems)
+	defer func() { ev.innermostArraySize = saved }()
+
+	var res []item
+	for _, s := range l {
+		from, err := ev.evalArrayIndex(s.From)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if s.To != nil {
+			if to, err = ev.evalArrayIndex(s.To); err != nil {
+				return nil, err
+			}
+		}
+		if from < 0 || from > to || to >= len(elems) {
+			if !ev.ignoreStructuralErrors {
+				return nil, silenceable(pgerror.New(pgcode.InvalidSQLJSONSubscript,
+					"jsonpath array subscript is out of bounds"))
+			}
+			from = max(from, 0)
+			to = min(to, len(elems)-1)
+		}
+		for i := from; i <= to; i++ {
+			res = append(res, elems[i])
+		}
+	}
+	return res, nil
+}
+
+// evalArrayIndex evaluates an array subscript, which must be a single number.
+// The number is truncated to an integer.
+func (ev *evaluator) evalArrayIndex(e Expr) (int, error) {
+	items, err := ev.eval(e)
+	if err != nil {
+		return 0, err
+	}
+	if len(items) != 1 || items[0].isDatetime || items[0].j.Type() != json.NumberJSONType {
+		return 0, silenceable(pgerror.New(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is not a single numeric value"))
+	}
+	d, _ := items[0].j.AsDecimal()
+	var truncated apd.Decimal
+	if _, err := truncateCtx.Quantize(&truncated, d, 0); err == nil {
+		if i, err := truncated.Int64(); err == nil && i >= math.MinInt32 && i <= math.MaxInt32 {
+			return int(i), nil
+		}
+	}
+	return 0, silenceable(pgerror.New(pgcode.InvalidSQLJSONSubscript,
+		"jsonpath array subscript is out of integer range"))
+}
+
+// evalAnyPath appends the items of the levels of it that are selected by the
+// .** accessor to res. The children of it are at the given level.
+func (ev *evaluator) evalAnyPath(it item, level uint32, a AnyPath, res []item) ([]item, error) {
+	var children []item
+	switch {
+	case it.isArray():
+		children = it.elements()
+	case it.isObject():
+		var err error
+		if _, children, err = it.objectValues(); err != nil {
+			return nil, err
+		}
+	}
+	for _, child := range children {
+		isContainer := child.isArray() || child.isObject()
+		// .**{last} selects the leaves of the document.
+		if level >= a.First || (a.First == AnyLevel && a.Last == AnyLevel && !isContainer) {
+			res = append(res, child)
+		}
+		if level < a.Last && isContainer {
+			var err error
+			if res, err = ev.evalAnyPath(child, level+1, a, res); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+func (ev *evaluator) evalMethod(m Method, it item) ([]item, error) {
+	switch m.Kind {
+	case MethodType:
+		var typ string
+		if it.isDatetime {
+			typ = it.dt.kind.String()
+		} else {
+			switch it.j.Type() {
+			case json.NullJSONType:
+				typ = "null"
+			case json.StringJSONType:
+				typ = "string"
+			case json.NumberJSONType:
+				typ = "number"
+			case json.TrueJSONType, json.FalseJSONType:
+				typ = "boolean"
+			case json.ArrayJSONType:
+				typ = "array"
+			case json.ObjectJSONType:
+				typ = "object"
+			}
+		}
+		return []item{jsonItem(json.FromString(typ))}, nil
+
+	case MethodSize:
+		if it.isArray() {
+			return []item{jsonItem(json.FromInt(it.j.Len()))}, nil
+		}
+		if !ev.strict {
+			return []item{jsonItem(json.FromInt(1))}, nil
+		}
+		return ev.structuralError(pgerror.New(pgcode.SQLJSONArrayNotFound,
+			"jsonpath item method .size() can only be applied to an array"))
+
+	case MethodDouble:
+		var f float64
+		switch {
+		case !it.isDatetime && it.j.Type() == json.NumberJSONType:
+			d, _ := it.j.AsDecimal()
+			var err error
+			if f, err = d.Float64(); err != nil {
+				return nil, silenceable(pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+					"numeric argument of jsonpath item method .%s() is out of range for type double precision", m.Kind))
+			}
+		case !it.isDatetime && it.j.Type() == json.StringJSONType:
+			s, _ := it.j.AsText()
+			var err error
+			if f, err = strconv.ParseFloat(strings.TrimSpace(*s), 64); err != nil {
+				return nil, silenceable(pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+					"argument %q of jsonpath item method .%s() is invalid for type double precision", *s, m.Kind))
+			}
+		default:
+			return nil, silenceable(pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+				"jsonpath item method .%s() can only be applied to a string or numeric value", m.Kind))
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, silenceable(pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+				"NaN or Infinity is not allowed for jsonpath item method .%s()", m.Kind))
+		}
+		j, err := json.FromFloat64(f)
+		if err != nil {
+			return nil, err
+		}
+		return []item{jsonItem(j)}, nil
+
+	case MethodCeiling, MethodFloor, MethodAbs:
+		if it.isDatetime || it.j.Type() != json.NumberJSONType {
+			return nil, silenceable(pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+				"jsonpath item method .%s() can only be applied to a numeric value", m.Kind))
+		}
+		d, _ := it.j.AsDecimal()
+		var res apd.Decimal
+		var err error
+		switch m.Kind {
+		case MethodCeiling:
+			_, err = exactCtx.Ceil(&res, d)
+		case MethodFloor:
+			_, err = exactCtx.Floor(&res, d)
+		default:
+			res.Abs(d)
+		}
+		if err != nil {
+			return nil, silenceable(pgerror.WithCandidateCode(err, pgcode.NumericValueOutOfRange))
+		}
+		// Avoid returning negative zero, which does not exist in Postgres.
+		if res.IsZero() {
+			res.Negative = false
+		}
+		return []item{jsonItem(json.FromDecimal(res))}, nil
+
+	case MethodKeyValue:
+		if !it.isObject() {
+			return nil, silenceable(pgerror.New(pgcode.SQLJSONObjectNotFound,
+				"jsonpath item method .keyvalue() can only be applied to an object"))
+		}
+		keys, values, err := it.objectValues()
+		if err != nil {
+			return nil, err
+		}
+		res := make([]item, len(keys))
+		for i := range keys {
+			// Unlike Postgres, which derives the id from the position of the
+			// object in the document, the id is always 0.
+			b := json.NewObjectBuilder(3)
+			b.Add("id", json.FromInt(0))
+			b.Add("key", json.FromString(keys[i]))
+			b.Add("value", values[i].j)
+			res[i] = jsonItem(b.Build())
+		}
+		return res, nil
+
+	case MethodDatetime:
+		if it.isDatetime || it.j.Type() != json.StringJSONType {
+			return nil, silenceable(pgerror.New(pgcode.InvalidArgumentForSQLJSONDatetimeFunction,
+				"jsonpath item method .datetime() can only be applied to a string"))
+		}
+		s, _ := it.j.AsText()
+		dt, err := parseDatetime(*s, m.Template, m.HasTemplate)
+		if err != nil {
+			return nil, silenceable(err)
+		}
+		return []item{{dt: dt, isDatetime: true}}, nil
+	}
+	return nil, errors.AssertionFailedf("unexpected jsonpath method %d", m.Kind)
+}
+
+// singleNumber returns the number of a sequence that must consist of a single
+// numeric item.
+func singleNumber(items []item, side string, op Operation) (*apd.Decimal, error) {
+	if len(items) != 1 || items[0].isDatetime || items[0].j.Type() != json.NumberJSONType {
+		return nil, silenceable(pgerror.Newf(pgcode.SingletonSQLJSONItemRequired,
+			"%s operand of jsonpath operator %s is not a single numeric value", side, op))
+	}
+	d, _ := items[0].j.AsDecimal()
+	return d, nil
+}
+
+func (ev *evaluator) evalArithmetic(b *Binary) ([]item, error) {
+	lseq, err := ev.evalUnwrapped(b.Left)
+	if err != nil {
+		return nil, err
+	}
+	rseq, err := ev.evalUnwrapped(b.Right)
+	if err != nil {
+		return nil, err
+	}
+	l, err := singleNumber(lseq, "left", b.Op)
+	if err != nil {
+		return nil, err
+	}
+	r, err := singleNumber(rseq, "right", b.Op)
+	if err != nil {
+		return nil, err
+	}
+	var res apd.Decimal
+	switch b.Op {
+	case OpAdd:
+		_, err = exactCtx.Add(&res, l, r)
+	case OpSub:
+		_, err = exactCtx.Sub(&res, l, r)
+	case OpMul:
+		_, err = exactCtx.Mul(&res, l, r)
+	case OpDiv, OpMod:
+		if r.IsZero() {
+			return nil, silenceable(pgerror.New(pgcode.DivisionByZero, "division by zero"))
+		}
+		if b.Op == OpDiv {
+			_, err = decimalCtx.Quo(&res, l, r)
+		} else {
+			_, err = highPrecisionCtx.Rem(&res, l, r)
+		}
+	default:
+		return nil, errors.AssertionFailedf("unexpected jsonpath operator %s", b.Op)
+	}
+	if err != nil {
+		return nil, silenceable(pgerror.WithCandidateCode(err, pgcode.NumericValueOutOfRange))
+	}
+	return []item{jsonItem(json.FromDecimal(res))}, nil
+}
+
+func (ev *evaluator) evalUnaryArithmetic(u *Unary) ([]item, error) {
+	items, err := ev.evalUnwrapped(u.Expr)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		if items[i].isDatetime || items[i].j.Type() != json.NumberJSONType {
+			return nil, silenceable(pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+				"operand of unary jsonpath operator %s is not a numeric value", u.Op))
+		}
+		if u.Op == OpMinus {
+			d, _ := items[i].j.AsDecimal()
+			var neg apd.Decimal
+			neg.Neg(d)
+			if neg.IsZero() {
+				neg.Negative = false
+			}
+			items[i] = jsonItem(json.FromDecimal(neg))
+		}
+	}
+	return items, nil
+}
+
+// evalPredicateItem evaluates a predicate that is used as an expression,
+// which returns a boolean item, or null if the result is unknown.
+func (ev *evaluator) evalPredicateItem(e Expr) ([]item, error) {
+	res, err := ev.evalPredicate(e)
+	if err != nil {
+		return nil, err
+	}
+	switch res {
+	case triTrue:
+		return []item{jsonItem(json.TrueJSONValue)}, nil
+	case triFalse:
+		return []item{jsonItem(json.FalseJSONValue)}, nil
+	}
+	return []item{jsonItem(json.NullJSONValue)}, nil
+}
+
+func (ev *evaluator) evalPredicate(e Expr) (triBool, error) {
+	switch t := e.(type) {
+	case *Binary:
+		switch t.Op {
+		case OpAnd:
+			l, err := ev.evalPredicate(t.Left)
+			if err != nil || l == triFalse {
+				return triFalse, err
+			}
+			r, err := ev.evalPredicate(t.Right)
+			if err != nil {
+				return triFalse, err
+			}
+			if l == triTrue || r == triFalse {
+				return r, nil
+			}
+			return triUnknown, nil
+
+		case OpOr:
+			l, err := ev.evalPredicate(t.Left)
+			if err != nil || l == triTrue {
+				return triTrue, err
+			}
+			r, err := ev.evalPredicate(t.Right)
+			if err != nil {
+				return triFalse, err
+			}
+			if l == triFalse || r == triTrue {
+				return r, nil
+			}
+			return triUnknown, nil
+
+		case OpStartsWith:
+			return ev.evalComparisonPredicate(t.Left, t.Right, false /* unwrapRight */, func(l, r item) (triBool, error) {
+				if l.isDatetime || r.isDatetime ||
+					l.j.Type() != json.StringJSONType || r.j.Type() != json.StringJSONType {
+					return triUnknown, nil
+				}
+				ls, _ := l.j.AsText()
+				rs, _ := r.j.AsText()
+				return makeTriBool(strings.HasPrefix(*ls, *rs)), nil
+			})
+		}
+		return ev.evalComparisonPredicate(t.Left, t.Right, true /* unwrapRight */, func(l, r item) (triBool, error) {
+			return ev.compareItems(t.Op, l, r)
+		})
+
+	case *Unary:
+		switch t.Op {
+		case OpNot:
+			res, err := ev.evalPredicate(t.Expr)
+			switch res {
+			case triTrue:
+				return triFalse, err
+			case triFalse:
+				return triTrue, err
+			}
+			return triUnknown, err
+
+		case OpIsUnknown:
+			res, err := ev.evalPredicate(t.Expr)
+			return makeTriBool(res == triUnknown), err
+
+		case OpExists:
+			items, err := ev.eval(t.Expr)
+			if err != nil {
+				if errors.Is(err, errSilenceable) {
+					return triUnknown, nil
+				}
+				return triFalse, err
+			}
+			return makeTriBool(len(items) > 0), nil
+		}
+
+	case *LikeRegex:
+		return ev.evalComparisonPredicate(t.Expr, nil, false /* unwrapRight */, func(l, _ item) (triBool, error) {
+			if l.isDatetime || l.j.Type() != json.StringJSONType {
+				return triUnknown, nil
+			}
+			s, _ := l.j.AsText()
+			return makeTriBool(t.re.MatchString(*s)), nil
+		})
+	}
+	return triFalse, errors.AssertionFailedf("unexpected jsonpath predicate %T", e)
+}
+
+// evalComparisonPredicate evaluates a predicate that compares each item of
+// the left sequence with each item of the right sequence, if any. Errors in
+// the evaluation of the operands make the result unknown. In lax mode, the
+// result is true if any comparison is true; in strict mode, the result is
+// unknown if any comparison is unknown.
+func (ev *evaluator) evalComparisonPredicate(
+	left, right Expr, unwrapRight bool, cmp func(l, r item) (triBool, error),
+) (triBool, error) {
+	lseq, err := ev.evalUnwrapped(left)
+	if err != nil {
+		return ev.operandError(err)
+	}
+	rseq := []item{{}}
+	if right != nil {
+		if unwrapRight {
+			rseq, err = ev.evalUnwrapped(right)
+		} else {
+			rseq, err = ev.eval(right)
+		}
+		if err != nil {
+			return ev.operandError(err)
+		}
+	}
+	var found, unknown bool
+	for _, l := range lseq {
+		for _, r := range rseq {
+			res, err := cmp(l, r)
+			if err != nil {
+				return triFalse, err
+			}
+			switch res {
+			case triUnknown:
+				if ev.strict {
+					return triUnknown, nil
+				}
+				unknown = true
+			case triTrue:
+				if !ev.strict {
+					return triTrue, nil
+				}
+				found = true
+			}
+		}
+	}
+	switch {
+	case found:
+		return triTrue, nil
+	case unknown:
+		return triUnknown, nil
+	}
+	return triFalse, nil
+}
+
+// operandError converts an error in the evaluation of the operand of a
+// predicate to an unknown result, unless it cannot be suppressed.
+func (ev *evaluator) operandError(err error) (triBool, error) {
+	if errors.Is(err, errSilenceable) {
+		return triUnknown, nil
+	}
+	return triFalse, err
+}
+
+// itemClass groups the types of items that can be compared with each other.
+type itemClass int
+
+const (
+	classNull itemClass = iota
+	classBool
+	classNumber
+	classString
+	classContainer
+	classDatetime
+)
+
+func (it item) class() itemClass {
+	if it.isDatetime {
+		return classDatetime
+	}
+	switch it.j.Type() {
+	case json.NullJSONType:
+		return classNull
+	case json.TrueJSONType, json.FalseJSONType:
+		return classBool
+	case json.NumberJSONType:
+		return classNumber
+	case json.StringJSONType:
+		return classString
+	}
+	return classContainer
+}
+
+// compareItems evaluates a comparison between two items.
+func (ev *evaluator) compareItems(op Operation, l, r item) (triBool, error) {
+	lc, rc := l.class(), r.class()
+	if lc != rc {
+		// Null is not equal to any other value, and other values of different
+		// types cannot be compared.
+		if lc == classNull || rc == classNull {
+			return makeTriBool(op == OpNotEqual), nil
+		}
+		return triUnknown, nil
+	}
+	var cmp int
+	switch lc {
+	case classNull:
+	case classBool, classNumber, classString:
+		var err error
+		if cmp, err = l.j.Compare(r.j); err != nil {
+			return triFalse, err
+		}
+	case classDatetime:
+		var ok bool
+		var err error
+		cmp, ok, err = compareDatetimes(l.dt, r.dt, ev.opts.UseTZ, ev.opts.Location)
+		if err != nil || !ok {
+			return triUnknown, err
+		}
+	default:
+		return triUnknown, nil
+	}
+	var res bool
+	switch op {
+	case OpEqual:
+		res = cmp == 0
+	case OpNotEqual:
+		res = cmp != 0
+	case OpLess:
+		res = cmp < 0
+	case OpLessOrEqual:
+		res = cmp <= 0
+	case OpGreater:
+		res = cmp > 0
+	case OpGreaterOrEqual:
+		res = cmp >= 0
+	default:
+		return triFalse, errors.AssertionFailedf("unexpected jsonpath comparison %s", op)
+	}
+	return makeTriBool(res), nil
+}
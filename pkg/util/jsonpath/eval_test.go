@@ -0,0 +1,243 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package jsonpath
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	for _, tc := range []struct {
+		target string
+		path   string
+		vars   string
+		// expected is the list of results separated by semicolons, or an error
+		// if err is set.
+		expected string
+		err      string
+		silent   bool
+	}{
+		{target: `{"a": 12}`, path: `$.a`, expected: `12`},
+		{target: `{"a": 12}`, path: `$.b`, expected: ``},
+		{target: `{"a": 12}`, path: `strict $.b`, err: `JSON object does not contain key "b"`},
+		{target: `{"a": 12}`, path: `strict $.b`, silent: true, expected: ``},
+		{target: `[{"a": 1}, {"a": 2}, 3]`, path: `$.a`, expected: `1;2`},
+		{target: `[{"a": 1}, {"a": 2}]`, path: `strict $.a`,
+			err: `jsonpath member accessor can only be applied to an object`},
+		{target: `[[{"a": 1}]]`, path: `$.a`, expected: ``},
+		{target: `[1, "a", null]`, path: `$[*]`, expected: `1;"a";null`},
+		{target: `[1, 2, 3]`, path: `$[1]`, expected: `2`},
+		{target: `[1, 2, 3]`, path: `$[last]`, expected: `3`},
+		{target: `[1, 2, 3]`, path: `$[1 to last]`, expected: `2;3`},
+		{target: `[1, 2, 3]`, path: `$[last - 1, 0]`, expected: `2;1`},
+		{target: `[1, 2, 3]`, path: `$[1.7]`, expected: `2`},
+		{target: `[1, 2, 3]`, path: `$[5]`, expected: ``},
+		{target: `[1, 2, 3]`, path: `$[1 to 5]`, expected: `2;3`},
+		{target: `[1, 2, 3]`, path: `strict $[5]`, err: `jsonpath array subscript is out of bounds`},
+		{target: `[1, 2, 3]`, path: `$["a"]`, err: `jsonpath array subscript is not a single numeric value`},
+		{target: `1`, path: `$[0]`, expected: `1`},
+		{target: `1`, path: `$[*]`, expected: `1`},
+		{target: `1`, path: `strict $[0]`, err: `jsonpath array accessor can only be applied to an array`},
+		{target: `{"a": 1, "b": [2]}`, path: `$.*`, expected: `1;[2]`},
+		{target: `1`, path: `strict $.*`,
+			err: `jsonpath wildcard member accessor can only be applied to an object`},
+		{target: `{"a": {"b": 1}}`, path: `$.**`, expected: `{"a": {"b": 1}};{"b": 1};1`},
+		{target: `{"a": {"b": 1}}`, path: `$.**{1}`, expected: `{"b": 1}`},
+		{target: `{"a": {"b": 1}}`, path: `$.**{last}`, expected: `1`},
+		{target: `{"a": {"b": [1, 2]}}`, path: `$.**{2 to last}`, expected: `[1, 2];1;2`},
+		{target: `{"a": 1, "b": {"a": 2}}`, path: `strict $.**.a`, expected: `1;2`},
+
+		// Filters.
+		{target: `[1, 2, 3]`, path: `$ ? (@ > 1)`, expected: `2;3`},
+		{target: `[1, 2, 3]`, path: `strict $ ? (@ > 1)`, expected: ``},
+		{target: `[1, 2, 3]`, path: `strict $[*] ? (@ > 1)`, expected: `2;3`},
+		{target: `{"a": [[1]]}`, path: `$.a ? (@ == 1)`, expected: `[1]`},
+		{target: `[{"a": 1}, {"a": 2}]`, path: `$[*] ? (@.a == 1)`, expected: `{"a": 1}`},
+		{target: `[1, 2, 3]`, path: `$[*] ? (@ > 1 && @ < 3)`, expected: `2`},
+		{target: `[1, 2, 3]`, path: `$[*] ? (@ < 2 || @ > 2)`, expected: `1;3`},
+		{target: `[1, 2, 3]`, path: `$[*] ? (!(@ == 2))`, expected: `1;3`},
+		{target: `[1, "a"]`, path: `$[*] ? ((@ == "a") is unknown)`, expected: `1`},
+		{target: `[{"a": 1}, {"b": 1}]`, path: `$[*] ? (exists (@.a))`, expected: `{"a": 1}`},
+		{target: `[1, 2]`, path: `$[*] ? (@ > $x)`, vars: `{"x": 1}`, expected: `2`},
+		{target: `["abc", "x", 1]`, path: `$[*] ? (@ starts with "ab")`, expected: `"abc"`},
+		{target: `["abc", "x"]`, path: `$[*] ? (@ starts with $p)`, vars: `{"p": "x"}`, expected: `"x"`},
+		{target: `["Abc", "b"]`, path: `$[*] ? (@ like_regex "^a" flag "i")`, expected: `"Abc"`},
+		{target: `["a.c", "abc"]`, path: `$[*] ? (@ like_regex "a.c" flag "q")`, expected: `"a.c"`},
+		{target: `[1, "a", null, [1]]`, path: `$[*] ? (@ != null)`, expected: `1;"a";1`},
+		{target: `[1, "a", null]`, path: `$[*] ? (@ == null)`, expected: `null`},
+		{target: `[1, [1, 2]]`, path: `strict $ ? (@[*] > 1)`, expected: ``},
+		{target: `[{"a": "x"}, {"a": 1}]`, path: `$[*] ? (@.a / 2 == 0.5)`, expected: `{"a": 1}`},
+
+		// Arithmetic.
+		{target: `{"a": 3}`, path: `$.a + 2`, expected: `5`},
+		{target: `[1]`, path: `$ + 1`, expected: `2`},
+		{target: `[1, 2]`, path: `$ + 1`, err: `left operand of jsonpath operator + is not a single numeric value`},
+		{target: `1`, path: `$ + "a"`, err: `right operand of jsonpath operator + is not a single numeric value`},
+		{target: `1`, path: `$ / 0`, err: `division by zero`},
+		{target: `1`, path: `$ / 0`, silent: true, expected: ``},
+		{target: `7`, path: `$ % 3`, expected: `1`},
+		{target: `1`, path: `$ / 4`, expected: `0.25000000000000000000`},
+		{target: `1.5`, path: `$ * 2`, expected: `3.0`},
+		{target: `[1, 2]`, path: `-$[*]`, expected: `-1;-2`},
+		{target: `"a"`, path: `-$`, err: `operand of unary jsonpath operator - is not a numeric value`},
+
+		// Predicates as expressions.
+		{target: `1`, path: `$ == 1`, expected: `true`},
+		{target: `1`, path: `$ == "a"`, expected: `null`},
+		{target: `{"a": 1}`, path: `$.a == null`, expected: `false`},
+		{target: `{"a": 1}`, path: `$.a != null`, expected: `true`},
+		{target: `[1, 2]`, path: `$[*] > 1`, expected: `true`},
+		{target: `[1, "a"]`, path: `strict $[*] > 0`, expected: `null`},
+
+		// Methods.
+		{target: `[null, true, 1, "a", [], {}]`, path: `$[*].type()`,
+			expected: `"null";"boolean";"number";"string";"array";"object"`},
+		{target: `[[1, 2]]`, path: `$.type()`, expected: `"array"`},
+		{target: `[1, 2]`, path: `$.size()`, expected: `2`},
+		{target: `1`, path: `$.size()`, expected: `1`},
+		{target: `1`, path: `strict $.size()`, err: `jsonpath item method .size() can only be applied to an array`},
+		{target: `["1.5", 2]`, path: `$.double()`, expected: `1.5;2`},
+		{target: `"x"`, path: `$.double()`,
+			err: `argument "x" of jsonpath item method .double() is invalid for type double precision`},
+		{target: `"NaN"`, path: `$.double()`, err: `NaN or Infinity is not allowed for jsonpath item method .double()`},
+		{target: `true`, path: `$.double()`,
+			err: `jsonpath item method .double() can only be applied to a string or numeric value`},
+		{target: `[1.5, -1.5, -0.5]`, path: `$.floor()`, expected: `1;-2;-1`},
+		{target: `[1.5, -1.5, -0.5]`, path: `$.ceiling()`, expected: `2;-1;0`},
+		{target: `[-2, 3.5]`, path: `$.abs()`, expected: `2;3.5`},
+		{target: `"a"`, path: `$.abs()`, err: `jsonpath item method .abs() can only be applied to a numeric value`},
+		{target: `{"a": 1, "b": [2]}`, path: `$.keyvalue()`,
+			expected: `{"id": 0, "key": "a", "value": 1};{"id": 0, "key": "b", "value": [2]}`},
+		{target: `{"a": 1}`, path: `$.keyvalue().key`, expected: `"a"`},
+		{target: `1`, path: `$.keyvalue()`, err: `jsonpath item method .keyvalue() can only be applied to an object`},
+
+		// Datetimes.
+		{target: `"2017-03-10"`, path: `$.datetime()`, expected: `"2017-03-10"`},
+		{target: `"2017-03-10"`, path: `$.datetime().type()`, expected: `"date"`},
+		{target: `"12:34:56"`, path: `$.datetime().type()`, expected: `"time without time zone"`},
+		{target: `"12:34:56.789+05:30"`, path: `$.datetime()`, expected: `"12:34:56.789+05:30"`},
+		{target: `"2017-03-10 12:34:56"`, path: `$.datetime()`, expected: `"2017-03-10T12:34:56"`},
+		{target: `"2017-03-10T12:34:56+3"`, path: `$.datetime()`, expected: `"2017-03-10T12:34:56+03:00"`},
+		{target: `"2017-03-10 12:34:56+3"`, path: `$.datetime().type()`, expected: `"timestamp with time zone"`},
+		{target: `"10-03-2017"`, path: `$.datetime("dd-mm-yyyy")`, expected: `"2017-03-10"`},
+		{target: `"10/03/17 04:05 PM"`, path: `$.datetime("dd/mm/yy HH:MI AM")`, expected: `"2017-03-10T16:05:00"`},
+		{target: `"12:30 +02"`, path: `$.datetime("HH24:MI TZH")`, expected: `"12:30:00+02:00"`},
+		{target: `"2017-02-30"`, path: `$.datetime()`, err: `datetime format is not recognized: "2017-02-30"`},
+		{target: `"2017-02-30"`, path: `$.datetime("yyyy-mm-dd")`, err: `date/time field value out of range`},
+		{target: `"10-03-2017"`, path: `$.datetime()`, err: `datetime format is not recognized: "10-03-2017"`},
+		{target: `"10-03-2017 10"`, path: `$.datetime("dd-mm-yyyy")`,
+			err: `trailing characters remain in input string after datetime format`},
+		{target: `1`, path: `$.datetime()`, err: `jsonpath item method .datetime() can only be applied to a string`},
+		{target: `["2017-03-09", "2017-03-10", "2017-03-11"]`,
+			path:     `$[*] ? (@.datetime() >= "2017-03-10".datetime())`,
+			expected: `"2017-03-10";"2017-03-11"`},
+		{target: `["2017-03-10", "2017-03-10 00:00:01"]`,
+			path:     `$[*] ? (@.datetime() > "2017-03-10".datetime())`,
+			expected: `"2017-03-10 00:00:01"`},
+		{target: `["2017-03-10", "12:34:56"]`, path: `$[*] ? (@.datetime() < "2018-01-01".datetime())`,
+			expected: `"2017-03-10"`},
+		{target: `"2017-03-10"`, path: `$.datetime() < "2017-03-10 12:00:00+01".datetime()`,
+			err: `cannot convert value from date to timestamp with time zone without time zone usage`},
+
+		// Variables.
+		{target: `1`, path: `$x`, vars: `{"x": [1, 2]}`, expected: `[1, 2]`},
+		{target: `1`, path: `$y`, vars: `{"x": 1}`, err: `could not find jsonpath variable "y"`},
+		{target: `1`, path: `$y`, vars: `{"x": 1}`, silent: true, err: `could not find jsonpath variable "y"`},
+		{target: `1`, path: `$`, vars: `[1]`, err: `"vars" argument is not an object`},
+	} {
+		t.Run(tc.target+" "+tc.path, func(t *testing.T) {
+			p, err := Parse(tc.path)
+			require.NoError(t, err)
+			target, err := json.ParseJSON(tc.target)
+			require.NoError(t, err)
+			var vars json.JSON
+			if tc.vars != "" {
+				vars, err = json.ParseJSON(tc.vars)
+				require.NoError(t, err)
+			}
+			res, err := Query(p, target, vars, Options{Silent: tc.silent})
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			strs := make([]string, len(res))
+			for i := range res {
+				strs[i] = res[i].String()
+			}
+			require.Equal(t, tc.expected, strings.Join(strs, ";"))
+		})
+	}
+}
+
+func TestExistsAndMatch(t *testing.T) {
+	target, err := json.ParseJSON(`{"a": [1, 2], "b": "x"}`)
+	require.NoError(t, err)
+
+	exists := func(path string, silent bool) (bool, bool, error) {
+		return Exists(MustParse(path), target, nil /* vars */, Options{Silent: silent})
+	}
+	res, isNull, err := exists(`$.a[*] ? (@ > 1)`, false)
+	require.NoError(t, err)
+	require.True(t, res)
+	require.False(t, isNull)
+
+	res, isNull, err = exists(`$.c`, false)
+	require.NoError(t, err)
+	require.False(t, res)
+	require.False(t, isNull)
+
+	_, _, err = exists(`strict $.c`, false)
+	require.Error(t, err)
+	_, isNull, err = exists(`strict $.c`, true)
+	require.NoError(t, err)
+	require.True(t, isNull)
+
+	match := func(path string, silent bool) (bool, bool, error) {
+		return Match(MustParse(path), target, nil /* vars */, Options{Silent: silent})
+	}
+	res, isNull, err = match(`$.a[*] > 1`, false)
+	require.NoError(t, err)
+	require.True(t, res)
+	require.False(t, isNull)
+
+	res, isNull, err = match(`$.b > 1`, false)
+	require.NoError(t, err)
+	require.True(t, isNull)
+
+	_, _, err = match(`$.a`, false)
+	require.EqualError(t, err, "single boolean result is expected")
+	_, isNull, err = match(`$.a`, true)
+	require.NoError(t, err)
+	require.True(t, isNull)
+}
+
+func TestDatetimeTimeZones(t *testing.T) {
+	target, err := json.ParseJSON(`["2017-03-10 12:00:00", "2017-03-10 12:00:00+01"]`)
+	require.NoError(t, err)
+	p := MustParse(`$[*].datetime() ? (@ < "2017-03-10 11:30:00+00".datetime())`)
+
+	_, err = Query(p, target, nil /* vars */, Options{})
+	require.EqualError(t, err,
+		"cannot convert value from timestamp without time zone to timestamp with time zone without time zone usage")
+
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	res, err := Query(p, target, nil /* vars */, Options{UseTZ: true, Location: loc})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, `"2017-03-10T12:00:00+01:00"`, res[0].String())
+
+	res, err = Query(p, target, nil /* vars */, Options{UseTZ: true, Location: time.UTC})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+}
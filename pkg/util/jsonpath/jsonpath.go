@@ -0,0 +1,473 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package jsonpath implements the SQL/JSON path language, which is used by
+// the jsonpath type and the jsonb_path_* functions to query JSON documents.
+package jsonpath
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+)
+
+// Jsonpath is a parsed SQL/JSON path expression.
+type Jsonpath struct {
+	// Strict is true if the path is evaluated in strict mode, in which
+	// structural errors (such as accessing a missing key) are reported. By
+	// default, paths are evaluated in lax mode, where arrays are automatically
+	// unwrapped and structural errors are suppressed.
+	Strict bool
+	// Expr is the root of the path expression.
+	Expr Expr
+}
+
+// String returns the canonical text representation of the path, which
+// matches the output format of Postgres.
+func (p Jsonpath) String() string {
+	var b strings.Builder
+	if p.Strict {
+		b.WriteString("strict ")
+	}
+	p.Expr.format(&b, false /* inKey */, true /* printBrackets */)
+	return b.String()
+}
+
+// Expr is a node of a jsonpath expression tree.
+type Expr interface {
+	// format writes the expression to b. inKey is true if the expression
+	// follows another item in an accessor chain, and printBrackets is true if
+	// operators should be enclosed in parentheses.
+	format(b *strings.Builder, inKey bool, printBrackets bool)
+}
+
+// Root is the $ item, which refers to the JSON document being queried.
+type Root struct{}
+
+// Current is the @ item, which refers to the item being tested by a filter.
+type Current struct{}
+
+// Last is the last item, which refers to the last index of the array being
+// subscripted.
+type Last struct{}
+
+// Variable is a named variable such as $x, whose value is taken from the
+// vars argument of the jsonb_path_* functions.
+type Variable string
+
+// Scalar is a literal null, boolean, number or string.
+type Scalar struct {
+	Value json.JSON
+}
+
+// Path is an item followed by a chain of accessors, filters and methods,
+// such as $.a[*] ? (@ > 1). The first element is never an accessor.
+type Path []Expr
+
+// Key is the .key member accessor.
+type Key string
+
+// AnyKey is the .* wildcard member accessor.
+type AnyKey struct{}
+
+// AnyArray is the [*] wildcard array accessor.
+type AnyArray struct{}
+
+// ArrayList is an array accessor with a list of subscripts, such as
+// [0, 2 to last].
+type ArrayList []Subscript
+
+// Subscript is a single array subscript or a range of subscripts.
+type Subscript struct {
+	From Expr
+	// To is nil if the subscript is not a range.
+	To Expr
+}
+
+// AnyLevel is used as a bound of the .** accessor to refer to the last level
+// of the document.
+const AnyLevel = math.MaxUint32
+
+// AnyPath is the .** accessor, which returns the values at all levels of the
+// item between First and Last, inclusive.
+type AnyPath struct {
+	First, Last uint32
+}
+
+// Filter is the ? (predicate) accessor, which returns the items for which the
+// predicate is true.
+type Filter struct {
+	Cond Expr
+}
+
+// MethodKind is an item method, such as .type() or .size().
+type MethodKind int
+
+const (
+	// MethodType returns the type of the item.
+	MethodType MethodKind = iota
+	// MethodSize returns the number of elements of an array.
+	MethodSize
+	// MethodDouble converts a number or string to a floating point number.
+	MethodDouble
+	// MethodCeiling rounds a number up to the nearest integer.
+	MethodCeiling
+	// MethodFloor rounds a number down to the nearest integer.
+	MethodFloor
+	// MethodAbs returns the absolute value of a number.
+	MethodAbs
+	// MethodKeyValue returns the key-value pairs of an object.
+	MethodKeyValue
+	// MethodDatetime converts a string to a date or time value.
+	MethodDatetime
+)
+
+var methodNames = [...]string{
+	MethodType:     "type",
+	MethodSize:     "size",
+	MethodDouble:   "double",
+	MethodCeiling:  "ceiling",
+	MethodFloor:    "floor",
+	MethodAbs:      "abs",
+	MethodKeyValue: "keyvalue",
+	MethodDatetime: "datetime",
+}
+
+func (k MethodKind) String() string {
+	return methodNames[k]
+}
+
+// Method is an item method call.
+type Method struct {
+	Kind MethodKind
+	// Template is the optional template of the .datetime() method.
+	Template    string
+	HasTemplate bool
+}
+
+// Operation is a unary or binary operator.
+type Operation int
+
+const (
+	// OpAnd is the && predicate.
+	OpAnd Operation = iota
+	// OpOr is the || predicate.
+	OpOr
+	// OpNot is the ! predicate.
+	OpNot
+	// OpIsUnknown is the is unknown predicate.
+	OpIsUnknown
+	// OpExists is the exists predicate.
+	OpExists
+	// OpEqual is the == predicate.
+	OpEqual
+	// OpNotEqual is the != (or <>) predicate.
+	OpNotEqual
+	// OpLess is the < predicate.
+	OpLess
+	// OpLessOrEqual is the <= predicate.
+	OpLessOrEqual
+	// OpGreater is the > predicate.
+	OpGreater
+	// OpGreaterOrEqual is the >= predicate.
+	OpGreaterOrEqual
+	// OpStartsWith is the starts with predicate.
+	OpStartsWith
+	// OpAdd is the binary + operator.
+	OpAdd
+	// OpSub is the binary - operator.
+	OpSub
+	// OpMul is the * operator.
+	OpMul
+	// OpDiv is the / operator.
+	OpDiv
+	// OpMod is the % operator.
+	OpMod
+	// OpPlus is the unary + operator.
+	OpPlus
+	// OpMinus is the unary - operator.
+	OpMinus
+)
+
+var operationNames = [...]string{
+	OpAnd:            "&&",
+	OpOr:             "||",
+	OpNot:            "!",
+	OpIsUnknown:      "is unknown",
+	OpExists:         "exists",
+	OpEqual:          "==",
+	OpNotEqual:       "!=",
+	OpLess:           "<",
+	OpLessOrEqual:    "<=",
+	OpGreater:        ">",
+	OpGreaterOrEqual: ">=",
+	OpStartsWith:     "starts with",
+	OpAdd:            "+",
+	OpSub:            "-",
+	OpMul:            "*",
+	OpDiv:            "/",
+	OpMod:            "%",
+	OpPlus:           "+",
+	OpMinus:          "-",
+}
+
+func (o Operation) String() string {
+	return operationNames[o]
+}
+
+// isComparison returns true if the operation compares two items.
+func (o Operation) isComparison() bool {
+	return o >= OpEqual && o <= OpGreaterOrEqual
+}
+
+// priority returns the precedence of the operation, which is used to decide
+// whether the operands of an operation must be enclosed in parentheses when
+// it is formatted.
+func (o Operation) priority() int {
+	switch o {
+	case OpOr:
+		return 0
+	case OpAnd:
+		return 1
+	case OpEqual, OpNotEqual, OpLess, OpLessOrEqual, OpGreater, OpGreaterOrEqual, OpStartsWith:
+		return 2
+	case OpAdd, OpSub:
+		return 3
+	case OpMul, OpDiv, OpMod:
+		return 4
+	case OpPlus, OpMinus:
+		return 5
+	}
+	return 6
+}
+
+// Binary is a binary operator or predicate.
+type Binary struct {
+	Op          Operation
+	Left, Right Expr
+}
+
+// Unary is a unary operator or predicate.
+type Unary struct {
+	Op   Operation
+	Expr Expr
+}
+
+// LikeRegex is the like_regex predicate.
+type LikeRegex struct {
+	Expr    Expr
+	Pattern string
+	Flags   string
+	re      *regexp.Regexp
+}
+
+// priority returns the precedence of e when it is an operand of another
+// operation.
+func priority(e Expr) int {
+	switch t := e.(type) {
+	case *Binary:
+		return t.Op.priority()
+	case *Unary:
+		return t.Op.priority()
+	}
+	return 6
+}
+
+// isPredicate returns true if e evaluates to a boolean (or unknown) result
+// rather than to a sequence of items.
+func isPredicate(e Expr) bool {
+	switch t := e.(type) {
+	case *Binary:
+		return t.Op <= OpStartsWith
+	case *Unary:
+		return t.Op <= OpExists
+	case *LikeRegex:
+		return true
+	}
+	return false
+}
+
+func (Root) format(b *strings.Builder, _, _ bool) {
+	b.WriteByte('$')
+}
+
+func (Current) format(b *strings.Builder, _, _ bool) {
+	b.WriteByte('@')
+}
+
+func (Last) format(b *strings.Builder, _, _ bool) {
+	b.WriteString("last")
+}
+
+func (v Variable) format(b *strings.Builder, _, _ bool) {
+	b.WriteByte('$')
+	writeString(b, string(v))
+}
+
+func (s Scalar) format(b *strings.Builder, _, _ bool) {
+	b.WriteString(s.Value.String())
+}
+
+func (p Path) format(b *strings.Builder, inKey, printBrackets bool) {
+	switch first := p[0].(type) {
+	case Scalar:
+		if first.Value.Type() == json.NumberJSONType {
+			// A number followed by an accessor must be parenthesized so that the
+			// accessor's dot is not parsed as a decimal point.
+			b.WriteByte('(')
+			first.format(b, inKey, false)
+			b.WriteByte(')')
+		} else {
+			first.format(b, inKey, printBrackets)
+		}
+	case *Binary, *Unary, *LikeRegex:
+		b.WriteByte('(')
+		first.format(b, inKey, false)
+		b.WriteByte(')')
+	default:
+		first.format(b, inKey, printBrackets)
+	}
+	for _, e := range p[1:] {
+		e.format(b, true /* inKey */, true /* printBrackets */)
+	}
+}
+
+func (k Key) format(b *strings.Builder, inKey, _ bool) {
+	if inKey {
+		b.WriteByte('.')
+	}
+	writeString(b, string(k))
+}
+
+func (AnyKey) format(b *strings.Builder, inKey, _ bool) {
+	if inKey {
+		b.WriteByte('.')
+	}
+	b.WriteByte('*')
+}
+
+func (AnyArray) format(b *strings.Builder, _, _ bool) {
+	b.WriteString("[*]")
+}
+
+func (l ArrayList) format(b *strings.Builder, _, _ bool) {
+	b.WriteByte('[')
+	for i, s := range l {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		s.From.format(b, false, false)
+		if s.To != nil {
+			b.WriteString(" to ")
+			s.To.format(b, false, false)
+		}
+	}
+	b.WriteByte(']')
+}
+
+func formatLevel(b *strings.Builder, level uint32) {
+	if level == AnyLevel {
+		b.WriteString("last")
+	} else {
+		b.WriteString(strconv.FormatUint(uint64(level), 10))
+	}
+}
+
+func (a AnyPath) format(b *strings.Builder, inKey, _ bool) {
+	if inKey {
+		b.WriteByte('.')
+	}
+	b.WriteString("**")
+	if a.First == 0 && a.Last == AnyLevel {
+		return
+	}
+	b.WriteByte('{')
+	formatLevel(b, a.First)
+	if a.First != a.Last {
+		b.WriteString(" to ")
+		formatLevel(b, a.Last)
+	}
+	b.WriteByte('}')
+}
+
+func (f Filter) format(b *strings.Builder, _, _ bool) {
+	b.WriteString("?(")
+	f.Cond.format(b, false, false)
+	b.WriteByte(')')
+}
+
+func (m Method) format(b *strings.Builder, _, _ bool) {
+	b.WriteByte('.')
+	b.WriteString(m.Kind.String())
+	b.WriteByte('(')
+	if m.HasTemplate {
+		writeString(b, m.Template)
+	}
+	b.WriteByte(')')
+}
+
+func (e *Binary) format(b *strings.Builder, _, printBrackets bool) {
+	if printBrackets {
+		b.WriteByte('(')
+	}
+	e.Left.format(b, false, priority(e.Left) <= e.Op.priority())
+	b.WriteByte(' ')
+	b.WriteString(e.Op.String())
+	b.WriteByte(' ')
+	e.Right.format(b, false, priority(e.Right) <= e.Op.priority())
+	if printBrackets {
+		b.WriteByte(')')
+	}
+}
+
+func (e *Unary) format(b *strings.Builder, _, printBrackets bool) {
+	switch e.Op {
+	case OpNot:
+		b.WriteString("!(")
+		e.Expr.format(b, false, false)
+		b.WriteByte(')')
+	case OpIsUnknown:
+		b.WriteByte('(')
+		e.Expr.format(b, false, false)
+		b.WriteString(") is unknown")
+	case OpExists:
+		b.WriteString("exists (")
+		e.Expr.format(b, false, false)
+		b.WriteByte(')')
+	default:
+		if printBrackets {
+			b.WriteByte('(')
+		}
+		b.WriteString(e.Op.String())
+		e.Expr.format(b, false, priority(e.Expr) <= e.Op.priority())
+		if printBrackets {
+			b.WriteByte(')')
+		}
+	}
+}
+
+func (e *LikeRegex) format(b *strings.Builder, _, printBrackets bool) {
+	if printBrackets {
+		b.WriteByte('(')
+	}
+	e.Expr.format(b, false, priority(e.Expr) <= priority(e))
+	b.WriteString(" like_regex ")
+	writeString(b, e.Pattern)
+	if e.Flags != "" {
+		b.WriteString(" flag ")
+		writeString(b, e.Flags)
+	}
+	if printBrackets {
+		b.WriteByte(')')
+	}
+}
+
+// writeString writes s to b as a quoted and escaped JSON string.
+func writeString(b *strings.Builder, s string) {
+	b.WriteString(json.FromString(s).String())
+}
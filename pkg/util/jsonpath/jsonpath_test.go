@@ -0,0 +1,115 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{`$`, `$`},
+		{`strict $`, `strict $`},
+		{`lax $`, `$`},
+		{`$.a`, `$."a"`},
+		{`$.a.v`, `$."a"."v"`},
+		{`$."a b".c`, `$."a b"."c"`},
+		{`$.a.*`, `$."a".*`},
+		{`$.*[*]`, `$.*[*]`},
+		{`$.a[*][*]`, `$."a"[*][*]`},
+		{`$[*][0].a.b`, `$[*][0]."a"."b"`},
+		{`$.last.type`, `$."last"."type"`},
+		{`$.a.**.b`, `$."a".**."b"`},
+		{`$.a.**{2}.b`, `$."a".**{2}."b"`},
+		{`$.a.**{2 to 2}.b`, `$."a".**{2}."b"`},
+		{`$.a.**{2 to 5}.b`, `$."a".**{2 to 5}."b"`},
+		{`$.a.**{5 to last}.b`, `$."a".**{5 to last}."b"`},
+		{`$.a.**{last}.b`, `$."a".**{last}."b"`},
+		{`$+1`, `($ + 1)`},
+		{`$--+1`, `($ - -1)`},
+		{`$.a/+-1`, `($."a" / -1)`},
+		{`1 * 2 + 4 % -3 != false`, `(1 * 2 + 4 % -3 != false)`},
+		{`"\b\f\r\n\t\"\\"`, `"\b\f\r\n\t\"\\"`},
+		{`"\x41B\u{43}"`, `"ABC"`},
+		{`$.g ? ($.a == 1)`, `$."g"?($."a" == 1)`},
+		{`$.g ? (@.a == 1 || @.a == 4 && @.b == 7)`, `$."g"?(@."a" == 1 || @."a" == 4 && @."b" == 7)`},
+		{`$.g ? (@.a == 1 || !(@.x >= 123 || @.a == 4) && @.b == 7)`,
+			`$."g"?(@."a" == 1 || !(@."x" >= 123 || @."a" == 4) && @."b" == 7)`},
+		{`$.g ? (@.x >= @[*]?(@.a > "abc"))`, `$."g"?(@."x" >= @[*]?(@."a" > "abc"))`},
+		{`$.g ? ((@.x >= 123 || @.a == 4) is unknown)`, `$."g"?((@."x" >= 123 || @."a" == 4) is unknown)`},
+		{`$.g ? (exists (@.x ? (@ == 14)))`, `$."g"?(exists (@."x"?(@ == 14)))`},
+		{`$.g ? (+@.x >= +-(+@.a + 2))`, `$."g"?(+@."x" >= +(-(+@."a" + 2)))`},
+		{`$a[*]`, `$"a"[*]`},
+		{`$.g ? (@.zip == $zip)`, `$."g"?(@."zip" == $"zip")`},
+		{`$.a[1,2, 3 to 16]`, `$."a"[1,2,3 to 16]`},
+		{`$.a[$a + 1, ($b[*]) to -($[0] * 2)]`, `$."a"[$"a" + 1,$"b"[*] to -($[0] * 2)]`},
+		{`$.a[$.a.size() - 3]`, `$."a"[$."a".size() - 3]`},
+		{`$[$[0] ? (last > 0)]`, `$[$[0]?(last > 0)]`},
+		{`null.type()`, `null.type()`},
+		{`(1).type()`, `(1).type()`},
+		{`1.2.type()`, `(1.2).type()`},
+		{`$.double().floor().ceiling().abs()`, `$.double().floor().ceiling().abs()`},
+		{`$.keyvalue().key`, `$.keyvalue()."key"`},
+		{`$.datetime("dd-mm-yyyy")`, `$.datetime("dd-mm-yyyy")`},
+		{`$ ? (@ starts with $var)`, `$?(@ starts with $"var")`},
+		{`$ ? (@ like_regex "pattern" flag "")`, `$?(@ like_regex "pattern")`},
+		{`$ ? (@ like_regex "pattern" flag "isim")`, `$?(@ like_regex "pattern" flag "ism")`},
+		{`($ < 1) || $.a.b <= $x`, `($ < 1 || $."a"."b" <= $"x")`},
+		{`($.a.b + -$.x.y).c.d`, `($."a"."b" + -$."x"."y")."c"."d"`},
+		{`(-+$.a.b).c.d`, `(-(+$."a"."b"))."c"."d"`},
+		{`1 + ($.a.b > 2).c.d`, `(1 + ($."a"."b" > 2)."c"."d")`},
+		{`((($ + 1)).a + ((2)).b ? ((((@ > 1)) || (exists(@.c)))))`,
+			`(($ + 1)."a" + (2)."b"?(@ > 1 || exists (@."c")))`},
+		{`$ ? (@.a < .1)`, `$?(@."a" < 0.1)`},
+		{`$ ? (@.a < -1e-1)`, `$?(@."a" < -0.1)`},
+		{`$ ? (@.a < 1.1e1)`, `$?(@."a" < 11)`},
+		{`$ ? (@.a < 1e3)`, `$?(@."a" < 1000)`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			p, err := Parse(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, p.String())
+			// The output must parse to the same path.
+			p, err = Parse(p.String())
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, p.String())
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		err   string
+	}{
+		{``, `syntax error at end of jsonpath input`},
+		{`$.`, `syntax error at end of jsonpath input`},
+		{`$ ? (@ == 1`, `syntax error at end of jsonpath input`},
+		{`$ ? (1)`, `syntax error at or near "1)" of jsonpath input`},
+		{`$ && $`, `syntax error at or near "$" of jsonpath input`},
+		{`1 == 2 == 3`, `syntax error at or near "==" of jsonpath input`},
+		{`$.foo()`, `syntax error at or near "foo()" of jsonpath input`},
+		{`last`, `LAST is allowed only in array subscripts`},
+		{`$ ? (last > 0)`, `LAST is allowed only in array subscripts`},
+		{`@ + 1`, `@ is not allowed in root expressions`},
+		{`$ ? (@ like_regex "(")`, `invalid regular expression`},
+		{`$ ? (@ like_regex "a" flag "a")`, `invalid input syntax for type jsonpath`},
+		{`$ ? (@ like_regex "a" flag "x")`, `XQuery "x" flag (expanded regular expressions) is not implemented`},
+		{`"abc`, `syntax error at or near ""abc" of jsonpath input`},
+		{`1a`, `syntax error at or near "1a" of jsonpath input`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,934 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package jsonpath
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// tokenKind is the kind of a jsonpath token.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	// tokPunct is an operator or punctuation, such as "==" or "[".
+	tokPunct
+	// tokIdent is an unquoted identifier, which is either a keyword or a key.
+	tokIdent
+	// tokString is a double-quoted string.
+	tokString
+	// tokNumber is a numeric literal.
+	tokNumber
+	// tokVariable is a $name or $"name" variable.
+	tokVariable
+)
+
+type token struct {
+	kind tokenKind
+	// val is the text of punctuation, identifiers and numbers, and the
+	// unescaped contents of strings and variable names.
+	val string
+	// pos is the byte offset of the token in the input.
+	pos int
+}
+
+// lexer splits a jsonpath string into tokens.
+type lexer struct {
+	input string
+	pos   int
+}
+
+// twoCharPunct are the operators made of two characters.
+var twoCharPunct = []string{"==", "!=", "<>", "<=", ">=", "&&", "||", "**"}
+
+// isSpecial returns true if c terminates an unquoted identifier.
+func isSpecial(c byte) bool {
+	return strings.IndexByte("?%$.[]{}()|&!=<>@#,*:-+/\\\" \t\n\r\f", c) >= 0
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && strings.IndexByte(" \t\n\r\f", l.input[l.pos]) >= 0 {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+	c := l.input[l.pos]
+	switch {
+	case c == '"':
+		s, err := l.scanString()
+		return token{kind: tokString, val: s, pos: start}, err
+
+	case c == '$':
+		l.pos++
+		if l.pos < len(l.input) && l.input[l.pos] == '"' {
+			s, err := l.scanString()
+			return token{kind: tokVariable, val: s, pos: start}, err
+		}
+		identStart := l.pos
+		for l.pos < len(l.input) && !isSpecial(l.input[l.pos]) {
+			l.pos++
+		}
+		if l.pos == identStart {
+			return token{kind: tokPunct, val: "$", pos: start}, nil
+		}
+		return token{kind: tokVariable, val: l.input[identStart:l.pos], pos: start}, nil
+
+	case isDigit(c) || (c == '.' && l.pos+1 < len(l.input) && isDigit(l.input[l.pos+1])):
+		return l.scanNumber()
+
+	case isSpecial(c):
+		for _, p := range twoCharPunct {
+			if strings.HasPrefix(l.input[l.pos:], p) {
+				l.pos += 2
+				return token{kind: tokPunct, val: p, pos: start}, nil
+			}
+		}
+		l.pos++
+		return token{kind: tokPunct, val: string(c), pos: start}, nil
+	}
+	for l.pos < len(l.input) && !isSpecial(l.input[l.pos]) {
+		l.pos++
+	}
+	return token{kind: tokIdent, val: l.input[start:l.pos], pos: start}, nil
+}
+
+func (l *lexer) scanNumber() (token, error) {
+	start := l.pos
+	for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+		l.pos++
+	}
+	// A dot is only part of the number if it is followed by a digit, so that
+	// accessors such as 1.type() are not parsed as decimals.
+	if l.pos+1 < len(l.input) && l.input[l.pos] == '.' && isDigit(l.input[l.pos+1]) {
+		l.pos++
+		for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+			l.pos++
+		}
+	}
+	if l.pos < len(l.input) && (l.input[l.pos] == 'e' || l.input[l.pos] == 'E') {
+		l.pos++
+		if l.pos < len(l.input) && (l.input[l.pos] == '+' || l.input[l.pos] == '-') {
+			l.pos++
+		}
+		if l.pos >= len(l.input) || !isDigit(l.input[l.pos]) {
+			return token{}, newSyntaxError(l.input, start, "invalid numeric literal")
+		}
+		for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+			l.pos++
+		}
+	}
+	if l.pos < len(l.input) && !isSpecial(l.input[l.pos]) {
+		return token{}, newSyntaxError(l.input, start, "trailing junk after numeric literal")
+	}
+	return token{kind: tokNumber, val: l.input[start:l.pos], pos: start}, nil
+}
+
+// scanString scans a double-quoted string starting at the current position
+// and returns its unescaped contents.
+func (l *lexer) scanString() (string, error) {
+	start := l.pos
+	l.pos++
+	var b strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch c {
+		case '"':
+			l.pos++
+			return b.String(), nil
+		case '\\':
+			if l.pos+1 >= len(l.input) {
+				return "", newSyntaxError(l.input, start, "unexpected end after backslash")
+			}
+			l.pos += 2
+			switch e := l.input[l.pos-1]; e {
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'v':
+				b.WriteByte('\v')
+			case 'x':
+				r, err := l.scanHex(2, 2)
+				if err != nil {
+					return "", err
+				}
+				b.WriteRune(r)
+			case 'u':
+				var r rune
+				var err error
+				if l.pos < len(l.input) && l.input[l.pos] == '{' {
+					l.pos++
+					r, err = l.scanHex(1, 6)
+					if err == nil {
+						if l.pos >= len(l.input) || l.input[l.pos] != '}' {
+							err = newSyntaxError(l.input, start, "invalid Unicode escape sequence")
+						}
+						l.pos++
+					}
+				} else {
+					r, err = l.scanHex(4, 4)
+				}
+				if err != nil {
+					return "", err
+				}
+				if r == 0 {
+					return "", pgerror.New(pgcode.UntranslatableCharacter,
+						"unsupported Unicode escape sequence")
+				}
+				b.WriteRune(r)
+			default:
+				b.WriteByte(e)
+			}
+		default:
+			l.pos++
+			b.WriteByte(c)
+		}
+	}
+	return "", newSyntaxError(l.input, start, "unexpected end of quoted string")
+}
+
+// scanHex scans between minDigits and maxDigits hexadecimal digits.
+func (l *lexer) scanHex(minDigits, maxDigits int) (rune, error) {
+	start := l.pos
+	for l.pos < len(l.input) && l.pos-start < maxDigits &&
+		strings.IndexByte("0123456789abcdefABCDEF", l.input[l.pos]) >= 0 {
+		l.pos++
+	}
+	if l.pos-start < minDigits {
+		return 0, newSyntaxError(l.input, start, "invalid hexadecimal character sequence")
+	}
+	v, err := strconv.ParseUint(l.input[start:l.pos], 16, 32)
+	if err != nil || v > utf8.MaxRune {
+		return 0, newSyntaxError(l.input, start, "invalid Unicode escape sequence")
+	}
+	return rune(v), nil
+}
+
+// newSyntaxError returns a syntax error for the given position of the input.
+func newSyntaxError(input string, pos int, detail string) error {
+	var err error
+	if pos >= len(input) {
+		err = pgerror.New(pgcode.Syntax, "syntax error at end of jsonpath input")
+	} else {
+		tok := input[pos:]
+		if i := strings.IndexAny(tok, " \t\n\r\f"); i > 0 {
+			tok = tok[:i]
+		}
+		err = pgerror.Newf(pgcode.Syntax, "syntax error at or near \"%s\" of jsonpath input", tok)
+	}
+	if detail != "" {
+		err = errors.WithDetail(err, detail)
+	}
+	return err
+}
+
+// parser is a recursive descent parser for jsonpath expressions.
+type parser struct {
+	lexer lexer
+	tok   token
+	// inFilter is the nesting depth of filters, in which @ may be used.
+	inFilter int
+	// inSubscript is the nesting depth of array subscripts, in which last
+	// may be used.
+	inSubscript int
+}
+
+// Parse parses the text representation of a jsonpath.
+func Parse(s string) (Jsonpath, error) {
+	p := parser{lexer: lexer{input: s}}
+	if err := p.advance(); err != nil {
+		return Jsonpath{}, err
+	}
+	var res Jsonpath
+	if p.isIdent("strict") || p.isIdent("lax") {
+		res.Strict = p.tok.val == "strict"
+		if err := p.advance(); err != nil {
+			return Jsonpath{}, err
+		}
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return Jsonpath{}, err
+	}
+	if p.tok.kind != tokEOF {
+		return Jsonpath{}, p.syntaxError()
+	}
+	res.Expr = expr
+	return res, nil
+}
+
+// MustParse is like Parse, but panics on error.
+func MustParse(s string) Jsonpath {
+	p, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) syntaxError() error {
+	return newSyntaxError(p.lexer.input, p.tok.pos, "")
+}
+
+func (p *parser) isPunct(s string) bool {
+	return p.tok.kind == tokPunct && p.tok.val == s
+}
+
+func (p *parser) isIdent(s string) bool {
+	return p.tok.kind == tokIdent && p.tok.val == s
+}
+
+// expectPunct consumes the punctuation s, or returns a syntax error.
+func (p *parser) expectPunct(s string) error {
+	if !p.isPunct(s) {
+		return p.syntaxError()
+	}
+	return p.advance()
+}
+
+// expectPredicate returns a syntax error if e is not a predicate.
+func (p *parser) expectPredicate(e Expr, pos int) error {
+	if !isPredicate(e) {
+		return newSyntaxError(p.lexer.input, pos, "")
+	}
+	return nil
+}
+
+// expectValue returns a syntax error if e is a predicate.
+func (p *parser) expectValue(e Expr, pos int) error {
+	if isPredicate(e) {
+		return newSyntaxError(p.lexer.input, pos, "")
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	pos := p.tok.pos
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("||") {
+		if err := p.expectPredicate(left, pos); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		pos = p.tok.pos
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPredicate(right, pos); err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: OpOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	pos := p.tok.pos
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("&&") {
+		if err := p.expectPredicate(left, pos); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		pos = p.tok.pos
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPredicate(right, pos); err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: OpAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if !p.isPunct("!") {
+		return p.parseComparison()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	// The operand of ! must be a parenthesized predicate or exists.
+	if !p.isPunct("(") && !p.isIdent("exists") {
+		return nil, p.syntaxError()
+	}
+	pos := p.tok.pos
+	e, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPredicate(e, pos); err != nil {
+		return nil, err
+	}
+	return &Unary{Op: OpNot, Expr: e}, nil
+}
+
+var comparisonOps = map[string]Operation{
+	"==": OpEqual,
+	"!=": OpNotEqual,
+	"<>": OpNotEqual,
+	"<":  OpLess,
+	"<=": OpLessOrEqual,
+	">":  OpGreater,
+	">=": OpGreaterOrEqual,
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	pos := p.tok.pos
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokPunct {
+		op, ok := comparisonOps[p.tok.val]
+		if !ok {
+			return left, nil
+		}
+		if err := p.expectValue(left, pos); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		pos = p.tok.pos
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(right, pos); err != nil {
+			return nil, err
+		}
+		return &Binary{Op: op, Left: left, Right: right}, nil
+	}
+	switch {
+	case p.isIdent("starts"):
+		if err := p.expectValue(left, pos); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if !p.isIdent("with") {
+			return nil, p.syntaxError()
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var right Expr
+		switch p.tok.kind {
+		case tokString:
+			right = Scalar{Value: json.FromString(p.tok.val)}
+		case tokVariable:
+			right = Variable(p.tok.val)
+		default:
+			return nil, p.syntaxError()
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return &Binary{Op: OpStartsWith, Left: left, Right: right}, nil
+
+	case p.isIdent("like_regex"):
+		if err := p.expectValue(left, pos); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokString {
+			return nil, p.syntaxError()
+		}
+		e := &LikeRegex{Expr: left, Pattern: p.tok.val}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.isIdent("flag") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if p.tok.kind != tokString {
+				return nil, p.syntaxError()
+			}
+			e.Flags = p.tok.val
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		if err := e.compile(); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	return left, nil
+}
+
+// compile validates the flags of the like_regex predicate, normalizes them,
+// and compiles its pattern.
+func (e *LikeRegex) compile() error {
+	var i, s, m, q bool
+	for _, c := range e.Flags {
+		switch c {
+		case 'i':
+			i = true
+		case 's':
+			s = true
+		case 'm':
+			m = true
+		case 'q':
+			q = true
+		case 'x':
+			return pgerror.New(pgcode.FeatureNotSupported,
+				`XQuery "x" flag (expanded regular expressions) is not implemented`)
+		default:
+			return errors.WithDetailf(
+				pgerror.New(pgcode.Syntax, "invalid input syntax for type jsonpath"),
+				"Unrecognized flag character %q in LIKE_REGEX predicate.", c,
+			)
+		}
+	}
+	var flags, goFlags strings.Builder
+	for _, f := range []struct {
+		set bool
+		c   byte
+	}{{i, 'i'}, {s, 's'}, {m, 'm'}, {q, 'q'}} {
+		if f.set {
+			flags.WriteByte(f.c)
+			if f.c != 'q' {
+				goFlags.WriteByte(f.c)
+			}
+		}
+	}
+	e.Flags = flags.String()
+	pattern := e.Pattern
+	if q {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if goFlags.Len() > 0 {
+		pattern = fmt.Sprintf("(?%s)%s", goFlags.String(), pattern)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return pgerror.Wrap(err, pgcode.InvalidRegularExpression, "invalid regular expression")
+	}
+	e.re = re
+	return nil
+}
+
+func (p *parser) parseAdditive() (Expr, error) {
+	pos := p.tok.pos
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("+") || p.isPunct("-") {
+		op := OpAdd
+		if p.tok.val == "-" {
+			op = OpSub
+		}
+		if err := p.expectValue(left, pos); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		pos = p.tok.pos
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(right, pos); err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+var multiplicativeOps = map[string]Operation{"*": OpMul, "/": OpDiv, "%": OpMod}
+
+func (p *parser) parseMultiplicative() (Expr, error) {
+	pos := p.tok.pos
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokPunct {
+		op, ok := multiplicativeOps[p.tok.val]
+		if !ok {
+			break
+		}
+		if err := p.expectValue(left, pos); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		pos = p.tok.pos
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(right, pos); err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if !p.isPunct("+") && !p.isPunct("-") {
+		return p.parseAccessorExpr()
+	}
+	op := OpPlus
+	if p.tok.val == "-" {
+		op = OpMinus
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	pos := p.tok.pos
+	e, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectValue(e, pos); err != nil {
+		return nil, err
+	}
+	// Negative numeric literals are folded into the literal, as in Postgres.
+	if s, ok := e.(Scalar); ok && s.Value.Type() == json.NumberJSONType {
+		d, _ := s.Value.AsDecimal()
+		if op == OpPlus || d.IsZero() {
+			return s, nil
+		}
+		var neg apd.Decimal
+		neg.Neg(d)
+		return Scalar{Value: json.FromDecimal(neg)}, nil
+	}
+	return &Unary{Op: op, Expr: e}, nil
+}
+
+// parseAccessorExpr parses a primary item followed by a chain of accessors.
+func (p *parser) parseAccessorExpr() (Expr, error) {
+	primary, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	path := Path{primary}
+	for {
+		var acc Expr
+		var err error
+		switch {
+		case p.isPunct("."):
+			acc, err = p.parseDotAccessor()
+		case p.isPunct("["):
+			acc, err = p.parseArrayAccessor()
+		case p.isPunct("?"):
+			acc, err = p.parseFilter()
+		default:
+			if len(path) == 1 {
+				return primary, nil
+			}
+			return path, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, acc)
+	}
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokString:
+		return Scalar{Value: json.FromString(tok.val)}, p.advance()
+
+	case tokNumber:
+		var d apd.Decimal
+		if _, _, err := d.SetString(tok.val); err != nil {
+			return nil, newSyntaxError(p.lexer.input, tok.pos, "invalid numeric literal")
+		}
+		// Numbers with a positive exponent, like 1e3, are stored in their
+		// expanded form, as Postgres does.
+		if d.Exponent > 0 {
+			if _, _, err := d.SetString(d.Text('f')); err != nil {
+				return nil, err
+			}
+		}
+		return Scalar{Value: json.FromDecimal(d)}, p.advance()
+
+	case tokVariable:
+		return Variable(tok.val), p.advance()
+
+	case tokIdent:
+		switch tok.val {
+		case "null":
+			return Scalar{Value: json.NullJSONValue}, p.advance()
+		case "true":
+			return Scalar{Value: json.TrueJSONValue}, p.advance()
+		case "false":
+			return Scalar{Value: json.FalseJSONValue}, p.advance()
+		case "last":
+			if p.inSubscript == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "LAST is allowed only in array subscripts")
+			}
+			return Last{}, p.advance()
+		case "exists":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct("("); err != nil {
+				return nil, err
+			}
+			pos := p.tok.pos
+			e, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectValue(e, pos); err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			return &Unary{Op: OpExists, Expr: e}, nil
+		}
+
+	case tokPunct:
+		switch tok.val {
+		case "$":
+			return Root{}, p.advance()
+		case "@":
+			if p.inFilter == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "@ is not allowed in root expressions")
+			}
+			return Current{}, p.advance()
+		case "(":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			e, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			if isPredicate(e) && p.isIdent("is") {
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+				if !p.isIdent("unknown") {
+					return nil, p.syntaxError()
+				}
+				return &Unary{Op: OpIsUnknown, Expr: e}, p.advance()
+			}
+			return e, nil
+		}
+	}
+	return nil, p.syntaxError()
+}
+
+// parseDotAccessor parses an accessor starting with a dot: a key, .*, .**
+// or an item method.
+func (p *parser) parseDotAccessor() (Expr, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	tok := p.tok
+	switch {
+	case tok.kind == tokString:
+		return Key(tok.val), p.advance()
+
+	case p.isPunct("*"):
+		return AnyKey{}, p.advance()
+
+	case p.isPunct("**"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		res := AnyPath{First: 0, Last: AnyLevel}
+		if !p.isPunct("{") {
+			return res, nil
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var err error
+		if res.First, err = p.parseLevel(); err != nil {
+			return nil, err
+		}
+		res.Last = res.First
+		if p.isIdent("to") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if res.Last, err = p.parseLevel(); err != nil {
+				return nil, err
+			}
+		}
+		return res, p.expectPunct("}")
+
+	case tok.kind == tokIdent:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if !p.isPunct("(") {
+			return Key(tok.val), nil
+		}
+		var m Method
+		found := false
+		for kind, name := range methodNames {
+			if name == tok.val {
+				m.Kind, found = MethodKind(kind), true
+				break
+			}
+		}
+		if !found {
+			return nil, newSyntaxError(p.lexer.input, tok.pos, "")
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if m.Kind == MethodDatetime && p.tok.kind == tokString {
+			m.Template, m.HasTemplate = p.tok.val, true
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		return m, p.expectPunct(")")
+	}
+	return nil, p.syntaxError()
+}
+
+// parseLevel parses a level of the .** accessor, which is either a
+// non-negative integer or last.
+func (p *parser) parseLevel() (uint32, error) {
+	if p.isIdent("last") {
+		return AnyLevel, p.advance()
+	}
+	if p.tok.kind != tokNumber {
+		return 0, p.syntaxError()
+	}
+	level, err := strconv.ParseUint(p.tok.val, 10, 32)
+	if err != nil || level == AnyLevel {
+		return 0, p.syntaxError()
+	}
+	return uint32(level), p.advance()
+}
+
+// parseArrayAccessor parses [*] or a list of subscripts.
+func (p *parser) parseArrayAccessor() (Expr, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("*") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return AnyArray{}, p.expectPunct("]")
+	}
+	p.inSubscript++
+	defer func() { p.inSubscript-- }()
+	var res ArrayList
+	for {
+		var s Subscript
+		var err error
+		if s.From, err = p.parseSubscriptExpr(); err != nil {
+			return nil, err
+		}
+		if p.isIdent("to") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if s.To, err = p.parseSubscriptExpr(); err != nil {
+				return nil, err
+			}
+		}
+		res = append(res, s)
+		if !p.isPunct(",") {
+			break
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return res, p.expectPunct("]")
+}
+
+func (p *parser) parseSubscriptExpr() (Expr, error) {
+	pos := p.tok.pos
+	e, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	return e, p.expectValue(e, pos)
+}
+
+func (p *parser) parseFilter() (Expr, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	p.inFilter++
+	defer func() { p.inFilter-- }()
+	pos := p.tok.pos
+	cond, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPredicate(cond, pos); err != nil {
+		return nil, err
+	}
+	return Filter{Cond: cond}, p.expectPunct(")")
+}
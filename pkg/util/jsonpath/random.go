@@ -0,0 +1,69 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package jsonpath
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+var alphabet = "abcdefghijklmnopqrstuvwxyz"
+
+// RandomJsonpath returns a random Jsonpath for testing.
+func RandomJsonpath(rng *rand.Rand) Jsonpath {
+	for {
+		var b strings.Builder
+		if rng.Intn(4) == 0 {
+			b.WriteString("strict ")
+		}
+		b.WriteString("$")
+		writeRandomAccessors(rng, &b)
+		if rng.Intn(3) == 0 {
+			b.WriteString(" ? (@")
+			writeRandomAccessors(rng, &b)
+			ops := []string{"==", "!=", "<", "<=", ">", ">="}
+			b.WriteString(" " + ops[rng.Intn(len(ops))] + " ")
+			switch rng.Intn(3) {
+			case 0:
+				b.WriteString(strconv.Itoa(rng.Intn(100)))
+			case 1:
+				b.WriteString(`"` + randomKey(rng) + `"`)
+			default:
+				b.WriteString("null")
+			}
+			b.WriteString(")")
+		}
+		p, err := Parse(b.String())
+		if err != nil {
+			continue
+		}
+		return p
+	}
+}
+
+func writeRandomAccessors(rng *rand.Rand, b *strings.Builder) {
+	for i, n := 0, rng.Intn(4); i < n; i++ {
+		switch rng.Intn(5) {
+		case 0:
+			b.WriteString("[*]")
+		case 1:
+			b.WriteString("[" + strconv.Itoa(rng.Intn(5)) + "]")
+		case 2:
+			b.WriteString(".*")
+		default:
+			b.WriteString("." + randomKey(rng))
+		}
+	}
+}
+
+func randomKey(rng *rand.Rand) string {
+	l := make([]byte, 1+rng.Intn(5))
+	for i := range l {
+		l[i] = alphabet[rng.Intn(len(alphabet))]
+	}
+	return string(l)
+}
@@ -0,0 +1,293 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package macaddr implements the postgres compatible MACADDR and MACADDR8
+// types, which hold 6 and 8 byte MAC addresses.
+package macaddr
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// MACAddr is a 6 byte MAC address, stored in the low 48 bits of a uint64 so
+// that ordering the integers orders the addresses.
+type MACAddr uint64
+
+// MACAddr8 is an 8 byte MAC address in EUI-64 format.
+type MACAddr8 uint64
+
+const (
+	// Size is the number of bytes in a MACAddr.
+	Size = 6
+	// Size8 is the number of bytes in a MACAddr8.
+	Size8 = 8
+
+	mask48 = 1<<48 - 1
+)
+
+// ParseMACAddr parses a MACAddr in any of the formats accepted by postgres:
+//
+//	'08:00:2b:01:02:03'
+//	'08-00-2b-01-02-03'
+//	'08002b:010203'
+//	'08002b-010203'
+//	'0800.2b01.0203'
+//	'0800-2b01-0203'
+//	'08002b010203'
+func ParseMACAddr(s string) (MACAddr, error) {
+	invalid := func() error {
+		return pgerror.Newf(pgcode.InvalidTextRepresentation,
+			"invalid input syntax for type macaddr: %q", s)
+	}
+	str := strings.TrimSpace(s)
+	var groups []string
+	if sep := strings.IndexAny(str, ":-."); sep < 0 {
+		groups = []string{str}
+	} else {
+		groups = strings.Split(str, str[sep:sep+1])
+	}
+	var minDigits, maxDigits int
+	switch len(groups) {
+	case 1:
+		minDigits, maxDigits = 12, 12
+	case 2:
+		if strings.Contains(str, ".") {
+			return 0, invalid()
+		}
+		minDigits, maxDigits = 6, 6
+	case 3:
+		if strings.Contains(str, ":") {
+			return 0, invalid()
+		}
+		minDigits, maxDigits = 4, 4
+	case 6:
+		if strings.Contains(str, ".") {
+			return 0, invalid()
+		}
+		minDigits, maxDigits = 1, 2
+	default:
+		return 0, invalid()
+	}
+	var v uint64
+	for _, g := range groups {
+		if len(g) < minDigits || len(g) > maxDigits {
+			return 0, invalid()
+		}
+		// Single digit groups are padded to bytes.
+		if len(g) < 2 {
+			v <<= 4
+		}
+		for i := 0; i < len(g); i++ {
+			d, ok := hexDigit(g[i])
+			if !ok {
+				return 0, invalid()
+			}
+			v = v<<4 | uint64(d)
+		}
+	}
+	return MACAddr(v), nil
+}
+
+// ParseMACAddr8 parses a MACAddr8. The input is a sequence of 6 or 8 pairs of
+// hex digits, optionally separated by one of ':', '-' or '.', which must be the
+// same throughout. 6 byte addresses are converted to EUI-64 format the same way
+// as with ToMACAddr8.
+func ParseMACAddr8(s string) (MACAddr8, error) {
+	invalid := func() error {
+		return pgerror.Newf(pgcode.InvalidTextRepresentation,
+			"invalid input syntax for type macaddr8: %q", s)
+	}
+	str := strings.TrimSpace(s)
+	var v uint64
+	var n int
+	var spacer byte
+	for i := 0; i < len(str); {
+		if n > 0 {
+			if c := str[i]; c == ':' || c == '-' || c == '.' {
+				if spacer == 0 {
+					spacer = c
+				} else if c != spacer {
+					return 0, invalid()
+				}
+				i++
+			}
+		}
+		if i+2 > len(str) || n == Size8 {
+			return 0, invalid()
+		}
+		hi, ok1 := hexDigit(str[i])
+		lo, ok2 := hexDigit(str[i+1])
+		if !ok1 || !ok2 {
+			return 0, invalid()
+		}
+		v = v<<8 | uint64(hi<<4|lo)
+		n++
+		i += 2
+	}
+	switch n {
+	case Size:
+		return MACAddr(v).ToMACAddr8(), nil
+	case Size8:
+		return MACAddr8(v), nil
+	default:
+		return 0, invalid()
+	}
+}
+
+func hexDigit(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+const hexDigits = "0123456789abcdef"
+
+func format(v uint64, n int) string {
+	b := make([]byte, 0, 3*n-1)
+	for i := n - 1; i >= 0; i-- {
+		octet := byte(v >> (8 * i))
+		b = append(b, hexDigits[octet>>4], hexDigits[octet&0xf])
+		if i > 0 {
+			b = append(b, ':')
+		}
+	}
+	return string(b)
+}
+
+// String returns the MACAddr as six colon separated pairs of lowercase hex
+// digits, which is the postgres output format.
+func (m MACAddr) String() string {
+	return format(uint64(m), Size)
+}
+
+// String returns the MACAddr8 as eight colon separated pairs of lowercase hex
+// digits, which is the postgres output format.
+func (m MACAddr8) String() string {
+	return format(uint64(m), Size8)
+}
+
+// ToMACAddr8 converts the MACAddr to EUI-64 format by inserting FF:FE between
+// its third and fourth bytes.
+func (m MACAddr) ToMACAddr8() MACAddr8 {
+	hi, lo := uint64(m)>>24, uint64(m)&0xffffff
+	return MACAddr8(hi<<40 | 0xfffe<<24 | lo)
+}
+
+// ToMACAddr converts the MACAddr8 to a 6 byte MACAddr by removing its fourth
+// and fifth bytes, which must be FF and FE.
+func (m MACAddr8) ToMACAddr() (MACAddr, error) {
+	if (uint64(m)>>24)&0xffff != 0xfffe {
+		return 0, errors.WithHint(
+			pgerror.New(pgcode.NumericValueOutOfRange,
+				"macaddr8 data out of range to convert to macaddr"),
+			"Only addresses that have FF and FE as values in the 4th and 5th bytes from the left, "+
+				"for example xx:xx:xx:ff:fe:xx:xx:xx, are eligible to be converted from macaddr8 to macaddr.",
+		)
+	}
+	hi, lo := uint64(m)>>40, uint64(m)&0xffffff
+	return MACAddr(hi<<24 | lo), nil
+}
+
+// Trunc sets the last 3 bytes of the MACAddr to zero, leaving the
+// manufacturer prefix.
+func (m MACAddr) Trunc() MACAddr {
+	return m &^ 0xffffff
+}
+
+// Trunc sets the last 5 bytes of the MACAddr8 to zero, leaving the
+// manufacturer prefix.
+func (m MACAddr8) Trunc() MACAddr8 {
+	return m &^ 0xffffffffff
+}
+
+// Set7Bit sets the 7th bit of the MACAddr8, which marks it as locally
+// administered. This is used to create modified EUI-64 addresses for IPv6.
+func (m MACAddr8) Set7Bit() MACAddr8 {
+	return m | 0x02<<56
+}
+
+// Not returns the bitwise complement of the MACAddr.
+func (m MACAddr) Not() MACAddr {
+	return ^m & mask48
+}
+
+// And returns the bitwise AND of the two MACAddrs.
+func (m MACAddr) And(other MACAddr) MACAddr {
+	return m & other
+}
+
+// Or returns the bitwise OR of the two MACAddrs.
+func (m MACAddr) Or(other MACAddr) MACAddr {
+	return m | other
+}
+
+// Not returns the bitwise complement of the MACAddr8.
+func (m MACAddr8) Not() MACAddr8 {
+	return ^m
+}
+
+// And returns the bitwise AND of the two MACAddr8s.
+func (m MACAddr8) And(other MACAddr8) MACAddr8 {
+	return m & other
+}
+
+// Or returns the bitwise OR of the two MACAddr8s.
+func (m MACAddr8) Or(other MACAddr8) MACAddr8 {
+	return m | other
+}
+
+// AppendBytes appends the 6 bytes of the MACAddr to b.
+func (m MACAddr) AppendBytes(b []byte) []byte {
+	var buf [Size8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(m))
+	return append(b, buf[Size8-Size:]...)
+}
+
+// AppendBytes appends the 8 bytes of the MACAddr8 to b.
+func (m MACAddr8) AppendBytes(b []byte) []byte {
+	return binary.BigEndian.AppendUint64(b, uint64(m))
+}
+
+// FromBytes returns the MACAddr stored in the 6 bytes b.
+func FromBytes(b []byte) (MACAddr, error) {
+	if len(b) != Size {
+		return 0, pgerror.Newf(pgcode.InvalidBinaryRepresentation,
+			"invalid macaddr length: %d", len(b))
+	}
+	var buf [Size8]byte
+	copy(buf[Size8-Size:], b)
+	return MACAddr(binary.BigEndian.Uint64(buf[:])), nil
+}
+
+// FromBytes8 returns the MACAddr8 stored in the 8 bytes b.
+func FromBytes8(b []byte) (MACAddr8, error) {
+	if len(b) != Size8 {
+		return 0, pgerror.Newf(pgcode.InvalidBinaryRepresentation,
+			"invalid macaddr8 length: %d", len(b))
+	}
+	return MACAddr8(binary.BigEndian.Uint64(b)), nil
+}
+
+// RandMACAddr generates a random MACAddr.
+func RandMACAddr(rng *rand.Rand) MACAddr {
+	return MACAddr(rng.Uint64() & mask48)
+}
+
+// RandMACAddr8 generates a random MACAddr8.
+func RandMACAddr8(rng *rand.Rand) MACAddr8 {
+	return MACAddr8(rng.Uint64())
+}
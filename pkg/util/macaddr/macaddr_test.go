@@ -0,0 +1,144 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package macaddr
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMACAddr(t *testing.T) {
+	for _, tc := range []struct {
+		s   string
+		exp string
+		err string
+	}{
+		{"08:00:2b:01:02:03", "08:00:2b:01:02:03", ""},
+		{"08-00-2b-01-02-03", "08:00:2b:01:02:03", ""},
+		{"08002b:010203", "08:00:2b:01:02:03", ""},
+		{"08002b-010203", "08:00:2b:01:02:03", ""},
+		{"0800.2b01.0203", "08:00:2b:01:02:03", ""},
+		{"0800-2b01-0203", "08:00:2b:01:02:03", ""},
+		{"08002b010203", "08:00:2b:01:02:03", ""},
+		{"08:00:2B:01:02:03", "08:00:2b:01:02:03", ""},
+		{"8:0:2b:1:2:3", "08:00:2b:01:02:03", ""},
+		{"  08:00:2b:01:02:03  ", "08:00:2b:01:02:03", ""},
+		{"ff:ff:ff:ff:ff:ff", "ff:ff:ff:ff:ff:ff", ""},
+
+		{"", "", `invalid input syntax for type macaddr: ""`},
+		{"08:00:2b:01:02", "", "invalid input syntax"},
+		{"08:00:2b:01:02:03:04", "", "invalid input syntax"},
+		{"08:00-2b:01:02:03", "", "invalid input syntax"},
+		{"0800:2b01:0203", "", "invalid input syntax"},
+		{"08002b.010203", "", "invalid input syntax"},
+		{"08:00:2b:01:02:0g", "", "invalid input syntax"},
+		{"08:00:2b:01:02:003", "", "invalid input syntax"},
+		{"08002b01020", "", "invalid input syntax"},
+	} {
+		t.Run(tc.s, func(t *testing.T) {
+			m, err := ParseMACAddr(tc.s)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, m.String())
+		})
+	}
+}
+
+func TestParseMACAddr8(t *testing.T) {
+	for _, tc := range []struct {
+		s   string
+		exp string
+		err string
+	}{
+		{"08:00:2b:01:02:03:04:05", "08:00:2b:01:02:03:04:05", ""},
+		{"08-00-2b-01-02-03-04-05", "08:00:2b:01:02:03:04:05", ""},
+		{"08002b:0102030405", "08:00:2b:01:02:03:04:05", ""},
+		{"0800.2b01.0203.0405", "08:00:2b:01:02:03:04:05", ""},
+		{"08002b0102030405", "08:00:2b:01:02:03:04:05", ""},
+		{"08:00:2b:01:02:03", "08:00:2b:ff:fe:01:02:03", ""},
+		{"08002b010203", "08:00:2b:ff:fe:01:02:03", ""},
+
+		{"", "", "invalid input syntax for type macaddr8"},
+		{"08:00:2b:01:02:03:04", "", "invalid input syntax"},
+		{"08:00:2b:01:02:03:04:05:06", "", "invalid input syntax"},
+		{"08:00-2b:01:02:03:04:05", "", "invalid input syntax"},
+		{"8:0:2b:1:2:3:4:5", "", "invalid input syntax"},
+		{":08:00:2b:01:02:03:04:05", "", "invalid input syntax"},
+		{"08:00:2b:01:02:03:04:05:", "", "invalid input syntax"},
+		{"08:00:2b:01:02:03:04:0g", "", "invalid input syntax"},
+	} {
+		t.Run(tc.s, func(t *testing.T) {
+			m, err := ParseMACAddr8(tc.s)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, m.String())
+		})
+	}
+}
+
+func TestMACAddrConversion(t *testing.T) {
+	m, err := ParseMACAddr("08:00:2b:01:02:03")
+	require.NoError(t, err)
+	m8 := m.ToMACAddr8()
+	require.Equal(t, "08:00:2b:ff:fe:01:02:03", m8.String())
+	back, err := m8.ToMACAddr()
+	require.NoError(t, err)
+	require.Equal(t, m, back)
+
+	m8, err = ParseMACAddr8("08:00:2b:01:02:03:04:05")
+	require.NoError(t, err)
+	_, err = m8.ToMACAddr()
+	require.ErrorContains(t, err, "macaddr8 data out of range to convert to macaddr")
+}
+
+func TestMACAddrOps(t *testing.T) {
+	m, err := ParseMACAddr("12:34:56:78:90:ab")
+	require.NoError(t, err)
+	require.Equal(t, "12:34:56:00:00:00", m.Trunc().String())
+	require.Equal(t, "ed:cb:a9:87:6f:54", m.Not().String())
+	other, err := ParseMACAddr("ff:00:ff:00:ff:00")
+	require.NoError(t, err)
+	require.Equal(t, "12:00:56:00:90:00", m.And(other).String())
+	require.Equal(t, "ff:34:ff:78:ff:ab", m.Or(other).String())
+
+	m8, err := ParseMACAddr8("12:34:56:78:90:ab:cd:ef")
+	require.NoError(t, err)
+	require.Equal(t, "12:34:56:00:00:00:00:00", m8.Trunc().String())
+	require.Equal(t, "ed:cb:a9:87:6f:54:32:10", m8.Not().String())
+	require.Equal(t, "12:34:56:78:90:ab:cd:ef", m8.Set7Bit().String())
+	m8, err = ParseMACAddr8("00:34:56:78:90:ab:cd:ef")
+	require.NoError(t, err)
+	require.Equal(t, "02:34:56:78:90:ab:cd:ef", m8.Set7Bit().String())
+}
+
+func TestMACAddrBytes(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		m := RandMACAddr(rng)
+		b := m.AppendBytes(nil)
+		require.Len(t, b, Size)
+		res, err := FromBytes(b)
+		require.NoError(t, err)
+		require.Equal(t, m, res)
+
+		m8 := RandMACAddr8(rng)
+		b = m8.AppendBytes(nil)
+		require.Len(t, b, Size8)
+		res8, err := FromBytes8(b)
+		require.NoError(t, err)
+		require.Equal(t, m8, res8)
+	}
+	_, err := FromBytes([]byte{1, 2, 3})
+	require.ErrorContains(t, err, "invalid macaddr length")
+}
@@ -0,0 +1,436 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package money implements the postgres compatible MONEY type, which stores a
+// currency amount as a 64-bit integer number of the smallest fractional unit
+// of the currency, and formats it according to the monetary conventions of
+// the lc_monetary locale.
+package money
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// Locale holds the monetary formatting conventions of a locale, which follow
+// the fields of the POSIX lconv struct. Postgres substitutes defaults for
+// some fields that are empty in the C locale; these are included in the
+// values below.
+type Locale struct {
+	// CurrencySymbol is the local currency symbol, such as "$".
+	CurrencySymbol string
+	// DecimalPoint is the radix character.
+	DecimalPoint string
+	// ThousandsSep separates the groups of digits left of the radix character.
+	ThousandsSep string
+	// Grouping is the number of digits in each group.
+	Grouping int
+	// PositiveSign and NegativeSign indicate the sign of the amount.
+	PositiveSign, NegativeSign string
+	// FracDigits is the number of digits right of the radix character, which
+	// also determines the scale of the stored integer.
+	FracDigits int
+	// CsPrecedes is whether the currency symbol precedes the amount, for
+	// positive and negative amounts.
+	PCsPrecedes, NCsPrecedes bool
+	// SepBySpace controls the spaces between the currency symbol, sign and
+	// amount for positive and negative amounts. 0 means no space, 1 means a
+	// space separates the currency symbol (and sign if adjacent) from the
+	// amount, 2 means a space separates the sign from the currency symbol if
+	// they are adjacent and otherwise from the amount.
+	PSepBySpace, NSepBySpace int
+	// SignPosn controls the position of the sign for positive and negative
+	// amounts. 0 means parentheses surround the amount and currency symbol,
+	// 1 means the sign precedes both, 2 means the sign follows both, 3 means
+	// the sign immediately precedes the currency symbol and 4 means it
+	// immediately follows it.
+	PSignPosn, NSignPosn int
+}
+
+// DefaultLocale is the name of the default locale for money values, which
+// matches the default lc_monetary.
+const DefaultLocale = "C.UTF-8"
+
+var cLocale = &Locale{
+	CurrencySymbol: "$",
+	DecimalPoint:   ".",
+	ThousandsSep:   ",",
+	Grouping:       3,
+	NegativeSign:   "-",
+	FracDigits:     2,
+	PCsPrecedes:    true,
+	NCsPrecedes:    true,
+	PSignPosn:      1,
+	NSignPosn:      1,
+}
+
+var enGBLocale = &Locale{
+	CurrencySymbol: "£",
+	DecimalPoint:   ".",
+	ThousandsSep:   ",",
+	Grouping:       3,
+	NegativeSign:   "-",
+	FracDigits:     2,
+	PCsPrecedes:    true,
+	NCsPrecedes:    true,
+	PSignPosn:      1,
+	NSignPosn:      1,
+}
+
+var deDELocale = &Locale{
+	CurrencySymbol: "€",
+	DecimalPoint:   ",",
+	ThousandsSep:   ".",
+	Grouping:       3,
+	NegativeSign:   "-",
+	FracDigits:     2,
+	PSepBySpace:    1,
+	NSepBySpace:    1,
+	PSignPosn:      1,
+	NSignPosn:      1,
+}
+
+var frFRLocale = &Locale{
+	CurrencySymbol: "€",
+	DecimalPoint:   ",",
+	ThousandsSep:   "\u202f",
+	Grouping:       3,
+	NegativeSign:   "-",
+	FracDigits:     2,
+	PSepBySpace:    1,
+	NSepBySpace:    1,
+	PSignPosn:      1,
+	NSignPosn:      1,
+}
+
+var jaJPLocale = &Locale{
+	CurrencySymbol: "￥",
+	DecimalPoint:   ".",
+	ThousandsSep:   ",",
+	Grouping:       3,
+	NegativeSign:   "-",
+	FracDigits:     0,
+	PCsPrecedes:    true,
+	NCsPrecedes:    true,
+	PSignPosn:      1,
+	NSignPosn:      4,
+}
+
+var locales = map[string]*Locale{
+	"":      cLocale,
+	"c":     cLocale,
+	"posix": cLocale,
+	"en_us": cLocale,
+	"en_gb": enGBLocale,
+	"de_de": deDELocale,
+	"fr_fr": frFRLocale,
+	"ja_jp": jaJPLocale,
+}
+
+// LookupLocale returns the monetary conventions of the named locale. The
+// encoding suffix of the name, such as ".UTF-8", is ignored.
+func LookupLocale(name string) (*Locale, bool) {
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[:i]
+	}
+	l, ok := locales[strings.ToLower(name)]
+	return l, ok
+}
+
+// MustLookupLocale is like LookupLocale but falls back to the default locale
+// if the named locale is unknown.
+func MustLookupLocale(name string) *Locale {
+	if l, ok := LookupLocale(name); ok {
+		return l
+	}
+	return cLocale
+}
+
+// Scale returns the number of stored units in one unit of currency, which is
+// 10 to the power of FracDigits.
+func (l *Locale) Scale() int64 {
+	scale := int64(1)
+	for i := 0; i < l.FracDigits; i++ {
+		scale *= 10
+	}
+	return scale
+}
+
+// Format formats the amount according to the locale, following cash_out in
+// postgres.
+func Format(v int64, l *Locale) string {
+	var sign string
+	var signPosn, sepBySpace int
+	var csPrecedes bool
+	if v < 0 {
+		sign, signPosn, csPrecedes, sepBySpace = l.NegativeSign, l.NSignPosn, l.NCsPrecedes, l.NSepBySpace
+	} else {
+		sign, signPosn, csPrecedes, sepBySpace = l.PositiveSign, l.PSignPosn, l.PCsPrecedes, l.PSepBySpace
+	}
+	// Negating in uint64 handles the most negative value.
+	u := uint64(v)
+	if v < 0 {
+		u = -u
+	}
+
+	// Build the digits, separators and radix character right to left.
+	// digitPos is the position of the current digit, with zero being the
+	// digit just left of the radix character.
+	var buf []string
+	digitPos := l.FracDigits
+	for {
+		if l.FracDigits > 0 && digitPos == 0 {
+			buf = append(buf, l.DecimalPoint)
+		} else if digitPos < 0 && digitPos%l.Grouping == 0 {
+			buf = append(buf, l.ThousandsSep)
+		}
+		buf = append(buf, string(rune('0'+u%10)))
+		u /= 10
+		digitPos--
+		if u == 0 && digitPos < 0 {
+			break
+		}
+	}
+	var sb strings.Builder
+	for i := len(buf) - 1; i >= 0; i-- {
+		sb.WriteString(buf[i])
+	}
+	amount := sb.String()
+
+	space := func(n int) string {
+		if sepBySpace == n {
+			return " "
+		}
+		return ""
+	}
+	cs := l.CurrencySymbol
+	switch signPosn {
+	case 0:
+		if csPrecedes {
+			return "(" + cs + space(1) + amount + ")"
+		}
+		return "(" + amount + space(1) + cs + ")"
+	case 2:
+		if csPrecedes {
+			return cs + space(1) + amount + space(2) + sign
+		}
+		return amount + space(1) + cs + space(2) + sign
+	case 3:
+		if csPrecedes {
+			return sign + space(2) + cs + space(1) + amount
+		}
+		return amount + space(1) + sign + space(2) + cs
+	case 4:
+		if csPrecedes {
+			return cs + space(2) + sign + space(1) + amount
+		}
+		return amount + space(1) + cs + space(2) + sign
+	default:
+		if csPrecedes {
+			return sign + space(2) + cs + space(1) + amount
+		}
+		return sign + space(2) + amount + space(1) + cs
+	}
+}
+
+// Parse parses an amount formatted according to the locale, following
+// cash_in in postgres. The currency symbol, thousands separators and sign are
+// optional, and digits beyond the precision of the locale are rounded.
+func Parse(s string, l *Locale) (int64, error) {
+	outOfRange := func() error {
+		return pgerror.Newf(pgcode.NumericValueOutOfRange,
+			"value %q is out of range for type money", s)
+	}
+	psymbol, nsymbol := l.PositiveSign, l.NegativeSign
+	if psymbol == "" {
+		psymbol = "+"
+	}
+	if nsymbol == "" {
+		nsymbol = "-"
+	}
+	str := strings.TrimLeftFunc(s, unicode.IsSpace)
+	str = strings.TrimPrefix(str, l.CurrencySymbol)
+	str = strings.TrimLeftFunc(str, unicode.IsSpace)
+
+	// A leading sign or parenthesis signifies a negative number.
+	negative := false
+	if strings.HasPrefix(str, nsymbol) {
+		negative = true
+		str = str[len(nsymbol):]
+	} else if strings.HasPrefix(str, "(") {
+		negative = true
+		str = str[1:]
+	} else if strings.HasPrefix(str, psymbol) {
+		str = str[len(psymbol):]
+	}
+	// Whitespace and the currency symbol can follow the sign.
+	str = strings.TrimLeftFunc(str, unicode.IsSpace)
+	str = strings.TrimPrefix(str, l.CurrencySymbol)
+	str = strings.TrimLeftFunc(str, unicode.IsSpace)
+
+	// The value is built as a negative number, so that the most negative
+	// value can be parsed.
+	var v int64
+	var dec int
+	seenDot := false
+	for len(str) > 0 {
+		c := str[0]
+		if isDigit(c) && (!seenDot || dec < l.FracDigits) {
+			digit := int64(c - '0')
+			if v < (math.MinInt64+digit)/10 {
+				return 0, outOfRange()
+			}
+			v = v*10 - digit
+			if seenDot {
+				dec++
+			}
+			str = str[1:]
+		} else if !seenDot && strings.HasPrefix(str, l.DecimalPoint) {
+			seenDot = true
+			str = str[len(l.DecimalPoint):]
+		} else if strings.HasPrefix(str, l.ThousandsSep) {
+			str = str[len(l.ThousandsSep):]
+		} else {
+			break
+		}
+	}
+	// Round off if there's another digit.
+	if len(str) > 0 && str[0] >= '5' && str[0] <= '9' {
+		if v == math.MinInt64 {
+			return 0, outOfRange()
+		}
+		v--
+	}
+	// Adjust for fewer decimal places than required.
+	for ; dec < l.FracDigits; dec++ {
+		if v < math.MinInt64/10 {
+			return 0, outOfRange()
+		}
+		v *= 10
+	}
+	// Only trailing digits followed by whitespace, a right parenthesis, a sign
+	// and the currency symbol can remain.
+	for len(str) > 0 && isDigit(str[0]) {
+		str = str[1:]
+	}
+	for len(str) > 0 {
+		r, size := utf8.DecodeRuneInString(str)
+		switch {
+		case unicode.IsSpace(r) || r == ')':
+			str = str[size:]
+		case strings.HasPrefix(str, nsymbol):
+			negative = true
+			str = str[len(nsymbol):]
+		case strings.HasPrefix(str, psymbol):
+			str = str[len(psymbol):]
+		case l.CurrencySymbol != "" && strings.HasPrefix(str, l.CurrencySymbol):
+			str = str[len(l.CurrencySymbol):]
+		default:
+			return 0, pgerror.Newf(pgcode.InvalidTextRepresentation,
+				"invalid input syntax for type money: %q", s)
+		}
+	}
+	if !negative {
+		if v == math.MinInt64 {
+			return 0, outOfRange()
+		}
+		v = -v
+	}
+	return v, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+var smallNumberWords = [...]string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+	"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+	"seventeen", "eighteen", "nineteen",
+}
+
+var tensWords = [...]string{
+	"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety",
+}
+
+// numberWords spells out a number less than 1000, following num_word in
+// postgres.
+func numberWords(v int64) string {
+	tu := v % 100
+	if v < 20 {
+		return smallNumberWords[v]
+	}
+	var hundreds string
+	if v > 99 {
+		hundreds = smallNumberWords[v/100] + " hundred"
+		if tu == 0 {
+			return hundreds
+		}
+		hundreds += " "
+		if tu < 20 {
+			hundreds += "and "
+		}
+	}
+	switch {
+	case tu < 20:
+		return hundreds + smallNumberWords[tu]
+	case tu%10 == 0:
+		return hundreds + tensWords[tu/10]
+	default:
+		return hundreds + tensWords[tu/10] + " " + smallNumberWords[tu%10]
+	}
+}
+
+// Words spells out the amount in dollars and cents, following cash_words in
+// postgres. Like postgres, it assumes two fractional digits regardless of
+// the locale.
+func Words(v int64) string {
+	var sb strings.Builder
+	// Negating in uint64 handles the most negative value.
+	u := uint64(v)
+	if v < 0 {
+		sb.WriteString("minus ")
+		u = -u
+	}
+	prefixLen := sb.Len()
+	for _, unit := range []struct {
+		div  uint64
+		name string
+	}{
+		{100000000000000000, " quadrillion "},
+		{100000000000000, " trillion "},
+		{100000000000, " billion "},
+		{100000000, " million "},
+		{100000, " thousand "},
+		{100, ""},
+	} {
+		if m := (u / unit.div) % 1000; m != 0 {
+			sb.WriteString(numberWords(int64(m)))
+			sb.WriteString(unit.name)
+		}
+	}
+	if sb.Len() == prefixLen {
+		sb.WriteString("zero")
+	}
+	if u/100 == 1 {
+		sb.WriteString(" dollar and ")
+	} else {
+		sb.WriteString(" dollars and ")
+	}
+	cents := int64(u % 100)
+	sb.WriteString(numberWords(cents))
+	if cents == 1 {
+		sb.WriteString(" cent")
+	} else {
+		sb.WriteString(" cents")
+	}
+	s := sb.String()
+	return strings.ToUpper(s[:1]) + s[1:]
+}
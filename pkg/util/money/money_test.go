@@ -0,0 +1,117 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	for _, tc := range []struct {
+		locale string
+		v      int64
+		exp    string
+	}{
+		{"C", 0, "$0.00"},
+		{"C", 5, "$0.05"},
+		{"C", 123456, "$1,234.56"},
+		{"C", -123456, "-$1,234.56"},
+		{"C.UTF-8", 100000000, "$1,000,000.00"},
+		{"C", math.MaxInt64, "$92,233,720,368,547,758.07"},
+		{"C", math.MinInt64, "-$92,233,720,368,547,758.08"},
+		{"en_US.utf8", -123456, "-$1,234.56"},
+		{"en_GB.UTF-8", 123456, "£1,234.56"},
+		{"de_DE.UTF-8", 123456, "1.234,56 €"},
+		{"de_DE", -123456, "-1.234,56 €"},
+		{"fr_FR", 123456, "1 234,56 €"},
+		{"ja_JP.UTF-8", 1234, "￥1,234"},
+		{"ja_JP.UTF-8", -1234, "￥-1,234"},
+	} {
+		t.Run(tc.exp, func(t *testing.T) {
+			l, ok := LookupLocale(tc.locale)
+			require.True(t, ok)
+			require.Equal(t, tc.exp, Format(tc.v, l))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		locale string
+		s      string
+		exp    int64
+		err    string
+	}{
+		{"C", "$1,234.56", 123456, ""},
+		{"C", "1234.56", 123456, ""},
+		{"C", "  $1234.5", 123450, ""},
+		{"C", "12", 1200, ""},
+		{"C", "-$12.34", -1234, ""},
+		{"C", "$-12.34", -1234, ""},
+		{"C", "($12.34)", -1234, ""},
+		{"C", "12.34-", -1234, ""},
+		{"C", "+12.34", 1234, ""},
+		{"C", "12.345", 1235, ""},
+		{"C", "12.344", 1234, ""},
+		{"C", "-12.345", -1235, ""},
+		{"C", "12.34 $", 1234, ""},
+		{"C", "92233720368547758.07", math.MaxInt64, ""},
+		{"C", "-92233720368547758.08", math.MinInt64, ""},
+		{"C", "92233720368547758.08", 0, `value "92233720368547758.08" is out of range for type money`},
+		{"C", "-92233720368547758.09", 0, "out of range"},
+		{"C", "92233720368547758.075", 0, "out of range"},
+		{"C", "1000000000000000000", 0, "out of range"},
+		{"C", "12a", 0, `invalid input syntax for type money: "12a"`},
+		{"C", "€12", 0, "invalid input syntax"},
+		{"de_DE", "1.234,56 €", 123456, ""},
+		{"de_DE", "-1234,5", -123450, ""},
+		{"ja_JP", "￥1,234", 1234, ""},
+		{"ja_JP", "1234.5", 1235, ""},
+	} {
+		t.Run(tc.s, func(t *testing.T) {
+			l, ok := LookupLocale(tc.locale)
+			require.True(t, ok)
+			v, err := Parse(tc.s, l)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, v)
+		})
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	for _, name := range []string{"", "C", "POSIX", "C.UTF-8", "en_US.UTF-8", "de_DE.utf8"} {
+		_, ok := LookupLocale(name)
+		require.True(t, ok, name)
+	}
+	_, ok := LookupLocale("xx_XX")
+	require.False(t, ok)
+	require.Equal(t, int64(100), MustLookupLocale("xx_XX").Scale())
+	require.Equal(t, int64(1), MustLookupLocale("ja_JP").Scale())
+}
+
+func TestWords(t *testing.T) {
+	for _, tc := range []struct {
+		v   int64
+		exp string
+	}{
+		{0, "Zero dollars and zero cents"},
+		{1, "Zero dollars and one cent"},
+		{100, "One dollar and zero cents"},
+		{12345, "One hundred twenty three dollars and forty five cents"},
+		{11000, "One hundred and ten dollars and zero cents"},
+		{-2050, "Minus twenty dollars and fifty cents"},
+		{123456789, "One million two hundred thirty four thousand five hundred sixty seven dollars and eighty nine cents"},
+	} {
+		require.Equal(t, tc.exp, Words(tc.v))
+	}
+}
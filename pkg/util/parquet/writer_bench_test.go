@@ -64,7 +64,10 @@ func getBenchmarkTypes() []*types.T {
 	for _, typ := range randgen.SeedTypes {
 		switch typ.Family() {
 		case types.AnyFamily, types.TSQueryFamily, types.TSVectorFamily,
-			types.VoidFamily, types.PGVectorFamily:
+			types.VoidFamily, types.PGVectorFamily, types.PointFamily, types.BoxFamily,
+			types.LSegFamily, types.LineFamily, types.PathFamily, types.PolygonFamily,
+			types.CircleFamily, types.MACAddrFamily, types.MACAddr8Family, types.MoneyFamily,
+			types.RangeFamily, types.MultirangeFamily, types.JsonpathFamily:
 		case types.TupleFamily:
 			// Replace Any Tuple with Tuple of Ints with size 5.
 			typs = append(typs, types.MakeTuple([]*types.T{
@@ -46,7 +46,10 @@ func newColSchema(numCols int) *colSchema {
 // that are not supported by the writer.
 func typSupported(typ *types.T) bool {
 	switch typ.Family() {
-	case types.AnyFamily, types.TSQueryFamily, types.TSVectorFamily, types.PGVectorFamily, types.VoidFamily:
+	case types.AnyFamily, types.TSQueryFamily, types.TSVectorFamily, types.PGVectorFamily, types.VoidFamily,
+		types.PointFamily, types.BoxFamily, types.LSegFamily, types.LineFamily, types.PathFamily,
+		types.PolygonFamily, types.CircleFamily, types.MACAddrFamily, types.MACAddr8Family,
+		types.MoneyFamily, types.RangeFamily, types.MultirangeFamily, types.JsonpathFamily:
 		return false
 	case types.ArrayFamily:
 		if typ.ArrayContents().Family() == types.ArrayFamily || typ.ArrayContents().Family() == types.TupleFamily {
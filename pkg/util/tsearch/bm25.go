@@ -0,0 +1,203 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsearch
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/keysbase"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// This file implements BM25 ranking of tsvectors. Unlike term frequency
+// ranking, BM25 weighs every lexeme of the query by its inverse document
+// frequency, so that rare lexemes contribute more to the score than common
+// ones. The score of a document is the sum over the distinct lexemes of the
+// query which appear in the document of:
+//
+//	idf * tf * (k1 + 1) / (tf + k1 * (1 - b + b * dl / avgdl))
+//
+// where tf is the number of occurrences of the lexeme in the document, dl is
+// the length of the document, avgdl is the average length of the documents of
+// the collection, and
+//
+//	idf = ln(1 + (N - df + 0.5) / (df + 0.5))
+//
+// where N is the number of documents of the collection and df is the number of
+// those documents which contain the lexeme.
+//
+// These collection statistics are kept by inverted indexes which are created
+// with the term_statistics storage parameter. The document frequency of a
+// lexeme is the number of its postings. The index also has a document entry
+// for every row with a non-empty vector, whose value is the length of the
+// vector, so the number of documents and their average length are computed
+// from the document entries. The document entries sort before the postings of
+// every lexeme, so they are never part of the spans that are scanned to
+// evaluate a text search query.
+
+// BM25Params are the free parameters of BM25 ranking.
+type BM25Params struct {
+	// K1 controls how quickly the score of a lexeme saturates as its number of
+	// occurrences increases.
+	K1 float64
+	// B controls how much the score is normalized by the document length.
+	B float64
+}
+
+// DefaultBM25Params are the parameters used when none are given.
+var DefaultBM25Params = BM25Params{K1: 1.2, B: 0.75}
+
+// Validate returns an error if the parameters are out of range.
+func (p BM25Params) Validate() error {
+	return TFRankParams{K1: p.K1, B: p.B}.Validate()
+}
+
+// QueryLexeme is a lexeme of a query which contributes to the BM25 rank of the
+// documents that contain it.
+type QueryLexeme struct {
+	Lexeme string
+	// Prefix is set if the query lexeme matches every lexeme that starts with
+	// it.
+	Prefix bool
+}
+
+// RankedLexemes returns the lexemes of the query which contribute to its BM25
+// rank, which are the distinct lexemes that aren't negated, in sorted order.
+// For a query which TopKSearchTerms accepts, these are the search terms.
+func RankedLexemes(q TSQuery) []QueryLexeme {
+	leaves := positiveQueryTerms(q)
+	ret := make([]QueryLexeme, len(leaves))
+	for i, leaf := range leaves {
+		ret[i].Lexeme = leaf.term.lexeme
+		if len(leaf.term.positions) > 0 {
+			ret[i].Prefix = leaf.term.positions[0].weight&weightStar != 0
+		}
+	}
+	return ret
+}
+
+// IndexSpan returns the span of the keys of the postings of the lexemes which
+// the query lexeme matches, in an inverted index with the given key prefix.
+func (l QueryLexeme) IndexSpan(prefix []byte) (start, end []byte) {
+	start = EncodeInvertedIndexKey(prefix, l.Lexeme)
+	if l.Prefix {
+		return start, EncodeInvertedIndexKey(prefix, string(keysbase.PrefixEnd([]byte(l.Lexeme))))
+	}
+	return start, keysbase.PrefixEnd(start)
+}
+
+// CollectionStatistics are the statistics of a collection of documents which
+// BM25 ranking needs for a query.
+type CollectionStatistics struct {
+	// NumDocs is the number of documents of the collection. Empty documents
+	// aren't counted.
+	NumDocs int
+	// TotalDocLength is the sum of the lengths of the documents.
+	TotalDocLength int
+	// DocFreqs contains the number of documents which contain each lexeme
+	// returned by RankedLexemes for the query, in the same order. The weights
+	// of the occurrences of the lexemes are ignored. The document frequency of
+	// a prefix lexeme is the sum of the frequencies of the lexemes that start
+	// with it, capped at NumDocs, since an inverted index can't tell how many
+	// distinct documents contain them without reading all their postings.
+	DocFreqs []int
+}
+
+// AvgDocLength returns the average length of the documents of the collection.
+func (s *CollectionStatistics) AvgDocLength() float64 {
+	if s.NumDocs == 0 {
+		return 0
+	}
+	return float64(s.TotalDocLength) / float64(s.NumDocs)
+}
+
+// idfs returns the inverse document frequency of each ranked lexeme.
+func (s *CollectionStatistics) idfs() []float64 {
+	ret := make([]float64, len(s.DocFreqs))
+	for i, df := range s.DocFreqs {
+		ret[i] = math.Log(1 + (float64(s.NumDocs-df)+0.5)/(float64(df)+0.5))
+	}
+	return ret
+}
+
+// RankBM25 implements the ts_rank_bm25 builtin, which ranks a tsvector against
+// a tsquery with BM25, given the statistics of the collection of documents
+// for the query. As with RankTF, the occurrences of a lexeme only count if
+// they have one of the weights the query lexeme is restricted to, and a prefix
+// lexeme counts the occurrences of all the lexemes it matches.
+func RankBM25(v TSVector, q TSQuery, p BM25Params, stats *CollectionStatistics) (float64, error) {
+	leaves := positiveQueryTerms(q)
+	if len(leaves) != len(stats.DocFreqs) {
+		return 0, errors.AssertionFailedf(
+			"expected %d document frequencies, found %d", len(leaves), len(stats.DocFreqs))
+	}
+	if len(v) == 0 {
+		return 0, nil
+	}
+	scorer := MakeBM25Scorer(p, stats)
+	dl := cntLen(v)
+	var score float64
+	for i, tf := range queryTermFrequencies(v, leaves) {
+		if tf > 0 {
+			score += scorer.TermScore(i, tf, dl)
+		}
+	}
+	return score, nil
+}
+
+// BM25Scorer is a TermScorer which scores the ranked lexemes of a query with
+// BM25.
+type BM25Scorer struct {
+	params TFRankParams
+	idfs   []float64
+}
+
+var _ TermScorer = BM25Scorer{}
+
+// MakeBM25Scorer returns a BM25Scorer for the lexemes of a query, given the
+// statistics of the collection for the query.
+func MakeBM25Scorer(p BM25Params, stats *CollectionStatistics) BM25Scorer {
+	return BM25Scorer{
+		params: TFRankParams{K1: p.K1, B: p.B, AvgDocLength: stats.AvgDocLength()},
+		idfs:   stats.idfs(),
+	}
+}
+
+// TermScore implements the TermScorer interface.
+func (s BM25Scorer) TermScore(i int, tf, dl int) float64 {
+	return s.idfs[i] * s.params.termScore(tf, dl)
+}
+
+// MaxTermScore implements the TermScorer interface.
+func (s BM25Scorer) MaxTermScore(i int) float64 {
+	return s.idfs[i] * (s.params.K1 + 1)
+}
+
+// EncodeInvertedIndexDocumentKey returns the key prefix of the document
+// entries of an inverted index which stores term statistics. The primary key
+// of the row follows it in the key of a document entry.
+func EncodeInvertedIndexDocumentKey(inKey []byte) []byte {
+	outKey := make([]byte, len(inKey), len(inKey)+1)
+	copy(outKey, inKey)
+	return encoding.EncodeNullAscending(outKey)
+}
+
+// EncodeDocumentStatistics appends the length of a document to the value of
+// its document entry in an inverted index.
+func EncodeDocumentStatistics(appendTo []byte, dl int) []byte {
+	return encoding.EncodeUvarintAscending(appendTo, uint64(dl))
+}
+
+// DecodeDocumentStatistics decodes the length encoded by
+// EncodeDocumentStatistics.
+func DecodeDocumentStatistics(b []byte) (dl int, err error) {
+	_, dl64, err := encoding.DecodeUvarintAscending(b)
+	if err != nil {
+		return 0, err
+	}
+	return int(dl64), nil
+}
@@ -0,0 +1,232 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsearch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankBM25(t *testing.T) {
+	// The collection has 4 documents with a total length of 10, so the average
+	// document length is 2.5. A document of length 3 then has a length
+	// normalization of 1 - 0.75 + 0.75 * 3 / 2.5 = 1.15.
+	tests := []struct {
+		v        string
+		q        string
+		docFreqs []int
+		params   BM25Params
+		expected float64
+	}{
+		{v: "a:1,2 b:3", q: "a", docFreqs: []int{2}, params: DefaultBM25Params,
+			expected: math.Log(2) * 4.4 / 3.38},
+		{v: "a:1,2 b:3", q: "a & b", docFreqs: []int{2, 2}, params: DefaultBM25Params,
+			expected: math.Log(2) * (4.4/3.38 + 2.2/2.38)},
+		{v: "a:1,2 b:3", q: "a | !b", docFreqs: []int{2}, params: DefaultBM25Params,
+			expected: math.Log(2) * 4.4 / 3.38},
+		// A lexeme which is in every document has a small weight.
+		{v: "a:1,2 b:3", q: "a", docFreqs: []int{4}, params: DefaultBM25Params,
+			expected: math.Log(1+0.5/4.5) * 4.4 / 3.38},
+		{v: "a:1,2 b:3", q: "c", docFreqs: []int{1}, params: DefaultBM25Params, expected: 0},
+		{v: "", q: "a", docFreqs: []int{2}, params: DefaultBM25Params, expected: 0},
+		{v: "a:1,2 b:3", q: "a", docFreqs: []int{2}, params: BM25Params{K1: 1.2},
+			expected: math.Log(2) * 4.4 / 3.2},
+		{v: "a:1 ba:2 bb:3,4", q: "b:*", docFreqs: []int{3}, params: BM25Params{K1: 1.2},
+			expected: math.Log(1+1.5/3.5) * 6.6 / 4.2},
+		{v: "a:1A,2 b:3", q: "a:A", docFreqs: []int{2}, params: BM25Params{K1: 1.2},
+			expected: math.Log(2)},
+	}
+	for _, tt := range tests {
+		v, err := ParseTSVector(tt.v)
+		require.NoError(t, err)
+		q, err := ParseTSQuery(tt.q)
+		require.NoError(t, err)
+		stats := CollectionStatistics{NumDocs: 4, TotalDocLength: 10, DocFreqs: tt.docFreqs}
+		actual, err := RankBM25(v, q, tt.params, &stats)
+		require.NoError(t, err)
+		assert.InDeltaf(t, tt.expected, actual, 1e-9, "RankBM25(%s, %s, %+v)", tt.v, tt.q, tt.params)
+	}
+
+	v, err := ParseTSVector("a b")
+	require.NoError(t, err)
+	q, err := ParseTSQuery("a & b")
+	require.NoError(t, err)
+	_, err = RankBM25(v, q, DefaultBM25Params, &CollectionStatistics{NumDocs: 1, DocFreqs: []int{1}})
+	require.Error(t, err)
+}
+
+func TestRankedLexemes(t *testing.T) {
+	tests := []struct {
+		q        string
+		expected []QueryLexeme
+	}{
+		{q: "a", expected: []QueryLexeme{{Lexeme: "a"}}},
+		{q: "c & (b | a) & c", expected: []QueryLexeme{{Lexeme: "a"}, {Lexeme: "b"}, {Lexeme: "c"}}},
+		{q: "a & !b", expected: []QueryLexeme{{Lexeme: "a"}}},
+		{q: "a:* <-> b:B", expected: []QueryLexeme{{Lexeme: "a", Prefix: true}, {Lexeme: "b"}}},
+	}
+	for _, tt := range tests {
+		q, err := ParseTSQuery(tt.q)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, RankedLexemes(q), tt.q)
+	}
+}
+
+func TestQueryLexemeIndexSpan(t *testing.T) {
+	prefix := []byte("prefix")
+	inSpan := func(l QueryLexeme, key []byte) bool {
+		start, end := l.IndexSpan(prefix)
+		return bytes.Compare(start, key) <= 0 && bytes.Compare(key, end) < 0
+	}
+	posting := func(lexeme string) []byte {
+		return append(EncodeInvertedIndexKey(prefix, lexeme), "pk"...)
+	}
+	assert.True(t, inSpan(QueryLexeme{Lexeme: "ab"}, posting("ab")))
+	assert.False(t, inSpan(QueryLexeme{Lexeme: "ab"}, posting("abc")))
+	assert.False(t, inSpan(QueryLexeme{Lexeme: "ab"}, posting("a")))
+	assert.True(t, inSpan(QueryLexeme{Lexeme: "ab", Prefix: true}, posting("ab")))
+	assert.True(t, inSpan(QueryLexeme{Lexeme: "ab", Prefix: true}, posting("abc")))
+	assert.False(t, inSpan(QueryLexeme{Lexeme: "ab", Prefix: true}, posting("ac")))
+
+	// The document entries are never part of the span of a lexeme.
+	doc := append(EncodeInvertedIndexDocumentKey(prefix), "pk"...)
+	assert.False(t, inSpan(QueryLexeme{Lexeme: "ab"}, doc))
+	assert.False(t, inSpan(QueryLexeme{Lexeme: "a", Prefix: true}, doc))
+}
+
+func TestDocumentStatistics(t *testing.T) {
+	for _, dl := range []int{0, 1, 300, 1 << 20} {
+		actual, err := DecodeDocumentStatistics(EncodeDocumentStatistics(nil, dl))
+		require.NoError(t, err)
+		assert.Equal(t, dl, actual)
+	}
+}
+
+// makeCollectionStatistics returns the statistics of the collection of the
+// given documents for the query.
+func makeCollectionStatistics(vectors []TSVector, q TSQuery) CollectionStatistics {
+	lexemes := RankedLexemes(q)
+	stats := CollectionStatistics{DocFreqs: make([]int, len(lexemes))}
+	for _, v := range vectors {
+		if len(v) == 0 {
+			continue
+		}
+		stats.NumDocs++
+		stats.TotalDocLength += cntLen(v)
+		for i, l := range lexemes {
+			for j := range v {
+				if v[j].lexeme == l.Lexeme || (l.Prefix && hasPrefix(v[j].lexeme, l.Lexeme)) {
+					stats.DocFreqs[i]++
+					break
+				}
+			}
+		}
+	}
+	return stats
+}
+
+func TestSearchTopKBM25(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	lexemes := []string{"a", "b", "c", "d", "e"}
+	queries := []string{"a", "a | b", "a & b", "b | c | d", "(a | b) & c", "e & a | d", "e | a"}
+	ctx := context.Background()
+
+	for iter := 0; iter < 100; iter++ {
+		// Generate random documents, where lexemes earlier in the list are more
+		// frequent, so that they have lower inverse document frequencies.
+		numDocs := rng.Intn(50)
+		docs := make([][]byte, numDocs)
+		vectors := make([]TSVector, numDocs)
+		for i := range docs {
+			docs[i] = []byte(fmt.Sprintf("doc%03d", i))
+			var words []string
+			pos := 1
+			for j, l := range lexemes {
+				if rng.Intn(j+2) != 0 {
+					continue
+				}
+				positions := make([]string, 1+rng.Intn(3))
+				for k := range positions {
+					positions[k] = fmt.Sprint(pos)
+					pos++
+				}
+				words = append(words, l+":"+strings.Join(positions, ","))
+			}
+			v, err := ParseTSVector(strings.Join(words, " "))
+			require.NoError(t, err)
+			vectors[i] = v
+		}
+		params := BM25Params{K1: 0.5 + rng.Float64(), B: rng.Float64()}
+		k := 1 + rng.Intn(5)
+
+		for _, qStr := range queries {
+			q, err := ParseTSQuery(qStr)
+			require.NoError(t, err)
+			terms, ok := TopKSearchTerms(q)
+			require.True(t, ok)
+			stats := makeCollectionStatistics(vectors, q)
+
+			// Build the posting lists of the query terms.
+			cursors := make([]PostingCursor, len(terms))
+			for i, term := range terms {
+				c := &slicePostingCursor{}
+				for j, v := range vectors {
+					tfs, dl := TermStatistics(v)
+					for l := range v {
+						if v[l].lexeme == term {
+							c.docs = append(c.docs, docs[j])
+							c.tfs = append(c.tfs, tfs[l])
+							c.dls = append(c.dls, dl)
+						}
+					}
+				}
+				cursors[i] = c
+			}
+
+			// Rank all the matching documents.
+			expected := []float64{}
+			for _, v := range vectors {
+				matches, err := EvalTSQuery(q, v)
+				require.NoError(t, err)
+				if matches {
+					score, err := RankBM25(v, q, params, &stats)
+					require.NoError(t, err)
+					expected = append(expected, score)
+				}
+			}
+			sort.Sort(sort.Reverse(sort.Float64Slice(expected)))
+			if len(expected) > k {
+				expected = expected[:k]
+			}
+
+			res, err := SearchTopK(ctx, q, terms, cursors, k, MakeBM25Scorer(params, &stats))
+			require.NoError(t, err)
+			actual := make([]float64, len(res))
+			for i := range res {
+				actual[i] = res[i].Score
+				j := sort.Search(len(docs), func(j int) bool {
+					return bytes.Compare(docs[j], res[i].Doc) >= 0
+				})
+				score, err := RankBM25(vectors[j], q, params, &stats)
+				require.NoError(t, err)
+				assert.InDelta(t, score, res[i].Score, 1e-9)
+			}
+			require.Len(t, actual, len(expected), "query %s, k=%d", qStr, k)
+			for i := range expected {
+				assert.InDelta(t, expected[i], actual[i], 1e-9, "query %s, k=%d", qStr, k)
+			}
+		}
+	}
+}
@@ -5,22 +5,201 @@
 
 package tsearch
 
-import "strings"
-
-// ValidConfig returns an error if the input string is not a supported and valid
-// text search config.
-func ValidConfig(input string) error {
-	input = GetConfigKey(input)
-	_, err := getStemmer(input)
-	return err
-}
-
-// GetConfigKey returns a config that can be used as a key to look up stemmers
-// and stopwords from an input config value. This is simulating the more
-// advanced customizable dictionaries and configs that Postgres has, which
-// allows user-defined text search configurations: because of this, configs can
-// have schema prefixes. Because we don't (yet?) allow this, we just have to
-// trim off any `pg_catalog.` prefix if it exists.
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GetConfigKey returns the name of a built-in text search configuration from
+// an input config value, which may be qualified with the pg_catalog schema.
 func GetConfigKey(config string) string {
 	return strings.TrimPrefix(config, "pg_catalog.")
 }
+
+// TokenType is the type of a token produced by the text search parser. The
+// values are the token type IDs of the Postgres default parser.
+type TokenType int
+
+// The token types that are produced by TSParse. Our parser only splits the
+// input into words, so the other token types of the Postgres default parser
+// can be used in text search configurations but never show up in documents.
+const (
+	TokenASCIIWord TokenType = 1
+	TokenWord      TokenType = 2
+	TokenNumWord   TokenType = 3
+	TokenUint      TokenType = 19
+)
+
+// tokenTypes lists the alias and description of the token types of the
+// Postgres default parser, in the order of their IDs.
+var tokenTypes = [...]struct{ alias, description string }{
+	{"asciiword", "Word, all ASCII"},
+	{"word", "Word, all letters"},
+	{"numword", "Word, letters and digits"},
+	{"asciihword", "Hyphenated word, all ASCII"},
+	{"hword", "Hyphenated word, all letters"},
+	{"numhword", "Hyphenated word, letters and digits"},
+	{"hword_asciipart", "Hyphenated word part, all ASCII"},
+	{"hword_part", "Hyphenated word part, all letters"},
+	{"hword_numpart", "Hyphenated word part, letters and digits"},
+	{"email", "Email address"},
+	{"protocol", "Protocol head"},
+	{"url", "URL"},
+	{"host", "Host"},
+	{"url_path", "URL path"},
+	{"file", "File or path name"},
+	{"sfloat", "Scientific notation"},
+	{"float", "Decimal notation"},
+	{"int", "Signed integer"},
+	{"uint", "Unsigned integer"},
+	{"version", "Version number"},
+	{"tag", "XML tag"},
+	{"entity", "XML entity"},
+	{"blank", "Space symbols"},
+}
+
+// NumTokenTypes is the number of token types. Token types range from 1 to
+// NumTokenTypes.
+const NumTokenTypes = TokenType(len(tokenTypes))
+
+// Alias returns the name of the token type, which is used to refer to it in
+// ALTER TEXT SEARCH CONFIGURATION.
+func (t TokenType) Alias() string {
+	return tokenTypes[t-1].alias
+}
+
+// Description returns the description of the token type.
+func (t TokenType) Description() string {
+	return tokenTypes[t-1].description
+}
+
+// TokenTypeByAlias returns the token type with the given alias.
+func TokenTypeByAlias(alias string) (TokenType, bool) {
+	for i := range tokenTypes {
+		if tokenTypes[i].alias == alias {
+			return TokenType(i + 1), true
+		}
+	}
+	return 0, false
+}
+
+// ClassifyToken returns the type of a token produced by TSParse.
+func ClassifyToken(token string) TokenType {
+	hasLetter, hasDigit, ascii := false, false, true
+	for _, r := range token {
+		if unicode.IsLetter(r) {
+			hasLetter = true
+		} else {
+			hasDigit = true
+		}
+		if r >= utf8.RuneSelf {
+			ascii = false
+		}
+	}
+	switch {
+	case !hasLetter:
+		return TokenUint
+	case hasDigit:
+		return TokenNumWord
+	case ascii:
+		return TokenASCIIWord
+	default:
+		return TokenWord
+	}
+}
+
+// Config is a text search configuration, which specifies the dictionaries
+// used to normalize each type of token. See
+// https://www.postgresql.org/docs/current/textsearch-configuration.html.
+type Config struct {
+	// Name is the name of the configuration.
+	Name string
+	// mappings holds the dictionaries of each token type, indexed by the token
+	// type. The dictionaries are consulted in order until one of them
+	// recognizes the token.
+	mappings [NumTokenTypes + 1][]*Dictionary
+}
+
+// NewConfig returns a text search configuration without mappings.
+func NewConfig(name string) *Config {
+	return &Config{Name: name}
+}
+
+// SetMapping sets the dictionaries used to normalize tokens of the given type.
+func (c *Config) SetMapping(t TokenType, dicts []*Dictionary) {
+	c.mappings[t] = dicts
+}
+
+// Mapping returns the dictionaries used to normalize tokens of the given type.
+func (c *Config) Mapping(t TokenType) []*Dictionary {
+	return c.mappings[t]
+}
+
+// lexize normalizes the first of the given tokens, which are followed by the
+// rest of the tokens of the document. It returns the lexemes and the number of
+// tokens that were consumed. No lexemes are returned if the token is a stop
+// word or if no dictionary recognizes it.
+func (c *Config) lexize(tokens []string) (lexemes []string, n int) {
+	for _, d := range c.mappings[ClassifyToken(tokens[0])] {
+		lexemes, n, res := d.lexize(tokens)
+		switch res {
+		case recognized:
+			return lexemes, n
+		case filtered:
+			tokens = append([]string{lexemes[0]}, tokens[1:]...)
+		}
+	}
+	return nil, 1
+}
+
+// builtinConfigs are the configurations of the pg_catalog schema, indexed by
+// name. There is one for each snowball language, plus the simple
+// configuration.
+var builtinConfigs = makeBuiltinConfigs()
+
+func makeBuiltinConfigs() map[string]*Config {
+	simple := builtinDictionaries["simple"]
+	configs := map[string]*Config{"simple": NewConfig("simple")}
+	for t := TokenType(1); t <= NumTokenTypes; t++ {
+		switch t.Alias() {
+		case "protocol", "tag", "entity", "blank":
+		default:
+			configs["simple"].SetMapping(t, []*Dictionary{simple})
+		}
+	}
+	for _, language := range snowballLanguages {
+		c := NewConfig(language)
+		stem := builtinDictionaries[language+"_stem"]
+		for t := TokenType(1); t <= NumTokenTypes; t++ {
+			switch t.Alias() {
+			case "asciiword", "word", "asciihword", "hword", "hword_asciipart", "hword_part":
+				c.SetMapping(t, []*Dictionary{stem})
+			case "protocol", "tag", "entity", "blank":
+			default:
+				c.SetMapping(t, []*Dictionary{simple})
+			}
+		}
+		configs[language] = c
+	}
+	return configs
+}
+
+// BuiltinConfig returns the built-in text search configuration with the given
+// name.
+func BuiltinConfig(name string) (*Config, bool) {
+	c, ok := builtinConfigs[name]
+	return c, ok
+}
+
+// BuiltinConfigNames returns the names of the built-in text search
+// configurations, in sorted order.
+func BuiltinConfigNames() []string {
+	names := make([]string, 0, len(builtinConfigs))
+	for name := range builtinConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
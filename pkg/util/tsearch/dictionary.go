@@ -0,0 +1,450 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsearch
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blevesearch/snowballstem"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"golang.org/x/text/unicode/norm"
+)
+
+// This file defines text search dictionaries, which normalize the tokens
+// produced by the parser into lexemes. Like in Postgres, a dictionary is an
+// instance of a template that is configured with options. See
+// https://www.postgresql.org/docs/current/textsearch-dictionaries.html.
+//
+// Given a token, a dictionary can:
+// - recognize it, and return zero or more lexemes. Stop words are recognized
+//   and produce no lexemes.
+// - not recognize it, in which case the token is passed to the next
+//   dictionary of the text search configuration.
+// - filter it, in which case the lexeme it returns replaces the token that is
+//   passed to the next dictionary. The unaccent dictionary works this way.
+
+// The dictionary templates.
+const (
+	// TemplateSimple dictionaries lowercase words and recognize stop words.
+	TemplateSimple = "simple"
+	// TemplateSnowball dictionaries stem words using a snowball stemmer.
+	TemplateSnowball = "snowball"
+	// TemplateSynonym dictionaries replace words with synonyms.
+	TemplateSynonym = "synonym"
+	// TemplateThesaurus dictionaries replace phrases with other phrases.
+	TemplateThesaurus = "thesaurus"
+	// TemplateUnaccent dictionaries remove accents from letters.
+	TemplateUnaccent = "unaccent"
+)
+
+// Templates lists the supported dictionary templates.
+var Templates = []string{
+	TemplateSimple, TemplateSnowball, TemplateSynonym, TemplateThesaurus, TemplateUnaccent,
+}
+
+// DictionaryOption is an option of a text search dictionary, as specified in
+// CREATE TEXT SEARCH DICTIONARY.
+type DictionaryOption struct {
+	Name  string
+	Value string
+}
+
+type lexizeResult int
+
+const (
+	unrecognized lexizeResult = iota
+	recognized
+	filtered
+)
+
+// Dictionary is a text search dictionary.
+type Dictionary struct {
+	// Name is the name of the dictionary.
+	Name string
+	// Template is the template of the dictionary.
+	Template string
+
+	// stopwords is used by simple and snowball dictionaries.
+	stopwords map[string]struct{}
+	// accept is false if a simple dictionary passes the words that are not
+	// stop words to the next dictionary.
+	accept bool
+	// stem is the stemmer of a snowball dictionary.
+	stem func(env *snowballstem.Env) bool
+	// synonyms and caseSensitive are used by synonym dictionaries.
+	synonyms      map[string]string
+	caseSensitive bool
+	// phrases and sub are used by thesaurus dictionaries. sub is the dictionary
+	// used to normalize the words of the phrases and of the input.
+	phrases []thesaurusPhrase
+	sub     *Dictionary
+}
+
+// thesaurusPhrase is a rule of a thesaurus dictionary, which replaces the
+// sample phrase with the substitute phrase. The words of both phrases are
+// normalized by the subdictionary of the thesaurus. Stop words in the sample
+// are represented by empty strings and match any stop word.
+type thesaurusPhrase struct {
+	sample []string
+	subst  []string
+}
+
+// NewDictionary returns a text search dictionary with the given template and
+// options. Thesaurus dictionaries need a DICTIONARY option, which names the
+// subdictionary used to normalize words. It is resolved by the caller and
+// passed as sub.
+func NewDictionary(
+	name string, template string, options []DictionaryOption, sub *Dictionary,
+) (*Dictionary, error) {
+	if !isTemplate(template) {
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "text search template %q does not exist", template)
+	}
+	d := &Dictionary{Name: name, Template: template, accept: true}
+	var language, synonyms, thesaurus string
+	var hasSynonyms, hasThesaurus, hasDictionary bool
+	for _, opt := range options {
+		var err error
+		switch optName := strings.ToLower(opt.Name); {
+		case optName == "stopwords" && (template == TemplateSimple || template == TemplateSnowball):
+			d.stopwords, err = getStopwords(opt.Value)
+		case optName == "accept" && template == TemplateSimple:
+			d.accept, err = parseBoolOption(optName, opt.Value)
+		case optName == "language" && template == TemplateSnowball:
+			language = strings.ToLower(opt.Value)
+		case optName == "synonyms" && template == TemplateSynonym:
+			synonyms, hasSynonyms = opt.Value, true
+		case optName == "casesensitive" && template == TemplateSynonym:
+			d.caseSensitive, err = parseBoolOption(optName, opt.Value)
+		case optName == "thesaurus" && template == TemplateThesaurus:
+			thesaurus, hasThesaurus = opt.Value, true
+		case optName == "dictionary" && template == TemplateThesaurus:
+			hasDictionary = true
+		case optName == "rules" && template == TemplateUnaccent:
+			if opt.Value != "unaccent" {
+				err = pgerror.Newf(pgcode.InvalidParameterValue, "unaccent rules %q do not exist", opt.Value)
+			}
+		default:
+			err = pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized %s dictionary parameter: %q", template, opt.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	switch template {
+	case TemplateSnowball:
+		if language == "" {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "missing Language parameter")
+		}
+		d.stem, err = getStemmer(language)
+	case TemplateSynonym:
+		if !hasSynonyms {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "missing Synonyms parameter")
+		}
+		d.synonyms, err = parseSynonyms(synonyms, d.caseSensitive)
+	case TemplateThesaurus:
+		if !hasThesaurus {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "missing Thesaurus parameter")
+		}
+		if !hasDictionary || sub == nil {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "missing Dictionary parameter")
+		}
+		if sub.Template == TemplateThesaurus {
+			return nil, pgerror.New(pgcode.InvalidParameterValue,
+				"the subdictionary of a thesaurus cannot be a thesaurus")
+		}
+		d.sub = sub
+		d.phrases, err = parseThesaurus(thesaurus, sub)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func isTemplate(template string) bool {
+	for _, t := range Templates {
+		if t == template {
+			return true
+		}
+	}
+	return false
+}
+
+func getStopwords(name string) (map[string]struct{}, error) {
+	stopwords, ok := stopwordsMap[strings.ToLower(name)]
+	if !ok {
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "stop-word list %q does not exist", name)
+	}
+	return stopwords, nil
+}
+
+func parseBoolOption(name string, value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "on", "yes", "1":
+		return true, nil
+	case "false", "off", "no", "0":
+		return false, nil
+	}
+	return false, pgerror.Newf(pgcode.InvalidParameterValue, "%s requires a Boolean value", name)
+}
+
+// parseSynonyms parses the SYNONYMS option of a synonym dictionary, which is
+// a comma-separated list of entries made of a word and its synonym.
+func parseSynonyms(s string, caseSensitive bool) (map[string]string, error) {
+	synonyms := make(map[string]string)
+	for _, entry := range strings.Split(s, ",") {
+		words := strings.Fields(entry)
+		if len(words) == 0 {
+			continue
+		}
+		if len(words) != 2 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid synonym entry %q: expected a word and its synonym", strings.TrimSpace(entry))
+		}
+		word, syn := words[0], words[1]
+		if !caseSensitive {
+			word, syn = strings.ToLower(word), strings.ToLower(syn)
+		}
+		synonyms[word] = syn
+	}
+	return synonyms, nil
+}
+
+// parseThesaurus parses the THESAURUS option of a thesaurus dictionary, which
+// is a comma-separated list of rules of the form "sample words : substitute
+// words". The words are normalized using the subdictionary.
+func parseThesaurus(s string, sub *Dictionary) ([]thesaurusPhrase, error) {
+	var phrases []thesaurusPhrase
+	for _, rule := range strings.Split(s, ",") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+		ruleNum := len(phrases) + 1
+		sample, subst, ok := strings.Cut(rule, ":")
+		if !ok || len(strings.Fields(sample)) == 0 || len(strings.Fields(subst)) == 0 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid thesaurus rule %q: expected sample and substitute phrases separated by a colon",
+				strings.TrimSpace(rule))
+		}
+		var p thesaurusPhrase
+		for _, word := range strings.Fields(sample) {
+			lexemes, _, res := sub.lexize([]string{word})
+			if res != recognized {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"thesaurus sample word %q isn't recognized by subdictionary (rule %d)", word, ruleNum)
+			}
+			// A stop word in the sample matches any stop word.
+			lexeme := ""
+			if len(lexemes) > 0 {
+				lexeme = lexemes[0]
+			}
+			p.sample = append(p.sample, lexeme)
+		}
+		for _, word := range strings.Fields(subst) {
+			lexemes, _, res := sub.lexize([]string{word})
+			if res != recognized {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"thesaurus substitute word %q isn't recognized by subdictionary (rule %d)", word, ruleNum)
+			}
+			if len(lexemes) == 0 {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"thesaurus substitute word %q is a stop word (rule %d)", word, ruleNum)
+			}
+			p.subst = append(p.subst, lexemes...)
+		}
+		phrases = append(phrases, p)
+	}
+	// Try longer phrases first, so that the longest phrase matches.
+	sort.SliceStable(phrases, func(i, j int) bool {
+		return len(phrases[i].sample) > len(phrases[j].sample)
+	})
+	return phrases, nil
+}
+
+// Lexize returns the lexemes that the dictionary produces for a token, like
+// the ts_lexize builtin. It returns false if the dictionary does not recognize
+// the token. Stop words produce no lexemes.
+func (d *Dictionary) Lexize(token string) ([]string, bool) {
+	lexemes, _, res := d.lexize([]string{token})
+	return lexemes, res != unrecognized
+}
+
+// lexize normalizes the first of the given tokens, which are followed by the
+// rest of the tokens of the document so that thesaurus dictionaries can
+// recognize phrases. It returns the lexemes and the number of tokens that were
+// consumed.
+func (d *Dictionary) lexize(tokens []string) (lexemes []string, n int, res lexizeResult) {
+	token := tokens[0]
+	switch d.Template {
+	case TemplateSimple:
+		lower := strings.ToLower(token)
+		if _, ok := d.stopwords[lower]; ok {
+			return nil, 1, recognized
+		}
+		if !d.accept {
+			return nil, 1, unrecognized
+		}
+		return []string{lower}, 1, recognized
+
+	case TemplateSnowball:
+		lower := strings.ToLower(token)
+		if _, ok := d.stopwords[lower]; ok {
+			return nil, 1, recognized
+		}
+		env := snowballstem.NewEnv(lower)
+		d.stem(env)
+		return []string{env.Current()}, 1, recognized
+
+	case TemplateSynonym:
+		if !d.caseSensitive {
+			token = strings.ToLower(token)
+		}
+		if syn, ok := d.synonyms[token]; ok {
+			return []string{syn}, 1, recognized
+		}
+		return nil, 1, unrecognized
+
+	case TemplateThesaurus:
+		return d.lexizePhrase(tokens)
+
+	case TemplateUnaccent:
+		if out := unaccent(token); out != token {
+			return []string{out}, 1, filtered
+		}
+		return nil, 1, unrecognized
+	}
+	return nil, 1, unrecognized
+}
+
+// lexizePhrase looks for the longest phrase of a thesaurus that matches the
+// start of tokens.
+func (d *Dictionary) lexizePhrase(tokens []string) (lexemes []string, n int, res lexizeResult) {
+	// normalized caches the tokens normalized by the subdictionary. ok is false
+	// if the subdictionary doesn't recognize the token.
+	type normalizedToken struct {
+		lexeme string
+		ok     bool
+	}
+	var normalized []normalizedToken
+	normalize := func(i int) normalizedToken {
+		for len(normalized) <= i {
+			l, _, res := d.sub.lexize(tokens[len(normalized):])
+			t := normalizedToken{ok: res == recognized}
+			if len(l) > 0 {
+				t.lexeme = l[0]
+			}
+			normalized = append(normalized, t)
+		}
+		return normalized[i]
+	}
+	for _, p := range d.phrases {
+		if len(p.sample) > len(tokens) {
+			continue
+		}
+		match := true
+		for i, word := range p.sample {
+			if t := normalize(i); !t.ok || t.lexeme != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			return p.subst, len(p.sample), recognized
+		}
+	}
+	return nil, 1, unrecognized
+}
+
+// unaccentLetters are the letters that unaccent replaces with other letters,
+// besides the letters that have combining diacritical marks.
+var unaccentLetters = map[rune]string{
+	'Æ': "AE", 'æ': "ae", 'Œ': "OE", 'œ': "oe", 'ß': "ss", 'Ø': "O", 'ø': "o",
+	'Ł': "L", 'ł': "l", 'Đ': "D", 'đ': "d", 'Ð': "D", 'ð': "d", 'Þ': "TH",
+	'þ': "th", 'ı': "i",
+}
+
+// unaccent removes accents and other diacritical marks from letters.
+func unaccent(s string) string {
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if repl, ok := unaccentLetters[r]; ok {
+			b.WriteString(repl)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return norm.NFC.String(b.String())
+}
+
+// builtinDictionaries are the dictionaries of the pg_catalog schema, indexed
+// by name.
+var builtinDictionaries = makeBuiltinDictionaries()
+
+func makeBuiltinDictionaries() map[string]*Dictionary {
+	dicts := map[string]*Dictionary{
+		"simple": {Name: "simple", Template: TemplateSimple, accept: true},
+	}
+	for _, language := range snowballLanguages {
+		name := language + "_stem"
+		stem, err := getStemmer(language)
+		if err != nil {
+			panic(err)
+		}
+		dicts[name] = &Dictionary{
+			Name:      name,
+			Template:  TemplateSnowball,
+			stopwords: stopwordsMap[language],
+			stem:      stem,
+		}
+	}
+	return dicts
+}
+
+// BuiltinDictionary returns the built-in dictionary with the given name.
+func BuiltinDictionary(name string) (*Dictionary, bool) {
+	d, ok := builtinDictionaries[name]
+	return d, ok
+}
+
+// BuiltinDictionaryNames returns the names of the built-in dictionaries, in
+// sorted order.
+func BuiltinDictionaryNames() []string {
+	names := make([]string, 0, len(builtinDictionaries))
+	for name := range builtinDictionaries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuiltinDictionaryOptions returns the options of a built-in dictionary, as
+// they would be specified in CREATE TEXT SEARCH DICTIONARY.
+func BuiltinDictionaryOptions(name string) []DictionaryOption {
+	if language, ok := strings.CutSuffix(name, "_stem"); ok {
+		return []DictionaryOption{{Name: "language", Value: language}, {Name: "stopwords", Value: language}}
+	}
+	return nil
+}
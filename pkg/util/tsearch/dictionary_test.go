@@ -0,0 +1,169 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsearch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDictionaryLexize(t *testing.T) {
+	englishStem, ok := BuiltinDictionary("english_stem")
+	require.True(t, ok)
+
+	tcs := []struct {
+		template string
+		options  []DictionaryOption
+		token    string
+		expected []string
+		ok       bool
+	}{
+		{TemplateSimple, nil, "Foo", []string{"foo"}, true},
+		{TemplateSimple, []DictionaryOption{{"StopWords", "english"}}, "The", nil, true},
+		{TemplateSimple, []DictionaryOption{{"stopwords", "english"}, {"accept", "false"}}, "foo", nil, false},
+		{TemplateSnowball, []DictionaryOption{{"language", "english"}}, "Running", []string{"run"}, true},
+		{TemplateSnowball, []DictionaryOption{{"language", "english"}}, "the", []string{"the"}, true},
+		{TemplateSnowball, []DictionaryOption{{"language", "english"}, {"stopwords", "english"}}, "the", nil, true},
+		{TemplateSnowball, []DictionaryOption{{"language", "german"}}, "Häuser", []string{"haus"}, true},
+		{TemplateSynonym, []DictionaryOption{{"synonyms", "postgres pgsql, Postgresql pgsql"}}, "PostgreSQL", []string{"pgsql"}, true},
+		{TemplateSynonym, []DictionaryOption{{"synonyms", "postgres pgsql"}}, "mysql", nil, false},
+		{TemplateSynonym, []DictionaryOption{{"synonyms", "Go golang"}, {"casesensitive", "true"}}, "go", nil, false},
+		{TemplateSynonym, []DictionaryOption{{"synonyms", "Go Golang"}, {"casesensitive", "true"}}, "Go", []string{"Golang"}, true},
+		{TemplateUnaccent, nil, "Hôtel", []string{"Hotel"}, true},
+		{TemplateUnaccent, nil, "Straße", []string{"Strasse"}, true},
+		{TemplateUnaccent, nil, "hotel", nil, false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.template+"/"+tc.token, func(t *testing.T) {
+			d, err := NewDictionary("d", tc.template, tc.options, nil /* sub */)
+			require.NoError(t, err)
+			lexemes, ok := d.Lexize(tc.token)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, lexemes)
+		})
+	}
+
+	t.Run("thesaurus", func(t *testing.T) {
+		d, err := NewDictionary("d", TemplateThesaurus, []DictionaryOption{
+			{"dictionary", "english_stem"},
+			{"thesaurus", "supernovae stars : sn, supernovae : sn, crab nebulae : crab, one of the : member"},
+		}, englishStem)
+		require.NoError(t, err)
+		for _, tc := range []struct {
+			tokens   string
+			expected []string
+			n        int
+			res      lexizeResult
+		}{
+			{"supernova stars are bright", []string{"sn"}, 2, recognized},
+			{"supernovae", []string{"sn"}, 1, recognized},
+			{"Crab Nebula", []string{"crab"}, 2, recognized},
+			{"one of a kind", []string{"member"}, 3, recognized},
+			{"crab cakes", nil, 1, unrecognized},
+		} {
+			lexemes, n, res := d.lexize(strings.Fields(tc.tokens))
+			assert.Equal(t, tc.expected, lexemes, tc.tokens)
+			assert.Equal(t, tc.n, n, tc.tokens)
+			assert.Equal(t, tc.res, res, tc.tokens)
+		}
+	})
+}
+
+func TestNewDictionaryErrors(t *testing.T) {
+	simple, ok := BuiltinDictionary("simple")
+	require.True(t, ok)
+	englishStem, ok := BuiltinDictionary("english_stem")
+	require.True(t, ok)
+	thesaurus, err := NewDictionary("t", TemplateThesaurus, []DictionaryOption{
+		{"dictionary", "simple"}, {"thesaurus", "a b : c"},
+	}, simple)
+	require.NoError(t, err)
+
+	tcs := []struct {
+		template string
+		options  []DictionaryOption
+		sub      *Dictionary
+		expected string
+	}{
+		{"ispell", nil, nil, `text search template "ispell" does not exist`},
+		{TemplateSimple, []DictionaryOption{{"language", "english"}}, nil, `unrecognized simple dictionary parameter: "language"`},
+		{TemplateSimple, []DictionaryOption{{"stopwords", "klingon"}}, nil, `stop-word list "klingon" does not exist`},
+		{TemplateSimple, []DictionaryOption{{"accept", "maybe"}}, nil, `accept requires a Boolean value`},
+		{TemplateSnowball, nil, nil, `missing Language parameter`},
+		{TemplateSnowball, []DictionaryOption{{"language", "klingon"}}, nil, `could not find stemmer for language "klingon"`},
+		{TemplateSynonym, nil, nil, `missing Synonyms parameter`},
+		{TemplateSynonym, []DictionaryOption{{"synonyms", "a b c"}}, nil, `invalid synonym entry "a b c"`},
+		{TemplateThesaurus, []DictionaryOption{{"thesaurus", "a : b"}}, nil, `missing Dictionary parameter`},
+		{TemplateThesaurus, []DictionaryOption{{"dictionary", "english_stem"}}, englishStem, `missing Thesaurus parameter`},
+		{TemplateThesaurus, []DictionaryOption{{"dictionary", "t"}, {"thesaurus", "a : b"}}, thesaurus,
+			`the subdictionary of a thesaurus cannot be a thesaurus`},
+		{TemplateThesaurus, []DictionaryOption{{"dictionary", "english_stem"}, {"thesaurus", "a b"}}, englishStem,
+			`invalid thesaurus rule "a b"`},
+		{TemplateThesaurus, []DictionaryOption{{"dictionary", "english_stem"}, {"thesaurus", "star : the"}}, englishStem,
+			`thesaurus substitute word "the" is a stop word (rule 1)`},
+		{TemplateUnaccent, []DictionaryOption{{"rules", "other"}}, nil, `unaccent rules "other" do not exist`},
+	}
+	for _, tc := range tcs {
+		_, err := NewDictionary("d", tc.template, tc.options, tc.sub)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), tc.expected)
+	}
+}
+
+func TestConfigLexize(t *testing.T) {
+	english, ok := BuiltinConfig("english")
+	require.True(t, ok)
+	simple, ok := BuiltinConfig("simple")
+	require.True(t, ok)
+
+	lexizeAll := func(c *Config, input string) []string {
+		var res []string
+		tokens := strings.Fields(input)
+		for i := 0; i < len(tokens); {
+			lexemes, n := c.lexize(tokens[i:])
+			res = append(res, lexemes...)
+			i += n
+		}
+		return res
+	}
+	assert.Equal(t, []string{"run", "dog", "42", "r2d2"}, lexizeAll(english, "The running dogs 42 R2D2"))
+	assert.Equal(t, []string{"the", "running", "dogs"}, lexizeAll(simple, "The running dogs"))
+
+	// A custom configuration that chains a synonym and a stemming dictionary,
+	// with unaccent first.
+	unaccentDict, err := NewDictionary("unaccent", TemplateUnaccent, nil, nil)
+	require.NoError(t, err)
+	synonyms, err := NewDictionary("syn", TemplateSynonym, []DictionaryOption{
+		{"synonyms", "cafe coffee, tee tshirt"},
+	}, nil)
+	require.NoError(t, err)
+	englishStem, _ := BuiltinDictionary("english_stem")
+	c := NewConfig("products")
+	c.SetMapping(TokenASCIIWord, []*Dictionary{synonyms, englishStem})
+	c.SetMapping(TokenWord, []*Dictionary{unaccentDict, synonyms, englishStem})
+	assert.Equal(t, []string{"coffee", "tshirt", "cup"}, lexizeAll(c, "café tee cups 42"))
+}
+
+func TestClassifyToken(t *testing.T) {
+	for _, tc := range []struct {
+		token    string
+		expected string
+	}{
+		{"foo", "asciiword"},
+		{"Crème", "word"},
+		{"abc123", "numword"},
+		{"123", "uint"},
+	} {
+		assert.Equal(t, tc.expected, ClassifyToken(tc.token).Alias(), tc.token)
+	}
+	typ, ok := TokenTypeByAlias("hword_part")
+	require.True(t, ok)
+	assert.Equal(t, TokenType(8), typ)
+	assert.Equal(t, "Hyphenated word part, all letters", typ.Description())
+}
@@ -0,0 +1,749 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsearch
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// This file implements ts_headline, which returns an excerpt of a document
+// with the words that match a query highlighted. The selection of the excerpt
+// follows the prsd_headline function of the Postgres default parser, in
+// wparser_def.c, so that the output is the same as in Postgres for documents
+// that our parser splits into tokens the same way.
+
+// headlineOptions are the options of ts_headline. See
+// https://www.postgresql.org/docs/current/textsearch-controls.html#TEXTSEARCH-HEADLINE.
+type headlineOptions struct {
+	// startSel and stopSel are the strings with which to delimit the query
+	// words appearing in the document.
+	startSel, stopSel string
+	// maxWords and minWords are the longest and shortest headlines to output.
+	maxWords, minWords int
+	// shortWord is the length of the words that are dropped at the start and
+	// end of a headline, unless they are query terms.
+	shortWord int
+	// highlightAll, if true, causes the whole document to be used as the
+	// headline, ignoring the preceding three parameters.
+	highlightAll bool
+	// maxFragments is the maximum number of text fragments to display. If
+	// zero, a single fragment is chosen.
+	maxFragments int
+	// fragmentDelimiter is the string that separates fragments.
+	fragmentDelimiter string
+}
+
+func defaultHeadlineOptions() headlineOptions {
+	return headlineOptions{
+		startSel:          "<b>",
+		stopSel:           "</b>",
+		maxWords:          35,
+		minWords:          15,
+		shortWord:         3,
+		fragmentDelimiter: " ... ",
+	}
+}
+
+// parseHeadlineOptions parses the options argument of ts_headline, which is a
+// comma-separated list of option=value pairs, such as
+// 'MaxWords=10, StartSel="<em class=hl>"'.
+func parseHeadlineOptions(input string) (headlineOptions, error) {
+	opts := defaultHeadlineOptions()
+	pairs, err := parseOptionList(input)
+	if err != nil {
+		return opts, err
+	}
+	for _, pair := range pairs {
+		name, val := pair[0], pair[1]
+		parseInt := func() (int, error) {
+			n, err := strconv.ParseInt(strings.TrimSpace(val), 10, 32)
+			if err != nil {
+				return 0, pgerror.Newf(pgcode.InvalidTextRepresentation,
+					"invalid input syntax for type integer: %q", val)
+			}
+			return int(n), nil
+		}
+		switch strings.ToLower(name) {
+		case "maxwords":
+			opts.maxWords, err = parseInt()
+		case "minwords":
+			opts.minWords, err = parseInt()
+		case "shortword":
+			opts.shortWord, err = parseInt()
+		case "maxfragments":
+			opts.maxFragments, err = parseInt()
+		case "startsel":
+			opts.startSel = val
+		case "stopsel":
+			opts.stopSel = val
+		case "fragmentdelimiter":
+			opts.fragmentDelimiter = val
+		case "highlightall":
+			switch strings.ToLower(val) {
+			case "1", "on", "true", "t", "y", "yes":
+				opts.highlightAll = true
+			default:
+				opts.highlightAll = false
+			}
+		default:
+			return opts, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized headline parameter: %q", name)
+		}
+		if err != nil {
+			return opts, err
+		}
+	}
+	// In HighlightAll mode these parameters are ignored.
+	if !opts.highlightAll {
+		if opts.minWords >= opts.maxWords {
+			return opts, pgerror.New(pgcode.InvalidParameterValue, "MinWords should be less than MaxWords")
+		}
+		if opts.minWords <= 0 {
+			return opts, pgerror.New(pgcode.InvalidParameterValue, "MinWords should be positive")
+		}
+		if opts.shortWord < 0 {
+			return opts, pgerror.New(pgcode.InvalidParameterValue, "ShortWord should be >= 0")
+		}
+		if opts.maxFragments < 0 {
+			return opts, pgerror.New(pgcode.InvalidParameterValue, "MaxFragments should be >= 0")
+		}
+	}
+	return opts, nil
+}
+
+// parseOptionList splits a list of the form 'a=1, b="x y"' into name-value
+// pairs. Values may be quoted with single or double quotes, in which case a
+// doubled quote stands for the quote character itself. It parallels the
+// deserialize_deflist function in Postgres.
+func parseOptionList(input string) ([][2]string, error) {
+	syntaxError := func() error {
+		return pgerror.Newf(pgcode.Syntax, "invalid parameter list format: %q", input)
+	}
+	var ret [][2]string
+	i := 0
+	skipSpace := func() {
+		for i < len(input) && unicode.IsSpace(rune(input[i])) {
+			i++
+		}
+	}
+	for {
+		skipSpace()
+		if i == len(input) {
+			return ret, nil
+		}
+		start := i
+		for i < len(input) && (input[i] == '_' || unicode.IsLetter(rune(input[i])) ||
+			unicode.IsDigit(rune(input[i]))) {
+			i++
+		}
+		if i == start {
+			return nil, syntaxError()
+		}
+		name := input[start:i]
+		skipSpace()
+		if i == len(input) || input[i] != '=' {
+			return nil, syntaxError()
+		}
+		i++
+		skipSpace()
+		if i == len(input) {
+			return nil, syntaxError()
+		}
+		var val strings.Builder
+		if quote := input[i]; quote == '"' || quote == '\'' {
+			i++
+			for {
+				if i == len(input) {
+					return nil, syntaxError()
+				}
+				if input[i] == quote {
+					if i+1 < len(input) && input[i+1] == quote {
+						val.WriteByte(quote)
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				val.WriteByte(input[i])
+				i++
+			}
+		} else {
+			for i < len(input) && input[i] != ',' && !unicode.IsSpace(rune(input[i])) {
+				val.WriteByte(input[i])
+				i++
+			}
+		}
+		ret = append(ret, [2]string{name, val.String()})
+		skipSpace()
+		if i == len(input) {
+			return ret, nil
+		}
+		if input[i] != ',' {
+			return nil, syntaxError()
+		}
+		i++
+	}
+}
+
+// hlWord is a token of a document that is being highlighted. Unlike TSParse,
+// the headline parser keeps the text between words, as separator tokens, so
+// that the document can be reproduced.
+type hlWord struct {
+	text string
+	// tokenType is the type of a word token, or zero for a separator.
+	tokenType TokenType
+	// pos is the position of a word in the document, as in the output of
+	// to_tsvector.
+	pos int
+	// lexemes are the lexemes that the word was normalized to.
+	lexemes []string
+	// matched is true if one of the lexemes matches a term of the query.
+	matched bool
+	// in is true if the word is part of the headline.
+	in bool
+	// selected is true if the word is highlighted in the headline.
+	selected bool
+}
+
+// isSpace returns whether the token isn't a word. It parallels the
+// NONWORDTOKEN macro in Postgres.
+func (w *hlWord) isSpace() bool {
+	return w.tokenType == 0
+}
+
+// noEnd returns whether the token type is one that should not be at the end
+// of a headline. It parallels the NOENDTOKEN macro in Postgres.
+func (w *hlWord) noEnd() bool {
+	return w.isSpace() || w.tokenType == TokenUint
+}
+
+type headline struct {
+	opts  headlineOptions
+	words []hlWord
+	// query is the query to highlight, with the weights of its terms removed,
+	// since those are ignored for highlighting.
+	query TSQuery
+	// maxCover is the maximum number of tokens in a cover.
+	maxCover int
+}
+
+// Headline implements the ts_headline builtin. It returns an excerpt of the
+// document with the words that match the query highlighted, as controlled by
+// the given options string.
+func Headline(config *Config, document string, q TSQuery, options string) (string, error) {
+	opts, err := parseHeadlineOptions(options)
+	if err != nil {
+		return "", err
+	}
+	h := headline{
+		opts:     opts,
+		words:    parseHeadlineWords(config, document, q),
+		query:    TSQuery{root: withoutWeights(q.root)},
+		maxCover: opts.maxWords * 10,
+	}
+	if h.maxCover < 100 {
+		h.maxCover = 100
+	}
+	if len(h.words) == 0 {
+		return "", nil
+	}
+	if opts.maxFragments == 0 {
+		err = h.markWords()
+	} else {
+		err = h.markFragments()
+	}
+	if err != nil {
+		return "", err
+	}
+	return h.generate(), nil
+}
+
+// parseHeadlineWords splits the document into word and separator tokens and
+// normalizes the words, marking the ones that match a term of the query.
+func parseHeadlineWords(config *Config, document string, q TSQuery) []hlWord {
+	var words []hlWord
+	var wordIdxs []int
+	start := 0
+	inWord := false
+	for i, r := range document {
+		isWordChar := unicode.IsOneOf(validCharTables, r)
+		if i > 0 && isWordChar != inWord {
+			words = append(words, hlWord{text: document[start:i]})
+			if inWord {
+				wordIdxs = append(wordIdxs, len(words)-1)
+			}
+			start = i
+		}
+		inWord = isWordChar
+	}
+	if start < len(document) {
+		words = append(words, hlWord{text: document[start:]})
+		if inWord {
+			wordIdxs = append(wordIdxs, len(words)-1)
+		}
+	}
+
+	var queryLeaves []*tsNode
+	if q.root != nil {
+		queryLeaves = sortAndDistinctQueryTerms(q)
+	}
+	tokens := make([]string, len(wordIdxs))
+	for i, idx := range wordIdxs {
+		words[idx].tokenType = ClassifyToken(words[idx].text)
+		tokens[i] = words[idx].text
+	}
+	for i := 0; i < len(tokens); {
+		lexemes, n := config.lexize(tokens[i:])
+		pos := i + 1
+		if i > maxTSVectorPosition {
+			pos = maxTSVectorPosition
+		}
+		matched := false
+		for _, lexeme := range lexemes {
+			for _, leaf := range queryLeaves {
+				if leafMatchesLexeme(leaf, lexeme) {
+					matched = true
+				}
+			}
+		}
+		for _, idx := range wordIdxs[i : i+n] {
+			words[idx].pos = pos
+			words[idx].lexemes = lexemes
+			words[idx].matched = matched
+		}
+		i += n
+	}
+	return words
+}
+
+// withoutWeights returns a copy of the query tree rooted at the given node in
+// which the terms don't have weights. Prefix matching is kept.
+func withoutWeights(n *tsNode) *tsNode {
+	if n == nil {
+		return nil
+	}
+	ret := *n
+	if n.op == invalid {
+		ret.term.positions = nil
+		if n.term.isPrefixMatch() {
+			ret.term.positions = []tsPosition{{weight: weightStar}}
+		}
+		return &ret
+	}
+	ret.l = withoutWeights(n.l)
+	ret.r = withoutWeights(n.r)
+	return &ret
+}
+
+// badEndpoint returns whether the word at index i should not be the first or
+// last word of a headline.
+func (h *headline) badEndpoint(i int) bool {
+	w := &h.words[i]
+	return (w.noEnd() || len(w.text) <= h.opts.shortWord) && !w.matched
+}
+
+// firstMatch returns the index of the first matching word at or after the
+// given index, or -1 if there is none.
+func (h *headline) firstMatch(i int) int {
+	for ; i < len(h.words); i++ {
+		if h.words[i].matched {
+			return i
+		}
+	}
+	return -1
+}
+
+// matches returns whether the words between the given indexes, inclusive,
+// satisfy the query.
+func (h *headline) matches(pmin, pmax int) (bool, error) {
+	var v TSVector
+	for i := pmin; i <= pmax; i++ {
+		w := &h.words[i]
+		if !w.matched {
+			continue
+		}
+		for _, lexeme := range w.lexemes {
+			v = append(v, tsTerm{lexeme: lexeme, positions: []tsPosition{{position: uint16(w.pos)}}})
+		}
+	}
+	v, err := normalizeTSVector(v)
+	if err != nil {
+		return false, err
+	}
+	return EvalTSQuery(h.query, v)
+}
+
+// cover finds the earliest, shortest sequence of words starting at or after
+// index p that satisfies the query. If one is found, its first and last
+// indexes are stored in p and q. It parallels the hlCover function in
+// Postgres.
+func (h *headline) cover(p, q *int) (bool, error) {
+	if h.query.root == nil {
+		return false, nil
+	}
+	// Both ends of a cover must be words that match a query term, so there is
+	// no point in trying other ends.
+	pmin := h.firstMatch(*p)
+	for pmin >= 0 {
+		nextPmin := -1
+		pmax := pmin
+		for pmax >= 0 && pmax-pmin < h.maxCover {
+			ok, err := h.matches(pmin, pmax)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				*p, *q = pmin, pmax
+				return true, nil
+			}
+			nextPmax := h.firstMatch(pmax + 1)
+			// The first end after pmin is also the next start to try.
+			if pmax == pmin {
+				nextPmin = nextPmax
+			}
+			pmax = nextPmax
+		}
+		pmin = nextPmin
+	}
+	return false, nil
+}
+
+// markFragment marks the words between the given indexes, inclusive, as part
+// of the headline.
+func (h *headline) markFragment(start, end int) {
+	for i := start; i <= end; i++ {
+		w := &h.words[i]
+		if w.matched {
+			w.selected = true
+		}
+		w.in = true
+	}
+}
+
+// markWords selects the headline when MaxFragments is zero. Among the covers
+// of the query, it prefers headlines that include a whole cover, then ones
+// with more query words, then ones that don't start or end with a short word.
+// It parallels the mark_hl_words function in Postgres.
+func (h *headline) markWords() error {
+	words := h.words
+	maxWords, minWords := h.opts.maxWords, h.opts.minWords
+	bestb, beste := -1, -1
+	bestlen := -1
+	bestcover := false
+	if h.opts.highlightAll {
+		h.markFragment(0, len(words)-1)
+		return nil
+	}
+	for p, q := 0, 0; ; p++ {
+		found, err := h.cover(&p, &q)
+		if err != nil {
+			return err
+		}
+		if !found {
+			break
+		}
+		// Count the words and the query words within the cover, but stop once
+		// max words are reached.
+		curlen, poslen := 0, 0
+		posb, pose := p, p
+		i := p
+		for ; i <= q && curlen < maxWords; i++ {
+			if !words[i].isSpace() {
+				curlen++
+			}
+			if words[i].matched {
+				poslen++
+			}
+			pose = i
+		}
+		if curlen < maxWords {
+			// There is room to lengthen the headline, so search forward until
+			// it's full or a good stopping point is found.
+			for i = i - 1; i < len(words) && curlen < maxWords; i++ {
+				if i > q {
+					if !words[i].isSpace() {
+						curlen++
+					}
+					if words[i].matched {
+						poslen++
+					}
+				}
+				pose = i
+				if h.badEndpoint(i) {
+					continue
+				}
+				if curlen >= minWords {
+					break
+				}
+			}
+			if curlen < minWords {
+				// The end of the document was reached and the headline is still
+				// shorter than min words, so try to extend it to the left.
+				for i = p - 1; i >= 0; i-- {
+					if !words[i].isSpace() {
+						curlen++
+					}
+					if words[i].matched {
+						poslen++
+					}
+					if curlen >= maxWords {
+						break
+					}
+					if h.badEndpoint(i) {
+						continue
+					}
+					if curlen >= minWords {
+						break
+					}
+				}
+				posb = i
+				if posb < 0 {
+					posb = 0
+				}
+			}
+		} else {
+			// The headline can't be made longer, so consider making it shorter
+			// to avoid a bad endpoint.
+			if i > q {
+				i = q
+			}
+			for ; curlen > minWords; i-- {
+				if !h.badEndpoint(i) {
+					break
+				}
+				if !words[i].isSpace() {
+					curlen--
+				}
+				if words[i].matched {
+					poslen--
+				}
+				pose = i - 1
+			}
+		}
+		// The headline might not include the whole cover if it was trimmed
+		// due to max words.
+		poscover := posb <= p && pose >= q
+		if (poscover && !bestcover) ||
+			(poscover == bestcover && poslen > bestlen) ||
+			(poscover == bestcover && poslen == bestlen && !h.badEndpoint(pose) && h.badEndpoint(beste)) {
+			bestb, beste = posb, pose
+			bestlen = poslen
+			bestcover = poscover
+		}
+	}
+	// If nothing was found, select min words words from the beginning.
+	if bestlen < 0 {
+		curlen, pose := 0, 0
+		for i := 0; i < len(words) && curlen < minWords; i++ {
+			if !words[i].isSpace() {
+				curlen++
+			}
+			pose = i
+		}
+		bestb, beste = 0, pose
+	}
+	h.markFragment(bestb, beste)
+	return nil
+}
+
+// fragment is a candidate fragment of a headline, made of part of a cover.
+type fragment struct {
+	start, end int
+	// curlen and poslen are the numbers of words and of query words in the
+	// fragment.
+	curlen, poslen int
+	chosen         bool
+	excluded       bool
+}
+
+// nextFragment shrinks the range between start and end, which is part of a
+// cover, so that it has at most max words and both of its ends are query
+// words. It parallels the get_next_fragment function in Postgres.
+func (h *headline) nextFragment(start, end int) fragment {
+	words := h.words
+	// First, move the start to a query word.
+	for i := start; i <= end; i++ {
+		start = i
+		if words[i].matched {
+			break
+		}
+	}
+	// Then cut the end to have only max words.
+	f := fragment{start: start, end: end}
+	i := start
+	for ; i <= end && f.curlen < h.opts.maxWords; i++ {
+		if !words[i].isSpace() {
+			f.curlen++
+		}
+		if words[i].matched {
+			f.poslen++
+		}
+	}
+	// If the cover was cut, move the end back to a query word.
+	if end > i {
+		f.end = i
+		for i = f.end; i >= f.start; i-- {
+			f.end = i
+			if words[i].matched {
+				break
+			}
+			if !words[i].isSpace() {
+				f.curlen--
+			}
+		}
+	}
+	return f
+}
+
+// markFragments selects up to MaxFragments fragments for the headline,
+// preferring the ones with the most query words and, among those, the
+// shortest ones. It parallels the mark_hl_fragments function in Postgres.
+func (h *headline) markFragments() error {
+	words := h.words
+	maxWords := h.opts.maxWords
+	var fragments []fragment
+	for p, q := 0, 0; ; p++ {
+		found, err := h.cover(&p, &q)
+		if err != nil {
+			return err
+		}
+		if !found {
+			break
+		}
+		// Break the cover into smaller fragments that have at most max words
+		// and that start and end with query words, which allows them to be
+		// stretched in either direction.
+		for start := p; start <= q; {
+			f := h.nextFragment(start, q)
+			fragments = append(fragments, f)
+			start = f.end + 1
+		}
+	}
+
+	numChosen := 0
+	for n := 0; n < h.opts.maxFragments; n++ {
+		// Choose the fragment with the most query words, breaking ties in
+		// favor of the one with fewer words.
+		maxItems, minWords := 0, math.MaxInt32
+		best := -1
+		for i := range fragments {
+			f := &fragments[i]
+			if !f.chosen && !f.excluded &&
+				(maxItems < f.poslen || (maxItems == f.poslen && minWords > f.curlen)) {
+				maxItems, minWords = f.poslen, f.curlen
+				best = i
+			}
+		}
+		if best < 0 {
+			// No selectable fragments remain.
+			break
+		}
+		f := &fragments[best]
+		f.chosen = true
+		start, end, curlen := f.start, f.end, f.curlen
+		// Stretch the fragment if it's shorter than max words, dividing the
+		// stretch between both sides.
+		if curlen < maxWords {
+			maxStretch := (maxWords - curlen) / 2
+			// First, stretch the start, stopping at the beginning of the
+			// document, at max stretch, or at an already chosen fragment.
+			stretch := 0
+			marker := start
+			for i := start - 1; i >= 0 && stretch < maxStretch && !words[i].in; i-- {
+				if !words[i].isSpace() {
+					curlen++
+					stretch++
+				}
+				marker = i
+			}
+			// Cut back the start until a good endpoint is found.
+			i := marker
+			for ; i < start && h.badEndpoint(i); i++ {
+				if !words[i].isSpace() {
+					curlen--
+				}
+			}
+			start = i
+			// Now stretch the end as much as possible.
+			marker = end
+			for i = end + 1; i < len(words) && curlen < maxWords && !words[i].in; i++ {
+				if !words[i].isSpace() {
+					curlen++
+				}
+				marker = i
+			}
+			// Cut back the end until a good endpoint is found.
+			for i = marker; i > end && h.badEndpoint(i); i-- {
+				if !words[i].isSpace() {
+					curlen--
+				}
+			}
+			end = i
+		}
+		f.start, f.end, f.curlen = start, end, curlen
+		h.markFragment(start, end)
+		numChosen++
+		// Exclude the fragments that overlap this one from consideration.
+		for i := range fragments {
+			o := &fragments[i]
+			if i != best && ((o.start >= start && o.start <= end) ||
+				(o.end >= start && o.end <= end) ||
+				(o.start < start && o.end > end)) {
+				o.excluded = true
+			}
+		}
+	}
+
+	// Show the first min words words if nothing was chosen.
+	if numChosen == 0 {
+		curlen, end := 0, -1
+		for i := 0; i < len(words) && curlen < h.opts.minWords; i++ {
+			if !words[i].isSpace() {
+				curlen++
+			}
+			end = i
+		}
+		h.markFragment(0, end)
+	}
+	return nil
+}
+
+// generate returns the text of the headline, made of the marked words.
+func (h *headline) generate() string {
+	var buf strings.Builder
+	inFragment := false
+	numFragments := 0
+	for i := range h.words {
+		w := &h.words[i]
+		if !w.in {
+			inFragment = false
+			continue
+		}
+		if !inFragment {
+			// This is the start of a new fragment.
+			inFragment = true
+			numFragments++
+			if numFragments > 1 {
+				buf.WriteString(h.opts.fragmentDelimiter)
+			}
+		}
+		if w.selected {
+			buf.WriteString(h.opts.startSel)
+		}
+		buf.WriteString(w.text)
+		if w.selected {
+			buf.WriteString(h.opts.stopSel)
+		}
+	}
+	return buf.String()
+}
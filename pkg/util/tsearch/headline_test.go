@@ -0,0 +1,84 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadline(t *testing.T) {
+	english, ok := BuiltinConfig("english")
+	require.True(t, ok)
+	const doc = `The most common type of search is to find all documents containing given query terms and return them in order of their similarity to the query.`
+	for _, tc := range []struct {
+		doc      string
+		query    string
+		options  string
+		expected string
+	}{
+		{
+			doc:      doc,
+			query:    `query & similarity`,
+			expected: `containing given <b>query</b> terms and return them in order of their <b>similarity</b> to the <b>query</b>.`,
+		},
+		{
+			doc:      doc,
+			query:    `search & term`,
+			options:  `MaxFragments=10, MaxWords=7, MinWords=3, StartSel=<<, StopSel=>>`,
+			expected: `common type of <<search>> is to find ... containing given query <<terms>> and return them`,
+		},
+		{
+			doc:      `a b c d`,
+			query:    `c`,
+			options:  `HighlightAll=true, StartSel="<em class=""x"">", StopSel=</em>`,
+			expected: `a b <em class="x">c</em> d`,
+		},
+		{
+			doc:      `nothing to see here`,
+			query:    `missing`,
+			options:  `MinWords=2, MaxWords=3`,
+			expected: `nothing to`,
+		},
+		{
+			doc:      ``,
+			query:    `missing`,
+			expected: ``,
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := ToTSQuery(english, tc.query)
+			require.NoError(t, err)
+			actual, err := Headline(english, tc.doc, q, tc.options)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestHeadlineOptionsError(t *testing.T) {
+	for _, tc := range []struct {
+		options  string
+		expected string
+	}{
+		{`MaxWords`, `invalid parameter list format`},
+		{`MaxWords=`, `invalid parameter list format`},
+		{`MaxWords=1 2`, `invalid parameter list format`},
+		{`StartSel="<b>`, `invalid parameter list format`},
+		{`Foo=1`, `unrecognized headline parameter: "Foo"`},
+		{`MaxWords=x`, `invalid input syntax for type integer: "x"`},
+		{`MaxWords=10, MinWords=10`, `MinWords should be less than MaxWords`},
+		{`MinWords=0`, `MinWords should be positive`},
+		{`ShortWord=-1`, `ShortWord should be >= 0`},
+		{`MaxFragments=-1`, `MaxFragments should be >= 0`},
+	} {
+		_, err := parseHeadlineOptions(tc.options)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), tc.expected)
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"math"
 	"sort"
 	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 )
 
 // defaultWeights is the default list of weights corresponding to the tsvector
@@ -20,12 +23,8 @@ var defaultWeights = [4]float32{0.1, 0.2, 0.4, 1.0}
 // 0, the default, ignores the document length.
 // 1 devides the rank by 1 + the logarithm of the document length.
 // 2 divides the rank by the document length.
-// 4 divides the rank by the mean harmonic distance between extents.
-//
-//	NOTE: This is only implemented by ts_rank_cd, which is currently not
-//	implemented by CockroachDB. This constant is left for consistency with
-//	the original PostgreSQL source code.
-//
+// 4 divides the rank by the mean harmonic distance between extents. This is
+// only implemented by ts_rank_cd.
 // 8 divides the rank by the number of unique words in document.
 // 16 divides the rank by 1 + the logarithm of the number of unique words in document.
 // 32 divides the rank by itself + 1.
@@ -39,8 +38,7 @@ const (
 	// rankNormLength divides the rank by the document length.
 	rankNormLength = 0x02
 	// rankNormExtdist divides the rank by the mean harmonic distance between extents.
-	// Note, this is only implemented by ts_rank_cd, which is not currently implemented
-	// by CockroachDB. The constant is kept for consistency with Postgres.
+	// It is only used by ts_rank_cd.
 	rankNormExtdist = 0x04
 	// rankNormUniq divides the rank by the number of unique words in document.
 	rankNormUniq = 0x08
@@ -52,7 +50,6 @@ const (
 
 // Defeat the unused linter.
 var _ = rankNoNorm
-var _ = rankNormExtdist
 
 // cntLen returns the count of represented lexemes in a tsvector, including
 // the number of repeated lexemes in the vector.
@@ -279,3 +276,239 @@ func wordDistance(dist int) float32 {
 	}
 	return float32(1.0 / (1.005 + 0.05*math.Exp(float64(float32(dist)/1.5-2))))
 }
+
+// docEntry is an occurrence of a lexeme that matches a query term, used by
+// RankCD. It corresponds to the DocRepresentation struct in tsrank.c.
+type docEntry struct {
+	lexeme string
+	pos    tsPosition
+}
+
+// coverExt is an extent of a document that satisfies a query, which is called
+// a cover. It corresponds to the CoverExt struct in tsrank.c.
+type coverExt struct {
+	// p and q are the first and last positions of the cover.
+	p, q int
+	// begin and end are the indexes of the first and last entries of the cover.
+	begin, end int
+	// next is the index of the entry at which the search for the next cover
+	// starts.
+	next int
+}
+
+// RankCD implements the ts_rank_cd functionality, which ranks a tsvector
+// against a tsquery using the cover density ranking method described in
+// "Relevance ranking for one to three term queries" by Clarke, Cormack and
+// Tudhope. The shorter the extents of the document that satisfy the query and
+// the more of them there are, the higher the rank. The weights and method
+// parameters are the same as for Rank. Lexemes without positions are ignored,
+// so RankCD returns 0 for stripped tsvectors.
+//
+// This function is translated from the calc_rank_cd function in tsrank.c.
+func RankCD(weights []float32, v TSVector, q TSQuery, method int) (float32, error) {
+	var invWeights [4]float64
+	for i := range invWeights {
+		w := defaultWeights[i]
+		if weights != nil && weights[i] >= 0 {
+			w = weights[i]
+		}
+		if w > 1.0 {
+			return 0, pgerror.New(pgcode.InvalidParameterValue, "weight out of range")
+		}
+		invWeights[i] = 1.0 / float64(w)
+	}
+	if len(v) == 0 || q.root == nil {
+		return 0, nil
+	}
+	doc := makeDocRepresentation(v, q)
+	if len(doc) == 0 {
+		return 0, nil
+	}
+
+	var wDoc, sumDist, prevExtPos float64
+	var nExtent int
+	var ext coverExt
+	for {
+		found, err := findCover(doc, q, &ext)
+		if err != nil {
+			return 0, err
+		}
+		if !found {
+			break
+		}
+		var invSum float64
+		for _, e := range doc[ext.begin : ext.end+1] {
+			invSum += invWeights[e.pos.weight.val()]
+		}
+		cPos := float64(ext.end-ext.begin+1) / invSum
+
+		// If the document is big enough, ext.q may be equal to ext.p due to the
+		// limit of positional information. In this case, we approximate the
+		// number of noise words as half of the cover's length.
+		nNoise := (ext.q - ext.p) - (ext.end - ext.begin)
+		if nNoise < 0 {
+			nNoise = (ext.end - ext.begin) / 2
+		}
+		wDoc += cPos / float64(1+nNoise)
+
+		curExtPos := float64(ext.q+ext.p) / 2
+		// Guard against division by zero when a position has several lexemes.
+		if nExtent > 0 && curExtPos > prevExtPos {
+			sumDist += 1.0 / (curExtPos - prevExtPos)
+		}
+		prevExtPos = curExtPos
+		nExtent++
+	}
+
+	if method&rankNormLoglength > 0 {
+		wDoc /= math.Log(float64(cntLen(v) + 1))
+	}
+	if method&rankNormLength > 0 {
+		l := cntLen(v)
+		if l > 0 {
+			wDoc /= float64(l)
+		}
+	}
+	if method&rankNormExtdist > 0 && nExtent > 0 && sumDist > 0 {
+		wDoc /= float64(nExtent) / sumDist
+	}
+	if method&rankNormUniq > 0 {
+		wDoc /= float64(len(v))
+	}
+	if method&rankNormLoguniq > 0 {
+		wDoc /= math.Log(float64(len(v)+1)) / math.Log(2.0)
+	}
+	if method&rankNormRdivrplus1 > 0 {
+		wDoc /= wDoc + 1
+	}
+	return float32(wDoc), nil
+}
+
+// makeDocRepresentation returns the occurrences of the lexemes of a tsvector
+// that match a term of the query, sorted by position. Occurrences whose weight
+// doesn't match the weight of any of the matching query terms are left out.
+func makeDocRepresentation(v TSVector, q TSQuery) []docEntry {
+	queryLeaves := sortAndDistinctQueryTerms(q)
+	var doc []docEntry
+	for i := range v {
+		t := &v[i]
+		for _, pos := range t.positions {
+			for _, leaf := range queryLeaves {
+				if !leafMatchesLexeme(leaf, t.lexeme) {
+					continue
+				}
+				targetWeight := weightAny
+				if len(leaf.term.positions) > 0 {
+					if w := leaf.term.positions[0].weight &^ weightStar; w != 0 {
+						targetWeight = w
+					}
+				}
+				if pos.weight.matches(targetWeight) {
+					doc = append(doc, docEntry{lexeme: t.lexeme, pos: pos})
+					break
+				}
+			}
+		}
+	}
+	sort.Slice(doc, func(i, j int) bool {
+		if doc[i].pos.position != doc[j].pos.position {
+			return doc[i].pos.position < doc[j].pos.position
+		}
+		if doc[i].pos.weight != doc[j].pos.weight {
+			return doc[i].pos.weight < doc[j].pos.weight
+		}
+		return doc[i].lexeme < doc[j].lexeme
+	})
+	return doc
+}
+
+// leafMatchesLexeme returns whether the query term of the given leaf node
+// matches the lexeme, ignoring weights.
+func leafMatchesLexeme(leaf *tsNode, lexeme string) bool {
+	if leaf.term.isPrefixMatch() {
+		return strings.HasPrefix(lexeme, leaf.term.lexeme)
+	}
+	return leaf.term.lexeme == lexeme
+}
+
+// findCover finds the next cover of the document, starting at the entry with
+// index ext.next, and stores it in ext. It returns false if there are no more
+// covers. The upper bound of the cover is the first entry at which the
+// entries seen so far satisfy the query. The lower bound is found by going
+// back from the upper bound until the query is satisfied again.
+func findCover(doc []docEntry, q TSQuery, ext *coverExt) (bool, error) {
+	var w coverVector
+	for ; ext.next < len(doc); ext.next++ {
+		ext.p, ext.q = math.MaxInt, 0
+		w.reset()
+		upper := -1
+		for i := ext.next; i < len(doc); i++ {
+			w.add(doc[i])
+			ok, err := EvalTSQuery(q, w.v)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				ext.q = int(doc[i].pos.position)
+				ext.end = i
+				upper = i
+				break
+			}
+		}
+		if upper < 0 {
+			return false, nil
+		}
+		w.reset()
+		for i := upper; i >= ext.next; i-- {
+			w.add(doc[i])
+			ok, err := EvalTSQuery(q, w.v)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				if p := int(doc[i].pos.position); p < ext.p {
+					ext.p = p
+					ext.begin = i
+				}
+				if ext.p <= ext.q {
+					// The search for the next cover starts after the beginning
+					// of this one.
+					ext.next = i + 1
+					return true, nil
+				}
+				break
+			}
+		}
+	}
+	return false, nil
+}
+
+// coverVector is a TSVector built from a set of document entries, against
+// which a query is evaluated to find covers.
+type coverVector struct {
+	v TSVector
+}
+
+func (c *coverVector) reset() {
+	c.v = c.v[:0]
+}
+
+// add adds a document entry to the vector, keeping both the lexemes and their
+// positions sorted.
+func (c *coverVector) add(e docEntry) {
+	i := sort.Search(len(c.v), func(i int) bool {
+		return c.v[i].lexeme >= e.lexeme
+	})
+	if i == len(c.v) || c.v[i].lexeme != e.lexeme {
+		c.v = append(c.v, tsTerm{})
+		copy(c.v[i+1:], c.v[i:])
+		c.v[i] = tsTerm{lexeme: e.lexeme}
+	}
+	t := &c.v[i]
+	j := sort.Search(len(t.positions), func(j int) bool {
+		return t.positions[j].position >= e.pos.position
+	})
+	t.positions = append(t.positions, tsPosition{})
+	copy(t.positions[j+1:], t.positions[j:])
+	t.positions[j] = e.pos
+}
@@ -0,0 +1,446 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsearch
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// This file implements term frequency ranking of tsvectors, along with a top-K
+// search over posting lists that uses the WAND algorithm to skip documents
+// which can't make it into the top K. See "Efficient Query Evaluation using a
+// Two-Level Retrieval Process" by Broder et al.
+//
+// Like the other ranking functions, term frequency ranking only uses
+// information from the document that's being ranked. The score of a document
+// is the sum over the distinct lexemes of the query which appear in the
+// document of:
+//
+//	tf * (k1 + 1) / (tf + k1 * (1 - b + b * dl / avgdl))
+//
+// where tf is the number of occurrences of the lexeme in the document, dl is
+// the length of the document, and avgdl is the average document length. This
+// is the term frequency component of BM25. Since there are no statistics about
+// the whole collection of documents, there is no inverse document frequency
+// component, so this is not BM25: every lexeme of the query has the same
+// weight, however common it is. See bm25.go for BM25 ranking, which takes the
+// statistics of the collection from an inverted index.
+
+// TFRankParams are the free parameters of term frequency ranking.
+type TFRankParams struct {
+	// K1 controls how quickly the score of a lexeme saturates as its number of
+	// occurrences increases.
+	K1 float64
+	// B controls how much the score is normalized by the document length. It
+	// is ignored if AvgDocLength is 0.
+	B float64
+	// AvgDocLength is the average length of the ranked documents.
+	AvgDocLength float64
+}
+
+// DefaultTFRankParams are the parameters used when only the average document
+// length is given.
+var DefaultTFRankParams = TFRankParams{K1: 1.2, B: 0.75}
+
+// Validate returns an error if the parameters are out of range.
+func (p TFRankParams) Validate() error {
+	if p.K1 < 0 {
+		return pgerror.Newf(pgcode.InvalidParameterValue, "k1 must be non-negative")
+	}
+	if p.B < 0 || p.B > 1 {
+		return pgerror.Newf(pgcode.InvalidParameterValue, "b must be between 0 and 1")
+	}
+	if p.AvgDocLength < 0 {
+		return pgerror.Newf(pgcode.InvalidParameterValue, "average document length must be non-negative")
+	}
+	return nil
+}
+
+// termScore returns the contribution to the score of a lexeme which occurs tf
+// times in a document of length dl.
+func (p TFRankParams) termScore(tf, dl int) float64 {
+	norm := 1.0
+	if p.AvgDocLength > 0 {
+		norm = 1 - p.B + p.B*float64(dl)/p.AvgDocLength
+	}
+	return float64(tf) * (p.K1 + 1) / (float64(tf) + p.K1*norm)
+}
+
+// TermScore implements the TermScorer interface. Every lexeme has the same
+// weight.
+func (p TFRankParams) TermScore(_ int, tf, dl int) float64 {
+	return p.termScore(tf, dl)
+}
+
+// MaxTermScore implements the TermScorer interface.
+func (p TFRankParams) MaxTermScore(int) float64 {
+	return p.K1 + 1
+}
+
+// termFrequency returns the number of occurrences of a term in a tsvector. A
+// lexeme without positions counts as a single occurrence.
+func termFrequency(t *tsTerm) int {
+	if len(t.positions) == 0 {
+		return 1
+	}
+	return len(t.positions)
+}
+
+// TermStatistics returns the frequency of each lexeme of the tsvector, in the
+// same order as the lexemes, along with the length of the document. These are
+// the statistics that inverted indexes store when they are created with the
+// term_statistics storage parameter.
+func TermStatistics(v TSVector) (tfs []int, dl int) {
+	tfs = make([]int, len(v))
+	for i := range v {
+		tfs[i] = termFrequency(&v[i])
+	}
+	return tfs, cntLen(v)
+}
+
+// EncodeTermStatistics appends the frequency of a lexeme and the length of its
+// document to the value of an inverted index entry.
+func EncodeTermStatistics(appendTo []byte, tf, dl int) []byte {
+	appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(tf))
+	return encoding.EncodeUvarintAscending(appendTo, uint64(dl))
+}
+
+// DecodeTermStatistics decodes the statistics encoded by EncodeTermStatistics.
+func DecodeTermStatistics(b []byte) (tf, dl int, err error) {
+	b, tf64, err := encoding.DecodeUvarintAscending(b)
+	if err != nil {
+		return 0, 0, err
+	}
+	_, dl64, err := encoding.DecodeUvarintAscending(b)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(tf64), int(dl64), nil
+}
+
+// positiveQueryTerms returns the leaves of the query which aren't negated,
+// sorted and de-duplicated by lexeme.
+func positiveQueryTerms(q TSQuery) []*tsNode {
+	var leaves []*tsNode
+	var walk func(n *tsNode)
+	walk = func(n *tsNode) {
+		switch n.op {
+		case invalid:
+			leaves = append(leaves, n)
+		case not:
+		default:
+			walk(n.l)
+			walk(n.r)
+		}
+	}
+	if q.root != nil {
+		walk(q.root)
+	}
+	sort.SliceStable(leaves, func(i, j int) bool {
+		return leaves[i].term.lexeme < leaves[j].term.lexeme
+	})
+	ret := leaves[:0]
+	for i := range leaves {
+		if i == 0 || leaves[i].term.lexeme != leaves[i-1].term.lexeme {
+			ret = append(ret, leaves[i])
+		}
+	}
+	return ret
+}
+
+// RankTF implements the ts_rank_tf builtin, which ranks a tsvector against a
+// tsquery by the frequency of the query lexemes. Only the lexemes of the query
+// which aren't negated contribute to the score. The occurrences of a lexeme
+// only count if they have one of the weights the query lexeme is restricted
+// to, and a prefix lexeme counts the occurrences of all the lexemes it
+// matches.
+func RankTF(v TSVector, q TSQuery, p TFRankParams) float64 {
+	if len(v) == 0 {
+		return 0
+	}
+	dl := cntLen(v)
+	var score float64
+	for _, tf := range queryTermFrequencies(v, positiveQueryTerms(q)) {
+		if tf > 0 {
+			score += p.termScore(tf, dl)
+		}
+	}
+	return score
+}
+
+// queryTermFrequencies returns the number of occurrences in the tsvector of
+// each of the given query lexemes, counting only the occurrences which have
+// one of the weights the query lexeme is restricted to. A prefix lexeme counts
+// the occurrences of all the lexemes it matches.
+func queryTermFrequencies(v TSVector, leaves []*tsNode) []int {
+	tfs := make([]int, len(leaves))
+	for j, leaf := range leaves {
+		var queryWeight tsWeight
+		if len(leaf.term.positions) > 0 {
+			queryWeight = leaf.term.positions[0].weight
+		}
+		prefix := queryWeight&weightStar != 0
+		queryWeight &^= weightStar
+		if queryWeight == 0 {
+			queryWeight = weightAny
+		}
+		target := leaf.term.lexeme
+		tf := 0
+		for i := sort.Search(len(v), func(i int) bool {
+			return v[i].lexeme >= target
+		}); i < len(v); i++ {
+			t := &v[i]
+			if t.lexeme != target && (!prefix || !hasPrefix(t.lexeme, target)) {
+				break
+			}
+			if queryWeight == weightAny {
+				tf += termFrequency(t)
+			} else if len(t.positions) == 0 {
+				if weightD.matches(queryWeight) {
+					tf++
+				}
+			} else {
+				for _, pos := range t.positions {
+					if pos.weight.matches(queryWeight) {
+						tf++
+					}
+				}
+			}
+		}
+		tfs[j] = tf
+	}
+	return tfs
+}
+
+func hasPrefix(s, prefix string) bool {
+	return len(s) >= len(prefix) && s[:len(prefix)] == prefix
+}
+
+// TopKSearchTerms returns the distinct lexemes of a query, in sorted order, if
+// a document matches the query if and only if it contains the right
+// combination of those lexemes. This is the case when the query only uses the
+// & and | operators on lexemes that aren't prefixes and aren't restricted to
+// some weights. It returns ok=false otherwise.
+func TopKSearchTerms(q TSQuery) (terms []string, ok bool) {
+	if q.root == nil {
+		return nil, false
+	}
+	var check func(n *tsNode) bool
+	check = func(n *tsNode) bool {
+		switch n.op {
+		case invalid:
+			return len(n.term.positions) == 0 || n.term.positions[0].weight == 0
+		case and, or:
+			return check(n.l) && check(n.r)
+		}
+		return false
+	}
+	if !check(q.root) {
+		return nil, false
+	}
+	for _, leaf := range positiveQueryTerms(q) {
+		terms = append(terms, leaf.term.lexeme)
+	}
+	return terms, true
+}
+
+// matchesTerms returns whether a document that contains exactly the given
+// subset of the search terms matches the query. The query must be one for
+// which TopKSearchTerms returns ok=true.
+func matchesTerms(n *tsNode, terms []string, present []bool) bool {
+	switch n.op {
+	case and:
+		return matchesTerms(n.l, terms, present) && matchesTerms(n.r, terms, present)
+	case or:
+		return matchesTerms(n.l, terms, present) || matchesTerms(n.r, terms, present)
+	}
+	i := sort.SearchStrings(terms, n.term.lexeme)
+	return i < len(terms) && terms[i] == n.term.lexeme && present[i]
+}
+
+// PostingCursor iterates over the postings of a lexeme in an inverted index,
+// in increasing order of document ID. A cursor must be positioned at its
+// first posting before it is passed to SearchTopK.
+type PostingCursor interface {
+	// Doc returns the ID of the document of the current posting, or nil if
+	// the cursor is exhausted. Document IDs are compared bytewise.
+	Doc() []byte
+	// Stats returns the frequency of the lexeme in the document of the
+	// current posting, and the length of that document.
+	Stats() (tf, dl int)
+	// Next advances the cursor to the next posting.
+	Next(ctx context.Context) error
+	// Seek advances the cursor to the first posting whose document ID is
+	// greater than or equal to the given one. It never moves the cursor
+	// backward.
+	Seek(ctx context.Context, doc []byte) error
+}
+
+// TermScorer computes the contribution of the search terms of a query to the
+// scores of the documents that contain them.
+type TermScorer interface {
+	// TermScore returns the contribution to the score of a document of length
+	// dl of the i-th search term, which occurs tf times in the document.
+	TermScore(i int, tf, dl int) float64
+	// MaxTermScore returns an upper bound on the contribution of the i-th
+	// search term to the score of any document.
+	MaxTermScore(i int) float64
+}
+
+// ScoredDoc is a document found by SearchTopK.
+type ScoredDoc struct {
+	Doc   []byte
+	Score float64
+}
+
+// scoredDocHeap is a min-heap of the best documents found so far.
+type scoredDocHeap []ScoredDoc
+
+func (h scoredDocHeap) Len() int           { return len(h) }
+func (h scoredDocHeap) Less(i, j int) bool { return h[i].Score < h[j].Score }
+func (h scoredDocHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *scoredDocHeap) Push(x any)        { *h = append(*h, x.(ScoredDoc)) }
+func (h *scoredDocHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// SearchTopK returns the k documents which match the query with the highest
+// scores, in decreasing order of score. The score of a document is the sum of
+// the contributions of the terms it contains, as computed by the scorer. Ties
+// are broken arbitrarily. The terms must be the ones returned by
+// TopKSearchTerms for the query, and cursors[i] must iterate over the postings
+// of terms[i].
+//
+// Every term contributes at most its MaxTermScore to the score of a document.
+// Once k documents have been found, the cursors are kept sorted by document,
+// and the first document for which the sum of the bounds of the cursors at or
+// before it exceeds the k-th best score is the next candidate; the documents
+// before it can't make it into the top k, so the cursors are moved directly
+// to the candidate.
+func SearchTopK(
+	ctx context.Context,
+	q TSQuery,
+	terms []string,
+	cursors []PostingCursor,
+	k int,
+	scorer TermScorer,
+) ([]ScoredDoc, error) {
+	if len(terms) != len(cursors) {
+		return nil, errors.AssertionFailedf("expected %d cursors, found %d", len(terms), len(cursors))
+	}
+	if k <= 0 || q.root == nil {
+		return nil, nil
+	}
+	// order holds the indexes of the non-exhausted cursors.
+	order := make([]int, 0, len(cursors))
+	for i := range cursors {
+		if cursors[i].Doc() != nil {
+			order = append(order, i)
+		}
+	}
+	present := make([]bool, len(cursors))
+	var best scoredDocHeap
+	for len(order) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return bytes.Compare(cursors[order[i]].Doc(), cursors[order[j]].Doc()) < 0
+		})
+
+		// Find the pivot, which is the first cursor at which the sum of the
+		// bounds exceeds the threshold.
+		pivot := 0
+		if len(best) == k {
+			threshold := best[0].Score
+			bound := 0.0
+			for pivot = range order {
+				bound += scorer.MaxTermScore(order[pivot])
+				if bound > threshold {
+					break
+				}
+			}
+			if bound <= threshold {
+				// No remaining document can make it into the top k.
+				break
+			}
+		}
+		pivotDoc := cursors[order[pivot]].Doc()
+
+		if !bytes.Equal(cursors[order[0]].Doc(), pivotDoc) {
+			// Skip the documents before the pivot.
+			for _, i := range order[:pivot] {
+				if err := cursors[i].Seek(ctx, pivotDoc); err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			// All the cursors which contain the pivot document are at the front,
+			// so the document can be fully evaluated.
+			for i := range present {
+				present[i] = false
+			}
+			var dl int
+			tfs := make([]int, len(cursors))
+			for _, i := range order {
+				if !bytes.Equal(cursors[i].Doc(), pivotDoc) {
+					break
+				}
+				present[i] = true
+				tfs[i], dl = cursors[i].Stats()
+			}
+			if matchesTerms(q.root, terms, present) {
+				var score float64
+				for i := range terms {
+					if present[i] {
+						score += scorer.TermScore(i, tfs[i], dl)
+					}
+				}
+				if len(best) < k {
+					heap.Push(&best, ScoredDoc{Doc: append([]byte(nil), pivotDoc...), Score: score})
+				} else if score > best[0].Score {
+					best[0] = ScoredDoc{Doc: append([]byte(nil), pivotDoc...), Score: score}
+					heap.Fix(&best, 0)
+				}
+			}
+			for i := range present {
+				if present[i] {
+					if err := cursors[i].Next(ctx); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+
+		// Remove the exhausted cursors.
+		n := 0
+		for _, i := range order {
+			if cursors[i].Doc() != nil {
+				order[n] = i
+				n++
+			}
+		}
+		order = order[:n]
+	}
+
+	ret := make([]ScoredDoc, len(best))
+	for i := len(ret) - 1; i >= 0; i-- {
+		ret[i] = heap.Pop(&best).(ScoredDoc)
+	}
+	return ret, nil
+}
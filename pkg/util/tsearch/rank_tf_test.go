@@ -0,0 +1,206 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsearch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankTF(t *testing.T) {
+	tests := []struct {
+		v        string
+		q        string
+		params   TFRankParams
+		expected float64
+	}{
+		{v: "a:1,2 b:3", q: "a", params: TFRankParams{K1: 1.2}, expected: 1.375},
+		{v: "a:1,2 b:3", q: "a & b", params: TFRankParams{K1: 1.2}, expected: 2.375},
+		{v: "a:1,2 b:3", q: "a | c", params: TFRankParams{K1: 1.2}, expected: 1.375},
+		{v: "a:1,2 b:3", q: "a & !b", params: TFRankParams{K1: 1.2}, expected: 1.375},
+		{v: "a:1,2 b:3", q: "a <-> a", params: TFRankParams{K1: 1.2}, expected: 1.375},
+		{v: "a:1,2 b:3", q: "c", params: TFRankParams{K1: 1.2}, expected: 0},
+		{v: "a b", q: "a", params: TFRankParams{K1: 1.2}, expected: 1},
+		{v: "a:1,2 b:3", q: "a", params: TFRankParams{K1: 1.2, B: 0.75, AvgDocLength: 3}, expected: 1.375},
+		{v: "a:1,2 b:3", q: "a", params: TFRankParams{K1: 1.2, B: 0.75, AvgDocLength: 6}, expected: 1.6},
+		{v: "a:1,2 b:3", q: "a", params: TFRankParams{K1: 0}, expected: 1},
+		{v: "a:1 ba:2 bb:3,4", q: "b:*", params: TFRankParams{K1: 1.2}, expected: 6.6 / 4.2},
+		{v: "a:1A,2 b:3", q: "a:A", params: TFRankParams{K1: 1.2}, expected: 1},
+		{v: "a:1A,2 b:3", q: "a:BC", params: TFRankParams{K1: 1.2}, expected: 0},
+	}
+	for _, tt := range tests {
+		v, err := ParseTSVector(tt.v)
+		require.NoError(t, err)
+		q, err := ParseTSQuery(tt.q)
+		require.NoError(t, err)
+		actual := RankTF(v, q, tt.params)
+		assert.InDeltaf(t, tt.expected, actual, 1e-9, "RankTF(%s, %s, %+v)", tt.v, tt.q, tt.params)
+	}
+}
+
+func TestTermStatistics(t *testing.T) {
+	v, err := ParseTSVector("a:1,3 b c:2")
+	require.NoError(t, err)
+	tfs, dl := TermStatistics(v)
+	assert.Equal(t, []int{2, 1, 1}, tfs)
+	assert.Equal(t, 4, dl)
+
+	for _, tf := range tfs {
+		tfOut, dlOut, err := DecodeTermStatistics(EncodeTermStatistics(nil, tf, dl))
+		require.NoError(t, err)
+		assert.Equal(t, tf, tfOut)
+		assert.Equal(t, dl, dlOut)
+	}
+}
+
+func TestTopKSearchTerms(t *testing.T) {
+	tests := []struct {
+		q     string
+		terms []string
+		ok    bool
+	}{
+		{q: "a", terms: []string{"a"}, ok: true},
+		{q: "c & (b | a) & c", terms: []string{"a", "b", "c"}, ok: true},
+		{q: "a & !b", ok: false},
+		{q: "a <-> b", ok: false},
+		{q: "a:*", ok: false},
+		{q: "a:B | b", ok: false},
+	}
+	for _, tt := range tests {
+		q, err := ParseTSQuery(tt.q)
+		require.NoError(t, err)
+		terms, ok := TopKSearchTerms(q)
+		assert.Equal(t, tt.ok, ok, tt.q)
+		if tt.ok {
+			assert.Equal(t, tt.terms, terms, tt.q)
+		}
+	}
+}
+
+// slicePostingCursor is a PostingCursor over an in-memory posting list.
+type slicePostingCursor struct {
+	docs [][]byte
+	tfs  []int
+	dls  []int
+	pos  int
+}
+
+func (c *slicePostingCursor) Doc() []byte {
+	if c.pos >= len(c.docs) {
+		return nil
+	}
+	return c.docs[c.pos]
+}
+
+func (c *slicePostingCursor) Stats() (tf, dl int) {
+	return c.tfs[c.pos], c.dls[c.pos]
+}
+
+func (c *slicePostingCursor) Next(context.Context) error {
+	c.pos++
+	return nil
+}
+
+func (c *slicePostingCursor) Seek(_ context.Context, doc []byte) error {
+	for c.pos < len(c.docs) && bytes.Compare(c.docs[c.pos], doc) < 0 {
+		c.pos++
+	}
+	return nil
+}
+
+func TestSearchTopK(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	lexemes := []string{"a", "b", "c", "d", "e"}
+	queries := []string{"a", "a | b", "a & b", "b | c | d", "(a | b) & c", "e & a | d"}
+	ctx := context.Background()
+
+	for iter := 0; iter < 100; iter++ {
+		// Generate random documents, where lexemes earlier in the list are more
+		// frequent.
+		numDocs := rng.Intn(50)
+		docs := make([][]byte, numDocs)
+		vectors := make([]TSVector, numDocs)
+		for i := range docs {
+			docs[i] = []byte(fmt.Sprintf("doc%03d", i))
+			var words []string
+			pos := 1
+			for j, l := range lexemes {
+				if rng.Intn(j+2) != 0 {
+					continue
+				}
+				positions := make([]string, 1+rng.Intn(3))
+				for k := range positions {
+					positions[k] = fmt.Sprint(pos)
+					pos++
+				}
+				words = append(words, l+":"+strings.Join(positions, ","))
+			}
+			v, err := ParseTSVector(strings.Join(words, " "))
+			require.NoError(t, err)
+			vectors[i] = v
+		}
+		params := TFRankParams{K1: 1.2, B: 0.75, AvgDocLength: 4}
+		k := 1 + rng.Intn(5)
+
+		for _, qStr := range queries {
+			q, err := ParseTSQuery(qStr)
+			require.NoError(t, err)
+			terms, ok := TopKSearchTerms(q)
+			require.True(t, ok)
+
+			// Build the posting lists of the query terms.
+			cursors := make([]PostingCursor, len(terms))
+			for i, term := range terms {
+				c := &slicePostingCursor{}
+				for j, v := range vectors {
+					tfs, dl := TermStatistics(v)
+					for l := range v {
+						if v[l].lexeme == term {
+							c.docs = append(c.docs, docs[j])
+							c.tfs = append(c.tfs, tfs[l])
+							c.dls = append(c.dls, dl)
+						}
+					}
+				}
+				cursors[i] = c
+			}
+
+			// Rank all the matching documents.
+			expected := []float64{}
+			for _, v := range vectors {
+				matches, err := EvalTSQuery(q, v)
+				require.NoError(t, err)
+				if matches {
+					expected = append(expected, RankTF(v, q, params))
+				}
+			}
+			sort.Sort(sort.Reverse(sort.Float64Slice(expected)))
+			if len(expected) > k {
+				expected = expected[:k]
+			}
+
+			res, err := SearchTopK(ctx, q, terms, cursors, k, params)
+			require.NoError(t, err)
+			actual := make([]float64, len(res))
+			for i := range res {
+				actual[i] = res[i].Score
+				j := sort.Search(len(docs), func(j int) bool {
+					return bytes.Compare(docs[j], res[i].Doc) >= 0
+				})
+				assert.Equal(t, RankTF(vectors[j], q, params), res[i].Score)
+			}
+			assert.Equal(t, expected, actual, "query %s, k=%d", qStr, k)
+		}
+	}
+}
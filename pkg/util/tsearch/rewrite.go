@@ -0,0 +1,309 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsearch
+
+import (
+	"sort"
+	"strings"
+)
+
+// This file implements ts_rewrite, which replaces subqueries of a TSQuery. It
+// parallels tsquery_rewrite.c and tsquery_util.c in Postgres. The matching is
+// done on a representation of the query in which runs of & and | operators
+// are flattened into single nodes with sorted children, so that a target like
+// 'a & b' matches the query 'c & b & a'.
+
+// qtNode is a node of a TSQuery in which the & and | operators can have more
+// than two children.
+type qtNode struct {
+	// term is set for leaf nodes.
+	term tsTerm
+	// crc is the legacy CRC-32 of the lexeme of leaf nodes, which Postgres uses
+	// to order them.
+	crc int32
+	// op is set for operator nodes.
+	op        tsOperator
+	followedN uint16
+	// children are the operands of an operator node. Note that as in Postgres,
+	// the first child of a binary operator is its right operand.
+	children []*qtNode
+	// noChange is set on the nodes that were substituted in, so that they
+	// aren't rewritten again.
+	noChange bool
+}
+
+func newQTNode(n *tsNode) *qtNode {
+	if n.op == invalid {
+		return &qtNode{term: n.term, crc: legacyCRC32(n.term.lexeme)}
+	}
+	ret := &qtNode{op: n.op, followedN: n.followedN}
+	if n.op == not {
+		ret.children = []*qtNode{newQTNode(n.l)}
+	} else {
+		ret.children = []*qtNode{newQTNode(n.r), newQTNode(n.l)}
+	}
+	return ret
+}
+
+// toTSNode converts the node back to a tree of binary operators.
+func (n *qtNode) toTSNode() *tsNode {
+	if n.op == invalid {
+		return &tsNode{term: n.term}
+	}
+	if n.op == not {
+		return &tsNode{op: not, l: n.children[0].toTSNode()}
+	}
+	// Postgres combines the first two children until only two are left, which
+	// determines the shape of the output.
+	children := append([]*qtNode(nil), n.children...)
+	for len(children) > 2 {
+		nn := &qtNode{op: n.op, followedN: n.followedN, children: []*qtNode{children[0], children[1]}}
+		children[0] = nn
+		children[1] = children[len(children)-1]
+		children = children[:len(children)-1]
+	}
+	return &tsNode{
+		op:        n.op,
+		followedN: n.followedN,
+		l:         children[1].toTSNode(),
+		r:         children[0].toTSNode(),
+	}
+}
+
+func (n *qtNode) copy() *qtNode {
+	ret := *n
+	ret.children = make([]*qtNode, len(n.children))
+	for i := range n.children {
+		ret.children[i] = n.children[i].copy()
+	}
+	return &ret
+}
+
+// flatten merges the children of & and | nodes that have the same operator
+// into their parent. It parallels QTNTernary in Postgres.
+func (n *qtNode) flatten() {
+	for _, c := range n.children {
+		c.flatten()
+	}
+	if n.op != and && n.op != or {
+		return
+	}
+	var children []*qtNode
+	for _, c := range n.children {
+		if c.op == n.op {
+			children = append(children, c.children...)
+		} else {
+			children = append(children, c)
+		}
+	}
+	n.children = children
+}
+
+// sortChildren sorts the children of the commutative operators. It parallels
+// QTNSort in Postgres.
+func (n *qtNode) sortChildren() {
+	for _, c := range n.children {
+		c.sortChildren()
+	}
+	if n.op != followedby && len(n.children) > 1 {
+		sort.SliceStable(n.children, func(i, j int) bool {
+			return compareQTNodes(n.children[i], n.children[j]) < 0
+		})
+	}
+}
+
+func (n *qtNode) clearNoChange() {
+	n.noChange = false
+	for _, c := range n.children {
+		c.clearNoChange()
+	}
+}
+
+// compareQTNodes orders nodes the same way as QTNodeCompare in Postgres:
+// operators come before leaves, and leaves are ordered by the CRC of their
+// lexemes. The weights of leaves are ignored.
+func compareQTNodes(a, b *qtNode) int {
+	aIsLeaf, bIsLeaf := a.op == invalid, b.op == invalid
+	if aIsLeaf != bIsLeaf {
+		if bIsLeaf {
+			return -1
+		}
+		return 1
+	}
+	if !aIsLeaf {
+		if a.op != b.op {
+			if a.op.pgwireEncoding() > b.op.pgwireEncoding() {
+				return -1
+			}
+			return 1
+		}
+		if len(a.children) != len(b.children) {
+			if len(a.children) > len(b.children) {
+				return -1
+			}
+			return 1
+		}
+		for i := range a.children {
+			if c := compareQTNodes(a.children[i], b.children[i]); c != 0 {
+				return c
+			}
+		}
+		if a.op == followedby && a.followedN != b.followedN {
+			if a.followedN > b.followedN {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	}
+	if a.crc != b.crc {
+		if a.crc > b.crc {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a.term.lexeme, b.term.lexeme)
+}
+
+// replace replaces the node with the substitute if it matches the target. If
+// the node is an & or | node with more children than the target, a subset of
+// its children that matches the children of the target is replaced. A nil
+// substitute removes the matched nodes. It parallels findeq in Postgres.
+func (n *qtNode) replace(target, substitute *qtNode) *qtNode {
+	if n.noChange || (n.op == invalid) != (target.op == invalid) {
+		return n
+	}
+	newSubstitute := func() *qtNode {
+		if substitute == nil {
+			return nil
+		}
+		ret := substitute.copy()
+		ret.noChange = true
+		return ret
+	}
+	if n.op == invalid || len(n.children) == len(target.children) {
+		if n.op == target.op && compareQTNodes(n, target) == 0 {
+			return newSubstitute()
+		}
+		return n
+	}
+	if n.op != target.op || len(n.children) < len(target.children) || len(target.children) == 0 {
+		return n
+	}
+	// Both lists of children are sorted, so they can be merged to find the
+	// children of the target.
+	matched := make([]int, 0, len(target.children))
+	for i, j := 0, 0; i < len(n.children) && j < len(target.children); {
+		c := compareQTNodes(n.children[i], target.children[j])
+		if c == 0 {
+			matched = append(matched, i)
+			i++
+			j++
+		} else if c < 0 {
+			i++
+		} else {
+			break
+		}
+	}
+	if len(matched) < len(target.children) {
+		return n
+	}
+	children := make([]*qtNode, 0, len(n.children)-len(matched)+1)
+	for i, j := 0, 0; i < len(n.children); i++ {
+		if j < len(matched) && i == matched[j] {
+			j++
+			continue
+		}
+		children = append(children, n.children[i])
+	}
+	if s := newSubstitute(); s != nil {
+		children = append(children, s)
+	}
+	n.children = children
+	return n
+}
+
+// replaceAll replaces all the matches of the target in the tree rooted at the
+// node. Operators that are left without operands are removed. It parallels
+// dofindsubquery in Postgres.
+func (n *qtNode) replaceAll(target, substitute *qtNode) *qtNode {
+	n = n.replace(target, substitute)
+	if n == nil || n.noChange || n.op == invalid {
+		return n
+	}
+	children := n.children[:0]
+	for _, c := range n.children {
+		if c = c.replaceAll(target, substitute); c != nil {
+			children = append(children, c)
+		}
+	}
+	n.children = children
+	if len(children) == 0 {
+		return nil
+	}
+	if len(children) == 1 && n.op != not {
+		return children[0]
+	}
+	return n
+}
+
+// Rewrite implements the ts_rewrite builtin. For each i, it replaces the
+// occurrences of targets[i] in the query by substitutes[i], in order. An empty
+// substitute removes the occurrences from the query.
+func Rewrite(q TSQuery, targets []TSQuery, substitutes []TSQuery) TSQuery {
+	if q.root == nil {
+		return q
+	}
+	root := newQTNode(q.root)
+	root.flatten()
+	root.sortChildren()
+	for i := range targets {
+		if targets[i].root == nil {
+			continue
+		}
+		target := newQTNode(targets[i].root)
+		target.flatten()
+		target.sortChildren()
+		var substitute *qtNode
+		if substitutes[i].root != nil {
+			substitute = newQTNode(substitutes[i].root)
+		}
+		root = root.replaceAll(target, substitute)
+		if root == nil {
+			return TSQuery{}
+		}
+		root.clearNoChange()
+	}
+	return TSQuery{root: root.toTSNode()}
+}
+
+// legacyCRC32Table is the lookup table for the CRC-32 polynomial in normal,
+// most significant bit first, form.
+var legacyCRC32Table = func() (table [256]uint32) {
+	for i := range table {
+		crc := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ 0x04C11DB7
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}()
+
+// legacyCRC32 computes the "legacy" CRC-32 that Postgres uses to sort the
+// operands of tsqueries. Unlike the standard CRC-32, it processes the bits of
+// each byte most significant first.
+func legacyCRC32(s string) int32 {
+	crc := uint32(0xFFFFFFFF)
+	for i := 0; i < len(s); i++ {
+		crc = legacyCRC32Table[byte(crc>>24)^s[i]] ^ (crc << 8)
+	}
+	return int32(crc ^ 0xFFFFFFFF)
+}
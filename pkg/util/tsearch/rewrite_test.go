@@ -0,0 +1,52 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewrite(t *testing.T) {
+	parse := func(s string) TSQuery {
+		if s == "" {
+			return TSQuery{}
+		}
+		q, err := ParseTSQuery(s)
+		require.NoError(t, err)
+		return q
+	}
+	for _, tc := range []struct {
+		query    string
+		rules    [][2]string
+		expected string
+	}{
+		{`a & b`, [][2]string{{`a`, `c`}}, `'b' & 'c'`},
+		{`a & b`, [][2]string{{`c`, `d`}}, `'b' & 'a'`},
+		{`a & b & c`, [][2]string{{`a & c`, `d`}}, `'d' & 'b'`},
+		{`a & b`, [][2]string{{`a`, ``}}, `'b'`},
+		{`a & b`, [][2]string{{`a & b`, ``}}, ``},
+		{`a | (b & c)`, [][2]string{{`b & c`, `d`}}, `'a' | 'd'`},
+		{`a <-> b`, [][2]string{{`b <-> a`, `c`}}, `'a' <-> 'b'`},
+		{`a <-> b`, [][2]string{{`a <-> b`, `c`}}, `'c'`},
+		{`!a`, [][2]string{{`a`, ``}}, ``},
+		// Substituted nodes aren't rewritten again by the same rule, but they
+		// are by the following ones.
+		{`a`, [][2]string{{`a`, `a & b`}, {`b`, `c`}}, `'a' & 'c'`},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			var targets, substitutes []TSQuery
+			for _, rule := range tc.rules {
+				targets = append(targets, parse(rule[0]))
+				substitutes = append(substitutes, parse(rule[1]))
+			}
+			actual := Rewrite(parse(tc.query), targets, substitutes)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+}
@@ -25,12 +25,18 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 )
 
-func getStemmer(config string) (func(env *snowballstem.Env) bool, error) {
-	switch config {
-	case "simple":
-		return func(env *snowballstem.Env) bool {
-			return true
-		}, nil
+// snowballLanguages are the languages supported by the snowball dictionary
+// template, in the order in which their built-in dictionaries and
+// configurations are listed.
+var snowballLanguages = []string{
+	"danish", "dutch", "english", "finnish", "french", "german", "hungarian",
+	"italian", "norwegian", "portuguese", "russian", "spanish", "swedish",
+	"turkish",
+}
+
+// getStemmer returns the snowball stemmer for the given language.
+func getStemmer(language string) (func(env *snowballstem.Env) bool, error) {
+	switch language {
 	case "english":
 		return english.Stem, nil
 	case "danish":
@@ -60,5 +66,5 @@ func getStemmer(config string) (func(env *snowballstem.Env) bool, error) {
 	case "turkish":
 		return turkish.Stem, nil
 	}
-	return nil, pgerror.Newf(pgcode.UndefinedObject, "text search configuration %q does not exist", config)
+	return nil, pgerror.Newf(pgcode.UndefinedObject, "could not find stemmer for language %q", language)
 }
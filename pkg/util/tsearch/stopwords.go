@@ -15,10 +15,12 @@ import (
 //go:embed stopwords/*
 var stopwordFS embed.FS
 
-var stopwordsMap map[string]map[string]struct{}
+// stopwordsMap holds the stop-word lists, indexed by name. It is initialized
+// before the built-in dictionaries that use it.
+var stopwordsMap = loadStopwords()
 
-func init() {
-	stopwordsMap = make(map[string]map[string]struct{})
+func loadStopwords() map[string]map[string]struct{} {
+	stopwordsMap := make(map[string]map[string]struct{})
 	dir, err := stopwordFS.ReadDir("stopwords")
 	if err != nil {
 		panic("error loading stopwords: " + err.Error())
@@ -38,6 +40,5 @@ func init() {
 			stopwordsMap[name][string(word)] = struct{}{}
 		}
 	}
-	// The simple text search config has no stopwords.
-	stopwordsMap["simple"] = nil
+	return stopwordsMap
 }
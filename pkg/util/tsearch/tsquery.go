@@ -8,6 +8,8 @@ package tsearch
 import (
 	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/cockroachdb/cockroach/pkg/keysbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
@@ -410,21 +412,21 @@ func (p *tsQueryParser) syntaxError() (*tsNode, error) {
 
 // ToTSQuery implements the to_tsquery builtin, which lexes an input, performs
 // stopwording and normalization on the tokens, and returns a parsed query.
-func ToTSQuery(config string, input string) (TSQuery, error) {
+func ToTSQuery(config *Config, input string) (TSQuery, error) {
 	return toTSQuery(config, invalid, input)
 }
 
 // PlainToTSQuery implements the plainto_tsquery builtin, which lexes an input,
 // performs stopwording and normalization on the tokens, and returns a parsed
 // query, interposing the & operator between each token.
-func PlainToTSQuery(config string, input string) (TSQuery, error) {
+func PlainToTSQuery(config *Config, input string) (TSQuery, error) {
 	return toTSQuery(config, and, input)
 }
 
 // PhraseToTSQuery implements the phraseto_tsquery builtin, which lexes an input,
 // performs stopwording and normalization on the tokens, and returns a parsed
 // query, interposing the <-> operator between each token.
-func PhraseToTSQuery(config string, input string) (TSQuery, error) {
+func PhraseToTSQuery(config *Config, input string) (TSQuery, error) {
 	return toTSQuery(config, followedby, input)
 }
 
@@ -432,7 +434,7 @@ func PhraseToTSQuery(config string, input string) (TSQuery, error) {
 // performs stopwording and normalization on the tokens, and returns a parsed
 // query. If the interpose operator is not invalid, it's interposed between each
 // token in the input.
-func toTSQuery(config string, interpose tsOperator, input string) (TSQuery, error) {
+func toTSQuery(config *Config, interpose tsOperator, input string) (TSQuery, error) {
 	vector, err := lexTSQuery(input)
 	if err != nil {
 		return TSQuery{}, err
@@ -470,19 +472,28 @@ func toTSQuery(config string, interpose tsOperator, input string) (TSQuery, erro
 			continue
 		}
 
-		// When we support more than just the simple configuration, we'll also
-		// want to remove stopwords, which will affect the interposing, but we can
-		// worry about that later.
-		// Additionally, if we're doing phraseto_tsquery, if we remove a stopword,
-		// we need to make sure to increase the "followedN" of the followedby
-		// operator. For example, phraseto_tsquery('hello a deer') will return
-		// 'hello <2> deer', since the a stopword would be removed.
+		// Normalize the lexemes of the token. Stop words and words that aren't
+		// recognized by any dictionary are added as empty lexemes, which are
+		// removed below. If we're doing phraseto_tsquery, removing a stop word
+		// increases the "followedN" of the followedby operator. For example,
+		// phraseto_tsquery('hello a deer') will return 'hello <2> deer', since
+		// the a stopword would be removed.
+		var lexemes []string
+		for j := 0; j < len(lexemeTokens); {
+			l, n := config.lexize(lexemeTokens[j:])
+			if len(l) == 0 {
+				foundStopwords = true
+				l = []string{""}
+			}
+			lexemes = append(lexemes, l...)
+			j += n
+		}
 
 		tokInterpose := interpose
 		if tokInterpose == invalid {
 			tokInterpose = followedby
 		}
-		for j := range lexemeTokens {
+		for j := range lexemes {
 			if j > 0 {
 				// We found more than one lexeme in our token, so we need to add all of them
 				// to the query, connected by our interpose operator.
@@ -494,14 +505,7 @@ func toTSQuery(config string, interpose tsOperator, input string) (TSQuery, erro
 				}
 				tokens = append(tokens, term)
 			}
-			lexeme, stopWord, err := TSLexize(config, lexemeTokens[j])
-			if err != nil {
-				return TSQuery{}, err
-			}
-			if stopWord {
-				foundStopwords = true
-			}
-			tokens = append(tokens, tsTerm{lexeme: lexeme, positions: tok.positions})
+			tokens = append(tokens, tsTerm{lexeme: lexemes[j], positions: tok.positions})
 		}
 	}
 
@@ -647,3 +651,180 @@ func cleanupStopword(node *tsNode) (ret *tsNode, lAdd int, rAdd int) {
 	// Otherwise we found a non-phrase operator; keep it as-is.
 	return node, 0, 0
 }
+
+// WebSearchToTSQuery implements the websearch_to_tsquery builtin, which
+// converts an input written in the syntax of web search engines to a query.
+// Unquoted words are connected by &, quoted text is converted to words
+// connected by <->, the word "or" is converted to |, and a leading - is
+// converted to !. Other punctuation is ignored, so that the function never
+// raises syntax errors.
+//
+// This function parallels the websearch mode of gettoken_query in Postgres.
+func WebSearchToTSQuery(config *Config, input string) (TSQuery, error) {
+	var tokens TSVector
+	appendOp := func(op tsOperator) {
+		term := tsTerm{operator: op}
+		if op == followedby {
+			term.followedN = 1
+		}
+		tokens = append(tokens, term)
+	}
+	waitingForOperand := true
+	inQuotes := false
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		if waitingForOperand {
+			switch {
+			case unicode.IsSpace(r) || isWebSearchPunctuation(r):
+				i += size
+			case r == '-':
+				i += size
+				if !inQuotes {
+					appendOp(not)
+				}
+			case r == '"':
+				i += size
+				if inQuotes {
+					// The quoted text ends with an operator, so add a stop word to
+					// be cleaned up below.
+					tokens = append(tokens, tsTerm{})
+					appendOp(rparen)
+					inQuotes = false
+					waitingForOperand = false
+				} else if strings.IndexByte(input[i:], '"') >= 0 {
+					// Quotes are ignored unless they're closed.
+					appendOp(lparen)
+					inQuotes = true
+				}
+			default:
+				end := i
+				for end < len(input) {
+					r, size := utf8.DecodeRuneInString(input[end:])
+					if unicode.IsSpace(r) || r == '"' || isWebSearchPunctuation(r) {
+						break
+					}
+					end += size
+				}
+				tokens = appendWebSearchOperand(config, tokens, input[i:end])
+				i = end
+				waitingForOperand = false
+			}
+			continue
+		}
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '"':
+			if inQuotes {
+				i += size
+				appendOp(rparen)
+				inQuotes = false
+			} else {
+				// Add an implicit & before the quoted text.
+				appendOp(and)
+				waitingForOperand = true
+			}
+		case !inQuotes && isWebSearchOr(input[i:]):
+			i += 2
+			appendOp(or)
+			waitingForOperand = true
+		default:
+			// Operands are connected by an implicit operator.
+			if inQuotes {
+				appendOp(followedby)
+			} else {
+				appendOp(and)
+			}
+			waitingForOperand = true
+		}
+	}
+	if waitingForOperand && len(tokens) > 0 {
+		// The input ended with an operator, so add a stop word as its operand.
+		tokens = append(tokens, tsTerm{})
+	}
+
+	queryParser := tsQueryParser{terms: tokens, input: input}
+	query, err := queryParser.parse()
+	if err != nil {
+		return query, err
+	}
+	query = cleanupStopwords(query)
+	if query.root == nil {
+		return query, pgerror.Newf(pgcode.Syntax, "text-search query doesn't contain lexemes: %s", input)
+	}
+	return query, nil
+}
+
+// isWebSearchPunctuation returns whether the rune is one of the tsquery
+// operators, which are ignored by websearch_to_tsquery.
+func isWebSearchPunctuation(r rune) bool {
+	switch r {
+	case '!', '&', '|', '(', ')', '<', '>':
+		return true
+	}
+	return false
+}
+
+// isWebSearchOr returns whether the input starts with the word "or" used as
+// an operator: it must not be part of a longer word, and it must be followed
+// by another operand.
+func isWebSearchOr(input string) bool {
+	if len(input) < 3 || !strings.EqualFold(input[:2], "or") {
+		return false
+	}
+	r, size := utf8.DecodeRuneInString(input[2:])
+	if r == '-' || r == '_' || unicode.IsOneOf(validCharTables, r) {
+		return false
+	}
+	return strings.TrimLeftFunc(input[2+size:], unicode.IsSpace) != ""
+}
+
+// appendWebSearchOperand appends the lexemes of an operand of a websearch
+// query to the tokens. If the operand has several lexemes, they're connected
+// by <-> and grouped in parentheses. Stop words are added as empty lexemes.
+func appendWebSearchOperand(config *Config, tokens TSVector, operand string) TSVector {
+	words := TSParse(operand)
+	var lexemes []string
+	for j := 0; j < len(words); {
+		l, n := config.lexize(words[j:])
+		if len(l) == 0 {
+			l = []string{""}
+		}
+		lexemes = append(lexemes, l...)
+		j += n
+	}
+	if len(lexemes) == 0 {
+		return append(tokens, tsTerm{})
+	}
+	if len(lexemes) > 1 {
+		tokens = append(tokens, tsTerm{operator: lparen})
+	}
+	for j := range lexemes {
+		if j > 0 {
+			tokens = append(tokens, tsTerm{operator: followedby, followedN: 1})
+		}
+		tokens = append(tokens, tsTerm{lexeme: lexemes[j]})
+	}
+	if len(lexemes) > 1 {
+		tokens = append(tokens, tsTerm{operator: rparen})
+	}
+	return tokens
+}
+
+// TSQueryPhrase implements the tsquery_phrase builtin, which returns a query
+// that searches for a match of the left query followed by a match of the
+// right query at the given distance.
+func TSQueryPhrase(l, r TSQuery, distance int) (TSQuery, error) {
+	if distance < 0 || distance > maxTSVectorFollowedBy {
+		return TSQuery{}, pgerror.Newf(pgcode.InvalidParameterValue,
+			"distance in phrase operator must be an integer value between zero and %d inclusive",
+			maxTSVectorFollowedBy)
+	}
+	if l.root == nil {
+		return r, nil
+	}
+	if r.root == nil {
+		return l, nil
+	}
+	return TSQuery{root: &tsNode{op: followedby, followedN: uint16(distance), l: l.root, r: r.root}}, nil
+}
@@ -290,3 +290,61 @@ func TestParseTSQueryError(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestWebSearchToTSQuery(t *testing.T) {
+	english, ok := BuiltinConfig("english")
+	require.True(t, ok)
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{`The fat rats`, `'fat' & 'rat'`},
+		{`"supernovae stars" -crab`, `'supernova' <-> 'star' & !'crab'`},
+		{`"sad cat" or "fat rat"`, `'sad' <-> 'cat' | 'fat' <-> 'rat'`},
+		{`signal -"segmentation fault"`, `'signal' & !( 'segment' <-> 'fault' )`},
+		{`""" )( dummy \\ query <->`, `'dummi' & 'queri'`},
+		{`cat or`, `'cat'`},
+		{`orange or apple`, `'orang' | 'appl'`},
+		{`"unclosed quote`, `'unclos' & 'quot'`},
+		{`foo-bar`, `'foo' <-> 'bar'`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := WebSearchToTSQuery(english, tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, q.String())
+		})
+	}
+
+	_, err := WebSearchToTSQuery(english, `the or a`)
+	assert.Error(t, err)
+}
+
+func TestTSQueryPhrase(t *testing.T) {
+	for _, tc := range []struct {
+		l, r     string
+		distance int
+		expected string
+	}{
+		{`fat`, `cat`, 1, `'fat' <-> 'cat'`},
+		{`fat`, `cat`, 10, `'fat' <10> 'cat'`},
+		{`a & b`, `c | d`, 0, `( 'a' & 'b' ) <0> ( 'c' | 'd' )`},
+	} {
+		l, err := ParseTSQuery(tc.l)
+		require.NoError(t, err)
+		r, err := ParseTSQuery(tc.r)
+		require.NoError(t, err)
+		q, err := TSQueryPhrase(l, r, tc.distance)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, q.String())
+	}
+
+	l, err := ParseTSQuery(`a`)
+	require.NoError(t, err)
+	q, err := TSQueryPhrase(l, TSQuery{}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, `'a'`, q.String())
+	_, err = TSQueryPhrase(l, l, 16385)
+	assert.Error(t, err)
+	_, err = TSQueryPhrase(l, l, -1)
+	assert.Error(t, err)
+}
@@ -13,7 +13,6 @@ import (
 	"unicode"
 	"unicode/utf8"
 
-	"github.com/blevesearch/snowballstem"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/errors"
@@ -396,52 +395,107 @@ func TSParse(input string) []string {
 	})
 }
 
-// TSLexize implements the "dictionary" construct that's exposed via ts_lexize.
-// It gets invoked once per input token to produce an output lexeme during
-// routines like to_tsvector and to_tsquery.
-// It can return true in the second parameter to indicate a stopword was found.
-func TSLexize(config string, token string) (lexeme string, stopWord bool, err error) {
-	stopwords, ok := stopwordsMap[config]
-	if !ok {
-		return "", false, pgerror.Newf(pgcode.UndefinedObject, "text search configuration %q does not exist", config)
+// DocumentToTSVector parses an input document into tokens, normalizes them
+// into lexemes using the dictionaries of a text search configuration, and
+// returns a TSVector annotated with lexeme positions. Stop words and tokens
+// that no dictionary recognizes are left out.
+func DocumentToTSVector(config *Config, input string) (TSVector, error) {
+	tokens := TSParse(input)
+	vector := make(TSVector, 0, len(tokens))
+	for i := 0; i < len(tokens); {
+		lexemes, n := config.lexize(tokens[i:])
+		pos := i + 1
+		if i > maxTSVectorPosition {
+			// Postgres silently truncates positions larger than 16383 to 16383.
+			pos = maxTSVectorPosition
+		}
+		for _, lexeme := range lexemes {
+			term := tsTerm{lexeme: lexeme}
+			term.positions = []tsPosition{{position: uint16(pos)}}
+			vector = append(vector, term)
+		}
+		i += n
 	}
+	return normalizeTSVector(vector)
+}
 
-	lower := strings.ToLower(token)
-	if _, ok := stopwords[lower]; ok {
-		return "", true, nil
+// SetWeight implements the setweight builtin. It returns a copy of the input
+// TSVector in which every position is assigned the given weight, which is one
+// of the letters A, B, C or D. If lexemes is not nil, only the positions of
+// the given lexemes are changed.
+func SetWeight(v TSVector, weight byte, lexemes []string) (TSVector, error) {
+	var w tsWeight
+	switch weight {
+	case 'A', 'a':
+		w = weightA
+	case 'B', 'b':
+		w = weightB
+	case 'C', 'c':
+		w = weightC
+	case 'D', 'd':
+		// The D weight is stored as 0, since it's the default.
+		w = 0
+	default:
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized weight: %d", weight)
 	}
-	stemmer, err := getStemmer(config)
-	if err != nil {
-		return "", false, err
+	var filter map[string]struct{}
+	if lexemes != nil {
+		filter = make(map[string]struct{}, len(lexemes))
+		for _, l := range lexemes {
+			filter[l] = struct{}{}
+		}
+	}
+	ret := make(TSVector, len(v))
+	for i, term := range v {
+		ret[i] = term
+		if filter != nil {
+			if _, ok := filter[term.lexeme]; !ok {
+				continue
+			}
+		}
+		ret[i].positions = make([]tsPosition, len(term.positions))
+		for j, pos := range term.positions {
+			ret[i].positions[j] = tsPosition{position: pos.position, weight: w}
+		}
 	}
-	env := snowballstem.NewEnv(lower)
-	stemmer(env)
-	return env.Current(), false, nil
+	return ret, nil
 }
 
-// DocumentToTSVector parses an input document into lexemes, removes stop words,
-// stems and normalizes the lexemes, and returns a TSVector annotated with
-// lexeme positions according to a text search configuration passed by name.
-func DocumentToTSVector(config string, input string) (TSVector, error) {
-	tokens := TSParse(input)
-	vector := make(TSVector, 0, len(tokens))
-	for i := range tokens {
-		lexeme, stopWord, err := TSLexize(config, tokens[i])
+// Strip implements the strip builtin, which returns a copy of the input
+// TSVector without positions or weights.
+func Strip(v TSVector) TSVector {
+	ret := make(TSVector, len(v))
+	for i, term := range v {
+		ret[i] = tsTerm{lexeme: term.lexeme}
+	}
+	return ret
+}
+
+// ArrayToTSVector implements the array_to_tsvector builtin, which returns a
+// TSVector without positions made of the given lexemes. The lexemes are not
+// normalized.
+func ArrayToTSVector(lexemes []string) (TSVector, error) {
+	ret := make(TSVector, 0, len(lexemes))
+	for _, l := range lexemes {
+		if l == "" {
+			return nil, pgerror.New(pgcode.ZeroLengthCharacterString,
+				"lexeme array may not contain empty strings")
+		}
+		term, err := newLexemeTerm(l)
 		if err != nil {
 			return nil, err
 		}
-		if stopWord {
-			continue
-		}
+		ret = append(ret, term)
+	}
+	return normalizeTSVector(ret)
+}
 
-		term := tsTerm{lexeme: lexeme}
-		pos := i + 1
-		if i > maxTSVectorPosition {
-			// Postgres silently truncates positions larger than 16383 to 16383.
-			pos = maxTSVectorPosition
-		}
-		term.positions = []tsPosition{{position: uint16(pos)}}
-		vector = append(vector, term)
+// TSVectorToArray implements the tsvector_to_array builtin, which returns the
+// lexemes of the input TSVector.
+func TSVectorToArray(v TSVector) []string {
+	ret := make([]string, len(v))
+	for i := range v {
+		ret[i] = v[i].lexeme
 	}
-	return normalizeTSVector(vector)
+	return ret
 }
@@ -213,3 +213,31 @@ func BenchmarkTSVector(b *testing.B) {
 		}
 	})
 }
+
+func TestTSVectorFunctions(t *testing.T) {
+	v, err := ParseTSVector(`a:1,3 b:2B c`)
+	require.NoError(t, err)
+
+	actual, err := SetWeight(v, 'A', nil /* lexemes */)
+	require.NoError(t, err)
+	assert.Equal(t, `'a':1A,3A 'b':2A 'c'`, actual.String())
+	actual, err = SetWeight(v, 'c', []string{"b", "z"})
+	require.NoError(t, err)
+	assert.Equal(t, `'a':1,3 'b':2C 'c'`, actual.String())
+	actual, err = SetWeight(v, 'D', nil /* lexemes */)
+	require.NoError(t, err)
+	assert.Equal(t, `'a':1,3 'b':2 'c'`, actual.String())
+	_, err = SetWeight(v, 'E', nil /* lexemes */)
+	assert.Error(t, err)
+	// The input isn't modified.
+	assert.Equal(t, `'a':1,3 'b':2B 'c'`, v.String())
+
+	assert.Equal(t, `'a' 'b' 'c'`, Strip(v).String())
+	assert.Equal(t, []string{"a", "b", "c"}, TSVectorToArray(v))
+
+	actual, err = ArrayToTSVector([]string{"fat", "cat", "fat", "Rat"})
+	require.NoError(t, err)
+	assert.Equal(t, `'Rat' 'cat' 'fat'`, actual.String())
+	_, err = ArrayToTSVector([]string{"fat", ""})
+	assert.Error(t, err)
+}
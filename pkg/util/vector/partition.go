@@ -0,0 +1,147 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package vector
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// Vector indexes use sign random projection, a locality-sensitive hashing
+// scheme: the vector space is divided into partitions by random hyperplanes
+// through the origin. Unlike the lists of an IVF index, the partitions are not
+// trained on the indexed vectors, so they are not adapted to the distribution
+// of the data, but the partition of a vector can be computed from the vector
+// alone.
+
+// MaxPartitions is the maximum number of partitions of a vector index.
+const MaxPartitions = 32768
+
+// DefaultPartitions is the number of partitions of a vector index if the
+// partitions storage parameter is not specified.
+const DefaultPartitions = 100
+
+// PartitionBits returns the number of random hyperplanes that divide the vector
+// space into the given number of partitions. The actual number of partitions
+// is 2^PartitionBits(partitions), which is partitions rounded up to the next
+// power of two.
+func PartitionBits(partitions int) int {
+	if partitions <= 1 {
+		return 0
+	}
+	return bits.Len(uint(partitions - 1))
+}
+
+// Partition returns the partition of v in a vector index with the given number
+// of partitions. The vector space is divided by PartitionBits(partitions)
+// hyperplanes that pass through the origin, and bit j of the partition is set
+// if v lies on the positive side of hyperplane j. Vectors separated by a small
+// angle are therefore likely to be in the same partition.
+//
+// The hyperplanes are derived from a fixed hash of the dimension index, so the
+// partition of a vector never changes and can be stored in an index.
+func Partition(v T, partitions int) int64 {
+	var p int64
+	for j, x := range project(v, PartitionBits(partitions)) {
+		if x > 0 {
+			p |= 1 << j
+		}
+	}
+	return p
+}
+
+// ProbePartitions returns the partitions of a vector index with the given
+// number of partitions that are searched for the nearest neighbors of the
+// query vector q, in ascending order. At most probes partitions are returned,
+// and at least one.
+//
+// The partitions are ranked by the sum of the distances between q and the
+// hyperplanes that separate the partition from the partition of q, so the
+// partition of q itself is always searched first. Searching more partitions
+// increases the recall of the search at the cost of latency.
+func ProbePartitions(q T, partitions int, probes int) []int64 {
+	n := PartitionBits(partitions)
+	numPartitions := 1 << n
+	if probes >= numPartitions {
+		res := make([]int64, numPartitions)
+		for i := range res {
+			res[i] = int64(i)
+		}
+		return res
+	}
+	if probes < 1 {
+		probes = 1
+	}
+
+	proj := project(q, n)
+	var qp int
+	for j, x := range proj {
+		if x > 0 {
+			qp |= 1 << j
+		}
+	}
+	type candidate struct {
+		partition int
+		cost      float64
+	}
+	candidates := make([]candidate, numPartitions)
+	for p := range candidates {
+		var cost float64
+		for j, diff := 0, p^qp; diff != 0; j, diff = j+1, diff>>1 {
+			if diff&1 != 0 {
+				cost += math.Abs(proj[j])
+			}
+		}
+		candidates[p] = candidate{partition: p, cost: cost}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].cost != candidates[j].cost {
+			return candidates[i].cost < candidates[j].cost
+		}
+		return candidates[i].partition < candidates[j].partition
+	})
+
+	res := make([]int64, probes)
+	for i := range res {
+		res[i] = int64(candidates[i].partition)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+// project returns the projections of v onto the normals of the first n
+// partitioning hyperplanes. Each component of a normal is either 1 or -1, so
+// all normals have the same length and the projections are proportional to
+// the distances between v and the hyperplanes.
+func project(v T, n int) []float64 {
+	proj := make([]float64, n)
+	if n == 0 {
+		return proj
+	}
+	for i, x := range v {
+		signs := hyperplaneSigns(i)
+		for j := range proj {
+			if signs&(1<<j) != 0 {
+				proj[j] += float64(x)
+			} else {
+				proj[j] -= float64(x)
+			}
+		}
+	}
+	return proj
+}
+
+// hyperplaneSigns returns the signs of the components of the hyperplane
+// normals in dimension i: bit j is set if the component of normal j is
+// positive. It uses the splitmix64 finalizer, which must never change since
+// partitions are persisted in vector indexes.
+func hyperplaneSigns(i int) uint64 {
+	z := uint64(i) + 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
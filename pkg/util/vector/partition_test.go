@@ -0,0 +1,160 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package vector
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionBits(t *testing.T) {
+	testCases := []struct {
+		partitions int
+		expected   int
+	}{
+		{partitions: 1, expected: 0},
+		{partitions: 2, expected: 1},
+		{partitions: 3, expected: 2},
+		{partitions: 4, expected: 2},
+		{partitions: 100, expected: 7},
+		{partitions: 128, expected: 7},
+		{partitions: MaxPartitions, expected: 15},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, PartitionBits(tc.partitions), "partitions=%d", tc.partitions)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	rng, _ := randutil.NewTestRand()
+	const partitions = 100
+	for i := 0; i < 100; i++ {
+		v := randomVector(rng, 1+rng.Intn(32))
+		p := Partition(v, partitions)
+		require.GreaterOrEqual(t, p, int64(0))
+		require.Less(t, p, int64(1<<PartitionBits(partitions)))
+
+		// The partition is deterministic and only depends on the direction of
+		// the vector.
+		require.Equal(t, p, Partition(v, partitions))
+		scaled := make(T, len(v))
+		for j := range v {
+			scaled[j] = v[j] * 4
+		}
+		require.Equal(t, p, Partition(scaled, partitions))
+	}
+	require.Equal(t, int64(0), Partition(T{1, 2, 3}, 1))
+	require.Equal(t, int64(0), Partition(T{0, 0, 0}, partitions))
+}
+
+func TestProbePartitions(t *testing.T) {
+	rng, _ := randutil.NewTestRand()
+	const partitions = 16
+	for i := 0; i < 100; i++ {
+		q := randomVector(rng, 1+rng.Intn(32))
+		probes := 1 + rng.Intn(partitions)
+		res := ProbePartitions(q, partitions, probes)
+		require.Len(t, res, probes)
+		require.True(t, sort.SliceIsSorted(res, func(i, j int) bool { return res[i] < res[j] }))
+		// The partition of the query vector is always searched.
+		require.Contains(t, res, Partition(q, partitions))
+		for j := 1; j < len(res); j++ {
+			require.NotEqual(t, res[j-1], res[j])
+		}
+	}
+
+	// All partitions are searched if probes is at least the number of
+	// partitions.
+	require.Equal(t, []int64{0, 1, 2, 3}, ProbePartitions(T{1, 2}, 3, 4))
+	require.Equal(t, []int64{0, 1, 2, 3}, ProbePartitions(T{1, 2}, 4, 10))
+	require.Equal(t, []int64{0}, ProbePartitions(T{1, 2}, 1, 1))
+
+	// At least the partition of the query vector is searched.
+	require.Equal(t, []int64{Partition(T{1, 2}, 4)}, ProbePartitions(T{1, 2}, 4, 0))
+	require.Equal(t, []int64{Partition(T{1, 2}, 4)}, ProbePartitions(T{1, 2}, 4, -1))
+}
+
+// TestProbePartitionsRecall checks the fraction of the exact nearest neighbors
+// of query vectors which are found in the searched partitions, for vectors
+// drawn from clusters.
+func TestProbePartitionsRecall(t *testing.T) {
+	rng, _ := randutil.NewTestRand()
+	const dims, numVectors, numQueries, k, partitions, numClusters = 16, 2000, 50, 10, 16, 20
+
+	clusters := make([]T, numClusters)
+	for i := range clusters {
+		clusters[i] = randomVector(rng, dims)
+	}
+	clusteredVector := func() T {
+		c := clusters[rng.Intn(numClusters)]
+		v := make(T, dims)
+		for i := range v {
+			v[i] = c[i] + float32(rng.NormFloat64()*0.2)
+		}
+		return v
+	}
+	vectors := make([]T, numVectors)
+	vectorPartitions := make([]int64, numVectors)
+	for i := range vectors {
+		vectors[i] = clusteredVector()
+		vectorPartitions[i] = Partition(vectors[i], partitions)
+	}
+	queries := make([]T, numQueries)
+	nearest := make([][]int, numQueries)
+	for i := range queries {
+		queries[i] = clusteredVector()
+		nearest[i] = make([]int, numVectors)
+		for j := range nearest[i] {
+			nearest[i][j] = j
+		}
+		dist := func(j int) float64 {
+			d, err := L2Distance(queries[i], vectors[nearest[i][j]])
+			require.NoError(t, err)
+			return d
+		}
+		sort.Slice(nearest[i], func(a, b int) bool { return dist(a) < dist(b) })
+		nearest[i] = nearest[i][:k]
+	}
+
+	recall := func(probes int) float64 {
+		var found int
+		for i, q := range queries {
+			searched := make(map[int64]bool)
+			for _, p := range ProbePartitions(q, partitions, probes) {
+				searched[p] = true
+			}
+			for _, j := range nearest[i] {
+				if searched[vectorPartitions[j]] {
+					found++
+				}
+			}
+		}
+		return float64(found) / float64(numQueries*k)
+	}
+
+	// Searching more partitions never lowers the recall, since the searched
+	// partitions are a superset of the partitions searched with fewer probes.
+	prev := 0.0
+	for probes := 1; probes <= partitions; probes++ {
+		r := recall(probes)
+		require.GreaterOrEqual(t, r, prev, "probes=%d", probes)
+		prev = r
+	}
+	require.Equal(t, 1.0, recall(partitions))
+	require.GreaterOrEqual(t, recall(partitions/4), 0.9)
+}
+
+func randomVector(rng *rand.Rand, dims int) T {
+	v := make(T, dims)
+	for i := range v {
+		v[i] = float32(rng.NormFloat64())
+	}
+	return v
+}
@@ -433,6 +433,12 @@ func DatumToGoSQL(d tree.Datum) (interface{}, error) {
 		return d.String(), nil
 	case *tree.DPGVector:
 		return d.String(), nil
+	case *tree.DMACAddr:
+		return d.MACAddr.String(), nil
+	case *tree.DMACAddr8:
+		return d.MACAddr8.String(), nil
+	case *tree.DMoney:
+		return d.String(), nil
 	}
 	return nil, errors.Errorf("unhandled datum type: %s", reflect.TypeOf(d))
 }